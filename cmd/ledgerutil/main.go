@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/core/ledger/ledgerutil"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	verifyCommand  = "verify"
+	compareCommand = "compare"
+)
+
+var (
+	app = kingpin.New("ledgerutil", "Offline verification and comparison of the ledgers of stopped peers")
+
+	verify        = app.Command(verifyCommand, "Verify the block hash chain, the data hashes and the validation flags of a ledger")
+	verifyFSPath  = verify.Flag("fs-path", "File system path (peer.fileSystemPath) of the stopped peer").Required().String()
+	verifyChannel = verify.Flag("channel", "Name of the channel").Required().String()
+
+	compare         = app.Command(compareCommand, "Find the first block at which the ledgers of two stopped peers diverge")
+	compareFSPath1  = compare.Flag("fs-path1", "File system path (peer.fileSystemPath) of the first stopped peer").Required().String()
+	compareFSPath2  = compare.Flag("fs-path2", "File system path (peer.fileSystemPath) of the second stopped peer").Required().String()
+	compareChannel  = compare.Flag("channel", "Name of the channel").Required().String()
+	compareMaxDiffs = compare.Flag("max-state-diffs", "Maximum number of differing state keys to report, 0 reports all").Default("100").Int()
+)
+
+func main() {
+	command := kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	var consistent bool
+	var err error
+	switch command {
+	case verify.FullCommand():
+		consistent, err = runVerify(os.Stdout, *verifyFSPath, *verifyChannel)
+	case compare.FullCommand():
+		consistent, err = runCompare(os.Stdout, *compareFSPath1, *compareFSPath2, *compareChannel, *compareMaxDiffs)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(2)
+	}
+	if !consistent {
+		os.Exit(1)
+	}
+}
+
+func runVerify(w io.Writer, fsPath, channel string) (bool, error) {
+	result, err := ledgerutil.VerifyLedger(fsPath, channel)
+	if err != nil {
+		return false, err
+	}
+	fmt.Fprintf(w, "Verified blocks [%d] to [%d] of channel [%s]\n", result.FirstBlockNum, result.LastBlockNum, channel)
+	if result.FirstCommitHashBlock != nil {
+		fmt.Fprintf(w, "Commit hashes present from block [%d]\n", *result.FirstCommitHashBlock)
+	}
+	if result.StateSavepoint != nil {
+		fmt.Fprintf(w, "Verified state database at savepoint (%d,%d)\n", result.StateSavepoint.BlockNum, result.StateSavepoint.TxNum)
+	}
+	if len(result.Problems) == 0 {
+		fmt.Fprintln(w, "No problem found")
+		return true, nil
+	}
+	fmt.Fprintf(w, "Found %d problem(s):\n", len(result.Problems))
+	for _, p := range result.Problems {
+		fmt.Fprintln(w, "  "+p.String())
+	}
+	return false, nil
+}
+
+func runCompare(w io.Writer, fsPath1, fsPath2, channel string, maxStateDiffs int) (bool, error) {
+	result, err := ledgerutil.CompareLedgers(fsPath1, fsPath2, channel, maxStateDiffs)
+	if err != nil {
+		return false, err
+	}
+	fmt.Fprintf(w, "Compared blocks [%d] to [%d] of channel [%s], last block on first peer [%d], on second peer [%d]\n",
+		result.FirstBlockNum, result.LastBlockNum, channel, result.LastBlockNum1, result.LastBlockNum2)
+
+	consistent := true
+	if d := result.Divergence; d != nil {
+		consistent = false
+		fmt.Fprintf(w, "Ledgers diverge at block [%d]\n", d.BlockNum)
+		fmt.Fprintf(w, "  block hash:  first=[%x] second=[%x]\n", d.BlockHash1, d.BlockHash2)
+		fmt.Fprintf(w, "  commit hash: first=[%x] second=[%x]\n", d.CommitHash1, d.CommitHash2)
+		if len(d.TxsWithDifferentFlags) > 0 {
+			fmt.Fprintf(w, "  transactions with different validation flags: %v\n", d.TxsWithDifferentFlags)
+		}
+		fmt.Fprintf(w, "  %d key(s) written differently by the block:\n", len(d.KeyDiffs))
+		for _, kd := range d.KeyDiffs {
+			fmt.Fprintln(w, "    "+kd.String())
+		}
+	} else {
+		fmt.Fprintln(w, "No divergence found in the common blocks")
+	}
+
+	if !result.StateCompared {
+		fmt.Fprintln(w, "State databases not compared as these are missing or at different savepoints")
+		return consistent, nil
+	}
+	if len(result.StateDiffs) == 0 {
+		fmt.Fprintln(w, "State databases match")
+		return consistent, nil
+	}
+	fmt.Fprintf(w, "%d differing state key(s):\n", len(result.StateDiffs))
+	for _, kd := range result.StateDiffs {
+		fmt.Fprintln(w, "  "+kd.String())
+	}
+	if result.StateDiffsTruncated {
+		fmt.Fprintln(w, "  ... (truncated, use --max-state-diffs to report more)")
+	}
+	return false, nil
+}
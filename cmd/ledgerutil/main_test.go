@@ -0,0 +1,49 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+func TestLedgerNotFound(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	ledgerutil, err := Build("github.com/hyperledger/fabric/cmd/ledgerutil")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer CleanupBuildArtifacts()
+
+	fsPath, err := ioutil.TempDir("", "ledgerutil")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(fsPath)
+
+	cmd := exec.Command(ledgerutil, "verify", "--fs-path", fsPath, "--channel", "mychannel")
+	process, err := Start(cmd, nil, nil)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Eventually(process, 5*time.Second).Should(Exit(2))
+	gt.Expect(process.Err).To(gbytes.Say("error accessing the block files of ledger \\[mychannel\\]"))
+}
+
+func TestMissingChannel(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	ledgerutil, err := Build("github.com/hyperledger/fabric/cmd/ledgerutil")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer CleanupBuildArtifacts()
+
+	cmd := exec.Command(ledgerutil, "compare", "--fs-path1", "peer1", "--fs-path2", "peer2")
+	process, err := Start(cmd, nil, nil)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Eventually(process, 5*time.Second).Should(Exit(1))
+	gt.Expect(process.Err).To(gbytes.Say("required flag --channel not provided"))
+}
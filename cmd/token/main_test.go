@@ -0,0 +1,31 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+func TestMissingTokenConfig(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	token, err := Build("github.com/hyperledger/fabric/cmd/token")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer CleanupBuildArtifacts()
+
+	// the token config flag is missing
+	cmd := exec.Command(token, "list")
+	process, err := Start(cmd, nil, nil)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Eventually(process, 5*time.Second).Should(Exit(1))
+	gt.Expect(process.Err).To(gbytes.Say("no token config specified"))
+}
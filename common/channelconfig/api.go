@@ -52,6 +52,9 @@ type Application interface {
 
 	// Capabilities defines the capabilities for the application portion of a channel
 	Capabilities() ApplicationCapabilities
+
+	// TokenSupplyCaps returns a map of token type to the maximum supply of that type
+	TokenSupplyCaps() map[string]uint64
 }
 
 // Channel gives read only access to the channel configuration
@@ -19,12 +19,16 @@ const (
 
 	// ACLsKey is the name of the ACLs config
 	ACLsKey = "ACLs"
+
+	// TokenSupplyCapsKey is the name of the token supply caps config
+	TokenSupplyCapsKey = "TokenSupplyCaps"
 )
 
 // ApplicationProtos is used as the source of the ApplicationConfig
 type ApplicationProtos struct {
-	ACLs         *pb.ACLs
-	Capabilities *cb.Capabilities
+	ACLs            *pb.ACLs
+	Capabilities    *cb.Capabilities
+	TokenSupplyCaps *pb.TokenSupplyCaps
 }
 
 // ApplicationConfig implements the Application interface
@@ -77,3 +81,8 @@ func (ac *ApplicationConfig) APIPolicyMapper() PolicyMapper {
 
 	return pm
 }
+
+// TokenSupplyCaps returns a map of token type to the maximum supply of that type
+func (ac *ApplicationConfig) TokenSupplyCaps() map[string]uint64 {
+	return ac.protos.TokenSupplyCaps.MaxSupplies
+}
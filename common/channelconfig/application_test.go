@@ -52,3 +52,28 @@ func TestACL(t *testing.T) {
 		g.Expect(err).To(MatchError("ACLs may not be specified without the required capability"))
 	})
 }
+
+func TestTokenSupplyCaps(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	t.Run("Success", func(t *testing.T) {
+		cg := &cb.ConfigGroup{
+			Values: map[string]*cb.ConfigValue{
+				TokenSupplyCapsKey: {
+					Value: utils.MarshalOrPanic(
+						TokenSupplyCapsValue(map[string]uint64{"TOK1": 100}).Value(),
+					),
+				},
+			},
+		}
+		ac, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ac.TokenSupplyCaps()).To(Equal(map[string]uint64{"TOK1": 100}))
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		ac, err := NewApplicationConfig(&cb.ConfigGroup{}, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ac.TokenSupplyCaps()).To(BeEmpty())
+	})
+}
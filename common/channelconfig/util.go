@@ -233,3 +233,14 @@ func ACLValues(acls map[string]string) *StandardConfigValue {
 		value: a,
 	}
 }
+
+// TokenSupplyCapsValue returns the config definition for the maximum supplies of the token types.
+// It is a value for the /Channel/Application/.
+func TokenSupplyCapsValue(maxSupplies map[string]uint64) *StandardConfigValue {
+	return &StandardConfigValue{
+		key: TokenSupplyCapsKey,
+		value: &pb.TokenSupplyCaps{
+			MaxSupplies: maxSupplies,
+		},
+	}
+}
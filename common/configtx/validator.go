@@ -67,7 +67,7 @@ func validateConfigID(configID string) error {
 	return nil
 }
 
-// validateChannelID makes sure that proposed channel IDs comply with the
+// ValidateChannelID makes sure that proposed channel IDs comply with the
 // following restrictions:
 //      1. Contain only lower case ASCII alphanumerics, dots '.', and dashes '-'
 //      2. Are shorter than 250 characters.
@@ -77,7 +77,7 @@ func validateConfigID(configID string) error {
 // with the following exception: '.' is converted to '_' in the CouchDB naming
 // This is to accomodate existing channel names with '.', especially in the
 // behave tests which rely on the dot notation for their sluggification.
-func validateChannelID(channelID string) error {
+func ValidateChannelID(channelID string) error {
 	re, _ := regexp.Compile(channelAllowedChars)
 	// Length
 	if len(channelID) <= 0 {
@@ -106,7 +106,7 @@ func NewValidatorImpl(channelID string, config *cb.Config, namespace string, pm
 		return nil, errors.Errorf("nil channel group")
 	}
 
-	if err := validateChannelID(channelID); err != nil {
+	if err := ValidateChannelID(channelID); err != nil {
 		return nil, errors.Errorf("bad channel ID: %s", err)
 	}
 
@@ -324,6 +324,11 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 			// Iterator has set the block and status vars
 		}
 
+		if status == cb.Status_GONE {
+			logger.Warningf("[channel: %s] Rejecting deliver request for %s because the requested block has been pruned", chdr.ChannelId, addr)
+			return status, nil
+		}
+
 		if status != cb.Status_SUCCESS {
 			logger.Errorf("[channel: %s] Error reading from channel, cause was: %v", chdr.ChannelId, status)
 			return status, nil
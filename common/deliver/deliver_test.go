@@ -934,6 +934,22 @@ var _ = Describe("Deliver", func() {
 			})
 		})
 
+		Context("when the requested blocks have been pruned", func() {
+			BeforeEach(func() {
+				fakeBlockReader.IteratorReturns(&blockledger.PrunedErrorIterator{}, 0)
+			})
+
+			It("sends status gone", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+				Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+				resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+				Expect(resp).To(Equal(cb.Status_GONE))
+			})
+		})
+
 		Context("when next block status does not indicate success", func() {
 			BeforeEach(func() {
 				fakeBlockIterator.NextReturns(nil, cb.Status_UNKNOWN)
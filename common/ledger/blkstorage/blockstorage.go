@@ -7,7 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package blkstorage
 
 import (
+	"fmt"
+
 	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
 	l "github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
@@ -40,12 +43,29 @@ var (
 	ErrAttrNotIndexed = errors.New("attribute not indexed")
 )
 
+// ErrBlockPruned is used to indicate that the requested block, or the block that
+// contains the requested transaction, has been removed from the block store by pruning
+type ErrBlockPruned struct {
+	LowWaterMark uint64
+	Msg          string
+}
+
+func (e *ErrBlockPruned) Error() string {
+	return fmt.Sprintf("%s: block has been pruned, the lowest available block is [%d]", e.Msg, e.LowWaterMark)
+}
+
 // BlockStoreProvider provides an handle to a BlockStore
 type BlockStoreProvider interface {
 	CreateBlockStore(ledgerid string) (BlockStore, error)
 	OpenBlockStore(ledgerid string) (BlockStore, error)
+	// BootstrapFromSnapshot creates a block store for the given ledgerid that contains only the last block
+	// of a snapshot. The blocks lower than the last block are treated as pruned, however, the txids present
+	// in the snapshot dir are retained for detecting duplicate txids during validation
+	BootstrapFromSnapshot(ledgerid, snapshotDir string, lastBlock *common.Block) (BlockStore, error)
 	Exists(ledgerid string) (bool, error)
 	List() ([]string, error)
+	// Remove removes the block store of the given ledgerid and all of its blocks
+	Remove(ledgerid string) error
 	Close()
 }
 
@@ -62,5 +82,14 @@ type BlockStore interface {
 	RetrieveTxByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error)
 	RetrieveBlockByTxID(txID string) (*common.Block, error)
 	RetrieveTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	// Prune removes the blocks with a block number lower than retainFromBlockNum.
+	// An implementation may retain more blocks than requested (e.g., if it can only
+	// remove blocks in chunks), but never removes a block at or above retainFromBlockNum.
+	// The lowest block number retained is exposed as BlockchainInfo.LowWaterMark.
+	// The block preservedBlockNum remains retrievable by number even if it is pruned
+	Prune(retainFromBlockNum, preservedBlockNum uint64) error
+	// ExportTxIds writes the txids, along with their validation codes, in a file in the specified dir
+	// and returns a map that contains the name of the file and its hash
+	ExportTxIds(dir string, newHashFunc snapshot.NewHashFunc) (map[string][]byte, error)
 	Shutdown()
 }
@@ -0,0 +1,73 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"os"
+
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// BlockFilesReader reads the blocks of a ledger sequentially, directly from the block files.
+// Unlike the block store, the reader neither opens the block index nor modifies any file and
+// hence, it can be used by the offline tools for inspecting the ledger of a stopped peer.
+// The reader starts from the first block file present, which may not contain the genesis block
+// if the block store has been pruned or bootstrapped from a snapshot
+type BlockFilesReader struct {
+	ledgerDir string
+	stream    *blockStream
+}
+
+// NewBlockFilesReader constructs a BlockFilesReader for the ledger `ledgerID` in the block storage
+// dir `blockStorageDir`. A nil reader is returned if the ledger does not contain any block file
+func NewBlockFilesReader(blockStorageDir, ledgerID string) (*BlockFilesReader, error) {
+	ledgerDir := (&Conf{blockStorageDir: blockStorageDir}).getLedgerBlockDir(ledgerID)
+	if _, err := os.Stat(ledgerDir); err != nil {
+		return nil, errors.Wrapf(err, "error accessing the block files of ledger [%s]", ledgerID)
+	}
+	firstFileNum, err := retrieveFirstFileSuffix(ledgerDir)
+	if err != nil {
+		return nil, err
+	}
+	if firstFileNum == -1 {
+		return nil, nil
+	}
+	lastFileNum, err := retrieveLastFileSuffix(ledgerDir)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := newBlockStream(ledgerDir, firstFileNum, 0, lastFileNum)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockFilesReader{ledgerDir: ledgerDir, stream: stream}, nil
+}
+
+// Next returns the next block present in the block files. A nil block is returned
+// after the last block. A partially written last block results in an error
+func (r *BlockFilesReader) Next() (*common.Block, error) {
+	blockBytes, placementInfo, err := r.stream.nextBlockBytesAndPlacementInfo()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error reading the next block from the block files")
+	}
+	if blockBytes == nil {
+		return nil, nil
+	}
+	block, err := deserializeBlock(blockBytes)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error deserializing block at "+placementInfo.String())
+	}
+	return block, nil
+}
+
+// Close closes the block file that is currently open
+func (r *BlockFilesReader) Close() {
+	if err := r.stream.close(); err != nil {
+		logger.Warningf("Error closing the block files of [%s]: %s", r.ledgerDir, err)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockFilesReader(t *testing.T) {
+	blockStoreRootDir := "/tmp/testBlockFilesReader"
+	assert.NoError(t, os.RemoveAll(blockStoreRootDir))
+	blocks := testutil.ConstructTestBlocks(t, 20)
+	env := newTestEnv(t, NewConf(blockStoreRootDir, 0))
+	defer env.Cleanup()
+	provider := env.provider
+	store, err := provider.CreateBlockStore("ledger1")
+	assert.NoError(t, err)
+	for i, b := range blocks {
+		assert.NoError(t, store.AddBlock(b))
+		if i != 0 && i%4 == 0 {
+			store.(*fsBlockStore).fileMgr.moveToNextFile()
+		}
+	}
+	store.Shutdown()
+	provider.Close()
+
+	t.Run("all-blocks", func(t *testing.T) {
+		r, err := NewBlockFilesReader(blockStoreRootDir, "ledger1")
+		assert.NoError(t, err)
+		defer r.Close()
+		for _, expectedBlock := range blocks {
+			block, err := r.Next()
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(expectedBlock, block), "block [%d] does not match", expectedBlock.Header.Number)
+		}
+		block, err := r.Next()
+		assert.NoError(t, err)
+		assert.Nil(t, block)
+	})
+
+	t.Run("first-file-removed", func(t *testing.T) {
+		ledgerDir := (&Conf{blockStorageDir: blockStoreRootDir}).getLedgerBlockDir("ledger1")
+		assert.NoError(t, os.Remove(deriveBlockfilePath(ledgerDir, 0)))
+		r, err := NewBlockFilesReader(blockStoreRootDir, "ledger1")
+		assert.NoError(t, err)
+		defer r.Close()
+		block, err := r.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(5), block.Header.Number)
+	})
+
+	t.Run("no-block-files", func(t *testing.T) {
+		ledgerDir := (&Conf{blockStorageDir: blockStoreRootDir}).getLedgerBlockDir("ledger2")
+		assert.NoError(t, os.MkdirAll(ledgerDir, 0755))
+		r, err := NewBlockFilesReader(blockStoreRootDir, "ledger2")
+		assert.NoError(t, err)
+		assert.Nil(t, r)
+	})
+
+	t.Run("missing-ledger", func(t *testing.T) {
+		_, err := NewBlockFilesReader(blockStoreRootDir, "non-existent-ledger")
+		assert.Contains(t, err.Error(), "error accessing the block files of ledger [non-existent-ledger]")
+	})
+}
@@ -7,6 +7,7 @@ package fsblkstorage
 
 import (
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
 	ledgerutil "github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -193,3 +194,59 @@ func extractMetadata(buf *ledgerutil.Buffer) (*common.BlockMetadata, error) {
 	}
 	return metadata, nil
 }
+
+// compressionType identifies the codec used for compressing a block in the block file
+type compressionType byte
+
+const (
+	noCompression     compressionType = 0
+	snappyCompression compressionType = 1
+)
+
+// compressedBlockMarker is the first byte of the record of a compressed block in a block file.
+// The record of an uncompressed block starts with the varint encoded length of the block bytes,
+// which is never zero and hence, the block files written without compression remain readable
+const compressedBlockMarker = 0x00
+
+// encodeBlockRecord returns the header and the (possibly compressed) block bytes that make up the record
+// of a block in a block file. The record of an uncompressed block is the varint encoded length followed by the
+// block bytes. The record of a compressed block is the `compressedBlockMarker`, the compression type and the
+// varint encoded length followed by the compressed bytes. A block is stored uncompressed if compressing it
+// does not make the record smaller
+func encodeBlockRecord(c compressionType, blockBytes []byte) (header []byte, recordBytes []byte, compressed bool, err error) {
+	header = proto.EncodeVarint(uint64(len(blockBytes)))
+	if c == noCompression {
+		return header, blockBytes, false, nil
+	}
+	compressedBytes, err := compressBlockBytes(c, blockBytes)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	compressedHeader := append([]byte{compressedBlockMarker, byte(c)}, proto.EncodeVarint(uint64(len(compressedBytes)))...)
+	if len(compressedHeader)+len(compressedBytes) >= len(header)+len(blockBytes) {
+		return header, blockBytes, false, nil
+	}
+	return compressedHeader, compressedBytes, true, nil
+}
+
+func compressBlockBytes(c compressionType, blockBytes []byte) ([]byte, error) {
+	switch c {
+	case snappyCompression:
+		return snappy.Encode(nil, blockBytes), nil
+	default:
+		return nil, errors.Errorf("unknown compression type [%d]", c)
+	}
+}
+
+func decompressBlockBytes(c compressionType, compressedBytes []byte) ([]byte, error) {
+	switch c {
+	case snappyCompression:
+		blockBytes, err := snappy.Decode(nil, compressedBytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "error decompressing the block bytes")
+		}
+		return blockBytes, nil
+	default:
+		return nil, errors.Errorf("unknown compression type [%d]", c)
+	}
+}
@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package fsblkstorage
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -122,3 +123,37 @@ func (c *testutilTxIDComputator) computeExpectedTxID(txNum int, txEnvBytes []byt
 func (c *testutilTxIDComputator) reset() {
 	c.malformedTxNums = map[int]struct{}{}
 }
+
+func TestEncodeBlockRecord(t *testing.T) {
+	compressibleBytes := bytes.Repeat([]byte("block-bytes"), 100)
+	randomBytes := testutil.ConstructRandomBytes(t, 1000)
+
+	header, recordBytes, compressed, err := encodeBlockRecord(noCompression, compressibleBytes)
+	assert.NoError(t, err)
+	assert.False(t, compressed)
+	assert.Equal(t, proto.EncodeVarint(uint64(len(compressibleBytes))), header)
+	assert.Equal(t, compressibleBytes, recordBytes)
+
+	header, recordBytes, compressed, err = encodeBlockRecord(snappyCompression, compressibleBytes)
+	assert.NoError(t, err)
+	assert.True(t, compressed)
+	assert.Equal(t, append([]byte{compressedBlockMarker, byte(snappyCompression)}, proto.EncodeVarint(uint64(len(recordBytes)))...), header)
+	assert.True(t, len(recordBytes) < len(compressibleBytes))
+	decompressedBytes, err := decompressBlockBytes(snappyCompression, recordBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, compressibleBytes, decompressedBytes)
+
+	// the block is stored uncompressed if the compression does not save any space
+	header, recordBytes, compressed, err = encodeBlockRecord(snappyCompression, randomBytes)
+	assert.NoError(t, err)
+	assert.False(t, compressed)
+	assert.Equal(t, proto.EncodeVarint(uint64(len(randomBytes))), header)
+	assert.Equal(t, randomBytes, recordBytes)
+
+	_, _, _, err = encodeBlockRecord(compressionType(10), compressibleBytes)
+	assert.EqualError(t, err, "unknown compression type [10]")
+	_, err = decompressBlockBytes(compressionType(10), compressibleBytes)
+	assert.EqualError(t, err, "unknown compression type [10]")
+	_, err = decompressBlockBytes(snappyCompression, []byte("not-snappy-compressed"))
+	assert.Contains(t, err.Error(), "error decompressing the block bytes")
+}
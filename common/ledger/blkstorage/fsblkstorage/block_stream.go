@@ -41,11 +41,13 @@ type blockStream struct {
 }
 
 // blockPlacementInfo captures the information related
-// to block's placement in the file.
+// to block's placement in the file. For a compressed block,
+// the blockBytesOffset is the offset of the compressed bytes
 type blockPlacementInfo struct {
 	fileNum          int
 	blockStartOffset int64
 	blockBytesOffset int64
+	compressed       bool
 }
 
 ///////////////////////////////////
@@ -94,9 +96,10 @@ func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacem
 		return nil, nil, nil
 	}
 	remainingBytes := fileInfo.Size() - s.currentOffset
-	// Peek 8 or smaller number of bytes (if remaining bytes are less than 8)
-	// Assumption is that a block size would be small enough to be represented in 8 bytes varint
-	peekBytes := 8
+	// Peek 10 or smaller number of bytes (if remaining bytes are less than 10)
+	// Assumption is that a block size would be small enough to be represented in 8 bytes varint,
+	// which is preceded by two more bytes (the marker and the compression type) for a compressed block
+	peekBytes := 10
 	if remainingBytes < int64(peekBytes) {
 		peekBytes = int(remainingBytes)
 		moreContentAvailable = false
@@ -105,6 +108,16 @@ func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacem
 	if lenBytes, err = s.reader.Peek(peekBytes); err != nil {
 		return nil, nil, errors.Wrapf(err, "error peeking [%d] bytes from block file", peekBytes)
 	}
+	compression := noCompression
+	headerLen := 0
+	if lenBytes[0] == compressedBlockMarker {
+		if len(lenBytes) < 2 {
+			return nil, nil, ErrUnexpectedEndOfBlockfile
+		}
+		compression = compressionType(lenBytes[1])
+		lenBytes = lenBytes[2:]
+		headerLen = 2
+	}
 	length, n := proto.DecodeVarint(lenBytes)
 	if n == 0 {
 		// proto.DecodeVarint did not consume any byte at all which means that the bytes
@@ -114,26 +127,34 @@ func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacem
 		}
 		panic(errors.Errorf("Error in decoding varint bytes [%#v]", lenBytes))
 	}
-	bytesExpected := int64(n) + int64(length)
+	headerLen += n
+	bytesExpected := int64(headerLen) + int64(length)
 	if bytesExpected > remainingBytes {
 		logger.Debugf("At least [%d] bytes expected. Remaining bytes = [%d]. Returning with error [%s]",
 			bytesExpected, remainingBytes, ErrUnexpectedEndOfBlockfile)
 		return nil, nil, ErrUnexpectedEndOfBlockfile
 	}
-	// skip the bytes representing the block size
-	if _, err = s.reader.Discard(n); err != nil {
-		return nil, nil, errors.Wrapf(err, "error discarding [%d] bytes", n)
+	// skip the bytes representing the block size (and the compression of the block)
+	if _, err = s.reader.Discard(headerLen); err != nil {
+		return nil, nil, errors.Wrapf(err, "error discarding [%d] bytes", headerLen)
 	}
 	blockBytes := make([]byte, length)
 	if _, err = io.ReadAtLeast(s.reader, blockBytes, int(length)); err != nil {
 		logger.Errorf("Error reading [%d] bytes from file number [%d], error: %s", length, s.fileNum, err)
 		return nil, nil, errors.Wrapf(err, "error reading [%d] bytes from file number [%d]", length, s.fileNum)
 	}
+	if compression != noCompression {
+		if blockBytes, err = decompressBlockBytes(compression, blockBytes); err != nil {
+			return nil, nil, errors.WithMessage(err,
+				fmt.Sprintf("error reading the block at offset [%d] in file number [%d]", s.currentOffset, s.fileNum))
+		}
+	}
 	blockPlacementInfo := &blockPlacementInfo{
 		fileNum:          s.fileNum,
 		blockStartOffset: s.currentOffset,
-		blockBytesOffset: s.currentOffset + int64(n)}
-	s.currentOffset += int64(n) + int64(length)
+		blockBytesOffset: s.currentOffset + int64(headerLen),
+		compressed:       compression != noCompression}
+	s.currentOffset += int64(headerLen) + int64(length)
 	logger.Debugf("Returning blockbytes - length=[%d], placementInfo={%s}", len(blockBytes), blockPlacementInfo)
 	return blockBytes, blockPlacementInfo, nil
 }
@@ -194,6 +215,6 @@ func (s *blockStream) close() error {
 }
 
 func (i *blockPlacementInfo) String() string {
-	return fmt.Sprintf("fileNum=[%d], startOffset=[%d], bytesOffset=[%d]",
-		i.fileNum, i.blockStartOffset, i.blockBytesOffset)
+	return fmt.Sprintf("fileNum=[%d], startOffset=[%d], bytesOffset=[%d], compressed=[%t]",
+		i.fileNum, i.blockStartOffset, i.blockBytesOffset, i.compressed)
 }
@@ -72,6 +72,44 @@ func TestBlockFileStreamUnexpectedEOF(t *testing.T) {
 	testBlockFileStreamUnexpectedEOF(t, 10, partialBlockBytes[:20])
 }
 
+func TestBlockFileStreamUnexpectedEOFCompressedBlock(t *testing.T) {
+	partialBlockBytes := []byte{compressedBlockMarker, byte(snappyCompression)}
+	dummyBlockBytes := testutil.ConstructRandomBytes(t, 100)
+	lenBytes := proto.EncodeVarint(uint64(len(dummyBlockBytes)))
+	partialBlockBytes = append(partialBlockBytes, lenBytes...)
+	partialBlockBytes = append(partialBlockBytes, dummyBlockBytes...)
+	testBlockFileStreamUnexpectedEOF(t, 10, partialBlockBytes[:1])
+	testBlockFileStreamUnexpectedEOF(t, 10, partialBlockBytes[:2])
+	testBlockFileStreamUnexpectedEOF(t, 10, partialBlockBytes[:3])
+	testBlockFileStreamUnexpectedEOF(t, 10, partialBlockBytes[:20])
+}
+
+func TestBlockfileStreamCompressedBlocks(t *testing.T) {
+	conf, err := NewConfWithCompression(testPath(), 0, "snappy")
+	assert.NoError(t, err)
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	blocks := constructCompressibleTestBlocks(t, 5)
+	w.addBlocks(blocks)
+	w.close()
+
+	s, err := newBlockfileStream(w.blockfileMgr.rootDir, 0, 0)
+	assert.NoError(t, err)
+	defer s.close()
+	for _, block := range blocks {
+		blockBytes, placementInfo, err := s.nextBlockBytesAndPlacementInfo()
+		assert.NoError(t, err)
+		assert.True(t, placementInfo.compressed)
+		expectedBlockBytes, _, err := serializeBlock(block)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBlockBytes, blockBytes)
+	}
+	blockBytes, err := s.nextBlockBytes()
+	assert.NoError(t, err)
+	assert.Nil(t, blockBytes)
+}
+
 func testBlockFileStreamUnexpectedEOF(t *testing.T, numBlocks int, partialBlockBytes []byte) {
 	env := newTestEnv(t, NewConf(testPath(), 0))
 	defer env.Cleanup()
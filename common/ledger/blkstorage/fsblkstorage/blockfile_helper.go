@@ -86,7 +86,11 @@ func binarySearchFileNumForBlock(rootDir string, blockNum uint64) (int, error) {
 		return -1, err
 	}
 
-	beginFile := 0
+	// the files lower than the first file present may have been removed by pruning
+	beginFile, err := retrieveFirstFileSuffix(rootDir)
+	if err != nil {
+		return -1, err
+	}
 	endFile := cpInfo.latestFileChunkSuffixNum
 
 	for endFile != beginFile {
@@ -124,6 +128,32 @@ func retriveFirstBlockNumFromFile(rootDir string, fileNum int) (uint64, error) {
 	return blockInfo.blockHeader.Number, nil
 }
 
+// retrieveFirstFileSuffix returns the lowest suffix among the block files present in the rootDir.
+// This is non-zero if the block store has been pruned. A -1 is returned if there is no block file.
+func retrieveFirstFileSuffix(rootDir string) (int, error) {
+	logger.Debugf("retrieveFirstFileSuffix()")
+	smallestFileNum := -1
+	filesInfo, err := ioutil.ReadDir(rootDir)
+	if err != nil {
+		return -1, errors.Wrapf(err, "error reading dir %s", rootDir)
+	}
+	for _, fileInfo := range filesInfo {
+		name := fileInfo.Name()
+		if fileInfo.IsDir() || !isBlockFileName(name) {
+			continue
+		}
+		fileNum, err := strconv.Atoi(strings.TrimPrefix(name, blockfilePrefix))
+		if err != nil {
+			return -1, err
+		}
+		if smallestFileNum == -1 || fileNum < smallestFileNum {
+			smallestFileNum = fileNum
+		}
+	}
+	logger.Debugf("retrieveFirstFileSuffix() - smallestFileNum = %d", smallestFileNum)
+	return smallestFileNum, nil
+}
+
 func retrieveLastFileSuffix(rootDir string) (int, error) {
 	logger.Debugf("retrieveLastFileSuffix()")
 	biggestFileNum := -1
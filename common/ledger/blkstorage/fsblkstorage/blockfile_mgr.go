@@ -44,6 +44,10 @@ type blockfileMgr struct {
 	cpInfoCond        *sync.Cond
 	currentFileWriter *blockfileWriter
 	bcInfo            atomic.Value
+	bcInfoLock        sync.Mutex
+	pruneInfo         atomic.Value
+	pruneLock         sync.Mutex
+	stats             *ledgerStats
 }
 
 /*
@@ -95,6 +99,10 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 	if err != nil {
 		panic(fmt.Sprintf("Error creating block storage root dir [%s]: %s", rootDir, err))
 	}
+	// Complete or discard the bootstrap from a snapshot, if it was interrupted by a crash
+	if err := recoverInterruptedBootstrap(rootDir, indexStore); err != nil {
+		panic(fmt.Sprintf("Could not recover the interrupted bootstrap from a snapshot: %s", err))
+	}
 	// Instantiate the manager, i.e. blockFileMgr structure
 	mgr := &blockfileMgr{rootDir: rootDir, conf: conf, db: indexStore}
 
@@ -120,6 +128,17 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 		panic(fmt.Sprintf("Could not save next block file info to db: %s", err))
 	}
 
+	// Load the outcome of the previous pruning (if any) and remove the blockfiles that
+	// were left over because of a crash during pruning
+	pruneInfo, err := mgr.loadPruneInfo()
+	if err != nil {
+		panic(fmt.Sprintf("Could not get prune info from db: %s", err))
+	}
+	if err = removePrunedBlockfiles(rootDir, 0, pruneInfo.firstFileSuffixNum); err != nil {
+		panic(fmt.Sprintf("Could not remove pruned block files: %s", err))
+	}
+	mgr.pruneInfo.Store(pruneInfo)
+
 	//Open a writer to the file identified by the number and truncate it to only contain the latest block
 	// that was completely saved (file system, index, cpinfo, etc)
 	currentFileWriter, err := newBlockfileWriter(deriveBlockfilePath(rootDir, cpInfo.latestFileChunkSuffixNum))
@@ -162,7 +181,8 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 		bcInfo = &common.BlockchainInfo{
 			Height:            cpInfo.lastBlockNumber + 1,
 			CurrentBlockHash:  lastBlockHash,
-			PreviousBlockHash: previousBlockHash}
+			PreviousBlockHash: previousBlockHash,
+			LowWaterMark:      pruneInfo.firstBlockNum}
 	}
 	mgr.bcInfo.Store(bcInfo)
 	return mgr
@@ -265,9 +285,11 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	txOffsets := info.txOffsets
 	currentOffset := mgr.cpInfo.latestFileChunksize
 
-	blockBytesLen := len(blockBytes)
-	blockBytesEncodedLen := proto.EncodeVarint(uint64(blockBytesLen))
-	totalBytesToAppend := blockBytesLen + len(blockBytesEncodedLen)
+	recordHeader, recordBytes, compressed, err := encodeBlockRecord(mgr.conf.compression, blockBytes)
+	if err != nil {
+		return errors.WithMessage(err, "error compressing block")
+	}
+	totalBytesToAppend := len(recordHeader) + len(recordBytes)
 
 	//Determine if we need to start a new file since the size of this block
 	//exceeds the amount of space left in the current file
@@ -275,11 +297,11 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 		mgr.moveToNextFile()
 		currentOffset = 0
 	}
-	//append blockBytesEncodedLen to the file
-	err = mgr.currentFileWriter.append(blockBytesEncodedLen, false)
+	//append the record header (the length of the block bytes) to the file
+	err = mgr.currentFileWriter.append(recordHeader, false)
 	if err == nil {
-		//append the actual block bytes to the file
-		err = mgr.currentFileWriter.append(blockBytes, true)
+		//append the actual (possibly compressed) block bytes to the file
+		err = mgr.currentFileWriter.append(recordBytes, true)
 	}
 	if err != nil {
 		truncateErr := mgr.currentFileWriter.truncateFile(mgr.cpInfo.latestFileChunksize)
@@ -308,16 +330,23 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	//Index block file location pointer updated with file suffex and offset for the new block
 	blockFLP := &fileLocPointer{fileSuffixNum: newCPInfo.latestFileChunkSuffixNum}
 	blockFLP.offset = currentOffset
-	// shift the txoffset because we prepend length of bytes before block bytes
-	for _, txOffset := range txOffsets {
-		txOffset.loc.offset += len(blockBytesEncodedLen)
+	// shift the txoffset because we prepend length of bytes before block bytes.
+	// The txoffsets of a compressed block remain relative to the decompressed block bytes
+	if !compressed {
+		for _, txOffset := range txOffsets {
+			txOffset.loc.offset += len(recordHeader)
+		}
 	}
 	//save the index in the database
 	if err = mgr.index.indexBlock(&blockIdxInfo{
 		blockNum: block.Header.Number, blockHash: blockHash,
-		flp: blockFLP, txOffsets: txOffsets, metadata: block.Metadata}); err != nil {
+		flp: blockFLP, txOffsets: txOffsets, metadata: block.Metadata, compressed: compressed}); err != nil {
 		return err
 	}
+	if compressed && mgr.stats != nil {
+		uncompressedRecordLen := len(proto.EncodeVarint(uint64(len(blockBytes)))) + len(blockBytes)
+		mgr.stats.updateCompressionBytesSaved(uncompressedRecordLen - totalBytesToAppend)
+	}
 
 	//update the checkpoint info (for storage) and the blockchain info (for APIs) in the manager
 	mgr.updateCheckpoint(newCPInfo)
@@ -337,13 +366,14 @@ func (mgr *blockfileMgr) syncIndex() error {
 		indexEmpty = true
 	}
 
-	//initialize index to file number:zero, offset:zero and blockNum:0
-	startFileNum := 0
+	//initialize index to the first file that is not pruned (file number:zero, blockNum:0 if never pruned) and offset:zero
+	pruneInfo := mgr.getPruneInfo()
+	startFileNum := pruneInfo.firstFileSuffixNum
 	startOffset := 0
 	skipFirstBlock := false
 	//get the last file that blocks were added to using the checkpoint info
 	endFileNum := mgr.cpInfo.latestFileChunkSuffixNum
-	startingBlockNum := uint64(0)
+	startingBlockNum := pruneInfo.firstBlockNum
 
 	//if the index stored in the db has value, update the index information with those values
 	if !indexEmpty {
@@ -401,10 +431,13 @@ func (mgr *blockfileMgr) syncIndex() error {
 		}
 
 		//The blockStartOffset will get applied to the txOffsets prior to indexing within indexBlock(),
-		//therefore just shift by the difference between blockBytesOffset and blockStartOffset
-		numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
-		for _, offset := range info.txOffsets {
-			offset.loc.offset += numBytesToShift
+		//therefore just shift by the difference between blockBytesOffset and blockStartOffset.
+		//The txOffsets of a compressed block remain relative to the decompressed block bytes
+		if !blockPlacementInfo.compressed {
+			numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
+			for _, offset := range info.txOffsets {
+				offset.loc.offset += numBytesToShift
+			}
 		}
 
 		//Update the blockIndexInfo with what was actually stored in file system
@@ -414,6 +447,7 @@ func (mgr *blockfileMgr) syncIndex() error {
 			locPointer: locPointer{offset: int(blockPlacementInfo.blockStartOffset)}}
 		blockIdxInfo.txOffsets = info.txOffsets
 		blockIdxInfo.metadata = info.metadata
+		blockIdxInfo.compressed = blockPlacementInfo.compressed
 
 		logger.Debugf("syncIndex() indexing block [%d]", blockIdxInfo.blockNum)
 		if err = mgr.index.indexBlock(blockIdxInfo); err != nil {
@@ -440,11 +474,14 @@ func (mgr *blockfileMgr) updateCheckpoint(cpInfo *checkpointInfo) {
 }
 
 func (mgr *blockfileMgr) updateBlockchainInfo(latestBlockHash []byte, latestBlock *common.Block) {
+	mgr.bcInfoLock.Lock()
+	defer mgr.bcInfoLock.Unlock()
 	currentBCInfo := mgr.getBlockchainInfo()
 	newBCInfo := &common.BlockchainInfo{
 		Height:            currentBCInfo.Height + 1,
 		CurrentBlockHash:  latestBlockHash,
-		PreviousBlockHash: latestBlock.Header.PreviousHash}
+		PreviousBlockHash: latestBlock.Header.PreviousHash,
+		LowWaterMark:      currentBCInfo.LowWaterMark}
 
 	mgr.bcInfo.Store(newBCInfo)
 }
@@ -465,6 +502,13 @@ func (mgr *blockfileMgr) retrieveBlockByNumber(blockNum uint64) (*common.Block,
 	if blockNum == math.MaxUint64 {
 		blockNum = mgr.getBlockchainInfo().Height - 1
 	}
+	if mgr.isBlockPruned(blockNum) {
+		block, err := mgr.retrievePreservedBlock(blockNum)
+		if err != nil || block != nil {
+			return block, err
+		}
+		return nil, mgr.errBlockPruned("error retrieving block [%d]", blockNum)
+	}
 
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
 	if err != nil {
@@ -507,6 +551,9 @@ func (mgr *blockfileMgr) retrieveBlockHeaderByNumber(blockNum uint64) (*common.B
 }
 
 func (mgr *blockfileMgr) retrieveBlocks(startNum uint64) (*blocksItr, error) {
+	if mgr.isBlockPruned(startNum) {
+		return nil, mgr.errBlockPruned("error retrieving blocks starting from block [%d]", startNum)
+	}
 	return newBlockItr(mgr, startNum), nil
 }
 
@@ -521,6 +568,9 @@ func (mgr *blockfileMgr) retrieveTransactionByID(txID string) (*common.Envelope,
 
 func (mgr *blockfileMgr) retrieveTransactionByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error) {
 	logger.Debugf("retrieveTransactionByBlockNumTranNum() - blockNum = [%d], tranNum = [%d]", blockNum, tranNum)
+	if mgr.isBlockPruned(blockNum) {
+		return nil, mgr.errBlockPruned("error retrieving transaction [%d] of block [%d]", tranNum, blockNum)
+	}
 	loc, err := mgr.index.getTXLocByBlockNumTranNum(blockNum, tranNum)
 	if err != nil {
 		return nil, err
@@ -544,7 +594,12 @@ func (mgr *blockfileMgr) fetchTransactionEnvelope(lp *fileLocPointer) (*common.E
 	logger.Debugf("Entering fetchTransactionEnvelope() %v\n", lp)
 	var err error
 	var txEnvelopeBytes []byte
-	if txEnvelopeBytes, err = mgr.fetchRawBytes(lp); err != nil {
+	if lp.isInCompressedBlock() {
+		txEnvelopeBytes, err = mgr.fetchTxBytesFromCompressedBlock(lp)
+	} else {
+		txEnvelopeBytes, err = mgr.fetchRawBytes(lp)
+	}
+	if err != nil {
 		return nil, err
 	}
 	_, n := proto.DecodeVarint(txEnvelopeBytes)
@@ -552,6 +607,9 @@ func (mgr *blockfileMgr) fetchTransactionEnvelope(lp *fileLocPointer) (*common.E
 }
 
 func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
+	if mgr.isFilePruned(lp.fileSuffixNum) {
+		return nil, mgr.errBlockPruned("error fetching block from block file [%d]", lp.fileSuffixNum)
+	}
 	stream, err := newBlockfileStream(mgr.rootDir, lp.fileSuffixNum, int64(lp.offset))
 	if err != nil {
 		return nil, err
@@ -565,6 +623,9 @@ func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
 }
 
 func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
+	if mgr.isFilePruned(lp.fileSuffixNum) {
+		return nil, mgr.errBlockPruned("error fetching transaction from block file [%d]", lp.fileSuffixNum)
+	}
 	filePath := deriveBlockfilePath(mgr.rootDir, lp.fileSuffixNum)
 	reader, err := newBlockfileReader(filePath)
 	if err != nil {
@@ -578,6 +639,20 @@ func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
 	return b, nil
 }
 
+// fetchTxBytesFromCompressedBlock reads and decompresses the block that contains the transaction and
+// returns the bytes of the transaction, in the same form as returned by fetchRawBytes for an uncompressed block
+func (mgr *blockfileMgr) fetchTxBytesFromCompressedBlock(lp *fileLocPointer) ([]byte, error) {
+	blockBytes, err := mgr.fetchBlockBytes(lp)
+	if err != nil {
+		return nil, err
+	}
+	txEnd := lp.txOffsetInBlock + lp.bytesLength
+	if txEnd > len(blockBytes) {
+		return nil, errors.Errorf("transaction location [%s] is beyond the block bytes of length [%d]", lp, len(blockBytes))
+	}
+	return blockBytes[lp.txOffsetInBlock:txEnd], nil
+}
+
 //Get the current checkpoint information that is stored in the database
 func (mgr *blockfileMgr) loadCurrentInfo() (*checkpointInfo, error) {
 	var b []byte
@@ -17,6 +17,7 @@ limitations under the License.
 package fsblkstorage
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -426,3 +427,114 @@ func testutilGetFileSize(t *testing.T, path string) int {
 	assert.NoError(t, err)
 	return int(fi.Size())
 }
+
+func TestNewConfWithCompression(t *testing.T) {
+	conf, err := NewConfWithCompression("/tmp/blockstorage", 0, "")
+	assert.NoError(t, err)
+	assert.Equal(t, &Conf{"/tmp/blockstorage", defaultMaxBlockfileSize, noCompression}, conf)
+	conf, err = NewConfWithCompression("/tmp/blockstorage", 1024, "none")
+	assert.NoError(t, err)
+	assert.Equal(t, &Conf{"/tmp/blockstorage", 1024, noCompression}, conf)
+	conf, err = NewConfWithCompression("/tmp/blockstorage", 1024, "snappy")
+	assert.NoError(t, err)
+	assert.Equal(t, &Conf{"/tmp/blockstorage", 1024, snappyCompression}, conf)
+	_, err = NewConfWithCompression("/tmp/blockstorage", 1024, "zip")
+	assert.EqualError(t, err, "unsupported block compression [zip], supported values are [none] and [snappy]")
+}
+
+func TestBlockfileMgrCompression(t *testing.T) {
+	conf, err := NewConfWithCompression(testPath(), 0, "snappy")
+	assert.NoError(t, err)
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+	ledgerid := "testLedger"
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	blocks := constructCompressibleTestBlocks(t, 10)
+	blkfileMgrWrapper.addBlocks(blocks)
+
+	// the records of all the blocks should be compressed in the block file
+	for i := range blocks {
+		loc, err := blkfileMgrWrapper.blockfileMgr.index.getBlockLocByBlockNum(uint64(i))
+		assert.NoError(t, err)
+		b, err := blkfileMgrWrapper.blockfileMgr.fetchRawBytes(&fileLocPointer{fileSuffixNum: loc.fileSuffixNum,
+			locPointer: locPointer{offset: loc.offset, bytesLength: 2}})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{compressedBlockMarker, byte(snappyCompression)}, b)
+	}
+	testBlockfileMgrReadCompressedBlocks(t, blkfileMgrWrapper, blocks)
+
+	// the compressed blocks should be readable after a restart as well
+	blkfileMgrWrapper.close()
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+	defer blkfileMgrWrapper.close()
+	testBlockfileMgrReadCompressedBlocks(t, blkfileMgrWrapper, blocks)
+}
+
+func TestBlockfileMgrCompressionEnabledOnExistingBlockfiles(t *testing.T) {
+	ledgerid := "testLedger"
+	blocks := constructCompressibleTestBlocks(t, 10)
+	blockStorageDir := testPath()
+
+	// add the first few blocks without compression
+	env := newTestEnv(t, NewConf(blockStorageDir, 0))
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	blkfileMgrWrapper.addBlocks(blocks[:5])
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	// add the remaining blocks with compression
+	conf, err := NewConfWithCompression(blockStorageDir, 0, "snappy")
+	assert.NoError(t, err)
+	env = newTestEnv(t, conf)
+	defer env.Cleanup()
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+	defer blkfileMgrWrapper.close()
+	blkfileMgrWrapper.addBlocks(blocks[5:])
+	testBlockfileMgrReadCompressedBlocks(t, blkfileMgrWrapper, blocks)
+
+	// rebuild the index from the block files
+	for i := range blocks {
+		assert.NoError(t, blkfileMgrWrapper.blockfileMgr.db.Delete(constructBlockNumKey(uint64(i)), true))
+	}
+	assert.NoError(t, blkfileMgrWrapper.blockfileMgr.db.Delete(indexCheckpointKey, true))
+	assert.NoError(t, blkfileMgrWrapper.blockfileMgr.syncIndex())
+	testBlockfileMgrReadCompressedBlocks(t, blkfileMgrWrapper, blocks)
+}
+
+func testBlockfileMgrReadCompressedBlocks(t *testing.T, blkfileMgrWrapper *testBlockfileMgrWrapper, blocks []*common.Block) {
+	blkfileMgrWrapper.testGetBlockByHash(blocks, nil)
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 0, nil)
+	blkfileMgrWrapper.testGetBlockByTxID(blocks, nil)
+	testBlockfileMgrBlockIterator(t, blkfileMgrWrapper.blockfileMgr, 0, len(blocks)-1, blocks)
+	for blockNum, blk := range blocks {
+		for tranNum, txEnvelopeBytes := range blk.Data.Data {
+			txEnvelope, err := putil.GetEnvelopeFromBlock(txEnvelopeBytes)
+			assert.NoError(t, err)
+			txID, err := putil.GetOrComputeTxIDFromEnvelope(txEnvelopeBytes)
+			assert.NoError(t, err)
+			txEnvelopeFromFileMgr, err := blkfileMgrWrapper.blockfileMgr.retrieveTransactionByID(txID)
+			assert.NoError(t, err)
+			assert.Equal(t, txEnvelope, txEnvelopeFromFileMgr)
+			txEnvelopeFromFileMgr, err = blkfileMgrWrapper.blockfileMgr.retrieveTransactionByBlockNumTranNum(uint64(blockNum), uint64(tranNum))
+			assert.NoError(t, err)
+			assert.Equal(t, txEnvelope, txEnvelopeFromFileMgr)
+		}
+	}
+}
+
+// constructCompressibleTestBlocks constructs blocks, starting from block number 0, with the transactions
+// that carry repetitive simulation results so that each block shrinks when compressed
+func constructCompressibleTestBlocks(t *testing.T, numBlocks int) []*common.Block {
+	var blocks []*common.Block
+	var previousHash []byte
+	for i := 0; i < numBlocks; i++ {
+		simulationResults := [][]byte{}
+		for j := 0; j < 3; j++ {
+			simulationResults = append(simulationResults, bytes.Repeat([]byte(fmt.Sprintf("block-%d-tx-%d", i, j)), 100))
+		}
+		block := testutil.ConstructBlock(t, uint64(i), previousHash, simulationResults, false)
+		previousHash = block.Header.Hash()
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
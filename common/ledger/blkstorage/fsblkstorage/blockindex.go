@@ -46,11 +46,12 @@ type index interface {
 }
 
 type blockIdxInfo struct {
-	blockNum  uint64
-	blockHash []byte
-	flp       *fileLocPointer
-	txOffsets []*txindexInfo
-	metadata  *common.BlockMetadata
+	blockNum   uint64
+	blockHash  []byte
+	flp        *fileLocPointer
+	txOffsets  []*txindexInfo
+	metadata   *common.BlockMetadata
+	compressed bool
 }
 
 type blockIndex struct {
@@ -126,7 +127,7 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 				continue
 			}
 
-			txFlp := newFileLocationPointer(flp.fileSuffixNum, flp.offset, txoffset.loc)
+			txFlp := newTxLocationPointer(flp, blockIdxInfo.compressed, txoffset.loc)
 			logger.Debugf("Adding txLoc [%s] for tx ID: [%s] to txid-index", txFlp, txoffset.txID)
 			txFlpBytes, marshalErr := txFlp.marshal()
 			if marshalErr != nil {
@@ -139,7 +140,7 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 	//Index4 - Store BlockNumTranNum will be used to query history data
 	if index.isAttributeIndexed(blkstorage.IndexableAttrBlockNumTranNum) {
 		for txIterator, txoffset := range txOffsets {
-			txFlp := newFileLocationPointer(flp.fileSuffixNum, flp.offset, txoffset.loc)
+			txFlp := newTxLocationPointer(flp, blockIdxInfo.compressed, txoffset.loc)
 			logger.Debugf("Adding txLoc [%s] for tx number:[%d] ID: [%s] to blockNumTranNum index", txFlp, txIterator, txoffset.txID)
 			txFlpBytes, marshalErr := txFlp.marshal()
 			if marshalErr != nil {
@@ -355,6 +356,10 @@ func (lp *locPointer) String() string {
 type fileLocPointer struct {
 	fileSuffixNum int
 	locPointer
+	// txOffsetInBlock is set only for a transaction in a compressed block. As the transaction can be
+	// read only after decompressing the block, the locPointer points to the block and txOffsetInBlock
+	// is the offset of the transaction in the decompressed block bytes
+	txOffsetInBlock int
 }
 
 func newFileLocationPointer(fileSuffixNum int, beginningOffset int, relativeLP *locPointer) *fileLocPointer {
@@ -364,6 +369,25 @@ func newFileLocationPointer(fileSuffixNum int, beginningOffset int, relativeLP *
 	return flp
 }
 
+// newTxLocationPointer returns the location of a transaction, given the location of the block and the relative
+// location of the transaction in the block bytes
+func newTxLocationPointer(blockFLP *fileLocPointer, blockCompressed bool, relativeLP *locPointer) *fileLocPointer {
+	if !blockCompressed {
+		return newFileLocationPointer(blockFLP.fileSuffixNum, blockFLP.offset, relativeLP)
+	}
+	return &fileLocPointer{
+		fileSuffixNum:   blockFLP.fileSuffixNum,
+		locPointer:      locPointer{offset: blockFLP.offset, bytesLength: relativeLP.bytesLength},
+		txOffsetInBlock: relativeLP.offset,
+	}
+}
+
+// isInCompressedBlock returns true if the pointer points to a transaction in a compressed block.
+// A transaction never starts at the beginning of the block bytes, as these begin with the block header
+func (flp *fileLocPointer) isInCompressedBlock() bool {
+	return flp.txOffsetInBlock > 0
+}
+
 func (flp *fileLocPointer) marshal() ([]byte, error) {
 	buffer := proto.NewBuffer([]byte{})
 	e := buffer.EncodeVarint(uint64(flp.fileSuffixNum))
@@ -378,11 +402,18 @@ func (flp *fileLocPointer) marshal() ([]byte, error) {
 	if e != nil {
 		return nil, e
 	}
+	// the offset in the block is appended only for a transaction in a compressed block, which
+	// keeps the pointers written by the previous versions (with three varints) decodable
+	if flp.isInCompressedBlock() {
+		if e = buffer.EncodeVarint(uint64(flp.txOffsetInBlock)); e != nil {
+			return nil, e
+		}
+	}
 	return buffer.Bytes(), nil
 }
 
 func (flp *fileLocPointer) unmarshal(b []byte) error {
-	buffer := proto.NewBuffer(b)
+	buffer := util.NewBuffer(b)
 	i, e := buffer.DecodeVarint()
 	if e != nil {
 		return e
@@ -399,10 +430,21 @@ func (flp *fileLocPointer) unmarshal(b []byte) error {
 		return e
 	}
 	flp.bytesLength = int(i)
+	if buffer.GetBytesConsumed() == len(b) {
+		return nil
+	}
+	i, e = buffer.DecodeVarint()
+	if e != nil {
+		return e
+	}
+	flp.txOffsetInBlock = int(i)
 	return nil
 }
 
 func (flp *fileLocPointer) String() string {
+	if flp.isInCompressedBlock() {
+		return fmt.Sprintf("fileSuffixNum=%d, %s, txOffsetInBlock=%d", flp.fileSuffixNum, flp.locPointer.String(), flp.txOffsetInBlock)
+	}
 	return fmt.Sprintf("fileSuffixNum=%d, %s", flp.fileSuffixNum, flp.locPointer.String())
 }
 
@@ -262,3 +262,28 @@ func containsAttr(indexItems []blkstorage.IndexableAttr, attr blkstorage.Indexab
 	}
 	return false
 }
+
+func TestFileLocPointerMarshal(t *testing.T) {
+	flp := &fileLocPointer{fileSuffixNum: 2, locPointer: locPointer{offset: 300, bytesLength: 50}}
+	b, err := flp.marshal()
+	assert.NoError(t, err)
+	// a pointer outside a compressed block is encoded as in the previous versions
+	assert.Equal(t, []byte{0x02, 0xac, 0x02, 0x32}, b)
+	unmarshaledFLP := &fileLocPointer{}
+	assert.NoError(t, unmarshaledFLP.unmarshal(b))
+	assert.Equal(t, flp, unmarshaledFLP)
+	assert.False(t, unmarshaledFLP.isInCompressedBlock())
+
+	txFLP := newTxLocationPointer(flp, true, &locPointer{offset: 70, bytesLength: 20})
+	assert.Equal(t, &fileLocPointer{fileSuffixNum: 2, locPointer: locPointer{offset: 300, bytesLength: 20}, txOffsetInBlock: 70}, txFLP)
+	b, err = txFLP.marshal()
+	assert.NoError(t, err)
+	unmarshaledFLP = &fileLocPointer{}
+	assert.NoError(t, unmarshaledFLP.unmarshal(b))
+	assert.Equal(t, txFLP, unmarshaledFLP)
+	assert.True(t, unmarshaledFLP.isInCompressedBlock())
+	assert.Equal(t, "fileSuffixNum=2, offset=300, bytesLength=20, txOffsetInBlock=70", unmarshaledFLP.String())
+
+	txFLP = newTxLocationPointer(flp, false, &locPointer{offset: 70, bytesLength: 20})
+	assert.Equal(t, &fileLocPointer{fileSuffixNum: 2, locPointer: locPointer{offset: 370, bytesLength: 20}}, txFLP)
+}
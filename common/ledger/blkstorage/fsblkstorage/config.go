@@ -16,7 +16,11 @@ limitations under the License.
 
 package fsblkstorage
 
-import "path/filepath"
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
 
 const (
 	// ChainsDir is the name of the directory containing the channel ledgers.
@@ -30,6 +34,7 @@ const (
 type Conf struct {
 	blockStorageDir  string
 	maxBlockfileSize int
+	compression      compressionType
 }
 
 // NewConf constructs new `Conf`.
@@ -38,7 +43,23 @@ func NewConf(blockStorageDir string, maxBlockfileSize int) *Conf {
 	if maxBlockfileSize <= 0 {
 		maxBlockfileSize = defaultMaxBlockfileSize
 	}
-	return &Conf{blockStorageDir, maxBlockfileSize}
+	return &Conf{blockStorageDir, maxBlockfileSize, noCompression}
+}
+
+// NewConfWithCompression constructs new `Conf` that compresses the blocks added to the block files
+// with the given compression - "none" or "snappy". The blocks already present in the block files
+// are readable irrespective of the compression they are written with
+func NewConfWithCompression(blockStorageDir string, maxBlockfileSize int, compression string) (*Conf, error) {
+	conf := NewConf(blockStorageDir, maxBlockfileSize)
+	switch compression {
+	case "", "none":
+		conf.compression = noCompression
+	case "snappy":
+		conf.compression = snappyCompression
+	default:
+		return nil, errors.Errorf("unsupported block compression [%s], supported values are [none] and [snappy]", compression)
+	}
+	return conf, nil
 }
 
 func (conf *Conf) getIndexDir() string {
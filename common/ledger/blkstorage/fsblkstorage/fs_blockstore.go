@@ -21,6 +21,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
@@ -43,6 +44,7 @@ func newFsBlockStore(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 	ledgerStats := stats.ledgerStats(id)
 	info := fileMgr.getBlockchainInfo()
 	ledgerStats.updateBlockchainHeight(info.Height)
+	fileMgr.stats = ledgerStats
 
 	return &fsBlockStore{id, conf, fileMgr, ledgerStats}
 }
@@ -102,6 +104,16 @@ func (store *fsBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return store.fileMgr.retrieveTxValidationCodeByTxID(txID)
 }
 
+// Prune removes the blockfiles that contain only blocks lower than the given block number
+func (store *fsBlockStore) Prune(retainFromBlockNum, preservedBlockNum uint64) error {
+	return store.fileMgr.prune(retainFromBlockNum, preservedBlockNum)
+}
+
+// ExportTxIds exports the txids and their validation codes in a file in the specified dir
+func (store *fsBlockStore) ExportTxIds(dir string, newHashFunc snapshot.NewHashFunc) (map[string][]byte, error) {
+	return store.fileMgr.exportTxIds(dir, newHashFunc)
+}
+
 // Shutdown shuts down the block store
 func (store *fsBlockStore) Shutdown() {
 	logger.Debugf("closing fs blockStore:%s", store.id)
@@ -17,10 +17,14 @@ limitations under the License.
 package fsblkstorage
 
 import (
+	"os"
+
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
 )
 
 // FsBlockstoreProvider provides handle to block storage - this is not thread-safe
@@ -52,6 +56,17 @@ func (p *FsBlockstoreProvider) OpenBlockStore(ledgerid string) (blkstorage.Block
 	return newFsBlockStore(ledgerid, p.conf, p.indexConfig, indexStoreHandle, p.stats), nil
 }
 
+// BootstrapFromSnapshot initializes the block store for the given ledgerid from a snapshot such that the
+// given last block of the snapshot is the only block present and the next block that can be added is
+// the block following the last block. The txids present in the snapshot are loaded in the txid index
+func (p *FsBlockstoreProvider) BootstrapFromSnapshot(ledgerid, snapshotDir string, lastBlock *common.Block) (blkstorage.BlockStore, error) {
+	indexStoreHandle := p.leveldbProvider.GetDBHandle(ledgerid)
+	if err := bootstrapFromSnapshot(ledgerid, p.conf, p.indexConfig, indexStoreHandle, snapshotDir, lastBlock); err != nil {
+		return nil, err
+	}
+	return newFsBlockStore(ledgerid, p.conf, p.indexConfig, indexStoreHandle, p.stats), nil
+}
+
 // Exists tells whether the BlockStore with given id exists
 func (p *FsBlockstoreProvider) Exists(ledgerid string) (bool, error) {
 	exists, _, err := util.FileExists(p.conf.getLedgerBlockDir(ledgerid))
@@ -63,6 +78,25 @@ func (p *FsBlockstoreProvider) List() ([]string, error) {
 	return util.ListSubdirs(p.conf.getChainsDir())
 }
 
+// Remove removes the block store of the given ledgerid, along with its index.
+// The block store should have been shut down before it is removed
+func (p *FsBlockstoreProvider) Remove(ledgerid string) error {
+	indexStoreHandle := p.leveldbProvider.GetDBHandle(ledgerid)
+	itr := indexStoreHandle.GetIterator(nil, nil)
+	defer itr.Release()
+	batch := leveldbhelper.NewUpdateBatch()
+	for itr.Next() {
+		batch.Delete(itr.Key())
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrapf(err, "error iterating over the index of ledger [%s]", ledgerid)
+	}
+	if err := indexStoreHandle.WriteBatch(batch, true); err != nil {
+		return errors.Wrapf(err, "error removing the index of ledger [%s]", ledgerid)
+	}
+	return errors.Wrapf(os.RemoveAll(p.conf.getLedgerBlockDir(ledgerid)), "error removing the blocks of ledger [%s]", ledgerid)
+}
+
 // Close closes the FsBlockstoreProvider
 func (p *FsBlockstoreProvider) Close() {
 	p.leveldbProvider.Close()
@@ -147,6 +147,47 @@ func TestBlockStoreProvider(t *testing.T) {
 
 }
 
+func TestRemove(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+
+	provider := env.provider
+	blocks := testutil.ConstructTestBlocks(t, 5)
+	for _, ledgerid := range []string{"ledger1", "ledger2"} {
+		store, err := provider.OpenBlockStore(ledgerid)
+		assert.NoError(t, err)
+		for _, b := range blocks {
+			assert.NoError(t, store.AddBlock(b))
+		}
+		store.Shutdown()
+	}
+
+	assert.NoError(t, provider.Remove("ledger1"))
+	exists, err := provider.Exists("ledger1")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	storeNames, err := provider.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ledger2"}, storeNames)
+
+	// a removed ledger is recreated empty, without any remains of its index
+	store1, err := provider.OpenBlockStore("ledger1")
+	assert.NoError(t, err)
+	defer store1.Shutdown()
+	bcInfo, err := store1.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), bcInfo.Height)
+	_, err = store1.RetrieveBlockByHash(blocks[0].Header.Hash())
+	assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+
+	store2, err := provider.OpenBlockStore("ledger2")
+	assert.NoError(t, err)
+	defer store2.Shutdown()
+	checkBlocks(t, blocks, store2)
+
+	assert.NoError(t, provider.Remove("non-existent-ledger"))
+}
+
 func constructLedgerid(id int) string {
 	return fmt.Sprintf("ledger_%d", id)
 }
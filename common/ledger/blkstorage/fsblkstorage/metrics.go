@@ -14,12 +14,14 @@ import (
 type stats struct {
 	blockchainHeight       metrics.Gauge
 	blockstorageCommitTime metrics.Histogram
+	compressionBytesSaved  metrics.Counter
 }
 
 func newStats(metricsProvider metrics.Provider) *stats {
 	stats := &stats{}
 	stats.blockchainHeight = metricsProvider.NewGauge(blockchainHeightOpts)
 	stats.blockstorageCommitTime = metricsProvider.NewHistogram(blockstorageCommitTimeOpts)
+	stats.compressionBytesSaved = metricsProvider.NewCounter(compressionBytesSavedOpts)
 	return stats
 }
 
@@ -45,6 +47,10 @@ func (s *ledgerStats) updateBlockstorageCommitTime(timeTaken time.Duration) {
 	s.stats.blockstorageCommitTime.With("channel", s.ledgerid).Observe(timeTaken.Seconds())
 }
 
+func (s *ledgerStats) updateCompressionBytesSaved(bytesSaved int) {
+	s.stats.compressionBytesSaved.With("channel", s.ledgerid).Add(float64(bytesSaved))
+}
+
 var (
 	blockchainHeightOpts = metrics.GaugeOpts{
 		Namespace:    "ledger",
@@ -64,4 +70,13 @@ var (
 		StatsdFormat: "%{#fqname}.%{channel}",
 		Buckets:      []float64{0.005, 0.01, 0.015, 0.05, 0.1, 1, 10},
 	}
+
+	compressionBytesSavedOpts = metrics.CounterOpts{
+		Namespace:    "ledger",
+		Subsystem:    "",
+		Name:         "blockstorage_compression_bytes_saved",
+		Help:         "Number of bytes saved in the block files by compressing the blocks.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
 )
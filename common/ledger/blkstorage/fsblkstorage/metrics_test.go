@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
@@ -115,16 +116,50 @@ func TestStatsBlockCommit(t *testing.T) {
 	)
 }
 
+func TestStatsCompressionBytesSaved(t *testing.T) {
+	testMetricProvider := testutilConstructMetricProvider()
+	conf, err := NewConfWithCompression(testPath(), 0, "snappy")
+	assert.NoError(t, err)
+	env := newTestEnvWithMetricsProvider(t, conf, testMetricProvider.fakeProvider)
+	defer env.Cleanup()
+
+	ledgerid := "ledger-stats"
+	store, err := env.provider.OpenBlockStore(ledgerid)
+	assert.NoError(t, err)
+	defer store.Shutdown()
+
+	blocks := constructCompressibleTestBlocks(t, 2)
+	for _, b := range blocks {
+		assert.NoError(t, store.AddBlock(b))
+	}
+
+	// should have a call to fakeCompressionBytesSavedCounter for each of the compressed blocks
+	fakeCompressionBytesSavedCounter := testMetricProvider.fakeCompressionBytesSavedCounter
+	assert.Equal(t, len(blocks), fakeCompressionBytesSavedCounter.AddCallCount())
+	for i, b := range blocks {
+		assert.Equal(t, []string{"channel", ledgerid}, fakeCompressionBytesSavedCounter.WithArgsForCall(i))
+		blockBytes, _, err := serializeBlock(b)
+		assert.NoError(t, err)
+		header, compressedBytes, compressed, err := encodeBlockRecord(snappyCompression, blockBytes)
+		assert.NoError(t, err)
+		assert.True(t, compressed)
+		expectedBytesSaved := len(proto.EncodeVarint(uint64(len(blockBytes)))) + len(blockBytes) - len(header) - len(compressedBytes)
+		assert.Equal(t, float64(expectedBytesSaved), fakeCompressionBytesSavedCounter.AddArgsForCall(i))
+	}
+}
+
 type testMetricProvider struct {
-	fakeProvider                   *metricsfakes.Provider
-	fakeBlockchainHeightGauge      *metricsfakes.Gauge
-	fakeBlockstorageCommitTimeHist *metricsfakes.Histogram
+	fakeProvider                     *metricsfakes.Provider
+	fakeBlockchainHeightGauge        *metricsfakes.Gauge
+	fakeBlockstorageCommitTimeHist   *metricsfakes.Histogram
+	fakeCompressionBytesSavedCounter *metricsfakes.Counter
 }
 
 func testutilConstructMetricProvider() *testMetricProvider {
 	fakeProvider := &metricsfakes.Provider{}
 	fakeBlockchainHeightGauge := testutilConstructGauge()
 	fakeBlockstorageCommitTimeHist := testutilConstructHist()
+	fakeCompressionBytesSavedCounter := testutilConstructCounter()
 	fakeProvider.NewGaugeStub = func(opts metrics.GaugeOpts) metrics.Gauge {
 		switch opts.Name {
 		case blockchainHeightOpts.Name:
@@ -142,10 +177,20 @@ func testutilConstructMetricProvider() *testMetricProvider {
 		}
 	}
 
+	fakeProvider.NewCounterStub = func(opts metrics.CounterOpts) metrics.Counter {
+		switch opts.Name {
+		case compressionBytesSavedOpts.Name:
+			return fakeCompressionBytesSavedCounter
+		default:
+			return nil
+		}
+	}
+
 	return &testMetricProvider{
 		fakeProvider,
 		fakeBlockchainHeightGauge,
 		fakeBlockstorageCommitTimeHist,
+		fakeCompressionBytesSavedCounter,
 	}
 }
 
@@ -164,3 +209,11 @@ func testutilConstructHist() *metricsfakes.Histogram {
 	}
 	return fakeHist
 }
+
+func testutilConstructCounter() *metricsfakes.Counter {
+	fakeCounter := &metricsfakes.Counter{}
+	fakeCounter.WithStub = func(lableValues ...string) metrics.Counter {
+		return fakeCounter
+	}
+	return fakeCounter
+}
@@ -0,0 +1,289 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+var (
+	blkMgrPruneInfoKey      = []byte("blkMgrPruneInfo")
+	blkMgrPreservedBlockKey = []byte("blkMgrPreservedBlock")
+)
+
+// pruneInfo records the outcome of the latest pruning of the block files.
+// The blockfiles with a suffix lower than firstFileSuffixNum have been removed
+// and firstBlockNum (i.e., the low-water mark) is the first block present in
+// the blockfile with suffix firstFileSuffixNum
+type pruneInfo struct {
+	firstFileSuffixNum int
+	firstBlockNum      uint64
+}
+
+// prune removes all the blockfiles that contain only blocks with a block number lower
+// than retainFromBlockNum, along with the block number keyed index entries of these blocks.
+// The blockfile that is currently being appended to is never removed.
+// The transaction-keyed index entries (txid, block by txid, validation code) of the pruned
+// blocks are retained as these are required for detecting duplicate txids during validation.
+// The block hash index entries are retained as well so that a lookup by hash reports the
+// block as pruned rather than unknown.
+// If the block preservedBlockNum falls in the pruned blockfiles, a copy of it is kept in the
+// db and it remains retrievable by number. Only the most recently preserved block is kept
+func (mgr *blockfileMgr) prune(retainFromBlockNum, preservedBlockNum uint64) error {
+	mgr.pruneLock.Lock()
+	defer mgr.pruneLock.Unlock()
+
+	bcInfo := mgr.getBlockchainInfo()
+	if bcInfo.Height == 0 {
+		return errors.New("cannot prune an empty block store")
+	}
+	if retainFromBlockNum >= bcInfo.Height {
+		return errors.Errorf("cannot prune block [%d] or higher, the last block in the block store is [%d]",
+			retainFromBlockNum, bcInfo.Height-1)
+	}
+	currentPruneInfo := mgr.getPruneInfo()
+	if retainFromBlockNum <= currentPruneInfo.firstBlockNum {
+		logger.Debugf("Blocks lower than [%d] are already pruned, nothing to prune", currentPruneInfo.firstBlockNum)
+		return nil
+	}
+
+	newPruneInfo, err := mgr.computePruneInfo(currentPruneInfo, retainFromBlockNum)
+	if err != nil {
+		return err
+	}
+	if newPruneInfo.firstFileSuffixNum == currentPruneInfo.firstFileSuffixNum {
+		logger.Infof("No blockfile contains only blocks lower than [%d], nothing to prune", retainFromBlockNum)
+		return nil
+	}
+
+	logger.Infof("Pruning blockfiles with suffixNum in the range [%d] to [%d] (blocks [%d] to [%d])",
+		currentPruneInfo.firstFileSuffixNum, newPruneInfo.firstFileSuffixNum-1,
+		currentPruneInfo.firstBlockNum, newPruneInfo.firstBlockNum-1)
+	batch := leveldbhelper.NewUpdateBatch()
+	if err := mgr.addPrunedIndexEntriesToBatch(batch, currentPruneInfo, newPruneInfo); err != nil {
+		return err
+	}
+	if err := mgr.addPreservedBlockToBatch(batch, preservedBlockNum, currentPruneInfo, newPruneInfo); err != nil {
+		return err
+	}
+	b, err := newPruneInfo.marshal()
+	if err != nil {
+		return err
+	}
+	batch.Put(blkMgrPruneInfoKey, b)
+	if err := mgr.db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+	mgr.updatePruneInfo(newPruneInfo)
+
+	// the prune info is persisted before removing the files. In the event of a crash
+	// in between, the left over files are removed when the block store is opened next time
+	return removePrunedBlockfiles(mgr.rootDir, currentPruneInfo.firstFileSuffixNum, newPruneInfo.firstFileSuffixNum)
+}
+
+// computePruneInfo finds the highest blockfile suffix such that all the blockfiles below this suffix
+// contain only blocks lower than retainFromBlockNum
+func (mgr *blockfileMgr) computePruneInfo(currentPruneInfo *pruneInfo, retainFromBlockNum uint64) (*pruneInfo, error) {
+	mgr.cpInfoCond.L.Lock()
+	currentFileNum := mgr.cpInfo.latestFileChunkSuffixNum
+	isCurrentFileEmpty := mgr.cpInfo.latestFileChunksize == 0
+	mgr.cpInfoCond.L.Unlock()
+
+	lastFileWithBlocks := currentFileNum
+	if isCurrentFileEmpty {
+		lastFileWithBlocks--
+	}
+
+	newPruneInfo := &pruneInfo{
+		firstFileSuffixNum: currentPruneInfo.firstFileSuffixNum,
+		firstBlockNum:      currentPruneInfo.firstBlockNum,
+	}
+	for fileNum := currentPruneInfo.firstFileSuffixNum + 1; fileNum <= lastFileWithBlocks; fileNum++ {
+		firstBlockNumInFile, err := retriveFirstBlockNumFromFile(mgr.rootDir, fileNum)
+		if err != nil {
+			return nil, err
+		}
+		if firstBlockNumInFile > retainFromBlockNum {
+			break
+		}
+		newPruneInfo.firstFileSuffixNum = fileNum
+		newPruneInfo.firstBlockNum = firstBlockNumInFile
+	}
+	return newPruneInfo, nil
+}
+
+// addPrunedIndexEntriesToBatch adds the deletion of block number and block number-tran number
+// index entries for all the blocks that are present in the blockfiles that are being pruned
+func (mgr *blockfileMgr) addPrunedIndexEntriesToBatch(batch *leveldbhelper.UpdateBatch,
+	currentPruneInfo, newPruneInfo *pruneInfo) error {
+	stream, err := newBlockStream(mgr.rootDir, currentPruneInfo.firstFileSuffixNum, 0, newPruneInfo.firstFileSuffixNum-1)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+	for {
+		blockBytes, err := stream.nextBlockBytes()
+		if err != nil {
+			return err
+		}
+		if blockBytes == nil {
+			return nil
+		}
+		info, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			return err
+		}
+		if mgr.index.isAttributeIndexed(blkstorage.IndexableAttrBlockNum) {
+			batch.Delete(constructBlockNumKey(info.blockHeader.Number))
+		}
+		if mgr.index.isAttributeIndexed(blkstorage.IndexableAttrBlockNumTranNum) {
+			for txIndex := range info.txOffsets {
+				batch.Delete(constructBlockNumTranNumKey(info.blockHeader.Number, uint64(txIndex)))
+			}
+		}
+	}
+}
+
+// addPreservedBlockToBatch adds a copy of the block preservedBlockNum to the batch if the block
+// is present in the blockfiles that are being pruned. A block that has been pruned earlier
+// cannot be preserved anymore; the previously preserved block, if any, is left in place in this case
+func (mgr *blockfileMgr) addPreservedBlockToBatch(batch *leveldbhelper.UpdateBatch, preservedBlockNum uint64,
+	currentPruneInfo, newPruneInfo *pruneInfo) error {
+	if preservedBlockNum >= newPruneInfo.firstBlockNum {
+		return nil
+	}
+	if preservedBlockNum < currentPruneInfo.firstBlockNum {
+		logger.Warningf("Block [%d] has already been pruned and cannot be preserved", preservedBlockNum)
+		return nil
+	}
+	loc, err := mgr.index.getBlockLocByBlockNum(preservedBlockNum)
+	if err != nil {
+		return err
+	}
+	blockBytes, err := mgr.fetchBlockBytes(loc)
+	if err != nil {
+		return err
+	}
+	logger.Infof("Preserving block [%d] that falls in the pruned blockfiles", preservedBlockNum)
+	batch.Put(blkMgrPreservedBlockKey, blockBytes)
+	return nil
+}
+
+// retrievePreservedBlock returns the block blockNum if it has been preserved during pruning, nil otherwise
+func (mgr *blockfileMgr) retrievePreservedBlock(blockNum uint64) (*common.Block, error) {
+	blockBytes, err := mgr.db.Get(blkMgrPreservedBlockKey)
+	if err != nil || blockBytes == nil {
+		return nil, err
+	}
+	block, err := deserializeBlock(blockBytes)
+	if err != nil {
+		return nil, err
+	}
+	if block.Header.Number != blockNum {
+		return nil, nil
+	}
+	return block, nil
+}
+
+// removePrunedBlockfiles removes the blockfiles with suffix in the range [startFileNum, endFileNum).
+// The files that are already removed are skipped
+func removePrunedBlockfiles(rootDir string, startFileNum, endFileNum int) error {
+	for fileNum := startFileNum; fileNum < endFileNum; fileNum++ {
+		filePath := deriveBlockfilePath(rootDir, fileNum)
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "error removing the block file [%s]", filePath)
+		}
+	}
+	return nil
+}
+
+// loadPruneInfo loads the prune info from the db. A zero value prune info is
+// returned if the block store has never been pruned
+func (mgr *blockfileMgr) loadPruneInfo() (*pruneInfo, error) {
+	b, err := mgr.db.Get(blkMgrPruneInfoKey)
+	if err != nil {
+		return nil, err
+	}
+	i := &pruneInfo{}
+	if b == nil {
+		return i, nil
+	}
+	if err = i.unmarshal(b); err != nil {
+		return nil, err
+	}
+	logger.Debugf("loaded pruneInfo:%s", i)
+	return i, nil
+}
+
+func (mgr *blockfileMgr) getPruneInfo() *pruneInfo {
+	return mgr.pruneInfo.Load().(*pruneInfo)
+}
+
+func (mgr *blockfileMgr) updatePruneInfo(i *pruneInfo) {
+	mgr.pruneInfo.Store(i)
+	mgr.bcInfoLock.Lock()
+	defer mgr.bcInfoLock.Unlock()
+	currentBCInfo := mgr.getBlockchainInfo()
+	mgr.bcInfo.Store(&common.BlockchainInfo{
+		Height:            currentBCInfo.Height,
+		CurrentBlockHash:  currentBCInfo.CurrentBlockHash,
+		PreviousBlockHash: currentBCInfo.PreviousBlockHash,
+		LowWaterMark:      i.firstBlockNum,
+	})
+}
+
+// isBlockPruned returns true if the given block number is lower than the low-water mark
+func (mgr *blockfileMgr) isBlockPruned(blockNum uint64) bool {
+	return blockNum < mgr.getPruneInfo().firstBlockNum
+}
+
+// isFilePruned returns true if the blockfile with the given suffix has been pruned
+func (mgr *blockfileMgr) isFilePruned(fileSuffixNum int) bool {
+	return fileSuffixNum < mgr.getPruneInfo().firstFileSuffixNum
+}
+
+func (mgr *blockfileMgr) errBlockPruned(format string, args ...interface{}) error {
+	return &blkstorage.ErrBlockPruned{
+		LowWaterMark: mgr.getPruneInfo().firstBlockNum,
+		Msg:          fmt.Sprintf(format, args...),
+	}
+}
+
+func (i *pruneInfo) marshal() ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	if err := buffer.EncodeVarint(uint64(i.firstFileSuffixNum)); err != nil {
+		return nil, errors.Wrapf(err, "error encoding the firstFileSuffixNum [%d]", i.firstFileSuffixNum)
+	}
+	if err := buffer.EncodeVarint(i.firstBlockNum); err != nil {
+		return nil, errors.Wrapf(err, "error encoding the firstBlockNum [%d]", i.firstBlockNum)
+	}
+	return buffer.Bytes(), nil
+}
+
+func (i *pruneInfo) unmarshal(b []byte) error {
+	buffer := proto.NewBuffer(b)
+	val, err := buffer.DecodeVarint()
+	if err != nil {
+		return err
+	}
+	i.firstFileSuffixNum = int(val)
+	if i.firstBlockNum, err = buffer.DecodeVarint(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *pruneInfo) String() string {
+	return fmt.Sprintf("firstFileSuffixNum=[%d], firstBlockNum=[%d]", i.firstFileSuffixNum, i.firstBlockNum)
+}
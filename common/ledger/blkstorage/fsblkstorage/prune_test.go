@@ -0,0 +1,179 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	putil "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneInfoMarshalUnmarshal(t *testing.T) {
+	i := &pruneInfo{firstFileSuffixNum: 5, firstBlockNum: 1024}
+	b, err := i.marshal()
+	assert.NoError(t, err)
+	unmarshalled := &pruneInfo{}
+	assert.NoError(t, unmarshalled.unmarshal(b))
+	assert.Equal(t, i, unmarshalled)
+}
+
+func TestBlockfileMgrPrune(t *testing.T) {
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	blocks := append([]*common.Block{gb}, bg.NextTestBlocks(59)...)
+	env := newTestEnv(t, NewConf(testPath(), maxFileSizeForBlocks(t, blocks[:10])))
+	defer env.Cleanup()
+	ledgerid := "testLedger"
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	blkfileMgrWrapper.addBlocks(blocks)
+	mgr := blkfileMgrWrapper.blockfileMgr
+	require.True(t, mgr.cpInfo.latestFileChunkSuffixNum >= 5)
+
+	firstBlockNumInFile2, err := retriveFirstBlockNumFromFile(mgr.rootDir, 2)
+	require.NoError(t, err)
+
+	// pruning retains the whole file that contains the block retainFromBlockNum
+	assert.NoError(t, mgr.prune(firstBlockNumInFile2+1, 1))
+	assert.Equal(t, &pruneInfo{firstFileSuffixNum: 2, firstBlockNum: firstBlockNumInFile2}, mgr.getPruneInfo())
+	assert.Equal(t, firstBlockNumInFile2, mgr.getBlockchainInfo().LowWaterMark)
+	assert.Equal(t, uint64(60), mgr.getBlockchainInfo().Height)
+	for fileNum := 0; fileNum < 2; fileNum++ {
+		exists, _, err := util.FileExists(deriveBlockfilePath(mgr.rootDir, fileNum))
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	}
+
+	prunedBlocks := append([]*common.Block{blocks[0]}, blocks[2:firstBlockNumInFile2]...)
+	assertBlocksPruned(t, mgr, prunedBlocks)
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[firstBlockNumInFile2:], firstBlockNumInFile2, nil)
+	blkfileMgrWrapper.testGetBlockByHash(blocks[firstBlockNumInFile2:], nil)
+	// the preserved block remains retrievable by number only
+	preservedBlock, err := mgr.retrieveBlockByNumber(1)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[1], preservedBlock)
+	_, err = mgr.retrieveBlockByHash(blocks[1].Header.Hash())
+	assert.IsType(t, &blkstorage.ErrBlockPruned{}, err)
+
+	// pruning to a lower block number is a no-op
+	assert.NoError(t, mgr.prune(1, 0))
+	assert.Equal(t, firstBlockNumInFile2, mgr.getBlockchainInfo().LowWaterMark)
+
+	// the last block cannot be pruned
+	assert.EqualError(t, mgr.prune(60, 0), "cannot prune block [60] or higher, the last block in the block store is [59]")
+
+	// the prune info and the low-water mark survive a restart and new blocks can be added
+	blkfileMgrWrapper.close()
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+	defer blkfileMgrWrapper.close()
+	mgr = blkfileMgrWrapper.blockfileMgr
+	assert.Equal(t, firstBlockNumInFile2, mgr.getBlockchainInfo().LowWaterMark)
+	assertBlocksPruned(t, mgr, prunedBlocks)
+	preservedBlock, err = mgr.retrieveBlockByNumber(1)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[1], preservedBlock)
+	moreBlocks := bg.NextTestBlocks(5)
+	blkfileMgrWrapper.addBlocks(moreBlocks)
+	assert.Equal(t, firstBlockNumInFile2, mgr.getBlockchainInfo().LowWaterMark)
+	assert.Equal(t, uint64(65), mgr.getBlockchainInfo().Height)
+}
+
+func TestBlockfileMgrPruneRetainsCurrentFile(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	blkfileMgrWrapper.addBlocks(blocks)
+	mgr := blkfileMgrWrapper.blockfileMgr
+
+	// all the blocks are in the current file, nothing can be pruned
+	assert.NoError(t, mgr.prune(9, 0))
+	assert.Equal(t, uint64(0), mgr.getBlockchainInfo().LowWaterMark)
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 0, nil)
+}
+
+func TestBlockfileMgrPruneCrashBeforeRemovingFiles(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 40)
+	env := newTestEnv(t, NewConf(testPath(), maxFileSizeForBlocks(t, blocks[:10])))
+	defer env.Cleanup()
+	ledgerid := "testLedger"
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	blkfileMgrWrapper.addBlocks(blocks)
+	mgr := blkfileMgrWrapper.blockfileMgr
+
+	firstBlockNumInFile1, err := retriveFirstBlockNumFromFile(mgr.rootDir, 1)
+	require.NoError(t, err)
+	// simulate a crash after persisting the prune info but before removing the blockfiles
+	b, err := (&pruneInfo{firstFileSuffixNum: 1, firstBlockNum: firstBlockNumInFile1}).marshal()
+	require.NoError(t, err)
+	require.NoError(t, mgr.db.Put(blkMgrPruneInfoKey, b, true))
+	blkfileMgrWrapper.close()
+
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+	defer blkfileMgrWrapper.close()
+	mgr = blkfileMgrWrapper.blockfileMgr
+	exists, _, err := util.FileExists(deriveBlockfilePath(mgr.rootDir, 0))
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	assert.Equal(t, firstBlockNumInFile1, mgr.getBlockchainInfo().LowWaterMark)
+}
+
+func assertBlocksPruned(t *testing.T, mgr *blockfileMgr, prunedBlocks []*common.Block) {
+	for _, block := range prunedBlocks {
+		blockNum := block.Header.Number
+		_, err := mgr.retrieveBlockByNumber(blockNum)
+		assert.IsType(t, &blkstorage.ErrBlockPruned{}, err)
+		_, err = mgr.retrieveBlockByHash(block.Header.Hash())
+		assert.IsType(t, &blkstorage.ErrBlockPruned{}, err)
+		_, err = mgr.retrieveTransactionByBlockNumTranNum(blockNum, 0)
+		assert.IsType(t, &blkstorage.ErrBlockPruned{}, err)
+		_, err = mgr.retrieveBlocks(blockNum)
+		assert.IsType(t, &blkstorage.ErrBlockPruned{}, err)
+
+		// the txid index is retained for detecting duplicate txids but the transaction cannot be retrieved
+		txID, err := extractTxID(block.Data.Data[0])
+		assert.NoError(t, err)
+		_, err = mgr.retrieveTransactionByID(txID)
+		assert.IsType(t, &blkstorage.ErrBlockPruned{}, err)
+		_, err = mgr.retrieveBlockByTxID(txID)
+		assert.IsType(t, &blkstorage.ErrBlockPruned{}, err)
+		_, err = mgr.retrieveTxValidationCodeByTxID(txID)
+		assert.NoError(t, err)
+	}
+}
+
+func maxFileSizeForBlocks(t *testing.T, blocks []*common.Block) int {
+	size := 0
+	for _, block := range blocks {
+		by, _, err := serializeBlock(block)
+		require.NoError(t, err)
+		size += len(by) + len(proto.EncodeVarint(uint64(len(by))))
+	}
+	return size
+}
+
+func extractTxID(txEnvelopBytes []byte) (string, error) {
+	txEnvelope, err := putil.GetEnvelopeFromBlock(txEnvelopBytes)
+	if err != nil {
+		return "", err
+	}
+	txPayload, err := putil.GetPayload(txEnvelope)
+	if err != nil {
+		return "", err
+	}
+	chdr, err := putil.UnmarshalChannelHeader(txPayload.Header.ChannelHeader)
+	if err != nil {
+		return "", err
+	}
+	return chdr.TxId, nil
+}
@@ -299,5 +299,17 @@ func validateTargetBlkNum(ledgerDir string, targetBlockNum uint64) error {
 		return errors.Errorf("target block number [%d] should be less than the biggest block number [%d]",
 			targetBlockNum, cpInfo.lastBlockNumber)
 	}
+	firstFileNum, err := retrieveFirstFileSuffix(ledgerDir)
+	if err != nil {
+		return err
+	}
+	firstBlockNum, err := retriveFirstBlockNumFromFile(ledgerDir, firstFileNum)
+	if err != nil {
+		return err
+	}
+	if targetBlockNum < firstBlockNum {
+		return errors.Errorf("target block number [%d] has been pruned, the lowest available block is [%d]",
+			targetBlockNum, firstBlockNum)
+	}
 	return nil
 }
@@ -0,0 +1,258 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+const (
+	// TxIDsFileName is the name of the snapshot file that contains the txids and their validation codes
+	TxIDsFileName      = "txids.data"
+	snapshotFileFormat = byte(1)
+
+	// bootstrapBlockFileName is the name of the temporary file in which the last block of a snapshot is
+	// written during bootstrapping. The file is renamed as the blockfile with suffix 1 only after the
+	// checkpoint info is persisted. The presence of this file on start-up indicates an interrupted bootstrap
+	bootstrapBlockFileName = "bootstrap_block.tmp"
+	// maxIndexBatchSize is the number of index entries accumulated in a batch before writing the batch
+	maxIndexBatchSize = 10000
+)
+
+// exportTxIds exports the txids and their validation codes from the txid index in a file in the specified dir.
+// The function returns a map that contains the name of the generated file and its hash
+func (mgr *blockfileMgr) exportTxIds(dir string, newHashFunc snapshot.NewHashFunc) (map[string][]byte, error) {
+	if !mgr.index.isAttributeIndexed(blkstorage.IndexableAttrTxID) ||
+		!mgr.index.isAttributeIndexed(blkstorage.IndexableAttrTxValidationCode) {
+		return nil, errors.Errorf("exporting txids requires the attributes [%s] and [%s] to be indexed",
+			blkstorage.IndexableAttrTxID, blkstorage.IndexableAttrTxValidationCode)
+	}
+	w, err := snapshot.CreateFile(filepath.Join(dir, TxIDsFileName), snapshotFileFormat, newHashFunc)
+	if err != nil {
+		return nil, err
+	}
+	defer w.Close()
+
+	itr := mgr.db.GetIterator([]byte{txIDIdxKeyPrefix}, []byte{txIDIdxKeyPrefix + 1})
+	defer itr.Release()
+	for itr.Next() {
+		txID := string(itr.Key()[1:])
+		validationCode, err := mgr.index.getTxValidationCodeByTxID(txID)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("error while retrieving the validation code for txid [%s]", txID))
+		}
+		if err := w.EncodeString(txID); err != nil {
+			return nil, err
+		}
+		if err := w.EncodeUVarint(uint64(validationCode)); err != nil {
+			return nil, err
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "error while iterating over the txid index")
+	}
+	fileHash, err := w.Done()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{TxIDsFileName: fileHash}, nil
+}
+
+// bootstrapFromSnapshot initializes an empty block store with the last block of a snapshot (block number N). The txids
+// present in the snapshot file are loaded in the txid index such that these are detected as duplicates during validation
+// of the subsequent blocks. These txids point to the blockfile with suffix 0 which is marked as pruned and hence, the
+// corresponding transactions cannot be retrieved. The block N is written in the blockfile with suffix 1 and the prune info
+// is set to {firstFileSuffixNum: 1, firstBlockNum: N}, i.e., the low-water mark of the block store is N. The block N is
+// indexed when the block store is opened subsequently, the same way as a block that is not indexed before a crash.
+// The persistence of the checkpoint info marks the completion of the bootstrap
+func bootstrapFromSnapshot(ledgerID string, conf *Conf, indexConfig *blkstorage.IndexConfig,
+	db *leveldbhelper.DBHandle, snapshotDir string, lastBlock *common.Block) error {
+	lastBlockNum := lastBlock.Header.Number
+	if lastBlockNum == 0 {
+		return errors.New("cannot bootstrap a block store from a snapshot of the genesis block")
+	}
+	cpInfoBytes, err := db.Get(blkMgrInfoKey)
+	if err != nil {
+		return err
+	}
+	if cpInfoBytes != nil {
+		return errors.Errorf("block store for ledger [%s] already exists", ledgerID)
+	}
+	index, err := newBlockIndex(indexConfig, db)
+	if err != nil {
+		return err
+	}
+
+	rootDir := conf.getLedgerBlockDir(ledgerID)
+	if err := os.RemoveAll(rootDir); err != nil {
+		return errors.Wrapf(err, "error while removing the block storage dir [%s]", rootDir)
+	}
+	if err := deleteAllKeys(db); err != nil {
+		return err
+	}
+	if _, err := util.CreateDirIfMissing(rootDir); err != nil {
+		return errors.Wrapf(err, "error while creating the block storage dir [%s]", rootDir)
+	}
+	// the bootstrap file is created first so as to mark the bootstrap as in-progress
+	// before any entry is added to the index
+	bootstrapFile, err := os.OpenFile(filepath.Join(rootDir, bootstrapBlockFileName), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0660)
+	if err != nil {
+		return errors.Wrap(err, "error while creating the bootstrap block file")
+	}
+	defer bootstrapFile.Close()
+
+	blockBytes, info, err := serializeBlock(lastBlock)
+	if err != nil {
+		return errors.WithMessage(err, "error serializing block")
+	}
+	if index.isAttributeIndexed(blkstorage.IndexableAttrTxID) {
+		if err := importTxIds(index, snapshotDir, info.txOffsets); err != nil {
+			return err
+		}
+	}
+
+	blockBytesEncodedLen := proto.EncodeVarint(uint64(len(blockBytes)))
+	if _, err := bootstrapFile.Write(append(blockBytesEncodedLen, blockBytes...)); err != nil {
+		return errors.Wrap(err, "error while writing the bootstrap block file")
+	}
+	if err := bootstrapFile.Sync(); err != nil {
+		return errors.Wrap(err, "error while syncing the bootstrap block file")
+	}
+
+	batch := leveldbhelper.NewUpdateBatch()
+	cpInfoBytes, err = (&checkpointInfo{
+		latestFileChunkSuffixNum: 1,
+		latestFileChunksize:      len(blockBytesEncodedLen) + len(blockBytes),
+		isChainEmpty:             false,
+		lastBlockNumber:          lastBlockNum,
+	}).marshal()
+	if err != nil {
+		return err
+	}
+	pruneInfoBytes, err := (&pruneInfo{firstFileSuffixNum: 1, firstBlockNum: lastBlockNum}).marshal()
+	if err != nil {
+		return err
+	}
+	batch.Put(blkMgrInfoKey, cpInfoBytes)
+	batch.Put(blkMgrPruneInfoKey, pruneInfoBytes)
+	if err := db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+	return completeBootstrap(rootDir)
+}
+
+// importTxIds loads the txids and their validation codes from the snapshot file in the txid index. The txids
+// of the last block are skipped, as these are added to the index when the last block itself is indexed
+func importTxIds(index *blockIndex, snapshotDir string, lastBlockTxOffsets []*txindexInfo) error {
+	lastBlockTxIDs := map[string]bool{}
+	for _, txOffset := range lastBlockTxOffsets {
+		lastBlockTxIDs[txOffset.txID] = true
+	}
+
+	r, err := snapshot.OpenFile(filepath.Join(snapshotDir, TxIDsFileName), snapshotFileFormat)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	// all the imported txids point to the pruned blockfile with suffix 0
+	prunedFLPBytes, err := (&fileLocPointer{fileSuffixNum: 0}).marshal()
+	if err != nil {
+		return err
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	for {
+		txID, err := r.DecodeString()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		validationCode, err := r.DecodeUVarInt()
+		if err != nil {
+			return err
+		}
+		if lastBlockTxIDs[txID] {
+			continue
+		}
+		batch.Put(constructTxIDKey(txID), prunedFLPBytes)
+		if index.isAttributeIndexed(blkstorage.IndexableAttrBlockTxID) {
+			batch.Put(constructBlockTxIDKey(txID), prunedFLPBytes)
+		}
+		if index.isAttributeIndexed(blkstorage.IndexableAttrTxValidationCode) {
+			batch.Put(constructTxValidationCodeIDKey(txID), []byte{byte(peer.TxValidationCode(validationCode))})
+		}
+		if batch.Len() >= maxIndexBatchSize {
+			if err := index.db.WriteBatch(batch, false); err != nil {
+				return err
+			}
+			batch = leveldbhelper.NewUpdateBatch()
+		}
+	}
+	return index.db.WriteBatch(batch, true)
+}
+
+// recoverInterruptedBootstrap is invoked on start-up of a blockfile manager. If the bootstrap file is present,
+// the bootstrap from a snapshot was interrupted by a crash. If the checkpoint info was persisted before the crash,
+// the bootstrap is completed. Otherwise, the partially imported index entries and the bootstrap file are removed
+func recoverInterruptedBootstrap(rootDir string, db *leveldbhelper.DBHandle) error {
+	exists, _, err := util.FileExists(filepath.Join(rootDir, bootstrapBlockFileName))
+	if err != nil || !exists {
+		return err
+	}
+	cpInfoBytes, err := db.Get(blkMgrInfoKey)
+	if err != nil {
+		return err
+	}
+	if cpInfoBytes != nil {
+		logger.Info("Completing the interrupted bootstrap of the block store from a snapshot")
+		return completeBootstrap(rootDir)
+	}
+	logger.Info("Discarding the incomplete bootstrap of the block store from a snapshot")
+	if err := deleteAllKeys(db); err != nil {
+		return err
+	}
+	return errors.Wrap(os.Remove(filepath.Join(rootDir, bootstrapBlockFileName)), "error while removing the bootstrap block file")
+}
+
+func completeBootstrap(rootDir string) error {
+	if err := os.Rename(filepath.Join(rootDir, bootstrapBlockFileName), deriveBlockfilePath(rootDir, 1)); err != nil {
+		return errors.Wrap(err, "error while renaming the bootstrap block file")
+	}
+	return nil
+}
+
+func deleteAllKeys(db *leveldbhelper.DBHandle) error {
+	itr := db.GetIterator(nil, nil)
+	defer itr.Release()
+	batch := leveldbhelper.NewUpdateBatch()
+	for itr.Next() {
+		batch.Delete(itr.Key())
+		if batch.Len() >= maxIndexBatchSize {
+			if err := db.WriteBatch(batch, false); err != nil {
+				return err
+			}
+			batch = leveldbhelper.NewUpdateBatch()
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrap(err, "error while iterating over the block index")
+	}
+	return db.WriteBatch(batch, true)
+}
@@ -0,0 +1,189 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportTxIdsAndBootstrapFromSnapshot(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	snapshotDir, err := ioutil.TempDir("", "fsblkstorage-snapshot-")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	bg, gb := testutil.NewBlockGenerator(t, "sourceLedger", false)
+	blocks := append([]*common.Block{gb}, bg.NextTestBlocks(9)...)
+	sourceWrapper := newTestBlockfileWrapper(env, "sourceLedger")
+	defer sourceWrapper.close()
+	sourceWrapper.addBlocks(blocks)
+
+	fileHashes, err := sourceWrapper.blockfileMgr.exportTxIds(snapshotDir, snapshot.DefaultNewHashFunc)
+	require.NoError(t, err)
+	expectedHash, err := snapshot.FileHash(filepath.Join(snapshotDir, TxIDsFileName), snapshot.DefaultNewHashFunc)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{TxIDsFileName: expectedHash}, fileHashes)
+	exportedTxIDs := readTxIDsFile(t, snapshotDir)
+	for _, block := range blocks {
+		for _, txEnvBytes := range block.Data.Data {
+			txID, err := extractTxID(txEnvBytes)
+			require.NoError(t, err)
+			assert.Equal(t, peer.TxValidationCode_VALID, exportedTxIDs[txID])
+		}
+	}
+
+	lastBlock := blocks[9]
+	store, err := env.provider.BootstrapFromSnapshot("bootstrappedLedger", snapshotDir, lastBlock)
+	require.NoError(t, err)
+	bootstrappedWrapper := &testBlockfileMgrWrapper{t, store.(*fsBlockStore).fileMgr}
+	verifyBootstrappedBlockStore(t, bootstrappedWrapper, blocks)
+
+	// the next blocks can be added to the bootstrapped block store and these survive a restart
+	moreBlocks := bg.NextTestBlocks(5)
+	bootstrappedWrapper.addBlocks(moreBlocks)
+	bootstrappedWrapper.close()
+	bootstrappedWrapper = newTestBlockfileWrapper(env, "bootstrappedLedger")
+	defer bootstrappedWrapper.close()
+	bcInfo := bootstrappedWrapper.blockfileMgr.getBlockchainInfo()
+	assert.Equal(t, uint64(15), bcInfo.Height)
+	assert.Equal(t, uint64(9), bcInfo.LowWaterMark)
+	bootstrappedWrapper.testGetBlockByNumber(append([]*common.Block{lastBlock}, moreBlocks...), 9, nil)
+	bootstrappedWrapper.testGetBlockByTxID(moreBlocks, nil)
+
+	// a block store cannot be bootstrapped more than once
+	_, err = env.provider.BootstrapFromSnapshot("bootstrappedLedger", snapshotDir, lastBlock)
+	assert.EqualError(t, err, "block store for ledger [bootstrappedLedger] already exists")
+	// a block store cannot be bootstrapped from the genesis block
+	_, err = env.provider.BootstrapFromSnapshot("anotherLedger", snapshotDir, gb)
+	assert.EqualError(t, err, "cannot bootstrap a block store from a snapshot of the genesis block")
+}
+
+func TestExportTxIdsRequiresTxIDIndex(t *testing.T) {
+	env := newTestEnvSelectiveIndexing(t, NewConf(testPath(), 0),
+		[]blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNum}, &disabled.Provider{})
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	defer w.close()
+	_, err := w.blockfileMgr.exportTxIds("", snapshot.DefaultNewHashFunc)
+	assert.EqualError(t, err, "exporting txids requires the attributes [TxID] and [TxValidationCode] to be indexed")
+}
+
+func TestBootstrapInterruptedBeforeCheckpoint(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	ledgerid := "testLedger"
+
+	// simulate a crash after importing a part of the txids and before persisting the checkpoint info
+	rootDir := env.provider.conf.getLedgerBlockDir(ledgerid)
+	_, err := util.CreateDirIfMissing(rootDir)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(rootDir, bootstrapBlockFileName), []byte("partial-block"), 0660))
+	dbHandle := env.provider.leveldbProvider.GetDBHandle(ledgerid)
+	require.NoError(t, dbHandle.Put(constructTxIDKey("txid-1"), []byte("flp"), true))
+
+	// the partially imported index entries and the bootstrap file are removed and the block store is empty
+	w := newTestBlockfileWrapper(env, ledgerid)
+	defer w.close()
+	assert.Equal(t, uint64(0), w.blockfileMgr.getBlockchainInfo().Height)
+	_, err = w.blockfileMgr.index.getTxLoc("txid-1")
+	assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+	exists, _, err := util.FileExists(filepath.Join(rootDir, bootstrapBlockFileName))
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	w.addBlocks(testutil.ConstructTestBlocks(t, 3))
+	assert.Equal(t, uint64(3), w.blockfileMgr.getBlockchainInfo().Height)
+}
+
+func TestBootstrapInterruptedAfterCheckpoint(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	snapshotDir, err := ioutil.TempDir("", "fsblkstorage-snapshot-")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	bg, gb := testutil.NewBlockGenerator(t, "sourceLedger", false)
+	blocks := append([]*common.Block{gb}, bg.NextTestBlocks(9)...)
+	sourceWrapper := newTestBlockfileWrapper(env, "sourceLedger")
+	defer sourceWrapper.close()
+	sourceWrapper.addBlocks(blocks)
+	_, err = sourceWrapper.blockfileMgr.exportTxIds(snapshotDir, snapshot.DefaultNewHashFunc)
+	require.NoError(t, err)
+
+	// simulate a crash after persisting the checkpoint info and before renaming the bootstrap file
+	ledgerid := "bootstrappedLedger"
+	dbHandle := env.provider.leveldbProvider.GetDBHandle(ledgerid)
+	require.NoError(t, bootstrapFromSnapshot(ledgerid, env.provider.conf, env.provider.indexConfig, dbHandle, snapshotDir, blocks[9]))
+	rootDir := env.provider.conf.getLedgerBlockDir(ledgerid)
+	require.NoError(t, os.Rename(deriveBlockfilePath(rootDir, 1), filepath.Join(rootDir, bootstrapBlockFileName)))
+
+	w := newTestBlockfileWrapper(env, ledgerid)
+	defer w.close()
+	verifyBootstrappedBlockStore(t, w, blocks)
+}
+
+func verifyBootstrappedBlockStore(t *testing.T, w *testBlockfileMgrWrapper, snapshotBlocks []*common.Block) {
+	lastBlock := snapshotBlocks[len(snapshotBlocks)-1]
+	lastBlockNum := lastBlock.Header.Number
+	bcInfo := w.blockfileMgr.getBlockchainInfo()
+	assert.Equal(t, &common.BlockchainInfo{
+		Height:            lastBlockNum + 1,
+		CurrentBlockHash:  lastBlock.Header.Hash(),
+		PreviousBlockHash: lastBlock.Header.PreviousHash,
+		LowWaterMark:      lastBlockNum,
+	}, bcInfo)
+
+	w.testGetBlockByNumber([]*common.Block{lastBlock}, lastBlockNum, nil)
+	w.testGetBlockByHash([]*common.Block{lastBlock}, nil)
+	w.testGetBlockByTxID([]*common.Block{lastBlock}, nil)
+	for _, block := range snapshotBlocks[:len(snapshotBlocks)-1] {
+		_, err := w.blockfileMgr.retrieveBlockByNumber(block.Header.Number)
+		assert.IsType(t, &blkstorage.ErrBlockPruned{}, err)
+		for _, txEnvBytes := range block.Data.Data {
+			txID, err := extractTxID(txEnvBytes)
+			require.NoError(t, err)
+			_, err = w.blockfileMgr.retrieveTransactionByID(txID)
+			assert.IsType(t, &blkstorage.ErrBlockPruned{}, err)
+			_, err = w.blockfileMgr.retrieveBlockByTxID(txID)
+			assert.IsType(t, &blkstorage.ErrBlockPruned{}, err)
+			validationCode, err := w.blockfileMgr.retrieveTxValidationCodeByTxID(txID)
+			assert.NoError(t, err)
+			assert.Equal(t, peer.TxValidationCode_VALID, validationCode)
+		}
+	}
+}
+
+func readTxIDsFile(t *testing.T, dir string) map[string]peer.TxValidationCode {
+	r, err := snapshot.OpenFile(filepath.Join(dir, TxIDsFileName), snapshotFileFormat)
+	require.NoError(t, err)
+	defer r.Close()
+	txIDs := map[string]peer.TxValidationCode{}
+	for {
+		txID, err := r.DecodeString()
+		if err == io.EOF {
+			return txIDs
+		}
+		require.NoError(t, err)
+		validationCode, err := r.DecodeUVarInt()
+		require.NoError(t, err)
+		txIDs[txID] = peer.TxValidationCode(validationCode)
+	}
+}
@@ -61,6 +61,21 @@ func (flf *fileLedgerFactory) ChainIDs() []string {
 	return chainIDs
 }
 
+// Remove removes the ledger of the given chain ID and all of its blocks
+func (flf *fileLedgerFactory) Remove(chainID string) error {
+	flf.mutex.Lock()
+	defer flf.mutex.Unlock()
+
+	if ledger, ok := flf.ledgers[chainID]; ok {
+		// the block store must be shut down before its files are removed
+		if blockStore, ok := ledger.(*FileLedger).blockStore.(blkstorage.BlockStore); ok {
+			blockStore.Shutdown()
+		}
+		delete(flf.ledgers, chainID)
+	}
+	return flf.blkstorageProvider.Remove(chainID)
+}
+
 // Close releases all resources acquired by the factory
 func (flf *fileLedgerFactory) Close() {
 	flf.blkstorageProvider.Close()
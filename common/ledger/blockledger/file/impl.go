@@ -9,9 +9,11 @@ package fileledger
 import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/pkg/errors"
 )
 
 var logger = flogging.MustGetLogger("common.ledger.blockledger.file")
@@ -45,6 +47,10 @@ type fileLedgerIterator struct {
 // It returns an error if the next block is no longer retrievable.
 func (i *fileLedgerIterator) Next() (*cb.Block, cb.Status) {
 	result, err := i.commonIterator.Next()
+	if isBlockPruned(err) {
+		logger.Warning(err)
+		return nil, cb.Status_GONE
+	}
 	if err != nil {
 		logger.Error(err)
 		return nil, cb.Status_SERVICE_UNAVAILABLE
@@ -86,13 +92,23 @@ func (fl *FileLedger) Iterator(startPosition *ab.SeekPosition) (blockledger.Iter
 	}
 
 	iterator, err := fl.blockStore.RetrieveBlocks(startingBlockNumber)
+	if isBlockPruned(err) {
+		logger.Warningf("Blocks starting from block [%d] have been pruned: %s", startingBlockNumber, err)
+		return &blockledger.PrunedErrorIterator{}, 0
+	}
 	if err != nil {
+		logger.Warningf("Failed to retrieve blocks starting from block [%d]: %s", startingBlockNumber, err)
 		return &blockledger.NotFoundErrorIterator{}, 0
 	}
 
 	return &fileLedgerIterator{ledger: fl, blockNumber: startingBlockNumber, commonIterator: iterator}, startingBlockNumber
 }
 
+func isBlockPruned(err error) bool {
+	_, ok := errors.Cause(err).(*blkstorage.ErrBlockPruned)
+	return ok
+}
+
 // Height returns the number of blocks on the ledger
 func (fl *FileLedger) Height() uint64 {
 	info, err := fl.blockStore.GetBlockchainInfo()
@@ -15,6 +15,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/flogging"
 	cl "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
@@ -306,6 +307,41 @@ func TestBlockstoreError(t *testing.T) {
 	}
 }
 
+func TestBlockstorePruned(t *testing.T) {
+	{
+		fl := &FileLedger{
+			blockStore: &mockBlockStore{
+				blockchainInfo: &cb.BlockchainInfo{Height: uint64(10), LowWaterMark: uint64(5)},
+				defaultError:   &blkstorage.ErrBlockPruned{LowWaterMark: 5, Msg: "error retrieving blocks starting from block [2]"},
+			},
+			signal: make(chan struct{}),
+		}
+		it, num := fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 2}}})
+		defer it.Close()
+		assert.IsType(t, &blockledger.PrunedErrorIterator{}, it, "Expected Pruned Error if seek number is below the low water mark")
+		assert.Equal(t, uint64(0), num)
+		_, status := it.Next()
+		assert.Equal(t, cb.Status_GONE, status, "Expected gone error")
+	}
+
+	{
+		resultsIterator := &mockBlockStoreIterator{}
+		resultsIterator.On("Next").Return(nil, &blkstorage.ErrBlockPruned{LowWaterMark: 5, Msg: "error fetching block from block file [0]"})
+		resultsIterator.On("Close").Return()
+		fl := &FileLedger{
+			blockStore: &mockBlockStore{
+				blockchainInfo:  &cb.BlockchainInfo{Height: uint64(10)},
+				resultsIterator: resultsIterator,
+			},
+			signal: make(chan struct{}),
+		}
+		it, _ := fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 2}}})
+		defer it.Close()
+		_, status := it.Next()
+		assert.Equal(t, cb.Status_GONE, status, "Expected gone error if the block is pruned during the iteration")
+	}
+}
+
 func getSampleEnvelopeWithSignatureHeader() *cb.Envelope {
 	nonce := utils.CreateNonceOrPanic()
 	sighdr := &cb.SignatureHeader{Nonce: nonce}
@@ -113,6 +113,14 @@ func (jlf *jsonLedgerFactory) ChainIDs() []string {
 	return ids
 }
 
+// Remove removes the ledger of the given chain ID and its directory
+func (jlf *jsonLedgerFactory) Remove(chainID string) error {
+	jlf.mutex.Lock()
+	defer jlf.mutex.Unlock()
+	delete(jlf.ledgers, chainID)
+	return os.RemoveAll(filepath.Join(jlf.directory, fmt.Sprintf(chainDirectoryFormatString, chainID)))
+}
+
 // Close is a no-op for the JSON ledger
 func (jlf *jsonLedgerFactory) Close() {
 	return // nothing to do
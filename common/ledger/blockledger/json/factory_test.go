@@ -114,3 +114,19 @@ func TestClose(t *testing.T) {
 	jlf := New(name)
 	assert.NotPanics(t, func() { jlf.Close() }, "Noop should not pannic")
 }
+
+func TestRemove(t *testing.T) {
+	name, err := ioutil.TempDir("", "hyperledger_fabric")
+	assert.Nil(t, err, "Error creating temp dir: %s", err)
+	defer os.RemoveAll(name)
+
+	jlf := New(name)
+	_, err = jlf.GetOrCreate("foo")
+	assert.NoError(t, err)
+	_, err = jlf.GetOrCreate("bar")
+	assert.NoError(t, err)
+
+	assert.NoError(t, jlf.Remove("foo"))
+	assert.Equal(t, []string{"bar"}, jlf.ChainIDs())
+	assert.Equal(t, []string{"bar"}, New(name).ChainIDs(), "Expected removed chain not to be restored")
+}
@@ -0,0 +1,216 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// NewHashFunc returns a new hash that is used for computing the hash of the contents of a snapshot file
+type NewHashFunc func() (hash.Hash, error)
+
+// DefaultNewHashFunc returns a new sha256 hash
+func DefaultNewHashFunc() (hash.Hash, error) {
+	return sha256.New(), nil
+}
+
+// FileWriter writes to a ledger snapshot file. The first byte of the file is the data format
+// and the remaining content is a sequence of encoded items. The writer computes the hash of
+// the entire content of the file as it is being written
+type FileWriter struct {
+	file              *os.File
+	hasher            hash.Hash
+	bufWriter         *bufio.Writer
+	multiWriter       io.Writer
+	varintReusableBuf []byte
+}
+
+// CreateFile creates a new file for exporting the ledger snapshot data and writes the dataFormat as the first byte.
+// This function returns an error if the file already exists
+func CreateFile(filePath string, dataFormat byte, newHashFunc NewHashFunc) (*FileWriter, error) {
+	hasher, err := newHashFunc()
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while creating the snapshot file: %s", filePath)
+	}
+	bufWriter := bufio.NewWriter(file)
+	w := &FileWriter{
+		file:              file,
+		hasher:            hasher,
+		bufWriter:         bufWriter,
+		multiWriter:       io.MultiWriter(bufWriter, hasher),
+		varintReusableBuf: make([]byte, binary.MaxVarintLen64),
+	}
+	if err := w.EncodeBytesWithoutLength([]byte{dataFormat}); err != nil {
+		w.Close()
+		return nil, errors.WithMessage(err, "error while writing data format to the snapshot file")
+	}
+	return w, nil
+}
+
+// EncodeString encodes and appends the string to the data file
+func (w *FileWriter) EncodeString(str string) error {
+	return w.EncodeBytes([]byte(str))
+}
+
+// EncodeBytes encodes and appends the bytes, preceded by their length, to the data file
+func (w *FileWriter) EncodeBytes(b []byte) error {
+	if err := w.EncodeUVarint(uint64(len(b))); err != nil {
+		return err
+	}
+	return w.EncodeBytesWithoutLength(b)
+}
+
+// EncodeBytesWithoutLength appends the bytes as such to the data file
+func (w *FileWriter) EncodeBytesWithoutLength(b []byte) error {
+	if _, err := w.multiWriter.Write(b); err != nil {
+		return errors.Wrapf(err, "error while writing data to the snapshot file: %s", w.file.Name())
+	}
+	return nil
+}
+
+// EncodeUVarint encodes a uint64 as a varint and appends it to the data file
+func (w *FileWriter) EncodeUVarint(u uint64) error {
+	n := binary.PutUvarint(w.varintReusableBuf, u)
+	return w.EncodeBytesWithoutLength(w.varintReusableBuf[:n])
+}
+
+// Done flushes the buffered data, syncs the file to the disk, and returns the hash of the file content
+func (w *FileWriter) Done() ([]byte, error) {
+	if err := w.bufWriter.Flush(); err != nil {
+		return nil, errors.Wrapf(err, "error while flushing to the snapshot file: %s", w.file.Name())
+	}
+	if err := w.file.Sync(); err != nil {
+		return nil, errors.Wrapf(err, "error while syncing the snapshot file: %s", w.file.Name())
+	}
+	return w.hasher.Sum(nil), nil
+}
+
+// Close closes the underlying file. It is safe to call Close after Done
+func (w *FileWriter) Close() error {
+	if w == nil {
+		return nil
+	}
+	return errors.Wrapf(w.file.Close(), "error while closing the snapshot file: %s", w.file.Name())
+}
+
+// FileReader reads from a ledger snapshot file. All the Decode functions return io.EOF,
+// as such, when the end of the file is reached at an item boundary
+type FileReader struct {
+	file              *os.File
+	bufReader         *bufio.Reader
+	reusableByteSlice []byte
+}
+
+// OpenFile opens a snapshot file for reading and verifies that the first byte of the file
+// matches the expectedDataFormat
+func OpenFile(filePath string, expectedDataFormat byte) (*FileReader, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while opening the snapshot file: %s", filePath)
+	}
+	r := &FileReader{
+		file:      file,
+		bufReader: bufio.NewReader(file),
+	}
+	dataFormat, err := r.bufReader.ReadByte()
+	if err != nil {
+		r.Close()
+		return nil, errors.Wrapf(err, "error while reading data format from the snapshot file: %s", filePath)
+	}
+	if dataFormat != expectedDataFormat {
+		r.Close()
+		return nil, errors.Errorf("unexpected data format [%x] in the snapshot file [%s], expected [%x]",
+			dataFormat, filePath, expectedDataFormat)
+	}
+	return r, nil
+}
+
+// DecodeString reads and decodes a string
+func (r *FileReader) DecodeString() (string, error) {
+	b, err := r.decodeBytes()
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// DecodeBytes reads and decodes bytes. The returned bytes are not shared with the reader
+func (r *FileReader) DecodeBytes() ([]byte, error) {
+	b, err := r.decodeBytes()
+	if err != nil {
+		return nil, err
+	}
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c, nil
+}
+
+// DecodeUVarInt reads and decodes a varint encoded uint64
+func (r *FileReader) DecodeUVarInt() (uint64, error) {
+	u, err := binary.ReadUvarint(r.bufReader)
+	if err == io.EOF {
+		return 0, io.EOF
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "error while reading from the snapshot file: %s", r.file.Name())
+	}
+	return u, nil
+}
+
+// Close closes the underlying file
+func (r *FileReader) Close() error {
+	if r == nil {
+		return nil
+	}
+	return errors.Wrapf(r.file.Close(), "error while closing the snapshot file: %s", r.file.Name())
+}
+
+func (r *FileReader) decodeBytes() ([]byte, error) {
+	sizeUint, err := r.DecodeUVarInt()
+	if err != nil {
+		return nil, err
+	}
+	size := int(sizeUint)
+	if size == 0 {
+		return []byte{}, nil
+	}
+	if len(r.reusableByteSlice) < size {
+		r.reusableByteSlice = make([]byte, size)
+	}
+	if _, err := io.ReadFull(r.bufReader, r.reusableByteSlice[:size]); err != nil {
+		return nil, errors.Wrapf(err, "error while reading from the snapshot file: %s", r.file.Name())
+	}
+	return r.reusableByteSlice[:size], nil
+}
+
+// FileHash computes the hash of the entire content of a file
+func FileHash(filePath string, newHashFunc NewHashFunc) ([]byte, error) {
+	hasher, err := newHashFunc()
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while opening the snapshot file: %s", filePath)
+	}
+	defer file.Close()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return nil, errors.Wrapf(err, "error while reading the snapshot file: %s", filePath)
+	}
+	return hasher.Sum(nil), nil
+}
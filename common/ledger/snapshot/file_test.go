@@ -0,0 +1,107 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"crypto/sha256"
+	"errors"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileWriterReader(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "snapshot-")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	filePath := filepath.Join(testDir, "test.data")
+
+	w, err := CreateFile(filePath, byte(7), DefaultNewHashFunc)
+	require.NoError(t, err)
+	require.NoError(t, w.EncodeString("string-1"))
+	require.NoError(t, w.EncodeBytes([]byte("bytes-1")))
+	require.NoError(t, w.EncodeBytes(nil))
+	require.NoError(t, w.EncodeUVarint(1024))
+	fileHash, err := w.Done()
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	content, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	expectedHash := sha256.Sum256(content)
+	assert.Equal(t, expectedHash[:], fileHash)
+	computedHash, err := FileHash(filePath, DefaultNewHashFunc)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedHash[:], computedHash)
+
+	r, err := OpenFile(filePath, byte(7))
+	require.NoError(t, err)
+	defer r.Close()
+	s, err := r.DecodeString()
+	assert.NoError(t, err)
+	assert.Equal(t, "string-1", s)
+	b, err := r.DecodeBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("bytes-1"), b)
+	b, err = r.DecodeBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{}, b)
+	u, err := r.DecodeUVarInt()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1024), u)
+	_, err = r.DecodeBytes()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestFileWriterErrors(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "snapshot-")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	filePath := filepath.Join(testDir, "test.data")
+
+	_, err = CreateFile(filePath, byte(1), func() (hash.Hash, error) { return nil, errors.New("hash-error") })
+	assert.EqualError(t, err, "hash-error")
+
+	w, err := CreateFile(filePath, byte(1), DefaultNewHashFunc)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	_, err = CreateFile(filePath, byte(1), DefaultNewHashFunc)
+	assert.Contains(t, err.Error(), "error while creating the snapshot file")
+}
+
+func TestFileReaderErrors(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "snapshot-")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	filePath := filepath.Join(testDir, "test.data")
+
+	_, err = OpenFile(filePath, byte(1))
+	assert.Contains(t, err.Error(), "error while opening the snapshot file")
+
+	w, err := CreateFile(filePath, byte(1), DefaultNewHashFunc)
+	require.NoError(t, err)
+	require.NoError(t, w.EncodeUVarint(100))
+	_, err = w.Done()
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	_, err = OpenFile(filePath, byte(2))
+	assert.Contains(t, err.Error(), "unexpected data format [1]")
+
+	r, err := OpenFile(filePath, byte(1))
+	require.NoError(t, err)
+	defer r.Close()
+	// the length prefix promises more bytes than present in the file
+	_, err = r.DecodeBytes()
+	assert.Contains(t, err.Error(), "error while reading from the snapshot file")
+}
@@ -32,6 +32,9 @@ const (
 // Conf configuration for `DB`
 type Conf struct {
 	DBPath string
+	// ReadOnly opens an existing db without creating it and rejects all writes.
+	// This is used by the offline tools that inspect the ledger of a stopped peer
+	ReadOnly bool
 }
 
 // DB - a wrapper on an actual store
@@ -72,10 +75,15 @@ func (dbInst *DB) Open() {
 	dbPath := dbInst.conf.DBPath
 	var err error
 	var dirEmpty bool
-	if dirEmpty, err = util.CreateDirIfMissing(dbPath); err != nil {
-		panic(fmt.Sprintf("Error creating dir if missing: %s", err))
+	if dbInst.conf.ReadOnly {
+		dbOpts.ReadOnly = true
+		dbOpts.ErrorIfMissing = true
+	} else {
+		if dirEmpty, err = util.CreateDirIfMissing(dbPath); err != nil {
+			panic(fmt.Sprintf("Error creating dir if missing: %s", err))
+		}
+		dbOpts.ErrorIfMissing = !dirEmpty
 	}
-	dbOpts.ErrorIfMissing = !dirEmpty
 	if dbInst.db, err = leveldb.OpenFile(dbPath, dbOpts); err != nil {
 		panic(fmt.Sprintf("Error opening leveldb: %s", err))
 	}
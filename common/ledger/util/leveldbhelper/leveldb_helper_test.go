@@ -159,7 +159,7 @@ func TestFileLock(t *testing.T) {
 func TestCreateDBInEmptyDir(t *testing.T) {
 	assert.NoError(t, os.RemoveAll(testDBPath), "")
 	assert.NoError(t, os.MkdirAll(testDBPath, 0775), "")
-	db := CreateDB(&Conf{testDBPath})
+	db := CreateDB(&Conf{DBPath: testDBPath})
 	defer db.Close()
 	defer func() {
 		if r := recover(); r != nil {
@@ -175,7 +175,7 @@ func TestCreateDBInNonEmptyDir(t *testing.T) {
 	file, err := os.Create(filepath.Join(testDBPath, "dummyfile.txt"))
 	assert.NoError(t, err, "")
 	file.Close()
-	db := CreateDB(&Conf{testDBPath})
+	db := CreateDB(&Conf{DBPath: testDBPath})
 	defer db.Close()
 	defer func() {
 		if r := recover(); r == nil {
@@ -184,3 +184,28 @@ func TestCreateDBInNonEmptyDir(t *testing.T) {
 	}()
 	db.Open()
 }
+
+func TestReadOnlyDB(t *testing.T) {
+	env := newTestDBEnv(t, testDBPath)
+	defer env.cleanup()
+	db := env.db
+	db.Open()
+	assert.NoError(t, db.Put([]byte("key"), []byte("value"), true))
+	db.Close()
+
+	readOnlyDB := CreateDB(&Conf{DBPath: testDBPath, ReadOnly: true})
+	readOnlyDB.Open()
+	defer readOnlyDB.Close()
+	val, err := readOnlyDB.Get([]byte("key"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), val)
+	assert.Error(t, readOnlyDB.Put([]byte("key"), []byte("newValue"), true))
+}
+
+func TestReadOnlyDBMissingDir(t *testing.T) {
+	assert.NoError(t, os.RemoveAll(testDBPath))
+	db := CreateDB(&Conf{DBPath: testDBPath, ReadOnly: true})
+	assert.Panics(t, db.Open)
+	_, err := os.Stat(testDBPath)
+	assert.True(t, os.IsNotExist(err), "a read-only open should not create the db dir")
+}
@@ -13,6 +13,7 @@ import (
 type MockApplication struct {
 	CapabilitiesRv channelconfig.ApplicationCapabilities
 	Acls           map[string]string
+	SupplyCaps     map[string]uint64
 }
 
 func (m *MockApplication) Organizations() map[string]channelconfig.ApplicationOrg {
@@ -35,6 +36,10 @@ func (m *MockApplication) APIPolicyMapper() channelconfig.PolicyMapper {
 	return m
 }
 
+func (m *MockApplication) TokenSupplyCaps() map[string]uint64 {
+	return m.SupplyCaps
+}
+
 type MockApplicationCapabilities struct {
 	SupportedRv                  error
 	ForbidDuplicateTXIdInBlockRv bool
@@ -20,6 +20,7 @@ import (
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/msp"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
 	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	"github.com/hyperledger/fabric/protos/utils"
 )
@@ -288,6 +289,46 @@ var _ = Describe("Encoder", func() {
 			})
 		})
 
+		Context("when the consensus type is bft", func() {
+			BeforeEach(func() {
+				conf.OrdererType = "bft"
+				conf.BFT = &bft.ConfigMetadata{
+					Options: &bft.Options{
+						RequestTimeout: "10s",
+					},
+				}
+			})
+
+			It("adds the bft metadata", func() {
+				cg, err := encoder.NewOrdererGroup(conf)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(cg.Values)).To(Equal(5))
+				consensusType := &ab.ConsensusType{}
+				err = proto.Unmarshal(cg.Values["ConsensusType"].Value, consensusType)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(consensusType.Type).To(Equal("bft"))
+				metadata := &bft.ConfigMetadata{}
+				err = proto.Unmarshal(consensusType.Metadata, metadata)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(metadata.Options.RequestTimeout).To(Equal("10s"))
+			})
+
+			Context("when the bft configuration is bad", func() {
+				BeforeEach(func() {
+					conf.BFT = &bft.ConfigMetadata{
+						Consenters: []*bft.Consenter{
+							{},
+						},
+					}
+				})
+
+				It("wraps and returns the error", func() {
+					_, err := encoder.NewOrdererGroup(conf)
+					Expect(err).To(MatchError("cannot marshal metadata for orderer type bft: cannot load identity for consenter :0: open : no such file or directory"))
+				})
+			})
+		})
+
 		Context("when the consensus type is unknown", func() {
 			BeforeEach(func() {
 				conf.OrdererType = "bad-type"
@@ -353,6 +394,19 @@ var _ = Describe("Encoder", func() {
 			Expect(cg.Values["Capabilities"]).NotTo(BeNil())
 		})
 
+		Context("when token supply caps are specified", func() {
+			BeforeEach(func() {
+				conf.TokenSupplyCaps = map[string]uint64{"TOK1": 100}
+			})
+
+			It("adds the token supply caps value", func() {
+				cg, err := encoder.NewApplicationGroup(conf)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(cg.Values)).To(Equal(3))
+				Expect(cg.Values["TokenSupplyCaps"]).NotTo(BeNil())
+			})
+		})
+
 		Context("when the policies are ommitted", func() {
 			BeforeEach(func() {
 				conf.Policies = nil
@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/fabric/common/viperutil"
 	cf "github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
 	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	"github.com/spf13/viper"
 )
@@ -113,11 +114,12 @@ type Consortium struct {
 // Application encodes the application-level configuration needed in config
 // transactions.
 type Application struct {
-	Organizations []*Organization    `yaml:"Organizations"`
-	Capabilities  map[string]bool    `yaml:"Capabilities"`
-	Resources     *Resources         `yaml:"Resources"`
-	Policies      map[string]*Policy `yaml:"Policies"`
-	ACLs          map[string]string  `yaml:"ACLs"`
+	Organizations   []*Organization    `yaml:"Organizations"`
+	Capabilities    map[string]bool    `yaml:"Capabilities"`
+	Resources       *Resources         `yaml:"Resources"`
+	Policies        map[string]*Policy `yaml:"Policies"`
+	ACLs            map[string]string  `yaml:"ACLs"`
+	TokenSupplyCaps map[string]uint64  `yaml:"TokenSupplyCaps"`
 }
 
 // Resources encodes the application-level resources configuration needed to
@@ -162,6 +164,7 @@ type Orderer struct {
 	BatchSize     BatchSize                `yaml:"BatchSize"`
 	Kafka         Kafka                    `yaml:"Kafka"`
 	EtcdRaft      *etcdraft.ConfigMetadata `yaml:"EtcdRaft"`
+	BFT           *bft.ConfigMetadata      `yaml:"BFT"`
 	Organizations []*Organization          `yaml:"Organizations"`
 	MaxChannels   uint64                   `yaml:"MaxChannels"`
 	Capabilities  map[string]bool          `yaml:"Capabilities"`
@@ -202,6 +205,12 @@ var genesisDefaults = TopLevel{
 				SnapshotIntervalSize: 20 * 1024 * 1024, // 20 MB
 			},
 		},
+		BFT: &bft.ConfigMetadata{
+			Options: &bft.Options{
+				RequestTimeout:    "10s",
+				ViewChangeTimeout: "20s",
+			},
+		},
 	},
 }
 
@@ -461,6 +470,59 @@ loop:
 			cf.TranslatePathInPlace(configDir, &serverCertPath)
 			c.ServerTlsCert = []byte(serverCertPath)
 		}
+	case bft.TypeKey:
+		if ord.BFT == nil {
+			logger.Panicf("%s configuration missing", bft.TypeKey)
+		}
+		if ord.BFT.Options == nil {
+			logger.Infof("Orderer.BFT.Options unset, setting to %v", genesisDefaults.Orderer.BFT.Options)
+			ord.BFT.Options = genesisDefaults.Orderer.BFT.Options
+		}
+		if ord.BFT.Options.RequestTimeout == "" {
+			logger.Infof("Orderer.BFT.Options.RequestTimeout unset, setting to %v", genesisDefaults.Orderer.BFT.Options.RequestTimeout)
+			ord.BFT.Options.RequestTimeout = genesisDefaults.Orderer.BFT.Options.RequestTimeout
+		}
+		if ord.BFT.Options.ViewChangeTimeout == "" {
+			logger.Infof("Orderer.BFT.Options.ViewChangeTimeout unset, setting to %v", genesisDefaults.Orderer.BFT.Options.ViewChangeTimeout)
+			ord.BFT.Options.ViewChangeTimeout = genesisDefaults.Orderer.BFT.Options.ViewChangeTimeout
+		}
+		if _, err := time.ParseDuration(ord.BFT.Options.RequestTimeout); err != nil {
+			logger.Panicf("BFT RequestTimeout (%s) must be in time duration format", ord.BFT.Options.RequestTimeout)
+		}
+		if _, err := time.ParseDuration(ord.BFT.Options.ViewChangeTimeout); err != nil {
+			logger.Panicf("BFT ViewChangeTimeout (%s) must be in time duration format", ord.BFT.Options.ViewChangeTimeout)
+		}
+		if len(ord.BFT.Consenters) == 0 {
+			logger.Panicf("%s configuration did not specify any consenter", bft.TypeKey)
+		}
+
+		for _, c := range ord.BFT.GetConsenters() {
+			switch {
+			case c.ConsenterId == 0:
+				logger.Panicf("consenter info in %s configuration did not specify consenter ID", bft.TypeKey)
+			case c.Host == "":
+				logger.Panicf("consenter info in %s configuration did not specify host", bft.TypeKey)
+			case c.Port == 0:
+				logger.Panicf("consenter info in %s configuration did not specify port", bft.TypeKey)
+			case c.MspId == "":
+				logger.Panicf("consenter info in %s configuration did not specify MSP ID", bft.TypeKey)
+			case c.Identity == nil:
+				logger.Panicf("consenter info in %s configuration did not specify identity", bft.TypeKey)
+			case c.ClientTlsCert == nil:
+				logger.Panicf("consenter info in %s configuration did not specify client TLS cert", bft.TypeKey)
+			case c.ServerTlsCert == nil:
+				logger.Panicf("consenter info in %s configuration did not specify server TLS cert", bft.TypeKey)
+			}
+			identityPath := string(c.GetIdentity())
+			cf.TranslatePathInPlace(configDir, &identityPath)
+			c.Identity = []byte(identityPath)
+			clientCertPath := string(c.GetClientTlsCert())
+			cf.TranslatePathInPlace(configDir, &clientCertPath)
+			c.ClientTlsCert = []byte(clientCertPath)
+			serverCertPath := string(c.GetServerTlsCert())
+			cf.TranslatePathInPlace(configDir, &serverCertPath)
+			c.ServerTlsCert = []byte(serverCertPath)
+		}
 	default:
 		logger.Panicf("unknown orderer type: %s", ord.OrdererType)
 	}
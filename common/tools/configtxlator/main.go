@@ -24,6 +24,7 @@ import (
 	cb "github.com/hyperledger/fabric/protos/common" // Import these to register the proto types
 	_ "github.com/hyperledger/fabric/protos/msp"
 	_ "github.com/hyperledger/fabric/protos/orderer"
+	_ "github.com/hyperledger/fabric/protos/orderer/bft"
 	_ "github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	_ "github.com/hyperledger/fabric/protos/peer"
 
@@ -21,6 +21,7 @@ import (
 const (
 	CHANNELREADERS = policies.ChannelApplicationReaders
 	CHANNELWRITERS = policies.ChannelApplicationWriters
+	CHANNELADMINS  = policies.ChannelApplicationAdmins
 )
 
 //defaultACLProvider used if resource-based ACL Provider is not provided or
@@ -93,6 +94,8 @@ func (d *defaultACLProvider) initialize() {
 	d.cResourcePolicyMap[resources.Token_Issue] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Token_Transfer] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Token_List] = CHANNELREADERS
+	//audits reveal the history and the balances of every owner
+	d.cResourcePolicyMap[resources.Token_Audit] = CHANNELADMINS
 
 	//Event resources
 	d.cResourcePolicyMap[resources.Event_Block] = CHANNELREADERS
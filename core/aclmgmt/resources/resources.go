@@ -48,4 +48,5 @@ const (
 	Token_Issue    = "token/Issue"
 	Token_Transfer = "token/Transfer"
 	Token_List     = "token/List"
+	Token_Audit    = "token/Audit"
 )
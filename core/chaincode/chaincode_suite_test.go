@@ -53,6 +53,11 @@ type processor interface {
 	chaincode.Processor
 }
 
+//go:generate counterfeiter -o mock/external_builder.go --fake-name ExternalBuilder . externalBuilder
+type externalBuilder interface {
+	chaincode.ExternalBuilder
+}
+
 //go:generate counterfeiter -o mock/invoker.go --fake-name Invoker . invoker
 type invoker interface {
 	chaincode.Invoker
@@ -58,6 +58,9 @@ type ChaincodeSupport struct {
 	appConfig        ApplicationConfigRetriever
 	HandlerMetrics   *HandlerMetrics
 	LaunchMetrics    *LaunchMetrics
+
+	// DeployedCCInfoProvider is used to retrieve the collections of the chaincodes
+	DeployedCCInfoProvider ledger.DeployedChaincodeInfoProvider
 }
 
 // NewChaincodeSupport creates a new ChaincodeSupport instance.
@@ -71,6 +74,7 @@ func NewChaincodeSupport(
 	lifecycle Lifecycle,
 	aclProvider ACLProvider,
 	processor Processor,
+	externalBuilder ExternalBuilder,
 	SystemCCProvider sysccprovider.SystemChaincodeProvider,
 	platformRegistry *platforms.Registry,
 	appConfig ApplicationConfigRetriever,
@@ -111,6 +115,7 @@ func NewChaincodeSupport(
 		Runtime:         cs.Runtime,
 		Registry:        cs.HandlerRegistry,
 		PackageProvider: packageProvider,
+		ExternalBuilder: externalBuilder,
 		StartupTimeout:  config.StartupTimeout,
 		Metrics:         cs.LaunchMetrics,
 	}
@@ -184,6 +189,7 @@ func (cs *ChaincodeSupport) HandleChaincodeStream(stream ccintf.ChaincodeStream)
 		QueryResponseBuilder:       &QueryResponseGenerator{MaxResultLimit: 100},
 		UUIDGenerator:              UUIDGeneratorFunc(util.GenerateUUID),
 		LedgerGetter:               peer.Default,
+		DeployedCCInfoProvider:     cs.DeployedCCInfoProvider,
 		AppConfig:                  cs.appConfig,
 		Metrics:                    cs.HandlerMetrics,
 	}
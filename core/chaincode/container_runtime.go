@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/hyperledger/fabric/core/chaincode/accesscontrol"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
@@ -41,6 +42,9 @@ type ContainerRuntime struct {
 	CommonEnv        []string
 	PeerAddress      string
 	PlatformRegistry *platforms.Registry
+
+	mutex          sync.Mutex
+	containerTypes map[string]string
 }
 
 // Start launches chaincode in a runtime environment.
@@ -74,6 +78,8 @@ func (c *ContainerRuntime) Start(ccci *ccprovider.ChaincodeContainerInfo, codePa
 		},
 	}
 
+	c.setContainerType(cname, ccci.ContainerType)
+
 	if err := c.Processor.Process(ccci.ContainerType, scr); err != nil {
 		return errors.WithMessage(err, "error starting container")
 	}
@@ -81,6 +87,28 @@ func (c *ContainerRuntime) Start(ccci *ccprovider.ChaincodeContainerInfo, codePa
 	return nil
 }
 
+// setContainerType records the container type the chaincode was started
+// with, so that the chaincode is stopped and waited on with the same type.
+func (c *ContainerRuntime) setContainerType(cname, containerType string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.containerTypes == nil {
+		c.containerTypes = map[string]string{}
+	}
+	c.containerTypes[cname] = containerType
+}
+
+// containerType returns the container type the chaincode was started with,
+// defaulting to the container type of the chaincode container info.
+func (c *ContainerRuntime) containerType(ccci *ccprovider.ChaincodeContainerInfo) string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if containerType, ok := c.containerTypes[ccci.Name+":"+ccci.Version]; ok {
+		return containerType
+	}
+	return ccci.ContainerType
+}
+
 // Stop terminates chaincode and its container runtime environment.
 func (c *ContainerRuntime) Stop(ccci *ccprovider.ChaincodeContainerInfo) error {
 	scr := container.StopContainerReq{
@@ -92,7 +120,7 @@ func (c *ContainerRuntime) Stop(ccci *ccprovider.ChaincodeContainerInfo) error {
 		Dontremove: false,
 	}
 
-	if err := c.Processor.Process(ccci.ContainerType, scr); err != nil {
+	if err := c.Processor.Process(c.containerType(ccci), scr); err != nil {
 		return errors.WithMessage(err, "error stopping container")
 	}
 
@@ -118,7 +146,7 @@ func (c *ContainerRuntime) Wait(ccci *ccprovider.ChaincodeContainerInfo) (int, e
 		},
 	}
 
-	if err := c.Processor.Process(ccci.ContainerType, wcr); err != nil {
+	if err := c.Processor.Process(c.containerType(ccci), wcr); err != nil {
 		return -1, err
 	}
 	r := <-resultCh
@@ -219,6 +219,46 @@ func TestContainerRuntimeStartErrors(t *testing.T) {
 	}
 }
 
+func TestContainerRuntimeStartContainerType(t *testing.T) {
+	fakeProcessor := &mock.Processor{}
+	cr := &chaincode.ContainerRuntime{
+		Processor:   fakeProcessor,
+		PeerAddress: "peer.example.com",
+	}
+
+	ccci := &ccprovider.ChaincodeContainerInfo{
+		Type:          pb.ChaincodeSpec_GOLANG.String(),
+		Name:          "chaincode-name",
+		Version:       "chaincode-version",
+		ContainerType: "EXTERNAL",
+	}
+
+	err := cr.Start(ccci, []byte("code-package"))
+	assert.NoError(t, err)
+
+	// the chaincode is stopped and waited on with the container type it was started with
+	ccci = &ccprovider.ChaincodeContainerInfo{
+		Type:          pb.ChaincodeSpec_GOLANG.String(),
+		Name:          "chaincode-name",
+		Version:       "chaincode-version",
+		ContainerType: "DOCKER",
+	}
+	err = cr.Stop(ccci)
+	assert.NoError(t, err)
+	fakeProcessor.ProcessStub = func(vmtype string, req container.VMCReq) error {
+		req.(container.WaitContainerReq).Exited(0, nil)
+		return nil
+	}
+	_, err = cr.Wait(ccci)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 3, fakeProcessor.ProcessCallCount())
+	for i := 0; i < 3; i++ {
+		vmType, _ := fakeProcessor.ProcessArgsForCall(i)
+		assert.Equal(t, "EXTERNAL", vmType)
+	}
+}
+
 func TestContainerRuntimeStop(t *testing.T) {
 	fakeProcessor := &mock.Processor{}
 	cr := &chaincode.ContainerRuntime{
@@ -138,6 +138,7 @@ func initPeer(chainIDs ...string) (net.Listener, *ChaincodeSupport, func(), erro
 				inproccontroller.ContainerType: ipRegistry,
 			},
 		),
+		nil,
 		sccp,
 		pr,
 		peer.DefaultSupport,
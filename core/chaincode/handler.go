@@ -145,6 +145,8 @@ type Handler struct {
 	QueryResponseBuilder QueryResponseBuilder
 	// LedgerGetter is used to get the ledger associated with a channel
 	LedgerGetter LedgerGetter
+	// DeployedCCInfoProvider is used to retrieve the collections of the chaincodes
+	DeployedCCInfoProvider ledger.DeployedChaincodeInfoProvider
 	// UUIDGenerator is used to generate UUIDs
 	UUIDGenerator UUIDGenerator
 	// AppConfig is used to retrieve the application config for a channel
@@ -934,15 +936,35 @@ func (h *Handler) HandleGetHistoryForKey(msg *pb.ChaincodeMessage, txContext *Tr
 		return nil, errors.Wrap(err, "unmarshal failed")
 	}
 
-	historyIter, err := txContext.HistoryQueryExecutor.GetHistoryForKey(chaincodeName, getHistoryForKey.Key)
+	metadata, err := getQueryMetadataFromBytes(getHistoryForKey.Metadata)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, err
 	}
 
-	totalReturnLimit := calculateTotalReturnLimit(nil)
+	totalReturnLimit := calculateTotalReturnLimit(metadata)
+	isPaginated := false
+
+	var historyIter commonledger.ResultsIterator
+	if isMetadataSetForPagination(metadata) || isHistoryRangeSet(getHistoryForKey) {
+		queryInfo := map[string]interface{}{}
+		if isMetadataSetForPagination(metadata) {
+			queryInfo, err = createPaginationInfoFromMetadata(metadata, totalReturnLimit, pb.ChaincodeMessage_GET_HISTORY_FOR_KEY)
+			if err != nil {
+				return nil, err
+			}
+			isPaginated = true
+		}
+		addHistoryRangeToQueryInfo(queryInfo, getHistoryForKey)
+		historyIter, err = txContext.HistoryQueryExecutor.GetHistoryForKeyWithMetadata(chaincodeName, getHistoryForKey.Key, queryInfo)
+	} else {
+		historyIter, err = txContext.HistoryQueryExecutor.GetHistoryForKey(chaincodeName, getHistoryForKey.Key)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 
 	txContext.InitializeQueryContext(iterID, historyIter)
-	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, historyIter, iterID, false, totalReturnLimit)
+	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, historyIter, iterID, isPaginated, totalReturnLimit)
 	if err != nil {
 		txContext.CleanupQueryContext(iterID)
 		return nil, errors.WithStack(err)
@@ -962,6 +984,24 @@ func isCollectionSet(collection string) bool {
 	return collection != ""
 }
 
+func isHistoryRangeSet(getHistoryForKey *pb.GetHistoryForKey) bool {
+	return getHistoryForKey.StartBlock != 0 || getHistoryForKey.EndBlock != 0 || getHistoryForKey.Reverse
+}
+
+// addHistoryRangeToQueryInfo adds the block range and the order requested
+// for a history query to the metadata passed to the history query executor
+func addHistoryRangeToQueryInfo(queryInfo map[string]interface{}, getHistoryForKey *pb.GetHistoryForKey) {
+	if getHistoryForKey.StartBlock != 0 {
+		queryInfo["startBlock"] = getHistoryForKey.StartBlock
+	}
+	if getHistoryForKey.EndBlock != 0 {
+		queryInfo["endBlock"] = getHistoryForKey.EndBlock
+	}
+	if getHistoryForKey.Reverse {
+		queryInfo["reverse"] = true
+	}
+}
+
 func isMetadataSetForPagination(metadata *pb.QueryMetadata) bool {
 	if metadata == nil {
 		return false
@@ -990,12 +1030,12 @@ func createPaginationInfoFromMetadata(metadata *pb.QueryMetadata, totalReturnLim
 	paginationInfoMap := make(map[string]interface{})
 
 	switch queryType {
-	case pb.ChaincodeMessage_GET_QUERY_RESULT:
+	case pb.ChaincodeMessage_GET_QUERY_RESULT, pb.ChaincodeMessage_GET_HISTORY_FOR_KEY:
 		paginationInfoMap["bookmark"] = metadata.Bookmark
 	case pb.ChaincodeMessage_GET_STATE_BY_RANGE:
 		// this is a no-op for range query
 	default:
-		return nil, errors.New("query type must be either GetQueryResult or GetStateByRange")
+		return nil, errors.New("query type must be either GetQueryResult, GetStateByRange or GetHistoryForKey")
 	}
 
 	paginationInfoMap["limit"] = totalReturnLimit
@@ -1207,9 +1247,12 @@ func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *Tra
 
 		version = cd.CCVersion()
 
-		err = h.InstantiationPolicyChecker.CheckInstantiationPolicy(targetInstance.ChaincodeName, version, cd.(*ccprovider.ChaincodeData))
-		if err != nil {
-			return nil, errors.WithStack(err)
+		// the chaincode defined through the new lifecycle has no instantiation policy
+		if cData, ok := cd.(*ccprovider.ChaincodeData); ok {
+			err = h.InstantiationPolicyChecker.CheckInstantiationPolicy(targetInstance.ChaincodeName, version, cData)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
 		}
 	}
 
@@ -1288,7 +1331,7 @@ func (h *Handler) getCollectionStore(channelID string) privdata.CollectionStore
 	csStoreSupport := &peer.CollectionSupport{
 		PeerLedger: h.LedgerGetter.GetLedger(channelID),
 	}
-	return privdata.NewSimpleCollectionStore(csStoreSupport)
+	return privdata.NewSimpleCollectionStore(csStoreSupport, h.DeployedCCInfoProvider)
 
 }
 
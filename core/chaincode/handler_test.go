@@ -17,10 +17,12 @@ import (
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/fake"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/mock"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -2000,6 +2002,108 @@ var _ = Describe("Handler", func() {
 			Expect(iterID).To(Equal("generated-query-id"))
 		})
 
+		Context("when a block range is requested", func() {
+			BeforeEach(func() {
+				request.StartBlock = 5
+				request.EndBlock = 10
+				request.Reverse = true
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+
+				fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataReturns(fakeIterator, nil)
+			})
+
+			It("calls GetHistoryForKeyWithMetadata on the history query executor", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyCallCount()).To(Equal(0))
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataCallCount()).To(Equal(1))
+				ccname, key, metadata := fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataArgsForCall(0)
+				Expect(ccname).To(Equal("cc-instance-name"))
+				Expect(key).To(Equal("history-key"))
+				Expect(metadata).To(Equal(map[string]interface{}{
+					"startBlock": uint64(5),
+					"endBlock":   uint64(10),
+					"reverse":    true,
+				}))
+			})
+
+			It("builds a query response without pagination", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+				_, iter, _, isPaginated, totalReturnLimit := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+				Expect(iter).To(Equal(fakeIterator))
+				Expect(isPaginated).To(BeFalse())
+				Expect(totalReturnLimit).To(Equal(int32(10000)))
+			})
+
+			Context("when the history query executor fails", func() {
+				BeforeEach(func() {
+					fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataReturns(nil, errors.New("anchovies"))
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).To(MatchError("anchovies"))
+				})
+			})
+		})
+
+		Context("when pagination is requested", func() {
+			BeforeEach(func() {
+				metadata, err := proto.Marshal(&pb.QueryMetadata{PageSize: 20, Bookmark: "0102"})
+				Expect(err).NotTo(HaveOccurred())
+				request.StartBlock = 5
+				request.Metadata = metadata
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+
+				fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataReturns(fakeIterator, nil)
+			})
+
+			It("passes the page size and the bookmark to the history query executor", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataCallCount()).To(Equal(1))
+				_, _, metadata := fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataArgsForCall(0)
+				Expect(metadata).To(Equal(map[string]interface{}{
+					"startBlock": uint64(5),
+					"limit":      int32(20),
+					"bookmark":   "0102",
+				}))
+			})
+
+			It("builds a paginated query response", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+				_, _, _, isPaginated, totalReturnLimit := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+				Expect(isPaginated).To(BeTrue())
+				Expect(totalReturnLimit).To(Equal(int32(20)))
+			})
+
+			Context("when unmarshalling the metadata fails", func() {
+				BeforeEach(func() {
+					request.Metadata = []byte("this-is-a-bogus-payload")
+					payload, err := proto.Marshal(request)
+					Expect(err).NotTo(HaveOccurred())
+					incomingMessage.Payload = payload
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+				})
+			})
+		})
+
 		Context("when unmarshalling the request fails", func() {
 			BeforeEach(func() {
 				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
@@ -2335,6 +2439,21 @@ var _ = Describe("Handler", func() {
 				})
 			})
 
+			Context("when the target is defined through the new lifecycle", func() {
+				BeforeEach(func() {
+					fakeDefinitionGetter.ChaincodeDefinitionReturns(&lifecycle.DefinedChaincode{
+						Name:                "target-chaincode-name",
+						ChaincodeDefinition: &lb.ChaincodeDefinition{Version: "target-chaincode-version"},
+					}, nil)
+				})
+
+				It("does not check an instantiation policy", func() {
+					_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeInstantiationPolicyChecker.CheckInstantiationPolicyCallCount()).To(Equal(0))
+				})
+			})
+
 			Context("when the instantiation policy evaluation returns an error", func() {
 				BeforeEach(func() {
 					fakeInstantiationPolicyChecker.CheckInstantiationPolicyReturns(errors.New("raspberry-pie"))
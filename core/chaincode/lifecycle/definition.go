@@ -0,0 +1,127 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/pkg/errors"
+)
+
+// LegacyLifecycle is the lifecycle of the chaincode instantiated with lscc
+type LegacyLifecycle interface {
+	ChaincodeDefinition(chaincodeName string, qe ledger.QueryExecutor) (ccprovider.ChaincodeDefinition, error)
+	ChaincodeContainerInfo(chaincodeName string, qe ledger.QueryExecutor) (*ccprovider.ChaincodeContainerInfo, error)
+}
+
+// DefinedChaincode is the definition of a chaincode committed through the new
+// lifecycle. It implements ccprovider.ChaincodeDefinition, so that the chaincode
+// is endorsed, executed and validated as per the committed definition
+type DefinedChaincode struct {
+	Name string
+	*lb.ChaincodeDefinition
+}
+
+// CCName returns the name of the chaincode
+func (dc *DefinedChaincode) CCName() string {
+	return dc.Name
+}
+
+// Hash returns nil, as the installed chaincode an org runs for the definition
+// is not part of the definition the orgs agree on
+func (dc *DefinedChaincode) Hash() []byte {
+	return nil
+}
+
+// CCVersion returns the version of the chaincode
+func (dc *DefinedChaincode) CCVersion() string {
+	return dc.Version
+}
+
+// Validation returns the name of the validation plugin and its argument
+func (dc *DefinedChaincode) Validation() (string, []byte) {
+	return dc.ValidationPlugin, dc.ValidationParameter
+}
+
+// Endorsement returns the name of the endorsement plugin
+func (dc *DefinedChaincode) Endorsement() string {
+	return dc.EndorsementPlugin
+}
+
+// CommittedDefinition returns the definition of the chaincode of a given name
+// committed through the new lifecycle, or nil if no definition has been committed
+func CommittedDefinition(chaincodeName string, qe ledger.SimpleQueryExecutor) (*DefinedChaincode, error) {
+	cd, err := committedDefinition(chaincodeName, &SimpleQueryExecutorShim{
+		Namespace:           LifecycleNamespace,
+		SimpleQueryExecutor: qe,
+	})
+	if err != nil || cd == nil {
+		return nil, err
+	}
+	return &DefinedChaincode{Name: chaincodeName, ChaincodeDefinition: cd}, nil
+}
+
+// ChaincodeDefinition returns the definition of the chaincode of a given name
+// committed through the new lifecycle, or the definition of the chaincode
+// instantiated with lscc if there is no such definition
+func (l *Lifecycle) ChaincodeDefinition(chaincodeName string, qe ledger.QueryExecutor) (ccprovider.ChaincodeDefinition, error) {
+	if qe == nil {
+		return l.LegacyImpl.ChaincodeDefinition(chaincodeName, qe)
+	}
+
+	dc, err := CommittedDefinition(chaincodeName, qe)
+	if err != nil {
+		return nil, err
+	}
+	if dc == nil {
+		return l.LegacyImpl.ChaincodeDefinition(chaincodeName, qe)
+	}
+	return dc, nil
+}
+
+// ChaincodeContainerInfo returns the info necessary to launch the chaincode of a
+// given name. The chaincode defined through the new lifecycle is launched from the
+// chaincode installed with the name and version of the committed definition
+func (l *Lifecycle) ChaincodeContainerInfo(chaincodeName string, qe ledger.QueryExecutor) (*ccprovider.ChaincodeContainerInfo, error) {
+	if qe == nil {
+		return l.LegacyImpl.ChaincodeContainerInfo(chaincodeName, qe)
+	}
+
+	dc, err := CommittedDefinition(chaincodeName, qe)
+	if err != nil {
+		return nil, err
+	}
+	if dc == nil {
+		return l.LegacyImpl.ChaincodeContainerInfo(chaincodeName, qe)
+	}
+
+	hash, err := l.ChaincodeStore.RetrieveHash(chaincodeName, dc.Version)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("chaincode '%s:%s' is defined but could not be found among the installed chaincodes", chaincodeName, dc.Version))
+	}
+	ccInstallPkg, _, _, err := l.ChaincodeStore.Load(hash)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not load installed chaincode '%s:%s'", chaincodeName, dc.Version))
+	}
+	ccPackage, err := l.PackageParser.Parse(ccInstallPkg)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not parse installed chaincode '%s:%s'", chaincodeName, dc.Version))
+	}
+
+	return &ccprovider.ChaincodeContainerInfo{
+		Name:          chaincodeName,
+		Version:       dc.Version,
+		Path:          ccPackage.Metadata.Path,
+		Type:          strings.ToUpper(ccPackage.Metadata.Type),
+		ContainerType: pb.ChaincodeDeploymentSpec_DOCKER.String(),
+	}, nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle_test
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Definition", func() {
+	var (
+		l               *lifecycle.Lifecycle
+		fakeCCStore     *mock.ChaincodeStore
+		fakeParser      *mock.PackageParser
+		fakeLegacyImpl  *mock.LegacyLifecycle
+		fakeQueryExec   *mock.QueryExecutor
+		publicKVS       map[string][]byte
+		legacyCCDef     *ccprovider.ChaincodeData
+		legacyContainer *ccprovider.ChaincodeContainerInfo
+	)
+
+	BeforeEach(func() {
+		fakeCCStore = &mock.ChaincodeStore{}
+		fakeParser = &mock.PackageParser{}
+		fakeLegacyImpl = &mock.LegacyLifecycle{}
+
+		legacyCCDef = &ccprovider.ChaincodeData{Name: "legacy-cc"}
+		fakeLegacyImpl.ChaincodeDefinitionReturns(legacyCCDef, nil)
+		legacyContainer = &ccprovider.ChaincodeContainerInfo{Name: "legacy-cc"}
+		fakeLegacyImpl.ChaincodeContainerInfoReturns(legacyContainer, nil)
+
+		publicKVS = map[string][]byte{}
+		fakeQueryExec = &mock.QueryExecutor{}
+		fakeQueryExec.GetStateStub = func(namespace, key string) ([]byte, error) {
+			if namespace != "+lifecycle" {
+				return nil, nil
+			}
+			return publicKVS[key], nil
+		}
+
+		l = &lifecycle.Lifecycle{
+			PackageParser:  fakeParser,
+			ChaincodeStore: fakeCCStore,
+			LegacyImpl:     fakeLegacyImpl,
+		}
+	})
+
+	Describe("ChaincodeDefinition", func() {
+		BeforeEach(func() {
+			publicKVS["namespaces/definitions/cc-name"] = marshal(&lb.ChaincodeDefinition{
+				Sequence:            2,
+				Version:             "version",
+				EndorsementPlugin:   "endorsement-plugin",
+				ValidationPlugin:    "validation-plugin",
+				ValidationParameter: []byte("validation-parameter"),
+			})
+		})
+
+		It("returns the committed definition", func() {
+			def, err := l.ChaincodeDefinition("cc-name", fakeQueryExec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(def.CCName()).To(Equal("cc-name"))
+			Expect(def.CCVersion()).To(Equal("version"))
+			Expect(def.Hash()).To(BeNil())
+			Expect(def.Endorsement()).To(Equal("endorsement-plugin"))
+			plugin, arg := def.Validation()
+			Expect(plugin).To(Equal("validation-plugin"))
+			Expect(arg).To(Equal([]byte("validation-parameter")))
+			Expect(fakeLegacyImpl.ChaincodeDefinitionCallCount()).To(Equal(0))
+		})
+
+		Context("when the chaincode has no committed definition", func() {
+			It("returns the definition of the legacy lifecycle", func() {
+				def, err := l.ChaincodeDefinition("legacy-cc", fakeQueryExec)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(def).To(Equal(legacyCCDef))
+				Expect(fakeLegacyImpl.ChaincodeDefinitionCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the committed definition cannot be read", func() {
+			BeforeEach(func() {
+				fakeQueryExec.GetStateStub = nil
+				fakeQueryExec.GetStateReturns(nil, fmt.Errorf("state-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := l.ChaincodeDefinition("cc-name", fakeQueryExec)
+				Expect(err).To(MatchError("could not fetch committed definition for chaincode 'cc-name': state-error"))
+			})
+		})
+	})
+
+	Describe("ChaincodeContainerInfo", func() {
+		BeforeEach(func() {
+			publicKVS["namespaces/definitions/cc-name"] = marshal(&lb.ChaincodeDefinition{
+				Sequence: 2,
+				Version:  "version",
+			})
+			fakeCCStore.RetrieveHashReturns([]byte("hash"), nil)
+			fakeCCStore.LoadReturns([]byte("cc-install-package"), "cc-name", "version", nil)
+			fakeParser.ParseReturns(&persistence.ChaincodePackage{
+				Metadata: &persistence.ChaincodePackageMetadata{
+					Type: "golang",
+					Path: "cc-path",
+				},
+			}, nil)
+		})
+
+		It("returns the info of the installed chaincode of the committed definition", func() {
+			ccci, err := l.ChaincodeContainerInfo("cc-name", fakeQueryExec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ccci).To(Equal(&ccprovider.ChaincodeContainerInfo{
+				Name:          "cc-name",
+				Version:       "version",
+				Path:          "cc-path",
+				Type:          "GOLANG",
+				ContainerType: "DOCKER",
+			}))
+
+			name, version := fakeCCStore.RetrieveHashArgsForCall(0)
+			Expect(name).To(Equal("cc-name"))
+			Expect(version).To(Equal("version"))
+			Expect(fakeCCStore.LoadArgsForCall(0)).To(Equal([]byte("hash")))
+			Expect(fakeParser.ParseArgsForCall(0)).To(Equal([]byte("cc-install-package")))
+		})
+
+		Context("when the chaincode has no committed definition", func() {
+			It("returns the info of the legacy lifecycle", func() {
+				ccci, err := l.ChaincodeContainerInfo("legacy-cc", fakeQueryExec)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ccci).To(Equal(legacyContainer))
+				Expect(fakeCCStore.RetrieveHashCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the chaincode of the definition is not installed", func() {
+			BeforeEach(func() {
+				fakeCCStore.RetrieveHashReturns(nil, fmt.Errorf("not-found"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := l.ChaincodeContainerInfo("cc-name", fakeQueryExec)
+				Expect(err).To(MatchError("chaincode 'cc-name:version' is defined but could not be found among the installed chaincodes: not-found"))
+			})
+		})
+
+		Context("when the installed chaincode cannot be loaded", func() {
+			BeforeEach(func() {
+				fakeCCStore.LoadReturns(nil, "", "", fmt.Errorf("load-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := l.ChaincodeContainerInfo("cc-name", fakeQueryExec)
+				Expect(err).To(MatchError("could not load installed chaincode 'cc-name:version': load-error"))
+			})
+		})
+
+		Context("when the installed chaincode cannot be parsed", func() {
+			BeforeEach(func() {
+				fakeParser.ParseReturns(nil, fmt.Errorf("parse-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := l.ChaincodeContainerInfo("cc-name", fakeQueryExec)
+				Expect(err).To(MatchError("could not parse installed chaincode 'cc-name:version': parse-error"))
+			})
+		})
+	})
+})
@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"strings"
+
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+)
+
+// DeployedCCInfoProvider implements interface ledger.DeployedChaincodeInfoProvider
+// for the chaincode defined through the new lifecycle, and falls back to the legacy
+// provider for the chaincode instantiated with lscc
+type DeployedCCInfoProvider struct {
+	LegacyDeployedCCInfoProvider ledger.DeployedChaincodeInfoProvider
+}
+
+// Namespaces implements function in interface ledger.DeployedChaincodeInfoProvider
+func (p *DeployedCCInfoProvider) Namespaces() []string {
+	return append([]string{LifecycleNamespace}, p.LegacyDeployedCCInfoProvider.Namespaces()...)
+}
+
+// UpdatedChaincodes implements function in interface ledger.DeployedChaincodeInfoProvider
+func (p *DeployedCCInfoProvider) UpdatedChaincodes(stateUpdates map[string][]*kvrwset.KVWrite) ([]*ledger.ChaincodeLifecycleInfo, error) {
+	lifecycleInfo, err := p.LegacyDeployedCCInfoProvider.UpdatedChaincodes(stateUpdates)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := committedDefinitionKey("")
+	for _, kvWrite := range stateUpdates[LifecycleNamespace] {
+		if kvWrite.IsDelete || !strings.HasPrefix(kvWrite.Key, prefix) {
+			continue
+		}
+		// the keys of the definitions approved by the orgs carry the sequence
+		// after a '#', only the committed definitions are keyed by the name
+		ccname := strings.TrimPrefix(kvWrite.Key, prefix)
+		if strings.Contains(ccname, "#") {
+			continue
+		}
+		lifecycleInfo = append(lifecycleInfo, &ledger.ChaincodeLifecycleInfo{Name: ccname})
+	}
+	return lifecycleInfo, nil
+}
+
+// ChaincodeInfo implements function in interface ledger.DeployedChaincodeInfoProvider
+func (p *DeployedCCInfoProvider) ChaincodeInfo(chaincodeName string, qe ledger.SimpleQueryExecutor) (*ledger.DeployedChaincodeInfo, error) {
+	dc, err := CommittedDefinition(chaincodeName, qe)
+	if err != nil {
+		return nil, err
+	}
+	if dc == nil {
+		return p.LegacyDeployedCCInfoProvider.ChaincodeInfo(chaincodeName, qe)
+	}
+	return &ledger.DeployedChaincodeInfo{
+		Name:                chaincodeName,
+		Version:             dc.Version,
+		CollectionConfigPkg: dc.Collections,
+	}, nil
+}
+
+// CollectionInfo implements function in interface ledger.DeployedChaincodeInfoProvider
+func (p *DeployedCCInfoProvider) CollectionInfo(chaincodeName, collectionName string, qe ledger.SimpleQueryExecutor) (*common.StaticCollectionConfig, error) {
+	if mspID, ok := privdata.MSPIDIfImplicitCollection(collectionName); ok {
+		return privdata.GenerateImplicitCollectionForOrg(mspID), nil
+	}
+	dc, err := CommittedDefinition(chaincodeName, qe)
+	if err != nil {
+		return nil, err
+	}
+	if dc == nil {
+		return p.LegacyDeployedCCInfoProvider.CollectionInfo(chaincodeName, collectionName, qe)
+	}
+	for _, conf := range dc.Collections.GetConfig() {
+		staticCollConfig := conf.GetStaticCollectionConfig()
+		if staticCollConfig != nil && staticCollConfig.Name == collectionName {
+			return staticCollConfig, nil
+		}
+	}
+	return nil, nil
+}
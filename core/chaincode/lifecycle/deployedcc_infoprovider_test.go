@@ -0,0 +1,138 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle_test
+
+import (
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
+	"github.com/hyperledger/fabric/core/ledger"
+	ledgermock "github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeployedCCInfoProvider", func() {
+	var (
+		provider       *lifecycle.DeployedCCInfoProvider
+		fakeLegacy     *ledgermock.DeployedChaincodeInfoProvider
+		fakeQueryExec  *mock.QueryExecutor
+		publicKVS      map[string][]byte
+		collections    *common.CollectionConfigPackage
+		legacyCollInfo *common.StaticCollectionConfig
+	)
+
+	BeforeEach(func() {
+		fakeLegacy = &ledgermock.DeployedChaincodeInfoProvider{}
+		fakeLegacy.NamespacesReturns([]string{"lscc"})
+		fakeLegacy.UpdatedChaincodesReturns([]*ledger.ChaincodeLifecycleInfo{{Name: "legacy-cc"}}, nil)
+		fakeLegacy.ChaincodeInfoReturns(&ledger.DeployedChaincodeInfo{Name: "legacy-cc"}, nil)
+		legacyCollInfo = &common.StaticCollectionConfig{Name: "legacy-coll"}
+		fakeLegacy.CollectionInfoReturns(legacyCollInfo, nil)
+
+		collections = &common.CollectionConfigPackage{
+			Config: []*common.CollectionConfig{{
+				Payload: &common.CollectionConfig_StaticCollectionConfig{
+					StaticCollectionConfig: &common.StaticCollectionConfig{Name: "coll1"},
+				},
+			}},
+		}
+		publicKVS = map[string][]byte{
+			"namespaces/definitions/cc-name": marshal(&lb.ChaincodeDefinition{
+				Sequence:    1,
+				Version:     "version",
+				Collections: collections,
+			}),
+		}
+		fakeQueryExec = &mock.QueryExecutor{}
+		fakeQueryExec.GetStateStub = func(namespace, key string) ([]byte, error) {
+			if namespace != "+lifecycle" {
+				return nil, nil
+			}
+			return publicKVS[key], nil
+		}
+
+		provider = &lifecycle.DeployedCCInfoProvider{
+			LegacyDeployedCCInfoProvider: fakeLegacy,
+		}
+	})
+
+	Describe("Namespaces", func() {
+		It("returns the lifecycle and the legacy namespaces", func() {
+			Expect(provider.Namespaces()).To(Equal([]string{"+lifecycle", "lscc"}))
+		})
+	})
+
+	Describe("UpdatedChaincodes", func() {
+		It("returns the chaincodes whose definition is committed along with the legacy ones", func() {
+			updates := map[string][]*kvrwset.KVWrite{
+				"+lifecycle": {
+					{Key: "namespaces/definitions/cc-name"},
+					{Key: "namespaces/definitions/cc-name#2"},
+					{Key: "chaincode-sources/cc-name#2"},
+				},
+			}
+			infos, err := provider.UpdatedChaincodes(updates)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(infos).To(Equal([]*ledger.ChaincodeLifecycleInfo{
+				{Name: "legacy-cc"},
+				{Name: "cc-name"},
+			}))
+			Expect(fakeLegacy.UpdatedChaincodesArgsForCall(0)).To(Equal(updates))
+		})
+	})
+
+	Describe("ChaincodeInfo", func() {
+		It("returns the info of the committed definition", func() {
+			info, err := provider.ChaincodeInfo("cc-name", fakeQueryExec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Name).To(Equal("cc-name"))
+			Expect(info.Version).To(Equal("version"))
+			Expect(info.CollectionConfigPkg.Config).To(HaveLen(1))
+			Expect(fakeLegacy.ChaincodeInfoCallCount()).To(Equal(0))
+		})
+
+		Context("when the chaincode has no committed definition", func() {
+			It("returns the info of the legacy provider", func() {
+				info, err := provider.ChaincodeInfo("legacy-cc", fakeQueryExec)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info).To(Equal(&ledger.DeployedChaincodeInfo{Name: "legacy-cc"}))
+			})
+		})
+	})
+
+	Describe("CollectionInfo", func() {
+		It("returns the collection of the committed definition", func() {
+			collInfo, err := provider.CollectionInfo("cc-name", "coll1", fakeQueryExec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(collInfo.Name).To(Equal("coll1"))
+			Expect(fakeLegacy.CollectionInfoCallCount()).To(Equal(0))
+		})
+
+		It("returns nil for a collection missing from the committed definition", func() {
+			collInfo, err := provider.CollectionInfo("cc-name", "coll2", fakeQueryExec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(collInfo).To(BeNil())
+		})
+
+		It("generates the implicit collections of the orgs", func() {
+			collInfo, err := provider.CollectionInfo("cc-name", "_implicit_org_org0", fakeQueryExec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(collInfo.Name).To(Equal("_implicit_org_org0"))
+		})
+
+		Context("when the chaincode has no committed definition", func() {
+			It("returns the collection of the legacy provider", func() {
+				collInfo, err := provider.CollectionInfo("legacy-cc", "legacy-coll", fakeQueryExec)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(collInfo).To(Equal(legacyCollInfo))
+			})
+		})
+	})
+})
@@ -7,16 +7,36 @@ SPDX-License-Identifier: Apache-2.0
 package lifecycle
 
 import (
+	"bytes"
 	"fmt"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	"github.com/pkg/errors"
 )
 
+const (
+	// LifecycleNamespace is the namespace in the ledger of the state of
+	// the lifecycle system chaincode
+	LifecycleNamespace = "+lifecycle"
+
+	// NamespacesName is the prefix of the keys under which the chaincode
+	// definitions are stored, both in the public state once committed and
+	// in the implicit collection of an org once approved by the org
+	NamespacesName = "namespaces"
+
+	// ChaincodeSourcesName is the prefix of the keys under which an org
+	// stores the installed chaincode it runs for an approved definition
+	ChaincodeSourcesName = "chaincode-sources"
+)
+
 // ChaincodeStore provides a way to persist chaincodes
 type ChaincodeStore interface {
 	Save(name, version string, ccInstallPkg []byte) (hash []byte, err error)
 	RetrieveHash(name, version string) (hash []byte, err error)
+	Load(hash []byte) (ccInstallPkg []byte, name, version string, err error)
 }
 
 type PackageParser interface {
@@ -28,6 +48,10 @@ type PackageParser interface {
 type Lifecycle struct {
 	ChaincodeStore ChaincodeStore
 	PackageParser  PackageParser
+
+	// LegacyImpl provides the chaincode that has no definition committed
+	// through the new lifecycle, i.e. the chaincode instantiated with lscc
+	LegacyImpl LegacyLifecycle
 }
 
 // InstallChaincode installs a given chaincode to the peer's chaincode store.
@@ -56,3 +80,144 @@ func (l *Lifecycle) QueryInstalledChaincode(name, version string) ([]byte, error
 
 	return hash, nil
 }
+
+// ApproveChaincodeDefinitionForOrg adds a chaincode definition entry into the passed in Org state.
+// The definition must be for the sequence following the sequence of the committed definition.
+// The hash of the installed chaincode the org runs for the definition is stored separately, as it
+// is not part of the definition the orgs have to agree on.
+func (l *Lifecycle) ApproveChaincodeDefinitionForOrg(name string, cd *lb.ChaincodeDefinition, hash []byte, publicState ReadableState, orgState ReadWritableState) error {
+	if err := checkSequence(name, cd, publicState); err != nil {
+		return err
+	}
+
+	cdBytes, err := proto.Marshal(cd)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal chaincode definition")
+	}
+	if err := orgState.PutState(approvedDefinitionKey(name, cd.Sequence), cdBytes); err != nil {
+		return errors.WithMessage(err, "could not write chaincode definition")
+	}
+
+	localPackageBytes, err := proto.Marshal(&lb.ChaincodeLocalPackage{Hash: hash})
+	if err != nil {
+		return errors.Wrap(err, "could not marshal chaincode local package")
+	}
+	if err := orgState.PutState(chaincodeSourceKey(name, cd.Sequence), localPackageBytes); err != nil {
+		return errors.WithMessage(err, "could not write chaincode local package")
+	}
+
+	return nil
+}
+
+// CheckCommitReadiness returns, for each of the orgs whose state is passed in, whether the
+// org has approved the given chaincode definition.
+func (l *Lifecycle) CheckCommitReadiness(name string, cd *lb.ChaincodeDefinition, publicState ReadableState, orgStates map[string]OpaqueState) (map[string]bool, error) {
+	if err := checkSequence(name, cd, publicState); err != nil {
+		return nil, err
+	}
+	return approvals(name, cd, orgStates)
+}
+
+// CommitChaincodeDefinition commits the given chaincode definition into the public state and
+// returns, for each of the orgs whose state is passed in, whether the org has approved the
+// definition. Whether enough orgs agree to the definition is not decided here: the transaction
+// committing the definition is valid only if its endorsements satisfy the LifecycleEndorsement
+// policy of the channel, and the peer of an org endorses the commit only if the org approved.
+func (l *Lifecycle) CommitChaincodeDefinition(name string, cd *lb.ChaincodeDefinition, publicState ReadWritableState, orgStates map[string]OpaqueState) (map[string]bool, error) {
+	if err := checkSequence(name, cd, publicState); err != nil {
+		return nil, err
+	}
+
+	orgApprovals, err := approvals(name, cd, orgStates)
+	if err != nil {
+		return nil, err
+	}
+
+	cdBytes, err := proto.Marshal(cd)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal chaincode definition")
+	}
+	if err := publicState.PutState(committedDefinitionKey(name), cdBytes); err != nil {
+		return nil, errors.WithMessage(err, "could not write chaincode definition")
+	}
+
+	return orgApprovals, nil
+}
+
+// QueryChaincodeDefinition returns the committed definition of the chaincode of a given name.
+func (l *Lifecycle) QueryChaincodeDefinition(name string, publicState ReadableState) (*lb.ChaincodeDefinition, error) {
+	cd, err := committedDefinition(name, publicState)
+	if err != nil {
+		return nil, err
+	}
+	if cd == nil {
+		return nil, errors.Errorf("chaincode '%s' has no committed definition", name)
+	}
+	return cd, nil
+}
+
+// committedDefinition returns the committed definition of the chaincode of
+// a given name, or nil if no definition has been committed for the chaincode
+func committedDefinition(name string, publicState ReadableState) (*lb.ChaincodeDefinition, error) {
+	cdBytes, err := publicState.GetState(committedDefinitionKey(name))
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not fetch committed definition for chaincode '%s'", name))
+	}
+	if cdBytes == nil {
+		return nil, nil
+	}
+	cd := &lb.ChaincodeDefinition{}
+	if err := proto.Unmarshal(cdBytes, cd); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal committed definition for chaincode '%s'", name)
+	}
+	return cd, nil
+}
+
+// checkSequence verifies that the given definition is for the sequence
+// following the sequence of the committed definition of the chaincode
+func checkSequence(name string, cd *lb.ChaincodeDefinition, publicState ReadableState) error {
+	committed, err := committedDefinition(name, publicState)
+	if err != nil {
+		return err
+	}
+	requiredSequence := int64(1)
+	if committed != nil {
+		requiredSequence = committed.Sequence + 1
+	}
+	if cd.Sequence != requiredSequence {
+		return errors.Errorf("requested sequence is %d, but new definition must be sequence %d", cd.Sequence, requiredSequence)
+	}
+	return nil
+}
+
+// approvals returns, for each of the given orgs, whether the hash of the
+// definition approved by the org matches the hash of the given definition
+func approvals(name string, cd *lb.ChaincodeDefinition, orgStates map[string]OpaqueState) (map[string]bool, error) {
+	cdBytes, err := proto.Marshal(cd)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal chaincode definition")
+	}
+	cdHash := util.ComputeSHA256(cdBytes)
+
+	result := map[string]bool{}
+	for mspID, orgState := range orgStates {
+		approvedHash, err := orgState.GetStateHash(approvedDefinitionKey(name, cd.Sequence))
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("could not fetch approved definition hash for org '%s'", mspID))
+		}
+		result[mspID] = bytes.Equal(approvedHash, cdHash)
+	}
+	return result, nil
+}
+
+func committedDefinitionKey(name string) string {
+	return fmt.Sprintf("%s/definitions/%s", NamespacesName, name)
+}
+
+func approvedDefinitionKey(name string, sequence int64) string {
+	return fmt.Sprintf("%s/definitions/%s#%d", NamespacesName, name, sequence)
+}
+
+func chaincodeSourceKey(name string, sequence int64) string {
+	return fmt.Sprintf("%s/%s#%d", ChaincodeSourcesName, name, sequence)
+}
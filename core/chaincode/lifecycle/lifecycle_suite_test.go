@@ -11,6 +11,7 @@ import (
 
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/ledger"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -35,6 +36,36 @@ type sccFunctions interface {
 	lifecycle.SCCFunctions
 }
 
+//go:generate counterfeiter -o mock/readable_state.go --fake-name ReadableState . readableState
+type readableState interface {
+	lifecycle.ReadableState
+}
+
+//go:generate counterfeiter -o mock/read_writable_state.go --fake-name ReadWritableState . readWritableState
+type readWritableState interface {
+	lifecycle.ReadWritableState
+}
+
+//go:generate counterfeiter -o mock/opaque_state.go --fake-name OpaqueState . opaqueState
+type opaqueState interface {
+	lifecycle.OpaqueState
+}
+
+//go:generate counterfeiter -o mock/channel_orgs_source.go --fake-name ChannelOrgsSource . channelOrgsSource
+type channelOrgsSource interface {
+	lifecycle.ChannelOrgsSource
+}
+
+//go:generate counterfeiter -o mock/legacy_lifecycle.go --fake-name LegacyLifecycle . legacyLifecycle
+type legacyLifecycle interface {
+	lifecycle.LegacyLifecycle
+}
+
+//go:generate counterfeiter -o mock/query_executor.go --fake-name QueryExecutor . queryExecutor
+type queryExecutor interface {
+	ledger.QueryExecutor
+}
+
 func TestLifecycle(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Lifecycle Suite")
@@ -9,8 +9,11 @@ package lifecycle_test
 import (
 	"fmt"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -103,4 +106,268 @@ var _ = Describe("Lifecycle", func() {
 			})
 		})
 	})
+
+	Describe("ApproveChaincodeDefinitionForOrg", func() {
+		var (
+			fakePublicState *mock.ReadWritableState
+			fakeOrgState    *mock.ReadWritableState
+			publicKVS       map[string][]byte
+			orgKVS          map[string][]byte
+			cd              *lb.ChaincodeDefinition
+		)
+
+		BeforeEach(func() {
+			publicKVS, fakePublicState = newFakeState()
+			orgKVS, fakeOrgState = newFakeState()
+
+			cd = &lb.ChaincodeDefinition{
+				Sequence:            5,
+				Version:             "version",
+				EndorsementPlugin:   "endorsement-plugin",
+				ValidationPlugin:    "validation-plugin",
+				ValidationParameter: []byte("validation-parameter"),
+			}
+			publicKVS["namespaces/definitions/cc-name"] = marshal(&lb.ChaincodeDefinition{Sequence: 4})
+		})
+
+		It("records the definition and the installed chaincode in the org state", func() {
+			err := l.ApproveChaincodeDefinitionForOrg("cc-name", cd, []byte("hash"), fakePublicState, fakeOrgState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(orgKVS).To(HaveLen(2))
+			Expect(orgKVS["namespaces/definitions/cc-name#5"]).To(Equal(marshal(cd)))
+			Expect(orgKVS["chaincode-sources/cc-name#5"]).To(Equal(marshal(&lb.ChaincodeLocalPackage{Hash: []byte("hash")})))
+		})
+
+		Context("when no definition has been committed for the chaincode", func() {
+			BeforeEach(func() {
+				delete(publicKVS, "namespaces/definitions/cc-name")
+				cd.Sequence = 1
+			})
+
+			It("requires the first sequence", func() {
+				err := l.ApproveChaincodeDefinitionForOrg("cc-name", cd, []byte("hash"), fakePublicState, fakeOrgState)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(orgKVS).To(HaveKey("namespaces/definitions/cc-name#1"))
+			})
+		})
+
+		Context("when the sequence is not the next sequence", func() {
+			BeforeEach(func() {
+				cd.Sequence = 4
+			})
+
+			It("returns an error", func() {
+				err := l.ApproveChaincodeDefinitionForOrg("cc-name", cd, []byte("hash"), fakePublicState, fakeOrgState)
+				Expect(err).To(MatchError("requested sequence is 4, but new definition must be sequence 5"))
+			})
+		})
+
+		Context("when the committed definition cannot be read", func() {
+			BeforeEach(func() {
+				fakePublicState.GetStateReturns(nil, fmt.Errorf("state-error"))
+				fakePublicState.GetStateStub = nil
+			})
+
+			It("wraps and returns the error", func() {
+				err := l.ApproveChaincodeDefinitionForOrg("cc-name", cd, []byte("hash"), fakePublicState, fakeOrgState)
+				Expect(err).To(MatchError("could not fetch committed definition for chaincode 'cc-name': state-error"))
+			})
+		})
+
+		Context("when the committed definition is malformed", func() {
+			BeforeEach(func() {
+				publicKVS["namespaces/definitions/cc-name"] = []byte("garbage")
+			})
+
+			It("wraps and returns the error", func() {
+				err := l.ApproveChaincodeDefinitionForOrg("cc-name", cd, []byte("hash"), fakePublicState, fakeOrgState)
+				Expect(err.Error()).To(HavePrefix("could not unmarshal committed definition for chaincode 'cc-name'"))
+			})
+		})
+
+		Context("when writing the definition fails", func() {
+			BeforeEach(func() {
+				fakeOrgState.PutStateReturns(fmt.Errorf("put-error"))
+				fakeOrgState.PutStateStub = nil
+			})
+
+			It("wraps and returns the error", func() {
+				err := l.ApproveChaincodeDefinitionForOrg("cc-name", cd, []byte("hash"), fakePublicState, fakeOrgState)
+				Expect(err).To(MatchError("could not write chaincode definition: put-error"))
+			})
+		})
+
+		Context("when writing the installed chaincode fails", func() {
+			BeforeEach(func() {
+				fakeOrgState.PutStateReturnsOnCall(1, fmt.Errorf("put-error"))
+				fakeOrgState.PutStateStub = nil
+			})
+
+			It("wraps and returns the error", func() {
+				err := l.ApproveChaincodeDefinitionForOrg("cc-name", cd, []byte("hash"), fakePublicState, fakeOrgState)
+				Expect(err).To(MatchError("could not write chaincode local package: put-error"))
+			})
+		})
+	})
+
+	Describe("CheckCommitReadiness and CommitChaincodeDefinition", func() {
+		var (
+			fakePublicState *mock.ReadWritableState
+			publicKVS       map[string][]byte
+			orgStates       map[string]lifecycle.OpaqueState
+			fakeOrgStates   []*mock.OpaqueState
+			cd              *lb.ChaincodeDefinition
+		)
+
+		BeforeEach(func() {
+			publicKVS, fakePublicState = newFakeState()
+
+			cd = &lb.ChaincodeDefinition{
+				Sequence:            1,
+				Version:             "version",
+				EndorsementPlugin:   "endorsement-plugin",
+				ValidationPlugin:    "validation-plugin",
+				ValidationParameter: []byte("validation-parameter"),
+			}
+
+			// org0 and org1 approved the definition, org2 approved a different one
+			fakeOrgStates = []*mock.OpaqueState{{}, {}, {}}
+			fakeOrgStates[0].GetStateHashReturns(util.ComputeSHA256(marshal(cd)), nil)
+			fakeOrgStates[1].GetStateHashReturns(util.ComputeSHA256(marshal(cd)), nil)
+			fakeOrgStates[2].GetStateHashReturns(util.ComputeSHA256([]byte("other-definition")), nil)
+			orgStates = map[string]lifecycle.OpaqueState{
+				"org0": fakeOrgStates[0],
+				"org1": fakeOrgStates[1],
+				"org2": fakeOrgStates[2],
+			}
+		})
+
+		It("returns the approvals of the orgs", func() {
+			approvals, err := l.CheckCommitReadiness("cc-name", cd, fakePublicState, orgStates)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(approvals).To(Equal(map[string]bool{
+				"org0": true,
+				"org1": true,
+				"org2": false,
+			}))
+			Expect(fakeOrgStates[0].GetStateHashCallCount()).To(Equal(1))
+			Expect(fakeOrgStates[0].GetStateHashArgsForCall(0)).To(Equal("namespaces/definitions/cc-name#1"))
+			Expect(publicKVS).To(BeEmpty())
+		})
+
+		It("commits the definition and returns the approvals of the orgs", func() {
+			fakeOrgStates[1].GetStateHashReturns(nil, nil)
+			approvals, err := l.CommitChaincodeDefinition("cc-name", cd, fakePublicState, orgStates)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(approvals).To(Equal(map[string]bool{
+				"org0": true,
+				"org1": false,
+				"org2": false,
+			}))
+			Expect(publicKVS["namespaces/definitions/cc-name"]).To(Equal(marshal(cd)))
+		})
+
+		Context("when the sequence is not the next sequence", func() {
+			BeforeEach(func() {
+				publicKVS["namespaces/definitions/cc-name"] = marshal(&lb.ChaincodeDefinition{Sequence: 1})
+			})
+
+			It("returns an error", func() {
+				_, err := l.CheckCommitReadiness("cc-name", cd, fakePublicState, orgStates)
+				Expect(err).To(MatchError("requested sequence is 1, but new definition must be sequence 2"))
+				_, err = l.CommitChaincodeDefinition("cc-name", cd, fakePublicState, orgStates)
+				Expect(err).To(MatchError("requested sequence is 1, but new definition must be sequence 2"))
+			})
+		})
+
+		Context("when the approved definition hash cannot be read", func() {
+			BeforeEach(func() {
+				fakeOrgStates[2].GetStateHashReturns(nil, fmt.Errorf("hash-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := l.CheckCommitReadiness("cc-name", cd, fakePublicState, orgStates)
+				Expect(err).To(MatchError("could not fetch approved definition hash for org 'org2': hash-error"))
+				_, err = l.CommitChaincodeDefinition("cc-name", cd, fakePublicState, orgStates)
+				Expect(err).To(MatchError("could not fetch approved definition hash for org 'org2': hash-error"))
+			})
+		})
+
+		Context("when writing the definition fails", func() {
+			BeforeEach(func() {
+				fakePublicState.PutStateReturns(fmt.Errorf("put-error"))
+				fakePublicState.PutStateStub = nil
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := l.CommitChaincodeDefinition("cc-name", cd, fakePublicState, orgStates)
+				Expect(err).To(MatchError("could not write chaincode definition: put-error"))
+			})
+		})
+	})
+
+	Describe("QueryChaincodeDefinition", func() {
+		var (
+			fakePublicState *mock.ReadWritableState
+			publicKVS       map[string][]byte
+		)
+
+		BeforeEach(func() {
+			publicKVS, fakePublicState = newFakeState()
+			publicKVS["namespaces/definitions/cc-name"] = marshal(&lb.ChaincodeDefinition{
+				Sequence: 3,
+				Version:  "version",
+			})
+		})
+
+		It("returns the committed definition", func() {
+			cd, err := l.QueryChaincodeDefinition("cc-name", fakePublicState)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(cd, &lb.ChaincodeDefinition{
+				Sequence: 3,
+				Version:  "version",
+			})).To(BeTrue())
+		})
+
+		Context("when no definition has been committed for the chaincode", func() {
+			It("returns an error", func() {
+				cd, err := l.QueryChaincodeDefinition("other-name", fakePublicState)
+				Expect(cd).To(BeNil())
+				Expect(err).To(MatchError("chaincode 'other-name' has no committed definition"))
+			})
+		})
+
+		Context("when the committed definition cannot be read", func() {
+			BeforeEach(func() {
+				fakePublicState.GetStateReturns(nil, fmt.Errorf("state-error"))
+				fakePublicState.GetStateStub = nil
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := l.QueryChaincodeDefinition("cc-name", fakePublicState)
+				Expect(err).To(MatchError("could not fetch committed definition for chaincode 'cc-name': state-error"))
+			})
+		})
+	})
 })
+
+// newFakeState returns a fake state backed by the returned map
+func newFakeState() (map[string][]byte, *mock.ReadWritableState) {
+	kvs := map[string][]byte{}
+	fakeState := &mock.ReadWritableState{}
+	fakeState.GetStateStub = func(key string) ([]byte, error) {
+		return kvs[key], nil
+	}
+	fakeState.PutStateStub = func(key string, value []byte) error {
+		kvs[key] = value
+		return nil
+	}
+	return kvs, fakeState
+}
+
+func marshal(msg proto.Message) []byte {
+	msgBytes, err := proto.Marshal(msg)
+	Expect(err).NotTo(HaveOccurred())
+	return msgBytes
+}
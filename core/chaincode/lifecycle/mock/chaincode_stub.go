@@ -138,6 +138,26 @@ type ChaincodeStub struct {
 		result1 shim.HistoryQueryIteratorInterface
 		result2 error
 	}
+	GetHistoryForKeyWithPaginationStub        func(string, uint64, uint64, bool, int32, string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error)
+	getHistoryForKeyWithPaginationMutex       sync.RWMutex
+	getHistoryForKeyWithPaginationArgsForCall []struct {
+		arg1 string
+		arg2 uint64
+		arg3 uint64
+		arg4 bool
+		arg5 int32
+		arg6 string
+	}
+	getHistoryForKeyWithPaginationReturns struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
+	getHistoryForKeyWithPaginationReturnsOnCall map[int]struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
 	GetPrivateDataStub        func(string, string) ([]byte, error)
 	getPrivateDataMutex       sync.RWMutex
 	getPrivateDataArgsForCall []struct {
@@ -1123,6 +1143,77 @@ func (fake *ChaincodeStub) GetHistoryForKeyReturnsOnCall(i int, result1 shim.His
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetHistoryForKeyWithPagination(arg1 string, arg2 uint64, arg3 uint64, arg4 bool, arg5 int32, arg6 string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	fake.getHistoryForKeyWithPaginationMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyWithPaginationReturnsOnCall[len(fake.getHistoryForKeyWithPaginationArgsForCall)]
+	fake.getHistoryForKeyWithPaginationArgsForCall = append(fake.getHistoryForKeyWithPaginationArgsForCall, struct {
+		arg1 string
+		arg2 uint64
+		arg3 uint64
+		arg4 bool
+		arg5 int32
+		arg6 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("GetHistoryForKeyWithPagination", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.getHistoryForKeyWithPaginationMutex.Unlock()
+	if fake.GetHistoryForKeyWithPaginationStub != nil {
+		return fake.GetHistoryForKeyWithPaginationStub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getHistoryForKeyWithPaginationReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationCallCount() int {
+	fake.getHistoryForKeyWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyWithPaginationMutex.RUnlock()
+	return len(fake.getHistoryForKeyWithPaginationArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationCalls(stub func(string, uint64, uint64, bool, int32, string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error)) {
+	fake.getHistoryForKeyWithPaginationMutex.Lock()
+	defer fake.getHistoryForKeyWithPaginationMutex.Unlock()
+	fake.GetHistoryForKeyWithPaginationStub = stub
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationArgsForCall(i int) (string, uint64, uint64, bool, int32, string) {
+	fake.getHistoryForKeyWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyWithPaginationMutex.RUnlock()
+	argsForCall := fake.getHistoryForKeyWithPaginationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationReturns(result1 shim.HistoryQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getHistoryForKeyWithPaginationMutex.Lock()
+	defer fake.getHistoryForKeyWithPaginationMutex.Unlock()
+	fake.GetHistoryForKeyWithPaginationStub = nil
+	fake.getHistoryForKeyWithPaginationReturns = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationReturnsOnCall(i int, result1 shim.HistoryQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getHistoryForKeyWithPaginationMutex.Lock()
+	defer fake.getHistoryForKeyWithPaginationMutex.Unlock()
+	fake.GetHistoryForKeyWithPaginationStub = nil
+	if fake.getHistoryForKeyWithPaginationReturnsOnCall == nil {
+		fake.getHistoryForKeyWithPaginationReturnsOnCall = make(map[int]struct {
+			result1 shim.HistoryQueryIteratorInterface
+			result2 *peer.QueryResponseMetadata
+			result3 error
+		})
+	}
+	fake.getHistoryForKeyWithPaginationReturnsOnCall[i] = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *ChaincodeStub) GetPrivateData(arg1 string, arg2 string) ([]byte, error) {
 	fake.getPrivateDataMutex.Lock()
 	ret, specificReturn := fake.getPrivateDataReturnsOnCall[len(fake.getPrivateDataArgsForCall)]
@@ -2806,6 +2897,8 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getFunctionAndParametersMutex.RUnlock()
 	fake.getHistoryForKeyMutex.RLock()
 	defer fake.getHistoryForKeyMutex.RUnlock()
+	fake.getHistoryForKeyWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyWithPaginationMutex.RUnlock()
 	fake.getPrivateDataMutex.RLock()
 	defer fake.getPrivateDataMutex.RUnlock()
 	fake.getPrivateDataByPartialCompositeKeyMutex.RLock()
@@ -10,6 +10,8 @@ import (
 	"fmt"
 
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	cb "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	"github.com/pkg/errors"
@@ -21,6 +23,20 @@ const (
 
 	// QueryInstalledChaincodeFuncName is the chaincode function name used to query an installed chaincode
 	QueryInstalledChaincodeFuncName = "QueryInstalledChaincode"
+
+	// ApproveChaincodeDefinitionForMyOrgFuncName is the chaincode function name used to approve a chaincode definition
+	// for the org of the peer
+	ApproveChaincodeDefinitionForMyOrgFuncName = "ApproveChaincodeDefinitionForMyOrg"
+
+	// CheckCommitReadinessFuncName is the chaincode function name used to check which orgs have approved a chaincode
+	// definition
+	CheckCommitReadinessFuncName = "CheckCommitReadiness"
+
+	// CommitChaincodeDefinitionFuncName is the chaincode function name used to commit a chaincode definition
+	CommitChaincodeDefinitionFuncName = "CommitChaincodeDefinition"
+
+	// QueryChaincodeDefinitionFuncName is the chaincode function name used to query a committed chaincode definition
+	QueryChaincodeDefinitionFuncName = "QueryChaincodeDefinition"
 )
 
 // SCCFunctions provides a backing implementation with concrete arguments
@@ -31,18 +47,40 @@ type SCCFunctions interface {
 
 	// QueryInstalledChaincode returns the hash for a given name and version of an installed chaincode
 	QueryInstalledChaincode(name, version string) (hash []byte, err error)
+
+	// ApproveChaincodeDefinitionForOrg records a chaincode definition into the state of an org
+	ApproveChaincodeDefinitionForOrg(name string, cd *lb.ChaincodeDefinition, hash []byte, publicState ReadableState, orgState ReadWritableState) error
+
+	// CheckCommitReadiness returns whether each of the given orgs has approved a chaincode definition
+	CheckCommitReadiness(name string, cd *lb.ChaincodeDefinition, publicState ReadableState, orgStates map[string]OpaqueState) (map[string]bool, error)
+
+	// CommitChaincodeDefinition records a chaincode definition into the public state and returns whether each of the given orgs has approved it
+	CommitChaincodeDefinition(name string, cd *lb.ChaincodeDefinition, publicState ReadWritableState, orgStates map[string]OpaqueState) (map[string]bool, error)
+
+	// QueryChaincodeDefinition returns the committed chaincode definition for a given name
+	QueryChaincodeDefinition(name string, publicState ReadableState) (*lb.ChaincodeDefinition, error)
+}
+
+// ChannelOrgsSource provides the MSP IDs of the application orgs of a channel
+type ChannelOrgsSource interface {
+	GetMSPIDs(channelID string) []string
 }
 
 // SCC implements the required methods to satisfy the chaincode interface.
 // It routes the invocation calls to the backing implementations.
 type SCC struct {
-	Protobuf  Protobuf
-	Functions SCCFunctions
+	// OrgMSPID is the MSP ID of the org of the peer, whose implicit
+	// collection holds the chaincode definitions approved by the org
+	OrgMSPID string
+
+	ChannelOrgs ChannelOrgsSource
+	Protobuf    Protobuf
+	Functions   SCCFunctions
 }
 
 // Name returns "+lifecycle"
 func (scc *SCC) Name() string {
-	return "+lifecycle"
+	return LifecycleNamespace
 }
 
 // Path returns "github.com/hyperledger/fabric/core/chaincode/lifecycle"
@@ -145,8 +183,183 @@ func (scc *SCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 			return shim.Error(err.Error())
 		}
 
+		return shim.Success(resultBytes)
+	case ApproveChaincodeDefinitionForMyOrgFuncName:
+		input := &lb.ApproveChaincodeDefinitionForMyOrgArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to ApproveChaincodeDefinitionForMyOrg")
+			return shim.Error(err.Error())
+		}
+
+		if stub.GetChannelID() == "" {
+			return shim.Error("ApproveChaincodeDefinitionForMyOrg must be invoked on a channel")
+		}
+
+		cd := chaincodeDefinition(input)
+		orgState := &ChaincodePrivateLedgerShim{
+			Stub:       stub,
+			Collection: privdata.ImplicitCollectionNameForOrg(scc.OrgMSPID),
+		}
+
+		err = scc.Functions.ApproveChaincodeDefinitionForOrg(input.Name, cd, input.Hash, &ChaincodePublicLedgerShim{stub}, orgState)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing ApproveChaincodeDefinitionForOrg")
+			return shim.Error(err.Error())
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(&lb.ApproveChaincodeDefinitionForMyOrgResult{})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
+		return shim.Success(resultBytes)
+	case CheckCommitReadinessFuncName:
+		input := &lb.CheckCommitReadinessArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to CheckCommitReadiness")
+			return shim.Error(err.Error())
+		}
+
+		orgStates, err := scc.orgStates(stub)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+
+		cd := chaincodeDefinition(input)
+
+		approvals, err := scc.Functions.CheckCommitReadiness(input.Name, cd, &ChaincodePublicLedgerShim{stub}, orgStates)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing CheckCommitReadiness")
+			return shim.Error(err.Error())
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(&lb.CheckCommitReadinessResult{
+			Approvals: approvals,
+		})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
+		return shim.Success(resultBytes)
+	case CommitChaincodeDefinitionFuncName:
+		input := &lb.CommitChaincodeDefinitionArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to CommitChaincodeDefinition")
+			return shim.Error(err.Error())
+		}
+
+		orgStates, err := scc.orgStates(stub)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+
+		cd := chaincodeDefinition(input)
+
+		approvals, err := scc.Functions.CommitChaincodeDefinition(input.Name, cd, &ChaincodePublicLedgerShim{stub}, orgStates)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing CommitChaincodeDefinition")
+			return shim.Error(err.Error())
+		}
+
+		// the peer endorses the commit only if its org agrees to the definition,
+		// whether enough orgs agree is decided by the LifecycleEndorsement policy
+		if !approvals[scc.OrgMSPID] {
+			return shim.Error(fmt.Sprintf("chaincode definition not agreed to by this org (%s)", scc.OrgMSPID))
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(&lb.CommitChaincodeDefinitionResult{})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
+		return shim.Success(resultBytes)
+	case QueryChaincodeDefinitionFuncName:
+		input := &lb.QueryChaincodeDefinitionArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to QueryChaincodeDefinition")
+			return shim.Error(err.Error())
+		}
+
+		if stub.GetChannelID() == "" {
+			return shim.Error("QueryChaincodeDefinition must be invoked on a channel")
+		}
+
+		cd, err := scc.Functions.QueryChaincodeDefinition(input.Name, &ChaincodePublicLedgerShim{stub})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing QueryChaincodeDefinition")
+			return shim.Error(err.Error())
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(&lb.QueryChaincodeDefinitionResult{
+			Sequence:            cd.Sequence,
+			Version:             cd.Version,
+			EndorsementPlugin:   cd.EndorsementPlugin,
+			ValidationPlugin:    cd.ValidationPlugin,
+			ValidationParameter: cd.ValidationParameter,
+			Collections:         cd.Collections,
+			InitRequired:        cd.InitRequired,
+		})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
 		return shim.Success(resultBytes)
 	default:
 		return shim.Error(fmt.Sprintf("unknown lifecycle function: %s", funcName))
 	}
 }
+
+// orgStates returns the implicit collections of the application orgs of the
+// channel the SCC is invoked on, keyed by the MSP IDs of the orgs
+func (scc *SCC) orgStates(stub shim.ChaincodeStubInterface) (map[string]OpaqueState, error) {
+	channelID := stub.GetChannelID()
+	if channelID == "" {
+		return nil, errors.New("no channel specified, the orgs of the channel are required")
+	}
+	mspIDs := scc.ChannelOrgs.GetMSPIDs(channelID)
+	if len(mspIDs) == 0 {
+		return nil, errors.Errorf("could not find the application orgs of channel '%s'", channelID)
+	}
+	orgStates := map[string]OpaqueState{}
+	for _, mspID := range mspIDs {
+		orgStates[mspID] = &ChaincodePrivateLedgerShim{
+			Stub:       stub,
+			Collection: privdata.ImplicitCollectionNameForOrg(mspID),
+		}
+	}
+	return orgStates, nil
+}
+
+// chaincodeDefinitionArgs is implemented by the arguments of the SCC
+// functions that take a chaincode definition
+type chaincodeDefinitionArgs interface {
+	GetSequence() int64
+	GetVersion() string
+	GetEndorsementPlugin() string
+	GetValidationPlugin() string
+	GetValidationParameter() []byte
+	GetCollections() *cb.CollectionConfigPackage
+	GetInitRequired() bool
+}
+
+// chaincodeDefinition returns the chaincode definition passed in the
+// arguments of an SCC function
+func chaincodeDefinition(input chaincodeDefinitionArgs) *lb.ChaincodeDefinition {
+	return &lb.ChaincodeDefinition{
+		Sequence:            input.GetSequence(),
+		Version:             input.GetVersion(),
+		EndorsementPlugin:   input.GetEndorsementPlugin(),
+		ValidationPlugin:    input.GetValidationPlugin(),
+		ValidationParameter: input.GetValidationParameter(),
+		Collections:         input.GetCollections(),
+		InitRequired:        input.GetInitRequired(),
+	}
+}
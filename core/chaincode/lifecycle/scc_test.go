@@ -13,6 +13,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	cb "github.com/hyperledger/fabric/protos/common"
 	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -20,17 +21,22 @@ import (
 
 var _ = Describe("SCC", func() {
 	var (
-		scc          *lifecycle.SCC
-		fakeProto    *mock.Protobuf
-		fakeSCCFuncs *mock.SCCFunctions
+		scc             *lifecycle.SCC
+		fakeProto       *mock.Protobuf
+		fakeSCCFuncs    *mock.SCCFunctions
+		fakeChannelOrgs *mock.ChannelOrgsSource
 	)
 
 	BeforeEach(func() {
 		fakeProto = &mock.Protobuf{}
 		fakeSCCFuncs = &mock.SCCFunctions{}
+		fakeChannelOrgs = &mock.ChannelOrgsSource{}
+		fakeChannelOrgs.GetMSPIDsReturns([]string{"fake-mspid", "other-mspid"})
 		scc = &lifecycle.SCC{
-			Protobuf:  fakeProto,
-			Functions: fakeSCCFuncs,
+			OrgMSPID:    "fake-mspid",
+			ChannelOrgs: fakeChannelOrgs,
+			Protobuf:    fakeProto,
+			Functions:   fakeSCCFuncs,
 		}
 	})
 
@@ -268,5 +274,475 @@ var _ = Describe("SCC", func() {
 				})
 			})
 		})
+
+		Describe("ApproveChaincodeDefinitionForMyOrg", func() {
+			var (
+				arg          *lb.ApproveChaincodeDefinitionForMyOrgArgs
+				marshaledArg []byte
+			)
+
+			BeforeEach(func() {
+				arg = &lb.ApproveChaincodeDefinitionForMyOrgArgs{
+					Sequence:            7,
+					Name:                "cc-name",
+					Version:             "version",
+					Hash:                []byte("hash"),
+					EndorsementPlugin:   "endorsement-plugin",
+					ValidationPlugin:    "validation-plugin",
+					ValidationParameter: []byte("validation-parameter"),
+					Collections: &cb.CollectionConfigPackage{
+						Config: []*cb.CollectionConfig{
+							{
+								Payload: &cb.CollectionConfig_StaticCollectionConfig{
+									StaticCollectionConfig: &cb.StaticCollectionConfig{Name: "collection-name"},
+								},
+							},
+						},
+					},
+					InitRequired: true,
+				}
+
+				var err error
+				marshaledArg, err = proto.Marshal(arg)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("ApproveChaincodeDefinitionForMyOrg"), marshaledArg})
+				fakeStub.GetChannelIDReturns("test-channel")
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+			})
+
+			It("passes the arguments to and returns the results from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Message).To(Equal(""))
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lb.ApproveChaincodeDefinitionForMyOrgResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeSCCFuncs.ApproveChaincodeDefinitionForOrgCallCount()).To(Equal(1))
+				name, cd, hash, pubState, orgState := fakeSCCFuncs.ApproveChaincodeDefinitionForOrgArgsForCall(0)
+				Expect(name).To(Equal("cc-name"))
+				Expect(proto.Equal(cd, &lb.ChaincodeDefinition{
+					Sequence:            7,
+					Version:             "version",
+					EndorsementPlugin:   "endorsement-plugin",
+					ValidationPlugin:    "validation-plugin",
+					ValidationParameter: []byte("validation-parameter"),
+					Collections:         arg.Collections,
+					InitRequired:        true,
+				})).To(BeTrue())
+				Expect(hash).To(Equal([]byte("hash")))
+				Expect(pubState).To(Equal(&lifecycle.ChaincodePublicLedgerShim{ChaincodeStubInterface: fakeStub}))
+				Expect(orgState).To(Equal(&lifecycle.ChaincodePrivateLedgerShim{
+					Stub:       fakeStub,
+					Collection: "_implicit_org_fake-mspid",
+				}))
+			})
+
+			Context("when the SCC is not invoked on a channel", func() {
+				BeforeEach(func() {
+					fakeStub.GetChannelIDReturns("")
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("ApproveChaincodeDefinitionForMyOrg must be invoked on a channel"))
+				})
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.ApproveChaincodeDefinitionForOrgReturns(fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing ApproveChaincodeDefinitionForOrg: underlying-error"))
+				})
+			})
+
+			Context("when unmarshaling the input fails", func() {
+				BeforeEach(func() {
+					fakeProto.UnmarshalReturns(fmt.Errorf("unmarshal-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to decode input arg to ApproveChaincodeDefinitionForMyOrg: unmarshal-error"))
+				})
+			})
+
+			Context("when marshaling the output fails", func() {
+				BeforeEach(func() {
+					fakeProto.MarshalReturns(nil, fmt.Errorf("marshal-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to marshal result: marshal-error"))
+				})
+			})
+		})
+
+		Describe("CheckCommitReadiness", func() {
+			var (
+				arg          *lb.CheckCommitReadinessArgs
+				marshaledArg []byte
+			)
+
+			BeforeEach(func() {
+				arg = &lb.CheckCommitReadinessArgs{
+					Sequence:            7,
+					Name:                "cc-name",
+					Version:             "version",
+					EndorsementPlugin:   "endorsement-plugin",
+					ValidationPlugin:    "validation-plugin",
+					ValidationParameter: []byte("validation-parameter"),
+				}
+
+				var err error
+				marshaledArg, err = proto.Marshal(arg)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("CheckCommitReadiness"), marshaledArg})
+				fakeStub.GetChannelIDReturns("test-channel")
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+
+				fakeSCCFuncs.CheckCommitReadinessReturns(map[string]bool{
+					"fake-mspid":  true,
+					"other-mspid": false,
+				}, nil)
+			})
+
+			It("passes the arguments to and returns the results from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Message).To(Equal(""))
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lb.CheckCommitReadinessResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload.Approvals).To(Equal(map[string]bool{
+					"fake-mspid":  true,
+					"other-mspid": false,
+				}))
+
+				Expect(fakeChannelOrgs.GetMSPIDsCallCount()).To(Equal(1))
+				Expect(fakeChannelOrgs.GetMSPIDsArgsForCall(0)).To(Equal("test-channel"))
+
+				Expect(fakeSCCFuncs.CheckCommitReadinessCallCount()).To(Equal(1))
+				name, cd, pubState, orgStates := fakeSCCFuncs.CheckCommitReadinessArgsForCall(0)
+				Expect(name).To(Equal("cc-name"))
+				Expect(proto.Equal(cd, &lb.ChaincodeDefinition{
+					Sequence:            7,
+					Version:             "version",
+					EndorsementPlugin:   "endorsement-plugin",
+					ValidationPlugin:    "validation-plugin",
+					ValidationParameter: []byte("validation-parameter"),
+				})).To(BeTrue())
+				Expect(pubState).To(Equal(&lifecycle.ChaincodePublicLedgerShim{ChaincodeStubInterface: fakeStub}))
+				Expect(orgStates).To(Equal(map[string]lifecycle.OpaqueState{
+					"fake-mspid": &lifecycle.ChaincodePrivateLedgerShim{
+						Stub:       fakeStub,
+						Collection: "_implicit_org_fake-mspid",
+					},
+					"other-mspid": &lifecycle.ChaincodePrivateLedgerShim{
+						Stub:       fakeStub,
+						Collection: "_implicit_org_other-mspid",
+					},
+				}))
+			})
+
+			Context("when the SCC is not invoked on a channel", func() {
+				BeforeEach(func() {
+					fakeStub.GetChannelIDReturns("")
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("no channel specified, the orgs of the channel are required"))
+				})
+			})
+
+			Context("when the orgs of the channel cannot be found", func() {
+				BeforeEach(func() {
+					fakeChannelOrgs.GetMSPIDsReturns(nil)
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("could not find the application orgs of channel 'test-channel'"))
+				})
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.CheckCommitReadinessReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing CheckCommitReadiness: underlying-error"))
+				})
+			})
+
+			Context("when unmarshaling the input fails", func() {
+				BeforeEach(func() {
+					fakeProto.UnmarshalReturns(fmt.Errorf("unmarshal-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to decode input arg to CheckCommitReadiness: unmarshal-error"))
+				})
+			})
+
+			Context("when marshaling the output fails", func() {
+				BeforeEach(func() {
+					fakeProto.MarshalReturns(nil, fmt.Errorf("marshal-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to marshal result: marshal-error"))
+				})
+			})
+		})
+
+		Describe("CommitChaincodeDefinition", func() {
+			var (
+				arg          *lb.CommitChaincodeDefinitionArgs
+				marshaledArg []byte
+			)
+
+			BeforeEach(func() {
+				arg = &lb.CommitChaincodeDefinitionArgs{
+					Sequence:            7,
+					Name:                "cc-name",
+					Version:             "version",
+					EndorsementPlugin:   "endorsement-plugin",
+					ValidationPlugin:    "validation-plugin",
+					ValidationParameter: []byte("validation-parameter"),
+					InitRequired:        true,
+				}
+
+				var err error
+				marshaledArg, err = proto.Marshal(arg)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("CommitChaincodeDefinition"), marshaledArg})
+				fakeStub.GetChannelIDReturns("test-channel")
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+
+				fakeSCCFuncs.CommitChaincodeDefinitionReturns(map[string]bool{
+					"fake-mspid":  true,
+					"other-mspid": false,
+				}, nil)
+			})
+
+			It("passes the arguments to and returns the results from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Message).To(Equal(""))
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lb.CommitChaincodeDefinitionResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeSCCFuncs.CommitChaincodeDefinitionCallCount()).To(Equal(1))
+				name, cd, pubState, orgStates := fakeSCCFuncs.CommitChaincodeDefinitionArgsForCall(0)
+				Expect(name).To(Equal("cc-name"))
+				Expect(proto.Equal(cd, &lb.ChaincodeDefinition{
+					Sequence:            7,
+					Version:             "version",
+					EndorsementPlugin:   "endorsement-plugin",
+					ValidationPlugin:    "validation-plugin",
+					ValidationParameter: []byte("validation-parameter"),
+					InitRequired:        true,
+				})).To(BeTrue())
+				Expect(pubState).To(Equal(&lifecycle.ChaincodePublicLedgerShim{ChaincodeStubInterface: fakeStub}))
+				Expect(orgStates).To(HaveLen(2))
+				Expect(orgStates).To(HaveKey("fake-mspid"))
+				Expect(orgStates).To(HaveKey("other-mspid"))
+			})
+
+			Context("when the orgs of the channel cannot be found", func() {
+				BeforeEach(func() {
+					fakeChannelOrgs.GetMSPIDsReturns(nil)
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("could not find the application orgs of channel 'test-channel'"))
+				})
+			})
+
+			Context("when the org of the peer has not approved the definition", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.CommitChaincodeDefinitionReturns(map[string]bool{
+						"fake-mspid":  false,
+						"other-mspid": true,
+					}, nil)
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("chaincode definition not agreed to by this org (fake-mspid)"))
+				})
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.CommitChaincodeDefinitionReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing CommitChaincodeDefinition: underlying-error"))
+				})
+			})
+
+			Context("when unmarshaling the input fails", func() {
+				BeforeEach(func() {
+					fakeProto.UnmarshalReturns(fmt.Errorf("unmarshal-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to decode input arg to CommitChaincodeDefinition: unmarshal-error"))
+				})
+			})
+
+			Context("when marshaling the output fails", func() {
+				BeforeEach(func() {
+					fakeProto.MarshalReturns(nil, fmt.Errorf("marshal-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to marshal result: marshal-error"))
+				})
+			})
+		})
+
+		Describe("QueryChaincodeDefinition", func() {
+			var (
+				arg          *lb.QueryChaincodeDefinitionArgs
+				marshaledArg []byte
+			)
+
+			BeforeEach(func() {
+				arg = &lb.QueryChaincodeDefinitionArgs{
+					Name: "cc-name",
+				}
+
+				var err error
+				marshaledArg, err = proto.Marshal(arg)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("QueryChaincodeDefinition"), marshaledArg})
+				fakeStub.GetChannelIDReturns("test-channel")
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+
+				fakeSCCFuncs.QueryChaincodeDefinitionReturns(&lb.ChaincodeDefinition{
+					Sequence:            4,
+					Version:             "version",
+					EndorsementPlugin:   "endorsement-plugin",
+					ValidationPlugin:    "validation-plugin",
+					ValidationParameter: []byte("validation-parameter"),
+					InitRequired:        true,
+				}, nil)
+			})
+
+			It("passes the arguments to and returns the results from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Message).To(Equal(""))
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lb.QueryChaincodeDefinitionResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(payload, &lb.QueryChaincodeDefinitionResult{
+					Sequence:            4,
+					Version:             "version",
+					EndorsementPlugin:   "endorsement-plugin",
+					ValidationPlugin:    "validation-plugin",
+					ValidationParameter: []byte("validation-parameter"),
+					InitRequired:        true,
+				})).To(BeTrue())
+
+				Expect(fakeSCCFuncs.QueryChaincodeDefinitionCallCount()).To(Equal(1))
+				name, pubState := fakeSCCFuncs.QueryChaincodeDefinitionArgsForCall(0)
+				Expect(name).To(Equal("cc-name"))
+				Expect(pubState).To(Equal(&lifecycle.ChaincodePublicLedgerShim{ChaincodeStubInterface: fakeStub}))
+			})
+
+			Context("when the SCC is not invoked on a channel", func() {
+				BeforeEach(func() {
+					fakeStub.GetChannelIDReturns("")
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("QueryChaincodeDefinition must be invoked on a channel"))
+				})
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.QueryChaincodeDefinitionReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing QueryChaincodeDefinition: underlying-error"))
+				})
+			})
+
+			Context("when unmarshaling the input fails", func() {
+				BeforeEach(func() {
+					fakeProto.UnmarshalReturns(fmt.Errorf("unmarshal-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to decode input arg to QueryChaincodeDefinition: unmarshal-error"))
+				})
+			})
+
+			Context("when marshaling the output fails", func() {
+				BeforeEach(func() {
+					fakeProto.MarshalReturns(nil, fmt.Errorf("marshal-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to marshal result: marshal-error"))
+				})
+			})
+		})
 	})
 })
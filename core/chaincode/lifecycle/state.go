@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/ledger"
+)
+
+// ReadableState is the subset of the chaincode stub required to read state
+type ReadableState interface {
+	GetState(key string) (value []byte, err error)
+}
+
+// ReadWritableState is the subset of the chaincode stub required to read and write state
+type ReadWritableState interface {
+	ReadableState
+	PutState(key string, value []byte) error
+}
+
+// OpaqueState is the subset of the chaincode stub required to read the hashes
+// of the values of a state which is not readable directly, such as the implicit
+// collection of another org
+type OpaqueState interface {
+	GetStateHash(key string) (value []byte, err error)
+}
+
+// ChaincodePublicLedgerShim decorates the chaincode stub to support the state
+// interfaces required by the lifecycle functions
+type ChaincodePublicLedgerShim struct {
+	shim.ChaincodeStubInterface
+}
+
+// ChaincodePrivateLedgerShim wraps the chaincode stub to make the private data
+// of a collection accessible through the state interfaces required by the
+// lifecycle functions
+type ChaincodePrivateLedgerShim struct {
+	Stub       shim.ChaincodeStubInterface
+	Collection string
+}
+
+// GetState returns the value for the key in the configured collection
+func (cls *ChaincodePrivateLedgerShim) GetState(key string) ([]byte, error) {
+	return cls.Stub.GetPrivateData(cls.Collection, key)
+}
+
+// GetStateHash returns the hash of the value for the key in the configured collection
+func (cls *ChaincodePrivateLedgerShim) GetStateHash(key string) ([]byte, error) {
+	return cls.Stub.GetPrivateDataHash(cls.Collection, key)
+}
+
+// PutState sets the value for the key in the configured collection
+func (cls *ChaincodePrivateLedgerShim) PutState(key string, value []byte) error {
+	return cls.Stub.PutPrivateData(cls.Collection, key, value)
+}
+
+// SimpleQueryExecutorShim wraps a ledger query executor to make the public
+// state of a namespace readable through the state interfaces required by the
+// lifecycle functions
+type SimpleQueryExecutorShim struct {
+	Namespace           string
+	SimpleQueryExecutor ledger.SimpleQueryExecutor
+}
+
+// GetState returns the value for the key in the configured namespace
+func (sqes *SimpleQueryExecutorShim) GetState(key string) ([]byte, error) {
+	return sqes.SimpleQueryExecutor.GetState(sqes.Namespace, key)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle_test
+
+import (
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ChaincodePrivateLedgerShim", func() {
+	var (
+		fakeStub *mock.ChaincodeStub
+		cls      *lifecycle.ChaincodePrivateLedgerShim
+	)
+
+	BeforeEach(func() {
+		fakeStub = &mock.ChaincodeStub{}
+		cls = &lifecycle.ChaincodePrivateLedgerShim{
+			Stub:       fakeStub,
+			Collection: "fake-collection",
+		}
+	})
+
+	Describe("GetState", func() {
+		BeforeEach(func() {
+			fakeStub.GetPrivateDataReturns([]byte("fake-value"), nil)
+		})
+
+		It("passes through to the stub", func() {
+			res, err := cls.GetState("fake-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal([]byte("fake-value")))
+			Expect(fakeStub.GetPrivateDataCallCount()).To(Equal(1))
+			collection, key := fakeStub.GetPrivateDataArgsForCall(0)
+			Expect(collection).To(Equal("fake-collection"))
+			Expect(key).To(Equal("fake-key"))
+		})
+	})
+
+	Describe("GetStateHash", func() {
+		BeforeEach(func() {
+			fakeStub.GetPrivateDataHashReturns([]byte("fake-hash"), nil)
+		})
+
+		It("passes through to the stub", func() {
+			res, err := cls.GetStateHash("fake-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal([]byte("fake-hash")))
+			Expect(fakeStub.GetPrivateDataHashCallCount()).To(Equal(1))
+			collection, key := fakeStub.GetPrivateDataHashArgsForCall(0)
+			Expect(collection).To(Equal("fake-collection"))
+			Expect(key).To(Equal("fake-key"))
+		})
+	})
+
+	Describe("PutState", func() {
+		It("passes through to the stub", func() {
+			err := cls.PutState("fake-key", []byte("fake-value"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeStub.PutPrivateDataCallCount()).To(Equal(1))
+			collection, key, value := fakeStub.PutPrivateDataArgsForCall(0)
+			Expect(collection).To(Equal("fake-collection"))
+			Expect(key).To(Equal("fake-key"))
+			Expect(value).To(Equal([]byte("fake-value")))
+		})
+	})
+})
+
+var _ = Describe("SimpleQueryExecutorShim", func() {
+	var (
+		fakeQueryExec *mock.QueryExecutor
+		sqes          *lifecycle.SimpleQueryExecutorShim
+	)
+
+	BeforeEach(func() {
+		fakeQueryExec = &mock.QueryExecutor{}
+		fakeQueryExec.GetStateReturns([]byte("fake-value"), nil)
+		sqes = &lifecycle.SimpleQueryExecutorShim{
+			Namespace:           "fake-namespace",
+			SimpleQueryExecutor: fakeQueryExec,
+		}
+	})
+
+	Describe("GetState", func() {
+		It("passes through to the query executor", func() {
+			res, err := sqes.GetState("fake-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal([]byte("fake-value")))
+			Expect(fakeQueryExec.GetStateCallCount()).To(Equal(1))
+			namespace, key := fakeQueryExec.GetStateArgsForCall(0)
+			Expect(namespace).To(Equal("fake-namespace"))
+			Expect(key).To(Equal("fake-key"))
+		})
+	})
+})
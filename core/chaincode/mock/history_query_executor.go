@@ -5,6 +5,7 @@ import (
 	sync "sync"
 
 	ledger "github.com/hyperledger/fabric/common/ledger"
+	ledgera "github.com/hyperledger/fabric/core/ledger"
 )
 
 type HistoryQueryExecutor struct {
@@ -22,6 +23,21 @@ type HistoryQueryExecutor struct {
 		result1 ledger.ResultsIterator
 		result2 error
 	}
+	GetHistoryForKeyWithMetadataStub        func(string, string, map[string]interface{}) (ledgera.QueryResultsIterator, error)
+	getHistoryForKeyWithMetadataMutex       sync.RWMutex
+	getHistoryForKeyWithMetadataArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 map[string]interface{}
+	}
+	getHistoryForKeyWithMetadataReturns struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
+	getHistoryForKeyWithMetadataReturnsOnCall map[int]struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -90,11 +106,78 @@ func (fake *HistoryQueryExecutor) GetHistoryForKeyReturnsOnCall(i int, result1 l
 	}{result1, result2}
 }
 
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadata(arg1 string, arg2 string, arg3 map[string]interface{}) (ledgera.QueryResultsIterator, error) {
+	fake.getHistoryForKeyWithMetadataMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyWithMetadataReturnsOnCall[len(fake.getHistoryForKeyWithMetadataArgsForCall)]
+	fake.getHistoryForKeyWithMetadataArgsForCall = append(fake.getHistoryForKeyWithMetadataArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 map[string]interface{}
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetHistoryForKeyWithMetadata", []interface{}{arg1, arg2, arg3})
+	fake.getHistoryForKeyWithMetadataMutex.Unlock()
+	if fake.GetHistoryForKeyWithMetadataStub != nil {
+		return fake.GetHistoryForKeyWithMetadataStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getHistoryForKeyWithMetadataReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataCallCount() int {
+	fake.getHistoryForKeyWithMetadataMutex.RLock()
+	defer fake.getHistoryForKeyWithMetadataMutex.RUnlock()
+	return len(fake.getHistoryForKeyWithMetadataArgsForCall)
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataCalls(stub func(string, string, map[string]interface{}) (ledgera.QueryResultsIterator, error)) {
+	fake.getHistoryForKeyWithMetadataMutex.Lock()
+	defer fake.getHistoryForKeyWithMetadataMutex.Unlock()
+	fake.GetHistoryForKeyWithMetadataStub = stub
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataArgsForCall(i int) (string, string, map[string]interface{}) {
+	fake.getHistoryForKeyWithMetadataMutex.RLock()
+	defer fake.getHistoryForKeyWithMetadataMutex.RUnlock()
+	argsForCall := fake.getHistoryForKeyWithMetadataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataReturns(result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.getHistoryForKeyWithMetadataMutex.Lock()
+	defer fake.getHistoryForKeyWithMetadataMutex.Unlock()
+	fake.GetHistoryForKeyWithMetadataStub = nil
+	fake.getHistoryForKeyWithMetadataReturns = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataReturnsOnCall(i int, result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.getHistoryForKeyWithMetadataMutex.Lock()
+	defer fake.getHistoryForKeyWithMetadataMutex.Unlock()
+	fake.GetHistoryForKeyWithMetadataStub = nil
+	if fake.getHistoryForKeyWithMetadataReturnsOnCall == nil {
+		fake.getHistoryForKeyWithMetadataReturnsOnCall = make(map[int]struct {
+			result1 ledgera.QueryResultsIterator
+			result2 error
+		})
+	}
+	fake.getHistoryForKeyWithMetadataReturnsOnCall[i] = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *HistoryQueryExecutor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getHistoryForKeyMutex.RLock()
 	defer fake.getHistoryForKeyMutex.RUnlock()
+	fake.getHistoryForKeyWithMetadataMutex.RLock()
+	defer fake.getHistoryForKeyWithMetadataMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/externalbuilder"
 	"github.com/hyperledger/fabric/core/container/inproccontroller"
 	"github.com/pkg/errors"
 )
@@ -26,11 +28,20 @@ type PackageProvider interface {
 	GetChaincodeCodePackage(ccname string, ccversion string) ([]byte, error)
 }
 
+// ExternalBuilder builds chaincode with the external builders configured
+// for the peer.
+type ExternalBuilder interface {
+	// Build returns true if the chaincode package was built by an external
+	// builder and is to be run with the external builder container type
+	Build(ccid ccintf.CCID, ccType, path string, codePackage []byte) (bool, error)
+}
+
 // RuntimeLauncher is responsible for launching chaincode runtimes.
 type RuntimeLauncher struct {
 	Runtime         Runtime
 	Registry        LaunchRegistry
 	PackageProvider PackageProvider
+	ExternalBuilder ExternalBuilder
 	StartupTimeout  time.Duration
 	Metrics         *LaunchMetrics
 }
@@ -52,6 +63,11 @@ func (r *RuntimeLauncher) Launch(ccci *ccprovider.ChaincodeContainerInfo) error
 		}
 
 		go func() {
+			ccci, err := r.build(ccci, codePackage)
+			if err != nil {
+				startFailCh <- err
+				return
+			}
 			if err := r.Runtime.Start(ccci, codePackage); err != nil {
 				startFailCh <- errors.WithMessage(err, "error starting container")
 				return
@@ -108,3 +124,26 @@ func (r *RuntimeLauncher) getCodePackage(ccci *ccprovider.ChaincodeContainerInfo
 
 	return codePackage, nil
 }
+
+// build builds the chaincode with the external builders, if any. When an
+// external builder built the chaincode, the returned container info has the
+// external builder container type, otherwise the container info is returned
+// unchanged.
+func (r *RuntimeLauncher) build(ccci *ccprovider.ChaincodeContainerInfo, codePackage []byte) (*ccprovider.ChaincodeContainerInfo, error) {
+	if r.ExternalBuilder == nil || ccci.ContainerType == inproccontroller.ContainerType {
+		return ccci, nil
+	}
+
+	ccid := ccintf.CCID{Name: ccci.Name, Version: ccci.Version}
+	built, err := r.ExternalBuilder.Build(ccid, ccci.Type, ccci.Path, codePackage)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building chaincode with external builder")
+	}
+	if !built {
+		return ccci, nil
+	}
+
+	externalCCCI := *ccci
+	externalCCCI.ContainerType = externalbuilder.ContainerType
+	return &externalCCCI, nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/fake"
 	"github.com/hyperledger/fabric/core/chaincode/mock"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/container/ccintf"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
@@ -341,4 +342,84 @@ var _ = Describe("RuntimeLauncher", func() {
 			Expect(fakeRuntime.StopCallCount()).To(Equal(1))
 		})
 	})
+
+	Context("when an external builder is configured", func() {
+		var fakeExternalBuilder *mock.ExternalBuilder
+
+		BeforeEach(func() {
+			fakeExternalBuilder = &mock.ExternalBuilder{}
+			fakeExternalBuilder.BuildReturns(true, nil)
+			runtimeLauncher.ExternalBuilder = fakeExternalBuilder
+		})
+
+		It("builds the chaincode with the external builder", func() {
+			err := runtimeLauncher.Launch(ccci)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeExternalBuilder.BuildCallCount()).To(Equal(1))
+			ccid, ccType, path, codePackage := fakeExternalBuilder.BuildArgsForCall(0)
+			Expect(ccid).To(Equal(ccintf.CCID{Name: "chaincode-name", Version: "chaincode-version"}))
+			Expect(ccType).To(Equal("chaincode-type"))
+			Expect(path).To(Equal("chaincode-path"))
+			Expect(codePackage).To(Equal([]byte("code-package")))
+		})
+
+		It("starts the runtime with the external builder container type", func() {
+			err := runtimeLauncher.Launch(ccci)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeRuntime.StartCallCount()).To(Equal(1))
+			ccciArg, _ := fakeRuntime.StartArgsForCall(0)
+			Expect(ccciArg.ContainerType).To(Equal("EXTERNAL"))
+			Expect(ccci.ContainerType).To(Equal("chaincode-container-type"))
+		})
+
+		Context("when no external builder detects the chaincode", func() {
+			BeforeEach(func() {
+				fakeExternalBuilder.BuildReturns(false, nil)
+			})
+
+			It("starts the runtime with the container type of the chaincode", func() {
+				err := runtimeLauncher.Launch(ccci)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeRuntime.StartCallCount()).To(Equal(1))
+				ccciArg, _ := fakeRuntime.StartArgsForCall(0)
+				Expect(ccciArg).To(Equal(ccci))
+			})
+		})
+
+		Context("when the chaincode is a system chaincode", func() {
+			BeforeEach(func() {
+				ccci.ContainerType = "SYSTEM"
+			})
+
+			It("does not build the chaincode", func() {
+				err := runtimeLauncher.Launch(ccci)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeExternalBuilder.BuildCallCount()).To(Equal(0))
+				ccciArg, _ := fakeRuntime.StartArgsForCall(0)
+				Expect(ccciArg.ContainerType).To(Equal("SYSTEM"))
+			})
+		})
+
+		Context("when the external build fails", func() {
+			BeforeEach(func() {
+				fakeExternalBuilder.BuildReturns(false, errors.New("build-failed"))
+			})
+
+			It("returns a wrapped error and does not start the runtime", func() {
+				err := runtimeLauncher.Launch(ccci)
+				Expect(err).To(MatchError("error building chaincode with external builder: build-failed"))
+				Expect(fakeRuntime.StartCallCount()).To(Equal(0))
+			})
+
+			It("stops the runtime and deregisters the chaincode", func() {
+				runtimeLauncher.Launch(ccci)
+				Expect(fakeRuntime.StopCallCount()).To(Equal(1))
+				Expect(fakeRegistry.DeregisterCallCount()).To(Equal(1))
+			})
+		})
+	})
 })
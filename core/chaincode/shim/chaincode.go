@@ -708,13 +708,47 @@ func (stub *ChaincodeStub) GetStateByRange(startKey, endKey string) (StateQueryI
 
 // GetHistoryForKey documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error) {
-	response, err := stub.handler.handleGetHistoryForKey(key, stub.ChannelId, stub.TxID)
+	response, err := stub.handler.handleGetHistoryForKey(&pb.GetHistoryForKey{Key: key}, stub.ChannelId, stub.TxID)
 	if err != nil {
 		return nil, err
 	}
 	return &HistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}, nil
 }
 
+// GetHistoryForKeyWithPagination documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetHistoryForKeyWithPagination(key string, startBlock, endBlock uint64, reverse bool,
+	pageSize int32, bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+
+	if endBlock != 0 && startBlock > endBlock {
+		return nil, nil, errors.Errorf("start block [%d] is greater than end block [%d]", startBlock, endBlock)
+	}
+
+	metadata, err := createQueryMetadata(pageSize, bookmark)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	getHistoryForKey := &pb.GetHistoryForKey{
+		Key:        key,
+		StartBlock: startBlock,
+		EndBlock:   endBlock,
+		Reverse:    reverse,
+		Metadata:   metadata,
+	}
+	response, err := stub.handler.handleGetHistoryForKey(getHistoryForKey, stub.ChannelId, stub.TxID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	iterator := &HistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}
+	responseMetadata, err := createQueryResponseMetadata(response.Metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return iterator, responseMetadata, nil
+}
+
 //CreateCompositeKey documentation can be found in interfaces.go
 func (stub *ChaincodeStub) CreateCompositeKey(objectType string, attributes []string) (string, error) {
 	return createCompositeKey(objectType, attributes)
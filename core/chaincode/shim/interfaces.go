@@ -233,6 +233,23 @@ type ChaincodeStubInterface interface {
 	// update ledger, and should limit use to read-only chaincode operations.
 	GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error)
 
+	// GetHistoryForKeyWithPagination returns a page of the history of key values
+	// committed between startBlock and endBlock (both inclusive). An endBlock of 0
+	// means that the history is not bounded above. When reverse is true, the most
+	// recent key updates are returned first.
+	// When an empty string is passed as a value to the bookmark argument, the returned
+	// iterator can be used to fetch the first `pageSize` historic key updates.
+	// When the bookmark is a non-empty string, the iterator can be used to fetch
+	// the first `pageSize` historic key updates following the bookmark.
+	// Note that only the bookmark present in a prior page of query results (ResponseMetadata)
+	// can be used as a value to the bookmark argument. Otherwise, an empty string
+	// must be passed as bookmark. A pageSize of 0 together with an empty bookmark
+	// returns every key update in the requested range and order.
+	// GetHistoryForKeyWithPagination has the same configuration requirements
+	// and limitations as GetHistoryForKey.
+	GetHistoryForKeyWithPagination(key string, startBlock, endBlock uint64, reverse bool,
+		pageSize int32, bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+
 	// GetPrivateData returns the value of the specified `key` from the specified
 	// `collection`. Note that GetPrivateData doesn't read data from the
 	// private writeset, which has not been committed to the `collection`. In
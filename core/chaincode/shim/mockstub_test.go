@@ -314,6 +314,7 @@ func TestMockMock(t *testing.T) {
 	stub.GetArgsSlice()
 	stub.SetEvent("e", nil)
 	stub.GetHistoryForKey("k")
+	stub.GetHistoryForKeyWithPagination("k", 0, 0, false, 10, "")
 	iter := &MockStateRangeQueryIterator{}
 	iter.HasNext()
 	iter.Close()
@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/core/comm"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// TLSProperties are the TLS settings of a ChaincodeServer
+type TLSProperties struct {
+	// Disabled disables TLS for the chaincode server
+	Disabled bool
+	// Key is the PEM encoded private key of the chaincode server
+	Key []byte
+	// Cert is the PEM encoded certificate of the chaincode server
+	Cert []byte
+	// ClientCACerts is the PEM encoded CA certificate used to verify the
+	// client certificate of the peer. If it is not provided, the peer is
+	// not required to present a client certificate
+	ClientCACerts []byte
+}
+
+// ChaincodeServer runs chaincode as a gRPC server. Instead of the chaincode
+// connecting to the peer, as with Start, the peer connects to the chaincode
+// server and the usual chaincode protocol is run over the established stream
+type ChaincodeServer struct {
+	// CCID is the name and the version of the chaincode, in the form
+	// name:version, which is used when registering with the peer
+	CCID string
+	// Address is the listen address of the chaincode server
+	Address string
+	// CC is the chaincode that handles the requests of the peer
+	CC Chaincode
+	// TLSProps are the TLS settings of the chaincode server
+	TLSProps TLSProperties
+	// KaOpts are the keepalive settings of the chaincode server. The
+	// defaults of the comm package are used if not provided
+	KaOpts *comm.KeepaliveOptions
+}
+
+// Connect is invoked for each stream the peer establishes with the chaincode
+// server. The stream ends when the chaincode stops chatting with the peer
+func (cs *ChaincodeServer) Connect(stream pb.Chaincode_ConnectServer) error {
+	return chatWithPeer(cs.CCID, &serverStream{stream}, cs.CC)
+}
+
+// Start starts the chaincode server and blocks until the server stops
+func (cs *ChaincodeServer) Start() error {
+	if cs.CCID == "" {
+		return errors.New("ccid must be specified")
+	}
+	if cs.Address == "" {
+		return errors.New("address must be specified")
+	}
+	if cs.CC == nil {
+		return errors.New("chaincode must be specified")
+	}
+
+	// set up formatted logging as for standalone chaincode
+	SetupChaincodeLogging()
+
+	err := factory.InitFactories(factory.GetDefaultOpts())
+	if err != nil {
+		return errors.WithMessage(err, "internal error, BCCSP could not be initialized with default options")
+	}
+
+	server, err := cs.newGRPCServer()
+	if err != nil {
+		return err
+	}
+	pb.RegisterChaincodeServer(server.Server(), cs)
+
+	chaincodeLogger.Infof("starting chaincode server for [%s] at %s", cs.CCID, server.Address())
+	return server.Start()
+}
+
+func (cs *ChaincodeServer) newGRPCServer() (*comm.GRPCServer, error) {
+	secOpts := &comm.SecureOptions{UseTLS: !cs.TLSProps.Disabled}
+	if secOpts.UseTLS {
+		if cs.TLSProps.Key == nil || cs.TLSProps.Cert == nil {
+			return nil, errors.New("key and cert must be specified if TLS is enabled")
+		}
+		secOpts.Key = cs.TLSProps.Key
+		secOpts.Certificate = cs.TLSProps.Cert
+		if cs.TLSProps.ClientCACerts != nil {
+			secOpts.RequireClientCert = true
+			secOpts.ClientRootCAs = [][]byte{cs.TLSProps.ClientCACerts}
+		}
+	}
+
+	server, err := comm.NewGRPCServer(cs.Address, comm.ServerConfig{
+		SecOpts: secOpts,
+		KaOpts:  cs.KaOpts,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create chaincode server")
+	}
+	return server, nil
+}
+
+// serverStream adapts the server side of the stream established by the
+// peer to the PeerChaincodeStream expected by the chaincode handler
+type serverStream struct {
+	pb.Chaincode_ConnectServer
+}
+
+// CloseSend is a no-op, as the server side of the stream is closed when
+// the handler of the stream returns
+func (s *serverStream) CloseSend() error {
+	return nil
+}
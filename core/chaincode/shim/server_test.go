@@ -0,0 +1,79 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestChaincodeServerStartErrors(t *testing.T) {
+	tests := []struct {
+		cs     *ChaincodeServer
+		errMsg string
+	}{
+		{&ChaincodeServer{Address: "127.0.0.1:0", CC: &shimTestCC{}}, "ccid must be specified"},
+		{&ChaincodeServer{CCID: "mycc:v1", CC: &shimTestCC{}}, "address must be specified"},
+		{&ChaincodeServer{CCID: "mycc:v1", Address: "127.0.0.1:0"}, "chaincode must be specified"},
+		{&ChaincodeServer{CCID: "mycc:v1", Address: "127.0.0.1:0", CC: &shimTestCC{}}, "key and cert must be specified if TLS is enabled"},
+		{
+			&ChaincodeServer{CCID: "mycc:v1", Address: "bad-address", CC: &shimTestCC{}, TLSProps: TLSProperties{Disabled: true}},
+			"failed to create chaincode server: listen tcp: address bad-address: missing port in address",
+		},
+	}
+	for _, tc := range tests {
+		assert.EqualError(t, tc.cs.Start(), tc.errMsg)
+	}
+}
+
+func TestChaincodeServerConnect(t *testing.T) {
+	cs := &ChaincodeServer{
+		CCID:     "mycc:v1",
+		Address:  "127.0.0.1:0",
+		CC:       &shimTestCC{},
+		TLSProps: TLSProperties{Disabled: true},
+	}
+	server, err := cs.newGRPCServer()
+	require.NoError(t, err)
+	pb.RegisterChaincodeServer(server.Server(), cs)
+	go server.Start()
+	defer server.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, server.Address(), grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	stream, err := pb.NewChaincodeClient(conn).Connect(ctx)
+	require.NoError(t, err)
+
+	// the chaincode registers with the peer on the stream established by the peer
+	msg, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, pb.ChaincodeMessage_REGISTER, msg.Type)
+	chaincodeID := &pb.ChaincodeID{}
+	require.NoError(t, proto.Unmarshal(msg.Payload, chaincodeID))
+	assert.Equal(t, "mycc:v1", chaincodeID.Name)
+
+	require.NoError(t, stream.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTERED}))
+	require.NoError(t, stream.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_READY}))
+	require.NoError(t, stream.CloseSend())
+	// the chaincode ends the stream once the peer closes its side of the stream
+	for {
+		if _, err := stream.Recv(); err != nil {
+			break
+		}
+	}
+}
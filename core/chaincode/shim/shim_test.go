@@ -81,6 +81,8 @@ func (t *shimTestCC) Invoke(stub ChaincodeStubInterface) pb.Response {
 		return t.rangeq(stub, args)
 	} else if function == "historyq" {
 		return t.historyq(stub, args)
+	} else if function == "historyqp" {
+		return t.historyqWithPagination(stub, args)
 	} else if function == "richq" {
 		return t.richq(stub, args)
 	} else if function == "putep" {
@@ -342,6 +344,28 @@ func (t *shimTestCC) historyq(stub ChaincodeStubInterface, args []string) pb.Res
 	return Success(buffer.Bytes())
 }
 
+// historyqWithPagination calls a paginated history query for the most recent
+// update of a key within blocks 1 to 10 and returns the bookmark of the next page
+func (t *shimTestCC) historyqWithPagination(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) < 1 {
+		return Error("Incorrect number of arguments. Expecting 1")
+	}
+
+	resultsIterator, metadata, err := stub.GetHistoryForKeyWithPagination(args[0], 1, 10, true, 1, "")
+	if err != nil {
+		return Error(err.Error())
+	}
+	defer resultsIterator.Close()
+
+	for resultsIterator.HasNext() {
+		if _, err := resultsIterator.Next(); err != nil {
+			return Error(err.Error())
+		}
+	}
+
+	return Success([]byte(metadata.Bookmark))
+}
+
 func (t *shimTestCC) putEP(stub ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 	err := stub.SetStateValidationParameter(string(args[1]), args[2])
@@ -856,6 +880,32 @@ func TestInvoke(t *testing.T) {
 	//wait for done
 	processDone(t, done, false)
 
+	//paginated history query
+
+	//create the response
+	historyQueryResponse = &pb.QueryResponse{Results: []*pb.QueryResultBytes{
+		{ResultBytes: utils.MarshalOrPanic(&lproto.KeyModification{TxId: "6", Value: []byte("100")})}},
+		Metadata: utils.MarshalOrPanic(&pb.QueryResponseMetadata{FetchedRecordsCount: 1, Bookmark: "0102"})}
+	payload = utils.MarshalOrPanic(historyQueryResponse)
+
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_HISTORY_FOR_KEY, Txid: "7b", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: payload, Txid: "7b", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_QUERY_STATE_CLOSE, Txid: "7b", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: "7b", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7b", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("historyqp"), []byte("A")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7b", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
 	//error history query
 
 	//create the response
@@ -141,6 +141,21 @@ func (m *mockLedger) Prune(policy ledger.PrunePolicy) error {
 	return args.Error(0)
 }
 
+func (m *mockLedger) SubmitSnapshotRequest(blockNum uint64) error {
+	args := m.Called(blockNum)
+	return args.Error(0)
+}
+
+func (m *mockLedger) CancelSnapshotRequest(blockNum uint64) error {
+	args := m.Called(blockNum)
+	return args.Error(0)
+}
+
+func (m *mockLedger) PendingSnapshotRequests() ([]uint64, error) {
+	args := m.Called()
+	return args.Get(0).([]uint64), args.Error(1)
+}
+
 func createLedger(channelID string) (*common.Block, *mockLedger) {
 	gb, _ := test.MakeGenesisBlock(channelID)
 	ledger := &mockLedger{
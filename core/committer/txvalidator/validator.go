@@ -16,6 +16,8 @@ import (
 	"github.com/hyperledger/fabric/common/configtx"
 	commonerrors "github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
@@ -53,6 +55,9 @@ type Support interface {
 
 	// Capabilities defines the capabilities for the application portion of this channel
 	Capabilities() channelconfig.ApplicationCapabilities
+
+	// PolicyManager returns the policy manager of the channel
+	PolicyManager() policies.Manager
 }
 
 //Validator interface which defines API to validate block transactions
@@ -483,8 +488,11 @@ func (v *TxValidator) checkTxIdDupsLedger(tIdx int, chdr *common.ChannelHeader,
 	_, err := ldgr.GetTransactionByID(txID)
 
 	// if returned error is nil, it means that there is already a tx in
-	// the ledger with the supplied id
-	if err == nil {
+	// the ledger with the supplied id. The same holds if the block that
+	// contains the tx has been pruned, e.g., for a ledger that is created
+	// from a snapshot, the txids of the pruned blocks are still retained
+	_, isBlockPrunedErrType := err.(*blkstorage.ErrBlockPruned)
+	if err == nil || isBlockPrunedErrType {
 		logger.Error("Duplicate transaction found, ", txID, ", skipping")
 		return &blockValidationResult{
 			tIdx:           tIdx,
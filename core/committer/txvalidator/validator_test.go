@@ -19,6 +19,7 @@ import (
 	ctxt "github.com/hyperledger/fabric/common/configtx/test"
 	commonerrors "github.com/hyperledger/fabric/common/errors"
 	ledger2 "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/common/mocks/scc"
@@ -44,6 +45,7 @@ import (
 	mb "github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/protos/peer"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/spf13/viper"
@@ -1656,6 +1658,21 @@ func (m *mockLedger) Prune(policy ledger2.PrunePolicy) error {
 	return nil
 }
 
+// SubmitSnapshotRequest submits a snapshot request
+func (m *mockLedger) SubmitSnapshotRequest(blockNum uint64) error {
+	return nil
+}
+
+// CancelSnapshotRequest cancels a snapshot request
+func (m *mockLedger) CancelSnapshotRequest(blockNum uint64) error {
+	return nil
+}
+
+// PendingSnapshotRequests returns the pending snapshot requests
+func (m *mockLedger) PendingSnapshotRequests() ([]uint64, error) {
+	return nil, nil
+}
+
 func (m *mockLedger) GetBlockchainInfo() (*common.BlockchainInfo, error) {
 	args := m.Called()
 	return args.Get(0).(*common.BlockchainInfo), nil
@@ -1947,6 +1964,38 @@ func TestDuplicateTxId(t *testing.T) {
 	assertion.True(txsfltr.Flag(0) == peer.TxValidationCode_DUPLICATE_TXID)
 }
 
+func TestDuplicateTxIdInPrunedBlock(t *testing.T) {
+	theLedger := new(mockLedger)
+	vcs := struct {
+		*mocktxvalidator.Support
+		*semaphore.Weighted
+	}{&mocktxvalidator.Support{LedgerVal: theLedger, ACVal: &mockconfig.MockApplicationCapabilities{}}, semaphore.NewWeighted(10)}
+	mp := (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider()
+	pm := &mocks.PluginMapper{}
+	validator := txvalidator.NewTxValidator("", vcs, mp, pm)
+
+	ccID := "mycc"
+	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
+
+	theLedger.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, &blkstorage.ErrBlockPruned{LowWaterMark: 10})
+
+	b := &common.Block{
+		Data:   &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}},
+		Header: &common.BlockHeader{},
+	}
+
+	err := validator.Validate(b)
+
+	assertion := assert.New(t)
+	// We expect no validation error because the txid is present in the ledger even though its block has been pruned
+	assertion.NoError(err)
+
+	// We expect the tx to be invalid because of a duplicate txid
+	txsfltr := lutils.TxValidationFlags(b.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assertion.True(txsfltr.IsInvalid(0))
+	assertion.True(txsfltr.Flag(0) == peer.TxValidationCode_DUPLICATE_TXID)
+}
+
 func TestValidationInvalidEndorsing(t *testing.T) {
 	theLedger := new(mockLedger)
 	vcs := struct {
@@ -1978,6 +2027,7 @@ func TestValidationInvalidEndorsing(t *testing.T) {
 	cdbytes := utils.MarshalOrPanic(cd)
 
 	queryExecutor := new(mockQueryExecutor)
+	queryExecutor.On("GetState", "+lifecycle", mock.Anything).Return([]byte(nil), nil)
 	queryExecutor.On("GetState", "lscc", ccID).Return(cdbytes, nil)
 	theLedger.On("NewQueryExecutor", mock.Anything).Return(queryExecutor, nil)
 
@@ -1993,6 +2043,50 @@ func TestValidationInvalidEndorsing(t *testing.T) {
 	assertInvalid(b, t, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
 }
 
+func TestValidationWithCommittedDefinition(t *testing.T) {
+	theLedger := new(mockLedger)
+	vcs := struct {
+		*mocktxvalidator.Support
+		*semaphore.Weighted
+	}{&mocktxvalidator.Support{LedgerVal: theLedger, ACVal: &mockconfig.MockApplicationCapabilities{}}, semaphore.NewWeighted(10)}
+	mp := (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider()
+	pm := &mocks.PluginMapper{}
+	factory := &mocks.PluginFactory{}
+	plugin := &mocks.Plugin{}
+	factory.On("New").Return(plugin)
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	plugin.On("Validate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	pm.On("PluginFactoryByName", txvalidator.PluginName("custom-vscc")).Return(factory)
+	validator := txvalidator.NewTxValidator("", vcs, mp, pm)
+
+	ccID := "mycc"
+	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
+
+	theLedger.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, ledger.NotFoundInIndexErr(""))
+
+	// the chaincode has a definition committed through the new lifecycle and no lscc state,
+	// hence it is validated with the validation plugin of the committed definition
+	cd := &lb.ChaincodeDefinition{
+		Sequence:            1,
+		Version:             ccVersion,
+		ValidationPlugin:    "custom-vscc",
+		ValidationParameter: signedByAnyMember([]string{"SampleOrg"}),
+	}
+	queryExecutor := new(mockQueryExecutor)
+	queryExecutor.On("GetState", "+lifecycle", "namespaces/definitions/"+ccID).Return(utils.MarshalOrPanic(cd), nil)
+	theLedger.On("NewQueryExecutor", mock.Anything).Return(queryExecutor, nil)
+
+	b := &common.Block{
+		Data:   &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}},
+		Header: &common.BlockHeader{},
+	}
+
+	err := validator.Validate(b)
+	assert.NoError(t, err)
+	assertValid(b, t)
+	plugin.AssertCalled(t, "Validate", mock.Anything, ccID, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func createMockLedger(t *testing.T, ccID string) *mockLedger {
 	l := new(mockLedger)
 	l.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, ledger.NotFoundInIndexErr(""))
@@ -2005,6 +2099,7 @@ func createMockLedger(t *testing.T, ccID string) *mockLedger {
 
 	cdbytes := utils.MarshalOrPanic(cd)
 	queryExecutor := new(mockQueryExecutor)
+	queryExecutor.On("GetState", "+lifecycle", mock.Anything).Return([]byte(nil), nil)
 	queryExecutor.On("GetState", "lscc", ccID).Return(cdbytes, nil)
 	l.On("NewQueryExecutor", mock.Anything).Return(queryExecutor, nil)
 	return l
@@ -11,16 +11,21 @@ package txvalidator
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	commonerrors "github.com/hyperledger/fabric/common/errors"
+	"github.com/hyperledger/fabric/common/policies"
 	coreUtil "github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
@@ -124,6 +129,7 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 		}
 	}
 
+	var lifecycleRWSet *rwsetutil.NsRwSet
 	namespaces := make(map[string]struct{})
 	for _, ns := range txRWSet.NsRwSets {
 		// check to make sure there is no duplicate namespace in txRWSet
@@ -133,6 +139,10 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 		}
 		namespaces[ns.NameSpace] = struct{}{}
 
+		if ns.NameSpace == lifecycle.LifecycleNamespace {
+			lifecycleRWSet = ns
+		}
+
 		if !v.txWritesToNamespace(ns) {
 			continue
 		}
@@ -192,6 +202,13 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 				logger.Errorf("GetInfoForValidate for txId = %s returned error: %+v", chdr.TxId, err)
 				return err, peer.TxValidationCode_INVALID_OTHER_REASON
 			}
+			if ns == lifecycle.LifecycleNamespace {
+				var code peer.TxValidationCode
+				policy, err, code = v.lifecycleValidationPolicy(chdr.ChannelId, payload, lifecycleRWSet)
+				if err != nil {
+					return err, code
+				}
+			}
 
 			// if the namespace corresponds to the cc that was originally
 			// invoked, we check that the version of the cc that was
@@ -238,6 +255,13 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 			logger.Errorf("GetInfoForValidate for txId = %s returned error: %+v", chdr.TxId, err)
 			return err, peer.TxValidationCode_INVALID_OTHER_REASON
 		}
+		if ccID == lifecycle.LifecycleNamespace {
+			var code peer.TxValidationCode
+			policy, err, code = v.lifecycleValidationPolicy(chdr.ChannelId, payload, lifecycleRWSet)
+			if err != nil {
+				return err, code
+			}
+		}
 
 		// validate the transaction as an invocation of this system chaincode;
 		// vscc will have to do custom validation for this system chaincode
@@ -293,6 +317,21 @@ func (v *VsccValidatorImpl) getCDataForCC(chid, ccid string) (ccprovider.Chainco
 	}
 	defer qe.Done()
 
+	// the chaincode defined through the new lifecycle is validated
+	// as per its committed definition rather than its lscc state
+	dc, err := lifecycle.CommittedDefinition(ccid, qe)
+	if err != nil {
+		return nil, &commonerrors.VSCCInfoLookupFailureError{
+			Reason: fmt.Sprintf("Could not retrieve committed definition for chaincode %s, error %s", ccid, err),
+		}
+	}
+	if dc != nil {
+		if dc.ValidationPlugin == "" {
+			return nil, errors.Errorf("committed definition for [%s] is invalid, validation plugin must be set", ccid)
+		}
+		return dc, nil
+	}
+
 	bytes, err := qe.GetState("lscc", ccid)
 	if err != nil {
 		return nil, &commonerrors.VSCCInfoLookupFailureError{
@@ -400,3 +439,132 @@ func (v *VsccValidatorImpl) txWritesToNamespace(ns *rwsetutil.NsRwSet) bool {
 
 	return false
 }
+
+// lifecycleValidationPolicy returns the policy that the endorsements of a transaction
+// writing to the namespace of the lifecycle system chaincode have to satisfy, after
+// checking the endorsements of the writes to the public state (i.e. the commit of a
+// chaincode definition) against the LifecycleEndorsement policy of the channel
+func (v *VsccValidatorImpl) lifecycleValidationPolicy(channelID string, payload *common.Payload, nsRWSet *rwsetutil.NsRwSet) ([]byte, error, peer.TxValidationCode) {
+	if writesToLifecycleState(nsRWSet) {
+		if err, code := v.checkLifecycleEndorsement(payload); err != nil {
+			return nil, err, code
+		}
+	}
+
+	policy, err := v.lifecycleEndorsementPolicy(channelID, nsRWSet)
+	if err != nil {
+		return nil, err, peer.TxValidationCode_INVALID_OTHER_REASON
+	}
+	return policy, nil, peer.TxValidationCode_VALID
+}
+
+// writesToLifecycleState returns true if the supplied NsRwSet writes to the
+// public state of the lifecycle namespace or to a collection other than
+// the implicit collections of the orgs
+func writesToLifecycleState(nsRWSet *rwsetutil.NsRwSet) bool {
+	if nsRWSet == nil {
+		return false
+	}
+	if nsRWSet.KvRwSet != nil && (len(nsRWSet.KvRwSet.Writes) > 0 || len(nsRWSet.KvRwSet.MetadataWrites) > 0) {
+		return true
+	}
+	for _, coll := range nsRWSet.CollHashedRwSets {
+		if coll.HashedRwSet == nil || (len(coll.HashedRwSet.HashedWrites) == 0 && len(coll.HashedRwSet.MetadataWrites) == 0) {
+			continue
+		}
+		if _, ok := privdata.MSPIDIfImplicitCollection(coll.CollectionName); !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLifecycleEndorsement evaluates the endorsements of a transaction
+// against the LifecycleEndorsement policy of the channel
+func (v *VsccValidatorImpl) checkLifecycleEndorsement(payload *common.Payload) (error, peer.TxValidationCode) {
+	policy, ok := v.support.PolicyManager().GetPolicy(policies.ChannelApplicationLifecycleEndorsement)
+	if !ok {
+		return errors.Errorf("could not find policy '%s'", policies.ChannelApplicationLifecycleEndorsement), peer.TxValidationCode_INVALID_OTHER_REASON
+	}
+
+	tx, err := utils.GetTransaction(payload.Data)
+	if err != nil {
+		return errors.WithMessage(err, "GetTransaction failed"), peer.TxValidationCode_BAD_PAYLOAD
+	}
+	if len(tx.Actions) != 1 {
+		return errors.Errorf("only one action per transaction is supported, tx contains %d", len(tx.Actions)), peer.TxValidationCode_BAD_PAYLOAD
+	}
+	cap, err := utils.GetChaincodeActionPayload(tx.Actions[0].Payload)
+	if err != nil {
+		return errors.WithMessage(err, "GetChaincodeActionPayload failed"), peer.TxValidationCode_BAD_PAYLOAD
+	}
+	if cap.Action == nil {
+		return errors.New("nil action in chaincode action payload"), peer.TxValidationCode_BAD_PAYLOAD
+	}
+
+	// endorsements of the same identity are counted once
+	signatureSet := []*common.SignedData{}
+	identities := map[string]struct{}{}
+	for _, endorsement := range cap.Action.Endorsements {
+		serializedIdentity := &mspprotos.SerializedIdentity{}
+		if err := proto.Unmarshal(endorsement.Endorser, serializedIdentity); err != nil {
+			return errors.Wrap(err, "could not unmarshal endorser"), peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE
+		}
+		identity := serializedIdentity.Mspid + string(serializedIdentity.IdBytes)
+		if _, ok := identities[identity]; ok {
+			continue
+		}
+		identities[identity] = struct{}{}
+		signatureSet = append(signatureSet, &common.SignedData{
+			Data:      append(append([]byte{}, cap.Action.ProposalResponsePayload...), endorsement.Endorser...),
+			Identity:  endorsement.Endorser,
+			Signature: endorsement.Signature,
+		})
+	}
+
+	if err := policy.Evaluate(signatureSet); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("endorsements do not satisfy policy '%s'", policies.ChannelApplicationLifecycleEndorsement)), peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE
+	}
+	return nil, peer.TxValidationCode_VALID
+}
+
+// lifecycleEndorsementPolicy returns the policy that the endorsements of a transaction
+// writing to the namespace of the lifecycle system chaincode have to satisfy. Writes to
+// the implicit collection of an org (i.e. the approval of a chaincode definition by
+// the org) must be endorsed by the org, whereas the writes to the public state are
+// checked against the LifecycleEndorsement policy of the channel
+func (v *VsccValidatorImpl) lifecycleEndorsementPolicy(channelID string, nsRWSet *rwsetutil.NsRwSet) ([]byte, error) {
+	mspIDs := v.support.GetMSPIDs(channelID)
+	sort.Strings(mspIDs)
+
+	var principals []*mspprotos.MSPPrincipal
+	var rules []*common.SignaturePolicy
+	if nsRWSet != nil {
+		for _, coll := range nsRWSet.CollHashedRwSets {
+			if coll.HashedRwSet == nil || (len(coll.HashedRwSet.HashedWrites) == 0 && len(coll.HashedRwSet.MetadataWrites) == 0) {
+				continue
+			}
+			mspID, ok := privdata.MSPIDIfImplicitCollection(coll.CollectionName)
+			if !ok {
+				continue
+			}
+			rules = append(rules, cauthdsl.SignedBy(int32(len(principals))))
+			principals = append(principals, &mspprotos.MSPPrincipal{
+				PrincipalClassification: mspprotos.MSPPrincipal_ROLE,
+				Principal:               utils.MarshalOrPanic(&mspprotos.MSPRole{Role: mspprotos.MSPRole_MEMBER, MspIdentifier: mspID}),
+			})
+		}
+	}
+
+	// a transaction that does not write to the implicit collections is
+	// validated like the transactions of the other system chaincodes
+	if len(rules) == 0 {
+		return utils.Marshal(cauthdsl.SignedByAnyMember(mspIDs))
+	}
+
+	return utils.Marshal(&common.SignaturePolicyEnvelope{
+		Version:    0,
+		Rule:       cauthdsl.NOutOf(int32(len(rules)), rules),
+		Identities: principals,
+	})
+}
@@ -0,0 +1,201 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txvalidator
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	mocktxvalidator "github.com/hyperledger/fabric/core/mocks/txvalidator"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/semaphore"
+)
+
+// orgsSupport is a Support whose channel has the given application orgs
+type orgsSupport struct {
+	*mocktxvalidator.Support
+	*semaphore.Weighted
+	mspIDs        []string
+	policyManager policies.Manager
+}
+
+func (s *orgsSupport) GetMSPIDs(cid string) []string {
+	return s.mspIDs
+}
+
+func (s *orgsSupport) PolicyManager() policies.Manager {
+	return s.policyManager
+}
+
+// recordingPolicy is a policy which records the signature set it evaluates
+type recordingPolicy struct {
+	signatureSet []*common.SignedData
+	err          error
+}
+
+func (p *recordingPolicy) Evaluate(signatureSet []*common.SignedData) error {
+	p.signatureSet = signatureSet
+	return p.err
+}
+
+func TestLifecycleEndorsementPolicy(t *testing.T) {
+	v := &VsccValidatorImpl{
+		support: &orgsSupport{Support: &mocktxvalidator.Support{}, mspIDs: []string{"Org3MSP", "Org1MSP", "Org2MSP"}},
+	}
+	member := func(mspID string) *mspprotos.MSPPrincipal {
+		return &mspprotos.MSPPrincipal{
+			PrincipalClassification: mspprotos.MSPPrincipal_ROLE,
+			Principal:               utils.MarshalOrPanic(&mspprotos.MSPRole{Role: mspprotos.MSPRole_MEMBER, MspIdentifier: mspID}),
+		}
+	}
+	implicitWrite := func(mspID string) *rwsetutil.CollHashedRwSet {
+		return &rwsetutil.CollHashedRwSet{
+			CollectionName: "_implicit_org_" + mspID,
+			HashedRwSet:    &kvrwset.HashedRWSet{HashedWrites: []*kvrwset.KVWriteHash{{KeyHash: []byte("key")}}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		nsRWSet  *rwsetutil.NsRwSet
+		expected *common.SignaturePolicyEnvelope
+	}{
+		{
+			name:     "no writes",
+			nsRWSet:  &rwsetutil.NsRwSet{NameSpace: "+lifecycle", KvRwSet: &kvrwset.KVRWSet{}},
+			expected: cauthdsl.SignedByAnyMember([]string{"Org1MSP", "Org2MSP", "Org3MSP"}),
+		},
+		{
+			name: "approval",
+			nsRWSet: &rwsetutil.NsRwSet{
+				NameSpace:        "+lifecycle",
+				KvRwSet:          &kvrwset.KVRWSet{},
+				CollHashedRwSets: []*rwsetutil.CollHashedRwSet{implicitWrite("Org2MSP")},
+			},
+			expected: &common.SignaturePolicyEnvelope{
+				Rule:       cauthdsl.NOutOf(1, []*common.SignaturePolicy{cauthdsl.SignedBy(0)}),
+				Identities: []*mspprotos.MSPPrincipal{member("Org2MSP")},
+			},
+		},
+		{
+			name: "commit",
+			nsRWSet: &rwsetutil.NsRwSet{
+				NameSpace: "+lifecycle",
+				KvRwSet:   &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "namespaces/definitions/mycc"}}},
+			},
+			expected: cauthdsl.SignedByAnyMember([]string{"Org1MSP", "Org2MSP", "Org3MSP"}),
+		},
+		{
+			name: "approval and write to a collection which is not implicit",
+			nsRWSet: &rwsetutil.NsRwSet{
+				NameSpace: "+lifecycle",
+				KvRwSet:   &kvrwset.KVRWSet{},
+				CollHashedRwSets: []*rwsetutil.CollHashedRwSet{
+					implicitWrite("Org4MSP"),
+					{
+						CollectionName: "mycollection",
+						HashedRwSet:    &kvrwset.HashedRWSet{HashedWrites: []*kvrwset.KVWriteHash{{KeyHash: []byte("key")}}},
+					},
+				},
+			},
+			expected: &common.SignaturePolicyEnvelope{
+				Rule:       cauthdsl.NOutOf(1, []*common.SignaturePolicy{cauthdsl.SignedBy(0)}),
+				Identities: []*mspprotos.MSPPrincipal{member("Org4MSP")},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			policyBytes, err := v.lifecycleEndorsementPolicy("mychannel", tc.nsRWSet)
+			require.NoError(t, err)
+			assert.Equal(t, utils.MarshalOrPanic(tc.expected), policyBytes)
+		})
+	}
+
+	policyBytes, err := v.lifecycleEndorsementPolicy("mychannel", nil)
+	require.NoError(t, err)
+	assert.Equal(t, utils.MarshalOrPanic(cauthdsl.SignedByAnyMember([]string{"Org1MSP", "Org2MSP", "Org3MSP"})), policyBytes)
+}
+
+func TestWritesToLifecycleState(t *testing.T) {
+	write := &kvrwset.HashedRWSet{HashedWrites: []*kvrwset.KVWriteHash{{KeyHash: []byte("key")}}}
+
+	assert.False(t, writesToLifecycleState(nil))
+	assert.False(t, writesToLifecycleState(&rwsetutil.NsRwSet{KvRwSet: &kvrwset.KVRWSet{}}))
+	assert.False(t, writesToLifecycleState(&rwsetutil.NsRwSet{
+		KvRwSet:          &kvrwset.KVRWSet{},
+		CollHashedRwSets: []*rwsetutil.CollHashedRwSet{{CollectionName: "_implicit_org_Org1MSP", HashedRwSet: write}},
+	}))
+	assert.True(t, writesToLifecycleState(&rwsetutil.NsRwSet{
+		KvRwSet: &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "namespaces/definitions/mycc"}}},
+	}))
+	assert.True(t, writesToLifecycleState(&rwsetutil.NsRwSet{
+		KvRwSet:          &kvrwset.KVRWSet{},
+		CollHashedRwSets: []*rwsetutil.CollHashedRwSet{{CollectionName: "mycollection", HashedRwSet: write}},
+	}))
+}
+
+func TestCheckLifecycleEndorsement(t *testing.T) {
+	endorser := func(mspID string) []byte {
+		return utils.MarshalOrPanic(&mspprotos.SerializedIdentity{Mspid: mspID, IdBytes: []byte("cert")})
+	}
+	payload := &common.Payload{
+		Data: utils.MarshalOrPanic(&peer.Transaction{
+			Actions: []*peer.TransactionAction{{
+				Payload: utils.MarshalOrPanic(&peer.ChaincodeActionPayload{
+					Action: &peer.ChaincodeEndorsedAction{
+						ProposalResponsePayload: []byte("prp"),
+						Endorsements: []*peer.Endorsement{
+							{Endorser: endorser("Org1MSP"), Signature: []byte("sig1")},
+							{Endorser: endorser("Org2MSP"), Signature: []byte("sig2")},
+							{Endorser: endorser("Org1MSP"), Signature: []byte("sig1")},
+						},
+					},
+				}),
+			}},
+		}),
+	}
+
+	policy := &recordingPolicy{}
+	v := &VsccValidatorImpl{
+		support: &orgsSupport{
+			Support: &mocktxvalidator.Support{},
+			policyManager: &mockpolicies.Manager{
+				PolicyMap: map[string]policies.Policy{"/Channel/Application/LifecycleEndorsement": policy},
+			},
+		},
+	}
+
+	err, code := v.checkLifecycleEndorsement(payload)
+	require.NoError(t, err)
+	assert.Equal(t, peer.TxValidationCode_VALID, code)
+	assert.Equal(t, []*common.SignedData{
+		{Data: append([]byte("prp"), endorser("Org1MSP")...), Identity: endorser("Org1MSP"), Signature: []byte("sig1")},
+		{Data: append([]byte("prp"), endorser("Org2MSP")...), Identity: endorser("Org2MSP"), Signature: []byte("sig2")},
+	}, policy.signatureSet)
+
+	policy.err = errors.New("signature set did not satisfy policy")
+	err, code = v.checkLifecycleEndorsement(payload)
+	assert.EqualError(t, err, "endorsements do not satisfy policy '/Channel/Application/LifecycleEndorsement': signature set did not satisfy policy")
+	assert.Equal(t, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE, code)
+
+	v.support.(*orgsSupport).policyManager = &mockpolicies.Manager{PolicyMap: map[string]policies.Policy{}}
+	err, code = v.checkLifecycleEndorsement(payload)
+	assert.EqualError(t, err, "could not find policy '/Channel/Application/LifecycleEndorsement'")
+	assert.Equal(t, peer.TxValidationCode_INVALID_OTHER_REASON, code)
+}
@@ -0,0 +1,50 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"strings"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/protos/common"
+)
+
+// implicitCollectionNamePrefix is the prefix of the name of the implicit
+// collection of an org. The name of such a collection is the prefix followed
+// by the MSP ID of the org
+const implicitCollectionNamePrefix = "_implicit_org_"
+
+// ImplicitCollectionNameForOrg returns the name of the implicit collection of the given org
+func ImplicitCollectionNameForOrg(mspID string) string {
+	return implicitCollectionNamePrefix + mspID
+}
+
+// MSPIDIfImplicitCollection returns the MSP ID of the org owning the given
+// collection and true if the collection is an implicit collection, or false otherwise
+func MSPIDIfImplicitCollection(collectionName string) (mspID string, isImplicitCollection bool) {
+	if !strings.HasPrefix(collectionName, implicitCollectionNamePrefix) {
+		return "", false
+	}
+	mspID = collectionName[len(implicitCollectionNamePrefix):]
+	return mspID, mspID != ""
+}
+
+// GenerateImplicitCollectionForOrg returns the config of the implicit collection of
+// the given org. Implicit collections are not defined in any collection config package;
+// they exist in every namespace, only the members of the org are members of the
+// collection, and the data of the collection is never purged
+func GenerateImplicitCollectionForOrg(mspID string) *common.StaticCollectionConfig {
+	return &common.StaticCollectionConfig{
+		Name: ImplicitCollectionNameForOrg(mspID),
+		MemberOrgsPolicy: &common.CollectionPolicyConfig{
+			Payload: &common.CollectionPolicyConfig_SignaturePolicy{
+				SignaturePolicy: cauthdsl.SignedByMspMember(mspID),
+			},
+		},
+		MemberOnlyRead: true,
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImplicitCollectionName(t *testing.T) {
+	assert.Equal(t, "_implicit_org_Org1MSP", ImplicitCollectionNameForOrg("Org1MSP"))
+
+	mspID, ok := MSPIDIfImplicitCollection("_implicit_org_Org1MSP")
+	assert.True(t, ok)
+	assert.Equal(t, "Org1MSP", mspID)
+
+	for _, name := range []string{"mycollection", "_implicit_org_", "implicit_org_Org1MSP"} {
+		_, ok := MSPIDIfImplicitCollection(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestGenerateImplicitCollectionForOrg(t *testing.T) {
+	conf := GenerateImplicitCollectionForOrg("Org1MSP")
+	assert.Equal(t, "_implicit_org_Org1MSP", conf.Name)
+	assert.Equal(t, cauthdsl.SignedByMspMember("Org1MSP"), conf.MemberOrgsPolicy.GetSignaturePolicy())
+	assert.True(t, conf.MemberOnlyRead)
+	assert.Equal(t, uint64(0), conf.BlockToLive)
+}
@@ -41,20 +41,23 @@ func (f NoSuchCollectionError) Error() string {
 }
 
 type simpleCollectionStore struct {
-	s Support
+	s              Support
+	ccInfoProvider ledger.DeployedChaincodeInfoProvider
 }
 
 // NewSimpleCollectionStore returns a collection stored backed
 // by a ledger supplied by the specified ledgerGetter with
 // an internal name formed as specified by the supplied
-// collectionNamer function
-func NewSimpleCollectionStore(s Support) CollectionStore {
-	return &simpleCollectionStore{s}
+// collectionNamer function. The collections of a chaincode are
+// retrieved through the supplied DeployedChaincodeInfoProvider,
+// or from the state of lscc if no provider is supplied
+func NewSimpleCollectionStore(s Support, ccInfoProvider ledger.DeployedChaincodeInfoProvider) CollectionStore {
+	return &simpleCollectionStore{s: s, ccInfoProvider: ccInfoProvider}
 }
 
 func (c *simpleCollectionStore) retrieveCollectionConfigPackage(cc common.CollectionCriteria, qe ledger.QueryExecutor) (*common.CollectionConfigPackage, error) {
 	if qe != nil {
-		return RetrieveCollectionConfigPackageFromState(cc, qe)
+		return c.retrieveCollectionConfigPackageFromState(cc, qe)
 	}
 
 	qe, err := c.s.GetQueryExecutorForLedger(cc.Channel)
@@ -62,7 +65,22 @@ func (c *simpleCollectionStore) retrieveCollectionConfigPackage(cc common.Collec
 		return nil, errors.WithMessage(err, fmt.Sprintf("could not retrieve query executor for collection criteria %#v", cc))
 	}
 	defer qe.Done()
-	return RetrieveCollectionConfigPackageFromState(cc, qe)
+	return c.retrieveCollectionConfigPackageFromState(cc, qe)
+}
+
+func (c *simpleCollectionStore) retrieveCollectionConfigPackageFromState(cc common.CollectionCriteria, qe ledger.QueryExecutor) (*common.CollectionConfigPackage, error) {
+	if c.ccInfoProvider == nil {
+		return RetrieveCollectionConfigPackageFromState(cc, qe)
+	}
+
+	ccInfo, err := c.ccInfoProvider.ChaincodeInfo(cc.Namespace, qe)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error while retrieving collection for collection criteria %#v", cc))
+	}
+	if ccInfo == nil || ccInfo.CollectionConfigPkg == nil {
+		return nil, NoSuchCollectionError(cc)
+	}
+	return ccInfo.CollectionConfigPkg, nil
 }
 
 // RetrieveCollectionConfigPackageFromState retrieves the collection config package from the given key from the given state
@@ -93,6 +111,9 @@ func ParseCollectionConfig(colBytes []byte) (*common.CollectionConfigPackage, er
 }
 
 func (c *simpleCollectionStore) retrieveCollectionConfig(cc common.CollectionCriteria, qe ledger.QueryExecutor) (*common.StaticCollectionConfig, error) {
+	if mspID, ok := MSPIDIfImplicitCollection(cc.Collection); ok {
+		return GenerateImplicitCollectionForOrg(mspID), nil
+	}
 	collections, err := c.retrieveCollectionConfigPackage(cc, qe)
 	if err != nil {
 		return nil, err
@@ -13,6 +13,7 @@ import (
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	lm "github.com/hyperledger/fabric/common/mocks/ledger"
 	"github.com/hyperledger/fabric/core/ledger"
+	ledgermock "github.com/hyperledger/fabric/core/ledger/mock"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
@@ -37,7 +38,7 @@ func (c *mockStoreSupport) GetIdentityDeserializer(chainID string) msp.IdentityD
 func TestCollectionStore(t *testing.T) {
 	wState := make(map[string]map[string][]byte)
 	support := &mockStoreSupport{Qe: &lm.MockQueryExecutor{State: wState}}
-	cs := NewSimpleCollectionStore(support)
+	cs := NewSimpleCollectionStore(support, nil)
 	assert.NotNil(t, cs)
 
 	support.QErr = errors.New("")
@@ -129,3 +130,58 @@ func TestCollectionStore(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, allowedAccess)
 }
+
+func TestCollectionStoreImplicitCollection(t *testing.T) {
+	// implicit collections are not defined in the state of lscc
+	support := &mockStoreSupport{Qe: &lm.MockQueryExecutor{State: map[string]map[string][]byte{}}}
+	cs := NewSimpleCollectionStore(support, nil)
+
+	ccr := common.CollectionCriteria{Channel: "ch", Namespace: "+lifecycle", Collection: "_implicit_org_Org1MSP"}
+	c, err := cs.RetrieveCollection(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Org1MSP"}, c.MemberOrgs())
+
+	pc, err := cs.RetrieveCollectionPersistenceConfigs(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), pc.BlockToLive())
+
+	_, err = cs.RetrieveCollection(common.CollectionCriteria{Channel: "ch", Namespace: "+lifecycle", Collection: "mycollection"})
+	assert.Error(t, err)
+}
+
+func TestCollectionStoreWithCCInfoProvider(t *testing.T) {
+	qe := &lm.MockQueryExecutor{State: map[string]map[string][]byte{}}
+	support := &mockStoreSupport{Qe: qe}
+	ccInfoProvider := &ledgermock.DeployedChaincodeInfoProvider{}
+	cs := NewSimpleCollectionStore(support, ccInfoProvider)
+
+	ccr := common.CollectionCriteria{Channel: "ch", Namespace: "cc", Collection: "mycollection"}
+
+	// the chaincode is not deployed
+	_, err := cs.RetrieveCollectionConfigPackage(ccr)
+	assert.EqualError(t, err, "collection ch/cc/mycollection could not be found")
+
+	ccInfoProvider.ChaincodeInfoReturns(nil, errors.New("provider error"))
+	_, err = cs.RetrieveCollectionConfigPackage(ccr)
+	assert.Contains(t, err.Error(), "provider error")
+
+	// the collections are retrieved from the provider rather than from the state of lscc
+	var signers = [][]byte{[]byte("signer0"), []byte("signer1")}
+	accessPolicy := createCollectionPolicyConfig(cauthdsl.Envelope(cauthdsl.Or(cauthdsl.SignedBy(0), cauthdsl.SignedBy(1)), signers))
+	ccp := &common.CollectionConfigPackage{Config: []*common.CollectionConfig{{
+		Payload: &common.CollectionConfig_StaticCollectionConfig{
+			StaticCollectionConfig: &common.StaticCollectionConfig{Name: "mycollection", MemberOrgsPolicy: accessPolicy, BlockToLive: 10},
+		},
+	}}}
+	ccInfoProvider.ChaincodeInfoReturns(&ledger.DeployedChaincodeInfo{Name: "cc", CollectionConfigPkg: ccp}, nil)
+
+	pkg, err := cs.RetrieveCollectionConfigPackage(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, ccp, pkg)
+	pc, err := cs.RetrieveCollectionPersistenceConfigs(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), pc.BlockToLive())
+	ccName, ccQE := ccInfoProvider.ChaincodeInfoArgsForCall(3)
+	assert.Equal(t, "cc", ccName)
+	assert.Equal(t, qe, ccQE)
+}
@@ -140,8 +140,9 @@ var _ = Describe("Container", func() {
 			})
 
 			Context("the request is for an unknown VM provider type", func() {
-				It("causes the system to halt as this is a serious bug", func() {
-					Expect(func() { vmController.Process("Unknown-Type", nil) }).To(Panic())
+				It("returns an error as the VM type is not configured", func() {
+					err := vmController.Process("Unknown-Type", vmcReq)
+					Expect(err).To(MatchError("unsupported VM type: Unknown-Type"))
 					Expect(vmProvider.NewVMCallCount()).To(Equal(0))
 				})
 			})
@@ -59,12 +59,14 @@ func NewVMController(vmProviders map[string]VMProvider) *VMController {
 	}
 }
 
-func (vmc *VMController) newVM(typ string) VM {
+// newVM returns a VM of the given type. A VM type is not available when the
+// peer is configured without it, e.g. docker when no vm endpoint is set
+func (vmc *VMController) newVM(typ string) (VM, error) {
 	v, ok := vmc.vmProviders[typ]
 	if !ok {
-		vmLogger.Panicf("Programming error: unsupported VM type: %s", typ)
+		return nil, fmt.Errorf("unsupported VM type: %s", typ)
 	}
-	return v.NewVM()
+	return v.NewVM(), nil
 }
 
 func (vmc *VMController) lockContainer(id string) {
@@ -199,7 +201,10 @@ func (w WaitContainerReq) GetCCID() ccintf.CCID {
 }
 
 func (vmc *VMController) Process(vmtype string, req VMCReq) error {
-	v := vmc.newVM(vmtype)
+	v, err := vmc.newVM(vmtype)
+	if err != nil {
+		return err
+	}
 	ccid := req.GetCCID()
 	id := ccid.GetName()
 
@@ -0,0 +1,293 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("externalbuilder")
+
+// DefaultEnvWhitelist is the list of environment variables of the peer
+// that are always propagated to the external builder programs
+var DefaultEnvWhitelist = []string{"LD_LIBRARY_PATH", "LIBPATH", "PATH", "TMPDIR"}
+
+// Config is the configuration of an external builder, as specified
+// in the chaincode.externalBuilders section of core.yaml
+type Config struct {
+	// Path is the dir that contains the programs bin/detect, bin/build,
+	// bin/release (optional) and bin/run of the external builder
+	Path string `mapstructure:"path" yaml:"path"`
+	// Name is the name of the external builder, used in logs and for
+	// locating the builder of a chaincode that was built previously
+	Name string `mapstructure:"name" yaml:"name"`
+	// EnvironmentWhitelist is the list of additional environment variables
+	// of the peer that are propagated to the external builder programs
+	EnvironmentWhitelist []string `mapstructure:"environmentWhitelist" yaml:"environmentWhitelist"`
+}
+
+// BuildMetadata is the content of the file metadata.json that is
+// passed to the detect and build programs of an external builder
+type BuildMetadata struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Path    string `json:"path"`
+	Type    string `json:"type"`
+}
+
+// BuildContext holds the dirs that are passed to the external builder
+// programs while detecting, building and releasing a chaincode package
+type BuildContext struct {
+	CCID        ccintf.CCID
+	ScratchDir  string
+	SourceDir   string
+	MetadataDir string
+	BldDir      string
+	ReleaseDir  string
+}
+
+// NewBuildContext creates a scratch dir in which the chaincode code package,
+// a gzipped tar, is extracted and the build metadata is written. The caller
+// is expected to invoke Cleanup once the build context is no longer needed
+func NewBuildContext(ccid ccintf.CCID, md *BuildMetadata, codePackage []byte) (*BuildContext, error) {
+	scratchDir, err := ioutil.TempDir("", "fabric-"+sanitizeName(ccid.GetName()))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create temp dir")
+	}
+	bc := &BuildContext{
+		CCID:        ccid,
+		ScratchDir:  scratchDir,
+		SourceDir:   filepath.Join(scratchDir, "src"),
+		MetadataDir: filepath.Join(scratchDir, "metadata"),
+		BldDir:      filepath.Join(scratchDir, "bld"),
+		ReleaseDir:  filepath.Join(scratchDir, "release"),
+	}
+	for _, dir := range []string{bc.SourceDir, bc.MetadataDir, bc.BldDir, bc.ReleaseDir} {
+		if err := os.Mkdir(dir, 0700); err != nil {
+			bc.Cleanup()
+			return nil, errors.Wrapf(err, "could not create dir [%s]", dir)
+		}
+	}
+	if err := untar(codePackage, bc.SourceDir); err != nil {
+		bc.Cleanup()
+		return nil, errors.WithMessage(err, "could not extract the chaincode code package")
+	}
+	mdBytes, err := json.Marshal(md)
+	if err != nil {
+		bc.Cleanup()
+		return nil, errors.Wrap(err, "failed to marshal build metadata")
+	}
+	if err := ioutil.WriteFile(filepath.Join(bc.MetadataDir, "metadata.json"), mdBytes, 0600); err != nil {
+		bc.Cleanup()
+		return nil, errors.Wrap(err, "could not write build metadata")
+	}
+	return bc, nil
+}
+
+// Cleanup removes the scratch dir of the build context
+func (bc *BuildContext) Cleanup() {
+	os.RemoveAll(bc.ScratchDir)
+}
+
+// Builder runs the programs of an external builder
+type Builder struct {
+	Location     string
+	Name         string
+	EnvWhitelist []string
+	Logger       *flogging.FabricLogger
+}
+
+// NewBuilder creates a Builder from the supplied configuration
+func NewBuilder(conf Config) *Builder {
+	return &Builder{
+		Location:     filepath.Clean(conf.Path),
+		Name:         conf.Name,
+		EnvWhitelist: conf.EnvironmentWhitelist,
+		Logger:       logger.Named(conf.Name),
+	}
+}
+
+// Detect runs the detect program of the builder. The chaincode package is
+// detected by the builder if the detect program exits with status zero
+func (b *Builder) Detect(bc *BuildContext) bool {
+	detect := filepath.Join(b.Location, "bin", "detect")
+	cmd := b.newCommand(detect, nil, bc.SourceDir, bc.MetadataDir)
+	if err := b.runCommand(cmd); err != nil {
+		b.Logger.Debugf("detect [%s] did not detect chaincode [%s]: %s", detect, bc.CCID.GetName(), err)
+		return false
+	}
+	return true
+}
+
+// Build runs the build program of the builder, which is expected to place
+// the build output in the bld dir of the build context
+func (b *Builder) Build(bc *BuildContext) error {
+	build := filepath.Join(b.Location, "bin", "build")
+	cmd := b.newCommand(build, nil, bc.SourceDir, bc.MetadataDir, bc.BldDir)
+	if err := b.runCommand(cmd); err != nil {
+		return errors.WithMessage(err, "external builder failed to build")
+	}
+	return nil
+}
+
+// Release runs the optional release program of the builder, which is
+// expected to place the artifacts to be consumed by the peer, if any, in
+// the release dir of the build context
+func (b *Builder) Release(bc *BuildContext) error {
+	release := filepath.Join(b.Location, "bin", "release")
+	if _, err := os.Stat(release); os.IsNotExist(err) {
+		b.Logger.Debugf("builder [%s] does not have a release program", b.Name)
+		return nil
+	}
+	cmd := b.newCommand(release, nil, bc.BldDir, bc.ReleaseDir)
+	if err := b.runCommand(cmd); err != nil {
+		return errors.WithMessage(err, "external builder failed to release")
+	}
+	return nil
+}
+
+// Run starts the run program of the builder with the build output dir and
+// the dir that contains the run metadata. The returned Instance tracks the
+// long-running chaincode process
+func (b *Builder) Run(ccid ccintf.CCID, bldDir, runMetadataDir string, env []string) (*Instance, error) {
+	run := filepath.Join(b.Location, "bin", "run")
+	cmd := b.newCommand(run, env, bldDir, runMetadataDir)
+	chaincodeLogger := flogging.MustGetLogger("peer.chaincode." + sanitizeName(ccid.GetName()))
+	cmd.Stdout = &logWriter{logger: chaincodeLogger}
+	cmd.Stderr = &logWriter{logger: chaincodeLogger}
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Wrapf(err, "could not start the run program [%s]", run)
+	}
+	return newInstance(ccid, cmd, runMetadataDir), nil
+}
+
+// newCommand creates the command for the given builder program. The command
+// inherits the whitelisted environment variables of the peer along with the
+// supplied additional environment variables
+func (b *Builder) newCommand(program string, additionalEnv []string, args ...string) *exec.Cmd {
+	cmd := exec.Command(program, args...)
+	whitelist := append(append([]string{}, DefaultEnvWhitelist...), b.EnvWhitelist...)
+	for _, key := range whitelist {
+		if val, ok := os.LookupEnv(key); ok {
+			cmd.Env = append(cmd.Env, key+"="+val)
+		}
+	}
+	cmd.Env = append(cmd.Env, additionalEnv...)
+	return cmd
+}
+
+func (b *Builder) runCommand(cmd *exec.Cmd) error {
+	output := &bytes.Buffer{}
+	cmd.Stdout = output
+	cmd.Stderr = output
+	err := cmd.Run()
+	if output.Len() != 0 {
+		b.Logger.Debugf("output of [%s]:\n%s", cmd.Path, output.String())
+	}
+	if err != nil {
+		return errors.Wrapf(err, "command [%s] failed, output: %s", cmd.Path, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
+
+// untar extracts a gzipped tar to the given dir. Only the dirs and the
+// regular files present in the tar are extracted
+func untar(codePackage []byte, dir string) error {
+	gzr, err := gzip.NewReader(bytes.NewReader(codePackage))
+	if err != nil {
+		return errors.Wrap(err, "could not open the code package as gzip")
+	}
+	defer gzr.Close()
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "could not read the code package as tar")
+		}
+		// cleaning the name as an absolute path confines the entry to the dir
+		target := filepath.Join(dir, filepath.Clean("/"+header.Name))
+		if target == dir {
+			continue
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0700); err != nil {
+				return errors.Wrapf(err, "could not create dir [%s]", target)
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+				return errors.Wrapf(err, "could not create dir [%s]", filepath.Dir(target))
+			}
+			if err := writeFile(target, tr, os.FileMode(header.Mode)&0700|0600); err != nil {
+				return err
+			}
+		default:
+			logger.Debugf("skipping entry [%s] of type [%c] in the code package", header.Name, header.Typeflag)
+		}
+	}
+}
+
+func writeFile(path string, r io.Reader, mode os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return errors.Wrapf(err, "could not create file [%s]", path)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, r); err != nil {
+		return errors.Wrapf(err, "could not write file [%s]", path)
+	}
+	return nil
+}
+
+// sanitizeName replaces the characters of a chaincode name that are
+// not suitable for the name of a dir or a logger
+func sanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '-'
+		}
+	}, name)
+}
+
+// logWriter writes each line of the output of a chaincode process to a logger
+type logWriter struct {
+	logger *flogging.FabricLogger
+	buf    bytes.Buffer
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+	for {
+		line, err := w.buf.ReadString('\n')
+		if err != nil {
+			// an incomplete line is retained until the rest of the line is written
+			w.buf.Reset()
+			w.buf.WriteString(line)
+			return len(p), nil
+		}
+		w.logger.Info(strings.TrimSuffix(line, "\n"))
+	}
+}
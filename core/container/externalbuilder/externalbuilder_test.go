@@ -0,0 +1,152 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBuildContext(t *testing.T) {
+	ccid := ccintf.CCID{Name: "mycc", Version: "v1"}
+	md := &BuildMetadata{Name: "mycc", Version: "v1", Path: "github.com/mycc", Type: "GOLANG"}
+	codePackage := codePackageBytes(t, map[string]string{
+		"main.go":          "package main",
+		"pkg/util.go":      "package pkg",
+		"../../escaped.go": "package escaped",
+	})
+
+	bc, err := NewBuildContext(ccid, md, codePackage)
+	require.NoError(t, err)
+
+	content, err := ioutil.ReadFile(filepath.Join(bc.SourceDir, "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main", string(content))
+	content, err = ioutil.ReadFile(filepath.Join(bc.SourceDir, "pkg", "util.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package pkg", string(content))
+	// an entry that points outside of the source dir is confined to the source dir
+	content, err = ioutil.ReadFile(filepath.Join(bc.SourceDir, "escaped.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package escaped", string(content))
+
+	mdBytes, err := ioutil.ReadFile(filepath.Join(bc.MetadataDir, "metadata.json"))
+	require.NoError(t, err)
+	readMetadata := &BuildMetadata{}
+	require.NoError(t, json.Unmarshal(mdBytes, readMetadata))
+	assert.Equal(t, md, readMetadata)
+
+	for _, dir := range []string{bc.BldDir, bc.ReleaseDir} {
+		_, err := os.Stat(dir)
+		assert.NoError(t, err)
+	}
+
+	bc.Cleanup()
+	_, err = os.Stat(bc.ScratchDir)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestNewBuildContextBadCodePackage(t *testing.T) {
+	ccid := ccintf.CCID{Name: "mycc", Version: "v1"}
+	_, err := NewBuildContext(ccid, &BuildMetadata{}, []byte("not-a-gzip"))
+	assert.EqualError(t, err, "could not extract the chaincode code package: could not open the code package as gzip: gzip: invalid header")
+}
+
+func TestBuilderDetectBuildRelease(t *testing.T) {
+	builder := NewBuilder(Config{Path: "testdata/goodbuilder", Name: "good"})
+
+	bc := newTestBuildContext(t, "GOLANG", map[string]string{"main.go": "package main"})
+	defer bc.Cleanup()
+	assert.True(t, builder.Detect(bc))
+	require.NoError(t, builder.Build(bc))
+	content, err := ioutil.ReadFile(filepath.Join(bc.BldDir, "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main", string(content))
+	require.NoError(t, builder.Release(bc))
+	content, err = ioutil.ReadFile(filepath.Join(bc.ReleaseDir, "release.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "released\n", string(content))
+
+	javaBC := newTestBuildContext(t, "JAVA", map[string]string{"main.go": "package main"})
+	defer javaBC.Cleanup()
+	assert.False(t, builder.Detect(javaBC))
+}
+
+func TestBuilderBuildFailure(t *testing.T) {
+	builder := NewBuilder(Config{Path: "testdata/failbuilder", Name: "fail"})
+
+	bc := newTestBuildContext(t, "GOLANG", map[string]string{"main.go": "package main"})
+	defer bc.Cleanup()
+	assert.True(t, builder.Detect(bc))
+	err := builder.Build(bc)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "external builder failed to build")
+	assert.Contains(t, err.Error(), "build failed on purpose")
+	// the release program is optional
+	assert.NoError(t, builder.Release(bc))
+}
+
+func TestBuilderEnvironment(t *testing.T) {
+	os.Setenv("EXTERNAL_BUILDER_WHITELISTED", "whitelisted")
+	defer os.Unsetenv("EXTERNAL_BUILDER_WHITELISTED")
+	os.Setenv("EXTERNAL_BUILDER_NOT_WHITELISTED", "not-whitelisted")
+	defer os.Unsetenv("EXTERNAL_BUILDER_NOT_WHITELISTED")
+
+	builder := NewBuilder(Config{
+		Path:                 "testdata/goodbuilder",
+		Name:                 "good",
+		EnvironmentWhitelist: []string{"EXTERNAL_BUILDER_WHITELISTED"},
+	})
+	cmd := builder.newCommand("program", []string{"ADDITIONAL=additional"})
+	assert.Contains(t, cmd.Env, "EXTERNAL_BUILDER_WHITELISTED=whitelisted")
+	assert.Contains(t, cmd.Env, "ADDITIONAL=additional")
+	assert.Contains(t, cmd.Env, "PATH="+os.Getenv("PATH"))
+	assert.NotContains(t, cmd.Env, "EXTERNAL_BUILDER_NOT_WHITELISTED=not-whitelisted")
+}
+
+func TestSanitizeName(t *testing.T) {
+	assert.Equal(t, "mycc-v1.0_beta", sanitizeName("mycc:v1.0_beta"))
+	assert.Equal(t, "my-cc-", sanitizeName("my/cc*"))
+}
+
+func newTestBuildContext(t *testing.T, ccType string, files map[string]string) *BuildContext {
+	ccid := ccintf.CCID{Name: "mycc", Version: "v1"}
+	md := &BuildMetadata{Name: "mycc", Version: "v1", Path: "github.com/mycc", Type: ccType}
+	bc, err := NewBuildContext(ccid, md, codePackageBytes(t, files))
+	require.NoError(t, err)
+	return bc
+}
+
+func codePackageBytes(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		})
+		require.NoError(t, err)
+		_, err = tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/pkg/errors"
+)
+
+// DefaultTerminationTimeout is the duration for which a chaincode
+// process is given to exit after a SIGTERM before it is killed
+const DefaultTerminationTimeout = 5 * time.Second
+
+// Instance represents a chaincode process launched by the run program
+// of an external builder
+type Instance struct {
+	CCID           ccintf.CCID
+	RunMetadataDir string
+
+	cmd      *exec.Cmd
+	done     chan struct{}
+	exitCode int
+	err      error
+}
+
+func newInstance(ccid ccintf.CCID, cmd *exec.Cmd, runMetadataDir string) *Instance {
+	i := &Instance{
+		CCID:           ccid,
+		RunMetadataDir: runMetadataDir,
+		cmd:            cmd,
+		done:           make(chan struct{}),
+	}
+	go i.waitForExit()
+	return i
+}
+
+func (i *Instance) waitForExit() {
+	err := i.cmd.Wait()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		i.exitCode = -1
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			i.exitCode = status.ExitStatus()
+		}
+	} else if err != nil {
+		i.exitCode = -1
+		i.err = errors.Wrapf(err, "failed to wait on the chaincode process of [%s]", i.CCID.GetName())
+	}
+	close(i.done)
+}
+
+// Wait blocks until the chaincode process exits and returns its exit code
+func (i *Instance) Wait() (int, error) {
+	<-i.done
+	return i.exitCode, i.err
+}
+
+// Stop sends a SIGTERM to the chaincode process and kills the process if it
+// does not exit within the given timeout. The run metadata dir is removed
+func (i *Instance) Stop(timeout time.Duration) error {
+	defer os.RemoveAll(i.RunMetadataDir)
+	select {
+	case <-i.done:
+		return nil
+	default:
+	}
+
+	if err := i.cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		logger.Debugf("failed to send SIGTERM to the chaincode process of [%s]: %s", i.CCID.GetName(), err)
+	}
+	select {
+	case <-i.done:
+		return nil
+	case <-time.After(timeout):
+	}
+
+	if err := i.cmd.Process.Kill(); err != nil {
+		return errors.Wrapf(err, "failed to kill the chaincode process of [%s]", i.CCID.GetName())
+	}
+	<-i.done
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// DefaultDialTimeout is the timeout for connecting to a chaincode server
+// when the connection info of the chaincode does not specify one
+const DefaultDialTimeout = 3 * time.Second
+
+// ChaincodeServerInfo is the content of the file chaincode/server/connection.json
+// that the release program of an external builder places in the release dir of
+// chaincode that runs as a server. The peer connects to such a chaincode at the
+// given address instead of launching it with the run program
+type ChaincodeServerInfo struct {
+	Address            string `json:"address"`
+	DialTimeout        string `json:"dial_timeout,omitempty"`
+	TLSRequired        bool   `json:"tls_required"`
+	ClientAuthRequired bool   `json:"client_auth_required"`
+	ClientKey          string `json:"client_key,omitempty"`
+	ClientCert         string `json:"client_cert,omitempty"`
+	RootCert           string `json:"root_cert,omitempty"`
+}
+
+// loadServerInfo returns the chaincode server info present in the given release
+// dir, or nil if the chaincode does not run as a server
+func loadServerInfo(releaseDir string) (*ChaincodeServerInfo, error) {
+	path := filepath.Join(releaseDir, "chaincode", "server", "connection.json")
+	serverInfoBytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read the chaincode server info [%s]", path)
+	}
+	serverInfo := &ChaincodeServerInfo{}
+	if err := json.Unmarshal(serverInfoBytes, serverInfo); err != nil {
+		return nil, errors.Wrapf(err, "malformed chaincode server info [%s]", path)
+	}
+	if serverInfo.Address == "" {
+		return nil, errors.Errorf("chaincode server address is missing in [%s]", path)
+	}
+	return serverInfo, nil
+}
+
+// clientConfig returns the gRPC client config for connecting to the chaincode server
+func (si *ChaincodeServerInfo) clientConfig() (comm.ClientConfig, error) {
+	dialTimeout := DefaultDialTimeout
+	if si.DialTimeout != "" {
+		var err error
+		if dialTimeout, err = time.ParseDuration(si.DialTimeout); err != nil {
+			return comm.ClientConfig{}, errors.Wrapf(err, "malformed dial timeout [%s]", si.DialTimeout)
+		}
+	}
+
+	secOpts := &comm.SecureOptions{UseTLS: si.TLSRequired}
+	if si.TLSRequired {
+		if si.RootCert == "" {
+			return comm.ClientConfig{}, errors.New("root cert is required when TLS is required")
+		}
+		secOpts.ServerRootCAs = [][]byte{[]byte(si.RootCert)}
+		if si.ClientAuthRequired {
+			if si.ClientKey == "" || si.ClientCert == "" {
+				return comm.ClientConfig{}, errors.New("client key and cert are required when client auth is required")
+			}
+			secOpts.RequireClientCert = true
+			secOpts.Key = []byte(si.ClientKey)
+			secOpts.Certificate = []byte(si.ClientCert)
+		}
+	}
+
+	return comm.ClientConfig{
+		SecOpts: secOpts,
+		KaOpts:  comm.DefaultKeepaliveOptions,
+		Timeout: dialTimeout,
+	}, nil
+}
+
+// connect establishes a stream with the chaincode server and hands the stream over
+// to the chaincode support, which runs the chaincode protocol over the stream
+func connect(ccid ccintf.CCID, si *ChaincodeServerInfo, ccSupport ccintf.CCSupport) (*serverInstance, error) {
+	clientConfig, err := si.clientConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := comm.NewGRPCClient(clientConfig)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create the chaincode server client")
+	}
+	conn, err := client.NewConnection(si.Address, "")
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not connect to the chaincode server at "+si.Address)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := pb.NewChaincodeClient(conn).Connect(ctx)
+	if err != nil {
+		cancel()
+		conn.Close()
+		return nil, errors.Wrapf(err, "could not establish a stream with the chaincode server at %s", si.Address)
+	}
+
+	s := &serverInstance{
+		ccid:   ccid,
+		conn:   conn,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go func() {
+		s.err = ccSupport.HandleChaincodeStream(stream)
+		close(s.done)
+	}()
+	return s, nil
+}
+
+// serverInstance represents the stream the peer established with a chaincode
+// server
+type serverInstance struct {
+	ccid   ccintf.CCID
+	conn   *grpc.ClientConn
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// Wait blocks until the stream with the chaincode server ends. The returned
+// exit code is always zero, as there is no process to exit
+func (s *serverInstance) Wait() (int, error) {
+	<-s.done
+	return 0, s.err
+}
+
+// Stop ends the stream with the chaincode server and closes the connection
+func (s *serverInstance) Stop(timeout time.Duration) error {
+	s.cancel()
+	defer s.conn.Close()
+	select {
+	case <-s.done:
+	case <-time.After(timeout):
+		logger.Warningf("timed out waiting for the stream with the chaincode server of [%s] to end", s.ccid.GetName())
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChaincodeServer sends a REGISTER message on each stream established by
+// the peer and keeps the stream open until the peer ends it
+type fakeChaincodeServer struct{}
+
+func (f *fakeChaincodeServer) Connect(stream pb.Chaincode_ConnectServer) error {
+	if err := stream.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTER}); err != nil {
+		return err
+	}
+	<-stream.Context().Done()
+	return nil
+}
+
+// fakeCCSupport forwards the messages received on the chaincode stream
+type fakeCCSupport struct {
+	msgs chan *pb.ChaincodeMessage
+}
+
+func (f *fakeCCSupport) HandleChaincodeStream(stream ccintf.ChaincodeStream) error {
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		f.msgs <- msg
+	}
+}
+
+func newFakeChaincodeServer(t *testing.T, secOpts *comm.SecureOptions) *comm.GRPCServer {
+	server, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{SecOpts: secOpts})
+	require.NoError(t, err)
+	pb.RegisterChaincodeServer(server.Server(), &fakeChaincodeServer{})
+	go server.Start()
+	return server
+}
+
+func TestLoadServerInfo(t *testing.T) {
+	releaseDir, err := ioutil.TempDir("", "release")
+	require.NoError(t, err)
+	defer os.RemoveAll(releaseDir)
+
+	serverInfo, err := loadServerInfo(releaseDir)
+	assert.NoError(t, err)
+	assert.Nil(t, serverInfo)
+
+	serverDir := filepath.Join(releaseDir, "chaincode", "server")
+	require.NoError(t, os.MkdirAll(serverDir, 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(serverDir, "connection.json"), []byte("{"), 0600))
+	_, err = loadServerInfo(releaseDir)
+	assert.Contains(t, err.Error(), "malformed chaincode server info")
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(serverDir, "connection.json"), []byte(`{"tls_required":false}`), 0600))
+	_, err = loadServerInfo(releaseDir)
+	assert.Contains(t, err.Error(), "chaincode server address is missing")
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(serverDir, "connection.json"), []byte(`{"address":"ccserver:9999","dial_timeout":"10s"}`), 0600))
+	serverInfo, err = loadServerInfo(releaseDir)
+	require.NoError(t, err)
+	assert.Equal(t, &ChaincodeServerInfo{Address: "ccserver:9999", DialTimeout: "10s"}, serverInfo)
+}
+
+func TestServerInfoClientConfig(t *testing.T) {
+	clientConfig, err := (&ChaincodeServerInfo{Address: "ccserver:9999"}).clientConfig()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultDialTimeout, clientConfig.Timeout)
+	assert.False(t, clientConfig.SecOpts.UseTLS)
+
+	clientConfig, err = (&ChaincodeServerInfo{
+		Address:            "ccserver:9999",
+		DialTimeout:        "10s",
+		TLSRequired:        true,
+		ClientAuthRequired: true,
+		ClientKey:          "key",
+		ClientCert:         "cert",
+		RootCert:           "root",
+	}).clientConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, clientConfig.Timeout)
+	assert.Equal(t, &comm.SecureOptions{
+		UseTLS:            true,
+		RequireClientCert: true,
+		Key:               []byte("key"),
+		Certificate:       []byte("cert"),
+		ServerRootCAs:     [][]byte{[]byte("root")},
+	}, clientConfig.SecOpts)
+
+	tests := []struct {
+		serverInfo *ChaincodeServerInfo
+		errMsg     string
+	}{
+		{&ChaincodeServerInfo{DialTimeout: "ten"}, "malformed dial timeout [ten]: time: invalid duration \"ten\""},
+		{&ChaincodeServerInfo{TLSRequired: true}, "root cert is required when TLS is required"},
+		{&ChaincodeServerInfo{TLSRequired: true, RootCert: "root", ClientAuthRequired: true}, "client key and cert are required when client auth is required"},
+	}
+	for _, tc := range tests {
+		_, err := tc.serverInfo.clientConfig()
+		assert.EqualError(t, err, tc.errMsg)
+	}
+}
+
+func TestConnect(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	serverKeyPair, err := ca.NewServerCertKeyPair("127.0.0.1")
+	require.NoError(t, err)
+	clientKeyPair, err := ca.NewClientCertKeyPair()
+	require.NoError(t, err)
+
+	server := newFakeChaincodeServer(t, &comm.SecureOptions{
+		UseTLS:            true,
+		RequireClientCert: true,
+		Key:               serverKeyPair.Key,
+		Certificate:       serverKeyPair.Cert,
+		ClientRootCAs:     [][]byte{ca.CertBytes()},
+	})
+	defer server.Stop()
+
+	ccSupport := &fakeCCSupport{msgs: make(chan *pb.ChaincodeMessage, 1)}
+	serverInfo := &ChaincodeServerInfo{
+		Address:            server.Address(),
+		TLSRequired:        true,
+		ClientAuthRequired: true,
+		ClientKey:          string(clientKeyPair.Key),
+		ClientCert:         string(clientKeyPair.Cert),
+		RootCert:           string(ca.CertBytes()),
+	}
+	ccid := ccintf.CCID{Name: "mycc", Version: "v1"}
+	instance, err := connect(ccid, serverInfo, ccSupport)
+	require.NoError(t, err)
+
+	select {
+	case msg := <-ccSupport.msgs:
+		assert.Equal(t, pb.ChaincodeMessage_REGISTER, msg.Type)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the REGISTER message")
+	}
+
+	require.NoError(t, instance.Stop(5*time.Second))
+	_, err = instance.Wait()
+	assert.Error(t, err)
+
+	// the server cannot be reached without a client certificate
+	serverInfo.ClientAuthRequired = false
+	serverInfo.DialTimeout = "100ms"
+	_, err = connect(ccid, serverInfo, ccSupport)
+	assert.Contains(t, err.Error(), "could not connect to the chaincode server at "+server.Address())
+}
+
+func TestExternalVMStartChaincodeServer(t *testing.T) {
+	server := newFakeChaincodeServer(t, nil)
+	defer server.Stop()
+
+	durablePath, err := ioutil.TempDir("", "externalbuilder")
+	require.NoError(t, err)
+	defer os.RemoveAll(durablePath)
+
+	p := NewProvider("peer-address", durablePath, []Config{{Path: "testdata/serverbuilder", Name: "server"}})
+	vm := p.NewVM()
+	ccid := ccintf.CCID{Name: "mycc", Version: "v1"}
+
+	serverInfoBytes, err := json.Marshal(&ChaincodeServerInfo{Address: server.Address()})
+	require.NoError(t, err)
+	codePackage := codePackageBytes(t, map[string]string{"connection.json": string(serverInfoBytes)})
+	built, err := p.Build(ccid, "GOLANG", "github.com/mycc", codePackage)
+	require.NoError(t, err)
+	require.True(t, built)
+
+	err = vm.Start(ccid, nil, nil, nil, nil)
+	assert.EqualError(t, err, "chaincode support is not available to handle the chaincode server stream")
+
+	ccSupport := &fakeCCSupport{msgs: make(chan *pb.ChaincodeMessage, 1)}
+	p.ChaincodeSupport = ccSupport
+	require.NoError(t, vm.Start(ccid, nil, nil, nil, nil))
+	select {
+	case msg := <-ccSupport.msgs:
+		assert.Equal(t, pb.ChaincodeMessage_REGISTER, msg.Type)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the REGISTER message")
+	}
+
+	require.NoError(t, vm.Stop(ccid, 0, false, false))
+	_, err = vm.Wait(ccid)
+	assert.EqualError(t, err, "chaincode [mycc-v1] is not running")
+}
@@ -0,0 +1,356 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/pkg/errors"
+)
+
+// ContainerType is the string which the external builder container type
+// is registered with the container.VMController
+const ContainerType = "EXTERNAL"
+
+const (
+	buildInfoFileName = "build-info.json"
+	runConfigFileName = "chaincode.json"
+)
+
+// buildInfo is persisted along with the build output of a chaincode so that
+// the chaincode can be run without being rebuilt after a restart of the peer
+type buildInfo struct {
+	BuilderName string `json:"builder_name"`
+}
+
+// RunConfig is the content of the file chaincode.json that is passed to the
+// run program of an external builder in the run metadata dir. The TLS
+// material is present only if TLS is enabled for the chaincode
+type RunConfig struct {
+	CCID        string `json:"chaincode_id"`
+	PeerAddress string `json:"peer_address"`
+	ClientCert  string `json:"client_cert,omitempty"`
+	ClientKey   string `json:"client_key,omitempty"`
+	RootCert    string `json:"root_cert,omitempty"`
+}
+
+// instance is a running chaincode, either a process launched by the run
+// program of an external builder or a stream with a chaincode server
+type instance interface {
+	Stop(timeout time.Duration) error
+	Wait() (int, error)
+}
+
+// Provider implements container.VMProvider for the chaincode that is built
+// and run by the external builders configured for the peer. The build output
+// of a chaincode is persisted under the durable path, one dir per chaincode.
+// ChaincodeSupport handles the streams with the chaincode that runs as a
+// server and must be set before such a chaincode is started
+type Provider struct {
+	PeerAddress        string
+	DurablePath        string
+	Builders           []*Builder
+	TerminationTimeout time.Duration
+	ChaincodeSupport   ccintf.CCSupport
+
+	mutex      sync.Mutex
+	instances  map[string]instance
+	buildLocks map[string]*sync.Mutex
+}
+
+// NewProvider creates a new instance of Provider
+func NewProvider(peerAddress, durablePath string, builderConfigs []Config) *Provider {
+	var builders []*Builder
+	for _, conf := range builderConfigs {
+		builders = append(builders, NewBuilder(conf))
+	}
+	return &Provider{
+		PeerAddress:        peerAddress,
+		DurablePath:        durablePath,
+		Builders:           builders,
+		TerminationTimeout: DefaultTerminationTimeout,
+		instances:          map[string]instance{},
+		buildLocks:         map[string]*sync.Mutex{},
+	}
+}
+
+// NewVM returns an ExternalVM bound to the provider
+func (p *Provider) NewVM() container.VM {
+	return &ExternalVM{provider: p}
+}
+
+// Build builds the chaincode package with the first external builder, in the
+// order of configuration, whose detect program detects the package. The
+// function returns false if none of the builders detects the package. If the
+// chaincode has already been built, the existing build output is reused
+func (p *Provider) Build(ccid ccintf.CCID, ccType, path string, codePackage []byte) (bool, error) {
+	if len(p.Builders) == 0 {
+		return false, nil
+	}
+
+	buildLock := p.buildLock(ccid)
+	buildLock.Lock()
+	defer buildLock.Unlock()
+
+	buildDir := p.buildDir(ccid)
+	builder, err := p.loadBuilder(buildDir)
+	if err != nil {
+		return false, err
+	}
+	if builder != nil {
+		logger.Debugf("chaincode [%s] has already been built by external builder [%s]", ccid.GetName(), builder.Name)
+		return true, nil
+	}
+
+	md := &BuildMetadata{
+		Name:    ccid.Name,
+		Version: ccid.Version,
+		Path:    path,
+		Type:    ccType,
+	}
+	bc, err := NewBuildContext(ccid, md, codePackage)
+	if err != nil {
+		return false, err
+	}
+	defer bc.Cleanup()
+
+	for _, b := range p.Builders {
+		if !b.Detect(bc) {
+			continue
+		}
+		logger.Infof("building chaincode [%s] with external builder [%s]", ccid.GetName(), b.Name)
+		if err := b.Build(bc); err != nil {
+			return false, errors.WithMessage(err, "external builder "+b.Name)
+		}
+		if err := b.Release(bc); err != nil {
+			return false, errors.WithMessage(err, "external builder "+b.Name)
+		}
+		if err := persistBuildOutput(bc, buildDir, b.Name); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	logger.Debugf("no external builder detected chaincode [%s]", ccid.GetName())
+	return false, nil
+}
+
+// buildLock returns the lock that serializes the builds of the chaincode, so
+// that distinct chaincodes are built concurrently
+func (p *Provider) buildLock(ccid ccintf.CCID) *sync.Mutex {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.buildLocks == nil {
+		p.buildLocks = map[string]*sync.Mutex{}
+	}
+	lock, ok := p.buildLocks[ccid.GetName()]
+	if !ok {
+		lock = &sync.Mutex{}
+		p.buildLocks[ccid.GetName()] = lock
+	}
+	return lock
+}
+
+func (p *Provider) buildDir(ccid ccintf.CCID) string {
+	return filepath.Join(p.DurablePath, sanitizeName(ccid.GetName()))
+}
+
+// loadBuilder returns the builder that built the chaincode whose build output
+// is present in the given dir, or nil if the dir does not contain a build output
+func (p *Provider) loadBuilder(buildDir string) (*Builder, error) {
+	buildInfoBytes, err := ioutil.ReadFile(filepath.Join(buildDir, buildInfoFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read build info")
+	}
+	bi := &buildInfo{}
+	if err := json.Unmarshal(buildInfoBytes, bi); err != nil {
+		return nil, errors.Wrapf(err, "malformed build info at [%s]", buildDir)
+	}
+	for _, b := range p.Builders {
+		if b.Name == bi.BuilderName {
+			return b, nil
+		}
+	}
+	// the builder is no longer configured, so the chaincode is rebuilt
+	logger.Warningf("external builder [%s] that built the chaincode at [%s] is not configured", bi.BuilderName, buildDir)
+	if err := os.RemoveAll(buildDir); err != nil {
+		return nil, errors.Wrapf(err, "could not remove the stale build output at [%s]", buildDir)
+	}
+	return nil, nil
+}
+
+// persistBuildOutput moves the bld and release dirs of the build context to
+// the durable build dir. The build info is written last so as to mark the
+// build output as complete
+func persistBuildOutput(bc *BuildContext, buildDir, builderName string) error {
+	if err := os.RemoveAll(buildDir); err != nil {
+		return errors.Wrapf(err, "could not remove the dir [%s]", buildDir)
+	}
+	if err := os.MkdirAll(buildDir, 0750); err != nil {
+		return errors.Wrapf(err, "could not create the dir [%s]", buildDir)
+	}
+	if err := os.Rename(bc.BldDir, filepath.Join(buildDir, "bld")); err != nil {
+		return errors.Wrap(err, "could not move the build output")
+	}
+	if err := os.Rename(bc.ReleaseDir, filepath.Join(buildDir, "release")); err != nil {
+		return errors.Wrap(err, "could not move the release output")
+	}
+	buildInfoBytes, err := json.Marshal(&buildInfo{BuilderName: builderName})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal build info")
+	}
+	if err := ioutil.WriteFile(filepath.Join(buildDir, buildInfoFileName), buildInfoBytes, 0600); err != nil {
+		return errors.Wrap(err, "could not write build info")
+	}
+	return nil
+}
+
+// ExternalVM is a vm that runs chaincode with the run program of the
+// external builder that built the chaincode
+type ExternalVM struct {
+	provider *Provider
+}
+
+// Start runs the chaincode that was previously built by an external builder.
+// The args are ignored, as the run program decides how the chaincode is
+// launched. The files to upload are provided to the run program as part of
+// the run config. If the release output of the chaincode contains chaincode
+// server info, the peer connects to the chaincode server instead
+func (vm *ExternalVM) Start(ccid ccintf.CCID, args, env []string, filesToUpload map[string][]byte, builder container.Builder) error {
+	p := vm.provider
+	buildDir := p.buildDir(ccid)
+	buildLock := p.buildLock(ccid)
+	buildLock.Lock()
+	b, err := p.loadBuilder(buildDir)
+	buildLock.Unlock()
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return errors.Errorf("chaincode [%s] has not been built by an external builder", ccid.GetName())
+	}
+
+	if err := vm.Stop(ccid, 0, false, false); err != nil {
+		logger.Debugf("failed to stop the existing instance of chaincode [%s]: %s", ccid.GetName(), err)
+	}
+
+	serverInfo, err := loadServerInfo(filepath.Join(buildDir, "release"))
+	if err != nil {
+		return err
+	}
+	if serverInfo != nil {
+		return vm.connect(ccid, serverInfo)
+	}
+
+	runMetadataDir, err := ioutil.TempDir("", "fabric-run-"+sanitizeName(ccid.GetName()))
+	if err != nil {
+		return errors.Wrap(err, "could not create temp dir")
+	}
+	if err := writeRunConfig(runMetadataDir, ccid, p.PeerAddress, filesToUpload); err != nil {
+		os.RemoveAll(runMetadataDir)
+		return err
+	}
+	instance, err := b.Run(ccid, filepath.Join(buildDir, "bld"), runMetadataDir, env)
+	if err != nil {
+		os.RemoveAll(runMetadataDir)
+		return errors.WithMessage(err, "external builder "+b.Name)
+	}
+
+	p.mutex.Lock()
+	p.instances[ccid.GetName()] = instance
+	p.mutex.Unlock()
+	logger.Debugf("started chaincode [%s] with external builder [%s]", ccid.GetName(), b.Name)
+	return nil
+}
+
+func (vm *ExternalVM) connect(ccid ccintf.CCID, serverInfo *ChaincodeServerInfo) error {
+	p := vm.provider
+	if p.ChaincodeSupport == nil {
+		return errors.New("chaincode support is not available to handle the chaincode server stream")
+	}
+	instance, err := connect(ccid, serverInfo, p.ChaincodeSupport)
+	if err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	p.instances[ccid.GetName()] = instance
+	p.mutex.Unlock()
+	logger.Debugf("connected to chaincode server of [%s] at %s", ccid.GetName(), serverInfo.Address)
+	return nil
+}
+
+// Stop terminates the chaincode process, or the stream with the chaincode
+// server, if running
+func (vm *ExternalVM) Stop(ccid ccintf.CCID, timeout uint, dontkill bool, dontremove bool) error {
+	p := vm.provider
+	p.mutex.Lock()
+	instance, ok := p.instances[ccid.GetName()]
+	delete(p.instances, ccid.GetName())
+	p.mutex.Unlock()
+	if !ok {
+		return nil
+	}
+	return instance.Stop(p.TerminationTimeout)
+}
+
+// Wait blocks until the chaincode process exits, or the stream with the
+// chaincode server ends, and returns the exit code
+func (vm *ExternalVM) Wait(ccid ccintf.CCID) (int, error) {
+	p := vm.provider
+	p.mutex.Lock()
+	instance, ok := p.instances[ccid.GetName()]
+	p.mutex.Unlock()
+	if !ok {
+		return 0, errors.Errorf("chaincode [%s] is not running", ccid.GetName())
+	}
+	return instance.Wait()
+}
+
+// HealthCheck is a no-op, as the external builders do not depend on a daemon
+func (vm *ExternalVM) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+// writeRunConfig writes the run config in the run metadata dir. The TLS material
+// is looked up in the files to upload by the base names of the file paths that
+// are used for a chaincode container
+func writeRunConfig(dir string, ccid ccintf.CCID, peerAddress string, filesToUpload map[string][]byte) error {
+	runConfig := &RunConfig{
+		CCID:        ccid.Name + ":" + ccid.Version,
+		PeerAddress: peerAddress,
+	}
+	for path, content := range filesToUpload {
+		switch filepath.Base(path) {
+		case "client.crt":
+			runConfig.ClientCert = string(content)
+		case "client.key":
+			runConfig.ClientKey = string(content)
+		case "peer.crt":
+			runConfig.RootCert = string(content)
+		}
+	}
+	runConfigBytes, err := json.Marshal(runConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal run config")
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, runConfigFileName), runConfigBytes, 0600); err != nil {
+		return errors.Wrap(err, "could not write run config")
+	}
+	return nil
+}
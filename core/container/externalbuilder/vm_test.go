@@ -0,0 +1,169 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderBuild(t *testing.T) {
+	durablePath, err := ioutil.TempDir("", "externalbuilder")
+	require.NoError(t, err)
+	defer os.RemoveAll(durablePath)
+
+	p := NewProvider("peer-address", durablePath, []Config{
+		{Path: "testdata/failbuilder", Name: "fail"},
+		{Path: "testdata/goodbuilder", Name: "good"},
+	})
+	ccid := ccintf.CCID{Name: "mycc", Version: "v1"}
+	codePackage := codePackageBytes(t, map[string]string{"main.go": "package main"})
+
+	// the first builder detects every package and fails the build
+	_, err = p.Build(ccid, "GOLANG", "github.com/mycc", codePackage)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "external builder fail: external builder failed to build")
+
+	p.Builders = p.Builders[1:]
+	built, err := p.Build(ccid, "JAVA", "github.com/mycc", codePackage)
+	require.NoError(t, err)
+	assert.False(t, built)
+
+	built, err = p.Build(ccid, "GOLANG", "github.com/mycc", codePackage)
+	require.NoError(t, err)
+	assert.True(t, built)
+	buildDir := filepath.Join(durablePath, "mycc-v1")
+	_, err = os.Stat(filepath.Join(buildDir, "bld", "main.go"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(buildDir, "release", "release.txt"))
+	assert.NoError(t, err)
+
+	// the existing build output is reused
+	require.NoError(t, os.Remove(filepath.Join(buildDir, "bld", "main.go")))
+	built, err = p.Build(ccid, "GOLANG", "github.com/mycc", codePackage)
+	require.NoError(t, err)
+	assert.True(t, built)
+	_, err = os.Stat(filepath.Join(buildDir, "bld", "main.go"))
+	assert.True(t, os.IsNotExist(err))
+
+	// the build output of a builder that is no longer configured is discarded
+	p.Builders = nil
+	built, err = p.Build(ccid, "GOLANG", "github.com/mycc", codePackage)
+	require.NoError(t, err)
+	assert.False(t, built)
+	p.Builders = []*Builder{NewBuilder(Config{Path: "testdata/goodbuilder", Name: "renamed"})}
+	built, err = p.Build(ccid, "GOLANG", "github.com/mycc", codePackage)
+	require.NoError(t, err)
+	assert.True(t, built)
+	_, err = os.Stat(filepath.Join(buildDir, "bld", "main.go"))
+	assert.NoError(t, err)
+}
+
+func TestProviderBuildLock(t *testing.T) {
+	durablePath, err := ioutil.TempDir("", "externalbuilder")
+	require.NoError(t, err)
+	defer os.RemoveAll(durablePath)
+
+	p := NewProvider("peer-address", durablePath, []Config{
+		{Path: "testdata/goodbuilder", Name: "good"},
+	})
+	ccid1 := ccintf.CCID{Name: "mycc1", Version: "v1"}
+	ccid2 := ccintf.CCID{Name: "mycc2", Version: "v1"}
+	assert.True(t, p.buildLock(ccid1) == p.buildLock(ccid1))
+	assert.False(t, p.buildLock(ccid1) == p.buildLock(ccid2))
+
+	// a build in progress for one chaincode does not block the build of another
+	buildLock := p.buildLock(ccid1)
+	buildLock.Lock()
+	defer buildLock.Unlock()
+	codePackage := codePackageBytes(t, map[string]string{"main.go": "package main"})
+	built, err := p.Build(ccid2, "GOLANG", "github.com/mycc", codePackage)
+	require.NoError(t, err)
+	assert.True(t, built)
+}
+
+func TestExternalVMStartStop(t *testing.T) {
+	durablePath, err := ioutil.TempDir("", "externalbuilder")
+	require.NoError(t, err)
+	defer os.RemoveAll(durablePath)
+
+	p := NewProvider("peer-address", durablePath, []Config{{Path: "testdata/goodbuilder", Name: "good"}})
+	p.TerminationTimeout = time.Second
+	vm := p.NewVM()
+	ccid := ccintf.CCID{Name: "mycc", Version: "v1"}
+
+	err = vm.Start(ccid, nil, nil, nil, nil)
+	assert.EqualError(t, err, "chaincode [mycc-v1] has not been built by an external builder")
+	_, err = vm.Wait(ccid)
+	assert.EqualError(t, err, "chaincode [mycc-v1] is not running")
+
+	codePackage := codePackageBytes(t, map[string]string{"main.go": "package main"})
+	built, err := p.Build(ccid, "GOLANG", "github.com/mycc", codePackage)
+	require.NoError(t, err)
+	require.True(t, built)
+
+	filesToUpload := map[string][]byte{
+		"/etc/hyperledger/fabric/client.crt": []byte("client-cert"),
+		"/etc/hyperledger/fabric/client.key": []byte("client-key"),
+		"/etc/hyperledger/fabric/peer.crt":   []byte("root-cert"),
+	}
+	err = vm.Start(ccid, nil, []string{"CORE_CHAINCODE_ID_NAME=mycc:v1"}, filesToUpload, nil)
+	require.NoError(t, err)
+	instance, ok := p.instances["mycc-v1"].(*Instance)
+	require.True(t, ok)
+
+	runConfigPath := filepath.Join(durablePath, "mycc-v1", "bld", "chaincode.json.out")
+	gt := NewGomegaWithT(t)
+	gt.Eventually(runConfigPath, 5*time.Second).Should(BeAnExistingFile())
+	runConfigBytes, err := ioutil.ReadFile(runConfigPath)
+	require.NoError(t, err)
+	runConfig := &RunConfig{}
+	require.NoError(t, json.Unmarshal(runConfigBytes, runConfig))
+	assert.Equal(t, &RunConfig{
+		CCID:        "mycc:v1",
+		PeerAddress: "peer-address",
+		ClientCert:  "client-cert",
+		ClientKey:   "client-key",
+		RootCert:    "root-cert",
+	}, runConfig)
+
+	require.NoError(t, vm.Stop(ccid, 0, false, false))
+	_, err = os.Stat(instance.RunMetadataDir)
+	assert.True(t, os.IsNotExist(err))
+	_, err = vm.Wait(ccid)
+	assert.EqualError(t, err, "chaincode [mycc-v1] is not running")
+}
+
+func TestExternalVMWait(t *testing.T) {
+	durablePath, err := ioutil.TempDir("", "externalbuilder")
+	require.NoError(t, err)
+	defer os.RemoveAll(durablePath)
+
+	p := NewProvider("peer-address", durablePath, []Config{{Path: "testdata/goodbuilder", Name: "good"}})
+	vm := p.NewVM()
+	ccid := ccintf.CCID{Name: "mycc", Version: "v1"}
+
+	codePackage := codePackageBytes(t, map[string]string{"main.go": "package main", "exitcode": "3"})
+	built, err := p.Build(ccid, "GOLANG", "github.com/mycc", codePackage)
+	require.NoError(t, err)
+	require.True(t, built)
+
+	require.NoError(t, vm.Start(ccid, nil, nil, nil, nil))
+	exitCode, err := vm.Wait(ccid)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, exitCode)
+	assert.NoError(t, vm.Stop(ccid, 0, false, false))
+}
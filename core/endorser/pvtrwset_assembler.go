@@ -57,7 +57,7 @@ func (as *rwSetAssembler) AssemblePvtRWSet(privData *rwset.TxPvtReadWriteSet, tx
 			if err != nil {
 				return nil, errors.WithMessage(err, fmt.Sprintf("error while retrieving collection config for chaincode %#v", namespace))
 			}
-			if cb == nil {
+			if cb == nil && !writesOnlyToImplicitCollections(pvtRwset) {
 				return nil, errors.New(fmt.Sprintf("no collection config for chaincode %#v", namespace))
 			}
 
@@ -69,11 +69,35 @@ func (as *rwSetAssembler) AssemblePvtRWSet(privData *rwset.TxPvtReadWriteSet, tx
 
 			txPvtRwSetWithConfig.CollectionConfigs[namespace] = colCP
 		}
+
+		// the implicit collections of the orgs are not part of
+		// the collection config package of the chaincode
+		colCP := txPvtRwSetWithConfig.CollectionConfigs[namespace]
+		for _, col := range pvtRwset.CollectionPvtRwset {
+			if mspID, ok := privdata.MSPIDIfImplicitCollection(col.CollectionName); ok {
+				colCP.Config = append(colCP.Config, &common.CollectionConfig{
+					Payload: &common.CollectionConfig_StaticCollectionConfig{
+						StaticCollectionConfig: privdata.GenerateImplicitCollectionForOrg(mspID),
+					},
+				})
+			}
+		}
 	}
 	as.trimCollectionConfigs(txPvtRwSetWithConfig)
 	return txPvtRwSetWithConfig, nil
 }
 
+// writesOnlyToImplicitCollections returns true if all the collections of
+// the given private read-write set are implicit collections
+func writesOnlyToImplicitCollections(pvtRwset *rwset.NsPvtReadWriteSet) bool {
+	for _, col := range pvtRwset.CollectionPvtRwset {
+		if _, ok := privdata.MSPIDIfImplicitCollection(col.CollectionName); !ok {
+			return false
+		}
+	}
+	return true
+}
+
 func (as *rwSetAssembler) trimCollectionConfigs(pvtData *transientstore.TxPvtReadWriteSetWithConfigInfo) {
 	flags := make(map[string]map[string]struct{})
 	for _, pvtRWset := range pvtData.PvtRwset.NsPvtRwset {
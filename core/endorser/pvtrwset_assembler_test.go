@@ -88,3 +88,39 @@ func TestAssemblePvtRWSet(t *testing.T) {
 	assert.Equal(t, 1, len(pvtReadWriteSetWithConfigInfo.PvtRwset.NsPvtRwset))
 
 }
+
+func TestAssemblePvtRWSetImplicitCollection(t *testing.T) {
+	configRetriever := &mockCollectionConfigRetriever{}
+	configRetriever.On("GetState", "lscc", privdata.BuildCollectionKVSKey("+lifecycle")).Return([]byte(nil), nil)
+
+	assembler := rwSetAssembler{}
+
+	privData := &rwset.TxPvtReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+			{
+				Namespace: "+lifecycle",
+				CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+					{
+						CollectionName: "_implicit_org_Org1MSP",
+						Rwset:          []byte{1, 2, 3, 4, 5, 6, 7, 8},
+					},
+				},
+			},
+		},
+	}
+
+	pvtReadWriteSetWithConfigInfo, err := assembler.AssemblePvtRWSet(privData, configRetriever)
+	assert.NoError(t, err)
+	configs, found := pvtReadWriteSetWithConfigInfo.CollectionConfigs["+lifecycle"]
+	assert.True(t, found)
+	assert.Equal(t, 1, len(configs.Config))
+	assert.True(t, proto.Equal(privdata.GenerateImplicitCollectionForOrg("Org1MSP"), configs.Config[0].GetStaticCollectionConfig()))
+
+	// a collection which is not implicit requires a collection config package
+	privData.NsPvtRwset[0].CollectionPvtRwset = append(privData.NsPvtRwset[0].CollectionPvtRwset, &rwset.CollectionPvtReadWriteSet{
+		CollectionName: "mycollection-1",
+	})
+	_, err = assembler.AssemblePvtRWSet(privData, configRetriever)
+	assert.EqualError(t, err, "no collection config for chaincode \"+lifecycle\"")
+}
@@ -171,9 +171,14 @@ func (s *SupportImpl) IsJavaCC(buf []byte) (bool, error) {
 }
 
 // CheckInstantiationPolicy returns an error if the instantiation in the supplied
-// ChaincodeDefinition differs from the instantiation policy stored on the ledger
+// ChaincodeDefinition differs from the instantiation policy stored on the ledger.
+// The chaincode defined through the new lifecycle has no instantiation policy
 func (s *SupportImpl) CheckInstantiationPolicy(name, version string, cd ccprovider.ChaincodeDefinition) error {
-	return ccprovider.CheckInstantiationPolicy(name, version, cd.(*ccprovider.ChaincodeData))
+	cData, ok := cd.(*ccprovider.ChaincodeData)
+	if !ok {
+		return nil
+	}
+	return ccprovider.CheckInstantiationPolicy(name, version, cData)
 }
 
 // GetApplicationConfig returns the configtxapplication.SharedConfig for the Channel
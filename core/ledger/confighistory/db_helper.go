@@ -95,6 +95,34 @@ func (d *db) entryAt(blockNum uint64, ns, key string) (*compositeKV, error) {
 	return &compositeKV{k, v}, nil
 }
 
+// forEachEntry invokes the function 'f' for each of the entries (i.e., all the keys and all the blocks) in the given namespace
+func (d *db) forEachEntry(ns string, f func(*compositeKV) error) error {
+	logger.Debugf("forEachEntry() - {%s}", ns)
+	startKey := append([]byte(keyPrefix+ns), separatorByte)
+	endKey := append([]byte(keyPrefix+ns), separatorByte+1)
+	itr := d.GetIterator(startKey, endKey)
+	defer itr.Release()
+	for itr.Next() {
+		k, v := decodeCompositeKey(itr.Key()), itr.Value()
+		if err := f(&compositeKV{k, v}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forEachRawEntry invokes the function 'f' for each of the entries in the db, with the encoded key and the value
+func (d *db) forEachRawEntry(f func(k, v []byte) error) error {
+	itr := d.GetIterator(nil, nil)
+	defer itr.Release()
+	for itr.Next() {
+		if err := f(itr.Key(), itr.Value()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func encodeCompositeKey(ns, key string, blockNum uint64) []byte {
 	b := []byte(keyPrefix + ns)
 	b = append(b, separatorByte)
@@ -8,9 +8,14 @@ package confighistory
 
 import (
 	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/protos/common"
@@ -21,13 +26,21 @@ import (
 var logger = flogging.MustGetLogger("confighistory")
 
 const (
+	// SnapshotFileName is the name of the snapshot file that contains the config history
+	SnapshotFileName   = "confighistory.data"
+	snapshotFileFormat = byte(1)
+
 	collectionConfigNamespace = "lscc" // lscc namespace was introduced in version 1.2 and we continue to use this in order to be compatible with existing data
+	collectionConfigKeySuffix = "~collection"
 )
 
 // Mgr should be registered as a state listener. The state listener builds the history and retriever helps in querying the history
 type Mgr interface {
 	ledger.StateListener
 	GetRetriever(ledgerID string, ledgerInfoRetriever LedgerInfoRetriever) ledger.ConfigHistoryRetriever
+	GetCollectionNames(ledgerID string) (map[string][]string, error)
+	ExportConfigHistory(ledgerID, dir string, newHashFunc snapshot.NewHashFunc) (map[string][]byte, error)
+	ImportConfigHistory(ledgerID, dir string) error
 	Close()
 }
 
@@ -96,6 +109,90 @@ func (m *mgr) GetRetriever(ledgerID string, ledgerInfoRetriever LedgerInfoRetrie
 	return &retriever{dbHandle: m.dbProvider.getDB(ledgerID), ledgerInfoRetriever: ledgerInfoRetriever}
 }
 
+// GetCollectionNames returns the names of all the collections, grouped by the chaincode name, that have
+// ever been defined for the chaincodes on the given ledger
+func (m *mgr) GetCollectionNames(ledgerID string) (map[string][]string, error) {
+	collNames := map[string]map[string]bool{}
+	err := m.dbProvider.getDB(ledgerID).forEachEntry(collectionConfigNamespace, func(compositeKV *compositeKV) error {
+		collConfigInfo, err := compositeKVToCollectionConfig(compositeKV)
+		if err != nil {
+			return err
+		}
+		ccName := strings.TrimSuffix(compositeKV.key, collectionConfigKeySuffix)
+		if _, ok := collNames[ccName]; !ok {
+			collNames[ccName] = map[string]bool{}
+		}
+		for _, config := range collConfigInfo.CollectionConfig.Config {
+			if sConfig := config.GetStaticCollectionConfig(); sConfig != nil {
+				collNames[ccName][sConfig.Name] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	nsCollMap := map[string][]string{}
+	for ccName, colls := range collNames {
+		for coll := range colls {
+			nsCollMap[ccName] = append(nsCollMap[ccName], coll)
+		}
+		sort.Strings(nsCollMap[ccName])
+	}
+	return nsCollMap, nil
+}
+
+// ExportConfigHistory exports all the entries of the config history of the given ledger in a file in the
+// specified dir. The function returns a map that contains the name of the generated file and its hash
+func (m *mgr) ExportConfigHistory(ledgerID, dir string, newHashFunc snapshot.NewHashFunc) (map[string][]byte, error) {
+	w, err := snapshot.CreateFile(filepath.Join(dir, SnapshotFileName), snapshotFileFormat, newHashFunc)
+	if err != nil {
+		return nil, err
+	}
+	defer w.Close()
+	err = m.dbProvider.getDB(ledgerID).forEachRawEntry(func(k, v []byte) error {
+		if err := w.EncodeBytes(k); err != nil {
+			return err
+		}
+		return w.EncodeBytes(v)
+	})
+	if err != nil {
+		return nil, err
+	}
+	fileHash, err := w.Done()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{SnapshotFileName: fileHash}, nil
+}
+
+// ImportConfigHistory loads the config history of the given ledger from the snapshot file present in the
+// specified dir, as generated by the function ExportConfigHistory
+func (m *mgr) ImportConfigHistory(ledgerID, dir string) error {
+	r, err := snapshot.OpenFile(filepath.Join(dir, SnapshotFileName), snapshotFileFormat)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	dbHandle := m.dbProvider.getDB(ledgerID)
+	batch := newBatch()
+	for {
+		k, err := r.DecodeBytes()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		v, err := r.DecodeBytes()
+		if err != nil {
+			return err
+		}
+		batch.Put(k, v)
+	}
+	return dbHandle.writeBatch(batch, true)
+}
+
 // Close implements the function in the interface 'Mgr'
 func (m *mgr) Close() {
 	m.dbProvider.Close()
@@ -157,7 +254,7 @@ func compositeKVToCollectionConfig(compositeKV *compositeKV) (*ledger.Collection
 }
 
 func constructCollectionConfigKey(chaincodeName string) string {
-	return chaincodeName + "~collection" // collection config key as in version 1.2 and we continue to use this in order to be compatible with existing data
+	return chaincodeName + collectionConfigKeySuffix // collection config key as in version 1.2 and we continue to use this in order to be compatible with existing data
 }
 
 func dbPath() string {
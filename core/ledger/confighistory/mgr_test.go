@@ -8,11 +8,14 @@ package confighistory
 
 import (
 	"fmt"
+	"io/ioutil"
 	"math"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/mock"
 	"github.com/hyperledger/fabric/protos/common"
@@ -113,6 +116,42 @@ func TestMgr(t *testing.T) {
 		assert.True(t, ok)
 		assert.Equal(t, maxBlockNumberInLedger, typedErr.MaxBlockNumCommitted)
 	})
+
+	t.Run("test-api-GetCollectionNames()", func(t *testing.T) {
+		nsCollMap, err := mgr.GetCollectionNames("ledgerid1")
+		assert.NoError(t, err)
+		assert.Equal(t,
+			map[string][]string{chaincodeName: {"ledgerid1-10", "ledgerid1-100", "ledgerid1-15", "ledgerid1-5"}},
+			nsCollMap)
+
+		nsCollMap, err = mgr.GetCollectionNames("non-existing-ledger")
+		assert.NoError(t, err)
+		assert.Len(t, nsCollMap, 0)
+	})
+
+	t.Run("test-api-ExportConfigHistory()-ImportConfigHistory()", func(t *testing.T) {
+		snapshotDir, err := ioutil.TempDir("", "confighistory-snapshot-")
+		assert.NoError(t, err)
+		defer os.RemoveAll(snapshotDir)
+		fileHashes, err := mgr.ExportConfigHistory("ledgerid1", snapshotDir, snapshot.DefaultNewHashFunc)
+		assert.NoError(t, err)
+		expectedHash, err := snapshot.FileHash(filepath.Join(snapshotDir, SnapshotFileName), snapshot.DefaultNewHashFunc)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]byte{SnapshotFileName: expectedHash}, fileHashes)
+
+		assert.NoError(t, mgr.ImportConfigHistory("imported-ledger", snapshotDir))
+		retriever := mgr.GetRetriever("imported-ledger", dummyLedgerInfoRetriever)
+		for _, commitHeight := range configCommittingBlockNums {
+			retrievedConfig, err := retriever.CollectionConfigAt(commitHeight, chaincodeName)
+			assert.NoError(t, err)
+			assert.Equal(t, sampleCollectionConfigPackage("ledgerid1", commitHeight), retrievedConfig.CollectionConfig)
+		}
+		nsCollMap, err := mgr.GetCollectionNames("imported-ledger")
+		assert.NoError(t, err)
+		assert.Equal(t,
+			map[string][]string{chaincodeName: {"ledgerid1-10", "ledgerid1-100", "ledgerid1-15", "ledgerid1-5"}},
+			nsCollMap)
+	})
 }
 
 type testEnv struct {
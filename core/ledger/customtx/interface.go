@@ -33,3 +33,11 @@ func (e *InvalidTxError) Error() string {
 type Processor interface {
 	GenerateSimulationResults(txEnvelop *common.Envelope, simulator ledger.TxSimulator, initializingLedger bool) error
 }
+
+// TimedProcessor is a Processor whose simulation results also depend on the number of the block
+// the transaction is committed in, for instance to enforce deadlines.
+// The ledger passes the block number to the Processors that implement it.
+type TimedProcessor interface {
+	Processor
+	GenerateSimulationResultsAt(txEnvelop *common.Envelope, blockNum uint64, simulator ledger.TxSimulator, initializingLedger bool) error
+}
@@ -21,6 +21,11 @@ const (
 	PvtdataExpiry Category = iota
 	// MetadataPresenceIndicator maintains the bookkeeping about whether metadata is ever set for a namespace
 	MetadataPresenceIndicator
+	// SnapshotRequest maintains the block numbers for which the generation of a snapshot is requested
+	SnapshotRequest
+	// CollectionEligibility maintains the member orgs policies of the collections loaded from a snapshot until the
+	// missing pvtdata of the collections is recorded
+	CollectionEligibility
 )
 
 // Provider provides handle to different bookkeepers for the given ledger
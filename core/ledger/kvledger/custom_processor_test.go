@@ -7,9 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package kvledger
 
 import (
+	"crypto/sha256"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/customtx"
@@ -17,7 +21,12 @@ import (
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/protos/utils"
+	mockid "github.com/hyperledger/fabric/token/identity/mock"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/hyperledger/fabric/token/transaction"
+	txmock "github.com/hyperledger/fabric/token/transaction/mock"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -38,6 +47,21 @@ func (ctp *customTxProcessor) GenerateSimulationResults(txEnvelop *common.Envelo
 	return simulator.SetState(chainid, kvw.Key, kvw.Value)
 }
 
+// timedCustomTxProcessor writes the value of the key along with the number of the block of the transaction
+type timedCustomTxProcessor struct {
+	customTxProcessor
+}
+
+func (tctp *timedCustomTxProcessor) GenerateSimulationResultsAt(txEnvelop *common.Envelope, blockNum uint64, simulator ledger.TxSimulator, initializingLedger bool) error {
+	payload := utils.UnmarshalPayloadOrPanic(txEnvelop.Payload)
+	chHdr, _ := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	kvw := &kvrwset.KVWrite{}
+	if err := proto.Unmarshal(payload.Data, kvw); err != nil {
+		return err
+	}
+	return simulator.SetState(chHdr.ChannelId, kvw.Key, []byte(fmt.Sprintf("%s@%d", kvw.Value, blockNum)))
+}
+
 func TestCustomProcessor(t *testing.T) {
 	env := newTestEnv(t)
 	defer env.cleanup()
@@ -94,6 +118,119 @@ func TestCustomProcessor(t *testing.T) {
 	assert.Equal(t, "value4", string(val))
 }
 
+func TestCustomTimedProcessor(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	// register a processor that takes into account the block number for the '102' type of transaction
+	chainid := "testLedger"
+	customtx.InitializeTestEnv(customtx.Processors{
+		102: &timedCustomTxProcessor{}})
+
+	_, gb := testutil.NewBlockGenerator(t, chainid, false)
+	lgr, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer lgr.Close()
+
+	tx1 := createCustomTx(t, 102, chainid, "custom_key1", "value1")
+	tx2 := createCustomTx(t, 102, chainid, "custom_key2", "value2")
+	blk1 := testutil.NewBlock([]*common.Envelope{tx1, tx2}, 1, gb.Header.Hash())
+	assert.NoError(t, lgr.CommitWithPvtData(&ledger.BlockAndPvtData{Block: blk1}, &ledger.CommitOptions{}))
+	tx3 := createCustomTx(t, 102, chainid, "custom_key3", "value3")
+	blk2 := testutil.NewBlock([]*common.Envelope{tx3}, 2, blk1.Header.Hash())
+	assert.NoError(t, lgr.CommitWithPvtData(&ledger.BlockAndPvtData{Block: blk2}, &ledger.CommitOptions{}))
+
+	qe, err := lgr.NewQueryExecutor()
+	assert.NoError(t, err)
+	defer qe.Done()
+	for key, expected := range map[string]string{"custom_key1": "value1@1", "custom_key2": "value2@1", "custom_key3": "value3@2"} {
+		val, err := qe.GetState(chainid, key)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(val))
+	}
+}
+
+func TestTokenLockDeadlines(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	chainid := "testLedger"
+	tmsManager := &txmock.TMSManager{}
+	tmsManager.GetTxProcessorReturns(&plain.Verifier{IssuingValidator: &mockid.IssuingValidator{}}, nil)
+	customtx.InitializeTestEnv(customtx.Processors{
+		common.HeaderType_TOKEN_TRANSACTION: &transaction.Processor{TMSManager: tmsManager}})
+
+	_, gb := testutil.NewBlockGenerator(t, chainid, false)
+	lgr, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer lgr.Close()
+
+	hash := sha256.Sum256([]byte("secret"))
+	lockedOutput := &token.PlainLockedOutput{
+		Sender:    []byte("alice"),
+		Recipient: []byte("bob"),
+		Type:      "TOK",
+		Quantity:  10,
+		Hash:      hash[:],
+		Deadline:  4,
+	}
+	lockedInput := &token.InputId{TxId: "lock", Index: 0}
+	// the claim and the reclaim are each the only transaction of their block,
+	// and their creators set the timestamps that suit them best
+	past, future := time.Unix(0, 0), time.Now().Add(24*time.Hour)
+	blocks := []struct {
+		tx       *common.Envelope
+		expected peer.TxValidationCode
+	}{
+		{createTokenTx(t, chainid, "issue", "alice", time.Now(), &token.PlainTokenAction{Data: &token.PlainTokenAction_PlainImport{PlainImport: &token.PlainImport{
+			Outputs: []*token.PlainOutput{{Owner: []byte("alice"), Type: "TOK", Quantity: 10}},
+		}}}), peer.TxValidationCode_VALID},
+		{createTokenTx(t, chainid, "lock", "alice", time.Now(), &token.PlainTokenAction{Data: &token.PlainTokenAction_PlainLock{PlainLock: &token.PlainLock{
+			Inputs:       []*token.InputId{{TxId: "issue", Index: 0}},
+			LockedOutput: lockedOutput,
+		}}}), peer.TxValidationCode_VALID},
+		{createTokenTx(t, chainid, "early-reclaim", "alice", future, &token.PlainTokenAction{Data: &token.PlainTokenAction_PlainReclaim{PlainReclaim: &token.PlainReclaim{
+			Input: lockedInput,
+		}}}), peer.TxValidationCode_INVALID_OTHER_REASON},
+		{createTokenTx(t, chainid, "late-claim", "bob", past, &token.PlainTokenAction{Data: &token.PlainTokenAction_PlainClaim{PlainClaim: &token.PlainClaim{
+			Input: lockedInput, Preimage: []byte("secret"),
+		}}}), peer.TxValidationCode_INVALID_OTHER_REASON},
+		{createTokenTx(t, chainid, "reclaim", "alice", past, &token.PlainTokenAction{Data: &token.PlainTokenAction_PlainReclaim{PlainReclaim: &token.PlainReclaim{
+			Input: lockedInput,
+		}}}), peer.TxValidationCode_VALID},
+	}
+	previousHash := gb.Header.Hash()
+	for i, b := range blocks {
+		blk := testutil.NewBlock([]*common.Envelope{b.tx}, uint64(i+1), previousHash)
+		assert.NoError(t, lgr.CommitWithPvtData(&ledger.BlockAndPvtData{Block: blk}, &ledger.CommitOptions{}))
+		previousHash = blk.Header.Hash()
+
+		blockPersisted, err := lgr.GetBlockByNumber(uint64(i + 1))
+		assert.NoError(t, err)
+		txFilter := lgrutil.TxValidationFlags(blockPersisted.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+		assert.Equal(t, b.expected, txFilter.Flag(0), "block %d", i+1)
+	}
+}
+
+func createTokenTx(t *testing.T, chainid, txID, creator string, timestamp time.Time, action *token.PlainTokenAction) *common.Envelope {
+	ts, err := ptypes.TimestampProto(timestamp)
+	assert.NoError(t, err)
+	chHdr := utils.MakeChannelHeader(common.HeaderType_TOKEN_TRANSACTION, 0, chainid, 0)
+	chHdr.TxId = txID
+	chHdr.Timestamp = ts
+	ttx := &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: action}}
+	return &common.Envelope{
+		Payload: utils.MarshalOrPanic(&common.Payload{
+			Header: utils.MakePayloadHeader(chHdr, &common.SignatureHeader{Creator: []byte(creator)}),
+			Data:   utils.MarshalOrPanic(ttx),
+		}),
+	}
+}
+
 func createCustomTx(t *testing.T, txType common.HeaderType, chainid, key, val string) *common.Envelope {
 	kvWrite := &kvrwset.KVWrite{Key: key, Value: []byte(val)}
 	txEnv, err := utils.CreateSignedEnvelope(txType, chainid, nil, kvWrite, 0, 0)
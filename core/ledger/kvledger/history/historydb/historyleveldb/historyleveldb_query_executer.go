@@ -7,9 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package historyleveldb
 
 import (
+	"bytes"
+	"encoding/hex"
+	"math"
+
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
@@ -28,33 +33,157 @@ type LevelHistoryDBQueryExecutor struct {
 
 // GetHistoryForKey implements method in interface `ledger.HistoryQueryExecutor`
 func (q *LevelHistoryDBQueryExecutor) GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error) {
+	return q.GetHistoryForKeyWithMetadata(namespace, key, nil)
+}
+
+// GetHistoryForKeyWithMetadata implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetHistoryForKeyWithMetadata(namespace string, key string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
 
 	if ledgerconfig.IsHistoryDBEnabled() == false {
 		return nil, errors.New("history database not enabled")
 	}
 
-	var compositeStartKey []byte
-	var compositeEndKey []byte
-	compositeStartKey = historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
-	compositeEndKey = historydb.ConstructPartialCompositeHistoryKey(namespace, key, true)
+	opts, err := parseHistoryQueryMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	compositePartialKey := historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
+	compositeStartKey, compositeEndKey, err := opts.rangeKeys(compositePartialKey)
+	if err != nil {
+		return nil, err
+	}
 
 	// range scan to find any history records starting with namespace~key
+	// and falling within the requested block range
 	dbItr := q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey)
-	return newHistoryScanner(compositeStartKey, namespace, key, dbItr, q.blockStore), nil
+	scanner := newHistoryScanner(compositePartialKey, namespace, key, dbItr, q.blockStore)
+	scanner.reverse = opts.reverse
+	scanner.requestedLimit = opts.limit
+	return scanner, nil
+}
+
+const (
+	optionStartBlock = "startBlock"
+	optionEndBlock   = "endBlock"
+	optionReverse    = "reverse"
+	optionLimit      = "limit"
+	optionBookmark   = "bookmark"
+)
+
+// historyQueryOptions holds the parsed metadata of a history query
+type historyQueryOptions struct {
+	startBlock    uint64
+	endBlock      uint64
+	endBlockIsSet bool
+	reverse       bool
+	limit         int32
+	bookmark      []byte
+}
+
+// parseHistoryQueryMetadata validates and parses the metadata passed to GetHistoryForKeyWithMetadata
+func parseHistoryQueryMetadata(metadata map[string]interface{}) (*historyQueryOptions, error) {
+	opts := &historyQueryOptions{}
+	for option, val := range metadata {
+		var ok bool
+		switch option {
+		case optionStartBlock:
+			opts.startBlock, ok = val.(uint64)
+		case optionEndBlock:
+			opts.endBlock, ok = val.(uint64)
+			opts.endBlockIsSet = true
+		case optionReverse:
+			opts.reverse, ok = val.(bool)
+		case optionLimit:
+			opts.limit, ok = val.(int32)
+		case optionBookmark:
+			var bookmark string
+			if bookmark, ok = val.(string); ok && bookmark != "" {
+				var err error
+				if opts.bookmark, err = hex.DecodeString(bookmark); err != nil {
+					return nil, errors.Errorf("invalid bookmark [%s]", bookmark)
+				}
+			}
+		default:
+			return nil, errors.Errorf("invalid entry, option %s not recognized", option)
+		}
+		if !ok {
+			return nil, errors.Errorf("invalid entry, option %s has unexpected type %T", option, val)
+		}
+	}
+	if opts.endBlockIsSet && opts.startBlock > opts.endBlock {
+		return nil, errors.Errorf("start block [%d] is greater than end block [%d]", opts.startBlock, opts.endBlock)
+	}
+	return opts, nil
 }
 
-//historyScanner implements ResultsIterator for iterating through history results
+// rangeKeys returns the range of history keys covered by the query. The start key is inclusive and the
+// end key is exclusive. A bookmark narrows the range to the records that were not returned yet, that is
+// the records starting from the bookmark in the direction of the scan
+func (opts *historyQueryOptions) rangeKeys(compositePartialKey []byte) ([]byte, []byte, error) {
+	startKey := compositePartialKey
+	if opts.startBlock > 0 {
+		startKey = appendKey(compositePartialKey, util.EncodeOrderPreservingVarUint64(opts.startBlock))
+	}
+	endKey := appendKey(compositePartialKey, []byte{0xff})
+	if opts.endBlockIsSet && opts.endBlock < math.MaxUint64 {
+		endKey = appendKey(compositePartialKey, util.EncodeOrderPreservingVarUint64(opts.endBlock+1))
+	}
+	if opts.bookmark == nil {
+		return startKey, endKey, nil
+	}
+	bookmarkKey := appendKey(compositePartialKey, opts.bookmark)
+	if opts.reverse {
+		// the record at the bookmark is included in the results
+		bookmarkKey = append(bookmarkKey, 0x00)
+		if bytes.Compare(bookmarkKey, endKey) < 0 {
+			endKey = bookmarkKey
+		}
+	} else if bytes.Compare(bookmarkKey, startKey) > 0 {
+		startKey = bookmarkKey
+	}
+	return startKey, endKey, nil
+}
+
+func appendKey(prefix []byte, suffix []byte) []byte {
+	key := make([]byte, 0, len(prefix)+len(suffix)+1)
+	return append(append(key, prefix...), suffix...)
+}
+
+// historyScanner implements ResultsIterator for iterating through history results
 type historyScanner struct {
-	compositePartialKey []byte //compositePartialKey includes namespace~key
-	namespace           string
-	key                 string
-	dbItr               iterator.Iterator
-	blockStore          blkstorage.BlockStore
+	compositePartialKey  []byte //compositePartialKey includes namespace~key
+	namespace            string
+	key                  string
+	dbItr                iterator.Iterator
+	blockStore           blkstorage.BlockStore
+	reverse              bool
+	started              bool
+	requestedLimit       int32
+	totalRecordsReturned int32
 }
 
 func newHistoryScanner(compositePartialKey []byte, namespace string, key string,
 	dbItr iterator.Iterator, blockStore blkstorage.BlockStore) *historyScanner {
-	return &historyScanner{compositePartialKey, namespace, key, dbItr, blockStore}
+	return &historyScanner{
+		compositePartialKey: compositePartialKey,
+		namespace:           namespace,
+		key:                 key,
+		dbItr:               dbItr,
+		blockStore:          blockStore,
+	}
+}
+
+// moveNext moves the underlying db iterator one record forward in the direction of the scan
+func (scanner *historyScanner) moveNext() bool {
+	if !scanner.reverse {
+		return scanner.dbItr.Next()
+	}
+	if !scanner.started {
+		scanner.started = true
+		return scanner.dbItr.Last()
+	}
+	return scanner.dbItr.Prev()
 }
 
 // Next iterates to the next key from history scanner, decodes blockNumTranNumBytes to get blockNum and tranNum,
@@ -68,8 +197,11 @@ func newHistoryScanner(compositePartialKey []byte, namespace string, key string,
 // was actually added for some other <ns, key, blockNum, tranNum>. It would cause this iterator to
 // return a history query result out of the order.
 func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
+	if scanner.requestedLimit > 0 && scanner.totalRecordsReturned >= scanner.requestedLimit {
+		return nil, nil
+	}
 	for {
-		if !scanner.dbItr.Next() {
+		if !scanner.moveNext() {
 			return nil, nil
 		}
 		historyKey := scanner.dbItr.Key() // history key is in the form namespace~key~blocknum~trannum
@@ -111,6 +243,11 @@ func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
 				historyKey, scanner.key)
 			continue
 		}
+		if _, ok := err.(*blkstorage.ErrBlockPruned); ok {
+			logger.Debugf("History record for key [%#v] at blockNumTranNum %v:%v belongs to a pruned block. Skipping",
+				scanner.key, blockNum, tranNum)
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -131,6 +268,7 @@ func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
 		}
 		logger.Debugf("Found historic key value for namespace:%s key:%s from transaction %s",
 			scanner.namespace, scanner.key, queryResult.(*queryresult.KeyModification).TxId)
+		scanner.totalRecordsReturned++
 		return queryResult, nil
 	}
 }
@@ -139,6 +277,18 @@ func (scanner *historyScanner) Close() {
 	scanner.dbItr.Release()
 }
 
+// GetBookmarkAndClose returns the position of the next record that would have been scanned, encoded as
+// a hex string, and releases the iterator. An empty bookmark is returned when the scan is exhausted
+func (scanner *historyScanner) GetBookmarkAndClose() string {
+	retval := ""
+	if scanner.moveNext() {
+		_, blockNumTranNumBytes := historydb.SplitCompositeHistoryKey(scanner.dbItr.Key(), scanner.compositePartialKey)
+		retval = hex.EncodeToString(blockNumTranNumBytes)
+	}
+	scanner.Close()
+	return retval
+}
+
 // getTxIDandKeyWriteValueFromTran inspects a transaction for writes to a given key
 func getKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string, key string) (commonledger.QueryResult, error) {
 	logger.Debugf("Entering getKeyModificationFromTran()\n", namespace, key)
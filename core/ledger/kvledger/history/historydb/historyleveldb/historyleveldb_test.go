@@ -9,6 +9,7 @@ package historyleveldb
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"os"
 	"strconv"
 	"testing"
@@ -520,6 +521,111 @@ func TestHistoryWithBlockNumber256(t *testing.T) {
 	assert.Equal(t, "value256", valueInBlock256)
 }
 
+func TestHistoryWithMetadata(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	assert.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb))
+
+	// blocks 1 to 5 each set "ns1", "key" to "value<blockNum>", block 3 has a second transaction setting "value3b"
+	for i := 1; i <= 5; i++ {
+		values := []string{fmt.Sprintf("value%d", i)}
+		if i == 3 {
+			values = append(values, "value3b")
+		}
+		simulationResults := [][]byte{}
+		for _, value := range values {
+			simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+			simulator.SetState("ns1", "key", []byte(value))
+			simulator.Done()
+			simRes, _ := simulator.GetTxSimulationResults()
+			pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+			simulationResults = append(simulationResults, pubSimResBytes)
+		}
+		block := bg.NextBlock(simulationResults)
+		assert.NoError(t, store1.AddBlock(block))
+		assert.NoError(t, env.testHistoryDB.Commit(block))
+	}
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err, "Error upon NewHistoryQueryExecutor")
+
+	t.Run("bounds and order", func(t *testing.T) {
+		testCases := []struct {
+			metadata     map[string]interface{}
+			expectedVals []string
+		}{
+			{nil, []string{"value1", "value2", "value3", "value3b", "value4", "value5"}},
+			{map[string]interface{}{"startBlock": uint64(2), "endBlock": uint64(4)}, []string{"value2", "value3", "value3b", "value4"}},
+			{map[string]interface{}{"startBlock": uint64(3), "endBlock": uint64(3)}, []string{"value3", "value3b"}},
+			{map[string]interface{}{"startBlock": uint64(4)}, []string{"value4", "value5"}},
+			{map[string]interface{}{"endBlock": uint64(1)}, []string{"value1"}},
+			{map[string]interface{}{"startBlock": uint64(6)}, []string{}},
+			{map[string]interface{}{"endBlock": uint64(math.MaxUint64)}, []string{"value1", "value2", "value3", "value3b", "value4", "value5"}},
+			{map[string]interface{}{"reverse": true}, []string{"value5", "value4", "value3b", "value3", "value2", "value1"}},
+			{map[string]interface{}{"reverse": true, "startBlock": uint64(2), "endBlock": uint64(3)}, []string{"value3b", "value3", "value2"}},
+			{map[string]interface{}{"reverse": true, "limit": int32(2)}, []string{"value5", "value4"}},
+		}
+		for _, tc := range testCases {
+			vals, _ := testutilQueryHistoryWithMetadata(t, qhistory, "ns1", "key", tc.metadata)
+			assert.Equal(t, tc.expectedVals, vals, "metadata %v", tc.metadata)
+		}
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		for _, reverse := range []bool{false, true} {
+			retrievedVals := []string{}
+			bookmark := ""
+			pages := 0
+			for {
+				vals, nextBookmark := testutilQueryHistoryWithMetadata(t, qhistory, "ns1", "key", map[string]interface{}{
+					"startBlock": uint64(1),
+					"endBlock":   uint64(4),
+					"reverse":    reverse,
+					"limit":      int32(2),
+					"bookmark":   bookmark,
+				})
+				retrievedVals = append(retrievedVals, vals...)
+				pages++
+				if nextBookmark == "" {
+					break
+				}
+				bookmark = nextBookmark
+			}
+			assert.Equal(t, 3, pages)
+			if reverse {
+				assert.Equal(t, []string{"value4", "value3b", "value3", "value2", "value1"}, retrievedVals)
+			} else {
+				assert.Equal(t, []string{"value1", "value2", "value3", "value3b", "value4"}, retrievedVals)
+			}
+		}
+	})
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		testCases := []struct {
+			metadata    map[string]interface{}
+			expectedErr string
+		}{
+			{map[string]interface{}{"pageSize": int32(2)}, "invalid entry, option pageSize not recognized"},
+			{map[string]interface{}{"startBlock": 2}, "invalid entry, option startBlock has unexpected type int"},
+			{map[string]interface{}{"reverse": "true"}, "invalid entry, option reverse has unexpected type string"},
+			{map[string]interface{}{"startBlock": uint64(3), "endBlock": uint64(2)}, "start block [3] is greater than end block [2]"},
+			{map[string]interface{}{"bookmark": "not-hex"}, "invalid bookmark [not-hex]"},
+		}
+		for _, tc := range testCases {
+			_, err := qhistory.GetHistoryForKeyWithMetadata("ns1", "key", tc.metadata)
+			assert.EqualError(t, err, tc.expectedErr)
+		}
+	})
+}
+
 func TestName(t *testing.T) {
 	env := newTestHistoryEnv(t)
 	defer env.cleanup()
@@ -543,6 +649,23 @@ func testutilVerifyResults(t *testing.T, hqe ledger.HistoryQueryExecutor, ns, ke
 	assert.Equal(t, expectedVals, retrievedVals)
 }
 
+// testutilQueryHistoryWithMetadata returns the values retrieved by a history query with the given metadata
+// and the bookmark returned at the end of the query
+func testutilQueryHistoryWithMetadata(t *testing.T, hqe ledger.HistoryQueryExecutor, ns, key string, metadata map[string]interface{}) ([]string, string) {
+	itr, err := hqe.GetHistoryForKeyWithMetadata(ns, key, metadata)
+	assert.NoError(t, err, "Error upon GetHistoryForKeyWithMetadata()")
+	retrievedVals := []string{}
+	for {
+		kmod, err := itr.Next()
+		assert.NoError(t, err)
+		if kmod == nil {
+			break
+		}
+		retrievedVals = append(retrievedVals, string(kmod.(*queryresult.KeyModification).Value))
+	}
+	return retrievedVals, itr.GetBookmarkAndClose()
+}
+
 // testutilCheckKeyInRange check if falseKey falls in range query when searching for desiredKey
 func testutilCheckKeyInRange(t *testing.T, hqe ledger.HistoryQueryExecutor, ns, desiredKey, falseKey string, expectedMatchCount int) {
 	itr, err := hqe.GetHistoryForKey(ns, desiredKey)
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/hyperledger/fabric/common/flogging"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/util"
@@ -38,14 +39,18 @@ var logger = flogging.MustGetLogger("kvledger")
 // KVLedger provides an implementation of `ledger.PeerLedger`.
 // This implementation provides a key-value based data model
 type kvLedger struct {
-	ledgerID               string
-	blockStore             *ledgerstorage.Store
-	txtmgmt                txmgr.TxMgr
-	historyDB              historydb.HistoryDB
-	configHistoryRetriever ledger.ConfigHistoryRetriever
-	blockAPIsRWLock        *sync.RWMutex
-	stats                  *ledgerStats
-	commitHash             []byte
+	ledgerID                  string
+	blockStore                *ledgerstorage.Store
+	txtmgmt                   txmgr.TxMgr
+	historyDB                 historydb.HistoryDB
+	configHistoryMgr          confighistory.Mgr
+	configHistoryRetriever    ledger.ConfigHistoryRetriever
+	blockAPIsRWLock           *sync.RWMutex
+	commitLock                sync.Mutex
+	stats                     *ledgerStats
+	commitHash                []byte
+	snapshotSigner            ledger.SnapshotSigner
+	snapshotRequestBookkeeper *snapshotRequestBookkeeper
 }
 
 // NewKVLedger constructs new `KVLedger`
@@ -58,6 +63,7 @@ func newKVLedger(
 	stateListeners []ledger.StateListener,
 	bookkeeperProvider bookkeeping.Provider,
 	ccInfoProvider ledger.DeployedChaincodeInfoProvider,
+	snapshotSigner ledger.SnapshotSigner,
 	stats *ledgerStats,
 ) (*kvLedger, error) {
 	logger.Debugf("Creating KVLedger ledgerID=%s: ", ledgerID)
@@ -89,7 +95,13 @@ func newKVLedger(
 	if err := l.recoverDBs(); err != nil {
 		return nil, err
 	}
+	l.configHistoryMgr = configHistoryMgr
 	l.configHistoryRetriever = configHistoryMgr.GetRetriever(ledgerID, l)
+	l.snapshotSigner = snapshotSigner
+	l.snapshotRequestBookkeeper = newSnapshotRequestBookkeeper(ledgerID, bookkeeperProvider)
+	if err := l.processSnapshotRequestsOnStartup(); err != nil {
+		return nil, err
+	}
 
 	l.stats = stats
 	return l, nil
@@ -135,8 +147,8 @@ func (l *kvLedger) lastPersistedCommitHash() ([]byte, error) {
 	return commitHash.Value, nil
 }
 
-//Recover the state database and history database (if exist)
-//by recommitting last valid blocks
+// Recover the state database and history database (if exist)
+// by recommitting last valid blocks
 func (l *kvLedger) recoverDBs() error {
 	logger.Debugf("Entering recoverDB()")
 	if err := l.syncStateAndHistoryDBWithBlockstore(); err != nil {
@@ -171,6 +183,11 @@ func (l *kvLedger) syncStateAndHistoryDBWithBlockstore() error {
 
 		// firstBlockNum is nothing but the nextBlockNum expected by the state DB.
 		// In otherwords, the firstBlockNum is nothing but the height of stateDB.
+		if recoverFlag && firstBlockNum < info.LowWaterMark {
+			dbName := recoverable.Name()
+			return fmt.Errorf("the %s database [height=%d] cannot be rebuilt as the blocks lower than [%d] have been pruned from the block store",
+				dbName, firstBlockNum, info.LowWaterMark)
+		}
 		if firstBlockNum > lastAvailableBlockNum+1 {
 			dbName := recoverable.Name()
 			return fmt.Errorf("the %s database [height=%d] is ahead of the block store [height=%d]. "+
@@ -249,8 +266,8 @@ func (l *kvLedger) filterYetToCommitBlocks(blocksPvtData map[uint64][]*ledger.Tx
 	return nil
 }
 
-//recommitLostBlocks retrieves blocks in specified range and commit the write set to either
-//state DB or history DB or both
+// recommitLostBlocks retrieves blocks in specified range and commit the write set to either
+// state DB or history DB or both
 func (l *kvLedger) recommitLostBlocks(firstBlockNum uint64, lastBlockNum uint64, recoverables ...recoverable) error {
 	logger.Infof("Recommitting lost blocks - firstBlockNum=%d, lastBlockNum=%d, recoverables=%#v", firstBlockNum, lastBlockNum, recoverables)
 	var err error
@@ -336,9 +353,140 @@ func (l *kvLedger) GetTxValidationCodeByTxID(txID string) (peer.TxValidationCode
 	return txValidationCode, err
 }
 
-//Prune prunes the blocks/transactions that satisfy the given policy
+// Prune removes the blocks that fall outside the retention window described by the given policy.
+// As the block store removes blocks in whole blockfiles, a few blocks older than the retention
+// window may be retained. The state, history, and config history databases are not affected.
+// The latest config block remains retrievable, as it is required to bootstrap the channel on peer restart.
 func (l *kvLedger) Prune(policy commonledger.PrunePolicy) error {
-	return errors.New("not yet implemented")
+	bcInfo, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	if bcInfo.Height == 0 {
+		logger.Debugf("[%s] Block storage is empty, nothing to prune", l.ledgerID)
+		return nil
+	}
+	retainFromBlockNum, err := l.retainFromBlockNum(policy, bcInfo)
+	if err != nil {
+		return err
+	}
+	return l.pruneBlockStore(retainFromBlockNum, bcInfo)
+}
+
+// pruneAsPerRetention prunes the blocks that fall outside the retention configured for the block store,
+// once every prune interval. If both the number of blocks and the duration to retain are configured, a
+// block is retained if either of the two requires so. A failure in pruning does not fail the block commit,
+// as the block is already committed
+func (l *kvLedger) pruneAsPerRetention(blockNum uint64) {
+	if blockNum%ledgerconfig.GetPruneInterval() != 0 {
+		return
+	}
+	var policies []commonledger.PrunePolicy
+	if numBlocks := ledgerconfig.GetRetainLastNBlocks(); numBlocks > 0 {
+		policies = append(policies, &ledger.RetainLastNBlocksPolicy{NumBlocks: numBlocks})
+	}
+	if duration := ledgerconfig.GetRetainDuration(); duration > 0 {
+		policies = append(policies, &ledger.RetainBlocksAfterPolicy{Timestamp: time.Now().Add(-duration)})
+	}
+	if len(policies) == 0 {
+		return
+	}
+	bcInfo, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		logger.Errorf("[%s] Failed to prune the block store: %+v", l.ledgerID, err)
+		return
+	}
+	retainFromBlockNum := blockNum
+	for _, policy := range policies {
+		policyRetainFromBlockNum, err := l.retainFromBlockNum(policy, bcInfo)
+		if err != nil {
+			logger.Errorf("[%s] Failed to prune the block store: %+v", l.ledgerID, err)
+			return
+		}
+		if policyRetainFromBlockNum < retainFromBlockNum {
+			retainFromBlockNum = policyRetainFromBlockNum
+		}
+	}
+	if err := l.pruneBlockStore(retainFromBlockNum, bcInfo); err != nil {
+		logger.Errorf("[%s] Failed to prune the block store: %+v", l.ledgerID, err)
+	}
+}
+
+// pruneBlockStore prunes the blocks lower than retainFromBlockNum while preserving the latest config block
+func (l *kvLedger) pruneBlockStore(retainFromBlockNum uint64, bcInfo *common.BlockchainInfo) error {
+	if retainFromBlockNum <= bcInfo.LowWaterMark {
+		logger.Debugf("[%s] Blocks lower than [%d] are already pruned, nothing to prune", l.ledgerID, bcInfo.LowWaterMark)
+		return nil
+	}
+	lastBlock, err := l.blockStore.RetrieveBlockByNumber(bcInfo.Height - 1)
+	if err != nil {
+		return err
+	}
+	lastConfigBlockNum, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return errors.WithMessage(err, "error retrieving the last config block number")
+	}
+	logger.Infof("[%s] Pruning blocks lower than block [%d], preserving the last config block [%d]",
+		l.ledgerID, retainFromBlockNum, lastConfigBlockNum)
+	return l.blockStore.Prune(retainFromBlockNum, lastConfigBlockNum)
+}
+
+// retainFromBlockNum translates the given prune policy to the lowest block number that should be retained
+func (l *kvLedger) retainFromBlockNum(policy commonledger.PrunePolicy, bcInfo *common.BlockchainInfo) (uint64, error) {
+	switch p := policy.(type) {
+	case *ledger.RetainLastNBlocksPolicy:
+		if p.NumBlocks == 0 {
+			return 0, errors.New("number of blocks to retain should be greater than zero")
+		}
+		if p.NumBlocks >= bcInfo.Height {
+			return 0, nil
+		}
+		return bcInfo.Height - p.NumBlocks, nil
+	case *ledger.RetainBlocksAfterPolicy:
+		return l.firstBlockNumAtOrAfter(p.Timestamp, bcInfo)
+	default:
+		return 0, errors.Errorf("unsupported prune policy type [%T]", policy)
+	}
+}
+
+// firstBlockNumAtOrAfter performs a binary search over the available blocks and returns the
+// number of the first block whose timestamp is not before the given time. The last block is
+// returned if all the blocks are older than the given time
+func (l *kvLedger) firstBlockNumAtOrAfter(t time.Time, bcInfo *common.BlockchainInfo) (uint64, error) {
+	low, high := bcInfo.LowWaterMark, bcInfo.Height-1
+	for low < high {
+		mid := low + (high-low)/2
+		block, err := l.blockStore.RetrieveBlockByNumber(mid)
+		if err != nil {
+			return 0, err
+		}
+		blockTime, err := blockTimestamp(block)
+		if err != nil {
+			return 0, err
+		}
+		if blockTime.Before(t) {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	return low, nil
+}
+
+// blockTimestamp returns the timestamp present in the channel header of the first transaction in the block
+func blockTimestamp(block *common.Block) (time.Time, error) {
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return time.Time{}, err
+	}
+	chdr, err := utils.ChannelHeader(env)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if chdr.Timestamp == nil {
+		return time.Time{}, nil
+	}
+	return ptypes.Timestamp(chdr.Timestamp)
 }
 
 // NewTxSimulator returns new `ledger.TxSimulator`
@@ -363,6 +511,10 @@ func (l *kvLedger) NewHistoryQueryExecutor() (ledger.HistoryQueryExecutor, error
 
 // CommitWithPvtData commits the block and the corresponding pvt data in an atomic operation
 func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData, commitOpts *ledger.CommitOptions) error {
+	// the commit lock is held until the snapshot requested for this block, if any, is generated
+	// so that the next block is not committed meanwhile
+	l.commitLock.Lock()
+	defer l.commitLock.Unlock()
 	var err error
 	block := pvtdataAndBlock.Block
 	blockNo := pvtdataAndBlock.Block.Header.Number
@@ -406,8 +558,8 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData, co
 
 	logger.Debugf("[%s] Committing block [%d] to storage", l.ledgerID, blockNo)
 	l.blockAPIsRWLock.Lock()
-	defer l.blockAPIsRWLock.Unlock()
 	if err = l.blockStore.CommitWithPvtData(pvtdataAndBlock); err != nil {
+		l.blockAPIsRWLock.Unlock()
 		return err
 	}
 	elapsedBlockstorageAndPvtdataCommit := time.Since(startBlockstorageAndPvtdataCommit)
@@ -443,7 +595,10 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData, co
 		elapsedCommitState,
 		txstatsInfo,
 	)
-	return nil
+	l.blockAPIsRWLock.Unlock()
+
+	l.pruneAsPerRetention(blockNo)
+	return l.processSnapshotRequest(blockNo)
 }
 
 func convertTxPvtDataArrayToMap(txPvtData []*ledger.TxPvtData) ledger.TxPvtDataMap {
@@ -523,12 +678,22 @@ func (l *kvLedger) DoesPvtDataInfoExist(blockNum uint64) (bool, error) {
 // a given maxBlockNumToRetain. In other words, Purge only retains private read-write sets
 // that were generated at block height of maxBlockNumToRetain or higher.
 func (l *kvLedger) PurgePrivateData(maxBlockNumToRetain uint64) error {
-	return errors.New("not yet implemented")
+	nsCollMap, err := l.configHistoryMgr.GetCollectionNames(l.ledgerID)
+	if err != nil {
+		return err
+	}
+	logger.Infof("[%s] Purging private data committed before block [%d] for collections %v", l.ledgerID, maxBlockNumToRetain, nsCollMap)
+	// the state is purged before the pvtdata store so that the min retained block number
+	// is recorded only after all the private data below it has been removed
+	if err := l.txtmgmt.PurgePvtData(maxBlockNumToRetain, nsCollMap); err != nil {
+		return err
+	}
+	return l.blockStore.PurgePrivateData(maxBlockNumToRetain)
 }
 
 // PrivateDataMinBlockNum returns the lowest retained endorsement block height
 func (l *kvLedger) PrivateDataMinBlockNum() (uint64, error) {
-	return 0, errors.New("not yet implemented")
+	return l.blockStore.PrivateDataMinBlockNum()
 }
 
 func (l *kvLedger) GetConfigHistoryRetriever() (ledger.ConfigHistoryRetriever, error) {
@@ -536,6 +701,11 @@ func (l *kvLedger) GetConfigHistoryRetriever() (ledger.ConfigHistoryRetriever, e
 }
 
 func (l *kvLedger) CommitPvtDataOfOldBlocks(pvtData []*ledger.BlockPvtData) ([]*ledger.PvtdataHashMismatch, error) {
+	pvtData, err := l.filterPurgedPvtData(pvtData)
+	if err != nil {
+		return nil, err
+	}
+
 	logger.Debugf("[%s:] Comparing pvtData of [%d] old blocks against the hashes in transaction's rwset to find valid and invalid data",
 		l.ledgerID, len(pvtData))
 
@@ -558,6 +728,25 @@ func (l *kvLedger) CommitPvtDataOfOldBlocks(pvtData []*ledger.BlockPvtData) ([]*
 	return hashMismatches, nil
 }
 
+// filterPurgedPvtData drops the pvtData of the blocks below the min retained block number
+// so that the purged pvtData is not brought back via reconciliation
+func (l *kvLedger) filterPurgedPvtData(pvtData []*ledger.BlockPvtData) ([]*ledger.BlockPvtData, error) {
+	minBlockNum, err := l.blockStore.PrivateDataMinBlockNum()
+	if err != nil || minBlockNum == 0 {
+		return pvtData, err
+	}
+	var retained []*ledger.BlockPvtData
+	for _, blockPvtData := range pvtData {
+		if blockPvtData.BlockNum < minBlockNum {
+			logger.Debugf("[%s:] Skipping pvtData of block [%d] as the pvtData below block [%d] has been purged",
+				l.ledgerID, blockPvtData.BlockNum, minBlockNum)
+			continue
+		}
+		retained = append(retained, blockPvtData)
+	}
+	return retained, nil
+}
+
 func (l *kvLedger) applyValidTxPvtDataOfOldBlocks(hashVerifiedPvtData map[uint64][]*ledger.TxPvtData) error {
 	logger.Debugf("[%s:] Filtering pvtData of invalidation transactions", l.ledgerID)
 	committedPvtData, err := filterPvtDataOfInvalidTx(hashVerifiedPvtData, l.blockStore)
@@ -18,6 +18,8 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb/historyleveldb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
 	"github.com/hyperledger/fabric/protos/common"
@@ -137,6 +139,145 @@ func (provider *Provider) Create(genesisBlock *common.Block) (ledger.PeerLedger,
 	return lgr, nil
 }
 
+// CreateFromSnapshot implements the corresponding method from interface ledger.PeerLedgerProvider
+// This function verifies the integrity of the snapshot and sets the under construction flag before loading the snapshot.
+// The config history, the state, and the history database are loaded first and the block store is bootstrapped with the
+// last block of the snapshot after that. Upon a successful bootstrap of the block store, the expiry schedule of the pvtdata
+// hashes is rebuilt, the pvtdata of the hashes is recorded as missing, and the flag is removed while adding the entry into
+// created ledgers list (atomically). If a crash happens after the bootstrap of the block store, the 'recoverUnderConstructionLedger'
+// function completes the remaining steps. The collection eligibility is loaded from the snapshot as the member orgs policy of each
+// collection, and the missing pvtdata of a collection is marked eligible if the peer that loads the snapshot is a member of the collection
+func (provider *Provider) CreateFromSnapshot(snapshotDir string) (ledger.PeerLedger, string, error) {
+	metadata, lastBlock, err := loadAndVerifySnapshot(snapshotDir)
+	if err != nil {
+		return nil, "", errors.WithMessage(err, fmt.Sprintf("error while loading the snapshot from dir [%s]", snapshotDir))
+	}
+	ledgerID := metadata.ChannelName
+	exists, err := provider.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return nil, "", err
+	}
+	if exists {
+		return nil, "", ErrLedgerIDExists
+	}
+	if err = provider.idStore.setUnderConstructionFlag(ledgerID); err != nil {
+		return nil, "", err
+	}
+	logger.Infof("Creating ledger [%s] from the snapshot of block [%d] in dir [%s]", ledgerID, lastBlock.Header.Number, snapshotDir)
+	if err := provider.importSnapshot(ledgerID, snapshotDir, lastBlock); err != nil {
+		logger.Errorf("Error importing the snapshot for ledger [%s]. Unsetting under construction flag. Error: %+v", ledgerID, err)
+		panicOnErr(provider.runCleanup(ledgerID), "Error running cleanup for ledger id [%s]", ledgerID)
+		panicOnErr(provider.idStore.unsetUnderConstructionFlag(), "Error while unsetting under construction flag")
+		return nil, "", err
+	}
+	lgr, err := provider.openInternal(ledgerID)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := provider.completeCreationFromSnapshot(lgr, lastBlock); err != nil {
+		lgr.Close()
+		return nil, "", err
+	}
+	return lgr, ledgerID, nil
+}
+
+func (provider *Provider) importSnapshot(ledgerID, snapshotDir string, lastBlock *common.Block) error {
+	if err := provider.configHistoryMgr.ImportConfigHistory(ledgerID, snapshotDir); err != nil {
+		return err
+	}
+	if err := newCollElgBookkeeper(ledgerID, provider.bookkeepingProvider).importFromSnapshot(snapshotDir); err != nil {
+		return err
+	}
+	vDB, err := provider.vdbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return err
+	}
+	savepoint := version.NewHeight(lastBlock.Header.Number, uint64(len(lastBlock.Data.Data)-1))
+	if err := vDB.ImportPubStateAndPvtStateHashes(snapshotDir, savepoint); err != nil {
+		return err
+	}
+	// the history of the keys is not part of a snapshot. The history database records the writes of the
+	// last block so that its savepoint matches the block store and the history grows from the last block
+	historyDB, err := provider.historydbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return err
+	}
+	if err := historyDB.Commit(lastBlock); err != nil {
+		return err
+	}
+	return provider.ledgerStoreProvider.BootstrapFromSnapshot(ledgerID, snapshotDir, lastBlock)
+}
+
+// completeCreationFromSnapshot rebuilds the expiry schedule of the pvtdata hashes loaded from the snapshot, records the
+// pvtdata of the hashes as missing, and marks the ledger as created. Repeating these steps is harmless, as the entries
+// are overwritten
+func (provider *Provider) completeCreationFromSnapshot(lgr ledger.PeerLedger, lastBlock *common.Block) error {
+	l := lgr.(*kvLedger)
+	nsCollMap, err := provider.configHistoryMgr.GetCollectionNames(l.ledgerID)
+	if err != nil {
+		return err
+	}
+	if err := l.txtmgmt.RebuildPvtdataExpirySchedule(nsCollMap); err != nil {
+		return err
+	}
+	if err := provider.recordMissingPvtDataFromSnapshot(l); err != nil {
+		return err
+	}
+	panicOnErr(provider.idStore.createLedgerID(l.ledgerID, lastBlock), "Error while marking ledger as created")
+	return nil
+}
+
+// recordMissingPvtDataFromSnapshot records the pvtdata of the hashes loaded from the snapshot as missing in the pvtdata
+// store. The missing pvtdata of a collection is marked eligible if the peer is a member of the collection as per the member
+// orgs policy loaded from the snapshot, so that the reconciler fetches the pvtdata from the other peers
+func (provider *Provider) recordMissingPvtDataFromSnapshot(l *kvLedger) error {
+	vDB, err := provider.vdbProvider.GetDBHandle(l.ledgerID)
+	if err != nil {
+		return err
+	}
+	bookkeeper := newCollElgBookkeeper(l.ledgerID, provider.bookkeepingProvider)
+	missingPvtData := map[uint64]ledger.TxMissingPvtDataMap{}
+	err = bookkeeper.forEach(func(ns, coll string, policy *common.CollectionPolicyConfig) error {
+		isEligible, err := provider.collElgNotifier.membershipInfoProvider.AmMemberOf(l.ledgerID, policy)
+		if err != nil {
+			return err
+		}
+		itr, err := vDB.GetHashedDataIterator(ns, coll)
+		if err != nil {
+			return err
+		}
+		defer itr.Close()
+		recordedTxs := map[version.Height]bool{}
+		for {
+			res, err := itr.Next()
+			if err != nil {
+				return err
+			}
+			if res == nil {
+				return nil
+			}
+			ver := res.(*statedb.VersionedKV).Version
+			if recordedTxs[*ver] {
+				continue
+			}
+			recordedTxs[*ver] = true
+			if _, ok := missingPvtData[ver.BlockNum]; !ok {
+				missingPvtData[ver.BlockNum] = ledger.TxMissingPvtDataMap{}
+			}
+			missingPvtData[ver.BlockNum].Add(ver.TxNum, ns, coll, isEligible)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if len(missingPvtData) > 0 {
+		if err := l.blockStore.AddMissingPvtDataOfOldBlocks(missingPvtData); err != nil {
+			return err
+		}
+	}
+	return bookkeeper.clear()
+}
+
 // Open implements the corresponding method from interface ledger.PeerLedgerProvider
 func (provider *Provider) Open(ledgerID string) (ledger.PeerLedger, error) {
 	logger.Debugf("Open() opening kvledger: %s", ledgerID)
@@ -177,6 +318,7 @@ func (provider *Provider) openInternal(ledgerID string) (ledger.PeerLedger, erro
 		ledgerID, blockStore, vDB, historyDB, provider.configHistoryMgr,
 		provider.stateListeners, provider.bookkeepingProvider,
 		provider.initializer.DeployedChaincodeInfoProvider,
+		provider.initializer.SnapshotSigner,
 		provider.stats.ledgerStats(ledgerID),
 	)
 	if err != nil {
@@ -209,7 +351,8 @@ func (provider *Provider) Close() {
 // recoverUnderConstructionLedger checks whether the under construction flag is set - this would be the case
 // if a crash had happened during creation of ledger and the ledger creation could have been left in intermediate
 // state. Recovery checks if the ledger was created and the genesis block was committed successfully then it completes
-// the last step of adding the ledger id to the list of created ledgers. Else, it clears the under construction flag
+// the last step of adding the ledger id to the list of created ledgers. Else, it clears the under construction flag.
+// For a ledger being created from a snapshot, the creation is completed if the block store was bootstrapped
 func (provider *Provider) recoverUnderConstructionLedger() {
 	logger.Debugf("Recovering under construction ledger")
 	ledgerID, err := provider.idStore.getUnderConstructionFlag()
@@ -223,6 +366,16 @@ func (provider *Provider) recoverUnderConstructionLedger() {
 	panicOnErr(err, "Error while opening under construction ledger [%s]", ledgerID)
 	bcInfo, err := ledger.GetBlockchainInfo()
 	panicOnErr(err, "Error while getting blockchain info for the under construction ledger [%s]", ledgerID)
+
+	if bcInfo.Height > 1 && bcInfo.LowWaterMark == bcInfo.Height-1 {
+		logger.Infof("Block store was bootstrapped from a snapshot. Hence, completing the creation of the peer ledger from the snapshot")
+		lastBlock, err := ledger.GetBlockByNumber(bcInfo.Height - 1)
+		panicOnErr(err, "Error while retrieving the last block from blockchain for ledger [%s]", ledgerID)
+		panicOnErr(provider.completeCreationFromSnapshot(ledger, lastBlock),
+			"Error while completing the creation of the ledger [%s] from the snapshot", ledgerID)
+		ledger.Close()
+		return
+	}
 	ledger.Close()
 
 	switch bcInfo.Height {
@@ -9,12 +9,15 @@ package kvledger
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
 	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
@@ -31,6 +34,7 @@ func TestMain(m *testing.M) {
 	flogging.ActivateSpec("lockbasedtxmgr,statevalidator,valimpl,confighistory,pvtstatepurgemgmt=debug")
 	viper.Set("peer.fileSystemPath", "/tmp/fabric/ledgertests/kvledger")
 	viper.Set("ledger.history.enableHistoryDatabase", true)
+	cceventmgmt.Initialize(nil)
 	os.Exit(m.Run())
 }
 
@@ -171,6 +175,145 @@ func TestAddCommitHash(t *testing.T) {
 
 }
 
+func TestKVLedgerPrune(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, _ := provider.Create(gb)
+	defer ledger.Close()
+	for _, block := range bg.NextTestBlocks(9) {
+		assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}, &lgr.CommitOptions{}))
+	}
+	kvLedger := ledger.(*kvLedger)
+	bcInfo, err := ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+
+	retainFrom, err := kvLedger.retainFromBlockNum(&lgr.RetainLastNBlocksPolicy{NumBlocks: 3}, bcInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), retainFrom)
+
+	retainFrom, err = kvLedger.retainFromBlockNum(&lgr.RetainLastNBlocksPolicy{NumBlocks: 20}, bcInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), retainFrom)
+
+	_, err = kvLedger.retainFromBlockNum(&lgr.RetainLastNBlocksPolicy{}, bcInfo)
+	assert.EqualError(t, err, "number of blocks to retain should be greater than zero")
+
+	retainFrom, err = kvLedger.retainFromBlockNum(&lgr.RetainBlocksAfterPolicy{Timestamp: time.Now().Add(time.Hour)}, bcInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(9), retainFrom)
+
+	retainFrom, err = kvLedger.retainFromBlockNum(&lgr.RetainBlocksAfterPolicy{}, bcInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), retainFrom)
+
+	assert.EqualError(t, ledger.Prune("unknown-policy"), "unsupported prune policy type [string]")
+
+	// all the blocks are in the current blockfile and hence, nothing gets pruned
+	assert.NoError(t, ledger.Prune(&lgr.RetainLastNBlocksPolicy{NumBlocks: 1}))
+	bcInfo, err = ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), bcInfo.LowWaterMark)
+	_, err = ledger.GetBlockByNumber(0)
+	assert.NoError(t, err)
+}
+
+func TestKVLedgerPruneRetrievals(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	viper.Set("ledger.blockchain.maxBlockfileSize", 16*1024)
+	defer viper.Set("ledger.blockchain.maxBlockfileSize", 0)
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer ledger.Close()
+	blocks := bg.NextTestBlocks(39)
+	for _, block := range blocks {
+		assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}, &lgr.CommitOptions{}))
+	}
+
+	assert.NoError(t, ledger.Prune(&lgr.RetainLastNBlocksPolicy{NumBlocks: 10}))
+	bcInfo, err := ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	lowWaterMark := bcInfo.LowWaterMark
+	assert.True(t, lowWaterMark > 1 && lowWaterMark <= 30, "unexpected low-water mark [%d]", lowWaterMark)
+
+	// the genesis block is the last config block and remains retrievable by number
+	configBlock, err := ledger.GetBlockByNumber(0)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(gb, configBlock))
+
+	for _, block := range blocks[:lowWaterMark-1] {
+		_, err := ledger.GetBlockByNumber(block.Header.Number)
+		assert.IsType(t, &blkstorage.ErrBlockPruned{}, err)
+		_, err = ledger.GetBlockByHash(block.Header.Hash())
+		assert.IsType(t, &blkstorage.ErrBlockPruned{}, err)
+		txID := txIDFromBlock(t, block)
+		_, err = ledger.GetBlockByTxID(txID)
+		assert.IsType(t, &blkstorage.ErrBlockPruned{}, err)
+		_, err = ledger.GetTransactionByID(txID)
+		assert.IsType(t, &blkstorage.ErrBlockPruned{}, err)
+		_, err = ledger.GetTxValidationCodeByTxID(txID)
+		assert.NoError(t, err)
+	}
+	for _, block := range blocks[lowWaterMark-1:] {
+		b, err := ledger.GetBlockByNumber(block.Header.Number)
+		assert.NoError(t, err)
+		assert.Equal(t, block.Header.Number, b.Header.Number)
+		_, err = ledger.GetBlockByHash(block.Header.Hash())
+		assert.NoError(t, err)
+		_, err = ledger.GetTransactionByID(txIDFromBlock(t, block))
+		assert.NoError(t, err)
+	}
+}
+
+func TestKVLedgerPruneAsPerRetention(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	viper.Set("ledger.blockchain.maxBlockfileSize", 16*1024)
+	viper.Set("ledger.blockchain.retention.retainLastNBlocks", 10)
+	viper.Set("ledger.blockchain.retention.pruneInterval", 20)
+	defer func() {
+		viper.Set("ledger.blockchain.maxBlockfileSize", 0)
+		viper.Set("ledger.blockchain.retention.retainLastNBlocks", 0)
+		viper.Set("ledger.blockchain.retention.pruneInterval", 0)
+	}()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer ledger.Close()
+	for _, block := range bg.NextTestBlocks(39) {
+		assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}, &lgr.CommitOptions{}))
+		bcInfo, err := ledger.GetBlockchainInfo()
+		assert.NoError(t, err)
+		if block.Header.Number < 20 {
+			// the blocks are pruned only once every prune interval
+			assert.Equal(t, uint64(0), bcInfo.LowWaterMark)
+		} else {
+			assert.True(t, bcInfo.LowWaterMark > 0 && bcInfo.LowWaterMark <= 10)
+		}
+	}
+	_, err = ledger.GetBlockByNumber(0)
+	assert.NoError(t, err)
+}
+
+func txIDFromBlock(t *testing.T, block *common.Block) string {
+	env, err := putils.ExtractEnvelope(block, 0)
+	assert.NoError(t, err)
+	chdr, err := putils.ChannelHeader(env)
+	assert.NoError(t, err)
+	return chdr.TxId
+}
+
 func TestKVLedgerBlockStorageWithPvtdata(t *testing.T) {
 	t.Skip()
 	env := newTestEnv(t)
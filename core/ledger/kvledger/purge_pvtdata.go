@@ -0,0 +1,41 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/pkg/errors"
+)
+
+// PurgePrivateData opens the ledger for the given channel and purges the private data that was
+// committed before the given block number, irrespective of the BlockToLive of the collections.
+// When this function is executed, the peer must be offline
+func PurgePrivateData(ledgerID string, maxBlockNumToRetain uint64, initializer *ledger.Initializer) error {
+	provider, err := NewProvider()
+	if err != nil {
+		return err
+	}
+	defer provider.Close()
+	if err := provider.Initialize(initializer); err != nil {
+		return err
+	}
+
+	lgr, err := provider.Open(ledgerID)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error opening the ledger [%s]", ledgerID))
+	}
+	defer lgr.Close()
+
+	if err := lgr.PurgePrivateData(maxBlockNumToRetain); err != nil {
+		return err
+	}
+	logger.Infof("The private data of the channel [%s] committed before the block number [%d] has been successfully purged",
+		ledgerID, maxBlockNumToRetain)
+	return nil
+}
@@ -0,0 +1,595 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SnapshotSignableMetadataFileName is the name of the snapshot file that contains the metadata of the snapshot,
+	// including the hashes of all the other data files of the snapshot. The hash of this file is the snapshot hash
+	SnapshotSignableMetadataFileName = "_snapshot_signable_metadata.json"
+	// SnapshotAdditionalMetadataFileName is the name of the snapshot file that contains the snapshot hash and,
+	// if the peer is configured with a snapshot signer, the identity of the signer and the signature over the snapshot hash
+	SnapshotAdditionalMetadataFileName = "_snapshot_additional_metadata.json"
+	// LastBlockFileName is the name of the snapshot file that contains the last block of the snapshot
+	LastBlockFileName = "last_block.data"
+	// CollectionEligibilityFileName is the name of the snapshot file that contains the member orgs policy of each
+	// collection as of the last block of the snapshot. The eligibility of the peer that loads the snapshot for the
+	// collections is derived from these policies
+	CollectionEligibilityFileName = "collection_eligibility.data"
+
+	snapshotFileFormat        = byte(1)
+	snapshotsTempDirName      = "temp"
+	completedSnapshotsDirName = "completed"
+)
+
+// snapshotSignableMetadata is persisted in the file SnapshotSignableMetadataFileName
+type snapshotSignableMetadata struct {
+	ChannelName            string            `json:"channel_name"`
+	LastBlockNumber        uint64            `json:"last_block_number"`
+	LastBlockHashInHex     string            `json:"last_block_hash"`
+	PreviousBlockHashInHex string            `json:"previous_block_hash"`
+	StateDBType            string            `json:"state_db_type"`
+	FilesAndHashes         map[string]string `json:"snapshot_files_raw_hashes"`
+}
+
+// snapshotAdditionalMetadata is persisted in the file SnapshotAdditionalMetadataFileName
+type snapshotAdditionalMetadata struct {
+	SnapshotHashInHex string `json:"snapshot_hash"`
+	SignerIdentity    []byte `json:"signer_identity,omitempty"`
+	Signature         []byte `json:"signature,omitempty"`
+}
+
+// SnapshotDirForLedgerBlockNum returns the dir in which the snapshot of the given ledger for the given block number
+// is generated under the given snapshots root dir
+func SnapshotDirForLedgerBlockNum(snapshotsRootDir, ledgerID string, blockNum uint64) string {
+	return filepath.Join(snapshotsRootDir, completedSnapshotsDirName, ledgerID, strconv.FormatUint(blockNum, 10))
+}
+
+// generateSnapshot generates a snapshot of the ledger at the last committed block. The caller is expected to ensure
+// that no block is committed meanwhile. The snapshot is first generated in a temporary dir and the temporary dir
+// is renamed as the final snapshot dir only after all the files are generated
+func (l *kvLedger) generateSnapshot() error {
+	bcInfo, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	if bcInfo.Height == 0 {
+		return errors.Errorf("cannot generate a snapshot of the ledger [%s] as the ledger is empty", l.ledgerID)
+	}
+	lastBlockNum := bcInfo.Height - 1
+	snapshotsRootDir := ledgerconfig.GetSnapshotsRootDir()
+	snapshotDir := SnapshotDirForLedgerBlockNum(snapshotsRootDir, l.ledgerID, lastBlockNum)
+	exists, _, err := util.FileExists(snapshotDir)
+	if err != nil {
+		return errors.Wrapf(err, "error while checking whether the snapshot dir [%s] exists", snapshotDir)
+	}
+	if exists {
+		return errors.Errorf("snapshot dir [%s] already exists", snapshotDir)
+	}
+
+	logger.Infof("[%s] Generating snapshot for block [%d]", l.ledgerID, lastBlockNum)
+	tempDirParent := filepath.Join(snapshotsRootDir, snapshotsTempDirName)
+	if _, err := util.CreateDirIfMissing(tempDirParent); err != nil {
+		return errors.Wrapf(err, "error while creating the dir [%s]", tempDirParent)
+	}
+	tempDir, err := ioutil.TempDir(tempDirParent, l.ledgerID+"-"+strconv.FormatUint(lastBlockNum, 10)+"-")
+	if err != nil {
+		return errors.Wrapf(err, "error while creating a temporary dir under [%s]", tempDirParent)
+	}
+	defer os.RemoveAll(tempDir)
+
+	newHashFunc := snapshot.DefaultNewHashFunc
+	filesAndHashes := map[string][]byte{}
+	exporters := []func() (map[string][]byte, error){
+		func() (map[string][]byte, error) { return l.blockStore.ExportTxIds(tempDir, newHashFunc) },
+		func() (map[string][]byte, error) {
+			return l.txtmgmt.ExportPubStateAndPvtStateHashes(tempDir, newHashFunc)
+		},
+		func() (map[string][]byte, error) {
+			return l.configHistoryMgr.ExportConfigHistory(l.ledgerID, tempDir, newHashFunc)
+		},
+		func() (map[string][]byte, error) {
+			return l.exportCollectionEligibility(tempDir, lastBlockNum, newHashFunc)
+		},
+	}
+	for _, export := range exporters {
+		fileHashes, err := export()
+		if err != nil {
+			return err
+		}
+		for fileName, fileHash := range fileHashes {
+			filesAndHashes[fileName] = fileHash
+		}
+	}
+
+	lastBlock, err := l.blockStore.RetrieveBlockByNumber(lastBlockNum)
+	if err != nil {
+		return err
+	}
+	lastBlockFileHash, err := exportLastBlock(tempDir, lastBlock, newHashFunc)
+	if err != nil {
+		return err
+	}
+	filesAndHashes[LastBlockFileName] = lastBlockFileHash
+
+	if err := l.generateSnapshotMetadataFiles(tempDir, lastBlock, filesAndHashes); err != nil {
+		return err
+	}
+	if _, err := util.CreateDirIfMissing(filepath.Dir(snapshotDir)); err != nil {
+		return errors.Wrapf(err, "error while creating the dir [%s]", filepath.Dir(snapshotDir))
+	}
+	if err := os.Rename(tempDir, snapshotDir); err != nil {
+		return errors.Wrapf(err, "error while renaming the dir [%s] to [%s]", tempDir, snapshotDir)
+	}
+	logger.Infof("[%s] Generated snapshot for block [%d] in dir [%s]", l.ledgerID, lastBlockNum, snapshotDir)
+	return nil
+}
+
+func (l *kvLedger) generateSnapshotMetadataFiles(dir string, lastBlock *common.Block, filesAndHashes map[string][]byte) error {
+	stateDBType := "goleveldb"
+	if ledgerconfig.IsCouchDBEnabled() {
+		stateDBType = "CouchDB"
+	}
+	signableMetadata := &snapshotSignableMetadata{
+		ChannelName:            l.ledgerID,
+		LastBlockNumber:        lastBlock.Header.Number,
+		LastBlockHashInHex:     hex.EncodeToString(lastBlock.Header.Hash()),
+		PreviousBlockHashInHex: hex.EncodeToString(lastBlock.Header.PreviousHash),
+		StateDBType:            stateDBType,
+		FilesAndHashes:         map[string]string{},
+	}
+	for fileName, fileHash := range filesAndHashes {
+		signableMetadata.FilesAndHashes[fileName] = hex.EncodeToString(fileHash)
+	}
+	signableMetadataBytes, err := json.Marshal(signableMetadata)
+	if err != nil {
+		return errors.Wrap(err, "error while marshaling the snapshot metadata")
+	}
+	if err := writeSnapshotMetadataFile(filepath.Join(dir, SnapshotSignableMetadataFileName), signableMetadataBytes); err != nil {
+		return err
+	}
+
+	snapshotHash, err := computeSnapshotHash(signableMetadataBytes)
+	if err != nil {
+		return err
+	}
+	additionalMetadata := &snapshotAdditionalMetadata{SnapshotHashInHex: hex.EncodeToString(snapshotHash)}
+	if l.snapshotSigner != nil {
+		if additionalMetadata.SignerIdentity, err = l.snapshotSigner.Serialize(); err != nil {
+			return errors.WithMessage(err, "error while serializing the identity of the snapshot signer")
+		}
+		if additionalMetadata.Signature, err = l.snapshotSigner.Sign(snapshotHash); err != nil {
+			return errors.WithMessage(err, "error while signing the snapshot hash")
+		}
+	}
+	additionalMetadataBytes, err := json.Marshal(additionalMetadata)
+	if err != nil {
+		return errors.Wrap(err, "error while marshaling the snapshot additional metadata")
+	}
+	return writeSnapshotMetadataFile(filepath.Join(dir, SnapshotAdditionalMetadataFileName), additionalMetadataBytes)
+}
+
+func exportLastBlock(dir string, lastBlock *common.Block, newHashFunc snapshot.NewHashFunc) ([]byte, error) {
+	blockBytes, err := proto.Marshal(lastBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while marshaling the last block")
+	}
+	w, err := snapshot.CreateFile(filepath.Join(dir, LastBlockFileName), snapshotFileFormat, newHashFunc)
+	if err != nil {
+		return nil, err
+	}
+	defer w.Close()
+	if err := w.EncodeBytes(blockBytes); err != nil {
+		return nil, err
+	}
+	return w.Done()
+}
+
+// exportCollectionEligibility exports the member orgs policy of each collection, as per the collection config in
+// effect at the last block, sorted by the chaincode name and the collection name
+func (l *kvLedger) exportCollectionEligibility(dir string, lastBlockNum uint64, newHashFunc snapshot.NewHashFunc) (map[string][]byte, error) {
+	nsCollMap, err := l.configHistoryMgr.GetCollectionNames(l.ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	var namespaces []string
+	for ns := range nsCollMap {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	w, err := snapshot.CreateFile(filepath.Join(dir, CollectionEligibilityFileName), snapshotFileFormat, newHashFunc)
+	if err != nil {
+		return nil, err
+	}
+	defer w.Close()
+	for _, ns := range namespaces {
+		collConfigInfo, err := l.configHistoryRetriever.MostRecentCollectionConfigBelow(lastBlockNum+1, ns)
+		if err != nil {
+			return nil, err
+		}
+		if collConfigInfo == nil {
+			continue
+		}
+		collConfigs := retrieveCollConfs(collConfigInfo.CollectionConfig)
+		sort.Slice(collConfigs, func(i, j int) bool { return collConfigs[i].GetName() < collConfigs[j].GetName() })
+		for _, collConfig := range collConfigs {
+			if collConfig == nil {
+				continue
+			}
+			policyBytes, err := proto.Marshal(collConfig.MemberOrgsPolicy)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error while marshaling the member orgs policy of the collection [%s:%s]", ns, collConfig.Name)
+			}
+			if err := w.EncodeString(ns); err != nil {
+				return nil, err
+			}
+			if err := w.EncodeString(collConfig.Name); err != nil {
+				return nil, err
+			}
+			if err := w.EncodeBytes(policyBytes); err != nil {
+				return nil, err
+			}
+		}
+	}
+	fileHash, err := w.Done()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{CollectionEligibilityFileName: fileHash}, nil
+}
+
+func writeSnapshotMetadataFile(filePath string, content []byte) error {
+	f, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "error while creating the snapshot file [%s]", filePath)
+	}
+	defer f.Close()
+	if _, err := f.Write(content); err != nil {
+		return errors.Wrapf(err, "error while writing the snapshot file [%s]", filePath)
+	}
+	return errors.Wrapf(f.Sync(), "error while syncing the snapshot file [%s]", filePath)
+}
+
+func computeSnapshotHash(signableMetadataBytes []byte) ([]byte, error) {
+	h, err := snapshot.DefaultNewHashFunc()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := h.Write(signableMetadataBytes); err != nil {
+		return nil, errors.Wrap(err, "error while computing the snapshot hash")
+	}
+	return h.Sum(nil), nil
+}
+
+// loadAndVerifySnapshot loads the metadata and the last block of the snapshot present in the given dir and verifies
+// the integrity of the snapshot. Specifically, the snapshot hash is checked against the signable metadata, the hash
+// of each data file is checked against the hash recorded in the signable metadata, and the last block is checked
+// against the block hashes recorded in the signable metadata. The signature, if present, is not verified here, as
+// the trust in the signer is to be established by the peer admin before supplying the snapshot to the peer
+func loadAndVerifySnapshot(snapshotDir string) (*snapshotSignableMetadata, *common.Block, error) {
+	signableMetadataBytes, err := ioutil.ReadFile(filepath.Join(snapshotDir, SnapshotSignableMetadataFileName))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error while reading the snapshot metadata file")
+	}
+	additionalMetadataBytes, err := ioutil.ReadFile(filepath.Join(snapshotDir, SnapshotAdditionalMetadataFileName))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error while reading the snapshot additional metadata file")
+	}
+	signableMetadata := &snapshotSignableMetadata{}
+	if err := json.Unmarshal(signableMetadataBytes, signableMetadata); err != nil {
+		return nil, nil, errors.Wrap(err, "error while unmarshaling the snapshot metadata")
+	}
+	additionalMetadata := &snapshotAdditionalMetadata{}
+	if err := json.Unmarshal(additionalMetadataBytes, additionalMetadata); err != nil {
+		return nil, nil, errors.Wrap(err, "error while unmarshaling the snapshot additional metadata")
+	}
+
+	snapshotHash, err := computeSnapshotHash(signableMetadataBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	if hex.EncodeToString(snapshotHash) != additionalMetadata.SnapshotHashInHex {
+		return nil, nil, errors.Errorf("snapshot hash mismatch: computed [%x], recorded [%s]",
+			snapshotHash, additionalMetadata.SnapshotHashInHex)
+	}
+	if _, ok := signableMetadata.FilesAndHashes[LastBlockFileName]; !ok {
+		return nil, nil, errors.Errorf("snapshot metadata does not contain the file [%s]", LastBlockFileName)
+	}
+	for fileName, expectedHash := range signableMetadata.FilesAndHashes {
+		fileHash, err := snapshot.FileHash(filepath.Join(snapshotDir, fileName), snapshot.DefaultNewHashFunc)
+		if err != nil {
+			return nil, nil, err
+		}
+		if hex.EncodeToString(fileHash) != expectedHash {
+			return nil, nil, errors.Errorf("hash mismatch for the snapshot file [%s]: computed [%x], recorded [%s]",
+				fileName, fileHash, expectedHash)
+		}
+	}
+
+	lastBlock, err := loadLastBlock(snapshotDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if lastBlock.Header.Number != signableMetadata.LastBlockNumber ||
+		hex.EncodeToString(lastBlock.Header.Hash()) != signableMetadata.LastBlockHashInHex ||
+		hex.EncodeToString(lastBlock.Header.PreviousHash) != signableMetadata.PreviousBlockHashInHex ||
+		!bytes.Equal(lastBlock.Header.DataHash, lastBlock.Data.Hash()) {
+		return nil, nil, errors.New("the last block in the snapshot does not match the snapshot metadata")
+	}
+	channelID, err := utils.GetChainIDFromBlock(lastBlock)
+	if err != nil {
+		return nil, nil, err
+	}
+	if channelID != signableMetadata.ChannelName {
+		return nil, nil, errors.Errorf("the channel name in the snapshot metadata [%s] does not match the channel of the last block [%s]",
+			signableMetadata.ChannelName, channelID)
+	}
+	return signableMetadata, lastBlock, nil
+}
+
+func loadLastBlock(snapshotDir string) (*common.Block, error) {
+	r, err := snapshot.OpenFile(filepath.Join(snapshotDir, LastBlockFileName), snapshotFileFormat)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	blockBytes, err := r.DecodeBytes()
+	if err != nil {
+		return nil, err
+	}
+	block := &common.Block{}
+	if err := proto.Unmarshal(blockBytes, block); err != nil {
+		return nil, errors.Wrap(err, "error while unmarshaling the last block of the snapshot")
+	}
+	if block.Header == nil || block.Data == nil || block.Metadata == nil {
+		return nil, errors.New("the last block of the snapshot is malformed")
+	}
+	return block, nil
+}
+
+// snapshotRequestBookkeeper persists the block numbers for which a snapshot is requested
+type snapshotRequestBookkeeper struct {
+	db *leveldbhelper.DBHandle
+}
+
+func newSnapshotRequestBookkeeper(ledgerID string, bookkeepingProvider bookkeeping.Provider) *snapshotRequestBookkeeper {
+	return &snapshotRequestBookkeeper{db: bookkeepingProvider.GetDBHandle(ledgerID, bookkeeping.SnapshotRequest)}
+}
+
+func (b *snapshotRequestBookkeeper) add(blockNum uint64) error {
+	exists, err := b.exists(blockNum)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.Errorf("duplicate snapshot request for block number [%d]", blockNum)
+	}
+	return b.db.Put(util.EncodeOrderPreservingVarUint64(blockNum), []byte{}, true)
+}
+
+func (b *snapshotRequestBookkeeper) delete(blockNum uint64) error {
+	exists, err := b.exists(blockNum)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("no snapshot request exists for block number [%d]", blockNum)
+	}
+	return b.db.Delete(util.EncodeOrderPreservingVarUint64(blockNum), true)
+}
+
+func (b *snapshotRequestBookkeeper) exists(blockNum uint64) (bool, error) {
+	val, err := b.db.Get(util.EncodeOrderPreservingVarUint64(blockNum))
+	if err != nil {
+		return false, err
+	}
+	return val != nil, nil
+}
+
+// list returns the block numbers of the pending requests in ascending order
+func (b *snapshotRequestBookkeeper) list() ([]uint64, error) {
+	itr := b.db.GetIterator(nil, nil)
+	defer itr.Release()
+	var blockNums []uint64
+	for itr.Next() {
+		blockNum, _, err := util.DecodeOrderPreservingVarUint64(itr.Key())
+		if err != nil {
+			return nil, err
+		}
+		blockNums = append(blockNums, blockNum)
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "error while iterating over the snapshot requests")
+	}
+	return blockNums, nil
+}
+
+// collElgBookkeeper persists the member orgs policies of the collections loaded from a snapshot until the missing
+// pvtdata of the collections is recorded in the pvtdata store, so that a crash in between does not lose them
+type collElgBookkeeper struct {
+	db *leveldbhelper.DBHandle
+}
+
+func newCollElgBookkeeper(ledgerID string, bookkeepingProvider bookkeeping.Provider) *collElgBookkeeper {
+	return &collElgBookkeeper{db: bookkeepingProvider.GetDBHandle(ledgerID, bookkeeping.CollectionEligibility)}
+}
+
+// importFromSnapshot loads the member orgs policies from the snapshot file generated by the function
+// exportCollectionEligibility
+func (b *collElgBookkeeper) importFromSnapshot(snapshotDir string) error {
+	r, err := snapshot.OpenFile(filepath.Join(snapshotDir, CollectionEligibilityFileName), snapshotFileFormat)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	batch := leveldbhelper.NewUpdateBatch()
+	for {
+		ns, err := r.DecodeString()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		coll, err := r.DecodeString()
+		if err != nil {
+			return err
+		}
+		policyBytes, err := r.DecodeBytes()
+		if err != nil {
+			return err
+		}
+		batch.Put(encodeCollElgKey(ns, coll), policyBytes)
+	}
+	return b.db.WriteBatch(batch, true)
+}
+
+// forEach invokes the given function for each of the collections along with its member orgs policy
+func (b *collElgBookkeeper) forEach(f func(ns, coll string, policy *common.CollectionPolicyConfig) error) error {
+	itr := b.db.GetIterator(nil, nil)
+	defer itr.Release()
+	for itr.Next() {
+		ns, coll := decodeCollElgKey(itr.Key())
+		policy := &common.CollectionPolicyConfig{}
+		if err := proto.Unmarshal(itr.Value(), policy); err != nil {
+			return errors.Wrapf(err, "error while unmarshaling the member orgs policy of the collection [%s:%s]", ns, coll)
+		}
+		if err := f(ns, coll, policy); err != nil {
+			return err
+		}
+	}
+	return errors.Wrap(itr.Error(), "error while iterating over the collection eligibility")
+}
+
+// clear removes all the member orgs policies
+func (b *collElgBookkeeper) clear() error {
+	itr := b.db.GetIterator(nil, nil)
+	defer itr.Release()
+	batch := leveldbhelper.NewUpdateBatch()
+	for itr.Next() {
+		batch.Delete(itr.Key())
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrap(err, "error while iterating over the collection eligibility")
+	}
+	return b.db.WriteBatch(batch, true)
+}
+
+func encodeCollElgKey(ns, coll string) []byte {
+	return []byte(ns + "\x00" + coll)
+}
+
+func decodeCollElgKey(key []byte) (string, string) {
+	split := bytes.SplitN(key, []byte{0x00}, 2)
+	return string(split[0]), string(split[1])
+}
+
+// SubmitSnapshotRequest implements the corresponding method from interface ledger.PeerLedger
+func (l *kvLedger) SubmitSnapshotRequest(blockNum uint64) error {
+	// exporting the state requires a full scan of the state database, which only goleveldb supports
+	if ledgerconfig.IsCouchDBEnabled() {
+		return errors.Errorf("cannot submit a snapshot request for the ledger [%s] as snapshots are not supported with CouchDB as the state database", l.ledgerID)
+	}
+	// the commit lock prevents the commit of a block while the snapshot is being generated immediately or the request
+	// is being recorded, so that a request for the block under commit is neither missed nor generated for a wrong block.
+	// The block APIs are not blocked meanwhile
+	l.commitLock.Lock()
+	defer l.commitLock.Unlock()
+	bcInfo, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	if bcInfo.Height == 0 {
+		return errors.Errorf("cannot submit a snapshot request for the ledger [%s] as the ledger is empty", l.ledgerID)
+	}
+	lastBlockNum := bcInfo.Height - 1
+	if blockNum == 0 || blockNum == lastBlockNum {
+		return l.generateSnapshot()
+	}
+	if blockNum < lastBlockNum {
+		return errors.Errorf("requested snapshot for block number [%d] cannot be less than the last committed block number [%d]",
+			blockNum, lastBlockNum)
+	}
+	return l.snapshotRequestBookkeeper.add(blockNum)
+}
+
+// CancelSnapshotRequest implements the corresponding method from interface ledger.PeerLedger
+func (l *kvLedger) CancelSnapshotRequest(blockNum uint64) error {
+	l.commitLock.Lock()
+	defer l.commitLock.Unlock()
+	return l.snapshotRequestBookkeeper.delete(blockNum)
+}
+
+// PendingSnapshotRequests implements the corresponding method from interface ledger.PeerLedger
+func (l *kvLedger) PendingSnapshotRequests() ([]uint64, error) {
+	return l.snapshotRequestBookkeeper.list()
+}
+
+// processSnapshotRequest generates the snapshot if a request is pending for the given block number, which is
+// expected to be the last committed block. A failure in generating the snapshot does not fail the block commit,
+// as the block is already committed. The request is removed irrespective of the outcome
+func (l *kvLedger) processSnapshotRequest(blockNum uint64) error {
+	exists, err := l.snapshotRequestBookkeeper.exists(blockNum)
+	if err != nil || !exists {
+		return err
+	}
+	if err := l.generateSnapshot(); err != nil {
+		logger.Errorf("[%s] Failed to generate the requested snapshot for block [%d]: %+v", l.ledgerID, blockNum, err)
+	}
+	return l.snapshotRequestBookkeeper.delete(blockNum)
+}
+
+// processSnapshotRequestsOnStartup processes the pending requests that are left behind by a crash after the
+// commit of the requested block and before the snapshot generation. A request for the last committed block
+// is processed, as the state is recovered up to the last committed block, and the requests for the lower
+// blocks are dropped
+func (l *kvLedger) processSnapshotRequestsOnStartup() error {
+	bcInfo, err := l.blockStore.GetBlockchainInfo()
+	if err != nil || bcInfo.Height == 0 {
+		return err
+	}
+	lastBlockNum := bcInfo.Height - 1
+	pendingRequests, err := l.snapshotRequestBookkeeper.list()
+	if err != nil {
+		return err
+	}
+	for _, blockNum := range pendingRequests {
+		if blockNum > lastBlockNum {
+			break
+		}
+		if blockNum == lastBlockNum {
+			return l.processSnapshotRequest(blockNum)
+		}
+		logger.Warningf("[%s] Dropping the snapshot request for block [%d] as the block is already committed", l.ledgerID, blockNum)
+		if err := l.snapshotRequestBookkeeper.delete(blockNum); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,388 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/util"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSnapshotSigner struct{}
+
+func (s *fakeSnapshotSigner) Sign(message []byte) ([]byte, error) {
+	return append([]byte("signature-"), message...), nil
+}
+
+func (s *fakeSnapshotSigner) Serialize() ([]byte, error) {
+	return []byte("signer-identity"), nil
+}
+
+func TestSnapshotRequests(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, util.GetTestChainID(), false)
+	l, err := provider.Create(gb)
+	require.NoError(t, err)
+	defer l.Close()
+	commitBlocksWithState(t, l, bg, 1, 3)
+
+	require.NoError(t, l.SubmitSnapshotRequest(5))
+	require.NoError(t, l.SubmitSnapshotRequest(6))
+	require.NoError(t, l.SubmitSnapshotRequest(4))
+	pendingRequests, err := l.PendingSnapshotRequests()
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{4, 5, 6}, pendingRequests)
+
+	assert.EqualError(t, l.SubmitSnapshotRequest(5), "duplicate snapshot request for block number [5]")
+	assert.EqualError(t, l.SubmitSnapshotRequest(2),
+		"requested snapshot for block number [2] cannot be less than the last committed block number [3]")
+	assert.EqualError(t, l.CancelSnapshotRequest(7), "no snapshot request exists for block number [7]")
+	require.NoError(t, l.CancelSnapshotRequest(6))
+
+	// a request for the last committed block is served immediately
+	require.NoError(t, l.SubmitSnapshotRequest(0))
+	assertSnapshotDirExists(t, util.GetTestChainID(), 3, true)
+	assert.Contains(t, l.SubmitSnapshotRequest(3).Error(), "already exists")
+
+	commitBlocksWithState(t, l, bg, 4, 5)
+	pendingRequests, err = l.PendingSnapshotRequests()
+	require.NoError(t, err)
+	assert.Empty(t, pendingRequests)
+	assertSnapshotDirExists(t, util.GetTestChainID(), 4, true)
+	assertSnapshotDirExists(t, util.GetTestChainID(), 5, true)
+	assertSnapshotDirExists(t, util.GetTestChainID(), 6, false)
+}
+
+func TestSnapshotRequestsRecoveredOnStartup(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+
+	bg, gb := testutil.NewBlockGenerator(t, util.GetTestChainID(), false)
+	l, err := provider.Create(gb)
+	require.NoError(t, err)
+	commitBlocksWithState(t, l, bg, 1, 3)
+	// simulate the requests that were left behind by a crash after the commit of the requested block
+	bookkeeper := l.(*kvLedger).snapshotRequestBookkeeper
+	require.NoError(t, bookkeeper.add(2))
+	require.NoError(t, bookkeeper.add(3))
+	require.NoError(t, bookkeeper.add(10))
+	l.Close()
+	provider.Close()
+
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+	l, err = provider.Open(util.GetTestChainID())
+	require.NoError(t, err)
+	defer l.Close()
+	pendingRequests, err := l.PendingSnapshotRequests()
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{10}, pendingRequests)
+	assertSnapshotDirExists(t, util.GetTestChainID(), 2, false)
+	assertSnapshotDirExists(t, util.GetTestChainID(), 3, true)
+}
+
+func TestSnapshotRequestsRejectedWithCouchDB(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, util.GetTestChainID(), false)
+	l, err := provider.Create(gb)
+	require.NoError(t, err)
+	defer l.Close()
+	commitBlocksWithState(t, l, bg, 1, 3)
+
+	viper.Set("ledger.state.stateDatabase", "CouchDB")
+	defer viper.Set("ledger.state.stateDatabase", "goleveldb")
+	expectedErr := fmt.Sprintf("cannot submit a snapshot request for the ledger [%s] as snapshots are not supported with CouchDB as the state database", util.GetTestChainID())
+	assert.EqualError(t, l.SubmitSnapshotRequest(0), expectedErr)
+	assert.EqualError(t, l.SubmitSnapshotRequest(5), expectedErr)
+	pendingRequests, err := l.PendingSnapshotRequests()
+	require.NoError(t, err)
+	assert.Empty(t, pendingRequests)
+	assertSnapshotDirExists(t, util.GetTestChainID(), 3, false)
+}
+
+func TestCreateFromSnapshot(t *testing.T) {
+	sourceEnv := newTestEnv(t)
+	defer sourceEnv.cleanup()
+	sourceProvider := testutilNewProvider(t)
+	sourceProvider.(*Provider).initializer.SnapshotSigner = &fakeSnapshotSigner{}
+
+	bg, gb := testutil.NewBlockGenerator(t, util.GetTestChainID(), false)
+	sourceLedger, err := sourceProvider.Create(gb)
+	require.NoError(t, err)
+	txIDs := commitBlocksWithState(t, sourceLedger, bg, 1, 5)
+	require.NoError(t, sourceLedger.SubmitSnapshotRequest(0))
+	sourceLedger.Close()
+	sourceProvider.Close()
+	snapshotDir := SnapshotDirForLedgerBlockNum(ledgerconfig.GetSnapshotsRootDir(), util.GetTestChainID(), 5)
+
+	additionalMetadataBytes, err := ioutil.ReadFile(filepath.Join(snapshotDir, SnapshotAdditionalMetadataFileName))
+	require.NoError(t, err)
+	additionalMetadata := &snapshotAdditionalMetadata{}
+	require.NoError(t, json.Unmarshal(additionalMetadataBytes, additionalMetadata))
+	snapshotHash, err := hex.DecodeString(additionalMetadata.SnapshotHashInHex)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("signer-identity"), additionalMetadata.SignerIdentity)
+	assert.Equal(t, append([]byte("signature-"), snapshotHash...), additionalMetadata.Signature)
+
+	targetEnv := newTestEnv(t)
+	defer targetEnv.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+	l, ledgerID, err := provider.CreateFromSnapshot(snapshotDir)
+	require.NoError(t, err)
+	assert.Equal(t, util.GetTestChainID(), ledgerID)
+	_, _, err = provider.CreateFromSnapshot(snapshotDir)
+	assert.Equal(t, ErrLedgerIDExists, err)
+
+	bcInfo, err := l.GetBlockchainInfo()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), bcInfo.Height)
+	assert.Equal(t, uint64(5), bcInfo.LowWaterMark)
+	verifyStateAtBlock(t, l, 5)
+	_, err = l.GetBlockByNumber(4)
+	assert.IsType(t, &blkstorage.ErrBlockPruned{}, err)
+	_, err = l.GetTransactionByID(txIDs[0])
+	assert.IsType(t, &blkstorage.ErrBlockPruned{}, err)
+	processedTx, err := l.GetTransactionByID(txIDs[4])
+	require.NoError(t, err)
+	assert.NotNil(t, processedTx.TransactionEnvelope)
+
+	// the ledger created from the snapshot continues committing from the block next to the last block
+	// of the snapshot and survives a restart
+	commitBlocksWithState(t, l, bg, 6, 7)
+	verifyStateAtBlock(t, l, 7)
+	l.Close()
+	provider.Close()
+	provider = testutilNewProvider(t)
+	l, err = provider.Open(util.GetTestChainID())
+	require.NoError(t, err)
+	defer l.Close()
+	bcInfo, err = l.GetBlockchainInfo()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), bcInfo.Height)
+	verifyStateAtBlock(t, l, 7)
+}
+
+func TestCreateFromTamperedSnapshot(t *testing.T) {
+	sourceEnv := newTestEnv(t)
+	defer sourceEnv.cleanup()
+	sourceProvider := testutilNewProvider(t)
+	bg, gb := testutil.NewBlockGenerator(t, util.GetTestChainID(), false)
+	sourceLedger, err := sourceProvider.Create(gb)
+	require.NoError(t, err)
+	commitBlocksWithState(t, sourceLedger, bg, 1, 2)
+	require.NoError(t, sourceLedger.SubmitSnapshotRequest(0))
+	sourceLedger.Close()
+	sourceProvider.Close()
+	snapshotDir := SnapshotDirForLedgerBlockNum(ledgerconfig.GetSnapshotsRootDir(), util.GetTestChainID(), 2)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, privacyenabledstate.PubStateDataFileName), []byte("tampered"), 0644))
+
+	targetEnv := newTestEnv(t)
+	defer targetEnv.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+	_, _, err = provider.CreateFromSnapshot(snapshotDir)
+	assert.Contains(t, err.Error(), "hash mismatch for the snapshot file [public_state.data]")
+	exists, err := provider.Exists(util.GetTestChainID())
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestCreateFromSnapshotWithPvtData(t *testing.T) {
+	sourceEnv := newTestEnv(t)
+	defer sourceEnv.cleanup()
+	collConfigPkg := testutilPrepapreMockCollectionConfigPkg(map[string]bool{"coll1": true, "coll2": false})
+	sourceProvider := testutilNewProviderWithCollConfigPkg(t, "ns1", collConfigPkg)
+	bg, gb := testutil.NewBlockGenerator(t, util.GetTestChainID(), false)
+	sourceLedger, err := sourceProvider.Create(gb)
+	require.NoError(t, err)
+
+	// block 1 deploys the chaincode with the collections, block 2 writes a key in each collection, and
+	// block 3 writes two keys in the collection 'coll1' in a single transaction
+	commitBlockWithWrites(t, sourceLedger, bg, map[string]map[string]string{"lscc": {"ns1": "ns1-definition"}}, nil)
+	commitBlockWithWrites(t, sourceLedger, bg, nil, map[string]map[string]string{
+		"coll1": {"key1": "value1"},
+		"coll2": {"key1": "value1"},
+	})
+	commitBlockWithWrites(t, sourceLedger, bg, nil, map[string]map[string]string{
+		"coll1": {"key2": "value2", "key3": "value3"},
+	})
+	require.NoError(t, sourceLedger.SubmitSnapshotRequest(0))
+	sourceLedger.Close()
+	sourceProvider.Close()
+	snapshotDir := SnapshotDirForLedgerBlockNum(ledgerconfig.GetSnapshotsRootDir(), util.GetTestChainID(), 3)
+	assert.FileExists(t, filepath.Join(snapshotDir, CollectionEligibilityFileName))
+
+	targetEnv := newTestEnv(t)
+	defer targetEnv.cleanup()
+	provider := testutilNewProviderWithCollConfigPkg(t, "ns1", collConfigPkg)
+	defer provider.Close()
+	l, _, err := provider.CreateFromSnapshot(snapshotDir)
+	require.NoError(t, err)
+	defer l.Close()
+
+	// the pvtdata of the eligible collection is reported as missing so that the reconciler fetches it
+	expectedMissingPvtDataInfo := make(lgr.MissingPvtDataInfo)
+	expectedMissingPvtDataInfo.Add(2, 0, "ns1", "coll1")
+	expectedMissingPvtDataInfo.Add(3, 0, "ns1", "coll1")
+	missingPvtDataInfo, err := l.(*kvLedger).GetMissingPvtDataInfoForMostRecentBlocks(10)
+	require.NoError(t, err)
+	assert.Equal(t, expectedMissingPvtDataInfo, missingPvtDataInfo)
+
+	// the member orgs policies are not retained once the missing pvtdata is recorded
+	bookkeeper := newCollElgBookkeeper(util.GetTestChainID(), provider.(*Provider).bookkeepingProvider)
+	require.NoError(t, bookkeeper.forEach(func(ns, coll string, policy *common.CollectionPolicyConfig) error {
+		return fmt.Errorf("unexpected member orgs policy of the collection [%s:%s]", ns, coll)
+	}))
+}
+
+// commitBlockWithWrites commits a block with a transaction that sets the given public keys, grouped by the namespace,
+// and the given private keys of the namespace "ns1", grouped by the collection. The pvtdata is committed along with the block
+func commitBlockWithWrites(t *testing.T, l lgr.PeerLedger, bg *testutil.BlockGenerator,
+	pubKVs map[string]map[string]string, pvtKVs map[string]map[string]string) {
+	txID := util.GenerateUUID()
+	simulator, err := l.NewTxSimulator(txID)
+	require.NoError(t, err)
+	for ns, kvs := range pubKVs {
+		for k, v := range kvs {
+			require.NoError(t, simulator.SetState(ns, k, []byte(v)))
+		}
+	}
+	for coll, kvs := range pvtKVs {
+		for k, v := range kvs {
+			require.NoError(t, simulator.SetPrivateData("ns1", coll, k, []byte(v)))
+		}
+	}
+	simulator.Done()
+	simRes, err := simulator.GetTxSimulationResults()
+	require.NoError(t, err)
+	pubSimBytes, err := simRes.GetPubSimulationBytes()
+	require.NoError(t, err)
+	block := bg.NextBlockWithTxid([][]byte{pubSimBytes}, []string{txID})
+	blockAndPvtData := &lgr.BlockAndPvtData{Block: block}
+	if simRes.PvtSimulationResults != nil {
+		blockAndPvtData.PvtData = lgr.TxPvtDataMap{0: {SeqInBlock: 0, WriteSet: simRes.PvtSimulationResults}}
+	}
+	require.NoError(t, l.CommitWithPvtData(blockAndPvtData, &lgr.CommitOptions{}))
+}
+
+// testutilNewProviderWithCollConfigPkg returns a provider for which the given collection config package is deployed for
+// the given chaincode by any write in the namespace "lscc" and the peer is eligible for the collections as per the function
+// testutilIsEligibleForMockPolicy
+func testutilNewProviderWithCollConfigPkg(t *testing.T, ccName string, collConfigPkg *common.CollectionConfigPackage) lgr.PeerLedgerProvider {
+	ccInfoProvider := &mock.DeployedChaincodeInfoProvider{}
+	ccInfoProvider.NamespacesReturns([]string{"lscc"})
+	ccInfoProvider.UpdatedChaincodesReturns([]*lgr.ChaincodeLifecycleInfo{{Name: ccName}}, nil)
+	ccInfoProvider.ChaincodeInfoReturns(&lgr.DeployedChaincodeInfo{Name: ccName, CollectionConfigPkg: collConfigPkg}, nil)
+	ccInfoProvider.CollectionInfoStub = func(ns, coll string, qe lgr.SimpleQueryExecutor) (*common.StaticCollectionConfig, error) {
+		for _, collConfig := range retrieveCollConfs(collConfigPkg) {
+			if ns == ccName && collConfig.Name == coll {
+				return collConfig, nil
+			}
+		}
+		return nil, nil
+	}
+	membershipInfoProvider := &mock.MembershipInfoProvider{}
+	membershipInfoProvider.AmMemberOfStub = func(channel string, p *common.CollectionPolicyConfig) (bool, error) {
+		return testutilIsEligibleForMockPolicy(p), nil
+	}
+	provider, err := NewProvider()
+	require.NoError(t, err)
+	require.NoError(t, provider.Initialize(&lgr.Initializer{
+		DeployedChaincodeInfoProvider: ccInfoProvider,
+		MembershipInfoProvider:        membershipInfoProvider,
+		MetricsProvider:               &disabled.Provider{},
+	}))
+	return provider
+}
+
+// commitBlocksWithState commits the blocks with numbers in the range [from, to], each with a transaction that
+// sets the key "key<n>" and overwrites the key "latest-block". The function returns the txids of the transactions
+func commitBlocksWithState(t *testing.T, l lgr.PeerLedger, bg *testutil.BlockGenerator, from, to uint64) []string {
+	var txIDs []string
+	for blockNum := from; blockNum <= to; blockNum++ {
+		txID := util.GenerateUUID()
+		simulator, err := l.NewTxSimulator(txID)
+		require.NoError(t, err)
+		require.NoError(t, simulator.SetState("ns1", keyForBlock(blockNum), []byte(valueForBlock(blockNum))))
+		require.NoError(t, simulator.SetState("ns1", "latest-block", []byte(valueForBlock(blockNum))))
+		simulator.Done()
+		simRes, err := simulator.GetTxSimulationResults()
+		require.NoError(t, err)
+		pubSimBytes, err := simRes.GetPubSimulationBytes()
+		require.NoError(t, err)
+		block := bg.NextBlockWithTxid([][]byte{pubSimBytes}, []string{txID})
+		require.Equal(t, blockNum, block.Header.Number)
+		require.NoError(t, l.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}, &lgr.CommitOptions{}))
+		txIDs = append(txIDs, txID)
+	}
+	return txIDs
+}
+
+func verifyStateAtBlock(t *testing.T, l lgr.PeerLedger, lastBlockNum uint64) {
+	qe, err := l.NewQueryExecutor()
+	require.NoError(t, err)
+	defer qe.Done()
+	for blockNum := uint64(1); blockNum <= lastBlockNum; blockNum++ {
+		val, err := qe.GetState("ns1", keyForBlock(blockNum))
+		require.NoError(t, err)
+		assert.Equal(t, valueForBlock(blockNum), string(val))
+	}
+	val, err := qe.GetState("ns1", "latest-block")
+	require.NoError(t, err)
+	assert.Equal(t, valueForBlock(lastBlockNum), string(val))
+}
+
+func assertSnapshotDirExists(t *testing.T, ledgerID string, blockNum uint64, expected bool) {
+	snapshotDir := SnapshotDirForLedgerBlockNum(ledgerconfig.GetSnapshotsRootDir(), ledgerID, blockNum)
+	files, err := ioutil.ReadDir(snapshotDir)
+	if !expected {
+		assert.Error(t, err)
+		return
+	}
+	require.NoError(t, err)
+	var fileNames []string
+	for _, f := range files {
+		fileNames = append(fileNames, f.Name())
+	}
+	assert.Contains(t, fileNames, SnapshotSignableMetadataFileName)
+	assert.Contains(t, fileNames, SnapshotAdditionalMetadataFileName)
+	assert.Contains(t, fileNames, LastBlockFileName)
+}
+
+func keyForBlock(blockNum uint64) string {
+	return fmt.Sprintf("key%d", blockNum)
+}
+
+func valueForBlock(blockNum uint64) string {
+	return fmt.Sprintf("value-for-block-%d", blockNum)
+}
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/util"
 )
 
 func TestMissingCollConfig(t *testing.T) {
@@ -161,3 +162,50 @@ func TestBTL(t *testing.T) {
 		r.pvtdataShouldNotContain("cc1", "coll2")                   // <cc1, coll2> shold have been purged from the pvtdata storage
 	})
 }
+
+func TestPurgePrivateData(t *testing.T) {
+	env := newEnv(defaultConfig, t)
+	defer env.cleanup()
+	h := newTestHelperCreateLgr("ledger1", t)
+
+	collConf := []*collConf{{name: "coll1", btl: 0}}
+
+	// deploy cc1 with 'collConf'
+	h.simulateDeployTx("cc1", collConf)
+	h.cutBlockAndCommitWithPvtdata()
+
+	// commit pvtdata writes in block 2 and block 3
+	h.simulateDataTx("", func(s *simulator) {
+		s.setPvtdata("cc1", "coll1", "key1", "value1")
+	})
+	h.cutBlockAndCommitWithPvtdata()
+	h.simulateDataTx("", func(s *simulator) {
+		s.setPvtdata("cc1", "coll1", "key2", "value2")
+	})
+	blk3 := h.cutBlockAndCommitWithPvtdata()
+
+	// purge the pvtdata committed before block 3
+	h.assert.NoError(h.lgr.PurgePrivateData(3))
+	minBlockNum, err := h.lgr.PrivateDataMinBlockNum()
+	h.assert.NoError(err)
+	h.assert.Equal(uint64(3), minBlockNum)
+
+	h.verifyPvtStateUnavailable("cc1", "coll1", "key1")                            // key1 should have been purged from the state
+	h.verifyPvtStateHash("cc1", "coll1", "key1", util.ComputeStringHash("value1")) // but not the hash of key1
+	h.verifyPvtState("cc1", "coll1", "key2", "value2")                             // key2 should still exist in the state
+	h.verifyBlockAndPvtData(2, nil, func(r *retrievedBlockAndPvtdata) {
+		r.pvtdataShouldNotContain("cc1", "coll1") // <cc1, coll1> should have been purged from the pvtdata storage
+	})
+	h.verifyBlockAndPvtDataSameAs(3, blk3)
+
+	// pvtdata can be written again for a purged key
+	h.simulateDataTx("", func(s *simulator) {
+		s.setPvtdata("cc1", "coll1", "key1", "value1-new")
+	})
+	h.cutBlockAndCommitWithPvtdata()
+	h.closeAndReopenLgr()
+	h.verifyPvtState("cc1", "coll1", "key1", "value1-new")
+	minBlockNum, err = h.lgr.PrivateDataMinBlockNum()
+	h.assert.NoError(err)
+	h.assert.Equal(uint64(3), minBlockNum)
+}
@@ -66,6 +66,23 @@ func (v *verifier) verifyPvtState(ns, coll, key string, expectedVal string) {
 	v.assert.Equal(expectedValBytes, committedVal)
 }
 
+func (v *verifier) verifyPvtStateUnavailable(ns, coll, key string) {
+	qe, err := v.lgr.NewQueryExecutor()
+	v.assert.NoError(err)
+	defer qe.Done()
+	_, err = qe.GetPrivateData(ns, coll, key)
+	v.assert.Contains(err.Error(), "private data matching public hash version is not available")
+}
+
+func (v *verifier) verifyPvtStateHash(ns, coll, key string, expectedValHash []byte) {
+	qe, err := v.lgr.NewQueryExecutor()
+	v.assert.NoError(err)
+	defer qe.Done()
+	committedValHash, err := qe.GetPrivateDataHash(ns, coll, key)
+	v.assert.NoError(err)
+	v.assert.Equal(expectedValHash, committedValHash)
+}
+
 func (v *verifier) verifyMostRecentCollectionConfigBelow(blockNum uint64, chaincodeName string, expectOut *expectedCollConfInfo) {
 	configHistory, err := v.lgr.GetConfigHistoryRetriever()
 	v.assert.NoError(err)
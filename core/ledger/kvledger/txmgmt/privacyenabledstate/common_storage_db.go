@@ -38,6 +38,8 @@ type CommonStorageDBProvider struct {
 	statedb.VersionedDBProvider
 	HealthCheckRegistry ledger.HealthCheckRegistry
 	bookkeepingProvider bookkeeping.Provider
+	cacheSize           int
+	cacheMetrics        *statedb.CacheMetrics
 }
 
 // NewCommonStorageDBProvider constructs an instance of DBProvider
@@ -52,7 +54,13 @@ func NewCommonStorageDBProvider(bookkeeperProvider bookkeeping.Provider, metrics
 		vdbProvider = stateleveldb.NewVersionedDBProvider()
 	}
 
-	dbProvider := &CommonStorageDBProvider{vdbProvider, healthCheckRegistry, bookkeeperProvider}
+	dbProvider := &CommonStorageDBProvider{
+		VersionedDBProvider: vdbProvider,
+		HealthCheckRegistry: healthCheckRegistry,
+		bookkeepingProvider: bookkeeperProvider,
+		cacheSize:           ledgerconfig.GetStateCacheSize(),
+		cacheMetrics:        statedb.NewCacheMetrics(metricsProvider),
+	}
 
 	err = dbProvider.RegisterHealthChecker()
 	if err != nil {
@@ -77,7 +85,11 @@ func (p *CommonStorageDBProvider) GetDBHandle(id string) (DB, error) {
 	}
 	bookkeeper := p.bookkeepingProvider.GetDBHandle(id, bookkeeping.MetadataPresenceIndicator)
 	metadataHint := newMetadataHint(bookkeeper)
-	return NewCommonStorageDB(vdb, id, metadataHint)
+	var cache *statedb.Cache
+	if p.cacheSize > 0 {
+		cache = statedb.NewCache(id, p.cacheSize, p.cacheMetrics)
+	}
+	return NewCommonStorageDB(vdb, id, metadataHint, cache)
 }
 
 // Close implements function from interface DBProvider
@@ -90,12 +102,76 @@ func (p *CommonStorageDBProvider) Close() {
 type CommonStorageDB struct {
 	statedb.VersionedDB
 	metadataHint *metadataHint
+	cache        *statedb.Cache
 }
 
 // NewCommonStorageDB wraps a VersionedDB instance. The public data is managed directly by the wrapped versionedDB.
-// For managing the hashed data and private data, this implementation creates separate namespaces in the wrapped db
-func NewCommonStorageDB(vdb statedb.VersionedDB, ledgerid string, metadataHint *metadataHint) (DB, error) {
-	return &CommonStorageDB{vdb, metadataHint}, nil
+// For managing the hashed data and private data, this implementation creates separate namespaces in the wrapped db.
+// If cache is not nil, the reads of single keys are served through the cache
+func NewCommonStorageDB(vdb statedb.VersionedDB, ledgerid string, metadataHint *metadataHint, cache *statedb.Cache) (DB, error) {
+	return &CommonStorageDB{vdb, metadataHint, cache}, nil
+}
+
+// GetState overrides the function in statedb.VersionedDB and serves the value from the cache, if enabled
+func (s *CommonStorageDB) GetState(namespace, key string) (*statedb.VersionedValue, error) {
+	if s.cache == nil {
+		return s.VersionedDB.GetState(namespace, key)
+	}
+	if vv, ok := s.cache.GetState(namespace, key); ok {
+		return vv, nil
+	}
+	generation := s.cache.Generation()
+	vv, err := s.VersionedDB.GetState(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.PutState(namespace, key, vv, generation)
+	return vv, nil
+}
+
+// GetVersion overrides the function in statedb.VersionedDB and serves the version from the cache, if enabled
+func (s *CommonStorageDB) GetVersion(namespace, key string) (*version.Height, error) {
+	if s.cache == nil {
+		return s.VersionedDB.GetVersion(namespace, key)
+	}
+	vv, err := s.GetState(namespace, key)
+	if err != nil || vv == nil {
+		return nil, err
+	}
+	return vv.Version, nil
+}
+
+// GetStateMultipleKeys overrides the function in statedb.VersionedDB and retrieves from the
+// wrapped db only the keys that are not present in the cache, if enabled
+func (s *CommonStorageDB) GetStateMultipleKeys(namespace string, keys []string) ([]*statedb.VersionedValue, error) {
+	if s.cache == nil {
+		return s.VersionedDB.GetStateMultipleKeys(namespace, keys)
+	}
+	vals := make([]*statedb.VersionedValue, len(keys))
+	var missingKeys []string
+	var missingIndexes []int
+	for i, key := range keys {
+		vv, ok := s.cache.GetState(namespace, key)
+		if !ok {
+			missingKeys = append(missingKeys, key)
+			missingIndexes = append(missingIndexes, i)
+			continue
+		}
+		vals[i] = vv
+	}
+	if len(missingKeys) == 0 {
+		return vals, nil
+	}
+	generation := s.cache.Generation()
+	missingVals, err := s.VersionedDB.GetStateMultipleKeys(namespace, missingKeys)
+	if err != nil {
+		return nil, err
+	}
+	for i, vv := range missingVals {
+		s.cache.PutState(namespace, missingKeys[i], vv, generation)
+		vals[missingIndexes[i]] = vv
+	}
+	return vals, nil
 }
 
 // IsBulkOptimizable implements corresponding function in interface DB
@@ -200,6 +276,19 @@ func (s *CommonStorageDB) GetPrivateDataRangeScanIterator(namespace, collection,
 	return s.GetStateRangeScanIterator(derivePvtDataNs(namespace, collection), startKey, endKey)
 }
 
+// GetHashedDataIterator implements corresponding function in interface DB. The key in each of the
+// results returned by the iterator is the key hash, irrespective of the encoding used in the underlying db
+func (s *CommonStorageDB) GetHashedDataIterator(namespace, collection string) (statedb.ResultsIterator, error) {
+	itr, err := s.GetStateRangeScanIterator(deriveHashedDataNs(namespace, collection), "", "")
+	if err != nil {
+		return nil, err
+	}
+	if s.BytesKeySupported() {
+		return itr, nil
+	}
+	return &base64KeyDecodingItr{itr}, nil
+}
+
 // ExecuteQueryOnPrivateData implements corresponding function in interface DB
 func (s CommonStorageDB) ExecuteQueryOnPrivateData(namespace, collection, query string) (statedb.ResultsIterator, error) {
 	return s.ExecuteQuery(derivePvtDataNs(namespace, collection), query)
@@ -218,7 +307,17 @@ func (s *CommonStorageDB) ApplyPrivacyAwareUpdates(updates *UpdateBatch, height
 	addPvtUpdates(combinedUpdates, updates.PvtUpdates)
 	addHashedUpdates(combinedUpdates, updates.HashUpdates, !s.BytesKeySupported())
 	s.metadataHint.setMetadataUsedFlag(updates)
-	return s.VersionedDB.ApplyUpdates(combinedUpdates.UpdateBatch, height)
+	if err := s.VersionedDB.ApplyUpdates(combinedUpdates.UpdateBatch, height); err != nil {
+		if s.cache != nil {
+			// the batch may have been partially applied
+			s.cache.Clear(combinedUpdates.GetUpdatedNamespaces())
+		}
+		return err
+	}
+	if s.cache != nil {
+		s.cache.UpdateCache(combinedUpdates.UpdateBatch)
+	}
+	return nil
 }
 
 // GetStateMetadata implements corresponding function in interface DB. This implementation provides
@@ -343,6 +442,26 @@ func addHashedUpdates(pubUpdateBatch *PubUpdateBatch, hashedUpdateBatch *HashedU
 	}
 }
 
+// base64KeyDecodingItr wraps an iterator over the hashed data in a db that does not support
+// bytes keys and decodes the base64 encoded key hashes
+type base64KeyDecodingItr struct {
+	statedb.ResultsIterator
+}
+
+func (itr *base64KeyDecodingItr) Next() (statedb.QueryResult, error) {
+	res, err := itr.ResultsIterator.Next()
+	if res == nil || err != nil {
+		return res, err
+	}
+	kv := res.(*statedb.VersionedKV)
+	keyHash, err := base64.StdEncoding.DecodeString(kv.Key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decoding the key hash [%s]", kv.Key)
+	}
+	kv.Key = string(keyHash)
+	return kv, nil
+}
+
 func extractCollectionNames(chaincodeDefinition *cceventmgmt.ChaincodeDefinition) (map[string]bool, error) {
 	collectionConfigs := chaincodeDefinition.CollectionConfigs
 	collectionConfigsMap := make(map[string]bool)
@@ -231,6 +231,22 @@ func testGetStateRangeScanIterator(t *testing.T, env TestEnv) {
 
 	pvtItr4, _ := db.GetPrivateDataRangeScanIterator("ns2", "coll1", "", "")
 	testItr(t, pvtItr4, []string{"key5", "key6"})
+
+	hashedItr, err := db.GetHashedDataIterator("ns2", "coll1")
+	assert.NoError(t, err)
+	defer hashedItr.Close()
+	var keyHashes []string
+	for {
+		res, err := hashedItr.Next()
+		assert.NoError(t, err)
+		if res == nil {
+			break
+		}
+		keyHashes = append(keyHashes, res.(*statedb.VersionedKV).Key)
+	}
+	assert.ElementsMatch(t,
+		[]string{string(util.ComputeStringHash("key5")), string(util.ComputeStringHash("key6"))},
+		keyHashes)
 }
 
 func TestQueryOnCouchDB(t *testing.T) {
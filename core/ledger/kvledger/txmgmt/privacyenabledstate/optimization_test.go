@@ -7,9 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package privacyenabledstate
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/mock"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/stretchr/testify/assert"
@@ -58,7 +61,7 @@ func TestMetadataHintOptimizationSkippingGoingToDB(t *testing.T) {
 	bookkeeper := bookkeepingTestEnv.TestProvider.GetDBHandle("ledger1", bookkeeping.MetadataPresenceIndicator)
 
 	mockVersionedDB := &mock.VersionedDB{}
-	db, err := NewCommonStorageDB(mockVersionedDB, "testledger", newMetadataHint(bookkeeper))
+	db, err := NewCommonStorageDB(mockVersionedDB, "testledger", newMetadataHint(bookkeeper), nil)
 	assert.NoError(t, err)
 	updates := NewUpdateBatch()
 	updates.PubUpdates.PutValAndMetadata("ns1", "key", []byte("value"), []byte("metadata"), version.NewHeight(1, 1))
@@ -78,3 +81,57 @@ func TestMetadataHintOptimizationSkippingGoingToDB(t *testing.T) {
 	db.GetPrivateDataMetadataByHash("randomeNs", "randomColl", []byte("randomKeyhash"))
 	assert.Equal(t, 2, mockVersionedDB.GetStateCallCount())
 }
+
+func TestStateCacheSkippingGoingToDB(t *testing.T) {
+	bookkeepingTestEnv := bookkeeping.NewTestEnv(t)
+	defer bookkeepingTestEnv.Cleanup()
+	bookkeeper := bookkeepingTestEnv.TestProvider.GetDBHandle("ledger1", bookkeeping.MetadataPresenceIndicator)
+
+	mockVersionedDB := &mock.VersionedDB{}
+	mockVersionedDB.BytesKeySupportedReturns(true)
+	mockVersionedDB.GetStateReturns(&statedb.VersionedValue{Value: []byte("value"), Version: version.NewHeight(1, 1)}, nil)
+	mockVersionedDB.GetStateMultipleKeysReturns([]*statedb.VersionedValue{nil}, nil)
+	cache := statedb.NewCache("testledger", 10, statedb.NewCacheMetrics(&disabled.Provider{}))
+	db, err := NewCommonStorageDB(mockVersionedDB, "testledger", newMetadataHint(bookkeeper), cache)
+	assert.NoError(t, err)
+
+	vv, err := db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), vv.Value)
+	ver, err := db.GetVersion("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(1, 1), ver)
+	assert.Equal(t, 1, mockVersionedDB.GetStateCallCount())
+	assert.Equal(t, 0, mockVersionedDB.GetVersionCallCount())
+
+	// only the keys that are not cached are retrieved from the db
+	vals, err := db.GetStateMultipleKeys("ns1", []string{"key1", "key2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), vals[0].Value)
+	assert.Nil(t, vals[1])
+	assert.Equal(t, 1, mockVersionedDB.GetStateMultipleKeysCallCount())
+	_, keys := mockVersionedDB.GetStateMultipleKeysArgsForCall(0)
+	assert.Equal(t, []string{"key2"}, keys)
+
+	// the committed updates are served from the cache
+	updates := NewUpdateBatch()
+	updates.PubUpdates.Put("ns1", "key1", []byte("value-new"), version.NewHeight(2, 1))
+	updates.PubUpdates.Put("ns1", "key2", []byte("value2"), version.NewHeight(2, 2))
+	assert.NoError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(2, 2)))
+	vv, err = db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value-new"), vv.Value)
+	vv, err = db.GetState("ns1", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value2"), vv.Value)
+	assert.Equal(t, 1, mockVersionedDB.GetStateCallCount())
+
+	// a failed commit clears the updated namespaces
+	mockVersionedDB.ApplyUpdatesReturns(errors.New("commit error"))
+	updates = NewUpdateBatch()
+	updates.PubUpdates.Put("ns1", "key1", []byte("value-newer"), version.NewHeight(3, 1))
+	assert.EqualError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(3, 1)), "commit error")
+	_, err = db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, mockVersionedDB.GetStateCallCount())
+}
@@ -0,0 +1,223 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/pkg/errors"
+)
+
+const (
+	snapshotFileFormat = byte(1)
+	// PubStateDataFileName is the name of the snapshot file that contains the public state
+	PubStateDataFileName = "public_state.data"
+	// PvtStateHashesFileName is the name of the snapshot file that contains the hashes of the private state
+	PvtStateHashesFileName = "private_state_hashes.data"
+
+	// maxImportBatchSize is the approximate size, in bytes, of the keys and values that are
+	// accumulated in an update batch before applying the batch during an import
+	maxImportBatchSize = 10 * 1024 * 1024
+)
+
+// ExportPubStateAndPvtStateHashes generates two files in the specified dir. The first file contains the public state
+// and the second file contains the hashes of the private state. The private state itself is not exported. The function
+// returns a map that contains the names of the generated files and their hashes.
+// The underlying db is expected to implement the interface statedb.FullScanIterable
+func (s *CommonStorageDB) ExportPubStateAndPvtStateHashes(dir string, newHashFunc snapshot.NewHashFunc) (map[string][]byte, error) {
+	fullScanDB, ok := s.VersionedDB.(statedb.FullScanIterable)
+	if !ok {
+		return nil, errors.New("exporting the state is not supported by the configured state database")
+	}
+	itr, err := fullScanDB.GetFullScanIterator(IsPvtdataNs)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	pubStateWriter, err := snapshot.CreateFile(filepath.Join(dir, PubStateDataFileName), snapshotFileFormat, newHashFunc)
+	if err != nil {
+		return nil, err
+	}
+	defer pubStateWriter.Close()
+	pvtStateHashesWriter, err := snapshot.CreateFile(filepath.Join(dir, PvtStateHashesFileName), snapshotFileFormat, newHashFunc)
+	if err != nil {
+		return nil, err
+	}
+	defer pvtStateHashesWriter.Close()
+
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			break
+		}
+		kv := res.(*statedb.VersionedKV)
+		if ns, coll, ok := DecodeHashedDataNs(kv.Namespace); ok {
+			err = writeSnapshotRecord(pvtStateHashesWriter, kv, ns, coll)
+		} else {
+			err = writeSnapshotRecord(pubStateWriter, kv, kv.Namespace)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pubStateHash, err := pubStateWriter.Done()
+	if err != nil {
+		return nil, err
+	}
+	pvtStateHashesHash, err := pvtStateHashesWriter.Done()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		PubStateDataFileName:   pubStateHash,
+		PvtStateHashesFileName: pvtStateHashesHash,
+	}, nil
+}
+
+// ImportPubStateAndPvtStateHashes loads the public state and the hashes of the private state from the snapshot
+// files present in the specified dir, as generated by the function ExportPubStateAndPvtStateHashes. The savepoint
+// of the db is set to the supplied savepoint only after all the data is loaded
+func (s *CommonStorageDB) ImportPubStateAndPvtStateHashes(dir string, savepoint *version.Height) error {
+	pubStateReader, err := snapshot.OpenFile(filepath.Join(dir, PubStateDataFileName), snapshotFileFormat)
+	if err != nil {
+		return err
+	}
+	defer pubStateReader.Close()
+	pvtStateHashesReader, err := snapshot.OpenFile(filepath.Join(dir, PvtStateHashesFileName), snapshotFileFormat)
+	if err != nil {
+		return err
+	}
+	defer pvtStateHashesReader.Close()
+
+	batch := NewUpdateBatch()
+	batchSize := 0
+	applyBatch := func(height *version.Height) error {
+		if err := s.ApplyPrivacyAwareUpdates(batch, height); err != nil {
+			return err
+		}
+		batch = NewUpdateBatch()
+		batchSize = 0
+		return nil
+	}
+
+	for {
+		ns, err := pubStateReader.DecodeString()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		key, vv, err := readSnapshotRecord(pubStateReader)
+		if err != nil {
+			return err
+		}
+		batch.PubUpdates.PutValAndMetadata(ns, string(key), vv.Value, vv.Metadata, vv.Version)
+		if batchSize += len(key) + len(vv.Value) + len(vv.Metadata); batchSize >= maxImportBatchSize {
+			if err := applyBatch(nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		ns, err := pvtStateHashesReader.DecodeString()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		coll, err := pvtStateHashesReader.DecodeString()
+		if err != nil {
+			return err
+		}
+		keyHash, vv, err := readSnapshotRecord(pvtStateHashesReader)
+		if err != nil {
+			return err
+		}
+		batch.HashUpdates.PutValHashAndMetadata(ns, coll, keyHash, vv.Value, vv.Metadata, vv.Version)
+		if batchSize += len(keyHash) + len(vv.Value) + len(vv.Metadata); batchSize >= maxImportBatchSize {
+			if err := applyBatch(nil); err != nil {
+				return err
+			}
+		}
+	}
+	return applyBatch(savepoint)
+}
+
+func writeSnapshotRecord(w *snapshot.FileWriter, kv *statedb.VersionedKV, nsAndColl ...string) error {
+	for _, s := range nsAndColl {
+		if err := w.EncodeString(s); err != nil {
+			return err
+		}
+	}
+	if err := w.EncodeString(kv.Key); err != nil {
+		return err
+	}
+	if err := w.EncodeBytes(kv.Version.ToBytes()); err != nil {
+		return err
+	}
+	if err := w.EncodeBytes(kv.Value); err != nil {
+		return err
+	}
+	return w.EncodeBytes(kv.Metadata)
+}
+
+func readSnapshotRecord(r *snapshot.FileReader) ([]byte, *statedb.VersionedValue, error) {
+	key, err := r.DecodeBytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	versionBytes, err := r.DecodeBytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	ver, _, err := version.NewHeightFromBytes(versionBytes)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error while decoding the version from the snapshot file")
+	}
+	value, err := r.DecodeBytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	metadata, err := r.DecodeBytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(metadata) == 0 {
+		metadata = nil
+	}
+	return key, &statedb.VersionedValue{Value: value, Metadata: metadata, Version: ver}, nil
+}
+
+// IsPvtdataNs returns true if the given namespace is derived for maintaining the private data of a collection.
+// Unlike the public and hashed data, the private data present in the statedb may differ across the peers
+func IsPvtdataNs(namespace string) bool {
+	i := strings.Index(namespace, nsJoiner)
+	return i != -1 && strings.HasPrefix(namespace[i+len(nsJoiner):], pvtDataPrefix)
+}
+
+// DecodeHashedDataNs returns the chaincode namespace and the collection name if the
+// given namespace is derived for maintaining the hashes of a collection
+func DecodeHashedDataNs(namespace string) (string, string, bool) {
+	i := strings.Index(namespace, nsJoiner)
+	if i == -1 || !strings.HasPrefix(namespace[i+len(nsJoiner):], hashDataPrefix) {
+		return "", "", false
+	}
+	return namespace[:i], namespace[i+len(nsJoiner)+len(hashDataPrefix):], true
+}
@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportPubStateAndPvtStateHashes(t *testing.T) {
+	env := &LevelDBCommonStorageTestEnv{}
+	env.Init(t)
+	defer env.Cleanup()
+	snapshotDir, err := ioutil.TempDir("", "privacyenabledstate-snapshot-")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	sourceDB := env.GetDBHandle("source-ledger")
+	updates := NewUpdateBatch()
+	updates.PubUpdates.Put("", "config-key", []byte("config-value"), version.NewHeight(1, 0))
+	updates.PubUpdates.PutValAndMetadata("ns1", "key1", []byte("value1"), []byte("metadata1"), version.NewHeight(1, 1))
+	updates.PubUpdates.Put("ns2", "key2", []byte("value2"), version.NewHeight(1, 2))
+	putPvtUpdatesWithMetadata(t, updates, "ns1", "coll1", "key1", []byte("pvt_value1"), []byte("metadata1"), version.NewHeight(1, 3))
+	putPvtUpdates(t, updates, "ns2", "coll2", "key2", []byte("pvt_value2"), version.NewHeight(1, 4))
+	require.NoError(t, sourceDB.ApplyPrivacyAwareUpdates(updates, version.NewHeight(1, 4)))
+
+	fileHashes, err := sourceDB.ExportPubStateAndPvtStateHashes(snapshotDir, snapshot.DefaultNewHashFunc)
+	require.NoError(t, err)
+	require.Len(t, fileHashes, 2)
+	for fileName, hash := range fileHashes {
+		computedHash, err := snapshot.FileHash(snapshotDir+"/"+fileName, snapshot.DefaultNewHashFunc)
+		require.NoError(t, err)
+		assert.Equal(t, computedHash, hash)
+	}
+
+	targetDB := env.GetDBHandle("target-ledger")
+	require.NoError(t, targetDB.ImportPubStateAndPvtStateHashes(snapshotDir, version.NewHeight(5, 10)))
+	savepoint, err := targetDB.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(5, 10), savepoint)
+
+	vv, err := targetDB.GetState("", "config-key")
+	assert.NoError(t, err)
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("config-value"), Version: version.NewHeight(1, 0)}, vv)
+	vv, err = targetDB.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("value1"), Metadata: []byte("metadata1"), Version: version.NewHeight(1, 1)}, vv)
+	vv, err = targetDB.GetState("ns2", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("value2"), Version: version.NewHeight(1, 2)}, vv)
+
+	vv, err = targetDB.GetValueHash("ns1", "coll1", util.ComputeStringHash("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, &statedb.VersionedValue{Value: util.ComputeHash([]byte("pvt_value1")), Metadata: []byte("metadata1"), Version: version.NewHeight(1, 3)}, vv)
+	metadata, err := targetDB.GetPrivateDataMetadataByHash("ns1", "coll1", util.ComputeStringHash("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("metadata1"), metadata)
+	vv, err = targetDB.GetValueHash("ns2", "coll2", util.ComputeStringHash("key2"))
+	assert.NoError(t, err)
+	assert.Equal(t, &statedb.VersionedValue{Value: util.ComputeHash([]byte("pvt_value2")), Version: version.NewHeight(1, 4)}, vv)
+
+	// the private data itself is not exported
+	vv, err = targetDB.GetPrivateData("ns1", "coll1", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+	vv, err = targetDB.GetPrivateData("ns2", "coll2", "key2")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+
+	// an export does not overwrite the existing files
+	_, err = sourceDB.ExportPubStateAndPvtStateHashes(snapshotDir, snapshot.DefaultNewHashFunc)
+	assert.Contains(t, err.Error(), "error while creating the snapshot file")
+}
+
+func TestDecodeHashedDataNs(t *testing.T) {
+	ns, coll, ok := DecodeHashedDataNs(deriveHashedDataNs("ns1", "coll1"))
+	assert.True(t, ok)
+	assert.Equal(t, "ns1", ns)
+	assert.Equal(t, "coll1", coll)
+	_, _, ok = DecodeHashedDataNs(derivePvtDataNs("ns1", "coll1"))
+	assert.False(t, ok)
+	_, _, ok = DecodeHashedDataNs("ns1")
+	assert.False(t, ok)
+	assert.True(t, IsPvtdataNs(derivePvtDataNs("ns1", "coll1")))
+	assert.False(t, IsPvtdataNs(deriveHashedDataNs("ns1", "coll1")))
+	assert.False(t, IsPvtdataNs("ns1"))
+}
@@ -122,11 +122,7 @@ func encodeExpiryInfoValue(pvtdataKeys *PvtdataKeys) ([]byte, error) {
 }
 
 func decodeExpiryInfo(key []byte, value []byte) (*expiryInfo, error) {
-	expiryBlk, n, err := util.DecodeOrderPreservingVarUint64(key[1:])
-	if err != nil {
-		return nil, err
-	}
-	committingBlk, _, err := util.DecodeOrderPreservingVarUint64(key[n+1:])
+	expinfoKey, err := decodeExpiryInfoKey(key)
 	if err != nil {
 		return nil, err
 	}
@@ -135,7 +131,19 @@ func decodeExpiryInfo(key []byte, value []byte) (*expiryInfo, error) {
 		return nil, err
 	}
 	return &expiryInfo{
-			expiryInfoKey: &expiryInfoKey{committingBlk: committingBlk, expiryBlk: expiryBlk},
+			expiryInfoKey: expinfoKey,
 			pvtdataKeys:   pvtdataKeys},
 		nil
 }
+
+func decodeExpiryInfoKey(key []byte) (*expiryInfoKey, error) {
+	expiryBlk, n, err := util.DecodeOrderPreservingVarUint64(key[1:])
+	if err != nil {
+		return nil, err
+	}
+	committingBlk, _, err := util.DecodeOrderPreservingVarUint64(key[n+1:])
+	if err != nil {
+		return nil, err
+	}
+	return &expiryInfoKey{committingBlk: committingBlk, expiryBlk: expiryBlk}, nil
+}
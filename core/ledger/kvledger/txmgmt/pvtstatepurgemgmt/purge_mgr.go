@@ -32,6 +32,14 @@ type PurgeMgr interface {
 	UpdateBookkeepingForPvtDataOfOldBlocks(pvtUpdates *privacyenabledstate.PvtUpdateBatch) error
 	// BlockCommitDone is a callback to the PurgeMgr when the block is committed to the ledger
 	BlockCommitDone() error
+	// DeleteCommittedBefore modifies the update batch by adding the deletes for the pvtdata of the given collections
+	// that was last updated by a block lower than the given block number, irrespective of the BTL. The hashed data is
+	// not deleted, as it is part of the public state; it continues to expire as per the BTL of the collections
+	DeleteCommittedBefore(blockNum uint64, nsCollMap map[string][]string, pvtUpdates *privacyenabledstate.PvtUpdateBatch) error
+	// RebuildExpirySchedule builds the expiry schedule for the hashed data of the given collections that is present
+	// in the state. This is expected to be invoked after the state is loaded from a snapshot, as the expiry schedule
+	// is not part of a snapshot
+	RebuildExpirySchedule(nsCollMap map[string][]string) error
 }
 
 type keyAndVersion struct {
@@ -208,6 +216,82 @@ func (p *purgeMgr) BlockCommitDone() error {
 	return p.expKeeper.updateBookkeeping(nil, p.workingset.toClearFromSchedule)
 }
 
+// DeleteCommittedBefore implements function in the interface 'PurgeMgr'
+func (p *purgeMgr) DeleteCommittedBefore(blockNum uint64, nsCollMap map[string][]string,
+	pvtUpdates *privacyenabledstate.PvtUpdateBatch) error {
+	deleteVersion := version.NewHeight(blockNum, 0)
+	for ns, colls := range nsCollMap {
+		for _, coll := range colls {
+			logger.Debugf("Adding the keys committed before block [%d] for [ns=%s, coll=%s] to the delete list in the update batch", blockNum, ns, coll)
+			pvtItr, err := p.db.GetPrivateDataRangeScanIterator(ns, coll, "", "")
+			if err != nil {
+				return err
+			}
+			err = forEachKeyCommittedBefore(pvtItr, blockNum, func(key string) {
+				pvtUpdates.Delete(ns, coll, key, deleteVersion)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RebuildExpirySchedule implements function in the interface 'PurgeMgr'
+func (p *purgeMgr) RebuildExpirySchedule(nsCollMap map[string][]string) error {
+	builder := newExpiryScheduleBuilder(p.btlPolicy)
+	for ns, colls := range nsCollMap {
+		for _, coll := range colls {
+			logger.Debugf("Building the expiry schedule for the hashed data of [ns=%s, coll=%s]", ns, coll)
+			itr, err := p.db.GetHashedDataIterator(ns, coll)
+			if err != nil {
+				return err
+			}
+			err = forEachVersionedKV(itr, func(kv *statedb.VersionedKV) error {
+				return builder.add(ns, coll, "", []byte(kv.Key), &kv.VersionedValue)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return p.expKeeper.updateBookkeeping(builder.getExpiryInfo(), nil)
+}
+
+func forEachVersionedKV(itr statedb.ResultsIterator, f func(kv *statedb.VersionedKV) error) error {
+	defer itr.Close()
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if res == nil {
+			return nil
+		}
+		if err := f(res.(*statedb.VersionedKV)); err != nil {
+			return err
+		}
+	}
+}
+
+func forEachKeyCommittedBefore(itr statedb.ResultsIterator, blockNum uint64, f func(key string)) error {
+	defer itr.Close()
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if res == nil {
+			return nil
+		}
+		kv := res.(*statedb.VersionedKV)
+		if kv.Version.BlockNum < blockNum {
+			f(kv.Key)
+		}
+	}
+}
+
 // prepareWorkingsetFor returns a working set for a given expiring block 'expiringAtBlk'.
 // This working set contains the pvt data keys that will expire with the commit of block 'expiringAtBlk'.
 func (p *purgeMgr) prepareWorkingsetFor(expiringAtBlk uint64) *workingset {
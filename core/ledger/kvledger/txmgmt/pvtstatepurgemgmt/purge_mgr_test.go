@@ -157,6 +157,89 @@ func testPurgeMgrForCommittingPvtDataOfOldBlocks(t *testing.T, dbEnv privacyenab
 	testHelper.checkPvtdataDoesNotExist("ns1", "coll1", "pvtkey2")
 }
 
+func TestPurgeMgrDeleteCommittedBefore(t *testing.T) {
+	dbEnvs := []privacyenabledstate.TestEnv{
+		&privacyenabledstate.LevelDBCommonStorageTestEnv{},
+		&privacyenabledstate.CouchDBCommonStorageTestEnv{},
+	}
+	for _, dbEnv := range dbEnvs {
+		t.Run(dbEnv.GetName(), func(t *testing.T) { testPurgeMgrDeleteCommittedBefore(t, dbEnv) })
+	}
+}
+
+func testPurgeMgrDeleteCommittedBefore(t *testing.T, dbEnv privacyenabledstate.TestEnv) {
+	ledgerid := "testledger-purge-mgr-delete-committed-before"
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns1", "coll1"}: 10,
+			{"ns1", "coll2"}: 0,
+		},
+	)
+
+	testHelper := &testHelper{}
+	testHelper.init(t, ledgerid, btlPolicy, dbEnv)
+	defer testHelper.cleanup()
+
+	block1Updates := privacyenabledstate.NewUpdateBatch()
+	putPvtAndHashUpdates(t, block1Updates, "ns1", "coll1", "pvtkey1", []byte("pvtvalue1-1"), version.NewHeight(1, 1))
+	putPvtAndHashUpdates(t, block1Updates, "ns1", "coll1", "pvtkey2", []byte("pvtvalue2-1"), version.NewHeight(1, 1))
+	putPvtAndHashUpdates(t, block1Updates, "ns1", "coll2", "pvtkey3", []byte("pvtvalue3-1"), version.NewHeight(1, 1))
+	putHashUpdates(block1Updates, "ns1", "coll2", "pvtkey4", []byte("pvtvalue4-1"), version.NewHeight(1, 1))
+	testHelper.commitUpdatesForTesting(1, block1Updates)
+
+	block2Updates := privacyenabledstate.NewUpdateBatch()
+	putPvtAndHashUpdates(t, block2Updates, "ns1", "coll1", "pvtkey2", []byte("pvtvalue2-2"), version.NewHeight(2, 1))
+	testHelper.commitUpdatesForTesting(2, block2Updates)
+	testHelper.checkExpiryEntryExistsForBlockNum(12, 1)
+	testHelper.checkExpiryEntryExistsForBlockNum(13, 1)
+
+	purgeUpdates := privacyenabledstate.NewUpdateBatch()
+	assert.NoError(t, testHelper.purgeMgr.DeleteCommittedBefore(2, map[string][]string{"ns1": {"coll1", "coll2"}},
+		purgeUpdates.PvtUpdates))
+	assert.NoError(t, testHelper.db.ApplyPrivacyAwareUpdates(purgeUpdates, nil))
+
+	// only the pvtdata is purged, the hashed data and its expiry schedule are retained
+	testHelper.checkOnlyKeyHashExists("ns1", "coll1", "pvtkey1")
+	testHelper.checkPvtdataExists("ns1", "coll1", "pvtkey2", []byte("pvtvalue2-2"))
+	testHelper.checkOnlyKeyHashExists("ns1", "coll2", "pvtkey3")
+	testHelper.checkOnlyKeyHashExists("ns1", "coll2", "pvtkey4")
+	testHelper.checkExpiryEntryExistsForBlockNum(12, 1)
+	testHelper.checkExpiryEntryExistsForBlockNum(13, 1)
+}
+
+func TestRebuildExpirySchedule(t *testing.T) {
+	dbEnv := &privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	ledgerid := "testledger-purge-mgr-rebuild-expiry-schedule"
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns1", "coll1"}: 1,
+			{"ns1", "coll2"}: 0,
+		},
+	)
+	helper := &testHelper{}
+	helper.init(t, ledgerid, btlPolicy, dbEnv)
+	defer helper.cleanup()
+
+	// load the hashed data directly in the db, the way an import of a snapshot does, bypassing the bookkeeping
+	updates := privacyenabledstate.NewUpdateBatch()
+	putHashUpdates(updates, "ns1", "coll1", "pvtkey1", []byte("pvtvalue1"), version.NewHeight(1, 1))
+	putHashUpdates(updates, "ns1", "coll1", "pvtkey2", []byte("pvtvalue2"), version.NewHeight(2, 1))
+	putHashUpdates(updates, "ns1", "coll2", "pvtkey3", []byte("pvtvalue3"), version.NewHeight(2, 1))
+	assert.NoError(t, helper.db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(2, 1)))
+	helper.checkNoExpiryEntryExistsForBlockNum(3)
+
+	assert.NoError(t, helper.purgeMgr.RebuildExpirySchedule(map[string][]string{"ns1": {"coll1", "coll2"}}))
+	helper.checkExpiryEntryExistsForBlockNum(3, 1)
+	helper.checkExpiryEntryExistsForBlockNum(4, 1)
+
+	helper.commitUpdatesForTesting(3, privacyenabledstate.NewUpdateBatch())
+	helper.checkOnlyKeyHashDoesNotExist("ns1", "coll1", "pvtkey1")
+	helper.checkOnlyKeyHashExists("ns1", "coll1", "pvtkey2")
+	helper.commitUpdatesForTesting(4, privacyenabledstate.NewUpdateBatch())
+	helper.checkOnlyKeyHashDoesNotExist("ns1", "coll1", "pvtkey2")
+	helper.checkOnlyKeyHashExists("ns1", "coll2", "pvtkey3")
+}
+
 func TestKeyUpdateBeforeExpiryBlock(t *testing.T) {
 	dbEnv := &privacyenabledstate.LevelDBCommonStorageTestEnv{}
 	ledgerid := "testledger-perge-mgr"
@@ -347,6 +430,12 @@ func (h *testHelper) checkOnlyKeyHashExists(ns, coll, key string) {
 	assert.NotNil(h.t, hashVersion)
 }
 
+func (h *testHelper) checkOnlyKeyHashDoesNotExist(ns, coll, key string) {
+	hashVersion, err := h.db.GetKeyHashVersion(ns, coll, util.ComputeStringHash(key))
+	assert.NoError(h.t, err)
+	assert.Nil(h.t, hashVersion)
+}
+
 func (h *testHelper) fetchPvtdataFronDB(ns, coll, key string) (kv *statedb.VersionedValue, hashVersion *version.Height) {
 	var err error
 	kv, err = h.db.GetPrivateData(ns, coll, key)
@@ -0,0 +1,187 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+var (
+	cacheHitsOpts = metrics.CounterOpts{
+		Namespace:    "ledger",
+		Subsystem:    "statedb_cache",
+		Name:         "hits",
+		Help:         "Number of state reads served by the state cache.",
+		LabelNames:   []string{"channel", "namespace"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{namespace}",
+	}
+
+	cacheMissesOpts = metrics.CounterOpts{
+		Namespace:    "ledger",
+		Subsystem:    "statedb_cache",
+		Name:         "misses",
+		Help:         "Number of state reads not served by the state cache.",
+		LabelNames:   []string{"channel", "namespace"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{namespace}",
+	}
+)
+
+// CacheMetrics holds the metrics shared by the caches of all the channels
+type CacheMetrics struct {
+	hits   metrics.Counter
+	misses metrics.Counter
+}
+
+// NewCacheMetrics creates the metrics of the state caches
+func NewCacheMetrics(metricsProvider metrics.Provider) *CacheMetrics {
+	return &CacheMetrics{
+		hits:   metricsProvider.NewCounter(cacheHitsOpts),
+		misses: metricsProvider.NewCounter(cacheMissesOpts),
+	}
+}
+
+// Cache is a read-through cache of the committed state of a channel. Each namespace
+// has its own LRU list, bounded to the size of the cache. The absence of a key is cached
+// as well, as a nil value.
+// The cache is kept consistent by calling UpdateCache with every batch applied to the
+// state db, after the batch is applied and before any new read is served.
+// As some batches (e.g., the pvt data of old blocks) are applied while reads are served,
+// a value read from the state db is cached only if no batch was applied since the read started
+type Cache struct {
+	channel    string
+	size       int
+	metrics    *CacheMetrics
+	lock       sync.Mutex
+	namespaces map[string]*nsCache
+	generation uint64
+}
+
+type nsCache struct {
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type cacheEntry struct {
+	key string
+	vv  *VersionedValue
+}
+
+// NewCache creates a cache for the given channel that holds up to size entries per namespace
+func NewCache(channel string, size int, metrics *CacheMetrics) *Cache {
+	return &Cache{
+		channel:    channel,
+		size:       size,
+		metrics:    metrics,
+		namespaces: make(map[string]*nsCache),
+	}
+}
+
+// GetState returns the cached value for the given namespace and key, and whether it was cached.
+// A nil value with true means that the key is known not to exist
+func (c *Cache) GetState(namespace, key string) (*VersionedValue, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	var elem *list.Element
+	if nsc, ok := c.namespaces[namespace]; ok {
+		elem = nsc.entries[key]
+		if elem != nil {
+			nsc.lru.MoveToFront(elem)
+		}
+	}
+	if elem == nil {
+		c.metrics.misses.With("channel", c.channel, "namespace", namespace).Add(1)
+		return nil, false
+	}
+	c.metrics.hits.With("channel", c.channel, "namespace", namespace).Add(1)
+	return copyVersionedValue(elem.Value.(*cacheEntry).vv), true
+}
+
+// Generation returns the number of batches applied to the cache so far. It is to be retrieved
+// before reading from the state db and passed to PutState along with the value read
+func (c *Cache) Generation() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.generation
+}
+
+// PutState caches the value read from the state db for the given namespace and key,
+// evicting the least recently used entry of the namespace if the namespace is full.
+// The value is discarded if a batch was applied since the given generation, as it may be stale
+func (c *Cache) PutState(namespace, key string, vv *VersionedValue, generation uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if generation != c.generation {
+		return
+	}
+	nsc, ok := c.namespaces[namespace]
+	if !ok {
+		nsc = &nsCache{entries: make(map[string]*list.Element), lru: list.New()}
+		c.namespaces[namespace] = nsc
+	}
+	if elem, ok := nsc.entries[key]; ok {
+		elem.Value.(*cacheEntry).vv = copyVersionedValue(vv)
+		nsc.lru.MoveToFront(elem)
+		return
+	}
+	nsc.entries[key] = nsc.lru.PushFront(&cacheEntry{key: key, vv: copyVersionedValue(vv)})
+	if nsc.lru.Len() > c.size {
+		oldest := nsc.lru.Back()
+		nsc.lru.Remove(oldest)
+		delete(nsc.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// UpdateCache replaces the cached values of the keys updated by the batch.
+// Keys that are not cached are left out, so that a commit does not evict the entries being read
+func (c *Cache) UpdateCache(batch *UpdateBatch) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.generation++
+	for _, namespace := range batch.GetUpdatedNamespaces() {
+		nsc, ok := c.namespaces[namespace]
+		if !ok {
+			continue
+		}
+		for key, vv := range batch.GetUpdates(namespace) {
+			elem, ok := nsc.entries[key]
+			if !ok {
+				continue
+			}
+			if vv.IsDelete() {
+				elem.Value.(*cacheEntry).vv = nil
+			} else {
+				elem.Value.(*cacheEntry).vv = copyVersionedValue(vv)
+			}
+		}
+	}
+}
+
+// Clear removes all the entries of the given namespaces from the cache
+func (c *Cache) Clear(namespaces []string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.generation++
+	for _, namespace := range namespaces {
+		delete(c.namespaces, namespace)
+	}
+}
+
+// copyVersionedValue returns a copy of vv, so that the entries of the cache
+// are not affected by the changes the callers make to the values they read
+func copyVersionedValue(vv *VersionedValue) *VersionedValue {
+	if vv == nil {
+		return nil
+	}
+	return &VersionedValue{
+		Value:    append([]byte(nil), vv.Value...),
+		Metadata: append([]byte(nil), vv.Metadata...),
+		Version:  vv.Version,
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheGetPut(t *testing.T) {
+	cache := NewCache("testchannel", 10, NewCacheMetrics(&disabled.Provider{}))
+
+	vv, ok := cache.GetState("ns1", "key1")
+	assert.False(t, ok)
+	assert.Nil(t, vv)
+
+	cache.PutState("ns1", "key1", &VersionedValue{Value: []byte("value1"), Metadata: []byte("md1"), Version: version.NewHeight(1, 1)}, 0)
+	cache.PutState("ns1", "key2", nil, 0)
+
+	vv, ok = cache.GetState("ns1", "key1")
+	assert.True(t, ok)
+	assert.Equal(t, &VersionedValue{Value: []byte("value1"), Metadata: []byte("md1"), Version: version.NewHeight(1, 1)}, vv)
+
+	// a nil value records that the key does not exist
+	vv, ok = cache.GetState("ns1", "key2")
+	assert.True(t, ok)
+	assert.Nil(t, vv)
+
+	// namespaces are kept apart
+	_, ok = cache.GetState("ns2", "key1")
+	assert.False(t, ok)
+
+	// changes to the returned values do not affect the cache
+	vv, _ = cache.GetState("ns1", "key1")
+	vv.Value[0] = 'x'
+	vv, _ = cache.GetState("ns1", "key1")
+	assert.Equal(t, []byte("value1"), vv.Value)
+}
+
+func TestCacheEviction(t *testing.T) {
+	cache := NewCache("testchannel", 2, NewCacheMetrics(&disabled.Provider{}))
+	cache.PutState("ns1", "key1", &VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, 0)
+	cache.PutState("ns1", "key2", &VersionedValue{Value: []byte("value2"), Version: version.NewHeight(1, 2)}, 0)
+	cache.PutState("ns2", "key1", &VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 3)}, 0)
+
+	// reading key1 makes key2 the least recently used key of ns1
+	_, ok := cache.GetState("ns1", "key1")
+	assert.True(t, ok)
+	cache.PutState("ns1", "key3", &VersionedValue{Value: []byte("value3"), Version: version.NewHeight(1, 4)}, 0)
+
+	_, ok = cache.GetState("ns1", "key2")
+	assert.False(t, ok)
+	_, ok = cache.GetState("ns1", "key1")
+	assert.True(t, ok)
+	_, ok = cache.GetState("ns1", "key3")
+	assert.True(t, ok)
+	// the size is bounded per namespace
+	_, ok = cache.GetState("ns2", "key1")
+	assert.True(t, ok)
+}
+
+func TestCacheUpdate(t *testing.T) {
+	cache := NewCache("testchannel", 10, NewCacheMetrics(&disabled.Provider{}))
+	cache.PutState("ns1", "key1", &VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, 0)
+	cache.PutState("ns1", "key2", &VersionedValue{Value: []byte("value2"), Version: version.NewHeight(1, 2)}, 0)
+	cache.PutState("ns1", "key3", nil, 0)
+
+	batch := NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1-new"), version.NewHeight(2, 1))
+	batch.Delete("ns1", "key2", version.NewHeight(2, 2))
+	batch.Put("ns1", "key3", []byte("value3"), version.NewHeight(2, 3))
+	batch.Put("ns1", "key4", []byte("value4"), version.NewHeight(2, 4))
+	batch.Put("ns2", "key1", []byte("value1"), version.NewHeight(2, 5))
+	cache.UpdateCache(batch)
+
+	vv, ok := cache.GetState("ns1", "key1")
+	assert.True(t, ok)
+	assert.Equal(t, &VersionedValue{Value: []byte("value1-new"), Version: version.NewHeight(2, 1)}, vv)
+	vv, ok = cache.GetState("ns1", "key2")
+	assert.True(t, ok)
+	assert.Nil(t, vv)
+	vv, ok = cache.GetState("ns1", "key3")
+	assert.True(t, ok)
+	assert.Equal(t, &VersionedValue{Value: []byte("value3"), Version: version.NewHeight(2, 3)}, vv)
+	// keys that were not cached are not added by a commit
+	_, ok = cache.GetState("ns1", "key4")
+	assert.False(t, ok)
+	_, ok = cache.GetState("ns2", "key1")
+	assert.False(t, ok)
+
+	cache.Clear([]string{"ns1"})
+	_, ok = cache.GetState("ns1", "key1")
+	assert.False(t, ok)
+}
+
+func TestCacheStaleRead(t *testing.T) {
+	cache := NewCache("testchannel", 10, NewCacheMetrics(&disabled.Provider{}))
+
+	// a value read from the state db before a batch is applied is not cached afterwards
+	generation := cache.Generation()
+	batch := NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(2, 1))
+	cache.UpdateCache(batch)
+	cache.PutState("ns1", "key1", nil, generation)
+	_, ok := cache.GetState("ns1", "key1")
+	assert.False(t, ok)
+
+	cache.PutState("ns1", "key1", &VersionedValue{Value: []byte("value1"), Version: version.NewHeight(2, 1)}, cache.Generation())
+	vv, ok := cache.GetState("ns1", "key1")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("value1"), vv.Value)
+}
+
+func TestCacheMetrics(t *testing.T) {
+	fakeProvider := &metricsfakes.Provider{}
+	fakeHits := &metricsfakes.Counter{}
+	fakeHits.WithReturns(fakeHits)
+	fakeMisses := &metricsfakes.Counter{}
+	fakeMisses.WithReturns(fakeMisses)
+	fakeProvider.NewCounterStub = func(opts metrics.CounterOpts) metrics.Counter {
+		switch opts.Name {
+		case "hits":
+			return fakeHits
+		case "misses":
+			return fakeMisses
+		}
+		return nil
+	}
+
+	cache := NewCache("testchannel", 10, NewCacheMetrics(fakeProvider))
+	cache.GetState("ns1", "key1")
+	cache.PutState("ns1", "key1", nil, 0)
+	cache.GetState("ns1", "key1")
+
+	assert.Equal(t, 1, fakeMisses.AddCallCount())
+	assert.Equal(t, []string{"channel", "testchannel", "namespace", "ns1"}, fakeMisses.WithArgsForCall(0))
+	assert.Equal(t, float64(1), fakeMisses.AddArgsForCall(0))
+	assert.Equal(t, 1, fakeHits.AddCallCount())
+	assert.Equal(t, []string{"channel", "testchannel", "namespace", "ns1"}, fakeHits.WithArgsForCall(0))
+	assert.Equal(t, float64(1), fakeHits.AddArgsForCall(0))
+}
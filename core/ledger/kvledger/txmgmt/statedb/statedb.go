@@ -88,6 +88,15 @@ type IndexCapable interface {
 	ProcessIndexesForChaincodeDeploy(namespace string, fileEntries []*ccprovider.TarFileEntry) error
 }
 
+//FullScanIterable interface provides additional functions for
+//databases capable of iterating over all the keys across the namespaces
+type FullScanIterable interface {
+	// GetFullScanIterator returns an iterator over all the keys in the db, ordered by namespace and
+	// then by key. The keys of the namespaces for which the function skipNamespace returns true are
+	// not included. The returned ResultsIterator contains results of type *VersionedKV
+	GetFullScanIterator(skipNamespace func(string) bool) (ResultsIterator, error)
+}
+
 // CompositeKey encloses Namespace and Key components
 type CompositeKey struct {
 	Namespace string
@@ -0,0 +1,379 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
+)
+
+// This file implements secondary indexes over the JSON values of a namespace. The indexes are
+// created from the CouchDB index definitions packaged with the chaincodes (META-INF/statedb/couchdb/indexes)
+// and are maintained in the same leveldb batch as the state updates.
+//
+// The index definitions and entries are stored in the db of the channel under keys that start with
+// the byte 0x01, which no namespace starts with:
+//   index definition: 0x01 'd' <namespace> 0x00 <index name>
+//   index entry:      0x01 'i' <namespace> 0x00 <index name> 0x00 <value1> 0x00 ... <valueN> 0x00 <key>
+// where each value is the encoding of a field of the index (see encodeIndexValue)
+
+var (
+	indexKeysStart   = byte(0x01)
+	indexDefPrefix   = []byte{indexKeysStart, 'd'}
+	indexEntryPrefix = []byte{indexKeysStart, 'i'}
+	indexEntryValue  = []byte{}
+)
+
+// indexDefinition is the definition of an index persisted in the db
+type indexDefinition struct {
+	Name   string   `json:"name"`
+	Ddoc   string   `json:"ddoc,omitempty"`
+	Fields []string `json:"fields"`
+}
+
+// couchIndexDefinition is the format of the index definitions packaged with the chaincodes, e.g.
+// {"index":{"fields":["docType","owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}
+type couchIndexDefinition struct {
+	Index struct {
+		Fields []interface{} `json:"fields"`
+	} `json:"index"`
+	Ddoc string `json:"ddoc"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func parseIndexDefinition(content []byte) (*indexDefinition, error) {
+	couchDef := &couchIndexDefinition{}
+	if err := json.Unmarshal(content, couchDef); err != nil {
+		return nil, errors.Wrap(err, "invalid index definition")
+	}
+	if couchDef.Type != "" && couchDef.Type != "json" {
+		return nil, errors.Errorf("unsupported index type %s", couchDef.Type)
+	}
+	def := &indexDefinition{Name: couchDef.Name, Ddoc: couchDef.Ddoc}
+	if def.Name == "" {
+		def.Name = def.Ddoc
+	}
+	if def.Name == "" {
+		return nil, errors.New("index definition must have a name or a ddoc")
+	}
+	for _, field := range couchDef.Index.Fields {
+		switch f := field.(type) {
+		case string:
+			def.Fields = append(def.Fields, f)
+		case map[string]interface{}:
+			// {"field":"asc"}; all the indexes are scanned in ascending order
+			if len(f) != 1 {
+				return nil, errors.New("each index field must specify exactly one field")
+			}
+			for name := range f {
+				def.Fields = append(def.Fields, name)
+			}
+		default:
+			return nil, errors.New("index fields must be field names or objects")
+		}
+	}
+	if len(def.Fields) == 0 {
+		return nil, errors.Errorf("index %s does not define any field", def.Name)
+	}
+	return def, nil
+}
+
+func (def *indexDefinition) sameAs(other *indexDefinition) bool {
+	if len(def.Fields) != len(other.Fields) {
+		return false
+	}
+	for i := range def.Fields {
+		if def.Fields[i] != other.Fields[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func constructIndexDefKey(ns, indexName string) []byte {
+	k := append([]byte{}, indexDefPrefix...)
+	k = append(k, ns...)
+	k = append(k, 0x00)
+	return append(k, indexName...)
+}
+
+func constructIndexPrefix(ns, indexName string) []byte {
+	k := append([]byte{}, indexEntryPrefix...)
+	k = append(k, ns...)
+	k = append(k, 0x00)
+	k = append(k, indexName...)
+	return append(k, 0x00)
+}
+
+// encodeIndexValue encodes a JSON value for an index entry. Numbers are normalized so that,
+// for instance, 1 and 1.0 have the same encoding. The encoding never contains the byte 0x00
+func encodeIndexValue(v interface{}) ([]byte, bool) {
+	if f, ok := toFloat(v); ok {
+		return []byte("n" + strconv.FormatFloat(f, 'g', -1, 64)), true
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, false
+	}
+	return encoded, true
+}
+
+// indexEntryKey returns the key of the entry of the index for a document. A document that
+// does not contain all the fields of the index is not indexed and nil is returned
+func (def *indexDefinition) indexEntryKey(ns, key string, doc interface{}) []byte {
+	entryKey := constructIndexPrefix(ns, def.Name)
+	for _, field := range def.Fields {
+		value, found := getField(doc, splitFieldPath(field))
+		if !found {
+			return nil
+		}
+		encoded, ok := encodeIndexValue(value)
+		if !ok {
+			return nil
+		}
+		entryKey = append(entryKey, encoded...)
+		entryKey = append(entryKey, 0x00)
+	}
+	return append(entryKey, key...)
+}
+
+// loadIndexDefinitions reads the definitions of the indexes from the db
+func loadIndexDefinitions(db *leveldbhelper.DBHandle) (map[string][]*indexDefinition, error) {
+	defs := make(map[string][]*indexDefinition)
+	itr := db.GetIterator(indexDefPrefix, []byte{indexKeysStart, 'd' + 1})
+	defer itr.Release()
+	for itr.Next() {
+		ns := string(bytes.SplitN(itr.Key()[len(indexDefPrefix):], []byte{0x00}, 2)[0])
+		def := &indexDefinition{}
+		if err := json.Unmarshal(itr.Value(), def); err != nil {
+			return nil, errors.Wrapf(err, "error unmarshaling index definition for namespace %s", ns)
+		}
+		defs[ns] = append(defs[ns], def)
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "error reading index definitions")
+	}
+	return defs, nil
+}
+
+// GetDBType implements method in interface statedb.IndexCapable. The leveldb state evaluates the same queries as
+// CouchDB and, hence, builds its indexes from the CouchDB index definitions packaged with the chaincodes
+func (vdb *versionedDB) GetDBType() string {
+	return "couchdb"
+}
+
+// ProcessIndexesForChaincodeDeploy implements method in interface statedb.IndexCapable. It creates
+// the indexes defined in the given files, indexing the existing state of the namespace
+func (vdb *versionedDB) ProcessIndexesForChaincodeDeploy(namespace string, fileEntries []*ccprovider.TarFileEntry) error {
+	vdb.indexLock.Lock()
+	defer vdb.indexLock.Unlock()
+	for _, fileEntry := range fileEntries {
+		def, err := parseIndexDefinition(fileEntry.FileContent)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf(
+				"error creating index from file [%s] for namespace [%s]", fileEntry.FileHeader.Name, namespace))
+		}
+		if err := vdb.createIndex(namespace, def); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (vdb *versionedDB) createIndex(ns string, def *indexDefinition) error {
+	existingDefs := vdb.indexes[ns]
+	position := len(existingDefs)
+	for i, existing := range existingDefs {
+		if existing.Name == def.Name {
+			if existing.sameAs(def) {
+				logger.Debugf("Channel [%s]: index [%s] already exists for namespace [%s]", vdb.dbName, def.Name, ns)
+				return nil
+			}
+			position = i
+		}
+	}
+	logger.Infof("Channel [%s]: building index [%s] on fields %s for namespace [%s]", vdb.dbName, def.Name, def.Fields, ns)
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	// remove the entries of a previous definition with the same name
+	indexPrefix := constructIndexPrefix(ns, def.Name)
+	itr := vdb.db.GetIterator(indexPrefix, prefixEnd(indexPrefix))
+	for itr.Next() {
+		dbBatch.Delete(append([]byte{}, itr.Key()...))
+	}
+	err := itr.Error()
+	itr.Release()
+	if err != nil {
+		return errors.Wrapf(err, "error reading index [%s]", def.Name)
+	}
+
+	stateItr, err := vdb.GetStateRangeScanIterator(ns, "", "")
+	if err != nil {
+		return err
+	}
+	defer stateItr.Close()
+	for {
+		res, err := stateItr.Next()
+		if err != nil {
+			return err
+		}
+		if res == nil {
+			break
+		}
+		kv := res.(*statedb.VersionedKV)
+		doc, ok := decodeJSONDoc(kv.Key, kv.Value)
+		if !ok {
+			continue
+		}
+		if entryKey := def.indexEntryKey(ns, kv.Key, doc); entryKey != nil {
+			dbBatch.Put(entryKey, indexEntryValue)
+		}
+	}
+	defBytes, err := json.Marshal(def)
+	if err != nil {
+		return errors.Wrap(err, "error marshaling index definition")
+	}
+	dbBatch.Put(constructIndexDefKey(ns, def.Name), defBytes)
+	if err := vdb.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+	if position == len(existingDefs) {
+		vdb.indexes[ns] = append(existingDefs, def)
+	} else {
+		existingDefs[position] = def
+	}
+	return nil
+}
+
+// addIndexUpdates adds to the batch the changes to the indexes of the namespace for the update of a key.
+// The caller holds the indexLock
+func (vdb *versionedDB) addIndexUpdates(dbBatch *leveldbhelper.UpdateBatch, ns, key string, oldValue, newValue []byte) {
+	defs := vdb.indexes[ns]
+	if len(defs) == 0 {
+		return
+	}
+	oldDoc, oldOK := decodeJSONDoc(key, oldValue)
+	newDoc, newOK := decodeJSONDoc(key, newValue)
+	for _, def := range defs {
+		// the entries of the old value are deleted first, as the new value may have the same entries
+		if oldOK {
+			if entryKey := def.indexEntryKey(ns, key, oldDoc); entryKey != nil {
+				dbBatch.Delete(entryKey)
+			}
+		}
+		if newOK {
+			if entryKey := def.indexEntryKey(ns, key, newDoc); entryKey != nil {
+				dbBatch.Put(entryKey, indexEntryValue)
+			}
+		}
+	}
+}
+
+// selectIndex returns an index that can be used to retrieve all the documents that satisfy
+// the selector of the query, along with the prefix of the entries to scan. An index can be used
+// if the selector constrains (at the top level) all the fields of the index and, at least, the first
+// field with an equality, because the documents that do not contain all the fields are not indexed.
+// The index named in use_index is preferred, otherwise the index with the longest prefix is selected
+func (vdb *versionedDB) selectIndex(ns string, q *query) (*indexDefinition, []byte) {
+	vdb.indexLock.RLock()
+	defer vdb.indexLock.RUnlock()
+	var selected *indexDefinition
+	var selectedPrefix []byte
+	selectedEqualities := 0
+	for _, def := range vdb.indexes[ns] {
+		prefix, equalities := indexScanPrefix(ns, def, q.selector)
+		if equalities == 0 {
+			continue
+		}
+		if q.useIndex != "" && (q.useIndex == def.Name || q.useIndex == def.Ddoc) {
+			return def, prefix
+		}
+		if equalities > selectedEqualities {
+			selected, selectedPrefix, selectedEqualities = def, prefix, equalities
+		}
+	}
+	return selected, selectedPrefix
+}
+
+// indexScanPrefix returns the prefix of the index entries that may satisfy the selector and the
+// number of fields with an equality it is made of. Zero is returned if the index cannot be used
+func indexScanPrefix(ns string, def *indexDefinition, selector map[string]interface{}) ([]byte, int) {
+	prefix := constructIndexPrefix(ns, def.Name)
+	equalities := 0
+	narrowing := true
+	for _, field := range def.Fields {
+		condition, ok := selector[field]
+		if !ok || isNotExistsCondition(condition) {
+			return nil, 0
+		}
+		if !narrowing {
+			continue
+		}
+		value, isEquality := equalityValue(condition)
+		if !isEquality {
+			narrowing = false
+			continue
+		}
+		encoded, ok := encodeIndexValue(value)
+		if !ok {
+			return nil, 0
+		}
+		prefix = append(append(prefix, encoded...), 0x00)
+		equalities++
+	}
+	return prefix, equalities
+}
+
+// equalityValue returns the value of an equality condition, i.e., a literal or {"$eq": value}
+func equalityValue(condition interface{}) (interface{}, bool) {
+	c, ok := condition.(map[string]interface{})
+	if !ok {
+		return condition, true
+	}
+	if len(c) == 0 {
+		return c, true
+	}
+	if v, ok := c["$eq"]; ok && len(c) == 1 {
+		return v, true
+	}
+	return nil, false
+}
+
+// indexedKeys returns, in ascending order, the keys of the index entries that start with the given prefix
+func (vdb *versionedDB) indexedKeys(ns string, def *indexDefinition, prefix []byte) ([]string, error) {
+	itr := vdb.db.GetIterator(prefix, prefixEnd(prefix))
+	defer itr.Release()
+	valuesStart := len(constructIndexPrefix(ns, def.Name))
+	var keys []string
+	for itr.Next() {
+		// the key follows the encoded values of the fields, which do not contain 0x00
+		parts := bytes.SplitN(itr.Key()[valuesStart:], []byte{0x00}, len(def.Fields)+1)
+		if len(parts) != len(def.Fields)+1 {
+			return nil, errors.Errorf("corrupted entry of index [%s]", def.Name)
+		}
+		keys = append(keys, string(parts[len(def.Fields)]))
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrapf(err, "error reading index [%s]", def.Name)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// prefixEnd returns the end of the range of the keys that start with the given prefix, which ends with 0x00
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	end[len(end)-1] = 0x01
+	return end
+}
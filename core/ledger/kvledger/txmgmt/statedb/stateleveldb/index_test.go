@@ -0,0 +1,144 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"archive/tar"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+)
+
+func indexFileEntry(name, content string) *ccprovider.TarFileEntry {
+	return &ccprovider.TarFileEntry{
+		FileHeader:  &tar.Header{Name: "META-INF/statedb/couchdb/indexes/" + name},
+		FileContent: []byte(content),
+	}
+}
+
+func TestParseIndexDefinition(t *testing.T) {
+	def, err := parseIndexDefinition([]byte(`{"index":{"fields":["docType",{"owner":"asc"}]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &indexDefinition{Name: "indexOwner", Ddoc: "indexOwnerDoc", Fields: []string{"docType", "owner"}}, def)
+
+	def, err = parseIndexDefinition([]byte(`{"index":{"fields":["size"]},"ddoc":"indexSizeDoc"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &indexDefinition{Name: "indexSizeDoc", Ddoc: "indexSizeDoc", Fields: []string{"size"}}, def)
+
+	_, err = parseIndexDefinition([]byte(`{"index":{"fields":["size"]}}`))
+	assert.EqualError(t, err, "index definition must have a name or a ddoc")
+	_, err = parseIndexDefinition([]byte(`{"index":{"fields":[]},"name":"empty"}`))
+	assert.EqualError(t, err, "index empty does not define any field")
+	_, err = parseIndexDefinition([]byte(`{"index":{"fields":["size"]},"name":"text","type":"text"}`))
+	assert.EqualError(t, err, "unsupported index type text")
+	_, err = parseIndexDefinition([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestIndexes(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testindexes")
+	assert.NoError(t, err)
+	populateMarbles(t, db, 10)
+	vdb := db.(*versionedDB)
+	assert.Equal(t, "couchdb", vdb.GetDBType())
+
+	err = vdb.ProcessIndexesForChaincodeDeploy("ns1", []*ccprovider.TarFileEntry{
+		indexFileEntry("indexColor.json", `{"index":{"fields":["color","size"]},"ddoc":"indexColorDoc","name":"indexColor","type":"json"}`),
+		indexFileEntry("indexOwner.json", `{"index":{"fields":["owner"]},"name":"indexOwner"}`),
+	})
+	assert.NoError(t, err)
+	err = vdb.ProcessIndexesForChaincodeDeploy("ns1", []*ccprovider.TarFileEntry{indexFileEntry("bad.json", `{"index":{}}`)})
+	assert.EqualError(t, err, "error creating index from file [META-INF/statedb/couchdb/indexes/bad.json] for namespace [ns1]: index definition must have a name or a ddoc")
+
+	t.Run("selection", func(t *testing.T) {
+		testCases := []struct {
+			query         string
+			expectedIndex string
+		}{
+			{`{"selector":{"color":"blue","size":{"$gt":2}}}`, "indexColor"},
+			{`{"selector":{"color":"blue"}}`, ""},
+			{`{"selector":{"size":2,"color":{"$gt":"a"}}}`, ""},
+			{`{"selector":{"color":"blue","size":{"$exists":false}}}`, ""},
+			{`{"selector":{"owner":"owner2","color":"blue","size":2}}`, "indexColor"},
+			{`{"selector":{"owner":"owner2","color":"blue","size":2},"use_index":["_design/indexOwnerDoc","indexOwner"]}`, "indexOwner"},
+			{`{"selector":{"$or":[{"owner":"owner2"},{"owner":"owner3"}]}}`, ""},
+		}
+		for _, tc := range testCases {
+			q, err := parseQuery(tc.query)
+			assert.NoError(t, err)
+			def, _ := vdb.selectIndex("ns1", q)
+			if tc.expectedIndex == "" {
+				assert.Nil(t, def, tc.query)
+			} else if assert.NotNil(t, def, tc.query) {
+				assert.Equal(t, tc.expectedIndex, def.Name, tc.query)
+			}
+		}
+	})
+
+	assertQuery := func(query string, expectedKeys ...string) {
+		itr, err := db.ExecuteQuery("ns1", query)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedKeys, queryKeys(t, itr), query)
+	}
+	assertQuery(`{"selector":{"color":"blue","size":{"$gt":2}}}`, "key4", "key6", "key8")
+	assertQuery(`{"selector":{"color":"red","size":{"$gt":2}},"sort":[{"size":"desc"}],"limit":2}`, "key9", "key7")
+	assertQuery(`{"selector":{"owner":"owner5"}}`, "key5")
+
+	// the indexes are maintained on commit
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key4", []byte(`{"owner":"owner5","color":"red","size":4}`), version.NewHeight(2, 1))
+	batch.Delete("ns1", "key6", version.NewHeight(2, 2))
+	batch.Put("ns1", "key8", []byte(`{"owner":"owner8","color":"blue"}`), version.NewHeight(2, 3))
+	batch.Put("ns1", "key10", []byte(`{"owner":"owner10","color":"blue","size":10.0}`), version.NewHeight(2, 4))
+	batch.Put("ns2", "key1", []byte(`{"owner":"owner5","color":"blue","size":10}`), version.NewHeight(2, 5))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 5)))
+	assertQuery(`{"selector":{"color":"blue","size":{"$gt":2}}}`, "key10")
+	assertQuery(`{"selector":{"color":"red","size":4}}`, "key4")
+	assertQuery(`{"selector":{"owner":"owner5"}}`, "key4", "key5")
+	assertQuery(`{"selector":{"color":"blue","size":10}}`, "key10")
+
+	// the definitions survive a restart and a redefinition rebuilds the index
+	env.DBProvider.Close()
+	env.DBProvider = NewVersionedDBProvider()
+	db, err = env.DBProvider.GetDBHandle("testindexes")
+	assert.NoError(t, err)
+	vdb = db.(*versionedDB)
+	assert.Len(t, vdb.indexes["ns1"], 2)
+	assertQuery(`{"selector":{"owner":"owner5"}}`, "key4", "key5")
+	err = vdb.ProcessIndexesForChaincodeDeploy("ns1", []*ccprovider.TarFileEntry{
+		indexFileEntry("indexOwner.json", `{"index":{"fields":["owner","color"]},"name":"indexOwner"}`),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, vdb.indexes["ns1"], 2)
+	q, err := parseQuery(`{"selector":{"owner":"owner5","color":"red"}}`)
+	assert.NoError(t, err)
+	def, _ := vdb.selectIndex("ns1", q)
+	assert.Equal(t, []string{"owner", "color"}, def.Fields)
+	assertQuery(`{"selector":{"owner":"owner5","color":"red"}}`, "key4", "key5")
+	assertQuery(`{"selector":{"owner":"owner5","color":"blue"}}`)
+
+	// the index entries are not visible to the full scan of the db
+	itr, err := vdb.GetFullScanIterator(func(string) bool { return false })
+	assert.NoError(t, err)
+	defer itr.Close()
+	count := 0
+	for {
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		if res == nil {
+			break
+		}
+		assert.NotEqual(t, indexKeysStart, res.(*statedb.VersionedKV).Namespace[0])
+		count++
+	}
+	// key0...key10 except key6, the binary value in ns1 and key1 in ns2
+	assert.Equal(t, 12, count)
+}
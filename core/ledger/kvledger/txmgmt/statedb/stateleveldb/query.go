@@ -0,0 +1,719 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// This file implements the subset of the CouchDB Mango query language that is evaluated
+// by scanning the state of a namespace (or the keys returned by an index, see index.go)
+
+const idField = "_id"
+
+// query is a parsed Mango query
+type query struct {
+	selector   map[string]interface{}
+	match      docMatcher
+	fields     [][]string
+	sortFields []*sortField
+	limit      int
+	skip       int
+	useIndex   string
+}
+
+type sortField struct {
+	path []string
+	desc bool
+}
+
+// docMatcher returns true if the given JSON document satisfies a selector
+type docMatcher func(doc interface{}) bool
+
+// valueMatcher returns true if the given value of a field satisfies a condition.
+// found is false if the document does not contain the field
+type valueMatcher func(value interface{}, found bool) bool
+
+// parseQuery parses a query string such as
+// {"selector":{"owner":"tom","size":{"$gt":5}},"fields":["owner","size"],"sort":[{"size":"desc"}],"limit":10}
+func parseQuery(queryString string) (*query, error) {
+	jsonQuery, err := decodeJSON([]byte(queryString))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid query string")
+	}
+	queryMap, ok := jsonQuery.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid query string: query must be a JSON object")
+	}
+	q := &query{}
+	for option, value := range queryMap {
+		switch option {
+		case "selector":
+			selector, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("selector must be a JSON object")
+			}
+			if q.match, err = compileSelector(selector); err != nil {
+				return nil, err
+			}
+			q.selector = selector
+		case "fields":
+			fields, ok := value.([]interface{})
+			if !ok {
+				return nil, errors.New("fields definition must be an array")
+			}
+			for _, field := range fields {
+				fieldName, ok := field.(string)
+				if !ok {
+					return nil, errors.New("fields definition must be an array of strings")
+				}
+				q.fields = append(q.fields, splitFieldPath(fieldName))
+			}
+		case "sort":
+			if q.sortFields, err = parseSort(value); err != nil {
+				return nil, err
+			}
+		case "limit":
+			if q.limit, err = toNonNegativeInt(value); err != nil {
+				return nil, errors.WithMessage(err, "invalid limit")
+			}
+		case "skip":
+			if q.skip, err = toNonNegativeInt(value); err != nil {
+				return nil, errors.WithMessage(err, "invalid skip")
+			}
+		case "use_index":
+			if q.useIndex, err = parseUseIndex(value); err != nil {
+				return nil, err
+			}
+		case "bookmark", "execution_stats", "r", "conflicts", "update", "stable", "stale":
+			// bookmarks are passed through the query metadata, the other options are CouchDB specific
+		default:
+			return nil, errors.Errorf("invalid query string: option %s not recognized", option)
+		}
+	}
+	if q.match == nil {
+		return nil, errors.New("invalid query string: selector is required")
+	}
+	return q, nil
+}
+
+func parseSort(value interface{}) ([]*sortField, error) {
+	sortArray, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.New("sort definition must be an array")
+	}
+	var sortFields []*sortField
+	for _, entry := range sortArray {
+		switch e := entry.(type) {
+		case string:
+			sortFields = append(sortFields, &sortField{path: splitFieldPath(e)})
+		case map[string]interface{}:
+			if len(e) != 1 {
+				return nil, errors.New("each sort entry must specify exactly one field")
+			}
+			for field, direction := range e {
+				switch direction {
+				case "asc":
+					sortFields = append(sortFields, &sortField{path: splitFieldPath(field)})
+				case "desc":
+					sortFields = append(sortFields, &sortField{path: splitFieldPath(field), desc: true})
+				default:
+					return nil, errors.Errorf("invalid sort direction for field %s: must be asc or desc", field)
+				}
+			}
+		default:
+			return nil, errors.New("sort entries must be field names or objects")
+		}
+	}
+	return sortFields, nil
+}
+
+func parseUseIndex(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimPrefix(v, "_design/"), nil
+	case []interface{}:
+		if len(v) == 0 || len(v) > 2 {
+			return "", errors.New("use_index must be a design document name or a [design document, index name] pair")
+		}
+		name, ok := v[len(v)-1].(string)
+		if !ok {
+			return "", errors.New("use_index must contain strings")
+		}
+		return strings.TrimPrefix(name, "_design/"), nil
+	default:
+		return "", errors.New("use_index must be a string or an array")
+	}
+}
+
+// compileSelector compiles a selector object, in which each entry is either a combination
+// operator ($and, $or, $nor, $not) or a condition on a field. All the entries must be satisfied
+func compileSelector(selector map[string]interface{}) (docMatcher, error) {
+	var matchers []docMatcher
+	for key, value := range selector {
+		var m docMatcher
+		var err error
+		switch key {
+		case "$and", "$or", "$nor":
+			var subMatchers []docMatcher
+			if subMatchers, err = compileSelectorArray(key, value); err != nil {
+				return nil, err
+			}
+			m = combineDocMatchers(key, subMatchers)
+		case "$not":
+			sub, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("$not requires a selector object")
+			}
+			var subMatcher docMatcher
+			if subMatcher, err = compileSelector(sub); err != nil {
+				return nil, err
+			}
+			m = func(doc interface{}) bool { return !subMatcher(doc) }
+		default:
+			if strings.HasPrefix(key, "$") {
+				return nil, errors.Errorf("invalid operator %s", key)
+			}
+			path := splitFieldPath(key)
+			var vm valueMatcher
+			if vm, err = compileFieldCondition(value); err != nil {
+				return nil, err
+			}
+			m = func(doc interface{}) bool {
+				value, found := getField(doc, path)
+				return vm(value, found)
+			}
+		}
+		matchers = append(matchers, m)
+	}
+	return combineDocMatchers("$and", matchers), nil
+}
+
+func compileSelectorArray(operator string, value interface{}) ([]docMatcher, error) {
+	selectors, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.Errorf("%s requires an array of selectors", operator)
+	}
+	var matchers []docMatcher
+	for _, s := range selectors {
+		sub, ok := s.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("%s requires an array of selectors", operator)
+		}
+		m, err := compileSelector(sub)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	return matchers, nil
+}
+
+func combineDocMatchers(operator string, matchers []docMatcher) docMatcher {
+	return func(doc interface{}) bool {
+		switch operator {
+		case "$or":
+			for _, m := range matchers {
+				if m(doc) {
+					return true
+				}
+			}
+			return false
+		case "$nor":
+			for _, m := range matchers {
+				if m(doc) {
+					return false
+				}
+			}
+			return true
+		default:
+			for _, m := range matchers {
+				if !m(doc) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+}
+
+// compileFieldCondition compiles the condition on a field. As in CouchDB, a missing field
+// only satisfies the condition {"$exists": false}
+func compileFieldCondition(condition interface{}) (valueMatcher, error) {
+	vm, err := compileCondition(condition)
+	if err != nil {
+		return nil, err
+	}
+	onlyNotExists := isNotExistsCondition(condition)
+	return func(value interface{}, found bool) bool {
+		if !found {
+			return onlyNotExists
+		}
+		return vm(value, true)
+	}, nil
+}
+
+func isNotExistsCondition(condition interface{}) bool {
+	c, ok := condition.(map[string]interface{})
+	if !ok || len(c) != 1 {
+		return false
+	}
+	exists, ok := c["$exists"].(bool)
+	return ok && !exists
+}
+
+// compileCondition compiles a condition on a value. A condition is either a literal, which
+// is compared for equality, or an object whose entries are operators or conditions on sub fields
+func compileCondition(condition interface{}) (valueMatcher, error) {
+	c, ok := condition.(map[string]interface{})
+	if !ok || len(c) == 0 {
+		return func(value interface{}, found bool) bool {
+			return found && compareJSON(value, condition) == 0
+		}, nil
+	}
+	var matchers []valueMatcher
+	for key, arg := range c {
+		var m valueMatcher
+		var err error
+		if strings.HasPrefix(key, "$") {
+			m, err = compileOperator(key, arg)
+		} else {
+			var dm docMatcher
+			dm, err = compileSelector(map[string]interface{}{key: arg})
+			m = func(value interface{}, found bool) bool { return found && dm(value) }
+		}
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	return func(value interface{}, found bool) bool {
+		for _, m := range matchers {
+			if !m(value, found) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+func compileOperator(operator string, arg interface{}) (valueMatcher, error) {
+	switch operator {
+	case "$eq", "$ne", "$gt", "$gte", "$lt", "$lte":
+		return func(value interface{}, found bool) bool {
+			if !found {
+				return false
+			}
+			c := compareJSON(value, arg)
+			switch operator {
+			case "$eq":
+				return c == 0
+			case "$ne":
+				return c != 0
+			case "$gt":
+				return c > 0
+			case "$gte":
+				return c >= 0
+			case "$lt":
+				return c < 0
+			default:
+				return c <= 0
+			}
+		}, nil
+
+	case "$exists":
+		exists, ok := arg.(bool)
+		if !ok {
+			return nil, errors.New("$exists requires a boolean")
+		}
+		return func(value interface{}, found bool) bool { return found == exists }, nil
+
+	case "$type":
+		typeName, ok := arg.(string)
+		if !ok {
+			return nil, errors.New("$type requires a string")
+		}
+		return func(value interface{}, found bool) bool { return found && jsonTypeName(value) == typeName }, nil
+
+	case "$in", "$nin":
+		args, ok := arg.([]interface{})
+		if !ok {
+			return nil, errors.Errorf("%s requires an array", operator)
+		}
+		return func(value interface{}, found bool) bool {
+			if !found {
+				return false
+			}
+			in := containsAny(value, args)
+			if operator == "$in" {
+				return in
+			}
+			return !in
+		}, nil
+
+	case "$all":
+		args, ok := arg.([]interface{})
+		if !ok {
+			return nil, errors.New("$all requires an array")
+		}
+		return func(value interface{}, found bool) bool {
+			values, ok := value.([]interface{})
+			if !found || !ok {
+				return false
+			}
+			for _, a := range args {
+				if !containsAny(a, values) {
+					return false
+				}
+			}
+			return true
+		}, nil
+
+	case "$size":
+		size, err := toNonNegativeInt(arg)
+		if err != nil {
+			return nil, errors.WithMessage(err, "$size requires a non-negative integer")
+		}
+		return func(value interface{}, found bool) bool {
+			values, ok := value.([]interface{})
+			return found && ok && len(values) == size
+		}, nil
+
+	case "$mod":
+		args, ok := arg.([]interface{})
+		if !ok || len(args) != 2 {
+			return nil, errors.New("$mod requires an array of a divisor and a remainder")
+		}
+		divisor, ok1 := toInt(args[0])
+		remainder, ok2 := toInt(args[1])
+		if !ok1 || !ok2 || divisor == 0 {
+			return nil, errors.New("$mod requires a non-zero integer divisor and an integer remainder")
+		}
+		return func(value interface{}, found bool) bool {
+			v, ok := toInt(value)
+			return found && ok && v%divisor == remainder
+		}, nil
+
+	case "$regex":
+		pattern, ok := arg.(string)
+		if !ok {
+			return nil, errors.New("$regex requires a string")
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid regular expression %s", pattern)
+		}
+		return func(value interface{}, found bool) bool {
+			s, ok := value.(string)
+			return found && ok && re.MatchString(s)
+		}, nil
+
+	case "$elemMatch", "$allMatch":
+		elemMatcher, err := compileCondition(arg)
+		if err != nil {
+			return nil, err
+		}
+		return func(value interface{}, found bool) bool {
+			values, ok := value.([]interface{})
+			if !found || !ok || len(values) == 0 {
+				return false
+			}
+			for _, v := range values {
+				matched := elemMatcher(v, true)
+				if operator == "$elemMatch" && matched {
+					return true
+				}
+				if operator == "$allMatch" && !matched {
+					return false
+				}
+			}
+			return operator == "$allMatch"
+		}, nil
+
+	case "$not":
+		m, err := compileCondition(arg)
+		if err != nil {
+			return nil, err
+		}
+		return func(value interface{}, found bool) bool { return found && !m(value, found) }, nil
+
+	case "$and", "$or", "$nor":
+		conditions, ok := arg.([]interface{})
+		if !ok {
+			return nil, errors.Errorf("%s requires an array", operator)
+		}
+		var matchers []valueMatcher
+		for _, c := range conditions {
+			m, err := compileCondition(c)
+			if err != nil {
+				return nil, err
+			}
+			matchers = append(matchers, m)
+		}
+		return func(value interface{}, found bool) bool {
+			for _, m := range matchers {
+				matched := m(value, found)
+				switch {
+				case operator == "$and" && !matched:
+					return false
+				case operator == "$or" && matched:
+					return true
+				case operator == "$nor" && matched:
+					return false
+				}
+			}
+			return operator != "$or"
+		}, nil
+
+	default:
+		return nil, errors.Errorf("invalid operator %s", operator)
+	}
+}
+
+// containsAny returns true if value, or any of its elements if value is an array, equals any of args
+func containsAny(value interface{}, args []interface{}) bool {
+	values, ok := value.([]interface{})
+	if !ok {
+		values = []interface{}{value}
+	}
+	for _, v := range values {
+		for _, a := range args {
+			if compareJSON(v, a) == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func splitFieldPath(field string) []string {
+	return strings.Split(field, ".")
+}
+
+func getField(doc interface{}, path []string) (interface{}, bool) {
+	value := doc
+	for _, name := range path {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[name]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func setField(doc map[string]interface{}, path []string, value interface{}) {
+	for _, name := range path[:len(path)-1] {
+		sub, ok := doc[name].(map[string]interface{})
+		if !ok {
+			sub = make(map[string]interface{})
+			doc[name] = sub
+		}
+		doc = sub
+	}
+	doc[path[len(path)-1]] = value
+}
+
+// project returns the JSON of the given fields of the document
+func (q *query) project(doc map[string]interface{}) ([]byte, error) {
+	projected := make(map[string]interface{})
+	for _, path := range q.fields {
+		if len(path) == 1 && path[0] == idField {
+			continue
+		}
+		if value, found := getField(doc, path); found {
+			setField(projected, path, value)
+		}
+	}
+	return json.Marshal(projected)
+}
+
+// sortValues returns the values of the sort fields of the document. A missing field sorts as null
+func (q *query) sortValues(doc interface{}) []interface{} {
+	values := make([]interface{}, len(q.sortFields))
+	for i, f := range q.sortFields {
+		values[i], _ = getField(doc, f.path)
+	}
+	return values
+}
+
+// compareSortPositions compares the positions of two results in the sort order of the query,
+// the keys breaking the ties
+func (q *query) compareSortPositions(values1 []interface{}, key1 string, values2 []interface{}, key2 string) int {
+	for i, f := range q.sortFields {
+		if c := compareJSON(values1[i], values2[i]); c != 0 {
+			if f.desc {
+				return -c
+			}
+			return c
+		}
+	}
+	return strings.Compare(key1, key2)
+}
+
+func (q *query) sortResults(results []*queryResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		return q.compareSortPositions(results[i].sortValues, results[i].key, results[j].sortValues, results[j].key) < 0
+	})
+}
+
+// compareJSON compares two JSON values following the CouchDB collation of types:
+// null < false < true < numbers < strings < arrays < objects.
+// Note that, unlike CouchDB, strings are compared by their code points
+func compareJSON(a, b interface{}) int {
+	ra, rb := jsonTypeRank(a), jsonTypeRank(b)
+	if ra != rb {
+		return ra - rb
+	}
+	switch va := a.(type) {
+	case bool:
+		return 0
+	case json.Number, float64:
+		fa, _ := toFloat(va)
+		fb, _ := toFloat(b)
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	case string:
+		return strings.Compare(va, b.(string))
+	case []interface{}:
+		vb := b.([]interface{})
+		for i := 0; i < len(va) && i < len(vb); i++ {
+			if c := compareJSON(va[i], vb[i]); c != 0 {
+				return c
+			}
+		}
+		return len(va) - len(vb)
+	case map[string]interface{}:
+		vb := b.(map[string]interface{})
+		keysA, keysB := sortedKeys(va), sortedKeys(vb)
+		for i := 0; i < len(keysA) && i < len(keysB); i++ {
+			if c := strings.Compare(keysA[i], keysB[i]); c != 0 {
+				return c
+			}
+			if c := compareJSON(va[keysA[i]], vb[keysB[i]]); c != 0 {
+				return c
+			}
+		}
+		return len(keysA) - len(keysB)
+	}
+	return 0
+}
+
+func jsonTypeRank(v interface{}) int {
+	switch val := v.(type) {
+	case nil:
+		return 0
+	case bool:
+		if !val {
+			return 1
+		}
+		return 2
+	case json.Number, float64:
+		return 3
+	case string:
+		return 4
+	case []interface{}:
+		return 5
+	default:
+		return 6
+	}
+}
+
+func jsonTypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number, float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func toInt(v interface{}) (int64, bool) {
+	f, ok := toFloat(v)
+	if !ok || f != math.Trunc(f) {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+func toNonNegativeInt(v interface{}) (int, error) {
+	i, ok := toInt(v)
+	if !ok || i < 0 || i > math.MaxInt32 {
+		return 0, errors.Errorf("%v is not a non-negative integer", v)
+	}
+	return int(i), nil
+}
+
+// decodeJSON decodes a JSON value, preserving the representation of the numbers
+func decodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+	return v, nil
+}
+
+// decodeJSONDoc decodes a value of the state as a JSON document. Values that are
+// not JSON objects are not visible to queries, as in CouchDB
+func decodeJSONDoc(key string, value []byte) (map[string]interface{}, bool) {
+	v, err := decodeJSON(value)
+	if err != nil {
+		return nil, false
+	}
+	doc, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	doc[idField] = key
+	return doc, true
+}
@@ -0,0 +1,260 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectorOperators(t *testing.T) {
+	docJSON := `{"_id":"key1","owner":"tom","size":10,"color":"blue","tags":["shiny","round"],"dims":{"height":3,"width":4.5},"sold":false,"note":null}`
+	v, err := decodeJSON([]byte(docJSON))
+	assert.NoError(t, err)
+	doc := v.(map[string]interface{})
+
+	testCases := []struct {
+		selector string
+		match    bool
+	}{
+		{`{"owner":"tom"}`, true},
+		{`{"owner":"jerry"}`, false},
+		{`{"_id":"key1"}`, true},
+		{`{"owner":"tom","size":10}`, true},
+		{`{"size":10.0}`, true},
+		{`{"size":{"$eq":10}}`, true},
+		{`{"size":{"$ne":10}}`, false},
+		{`{"size":{"$gt":9,"$lte":10}}`, true},
+		{`{"size":{"$gte":11}}`, false},
+		{`{"size":{"$lt":"a"}}`, true},
+		{`{"owner":{"$gt":10}}`, true},
+		{`{"owner":{"$in":["jerry","tom"]}}`, true},
+		{`{"owner":{"$nin":["jerry","tom"]}}`, false},
+		{`{"tags":{"$in":["round"]}}`, true},
+		{`{"tags":{"$all":["round","shiny"]}}`, true},
+		{`{"tags":{"$all":["round","flat"]}}`, false},
+		{`{"tags":{"$size":2}}`, true},
+		{`{"tags":{"$elemMatch":{"$regex":"^sh"}}}`, true},
+		{`{"tags":{"$allMatch":{"$regex":"^sh"}}}`, false},
+		{`{"owner":{"$regex":"^t.m$"}}`, true},
+		{`{"size":{"$regex":"10"}}`, false},
+		{`{"size":{"$mod":[3,1]}}`, true},
+		{`{"size":{"$type":"number"}}`, true},
+		{`{"note":{"$type":"null"}}`, true},
+		{`{"sold":false}`, true},
+		{`{"owner":{"$exists":true}}`, true},
+		{`{"price":{"$exists":false}}`, true},
+		{`{"price":{"$ne":10}}`, false},
+		{`{"dims.height":3}`, true},
+		{`{"dims":{"width":{"$gt":4}}}`, true},
+		{`{"dims":{"height":3,"width":4.5}}`, true},
+		{`{"$and":[{"owner":"tom"},{"size":{"$gt":5}}]}`, true},
+		{`{"$or":[{"owner":"jerry"},{"size":{"$gt":50}}]}`, false},
+		{`{"$nor":[{"owner":"jerry"},{"size":{"$gt":50}}]}`, true},
+		{`{"$not":{"owner":"tom"}}`, false},
+		{`{"size":{"$not":{"$gt":50}}}`, true},
+		{`{"size":{"$or":[{"$lt":5},{"$gt":8}]}}`, true},
+		{`{"color":"blue","$or":[{"owner":"fred"},{"owner":"tom"}]}`, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.selector, func(t *testing.T) {
+			q, err := parseQuery(fmt.Sprintf(`{"selector":%s}`, tc.selector))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.match, q.match(doc))
+		})
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	testCases := []struct {
+		query       string
+		expectedErr string
+	}{
+		{`this is an invalid query string`, "invalid query string"},
+		{`["selector"]`, "invalid query string: query must be a JSON object"},
+		{`{"fields":["owner"]}`, "invalid query string: selector is required"},
+		{`{"selector":"owner"}`, "selector must be a JSON object"},
+		{`{"selector":{"$xor":[]}}`, "invalid operator $xor"},
+		{`{"selector":{"size":{"$between":[1,2]}}}`, "invalid operator $between"},
+		{`{"selector":{"size":{"$in":1}}}`, "$in requires an array"},
+		{`{"selector":{"$or":{"size":1}}}`, "$or requires an array of selectors"},
+		{`{"selector":{"owner":{"$regex":"("}}}`, "invalid regular expression ("},
+		{`{"selector":{"size":{"$mod":[0,1]}}}`, "$mod requires a non-zero integer divisor and an integer remainder"},
+		{`{"selector":{},"fields":"owner"}`, "fields definition must be an array"},
+		{`{"selector":{},"sort":[{"size":"up"}]}`, "invalid sort direction for field size: must be asc or desc"},
+		{`{"selector":{},"limit":-1}`, "invalid limit: -1 is not a non-negative integer"},
+		{`{"selector":{},"group":"owner"}`, "invalid query string: option group not recognized"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			_, err := parseQuery(tc.query)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedErr)
+		})
+	}
+}
+
+func TestCompareJSON(t *testing.T) {
+	ordered := []string{`null`, `false`, `true`, `-1`, `2`, `10.5`, `"A"`, `"a"`, `"b"`, `[]`, `[1]`, `[1,2]`, `{}`, `{"a":1}`, `{"a":2}`}
+	for i := range ordered {
+		for j := range ordered {
+			a, err := decodeJSON([]byte(ordered[i]))
+			assert.NoError(t, err)
+			b, err := decodeJSON([]byte(ordered[j]))
+			assert.NoError(t, err)
+			c := compareJSON(a, b)
+			switch {
+			case i < j:
+				assert.True(t, c < 0, "%s < %s", ordered[i], ordered[j])
+			case i > j:
+				assert.True(t, c > 0, "%s > %s", ordered[i], ordered[j])
+			default:
+				assert.Equal(t, 0, c, "%s == %s", ordered[i], ordered[j])
+			}
+		}
+	}
+}
+
+func TestQuerySortLimitAndFields(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testquerysort")
+	assert.NoError(t, err)
+	populateMarbles(t, db, 10)
+
+	// the results are in the order of the keys by default
+	itr, err := db.ExecuteQuery("ns1", `{"selector":{"color":"blue"}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key0", "key2", "key4", "key6", "key8"}, queryKeys(t, itr))
+
+	itr, err = db.ExecuteQuery("ns1", `{"selector":{"color":"blue"},"sort":[{"size":"desc"}]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key8", "key6", "key4", "key2", "key0"}, queryKeys(t, itr))
+
+	// ties are broken by the keys
+	itr, err = db.ExecuteQuery("ns1", `{"selector":{"size":{"$gte":0}},"sort":["color"],"skip":1,"limit":6}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key2", "key4", "key6", "key8", "key1", "key3"}, queryKeys(t, itr))
+
+	// values that are not JSON objects are not visible to queries
+	itr, err = db.ExecuteQuery("ns1", `{"selector":{"_id":{"$regex":"binary"}}}`)
+	assert.NoError(t, err)
+	assert.Empty(t, queryKeys(t, itr))
+
+	itr, err = db.ExecuteQuery("ns1", `{"selector":{"owner":"owner3"},"fields":["owner","dims.height","_id","missing"]}`)
+	assert.NoError(t, err)
+	res, err := itr.Next()
+	assert.NoError(t, err)
+	kv := res.(*statedb.VersionedKV)
+	assert.Equal(t, "key3", kv.Key)
+	assert.Equal(t, `{"dims":{"height":1000003},"owner":"owner3"}`, string(kv.Value))
+	assert.Equal(t, version.NewHeight(1, 3), kv.Version)
+	itr.Close()
+
+	// without fields, the value is returned as committed
+	itr, err = db.ExecuteQuery("ns1", `{"selector":{"owner":"owner3"}}`)
+	assert.NoError(t, err)
+	res, err = itr.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, marbleJSON(3), string(res.(*statedb.VersionedKV).Value))
+	itr.Close()
+
+	_, err = db.ExecuteQueryWithMetadata("ns1", `{"selector":{}}`, map[string]interface{}{"limit": 1})
+	assert.EqualError(t, err, `Invalid entry, "limit" must be an int32`)
+	_, err = db.ExecuteQueryWithMetadata("ns1", `{"selector":{}}`, map[string]interface{}{"bookmark": "&&&"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid bookmark [&&&]")
+}
+
+func TestQueryPagination(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testquerypagination")
+	assert.NoError(t, err)
+	populateMarbles(t, db, 10)
+
+	testCases := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{"key order", `{"selector":{"size":{"$gt":1}}}`, []string{"key2", "key3", "key4", "key5", "key6", "key7", "key8", "key9"}},
+		{"sorted", `{"selector":{"size":{"$gt":1}},"sort":[{"color":"desc"},{"size":"desc"}]}`, []string{"key9", "key7", "key5", "key3", "key8", "key6", "key4", "key2"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var keys []string
+			bookmark := ""
+			for page := 0; page < 4; page++ {
+				itr, err := db.ExecuteQueryWithMetadata("ns1", tc.query, map[string]interface{}{"limit": int32(3), "bookmark": bookmark})
+				assert.NoError(t, err)
+				var pageKeys []string
+				for {
+					res, err := itr.Next()
+					assert.NoError(t, err)
+					if res == nil {
+						break
+					}
+					pageKeys = append(pageKeys, res.(*statedb.VersionedKV).Key)
+				}
+				assert.True(t, len(pageKeys) <= 3)
+				keys = append(keys, pageKeys...)
+				nextBookmark := itr.GetBookmarkAndClose()
+				if len(pageKeys) == 0 {
+					// an empty page returns the bookmark it was given
+					assert.Equal(t, bookmark, nextBookmark)
+				}
+				bookmark = nextBookmark
+			}
+			assert.Equal(t, tc.expected, keys)
+		})
+	}
+
+	// a bookmark of a sorted query cannot be used for a query with another sort
+	itr, err := db.ExecuteQueryWithMetadata("ns1", `{"selector":{},"sort":["size"]}`, map[string]interface{}{"limit": int32(1)})
+	assert.NoError(t, err)
+	_, err = itr.Next()
+	assert.NoError(t, err)
+	bookmark := itr.GetBookmarkAndClose()
+	_, err = db.ExecuteQueryWithMetadata("ns1", `{"selector":{},"sort":["size","color"]}`, map[string]interface{}{"bookmark": bookmark})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "does not match the sort of the query")
+}
+
+func marbleJSON(i int) string {
+	color := "blue"
+	if i%2 == 1 {
+		color = "red"
+	}
+	return fmt.Sprintf(`{"owner":"owner%d","color":"%s","size":%d,"dims":{"height":%d}}`, i, color, i, 1000000+i)
+}
+
+// populateMarbles commits count JSON values key0...key<count-1> in namespace ns1, plus a value that is not JSON
+func populateMarbles(t *testing.T, db statedb.VersionedDB, count int) {
+	batch := statedb.NewUpdateBatch()
+	for i := 0; i < count; i++ {
+		batch.Put("ns1", fmt.Sprintf("key%d", i), []byte(marbleJSON(i)), version.NewHeight(1, uint64(i)))
+	}
+	batch.Put("ns1", "binary", []byte{0x01, 0x02}, version.NewHeight(1, uint64(count)))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, uint64(count))))
+}
+
+func queryKeys(t *testing.T, itr statedb.ResultsIterator) []string {
+	defer itr.Close()
+	var keys []string
+	for {
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		if res == nil {
+			return keys
+		}
+		keys = append(keys, res.(*statedb.VersionedKV).Key)
+	}
+}
@@ -7,6 +7,9 @@ package stateleveldb
 
 import (
 	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"sync"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
@@ -26,6 +29,8 @@ var savePointKey = []byte{0x00}
 // VersionedDBProvider implements interface VersionedDBProvider
 type VersionedDBProvider struct {
 	dbProvider *leveldbhelper.Provider
+	databases  map[string]*versionedDB
+	mux        sync.Mutex
 }
 
 // NewVersionedDBProvider instantiates VersionedDBProvider
@@ -33,12 +38,32 @@ func NewVersionedDBProvider() *VersionedDBProvider {
 	dbPath := ledgerconfig.GetStateLevelDBPath()
 	logger.Debugf("constructing VersionedDBProvider dbPath=%s", dbPath)
 	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
-	return &VersionedDBProvider{dbProvider}
+	return &VersionedDBProvider{dbProvider: dbProvider, databases: make(map[string]*versionedDB)}
+}
+
+// NewReadOnlyVersionedDBProvider instantiates VersionedDBProvider over an existing statedb
+// at the given path. The statedb is opened read-only and hence, the databases returned by
+// this provider can be used only for the queries
+func NewReadOnlyVersionedDBProvider(dbPath string) *VersionedDBProvider {
+	logger.Debugf("constructing read-only VersionedDBProvider dbPath=%s", dbPath)
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath, ReadOnly: true})
+	return &VersionedDBProvider{dbProvider: dbProvider, databases: make(map[string]*versionedDB)}
 }
 
 // GetDBHandle gets the handle to a named database
 func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.VersionedDB, error) {
-	return newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName), nil
+	provider.mux.Lock()
+	defer provider.mux.Unlock()
+	vdb := provider.databases[dbName]
+	if vdb == nil {
+		var err error
+		vdb, err = newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName)
+		if err != nil {
+			return nil, err
+		}
+		provider.databases[dbName] = vdb
+	}
+	return vdb, nil
 }
 
 // Close closes the underlying db
@@ -50,11 +75,18 @@ func (provider *VersionedDBProvider) Close() {
 type versionedDB struct {
 	db     *leveldbhelper.DBHandle
 	dbName string
+	// indexes maps the namespaces to the definitions of their indexes, see index.go
+	indexes   map[string][]*indexDefinition
+	indexLock sync.RWMutex
 }
 
 // newVersionedDB constructs an instance of VersionedDB
-func newVersionedDB(db *leveldbhelper.DBHandle, dbName string) *versionedDB {
-	return &versionedDB{db, dbName}
+func newVersionedDB(db *leveldbhelper.DBHandle, dbName string) (*versionedDB, error) {
+	indexes, err := loadIndexDefinitions(db)
+	if err != nil {
+		return nil, err
+	}
+	return &versionedDB{db: db, dbName: dbName, indexes: indexes}, nil
 }
 
 // Open implements method in VersionedDB interface
@@ -154,18 +186,103 @@ func (vdb *versionedDB) GetStateRangeScanIteratorWithMetadata(namespace string,
 
 }
 
+const optionBookmark = "bookmark"
+
 // ExecuteQuery implements method in VersionedDB interface
 func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
-	return nil, errors.New("ExecuteQuery not supported for leveldb")
+	return vdb.ExecuteQueryWithMetadata(namespace, query, nil)
+}
+
+// ExecuteQueryWithMetadata implements method in VersionedDB interface. The query, in the CouchDB
+// query language (see query.go), is evaluated against the values of the namespace, which are retrieved
+// through an index if the query can use one (see index.go) or, otherwise, by scanning the namespace
+func (vdb *versionedDB) ExecuteQueryWithMetadata(namespace, queryString string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
+	logger.Debugf("Entering ExecuteQueryWithMetadata namespace: %s, query: %s, metadata: %v", namespace, queryString, metadata)
+	requestedLimit := int32(0)
+	bookmark := ""
+	if metadata != nil {
+		if err := validateQueryMetadata(metadata); err != nil {
+			return nil, err
+		}
+		if limitOption, ok := metadata[optionLimit]; ok {
+			requestedLimit = limitOption.(int32)
+		}
+		if bookmarkOption, ok := metadata[optionBookmark]; ok {
+			bookmark = bookmarkOption.(string)
+		}
+	}
+	q, err := parseQuery(queryString)
+	if err != nil {
+		return nil, err
+	}
+	position, err := decodeBookmark(bookmark, len(q.sortFields))
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates candidateIterator
+	if def, prefix := vdb.selectIndex(namespace, q); def != nil {
+		logger.Debugf("Using index [%s] for query on namespace [%s]", def.Name, namespace)
+		keys, err := vdb.indexedKeys(namespace, def, prefix)
+		if err != nil {
+			return nil, err
+		}
+		candidates = &indexedCandidates{vdb: vdb, namespace: namespace, keys: keys}
+	} else {
+		startKey := ""
+		if position != nil && len(q.sortFields) == 0 {
+			// the results are in the order of the keys, resume right after the bookmarked key
+			startKey = position.Key + "\x00"
+		}
+		dbItr := vdb.db.GetIterator(constructCompositeKey(namespace, startKey), append([]byte(namespace), lastKeyIndicator))
+		candidates = &scannedCandidates{dbItr}
+	}
+
+	scanner := &queryScanner{query: q, candidates: candidates, position: position, bookmark: bookmark, limit: q.limit, skip: q.skip}
+	if requestedLimit > 0 && (scanner.limit == 0 || int(requestedLimit) < scanner.limit) {
+		scanner.limit = int(requestedLimit)
+	}
+	if bookmark != "" {
+		// the results skipped by the query were skipped on the first page
+		scanner.skip = 0
+	}
+	if len(q.sortFields) > 0 {
+		if err := scanner.sortResults(); err != nil {
+			scanner.Close()
+			return nil, err
+		}
+	}
+	return scanner, nil
 }
 
-// ExecuteQueryWithMetadata implements method in VersionedDB interface
-func (vdb *versionedDB) ExecuteQueryWithMetadata(namespace, query string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
-	return nil, errors.New("ExecuteQueryWithMetadata not supported for leveldb")
+func validateQueryMetadata(metadata map[string]interface{}) error {
+	for key, keyVal := range metadata {
+		switch key {
+		case optionBookmark:
+			//Verify the bookmark is a string
+			if _, ok := keyVal.(string); ok {
+				continue
+			}
+			return errors.New("Invalid entry, \"bookmark\" must be a string")
+
+		case optionLimit:
+			//Verify the limit is an integer
+			if _, ok := keyVal.(int32); ok {
+				continue
+			}
+			return errors.New("Invalid entry, \"limit\" must be an int32")
+
+		default:
+			return errors.Errorf("Invalid entry, option %s not recognized", key)
+		}
+	}
+	return nil
 }
 
 // ApplyUpdates implements method in VersionedDB interface
 func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
+	vdb.indexLock.Lock()
+	defer vdb.indexLock.Unlock()
 	dbBatch := leveldbhelper.NewUpdateBatch()
 	namespaces := batch.GetUpdatedNamespaces()
 	for _, ns := range namespaces {
@@ -174,6 +291,18 @@ func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version
 			compositeKey := constructCompositeKey(ns, k)
 			logger.Debugf("Channel [%s]: Applying key(string)=[%s] key(bytes)=[%#v]", vdb.dbName, string(compositeKey), compositeKey)
 
+			if len(vdb.indexes[ns]) > 0 {
+				committedVal, err := vdb.GetState(ns, k)
+				if err != nil {
+					return err
+				}
+				var committedValue []byte
+				if committedVal != nil {
+					committedValue = committedVal.Value
+				}
+				vdb.addIndexUpdates(dbBatch, ns, k, committedValue, vv.Value)
+			}
+
 			if vv.Value == nil {
 				dbBatch.Delete(compositeKey)
 			} else {
@@ -278,3 +407,257 @@ func (scanner *kvScanner) GetBookmarkAndClose() string {
 	scanner.Close()
 	return retval
 }
+
+// GetFullScanIterator implements method in interface statedb.FullScanIterable
+func (vdb *versionedDB) GetFullScanIterator(skipNamespace func(string) bool) (statedb.ResultsIterator, error) {
+	return newFullDBScanner(vdb.db, skipNamespace), nil
+}
+
+// fullDBScanner iterates over all the keys of a db, excluding the savepoint key. When a namespace
+// to be skipped is encountered, the scanner moves past the entire namespace by opening a new
+// iterator that starts right after the last possible key of the namespace
+type fullDBScanner struct {
+	db            *leveldbhelper.DBHandle
+	dbItr         iterator.Iterator
+	skipNamespace func(string) bool
+}
+
+func newFullDBScanner(db *leveldbhelper.DBHandle, skipNamespace func(string) bool) *fullDBScanner {
+	return &fullDBScanner{
+		db:            db,
+		dbItr:         db.GetIterator(nil, nil),
+		skipNamespace: skipNamespace,
+	}
+}
+
+// Next returns the key-values in the lexical order of <Namespace, key>
+func (s *fullDBScanner) Next() (statedb.QueryResult, error) {
+	for s.dbItr.Next() {
+		dbKey := s.dbItr.Key()
+		if bytes.Equal(dbKey, savePointKey) || dbKey[0] == indexKeysStart {
+			continue
+		}
+		ns, key := splitCompositeKey(dbKey)
+		if s.skipNamespace(ns) {
+			s.dbItr.Release()
+			nextNsStartKey := append([]byte(ns), lastKeyIndicator)
+			s.dbItr = s.db.GetIterator(nextNsStartKey, nil)
+			continue
+		}
+		dbVal := s.dbItr.Value()
+		dbValCopy := make([]byte, len(dbVal))
+		copy(dbValCopy, dbVal)
+		vv, err := decodeValue(dbValCopy)
+		if err != nil {
+			return nil, err
+		}
+		return &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: ns, Key: key},
+			VersionedValue: *vv,
+		}, nil
+	}
+	return nil, nil
+}
+
+// Close releases the underlying iterator
+func (s *fullDBScanner) Close() {
+	s.dbItr.Release()
+}
+
+// candidateIterator returns the key-values of a namespace that may satisfy a query, in the order of the keys
+type candidateIterator interface {
+	next() (*statedb.VersionedKV, error)
+	close()
+}
+
+// scannedCandidates returns all the key-values of a namespace
+type scannedCandidates struct {
+	dbItr iterator.Iterator
+}
+
+func (c *scannedCandidates) next() (*statedb.VersionedKV, error) {
+	if !c.dbItr.Next() {
+		return nil, nil
+	}
+	ns, key := splitCompositeKey(c.dbItr.Key())
+	dbVal := c.dbItr.Value()
+	dbValCopy := make([]byte, len(dbVal))
+	copy(dbValCopy, dbVal)
+	vv, err := decodeValue(dbValCopy)
+	if err != nil {
+		return nil, err
+	}
+	return &statedb.VersionedKV{CompositeKey: statedb.CompositeKey{Namespace: ns, Key: key}, VersionedValue: *vv}, nil
+}
+
+func (c *scannedCandidates) close() {
+	c.dbItr.Release()
+}
+
+// indexedCandidates returns the key-values of the keys retrieved from an index
+type indexedCandidates struct {
+	vdb       *versionedDB
+	namespace string
+	keys      []string
+}
+
+func (c *indexedCandidates) next() (*statedb.VersionedKV, error) {
+	for len(c.keys) > 0 {
+		key := c.keys[0]
+		c.keys = c.keys[1:]
+		vv, err := c.vdb.GetState(c.namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		if vv != nil {
+			return &statedb.VersionedKV{CompositeKey: statedb.CompositeKey{Namespace: c.namespace, Key: key}, VersionedValue: *vv}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *indexedCandidates) close() {}
+
+// bookmarkPosition is the position of the last result returned by a query, which is encoded in the bookmarks
+type bookmarkPosition struct {
+	Key        string        `json:"key"`
+	SortValues []interface{} `json:"sort,omitempty"`
+}
+
+func encodeBookmark(position *bookmarkPosition) string {
+	positionBytes, err := json.Marshal(position)
+	if err != nil {
+		logger.Errorf("Error marshaling bookmark for key [%s]: %s", position.Key, err)
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(positionBytes)
+}
+
+func decodeBookmark(bookmark string, sortFieldCount int) (*bookmarkPosition, error) {
+	if bookmark == "" {
+		return nil, nil
+	}
+	positionBytes, err := base64.RawURLEncoding.DecodeString(bookmark)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid bookmark [%s]", bookmark)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(positionBytes))
+	decoder.UseNumber()
+	position := &bookmarkPosition{}
+	if err := decoder.Decode(position); err != nil {
+		return nil, errors.Wrapf(err, "invalid bookmark [%s]", bookmark)
+	}
+	if len(position.SortValues) != sortFieldCount {
+		return nil, errors.Errorf("bookmark [%s] does not match the sort of the query", bookmark)
+	}
+	return position, nil
+}
+
+// queryResult is a key-value that satisfies a query
+type queryResult struct {
+	key        string
+	kv         *statedb.VersionedKV
+	sortValues []interface{}
+}
+
+// queryScanner returns the key-values that satisfy a query, in the order of the keys or, if the query
+// defines a sort, in the sort order. As the sort requires all the results, they are retrieved upfront
+type queryScanner struct {
+	query      *query
+	candidates candidateIterator
+	// position is the position of the last result of the previous page, if any
+	position *bookmarkPosition
+	// bookmark is the bookmark of the last result returned
+	bookmark string
+	limit    int
+	skip     int
+	returned int
+	sorted   []*queryResult
+}
+
+func (scanner *queryScanner) nextMatch() (*queryResult, error) {
+	for {
+		kv, err := scanner.candidates.next()
+		if err != nil || kv == nil {
+			return nil, err
+		}
+		if scanner.position != nil && len(scanner.query.sortFields) == 0 && kv.Key <= scanner.position.Key {
+			continue
+		}
+		doc, ok := decodeJSONDoc(kv.Key, kv.Value)
+		if !ok || !scanner.query.match(doc) {
+			continue
+		}
+		result := &queryResult{key: kv.Key, kv: kv}
+		if len(scanner.query.sortFields) > 0 {
+			result.sortValues = scanner.query.sortValues(doc)
+		}
+		if len(scanner.query.fields) > 0 {
+			delete(doc, idField)
+			if kv.Value, err = scanner.query.project(doc); err != nil {
+				return nil, errors.Wrapf(err, "error projecting the fields of key [%s]", kv.Key)
+			}
+		}
+		return result, nil
+	}
+}
+
+func (scanner *queryScanner) sortResults() error {
+	scanner.sorted = []*queryResult{}
+	for {
+		result, err := scanner.nextMatch()
+		if err != nil {
+			return err
+		}
+		if result == nil {
+			break
+		}
+		if scanner.position != nil && scanner.query.compareSortPositions(result.sortValues, result.key,
+			scanner.position.SortValues, scanner.position.Key) <= 0 {
+			continue
+		}
+		scanner.sorted = append(scanner.sorted, result)
+	}
+	scanner.query.sortResults(scanner.sorted)
+	return nil
+}
+
+// Next implements method in interface statedb.ResultsIterator
+func (scanner *queryScanner) Next() (statedb.QueryResult, error) {
+	for {
+		if scanner.limit > 0 && scanner.returned >= scanner.limit {
+			return nil, nil
+		}
+		var result *queryResult
+		if scanner.sorted != nil {
+			if len(scanner.sorted) == 0 {
+				return nil, nil
+			}
+			result, scanner.sorted = scanner.sorted[0], scanner.sorted[1:]
+		} else {
+			var err error
+			if result, err = scanner.nextMatch(); err != nil || result == nil {
+				return nil, err
+			}
+		}
+		if scanner.skip > 0 {
+			scanner.skip--
+			continue
+		}
+		scanner.returned++
+		scanner.bookmark = encodeBookmark(&bookmarkPosition{Key: result.key, SortValues: result.sortValues})
+		return result.kv, nil
+	}
+}
+
+// Close implements method in interface statedb.ResultsIterator
+func (scanner *queryScanner) Close() {
+	scanner.candidates.close()
+}
+
+// GetBookmarkAndClose implements method in interface statedb.QueryResultsIterator. The bookmark
+// is the position of the last result returned, which the next page of results starts after
+func (scanner *queryScanner) GetBookmarkAndClose() string {
+	scanner.Close()
+	return scanner.bookmark
+}
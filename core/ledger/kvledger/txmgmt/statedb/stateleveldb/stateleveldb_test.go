@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -73,23 +74,7 @@ func testCompositeKey(t *testing.T, dbName string, ns string, key string) {
 func TestQueryOnLevelDB(t *testing.T) {
 	env := NewTestVDBEnv(t)
 	defer env.Cleanup()
-	db, err := env.DBProvider.GetDBHandle("testquery")
-	assert.NoError(t, err)
-	db.Open()
-	defer db.Close()
-	batch := statedb.NewUpdateBatch()
-	jsonValue1 := `{"asset_name": "marble1","color": "blue","size": 1,"owner": "tom"}`
-	batch.Put("ns1", "key1", []byte(jsonValue1), version.NewHeight(1, 1))
-
-	savePoint := version.NewHeight(2, 22)
-	db.ApplyUpdates(batch, savePoint)
-
-	// query for owner=jerry, use namespace "ns1"
-	// As queries are not supported in levelDB, call to ExecuteQuery()
-	// should return a error message
-	itr, err := db.ExecuteQuery("ns1", `{"selector":{"owner":"jerry"}}`)
-	assert.Error(t, err, "ExecuteQuery not supported for leveldb")
-	assert.Nil(t, itr)
+	commontests.TestQuery(t, env.DBProvider)
 }
 
 func TestGetStateMultipleKeys(t *testing.T) {
@@ -136,3 +121,83 @@ func TestApplyUpdatesWithNilHeight(t *testing.T) {
 	defer env.Cleanup()
 	commontests.TestApplyUpdatesWithNilHeight(t, env.DBProvider)
 }
+
+func TestFullScanIterator(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("test-full-scan-iterator")
+	assert.NoError(t, err)
+	// an entry in another db should not be returned
+	otherDB, err := env.DBProvider.GetDBHandle("test-full-scan-iterator-other")
+	assert.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("", "configkey", []byte("config-value"), version.NewHeight(1, 0))
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.PutValAndMetadata("ns1", "key2", []byte("value2"), []byte("metadata2"), version.NewHeight(1, 2))
+	batch.Put("ns2", "key1", []byte("value1"), version.NewHeight(1, 3))
+	batch.Put("ns3", "key1", []byte("value1"), version.NewHeight(1, 4))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 4)))
+	otherBatch := statedb.NewUpdateBatch()
+	otherBatch.Put("ns1", "key3", []byte("value3"), version.NewHeight(1, 1))
+	assert.NoError(t, otherDB.ApplyUpdates(otherBatch, version.NewHeight(1, 1)))
+
+	itr, err := db.(statedb.FullScanIterable).GetFullScanIterator(
+		func(ns string) bool { return ns == "ns2" },
+	)
+	assert.NoError(t, err)
+	defer itr.Close()
+	var results []*statedb.VersionedKV
+	for {
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		if res == nil {
+			break
+		}
+		results = append(results, res.(*statedb.VersionedKV))
+	}
+	assert.Equal(t, []*statedb.VersionedKV{
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "", Key: "configkey"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("config-value"), Version: version.NewHeight(1, 0)},
+		},
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: "key1"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)},
+		},
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: "key2"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value2"), Metadata: []byte("metadata2"), Version: version.NewHeight(1, 2)},
+		},
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "ns3", Key: "key1"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 4)},
+		},
+	}, results)
+}
+
+func TestReadOnlyVersionedDBProvider(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("test-read-only")
+	assert.NoError(t, err)
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 1)))
+	env.DBProvider.Close()
+
+	readOnlyProvider := NewReadOnlyVersionedDBProvider(ledgerconfig.GetStateLevelDBPath())
+	defer readOnlyProvider.Close()
+	readOnlyDB, err := readOnlyProvider.GetDBHandle("test-read-only")
+	assert.NoError(t, err)
+	vv, err := readOnlyDB.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, vv)
+	savepoint, err := readOnlyDB.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(1, 1), savepoint)
+
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value2"), version.NewHeight(2, 1))
+	assert.Error(t, readOnlyDB.ApplyUpdates(batch, version.NewHeight(2, 1)))
+}
@@ -23,19 +23,35 @@ func newCollNameValidator(ccInfoProvider ledger.DeployedChaincodeInfoProvider, q
 }
 
 func (v *collNameValidator) validateCollName(ns, coll string) error {
+	if v.cache.containsCollName(ns, coll) {
+		return nil
+	}
+	var err error
 	if !v.cache.isPopulatedFor(ns) {
-		conf, err := v.retrieveCollConfigFromStateDB(ns)
-		if err != nil {
-			return err
+		var conf *common.CollectionConfigPackage
+		if conf, err = v.retrieveCollConfigFromStateDB(ns); err == nil {
+			v.cache.populate(ns, conf)
+			if v.cache.containsCollName(ns, coll) {
+				return nil
+			}
 		}
-		v.cache.populate(ns, conf)
 	}
-	if !v.cache.containsCollName(ns, coll) {
-		return &ledger.InvalidCollNameError{
+	if err == nil {
+		err = &ledger.InvalidCollNameError{
 			Ns:   ns,
 			Coll: coll,
 		}
 	}
+	// the collections that are not part of the collection config package of the namespace,
+	// such as the implicit collections of the orgs, are looked up individually
+	collConfig, collErr := v.ccInfoProvider.CollectionInfo(ns, coll, v.queryExecutor)
+	if collErr != nil {
+		return collErr
+	}
+	if collConfig == nil {
+		return err
+	}
+	v.cache[collConfigkey{ns, coll}] = true
 	return nil
 }
 
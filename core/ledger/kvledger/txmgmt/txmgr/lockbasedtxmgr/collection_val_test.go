@@ -10,6 +10,9 @@ import (
 
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -51,6 +54,50 @@ func TestCollectionValidation(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCollectionValidationNotInConfigPackage(t *testing.T) {
+	testEnv := testEnvsMap[levelDBtestEnvName]
+	testEnv.init(t, "testLedger", nil)
+	defer testEnv.cleanup()
+	txMgr := testEnv.getTxMgr()
+	populateCollConfigForTest(t, txMgr.(*LockBasedTxMgr),
+		[]collConfigkey{
+			{"ns1", "coll1"},
+		},
+		version.NewHeight(1, 1),
+	)
+	ccInfoProvider := txMgr.(*LockBasedTxMgr).ccInfoProvider.(*mock.DeployedChaincodeInfoProvider)
+	ccInfoProvider.CollectionInfoStub = func(ccName, collName string, qe ledger.SimpleQueryExecutor) (*common.StaticCollectionConfig, error) {
+		if collName == "implicit-coll" {
+			return &common.StaticCollectionConfig{Name: collName}, nil
+		}
+		return nil, nil
+	}
+
+	sim, err := txMgr.NewTxSimulator("tx-id1")
+	assert.NoError(t, err)
+
+	// a collection known to the chaincode info provider is valid even
+	// if the namespace does not define a collection config package
+	err = sim.SetPrivateData("ns2", "implicit-coll", "key1", []byte("val1"))
+	assert.NoError(t, err)
+	err = sim.SetPrivateData("ns1", "implicit-coll", "key1", []byte("val1"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, ccInfoProvider.CollectionInfoCallCount())
+
+	err = sim.SetPrivateData("ns2", "coll1", "key1", []byte("val1"))
+	_, ok := err.(*ledger.CollConfigNotDefinedError)
+	assert.True(t, ok)
+
+	err = sim.SetPrivateData("ns1", "coll2", "key1", []byte("val1"))
+	_, ok = err.(*ledger.InvalidCollNameError)
+	assert.True(t, ok)
+
+	ccInfoProvider.CollectionInfoReturns(nil, errors.New("collection info error"))
+	ccInfoProvider.CollectionInfoStub = nil
+	err = sim.SetPrivateData("ns1", "coll3", "key1", []byte("val1"))
+	assert.EqualError(t, err, "collection info error")
+}
+
 func TestPvtGetNoCollection(t *testing.T) {
 	testEnv := testEnvs[0]
 	testEnv.init(t, "test-pvtdata-get-no-collection", nil)
@@ -11,6 +11,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
@@ -206,6 +207,49 @@ func (txmgr *LockBasedTxMgr) RemoveStaleAndCommitPvtDataOfOldBlocks(blocksPvtDat
 	return nil
 }
 
+// PurgePvtData implements method in interface `txmgmt.TxMgr`. It removes the pvt data of the given collections that was
+// last updated by a block lower than the maxBlockNumToRetain, irrespective of the BTL of the collections. The hashed data
+// is retained, along with the expiry schedule that removes it as per the BTL
+func (txmgr *LockBasedTxMgr) PurgePvtData(maxBlockNumToRetain uint64, nsCollMap map[string][]string) error {
+	// similar to RemoveStaleAndCommitPvtDataOfOldBlocks(), the purge update batch is computed based on the current
+	// state and hence the regular block commits and the commits of the pvtData of old blocks are not allowed meanwhile
+	txmgr.pvtdataPurgeMgr.WaitForPrepareToFinish()
+	txmgr.oldBlockCommit.Lock()
+	defer txmgr.oldBlockCommit.Unlock()
+	logger.Debug("lock acquired on oldBlockCommit for purging pvtData from state database")
+
+	batch := privacyenabledstate.NewUpdateBatch()
+	if err := txmgr.pvtdataPurgeMgr.DeleteCommittedBefore(maxBlockNumToRetain, nsCollMap, batch.PvtUpdates); err != nil {
+		return err
+	}
+
+	txmgr.commitRWLock.Lock()
+	logger.Debugf("Write lock acquired for purging pvtData committed before block [%d] from state database", maxBlockNumToRetain)
+	if err := txmgr.db.ApplyPrivacyAwareUpdates(batch, nil); err != nil {
+		txmgr.commitRWLock.Unlock()
+		return err
+	}
+	txmgr.commitRWLock.Unlock()
+	txmgr.clearCache()
+	return nil
+}
+
+// ExportPubStateAndPvtStateHashes implements method in interface `txmgmt.TxMgr`. The export is performed against
+// a consistent state, as the commits of the regular blocks and of the pvtData of old blocks are blocked meanwhile
+func (txmgr *LockBasedTxMgr) ExportPubStateAndPvtStateHashes(dir string, newHashFunc snapshot.NewHashFunc) (map[string][]byte, error) {
+	txmgr.oldBlockCommit.Lock()
+	defer txmgr.oldBlockCommit.Unlock()
+	txmgr.commitRWLock.RLock()
+	defer txmgr.commitRWLock.RUnlock()
+	return txmgr.db.ExportPubStateAndPvtStateHashes(dir, newHashFunc)
+}
+
+// RebuildPvtdataExpirySchedule implements method in interface `txmgmt.TxMgr`. This is expected to be invoked
+// after the state is loaded from a snapshot and before any block is committed
+func (txmgr *LockBasedTxMgr) RebuildPvtdataExpirySchedule(nsCollMap map[string][]string) error {
+	return txmgr.pvtdataPurgeMgr.RebuildExpirySchedule(nsCollMap)
+}
+
 type uniquePvtDataMap map[privacyenabledstate.HashedCompositeKey]*privacyenabledstate.PvtKVWrite
 
 func constructUniquePvtData(blocksPvtData map[uint64][]*ledger.TxPvtData) (uniquePvtDataMap, error) {
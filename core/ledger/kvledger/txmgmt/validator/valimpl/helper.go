@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 )
 
 // validateAndPreparePvtBatch pulls out the private write-set for the transactions that are marked as valid
@@ -145,8 +146,9 @@ func preprocessProtoBlock(txMgr txmgr.TxMgr,
 				continue
 			}
 		} else {
-			rwsetProto, err := processNonEndorserTx(env, chdr.TxId, txType, txMgr, !doMVCCValidation)
-			if _, ok := err.(*customtx.InvalidTxError); ok {
+			rwsetProto, err := processNonEndorserTx(env, chdr.TxId, txType, block.Header.Number, txMgr, !doMVCCValidation)
+			// processors may wrap the error of an invalid transaction with context
+			if _, ok := errors.Cause(err).(*customtx.InvalidTxError); ok {
 				txsFilter.SetFlag(txIndex, peer.TxValidationCode_INVALID_OTHER_REASON)
 				continue
 			}
@@ -174,7 +176,7 @@ func preprocessProtoBlock(txMgr txmgr.TxMgr,
 	return b, txsStatInfo, nil
 }
 
-func processNonEndorserTx(txEnv *common.Envelope, txid string, txType common.HeaderType, txmgr txmgr.TxMgr, synchingState bool) (*rwset.TxReadWriteSet, error) {
+func processNonEndorserTx(txEnv *common.Envelope, txid string, txType common.HeaderType, blockNum uint64, txmgr txmgr.TxMgr, synchingState bool) (*rwset.TxReadWriteSet, error) {
 	logger.Debugf("Performing custom processing for transaction [txid=%s], [txType=%s]", txid, txType)
 	processor := customtx.GetProcessor(txType)
 	logger.Debugf("Processor for custom tx processing:%#v", processor)
@@ -189,7 +191,12 @@ func processNonEndorserTx(txEnv *common.Envelope, txid string, txType common.Hea
 		return nil, err
 	}
 	defer sim.Done()
-	if err = processor.GenerateSimulationResults(txEnv, sim, synchingState); err != nil {
+	if timedProcessor, ok := processor.(customtx.TimedProcessor); ok {
+		err = timedProcessor.GenerateSimulationResultsAt(txEnv, blockNum, sim, synchingState)
+	} else {
+		err = processor.GenerateSimulationResults(txEnv, sim, synchingState)
+	}
+	if err != nil {
 		return nil, err
 	}
 	if simRes, err = sim.GetTxSimulationResults(); err != nil {
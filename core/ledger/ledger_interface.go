@@ -8,6 +8,7 @@ package ledger
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-lib-go/healthz"
@@ -26,6 +27,16 @@ type Initializer struct {
 	MembershipInfoProvider        MembershipInfoProvider
 	MetricsProvider               metrics.Provider
 	HealthCheckRegistry           HealthCheckRegistry
+	SnapshotSigner                SnapshotSigner
+}
+
+// SnapshotSigner signs the hash of the snapshots generated by the peer. If no signer is supplied
+// in the Initializer, the snapshots are generated without a signature
+type SnapshotSigner interface {
+	// Sign signs the given message
+	Sign(message []byte) ([]byte, error)
+	// Serialize returns the serialized identity of the signer
+	Serialize() ([]byte, error)
 }
 
 // PeerLedgerProvider provides handle to ledger instances
@@ -35,6 +46,11 @@ type PeerLedgerProvider interface {
 	// This function guarantees that the creation of ledger and committing the genesis block would an atomic action
 	// The chain id retrieved from the genesis block is treated as a ledger id
 	Create(genesisBlock *common.Block) (PeerLedger, error)
+	// CreateFromSnapshot creates a new ledger from a snapshot generated by a peer via the function
+	// PeerLedger.SubmitSnapshotRequest and returns the ledger along with the ledger id. The ledger contains
+	// only the last block of the snapshot and the subsequent blocks can be committed to the ledger.
+	// This function guarantees that the creation of ledger from the snapshot would be an atomic action
+	CreateFromSnapshot(snapshotDir string) (PeerLedger, string, error)
 	// Open opens an already created ledger
 	Open(ledgerID string) (PeerLedger, error)
 	// Exists tells whether the ledger with given id exists
@@ -85,7 +101,8 @@ type PeerLedger interface {
 	PurgePrivateData(maxBlockNumToRetain uint64) error
 	// PrivateDataMinBlockNum returns the lowest retained endorsement block height
 	PrivateDataMinBlockNum() (uint64, error)
-	//Prune prunes the blocks/transactions that satisfy the given policy
+	// Prune prunes the blocks/transactions that satisfy the given policy.
+	// The supported policies are `*RetainLastNBlocksPolicy` and `*RetainBlocksAfterPolicy`
 	Prune(policy commonledger.PrunePolicy) error
 	// GetConfigHistoryRetriever returns the ConfigHistoryRetriever
 	GetConfigHistoryRetriever() (ConfigHistoryRetriever, error)
@@ -102,6 +119,14 @@ type PeerLedger interface {
 	//     missing info is recorded in the ledger (or)
 	// (3) the block is committed and does not contain any pvtData.
 	DoesPvtDataInfoExist(blockNum uint64) (bool, error)
+	// SubmitSnapshotRequest submits a request for generating a snapshot of the ledger when the block with
+	// the given number is committed. A block number of 0 is treated as the last committed block and in this
+	// case the snapshot is generated immediately. The submitted requests survive a peer restart
+	SubmitSnapshotRequest(blockNum uint64) error
+	// CancelSnapshotRequest cancels a previously submitted request for generating a snapshot
+	CancelSnapshotRequest(blockNum uint64) error
+	// PendingSnapshotRequests returns the block numbers of the pending snapshot requests in ascending order
+	PendingSnapshotRequests() ([]uint64, error)
 }
 
 // SimpleQueryExecutor encapsulates basic functions
@@ -178,6 +203,13 @@ type HistoryQueryExecutor interface {
 	// GetHistoryForKey retrieves the history of values for a key.
 	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
 	GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error)
+	// GetHistoryForKeyWithMetadata retrieves the history of values for a key, restricted and paginated
+	// as specified by the metadata. metadata is a map of additional query parameters:
+	// "startBlock" and "endBlock" (uint64, both inclusive) bound the heights of the modifications returned,
+	// "reverse" (bool) returns the most recent modifications first, "limit" (int32) caps the number of results
+	// and "bookmark" (string) resumes a previous query from the bookmark it returned.
+	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
+	GetHistoryForKeyWithMetadata(namespace string, key string, metadata map[string]interface{}) (QueryResultsIterator, error)
 }
 
 // TxSimulator simulates a transaction on a consistent snapshot of the 'as recent state as possible'
@@ -268,6 +300,17 @@ func (txMissingPvtData TxMissingPvtDataMap) Add(txNum uint64, ns, coll string, i
 	txMissingPvtData[txNum] = append(txMissingPvtData[txNum], &MissingPvtData{ns, coll, isEligible})
 }
 
+// RetainLastNBlocksPolicy is a prune policy that retains the most recent NumBlocks blocks
+type RetainLastNBlocksPolicy struct {
+	NumBlocks uint64
+}
+
+// RetainBlocksAfterPolicy is a prune policy that retains the blocks that
+// were created at or after the given timestamp
+type RetainBlocksAfterPolicy struct {
+	Timestamp time.Time
+}
+
 // CommitOptions encapsulates options associated with a block commit.
 type CommitOptions struct {
 	FetchPvtDataFromLedger bool
@@ -8,6 +8,7 @@ package ledgerconfig
 
 import (
 	"path/filepath"
+	"time"
 
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/spf13/viper"
@@ -32,7 +33,15 @@ const confConfigHistory = "configHistory"
 const confChains = "chains"
 const confPvtdataStore = "pvtdataStore"
 const fileLockPath = "fileLock"
+const confSnapshots = "snapshots"
+const confSnapshotsRootDir = "ledger.snapshots.rootDir"
+const confBlockCompression = "ledger.blockchain.compression"
+const confMaxBlockfileSize = "ledger.blockchain.maxBlockfileSize"
+const confRetainLastNBlocks = "ledger.blockchain.retention.retainLastNBlocks"
+const confRetainDuration = "ledger.blockchain.retention.retainDuration"
+const confPruneInterval = "ledger.blockchain.retention.pruneInterval"
 const confTotalQueryLimit = "ledger.state.totalQueryLimit"
+const confStateCacheSize = "ledger.state.cacheSize"
 const confInternalQueryLimit = "ledger.state.couchDBConfig.internalQueryLimit"
 const confEnableHistoryDatabase = "ledger.history.enableHistoryDatabase"
 const confMaxBatchSize = "ledger.state.couchDBConfig.maxBatchUpdateSize"
@@ -89,9 +98,62 @@ func GetConfigHistoryPath() string {
 	return filepath.Join(GetRootPath(), confConfigHistory)
 }
 
-// GetMaxBlockfileSize returns maximum size of the block file
+// GetSnapshotsRootDir returns the filesystem path under which the ledger snapshots are generated.
+// If not configured, the snapshots are generated under the ledger root path
+func GetSnapshotsRootDir() string {
+	if viper.GetString(confSnapshotsRootDir) != "" {
+		return config.GetPath(confSnapshotsRootDir)
+	}
+	return filepath.Join(GetRootPath(), confSnapshots)
+}
+
+// GetMaxBlockfileSize returns maximum size of the block file.
+// If not configured, the maximum size is 64 MB
 func GetMaxBlockfileSize() int {
-	return 64 * 1024 * 1024
+	maxBlockfileSize := viper.GetInt(confMaxBlockfileSize)
+	if maxBlockfileSize <= 0 {
+		maxBlockfileSize = 64 * 1024 * 1024
+	}
+	return maxBlockfileSize
+}
+
+// GetRetainLastNBlocks returns the number of most recent blocks retained in the block store.
+// A value of zero means that the blocks are not pruned based on their number
+func GetRetainLastNBlocks() uint64 {
+	retainLastNBlocks := viper.GetInt(confRetainLastNBlocks)
+	if retainLastNBlocks <= 0 {
+		return 0
+	}
+	return uint64(retainLastNBlocks)
+}
+
+// GetRetainDuration returns the age beyond which the blocks are pruned from the block store.
+// A value of zero means that the blocks are not pruned based on their age
+func GetRetainDuration() time.Duration {
+	retainDuration := viper.GetDuration(confRetainDuration)
+	if retainDuration <= 0 {
+		return 0
+	}
+	return retainDuration
+}
+
+// GetPruneInterval returns the interval in the terms of number of blocks
+// at which the block store is pruned as per the configured retention
+func GetPruneInterval() uint64 {
+	pruneInterval := viper.GetInt(confPruneInterval)
+	if pruneInterval <= 0 {
+		pruneInterval = 1000
+	}
+	return uint64(pruneInterval)
+}
+
+// GetBlockCompression returns the compression ("none" or "snappy") applied to the blocks added to the block files.
+// If not configured, the blocks are not compressed
+func GetBlockCompression() string {
+	if !viper.IsSet(confBlockCompression) {
+		return "none"
+	}
+	return viper.GetString(confBlockCompression)
 }
 
 // GetTotalQueryLimit exposes the totalLimit variable
@@ -104,6 +166,16 @@ func GetTotalQueryLimit() int {
 	return totalQueryLimit
 }
 
+// GetStateCacheSize exposes the maximum number of entries cached per namespace by the state cache
+func GetStateCacheSize() int {
+	stateCacheSize := viper.GetInt(confStateCacheSize)
+	// if cacheSize was unset or negative, default to 0 (cache disabled)
+	if !viper.IsSet(confStateCacheSize) || stateCacheSize < 0 {
+		stateCacheSize = 0
+	}
+	return stateCacheSize
+}
+
 // GetInternalQueryLimit exposes the queryLimit variable
 func GetInternalQueryLimit() int {
 	internalQueryLimit := viper.GetInt(confInternalQueryLimit)
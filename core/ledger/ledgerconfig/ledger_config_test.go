@@ -18,6 +18,7 @@ package ledgerconfig
 
 import (
 	"testing"
+	"time"
 
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
 	"github.com/spf13/viper"
@@ -70,6 +71,19 @@ func TestLedgerConfigPath(t *testing.T) {
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/pvtdataStore", GetPvtdataStorePath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/bookkeeper", GetInternalBookkeeperPath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/fileLock", GetFileLockPath())
+	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/snapshots", GetSnapshotsRootDir())
+	viper.Set("ledger.snapshots.rootDir", "/tmp/hyperledger/snapshots")
+	assert.Equal(t, "/tmp/hyperledger/snapshots", GetSnapshotsRootDir())
+}
+
+func TestGetBlockCompression(t *testing.T) {
+	viper.Reset()
+	assert.Equal(t, "none", GetBlockCompression())
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.Equal(t, "none", GetBlockCompression())
+	viper.Set("ledger.blockchain.compression", "snappy")
+	assert.Equal(t, "snappy", GetBlockCompression())
 }
 
 func TestGetTotalLimitDefault(t *testing.T) {
@@ -92,6 +106,28 @@ func TestGetTotalLimit(t *testing.T) {
 	assert.Equal(t, 5000, updatedValue) //test config returns 5000
 }
 
+func TestGetStateCacheSizeDefault(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defaultValue := GetStateCacheSize()
+	assert.Equal(t, 0, defaultValue) //test default config is 0
+}
+
+func TestGetStateCacheSizeUnset(t *testing.T) {
+	viper.Reset()
+	defaultValue := GetStateCacheSize()
+	assert.Equal(t, 0, defaultValue) //test default config is 0
+}
+
+func TestGetStateCacheSize(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	viper.Set("ledger.state.cacheSize", 500)
+	updatedValue := GetStateCacheSize()
+	assert.Equal(t, 500, updatedValue) //test config returns 500
+	viper.Set("ledger.state.cacheSize", -1)
+	assert.Equal(t, 0, GetStateCacheSize()) //test negative config disables the cache
+}
+
 func TestGetQueryLimitDefault(t *testing.T) {
 	setUpCoreYAMLConfig()
 	defaultValue := GetInternalQueryLimit()
@@ -248,6 +284,29 @@ func TestGetWarmIndexesAfterNBlocks(t *testing.T) {
 
 func TestGetMaxBlockfileSize(t *testing.T) {
 	assert.Equal(t, 67108864, GetMaxBlockfileSize())
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.Equal(t, 67108864, GetMaxBlockfileSize())
+	viper.Set("ledger.blockchain.maxBlockfileSize", 1024)
+	assert.Equal(t, 1024, GetMaxBlockfileSize())
+}
+
+func TestGetBlockRetention(t *testing.T) {
+	viper.Reset()
+	assert.Equal(t, uint64(0), GetRetainLastNBlocks())
+	assert.Equal(t, time.Duration(0), GetRetainDuration())
+	assert.Equal(t, uint64(1000), GetPruneInterval())
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.Equal(t, uint64(0), GetRetainLastNBlocks())
+	assert.Equal(t, time.Duration(0), GetRetainDuration())
+	assert.Equal(t, uint64(1000), GetPruneInterval())
+	viper.Set("ledger.blockchain.retention.retainLastNBlocks", 5000)
+	viper.Set("ledger.blockchain.retention.retainDuration", "720h")
+	viper.Set("ledger.blockchain.retention.pruneInterval", 10)
+	assert.Equal(t, uint64(5000), GetRetainLastNBlocks())
+	assert.Equal(t, 720*time.Hour, GetRetainDuration())
+	assert.Equal(t, uint64(10), GetPruneInterval())
 }
 
 func setUpCoreYAMLConfig() {
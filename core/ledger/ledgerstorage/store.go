@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package ledgerstorage
 
 import (
+	"fmt"
 	"sync"
 	"sync/atomic"
 
@@ -51,10 +52,15 @@ var attrsToIndex = []blkstorage.IndexableAttr{
 func NewProvider(metricsProvider metrics.Provider) *Provider {
 	// Initialize the block storage
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
-	blockStoreProvider := fsblkstorage.NewProvider(
-		fsblkstorage.NewConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize()),
-		indexConfig,
-		metricsProvider)
+	blockStoreConf, err := fsblkstorage.NewConfWithCompression(
+		ledgerconfig.GetBlockStorePath(),
+		ledgerconfig.GetMaxBlockfileSize(),
+		ledgerconfig.GetBlockCompression(),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("invalid block storage configuration: %s", err))
+	}
+	blockStoreProvider := fsblkstorage.NewProvider(blockStoreConf, indexConfig, metricsProvider)
 
 	pvtStoreProvider := pvtdatastorage.NewProvider()
 	return &Provider{blockStoreProvider, pvtStoreProvider}
@@ -93,6 +99,18 @@ func (p *Provider) Open(ledgerid string) (*Store, error) {
 	return store, nil
 }
 
+// BootstrapFromSnapshot initializes the block store for the ledgerid from the snapshot such that the last block
+// of the snapshot is the only block present in the block store. The pvtdata store is brought up to the last block
+// when the store is opened subsequently, as if it has processed the blocks up to the last block with no pvt data
+func (p *Provider) BootstrapFromSnapshot(ledgerid, snapshotDir string, lastBlock *common.Block) error {
+	blockStore, err := p.blkStoreProvider.BootstrapFromSnapshot(ledgerid, snapshotDir, lastBlock)
+	if err != nil {
+		return err
+	}
+	blockStore.Shutdown()
+	return nil
+}
+
 // Close closes the provider
 func (p *Provider) Close() {
 	p.blkStoreProvider.Close()
@@ -192,6 +210,11 @@ func (s *Store) CommitPvtDataOfOldBlocks(blocksPvtData map[uint64][]*ledger.TxPv
 	return nil
 }
 
+// AddMissingPvtDataOfOldBlocks invokes the function on underlying pvtdata store
+func (s *Store) AddMissingPvtDataOfOldBlocks(blocksMissingPvtData map[uint64]ledger.TxMissingPvtDataMap) error {
+	return s.pvtdataStore.AddMissingPvtDataOfOldBlocks(blocksMissingPvtData)
+}
+
 // GetPvtDataAndBlockByNum returns the block and the corresponding pvt data.
 // The pvt data is filtered by the list of 'collections' supplied
 func (s *Store) GetPvtDataAndBlockByNum(blockNum uint64, filter ledger.PvtNsCollFilter) (*ledger.BlockAndPvtData, error) {
@@ -268,6 +291,16 @@ func (s *Store) ResetLastUpdatedOldBlocksList() error {
 	return s.pvtdataStore.ResetLastUpdatedOldBlocksList()
 }
 
+// PurgePrivateData invokes the function on underlying pvtdata store
+func (s *Store) PurgePrivateData(maxBlockNumToRetain uint64) error {
+	return s.pvtdataStore.Purge(maxBlockNumToRetain)
+}
+
+// PrivateDataMinBlockNum invokes the function on underlying pvtdata store
+func (s *Store) PrivateDataMinBlockNum() (uint64, error) {
+	return s.pvtdataStore.MinRetainedBlockNum()
+}
+
 // IsPvtStoreAheadOfBlockStore returns true when the pvtStore height is
 // greater than the blockstore height. Otherwise, it returns false.
 func (s *Store) IsPvtStoreAheadOfBlockStore() bool {
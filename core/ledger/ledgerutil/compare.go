@@ -0,0 +1,340 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledgerutil
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// Divergence describes the first block at which two ledgers differ. The block hashes differ if the blocks
+// themselves differ and otherwise, the commit hashes differ because the peers computed different validation
+// flags or state updates for the block. TxsWithDifferentFlags lists the transactions whose validation flags
+// differ and KeyDiffs lists the keys whose writes by the block differ
+type Divergence struct {
+	BlockNum              uint64
+	BlockHash1            []byte
+	BlockHash2            []byte
+	CommitHash1           []byte
+	CommitHash2           []byte
+	TxsWithDifferentFlags []int
+	KeyDiffs              []*KeyDiff
+}
+
+// ComparisonResult summarizes the comparison of a ledger across two peers. The blocks are compared in the
+// range [FirstBlockNum, LastBlockNum] that is present on both the peers. Divergence is nil if no difference is
+// found in this range. The public and hashed states are compared only if the state leveldb of both the peers is
+// at the same savepoint, in which case StateCompared is set and StateDiffs lists the differing keys, up to the
+// requested maximum
+type ComparisonResult struct {
+	FirstBlockNum       uint64
+	LastBlockNum        uint64
+	LastBlockNum1       uint64
+	LastBlockNum2       uint64
+	Divergence          *Divergence
+	StateSavepoint1     *version.Height
+	StateSavepoint2     *version.Height
+	StateCompared       bool
+	StateDiffs          []*KeyDiff
+	StateDiffsTruncated bool
+}
+
+// CompareLedgers compares the ledger `ledgerID` of two stopped peers whose file system paths are `fsPath1` and
+// `fsPath2` and reports the first block whose hash or commit hash differs. A commit hash is compared only if it is
+// present on both the peers. The state databases are compared as well and at most `maxStateDiffs` differing keys
+// are reported, where a `maxStateDiffs` of zero reports all the differing keys
+func CompareLedgers(fsPath1, fsPath2, ledgerID string, maxStateDiffs int) (*ComparisonResult, error) {
+	result, err := compareBlocks(fsPath1, fsPath2, ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := compareStates(fsPath1, fsPath2, ledgerID, maxStateDiffs, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func compareBlocks(fsPath1, fsPath2, ledgerID string) (*ComparisonResult, error) {
+	blocks1, err := openBlocks(fsPath1, ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	defer blocks1.Close()
+	blocks2, err := openBlocks(fsPath2, ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	defer blocks2.Close()
+
+	block1, err := blocks1.Next()
+	if err != nil {
+		return nil, err
+	}
+	block2, err := blocks2.Next()
+	if err != nil {
+		return nil, err
+	}
+	if block1 == nil || block2 == nil {
+		return nil, errors.Errorf("no block found for ledger [%s] on one of the peers", ledgerID)
+	}
+	// skip the blocks that are pruned on the other peer
+	for block1 != nil && block1.Header.Number < block2.Header.Number {
+		if block1, err = blocks1.Next(); err != nil {
+			return nil, err
+		}
+	}
+	for block2 != nil && block1 != nil && block2.Header.Number < block1.Header.Number {
+		if block2, err = blocks2.Next(); err != nil {
+			return nil, err
+		}
+	}
+	if block1 == nil || block2 == nil {
+		return nil, errors.Errorf("the peers have no block of ledger [%s] in common", ledgerID)
+	}
+
+	result := &ComparisonResult{FirstBlockNum: block1.Header.Number}
+	for block1 != nil && block2 != nil {
+		result.LastBlockNum = block1.Header.Number
+		d, err := compareBlock(block1, block2)
+		if err != nil {
+			return nil, err
+		}
+		if d != nil {
+			result.Divergence = d
+			break
+		}
+		if block1, err = blocks1.Next(); err != nil {
+			return nil, err
+		}
+		if block2, err = blocks2.Next(); err != nil {
+			return nil, err
+		}
+	}
+
+	if result.LastBlockNum1, err = lastBlockNum(blocks1, block1, result.LastBlockNum); err != nil {
+		return nil, err
+	}
+	if result.LastBlockNum2, err = lastBlockNum(blocks2, block2, result.LastBlockNum); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// lastBlockNum reads the remaining blocks and returns the number of the last block
+func lastBlockNum(blocks *fsblkstorage.BlockFilesReader, current *common.Block, lastCompared uint64) (uint64, error) {
+	last := lastCompared
+	for current != nil {
+		last = current.Header.Number
+		var err error
+		if current, err = blocks.Next(); err != nil {
+			return 0, err
+		}
+	}
+	return last, nil
+}
+
+// compareBlock returns a Divergence if either the block hashes or the commit hashes of the two blocks differ
+func compareBlock(block1, block2 *common.Block) (*Divergence, error) {
+	blockHash1, blockHash2 := block1.Header.Hash(), block2.Header.Hash()
+	commitHash1, err := commitHash(block1)
+	if err != nil {
+		return nil, err
+	}
+	commitHash2, err := commitHash(block2)
+	if err != nil {
+		return nil, err
+	}
+	commitHashesDiffer := commitHash1 != nil && commitHash2 != nil && !bytes.Equal(commitHash1, commitHash2)
+	if bytes.Equal(blockHash1, blockHash2) && !commitHashesDiffer {
+		return nil, nil
+	}
+	logger.Infof("Ledgers diverge at block [%d]", block1.Header.Number)
+
+	d := &Divergence{
+		BlockNum:    block1.Header.Number,
+		BlockHash1:  blockHash1,
+		BlockHash2:  blockHash2,
+		CommitHash1: commitHash1,
+		CommitHash2: commitHash2,
+	}
+	txsFilter1, txsFilter2 := txValidationFlags(block1), txValidationFlags(block2)
+	numTxs := len(txsFilter1)
+	if len(txsFilter2) > numTxs {
+		numTxs = len(txsFilter2)
+	}
+	for txNum := 0; txNum < numTxs; txNum++ {
+		if txNum >= len(txsFilter1) || txNum >= len(txsFilter2) || txsFilter1.Flag(txNum) != txsFilter2.Flag(txNum) {
+			d.TxsWithDifferentFlags = append(d.TxsWithDifferentFlags, txNum)
+		}
+	}
+
+	writes1, err := blockWrites(block1)
+	if err != nil {
+		return nil, err
+	}
+	writes2, err := blockWrites(block2)
+	if err != nil {
+		return nil, err
+	}
+	for k, v1 := range writes1 {
+		if v2 := writes2[k]; !sameVersionedValue(v1, v2) {
+			d.KeyDiffs = append(d.KeyDiffs, &KeyDiff{Namespace: k.ns, Collection: k.coll, Key: k.key, Value1: v1, Value2: v2})
+		}
+	}
+	for k, v2 := range writes2 {
+		if _, ok := writes1[k]; !ok {
+			d.KeyDiffs = append(d.KeyDiffs, &KeyDiff{Namespace: k.ns, Collection: k.coll, Key: k.key, Value2: v2})
+		}
+	}
+	sort.Slice(d.KeyDiffs, func(i, j int) bool {
+		return keyDiffLess(d.KeyDiffs[i], d.KeyDiffs[j])
+	})
+	return d, nil
+}
+
+func keyDiffLess(d1, d2 *KeyDiff) bool {
+	if d1.Namespace != d2.Namespace {
+		return d1.Namespace < d2.Namespace
+	}
+	if d1.Collection != d2.Collection {
+		return d1.Collection < d2.Collection
+	}
+	return d1.Key < d2.Key
+}
+
+func sameVersionedValue(v1, v2 *statedb.VersionedValue) bool {
+	if v1 == nil || v2 == nil {
+		return v1 == v2
+	}
+	return (v1.Value == nil) == (v2.Value == nil) &&
+		bytes.Equal(v1.Value, v2.Value) &&
+		bytes.Equal(v1.Metadata, v2.Metadata) &&
+		version.AreSame(v1.Version, v2.Version)
+}
+
+// compareStates compares the public and hashed states of the two peers, key by key, if the state
+// leveldb of both the peers is present and is at the same savepoint
+func compareStates(fsPath1, fsPath2, ledgerID string, maxStateDiffs int, result *ComparisonResult) error {
+	for _, fsPath := range []string{fsPath1, fsPath2} {
+		if _, err := os.Stat(stateLevelDBPath(fsPath)); os.IsNotExist(err) {
+			logger.Warningf("State leveldb not found under [%s], skipping the comparison of the state databases", fsPath)
+			return nil
+		}
+	}
+	provider1, db1, err := openStateDB(fsPath1, ledgerID)
+	if err != nil {
+		return err
+	}
+	defer provider1.Close()
+	provider2, db2, err := openStateDB(fsPath2, ledgerID)
+	if err != nil {
+		return err
+	}
+	defer provider2.Close()
+
+	if result.StateSavepoint1, err = db1.GetLatestSavePoint(); err != nil {
+		return err
+	}
+	if result.StateSavepoint2, err = db2.GetLatestSavePoint(); err != nil {
+		return err
+	}
+	if !version.AreSame(result.StateSavepoint1, result.StateSavepoint2) {
+		logger.Warningf("State databases are at different savepoints %s and %s, skipping their comparison",
+			heightString(result.StateSavepoint1), heightString(result.StateSavepoint2))
+		return nil
+	}
+
+	itr1, err := db1.(statedb.FullScanIterable).GetFullScanIterator(privacyenabledstate.IsPvtdataNs)
+	if err != nil {
+		return err
+	}
+	defer itr1.Close()
+	itr2, err := db2.(statedb.FullScanIterable).GetFullScanIterator(privacyenabledstate.IsPvtdataNs)
+	if err != nil {
+		return err
+	}
+	defer itr2.Close()
+
+	result.StateCompared = true
+	kv1, err := nextKV(itr1)
+	if err != nil {
+		return err
+	}
+	kv2, err := nextKV(itr2)
+	if err != nil {
+		return err
+	}
+	for kv1 != nil || kv2 != nil {
+		var diff *KeyDiff
+		switch c := compareKeys(kv1, kv2); {
+		case c < 0:
+			diff = stateKeyDiff(kv1.CompositeKey, &kv1.VersionedValue, nil)
+			kv1, err = nextKV(itr1)
+		case c > 0:
+			diff = stateKeyDiff(kv2.CompositeKey, nil, &kv2.VersionedValue)
+			kv2, err = nextKV(itr2)
+		default:
+			if !sameVersionedValue(&kv1.VersionedValue, &kv2.VersionedValue) {
+				diff = stateKeyDiff(kv1.CompositeKey, &kv1.VersionedValue, &kv2.VersionedValue)
+			}
+			if kv1, err = nextKV(itr1); err == nil {
+				kv2, err = nextKV(itr2)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if diff == nil {
+			continue
+		}
+		if maxStateDiffs > 0 && len(result.StateDiffs) == maxStateDiffs {
+			result.StateDiffsTruncated = true
+			return nil
+		}
+		result.StateDiffs = append(result.StateDiffs, diff)
+	}
+	return nil
+}
+
+func nextKV(itr statedb.ResultsIterator) (*statedb.VersionedKV, error) {
+	res, err := itr.Next()
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.(*statedb.VersionedKV), nil
+}
+
+// compareKeys compares the keys in the order of the full scan iterator, where an exhausted iterator (nil) comes last
+func compareKeys(kv1, kv2 *statedb.VersionedKV) int {
+	switch {
+	case kv2 == nil:
+		return -1
+	case kv1 == nil:
+		return 1
+	case kv1.Namespace != kv2.Namespace:
+		return strings.Compare(kv1.Namespace, kv2.Namespace)
+	default:
+		return strings.Compare(kv1.Key, kv2.Key)
+	}
+}
+
+func stateKeyDiff(key statedb.CompositeKey, v1, v2 *statedb.VersionedValue) *KeyDiff {
+	if ns, coll, ok := privacyenabledstate.DecodeHashedDataNs(key.Namespace); ok {
+		return &KeyDiff{Namespace: ns, Collection: coll, Key: hex.EncodeToString([]byte(key.Key)), Value1: v1, Value2: v2}
+	}
+	return &KeyDiff{Namespace: key.Namespace, Key: key.Key, Value1: v1, Value2: v2}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledgerutil
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareLedgersIdentical(t *testing.T) {
+	fsPath1, fsPath2 := newTestFSPath(t), newTestFSPath(t)
+	defer os.RemoveAll(fsPath1)
+	defer os.RemoveAll(fsPath2)
+	blocks := constructTestBlocks(t)
+	commitBlocks(t, fsPath1, blocks)
+	commitBlocks(t, fsPath2, blocks)
+
+	result, err := CompareLedgers(fsPath1, fsPath2, testLedgerID, 0)
+	require.NoError(t, err)
+	require.Equal(t, &ComparisonResult{
+		FirstBlockNum:   0,
+		LastBlockNum:    2,
+		LastBlockNum1:   2,
+		LastBlockNum2:   2,
+		StateSavepoint1: version.NewHeight(2, 1),
+		StateSavepoint2: version.NewHeight(2, 1),
+		StateCompared:   true,
+	}, result)
+}
+
+func TestCompareLedgersDifferentValidationFlags(t *testing.T) {
+	fsPath1, fsPath2 := newTestFSPath(t), newTestFSPath(t)
+	defer os.RemoveAll(fsPath1)
+	defer os.RemoveAll(fsPath2)
+	blocks := constructTestBlocks(t)
+	commitBlocks(t, fsPath1, blocks)
+	// the second peer invalidates the first transaction in block 2
+	setTxFlag(blocks[2], 0, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	commitBlocks(t, fsPath2, blocks)
+
+	result, err := CompareLedgers(fsPath1, fsPath2, testLedgerID, 0)
+	require.NoError(t, err)
+	d := result.Divergence
+	require.NotNil(t, d)
+	require.Equal(t, uint64(2), d.BlockNum)
+	require.Equal(t, d.BlockHash1, d.BlockHash2)
+	require.NotEqual(t, d.CommitHash1, d.CommitHash2)
+	require.Equal(t, []int{0}, d.TxsWithDifferentFlags)
+	require.Equal(t, []*KeyDiff{
+		{
+			Namespace: "ns1",
+			Key:       "key1",
+			Value1:    &statedb.VersionedValue{Value: []byte("value1-updated"), Version: version.NewHeight(2, 0)},
+		},
+	}, d.KeyDiffs)
+	require.Equal(t, `[ns1]:["key1"] first="value1-updated"@(2,0) second=<absent>`, d.KeyDiffs[0].String())
+
+	require.True(t, result.StateCompared)
+	require.Equal(t, []*KeyDiff{
+		{
+			Namespace: "ns1",
+			Key:       "key1",
+			Value1:    &statedb.VersionedValue{Value: []byte("value1-updated"), Version: version.NewHeight(2, 0)},
+			Value2:    &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 0)},
+		},
+	}, result.StateDiffs)
+}
+
+func TestCompareLedgersDifferentBlocks(t *testing.T) {
+	fsPath1, fsPath2 := newTestFSPath(t), newTestFSPath(t)
+	defer os.RemoveAll(fsPath1)
+	defer os.RemoveAll(fsPath2)
+	blocks := constructTestBlocks(t)
+	commitBlocks(t, fsPath1, blocks)
+
+	bg, _ := testutil.NewBlockGenerator(t, testLedgerID, false)
+	bg.NextBlock(nil)
+	forkedBlock := bg.NextBlock([][]byte{pubSimulationResults(t, "key4", "value4-forked", "key5", "value5")})
+	forkedBlock.Header.PreviousHash = blocks[1].Header.Hash()
+	commitBlocks(t, fsPath2, []*common.Block{blocks[0], blocks[1], forkedBlock})
+
+	result, err := CompareLedgers(fsPath1, fsPath2, testLedgerID, 1)
+	require.NoError(t, err)
+	d := result.Divergence
+	require.NotNil(t, d)
+	require.Equal(t, uint64(2), d.BlockNum)
+	require.NotEqual(t, d.BlockHash1, d.BlockHash2)
+	require.Equal(t, []int{1}, d.TxsWithDifferentFlags)
+	require.Equal(t, []*KeyDiff{
+		{
+			Namespace: "ns1",
+			Key:       "key1",
+			Value1:    &statedb.VersionedValue{Value: []byte("value1-updated"), Version: version.NewHeight(2, 0)},
+		},
+		{
+			Namespace: "ns1",
+			Key:       "key4",
+			Value1:    &statedb.VersionedValue{Value: []byte("value4"), Version: version.NewHeight(2, 1)},
+			Value2:    &statedb.VersionedValue{Value: []byte("value4-forked"), Version: version.NewHeight(2, 0)},
+		},
+		{
+			Namespace: "ns1",
+			Key:       "key5",
+			Value2:    &statedb.VersionedValue{Value: []byte("value5"), Version: version.NewHeight(2, 0)},
+		},
+	}, d.KeyDiffs)
+
+	// the savepoints differ as the forked block has a single transaction
+	require.Equal(t, version.NewHeight(2, 1), result.StateSavepoint1)
+	require.Equal(t, version.NewHeight(2, 0), result.StateSavepoint2)
+	require.False(t, result.StateCompared)
+}
+
+func TestCompareLedgersDifferentHeights(t *testing.T) {
+	fsPath1, fsPath2 := newTestFSPath(t), newTestFSPath(t)
+	defer os.RemoveAll(fsPath1)
+	defer os.RemoveAll(fsPath2)
+	blocks := constructTestBlocks(t)
+	commitBlocks(t, fsPath1, blocks)
+	commitBlocks(t, fsPath2, blocks[:2])
+
+	result, err := CompareLedgers(fsPath1, fsPath2, testLedgerID, 0)
+	require.NoError(t, err)
+	require.Nil(t, result.Divergence)
+	require.Equal(t, uint64(1), result.LastBlockNum)
+	require.Equal(t, uint64(2), result.LastBlockNum1)
+	require.Equal(t, uint64(1), result.LastBlockNum2)
+	require.False(t, result.StateCompared)
+}
+
+func TestCompareLedgersStateDiffsLimit(t *testing.T) {
+	fsPath1, fsPath2 := newTestFSPath(t), newTestFSPath(t)
+	defer os.RemoveAll(fsPath1)
+	defer os.RemoveAll(fsPath2)
+	blocks := constructTestBlocks(t)
+	commitBlocks(t, fsPath1, blocks)
+	setTxFlag(blocks[1], 1, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	setTxFlag(blocks[2], 1, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	commitBlocks(t, fsPath2, blocks)
+
+	result, err := CompareLedgers(fsPath1, fsPath2, testLedgerID, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), result.Divergence.BlockNum)
+	require.Len(t, result.StateDiffs, 1)
+	require.True(t, result.StateDiffsTruncated)
+	require.Equal(t, "key3", result.StateDiffs[0].Key)
+}
@@ -0,0 +1,219 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ledgerutil contains the offline tools for inspecting the ledger of a stopped peer.
+// The block files and the state database are opened read-only and hence, the tools can be run
+// against a copy of the file system of a peer that is suspected to have forked from its org-mates
+package ledgerutil
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("ledgerutil")
+
+// the layout of the ledgers data under the peer file system path, as maintained by the package ledgerconfig
+const (
+	ledgersDataDir  = "ledgersData"
+	blockStoreDir   = "chains"
+	stateLevelDBDir = "stateLeveldb"
+)
+
+func blockStorePath(fsPath string) string {
+	return filepath.Join(fsPath, ledgersDataDir, blockStoreDir)
+}
+
+func stateLevelDBPath(fsPath string) string {
+	return filepath.Join(fsPath, ledgersDataDir, stateLevelDBDir)
+}
+
+// openBlocks opens the block files of the ledger `ledgerID` under the peer file system path `fsPath`
+func openBlocks(fsPath, ledgerID string) (*fsblkstorage.BlockFilesReader, error) {
+	r, err := fsblkstorage.NewBlockFilesReader(blockStorePath(fsPath), ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, errors.Errorf("no block files found for ledger [%s] under [%s]", ledgerID, fsPath)
+	}
+	return r, nil
+}
+
+// openStateDB opens the state leveldb under the peer file system path `fsPath` read-only.
+// An error is returned if the state is maintained in CouchDB or if the peer is still running,
+// as the running peer holds an exclusive lock on the state leveldb
+func openStateDB(fsPath, ledgerID string) (provider *stateleveldb.VersionedDBProvider, db statedb.VersionedDB, err error) {
+	dbPath := stateLevelDBPath(fsPath)
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, nil, errors.Wrapf(err, "error accessing the state leveldb under [%s]", fsPath)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			provider, db, err = nil, nil, errors.Errorf("error opening the state leveldb under [%s], make sure that the peer is stopped: %s", fsPath, r)
+		}
+	}()
+	provider = stateleveldb.NewReadOnlyVersionedDBProvider(dbPath)
+	db, err = provider.GetDBHandle(ledgerID)
+	if err != nil {
+		provider.Close()
+		return nil, nil, err
+	}
+	return provider, db, nil
+}
+
+// KeyDiff captures a key whose value differs between two ledgers. For a collection, the Key contains the hex encoded
+// hash of the key. A nil value indicates that the key is absent in (or not written by) the corresponding ledger and
+// a value with nil bytes indicates a delete
+type KeyDiff struct {
+	Namespace  string
+	Collection string
+	Key        string
+	Value1     *statedb.VersionedValue
+	Value2     *statedb.VersionedValue
+}
+
+func (d *KeyDiff) String() string {
+	if d.Collection != "" {
+		// the values of a collection are the value hashes
+		return fmt.Sprintf("[%s]:[%s]:[%s] first=%s second=%s", d.Namespace, d.Collection, d.Key,
+			versionedValueString(d.Value1, "%x"), versionedValueString(d.Value2, "%x"))
+	}
+	return fmt.Sprintf("[%s]:[%q] first=%s second=%s", d.Namespace, d.Key,
+		versionedValueString(d.Value1, "%q"), versionedValueString(d.Value2, "%q"))
+}
+
+func versionedValueString(v *statedb.VersionedValue, valueFormat string) string {
+	switch {
+	case v == nil:
+		return "<absent>"
+	case v.Value == nil:
+		return "<deleted>@" + heightString(v.Version)
+	default:
+		return fmt.Sprintf(valueFormat, v.Value) + "@" + heightString(v.Version)
+	}
+}
+
+func heightString(h *version.Height) string {
+	if h == nil {
+		return "(-)"
+	}
+	return fmt.Sprintf("(%d,%d)", h.BlockNum, h.TxNum)
+}
+
+// writeKey identifies a key written by a transaction. For a collection, the key is the hex encoded key hash
+type writeKey struct {
+	ns, coll, key string
+}
+
+// blockWrites returns the effective public and hashed writes of the valid endorser transactions in the block,
+// i.e., the updates that the block is expected to apply to the state database
+func blockWrites(block *common.Block) (map[writeKey]*statedb.VersionedValue, error) {
+	writes := map[writeKey]*statedb.VersionedValue{}
+	txsFilter := txValidationFlags(block)
+	for txNum, envBytes := range block.Data.Data {
+		if txNum >= len(txsFilter) || txsFilter.IsInvalid(txNum) {
+			continue
+		}
+		txRWSet, err := endorserTxRWSet(envBytes)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("error extracting the rwset of transaction [%d] in block [%d]", txNum, block.Header.Number))
+		}
+		if txRWSet == nil {
+			continue
+		}
+		ver := version.NewHeight(block.Header.Number, uint64(txNum))
+		for _, nsRWSet := range txRWSet.NsRwSets {
+			for _, w := range nsRWSet.KvRwSet.Writes {
+				writes[writeKey{ns: nsRWSet.NameSpace, key: w.Key}] = writtenValue(w.IsDelete, w.Value, ver)
+			}
+			for _, collRWSet := range nsRWSet.CollHashedRwSets {
+				for _, w := range collRWSet.HashedRwSet.HashedWrites {
+					k := writeKey{ns: nsRWSet.NameSpace, coll: collRWSet.CollectionName, key: hex.EncodeToString(w.KeyHash)}
+					writes[k] = writtenValue(w.IsDelete, w.ValueHash, ver)
+				}
+			}
+		}
+	}
+	return writes, nil
+}
+
+func writtenValue(isDelete bool, value []byte, ver *version.Height) *statedb.VersionedValue {
+	if isDelete {
+		return &statedb.VersionedValue{Version: ver}
+	}
+	if value == nil {
+		value = []byte{}
+	}
+	return &statedb.VersionedValue{Value: value, Version: ver}
+}
+
+// endorserTxRWSet returns the rwset of the transaction if the transaction is an endorser transaction and nil otherwise
+func endorserTxRWSet(envBytes []byte) (*rwsetutil.TxRwSet, error) {
+	env, err := putils.GetEnvelopeFromBlock(envBytes)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := putils.GetPayload(env)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("payload header is nil")
+	}
+	chdr, err := putils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+		return nil, nil
+	}
+	respPayload, err := putils.GetActionFromEnvelopeMsg(env)
+	if err != nil {
+		return nil, err
+	}
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err := txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+		return nil, err
+	}
+	return txRWSet, nil
+}
+
+func txValidationFlags(block *common.Block) util.TxValidationFlags {
+	if block.Metadata == nil || len(block.Metadata.Metadata) <= int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		return nil
+	}
+	return util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+}
+
+// commitHash returns the commit hash recorded in the block metadata by the committing peer, if any
+func commitHash(block *common.Block) ([]byte, error) {
+	if block.Metadata == nil || len(block.Metadata.Metadata) <= int(common.BlockMetadataIndex_COMMIT_HASH) {
+		return nil, nil
+	}
+	metadataBytes := block.Metadata.Metadata[common.BlockMetadataIndex_COMMIT_HASH]
+	if len(metadataBytes) == 0 {
+		return nil, nil
+	}
+	metadata, err := putils.GetMetadataFromBlock(block, common.BlockMetadataIndex_COMMIT_HASH)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error unmarshaling the commit hash of block [%d]", block.Header.Number))
+	}
+	return metadata.Value, nil
+}
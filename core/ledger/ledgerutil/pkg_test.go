@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledgerutil
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/mock"
+	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
+	lutils "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+const testLedgerID = "testchainid"
+
+func TestMain(m *testing.M) {
+	ledgertestutil.SetupCoreYAMLConfig()
+	cceventmgmt.Initialize(nil)
+	os.Exit(m.Run())
+}
+
+func newTestFSPath(t *testing.T) string {
+	fsPath, err := ioutil.TempDir("", "ledgerutil")
+	require.NoError(t, err)
+	return fsPath
+}
+
+// commitBlocks creates the ledger of a peer whose file system path is `fsPath` from the genesis block `blocks[0]`
+// and commits the remaining blocks. The validation flags present in the blocks are honored by the ledger
+func commitBlocks(t *testing.T, fsPath string, blocks []*common.Block) {
+	viper.Set("peer.fileSystemPath", fsPath)
+	provider, err := kvledger.NewProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+	require.NoError(t, provider.Initialize(&ledger.Initializer{
+		DeployedChaincodeInfoProvider: &mock.DeployedChaincodeInfoProvider{},
+		MetricsProvider:               &disabled.Provider{},
+	}))
+	lgr, err := provider.Create(proto.Clone(blocks[0]).(*common.Block))
+	require.NoError(t, err)
+	defer lgr.Close()
+	for _, b := range blocks[1:] {
+		require.NoError(t, lgr.CommitWithPvtData(&ledger.BlockAndPvtData{Block: proto.Clone(b).(*common.Block)}, &ledger.CommitOptions{}))
+	}
+}
+
+// pubSimulationResults returns the public simulation results of a transaction that writes the given
+// key-values, passed as alternate keys and values, in the namespace "ns1"
+func pubSimulationResults(t *testing.T, kvs ...string) []byte {
+	b := rwsetutil.NewRWSetBuilder()
+	for i := 0; i < len(kvs); i += 2 {
+		b.AddToWriteSet("ns1", kvs[i], []byte(kvs[i+1]))
+	}
+	simRes, err := b.GetTxSimulationResults()
+	require.NoError(t, err)
+	pubSimBytes, err := simRes.GetPubSimulationBytes()
+	require.NoError(t, err)
+	return pubSimBytes
+}
+
+// constructTestBlocks returns a genesis block and two blocks with two transactions each
+func constructTestBlocks(t *testing.T) []*common.Block {
+	bg, gb := testutil.NewBlockGenerator(t, testLedgerID, false)
+	block1 := bg.NextBlock([][]byte{
+		pubSimulationResults(t, "key1", "value1", "key2", "value2"),
+		pubSimulationResults(t, "key3", "value3"),
+	})
+	block2 := bg.NextBlock([][]byte{
+		pubSimulationResults(t, "key1", "value1-updated"),
+		pubSimulationResults(t, "key4", "value4"),
+	})
+	return []*common.Block{gb, block1, block2}
+}
+
+func setTxFlag(block *common.Block, txNum int, flag peer.TxValidationCode) {
+	lutils.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER]).SetFlag(txNum, flag)
+}
@@ -0,0 +1,262 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledgerutil
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// Problem describes an inconsistency found in a ledger. TxNum is -1 if the problem is not specific to a transaction
+type Problem struct {
+	BlockNum uint64
+	TxNum    int
+	Msg      string
+}
+
+func (p *Problem) String() string {
+	if p.TxNum < 0 {
+		return fmt.Sprintf("block [%d]: %s", p.BlockNum, p.Msg)
+	}
+	return fmt.Sprintf("block [%d] transaction [%d]: %s", p.BlockNum, p.TxNum, p.Msg)
+}
+
+// VerificationResult summarizes the verification of a ledger. The blocks below FirstBlockNum may have been
+// pruned or the block store may have been bootstrapped from a snapshot. StateSavepoint is nil if the peer does
+// not maintain a state leveldb, in which case the state database is not verified
+type VerificationResult struct {
+	FirstBlockNum        uint64
+	LastBlockNum         uint64
+	FirstCommitHashBlock *uint64
+	StateSavepoint       *version.Height
+	Problems             []*Problem
+}
+
+// VerifyLedger verifies the ledger `ledgerID` of a stopped peer whose file system path is `fsPath`. The blocks
+// are verified for the hash chain, the data hashes and the validation flags. The validation flags are re-checked
+// for the transactions that are marked valid, which are expected to be well formed, to belong to the ledger and not to
+// reuse a txid, and against the state database, where every key is expected to be written by a valid transaction.
+// Note that the MVCC and endorsement policy validation are not repeated as these need the state as of each block
+func VerifyLedger(fsPath, ledgerID string) (*VerificationResult, error) {
+	blocks, err := openBlocks(fsPath, ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	defer blocks.Close()
+
+	v := &verifier{
+		ledgerID: ledgerID,
+		txIDs:    map[string]struct{}{},
+		result:   &VerificationResult{},
+	}
+	for {
+		block, err := blocks.Next()
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			break
+		}
+		if err := v.verifyBlock(block); err != nil {
+			return nil, err
+		}
+	}
+	if v.prevHeader == nil {
+		return nil, errors.Errorf("no block found for ledger [%s] under [%s]", ledgerID, fsPath)
+	}
+	v.result.LastBlockNum = v.prevHeader.Number
+
+	if _, err := os.Stat(stateLevelDBPath(fsPath)); os.IsNotExist(err) {
+		logger.Warningf("State leveldb not found under [%s], skipping the verification of the state database", fsPath)
+		return v.result, nil
+	}
+	if err := v.verifyState(fsPath); err != nil {
+		return nil, err
+	}
+	return v.result, nil
+}
+
+type verifier struct {
+	ledgerID   string
+	prevHeader *common.BlockHeader
+	// txsFilters holds the validation flags of the blocks, starting from the first block present
+	txsFilters []util.TxValidationFlags
+	txIDs      map[string]struct{}
+	result     *VerificationResult
+}
+
+func (v *verifier) addProblem(blockNum uint64, txNum int, format string, args ...interface{}) {
+	p := &Problem{BlockNum: blockNum, TxNum: txNum, Msg: fmt.Sprintf(format, args...)}
+	logger.Debugf("Found problem: %s", p)
+	v.result.Problems = append(v.result.Problems, p)
+}
+
+func (v *verifier) verifyBlock(block *common.Block) error {
+	if block.Header == nil || block.Data == nil {
+		return errors.New("block with a nil header or data found")
+	}
+	blockNum := block.Header.Number
+	logger.Debugf("Verifying block [%d]", blockNum)
+
+	if v.prevHeader == nil {
+		v.result.FirstBlockNum = blockNum
+	} else {
+		if blockNum != v.prevHeader.Number+1 {
+			return errors.Errorf("block [%d] found after block [%d], the block files are out of order", blockNum, v.prevHeader.Number)
+		}
+		if !bytes.Equal(block.Header.PreviousHash, v.prevHeader.Hash()) {
+			v.addProblem(blockNum, -1, "previous hash [%x] does not match the hash [%x] of block [%d]",
+				block.Header.PreviousHash, v.prevHeader.Hash(), v.prevHeader.Number)
+		}
+	}
+	v.prevHeader = block.Header
+
+	if !bytes.Equal(block.Header.DataHash, block.Data.Hash()) {
+		v.addProblem(blockNum, -1, "data hash [%x] does not match the hash [%x] of the block data", block.Header.DataHash, block.Data.Hash())
+	}
+
+	ch, err := commitHash(block)
+	if err != nil {
+		v.addProblem(blockNum, -1, "%s", err)
+	}
+	switch {
+	case ch != nil && v.result.FirstCommitHashBlock == nil:
+		v.result.FirstCommitHashBlock = &blockNum
+	case ch == nil && v.result.FirstCommitHashBlock != nil:
+		v.addProblem(blockNum, -1, "commit hash is missing whereas it is present since block [%d]", *v.result.FirstCommitHashBlock)
+	}
+
+	txsFilter := txValidationFlags(block)
+	if len(txsFilter) != len(block.Data.Data) {
+		v.addProblem(blockNum, -1, "number of validation flags [%d] does not match the number of transactions [%d]",
+			len(txsFilter), len(block.Data.Data))
+	}
+	v.txsFilters = append(v.txsFilters, append(util.TxValidationFlags{}, txsFilter...))
+
+	for txNum, envBytes := range block.Data.Data {
+		if txNum >= len(txsFilter) {
+			break
+		}
+		v.verifyTx(blockNum, txNum, envBytes, txsFilter.Flag(txNum))
+	}
+	return nil
+}
+
+// verifyTx re-checks the validation flag of a transaction, as far as it can be done without the state
+func (v *verifier) verifyTx(blockNum uint64, txNum int, envBytes []byte, flag peer.TxValidationCode) {
+	if _, ok := peer.TxValidationCode_name[int32(flag)]; !ok {
+		v.addProblem(blockNum, txNum, "unknown validation flag [%d]", flag)
+		return
+	}
+	if flag == peer.TxValidationCode_NOT_VALIDATED {
+		v.addProblem(blockNum, txNum, "transaction is committed without being validated")
+		return
+	}
+
+	chdr, err := channelHeader(envBytes)
+	if err != nil {
+		if flag == peer.TxValidationCode_VALID {
+			v.addProblem(blockNum, txNum, "transaction is marked valid but it is malformed: %s", err)
+		}
+		return
+	}
+	_, duplicate := v.txIDs[chdr.TxId]
+	if chdr.TxId != "" && !duplicate {
+		v.txIDs[chdr.TxId] = struct{}{}
+	}
+	if flag != peer.TxValidationCode_VALID {
+		return
+	}
+
+	if chdr.ChannelId != v.ledgerID {
+		v.addProblem(blockNum, txNum, "transaction is marked valid but it belongs to channel [%s]", chdr.ChannelId)
+	}
+	if duplicate {
+		v.addProblem(blockNum, txNum, "transaction is marked valid but its txid [%s] is used by an earlier transaction", chdr.TxId)
+	}
+	if _, err := endorserTxRWSet(envBytes); err != nil {
+		v.addProblem(blockNum, txNum, "transaction is marked valid but its rwset cannot be extracted: %s", err)
+	}
+}
+
+func channelHeader(envBytes []byte) (*common.ChannelHeader, error) {
+	env, err := putils.GetEnvelopeFromBlock(envBytes)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := putils.GetPayload(env)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("payload header is nil")
+	}
+	return putils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+}
+
+// verifyState checks that the state database is not ahead of the block store and that every key in the
+// public and hashed state is written by a transaction that is marked valid
+func (v *verifier) verifyState(fsPath string) error {
+	provider, db, err := openStateDB(fsPath, v.ledgerID)
+	if err != nil {
+		return err
+	}
+	defer provider.Close()
+
+	savepoint, err := db.GetLatestSavePoint()
+	if err != nil {
+		return err
+	}
+	if savepoint == nil {
+		return errors.Errorf("state database of ledger [%s] is empty", v.ledgerID)
+	}
+	v.result.StateSavepoint = savepoint
+	if savepoint.BlockNum > v.result.LastBlockNum {
+		v.addProblem(savepoint.BlockNum, -1, "state database savepoint is ahead of the last block [%d]", v.result.LastBlockNum)
+	}
+
+	itr, err := db.(statedb.FullScanIterable).GetFullScanIterator(privacyenabledstate.IsPvtdataNs)
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if res == nil {
+			return nil
+		}
+		kv := res.(*statedb.VersionedKV)
+		blockNum, txNum := kv.Version.BlockNum, int(kv.Version.TxNum)
+		switch {
+		case blockNum > savepoint.BlockNum:
+			v.addProblem(blockNum, txNum, "state key [%s]:[%q] is written beyond the savepoint %s",
+				kv.Namespace, kv.Key, heightString(savepoint))
+		case blockNum < v.result.FirstBlockNum || blockNum > v.result.LastBlockNum:
+			// the writing block is pruned or is yet to be committed to the block store
+			continue
+		default:
+			txsFilter := v.txsFilters[blockNum-v.result.FirstBlockNum]
+			if txNum >= len(txsFilter) || !txsFilter.IsValid(txNum) {
+				v.addProblem(blockNum, txNum, "state key [%s]:[%q] is written by a transaction that is not marked valid",
+					kv.Namespace, kv.Key)
+			}
+		}
+	}
+}
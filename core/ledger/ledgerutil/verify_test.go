@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledgerutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyLedger(t *testing.T) {
+	fsPath := newTestFSPath(t)
+	defer os.RemoveAll(fsPath)
+	commitBlocks(t, fsPath, constructTestBlocks(t))
+
+	result, err := VerifyLedger(fsPath, testLedgerID)
+	require.NoError(t, err)
+	firstCommitHashBlock := uint64(1)
+	require.Equal(t, &VerificationResult{
+		FirstBlockNum:        0,
+		LastBlockNum:         2,
+		FirstCommitHashBlock: &firstCommitHashBlock,
+		StateSavepoint:       version.NewHeight(2, 1),
+	}, result)
+}
+
+func TestVerifyLedgerBlockProblems(t *testing.T) {
+	fsPath := newTestFSPath(t)
+	defer os.RemoveAll(fsPath)
+
+	bg, gb := testutil.NewBlockGenerator(t, testLedgerID, false)
+	block1 := bg.NextBlockWithTxid(
+		[][]byte{pubSimulationResults(t, "key1", "value1"), pubSimulationResults(t, "key2", "value2")},
+		[]string{"txid-1", "txid-2"},
+	)
+	setTxFlag(block1, 1, peer.TxValidationCode_NOT_VALIDATED)
+	block1.Header.DataHash = []byte("tampered-data-hash")
+	block2 := bg.NextBlockWithTxid(
+		[][]byte{pubSimulationResults(t, "key3", "value3"), pubSimulationResults(t, "key4", "value4")},
+		[]string{"txid-1", "txid-3"},
+	)
+	block2.Header.PreviousHash = block1.Header.Hash()
+	commitBlocks(t, fsPath, []*common.Block{gb, block1, block2})
+
+	result, err := VerifyLedger(fsPath, testLedgerID)
+	require.NoError(t, err)
+	require.Len(t, result.Problems, 3)
+	require.Equal(t, &Problem{BlockNum: 1, TxNum: -1,
+		Msg: fmt.Sprintf("data hash [%x] does not match the hash [%x] of the block data", "tampered-data-hash", block1.Data.Hash())},
+		result.Problems[0],
+	)
+	require.Equal(t, &Problem{BlockNum: 1, TxNum: 1, Msg: "transaction is committed without being validated"}, result.Problems[1])
+	require.Equal(t, &Problem{BlockNum: 2, TxNum: 0,
+		Msg: "transaction is marked valid but its txid [txid-1] is used by an earlier transaction"},
+		result.Problems[2],
+	)
+	require.Equal(t, "block [2] transaction [0]: transaction is marked valid but its txid [txid-1] is used by an earlier transaction",
+		result.Problems[2].String())
+}
+
+func TestVerifyLedgerStateProblems(t *testing.T) {
+	fsPath := newTestFSPath(t)
+	defer os.RemoveAll(fsPath)
+	blocks := constructTestBlocks(t)
+	setTxFlag(blocks[1], 1, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	commitBlocks(t, fsPath, blocks)
+
+	// tamper the state with a key written by the invalid transaction and a key written beyond the savepoint
+	provider := stateleveldb.NewVersionedDBProvider()
+	db, err := provider.GetDBHandle(testLedgerID)
+	require.NoError(t, err)
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key3", []byte("value3"), version.NewHeight(1, 1))
+	batch.Put("ns1", "key5", []byte("value5"), version.NewHeight(5, 0))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 1)))
+	provider.Close()
+
+	result, err := VerifyLedger(fsPath, testLedgerID)
+	require.NoError(t, err)
+	require.Equal(t, []*Problem{
+		{BlockNum: 1, TxNum: 1, Msg: `state key [ns1]:["key3"] is written by a transaction that is not marked valid`},
+		{BlockNum: 5, TxNum: 0, Msg: `state key [ns1]:["key5"] is written beyond the savepoint (2,1)`},
+	}, result.Problems)
+}
+
+func TestVerifyLedgerErrors(t *testing.T) {
+	fsPath := newTestFSPath(t)
+	defer os.RemoveAll(fsPath)
+
+	_, err := VerifyLedger(fsPath, testLedgerID)
+	require.Contains(t, err.Error(), "error accessing the block files of ledger [testchainid]")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(blockStorePath(fsPath), "chains", testLedgerID), 0755))
+	_, err = VerifyLedger(fsPath, testLedgerID)
+	require.EqualError(t, err, "no block files found for ledger [testchainid] under ["+fsPath+"]")
+}
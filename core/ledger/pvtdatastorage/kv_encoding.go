@@ -27,6 +27,7 @@ var (
 	ineligibleMissingDataKeyPrefix = []byte{5}
 	collElgKeyPrefix               = []byte{6}
 	lastUpdatedOldBlocksKey        = []byte{7}
+	minRetainedBlkKey              = []byte{8}
 
 	nilByte    = byte(0)
 	emptyValue = []byte{}
@@ -38,6 +39,12 @@ func getDataKeysForRangeScanByBlockNum(blockNum uint64) (startKey, endKey []byte
 	return
 }
 
+func getDataKeysForRangeScanBelowBlockNum(minBlkNum, maxBlkNum uint64) (startKey, endKey []byte) {
+	startKey = append(pvtDataKeyPrefix, version.NewHeight(minBlkNum, 0).ToBytes()...)
+	endKey = append(pvtDataKeyPrefix, version.NewHeight(maxBlkNum, 0).ToBytes()...)
+	return
+}
+
 func getExpiryKeysForRangeScan(minBlkNum, maxBlkNum uint64) (startKey, endKey []byte) {
 	startKey = append(expiryKeyPrefix, version.NewHeight(minBlkNum, 0).ToBytes()...)
 	endKey = append(expiryKeyPrefix, version.NewHeight(maxBlkNum+1, 0).ToBytes()...)
@@ -53,6 +60,15 @@ func decodeLastCommittedBlockVal(blockNumBytes []byte) uint64 {
 	return s
 }
 
+func encodeMinRetainedBlockVal(blockNum uint64) []byte {
+	return proto.EncodeVarint(blockNum)
+}
+
+func decodeMinRetainedBlockVal(blockNumBytes []byte) uint64 {
+	s, _ := proto.DecodeVarint(blockNumBytes)
+	return s
+}
+
 func encodeDataKey(key *dataKey) []byte {
 	dataKeyBytes := append(pvtDataKeyPrefix, version.NewHeight(key.blkNum, key.txNum).ToBytes()...)
 	dataKeyBytes = append(dataKeyBytes, []byte(key.ns)...)
@@ -210,6 +226,20 @@ func datakeyRange(blockNum uint64) (startKey, endKey []byte) {
 	return
 }
 
+func createRangeScanKeysForEligibleMissingDataBelow(blkNum uint64) (startKey, endKey []byte) {
+	startKey = append(eligibleMissingDataKeyPrefix, util.EncodeReverseOrderVarUint64(blkNum-1)...)
+	endKey = ineligibleMissingDataKeyPrefix
+	return startKey, endKey
+}
+
+func createRangeScanKeysForAllIneligibleMissingData() (startKey, endKey []byte) {
+	return ineligibleMissingDataKeyPrefix, collElgKeyPrefix
+}
+
+func createRangeScanKeysForAllExpiryEntries() (startKey, endKey []byte) {
+	return expiryKeyPrefix, eligibleMissingDataKeyPrefix
+}
+
 func eligibleMissingdatakeyRange(blkNum uint64) (startKey, endKey []byte) {
 	startKey = append(eligibleMissingDataKeyPrefix, util.EncodeReverseOrderVarUint64(blkNum)...)
 	endKey = append(eligibleMissingDataKeyPrefix, util.EncodeReverseOrderVarUint64(blkNum-1)...)
@@ -65,10 +65,22 @@ type Store interface {
 	// these pvtData, the `lastUpdatedOldBlocksList` must be removed. During the peer startup,
 	// if the `lastUpdatedOldBlocksList` exists, stateDB needs to be updated with the appropriate pvtData.
 	CommitPvtDataOfOldBlocks(blocksPvtData map[uint64][]*ledger.TxPvtData) error
+	// AddMissingPvtDataOfOldBlocks records the given pvt data of old blocks as missing, along with its expiry
+	// schedule. The missing data of eligible collections is then reported by `GetMissingPvtDataInfoForMostRecentBlocks`
+	// and the missing data of ineligible collections becomes eligible via `ProcessCollsEligibilityEnabled`, as for the
+	// pvt data missing at commit time. This is expected to be called only for the blocks for which the store does not
+	// hold any pvt data or missing data info, such as the blocks of a ledger created from a snapshot
+	AddMissingPvtDataOfOldBlocks(blocksMissingPvtData map[uint64]ledger.TxMissingPvtDataMap) error
 	// GetLastUpdatedOldBlocksPvtData returns the pvtdata of blocks listed in `lastUpdatedOldBlocksList`
 	GetLastUpdatedOldBlocksPvtData() (map[uint64][]*ledger.TxPvtData, error)
 	// ResetLastUpdatedOldBlocksList removes the `lastUpdatedOldBlocksList` entry from the store
 	ResetLastUpdatedOldBlocksList() error
+	// Purge removes the pvt data, the missing data info, and the expiry schedule of the blocks lower
+	// than the given block number, irrespective of the BlockToLive configured for the collections
+	Purge(maxBlockNumToRetain uint64) error
+	// MinRetainedBlockNum returns the lowest block number for which the pvt data has not been
+	// removed via the function `Purge`
+	MinRetainedBlockNum() (uint64, error)
 	// IsEmpty returns true if the store does not have any block committed yet
 	IsEmpty() (bool, error)
 	// LastCommittedBlockHeight returns the height of the last committed block
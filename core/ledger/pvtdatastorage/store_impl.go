@@ -36,6 +36,7 @@ type store struct {
 
 	isEmpty            bool
 	lastCommittedBlock uint64
+	minRetainedBlock   uint64
 	batchPending       bool
 	purgerLock         sync.Mutex
 	collElgProcSync    *collElgProcSync
@@ -147,6 +148,9 @@ func (s *store) initState() error {
 	if s.batchPending, err = s.hasPendingCommit(); err != nil {
 		return err
 	}
+	if s.minRetainedBlock, err = s.getMinRetainedBlockNum(); err != nil {
+		return err
+	}
 	if blist, err = s.getLastUpdatedOldBlocksList(); err != nil {
 		return err
 	}
@@ -277,6 +281,37 @@ func (s *store) CommitPvtDataOfOldBlocks(blocksPvtData map[uint64][]*ledger.TxPv
 	return nil
 }
 
+// AddMissingPvtDataOfOldBlocks implements the function in the interface `Store`
+func (s *store) AddMissingPvtDataOfOldBlocks(blocksMissingPvtData map[uint64]ledger.TxMissingPvtDataMap) error {
+	lastCommittedBlock := atomic.LoadUint64(&s.lastCommittedBlock)
+	batch := leveldbhelper.NewUpdateBatch()
+	for blkNum, missingPvtData := range blocksMissingPvtData {
+		if s.isEmpty || blkNum > lastCommittedBlock {
+			return &ErrIllegalArgs{fmt.Sprintf("block [%d] is not committed yet", blkNum)}
+		}
+		storeEntries, err := prepareStoreEntries(blkNum, nil, s.btlPolicy, missingPvtData)
+		if err != nil {
+			return err
+		}
+		for _, expiryEntry := range storeEntries.expiryEntries {
+			valBytes, err := encodeExpiryValue(expiryEntry.value)
+			if err != nil {
+				return err
+			}
+			batch.Put(encodeExpiryKey(expiryEntry.key), valBytes)
+		}
+		for missingDataKey, missingDataValue := range storeEntries.missingDataEntries {
+			valBytes, err := encodeMissingDataValue(missingDataValue)
+			if err != nil {
+				return err
+			}
+			batch.Put(encodeMissingDataKey(&missingDataKey), valBytes)
+		}
+	}
+	logger.Debugf("Adding missing private data info of [%d] old blocks", len(blocksMissingPvtData))
+	return s.commitBatch(batch)
+}
+
 func constructDataEntriesFromBlocksPvtData(blocksPvtData map[uint64][]*ledger.TxPvtData) []*dataEntry {
 	// construct dataEntries for all pvtData
 	var dataEntries []*dataEntry
@@ -883,6 +918,95 @@ func (s *store) processCollElgEvents(maxBatchSize, batchesInterval int) {
 	logger.Debugf("Converted [%d] inelligible mising data entries to elligible", totalEntriesConverted)
 }
 
+// Purge implements the function in the interface `Store`
+func (s *store) Purge(maxBlockNumToRetain uint64) error {
+	if s.isEmpty {
+		return &ErrIllegalCall{"The private data store is empty. Purge() function call is not allowed"}
+	}
+	lastCommittedBlock := atomic.LoadUint64(&s.lastCommittedBlock)
+	if maxBlockNumToRetain > lastCommittedBlock {
+		return &ErrIllegalArgs{fmt.Sprintf("Last committed block=%d, maxBlockNumToRetain=%d. The pvt data of the last committed block cannot be purged",
+			lastCommittedBlock, maxBlockNumToRetain)}
+	}
+
+	s.purgerLock.Lock()
+	defer s.purgerLock.Unlock()
+	minRetainedBlock := atomic.LoadUint64(&s.minRetainedBlock)
+	if maxBlockNumToRetain <= minRetainedBlock {
+		logger.Debugf("Pvt data below block [%d] is already purged, nothing to purge", minRetainedBlock)
+		return nil
+	}
+
+	batch := leveldbhelper.NewUpdateBatch()
+	numDataEntries := s.addDataEntriesToPurgeToBatch(batch, minRetainedBlock, maxBlockNumToRetain)
+	if err := s.addExpiryEntriesToPurgeToBatch(batch, maxBlockNumToRetain); err != nil {
+		return err
+	}
+	s.addMissingDataEntriesToPurgeToBatch(batch, maxBlockNumToRetain)
+	batch.Put(minRetainedBlkKey, encodeMinRetainedBlockVal(maxBlockNumToRetain))
+	if err := s.db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+	atomic.StoreUint64(&s.minRetainedBlock, maxBlockNumToRetain)
+	logger.Infof("[%s] - [%d] Entries purged from private data storage below block number [%d]", s.ledgerid, numDataEntries, maxBlockNumToRetain)
+	return nil
+}
+
+// addDataEntriesToPurgeToBatch adds the deletes for all the data entries committed by the blocks in the range [minBlkNum, maxBlkNum)
+func (s *store) addDataEntriesToPurgeToBatch(batch *leveldbhelper.UpdateBatch, minBlkNum, maxBlkNum uint64) int {
+	startKey, endKey := getDataKeysForRangeScanBelowBlockNum(minBlkNum, maxBlkNum)
+	itr := s.db.GetIterator(startKey, endKey)
+	defer itr.Release()
+	numEntries := 0
+	for itr.Next() {
+		batch.Delete(itr.Key())
+		numEntries++
+	}
+	return numEntries
+}
+
+// addExpiryEntriesToPurgeToBatch adds the deletes for all the expiry entries that are created by the blocks lower than maxBlkNum
+func (s *store) addExpiryEntriesToPurgeToBatch(batch *leveldbhelper.UpdateBatch, maxBlkNum uint64) error {
+	startKey, endKey := createRangeScanKeysForAllExpiryEntries()
+	itr := s.db.GetIterator(startKey, endKey)
+	defer itr.Release()
+	for itr.Next() {
+		expiryKey, err := decodeExpiryKey(itr.Key())
+		if err != nil {
+			return err
+		}
+		if expiryKey.committingBlk < maxBlkNum {
+			batch.Delete(itr.Key())
+		}
+	}
+	return nil
+}
+
+// addMissingDataEntriesToPurgeToBatch adds the deletes for both the eligible and the ineligible
+// missing data entries of the blocks lower than maxBlkNum
+func (s *store) addMissingDataEntriesToPurgeToBatch(batch *leveldbhelper.UpdateBatch, maxBlkNum uint64) {
+	startKey, endKey := createRangeScanKeysForEligibleMissingDataBelow(maxBlkNum)
+	elgItr := s.db.GetIterator(startKey, endKey)
+	for elgItr.Next() {
+		batch.Delete(elgItr.Key())
+	}
+	elgItr.Release()
+
+	startKey, endKey = createRangeScanKeysForAllIneligibleMissingData()
+	inelgItr := s.db.GetIterator(startKey, endKey)
+	defer inelgItr.Release()
+	for inelgItr.Next() {
+		if decodeMissingDataKey(inelgItr.Key()).blkNum < maxBlkNum {
+			batch.Delete(inelgItr.Key())
+		}
+	}
+}
+
+// MinRetainedBlockNum implements the function in the interface `Store`
+func (s *store) MinRetainedBlockNum() (uint64, error) {
+	return atomic.LoadUint64(&s.minRetainedBlock), nil
+}
+
 // LastCommittedBlockHeight implements the function in the interface `Store`
 func (s *store) LastCommittedBlockHeight() (uint64, error) {
 	if s.isEmpty {
@@ -931,6 +1055,14 @@ func (s *store) getLastCommittedBlockNum() (bool, uint64, error) {
 	return false, decodeLastCommittedBlockVal(v), nil
 }
 
+func (s *store) getMinRetainedBlockNum() (uint64, error) {
+	v, err := s.db.Get(minRetainedBlkKey)
+	if v == nil || err != nil {
+		return 0, err
+	}
+	return decodeMinRetainedBlockVal(v), nil
+}
+
 type collElgProcSync struct {
 	notification, procComplete chan bool
 }
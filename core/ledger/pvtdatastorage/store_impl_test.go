@@ -623,6 +623,73 @@ func TestStorePurge(t *testing.T) {
 	assert.True(testDataKeyExists(t, s, &dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-2", blkNum: 1}, txNum: 2}))
 }
 
+func TestStorePurgeBelowBlockNum(t *testing.T) {
+	ledgerid := "TestStorePurgeBelowBlockNum"
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+			{"ns-1", "coll-2"}: 10,
+		},
+	)
+	env := NewTestStoreEnv(t, ledgerid, btlPolicy)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	s := env.TestStore
+
+	_, ok := s.Purge(1).(*ErrIllegalCall)
+	assert.True(ok)
+
+	assert.NoError(s.Prepare(0, nil, nil))
+	assert.NoError(s.Commit())
+	for blkNum := uint64(1); blkNum <= 3; blkNum++ {
+		missingData := make(ledger.TxMissingPvtDataMap)
+		missingData.Add(1, "ns-1", "coll-1", true)
+		missingData.Add(1, "ns-1", "coll-2", false)
+		testData := []*ledger.TxPvtData{
+			produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2"}),
+		}
+		assert.NoError(s.Prepare(blkNum, testData, missingData))
+		assert.NoError(s.Commit())
+	}
+	minBlkNum, err := s.MinRetainedBlockNum()
+	assert.NoError(err)
+	assert.Equal(uint64(0), minBlkNum)
+
+	_, ok = s.Purge(4).(*ErrIllegalArgs)
+	assert.True(ok)
+
+	assert.NoError(s.Purge(3))
+	for blkNum := uint64(1); blkNum <= 3; blkNum++ {
+		retained := blkNum == 3
+		assert.Equal(retained, testDataKeyExists(t, s, &dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-1", blkNum: blkNum}, txNum: 2}))
+		assert.Equal(retained, testDataKeyExists(t, s, &dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-2", blkNum: blkNum}, txNum: 2}))
+		assert.Equal(retained, testMissingDataKeyExists(t, s, &missingDataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-1", blkNum: blkNum}, isEligible: true}))
+		assert.Equal(retained, testMissingDataKeyExists(t, s, &missingDataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-2", blkNum: blkNum}, isEligible: false}))
+		expiryEntry, err := s.(*store).getExpiryDataOfExpiryKey(&expiryKey{expiringBlk: blkNum + 11, committingBlk: blkNum})
+		assert.NoError(err)
+		assert.Equal(retained, expiryEntry != nil)
+	}
+	pvtdata, err := s.GetPvtDataByBlockNum(2, nil)
+	assert.NoError(err)
+	assert.Nil(pvtdata)
+	pvtdata, err = s.GetPvtDataByBlockNum(3, nil)
+	assert.NoError(err)
+	assert.Len(pvtdata, 1)
+
+	missingDataInfo, err := s.GetMissingPvtDataInfoForMostRecentBlocks(10)
+	assert.NoError(err)
+	assert.Len(missingDataInfo, 1)
+	assert.Contains(missingDataInfo, uint64(3))
+
+	// purging below a lower block number is a noop and the min retained block number survives a restart
+	assert.NoError(s.Purge(2))
+	env.CloseAndReopen()
+	s = env.TestStore
+	minBlkNum, err = s.MinRetainedBlockNum()
+	assert.NoError(err)
+	assert.Equal(uint64(3), minBlkNum)
+}
+
 func TestStoreState(t *testing.T) {
 	btlPolicy := btltestutil.SampleBTLPolicy(
 		map[[2]string]uint64{
@@ -670,6 +737,58 @@ func TestInitLastCommittedBlock(t *testing.T) {
 	assert.True(ok)
 }
 
+func TestAddMissingPvtDataOfOldBlocks(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+			{"ns-1", "coll-2"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestAddMissingPvtDataOfOldBlocks", btlPolicy)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	store := env.TestStore
+
+	missingPvtData := make(ledger.TxMissingPvtDataMap)
+	missingPvtData.Add(1, "ns-1", "coll-1", true)
+	err := store.AddMissingPvtDataOfOldBlocks(map[uint64]ledger.TxMissingPvtDataMap{3: missingPvtData})
+	_, ok := err.(*ErrIllegalArgs)
+	assert.True(ok)
+
+	assert.NoError(store.InitLastCommittedBlock(10))
+	blk3MissingData := make(ledger.TxMissingPvtDataMap)
+	blk3MissingData.Add(1, "ns-1", "coll-1", true)
+	blk3MissingData.Add(2, "ns-1", "coll-2", false)
+	blk7MissingData := make(ledger.TxMissingPvtDataMap)
+	blk7MissingData.Add(0, "ns-1", "coll-1", true)
+	blk7MissingData.Add(0, "ns-1", "coll-2", false)
+	assert.NoError(store.AddMissingPvtDataOfOldBlocks(map[uint64]ledger.TxMissingPvtDataMap{
+		3: blk3MissingData,
+		7: blk7MissingData,
+	}))
+
+	// only the missing data of the eligible collections is reported
+	expectedMissingPvtDataInfo := make(ledger.MissingPvtDataInfo)
+	expectedMissingPvtDataInfo.Add(3, 1, "ns-1", "coll-1")
+	expectedMissingPvtDataInfo.Add(7, 0, "ns-1", "coll-1")
+	missingPvtDataInfo, err := store.GetMissingPvtDataInfoForMostRecentBlocks(10)
+	assert.NoError(err)
+	assert.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
+
+	// the missing data of the ineligible collections is reported once the peer becomes eligible
+	assert.NoError(store.ProcessCollsEligibilityEnabled(10, map[string][]string{"ns-1": {"coll-2"}}))
+	testutilWaitForCollElgProcToFinish(store)
+	expectedMissingPvtDataInfo.Add(3, 2, "ns-1", "coll-2")
+	expectedMissingPvtDataInfo.Add(7, 0, "ns-1", "coll-2")
+	missingPvtDataInfo, err = store.GetMissingPvtDataInfoForMostRecentBlocks(10)
+	assert.NoError(err)
+	assert.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
+
+	err = store.AddMissingPvtDataOfOldBlocks(map[uint64]ledger.TxMissingPvtDataMap{11: missingPvtData})
+	_, ok = err.(*ErrIllegalArgs)
+	assert.True(ok)
+}
+
 func TestCollElgEnabled(t *testing.T) {
 	testCollElgEnabled(t)
 	defaultValBatchSize := ledgerconfig.GetPvtdataStoreCollElgProcMaxDbBatchSize()
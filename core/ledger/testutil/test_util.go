@@ -81,6 +81,8 @@ func ResetConfigToDefaultValues() {
 	viper.Set("ledger.state.couchDBConfig.autoWarmIndexes", true)
 	viper.Set("ledger.state.couchDBConfig.warmIndexesAfterNBlocks", 1)
 	viper.Set("peer.fileSystemPath", "/var/hyperledger/production")
+	viper.Set("ledger.snapshots.rootDir", "")
+	viper.Set("ledger.blockchain.compression", "none")
 }
 
 // ParseTestParams parses tests params
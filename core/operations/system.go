@@ -144,6 +144,13 @@ func (s *System) RegisterChecker(component string, checker healthz.HealthChecker
 	return s.healthHandler.RegisterChecker(component, checker)
 }
 
+// RegisterHandler registers into the ServeMux a handler chain that borrows its security properties from the
+// operations.System. This method is thread safe because ServeMux.Handle() is thread safe, and options are immutable.
+// This method can be called either before or after System.Start(). If the pattern exists the method panics.
+func (s *System) RegisterHandler(pattern string, handler http.Handler, secure bool) {
+	s.mux.Handle(pattern, s.handlerChain(handler, secure))
+}
+
 func (s *System) initializeServer() {
 	s.mux = http.NewServeMux()
 	s.httpServer = &http.Server{
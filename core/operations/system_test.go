@@ -104,6 +104,22 @@ var _ = Describe("System", func() {
 		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
 	})
 
+	It("hosts a secure endpoint for additional APIs when added", func() {
+		system.RegisterHandler("/fakeAPI", &fakeHandler{}, options.TLS.Enabled)
+		err := system.Start()
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeAPIURL := fmt.Sprintf("https://%s/fakeAPI", system.Addr())
+		resp, err := client.Get(fakeAPIURL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		resp.Body.Close()
+
+		resp, err = unauthClient.Get(fakeAPIURL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+	})
+
 	Context("when TLS is disabled", func() {
 		BeforeEach(func() {
 			options.TLS.Enabled = false
@@ -409,3 +425,9 @@ var _ = Describe("System", func() {
 		})
 	})
 })
+
+type fakeHandler struct{}
+
+func (h *fakeHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	resp.WriteHeader(http.StatusOK)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/common/deliver"
 	"github.com/hyperledger/fabric/common/flogging"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	fileledger "github.com/hyperledger/fabric/common/ledger/blockledger/file"
 	"github.com/hyperledger/fabric/common/metrics"
@@ -54,7 +55,8 @@ var peerServers []*comm.GRPCServer
 var configTxProcessor = newConfigTxProcessor()
 var tokenTxProcessor = &transaction.Processor{
 	TMSManager: &manager.Manager{
-		IdentityDeserializerManager: &manager.FabricIdentityDeserializerManager{}}}
+		IdentityDeserializerManager: &manager.FabricIdentityDeserializerManager{},
+		SupplyPolicyManager:         &manager.ChannelConfigSupplyPolicyManager{ChannelConfig: GetChannelConfig}}}
 var ConfigTxProcessors = customtx.Processors{
 	common.HeaderType_CONFIG:            configTxProcessor,
 	common.HeaderType_TOKEN_TRANSACTION: tokenTxProcessor,
@@ -225,6 +227,9 @@ var chainInitializer func(string)
 
 var pluginMapper txvalidator.PluginMapper
 
+// ccInfoProvider provides the collections of the chaincodes
+var ccInfoProvider ledger.DeployedChaincodeInfoProvider
+
 var mockMSPIDGetter func(string) []string
 
 func MockSetMSPIDGetter(mspIDGetter func(string) []string) {
@@ -248,6 +253,7 @@ func Initialize(init func(string), ccp ccprovider.ChaincodeProvider, sccp sysccp
 	validationWorkersSemaphore = semaphore.NewWeighted(int64(nWorkers))
 
 	pluginMapper = pm
+	ccInfoProvider = deployedCCInfoProvider
 	chainInitializer = init
 
 	var cb *common.Block
@@ -270,7 +276,13 @@ func Initialize(init func(string), ccp ccprovider.ChaincodeProvider, sccp sysccp
 			peerLogger.Debugf("Error while loading ledger %s with message %s. We continue to the next ledger rather than abort.", cid, err)
 			continue
 		}
-		if cb, err = getCurrConfigBlockFromLedger(ledger); err != nil {
+		if cb, err = getCurrConfigBlockFromLedger(ledger); isBlockPruned(err) {
+			// the ledger created from a snapshot does not contain the config block until the next
+			// config block is committed. The channel config is loaded from the state in this case
+			peerLogger.Infof("Config block of ledger %s has been pruned, loading the channel config from the state", cid)
+			cb, err = nil, nil
+		}
+		if err != nil {
 			peerLogger.Errorf("Failed to find config block on ledger %s(%s)", cid, err)
 			peerLogger.Debugf("Error while looking for config block on ledger %s with message %s. We continue to the next ledger rather than abort.", cid, err)
 			continue
@@ -324,7 +336,13 @@ func getCurrConfigBlockFromLedger(ledger ledger.PeerLedger) (*common.Block, erro
 	return configBlock, nil
 }
 
-// createChain creates a new chain object and insert it into the chains
+func isBlockPruned(err error) bool {
+	_, ok := errors.Cause(err).(*blkstorage.ErrBlockPruned)
+	return ok
+}
+
+// createChain creates a new chain object and insert it into the chains.
+// The config block cb may be nil only if the channel config is persisted in the state
 func createChain(
 	cid string,
 	ledger ledger.PeerLedger,
@@ -347,6 +365,9 @@ func createChain(
 			return err
 		}
 	} else {
+		if cb == nil {
+			return errors.Errorf("channel config for channel %s is neither found in the state nor supplied", cid)
+		}
 		// Config was only stored in the statedb starting with v1.1 binaries
 		// so if the config is not found there, extract it manually from the config block
 		envelopeConfig, err := utils.ExtractEnvelope(cb, 0)
@@ -480,7 +501,7 @@ func createChain(
 	csStoreSupport := &CollectionSupport{
 		PeerLedger: ledger,
 	}
-	simpleCollectionStore := privdata.NewSimpleCollectionStore(csStoreSupport)
+	simpleCollectionStore := privdata.NewSimpleCollectionStore(csStoreSupport, ccInfoProvider)
 
 	oac := service.OrdererAddressConfig{
 		Addresses:        ordererAddresses,
@@ -523,6 +544,21 @@ func CreateChainFromBlock(cb *common.Block, ccp ccprovider.ChaincodeProvider, sc
 	return createChain(cid, l, cb, ccp, sccp, pluginMapper)
 }
 
+// CreateChainFromSnapshot creates a new chain from the ledger snapshot present in the given dir and
+// returns the chain ID. As the ledger contains only the last block of the snapshot, the current config
+// block of the chain is not available until the next config block is committed
+func CreateChainFromSnapshot(snapshotDir string, ccp ccprovider.ChaincodeProvider, sccp sysccprovider.SystemChaincodeProvider) (string, error) {
+	l, cid, err := ledgermgmt.CreateLedgerFromSnapshot(snapshotDir)
+	if err != nil {
+		return "", errors.WithMessage(err, "cannot create ledger from snapshot")
+	}
+	cb, err := getCurrConfigBlockFromLedger(l)
+	if err != nil && !isBlockPruned(err) {
+		return "", err
+	}
+	return cid, createChain(cid, l, cb, ccp, sccp, pluginMapper)
+}
+
 // GetLedger returns the ledger of the chain with chain ID. Note that this
 // call returns nil if chain cid has not been created.
 func GetLedger(cid string) ledger.PeerLedger {
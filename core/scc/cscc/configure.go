@@ -13,6 +13,7 @@ package cscc
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/channelconfig"
@@ -73,10 +74,12 @@ var cnflogger = flogging.MustGetLogger("cscc")
 // These are function names from Invoke first parameter
 const (
 	JoinChain                string = "JoinChain"
+	JoinChainBySnapshot      string = "JoinChainBySnapshot"
 	GetConfigBlock           string = "GetConfigBlock"
 	GetChannels              string = "GetChannels"
 	GetConfigTree            string = "GetConfigTree"
 	SimulateConfigTreeUpdate string = "SimulateConfigTreeUpdate"
+	SubmitSnapshotRequest    string = "SubmitSnapshotRequest"
 )
 
 // Init is mostly useless from an SCC perspective
@@ -93,7 +96,9 @@ func (e *PeerConfiger) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # args[0] is the function name, which must be JoinChain, GetConfigBlock or
 // UpdateConfigBlock
 // # args[1] is a configuration Block if args[0] is JoinChain or
-// UpdateConfigBlock; otherwise it is the chain id
+// UpdateConfigBlock, the path of a ledger snapshot dir if args[0] is
+// JoinChainBySnapshot; otherwise it is the chain id
+// # args[2] is the block number to snapshot if args[0] is SubmitSnapshotRequest
 // TODO: Improve the scc interface to avoid marshal/unmarshal args
 func (e *PeerConfiger) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
@@ -162,6 +167,30 @@ func (e *PeerConfiger) InvokeNoShim(args [][]byte, sp *pb.SignedProposal) pb.Res
 		}
 
 		return joinChain(cid, block, e.ccp, e.sccp)
+	case JoinChainBySnapshot:
+		if len(args[1]) == 0 {
+			return shim.Error("Cannot join the channel, no snapshot dir provided")
+		}
+
+		// 2. check local MSP Admins policy
+		// TODO: move to ACLProvider once it will support chainless ACLs
+		if err = e.policyChecker.CheckPolicyNoChannel(mgmt.Admins, sp); err != nil {
+			return shim.Error(fmt.Sprintf("access denied for [%s][%s]: [%s]", fname, args[1], err))
+		}
+
+		return joinChainBySnapshot(string(args[1]), e.ccp, e.sccp)
+	case SubmitSnapshotRequest:
+		if len(args) < 3 {
+			return shim.Error(fmt.Sprintf("Incorrect number of arguments, %d", len(args)))
+		}
+
+		// 2. check local MSP Admins policy, as the snapshot is generated on the peer's file system
+		// TODO: move to ACLProvider once it will support chainless ACLs
+		if err = e.policyChecker.CheckPolicyNoChannel(mgmt.Admins, sp); err != nil {
+			return shim.Error(fmt.Sprintf("access denied for [%s][%s]: [%s]", fname, args[1], err))
+		}
+
+		return submitSnapshotRequest(args[1], args[2])
 	case GetConfigBlock:
 		// 2. check policy
 		if err = e.aclProvider.CheckACL(resources.Cscc_GetConfigBlock, string(args[1]), sp); err != nil {
@@ -242,6 +271,42 @@ func joinChain(chainID string, block *common.Block, ccp ccprovider.ChaincodeProv
 	return shim.Success(nil)
 }
 
+// joinChainBySnapshot will join the chain of the ledger snapshot present in the
+// specified dir. The ledger is created from the snapshot and the peer commits the
+// blocks of the chain starting from the block next to the last block of the snapshot
+func joinChainBySnapshot(snapshotDir string, ccp ccprovider.ChaincodeProvider, sccp sysccprovider.SystemChaincodeProvider) pb.Response {
+	chainID, err := peer.CreateChainFromSnapshot(snapshotDir, ccp, sccp)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	peer.InitChain(chainID)
+
+	return shim.Success(nil)
+}
+
+// submitSnapshotRequest submits a request to generate a snapshot of the ledger of the
+// specified chain at the specified block number. A block number of 0 stands for the
+// last committed block, whose snapshot is generated immediately
+func submitSnapshotRequest(chainID []byte, blockNumber []byte) pb.Response {
+	if chainID == nil {
+		return shim.Error("ChainID must not be nil.")
+	}
+	blockNum, err := strconv.ParseUint(string(blockNumber), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Invalid block number [%s]: %s", blockNumber, err))
+	}
+	ledger := peer.GetLedger(string(chainID))
+	if ledger == nil {
+		return shim.Error(fmt.Sprintf("Unknown chain ID, %s", string(chainID)))
+	}
+	if err := ledger.SubmitSnapshotRequest(blockNum); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(nil)
+}
+
 // Return the current configuration block for the specified chainID. If the
 // peer doesn't belong to the chain, return error
 func getConfigBlock(chainID []byte) pb.Response {
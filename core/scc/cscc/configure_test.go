@@ -199,6 +199,107 @@ func TestConfigerInvokeJoinChainWrongParams(t *testing.T) {
 	}
 }
 
+func TestConfigerInvokeJoinChainBySnapshot(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/hyperledgertest/")
+	os.Mkdir("/tmp/hyperledgertest", 0755)
+	defer os.RemoveAll("/tmp/hyperledgertest/")
+
+	ledgermgmt.InitializeTestEnv()
+	defer ledgermgmt.CleanupTestEnv()
+
+	e := New(nil, nil, mockAclProvider)
+	stub := shim.NewMockStub("PeerConfiger", e)
+	identityDeserializer := &policymocks.MockIdentityDeserializer{
+		Identity: []byte("Alice"),
+		Msg:      []byte("msg1"),
+	}
+	e.policyChecker = policy.NewPolicyChecker(
+		&policymocks.MockChannelPolicyManagerGetter{},
+		identityDeserializer,
+		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	)
+	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
+	identityDeserializer.Msg = sProp.ProposalBytes
+	sProp.Signature = sProp.ProposalBytes
+
+	// Failed path: empty snapshot dir
+	res := stub.MockInvokeWithSignedProposal("2", [][]byte{[]byte("JoinChainBySnapshot"), nil}, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "Cannot join the channel, no snapshot dir provided", res.Message)
+
+	// Failed path: the snapshot dir does not contain a snapshot
+	res = stub.MockInvokeWithSignedProposal("3", [][]byte{[]byte("JoinChainBySnapshot"), []byte("/non-existent-dir")}, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "cannot create ledger from snapshot")
+
+	// Failed path: the creator does not satisfy the local MSP Admins policy
+	sProp.Signature = nil
+	res = stub.MockInvokeWithSignedProposal("4", [][]byte{[]byte("JoinChainBySnapshot"), []byte("/non-existent-dir")}, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "access denied for [JoinChainBySnapshot][/non-existent-dir]")
+}
+
+func TestConfigerInvokeSubmitSnapshotRequest(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/hyperledgertest/")
+	os.Mkdir("/tmp/hyperledgertest", 0755)
+	defer os.RemoveAll("/tmp/hyperledgertest/")
+
+	peer.MockInitialize()
+	defer ledgermgmt.CleanupTestEnv()
+	assert.NoError(t, peer.MockCreateChain("mytestchainid"))
+
+	e := New(nil, nil, mockAclProvider)
+	stub := shim.NewMockStub("PeerConfiger", e)
+	identityDeserializer := &policymocks.MockIdentityDeserializer{
+		Identity: []byte("Alice"),
+		Msg:      []byte("msg1"),
+	}
+	e.policyChecker = policy.NewPolicyChecker(
+		&policymocks.MockChannelPolicyManagerGetter{},
+		identityDeserializer,
+		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	)
+	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
+	identityDeserializer.Msg = sProp.ProposalBytes
+	sProp.Signature = sProp.ProposalBytes
+
+	// Failed path: no block number
+	res := stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte("SubmitSnapshotRequest"), []byte("mytestchainid")}, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "Incorrect number of arguments, 2", res.Message)
+
+	// Failed path: invalid block number
+	res = stub.MockInvokeWithSignedProposal("2", [][]byte{[]byte("SubmitSnapshotRequest"), []byte("mytestchainid"), []byte("ten")}, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "Invalid block number [ten]")
+
+	// Failed path: unknown chain
+	res = stub.MockInvokeWithSignedProposal("3", [][]byte{[]byte("SubmitSnapshotRequest"), []byte("unknownchainid"), []byte("10")}, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "Unknown chain ID, unknownchainid", res.Message)
+
+	// Happy path: the request for a future block is recorded
+	res = stub.MockInvokeWithSignedProposal("4", [][]byte{[]byte("SubmitSnapshotRequest"), []byte("mytestchainid"), []byte("10")}, sProp)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	pendingRequests, err := peer.GetLedger("mytestchainid").PendingSnapshotRequests()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{10}, pendingRequests)
+
+	// Failed path: the ledger rejects the request
+	res = stub.MockInvokeWithSignedProposal("5", [][]byte{[]byte("SubmitSnapshotRequest"), []byte("mytestchainid"), []byte("10")}, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "duplicate snapshot request for block number [10]", res.Message)
+
+	// Failed path: the creator does not satisfy the local MSP Admins policy
+	sProp.Signature = nil
+	res = stub.MockInvokeWithSignedProposal("6", [][]byte{[]byte("SubmitSnapshotRequest"), []byte("mytestchainid"), []byte("11")}, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "access denied for [SubmitSnapshotRequest][mytestchainid]")
+	pendingRequests, err = peer.GetLedger("mytestchainid").PendingSnapshotRequests()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{10}, pendingRequests)
+}
+
 func TestConfigerInvokeJoinChainCorrectParams(t *testing.T) {
 	mp := (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider()
 	ccp := &ccprovidermocks.MockCcProviderImpl{}
@@ -235,6 +336,7 @@ func TestConfigerInvokeJoinChainCorrectParams(t *testing.T) {
 				inproccontroller.ContainerType: inproccontroller.NewRegistry(),
 			},
 		),
+		nil,
 		mp,
 		platforms.NewRegistry(&golang.Platform{}),
 		peer.DefaultSupport,
@@ -271,7 +373,7 @@ func TestConfigerInvokeJoinChainCorrectParams(t *testing.T) {
 	require.NoError(t, err)
 
 	identity, _ := mgmt.GetLocalSigningIdentityOrPanic().Serialize()
-	messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager())
+	messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager(), nil)
 	secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager())
 	var defaultSecureDialOpts = func() []grpc.DialOption {
 		var dialOpts []grpc.DialOption
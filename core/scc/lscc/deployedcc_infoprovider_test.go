@@ -85,6 +85,10 @@ func TestCollectionInfo(t *testing.T) {
 	collInfo3, err := ccInfoProvdier.CollectionInfo("cc2", "non-existing-coll-in-cc2", mockQE)
 	assert.NoError(t, err)
 	assert.Nil(t, collInfo3)
+
+	collInfo4, err := ccInfoProvdier.CollectionInfo("+lifecycle", "_implicit_org_Org1MSP", mockQE)
+	assert.NoError(t, err)
+	assert.Equal(t, privdata.GenerateImplicitCollectionForOrg("Org1MSP"), collInfo4)
 }
 
 func prepareMockQE(t *testing.T, deployedChaincodes []*ledger.DeployedChaincodeInfo) *mock.QueryExecutor {
@@ -7,11 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package blockcutter
 
 import (
+	"math"
 	"time"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
 )
 
 var logger = flogging.MustGetLogger("orderer.common.blockcutter")
@@ -39,6 +41,21 @@ type receiver struct {
 	PendingBatchStartTime time.Time
 	ChannelID             string
 	Metrics               *Metrics
+	FairQueuing           bool
+
+	// With fair queuing, the pending messages are held in the queue of the organization
+	// of their creator, and pendingBatchSizeBytes is the size of all the queued messages
+	queues      map[string][]*queuedMessage
+	queuedCount uint32
+	// mspIDs lists the organizations with queued messages, in round robin order
+	mspIDs    []string
+	nextMSPID int
+}
+
+// queuedMessage is a message waiting in the queue of its organization
+type queuedMessage struct {
+	envelope *cb.Envelope
+	deferred bool
 }
 
 // NewReceiverImpl creates a Receiver implementation based on the given configtxorderer manager
@@ -50,6 +67,20 @@ func NewReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher,
 	}
 }
 
+// NewFairQueuingReceiverImpl creates a Receiver implementation which queues the messages of each
+// organization separately, and fills each batch by taking in turn the oldest message of each queue,
+// so that an organization submitting many messages cannot push back the messages of the others
+// to later batches
+func NewFairQueuingReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher, metrics *Metrics) Receiver {
+	return &receiver{
+		sharedConfigFetcher: sharedConfigFetcher,
+		Metrics:             metrics,
+		ChannelID:           channelID,
+		FairQueuing:         true,
+		queues:              map[string][]*queuedMessage{},
+	}
+}
+
 // Ordered should be invoked sequentially as messages are ordered
 //
 // messageBatches length: 0, pending: false
@@ -66,8 +97,11 @@ func NewReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher,
 //   - impossible
 //
 // Note that messageBatches can not be greater than 2.
+//
+// With fair queuing, a batch is only cut once the pending messages no longer fit in a single batch,
+// and the messages left out of the batch are deferred to the next one, hence pending is then always true.
 func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, pending bool) {
-	if len(r.pendingBatch) == 0 {
+	if len(r.pendingBatch) == 0 && r.queuedCount == 0 {
 		// We are beginning a new batch, mark the time
 		r.PendingBatchStartTime = time.Now()
 	}
@@ -84,7 +118,7 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 		logger.Debugf("The current message, with %v bytes, is larger than the preferred batch size of %v bytes and will be isolated.", messageSizeBytes, batchSize.PreferredMaxBytes)
 
 		// cut pending batch, if it has any messages
-		if len(r.pendingBatch) > 0 {
+		if len(r.pendingBatch) > 0 || r.queuedCount > 0 {
 			messageBatch := r.Cut()
 			messageBatches = append(messageBatches, messageBatch)
 		}
@@ -98,6 +132,10 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 		return
 	}
 
+	if r.FairQueuing {
+		return r.enqueue(msg, messageSizeBytes, batchSize.MaxMessageCount, batchSize.PreferredMaxBytes), true
+	}
+
 	messageWillOverflowBatchSizeBytes := r.pendingBatchSizeBytes+messageSizeBytes > batchSize.PreferredMaxBytes
 
 	if messageWillOverflowBatchSizeBytes {
@@ -125,6 +163,11 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 
 // Cut returns the current batch and starts a new one
 func (r *receiver) Cut() []*cb.Envelope {
+	if r.FairQueuing {
+		// Ordered cuts a batch as soon as the queued messages no longer fit in one,
+		// so all of them fit in the current batch
+		return r.cutQueues(math.MaxUint32, math.MaxUint32)
+	}
 	r.Metrics.BlockFillDuration.With("channel", r.ChannelID).Observe(time.Since(r.PendingBatchStartTime).Seconds())
 	r.PendingBatchStartTime = time.Time{}
 	batch := r.pendingBatch
@@ -133,6 +176,91 @@ func (r *receiver) Cut() []*cb.Envelope {
 	return batch
 }
 
+// enqueue appends the message to the queue of its organization, and cuts a batch from the queues
+// while the queued messages do not fit in a single batch
+func (r *receiver) enqueue(msg *cb.Envelope, messageSizeBytes, maxMessageCount, preferredMaxBytes uint32) [][]*cb.Envelope {
+	mspID := creatorMSPID(msg)
+	if len(r.queues[mspID]) == 0 {
+		r.mspIDs = append(r.mspIDs, mspID)
+	}
+	logger.Debugf("Enqueuing message into the queue of organization '%s'", mspID)
+	r.queues[mspID] = append(r.queues[mspID], &queuedMessage{envelope: msg})
+	r.queuedCount++
+	r.pendingBatchSizeBytes += messageSizeBytes
+
+	var messageBatches [][]*cb.Envelope
+	for r.queuedCount > maxMessageCount || r.pendingBatchSizeBytes > preferredMaxBytes {
+		logger.Debugf("Queued messages do not fit in a batch, cutting batch now.")
+		messageBatches = append(messageBatches, r.cutQueues(maxMessageCount, preferredMaxBytes))
+		r.PendingBatchStartTime = time.Now()
+	}
+	return messageBatches
+}
+
+// cutQueues fills a batch by taking in turn the oldest message of the queue of each organization,
+// skipping the organizations whose oldest message does not fit in the batch anymore. The messages
+// which remain in the queues are deferred to the next batch. As the batch depends only on the order
+// of the messages, all the orderers cut the same blocks
+func (r *receiver) cutQueues(maxMessageCount, preferredMaxBytes uint32) []*cb.Envelope {
+	r.Metrics.BlockFillDuration.With("channel", r.ChannelID).Observe(time.Since(r.PendingBatchStartTime).Seconds())
+	r.PendingBatchStartTime = time.Time{}
+
+	var batch []*cb.Envelope
+	var batchSizeBytes uint32
+	for skipped := 0; uint32(len(batch)) < maxMessageCount && skipped < len(r.mspIDs); {
+		mspID := r.mspIDs[r.nextMSPID]
+		r.nextMSPID = (r.nextMSPID + 1) % len(r.mspIDs)
+
+		queue := r.queues[mspID]
+		if len(queue) == 0 || batchSizeBytes+messageSizeBytes(queue[0].envelope) > preferredMaxBytes {
+			skipped++
+			continue
+		}
+		skipped = 0
+		batch = append(batch, queue[0].envelope)
+		batchSizeBytes += messageSizeBytes(queue[0].envelope)
+		r.queues[mspID] = queue[1:]
+	}
+	r.queuedCount -= uint32(len(batch))
+	r.pendingBatchSizeBytes -= batchSizeBytes
+
+	// keep the round robin order, starting with the organization after the last one served
+	mspIDs := append(append([]string{}, r.mspIDs[r.nextMSPID:]...), r.mspIDs[:r.nextMSPID]...)
+	r.mspIDs = nil
+	r.nextMSPID = 0
+	for _, mspID := range mspIDs {
+		queue := r.queues[mspID]
+		if len(queue) == 0 {
+			delete(r.queues, mspID)
+			continue
+		}
+		r.mspIDs = append(r.mspIDs, mspID)
+
+		deferred := 0
+		for _, message := range queue {
+			if !message.deferred {
+				message.deferred = true
+				deferred++
+			}
+		}
+		if deferred > 0 {
+			r.Metrics.DeferredCount.With("channel", r.ChannelID, "msp", mspID).Add(float64(deferred))
+		}
+	}
+	return batch
+}
+
+// creatorMSPID returns the MSP ID of the creator of the message, or the empty string if the
+// creator cannot be determined
+func creatorMSPID(message *cb.Envelope) string {
+	sid, err := utils.Creator(message)
+	if err != nil {
+		logger.Debugf("Could not determine the creator of the message: %s", err)
+		return ""
+	}
+	return sid.Mspid
+}
+
 func messageSizeBytes(message *cb.Envelope) uint32 {
 	return uint32(len(message.Payload) + len(message.Signature))
 }
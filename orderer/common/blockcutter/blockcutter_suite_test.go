@@ -22,6 +22,11 @@ type metricsHistogram interface {
 	metrics.Histogram
 }
 
+//go:generate counterfeiter -o mock/metrics_counter.go --fake-name MetricsCounter . metricsCounter
+type metricsCounter interface {
+	metrics.Counter
+}
+
 //go:generate counterfeiter -o mock/metrics_provider.go --fake-name MetricsProvider . metricsProvider
 type metricsProvider interface {
 	metrics.Provider
@@ -7,13 +7,17 @@ SPDX-License-Identifier: Apache-2.0
 package blockcutter_test
 
 import (
+	"strings"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter/mock"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 )
 
 var _ = Describe("Blockcutter", func() {
@@ -183,4 +187,157 @@ var _ = Describe("Blockcutter", func() {
 			})
 		})
 	})
+
+	Describe("Cut", func() {
+		var (
+			fakeDeferredCount *mock.MetricsCounter
+		)
+
+		messageFrom := func(mspID string, data string) *cb.Envelope {
+			return &cb.Envelope{
+				Payload: utils.MarshalOrPanic(&cb.Payload{
+					Header: &cb.Header{
+						SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{
+							Creator: utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: mspID}),
+						}),
+					},
+					Data: []byte(data),
+				}),
+			}
+		}
+
+		BeforeEach(func() {
+			fakeConfig.BatchSizeReturns(&ab.BatchSize{
+				MaxMessageCount:   10,
+				PreferredMaxBytes: 1000,
+			})
+
+			fakeDeferredCount = &mock.MetricsCounter{}
+			fakeDeferredCount.WithReturns(fakeDeferredCount)
+			metrics.DeferredCount = fakeDeferredCount
+		})
+
+		It("returns the messages in their order of arrival", func() {
+			messages := []*cb.Envelope{messageFrom("Org1MSP", "1"), messageFrom("Org1MSP", "2"), messageFrom("Org2MSP", "3")}
+			for _, m := range messages {
+				bc.Ordered(m)
+			}
+			Expect(bc.Cut()).To(Equal(messages))
+			Expect(fakeDeferredCount.WithCallCount()).To(Equal(0))
+		})
+
+		Context("when fair queuing is enabled", func() {
+			BeforeEach(func() {
+				bc = blockcutter.NewFairQueuingReceiverImpl("mychannel", fakeConfigFetcher, metrics)
+			})
+
+			It("returns the queued messages, taking in turn a message from each organization", func() {
+				a1, a2, a3 := messageFrom("Org1MSP", "a1"), messageFrom("Org1MSP", "a2"), messageFrom("Org1MSP", "a3")
+				b1, b2 := messageFrom("Org2MSP", "b1"), messageFrom("Org2MSP", "b2")
+				c1 := &cb.Envelope{Payload: []byte("garbage")}
+				for _, m := range []*cb.Envelope{a1, a2, a3, b1, c1, b2} {
+					batches, pending := bc.Ordered(m)
+					Expect(batches).To(BeEmpty())
+					Expect(pending).To(BeTrue())
+				}
+
+				Expect(bc.Cut()).To(Equal([]*cb.Envelope{a1, b1, c1, a2, b2, a3}))
+				Expect(fakeDeferredCount.WithCallCount()).To(Equal(0))
+				Expect(bc.Cut()).To(BeEmpty())
+			})
+
+			It("defers the messages of an organization flooding the channel to the next batch", func() {
+				fakeConfig.BatchSizeReturns(&ab.BatchSize{
+					MaxMessageCount:   3,
+					PreferredMaxBytes: 1000,
+				})
+
+				a1, a2, a3, a4 := messageFrom("Org1MSP", "a1"), messageFrom("Org1MSP", "a2"), messageFrom("Org1MSP", "a3"), messageFrom("Org1MSP", "a4")
+				b1 := messageFrom("Org2MSP", "b1")
+				for _, m := range []*cb.Envelope{a1, a2, a3} {
+					batches, pending := bc.Ordered(m)
+					Expect(batches).To(BeEmpty())
+					Expect(pending).To(BeTrue())
+				}
+
+				batches, pending := bc.Ordered(b1)
+				Expect(batches).To(Equal([][]*cb.Envelope{{a1, b1, a2}}))
+				Expect(pending).To(BeTrue())
+				Expect(fakeDeferredCount.WithCallCount()).To(Equal(1))
+				Expect(fakeDeferredCount.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel", "msp", "Org1MSP"}))
+				Expect(fakeDeferredCount.AddCallCount()).To(Equal(1))
+				Expect(fakeDeferredCount.AddArgsForCall(0)).To(Equal(float64(1)))
+
+				batches, pending = bc.Ordered(a4)
+				Expect(batches).To(BeEmpty())
+				Expect(pending).To(BeTrue())
+				Expect(bc.Cut()).To(Equal([]*cb.Envelope{a3, a4}))
+				Expect(fakeDeferredCount.AddCallCount()).To(Equal(1))
+			})
+
+			It("counts the messages deferred at each cut", func() {
+				fakeConfig.BatchSizeReturns(&ab.BatchSize{
+					MaxMessageCount:   2,
+					PreferredMaxBytes: 1000,
+				})
+
+				a1, a2, a3 := messageFrom("Org1MSP", "a1"), messageFrom("Org1MSP", "a2"), messageFrom("Org1MSP", "a3")
+				b1, b2 := messageFrom("Org2MSP", "b1"), messageFrom("Org2MSP", "b2")
+				var batches [][]*cb.Envelope
+				for _, m := range []*cb.Envelope{a1, a2, b1, a3, b2} {
+					cut, _ := bc.Ordered(m)
+					batches = append(batches, cut...)
+				}
+
+				Expect(batches).To(Equal([][]*cb.Envelope{{a1, b1}, {a2, b2}}))
+				Expect(bc.Cut()).To(Equal([]*cb.Envelope{a3}))
+				Expect(fakeDeferredCount.AddCallCount()).To(Equal(2))
+				Expect(fakeDeferredCount.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel", "msp", "Org1MSP"}))
+				Expect(fakeDeferredCount.AddArgsForCall(0)).To(Equal(float64(1)))
+				Expect(fakeDeferredCount.WithArgsForCall(1)).To(Equal([]string{"channel", "mychannel", "msp", "Org1MSP"}))
+				Expect(fakeDeferredCount.AddArgsForCall(1)).To(Equal(float64(1)))
+			})
+
+			It("skips the organizations whose next message overflows the batch", func() {
+				a1 := messageFrom("Org1MSP", strings.Repeat("a", 50))
+				a2 := messageFrom("Org1MSP", strings.Repeat("a", 500))
+				b1, b2 := messageFrom("Org2MSP", "b1"), messageFrom("Org2MSP", "b2")
+				fakeConfig.BatchSizeReturns(&ab.BatchSize{
+					MaxMessageCount:   10,
+					PreferredMaxBytes: uint32(len(a1.Payload)+len(a2.Payload)+len(b1.Payload)) - 1,
+				})
+
+				var batches [][]*cb.Envelope
+				for _, m := range []*cb.Envelope{a1, b1, b2, a2} {
+					cut, pending := bc.Ordered(m)
+					Expect(pending).To(BeTrue())
+					batches = append(batches, cut...)
+				}
+
+				Expect(batches).To(Equal([][]*cb.Envelope{{a1, b1, b2}}))
+				Expect(bc.Cut()).To(Equal([]*cb.Envelope{a2}))
+			})
+
+			It("cuts the queued messages before isolating a large message", func() {
+				a1, b1 := messageFrom("Org1MSP", "a1"), messageFrom("Org2MSP", "b1")
+				large := messageFrom("Org1MSP", strings.Repeat("a", 1000))
+				bc.Ordered(a1)
+				bc.Ordered(b1)
+
+				batches, pending := bc.Ordered(large)
+				Expect(batches).To(Equal([][]*cb.Envelope{{a1, b1}, {large}}))
+				Expect(pending).To(BeFalse())
+				Expect(bc.Cut()).To(BeEmpty())
+			})
+
+			It("keeps the order of the messages of a single organization", func() {
+				messages := []*cb.Envelope{messageFrom("Org1MSP", "1"), messageFrom("Org1MSP", "2")}
+				for _, m := range messages {
+					bc.Ordered(m)
+				}
+				Expect(bc.Cut()).To(Equal(messages))
+				Expect(fakeDeferredCount.WithCallCount()).To(Equal(0))
+			})
+		})
+	})
 })
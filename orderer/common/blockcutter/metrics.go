@@ -16,14 +16,23 @@ var (
 		LabelNames:   []string{"channel"},
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
+	deferredCount = metrics.CounterOpts{
+		Namespace:    "blockcutter",
+		Name:         "deferred_count",
+		Help:         "The number of transactions deferred to a later block by fair queuing.",
+		LabelNames:   []string{"channel", "msp"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{msp}",
+	}
 )
 
 type Metrics struct {
 	BlockFillDuration metrics.Histogram
+	DeferredCount     metrics.Counter
 }
 
 func NewMetrics(p metrics.Provider) *Metrics {
 	return &Metrics{
 		BlockFillDuration: p.NewHistogram(blockFillDuration),
+		DeferredCount:     p.NewCounter(deferredCount),
 	}
 }
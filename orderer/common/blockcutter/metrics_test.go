@@ -23,14 +23,17 @@ var _ = Describe("Metrics", func() {
 		BeforeEach(func() {
 			fakeProvider = &mock.MetricsProvider{}
 			fakeProvider.NewHistogramReturns(&mock.MetricsHistogram{})
+			fakeProvider.NewCounterReturns(&mock.MetricsCounter{})
 		})
 
-		It("uses the provider to initialize its field", func() {
+		It("uses the provider to initialize all fields", func() {
 			metrics := blockcutter.NewMetrics(fakeProvider)
 			Expect(metrics).NotTo(BeNil())
 			Expect(metrics.BlockFillDuration).To(Equal(&mock.MetricsHistogram{}))
+			Expect(metrics.DeferredCount).To(Equal(&mock.MetricsCounter{}))
 
 			Expect(fakeProvider.NewHistogramCallCount()).To(Equal(1))
+			Expect(fakeProvider.NewCounterCallCount()).To(Equal(1))
 		})
 	})
 })
@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 )
 
@@ -60,6 +61,8 @@ type Consenter interface {
 type Handler struct {
 	SupportRegistrar ChannelSupportRegistrar
 	Metrics          *Metrics
+	// Throttler, if set, limits the rate of the messages accepted from each organization
+	Throttler Throttler
 }
 
 // Handle reads requests from a Broadcast stream, processes them, and returns the responses to the stream
@@ -167,6 +170,11 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 		}
 		tracker.EndValidate()
 
+		if err = bh.throttle(msg, chdr.ChannelId); err != nil {
+			logger.Warningf("[channel: %s] Rejecting broadcast of message from %s because of error: %s", chdr.ChannelId, addr, err)
+			return &ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()}
+		}
+
 		tracker.BeginEnqueue()
 		if err = processor.WaitReady(); err != nil {
 			logger.Warningf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: rejected by Consenter: %s", chdr.ChannelId, addr, err)
@@ -188,6 +196,11 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 		}
 		tracker.EndValidate()
 
+		if err = bh.throttle(msg, chdr.ChannelId); err != nil {
+			logger.Warningf("[channel: %s] Rejecting broadcast of message from %s because of error: %s", chdr.ChannelId, addr, err)
+			return &ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()}
+		}
+
 		tracker.BeginEnqueue()
 		if err = processor.WaitReady(); err != nil {
 			logger.Warningf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: rejected by Consenter: %s", chdr.ChannelId, addr, err)
@@ -206,8 +219,31 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 	return &ab.BroadcastResponse{Status: cb.Status_SUCCESS}
 }
 
+// throttle returns an error if the creator of the message exceeds the rate limits of the Throttler
+func (bh *Handler) throttle(msg *cb.Envelope, channelID string) error {
+	if bh.Throttler == nil {
+		return nil
+	}
+	sid, err := utils.Creator(msg)
+	if err != nil {
+		return errors.WithMessage(err, "could not determine the creator of the message to apply the rate limits")
+	}
+	err = bh.Throttler.Allow(sid.Mspid, sid.IdBytes)
+	if throttledErr, ok := err.(*ThrottledError); ok {
+		scope := "org"
+		if throttledErr.PerClient {
+			scope = "client"
+		}
+		bh.Metrics.ThrottledCount.With("channel", channelID, "msp", sid.Mspid, "scope", scope).Add(1)
+	}
+	return err
+}
+
 // ClassifyError converts an error type into a status code.
 func ClassifyError(err error) cb.Status {
+	if _, ok := errors.Cause(err).(*ThrottledError); ok {
+		return cb.Status_SERVICE_UNAVAILABLE
+	}
 	switch errors.Cause(err) {
 	case msgprocessor.ErrChannelDoesNotExist:
 		return cb.Status_NOT_FOUND
@@ -19,7 +19,9 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/broadcast/mock"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 )
 
 var _ = Describe("Broadcast", func() {
@@ -29,6 +31,7 @@ var _ = Describe("Broadcast", func() {
 		fakeValidateHistogram *mock.MetricsHistogram
 		fakeEnqueueHistogram  *mock.MetricsHistogram
 		fakeProcessedCounter  *mock.MetricsCounter
+		fakeThrottledCounter  *mock.MetricsCounter
 	)
 
 	BeforeEach(func() {
@@ -43,12 +46,16 @@ var _ = Describe("Broadcast", func() {
 		fakeProcessedCounter = &mock.MetricsCounter{}
 		fakeProcessedCounter.WithReturns(fakeProcessedCounter)
 
+		fakeThrottledCounter = &mock.MetricsCounter{}
+		fakeThrottledCounter.WithReturns(fakeThrottledCounter)
+
 		handler = &broadcast.Handler{
 			SupportRegistrar: fakeSupportRegistrar,
 			Metrics: &broadcast.Metrics{
 				ValidateDuration: fakeValidateHistogram,
 				EnqueueDuration:  fakeEnqueueHistogram,
 				ProcessedCount:   fakeProcessedCounter,
+				ThrottledCount:   fakeThrottledCounter,
 			},
 		}
 	})
@@ -276,6 +283,62 @@ var _ = Describe("Broadcast", func() {
 			})
 		})
 
+		Context("when the creator of the message exceeds the rate limit", func() {
+			BeforeEach(func() {
+				fakeMsg.Payload = utils.MarshalOrPanic(&cb.Payload{
+					Header: &cb.Header{
+						SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{
+							Creator: utils.MarshalOrPanic(&msp.SerializedIdentity{
+								Mspid:   "Org1MSP",
+								IdBytes: []byte("client-cert"),
+							}),
+						}),
+					},
+				})
+				fakeABServer.RecvReturnsOnCall(1, fakeMsg, nil)
+				fakeABServer.RecvReturnsOnCall(2, nil, io.EOF)
+				handler.Throttler = broadcast.NewRateLimiter(0.001, 1, 0, 0)
+			})
+
+			It("rejects the message with a service unavailable status", func() {
+				err := handler.Handle(fakeABServer)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeSupport.OrderCallCount()).To(Equal(1))
+				Expect(fakeABServer.SendCallCount()).To(Equal(2))
+				Expect(proto.Equal(fakeABServer.SendArgsForCall(0), &ab.BroadcastResponse{Status: cb.Status_SUCCESS})).To(BeTrue())
+				Expect(proto.Equal(
+					fakeABServer.SendArgsForCall(1),
+					&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: "broadcast rate limit exceeded for organization [Org1MSP]"},
+				)).To(BeTrue())
+
+				Expect(fakeThrottledCounter.WithCallCount()).To(Equal(1))
+				Expect(fakeThrottledCounter.WithArgsForCall(0)).To(Equal([]string{
+					"channel", "fake-channel",
+					"msp", "Org1MSP",
+					"scope", "org",
+				}))
+				Expect(fakeThrottledCounter.AddCallCount()).To(Equal(1))
+			})
+
+			Context("when the creator of the message cannot be determined", func() {
+				BeforeEach(func() {
+					fakeMsg.Payload = []byte("garbage")
+				})
+
+				It("rejects the message with a bad request status", func() {
+					err := handler.Handle(fakeABServer)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeSupport.OrderCallCount()).To(Equal(0))
+					Expect(fakeABServer.SendCallCount()).To(Equal(1))
+					resp := fakeABServer.SendArgsForCall(0)
+					Expect(resp.Status).To(Equal(cb.Status_BAD_REQUEST))
+					Expect(resp.Info).To(ContainSubstring("could not determine the creator of the message to apply the rate limits"))
+				})
+			})
+		})
+
 		Context("when the message is a config message", func() {
 			var (
 				fakeConfig *cb.Envelope
@@ -0,0 +1,149 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/util"
+)
+
+// Throttler limits the rate at which the clients of the organizations can broadcast messages
+type Throttler interface {
+	// Allow returns nil if a message from the client, identified by its certificate, of the organization,
+	// identified by its MSP ID, is within the rate limits and a *ThrottledError otherwise
+	Allow(mspID string, clientCert []byte) error
+}
+
+// ThrottledError is returned by a Throttler when a message exceeds the rate limit of the organization or the client
+type ThrottledError struct {
+	MSPID     string
+	PerClient bool
+}
+
+func (e *ThrottledError) Error() string {
+	if e.PerClient {
+		return fmt.Sprintf("broadcast rate limit exceeded for a client of organization [%s]", e.MSPID)
+	}
+	return fmt.Sprintf("broadcast rate limit exceeded for organization [%s]", e.MSPID)
+}
+
+// the buckets of the clients that are idle for this long are released
+const clientBucketsSweepInterval = time.Minute
+
+// RateLimiter is a Throttler that enforces a token bucket rate limit per organization
+// and, optionally, per client
+type RateLimiter struct {
+	orgRate     float64
+	orgBurst    int
+	clientRate  float64
+	clientBurst int
+	now         func() time.Time
+
+	mutex         sync.Mutex
+	orgBuckets    map[string]*tokenBucket
+	clientBuckets map[string]*tokenBucket
+	lastSweep     time.Time
+}
+
+// NewRateLimiter returns a RateLimiter that allows the clients of each organization to broadcast `orgRate` messages
+// per second, with bursts of up to `orgBurst` messages. If `clientRate` is greater than zero, each client is
+// additionally limited to `clientRate` messages per second, with bursts of up to `clientBurst` messages
+func NewRateLimiter(orgRate float64, orgBurst int, clientRate float64, clientBurst int) *RateLimiter {
+	return &RateLimiter{
+		orgRate:       orgRate,
+		orgBurst:      orgBurst,
+		clientRate:    clientRate,
+		clientBurst:   clientBurst,
+		now:           time.Now,
+		orgBuckets:    map[string]*tokenBucket{},
+		clientBuckets: map[string]*tokenBucket{},
+	}
+}
+
+// Allow implements function from interface Throttler. A message consumes a token from the bucket of the
+// organization and from the bucket of the client only if both the buckets have a token available
+func (r *RateLimiter) Allow(mspID string, clientCert []byte) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := r.now()
+	orgBucket, ok := r.orgBuckets[mspID]
+	if !ok {
+		orgBucket = newTokenBucket(r.orgRate, r.orgBurst, now)
+		r.orgBuckets[mspID] = orgBucket
+	}
+	orgBucket.refill(now)
+
+	var clientBucket *tokenBucket
+	if r.clientRate > 0 {
+		r.sweepIdleClientBuckets(now)
+		clientKey := string(util.ComputeSHA256(clientCert))
+		clientBucket, ok = r.clientBuckets[clientKey]
+		if !ok {
+			clientBucket = newTokenBucket(r.clientRate, r.clientBurst, now)
+			r.clientBuckets[clientKey] = clientBucket
+		}
+		clientBucket.refill(now)
+		if clientBucket.tokens < 1 {
+			return &ThrottledError{MSPID: mspID, PerClient: true}
+		}
+	}
+
+	if orgBucket.tokens < 1 {
+		return &ThrottledError{MSPID: mspID}
+	}
+	orgBucket.tokens--
+	if clientBucket != nil {
+		clientBucket.tokens--
+	}
+	return nil
+}
+
+// sweepIdleClientBuckets releases the buckets that have refilled completely, as a new bucket would be no different
+func (r *RateLimiter) sweepIdleClientBuckets(now time.Time) {
+	if now.Sub(r.lastSweep) < clientBucketsSweepInterval {
+		return
+	}
+	r.lastSweep = now
+	for clientKey, b := range r.clientBuckets {
+		b.refill(now)
+		if b.tokens >= b.burst {
+			delete(r.clientBuckets, clientKey)
+		}
+	}
+}
+
+type tokenBucket struct {
+	rate       float64
+	burst      float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		rate:       rate,
+		burst:      float64(burst),
+		tokens:     float64(burst),
+		lastRefill: now,
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.lastRefill)
+	if elapsed <= 0 {
+		return
+	}
+	b.lastRefill = now
+	b.tokens += elapsed.Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RateLimiter", func() {
+	var (
+		rateLimiter *RateLimiter
+		now         time.Time
+	)
+
+	BeforeEach(func() {
+		now = time.Unix(1000, 0)
+		rateLimiter = NewRateLimiter(2, 3, 0, 0)
+		rateLimiter.now = func() time.Time { return now }
+	})
+
+	It("allows bursts up to the burst size of the organization", func() {
+		for i := 0; i < 3; i++ {
+			Expect(rateLimiter.Allow("Org1MSP", []byte("cert1"))).To(Succeed())
+		}
+		Expect(rateLimiter.Allow("Org1MSP", []byte("cert2"))).To(MatchError(&ThrottledError{MSPID: "Org1MSP"}))
+	})
+
+	It("limits each organization independently", func() {
+		for i := 0; i < 3; i++ {
+			Expect(rateLimiter.Allow("Org1MSP", nil)).To(Succeed())
+		}
+		Expect(rateLimiter.Allow("Org1MSP", nil)).NotTo(Succeed())
+		Expect(rateLimiter.Allow("Org2MSP", nil)).To(Succeed())
+	})
+
+	It("refills the bucket of the organization at the configured rate", func() {
+		for i := 0; i < 3; i++ {
+			Expect(rateLimiter.Allow("Org1MSP", nil)).To(Succeed())
+		}
+		now = now.Add(500 * time.Millisecond)
+		Expect(rateLimiter.Allow("Org1MSP", nil)).To(Succeed())
+		Expect(rateLimiter.Allow("Org1MSP", nil)).NotTo(Succeed())
+
+		now = now.Add(time.Hour)
+		for i := 0; i < 3; i++ {
+			Expect(rateLimiter.Allow("Org1MSP", nil)).To(Succeed())
+		}
+		Expect(rateLimiter.Allow("Org1MSP", nil)).NotTo(Succeed())
+	})
+
+	Context("when the clients are rate limited", func() {
+		BeforeEach(func() {
+			rateLimiter = NewRateLimiter(2, 3, 1, 2)
+			rateLimiter.now = func() time.Time { return now }
+		})
+
+		It("limits each client of the organization independently", func() {
+			Expect(rateLimiter.Allow("Org1MSP", []byte("cert1"))).To(Succeed())
+			Expect(rateLimiter.Allow("Org1MSP", []byte("cert1"))).To(Succeed())
+			Expect(rateLimiter.Allow("Org1MSP", []byte("cert1"))).To(MatchError(&ThrottledError{MSPID: "Org1MSP", PerClient: true}))
+			Expect(rateLimiter.Allow("Org1MSP", []byte("cert2"))).To(Succeed())
+			Expect(rateLimiter.Allow("Org1MSP", []byte("cert2"))).To(MatchError(&ThrottledError{MSPID: "Org1MSP"}))
+		})
+
+		It("does not consume the token of the organization when the client is throttled", func() {
+			Expect(rateLimiter.Allow("Org1MSP", []byte("cert1"))).To(Succeed())
+			Expect(rateLimiter.Allow("Org1MSP", []byte("cert1"))).To(Succeed())
+			Expect(rateLimiter.Allow("Org1MSP", []byte("cert1"))).NotTo(Succeed())
+			Expect(rateLimiter.orgBuckets["Org1MSP"].tokens).To(Equal(float64(1)))
+		})
+
+		It("releases the buckets of the idle clients", func() {
+			Expect(rateLimiter.Allow("Org1MSP", []byte("cert1"))).To(Succeed())
+			now = now.Add(30 * time.Second)
+			Expect(rateLimiter.Allow("Org1MSP", []byte("cert2"))).To(Succeed())
+			Expect(rateLimiter.clientBuckets).To(HaveLen(2))
+
+			now = now.Add(time.Minute)
+			Expect(rateLimiter.Allow("Org1MSP", []byte("cert3"))).To(Succeed())
+			Expect(rateLimiter.clientBuckets).To(HaveLen(1))
+		})
+	})
+})
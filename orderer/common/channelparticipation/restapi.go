@@ -0,0 +1,262 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelparticipation
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// URLBaseV1 is the base path of version 1 of the channel participation API.
+	URLBaseV1 = "/participation/v1/"
+	// URLBaseV1Channels is the path of the channels resource.
+	URLBaseV1Channels = URLBaseV1 + "channels"
+	// FormDataConfigBlockKey is the key of the config block file in a multipart form join request.
+	FormDataConfigBlockKey = "config-block"
+
+	channelIDKey        = "channelID"
+	urlWithChannelIDKey = URLBaseV1Channels + "/{" + channelIDKey + "}"
+)
+
+var logger = flogging.MustGetLogger("orderer.commmon.channelparticipation")
+
+//go:generate counterfeiter -o mocks/channel_management.go -fake-name ChannelManagement . ChannelManagement
+
+// ChannelManagement is the interface of the registrar that the channel participation API relies upon.
+type ChannelManagement interface {
+	// ChannelList returns a slim list of channels.
+	ChannelList() types.ChannelList
+
+	// ChannelInfo provides extended status information about a channel.
+	ChannelInfo(channelID string) (types.ChannelInfo, error)
+
+	// JoinChannel instructs the orderer to create a channel and join it with the provided config block.
+	JoinChannel(channelID string, configBlock *cb.Block) (types.ChannelInfo, error)
+
+	// RemoveChannel instructs the orderer to remove a channel.
+	RemoveChannel(channelID string) error
+}
+
+// Error is the body of an HTTP error response of the channel participation API.
+type Error struct {
+	Error string `json:"error"`
+}
+
+// HTTPHandler handles the requests of the channel participation API, which lists, joins, and
+// removes the channels of an orderer that is not bound to a system channel.
+type HTTPHandler struct {
+	logger    *flogging.FabricLogger
+	config    localconfig.ChannelParticipation
+	registrar ChannelManagement
+	router    *mux.Router
+}
+
+// NewHTTPHandler creates an HTTPHandler that serves the channel participation API with the given registrar.
+func NewHTTPHandler(config localconfig.ChannelParticipation, registrar ChannelManagement) *HTTPHandler {
+	handler := &HTTPHandler{
+		logger:    logger,
+		config:    config,
+		registrar: registrar,
+		router:    mux.NewRouter(),
+	}
+
+	handler.router.HandleFunc(urlWithChannelIDKey, handler.serveListOne).Methods(http.MethodGet)
+	handler.router.HandleFunc(urlWithChannelIDKey, handler.serveRemove).Methods(http.MethodDelete)
+	handler.router.HandleFunc(urlWithChannelIDKey, handler.serveNotAllowed("GET, DELETE"))
+
+	handler.router.HandleFunc(URLBaseV1Channels, handler.serveListAll).Methods(http.MethodGet)
+	handler.router.HandleFunc(URLBaseV1Channels, handler.serveJoin).Methods(http.MethodPost)
+	handler.router.HandleFunc(URLBaseV1Channels, handler.serveNotAllowed("GET, POST"))
+
+	handler.router.NotFoundHandler = http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		handler.sendResponseJSONError(resp, http.StatusNotFound, errors.Errorf("resource %s not found", req.URL.Path))
+	})
+
+	return handler
+}
+
+func (h *HTTPHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if !h.config.Enabled {
+		h.sendResponseJSONError(resp, http.StatusServiceUnavailable, errors.New("channel participation API is disabled"))
+		return
+	}
+
+	h.router.ServeHTTP(resp, req)
+}
+
+// List all channels
+func (h *HTTPHandler) serveListAll(resp http.ResponseWriter, req *http.Request) {
+	channelList := h.registrar.ChannelList()
+	if channelList.SystemChannel != nil {
+		channelList.SystemChannel.URL = channelURL(channelList.SystemChannel.Name)
+	}
+	for i := range channelList.Channels {
+		channelList.Channels[i].URL = channelURL(channelList.Channels[i].Name)
+	}
+	h.sendResponseOK(resp, http.StatusOK, channelList)
+}
+
+// List a single channel
+func (h *HTTPHandler) serveListOne(resp http.ResponseWriter, req *http.Request) {
+	channelID, err := channelIDFromRequest(req)
+	if err != nil {
+		h.sendResponseJSONError(resp, http.StatusBadRequest, err)
+		return
+	}
+
+	info, err := h.registrar.ChannelInfo(channelID)
+	if err != nil {
+		h.sendResponseJSONError(resp, statusCode(err), err)
+		return
+	}
+	info.URL = channelURL(channelID)
+	h.sendResponseOK(resp, http.StatusOK, info)
+}
+
+// Join a channel with a config block sent as a file in a multipart form
+func (h *HTTPHandler) serveJoin(resp http.ResponseWriter, req *http.Request) {
+	block, err := h.configBlockFromRequest(req)
+	if err != nil {
+		h.sendResponseJSONError(resp, http.StatusBadRequest, err)
+		return
+	}
+
+	channelID, err := utils.GetChainIDFromBlock(block)
+	if err != nil {
+		h.sendResponseJSONError(resp, http.StatusBadRequest, errors.WithMessage(err, "invalid config block"))
+		return
+	}
+	if err := configtx.ValidateChannelID(channelID); err != nil {
+		h.sendResponseJSONError(resp, http.StatusBadRequest, errors.WithMessage(err, "invalid config block"))
+		return
+	}
+
+	info, err := h.registrar.JoinChannel(channelID, block)
+	if err != nil {
+		h.sendResponseJSONError(resp, statusCode(err), err)
+		return
+	}
+	info.URL = channelURL(channelID)
+	resp.Header().Set("Location", info.URL)
+	h.sendResponseOK(resp, http.StatusCreated, info)
+}
+
+// Remove a channel
+func (h *HTTPHandler) serveRemove(resp http.ResponseWriter, req *http.Request) {
+	channelID, err := channelIDFromRequest(req)
+	if err != nil {
+		h.sendResponseJSONError(resp, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.registrar.RemoveChannel(channelID); err != nil {
+		if err == types.ErrSystemChannelExists {
+			resp.Header().Set("Allow", http.MethodGet)
+		}
+		h.sendResponseJSONError(resp, statusCode(err), err)
+		return
+	}
+	resp.WriteHeader(http.StatusNoContent)
+}
+
+func (h *HTTPHandler) serveNotAllowed(allowedMethods string) http.HandlerFunc {
+	return func(resp http.ResponseWriter, req *http.Request) {
+		resp.Header().Set("Allow", allowedMethods)
+		h.sendResponseJSONError(resp, http.StatusMethodNotAllowed, errors.Errorf("invalid request method: %s", req.Method))
+	}
+}
+
+func (h *HTTPHandler) configBlockFromRequest(req *http.Request) (*cb.Block, error) {
+	mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed parsing the content type")
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return nil, errors.Errorf("unsupported content type %s, expected multipart/form-data", mediaType)
+	}
+	if params["boundary"] == "" {
+		return nil, errors.New("content type is missing the multipart boundary")
+	}
+
+	req.Body = http.MaxBytesReader(nil, req.Body, int64(h.config.MaxRequestBodySize))
+	if err := req.ParseMultipartForm(int64(h.config.MaxRequestBodySize)); err != nil {
+		return nil, errors.Wrap(err, "failed parsing the multipart form")
+	}
+
+	file, _, err := req.FormFile(FormDataConfigBlockKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed retrieving the %s file from the form", FormDataConfigBlockKey)
+	}
+	defer file.Close()
+
+	blockBytes, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading the %s file", FormDataConfigBlockKey)
+	}
+
+	block := &cb.Block{}
+	if err := proto.Unmarshal(blockBytes, block); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling the config block")
+	}
+	return block, nil
+}
+
+func (h *HTTPHandler) sendResponseJSONError(resp http.ResponseWriter, code int, err error) {
+	h.logger.Debugf("Channel participation request failed with status %d: %s", code, err)
+	h.sendResponseOK(resp, code, &Error{Error: err.Error()})
+}
+
+func (h *HTTPHandler) sendResponseOK(resp http.ResponseWriter, code int, content interface{}) {
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+	if err := json.NewEncoder(resp).Encode(content); err != nil {
+		h.logger.Errorf("Failed encoding the response body: %s", err)
+	}
+}
+
+func channelIDFromRequest(req *http.Request) (string, error) {
+	channelID := mux.Vars(req)[channelIDKey]
+	if err := configtx.ValidateChannelID(channelID); err != nil {
+		return "", errors.WithMessage(err, "invalid channel ID")
+	}
+	return channelID, nil
+}
+
+func channelURL(channelID string) string {
+	return path.Join(URLBaseV1Channels, channelID)
+}
+
+// statusCode maps the errors of the registrar to HTTP status codes.
+func statusCode(err error) int {
+	switch err {
+	case types.ErrSystemChannelExists:
+		return http.StatusMethodNotAllowed
+	case types.ErrChannelAlreadyExists:
+		return http.StatusMethodNotAllowed
+	case types.ErrChannelNotExist:
+		return http.StatusNotFound
+	case types.ErrChannelOnboarding:
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
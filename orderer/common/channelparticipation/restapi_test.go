@@ -0,0 +1,255 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelparticipation_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation/mocks"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var enabledConfig = localconfig.ChannelParticipation{Enabled: true, MaxRequestBodySize: 1024 * 1024}
+
+func configBlock(channelID string) *cb.Block {
+	block := cb.NewBlock(0, nil)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(&cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+					Type:      int32(cb.HeaderType_CONFIG),
+					ChannelId: channelID,
+				}),
+			},
+		}),
+	})}
+	return block
+}
+
+func joinRequest(t *testing.T, key string, content []byte) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(key, "config.block")
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, channelparticipation.URLBaseV1Channels, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func serve(h http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, req)
+	return resp
+}
+
+func errorBody(t *testing.T, resp *httptest.ResponseRecorder) string {
+	respErr := &channelparticipation.Error{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), respErr))
+	return respErr.Error
+}
+
+func TestHTTPHandlerDisabled(t *testing.T) {
+	h := channelparticipation.NewHTTPHandler(localconfig.ChannelParticipation{}, &mocks.ChannelManagement{})
+	resp := serve(h, httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1Channels, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	assert.Equal(t, "channel participation API is disabled", errorBody(t, resp))
+}
+
+func TestHTTPHandlerList(t *testing.T) {
+	registrar := &mocks.ChannelManagement{}
+	h := channelparticipation.NewHTTPHandler(enabledConfig, registrar)
+
+	t.Run("all channels", func(t *testing.T) {
+		registrar.ChannelListReturns(types.ChannelList{
+			Channels: []types.ChannelInfoShort{{Name: "app-channel1"}, {Name: "app-channel2"}},
+		})
+		resp := serve(h, httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1Channels, nil))
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+
+		list := &types.ChannelList{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), list))
+		assert.Equal(t, types.ChannelList{
+			Channels: []types.ChannelInfoShort{
+				{Name: "app-channel1", URL: "/participation/v1/channels/app-channel1"},
+				{Name: "app-channel2", URL: "/participation/v1/channels/app-channel2"},
+			},
+		}, *list)
+	})
+
+	t.Run("single channel", func(t *testing.T) {
+		registrar.ChannelInfoReturns(types.ChannelInfo{
+			Name:              "app-channel",
+			ConsensusRelation: types.ConsensusRelationConsenter,
+			Status:            types.StatusActive,
+			Height:            5,
+		}, nil)
+		resp := serve(h, httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1Channels+"/app-channel", nil))
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "app-channel", registrar.ChannelInfoArgsForCall(0))
+
+		info := &types.ChannelInfo{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), info))
+		assert.Equal(t, types.ChannelInfo{
+			Name:              "app-channel",
+			URL:               "/participation/v1/channels/app-channel",
+			ConsensusRelation: types.ConsensusRelationConsenter,
+			Status:            types.StatusActive,
+			Height:            5,
+		}, *info)
+	})
+
+	t.Run("channel does not exist", func(t *testing.T) {
+		registrar.ChannelInfoReturns(types.ChannelInfo{}, types.ErrChannelNotExist)
+		resp := serve(h, httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1Channels+"/app-channel", nil))
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		assert.Equal(t, "channel does not exist", errorBody(t, resp))
+	})
+
+	t.Run("invalid channel ID", func(t *testing.T) {
+		resp := serve(h, httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1Channels+"/App_Channel", nil))
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Equal(t, "invalid channel ID: channel ID 'App_Channel' contains illegal characters", errorBody(t, resp))
+	})
+}
+
+func TestHTTPHandlerJoin(t *testing.T) {
+	block := utils.MarshalOrPanic(configBlock("app-channel"))
+
+	t.Run("success", func(t *testing.T) {
+		registrar := &mocks.ChannelManagement{}
+		registrar.JoinChannelReturns(types.ChannelInfo{
+			Name:              "app-channel",
+			ConsensusRelation: types.ConsensusRelationConsenter,
+			Status:            types.StatusOnboarding,
+			Height:            0,
+		}, nil)
+		h := channelparticipation.NewHTTPHandler(enabledConfig, registrar)
+
+		resp := serve(h, joinRequest(t, channelparticipation.FormDataConfigBlockKey, block))
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		assert.Equal(t, "/participation/v1/channels/app-channel", resp.Header().Get("Location"))
+
+		require.Equal(t, 1, registrar.JoinChannelCallCount())
+		channelID, joinBlock := registrar.JoinChannelArgsForCall(0)
+		assert.Equal(t, "app-channel", channelID)
+		assert.Equal(t, block, utils.MarshalOrPanic(joinBlock))
+
+		info := &types.ChannelInfo{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), info))
+		assert.Equal(t, types.StatusOnboarding, info.Status)
+		assert.Equal(t, "/participation/v1/channels/app-channel", info.URL)
+	})
+
+	for _, testCase := range []struct {
+		description  string
+		registrarErr error
+		expectedCode int
+	}{
+		{description: "system channel exists", registrarErr: types.ErrSystemChannelExists, expectedCode: http.StatusMethodNotAllowed},
+		{description: "channel exists", registrarErr: types.ErrChannelAlreadyExists, expectedCode: http.StatusMethodNotAllowed},
+		{description: "invalid block", registrarErr: errors.New("block is not a config block"), expectedCode: http.StatusBadRequest},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			registrar := &mocks.ChannelManagement{}
+			registrar.JoinChannelReturns(types.ChannelInfo{}, testCase.registrarErr)
+			h := channelparticipation.NewHTTPHandler(enabledConfig, registrar)
+
+			resp := serve(h, joinRequest(t, channelparticipation.FormDataConfigBlockKey, block))
+			assert.Equal(t, testCase.expectedCode, resp.Code)
+			assert.Equal(t, testCase.registrarErr.Error(), errorBody(t, resp))
+		})
+	}
+
+	t.Run("bad requests", func(t *testing.T) {
+		registrar := &mocks.ChannelManagement{}
+		h := channelparticipation.NewHTTPHandler(enabledConfig, registrar)
+
+		resp := serve(h, joinRequest(t, "block", block))
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Equal(t, "failed retrieving the config-block file from the form: http: no such file", errorBody(t, resp))
+
+		resp = serve(h, joinRequest(t, channelparticipation.FormDataConfigBlockKey, []byte{1, 2, 3}))
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, errorBody(t, resp), "failed unmarshaling the config block")
+
+		resp = serve(h, joinRequest(t, channelparticipation.FormDataConfigBlockKey, utils.MarshalOrPanic(configBlock("Bad_Channel"))))
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Equal(t, "invalid config block: channel ID 'Bad_Channel' contains illegal characters", errorBody(t, resp))
+
+		req := httptest.NewRequest(http.MethodPost, channelparticipation.URLBaseV1Channels, bytes.NewReader(block))
+		req.Header.Set("Content-Type", "application/octet-stream")
+		resp = serve(h, req)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Equal(t, "unsupported content type application/octet-stream, expected multipart/form-data", errorBody(t, resp))
+
+		small := channelparticipation.NewHTTPHandler(localconfig.ChannelParticipation{Enabled: true, MaxRequestBodySize: 10}, registrar)
+		resp = serve(small, joinRequest(t, channelparticipation.FormDataConfigBlockKey, block))
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, errorBody(t, resp), "failed parsing the multipart form")
+
+		assert.Equal(t, 0, registrar.JoinChannelCallCount())
+	})
+}
+
+func TestHTTPHandlerRemove(t *testing.T) {
+	registrar := &mocks.ChannelManagement{}
+	h := channelparticipation.NewHTTPHandler(enabledConfig, registrar)
+	url := channelparticipation.URLBaseV1Channels + "/app-channel"
+
+	resp := serve(h, httptest.NewRequest(http.MethodDelete, url, nil))
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	require.Equal(t, 1, registrar.RemoveChannelCallCount())
+	assert.Equal(t, "app-channel", registrar.RemoveChannelArgsForCall(0))
+
+	registrar.RemoveChannelReturns(types.ErrChannelNotExist)
+	resp = serve(h, httptest.NewRequest(http.MethodDelete, url, nil))
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	registrar.RemoveChannelReturns(types.ErrChannelOnboarding)
+	resp = serve(h, httptest.NewRequest(http.MethodDelete, url, nil))
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	assert.Equal(t, "channel is onboarding", errorBody(t, resp))
+
+	registrar.RemoveChannelReturns(types.ErrSystemChannelExists)
+	resp = serve(h, httptest.NewRequest(http.MethodDelete, url, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+	assert.Equal(t, "GET", resp.Header().Get("Allow"))
+}
+
+func TestHTTPHandlerNotAllowed(t *testing.T) {
+	h := channelparticipation.NewHTTPHandler(enabledConfig, &mocks.ChannelManagement{})
+
+	resp := serve(h, httptest.NewRequest(http.MethodPut, channelparticipation.URLBaseV1Channels, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+	assert.Equal(t, "GET, POST", resp.Header().Get("Allow"))
+	assert.Equal(t, "invalid request method: PUT", errorBody(t, resp))
+
+	resp = serve(h, httptest.NewRequest(http.MethodPost, channelparticipation.URLBaseV1Channels+"/app-channel", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+	assert.Equal(t, "GET, DELETE", resp.Header().Get("Allow"))
+
+	resp = serve(h, httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1+"nothing", nil))
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Equal(t, "resource /participation/v1/nothing not found", errorBody(t, resp))
+}
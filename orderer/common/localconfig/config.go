@@ -5,6 +5,7 @@ package localconfig
 
 import (
 	"fmt"
+	"math"
 	"path/filepath"
 	"strings"
 	"time"
@@ -28,34 +29,38 @@ var logger = flogging.MustGetLogger("localconfig")
 // modify the default mapping, see the "Unmarshal"
 // section of https://github.com/spf13/viper for more info.
 type TopLevel struct {
-	General    General
-	FileLedger FileLedger
-	RAMLedger  RAMLedger
-	Kafka      Kafka
-	Debug      Debug
-	Consensus  interface{}
-	Operations Operations
-	Metrics    Metrics
+	General              General
+	FileLedger           FileLedger
+	RAMLedger            RAMLedger
+	Kafka                Kafka
+	Debug                Debug
+	Consensus            interface{}
+	Operations           Operations
+	Metrics              Metrics
+	ChannelParticipation ChannelParticipation
 }
 
 // General contains config which should be common among all orderer types.
 type General struct {
-	LedgerType        string
-	ListenAddress     string
-	ListenPort        uint16
-	TLS               TLS
-	Cluster           Cluster
-	Keepalive         Keepalive
-	ConnectionTimeout time.Duration
-	GenesisMethod     string
-	GenesisProfile    string
-	SystemChannel     string
-	GenesisFile       string
-	Profile           Profile
-	LocalMSPDir       string
-	LocalMSPID        string
-	BCCSP             *bccsp.FactoryOpts
-	Authentication    Authentication
+	LedgerType          string
+	ListenAddress       string
+	ListenPort          uint16
+	TLS                 TLS
+	Cluster             Cluster
+	Keepalive           Keepalive
+	ConnectionTimeout   time.Duration
+	GenesisMethod       string
+	GenesisProfile      string
+	SystemChannel       string
+	GenesisFile         string
+	Profile             Profile
+	LocalMSPDir         string
+	LocalMSPID          string
+	BCCSP               *bccsp.FactoryOpts
+	Authentication      Authentication
+	Throttling          Throttling
+	FairQueuing         bool
+	DuplicateTxIDFilter DuplicateTxIDFilter
 }
 
 type Cluster struct {
@@ -103,6 +108,25 @@ type SASLPlain struct {
 	Password string
 }
 
+// Throttling contains configuration parameters related to limiting the rate at
+// which the clients of each organization can broadcast messages.
+type Throttling struct {
+	Enabled     bool
+	OrgRate     float64
+	OrgBurst    int
+	ClientRate  float64
+	ClientBurst int
+}
+
+// DuplicateTxIDFilter contains configuration parameters related to rejecting the
+// transactions whose ID has already been ordered on the channel.
+type DuplicateTxIDFilter struct {
+	Enabled          bool
+	Window           time.Duration
+	MaxSize          int
+	RebuildMaxBlocks int
+}
+
 // Authentication contains configuration parameters related to authenticating
 // client messages.
 type Authentication struct {
@@ -203,6 +227,13 @@ type Metrics struct {
 	Statsd   Statsd
 }
 
+// ChannelParticipation provides the channel participation API configuration for the orderer.
+// Channel participation uses the same ListenAddress and TLS settings of the Operations service.
+type ChannelParticipation struct {
+	Enabled            bool
+	MaxRequestBodySize uint32
+}
+
 // Statsd provides the configuration required to emit statsd metrics from the orderer.
 type Statsd struct {
 	Network       string
@@ -242,6 +273,17 @@ var Defaults = TopLevel{
 		Authentication: Authentication{
 			TimeWindow: time.Duration(15 * time.Minute),
 		},
+		Throttling: Throttling{
+			Enabled:  false,
+			OrgRate:  1000,
+			OrgBurst: 1000,
+		},
+		DuplicateTxIDFilter: DuplicateTxIDFilter{
+			Enabled:          false,
+			Window:           time.Hour,
+			MaxSize:          100000,
+			RebuildMaxBlocks: 1000,
+		},
 	},
 	RAMLedger: RAMLedger{
 		HistorySize: 10000,
@@ -292,6 +334,10 @@ var Defaults = TopLevel{
 	Metrics: Metrics{
 		Provider: "disabled",
 	},
+	ChannelParticipation: ChannelParticipation{
+		Enabled:            false,
+		MaxRequestBodySize: 1024 * 1024,
+	},
 }
 
 // Load parses the orderer YAML file and environment, producing
@@ -398,10 +444,36 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			logger.Infof("General.LocalMSPID unset, setting to %s", Defaults.General.LocalMSPID)
 			c.General.LocalMSPID = Defaults.General.LocalMSPID
 
+		case c.ChannelParticipation.Enabled && c.ChannelParticipation.MaxRequestBodySize == 0:
+			logger.Infof("ChannelParticipation.MaxRequestBodySize unset, setting to %v", Defaults.ChannelParticipation.MaxRequestBodySize)
+			c.ChannelParticipation.MaxRequestBodySize = Defaults.ChannelParticipation.MaxRequestBodySize
+
+		case c.General.GenesisMethod == "none" && !c.ChannelParticipation.Enabled:
+			logger.Panic("General.GenesisMethod can be set to none only if ChannelParticipation.Enabled is set to true.")
+
 		case c.General.Authentication.TimeWindow == 0:
 			logger.Infof("General.Authentication.TimeWindow unset, setting to %s", Defaults.General.Authentication.TimeWindow)
 			c.General.Authentication.TimeWindow = Defaults.General.Authentication.TimeWindow
 
+		case c.General.Throttling.Enabled && c.General.Throttling.OrgRate <= 0:
+			logger.Panic("General.Throttling.OrgRate must be greater than zero if General.Throttling.Enabled is set to true.")
+		case c.General.Throttling.Enabled && c.General.Throttling.OrgBurst <= 0:
+			logger.Infof("General.Throttling.OrgBurst unset, setting to %v", burstOf(c.General.Throttling.OrgRate))
+			c.General.Throttling.OrgBurst = burstOf(c.General.Throttling.OrgRate)
+		case c.General.Throttling.Enabled && c.General.Throttling.ClientRate > 0 && c.General.Throttling.ClientBurst <= 0:
+			logger.Infof("General.Throttling.ClientBurst unset, setting to %v", burstOf(c.General.Throttling.ClientRate))
+			c.General.Throttling.ClientBurst = burstOf(c.General.Throttling.ClientRate)
+
+		case c.General.DuplicateTxIDFilter.Enabled && c.General.DuplicateTxIDFilter.Window <= 0:
+			logger.Infof("General.DuplicateTxIDFilter.Window unset, setting to %s", Defaults.General.DuplicateTxIDFilter.Window)
+			c.General.DuplicateTxIDFilter.Window = Defaults.General.DuplicateTxIDFilter.Window
+		case c.General.DuplicateTxIDFilter.Enabled && c.General.DuplicateTxIDFilter.MaxSize <= 0:
+			logger.Infof("General.DuplicateTxIDFilter.MaxSize unset, setting to %d", Defaults.General.DuplicateTxIDFilter.MaxSize)
+			c.General.DuplicateTxIDFilter.MaxSize = Defaults.General.DuplicateTxIDFilter.MaxSize
+		case c.General.DuplicateTxIDFilter.Enabled && c.General.DuplicateTxIDFilter.RebuildMaxBlocks <= 0:
+			logger.Infof("General.DuplicateTxIDFilter.RebuildMaxBlocks unset, setting to %d", Defaults.General.DuplicateTxIDFilter.RebuildMaxBlocks)
+			c.General.DuplicateTxIDFilter.RebuildMaxBlocks = Defaults.General.DuplicateTxIDFilter.RebuildMaxBlocks
+
 		case c.FileLedger.Prefix == "":
 			logger.Infof("FileLedger.Prefix unset, setting to %s", Defaults.FileLedger.Prefix)
 			c.FileLedger.Prefix = Defaults.FileLedger.Prefix
@@ -465,3 +537,8 @@ func translateCAs(configDir string, certificateAuthorities []string) []string {
 	}
 	return results
 }
+
+// burstOf returns the default burst for a rate limit, which allows a second worth of messages at once
+func burstOf(rate float64) int {
+	return int(math.Ceil(rate))
+}
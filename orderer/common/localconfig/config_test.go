@@ -153,6 +153,56 @@ func TestKafkaSASLPlain(t *testing.T) {
 	}
 }
 
+func TestThrottlingConfig(t *testing.T) {
+	testCases := []struct {
+		name               string
+		throttling         Throttling
+		expectedThrottling Throttling
+		shouldPanic        bool
+	}{
+		{"Disabled", Throttling{}, Throttling{}, false},
+		{"EnabledNoOrgRate", Throttling{Enabled: true}, Throttling{}, true},
+		{"EnabledNoBursts", Throttling{Enabled: true, OrgRate: 10.5, ClientRate: 2},
+			Throttling{Enabled: true, OrgRate: 10.5, OrgBurst: 11, ClientRate: 2, ClientBurst: 2}, false},
+		{"EnabledBursts", Throttling{Enabled: true, OrgRate: 10, OrgBurst: 50, ClientRate: 2, ClientBurst: 5},
+			Throttling{Enabled: true, OrgRate: 10, OrgBurst: 50, ClientRate: 2, ClientBurst: 5}, false},
+		{"EnabledNoClientRate", Throttling{Enabled: true, OrgRate: 10},
+			Throttling{Enabled: true, OrgRate: 10, OrgBurst: 10}, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uconf := &TopLevel{General: General{Throttling: tc.throttling}}
+			if tc.shouldPanic {
+				assert.Panics(t, func() { uconf.completeInitialization("/dummy/path") }, "Should panic")
+				return
+			}
+			assert.NotPanics(t, func() { uconf.completeInitialization("/dummy/path") }, "Should not panic")
+			assert.Equal(t, tc.expectedThrottling, uconf.General.Throttling)
+		})
+	}
+}
+
+func TestDuplicateTxIDFilterConfig(t *testing.T) {
+	testCases := []struct {
+		name           string
+		filter         DuplicateTxIDFilter
+		expectedFilter DuplicateTxIDFilter
+	}{
+		{"Disabled", DuplicateTxIDFilter{}, DuplicateTxIDFilter{}},
+		{"EnabledNoValues", DuplicateTxIDFilter{Enabled: true},
+			DuplicateTxIDFilter{Enabled: true, Window: time.Hour, MaxSize: 100000, RebuildMaxBlocks: 1000}},
+		{"EnabledValues", DuplicateTxIDFilter{Enabled: true, Window: time.Minute, MaxSize: 10, RebuildMaxBlocks: 5},
+			DuplicateTxIDFilter{Enabled: true, Window: time.Minute, MaxSize: 10, RebuildMaxBlocks: 5}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uconf := &TopLevel{General: General{DuplicateTxIDFilter: tc.filter}}
+			uconf.completeInitialization("/dummy/path")
+			assert.Equal(t, tc.expectedFilter, uconf.General.DuplicateTxIDFilter)
+		})
+	}
+}
+
 func TestClusterDefaults(t *testing.T) {
 	cleanup := configtest.SetDevFabricConfigPath(t)
 	defer cleanup()
@@ -0,0 +1,166 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// ErrDuplicateTxID is returned by the DuplicateTxIDFilter for transactions
+// whose ID has already been ordered on the channel.
+var ErrDuplicateTxID = errors.New("duplicate transaction ID")
+
+// DuplicateTxIDFilter implements the Rule interface. It rejects the endorser transactions
+// whose ID is found in the blocks recorded within the time window. At most maxSize
+// transaction IDs are remembered, the oldest ones being forgotten first.
+//
+// As the filter only learns of a transaction ID once its block is written, copies of a
+// transaction submitted at the same time may still be ordered, and are then invalidated
+// by the peers as before.
+type DuplicateTxIDFilter struct {
+	window  time.Duration
+	maxSize int
+	now     func() time.Time
+
+	mutex   sync.Mutex
+	txIDs   map[string]*list.Element
+	entries *list.List // of *txIDEntry, in the order they were recorded
+}
+
+type txIDEntry struct {
+	txID       string
+	recordedAt time.Time
+}
+
+// NewDuplicateTxIDFilter creates a DuplicateTxIDFilter which remembers up to maxSize
+// transaction IDs for the duration of the window.
+func NewDuplicateTxIDFilter(window time.Duration, maxSize int) *DuplicateTxIDFilter {
+	return &DuplicateTxIDFilter{
+		window:  window,
+		maxSize: maxSize,
+		now:     time.Now,
+		txIDs:   map[string]*list.Element{},
+		entries: list.New(),
+	}
+}
+
+// Apply returns an error if the message is an endorser transaction whose ID has already been ordered.
+func (f *DuplicateTxIDFilter) Apply(message *cb.Envelope) error {
+	chdr, err := utils.ChannelHeader(message)
+	if err != nil {
+		return errors.WithMessage(err, "could not extract the channel header of the message")
+	}
+	if chdr.Type != int32(cb.HeaderType_ENDORSER_TRANSACTION) {
+		return nil
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.evictExpired(f.now())
+	if _, exists := f.txIDs[chdr.TxId]; exists {
+		return errors.WithMessage(ErrDuplicateTxID, fmt.Sprintf("transaction [%s] has already been ordered", chdr.TxId))
+	}
+	return nil
+}
+
+// Record remembers the IDs of the endorser transactions of a block written to the ledger of the channel.
+func (f *DuplicateTxIDFilter) Record(block *cb.Block) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	now := f.now()
+	f.add(endorserTxIDs(block), now)
+	f.evictExpired(now)
+}
+
+// Rebuild remembers the IDs of the endorser transactions of the most recent blocks of the ledger,
+// reading back at most maxBlocks blocks and stopping once the maximum size is reached.
+//
+// The rebuild is best-effort. As the blocks do not carry the time at which they were written, and
+// the timestamps of the transactions are set by the clients, the transaction IDs are recorded as of
+// the rebuild and are remembered for the duration of the window from then on. The transactions
+// ordered before the blocks read back are not remembered and are left to the peers to invalidate.
+func (f *DuplicateTxIDFilter) Rebuild(reader blockledger.Reader, maxBlocks int) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var recentTxIDs [][]string
+	count := 0
+	blocksRead := 0
+	for blockNum := reader.Height(); blockNum > 0 && blocksRead < maxBlocks && count < f.maxSize; blockNum-- {
+		block := blockledger.GetBlock(reader, blockNum-1)
+		if block == nil {
+			logger.Panicf("Could not retrieve block [%d] to rebuild the transaction ID filter", blockNum-1)
+		}
+		blocksRead++
+		txIDs := endorserTxIDs(block)
+		recentTxIDs = append(recentTxIDs, txIDs)
+		count += len(txIDs)
+	}
+
+	now := f.now()
+	for i := len(recentTxIDs) - 1; i >= 0; i-- {
+		f.add(recentTxIDs[i], now)
+	}
+	logger.Debugf("Rebuilt the transaction ID filter with %d transaction IDs from %d blocks", f.entries.Len(), blocksRead)
+}
+
+// add should only be invoked with the mutex held
+func (f *DuplicateTxIDFilter) add(txIDs []string, recordedAt time.Time) {
+	for _, txID := range txIDs {
+		if _, exists := f.txIDs[txID]; exists {
+			continue
+		}
+		f.txIDs[txID] = f.entries.PushBack(&txIDEntry{txID: txID, recordedAt: recordedAt})
+	}
+	for f.entries.Len() > f.maxSize {
+		f.remove(f.entries.Front())
+	}
+}
+
+// evictExpired should only be invoked with the mutex held
+func (f *DuplicateTxIDFilter) evictExpired(now time.Time) {
+	for e := f.entries.Front(); e != nil && now.Sub(e.Value.(*txIDEntry).recordedAt) > f.window; e = f.entries.Front() {
+		f.remove(e)
+	}
+}
+
+func (f *DuplicateTxIDFilter) remove(e *list.Element) {
+	delete(f.txIDs, e.Value.(*txIDEntry).txID)
+	f.entries.Remove(e)
+}
+
+// endorserTxIDs returns the IDs of the endorser transactions of the block
+func endorserTxIDs(block *cb.Block) []string {
+	var txIDs []string
+	for i := range block.GetData().GetData() {
+		env, err := utils.ExtractEnvelope(block, i)
+		if err != nil {
+			logger.Warningf("Could not extract transaction [%d] of block [%d]: %s", i, block.Header.Number, err)
+			continue
+		}
+		chdr, err := utils.ChannelHeader(env)
+		if err != nil {
+			logger.Warningf("Could not extract the channel header of transaction [%d] of block [%d]: %s", i, block.Header.Number, err)
+			continue
+		}
+		if chdr.Type != int32(cb.HeaderType_ENDORSER_TRANSACTION) || chdr.TxId == "" {
+			continue
+		}
+		txIDs = append(txIDs, chdr.TxId)
+	}
+	return txIDs
+}
@@ -0,0 +1,164 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	ramledger "github.com/hyperledger/fabric/common/ledger/blockledger/ram"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeTxEnvelope(headerType cb.HeaderType, txID string, ts time.Time) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+					Type:      int32(headerType),
+					TxId:      txID,
+					Timestamp: &timestamp.Timestamp{Seconds: ts.Unix()},
+				}),
+			},
+		}),
+	}
+}
+
+func makeTxBlock(number uint64, previousHash []byte, envs ...*cb.Envelope) *cb.Block {
+	block := cb.NewBlock(number, previousHash)
+	for _, env := range envs {
+		block.Data.Data = append(block.Data.Data, utils.MarshalOrPanic(env))
+	}
+	block.Header.DataHash = block.Data.Hash()
+	return block
+}
+
+func newTestDuplicateTxIDFilter(window time.Duration, maxSize int, now *time.Time) *DuplicateTxIDFilter {
+	f := NewDuplicateTxIDFilter(window, maxSize)
+	f.now = func() time.Time { return *now }
+	return f
+}
+
+func TestDuplicateTxIDFilter(t *testing.T) {
+	now := time.Unix(1000000, 0)
+	f := newTestDuplicateTxIDFilter(time.Hour, 10, &now)
+
+	f.Record(makeTxBlock(1, nil,
+		makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx1", now),
+		makeTxEnvelope(cb.HeaderType_CONFIG, "tx2", now),
+		&cb.Envelope{Payload: []byte("garbage")},
+	))
+
+	err := f.Apply(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx1", now))
+	assert.EqualError(t, err, "transaction [tx1] has already been ordered: duplicate transaction ID")
+	assert.Equal(t, ErrDuplicateTxID, errors.Cause(err))
+
+	assert.NoError(t, f.Apply(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx2", now)), "config transaction IDs are not recorded")
+	assert.NoError(t, f.Apply(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx3", now)))
+	assert.NoError(t, f.Apply(makeTxEnvelope(cb.HeaderType_CONFIG_UPDATE, "tx1", now)), "only endorser transactions are filtered")
+
+	err = f.Apply(&cb.Envelope{Payload: []byte("garbage")})
+	assert.Contains(t, err.Error(), "could not extract the channel header of the message")
+}
+
+func TestDuplicateTxIDFilterWindow(t *testing.T) {
+	now := time.Unix(1000000, 0)
+	f := newTestDuplicateTxIDFilter(time.Hour, 10, &now)
+
+	f.Record(makeTxBlock(1, nil, makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx1", now)))
+	now = now.Add(30 * time.Minute)
+	f.Record(makeTxBlock(2, nil, makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx2", now)))
+
+	now = now.Add(45 * time.Minute)
+	assert.NoError(t, f.Apply(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx1", now)))
+	assert.Error(t, f.Apply(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx2", now)))
+	assert.Equal(t, 1, f.entries.Len())
+}
+
+func TestDuplicateTxIDFilterMaxSize(t *testing.T) {
+	now := time.Unix(1000000, 0)
+	f := newTestDuplicateTxIDFilter(time.Hour, 2, &now)
+
+	f.Record(makeTxBlock(1, nil,
+		makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx1", now),
+		makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx2", now),
+	))
+	f.Record(makeTxBlock(2, nil,
+		makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx2", now),
+		makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx3", now),
+	))
+
+	assert.NoError(t, f.Apply(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx1", now)))
+	assert.Error(t, f.Apply(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx2", now)))
+	assert.Error(t, f.Apply(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx3", now)))
+}
+
+func TestDuplicateTxIDFilterRebuild(t *testing.T) {
+	now := time.Unix(1000000, 0)
+	rl, err := ramledger.New(10).GetOrCreate("mychannel")
+	assert.NoError(t, err)
+
+	blocks := []*cb.Block{makeTxBlock(0, nil, makeTxEnvelope(cb.HeaderType_CONFIG, "", now.Add(-3*time.Hour)))}
+	for _, envs := range [][]*cb.Envelope{
+		{makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx1", now.Add(-2*time.Hour))},
+		{
+			makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx2", now.Add(-2*time.Hour)),
+			makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx3", now.Add(-30*time.Minute)),
+		},
+		{makeTxEnvelope(cb.HeaderType_CONFIG, "", now.Add(-20*time.Minute))},
+		{makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx4", now.Add(time.Minute))},
+	} {
+		previous := blocks[len(blocks)-1]
+		blocks = append(blocks, makeTxBlock(previous.Header.Number+1, previous.Header.Hash(), envs...))
+	}
+	for _, block := range blocks {
+		assert.NoError(t, rl.Append(block))
+	}
+
+	t.Run("Window", func(t *testing.T) {
+		now := now
+		f := newTestDuplicateTxIDFilter(time.Hour, 10, &now)
+		f.Rebuild(rl, 10)
+
+		for _, txID := range []string{"tx1", "tx2", "tx3", "tx4"} {
+			assert.Error(t, f.Apply(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, txID, now)), "the client timestamps do not matter")
+		}
+		assert.Equal(t, now, f.entries.Front().Value.(*txIDEntry).recordedAt)
+		assert.Equal(t, now, f.entries.Back().Value.(*txIDEntry).recordedAt)
+
+		now = now.Add(time.Hour + time.Second)
+		for _, txID := range []string{"tx1", "tx2", "tx3", "tx4"} {
+			assert.NoError(t, f.Apply(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, txID, now)))
+		}
+		assert.Equal(t, 0, f.entries.Len())
+	})
+
+	t.Run("MaxSize", func(t *testing.T) {
+		f := newTestDuplicateTxIDFilter(time.Hour, 2, &now)
+		f.Rebuild(rl, 10)
+
+		assert.NoError(t, f.Apply(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx1", now)))
+		assert.NoError(t, f.Apply(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx2", now)))
+		assert.Error(t, f.Apply(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx3", now)))
+		assert.Error(t, f.Apply(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx4", now)))
+	})
+
+	t.Run("MaxBlocks", func(t *testing.T) {
+		f := newTestDuplicateTxIDFilter(time.Hour, 10, &now)
+		// the blocks without endorser transactions count towards the bound
+		f.Rebuild(rl, 2)
+
+		for _, txID := range []string{"tx1", "tx2", "tx3"} {
+			assert.NoError(t, f.Apply(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, txID, now)))
+		}
+		assert.Error(t, f.Apply(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx4", now)))
+	})
+}
@@ -57,7 +57,10 @@ func NewStandardChannel(support StandardChannelSupport, filters *RuleSet) *Stand
 //
 // In maintenance mode, require the signature of /Channel/Orderer/Writer. This will filter out configuration
 // changes that are not related to consensus-type migration (e.g on /Channel/Application).
-func CreateStandardChannelFilters(filterSupport channelconfig.Resources, config localconfig.TopLevel) *RuleSet {
+//
+// If txIDFilter is not nil, it is applied last, so that only the clients allowed to write to the channel
+// learn whether a transaction ID has already been ordered.
+func CreateStandardChannelFilters(filterSupport channelconfig.Resources, config localconfig.TopLevel, txIDFilter *DuplicateTxIDFilter) *RuleSet {
 	rules := []Rule{
 		EmptyRejectRule,
 		NewSizeFilter(filterSupport),
@@ -69,6 +72,10 @@ func CreateStandardChannelFilters(filterSupport channelconfig.Resources, config
 		rules = append(rules[:2], append([]Rule{expirationRule}, rules[2:]...)...)
 	}
 
+	if txIDFilter != nil {
+		rules = append(rules, txIDFilter)
+	}
+
 	return NewRuleSet(rules)
 }
 
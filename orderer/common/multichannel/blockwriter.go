@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
 	"github.com/hyperledger/fabric/protos/utils"
 )
 
@@ -184,7 +185,10 @@ func (bw *BlockWriter) commitBlock(encodedMetadataValue []byte) {
 	}
 
 	bw.addLastConfigSignature(bw.lastBlock)
-	bw.addBlockSignature(bw.lastBlock)
+	// BFT blocks already carry the signatures of a quorum of the consenters.
+	if bw.support.SharedConfig().ConsensusType() != bft.TypeKey {
+		bw.addBlockSignature(bw.lastBlock)
+	}
 
 	err := bw.support.Append(bw.lastBlock)
 	if err != nil {
@@ -65,12 +65,16 @@ func TestBlockSignature(t *testing.T) {
 	lastBlock := cb.NewBlock(0, nil)
 	l.Append(lastBlock)
 
+	fakeConfig := &mock.OrdererConfig{}
+	fakeConfig.ConsensusTypeReturns("solo")
+
 	bw := &BlockWriter{
 		lastConfigBlockNum: 42,
 		support: &mockBlockWriterSupport{
 			LocalSigner: mockCrypto(),
 			Validator:   &mockconfigtx.Validator{},
 			ReadWriter:  l,
+			fakeConfig:  fakeConfig,
 		},
 		lastBlock: cb.NewBlock(1, lastBlock.Header.Hash()),
 	}
@@ -94,6 +98,41 @@ func TestBlockSignature(t *testing.T) {
 	assert.NotNil(t, md.Signatures, "Should have signature")
 }
 
+func TestBlockSignatureBFT(t *testing.T) {
+	rlf := ramledger.New(2)
+	l, err := rlf.GetOrCreate("mychannel")
+	assert.NoError(t, err)
+	lastBlock := cb.NewBlock(0, nil)
+	l.Append(lastBlock)
+
+	fakeConfig := &mock.OrdererConfig{}
+	fakeConfig.ConsensusTypeReturns("bft")
+
+	block := cb.NewBlock(1, lastBlock.Header.Hash())
+	quorumSignatures := utils.MarshalOrPanic(&cb.Metadata{
+		Value:      []byte("value"),
+		Signatures: []*cb.MetadataSignature{{Signature: []byte("sig1")}, {Signature: []byte("sig2")}},
+	})
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = quorumSignatures
+
+	bw := &BlockWriter{
+		support: &mockBlockWriterSupport{
+			LocalSigner: mockCrypto(),
+			Validator:   &mockconfigtx.Validator{},
+			ReadWriter:  l,
+			fakeConfig:  fakeConfig,
+		},
+		lastBlock: block,
+	}
+
+	bw.commitBlock(nil)
+
+	it, _ := l.Iterator(&orderer.SeekPosition{Type: &orderer.SeekPosition_Newest{}})
+	committedBlock, status := it.Next()
+	assert.Equal(t, cb.Status_SUCCESS, status)
+	assert.Equal(t, quorumSignatures, committedBlock.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES], "Signatures of the consenters are kept")
+}
+
 func TestBlockLastConfig(t *testing.T) {
 	lastConfigSeq := uint64(6)
 	newConfigSeq := lastConfigSeq + 1
@@ -27,6 +27,7 @@ type ChainSupport struct {
 	consensus.Chain
 	cutter blockcutter.Receiver
 	crypto.LocalSigner
+	txIDFilter *msgprocessor.DuplicateTxIDFilter
 }
 
 func newChainSupport(
@@ -45,19 +46,33 @@ func newChainSupport(
 		logger.Fatalf("[channel: %s] Error extracting orderer metadata: %s", ledgerResources.ConfigtxValidator().ChainID(), err)
 	}
 
+	newReceiver := blockcutter.NewReceiverImpl
+	chainID := ledgerResources.ConfigtxValidator().ChainID()
+	if fairQueuingEnabled(registrar.config.General.FairQueuing, chainID, ledgerResources.SharedConfig().ConsensusType()) {
+		newReceiver = blockcutter.NewFairQueuingReceiverImpl
+	}
+
 	// Construct limited support needed as a parameter for additional support
 	cs := &ChainSupport{
 		ledgerResources: ledgerResources,
 		LocalSigner:     signer,
-		cutter: blockcutter.NewReceiverImpl(
-			ledgerResources.ConfigtxValidator().ChainID(),
+		cutter: newReceiver(
+			chainID,
 			ledgerResources,
 			blockcutterMetrics,
 		),
 	}
 
+	// Set up the duplicate transaction ID filter, which the system channel has no use for
+	if txIDFilterConf := registrar.config.General.DuplicateTxIDFilter; txIDFilterConf.Enabled {
+		if _, isSystemChannel := ledgerResources.ConsortiumsConfig(); !isSystemChannel {
+			cs.txIDFilter = msgprocessor.NewDuplicateTxIDFilter(txIDFilterConf.Window, txIDFilterConf.MaxSize)
+			cs.txIDFilter.Rebuild(ledgerResources, txIDFilterConf.RebuildMaxBlocks)
+		}
+	}
+
 	// Set up the msgprocessor
-	cs.Processor = msgprocessor.NewStandardChannel(cs, msgprocessor.CreateStandardChannelFilters(cs, registrar.config))
+	cs.Processor = msgprocessor.NewStandardChannel(cs, msgprocessor.CreateStandardChannelFilters(cs, registrar.config, cs.txIDFilter))
 
 	// Set up the block writer
 	cs.BlockWriter = newBlockWriter(lastBlock, registrar, cs)
@@ -79,6 +94,22 @@ func newChainSupport(
 	return cs
 }
 
+// fairQueuingEnabled returns whether the block cutter of a channel queues the messages
+// of each organization separately. Fair queuing is not supported on Kafka channels, as
+// each orderer cuts their blocks on its own: the messages it defers are not accounted
+// for by the offsets persisted in the blocks, hence are lost when the orderer restarts,
+// and orderers configured differently would cut different blocks.
+func fairQueuingEnabled(fairQueuing bool, chainID, consensusType string) bool {
+	if !fairQueuing {
+		return false
+	}
+	if consensusType == "kafka" {
+		logger.Warningf("[channel: %s] Fair queuing is not supported by the kafka consensus, ordering the messages as they arrive", chainID)
+		return false
+	}
+	return true
+}
+
 // Block returns a block with the following number,
 // or nil if such a block doesn't exist.
 func (cs *ChainSupport) Block(number uint64) *cb.Block {
@@ -148,7 +179,13 @@ func (cs *ChainSupport) Sequence() uint64 {
 // Append appends a new block to the ledger in its raw form,
 // unlike WriteBlock that also mutates its metadata.
 func (cs *ChainSupport) Append(block *cb.Block) error {
-	return cs.ledgerResources.ReadWriter.Append(block)
+	if err := cs.ledgerResources.ReadWriter.Append(block); err != nil {
+		return err
+	}
+	if cs.txIDFilter != nil {
+		cs.txIDFilter.Record(block)
+	}
+	return nil
 }
 
 // VerifyBlockSignature verifies a signature of a block.
@@ -8,7 +8,9 @@ package multichannel
 
 import (
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/deliver/mock"
 	"github.com/hyperledger/fabric/common/ledger/blockledger/mocks"
@@ -19,8 +21,10 @@ import (
 	"github.com/hyperledger/fabric/common/tools/configtxgen/configtxgentest"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
@@ -41,6 +45,43 @@ func TestChainSupportBlock(t *testing.T) {
 	assert.Equal(t, uint64(99), cs.Block(99).Header.Number)
 }
 
+func TestChainSupportAppendRecordsTxIDs(t *testing.T) {
+	env := &common.Envelope{
+		Payload: utils.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{
+				ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+					Type:      int32(common.HeaderType_ENDORSER_TRANSACTION),
+					TxId:      "tx1",
+					Timestamp: ptypes.TimestampNow(),
+				}),
+			},
+		}),
+	}
+	block := common.NewBlock(1, nil)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(env)}
+
+	ledger := &mocks.ReadWriter{}
+	ledger.On("Append", block).Return(errors.New("append-error")).Once()
+	ledger.On("Append", block).Return(nil).Once()
+	cs := &ChainSupport{
+		ledgerResources: &ledgerResources{ReadWriter: ledger},
+		txIDFilter:      msgprocessor.NewDuplicateTxIDFilter(time.Hour, 10),
+	}
+
+	assert.EqualError(t, cs.Append(block), "append-error")
+	assert.NoError(t, cs.txIDFilter.Apply(env), "transaction IDs of blocks that failed to be appended are not recorded")
+
+	assert.NoError(t, cs.Append(block))
+	assert.EqualError(t, cs.txIDFilter.Apply(env), "transaction [tx1] has already been ordered: duplicate transaction ID")
+}
+
+func TestFairQueuingEnabled(t *testing.T) {
+	assert.False(t, fairQueuingEnabled(false, "mychannel", "etcdraft"))
+	assert.True(t, fairQueuingEnabled(true, "mychannel", "etcdraft"))
+	assert.True(t, fairQueuingEnabled(true, "mychannel", "solo"))
+	assert.False(t, fairQueuingEnabled(true, "mychannel", "kafka"), "fair queuing is not supported on kafka channels")
+}
+
 type mutableResourcesMock struct {
 	config.Resources
 }
@@ -11,6 +11,7 @@ package multichannel
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
@@ -22,7 +23,9 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/inactive"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -90,10 +93,20 @@ type ledgerResources struct {
 	blockledger.ReadWriter
 }
 
+// ChannelReplicator replicates the blocks of a channel that is joined with a config block
+// which is not its genesis block.
+type ChannelReplicator interface {
+	// ReplicateChannel pulls the blocks of the channel of the given join block from the ordering
+	// service nodes of the channel, and appends them to the ledger, up to but not including the join block.
+	ReplicateChannel(joinBlock *cb.Block) error
+}
+
 // Registrar serves as a point of access and control for the individual channel resources.
 type Registrar struct {
 	lock               sync.RWMutex
 	chains             map[string]*ChainSupport
+	onboarding         map[string]struct{}
+	replicator         ChannelReplicator
 	config             localconfig.TopLevel
 	consenters         map[string]consensus.Consenter
 	ledgerFactory      blockledger.Factory
@@ -135,6 +148,7 @@ func NewRegistrar(
 	r := &Registrar{
 		config:             config,
 		chains:             make(map[string]*ChainSupport),
+		onboarding:         make(map[string]struct{}),
 		ledgerFactory:      ledgerFactory,
 		signer:             signer,
 		blockcutterMetrics: blockcutter.NewMetrics(metricsProvider),
@@ -153,6 +167,14 @@ func (r *Registrar) Initialize(consenters map[string]consensus.Consenter) {
 		if err != nil {
 			logger.Panicf("Ledger factory reported chainID %s but could not retrieve it: %s", chainID, err)
 		}
+		if rl.Height() == 0 && r.config.ChannelParticipation.Enabled {
+			// A join that was interrupted before any block was replicated leaves an empty ledger behind
+			logger.Warningf("Removing the empty ledger of channel %s", chainID)
+			if err := r.ledgerFactory.Remove(chainID); err != nil {
+				logger.Panicf("Failed removing the empty ledger of channel %s: %s", chainID, err)
+			}
+			continue
+		}
 		configTx := configTx(rl)
 		if configTx == nil {
 			logger.Panic("Programming error, configTx should never be nil here")
@@ -209,10 +231,22 @@ func (r *Registrar) Initialize(consenters map[string]consensus.Consenter) {
 	}
 
 	if r.systemChannelID == "" {
-		logger.Panicf("No system chain found.  If bootstrapping, does your system channel contain a consortiums group definition?")
+		if !r.config.ChannelParticipation.Enabled {
+			logger.Panicf("No system chain found.  If bootstrapping, does your system channel contain a consortiums group definition?")
+		}
+		logger.Infof("Starting without a system channel, %d application channels found", len(r.chains))
 	}
 }
 
+// SetChannelReplicator sets the ChannelReplicator used to replicate the blocks of channels that are
+// joined with a config block which is not their genesis block.
+func (r *Registrar) SetChannelReplicator(replicator ChannelReplicator) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.replicator = replicator
+}
+
 // SystemChannelID returns the ChannelID for the system channel.
 func (r *Registrar) SystemChannelID() string {
 	return r.systemChannelID
@@ -230,6 +264,9 @@ func (r *Registrar) BroadcastChannelSupport(msg *cb.Envelope) (*cb.ChannelHeader
 	cs := r.GetChain(chdr.ChannelId)
 	// New channel creation
 	if cs == nil {
+		if r.systemChannel == nil {
+			return nil, false, nil, errors.Errorf("channel %s does not exist", chdr.ChannelId)
+		}
 		cs = r.systemChannel
 	}
 
@@ -312,6 +349,12 @@ func (r *Registrar) newChain(configtx *cb.Envelope) {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
+	r.startChain(configtx)
+}
+
+// startChain creates and starts the chain of the given config transaction.
+// It must be called while holding the lock.
+func (r *Registrar) startChain(configtx *cb.Envelope) {
 	ledgerResources := r.newLedgerResources(configtx)
 	// If we have no blocks, we need to create the genesis block ourselves.
 	if ledgerResources.Height() == 0 {
@@ -352,3 +395,248 @@ func (r *Registrar) NewChannelConfig(envConfigUpdate *cb.Envelope) (channelconfi
 func (r *Registrar) CreateBundle(channelID string, config *cb.Config) (channelconfig.Resources, error) {
 	return channelconfig.NewBundle(channelID, config)
 }
+
+// ChannelList returns a slim list of channels.
+func (r *Registrar) ChannelList() types.ChannelList {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	list := types.ChannelList{}
+	for name := range r.chains {
+		if name == r.systemChannelID {
+			list.SystemChannel = &types.ChannelInfoShort{Name: name}
+			continue
+		}
+		list.Channels = append(list.Channels, types.ChannelInfoShort{Name: name})
+	}
+	for name := range r.onboarding {
+		list.Channels = append(list.Channels, types.ChannelInfoShort{Name: name})
+	}
+	sort.Slice(list.Channels, func(i, j int) bool {
+		return list.Channels[i].Name < list.Channels[j].Name
+	})
+
+	return list
+}
+
+// ChannelInfo provides extended status information about a channel.
+func (r *Registrar) ChannelInfo(channelID string) (types.ChannelInfo, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	info := types.ChannelInfo{
+		Name:              channelID,
+		ConsensusRelation: types.ConsensusRelationConsenter,
+		Status:            types.StatusActive,
+	}
+
+	if _, exists := r.onboarding[channelID]; exists {
+		ledger, err := r.ledgerFactory.GetOrCreate(channelID)
+		if err != nil {
+			return types.ChannelInfo{}, errors.Wrapf(err, "failed retrieving the ledger of channel %s", channelID)
+		}
+		info.Status = types.StatusOnboarding
+		info.Height = ledger.Height()
+		return info, nil
+	}
+
+	cs, exists := r.chains[channelID]
+	if !exists {
+		return types.ChannelInfo{}, types.ErrChannelNotExist
+	}
+	if _, isInactive := cs.Chain.(*inactive.Chain); isInactive {
+		info.ConsensusRelation = types.ConsensusRelationOther
+		info.Status = types.StatusInactive
+	}
+	info.Height = cs.Height()
+
+	return info, nil
+}
+
+// JoinChannel instructs the orderer to create a channel and join it with the provided config block.
+// If the config block is not the genesis block of the channel, the blocks that precede it are replicated
+// from the ordering service nodes of the channel before the channel is started, and the channel is
+// reported as onboarding meanwhile.
+func (r *Registrar) JoinChannel(channelID string, configBlock *cb.Block) (types.ChannelInfo, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.systemChannelID != "" {
+		return types.ChannelInfo{}, types.ErrSystemChannelExists
+	}
+	if _, exists := r.chains[channelID]; exists {
+		return types.ChannelInfo{}, types.ErrChannelAlreadyExists
+	}
+	if _, exists := r.onboarding[channelID]; exists {
+		return types.ChannelInfo{}, types.ErrChannelAlreadyExists
+	}
+
+	configTx, err := ValidateJoinBlock(channelID, configBlock)
+	if err != nil {
+		return types.ChannelInfo{}, err
+	}
+	if err := r.checkConsensusType(configTx); err != nil {
+		return types.ChannelInfo{}, err
+	}
+
+	if configBlock.Header.Number > 0 && r.replicator == nil {
+		return types.ChannelInfo{}, errors.Errorf("cannot join channel %s with config block [%d]: "+
+			"only channels of a cluster consensus type can be joined with a config block other than the genesis block",
+			channelID, configBlock.Header.Number)
+	}
+
+	ledger, err := r.ledgerFactory.GetOrCreate(channelID)
+	if err != nil {
+		return types.ChannelInfo{}, errors.Wrapf(err, "failed creating the ledger of channel %s", channelID)
+	}
+
+	if configBlock.Header.Number > 0 {
+		r.onboarding[channelID] = struct{}{}
+		go r.onboard(channelID, configBlock, configTx)
+		return types.ChannelInfo{
+			Name:              channelID,
+			ConsensusRelation: types.ConsensusRelationConsenter,
+			Status:            types.StatusOnboarding,
+			Height:            ledger.Height(),
+		}, nil
+	}
+
+	if err := ledger.Append(configBlock); err != nil {
+		r.removeLedger(channelID)
+		return types.ChannelInfo{}, errors.Wrapf(err, "failed appending the genesis block of channel %s", channelID)
+	}
+	r.startChain(configTx)
+
+	return types.ChannelInfo{
+		Name:              channelID,
+		ConsensusRelation: types.ConsensusRelationConsenter,
+		Status:            types.StatusActive,
+		Height:            ledger.Height(),
+	}, nil
+}
+
+// checkConsensusType checks that a consenter is registered for the consensus type
+// of the channel a config transaction is of, so that the chain can be started.
+func (r *Registrar) checkConsensusType(configTx *cb.Envelope) error {
+	bundle, err := channelconfig.NewBundleFromEnvelope(configTx)
+	if err != nil {
+		return errors.WithMessage(err, "failed creating the channel config from the block")
+	}
+	ordererConfig, ok := bundle.OrdererConfig()
+	if !ok {
+		return errors.New("block is missing the orderer config")
+	}
+	if _, exists := r.consenters[ordererConfig.ConsensusType()]; !exists {
+		return errors.Errorf("unknown consensus type %s", ordererConfig.ConsensusType())
+	}
+	return nil
+}
+
+// onboard replicates the blocks that precede the join block, and then starts the channel.
+func (r *Registrar) onboard(channelID string, joinBlock *cb.Block, configTx *cb.Envelope) {
+	logger.Infof("Replicating the blocks of channel %s up to block [%d]", channelID, joinBlock.Header.Number)
+	err := r.replicator.ReplicateChannel(joinBlock)
+	if err == nil {
+		var ledger blockledger.ReadWriter
+		if ledger, err = r.ledgerFactory.GetOrCreate(channelID); err == nil {
+			err = ledger.Append(joinBlock)
+		}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.onboarding, channelID)
+	if err != nil {
+		logger.Errorf("Failed onboarding channel %s, removing it: %s", channelID, err)
+		r.removeLedger(channelID)
+		return
+	}
+
+	logger.Infof("Replicated channel %s up to block [%d]", channelID, joinBlock.Header.Number)
+	r.startChain(configTx)
+}
+
+// RemoveChannel instructs the orderer to halt the given channel, and remove its ledger.
+func (r *Registrar) RemoveChannel(channelID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.systemChannelID != "" {
+		return types.ErrSystemChannelExists
+	}
+	if _, exists := r.onboarding[channelID]; exists {
+		return types.ErrChannelOnboarding
+	}
+	cs, exists := r.chains[channelID]
+	if !exists {
+		return types.ErrChannelNotExist
+	}
+
+	cs.Halt()
+
+	// Copy the map to allow concurrent reads from broadcast/deliver while the chain is removed
+	newChains := make(map[string]*ChainSupport)
+	for key, value := range r.chains {
+		if key != channelID {
+			newChains[key] = value
+		}
+	}
+	r.chains = newChains
+
+	if err := r.ledgerFactory.Remove(channelID); err != nil {
+		return errors.Wrapf(err, "failed removing the ledger of channel %s", channelID)
+	}
+	logger.Infof("Removed channel %s", channelID)
+
+	return nil
+}
+
+func (r *Registrar) removeLedger(channelID string) {
+	if err := r.ledgerFactory.Remove(channelID); err != nil {
+		logger.Errorf("Failed removing the ledger of channel %s: %s", channelID, err)
+	}
+}
+
+// ValidateJoinBlock checks that the given block is a config block of the given channel that
+// this orderer supports, and returns its config transaction.
+func ValidateJoinBlock(channelID string, configBlock *cb.Block) (*cb.Envelope, error) {
+	if configBlock == nil || configBlock.Header == nil || configBlock.Data == nil || len(configBlock.Data.Data) == 0 {
+		return nil, errors.New("block is empty or missing its header")
+	}
+
+	env, err := utils.ExtractEnvelope(configBlock, 0)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed extracting the envelope from the block")
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed unmarshaling the payload of the block")
+	}
+	if payload.Header == nil {
+		return nil, errors.New("block payload is missing its header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed unmarshaling the channel header of the block")
+	}
+	if chdr.Type != int32(cb.HeaderType_CONFIG) {
+		return nil, errors.Errorf("block is not a config block, its type is %s", cb.HeaderType(chdr.Type))
+	}
+	if chdr.ChannelId != channelID {
+		return nil, errors.Errorf("block is of channel %s, not of channel %s", chdr.ChannelId, channelID)
+	}
+
+	bundle, err := channelconfig.NewBundleFromEnvelope(env)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed creating the channel config from the block")
+	}
+	if _, isSystemChannel := bundle.ConsortiumsConfig(); isSystemChannel {
+		return nil, errors.New("block is a config block of a system channel")
+	}
+	if err := checkResources(bundle); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
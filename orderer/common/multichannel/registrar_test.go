@@ -8,6 +8,7 @@ package multichannel
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/crypto"
@@ -22,6 +23,7 @@ import (
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -390,3 +392,219 @@ func TestBroadcastChannelSupportRejection(t *testing.T) {
 		assert.Error(t, err, "Messages of type HeaderType_CONFIG should return an error.")
 	})
 }
+
+type fakeChannelReplicator struct {
+	ledgerFactory blockledger.Factory
+	source        blockledger.Reader
+	proceed       chan struct{}
+	err           error
+}
+
+func (fcr *fakeChannelReplicator) ReplicateChannel(joinBlock *cb.Block) error {
+	<-fcr.proceed
+	if fcr.err != nil {
+		return fcr.err
+	}
+	channelID, err := utils.GetChainIDFromBlock(joinBlock)
+	if err != nil {
+		return err
+	}
+	ledger, err := fcr.ledgerFactory.GetOrCreate(channelID)
+	if err != nil {
+		return err
+	}
+	for seq := uint64(0); seq < joinBlock.Header.Number; seq++ {
+		if err := ledger.Append(blockledger.GetBlock(fcr.source, seq)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func waitForStatus(t *testing.T, registrar *Registrar, channelID string, status types.Status) types.ChannelInfo {
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		info, err := registrar.ChannelInfo(channelID)
+		if err == nil && info.Status == status {
+			return info
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Channel %s did not reach status %s, last info: %+v, error: %v", channelID, status, info, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestChannelParticipation(t *testing.T) {
+	conf := localconfig.TopLevel{ChannelParticipation: localconfig.ChannelParticipation{Enabled: true}}
+	confSys := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	confApp := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	confApp.Consortiums = nil
+	consenters := map[string]consensus.Consenter{confSys.Orderer.OrdererType: &mockConsenter{}}
+
+	newRegistrar := func() (*Registrar, blockledger.Factory) {
+		lf := ramledger.New(10)
+		registrar := NewRegistrar(conf, lf, mockCrypto(), &disabled.Provider{})
+		registrar.Initialize(consenters)
+		return registrar, lf
+	}
+
+	// sourceLedger builds the ledger of a channel that has a config block at the given height
+	sourceLedger := func(channelID string, height uint64) (blockledger.ReadWriter, *cb.Block) {
+		source, _ := newRAMLedgerAndFactory(10, channelID, encoder.New(confApp).GenesisBlockForChannel(channelID))
+		rl, err := source.GetOrCreate(channelID)
+		assert.NoError(t, err)
+		for seq := uint64(1); seq < height-1; seq++ {
+			rl.Append(blockledger.CreateNextBlock(rl, []*cb.Envelope{makeNormalTx(channelID, int(seq))}))
+		}
+		joinBlock := blockledger.CreateNextBlock(rl, []*cb.Envelope{utils.ExtractEnvelopeOrPanic(blockledger.GetBlock(rl, 0), 0)})
+		joinBlock.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&cb.Metadata{
+			Value: utils.MarshalOrPanic(&cb.LastConfig{Index: joinBlock.Header.Number}),
+		})
+		return rl, joinBlock
+	}
+
+	t.Run("Join with genesis block", func(t *testing.T) {
+		registrar, lf := newRegistrar()
+		assert.Equal(t, types.ChannelList{}, registrar.ChannelList())
+
+		info, err := registrar.JoinChannel("app-channel", encoder.New(confApp).GenesisBlockForChannel("app-channel"))
+		assert.NoError(t, err)
+		assert.Equal(t, types.ChannelInfo{
+			Name:              "app-channel",
+			ConsensusRelation: types.ConsensusRelationConsenter,
+			Status:            types.StatusActive,
+			Height:            1,
+		}, info)
+		assert.NotNil(t, registrar.GetChain("app-channel"))
+		assert.Equal(t, []string{"app-channel"}, lf.ChainIDs())
+
+		info, err = registrar.ChannelInfo("app-channel")
+		assert.NoError(t, err)
+		assert.Equal(t, types.StatusActive, info.Status)
+		assert.Equal(t, types.ChannelList{Channels: []types.ChannelInfoShort{{Name: "app-channel"}}}, registrar.ChannelList())
+
+		_, err = registrar.JoinChannel("app-channel", encoder.New(confApp).GenesisBlockForChannel("app-channel"))
+		assert.Equal(t, types.ErrChannelAlreadyExists, err)
+
+		_, err = registrar.ChannelInfo("other-channel")
+		assert.Equal(t, types.ErrChannelNotExist, err)
+
+		_, _, _, err = registrar.BroadcastChannelSupport(makeNormalTx("other-channel", 1))
+		assert.EqualError(t, err, "channel other-channel does not exist")
+	})
+
+	t.Run("Join with invalid block", func(t *testing.T) {
+		registrar, lf := newRegistrar()
+
+		_, err := registrar.JoinChannel("app-channel", encoder.New(confApp).GenesisBlockForChannel("other-channel"))
+		assert.EqualError(t, err, "block is of channel other-channel, not of channel app-channel")
+
+		_, err = registrar.JoinChannel("app-channel", encoder.New(confSys).GenesisBlockForChannel("app-channel"))
+		assert.EqualError(t, err, "block is a config block of a system channel")
+
+		block := cb.NewBlock(0, nil)
+		block.Data.Data = [][]byte{utils.MarshalOrPanic(makeNormalTx("app-channel", 1))}
+		_, err = registrar.JoinChannel("app-channel", block)
+		assert.EqualError(t, err, "block is not a config block, its type is ENDORSER_TRANSACTION")
+
+		_, err = registrar.JoinChannel("app-channel", &cb.Block{})
+		assert.EqualError(t, err, "block is empty or missing its header")
+
+		_, joinBlock := sourceLedger("app-channel", 5)
+		_, err = registrar.JoinChannel("app-channel", joinBlock)
+		assert.EqualError(t, err, "cannot join channel app-channel with config block [4]: only channels of a cluster "+
+			"consensus type can be joined with a config block other than the genesis block")
+
+		assert.Empty(t, lf.ChainIDs())
+		assert.Equal(t, types.ChannelList{}, registrar.ChannelList())
+	})
+	t.Run("Join with an unregistered consensus type", func(t *testing.T) {
+		lf := ramledger.New(10)
+		registrar := NewRegistrar(conf, lf, mockCrypto(), &disabled.Provider{})
+		registrar.Initialize(map[string]consensus.Consenter{"other": &mockConsenter{}})
+
+		_, err := registrar.JoinChannel("app-channel", encoder.New(confApp).GenesisBlockForChannel("app-channel"))
+		assert.EqualError(t, err, "unknown consensus type "+confApp.Orderer.OrdererType)
+		assert.Empty(t, lf.ChainIDs())
+		assert.Equal(t, types.ChannelList{}, registrar.ChannelList())
+	})
+
+	t.Run("Join with a later config block", func(t *testing.T) {
+		registrar, lf := newRegistrar()
+		source, joinBlock := sourceLedger("app-channel", 5)
+		replicator := &fakeChannelReplicator{ledgerFactory: lf, source: source, proceed: make(chan struct{})}
+		registrar.SetChannelReplicator(replicator)
+
+		info, err := registrar.JoinChannel("app-channel", joinBlock)
+		assert.NoError(t, err)
+		assert.Equal(t, types.StatusOnboarding, info.Status)
+		assert.Nil(t, registrar.GetChain("app-channel"))
+		assert.Equal(t, types.ChannelList{Channels: []types.ChannelInfoShort{{Name: "app-channel"}}}, registrar.ChannelList())
+
+		_, err = registrar.JoinChannel("app-channel", joinBlock)
+		assert.Equal(t, types.ErrChannelAlreadyExists, err)
+		assert.Equal(t, types.ErrChannelOnboarding, registrar.RemoveChannel("app-channel"))
+
+		close(replicator.proceed)
+		info = waitForStatus(t, registrar, "app-channel", types.StatusActive)
+		assert.Equal(t, uint64(5), info.Height)
+		assert.NotNil(t, registrar.GetChain("app-channel"))
+	})
+
+	t.Run("Join with a later config block fails to replicate", func(t *testing.T) {
+		registrar, lf := newRegistrar()
+		source, joinBlock := sourceLedger("app-channel", 5)
+		replicator := &fakeChannelReplicator{ledgerFactory: lf, source: source, proceed: make(chan struct{}), err: errors.New("unreachable")}
+		registrar.SetChannelReplicator(replicator)
+
+		_, err := registrar.JoinChannel("app-channel", joinBlock)
+		assert.NoError(t, err)
+		close(replicator.proceed)
+
+		deadline := time.Now().Add(10 * time.Second)
+		for len(registrar.ChannelList().Channels) != 0 && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		_, err = registrar.ChannelInfo("app-channel")
+		assert.Equal(t, types.ErrChannelNotExist, err)
+		assert.Empty(t, lf.ChainIDs())
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		registrar, lf := newRegistrar()
+		_, err := registrar.JoinChannel("app-channel", encoder.New(confApp).GenesisBlockForChannel("app-channel"))
+		assert.NoError(t, err)
+		chain := registrar.GetChain("app-channel")
+
+		assert.NoError(t, registrar.RemoveChannel("app-channel"))
+		assert.Nil(t, registrar.GetChain("app-channel"))
+		assert.Empty(t, lf.ChainIDs())
+		_, ok := <-chain.Chain.(*mockChain).queue
+		assert.False(t, ok, "The removed chain should have been halted")
+
+		assert.Equal(t, types.ErrChannelNotExist, registrar.RemoveChannel("app-channel"))
+	})
+
+	t.Run("Empty ledgers are removed on startup", func(t *testing.T) {
+		lf := ramledger.New(10)
+		_, err := lf.GetOrCreate("app-channel")
+		assert.NoError(t, err)
+
+		registrar := NewRegistrar(conf, lf, mockCrypto(), &disabled.Provider{})
+		registrar.Initialize(consenters)
+		assert.Empty(t, lf.ChainIDs())
+		assert.Equal(t, types.ChannelList{}, registrar.ChannelList())
+	})
+
+	t.Run("With a system channel", func(t *testing.T) {
+		lf, _ := newRAMLedgerAndFactory(10, genesisconfig.TestChainID, encoder.New(confSys).GenesisBlock())
+		registrar := NewRegistrar(conf, lf, mockCrypto(), &disabled.Provider{})
+		registrar.Initialize(consenters)
+
+		assert.Equal(t, types.ChannelList{SystemChannel: &types.ChannelInfoShort{Name: genesisconfig.TestChainID}}, registrar.ChannelList())
+		_, err := registrar.JoinChannel("app-channel", encoder.New(confApp).GenesisBlockForChannel("app-channel"))
+		assert.Equal(t, types.ErrSystemChannelExists, err)
+		assert.Equal(t, types.ErrSystemChannelExists, registrar.RemoveChannel(genesisconfig.TestChainID))
+	})
+}
@@ -40,11 +40,13 @@ import (
 	"github.com/hyperledger/fabric/msp"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/file"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/metadata"
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
 	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/bft"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
 	"github.com/hyperledger/fabric/orderer/consensus/kafka"
 	"github.com/hyperledger/fabric/orderer/consensus/solo"
@@ -66,7 +68,7 @@ var (
 	version   = app.Command("version", "Show version information")
 	benchmark = app.Command("benchmark", "Run orderer in benchmark mode")
 
-	clusterTypes = map[string]struct{}{"etcdraft": {}}
+	clusterTypes = map[string]struct{}{"etcdraft": {}, "bft": {}}
 )
 
 // Main is the entry point of orderer process
@@ -93,9 +95,13 @@ func Main() {
 
 // Start provides a layer of abstraction for benchmark test
 func Start(cmd string, conf *localconfig.TopLevel) {
-	bootstrapBlock := extractBootstrapBlock(conf)
-	if err := ValidateBootstrapBlock(bootstrapBlock); err != nil {
-		logger.Panicf("Failed validating bootstrap block: %v", err)
+	// Without a system channel, channels are joined through the channel participation API
+	var bootstrapBlock *cb.Block
+	if conf.General.GenesisMethod != "none" {
+		bootstrapBlock = extractBootstrapBlock(conf)
+		if err := ValidateBootstrapBlock(bootstrapBlock); err != nil {
+			logger.Panicf("Failed validating bootstrap block: %v", err)
+		}
 	}
 
 	opsSystem := newOperationsSystem(conf.Operations, conf.Metrics)
@@ -107,8 +113,11 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 	metricsProvider := opsSystem.Provider
 
 	lf, _ := createLedgerFactory(conf, metricsProvider)
-	sysChanLastConfigBlock := extractSysChanLastConfig(lf, bootstrapBlock)
-	clusterBootBlock := selectClusterBootBlock(bootstrapBlock, sysChanLastConfigBlock)
+	var clusterBootBlock *cb.Block
+	if bootstrapBlock != nil {
+		sysChanLastConfigBlock := extractSysChanLastConfig(lf, bootstrapBlock)
+		clusterBootBlock = selectClusterBootBlock(bootstrapBlock, sysChanLastConfigBlock)
+	}
 
 	signer := localmsp.NewSigner()
 
@@ -130,10 +139,15 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 	var clusterDialer *cluster.PredicateDialer
 
 	var reuseGrpcListener bool
-	typ := consensusType(bootstrapBlock)
 	var serversToUpdate []*comm.GRPCServer
 
-	clusterType := isClusterType(clusterBootBlock)
+	// Without a system channel, the joined channels may be of a cluster type,
+	// hence the cluster is always set up.
+	typ := "etcdraft"
+	if bootstrapBlock != nil {
+		typ = consensusType(bootstrapBlock)
+	}
+	clusterType := bootstrapBlock == nil || isClusterType(clusterBootBlock)
 	if clusterType {
 		logger.Infof("Setting up cluster for orderer type %s", typ)
 
@@ -142,10 +156,12 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 			ClientConfig: clusterClientConfig,
 		}
 
-		r = createReplicator(lf, bootstrapBlock, conf, clusterClientConfig.SecOpts, signer)
-		// Only clusters that are equipped with a recent config block can replicate.
-		if conf.General.GenesisMethod == "file" {
-			r.replicateIfNeeded(bootstrapBlock)
+		if bootstrapBlock != nil {
+			r = createReplicator(lf, bootstrapBlock, conf, clusterClientConfig.SecOpts, signer)
+			// Only clusters that are equipped with a recent config block can replicate.
+			if conf.General.GenesisMethod == "file" {
+				r.replicateIfNeeded(bootstrapBlock)
+			}
 		}
 
 		if reuseGrpcListener = reuseListener(conf, typ); !reuseGrpcListener {
@@ -188,9 +204,23 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 		time.AfterFunc)
 
 	manager := initializeMultichannelRegistrar(clusterBootBlock, r, clusterDialer, clusterServerConfig, clusterGRPCServer, conf, signer, metricsProvider, opsSystem, lf, tlsCallback)
+	if bootstrapBlock == nil {
+		manager.SetChannelReplicator(&channelReplicator{
+			logger:  flogging.MustGetLogger("orderer.common.cluster"),
+			secOpts: clusterClientConfig.SecOpts,
+			conf:    conf,
+			lf:      lf,
+			signer:  signer,
+		})
+	}
+	if conf.ChannelParticipation.Enabled {
+		logger.Info("Channel participation API is enabled")
+		channelParticipationHandler := channelparticipation.NewHTTPHandler(conf.ChannelParticipation, manager)
+		opsSystem.RegisterHandler(channelparticipation.URLBaseV1, channelParticipationHandler, conf.Operations.TLS.Enabled)
+	}
 	mutualTLS := serverConfig.SecOpts.UseTLS && serverConfig.SecOpts.RequireClientCert
 	expiration := conf.General.Authentication.NoExpirationChecks
-	server := NewServer(manager, metricsProvider, &conf.Debug, conf.General.Authentication.TimeWindow, mutualTLS, expiration)
+	server := NewServer(manager, metricsProvider, &conf.Debug, conf.General.Authentication.TimeWindow, mutualTLS, expiration, conf.General.Throttling)
 
 	logger.Infof("Starting %s", metadata.GetVersionInfo())
 	go handleSignals(addPlatformSignals(map[os.Signal]func(){
@@ -664,12 +694,14 @@ func initializeMultichannelRegistrar(
 	lf blockledger.Factory,
 	callbacks ...channelconfig.BundleActor,
 ) *multichannel.Registrar {
-	genesisBlock := extractBootstrapBlock(conf)
-	// Are we bootstrapping?
-	if len(lf.ChainIDs()) == 0 {
-		initializeBootstrapChannel(genesisBlock, lf)
-	} else {
-		logger.Info("Not bootstrapping because of existing channels")
+	if bootstrapBlock != nil {
+		genesisBlock := extractBootstrapBlock(conf)
+		// Are we bootstrapping?
+		if len(lf.ChainIDs()) == 0 {
+			initializeBootstrapChannel(genesisBlock, lf)
+		} else {
+			logger.Info("Not bootstrapping because of existing channels")
+		}
 	}
 
 	consenters := make(map[string]consensus.Consenter)
@@ -677,7 +709,14 @@ func initializeMultichannelRegistrar(
 	registrar := multichannel.NewRegistrar(*conf, lf, signer, metricsProvider, callbacks...)
 
 	var icr etcdraft.InactiveChainRegistry
-	if isClusterType(bootstrapBlock) {
+	if bootstrapBlock == nil {
+		// Without a system channel, inactive chains cannot be replicated through it
+		icr = &noopInactiveChainRegistry{}
+		consenters["etcdraft"] = etcdraft.New(clusterDialer, conf, srvConf, srv, registrar, icr, metricsProvider)
+		consenters["bft"] = bft.New(clusterDialer, conf, srvConf, srv, registrar, metricsProvider)
+	} else if consensusType(bootstrapBlock) == "bft" {
+		consenters["bft"] = bft.New(clusterDialer, conf, srvConf, srv, registrar, metricsProvider)
+	} else if isClusterType(bootstrapBlock) {
 		etcdConsenter := initializeEtcdraftConsenter(consenters, conf, lf, clusterDialer, bootstrapBlock, ri, srvConf, srv, registrar, metricsProvider)
 		icr = etcdConsenter.InactiveChainRegistry
 	}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package server
 
 import (
+	"bytes"
 	"sync"
 	"time"
 
@@ -247,6 +248,77 @@ func (dc *inactiveChainReplicator) listInactiveChains() []string {
 	return chains
 }
 
+// channelReplicator replicates the blocks of channels that are joined through the channel
+// participation API with a config block which is not their genesis block.
+type channelReplicator struct {
+	logger  *flogging.FabricLogger
+	secOpts *comm.SecureOptions
+	conf    *localconfig.TopLevel
+	lf      blockledger.Factory
+	signer  crypto.LocalSigner
+}
+
+// ReplicateChannel pulls the blocks that precede the given join block from the ordering service
+// nodes of its channel, and appends them to the ledger of the channel.
+// The join block is trusted, hence the pulled blocks are verified by the hash chain that leads
+// to the join block rather than by their signatures.
+func (cr *channelReplicator) ReplicateChannel(joinBlock *common.Block) error {
+	channel, err := utils.GetChainIDFromBlock(joinBlock)
+	if err != nil {
+		return errors.WithMessage(err, "failed extracting the channel ID from the join block")
+	}
+	ledger, err := cr.lf.GetOrCreate(channel)
+	if err != nil {
+		return errors.Wrapf(err, "failed obtaining the ledger of channel %s", channel)
+	}
+
+	pullerConfig := cluster.PullerConfigFromTopLevelConfig(channel, cr.conf, cr.secOpts.Key, cr.secOpts.Certificate, cr.signer)
+	verifierRetriever := &cluster.VerificationRegistry{
+		Logger:             cr.logger,
+		VerifiersByChannel: map[string]cluster.BlockVerifier{channel: &cluster.NoopBlockVerifier{}},
+	}
+	puller, err := cluster.BlockPullerFromConfigBlock(pullerConfig, joinBlock, verifierRetriever)
+	if err != nil {
+		return errors.WithMessage(err, "failed creating a block puller from the join block")
+	}
+	puller.MaxPullBlockRetries = uint64(cr.conf.General.Cluster.ReplicationMaxRetries)
+	puller.RetryTimeout = cr.conf.General.Cluster.ReplicationRetryTimeout
+	defer puller.Close()
+
+	var prevHash []byte
+	if height := ledger.Height(); height > 0 {
+		prevHash = blockledger.GetBlock(ledger, height-1).Header.Hash()
+	}
+	for seq := ledger.Height(); seq < joinBlock.Header.Number; seq++ {
+		block := puller.PullBlock(seq)
+		if block == nil {
+			return errors.Errorf("failed pulling block [%d] of channel %s", seq, channel)
+		}
+		if seq > 0 && !bytes.Equal(block.Header.PreviousHash, prevHash) {
+			return errors.Errorf("block [%d] of channel %s has previous hash %x, but expected %x",
+				seq, channel, block.Header.PreviousHash, prevHash)
+		}
+		if err := ledger.Append(block); err != nil {
+			return errors.Wrapf(err, "failed appending block [%d] of channel %s", seq, channel)
+		}
+		prevHash = block.Header.Hash()
+	}
+
+	if !bytes.Equal(joinBlock.Header.PreviousHash, prevHash) {
+		return errors.Errorf("join block [%d] of channel %s has previous hash %x, but the replicated blocks end with %x",
+			joinBlock.Header.Number, channel, joinBlock.Header.PreviousHash, prevHash)
+	}
+	cr.logger.Infof("Replicated %d blocks of channel %s", joinBlock.Header.Number, channel)
+	return nil
+}
+
+// noopInactiveChainRegistry does not track inactive chains, as without a system channel
+// there is no config block to replicate them with.
+type noopInactiveChainRegistry struct{}
+
+func (*noopInactiveChainRegistry) TrackChain(chain string, genesisBlock *common.Block, createChainCallback func()) {
+}
+
 //go:generate mockery -dir . -name Factory -case underscore  -output mocks/
 
 // Factory retrieves or creates new ledgers by chainID
@@ -258,6 +330,9 @@ type Factory interface {
 	// ChainIDs returns the chain IDs the Factory is aware of
 	ChainIDs() []string
 
+	// Remove removes the ledger of the given chainID
+	Remove(chainID string) error
+
 	// Close releases all resources acquired by the factory
 	Close()
 }
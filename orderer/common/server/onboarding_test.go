@@ -864,6 +864,110 @@ func TestLedgerFactory(t *testing.T) {
 	assert.Equal(t, uint64(0), lw.Height())
 }
 
+func TestChannelReplicator(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+
+	blockBytes, err := ioutil.ReadFile(filepath.Join("testdata", "genesis.block"))
+	assert.NoError(t, err)
+
+	caCert := loadPEM("ca.crt", t)
+	key := loadPEM("server.key", t)
+	cert := loadPEM("server.crt", t)
+
+	copyBlock := func(block *common.Block, seq uint64) *common.Block {
+		res := &common.Block{}
+		proto.Unmarshal(utils.MarshalOrPanic(block), res)
+		res.Header.Number = seq
+		return res
+	}
+
+	for _, testCase := range []struct {
+		name           string
+		corruptBlock   uint64
+		expectedErr    string
+		expectedHeight uint64
+	}{
+		{
+			name:           "hash chain is valid",
+			expectedHeight: 5,
+		},
+		{
+			name:         "hash chain is broken",
+			corruptBlock: 3,
+			expectedErr:  "failed pulling block [0] of channel testchainid",
+		},
+	} {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			deliverServer := newServerNode(t, key, cert)
+			defer deliverServer.srv.Stop()
+
+			genesisBlock := &common.Block{}
+			assert.NoError(t, proto.Unmarshal(blockBytes, genesisBlock))
+			injectOrdererEndpoint(t, genesisBlock, deliverServer.srv.Address())
+
+			blocks := make([]*common.Block, 5)
+			for seq := uint64(0); seq < 5; seq++ {
+				blocks[seq] = copyBlock(genesisBlock, seq)
+				if seq > 0 {
+					blocks[seq].Header.PreviousHash = blocks[seq-1].Header.Hash()
+				}
+			}
+			if testCase.corruptBlock > 0 {
+				blocks[testCase.corruptBlock].Header.PreviousHash = []byte{1, 2, 3}
+			}
+			joinBlock := copyBlock(genesisBlock, 5)
+			joinBlock.Header.PreviousHash = blocks[4].Header.Hash()
+
+			deliverServer.blockResponses <- &orderer.DeliverResponse{
+				Type: &orderer.DeliverResponse_Block{Block: joinBlock},
+			}
+			for _, block := range append(blocks, joinBlock) {
+				deliverServer.blockResponses <- &orderer.DeliverResponse{
+					Type: &orderer.DeliverResponse_Block{Block: block},
+				}
+			}
+			close(deliverServer.blockResponses)
+
+			lf := ramledger.New(10)
+			cr := &channelReplicator{
+				logger: flogging.MustGetLogger("testChannelReplicator"),
+				secOpts: &comm.SecureOptions{
+					Certificate:   cert,
+					Key:           key,
+					UseTLS:        true,
+					ServerRootCAs: [][]byte{caCert},
+				},
+				conf: &localconfig.TopLevel{
+					General: localconfig.General{
+						Cluster: localconfig.Cluster{
+							ReplicationMaxRetries:   1,
+							ReplicationPullTimeout:  time.Second,
+							DialTimeout:             time.Second,
+							RPCTimeout:              time.Second,
+							ReplicationRetryTimeout: time.Millisecond * 100,
+							ReplicationBufferSize:   1024 * 1024,
+						},
+					},
+				},
+				lf: lf,
+			}
+
+			err := cr.ReplicateChannel(joinBlock)
+			if testCase.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), testCase.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			ledger, err := lf.GetOrCreate("testchainid")
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedHeight, ledger.Height())
+		})
+	}
+}
+
 func injectConsenterCertificate(t *testing.T, block *common.Block, tlsCert []byte) {
 	env, err := utils.ExtractEnvelope(block, 0)
 	assert.NoError(t, err)
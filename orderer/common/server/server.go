@@ -79,7 +79,12 @@ func NewServer(
 	timeWindow time.Duration,
 	mutualTLS bool,
 	expirationCheckDisabled bool,
+	throttling localconfig.Throttling,
 ) ab.AtomicBroadcastServer {
+	var throttler broadcast.Throttler
+	if throttling.Enabled {
+		throttler = broadcast.NewRateLimiter(throttling.OrgRate, throttling.OrgBurst, throttling.ClientRate, throttling.ClientBurst)
+	}
 	s := &server{
 		dh: deliver.NewHandler(
 			deliverSupport{Registrar: r},
@@ -91,6 +96,7 @@ func NewServer(
 		bh: &broadcast.Handler{
 			SupportRegistrar: broadcastSupport{Registrar: r},
 			Metrics:          broadcast.NewMetrics(metricsProvider),
+			Throttler:        throttler,
 		},
 		debug:     debug,
 		Registrar: r,
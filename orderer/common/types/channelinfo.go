@@ -0,0 +1,86 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package types
+
+// ErrSystemChannelExists is returned when trying to join or remove an application channel when the system channel exists.
+var ErrSystemChannelExists = &ChannelParticipationError{"system channel exists"}
+
+// ErrChannelAlreadyExists is returned when trying to join a channel that already exists.
+var ErrChannelAlreadyExists = &ChannelParticipationError{"channel already exists"}
+
+// ErrChannelNotExist is returned when trying to remove or list a channel that does not exist.
+var ErrChannelNotExist = &ChannelParticipationError{"channel does not exist"}
+
+// ErrChannelOnboarding is returned when trying to remove a channel whose blocks are still being replicated.
+var ErrChannelOnboarding = &ChannelParticipationError{"channel is onboarding"}
+
+// ChannelParticipationError is an error of the channel participation API that
+// the caller should be able to tell apart from other failures.
+type ChannelParticipationError struct {
+	msg string
+}
+
+func (e *ChannelParticipationError) Error() string {
+	return e.msg
+}
+
+// ChannelList carries the response to an HTTP request to List all the channels.
+// This is marshaled into the body of the HTTP response.
+type ChannelList struct {
+	// The system channel info, nil if it doesn't exist.
+	SystemChannel *ChannelInfoShort `json:"systemChannel"`
+	// Application channels only, nil or empty if no channels defined.
+	Channels []ChannelInfoShort `json:"channels"`
+}
+
+// ChannelInfoShort carries a short info of a single channel.
+type ChannelInfoShort struct {
+	// The channel name.
+	Name string `json:"name"`
+	// The channel relative URL (no Host:Port, only path), e.g.: "/participation/v1/channels/my-channel".
+	URL string `json:"url"`
+}
+
+// ConsensusRelation represents the relationship between the orderer and the channel's consensus cluster.
+type ConsensusRelation string
+
+const (
+	// ConsensusRelationConsenter means the orderer is a cluster consenter of a cluster consensus protocol
+	// (e.g. etcdraft), or a single-node consenter (e.g. solo, kafka).
+	ConsensusRelationConsenter ConsensusRelation = "consenter"
+	// ConsensusRelationOther means the orderer is not a consenter of the channel, and does not service it.
+	ConsensusRelationOther ConsensusRelation = "other"
+)
+
+// Status represents the degree by which the orderer had caught up with the rest of the cluster after joining the
+// channel.
+type Status string
+
+const (
+	// StatusActive means the orderer is active in the consensus protocol, or a follower with height that is
+	// equal or larger than the join block.
+	StatusActive Status = "active"
+	// StatusOnboarding means the orderer is replicating the blocks of the channel that precede the join block.
+	StatusOnboarding Status = "onboarding"
+	// StatusInactive means the orderer is not a consenter of the channel, and does not service it.
+	StatusInactive Status = "inactive"
+)
+
+// ChannelInfo carries the response to an HTTP request to List a single channel.
+// This is marshaled into the body of the HTTP response.
+type ChannelInfo struct {
+	// The channel name.
+	Name string `json:"name"`
+	// The channel relative URL (no Host:Port, only path), e.g.: "/participation/v1/channels/my-channel".
+	URL string `json:"url"`
+	// Whether the orderer is a "consenter" or "other" in the channel.
+	ConsensusRelation ConsensusRelation `json:"consensusRelation"`
+	// Whether the orderer is "onboarding", "active", or "inactive", for this channel.
+	Status Status `json:"status"`
+	// Current block height.
+	Height uint64 `json:"height"`
+}
@@ -0,0 +1,1278 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// Configurator is used to configure the communication layer
+// when the chain starts or its consenter set changes.
+type Configurator interface {
+	Configure(channel string, newNodes []cluster.RemoteNode)
+}
+
+// RPC is used to mock the transport layer in tests.
+type RPC interface {
+	SendConsensus(dest uint64, msg *orderer.ConsensusRequest) error
+	SendSubmit(dest uint64, request *orderer.SubmitRequest) error
+}
+
+// Options contains all the configurations relevant to the chain.
+type Options struct {
+	SelfID     uint64
+	Consenters []*bft.Consenter
+
+	// RequestTimeout is the time a request or a proposal may remain
+	// unordered before the leader is suspected.
+	RequestTimeout time.Duration
+	// ViewChangeTimeout is the time a view change may take before the
+	// node moves on to the next view.
+	ViewChangeTimeout time.Duration
+
+	Clock  clock.Clock
+	Logger *flogging.FabricLogger
+}
+
+type submission struct {
+	req    *orderer.SubmitRequest
+	sender uint64
+}
+
+type inboundMessage struct {
+	msg    *bft.Message
+	sender uint64
+}
+
+// pendingRequest is a request this node has seen and
+// expects to be included in a block.
+type pendingRequest struct {
+	req       *orderer.SubmitRequest
+	received  time.Time
+	enqueued  bool
+	forwarded bool
+}
+
+// maxFutureMessages bounds the number of messages of upcoming
+// blocks which are kept until this node catches up with them.
+const maxFutureMessages = 1000
+
+// recentBlocks is the number of latest blocks whose requests are
+// remembered, so that requests relayed late are not ordered twice.
+const recentBlocks = 100
+
+type voteKey struct {
+	view uint64
+	seq  uint64
+}
+
+// Chain implements consensus.Chain on top of a PBFT-like protocol:
+// the leader of a view proposes the next block, the nodes prepare it,
+// and once a quorum prepared it they sign it. A block is written once
+// it carries the signatures of a quorum of the consenters. Nodes which
+// suspect the leader change the view, carrying over the certificates
+// of the proposals they prepared so that a block which might have been
+// written by some node is never replaced by another one.
+type Chain struct {
+	support      consensus.ConsenterSupport
+	rpc          RPC
+	configurator Configurator
+	logger       *flogging.FabricLogger
+	channelID    string
+	opts         Options
+
+	submitC chan *submission
+	msgC    chan *inboundMessage
+	haltC   chan struct{}
+	doneC   chan struct{}
+	startC  chan struct{}
+	errorC  chan struct{}
+
+	haltOnce sync.Once
+
+	// The fields below are only accessed by the run goroutine.
+	consenters      []*bft.Consenter
+	view            uint64
+	lastBlock       *common.Block
+	lastConfigIndex uint64
+
+	pending map[string]*pendingRequest
+	batches [][]*common.Envelope
+
+	committed        map[string]struct{}
+	committedByBlock [][]string
+
+	batchTimer  clock.Timer
+	batchTimerC <-chan time.Time
+
+	proposal      *bft.PrePrepare
+	proposalStart time.Time
+	sentCommit    bool
+	locked        *bft.PreparedCertificate
+	prepares      map[voteKey]map[uint64]*bft.Prepare
+	commits       map[voteKey]map[uint64]*bft.Commit
+
+	viewChanging    bool
+	viewChangeStart time.Time
+	nextView        uint64
+	viewChanges     map[uint64]map[uint64]*bft.ViewChange
+	seenViews       map[uint64]uint64
+
+	future []*inboundMessage
+
+	syncRequested uint64
+	syncStart     time.Time
+}
+
+// NewChain constructs a chain object.
+func NewChain(
+	support consensus.ConsenterSupport,
+	opts Options,
+	conf Configurator,
+	rpc RPC,
+) (*Chain, error) {
+	lastBlock := support.Block(support.Height() - 1)
+	if lastBlock == nil {
+		return nil, errors.Errorf("failed to retrieve block [%d] of channel %s", support.Height()-1, support.ChainID())
+	}
+
+	var lastConfigIndex uint64
+	if lastBlock.Header.Number != 0 {
+		var err error
+		lastConfigIndex, err = utils.GetLastConfigIndexFromBlock(lastBlock)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to read the last config index")
+		}
+	}
+
+	logger := opts.Logger.With("channel", support.ChainID(), "node", opts.SelfID)
+
+	return &Chain{
+		support:         support,
+		rpc:             rpc,
+		configurator:    conf,
+		logger:          logger,
+		channelID:       support.ChainID(),
+		opts:            opts,
+		submitC:         make(chan *submission),
+		msgC:            make(chan *inboundMessage),
+		haltC:           make(chan struct{}),
+		doneC:           make(chan struct{}),
+		startC:          make(chan struct{}),
+		errorC:          make(chan struct{}),
+		consenters:      sortedConsenters(opts.Consenters),
+		lastBlock:       lastBlock,
+		lastConfigIndex: lastConfigIndex,
+		pending:         make(map[string]*pendingRequest),
+		committed:       make(map[string]struct{}),
+		prepares:        make(map[voteKey]map[uint64]*bft.Prepare),
+		commits:         make(map[voteKey]map[uint64]*bft.Commit),
+		viewChanges:     make(map[uint64]map[uint64]*bft.ViewChange),
+		seenViews:       make(map[uint64]uint64),
+	}, nil
+}
+
+// Start instructs the orderer to begin serving the chain and keep it current.
+func (c *Chain) Start() {
+	c.logger.Infof("Starting BFT node")
+
+	if err := c.configureComm(); err != nil {
+		c.logger.Errorf("Failed to start chain, aborting: %+v", err)
+		close(c.doneC)
+		c.halt()
+		return
+	}
+
+	close(c.startC)
+	go c.run()
+}
+
+// Order submits normal type transactions for ordering.
+func (c *Chain) Order(env *common.Envelope, configSeq uint64) error {
+	return c.Submit(&orderer.SubmitRequest{LastValidationSeq: configSeq, Payload: env, Channel: c.channelID}, 0)
+}
+
+// Configure submits config type transactions for ordering.
+func (c *Chain) Configure(env *common.Envelope, configSeq uint64) error {
+	if !isConfig(env) {
+		return errors.New("config transaction has unknown header type")
+	}
+	return c.Submit(&orderer.SubmitRequest{LastValidationSeq: configSeq, Payload: env, Channel: c.channelID}, 0)
+}
+
+// WaitReady blocks when the chain is not able to accept messages.
+func (c *Chain) WaitReady() error {
+	return c.isRunning()
+}
+
+// Errored returns a channel that closes when the chain stops.
+func (c *Chain) Errored() <-chan struct{} {
+	return c.errorC
+}
+
+// Halt stops the chain.
+func (c *Chain) Halt() {
+	select {
+	case <-c.startC:
+	default:
+		c.logger.Warning("Attempted to halt a chain that has not started")
+		return
+	}
+
+	c.halt()
+	<-c.doneC
+}
+
+func (c *Chain) halt() {
+	c.haltOnce.Do(func() {
+		close(c.haltC)
+		close(c.errorC)
+	})
+}
+
+func (c *Chain) isRunning() error {
+	select {
+	case <-c.startC:
+	default:
+		return errors.Errorf("chain is not started")
+	}
+
+	select {
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	default:
+	}
+
+	return nil
+}
+
+// Submit passes a transaction to the chain, either from a local
+// client (sender 0) or relayed by another consenter.
+func (c *Chain) Submit(req *orderer.SubmitRequest, sender uint64) error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	select {
+	case c.submitC <- &submission{req: req, sender: sender}:
+		return nil
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	}
+}
+
+// Consensus passes the given ConsensusRequest message to the chain.
+func (c *Chain) Consensus(req *orderer.ConsensusRequest, sender uint64) error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	msg := &bft.Message{}
+	if err := proto.Unmarshal(req.Payload, msg); err != nil {
+		return errors.Wrap(err, "failed to unmarshal BFT message")
+	}
+
+	select {
+	case c.msgC <- &inboundMessage{msg: msg, sender: sender}:
+		return nil
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	}
+}
+
+func (c *Chain) run() {
+	defer close(c.doneC)
+
+	ticker := c.opts.Clock.NewTicker(c.checkInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case s := <-c.submitC:
+			c.onSubmit(s)
+		case in := <-c.msgC:
+			c.onMessage(in.sender, in.msg)
+		case <-c.batchTimerC:
+			c.batchTimerC = nil
+			if batch := c.support.BlockCutter().Cut(); len(batch) != 0 {
+				c.batches = append(c.batches, batch)
+			}
+			c.propose()
+		case <-ticker.C():
+			c.checkTimeouts()
+		case <-c.haltC:
+			c.stopBatchTimer()
+			c.logger.Infof("Stopped BFT node")
+			return
+		}
+	}
+}
+
+func (c *Chain) checkInterval() time.Duration {
+	interval := c.opts.RequestTimeout
+	if c.opts.ViewChangeTimeout < interval {
+		interval = c.opts.ViewChangeTimeout
+	}
+	return interval / 4
+}
+
+func (c *Chain) quorum() int {
+	return bft.Quorum(len(c.consenters))
+}
+
+func (c *Chain) leader(view uint64) uint64 {
+	return c.consenters[view%uint64(len(c.consenters))].ConsenterId
+}
+
+func (c *Chain) isLeader() bool {
+	return !c.viewChanging && c.leader(c.view) == c.opts.SelfID
+}
+
+func (c *Chain) consenter(id uint64) *bft.Consenter {
+	for _, consenter := range c.consenters {
+		if consenter.ConsenterId == id {
+			return consenter
+		}
+	}
+	return nil
+}
+
+func (c *Chain) nextSeq() uint64 {
+	return c.lastBlock.Header.Number + 1
+}
+
+func (c *Chain) configureComm() error {
+	nodes, err := remoteNodes(c.opts.SelfID, c.consenters)
+	if err != nil {
+		return err
+	}
+	c.configurator.Configure(c.channelID, nodes)
+	return nil
+}
+
+func (c *Chain) broadcast(msg *bft.Message) {
+	payload := utils.MarshalOrPanic(msg)
+	for _, consenter := range c.consenters {
+		if consenter.ConsenterId == c.opts.SelfID {
+			continue
+		}
+		c.send(consenter.ConsenterId, payload)
+	}
+}
+
+func (c *Chain) send(dest uint64, payload []byte) {
+	if err := c.rpc.SendConsensus(dest, &orderer.ConsensusRequest{Channel: c.channelID, Payload: payload}); err != nil {
+		c.logger.Debugf("Failed to send message to %d: %s", dest, err)
+	}
+}
+
+// onSubmit records the request as pending and routes it to the leader.
+func (c *Chain) onSubmit(s *submission) {
+	if s.req.Payload == nil {
+		return
+	}
+
+	key := envelopeHash(s.req.Payload)
+	if _, exists := c.committed[key]; exists {
+		return
+	}
+	pr, exists := c.pending[key]
+	if !exists {
+		pr = &pendingRequest{req: s.req, received: c.opts.Clock.Now()}
+		c.pending[key] = pr
+	}
+
+	if c.isLeader() {
+		c.enqueue(pr)
+		c.propose()
+		return
+	}
+
+	if s.sender != 0 || c.viewChanging {
+		// Relayed to us while we are not the leader, or the leader is not
+		// known yet; it is relayed again once the view is established.
+		return
+	}
+
+	if err := c.rpc.SendSubmit(c.leader(c.view), s.req); err != nil {
+		c.logger.Debugf("Failed to relay request to leader %d: %s", c.leader(c.view), err)
+	}
+}
+
+// enqueue orders a pending request into the batches of the leader.
+func (c *Chain) enqueue(pr *pendingRequest) {
+	if pr.enqueued {
+		return
+	}
+	pr.enqueued = true
+
+	req := pr.req
+	seq := c.support.Sequence()
+
+	if isConfig(req.Payload) {
+		if req.LastValidationSeq < seq {
+			c.logger.Warnf("Config message was validated against %d, although current config seq has advanced (%d)", req.LastValidationSeq, seq)
+			env, _, err := c.support.ProcessConfigMsg(req.Payload)
+			if err != nil {
+				c.logger.Warnf("Discarding bad config message: %s", err)
+				delete(c.pending, envelopeHash(req.Payload))
+				return
+			}
+			req = &orderer.SubmitRequest{Channel: req.Channel, LastValidationSeq: seq, Payload: env}
+		}
+
+		if batch := c.support.BlockCutter().Cut(); len(batch) != 0 {
+			c.batches = append(c.batches, batch)
+		}
+		c.stopBatchTimer()
+		c.batches = append(c.batches, []*common.Envelope{req.Payload})
+		return
+	}
+
+	if req.LastValidationSeq < seq {
+		c.logger.Warnf("Normal message was validated against %d, although current config seq has advanced (%d)", req.LastValidationSeq, seq)
+		if _, err := c.support.ProcessNormalMsg(req.Payload); err != nil {
+			c.logger.Warnf("Discarding bad normal message: %s", err)
+			delete(c.pending, envelopeHash(req.Payload))
+			return
+		}
+	}
+
+	batches, pending := c.support.BlockCutter().Ordered(req.Payload)
+	c.batches = append(c.batches, batches...)
+	if pending {
+		c.startBatchTimer()
+	} else {
+		c.stopBatchTimer()
+	}
+}
+
+func (c *Chain) startBatchTimer() {
+	if c.batchTimerC != nil {
+		return
+	}
+	c.batchTimer = c.opts.Clock.NewTimer(c.support.SharedConfig().BatchTimeout())
+	c.batchTimerC = c.batchTimer.C()
+}
+
+func (c *Chain) stopBatchTimer() {
+	if c.batchTimer != nil {
+		c.batchTimer.Stop()
+	}
+	c.batchTimerC = nil
+}
+
+// propose sends the next proposal if this node is the leader
+// and no proposal is in flight.
+func (c *Chain) propose() {
+	if !c.isLeader() || c.proposal != nil {
+		return
+	}
+
+	seq := c.nextSeq()
+
+	// A block which a quorum may have prepared must be proposed again
+	// before anything else may take its place.
+	if c.locked != nil && c.locked.Block.Header.Number == seq {
+		c.sendPrePrepare(&bft.PrePrepare{View: c.view, Seq: seq, Block: c.locked.Block, Prepared: c.locked})
+		return
+	}
+
+	for len(c.batches) > 0 {
+		batch := c.validEnvelopes(c.batches[0])
+		c.batches = c.batches[1:]
+		if len(batch) == 0 {
+			continue
+		}
+
+		c.sendPrePrepare(&bft.PrePrepare{View: c.view, Seq: seq, Block: c.createBlock(batch)})
+		return
+	}
+}
+
+func (c *Chain) createBlock(batch []*common.Envelope) *common.Block {
+	data := &common.BlockData{
+		Data: make([][]byte, len(batch)),
+	}
+	for i, env := range batch {
+		data.Data[i] = utils.MarshalOrPanic(env)
+	}
+
+	block := common.NewBlock(c.lastBlock.Header.Number+1, c.lastBlock.Header.Hash())
+	block.Header.DataHash = data.Hash()
+	block.Data = data
+	return block
+}
+
+// validEnvelopes returns the envelopes of the batch which are valid
+// against the current configuration.
+func (c *Chain) validEnvelopes(batch []*common.Envelope) []*common.Envelope {
+	var valid []*common.Envelope
+	for _, env := range batch {
+		if err := c.validateEnvelope(env); err != nil {
+			c.logger.Warnf("Discarding invalid message: %s", err)
+			delete(c.pending, envelopeHash(env))
+			continue
+		}
+		valid = append(valid, env)
+	}
+	return valid
+}
+
+func (c *Chain) validateEnvelope(env *common.Envelope) error {
+	if isConfig(env) {
+		// Processing the message checks only its config update, hence the config
+		// computed from the update must also match the config that is proposed,
+		// as a config block that does not is rejected when it is written.
+		computed, _, err := c.support.ProcessConfigMsg(env)
+		if err != nil {
+			return err
+		}
+		return sameConfig(env, computed)
+	}
+	_, err := c.support.ProcessNormalMsg(env)
+	return err
+}
+
+func (c *Chain) sendPrePrepare(pp *bft.PrePrepare) {
+	c.logger.Infof("Proposing block [%d] in view %d", pp.Seq, pp.View)
+	c.broadcast(&bft.Message{Payload: &bft.Message_PrePrepare{PrePrepare: pp}})
+	c.acceptProposal(pp)
+}
+
+func (c *Chain) onMessage(sender uint64, msg *bft.Message) {
+	if c.consenter(sender) == nil {
+		c.logger.Warnf("Ignoring message from %d which is not a consenter", sender)
+		return
+	}
+
+	switch payload := msg.Payload.(type) {
+	case *bft.Message_PrePrepare:
+		c.onPrePrepare(sender, payload.PrePrepare)
+	case *bft.Message_Prepare:
+		c.onPrepare(sender, payload.Prepare)
+	case *bft.Message_Commit:
+		c.onCommit(sender, payload.Commit)
+	case *bft.Message_ViewChange:
+		c.onViewChange(sender, payload.ViewChange)
+	case *bft.Message_BlockRequest:
+		c.onBlockRequest(sender, payload.BlockRequest)
+	case *bft.Message_BlockResponse:
+		c.onBlockResponse(sender, payload.BlockResponse)
+	default:
+		c.logger.Warnf("Ignoring message of unknown type %T from %d", payload, sender)
+	}
+}
+
+// observe tracks the views and sequences the other nodes are at, so that a
+// node which fell behind can catch up with them.
+func (c *Chain) observe(sender, view, seq uint64) {
+	if seq > c.nextSeq() {
+		c.requestBlocks(sender)
+	}
+
+	if view <= c.seenViews[sender] {
+		return
+	}
+	c.seenViews[sender] = view
+
+	// Once more than f nodes moved past our view, at least one correct
+	// node did, so we follow them.
+	if target, ok := c.viewSupportedBy(len(c.consenters) - c.quorum() + 1); ok {
+		c.startViewChange(target)
+	}
+}
+
+// viewSupportedBy returns the highest view above the one this node is in
+// or moving to, which at least the given number of nodes reached.
+func (c *Chain) viewSupportedBy(count int) (uint64, bool) {
+	current := c.view
+	if c.viewChanging {
+		current = c.nextView
+	}
+
+	var views []uint64
+	for _, view := range c.seenViews {
+		if view > current {
+			views = append(views, view)
+		}
+	}
+	if len(views) < count {
+		return 0, false
+	}
+
+	sort.Slice(views, func(i, j int) bool { return views[i] > views[j] })
+	return views[count-1], true
+}
+
+// deferFuture keeps a message of an upcoming block, and returns
+// whether it is not relevant to the current block.
+func (c *Chain) deferFuture(sender uint64, seq uint64, msg *bft.Message) bool {
+	if seq <= c.nextSeq() {
+		return seq < c.nextSeq()
+	}
+	if len(c.future) < maxFutureMessages {
+		c.future = append(c.future, &inboundMessage{msg: msg, sender: sender})
+	}
+	return true
+}
+
+// replayFuture processes the messages kept for upcoming blocks.
+func (c *Chain) replayFuture() {
+	future := c.future
+	c.future = nil
+	for _, in := range future {
+		c.onMessage(in.sender, in.msg)
+	}
+}
+
+func (c *Chain) onPrePrepare(sender uint64, pp *bft.PrePrepare) {
+	c.observe(sender, pp.View, pp.Seq)
+
+	if c.deferFuture(sender, pp.Seq, &bft.Message{Payload: &bft.Message_PrePrepare{PrePrepare: pp}}) || sender != c.leader(pp.View) {
+		return
+	}
+
+	switch {
+	case c.viewChanging && pp.View == c.nextView:
+		// The new leader already installed the view we are moving to.
+		c.installView(pp.View)
+	case c.viewChanging || pp.View != c.view:
+		return
+	}
+
+	if c.proposal != nil {
+		if !bytes.Equal(blockDigest(c.proposal.Block), blockDigest(pp.Block)) {
+			c.logger.Warnf("Leader %d sent conflicting proposals for block [%d] in view %d", sender, pp.Seq, pp.View)
+		}
+		return
+	}
+
+	if err := c.validateProposal(pp); err != nil {
+		c.logger.Warnf("Rejecting proposal for block [%d] from %d: %s", pp.Seq, sender, err)
+		return
+	}
+
+	c.acceptProposal(pp)
+}
+
+// validateProposal checks that the proposed block extends the chain,
+// carries valid transactions, and does not conflict with our lock.
+func (c *Chain) validateProposal(pp *bft.PrePrepare) error {
+	block := pp.Block
+	if block == nil || block.Header == nil || block.Data == nil {
+		return errors.New("proposal is missing its block")
+	}
+	if block.Header.Number != pp.Seq {
+		return errors.Errorf("block number %d does not match the proposed sequence %d", block.Header.Number, pp.Seq)
+	}
+	if !bytes.Equal(block.Header.PreviousHash, c.lastBlock.Header.Hash()) {
+		return errors.New("block does not extend the chain")
+	}
+	if !bytes.Equal(block.Header.DataHash, block.Data.Hash()) {
+		return errors.New("block data hash does not match its data")
+	}
+	if len(block.Data.Data) == 0 {
+		return errors.New("block is empty")
+	}
+
+	envs := make([]*common.Envelope, len(block.Data.Data))
+	for i, data := range block.Data.Data {
+		env, err := utils.UnmarshalEnvelope(data)
+		if err != nil {
+			return err
+		}
+		envs[i] = env
+		if isConfig(env) && len(block.Data.Data) != 1 {
+			return errors.New("config transaction is not alone in its block")
+		}
+	}
+
+	digest := blockDigest(block)
+	if pp.Prepared != nil {
+		if err := c.verifyCertificate(pp.Prepared); err != nil {
+			return errors.WithMessage(err, "invalid prepared certificate")
+		}
+		if pp.Prepared.View >= pp.View || !bytes.Equal(blockDigest(pp.Prepared.Block), digest) {
+			return errors.New("prepared certificate does not justify the proposal")
+		}
+	}
+
+	if c.locked != nil && c.locked.Block.Header.Number == pp.Seq && !bytes.Equal(blockDigest(c.locked.Block), digest) {
+		if pp.Prepared == nil || pp.Prepared.View < c.locked.View {
+			return errors.Errorf("proposal conflicts with the block prepared in view %d", c.locked.View)
+		}
+	}
+
+	for _, env := range envs {
+		if err := c.validateEnvelope(env); err != nil {
+			return err
+		}
+	}
+
+	if pp.Prepared != nil && (c.locked == nil || pp.Prepared.View > c.locked.View) {
+		c.locked = pp.Prepared
+	}
+
+	return nil
+}
+
+func (c *Chain) acceptProposal(pp *bft.PrePrepare) {
+	c.proposal = pp
+	c.proposalStart = c.opts.Clock.Now()
+	c.sentCommit = false
+
+	prepare := &bft.Prepare{
+		View:   pp.View,
+		Seq:    pp.Seq,
+		Digest: blockDigest(pp.Block),
+		Sender: c.opts.SelfID,
+	}
+	signature, err := c.support.Sign(prepareSigningBytes(prepare))
+	if err != nil {
+		c.logger.Panicf("Failed to sign prepare: %s", err)
+	}
+	prepare.Signature = signature
+
+	c.broadcast(&bft.Message{Payload: &bft.Message_Prepare{Prepare: prepare}})
+	c.storePrepare(prepare)
+	c.checkPrepared()
+}
+
+func (c *Chain) onPrepare(sender uint64, prepare *bft.Prepare) {
+	c.observe(sender, prepare.View, prepare.Seq)
+
+	if prepare.Sender != sender || c.deferFuture(sender, prepare.Seq, &bft.Message{Payload: &bft.Message_Prepare{Prepare: prepare}}) {
+		return
+	}
+	if err := c.verifyPrepare(prepare); err != nil {
+		c.logger.Warnf("Ignoring prepare from %d: %s", sender, err)
+		return
+	}
+
+	c.storePrepare(prepare)
+	c.checkPrepared()
+}
+
+func (c *Chain) storePrepare(prepare *bft.Prepare) {
+	key := voteKey{view: prepare.View, seq: prepare.Seq}
+	if c.prepares[key] == nil {
+		c.prepares[key] = make(map[uint64]*bft.Prepare)
+	}
+	c.prepares[key][prepare.Sender] = prepare
+}
+
+// checkPrepared signs the proposal once a quorum prepared it.
+func (c *Chain) checkPrepared() {
+	if c.proposal == nil || c.sentCommit {
+		return
+	}
+
+	digest := blockDigest(c.proposal.Block)
+	var prepares []*bft.Prepare
+	for _, prepare := range c.prepares[voteKey{view: c.proposal.View, seq: c.proposal.Seq}] {
+		if bytes.Equal(prepare.Digest, digest) {
+			prepares = append(prepares, prepare)
+		}
+	}
+	if len(prepares) < c.quorum() {
+		return
+	}
+
+	sort.Slice(prepares, func(i, j int) bool { return prepares[i].Sender < prepares[j].Sender })
+	c.locked = &bft.PreparedCertificate{View: c.proposal.View, Block: c.proposal.Block, Prepares: prepares}
+	c.sentCommit = true
+
+	block := c.proposal.Block
+	signatureHeader := utils.MarshalOrPanic(utils.NewSignatureHeaderOrPanic(c.support))
+	value := blockSignatureValue(block, c.lastConfigIndexFor(block))
+	signature, err := c.support.Sign(blockSignedData(block, value, signatureHeader))
+	if err != nil {
+		c.logger.Panicf("Failed to sign block [%d]: %s", block.Header.Number, err)
+	}
+
+	commit := &bft.Commit{
+		View:   c.proposal.View,
+		Seq:    c.proposal.Seq,
+		Digest: digest,
+		Signature: &common.MetadataSignature{
+			SignatureHeader: signatureHeader,
+			Signature:       signature,
+		},
+	}
+
+	c.broadcast(&bft.Message{Payload: &bft.Message_Commit{Commit: commit}})
+	c.storeCommit(c.opts.SelfID, commit)
+	c.checkCommitted()
+}
+
+func (c *Chain) onCommit(sender uint64, commit *bft.Commit) {
+	c.observe(sender, commit.View, commit.Seq)
+
+	if commit.Signature == nil || c.deferFuture(sender, commit.Seq, &bft.Message{Payload: &bft.Message_Commit{Commit: commit}}) {
+		return
+	}
+
+	c.storeCommit(sender, commit)
+	c.checkCommitted()
+}
+
+func (c *Chain) storeCommit(sender uint64, commit *bft.Commit) {
+	key := voteKey{view: commit.View, seq: commit.Seq}
+	if c.commits[key] == nil {
+		c.commits[key] = make(map[uint64]*bft.Commit)
+	}
+	c.commits[key][sender] = commit
+}
+
+// checkCommitted writes the proposed block once it carries
+// valid signatures of a quorum of the consenters.
+func (c *Chain) checkCommitted() {
+	if c.proposal == nil {
+		return
+	}
+
+	block := c.proposal.Block
+	digest := blockDigest(block)
+	value := blockSignatureValue(block, c.lastConfigIndexFor(block))
+
+	key := voteKey{view: c.proposal.View, seq: c.proposal.Seq}
+	var senders []uint64
+	for sender := range c.commits[key] {
+		senders = append(senders, sender)
+	}
+	if len(senders) < c.quorum() {
+		return
+	}
+	sort.Slice(senders, func(i, j int) bool { return senders[i] < senders[j] })
+
+	var signatures []*common.MetadataSignature
+	for _, sender := range senders {
+		commit := c.commits[key][sender]
+		if !bytes.Equal(commit.Digest, digest) {
+			continue
+		}
+		if err := c.verifyBlockSignature(sender, block, value, commit.Signature); err != nil {
+			c.logger.Warnf("Ignoring commit of block [%d] from %d: %s", block.Header.Number, sender, err)
+			delete(c.commits[key], sender)
+			continue
+		}
+		signatures = append(signatures, commit.Signature)
+	}
+	if len(signatures) < c.quorum() {
+		return
+	}
+
+	block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&common.Metadata{
+		Value:      value,
+		Signatures: signatures,
+	})
+	c.writeBlock(block)
+}
+
+// lastConfigIndexFor returns the index of the last config block
+// as of the given block, which is about to be written.
+func (c *Chain) lastConfigIndexFor(block *common.Block) uint64 {
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return c.lastConfigIndex
+	}
+	if typ, err := headerType(env); err == nil && typ == common.HeaderType_CONFIG {
+		return block.Header.Number
+	}
+	return c.lastConfigIndex
+}
+
+func (c *Chain) isConfigBlock(block *common.Block) bool {
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return false
+	}
+	return isConfig(env)
+}
+
+func (c *Chain) writeBlock(block *common.Block) {
+	c.logger.Infof("Writing block [%d]", block.Header.Number)
+
+	configBlock := c.isConfigBlock(block)
+	if configBlock {
+		c.lastConfigIndex = c.lastConfigIndexFor(block)
+		c.support.WriteConfigBlock(block, nil)
+	} else {
+		c.support.WriteBlock(block, nil)
+	}
+	c.lastBlock = block
+
+	var keys []string
+	for _, data := range block.Data.Data {
+		env, err := utils.UnmarshalEnvelope(data)
+		if err != nil {
+			continue
+		}
+		key := envelopeHash(env)
+		delete(c.pending, key)
+		c.committed[key] = struct{}{}
+		keys = append(keys, key)
+	}
+	c.committedByBlock = append(c.committedByBlock, keys)
+	if len(c.committedByBlock) > recentBlocks {
+		for _, key := range c.committedByBlock[0] {
+			delete(c.committed, key)
+		}
+		c.committedByBlock = c.committedByBlock[1:]
+	}
+
+	c.proposal = nil
+	c.sentCommit = false
+	c.locked = nil
+	for key := range c.prepares {
+		if key.seq <= block.Header.Number {
+			delete(c.prepares, key)
+		}
+	}
+	for key := range c.commits {
+		if key.seq <= block.Header.Number {
+			delete(c.commits, key)
+		}
+	}
+
+	if configBlock {
+		c.applyConfig()
+	}
+
+	// The requests are given a fresh timeout since the leader made progress.
+	now := c.opts.Clock.Now()
+	for _, pr := range c.pending {
+		pr.received = now
+	}
+
+	c.replayFuture()
+	c.propose()
+}
+
+// applyConfig picks up changes of the consenter set and the options
+// after a config block has been written.
+func (c *Chain) applyConfig() {
+	// Config requests are transformed by the leader before they are
+	// ordered, so they cannot be matched against the block.
+	for key, pr := range c.pending {
+		if isConfig(pr.req.Payload) {
+			delete(c.pending, key)
+		}
+	}
+
+	// Requests which were validated against the previous config may no longer be valid.
+	seq := c.support.Sequence()
+	for key, pr := range c.pending {
+		if pr.req.LastValidationSeq >= seq {
+			continue
+		}
+		if _, err := c.support.ProcessNormalMsg(pr.req.Payload); err != nil {
+			c.logger.Warnf("Discarding request which became invalid: %s", err)
+			delete(c.pending, key)
+			continue
+		}
+		pr.req.LastValidationSeq = seq
+	}
+
+	if c.support.SharedConfig().ConsensusType() != bft.TypeKey {
+		c.logger.Warnf("Consensus type changed to %s, halting", c.support.SharedConfig().ConsensusType())
+		c.halt()
+		return
+	}
+
+	m, err := ReadConfigMetadata(c.support.SharedConfig().ConsensusMetadata())
+	if err != nil {
+		c.logger.Panicf("Failed to read BFT metadata of the new config: %s", err)
+	}
+	c.opts.RequestTimeout, c.opts.ViewChangeTimeout, _ = timeouts(m.Options)
+
+	consenters := sortedConsenters(m.Consenters)
+	if proto.Equal(&bft.ConfigMetadata{Consenters: consenters}, &bft.ConfigMetadata{Consenters: c.consenters}) {
+		return
+	}
+
+	c.consenters = consenters
+	if c.consenter(c.opts.SelfID) == nil {
+		c.logger.Warningf("This node was removed from the consenters of the channel, halting")
+		c.halt()
+		return
+	}
+
+	c.logger.Infof("Consenter set changed, there are now %d consenters", len(c.consenters))
+	if err := c.configureComm(); err != nil {
+		c.logger.Panicf("Failed to configure communication: %s", err)
+	}
+}
+
+// checkTimeouts suspects the leader when requests or a proposal
+// are not ordered in time, and moves on when a view change stalls.
+func (c *Chain) checkTimeouts() {
+	now := c.opts.Clock.Now()
+
+	if c.viewChanging {
+		if now.Sub(c.viewChangeStart) > c.opts.ViewChangeTimeout {
+			c.logger.Warnf("View change to view %d timed out", c.nextView)
+			c.startViewChange(c.nextView + 1)
+		}
+		return
+	}
+
+	if c.proposal != nil && now.Sub(c.proposalStart) > c.opts.RequestTimeout {
+		c.logger.Warnf("Block [%d] was not committed in time, suspecting leader %d", c.proposal.Seq, c.leader(c.view))
+		c.startViewChange(c.view + 1)
+		return
+	}
+
+	var suspect bool
+	for _, pr := range c.pending {
+		if now.Sub(pr.received) <= c.opts.RequestTimeout {
+			continue
+		}
+		if pr.forwarded {
+			suspect = true
+			continue
+		}
+		// The leader and the other nodes might have not received the request,
+		// so it is forwarded to all of them before the leader is suspected.
+		pr.forwarded = true
+		pr.received = now
+		for _, consenter := range c.consenters {
+			if consenter.ConsenterId == c.opts.SelfID {
+				continue
+			}
+			if err := c.rpc.SendSubmit(consenter.ConsenterId, pr.req); err != nil {
+				c.logger.Debugf("Failed to forward request to %d: %s", consenter.ConsenterId, err)
+			}
+		}
+	}
+
+	if suspect {
+		c.logger.Warnf("A request was not ordered in time, suspecting leader %d", c.leader(c.view))
+		c.startViewChange(c.view + 1)
+	}
+}
+
+func (c *Chain) startViewChange(view uint64) {
+	if view <= c.view || (c.viewChanging && view <= c.nextView) {
+		return
+	}
+
+	c.logger.Infof("Changing view from %d to %d", c.view, view)
+
+	if c.leader(c.view) == c.opts.SelfID && !c.viewChanging {
+		// Whatever was not proposed yet is still tracked as pending
+		// and is relayed to the next leader.
+		c.support.BlockCutter().Cut()
+		c.batches = nil
+		c.stopBatchTimer()
+	}
+
+	c.viewChanging = true
+	c.viewChangeStart = c.opts.Clock.Now()
+	c.nextView = view
+	c.proposal = nil
+	c.sentCommit = false
+
+	vc := &bft.ViewChange{NextView: view, Prepared: c.locked}
+	c.broadcast(&bft.Message{Payload: &bft.Message_ViewChange{ViewChange: vc}})
+	c.storeViewChange(c.opts.SelfID, vc)
+	c.checkNewView()
+}
+
+func (c *Chain) onViewChange(sender uint64, vc *bft.ViewChange) {
+	if vc.NextView <= c.view {
+		return
+	}
+	if vc.Prepared != nil {
+		if err := c.verifyCertificate(vc.Prepared); err != nil {
+			c.logger.Warnf("Ignoring view change from %d: %s", sender, err)
+			return
+		}
+	}
+
+	c.storeViewChange(sender, vc)
+	c.observe(sender, vc.NextView, 0)
+	c.checkNewView()
+}
+
+func (c *Chain) storeViewChange(sender uint64, vc *bft.ViewChange) {
+	if c.viewChanges[vc.NextView] == nil {
+		c.viewChanges[vc.NextView] = make(map[uint64]*bft.ViewChange)
+	}
+	c.viewChanges[vc.NextView][sender] = vc
+}
+
+// checkNewView installs the view this node is moving to once a quorum
+// asked for it.
+func (c *Chain) checkNewView() {
+	if !c.viewChanging || len(c.viewChanges[c.nextView]) < c.quorum() {
+		return
+	}
+
+	view := c.nextView
+	var highest *bft.PreparedCertificate
+	for _, vc := range c.viewChanges[view] {
+		cert := vc.Prepared
+		if cert == nil || cert.Block.Header.Number != c.nextSeq() {
+			continue
+		}
+		if highest == nil || cert.View > highest.View {
+			highest = cert
+		}
+	}
+	if highest != nil && (c.locked == nil || highest.View > c.locked.View) {
+		c.locked = highest
+	}
+
+	c.installView(view)
+}
+
+func (c *Chain) installView(view uint64) {
+	c.logger.Infof("Installed view %d, leader is %d", view, c.leader(view))
+
+	c.view = view
+	c.viewChanging = false
+	c.proposal = nil
+	c.sentCommit = false
+	for v := range c.viewChanges {
+		if v <= view {
+			delete(c.viewChanges, v)
+		}
+	}
+
+	now := c.opts.Clock.Now()
+	for _, pr := range c.pending {
+		pr.received = now
+		pr.enqueued = false
+		pr.forwarded = false
+	}
+
+	if c.leader(view) != c.opts.SelfID {
+		for _, pr := range c.pending {
+			if err := c.rpc.SendSubmit(c.leader(view), pr.req); err != nil {
+				c.logger.Debugf("Failed to relay request to leader %d: %s", c.leader(view), err)
+			}
+		}
+		return
+	}
+
+	for _, pr := range c.pending {
+		c.enqueue(pr)
+	}
+	c.propose()
+}
+
+func (c *Chain) requestBlocks(sender uint64) {
+	next := c.nextSeq()
+	if c.syncRequested == next && c.opts.Clock.Since(c.syncStart) < c.opts.RequestTimeout {
+		return
+	}
+	c.syncRequested = next
+	c.syncStart = c.opts.Clock.Now()
+
+	c.logger.Infof("Node %d is ahead of us, requesting block [%d]", sender, next)
+	c.send(sender, utils.MarshalOrPanic(&bft.Message{Payload: &bft.Message_BlockRequest{BlockRequest: &bft.BlockRequest{Seq: next}}}))
+}
+
+func (c *Chain) onBlockRequest(sender uint64, req *bft.BlockRequest) {
+	if req.Seq >= c.nextSeq() {
+		return
+	}
+	block := c.support.Block(req.Seq)
+	if block == nil {
+		return
+	}
+	c.send(sender, utils.MarshalOrPanic(&bft.Message{Payload: &bft.Message_BlockResponse{BlockResponse: &bft.BlockResponse{Block: block}}}))
+}
+
+// onBlockResponse writes a block pulled from another node
+// if it carries the signatures of a quorum of the consenters.
+func (c *Chain) onBlockResponse(sender uint64, resp *bft.BlockResponse) {
+	block := resp.Block
+	if block == nil || block.Header == nil || block.Data == nil || block.Header.Number != c.nextSeq() {
+		return
+	}
+	if !bytes.Equal(block.Header.PreviousHash, c.lastBlock.Header.Hash()) || !bytes.Equal(block.Header.DataHash, block.Data.Hash()) {
+		c.logger.Warnf("Block [%d] from %d does not extend the chain", block.Header.Number, sender)
+		return
+	}
+	if err := bft.VerifyBlockSignatures(block, c.consenters, c.verifySignature); err != nil {
+		c.logger.Warnf("Block [%d] from %d is not properly signed: %s", block.Header.Number, sender, err)
+		return
+	}
+
+	c.writeBlock(block)
+	c.requestBlocks(sender)
+}
+
+func (c *Chain) verifySignature(identity, data, signature []byte) error {
+	return c.support.VerifyBlockSignature([]*common.SignedData{{
+		Identity:  identity,
+		Data:      data,
+		Signature: signature,
+	}}, nil)
+}
+
+func (c *Chain) verifyPrepare(prepare *bft.Prepare) error {
+	consenter := c.consenter(prepare.Sender)
+	if consenter == nil {
+		return errors.Errorf("%d is not a consenter", prepare.Sender)
+	}
+	return c.verifySignature(consenter.Identity, prepareSigningBytes(prepare), prepare.Signature)
+}
+
+func (c *Chain) verifyBlockSignature(sender uint64, block *common.Block, value []byte, signature *common.MetadataSignature) error {
+	shdr, err := utils.GetSignatureHeader(signature.SignatureHeader)
+	if err != nil {
+		return err
+	}
+	consenter := c.consenter(sender)
+	if consenter == nil || !bytes.Equal(shdr.Creator, consenter.Identity) {
+		return errors.Errorf("block is not signed by the identity of consenter %d", sender)
+	}
+	return c.verifySignature(shdr.Creator, blockSignedData(block, value, signature.SignatureHeader), signature.Signature)
+}
+
+// verifyCertificate checks that a quorum of distinct consenters
+// prepared the block of the certificate.
+func (c *Chain) verifyCertificate(cert *bft.PreparedCertificate) error {
+	if cert.Block == nil || cert.Block.Header == nil {
+		return errors.New("certificate is missing its block")
+	}
+
+	digest := blockDigest(cert.Block)
+	senders := make(map[uint64]struct{})
+	for _, prepare := range cert.Prepares {
+		if prepare.View != cert.View || prepare.Seq != cert.Block.Header.Number || !bytes.Equal(prepare.Digest, digest) {
+			continue
+		}
+		if err := c.verifyPrepare(prepare); err != nil {
+			continue
+		}
+		senders[prepare.Sender] = struct{}{}
+	}
+
+	if len(senders) < c.quorum() {
+		return errors.Errorf("certificate has %d valid prepares, but a quorum of %d is required", len(senders), c.quorum())
+	}
+	return nil
+}
@@ -0,0 +1,535 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft_test
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter/mock"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/consensus/bft"
+	"github.com/hyperledger/fabric/orderer/consensus/mocks"
+	mockblockcutter "github.com/hyperledger/fabric/orderer/mocks/common/blockcutter"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	bftprotos "github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/hyperledger/fabric/protos/utils"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	channelID         = "test-channel"
+	requestTimeout    = 10 * time.Second
+	viewChangeTimeout = 20 * time.Second
+)
+
+func identity(id uint64) []byte {
+	return []byte(fmt.Sprintf("node%d", id))
+}
+
+// sign produces the signature of an identity over data,
+// which is the identity followed by the data.
+func sign(identity, data []byte) []byte {
+	return append(append([]byte{}, identity...), data...)
+}
+
+func verify(identity, data, signature []byte) error {
+	if !bytes.Equal(signature, sign(identity, data)) {
+		return errors.New("bad signature")
+	}
+	return nil
+}
+
+var (
+	ca       tlsgen.CA
+	certs    = make(map[uint64][]byte)
+	certLock sync.Mutex
+)
+
+func init() {
+	var err error
+	if ca, err = tlsgen.NewCA(); err != nil {
+		panic(err)
+	}
+}
+
+// tlsCert returns the TLS certificate of the given node.
+func tlsCert(id uint64) []byte {
+	certLock.Lock()
+	defer certLock.Unlock()
+	if cert, exists := certs[id]; exists {
+		return cert
+	}
+	kp, err := ca.NewServerCertKeyPair("localhost")
+	if err != nil {
+		panic(err)
+	}
+	certs[id] = kp.Cert
+	return kp.Cert
+}
+
+func consenterSet(ids ...uint64) []*bftprotos.Consenter {
+	var consenters []*bftprotos.Consenter
+	for _, id := range ids {
+		consenters = append(consenters, &bftprotos.Consenter{
+			ConsenterId:   id,
+			Host:          "localhost",
+			Port:          uint32(7050 + id),
+			Identity:      identity(id),
+			ClientTlsCert: tlsCert(id),
+			ServerTlsCert: tlsCert(id),
+		})
+	}
+	return consenters
+}
+
+func envelope(typ common.HeaderType, data string) *common.Envelope {
+	return &common.Envelope{
+		Payload: utils.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{
+				ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{Type: int32(typ), ChannelId: channelID}),
+			},
+			Data: []byte(data),
+		}),
+	}
+}
+
+func configEnvelope(update string, config *common.Config) *common.Envelope {
+	return &common.Envelope{
+		Payload: utils.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{
+				ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{Type: int32(common.HeaderType_CONFIG), ChannelId: channelID}),
+			},
+			Data: utils.MarshalOrPanic(&common.ConfigEnvelope{
+				Config:     config,
+				LastUpdate: envelope(common.HeaderType_CONFIG_UPDATE, update),
+			}),
+		}),
+	}
+}
+
+type node struct {
+	id      uint64
+	chain   *bft.Chain
+	support *mocks.FakeConsenterSupport
+	cutter  *mockblockcutter.Receiver
+	config  *mock.OrdererConfig
+
+	lock    sync.Mutex
+	ledger  []*common.Block
+	byzSign bool
+}
+
+func (n *node) height() int {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return len(n.ledger)
+}
+
+func (n *node) block(number uint64) *common.Block {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if number >= uint64(len(n.ledger)) {
+		return nil
+	}
+	return n.ledger[number]
+}
+
+func (n *node) write(block *common.Block) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.ledger = append(n.ledger, block)
+}
+
+// link delivers the messages from one node to another in order.
+type link struct {
+	sync.Mutex
+	cond  *sync.Cond
+	queue []func()
+}
+
+func newLink() *link {
+	l := &link{}
+	l.cond = sync.NewCond(l)
+	go func() {
+		for {
+			l.Lock()
+			for len(l.queue) == 0 {
+				l.cond.Wait()
+			}
+			deliver := l.queue[0]
+			l.queue = l.queue[1:]
+			l.Unlock()
+			deliver()
+		}
+	}()
+	return l
+}
+
+func (l *link) push(deliver func()) {
+	l.Lock()
+	defer l.Unlock()
+	l.queue = append(l.queue, deliver)
+	l.cond.Signal()
+}
+
+type network struct {
+	lock         sync.RWMutex
+	nodes        map[uint64]*node
+	links        map[[2]uint64]*link
+	disconnected map[uint64]bool
+	clock        *fakeclock.FakeClock
+}
+
+func newNetwork(t *testing.T, size uint64) *network {
+	var ids []uint64
+	for id := uint64(1); id <= size; id++ {
+		ids = append(ids, id)
+	}
+	metadata := utils.MarshalOrPanic(&bftprotos.ConfigMetadata{Consenters: consenterSet(ids...)})
+
+	genesis := common.NewBlock(0, nil)
+	genesis.Data.Data = [][]byte{utils.MarshalOrPanic(envelope(common.HeaderType_CONFIG, "genesis"))}
+	genesis.Header.DataHash = genesis.Data.Hash()
+
+	n := &network{
+		nodes:        make(map[uint64]*node),
+		links:        make(map[[2]uint64]*link),
+		disconnected: make(map[uint64]bool),
+		clock:        fakeclock.NewFakeClock(time.Now()),
+	}
+	for _, from := range ids {
+		for _, to := range ids {
+			if from != to {
+				n.links[[2]uint64{from, to}] = newLink()
+			}
+		}
+	}
+
+	for _, id := range ids {
+		nd := &node{id: id, ledger: []*common.Block{genesis}}
+		nd.cutter = mockblockcutter.NewReceiver()
+		nd.cutter.CutNext = true
+		close(nd.cutter.Block)
+
+		nd.config = &mock.OrdererConfig{}
+		nd.config.ConsensusTypeReturns("bft")
+		nd.config.ConsensusMetadataReturns(metadata)
+		nd.config.BatchTimeoutReturns(time.Second)
+
+		nd.support = newSupport(nd)
+
+		chain, err := bft.NewChain(nd.support, bft.Options{
+			SelfID:            id,
+			Consenters:        consenterSet(ids...),
+			RequestTimeout:    requestTimeout,
+			ViewChangeTimeout: viewChangeTimeout,
+			Clock:             n.clock,
+			Logger:            flogging.MustGetLogger("orderer.consensus.bft.test"),
+		}, &configurator{}, &rpc{network: n, from: id})
+		if err != nil {
+			t.Fatalf("failed creating chain: %s", err)
+		}
+		nd.chain = chain
+		n.nodes[id] = nd
+	}
+
+	return n
+}
+
+func newSupport(nd *node) *mocks.FakeConsenterSupport {
+	support := &mocks.FakeConsenterSupport{}
+	support.ChainIDReturns(channelID)
+	support.HeightCalls(func() uint64 { return uint64(nd.height()) })
+	support.BlockCalls(nd.block)
+	support.BlockCutterReturns(nd.cutter)
+	support.SharedConfigReturns(nd.config)
+	support.NewSignatureHeaderReturns(&common.SignatureHeader{Creator: identity(nd.id)}, nil)
+	support.SignCalls(func(data []byte) ([]byte, error) {
+		nd.lock.Lock()
+		defer nd.lock.Unlock()
+		if nd.byzSign {
+			return []byte("forged"), nil
+		}
+		return sign(identity(nd.id), data), nil
+	})
+	support.VerifyBlockSignatureCalls(func(sd []*common.SignedData, _ *common.ConfigEnvelope) error {
+		return verify(sd[0].Identity, sd[0].Data, sd[0].Signature)
+	})
+	support.ProcessConfigMsgCalls(func(env *common.Envelope) (*common.Envelope, uint64, error) {
+		return env, 0, nil
+	})
+	support.WriteBlockCalls(func(block *common.Block, _ []byte) { nd.write(block) })
+	support.WriteConfigBlockCalls(func(block *common.Block, _ []byte) { nd.write(block) })
+	return support
+}
+
+func (n *network) start() {
+	for _, nd := range n.nodes {
+		nd.chain.Start()
+	}
+}
+
+func (n *network) stop() {
+	for _, nd := range n.nodes {
+		nd.chain.Halt()
+	}
+}
+
+func (n *network) disconnect(id uint64) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.disconnected[id] = true
+}
+
+func (n *network) connect(id uint64) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	delete(n.disconnected, id)
+}
+
+func (n *network) send(from, to uint64, deliver func(dest *bft.Chain)) error {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	l, exists := n.links[[2]uint64{from, to}]
+	if !exists {
+		return errors.Errorf("node %d is unknown", to)
+	}
+	if n.disconnected[from] || n.disconnected[to] {
+		return nil
+	}
+	dest := n.nodes[to].chain
+	l.push(func() { deliver(dest) })
+	return nil
+}
+
+type rpc struct {
+	network *network
+	from    uint64
+}
+
+func (r *rpc) SendConsensus(dest uint64, msg *orderer.ConsensusRequest) error {
+	return r.network.send(r.from, dest, func(chain *bft.Chain) { chain.Consensus(msg, r.from) })
+}
+
+func (r *rpc) SendSubmit(dest uint64, request *orderer.SubmitRequest) error {
+	return r.network.send(r.from, dest, func(chain *bft.Chain) { chain.Submit(request, r.from) })
+}
+
+type configurator struct{}
+
+func (*configurator) Configure(channel string, newNodes []cluster.RemoteNode) {}
+
+// verifyLedgers checks that the given nodes have the same blocks, each of
+// which is signed by a quorum of the consenters.
+func verifyLedgers(t *testing.T, consenters []*bftprotos.Consenter, height int, nodes ...*node) {
+	for number := 1; number < height; number++ {
+		expected := nodes[0].block(uint64(number))
+		assert.NoError(t, bftprotos.VerifyBlockSignatures(expected, consenters, verify))
+		for _, nd := range nodes[1:] {
+			assert.True(t, proto.Equal(expected.Header, nd.block(uint64(number)).Header), "block [%d] of node %d", number, nd.id)
+		}
+	}
+}
+
+func TestChainOrdersTransactions(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	n := newNetwork(t, 4)
+	n.start()
+	defer n.stop()
+
+	// Submitted to the leader
+	assert.NoError(t, n.nodes[1].chain.Order(envelope(common.HeaderType_ENDORSER_TRANSACTION, "tx1"), 0))
+	// Relayed to the leader by followers
+	assert.NoError(t, n.nodes[2].chain.Order(envelope(common.HeaderType_ENDORSER_TRANSACTION, "tx2"), 0))
+	assert.NoError(t, n.nodes[4].chain.Order(envelope(common.HeaderType_ENDORSER_TRANSACTION, "tx3"), 0))
+
+	for _, nd := range n.nodes {
+		gt.Eventually(nd.height, time.Minute).Should(Equal(4))
+	}
+	verifyLedgers(t, consenterSet(1, 2, 3, 4), 4, n.nodes[1], n.nodes[2], n.nodes[3], n.nodes[4])
+
+	block := n.nodes[3].block(1)
+	md := utils.GetMetadataFromBlockOrPanic(block, common.BlockMetadataIndex_SIGNATURES)
+	assert.True(t, len(md.Signatures) >= 3, "block should carry a quorum of signatures")
+}
+
+func TestChainToleratesFaultyNode(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	t.Run("crashed", func(t *testing.T) {
+		n := newNetwork(t, 4)
+		n.start()
+		defer n.stop()
+
+		n.disconnect(3)
+		assert.NoError(t, n.nodes[2].chain.Order(envelope(common.HeaderType_ENDORSER_TRANSACTION, "tx1"), 0))
+		for _, id := range []uint64{1, 2, 4} {
+			gt.Eventually(n.nodes[id].height, time.Minute).Should(Equal(2))
+		}
+		assert.Equal(t, 1, n.nodes[3].height())
+		verifyLedgers(t, consenterSet(1, 2, 3, 4), 2, n.nodes[1], n.nodes[2], n.nodes[4])
+	})
+
+	t.Run("forging signatures", func(t *testing.T) {
+		n := newNetwork(t, 4)
+		n.nodes[4].byzSign = true
+		n.start()
+		defer n.stop()
+
+		assert.NoError(t, n.nodes[2].chain.Order(envelope(common.HeaderType_ENDORSER_TRANSACTION, "tx1"), 0))
+		for _, nd := range n.nodes {
+			gt.Eventually(nd.height, time.Minute).Should(Equal(2))
+		}
+		verifyLedgers(t, consenterSet(1, 2, 3), 2, n.nodes[1], n.nodes[2], n.nodes[3], n.nodes[4])
+	})
+}
+
+func TestChainViewChange(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	n := newNetwork(t, 4)
+	n.start()
+	defer n.stop()
+
+	// The leader of the first view is node 1
+	n.disconnect(1)
+	assert.NoError(t, n.nodes[2].chain.Order(envelope(common.HeaderType_ENDORSER_TRANSACTION, "tx1"), 0))
+
+	gt.Eventually(func() int {
+		n.clock.Increment(requestTimeout / 2)
+		return n.nodes[2].height()
+	}, time.Minute).Should(Equal(2))
+
+	for _, id := range []uint64{3, 4} {
+		gt.Eventually(n.nodes[id].height, time.Minute).Should(Equal(2))
+	}
+	assert.Equal(t, 1, n.nodes[1].height())
+	verifyLedgers(t, consenterSet(1, 2, 3, 4), 2, n.nodes[2], n.nodes[3], n.nodes[4])
+
+	// The new leader keeps ordering transactions
+	assert.NoError(t, n.nodes[3].chain.Order(envelope(common.HeaderType_ENDORSER_TRANSACTION, "tx2"), 0))
+	for _, id := range []uint64{2, 3, 4} {
+		gt.Eventually(n.nodes[id].height, time.Minute).Should(Equal(3))
+	}
+}
+
+func TestChainCatchesUp(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	n := newNetwork(t, 4)
+	n.start()
+	defer n.stop()
+
+	n.disconnect(4)
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, n.nodes[1].chain.Order(envelope(common.HeaderType_ENDORSER_TRANSACTION, fmt.Sprintf("tx%d", i)), 0))
+	}
+	for _, id := range []uint64{1, 2, 3} {
+		gt.Eventually(n.nodes[id].height, time.Minute).Should(Equal(4))
+	}
+
+	n.connect(4)
+	assert.NoError(t, n.nodes[1].chain.Order(envelope(common.HeaderType_ENDORSER_TRANSACTION, "tx3"), 0))
+	for _, nd := range n.nodes {
+		gt.Eventually(nd.height, time.Minute).Should(Equal(5))
+	}
+	verifyLedgers(t, consenterSet(1, 2, 3, 4), 5, n.nodes[1], n.nodes[2], n.nodes[3], n.nodes[4])
+}
+
+func TestChainConfig(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	n := newNetwork(t, 4)
+	n.start()
+	defer n.stop()
+
+	assert.EqualError(t, n.nodes[1].chain.Configure(envelope(common.HeaderType_ENDORSER_TRANSACTION, "tx"), 0), "config transaction has unknown header type")
+
+	// Node 4 is removed by the config update
+	metadata := utils.MarshalOrPanic(&bftprotos.ConfigMetadata{Consenters: consenterSet(1, 2, 3)})
+	for _, nd := range n.nodes {
+		nd := nd
+		nd.support.WriteConfigBlockCalls(func(block *common.Block, _ []byte) {
+			nd.config.ConsensusMetadataReturns(metadata)
+			nd.write(block)
+		})
+	}
+
+	assert.NoError(t, n.nodes[2].chain.Configure(configEnvelope("update", &common.Config{Sequence: 1}), 0))
+	for _, nd := range n.nodes {
+		gt.Eventually(nd.height, time.Minute).Should(Equal(2))
+		assert.Equal(t, 1, nd.support.WriteConfigBlockCallCount())
+	}
+	gt.Eventually(n.nodes[4].chain.Errored(), time.Minute).Should(BeClosed())
+	assert.Error(t, n.nodes[4].chain.Order(envelope(common.HeaderType_ENDORSER_TRANSACTION, "tx"), 0))
+
+	// The remaining consenters keep ordering transactions
+	assert.NoError(t, n.nodes[3].chain.Order(envelope(common.HeaderType_ENDORSER_TRANSACTION, "tx"), 0))
+	for _, id := range []uint64{1, 2, 3} {
+		gt.Eventually(n.nodes[id].height, time.Minute).Should(Equal(3))
+	}
+	verifyLedgers(t, consenterSet(1, 2, 3), 3, n.nodes[1], n.nodes[2], n.nodes[3])
+}
+
+func TestChainRejectsForgedConfig(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	n := newNetwork(t, 4)
+	// The followers compute the config from the config update, whereas the
+	// byzantine leader proposes a config which does not follow from it.
+	for _, id := range []uint64{2, 3, 4} {
+		n.nodes[id].support.ProcessConfigMsgCalls(func(env *common.Envelope) (*common.Envelope, uint64, error) {
+			return configEnvelope("update", &common.Config{Sequence: 1}), 0, nil
+		})
+	}
+	n.start()
+	defer n.stop()
+
+	forged := configEnvelope("update", &common.Config{Sequence: 1, ChannelGroup: &common.ConfigGroup{ModPolicy: "forged"}})
+	assert.NoError(t, n.nodes[1].chain.Configure(forged, 0))
+	for _, nd := range n.nodes {
+		gt.Consistently(nd.height, time.Second).Should(Equal(1))
+		assert.Equal(t, 0, nd.support.WriteConfigBlockCallCount())
+	}
+}
+
+func TestChainLifecycle(t *testing.T) {
+	n := newNetwork(t, 1)
+	chain := n.nodes[1].chain
+
+	assert.EqualError(t, chain.WaitReady(), "chain is not started")
+	assert.EqualError(t, chain.Order(envelope(common.HeaderType_ENDORSER_TRANSACTION, "tx"), 0), "chain is not started")
+
+	chain.Start()
+	assert.NoError(t, chain.WaitReady())
+	select {
+	case <-chain.Errored():
+		t.Fatal("chain should not be errored")
+	default:
+	}
+
+	// A single consenter orders by itself
+	assert.NoError(t, chain.Order(envelope(common.HeaderType_ENDORSER_TRANSACTION, "tx"), 0))
+	NewGomegaWithT(t).Eventually(n.nodes[1].height, time.Minute).Should(Equal(2))
+
+	chain.Halt()
+	assert.EqualError(t, chain.WaitReady(), "chain is stopped")
+	assert.EqualError(t, chain.Order(envelope(common.HeaderType_ENDORSER_TRANSACTION, "tx"), 0), "chain is stopped")
+	<-chain.Errored()
+}
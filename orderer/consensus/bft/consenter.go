@@ -0,0 +1,193 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"reflect"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/inactive"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/pkg/errors"
+)
+
+// ChainGetter obtains instances of ChainSupport for the given channel
+type ChainGetter interface {
+	// GetChain obtains the ChainSupport for the given channel.
+	// Returns nil when the ChainSupport for the given channel
+	// isn't found.
+	GetChain(chainID string) *multichannel.ChainSupport
+}
+
+// Consenter implements the BFT consenter
+type Consenter struct {
+	Communication cluster.Communicator
+	Chains        ChainGetter
+	Logger        *flogging.FabricLogger
+	OrdererConfig localconfig.TopLevel
+	Cert          []byte
+}
+
+// TargetChannel extracts the channel from the given proto.Message.
+// Returns an empty string on failure.
+func (c *Consenter) TargetChannel(message proto.Message) string {
+	switch req := message.(type) {
+	case *orderer.ConsensusRequest:
+		return req.Channel
+	case *orderer.SubmitRequest:
+		return req.Channel
+	default:
+		return ""
+	}
+}
+
+// ReceiverByChain returns the BFT chain of the given channel,
+// or nil if there is none.
+func (c *Consenter) ReceiverByChain(channelID string) *Chain {
+	cs := c.Chains.GetChain(channelID)
+	if cs == nil {
+		return nil
+	}
+	if cs.Chain == nil {
+		c.Logger.Panicf("Programming error - Chain %s is nil although it exists in the mapping", channelID)
+	}
+	if bftChain, isBFTChain := cs.Chain.(*Chain); isBFTChain {
+		return bftChain
+	}
+	c.Logger.Warningf("Chain %s is of type %v and not bft.Chain", channelID, reflect.TypeOf(cs.Chain))
+	return nil
+}
+
+// OnConsensus passes a consensus message received from the given sender to the chain of the channel.
+func (c *Consenter) OnConsensus(channel string, sender uint64, request *orderer.ConsensusRequest) error {
+	receiver := c.ReceiverByChain(channel)
+	if receiver == nil {
+		c.Logger.Warningf("An attempt to send a consensus request to a non existing channel (%s) was made by %d", channel, sender)
+		return errors.Errorf("channel %s doesn't exist", channel)
+	}
+	return receiver.Consensus(request, sender)
+}
+
+// OnSubmit passes a transaction relayed by the given sender to the chain of the channel.
+func (c *Consenter) OnSubmit(channel string, sender uint64, request *orderer.SubmitRequest) error {
+	receiver := c.ReceiverByChain(channel)
+	if receiver == nil {
+		c.Logger.Warningf("An attempt to submit a transaction to a non existing channel (%s) was made by %d", channel, sender)
+		return errors.Errorf("channel %s doesn't exist", channel)
+	}
+	return receiver.Submit(request, sender)
+}
+
+// HandleChain returns a new Chain instance or an error upon failure
+func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *common.Metadata) (consensus.Chain, error) {
+	m, err := ReadConfigMetadata(support.SharedConfig().ConsensusMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := detectSelfID(c.Cert, m.Consenters)
+	if err != nil {
+		if err != cluster.ErrNotInChannel {
+			return nil, err
+		}
+		return &inactive.Chain{Err: errors.Errorf("channel %s is not serviced by me", support.ChainID())}, nil
+	}
+
+	requestTimeout, viewChangeTimeout, err := timeouts(m.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := Options{
+		SelfID:            id,
+		Consenters:        m.Consenters,
+		RequestTimeout:    requestTimeout,
+		ViewChangeTimeout: viewChangeTimeout,
+		Clock:             clock.NewClock(),
+		Logger:            c.Logger,
+	}
+
+	rpc := &cluster.RPC{
+		Timeout:       c.OrdererConfig.General.Cluster.RPCTimeout,
+		Logger:        c.Logger,
+		Channel:       support.ChainID(),
+		Comm:          c.Communication,
+		StreamsByType: cluster.NewStreamsByType(),
+	}
+	return NewChain(support, opts, c.Communication, rpc)
+}
+
+// New creates a BFT Consenter
+func New(
+	clusterDialer *cluster.PredicateDialer,
+	conf *localconfig.TopLevel,
+	srvConf comm.ServerConfig,
+	srv *comm.GRPCServer,
+	r *multichannel.Registrar,
+	metricsProvider metrics.Provider,
+) *Consenter {
+	logger := flogging.MustGetLogger("orderer.consensus.bft")
+
+	consenter := &Consenter{
+		Cert:          srvConf.SecOpts.Certificate,
+		Logger:        logger,
+		Chains:        r,
+		OrdererConfig: *conf,
+	}
+
+	comm := createComm(clusterDialer, consenter, conf.General.Cluster, metricsProvider)
+	svc := &cluster.Service{
+		CertExpWarningThreshold:          conf.General.Cluster.CertExpirationWarningThreshold,
+		MinimumExpirationWarningInterval: cluster.MinimumExpirationWarningInterval,
+		StreamCountReporter: &cluster.StreamCountReporter{
+			Metrics: comm.Metrics,
+		},
+		StepLogger: flogging.MustGetLogger("orderer.common.cluster.step"),
+		Logger:     flogging.MustGetLogger("orderer.common.cluster"),
+		Dispatcher: comm,
+	}
+	orderer.RegisterClusterServer(srv.Server(), svc)
+	return consenter
+}
+
+func createComm(clusterDialer *cluster.PredicateDialer, c *Consenter, config localconfig.Cluster, p metrics.Provider) *cluster.Comm {
+	metrics := cluster.NewMetrics(p)
+	logger := flogging.MustGetLogger("orderer.common.cluster")
+
+	compareCert := cluster.CachePublicKeyComparisons(func(a, b []byte) bool {
+		err := crypto.CertificatesWithSamePublicKey(a, b)
+		if err != nil && err != crypto.ErrPubKeyMismatch {
+			crypto.LogNonPubKeyMismatchErr(logger.Errorf, err, a, b)
+		}
+		return err == nil
+	})
+
+	comm := &cluster.Comm{
+		MinimumExpirationWarningInterval: cluster.MinimumExpirationWarningInterval,
+		CertExpWarningThreshold:          config.CertExpirationWarningThreshold,
+		SendBufferSize:                   config.SendBufferSize,
+		Logger:                           logger,
+		Chan2Members:                     make(map[string]cluster.MemberMapping),
+		Connections:                      cluster.NewConnectionStore(clusterDialer, metrics.EgressTLSConnectionCount),
+		Metrics:                          metrics,
+		ChanExt:                          c,
+		H:                                c,
+		CompareCertificate:               compareCert,
+	}
+	c.Communication = comm
+	return comm
+}
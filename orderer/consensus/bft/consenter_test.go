@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft_test
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter/mock"
+	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/consensus/bft"
+	"github.com/hyperledger/fabric/orderer/consensus/inactive"
+	"github.com/hyperledger/fabric/orderer/consensus/mocks"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	bftprotos "github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type chainGetter map[string]*multichannel.ChainSupport
+
+func (cg chainGetter) GetChain(chainID string) *multichannel.ChainSupport {
+	return cg[chainID]
+}
+
+func newConsenterSupport(metadata []byte) *mocks.FakeConsenterSupport {
+	config := &mock.OrdererConfig{}
+	config.ConsensusTypeReturns("bft")
+	config.ConsensusMetadataReturns(metadata)
+
+	support := &mocks.FakeConsenterSupport{}
+	support.ChainIDReturns(channelID)
+	support.SharedConfigReturns(config)
+	support.HeightReturns(1)
+	support.BlockReturns(common.NewBlock(0, nil))
+	return support
+}
+
+func TestHandleChain(t *testing.T) {
+	metadata := utils.MarshalOrPanic(&bftprotos.ConfigMetadata{Consenters: consenterSet(1, 2, 3, 4)})
+	consenter := &bft.Consenter{
+		Logger: flogging.MustGetLogger("orderer.consensus.bft.test"),
+		Cert:   tlsCert(3),
+	}
+
+	t.Run("consenter of the channel", func(t *testing.T) {
+		chain, err := consenter.HandleChain(newConsenterSupport(metadata), nil)
+		require.NoError(t, err)
+		assert.IsType(t, &bft.Chain{}, chain)
+	})
+
+	t.Run("not a consenter of the channel", func(t *testing.T) {
+		consenter := &bft.Consenter{
+			Logger: consenter.Logger,
+			Cert:   tlsCert(5),
+		}
+		chain, err := consenter.HandleChain(newConsenterSupport(metadata), nil)
+		require.NoError(t, err)
+		assert.IsType(t, &inactive.Chain{}, chain)
+		assert.EqualError(t, chain.WaitReady(), "channel test-channel is not serviced by me")
+	})
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		badMetadata := utils.MarshalOrPanic(&bftprotos.ConfigMetadata{})
+		_, err := consenter.HandleChain(newConsenterSupport(badMetadata), nil)
+		assert.EqualError(t, err, "empty consenter set")
+	})
+}
+
+func TestConsenterDispatch(t *testing.T) {
+	consenter := &bft.Consenter{
+		Logger: flogging.MustGetLogger("orderer.consensus.bft.test"),
+		Chains: chainGetter{},
+	}
+
+	assert.Equal(t, "foo", consenter.TargetChannel(&orderer.ConsensusRequest{Channel: "foo"}))
+	assert.Equal(t, "bar", consenter.TargetChannel(&orderer.SubmitRequest{Channel: "bar"}))
+	assert.Equal(t, "", consenter.TargetChannel(&orderer.StepRequest{}))
+
+	assert.Nil(t, consenter.ReceiverByChain("foo"))
+	assert.EqualError(t, consenter.OnConsensus("foo", 1, &orderer.ConsensusRequest{}), "channel foo doesn't exist")
+	assert.EqualError(t, consenter.OnSubmit("foo", 1, &orderer.SubmitRequest{}), "channel foo doesn't exist")
+}
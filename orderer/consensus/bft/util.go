@@ -0,0 +1,269 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultRequestTimeout is used when the channel configuration
+	// does not specify a request timeout.
+	DefaultRequestTimeout = 10 * time.Second
+
+	// DefaultViewChangeTimeout is used when the channel configuration
+	// does not specify a view change timeout.
+	DefaultViewChangeTimeout = 20 * time.Second
+)
+
+// ReadConfigMetadata unmarshals and validates the BFT metadata
+// carried in the ConsensusType of a channel configuration.
+func ReadConfigMetadata(consensusMetadata []byte) (*bft.ConfigMetadata, error) {
+	m := &bft.ConfigMetadata{}
+	if err := proto.Unmarshal(consensusMetadata, m); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal consensus metadata")
+	}
+	if err := CheckConfigMetadata(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CheckConfigMetadata validates BFT config metadata.
+func CheckConfigMetadata(metadata *bft.ConfigMetadata) error {
+	if metadata == nil {
+		return errors.New("nil BFT config metadata")
+	}
+	if len(metadata.Consenters) == 0 {
+		return errors.New("empty consenter set")
+	}
+
+	ids := make(map[uint64]struct{})
+	for _, consenter := range metadata.Consenters {
+		if consenter == nil {
+			return errors.New("empty consenter")
+		}
+		if consenter.ConsenterId == 0 {
+			return errors.Errorf("consenter %s:%d has no consenter ID", consenter.Host, consenter.Port)
+		}
+		if _, exists := ids[consenter.ConsenterId]; exists {
+			return errors.Errorf("duplicate consenter ID %d", consenter.ConsenterId)
+		}
+		ids[consenter.ConsenterId] = struct{}{}
+		if len(consenter.Identity) == 0 {
+			return errors.Errorf("consenter %d has no identity", consenter.ConsenterId)
+		}
+	}
+
+	if _, _, err := timeouts(metadata.Options); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// timeouts returns the request and view change timeouts of the given
+// options, falling back to the defaults for the ones not set.
+func timeouts(options *bft.Options) (time.Duration, time.Duration, error) {
+	requestTimeout, viewChangeTimeout := DefaultRequestTimeout, DefaultViewChangeTimeout
+	if options == nil {
+		return requestTimeout, viewChangeTimeout, nil
+	}
+
+	var err error
+	if options.RequestTimeout != "" {
+		if requestTimeout, err = time.ParseDuration(options.RequestTimeout); err != nil || requestTimeout <= 0 {
+			return 0, 0, errors.Errorf("invalid request timeout: %s", options.RequestTimeout)
+		}
+	}
+	if options.ViewChangeTimeout != "" {
+		if viewChangeTimeout, err = time.ParseDuration(options.ViewChangeTimeout); err != nil || viewChangeTimeout <= 0 {
+			return 0, 0, errors.Errorf("invalid view change timeout: %s", options.ViewChangeTimeout)
+		}
+	}
+
+	return requestTimeout, viewChangeTimeout, nil
+}
+
+// sortedConsenters returns the consenters ordered by their IDs.
+func sortedConsenters(consenters []*bft.Consenter) []*bft.Consenter {
+	sorted := make([]*bft.Consenter, len(consenters))
+	copy(sorted, consenters)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ConsenterId < sorted[j].ConsenterId
+	})
+	return sorted
+}
+
+// detectSelfID returns the ID of the consenter whose server TLS certificate
+// has the same public key as the given certificate.
+func detectSelfID(cert []byte, consenters []*bft.Consenter) (uint64, error) {
+	thisNodeCertAsDER, err := pemToDER(cert, 0, "server")
+	if err != nil {
+		return 0, err
+	}
+
+	for _, consenter := range consenters {
+		certAsDER, err := pemToDER(consenter.ServerTlsCert, consenter.ConsenterId, "server")
+		if err != nil {
+			return 0, err
+		}
+
+		if crypto.CertificatesWithSamePublicKey(thisNodeCertAsDER, certAsDER) == nil {
+			return consenter.ConsenterId, nil
+		}
+	}
+
+	return 0, cluster.ErrNotInChannel
+}
+
+// remoteNodes converts the consenters other than selfID to cluster members.
+func remoteNodes(selfID uint64, consenters []*bft.Consenter) ([]cluster.RemoteNode, error) {
+	var nodes []cluster.RemoteNode
+	for _, consenter := range consenters {
+		// No need to know yourself
+		if consenter.ConsenterId == selfID {
+			continue
+		}
+		serverCertAsDER, err := pemToDER(consenter.ServerTlsCert, consenter.ConsenterId, "server")
+		if err != nil {
+			return nil, err
+		}
+		clientCertAsDER, err := pemToDER(consenter.ClientTlsCert, consenter.ConsenterId, "client")
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, cluster.RemoteNode{
+			ID:            consenter.ConsenterId,
+			Endpoint:      fmt.Sprintf("%s:%d", consenter.Host, consenter.Port),
+			ServerTLSCert: serverCertAsDER,
+			ClientTLSCert: clientCertAsDER,
+		})
+	}
+	return nodes, nil
+}
+
+func pemToDER(pemBytes []byte, id uint64, certType string) ([]byte, error) {
+	bl, _ := pem.Decode(pemBytes)
+	if bl == nil {
+		return nil, errors.Errorf("invalid PEM block of %s TLS certificate of consenter %d", certType, id)
+	}
+	return bl.Bytes, nil
+}
+
+// blockDigest returns the digest nodes agree on when voting for a block.
+func blockDigest(block *common.Block) []byte {
+	return block.Header.Hash()
+}
+
+// prepareSigningBytes returns the bytes a node signs when preparing a proposal.
+func prepareSigningBytes(prepare *bft.Prepare) []byte {
+	return utils.MarshalOrPanic(&bft.Prepare{
+		View:   prepare.View,
+		Seq:    prepare.Seq,
+		Digest: prepare.Digest,
+		Sender: prepare.Sender,
+	})
+}
+
+// blockSignatureValue returns the value of the SIGNATURES metadata of the
+// given block, which every consenter signs along with the block header.
+func blockSignatureValue(block *common.Block, lastConfigIndex uint64) []byte {
+	return utils.MarshalOrPanic(&common.OrdererBlockMetadata{
+		LastConfig:        &common.LastConfig{Index: lastConfigIndex},
+		ConsenterMetadata: block.Metadata.Metadata[common.BlockMetadataIndex_ORDERER],
+	})
+}
+
+// blockSignedData returns the data a consenter signs for the given block.
+func blockSignedData(block *common.Block, value []byte, signatureHeader []byte) []byte {
+	return bytes.Join([][]byte{value, signatureHeader, block.Header.Bytes()}, nil)
+}
+
+// envelopeHash identifies a request among the pending ones.
+func envelopeHash(env *common.Envelope) string {
+	h := sha256.Sum256(utils.MarshalOrPanic(env))
+	return string(h[:])
+}
+
+// headerType returns the type of the channel header of the envelope.
+func headerType(env *common.Envelope) (common.HeaderType, error) {
+	chdr, err := utils.ChannelHeader(env)
+	if err != nil {
+		return 0, err
+	}
+	return common.HeaderType(chdr.Type), nil
+}
+
+// isConfig returns whether the envelope carries a channel configuration
+// or a channel creation transaction.
+func isConfig(env *common.Envelope) bool {
+	typ, err := headerType(env)
+	if err != nil {
+		return false
+	}
+	return typ == common.HeaderType_CONFIG || typ == common.HeaderType_ORDERER_TRANSACTION
+}
+
+// sameConfig returns an error unless the two config envelopes carry the same config.
+func sameConfig(proposed, computed *common.Envelope) error {
+	proposedConfig, err := configFromEnvelope(proposed)
+	if err != nil {
+		return err
+	}
+	computedConfig, err := configFromEnvelope(computed)
+	if err != nil {
+		return err
+	}
+	if !proto.Equal(proposedConfig, computedConfig) {
+		return errors.New("config does not match the config computed from its config update")
+	}
+	return nil
+}
+
+// configFromEnvelope returns the config carried by a CONFIG envelope, or by the
+// CONFIG envelope which a channel creation transaction wraps.
+func configFromEnvelope(env *common.Envelope) (*common.Config, error) {
+	typ, err := headerType(env)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	switch typ {
+	case common.HeaderType_CONFIG:
+		configEnv := &common.ConfigEnvelope{}
+		if err := proto.Unmarshal(payload.Data, configEnv); err != nil {
+			return nil, errors.Wrap(err, "malformed config envelope")
+		}
+		return configEnv.Config, nil
+	case common.HeaderType_ORDERER_TRANSACTION:
+		inner, err := utils.UnmarshalEnvelope(payload.Data)
+		if err != nil {
+			return nil, err
+		}
+		return configFromEnvelope(inner)
+	default:
+		return nil, errors.Errorf("envelope of type %s does not carry a config", typ)
+	}
+}
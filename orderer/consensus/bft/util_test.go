@@ -0,0 +1,188 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckConfigMetadata(t *testing.T) {
+	validConsenters := func() []*bft.Consenter {
+		return []*bft.Consenter{
+			{ConsenterId: 1, Host: "host1", Port: 7050, Identity: []byte("id1")},
+			{ConsenterId: 2, Host: "host2", Port: 7050, Identity: []byte("id2")},
+		}
+	}
+
+	for _, testCase := range []struct {
+		description string
+		mutate      func(*bft.ConfigMetadata)
+		expectedErr string
+	}{
+		{
+			description: "valid",
+			mutate:      func(*bft.ConfigMetadata) {},
+		},
+		{
+			description: "no consenters",
+			mutate:      func(md *bft.ConfigMetadata) { md.Consenters = nil },
+			expectedErr: "empty consenter set",
+		},
+		{
+			description: "missing consenter ID",
+			mutate:      func(md *bft.ConfigMetadata) { md.Consenters[1].ConsenterId = 0 },
+			expectedErr: "consenter host2:7050 has no consenter ID",
+		},
+		{
+			description: "duplicate consenter ID",
+			mutate:      func(md *bft.ConfigMetadata) { md.Consenters[1].ConsenterId = 1 },
+			expectedErr: "duplicate consenter ID 1",
+		},
+		{
+			description: "missing identity",
+			mutate:      func(md *bft.ConfigMetadata) { md.Consenters[0].Identity = nil },
+			expectedErr: "consenter 1 has no identity",
+		},
+		{
+			description: "invalid request timeout",
+			mutate:      func(md *bft.ConfigMetadata) { md.Options = &bft.Options{RequestTimeout: "soon"} },
+			expectedErr: "invalid request timeout: soon",
+		},
+		{
+			description: "invalid view change timeout",
+			mutate:      func(md *bft.ConfigMetadata) { md.Options = &bft.Options{ViewChangeTimeout: "-1s"} },
+			expectedErr: "invalid view change timeout: -1s",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			md := &bft.ConfigMetadata{Consenters: validConsenters()}
+			testCase.mutate(md)
+			err := CheckConfigMetadata(md)
+			if testCase.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+
+	_, err := ReadConfigMetadata([]byte{1, 2, 3})
+	assert.Contains(t, err.Error(), "failed to unmarshal consensus metadata")
+}
+
+func TestTimeouts(t *testing.T) {
+	requestTimeout, viewChangeTimeout, err := timeouts(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultRequestTimeout, requestTimeout)
+	assert.Equal(t, DefaultViewChangeTimeout, viewChangeTimeout)
+
+	requestTimeout, viewChangeTimeout, err = timeouts(&bft.Options{RequestTimeout: "3s", ViewChangeTimeout: "1m"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3*time.Second, requestTimeout)
+	assert.Equal(t, time.Minute, viewChangeTimeout)
+}
+
+func TestConsenterMembership(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+
+	var consenters []*bft.Consenter
+	for id := uint64(3); id > 0; id-- {
+		server, err := ca.NewServerCertKeyPair("localhost")
+		require.NoError(t, err)
+		client, err := ca.NewClientCertKeyPair()
+		require.NoError(t, err)
+		consenters = append(consenters, &bft.Consenter{
+			ConsenterId:   id,
+			Host:          "localhost",
+			Port:          uint32(7050 + id),
+			ServerTlsCert: server.Cert,
+			ClientTlsCert: client.Cert,
+		})
+	}
+
+	sorted := sortedConsenters(consenters)
+	assert.Equal(t, []uint64{1, 2, 3}, []uint64{sorted[0].ConsenterId, sorted[1].ConsenterId, sorted[2].ConsenterId})
+	assert.Equal(t, uint64(3), consenters[0].ConsenterId, "sorting should not mutate the input")
+
+	t.Run("detect self ID", func(t *testing.T) {
+		id, err := detectSelfID(consenters[1].ServerTlsCert, consenters)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(2), id)
+
+		other, err := ca.NewServerCertKeyPair("localhost")
+		require.NoError(t, err)
+		_, err = detectSelfID(other.Cert, consenters)
+		assert.Equal(t, cluster.ErrNotInChannel, err)
+
+		_, err = detectSelfID([]byte("not a certificate"), consenters)
+		assert.EqualError(t, err, "invalid PEM block of server TLS certificate of consenter 0")
+	})
+
+	t.Run("remote nodes", func(t *testing.T) {
+		nodes, err := remoteNodes(2, consenters)
+		assert.NoError(t, err)
+		require.Len(t, nodes, 2)
+		assert.Equal(t, uint64(3), nodes[0].ID)
+		assert.Equal(t, "localhost:7053", nodes[0].Endpoint)
+		serverCert, _ := pem.Decode(consenters[0].ServerTlsCert)
+		assert.Equal(t, serverCert.Bytes, nodes[0].ServerTLSCert)
+		assert.Equal(t, uint64(1), nodes[1].ID)
+	})
+}
+
+func TestIsConfig(t *testing.T) {
+	env := func(typ common.HeaderType) *common.Envelope {
+		return &common.Envelope{
+			Payload: utils.MarshalOrPanic(&common.Payload{
+				Header: &common.Header{ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{Type: int32(typ)})},
+			}),
+		}
+	}
+
+	assert.True(t, isConfig(env(common.HeaderType_CONFIG)))
+	assert.True(t, isConfig(env(common.HeaderType_ORDERER_TRANSACTION)))
+	assert.False(t, isConfig(env(common.HeaderType_ENDORSER_TRANSACTION)))
+	assert.False(t, isConfig(&common.Envelope{Payload: []byte{1, 2, 3}}))
+}
+
+func TestSameConfig(t *testing.T) {
+	env := func(typ common.HeaderType, data []byte) *common.Envelope {
+		return &common.Envelope{
+			Payload: utils.MarshalOrPanic(&common.Payload{
+				Header: &common.Header{ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{Type: int32(typ)})},
+				Data:   data,
+			}),
+		}
+	}
+	configEnv := func(sequence uint64) *common.Envelope {
+		return env(common.HeaderType_CONFIG, utils.MarshalOrPanic(&common.ConfigEnvelope{
+			Config:     &common.Config{Sequence: sequence},
+			LastUpdate: env(common.HeaderType_CONFIG_UPDATE, []byte("update")),
+		}))
+	}
+	creationEnv := func(sequence uint64) *common.Envelope {
+		return env(common.HeaderType_ORDERER_TRANSACTION, utils.MarshalOrPanic(configEnv(sequence)))
+	}
+
+	assert.NoError(t, sameConfig(configEnv(1), configEnv(1)))
+	assert.EqualError(t, sameConfig(configEnv(1), configEnv(2)), "config does not match the config computed from its config update")
+	assert.NoError(t, sameConfig(creationEnv(1), creationEnv(1)))
+	assert.EqualError(t, sameConfig(creationEnv(1), creationEnv(2)), "config does not match the config computed from its config update")
+	assert.EqualError(t, sameConfig(env(common.HeaderType_ENDORSER_TRANSACTION, nil), configEnv(1)), "envelope of type ENDORSER_TRANSACTION does not carry a config")
+	assert.Error(t, sameConfig(env(common.HeaderType_CONFIG, []byte("config")), configEnv(1)))
+}
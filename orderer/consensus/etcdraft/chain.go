@@ -430,6 +430,9 @@ func (c *Chain) checkConfigUpdateValidity(ctx *common.Envelope) error {
 			if _, exits := set[string(c.ClientTlsCert)]; !exits {
 				return errors.Errorf("new channel has consenter that is not part of system consenter set")
 			}
+			if c.Learner {
+				return errors.Errorf("new channel has consenter that is a learner, learners can only be added by a config update")
+			}
 		}
 
 		return nil
@@ -734,13 +737,14 @@ func (c *Chain) serveRequest() {
 					select {
 					case <-c.errorC:
 					default:
-						nodeCount := len(c.opts.BlockMetadata.ConsenterIds)
+						voterCount := VoterCount(c.opts.BlockMetadata, c.opts.Consenters)
 						// Only close the error channel (to signal the broadcast/deliver front-end a consensus backend error)
-						// If we are a cluster of size 3 or more, otherwise we can't expand a cluster of size 1 to 2 nodes.
-						if nodeCount > 2 {
+						// If we are a cluster of 3 voters or more, otherwise we can't expand a cluster of size 1 to 2 nodes.
+						// The learners do not vote, hence do not count towards the cluster size.
+						if voterCount > 2 {
 							close(c.errorC)
 						} else {
-							c.logger.Warningf("No leader is present, cluster size is %d", nodeCount)
+							c.logger.Warningf("No leader is present, number of voters is %d", voterCount)
 						}
 					}
 				}
@@ -1060,6 +1064,8 @@ func (c *Chain) apply(ents []raftpb.Entry) {
 			switch cc.Type {
 			case raftpb.ConfChangeAddNode:
 				c.logger.Infof("Applied config change to add node %d, current nodes in channel: %+v", cc.NodeID, c.confState.Nodes)
+			case raftpb.ConfChangeAddLearnerNode:
+				c.logger.Infof("Applied config change to add learner node %d, current learners in channel: %+v", cc.NodeID, c.confState.Learners)
 			case raftpb.ConfChangeRemoveNode:
 				c.logger.Infof("Applied config change to remove node %d, current nodes in channel: %+v", cc.NodeID, c.confState.Nodes)
 			default:
@@ -1249,7 +1255,13 @@ func (c *Chain) writeConfigBlock(block *common.Block, index uint64) {
 
 			switch configMembership.ConfChange.Type {
 			case raftpb.ConfChangeAddNode:
+				if configMembership.Promoted() {
+					c.logger.Infof("Config block just committed promotes learner node %d to voter, pause accepting transactions till config change is applied", configMembership.ConfChange.NodeID)
+					break
+				}
 				c.logger.Infof("Config block just committed adds node %d, pause accepting transactions till config change is applied", configMembership.ConfChange.NodeID)
+			case raftpb.ConfChangeAddLearnerNode:
+				c.logger.Infof("Config block just committed adds learner node %d, pause accepting transactions till config change is applied", configMembership.ConfChange.NodeID)
 			case raftpb.ConfChangeRemoveNode:
 				c.logger.Infof("Config block just committed removes node %d, pause accepting transactions till config change is applied", configMembership.ConfChange.NodeID)
 			default:
@@ -1307,16 +1319,11 @@ func (c *Chain) getInFlightConfChange() *raftpb.ConfChange {
 	// extracting current Raft configuration state
 	confState := c.Node.ApplyConfChange(raftpb.ConfChange{})
 
-	if len(confState.Nodes) == len(c.opts.BlockMetadata.ConsenterIds) {
-		// Raft configuration change could only add one node or
-		// remove one node at a time, if raft conf state size is
-		// equal to membership stored in block metadata field,
-		// that means everything is in sync and no need to propose
-		// config update.
-		return nil
-	}
-
-	return ConfChange(c.opts.BlockMetadata, confState)
+	// Raft configuration change could only add, remove or promote
+	// one node at a time, if raft conf state matches the membership
+	// stored in block metadata field, that means everything is in
+	// sync and no need to propose config update.
+	return ConfChange(c.opts.BlockMetadata, c.opts.Consenters, confState)
 }
 
 // newMetadata extract config metadata from the configuration block
@@ -1817,6 +1817,18 @@ var _ = Describe("Chain", func() {
 					Expect(c1.Configure(createChannelEnv(metadata), 0)).To(MatchError(
 						"new channel has consenter that is not part of system consenter set"))
 				})
+
+				It("fails with learner consenter", func() {
+					metadata := &raftprotos.ConfigMetadata{Options: options}
+					for _, consenter := range consenters {
+						learner := proto.Clone(consenter).(*raftprotos.Consenter)
+						learner.Learner = len(metadata.Consenters) == 0
+						metadata.Consenters = append(metadata.Consenters, learner)
+					}
+
+					Expect(c1.Configure(createChannelEnv(metadata), 0)).To(MatchError(
+						"new channel has consenter that is a learner, learners can only be added by a config update"))
+				})
 			})
 
 			Context("reconfiguration", func() {
@@ -2193,6 +2205,81 @@ var _ = Describe("Chain", func() {
 					Expect(err.Error()).To(ContainSubstring(string(duplicatedMetadata.Consenters[1].ClientTlsCert)))
 				})
 
+				It("adding learner to the cluster and promoting it", func() {
+					learner := &raftprotos.Consenter{
+						Host:          "localhost",
+						Port:          7050,
+						ServerTlsCert: serverTLSCert(tlsCA),
+						ClientTlsCert: clientTLSCert(tlsCA),
+						Learner:       true,
+					}
+					consentersUpdate := func(learner *raftprotos.Consenter) map[string]*common.ConfigValue {
+						metadata := &raftprotos.ConfigMetadata{Options: options}
+						for _, consenter := range consenters {
+							metadata.Consenters = append(metadata.Consenters, consenter)
+						}
+						metadata.Consenters = append(metadata.Consenters, learner)
+						return updateRaftConfigValue(metadata)
+					}
+
+					By("sending config transaction adding the learner")
+					c1.cutter.CutNext = true
+					configEnv := newConfigEnv(channelID, common.HeaderType_CONFIG, newConfigUpdateEnv(channelID, nil, consentersUpdate(learner)))
+					Expect(c1.Configure(configEnv, 0)).To(Succeed())
+
+					network.exec(func(c *chain) {
+						Eventually(c.support.WriteConfigBlockCallCount, defaultTimeout).Should(Equal(1))
+						Eventually(c.fakeFields.fakeClusterSize.SetCallCount, LongEventualTimeout).Should(Equal(2))
+						Expect(c.fakeFields.fakeClusterSize.SetArgsForCall(1)).To(Equal(float64(4)))
+					})
+
+					_, raftmetabytes := c1.support.WriteConfigBlockArgsForCall(0)
+					meta := &common.Metadata{Value: raftmetabytes}
+					raftmeta, err := etcdraft.ReadBlockMetadata(meta, nil)
+					Expect(err).NotTo(HaveOccurred())
+
+					learnerConsenters := map[uint64]*raftprotos.Consenter{4: learner}
+					for id, consenter := range consenters {
+						learnerConsenters[id] = consenter
+					}
+					c4 := newChain(timeout, channelID, dataDir, 4, raftmeta, learnerConsenters)
+					c4.support.WriteBlock(c1.support.WriteBlockArgsForCall(0))
+					c4.support.WriteConfigBlock(c1.support.WriteConfigBlockArgsForCall(0))
+					c4.init()
+
+					network.addChain(c4)
+					c4.Start()
+
+					Eventually(func() <-chan raft.SoftState {
+						c1.clock.Increment(interval)
+						return c4.observe
+					}, defaultTimeout).Should(Receive(Equal(raft.SoftState{Lead: 1, RaftState: raft.StateFollower})))
+					Expect(c1.Node.Status().Progress[4].IsLearner).To(BeTrue())
+
+					By("submitting new transaction to the learner")
+					c1.cutter.CutNext = true
+					Expect(c4.Order(env, 0)).To(Succeed())
+					network.exec(func(c *chain) {
+						Eventually(c.support.WriteBlockCallCount, defaultTimeout).Should(Equal(2))
+					})
+
+					By("sending config transaction promoting the learner")
+					c1.cutter.CutNext = true
+					voter := proto.Clone(learner).(*raftprotos.Consenter)
+					voter.Learner = false
+					configEnv = newConfigEnv(channelID, common.HeaderType_CONFIG, newConfigUpdateEnv(channelID, nil, consentersUpdate(voter)))
+					Expect(c1.Configure(configEnv, 0)).To(Succeed())
+
+					network.exec(func(c *chain) {
+						Eventually(c.support.WriteConfigBlockCallCount, defaultTimeout).Should(Equal(2))
+					})
+					Eventually(func() bool {
+						c1.clock.Increment(interval)
+						return c1.Node.Status().Progress[4].IsLearner
+					}, defaultTimeout).Should(BeFalse())
+					Expect(c1.Node.Status().Progress).To(HaveLen(4))
+				})
+
 				It("does not reconfigure raft cluster if it's a channel creation tx", func() {
 					configEnv := newConfigEnv("another-channel",
 						common.HeaderType_CONFIG,
@@ -2899,6 +2986,32 @@ var _ = Describe("Chain", func() {
 
 					Eventually(c2.Errored).ShouldNot(BeClosed())
 				})
+
+				Context("when the other consenters are learners", func() {
+					BeforeEach(func() {
+						// raft is bootstrapped with 3 voters, only the chain
+						// metadata tells that 2 and 3 are learners
+						consenters[2].Learner = true
+						consenters[3].Learner = true
+					})
+
+					It("does not return error as the cluster has a single voter", func() {
+						network.disconnect(2)
+
+						errorC := c2.Errored()
+						Consistently(errorC).ShouldNot(BeClosed())
+
+						By("Ticking node 2 until it becomes pre-candidate")
+						Eventually(func() <-chan raft.SoftState {
+							c2.clock.Increment(interval)
+							return c2.observe
+						}, LongEventualTimeout).Should(Receive(Equal(raft.SoftState{Lead: 0, RaftState: raft.StatePreCandidate})))
+
+						Consistently(errorC).ShouldNot(BeClosed())
+
+						network.connect(2)
+					})
+				})
 			})
 
 			It("leader retransmits lost messages", func() {
@@ -237,7 +237,8 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 }
 
 // ReadBlockMetadata attempts to read raft metadata from block metadata, if available.
-// otherwise, it reads raft metadata from config metadata supplied.
+// otherwise, it reads raft metadata from config metadata supplied. As the Raft nodes
+// of a new channel all start as voters, learners can only join through a config update.
 func ReadBlockMetadata(blockMetadata *common.Metadata, configMetadata *etcdraft.ConfigMetadata) (*etcdraft.BlockMetadata, error) {
 	if blockMetadata != nil && len(blockMetadata.Value) != 0 { // we have consenters mapping from block
 		m := &etcdraft.BlockMetadata{}
@@ -247,6 +248,13 @@ func ReadBlockMetadata(blockMetadata *common.Metadata, configMetadata *etcdraft.
 		return m, nil
 	}
 
+	for _, consenter := range configMetadata.Consenters {
+		if consenter.Learner {
+			return nil, errors.Errorf("consenter %s:%d is a learner, learners cannot be part of the initial consenter set",
+				consenter.Host, consenter.Port)
+		}
+	}
+
 	m := &etcdraft.BlockMetadata{
 		NextConsenterId: 1,
 		ConsenterIds:    make([]uint64, len(configMetadata.Consenters)),
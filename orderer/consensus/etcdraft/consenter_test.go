@@ -221,6 +221,32 @@ var _ = Describe("Consenter", func() {
 		consenter.icr.AssertNumberOfCalls(testingInstance, "TrackChain", 1)
 	})
 
+	It("fails to handle chain if the initial consenter set has a learner", func() {
+		m := &etcdraftproto.ConfigMetadata{
+			Consenters: []*etcdraftproto.Consenter{
+				{Host: "host1", Port: 10001, ServerTlsCert: certAsPEM},
+				{Host: "host2", Port: 10002, Learner: true},
+			},
+			Options: &etcdraftproto.Options{
+				TickInterval:      "500ms",
+				ElectionTick:      10,
+				HeartbeatTick:     1,
+				MaxInflightBlocks: 5,
+			},
+		}
+		metadata := utils.MarshalOrPanic(m)
+		support.SharedConfigReturns(&mockconfig.Orderer{
+			ConsensusMetadataVal: metadata,
+			BatchSizeVal:         &orderer.BatchSize{PreferredMaxBytes: 2 * 1024 * 1024},
+		})
+
+		consenter := newConsenter(chainGetter)
+
+		chain, err := consenter.HandleChain(support, nil)
+		Expect(chain).To(BeNil())
+		Expect(err).To(MatchError("failed to read Raft metadata: consenter host2:10002 is a learner, learners cannot be part of the initial consenter set"))
+	})
+
 	It("fails to handle chain if etcdraft options have not been provided", func() {
 		m := &etcdraftproto.ConfigMetadata{
 			Consenters: []*etcdraftproto.Consenter{
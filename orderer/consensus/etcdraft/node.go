@@ -216,6 +216,10 @@ func (n *node) abdicateLeader(currentLead uint64) {
 				continue // skip self
 			}
 
+			if pr.IsLearner {
+				continue // learners cannot lead
+			}
+
 			if pr.RecentActive && !pr.Paused {
 				transferee = id
 				break
@@ -84,8 +84,8 @@ func CreateStorage(
 		}
 	} else {
 		// snapshot found
-		lg.Debugf("Loaded snapshot at Term %d and Index %d, Nodes: %+v",
-			snapshot.Metadata.Term, snapshot.Metadata.Index, snapshot.Metadata.ConfState.Nodes)
+		lg.Debugf("Loaded snapshot at Term %d and Index %d, Nodes: %+v, Learners: %+v",
+			snapshot.Metadata.Term, snapshot.Metadata.Index, snapshot.Metadata.ConfState.Nodes, snapshot.Metadata.ConfState.Learners)
 	}
 
 	w, st, ents, err := createOrReadWAL(lg, walDir, snapshot)
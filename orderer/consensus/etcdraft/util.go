@@ -38,18 +38,28 @@ type MembershipChanges struct {
 	NewConsenters    map[uint64]*etcdraft.Consenter
 	AddedNodes       []*etcdraft.Consenter
 	RemovedNodes     []*etcdraft.Consenter
+	PromotedNodes    []*etcdraft.Consenter
 	ConfChange       *raftpb.ConfChange
 	RotatedNode      uint64
 }
 
 // Stringer implements fmt.Stringer interface
 func (mc *MembershipChanges) String() string {
+	if len(mc.PromotedNodes) > 0 {
+		return fmt.Sprintf("add %d node(s), remove %d node(s), promote %d learner(s)",
+			len(mc.AddedNodes), len(mc.RemovedNodes), len(mc.PromotedNodes))
+	}
 	return fmt.Sprintf("add %d node(s), remove %d node(s)", len(mc.AddedNodes), len(mc.RemovedNodes))
 }
 
 // Changed indicates whether these changes actually do anything
 func (mc *MembershipChanges) Changed() bool {
-	return len(mc.AddedNodes) > 0 || len(mc.RemovedNodes) > 0
+	return len(mc.AddedNodes) > 0 || len(mc.RemovedNodes) > 0 || len(mc.PromotedNodes) > 0
+}
+
+// Promoted indicates whether the change was the promotion of a learner to a voter
+func (mc *MembershipChanges) Promoted() bool {
+	return len(mc.PromotedNodes) == 1
 }
 
 // Rotated indicates whether the change was a rotation
@@ -160,23 +170,33 @@ func MembershipByCert(consenters map[uint64]*etcdraft.Consenter) map[string]uint
 }
 
 // ComputeMembershipChanges computes membership update based on information about new conseters, returns
-// two slices: a slice of added consenters and a slice of consenters to be removed
+// three slices: a slice of added consenters, a slice of consenters to be removed and a slice of learners
+// to be promoted to voters. Turning a voter into a learner is not supported.
 func ComputeMembershipChanges(oldMetadata *etcdraft.BlockMetadata, oldConsenters map[uint64]*etcdraft.Consenter, newConsenters []*etcdraft.Consenter) (mc *MembershipChanges, err error) {
 	result := &MembershipChanges{
 		NewConsenters:    map[uint64]*etcdraft.Consenter{},
 		NewBlockMetadata: proto.Clone(oldMetadata).(*etcdraft.BlockMetadata),
 		AddedNodes:       []*etcdraft.Consenter{},
 		RemovedNodes:     []*etcdraft.Consenter{},
+		PromotedNodes:    []*etcdraft.Consenter{},
 	}
 
 	result.NewBlockMetadata.ConsenterIds = make([]uint64, len(newConsenters))
 
 	var addedNodeIndex int
+	var promotedNodeID uint64
 	currentConsentersSet := MembershipByCert(oldConsenters)
 	for i, c := range newConsenters {
 		if nodeID, exists := currentConsentersSet[string(c.ClientTlsCert)]; exists {
 			result.NewBlockMetadata.ConsenterIds[i] = nodeID
 			result.NewConsenters[nodeID] = c
+			switch oldLearner := oldConsenters[nodeID].Learner; {
+			case oldLearner && !c.Learner:
+				promotedNodeID = nodeID
+				result.PromotedNodes = append(result.PromotedNodes, c)
+			case !oldLearner && c.Learner:
+				return nil, errors.Errorf("consenter %s:%d is a voter and cannot be turned into a learner", c.Host, c.Port)
+			}
 			continue
 		}
 		addedNodeIndex = i
@@ -193,8 +213,21 @@ func ComputeMembershipChanges(oldMetadata *etcdraft.BlockMetadata, oldConsenters
 	}
 
 	switch {
+	case len(result.PromotedNodes) > 0:
+		if len(result.PromotedNodes) > 1 || len(result.AddedNodes) > 0 || len(result.RemovedNodes) > 0 {
+			return nil, errors.Errorf("update of more than one consenter at a time is not supported, requested changes: %s", result)
+		}
+		// learner promotion, adding a learner as a node makes it a voter
+		result.ConfChange = &raftpb.ConfChange{
+			NodeID: promotedNodeID,
+			Type:   raftpb.ConfChangeAddNode,
+		}
 	case len(result.AddedNodes) == 1 && len(result.RemovedNodes) == 1:
 		// cert rotation
+		if result.AddedNodes[0].Learner != result.RemovedNodes[0].Learner {
+			return nil, errors.Errorf("rotating the certificates of consenter %s:%d cannot change whether it is a learner",
+				result.AddedNodes[0].Host, result.AddedNodes[0].Port)
+		}
 		result.RotatedNode = deletedNodeID
 		result.NewBlockMetadata.ConsenterIds[addedNodeIndex] = deletedNodeID
 		result.NewConsenters[deletedNodeID] = result.AddedNodes[0]
@@ -208,6 +241,9 @@ func ComputeMembershipChanges(oldMetadata *etcdraft.BlockMetadata, oldConsenters
 			NodeID: nodeID,
 			Type:   raftpb.ConfChangeAddNode,
 		}
+		if result.AddedNodes[0].Learner {
+			result.ConfChange.Type = raftpb.ConfChangeAddLearnerNode
+		}
 	case len(result.AddedNodes) == 0 && len(result.RemovedNodes) == 1:
 		// removed node
 		nodeID := deletedNodeID
@@ -413,6 +449,16 @@ func CheckConfigMetadata(metadata *etcdraft.ConfigMetadata) error {
 		return errors.Errorf("empty consenter set")
 	}
 
+	var voters int
+	for _, consenter := range metadata.Consenters {
+		if !consenter.GetLearner() {
+			voters++
+		}
+	}
+	if voters == 0 {
+		return errors.Errorf("consenter set has no voter, all consenters are learners")
+	}
+
 	// sanity check of certificates
 	for _, consenter := range metadata.Consenters {
 		if err := validateCert(consenter.ServerTlsCert, "server"); err != nil {
@@ -521,33 +567,51 @@ func NodeExists(id uint64, nodes []uint64) bool {
 	return false
 }
 
+// VoterCount returns the number of consenters stored in RaftMetadata
+// that are voters. The consenters tell apart the learners.
+func VoterCount(blockMetadata *etcdraft.BlockMetadata, consenters map[uint64]*etcdraft.Consenter) int {
+	count := 0
+	for _, consenterID := range blockMetadata.ConsenterIds {
+		if !consenters[consenterID].GetLearner() {
+			count++
+		}
+	}
+	return count
+}
+
 // ConfChange computes Raft configuration changes based on current Raft
-// configuration state and consenters IDs stored in RaftMetadata.
-func ConfChange(blockMetadata *etcdraft.BlockMetadata, confState *raftpb.ConfState) *raftpb.ConfChange {
-	raftConfChange := &raftpb.ConfChange{}
-
-	// need to compute conf changes to propose
-	if len(confState.Nodes) < len(blockMetadata.ConsenterIds) {
-		// adding new node
-		raftConfChange.Type = raftpb.ConfChangeAddNode
-		for _, consenterID := range blockMetadata.ConsenterIds {
-			if NodeExists(consenterID, confState.Nodes) {
-				continue
+// configuration state and consenters IDs stored in RaftMetadata, or
+// returns nil if both are in sync. The consenters tell apart the learners.
+func ConfChange(blockMetadata *etcdraft.BlockMetadata, consenters map[uint64]*etcdraft.Consenter, confState *raftpb.ConfState) *raftpb.ConfChange {
+	for _, consenterID := range blockMetadata.ConsenterIds {
+		learner := consenters[consenterID].GetLearner()
+		switch {
+		case NodeExists(consenterID, confState.Nodes):
+			// voters are never turned into learners
+		case NodeExists(consenterID, confState.Learners):
+			if !learner {
+				// promoting learner
+				return &raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: consenterID}
 			}
-			raftConfChange.NodeID = consenterID
+		case learner:
+			// adding new learner
+			return &raftpb.ConfChange{Type: raftpb.ConfChangeAddLearnerNode, NodeID: consenterID}
+		default:
+			// adding new node
+			return &raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: consenterID}
 		}
-	} else {
-		// removing node
-		raftConfChange.Type = raftpb.ConfChangeRemoveNode
-		for _, nodeID := range confState.Nodes {
-			if NodeExists(nodeID, blockMetadata.ConsenterIds) {
-				continue
+	}
+
+	// removing node
+	for _, nodes := range [][]uint64{confState.Nodes, confState.Learners} {
+		for _, nodeID := range nodes {
+			if !NodeExists(nodeID, blockMetadata.ConsenterIds) {
+				return &raftpb.ConfChange{Type: raftpb.ConfChangeRemoveNode, NodeID: nodeID}
 			}
-			raftConfChange.NodeID = nodeID
 		}
 	}
 
-	return raftConfChange
+	return nil
 }
 
 // PeriodicCheck checks periodically a condition, and reports
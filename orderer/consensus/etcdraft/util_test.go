@@ -48,6 +48,8 @@ func TestCheckConfigMetadata(t *testing.T) {
 		{&etcdraft.ConfigMetadata{Options: &etcdraft.Options{HeartbeatTick: 1, ElectionTick: 2, MaxInflightBlocks: 1, TickInterval: "q"}}, "failed to parse TickInterval (q) to time duration: time: invalid duration q"},
 		{&etcdraft.ConfigMetadata{Options: &etcdraft.Options{HeartbeatTick: 1, ElectionTick: 2, MaxInflightBlocks: 1, TickInterval: "0"}}, "TickInterval cannot be zero"},
 		{&etcdraft.ConfigMetadata{Options: &etcdraft.Options{HeartbeatTick: 1, ElectionTick: 2, MaxInflightBlocks: 1, TickInterval: "1s"}}, "empty consenter set"},
+		{&etcdraft.ConfigMetadata{Options: &etcdraft.Options{HeartbeatTick: 1, ElectionTick: 2, MaxInflightBlocks: 1, TickInterval: "1s"},
+			Consenters: []*etcdraft.Consenter{{Learner: true}}}, "consenter set has no voter, all consenters are learners"},
 	}
 
 	for _, tc := range tests {
@@ -61,6 +63,164 @@ func TestCheckConfigMetadata(t *testing.T) {
 
 }
 
+func TestComputeMembershipChanges(t *testing.T) {
+	c1 := &etcdraft.Consenter{Host: "host1", Port: 7050, ClientTlsCert: []byte("cert1")}
+	c2 := &etcdraft.Consenter{Host: "host2", Port: 7050, ClientTlsCert: []byte("cert2")}
+	learner3 := &etcdraft.Consenter{Host: "host3", Port: 7050, ClientTlsCert: []byte("cert3"), Learner: true}
+	voter3 := &etcdraft.Consenter{Host: "host3", Port: 7050, ClientTlsCert: []byte("cert3")}
+	rotatedLearner3 := &etcdraft.Consenter{Host: "host3", Port: 7050, ClientTlsCert: []byte("cert3'"), Learner: true}
+	learner4 := &etcdraft.Consenter{Host: "host4", Port: 7050, ClientTlsCert: []byte("cert4"), Learner: true}
+	learner2 := &etcdraft.Consenter{Host: "host2", Port: 7050, ClientTlsCert: []byte("cert2"), Learner: true}
+
+	oldMetadata := &etcdraft.BlockMetadata{ConsenterIds: []uint64{1, 2, 3}, NextConsenterId: 4}
+	oldConsenters := map[uint64]*etcdraft.Consenter{1: c1, 2: c2, 3: learner3}
+
+	tests := []struct {
+		name          string
+		newConsenters []*etcdraft.Consenter
+		confChange    *raftpb.ConfChange
+		consenterIDs  []uint64
+		changed       bool
+		promoted      bool
+		rotated       bool
+		err           string
+	}{
+		{
+			name:          "no change",
+			newConsenters: []*etcdraft.Consenter{c1, c2, learner3},
+			consenterIDs:  []uint64{1, 2, 3},
+		},
+		{
+			name:          "add learner",
+			newConsenters: []*etcdraft.Consenter{c1, c2, learner3, learner4},
+			confChange:    &raftpb.ConfChange{Type: raftpb.ConfChangeAddLearnerNode, NodeID: 4},
+			consenterIDs:  []uint64{1, 2, 3, 4},
+			changed:       true,
+		},
+		{
+			name:          "promote learner",
+			newConsenters: []*etcdraft.Consenter{c1, c2, voter3},
+			confChange:    &raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 3},
+			consenterIDs:  []uint64{1, 2, 3},
+			changed:       true,
+			promoted:      true,
+		},
+		{
+			name:          "remove learner",
+			newConsenters: []*etcdraft.Consenter{c1, c2},
+			confChange:    &raftpb.ConfChange{Type: raftpb.ConfChangeRemoveNode, NodeID: 3},
+			consenterIDs:  []uint64{1, 2},
+			changed:       true,
+		},
+		{
+			name:          "rotate learner",
+			newConsenters: []*etcdraft.Consenter{c1, c2, rotatedLearner3},
+			consenterIDs:  []uint64{1, 2, 3},
+			changed:       true,
+			rotated:       true,
+		},
+		{
+			name:          "rotate and promote learner",
+			newConsenters: []*etcdraft.Consenter{c1, c2, {Host: "host3", Port: 7050, ClientTlsCert: []byte("cert3'")}},
+			err:           "rotating the certificates of consenter host3:7050 cannot change whether it is a learner",
+		},
+		{
+			name:          "promote learner and add learner",
+			newConsenters: []*etcdraft.Consenter{c1, c2, voter3, learner4},
+			err:           "update of more than one consenter at a time is not supported, requested changes: add 1 node(s), remove 0 node(s), promote 1 learner(s)",
+		},
+		{
+			name:          "demote voter",
+			newConsenters: []*etcdraft.Consenter{c1, learner2, learner3},
+			err:           "consenter host2:7050 is a voter and cannot be turned into a learner",
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			changes, err := ComputeMembershipChanges(oldMetadata, oldConsenters, testCase.newConsenters)
+			if testCase.err != "" {
+				assert.EqualError(t, err, testCase.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.confChange, changes.ConfChange)
+			assert.Equal(t, testCase.consenterIDs, changes.NewBlockMetadata.ConsenterIds)
+			assert.Equal(t, testCase.changed, changes.Changed())
+			assert.Equal(t, testCase.promoted, changes.Promoted())
+			assert.Equal(t, testCase.rotated, changes.Rotated())
+			for i, id := range changes.NewBlockMetadata.ConsenterIds {
+				assert.Equal(t, testCase.newConsenters[i], changes.NewConsenters[id])
+			}
+		})
+	}
+}
+
+func TestConfChange(t *testing.T) {
+	blockMetadata := &etcdraft.BlockMetadata{ConsenterIds: []uint64{1, 2, 3}}
+	consenters := map[uint64]*etcdraft.Consenter{1: {}, 2: {}, 3: {Learner: true}}
+
+	tests := []struct {
+		name       string
+		consenters map[uint64]*etcdraft.Consenter
+		confState  *raftpb.ConfState
+		confChange *raftpb.ConfChange
+	}{
+		{
+			name:      "in sync",
+			confState: &raftpb.ConfState{Nodes: []uint64{1, 2}, Learners: []uint64{3}},
+		},
+		{
+			name:       "add node",
+			consenters: map[uint64]*etcdraft.Consenter{1: {}, 2: {}, 3: {}},
+			confState:  &raftpb.ConfState{Nodes: []uint64{1, 2}},
+			confChange: &raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 3},
+		},
+		{
+			name:       "add learner",
+			confState:  &raftpb.ConfState{Nodes: []uint64{1, 2}},
+			confChange: &raftpb.ConfChange{Type: raftpb.ConfChangeAddLearnerNode, NodeID: 3},
+		},
+		{
+			name:       "promote learner",
+			consenters: map[uint64]*etcdraft.Consenter{1: {}, 2: {}, 3: {}},
+			confState:  &raftpb.ConfState{Nodes: []uint64{1, 2}, Learners: []uint64{3}},
+			confChange: &raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 3},
+		},
+		{
+			name:       "remove node",
+			confState:  &raftpb.ConfState{Nodes: []uint64{1, 2, 4}, Learners: []uint64{3}},
+			confChange: &raftpb.ConfChange{Type: raftpb.ConfChangeRemoveNode, NodeID: 4},
+		},
+		{
+			name:       "remove learner",
+			confState:  &raftpb.ConfState{Nodes: []uint64{1, 2}, Learners: []uint64{3, 4}},
+			confChange: &raftpb.ConfChange{Type: raftpb.ConfChangeRemoveNode, NodeID: 4},
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			if testCase.consenters == nil {
+				testCase.consenters = consenters
+			}
+			assert.Equal(t, testCase.confChange, ConfChange(blockMetadata, testCase.consenters, testCase.confState))
+		})
+	}
+}
+
+func TestVoterCount(t *testing.T) {
+	voter := &etcdraft.Consenter{Host: "host", Port: 7050}
+	learner := &etcdraft.Consenter{Host: "host", Port: 7050, Learner: true}
+
+	blockMetadata := &etcdraft.BlockMetadata{ConsenterIds: []uint64{1, 2, 3}, NextConsenterId: 4}
+	assert.Equal(t, 3, VoterCount(blockMetadata, map[uint64]*etcdraft.Consenter{1: voter, 2: voter, 3: voter}))
+	assert.Equal(t, 2, VoterCount(blockMetadata, map[uint64]*etcdraft.Consenter{1: voter, 2: learner, 3: voter}))
+	assert.Equal(t, 1, VoterCount(blockMetadata, map[uint64]*etcdraft.Consenter{1: voter, 2: learner, 3: learner}))
+}
+
 func TestIsConsenterOfChannel(t *testing.T) {
 	certInsideConfigBlock, err := base64.StdEncoding.DecodeString("LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0tCk1JSUNmekNDQWlhZ0F3SUJBZ0l" +
 		"SQUo4bjFLYTVzS1ZaTXRMTHJ1dldERDB3Q2dZSUtvWkl6ajBFQXdJd2JERUwKTUFrR0ExVUVCaE1DVlZNeEV6QVJCZ05WQkFnVENrTmhiR" +
@@ -161,10 +161,10 @@ type collectionConfigJson struct {
 	MemberOnlyRead bool   `json:"memberOnlyRead"`
 }
 
-// getCollectionConfig retrieves the collection configuration
+// GetCollectionConfigFromFile retrieves the collection configuration
 // from the supplied file; the supplied file must contain a
 // json-formatted array of collectionConfigJson elements
-func getCollectionConfigFromFile(ccFile string) ([]byte, error) {
+func GetCollectionConfigFromFile(ccFile string) ([]byte, error) {
 	fileBytes, err := ioutil.ReadFile(ccFile)
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not read file '%s'", ccFile)
@@ -252,7 +252,7 @@ func checkChaincodeCmdParams(cmd *cobra.Command) error {
 
 		if collectionsConfigFile != common.UndefinedParamValue {
 			var err error
-			collectionConfigBytes, err = getCollectionConfigFromFile(collectionsConfigFile)
+			collectionConfigBytes, err = GetCollectionConfigFromFile(collectionsConfigFile)
 			if err != nil {
 				return errors.WithMessage(err, fmt.Sprintf("invalid collection configuration in file %s", collectionsConfigFile))
 			}
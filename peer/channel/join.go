@@ -90,7 +90,12 @@ func executeJoin(cf *ChannelCmdFactory) (err error) {
 	if err != nil {
 		return err
 	}
+	return sendJoinProposal(cf, spec)
+}
 
+// sendJoinProposal sends a proposal for invoking the given cscc
+// spec to the peer and checks the proposal response
+func sendJoinProposal(cf *ChannelCmdFactory, spec *pb.ChaincodeSpec) (err error) {
 	// Build the ChaincodeInvocationSpec message
 	invocation := &pb.ChaincodeInvocationSpec{ChaincodeSpec: spec}
 
@@ -0,0 +1,97 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hyperledger/fabric/core/scc/cscc"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const submitSnapshotRequestCommandDescription = "Submits a request to generate a snapshot of the ledger of a channel."
+
+func submitSnapshotRequestCmd(cf *ChannelCmdFactory) *cobra.Command {
+	// Set the flags on the channel submitsnapshotrequest command.
+	submitSnapshotRequestCmd := &cobra.Command{
+		Use:   "submitsnapshotrequest",
+		Short: submitSnapshotRequestCommandDescription,
+		Long:  submitSnapshotRequestCommandDescription + " Requires '-c'. The peer generates the snapshot on its file system once it commits the requested block, or immediately if no block number is supplied.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return submitSnapshotRequest(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"blockNumber",
+	}
+	attachFlags(submitSnapshotRequestCmd, flagList)
+
+	return submitSnapshotRequestCmd
+}
+
+func getSubmitSnapshotRequestCCSpec() *pb.ChaincodeSpec {
+	input := &pb.ChaincodeInput{Args: [][]byte{
+		[]byte(cscc.SubmitSnapshotRequest),
+		[]byte(channelID),
+		[]byte(strconv.FormatUint(snapshotBlockNumber, 10)),
+	}}
+
+	return &pb.ChaincodeSpec{
+		Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
+		ChaincodeId: &pb.ChaincodeID{Name: "cscc"},
+		Input:       input,
+	}
+}
+
+func submitSnapshotRequest(cmd *cobra.Command, cf *ChannelCmdFactory) error {
+	//the global chainID filled by the "-c" command
+	if channelID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserRequired, PeerDeliverNotRequired, OrdererNotRequired)
+		if err != nil {
+			return err
+		}
+	}
+
+	invocation := &pb.ChaincodeInvocationSpec{ChaincodeSpec: getSubmitSnapshotRequestCCSpec()}
+	creator, err := cf.Signer.Serialize()
+	if err != nil {
+		return errors.WithMessage(err, "cannot serialize the signer identity")
+	}
+	prop, _, err := utils.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, "", invocation, creator)
+	if err != nil {
+		return errors.WithMessage(err, "cannot create proposal")
+	}
+	signedProp, err := utils.GetSignedProposal(prop, cf.Signer)
+	if err != nil {
+		return errors.WithMessage(err, "cannot create signed proposal")
+	}
+
+	proposalResp, err := cf.EndorserClient.ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return errors.WithMessage(err, "failed sending proposal")
+	}
+	if proposalResp.Response == nil || proposalResp.Response.Status != 200 {
+		return errors.Errorf("received bad response, status %d: %s", proposalResp.Response.GetStatus(), proposalResp.Response.GetMessage())
+	}
+
+	logger.Infof("Successfully submitted the request to generate a snapshot of channel %s", channelID)
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/scc/cscc"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubmitSnapshotRequestMissingChannelID(t *testing.T) {
+	defer resetFlags()
+
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	cmd := submitSnapshotRequestCmd(&ChannelCmdFactory{Signer: signer})
+	AddFlags(cmd)
+	cmd.SetArgs([]string{})
+
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+}
+
+func TestSubmitSnapshotRequest(t *testing.T) {
+	defer resetFlags()
+
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	cmd := submitSnapshotRequestCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel})
+	assert.NoError(t, cmd.Execute())
+	spec := getSubmitSnapshotRequestCCSpec()
+	assert.Equal(t, [][]byte{[]byte(cscc.SubmitSnapshotRequest), []byte(mockChannel), []byte("0")}, spec.Input.Args)
+
+	cmd = submitSnapshotRequestCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel, "--blockNumber", "10"})
+	assert.NoError(t, cmd.Execute())
+	spec = getSubmitSnapshotRequestCCSpec()
+	assert.Equal(t, [][]byte{[]byte(cscc.SubmitSnapshotRequest), []byte(mockChannel), []byte("10")}, spec.Input.Args)
+}
+
+func TestSubmitSnapshotRequestBadProposalResponse(t *testing.T) {
+	defer resetFlags()
+
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 500, Message: "access denied"},
+		Endorsement: &pb.Endorsement{},
+	}
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	cmd := submitSnapshotRequestCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel, "--blockNumber", "10"})
+	assert.EqualError(t, cmd.Execute(), "received bad response, status 500: access denied")
+}
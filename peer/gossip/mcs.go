@@ -11,8 +11,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/policies"
@@ -22,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	pcommon "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 )
@@ -41,16 +44,23 @@ type MSPMessageCryptoService struct {
 	channelPolicyManagerGetter policies.ChannelPolicyManagerGetter
 	localSigner                crypto.LocalSigner
 	deserializer               mgmt.DeserializersManager
+	ordererConfigGetter        OrdererConfigGetter
 }
 
+// OrdererConfigGetter returns the orderer configuration of the given channel,
+// and false if the channel or its orderer configuration is not found.
+type OrdererConfigGetter func(chainID string) (channelconfig.Orderer, bool)
+
 // NewMCS creates a new instance of MSPMessageCryptoService
 // that implements MessageCryptoService.
 // The method takes in input:
 // 1. a policies.ChannelPolicyManagerGetter that gives access to the policy manager of a given channel via the Manager method.
 // 2. an instance of crypto.LocalSigner
 // 3. an identity deserializer manager
-func NewMCS(channelPolicyManagerGetter policies.ChannelPolicyManagerGetter, localSigner crypto.LocalSigner, deserializer mgmt.DeserializersManager) *MSPMessageCryptoService {
-	return &MSPMessageCryptoService{channelPolicyManagerGetter: channelPolicyManagerGetter, localSigner: localSigner, deserializer: deserializer}
+// 4. an OrdererConfigGetter, which may be nil if blocks ordered by
+// BFT ordering services need not be verified against their consenter set
+func NewMCS(channelPolicyManagerGetter policies.ChannelPolicyManagerGetter, localSigner crypto.LocalSigner, deserializer mgmt.DeserializersManager, ordererConfigGetter OrdererConfigGetter) *MSPMessageCryptoService {
+	return &MSPMessageCryptoService{channelPolicyManagerGetter: channelPolicyManagerGetter, localSigner: localSigner, deserializer: deserializer, ordererConfigGetter: ordererConfigGetter}
 }
 
 // ValidateIdentity validates the identity of a remote peer.
@@ -182,7 +192,42 @@ func (s *MSPMessageCryptoService) VerifyBlock(chainID common.ChainID, seqNum uin
 	}
 
 	// - Evaluate policy
-	return policy.Evaluate(signatureSet)
+	if err := policy.Evaluate(signatureSet); err != nil {
+		return err
+	}
+
+	// - Blocks of BFT ordering services must also be signed by a quorum of the consenters
+	return s.verifyConsentersQuorum(channelID, block)
+}
+
+// verifyConsentersQuorum checks that the block carries the signatures of a quorum
+// of the consenters of the channel, if the channel is ordered by a BFT ordering service.
+func (s *MSPMessageCryptoService) verifyConsentersQuorum(channelID string, block *pcommon.Block) error {
+	if s.ordererConfigGetter == nil {
+		return nil
+	}
+	oc, ok := s.ordererConfigGetter(channelID)
+	if !ok || oc.ConsensusType() != bft.TypeKey {
+		return nil
+	}
+
+	metadata := &bft.ConfigMetadata{}
+	if err := proto.Unmarshal(oc.ConsensusMetadata(), metadata); err != nil {
+		return fmt.Errorf("Failed unmarshalling BFT metadata of channel [%s]: [%s]", channelID, err)
+	}
+
+	deserializer, ok := s.deserializer.GetChannelDeserializers()[channelID]
+	if !ok {
+		return fmt.Errorf("Could not acquire identity deserializer for channel [%s]", channelID)
+	}
+
+	return bft.VerifyBlockSignatures(block, metadata.Consenters, func(identity, data, signature []byte) error {
+		id, err := deserializer.DeserializeIdentity(identity)
+		if err != nil {
+			return err
+		}
+		return id.Verify(data, signature)
+	})
 }
 
 // Sign signs msg with this peer's signing key and outputs
@@ -26,8 +26,10 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/localmsp"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	mockscrypto "github.com/hyperledger/fabric/common/mocks/crypto"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
@@ -37,6 +39,7 @@ import (
 	"github.com/hyperledger/fabric/peer/gossip/mocks"
 	"github.com/hyperledger/fabric/protos/common"
 	pmsp "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
 	protospeer "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
@@ -50,6 +53,7 @@ func TestPKIidOfCert(t *testing.T) {
 	msgCryptoService := NewMCS(&mocks.ChannelPolicyManagerGetterWithManager{},
 		&mockscrypto.LocalSigner{Identity: []byte("Alice")},
 		deserializersManager,
+		nil,
 	)
 
 	peerIdentity := []byte("Alice")
@@ -79,7 +83,7 @@ func TestPKIidOfCert(t *testing.T) {
 }
 
 func TestPKIidOfNil(t *testing.T) {
-	msgCryptoService := NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager())
+	msgCryptoService := NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager(), nil)
 
 	pkid := msgCryptoService.GetPKIidOfCert(nil)
 	// Check pkid is not nil
@@ -97,6 +101,7 @@ func TestValidateIdentity(t *testing.T) {
 		&mocks.ChannelPolicyManagerGetterWithManager{},
 		&mockscrypto.LocalSigner{Identity: []byte("Charlie")},
 		deserializersManager,
+		nil,
 	)
 
 	err := msgCryptoService.ValidateIdentity([]byte("Alice"))
@@ -128,6 +133,7 @@ func TestSign(t *testing.T) {
 		&mocks.ChannelPolicyManagerGetter{},
 		&mockscrypto.LocalSigner{Identity: []byte("Alice")},
 		mgmt.NewDeserializersManager(),
+		nil,
 	)
 
 	msg := []byte("Hello World!!!")
@@ -158,6 +164,7 @@ func TestVerify(t *testing.T) {
 				"C": &mocks.IdentityDeserializer{Identity: []byte("Dave"), Msg: []byte("msg4"), Mock: mock.Mock{}},
 			},
 		},
+		nil,
 	)
 
 	msg := []byte("msg1")
@@ -212,6 +219,7 @@ func TestVerifyBlock(t *testing.T) {
 				"B": &mocks.IdentityDeserializer{Identity: []byte("Charlie"), Msg: []byte("msg3"), Mock: mock.Mock{}},
 			},
 		},
+		nil,
 	)
 
 	// - Prepare testing valid block, Alice signs it.
@@ -245,6 +253,62 @@ func TestVerifyBlock(t *testing.T) {
 	assert.Error(t, msgCryptoService.VerifyBlock([]byte("C"), 42, nil))
 }
 
+func TestVerifyBlockBFT(t *testing.T) {
+	aliceSigner := &mockscrypto.LocalSigner{Identity: []byte("Alice")}
+	blockRaw, msg := mockBlock(t, "C", 42, aliceSigner, nil)
+
+	policyManagerGetter := &mocks.ChannelPolicyManagerGetterWithManager{
+		Managers: map[string]policies.Manager{
+			"C": &mocks.ChannelPolicyManager{
+				Policy: &mocks.Policy{Deserializer: &mocks.IdentityDeserializer{Identity: []byte("Alice"), Msg: msg, Mock: mock.Mock{}}},
+			},
+		},
+	}
+
+	ordererConfig := &mockconfig.Orderer{ConsensusTypeVal: "bft"}
+	msgCryptoService := NewMCS(
+		policyManagerGetter,
+		aliceSigner,
+		&mocks.DeserializersManager{
+			LocalDeserializer: &mocks.IdentityDeserializer{Identity: []byte("Alice"), Msg: []byte("msg1"), Mock: mock.Mock{}},
+			ChannelDeserializers: map[string]msp.IdentityDeserializer{
+				"C": &mocks.IdentityDeserializer{Identity: []byte("Alice"), Msg: msg, Mock: mock.Mock{}},
+			},
+		},
+		func(chainID string) (channelconfig.Orderer, bool) {
+			return ordererConfig, chainID == "C"
+		},
+	)
+
+	// Alice is the only consenter, hence her signature is a quorum
+	ordererConfig.ConsensusMetadataVal = utils.MarshalOrPanic(&bft.ConfigMetadata{
+		Consenters: []*bft.Consenter{{ConsenterId: 1, Identity: []byte("Alice")}},
+	})
+	assert.NoError(t, msgCryptoService.VerifyBlock([]byte("C"), 42, blockRaw))
+
+	// Alice is one of four consenters, and a quorum of three is required
+	ordererConfig.ConsensusMetadataVal = utils.MarshalOrPanic(&bft.ConfigMetadata{
+		Consenters: []*bft.Consenter{
+			{ConsenterId: 1, Identity: []byte("Alice")},
+			{ConsenterId: 2, Identity: []byte("Bob")},
+			{ConsenterId: 3, Identity: []byte("Charlie")},
+			{ConsenterId: 4, Identity: []byte("Dave")},
+		},
+	})
+	err := msgCryptoService.VerifyBlock([]byte("C"), 42, blockRaw)
+	assert.EqualError(t, err, "block [42] has 1 valid consenter signatures, but a quorum of 3 is required")
+
+	// Alice is not a consenter
+	ordererConfig.ConsensusMetadataVal = utils.MarshalOrPanic(&bft.ConfigMetadata{
+		Consenters: []*bft.Consenter{{ConsenterId: 1, Identity: []byte("Bob")}},
+	})
+	assert.Error(t, msgCryptoService.VerifyBlock([]byte("C"), 42, blockRaw))
+
+	// Blocks of other consensus types are only checked against the block validation policy
+	ordererConfig.ConsensusTypeVal = "etcdraft"
+	assert.NoError(t, msgCryptoService.VerifyBlock([]byte("C"), 42, blockRaw))
+}
+
 func mockBlock(t *testing.T, channel string, seqNum uint64, localSigner crypto.LocalSigner, dataHash []byte) ([]byte, []byte) {
 	block := common.NewBlock(seqNum, nil)
 
@@ -329,6 +393,7 @@ func TestExpiration(t *testing.T) {
 		&mocks.ChannelPolicyManagerGetterWithManager{},
 		&mockscrypto.LocalSigner{Identity: []byte("Yacov")},
 		deserializersManager,
+		nil,
 	)
 
 	// Green path I check the expiration date is as expected
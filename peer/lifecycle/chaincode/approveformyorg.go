@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"encoding/hex"
+
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const approveForMyOrgCmdName = "approveformyorg"
+
+// approveForMyOrgCmd returns the cobra command for approving a chaincode
+// definition on behalf of the peer's org
+func approveForMyOrgCmd(cf *CmdFactory) *cobra.Command {
+	chaincodeApproveForMyOrgCmd := &cobra.Command{
+		Use:   approveForMyOrgCmdName,
+		Short: "Approve the chaincode definition for my org.",
+		Long:  "Approve the chaincode definition for my organization and send the approval to the orderer.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return approveForMyOrg(cmd, cf)
+		},
+	}
+
+	flagList := []string{
+		"channelID",
+		"name",
+		"version",
+		"sequence",
+		"hash",
+		"escc",
+		"vscc",
+		"signature-policy",
+		"collections-config",
+		"init-required",
+		"peerAddresses",
+		"tlsRootCertFiles",
+	}
+	attachFlags(chaincodeApproveForMyOrgCmd, flagList)
+
+	return chaincodeApproveForMyOrgCmd
+}
+
+func approveForMyOrg(cmd *cobra.Command, cf *CmdFactory) error {
+	cd, err := checkDefinitionParams()
+	if err != nil {
+		return err
+	}
+	hashBytes, err := hex.DecodeString(hash)
+	if err != nil {
+		return errors.Wrap(err, "could not decode the chaincode hash")
+	}
+
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cf == nil {
+		cf, err = InitCmdFactory(cmd.Name(), true, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	args := &lb.ApproveChaincodeDefinitionForMyOrgArgs{
+		Name:                chaincodeName,
+		Version:             chaincodeVersion,
+		Sequence:            sequence,
+		Hash:                hashBytes,
+		EndorsementPlugin:   escc,
+		ValidationPlugin:    vscc,
+		ValidationParameter: cd.validationParameter,
+		Collections:         cd.collections,
+		InitRequired:        initRequired,
+	}
+
+	return submit(lifecycle.ApproveChaincodeDefinitionForMyOrgFuncName, args, cf)
+}
@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApproveForMyOrgCmd(t *testing.T) {
+	defer resetFlags()
+
+	mockCF := newMockCmdFactory(t, 200, nil, nil)
+
+	resetFlags()
+	cmd := approveForMyOrgCmd(mockCF)
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "mycc", "-v", "1.0", "--sequence", "1", "--hash", "0102", "--signature-policy", "AND('Org1MSP.member','Org2MSP.member')"})
+	assert.NoError(t, cmd.Execute())
+
+	resetFlags()
+	cmd = approveForMyOrgCmd(mockCF)
+	cmd.SetArgs([]string{"-n", "mycc", "-v", "1.0", "--sequence", "1"})
+	assert.EqualError(t, cmd.Execute(), "The required parameter 'channelID' is empty. Rerun the command with -C flag")
+
+	resetFlags()
+	cmd = approveForMyOrgCmd(mockCF)
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "mycc", "-v", "1.0"})
+	assert.EqualError(t, cmd.Execute(), "The required parameter 'sequence' must be greater than zero. Rerun the command with --sequence flag")
+
+	resetFlags()
+	cmd = approveForMyOrgCmd(mockCF)
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "mycc", "-v", "1.0", "--sequence", "1", "--hash", "zz"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "could not decode the chaincode hash")
+
+	resetFlags()
+	cmd = approveForMyOrgCmd(mockCF)
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "mycc", "-v", "1.0", "--sequence", "1", "--signature-policy", "notapolicy"})
+	assert.EqualError(t, cmd.Execute(), "invalid signature policy notapolicy")
+
+	resetFlags()
+	cmd = approveForMyOrgCmd(newMockCmdFactory(t, 500, nil, nil))
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "mycc", "-v", "1.0", "--sequence", "1"})
+	assert.EqualError(t, cmd.Execute(), "ApproveChaincodeDefinitionForMyOrg failed with status: 500 - response message")
+
+	resetFlags()
+	cmd = approveForMyOrgCmd(newMockCmdFactory(t, 200, nil, errors.New("orderer unavailable")))
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "mycc", "-v", "1.0", "--sequence", "1"})
+	assert.EqualError(t, cmd.Execute(), "error sending transaction for ApproveChaincodeDefinitionForMyOrg: orderer unavailable")
+}
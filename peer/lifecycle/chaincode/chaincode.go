@@ -0,0 +1,115 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	chainFuncName = "chaincode"
+	chainCmdDes   = "Perform chaincode operations: approveformyorg|checkcommitreadiness|commit|querycommitted"
+
+	// lifecycleName is the name of the lifecycle system chaincode
+	lifecycleName = "+lifecycle"
+)
+
+var logger = flogging.MustGetLogger("cli.lifecycle.chaincode")
+
+func addFlags(cmd *cobra.Command) {
+	common.AddOrdererFlags(cmd)
+}
+
+// Cmd returns the cobra command for Chaincode
+func Cmd(cf *CmdFactory) *cobra.Command {
+	addFlags(chaincodeCmd)
+
+	chaincodeCmd.AddCommand(approveForMyOrgCmd(cf))
+	chaincodeCmd.AddCommand(checkCommitReadinessCmd(cf))
+	chaincodeCmd.AddCommand(commitCmd(cf))
+	chaincodeCmd.AddCommand(queryCommittedCmd(cf))
+
+	return chaincodeCmd
+}
+
+// Chaincode-related variables.
+var (
+	channelID             string
+	chaincodeName         string
+	chaincodeVersion      string
+	sequence              int64
+	hash                  string
+	escc                  string
+	vscc                  string
+	signaturePolicy       string
+	collectionsConfigFile string
+	initRequired          bool
+	peerAddresses         []string
+	tlsRootCertFiles      []string
+)
+
+var chaincodeCmd = &cobra.Command{
+	Use:   chainFuncName,
+	Short: fmt.Sprint(chainCmdDes),
+	Long:  fmt.Sprint(chainCmdDes),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		common.InitCmd(cmd, args)
+		common.SetOrdererEnv(cmd, args)
+	},
+}
+
+var flags *pflag.FlagSet
+
+func init() {
+	resetFlags()
+}
+
+// Explicitly define a method to facilitate tests
+func resetFlags() {
+	flags = &pflag.FlagSet{}
+
+	flags.StringVarP(&channelID, "channelID", "C", "",
+		"The channel on which this command should be executed")
+	flags.StringVarP(&chaincodeName, "name", "n", "",
+		"Name of the chaincode")
+	flags.StringVarP(&chaincodeVersion, "version", "v", "",
+		"Version of the chaincode")
+	flags.Int64VarP(&sequence, "sequence", "", 0,
+		"The sequence number of the chaincode definition for the channel")
+	flags.StringVarP(&hash, "hash", "", "",
+		"The hash of the installed chaincode package, hex encoded, as returned by the install command")
+	flags.StringVarP(&escc, "escc", "E", "escc",
+		"The name of the endorsement plugin to be used for this chaincode")
+	flags.StringVarP(&vscc, "vscc", "V", "vscc",
+		"The name of the validation plugin to be used for this chaincode")
+	flags.StringVarP(&signaturePolicy, "signature-policy", "", "",
+		"The endorsement policy associated to this chaincode")
+	flags.StringVar(&collectionsConfigFile, "collections-config", "",
+		"The fully qualified path to the collection JSON file including the file name")
+	flags.BoolVarP(&initRequired, "init-required", "", false,
+		"Whether the chaincode requires invoking 'init'")
+	flags.StringArrayVarP(&peerAddresses, "peerAddresses", "", []string{common.UndefinedParamValue},
+		"The addresses of the peers to connect to")
+	flags.StringArrayVarP(&tlsRootCertFiles, "tlsRootCertFiles", "", []string{common.UndefinedParamValue},
+		"If TLS is enabled, the paths to the TLS root cert files of the peers to connect to. The order and number of certs specified should match the --peerAddresses flag")
+}
+
+func attachFlags(cmd *cobra.Command, names []string) {
+	cmdFlags := cmd.Flags()
+	for _, name := range names {
+		if flag := flags.Lookup(name); flag != nil {
+			cmdFlags.AddFlag(flag)
+		} else {
+			logger.Fatalf("Could not find flag '%s' to attach to command '%s'", name, cmd.Name())
+		}
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+func TestMain(m *testing.M) {
+	err := msptesttools.LoadMSPSetupForTesting()
+	if err != nil {
+		panic(fmt.Sprintf("Fatal error when reading MSP config: %s", err))
+	}
+
+	os.Exit(m.Run())
+}
+
+func newMockCmdFactory(t *testing.T, status int32, payload proto.Message, broadcastErr error) *CmdFactory {
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		t.Fatalf("Get default signer error: %s", err)
+	}
+
+	var payloadBytes []byte
+	if payload != nil {
+		payloadBytes, err = proto.Marshal(payload)
+		if err != nil {
+			t.Fatalf("Marshal error: %s", err)
+		}
+	}
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: status, Payload: payloadBytes, Message: "response message"},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	return &CmdFactory{
+		EndorserClients: []pb.EndorserClient{common.GetMockEndorserClient(mockResponse, nil)},
+		Signer:          signer,
+		BroadcastClient: common.GetMockBroadcastClient(broadcastErr),
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+const checkCommitReadinessCmdName = "checkcommitreadiness"
+
+// checkCommitReadinessCmd returns the cobra command for checking which
+// orgs have approved a chaincode definition
+func checkCommitReadinessCmd(cf *CmdFactory) *cobra.Command {
+	chaincodeCheckCommitReadinessCmd := &cobra.Command{
+		Use:   checkCommitReadinessCmdName,
+		Short: "Check whether a chaincode definition is ready to be committed on a channel.",
+		Long:  "Check whether a chaincode definition is ready to be committed on a channel by listing the approval of each org.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return checkCommitReadiness(cmd, cf)
+		},
+	}
+
+	flagList := []string{
+		"channelID",
+		"name",
+		"version",
+		"sequence",
+		"escc",
+		"vscc",
+		"signature-policy",
+		"collections-config",
+		"init-required",
+		"peerAddresses",
+		"tlsRootCertFiles",
+	}
+	attachFlags(chaincodeCheckCommitReadinessCmd, flagList)
+
+	return chaincodeCheckCommitReadinessCmd
+}
+
+func checkCommitReadiness(cmd *cobra.Command, cf *CmdFactory) error {
+	cd, err := checkDefinitionParams()
+	if err != nil {
+		return err
+	}
+
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cf == nil {
+		cf, err = InitCmdFactory(cmd.Name(), true, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	args := &lb.CheckCommitReadinessArgs{
+		Name:                chaincodeName,
+		Version:             chaincodeVersion,
+		Sequence:            sequence,
+		EndorsementPlugin:   escc,
+		ValidationPlugin:    vscc,
+		ValidationParameter: cd.validationParameter,
+		Collections:         cd.collections,
+		InitRequired:        initRequired,
+	}
+
+	result := &lb.CheckCommitReadinessResult{}
+	if err := query(lifecycle.CheckCommitReadinessFuncName, args, result, cf); err != nil {
+		return err
+	}
+
+	orgs := make([]string, 0, len(result.Approvals))
+	for org := range result.Approvals {
+		orgs = append(orgs, org)
+	}
+	sort.Strings(orgs)
+
+	fmt.Printf("Chaincode definition for chaincode '%s', version '%s', sequence '%d' on channel '%s' approval status by org:\n", chaincodeName, chaincodeVersion, sequence, channelID)
+	for _, org := range orgs {
+		fmt.Printf("%s: %t\n", org, result.Approvals[org])
+	}
+
+	return nil
+}
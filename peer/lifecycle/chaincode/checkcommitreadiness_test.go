@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"testing"
+
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCommitReadinessCmd(t *testing.T) {
+	defer resetFlags()
+
+	result := &lb.CheckCommitReadinessResult{
+		Approvals: map[string]bool{
+			"Org1MSP": true,
+			"Org2MSP": false,
+		},
+	}
+
+	resetFlags()
+	cmd := checkCommitReadinessCmd(newMockCmdFactory(t, 200, result, nil))
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "mycc", "-v", "1.0", "--sequence", "1"})
+	assert.NoError(t, cmd.Execute())
+
+	resetFlags()
+	cmd = checkCommitReadinessCmd(newMockCmdFactory(t, 200, result, nil))
+	cmd.SetArgs([]string{"-C", "mychannel", "-v", "1.0", "--sequence", "1"})
+	assert.EqualError(t, cmd.Execute(), "The required parameter 'name' is empty. Rerun the command with -n flag")
+
+	resetFlags()
+	cmd = checkCommitReadinessCmd(newMockCmdFactory(t, 500, nil, nil))
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "mycc", "-v", "1.0", "--sequence", "1"})
+	assert.EqualError(t, cmd.Execute(), "CheckCommitReadiness failed with status: 500 - response message")
+}
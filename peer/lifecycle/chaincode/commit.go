@@ -0,0 +1,75 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+const commitCmdName = "commit"
+
+// commitCmd returns the cobra command for committing a chaincode
+// definition to a channel
+func commitCmd(cf *CmdFactory) *cobra.Command {
+	chaincodeCommitCmd := &cobra.Command{
+		Use:   commitCmdName,
+		Short: "Commit the chaincode definition on the channel.",
+		Long:  "Commit the chaincode definition on the channel once a majority of the channel's orgs have approved it.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commit(cmd, cf)
+		},
+	}
+
+	flagList := []string{
+		"channelID",
+		"name",
+		"version",
+		"sequence",
+		"escc",
+		"vscc",
+		"signature-policy",
+		"collections-config",
+		"init-required",
+		"peerAddresses",
+		"tlsRootCertFiles",
+	}
+	attachFlags(chaincodeCommitCmd, flagList)
+
+	return chaincodeCommitCmd
+}
+
+func commit(cmd *cobra.Command, cf *CmdFactory) error {
+	cd, err := checkDefinitionParams()
+	if err != nil {
+		return err
+	}
+
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cf == nil {
+		cf, err = InitCmdFactory(cmd.Name(), true, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	args := &lb.CommitChaincodeDefinitionArgs{
+		Name:                chaincodeName,
+		Version:             chaincodeVersion,
+		Sequence:            sequence,
+		EndorsementPlugin:   escc,
+		ValidationPlugin:    vscc,
+		ValidationParameter: cd.validationParameter,
+		Collections:         cd.collections,
+		InitRequired:        initRequired,
+	}
+
+	return submit(lifecycle.CommitChaincodeDefinitionFuncName, args, cf)
+}
@@ -0,0 +1,41 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitCmd(t *testing.T) {
+	defer resetFlags()
+
+	resetFlags()
+	cmd := commitCmd(newMockCmdFactory(t, 200, nil, nil))
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "mycc", "-v", "1.0", "--sequence", "1", "--init-required"})
+	assert.NoError(t, cmd.Execute())
+
+	resetFlags()
+	cmd = commitCmd(newMockCmdFactory(t, 200, nil, nil))
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "mycc", "--sequence", "1"})
+	assert.EqualError(t, cmd.Execute(), "The required parameter 'version' is empty. Rerun the command with -v flag")
+
+	resetFlags()
+	cmd = commitCmd(newMockCmdFactory(t, 500, nil, nil))
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "mycc", "-v", "1.0", "--sequence", "1"})
+	assert.EqualError(t, cmd.Execute(), "CommitChaincodeDefinition failed with status: 500 - response message")
+}
+
+func TestValidatePeerConnectionParameters(t *testing.T) {
+	defer resetFlags()
+
+	peerAddresses = []string{"peer0:7051", "peer1:7051"}
+	tlsRootCertFiles = nil
+	assert.NoError(t, validatePeerConnectionParameters(commitCmdName))
+	assert.EqualError(t, validatePeerConnectionParameters(approveForMyOrgCmdName), "'approveformyorg' command can only be executed against one peer. received 2")
+}
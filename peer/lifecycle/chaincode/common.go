@@ -0,0 +1,261 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/msp"
+	ccCmd "github.com/hyperledger/fabric/peer/chaincode"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// CmdFactory holds the clients used by the lifecycle chaincode commands
+type CmdFactory struct {
+	EndorserClients []pb.EndorserClient
+	Signer          msp.SigningIdentity
+	BroadcastClient common.BroadcastClient
+}
+
+// InitCmdFactory init the CmdFactory with default clients
+func InitCmdFactory(cmdName string, isEndorserRequired, isOrdererRequired bool) (*CmdFactory, error) {
+	var endorserClients []pb.EndorserClient
+	if isEndorserRequired {
+		if err := validatePeerConnectionParameters(cmdName); err != nil {
+			return nil, errors.WithMessage(err, "error validating peer connection parameters")
+		}
+		for i, address := range peerAddresses {
+			var tlsRootCertFile string
+			if tlsRootCertFiles != nil {
+				tlsRootCertFile = tlsRootCertFiles[i]
+			}
+			endorserClient, err := common.GetEndorserClientFnc(address, tlsRootCertFile)
+			if err != nil {
+				return nil, errors.WithMessage(err, fmt.Sprintf("error getting endorser client for %s", cmdName))
+			}
+			endorserClients = append(endorserClients, endorserClient)
+		}
+		if len(endorserClients) == 0 {
+			return nil, errors.New("no endorser clients retrieved - this might indicate a bug")
+		}
+	}
+
+	signer, err := common.GetDefaultSignerFnc()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting default signer")
+	}
+
+	var broadcastClient common.BroadcastClient
+	if isOrdererRequired {
+		if len(common.OrderingEndpoint) == 0 {
+			if len(endorserClients) == 0 {
+				return nil, errors.New("orderer is required, but no ordering endpoint or endorser client supplied")
+			}
+
+			orderingEndpoints, err := common.GetOrdererEndpointOfChainFnc(channelID, signer, endorserClients[0])
+			if err != nil {
+				return nil, errors.WithMessage(err, fmt.Sprintf("error getting channel (%s) orderer endpoint", channelID))
+			}
+			if len(orderingEndpoints) == 0 {
+				return nil, errors.Errorf("no orderer endpoints retrieved for channel %s", channelID)
+			}
+			logger.Infof("Retrieved channel (%s) orderer endpoint: %s", channelID, orderingEndpoints[0])
+			// override viper env
+			viper.Set("orderer.address", orderingEndpoints[0])
+		}
+
+		broadcastClient, err = common.GetBroadcastClientFnc()
+		if err != nil {
+			return nil, errors.WithMessage(err, "error getting broadcast client")
+		}
+	}
+
+	return &CmdFactory{
+		EndorserClients: endorserClients,
+		Signer:          signer,
+		BroadcastClient: broadcastClient,
+	}, nil
+}
+
+func validatePeerConnectionParameters(cmdName string) error {
+	// only commit gathers endorsements from the peers of several orgs
+	if cmdName != commitCmdName && len(peerAddresses) > 1 {
+		return errors.Errorf("'%s' command can only be executed against one peer. received %d", cmdName, len(peerAddresses))
+	}
+
+	if len(tlsRootCertFiles) > len(peerAddresses) {
+		logger.Warningf("received more TLS root cert files (%d) than peer addresses (%d)", len(tlsRootCertFiles), len(peerAddresses))
+	}
+
+	if viper.GetBool("peer.tls.enabled") {
+		if len(tlsRootCertFiles) != len(peerAddresses) {
+			return errors.Errorf("number of peer addresses (%d) does not match the number of TLS root cert files (%d)", len(peerAddresses), len(tlsRootCertFiles))
+		}
+	} else {
+		tlsRootCertFiles = nil
+	}
+
+	return nil
+}
+
+// chaincodeDefinition holds the parts of a chaincode definition which
+// are common to the approve, checkcommitreadiness and commit commands
+type chaincodeDefinition struct {
+	validationParameter []byte
+	collections         *cb.CollectionConfigPackage
+}
+
+// checkDefinitionParams validates the flags describing a chaincode
+// definition and converts the policy and collection configuration
+func checkDefinitionParams() (*chaincodeDefinition, error) {
+	if channelID == "" {
+		return nil, errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+	if chaincodeName == "" {
+		return nil, errors.New("The required parameter 'name' is empty. Rerun the command with -n flag")
+	}
+	if chaincodeVersion == "" {
+		return nil, errors.New("The required parameter 'version' is empty. Rerun the command with -v flag")
+	}
+	if sequence <= 0 {
+		return nil, errors.New("The required parameter 'sequence' must be greater than zero. Rerun the command with --sequence flag")
+	}
+
+	cd := &chaincodeDefinition{}
+	if signaturePolicy != "" {
+		p, err := cauthdsl.FromString(signaturePolicy)
+		if err != nil {
+			return nil, errors.Errorf("invalid signature policy %s", signaturePolicy)
+		}
+		cd.validationParameter = putils.MarshalOrPanic(p)
+	}
+
+	if collectionsConfigFile != "" {
+		ccpBytes, err := ccCmd.GetCollectionConfigFromFile(collectionsConfigFile)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("invalid collection configuration in file %s", collectionsConfigFile))
+		}
+		cd.collections = &cb.CollectionConfigPackage{}
+		if err := proto.Unmarshal(ccpBytes, cd.collections); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal collection configuration")
+		}
+	}
+
+	return cd, nil
+}
+
+// createProposal builds a signed proposal invoking funcName of the
+// lifecycle system chaincode with the marshaled args on the channel
+func createProposal(funcName string, args proto.Message, signer msp.SigningIdentity) (*pb.Proposal, *pb.SignedProposal, error) {
+	argsBytes, err := proto.Marshal(args)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to marshal args")
+	}
+
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: lifecycleName},
+			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte(funcName), argsBytes}},
+		},
+	}
+
+	creator, err := signer.Serialize()
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, fmt.Sprintf("error serializing identity for %s", signer.GetIdentifier()))
+	}
+
+	prop, _, err := putils.CreateChaincodeProposalWithTxIDAndTransient(cb.HeaderType_ENDORSER_TRANSACTION, channelID, cis, creator, "", nil)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, fmt.Sprintf("error creating proposal for %s", funcName))
+	}
+
+	signedProp, err := putils.GetSignedProposal(prop, signer)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, fmt.Sprintf("error creating signed proposal for %s", funcName))
+	}
+
+	return prop, signedProp, nil
+}
+
+// endorse sends the signed proposal to all the endorsers and returns
+// their responses, failing if any of them did not succeed
+func endorse(funcName string, signedProp *pb.SignedProposal, endorserClients []pb.EndorserClient) ([]*pb.ProposalResponse, error) {
+	var responses []*pb.ProposalResponse
+	for _, endorser := range endorserClients {
+		proposalResp, err := endorser.ProcessProposal(context.Background(), signedProp)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("error endorsing %s", funcName))
+		}
+		if proposalResp == nil || proposalResp.Response == nil {
+			return nil, errors.Errorf("received nil proposal response for %s", funcName)
+		}
+		if proposalResp.Response.Status != int32(cb.Status_SUCCESS) {
+			return nil, errors.Errorf("%s failed with status: %d - %s", funcName, proposalResp.Response.Status, proposalResp.Response.Message)
+		}
+		responses = append(responses, proposalResp)
+	}
+
+	if len(responses) == 0 {
+		// this should only happen if some new code has introduced a bug
+		return nil, errors.New("no proposal responses received - this might indicate a bug")
+	}
+
+	return responses, nil
+}
+
+// submit endorses funcName of the lifecycle system chaincode and sends the
+// resulting transaction to the orderer
+func submit(funcName string, args proto.Message, cf *CmdFactory) error {
+	prop, signedProp, err := createProposal(funcName, args, cf.Signer)
+	if err != nil {
+		return err
+	}
+
+	responses, err := endorse(funcName, signedProp, cf.EndorserClients)
+	if err != nil {
+		return err
+	}
+
+	env, err := putils.CreateSignedTx(prop, cf.Signer, responses...)
+	if err != nil {
+		return errors.WithMessage(err, "could not assemble transaction")
+	}
+
+	if err := cf.BroadcastClient.Send(env); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error sending transaction for %s", funcName))
+	}
+
+	return nil
+}
+
+// query evaluates funcName of the lifecycle system chaincode on the first
+// endorser and unmarshals the response payload into result
+func query(funcName string, args proto.Message, result proto.Message, cf *CmdFactory) error {
+	_, signedProp, err := createProposal(funcName, args, cf.Signer)
+	if err != nil {
+		return err
+	}
+
+	responses, err := endorse(funcName, signedProp, cf.EndorserClients[:1])
+	if err != nil {
+		return err
+	}
+
+	if err := proto.Unmarshal(responses[0].Response.Payload, result); err != nil {
+		return errors.Wrap(err, "failed to unmarshal proposal response's response payload")
+	}
+
+	return nil
+}
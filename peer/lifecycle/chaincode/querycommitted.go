@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const queryCommittedCmdName = "querycommitted"
+
+// queryCommittedCmd returns the cobra command for querying the committed
+// definition of a chaincode
+func queryCommittedCmd(cf *CmdFactory) *cobra.Command {
+	chaincodeQueryCommittedCmd := &cobra.Command{
+		Use:   queryCommittedCmdName,
+		Short: "Query the committed chaincode definition on a channel.",
+		Long:  "Query the chaincode definition committed on a channel for the given chaincode name.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return queryCommitted(cmd, cf)
+		},
+	}
+
+	flagList := []string{
+		"channelID",
+		"name",
+		"peerAddresses",
+		"tlsRootCertFiles",
+	}
+	attachFlags(chaincodeQueryCommittedCmd, flagList)
+
+	return chaincodeQueryCommittedCmd
+}
+
+func queryCommitted(cmd *cobra.Command, cf *CmdFactory) error {
+	if channelID == "" {
+		return errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+	if chaincodeName == "" {
+		return errors.New("The required parameter 'name' is empty. Rerun the command with -n flag")
+	}
+
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(cmd.Name(), true, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	args := &lb.QueryChaincodeDefinitionArgs{
+		Name: chaincodeName,
+	}
+
+	result := &lb.QueryChaincodeDefinitionResult{}
+	if err := query(lifecycle.QueryChaincodeDefinitionFuncName, args, result, cf); err != nil {
+		return err
+	}
+
+	fmt.Printf("Committed chaincode definition for chaincode '%s' on channel '%s':\n", chaincodeName, channelID)
+	fmt.Printf("Version: %s, Sequence: %d, Endorsement Plugin: %s, Validation Plugin: %s, Init Required: %t\n",
+		result.Version, result.Sequence, result.EndorsementPlugin, result.ValidationPlugin, result.InitRequired)
+
+	return nil
+}
@@ -0,0 +1,45 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"testing"
+
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryCommittedCmd(t *testing.T) {
+	defer resetFlags()
+
+	result := &lb.QueryChaincodeDefinitionResult{
+		Sequence:          1,
+		Version:           "1.0",
+		EndorsementPlugin: "escc",
+		ValidationPlugin:  "vscc",
+	}
+
+	resetFlags()
+	cmd := queryCommittedCmd(newMockCmdFactory(t, 200, result, nil))
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "mycc"})
+	assert.NoError(t, cmd.Execute())
+
+	resetFlags()
+	cmd = queryCommittedCmd(newMockCmdFactory(t, 200, result, nil))
+	cmd.SetArgs([]string{"-n", "mycc"})
+	assert.EqualError(t, cmd.Execute(), "The required parameter 'channelID' is empty. Rerun the command with -C flag")
+
+	resetFlags()
+	cmd = queryCommittedCmd(newMockCmdFactory(t, 200, nil, nil))
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "mycc"})
+	assert.NoError(t, cmd.Execute())
+
+	resetFlags()
+	cmd = queryCommittedCmd(newMockCmdFactory(t, 500, nil, nil))
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "mycc"})
+	assert.EqualError(t, cmd.Execute(), "QueryChaincodeDefinition failed with status: 500 - response message")
+}
@@ -0,0 +1,30 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"github.com/hyperledger/fabric/peer/lifecycle/chaincode"
+	"github.com/spf13/cobra"
+)
+
+const (
+	lifecycleName = "lifecycle"
+	lifecycleDesc = "Perform _lifecycle operations"
+)
+
+// Cmd returns the cobra command for lifecycle
+func Cmd() *cobra.Command {
+	lifecycleCmd.AddCommand(chaincode.Cmd(nil))
+
+	return lifecycleCmd
+}
+
+var lifecycleCmd = &cobra.Command{
+	Use:   lifecycleName,
+	Short: lifecycleDesc,
+	Long:  lifecycleDesc,
+}
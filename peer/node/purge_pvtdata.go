@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/hyperledger/fabric-lib-go/healthz"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/scc/lscc"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func purgePvtDataCmd() *cobra.Command {
+	nodePurgePvtDataCmd.ResetFlags()
+	flags := nodePurgePvtDataCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel to purge the private data of.")
+	flags.Uint64VarP(&blockNumber, "blockNumber", "b", 0, "Block number below which the private data needs to be purged.")
+
+	return nodePurgePvtDataCmd
+}
+
+var nodePurgePvtDataCmd = &cobra.Command{
+	Use:   "purge-pvtdata",
+	Short: "Purges the private data of a channel.",
+	Long:  `Purges the private data of a channel that was committed before a specified block number, irrespective of the BlockToLive of the collections. When the command is executed, the peer must be offline. When the peer starts after the purge, the purged private data is not fetched again from other peers.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if channelID == common.UndefinedParamValue {
+			return errors.New("Must supply channel ID")
+		}
+		// the ledger registers its state database for the chaincode events on opening. No chaincode
+		// is deployed or installed while the peer is offline, hence no chaincode info provider is needed
+		cceventmgmt.Initialize(nil)
+		return kvledger.PurgePrivateData(channelID, blockNumber, purgeLedgerInitializer())
+	},
+}
+
+// purgeLedgerInitializer returns the initializer for opening the ledger while the peer is offline.
+// The membership info provider is required only if the ledger needs to recommit the blocks that
+// are missing in the state database
+func purgeLedgerInitializer() *ledger.Initializer {
+	identityDeserializerFactory := func(chainID string) msp.IdentityDeserializer {
+		return mgmt.GetManagerForChain(chainID)
+	}
+	mspID := viper.GetString("peer.localMspId")
+	return &ledger.Initializer{
+		DeployedChaincodeInfoProvider: &lifecycle.DeployedCCInfoProvider{LegacyDeployedCCInfoProvider: &lscc.DeployedCCInfoProvider{}},
+		MembershipInfoProvider:        privdata.NewMembershipInfoProvider(mspID, createSelfSignedData(), identityDeserializerFactory),
+		MetricsProvider:               &disabled.Provider{},
+		HealthCheckRegistry:           &noopHealthCheckRegistry{},
+	}
+}
+
+// noopHealthCheckRegistry ignores the health checkers as the operations system
+// is not started while the peer is offline
+type noopHealthCheckRegistry struct{}
+
+func (*noopHealthCheckRegistry) RegisterChecker(string, healthz.HealthChecker) error {
+	return nil
+}
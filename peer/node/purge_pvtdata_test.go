@@ -0,0 +1,142 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	lutil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/msp/mgmt/testtools"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgePvtDataCmd(t *testing.T) {
+	t.Run("when the channelID is not supplied", func(t *testing.T) {
+		cmd := purgePvtDataCmd()
+		args := []string{"-b", "10"}
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		assert.Equal(t, "Must supply channel ID", err.Error())
+	})
+
+	t.Run("when the private data is purged", func(t *testing.T) {
+		testPath, err := ioutil.TempDir("", "purge-pvtdata")
+		require.NoError(t, err)
+		defer os.RemoveAll(testPath)
+		viper.Set("peer.fileSystemPath", testPath)
+		defer viper.Set("peer.fileSystemPath", "")
+		require.NoError(t, msptesttools.LoadMSPSetupForTesting())
+
+		// block 1 deploys cc1 with the collection coll1, blocks 2 and 3 write the keys key1 and key2 to coll1
+		bg, gb := testutil.NewBlockGenerator(t, "ch1", false)
+		provider, lgr := openLedgerForPurgeTest(t, gb)
+		commitPvtDataTxForPurgeTest(t, lgr, bg, func(s ledger.TxSimulator) {
+			ccData, err := proto.Marshal(&ccprovider.ChaincodeData{Name: "cc1"})
+			require.NoError(t, err)
+			collConfigs, err := proto.Marshal(&common.CollectionConfigPackage{
+				Config: []*common.CollectionConfig{{
+					Payload: &common.CollectionConfig_StaticCollectionConfig{
+						StaticCollectionConfig: &common.StaticCollectionConfig{Name: "coll1"},
+					},
+				}},
+			})
+			require.NoError(t, err)
+			require.NoError(t, s.SetState("lscc", "cc1", ccData))
+			require.NoError(t, s.SetState("lscc", privdata.BuildCollectionKVSKey("cc1"), collConfigs))
+		})
+		commitPvtDataTxForPurgeTest(t, lgr, bg, func(s ledger.TxSimulator) {
+			require.NoError(t, s.SetPrivateData("cc1", "coll1", "key1", []byte("value1")))
+		})
+		commitPvtDataTxForPurgeTest(t, lgr, bg, func(s ledger.TxSimulator) {
+			require.NoError(t, s.SetPrivateData("cc1", "coll1", "key2", []byte("value2")))
+		})
+		lgr.Close()
+		provider.Close()
+
+		cmd := purgePvtDataCmd()
+		cmd.SetArgs([]string{"-c", "ch1", "-b", "3"})
+		require.NoError(t, cmd.Execute())
+
+		provider, lgr = openLedgerForPurgeTest(t, nil)
+		defer provider.Close()
+		defer lgr.Close()
+		minBlockNum, err := lgr.PrivateDataMinBlockNum()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(3), minBlockNum)
+
+		// the private data of block 2 is purged from the private data store and the state,
+		// whereas the hashes remain in the state
+		blkAndPvtData, err := lgr.GetPvtDataAndBlockByNum(2, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, blkAndPvtData.PvtData)
+		qe, err := lgr.NewQueryExecutor()
+		require.NoError(t, err)
+		defer qe.Done()
+		_, err = qe.GetPrivateData("cc1", "coll1", "key1")
+		assert.Contains(t, err.Error(), "private data matching public hash version is not available")
+		valueHash, err := qe.GetPrivateDataHash("cc1", "coll1", "key1")
+		assert.NoError(t, err)
+		assert.Equal(t, lutil.ComputeStringHash("value1"), valueHash)
+
+		// the private data of block 3 is retained
+		blkAndPvtData, err = lgr.GetPvtDataAndBlockByNum(3, nil)
+		assert.NoError(t, err)
+		assert.Len(t, blkAndPvtData.PvtData, 1)
+		value, err := qe.GetPrivateData("cc1", "coll1", "key2")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("value2"), value)
+	})
+}
+
+// openLedgerForPurgeTest opens the ledger ch1, after creating it from the genesis block if supplied
+func openLedgerForPurgeTest(t *testing.T, gb *common.Block) (ledger.PeerLedgerProvider, ledger.PeerLedger) {
+	cceventmgmt.Initialize(nil)
+	provider, err := kvledger.NewProvider()
+	require.NoError(t, err)
+	require.NoError(t, provider.Initialize(purgeLedgerInitializer()))
+	var lgr ledger.PeerLedger
+	if gb != nil {
+		lgr, err = provider.Create(gb)
+	} else {
+		lgr, err = provider.Open("ch1")
+	}
+	require.NoError(t, err)
+	return provider, lgr
+}
+
+func commitPvtDataTxForPurgeTest(t *testing.T, lgr ledger.PeerLedger, bg *testutil.BlockGenerator, simulate func(s ledger.TxSimulator)) {
+	txID := util.GenerateUUID()
+	sim, err := lgr.NewTxSimulator(txID)
+	require.NoError(t, err)
+	simulate(sim)
+	sim.Done()
+	simRes, err := sim.GetTxSimulationResults()
+	require.NoError(t, err)
+	pubSimBytes, err := simRes.GetPubSimulationBytes()
+	require.NoError(t, err)
+	block := bg.NextBlockWithTxid([][]byte{pubSimBytes}, []string{txID})
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = lutil.NewTxValidationFlagsSetValue(1, peer.TxValidationCode_VALID)
+	pvtData := ledger.TxPvtDataMap{}
+	if simRes.PvtSimulationResults != nil {
+		pvtData[0] = &ledger.TxPvtData{SeqInBlock: 0, WriteSet: simRes.PvtSimulationResults}
+	}
+	require.NoError(t, lgr.CommitWithPvtData(&ledger.BlockAndPvtData{Block: block, PvtData: pvtData}, &ledger.CommitOptions{}))
+}
@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"sync"
 	"syscall"
 	"time"
@@ -20,6 +21,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	ccdef "github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/common/deliver"
@@ -49,8 +51,10 @@ import (
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/privdata"
+	coreconfig "github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/core/container/dockercontroller"
+	"github.com/hyperledger/fabric/core/container/externalbuilder"
 	"github.com/hyperledger/fabric/core/container/inproccontroller"
 	"github.com/hyperledger/fabric/core/endorser"
 	authHandler "github.com/hyperledger/fabric/core/handlers/auth"
@@ -161,7 +165,9 @@ func serve(args []string) error {
 		&car.Platform{},
 	)
 
-	deployedCCInfoProvider := &lscc.DeployedCCInfoProvider{}
+	deployedCCInfoProvider := &lifecycle.DeployedCCInfoProvider{
+		LegacyDeployedCCInfoProvider: &lscc.DeployedCCInfoProvider{},
+	}
 
 	identityDeserializerFactory := func(chainID string) msp.IdentityDeserializer {
 		return mgmt.GetManagerForChain(chainID)
@@ -188,6 +194,7 @@ func serve(args []string) error {
 			MembershipInfoProvider:        membershipInfoProvider,
 			MetricsProvider:               metricsProvider,
 			HealthCheckRegistry:           opsSystem,
+			SnapshotSigner:                mgmt.GetLocalSigningIdentityOrPanic(),
 		},
 	)
 
@@ -271,7 +278,7 @@ func serve(args []string) error {
 	pb.RegisterDeliverServer(peerServer.Server(), abServer)
 
 	// Initialize chaincode service
-	chaincodeSupport, ccp, sccp, packageProvider := startChaincodeServer(peerHost, aclProvider, pr, opsSystem)
+	chaincodeSupport, ccp, sccp, packageProvider := startChaincodeServer(peerHost, aclProvider, pr, deployedCCInfoProvider, opsSystem)
 
 	logger.Debugf("Running peer")
 
@@ -668,6 +675,8 @@ func registerChaincodeSupport(
 	aclProvider aclmgmt.ACLProvider,
 	pr *platforms.Registry,
 	lifecycleSCC *lifecycle.SCC,
+	lifecycleImpl *lifecycle.Lifecycle,
+	deployedCCInfoProvider ledger.DeployedChaincodeInfoProvider,
 	ops *operations.System,
 ) (*chaincode.ChaincodeSupport, ccprovider.ChaincodeProvider, *scc.Provider) {
 	//get user mode
@@ -679,22 +688,45 @@ func registerChaincodeSupport(
 
 	sccp := scc.NewProvider(peer.Default, peer.DefaultSupport, ipRegistry)
 	lsccInst := lscc.New(sccp, aclProvider, pr)
+	// the chaincode without a definition committed through the new
+	// lifecycle is executed as per its definition in lscc
+	lifecycleImpl.LegacyImpl = lsccInst
 
-	dockerProvider := dockercontroller.NewProvider(
-		viper.GetString("peer.id"),
-		viper.GetString("peer.networkId"),
-		ops.Provider,
-	)
-	dockerVM := dockercontroller.NewDockerVM(
-		dockerProvider.PeerID,
-		dockerProvider.NetworkID,
-		dockerProvider.BuildMetrics,
-	)
+	vmProviders := map[string]container.VMProvider{
+		inproccontroller.ContainerType: ipRegistry,
+	}
 
-	err := ops.RegisterChecker("docker", dockerVM)
-	if err != nil {
-		logger.Panicf("failed to register docker health check: %s", err)
+	// docker is used only if the vm endpoint is set, so that the peer can run
+	// without a docker daemon when the chaincode is built by external builders
+	if viper.GetString("vm.endpoint") != "" {
+		dockerProvider := dockercontroller.NewProvider(
+			viper.GetString("peer.id"),
+			viper.GetString("peer.networkId"),
+			ops.Provider,
+		)
+		dockerVM := dockercontroller.NewDockerVM(
+			dockerProvider.PeerID,
+			dockerProvider.NetworkID,
+			dockerProvider.BuildMetrics,
+		)
+
+		err := ops.RegisterChecker("docker", dockerVM)
+		if err != nil {
+			logger.Panicf("failed to register docker health check: %s", err)
+		}
+		vmProviders[dockercontroller.ContainerType] = dockerProvider
+	}
+
+	var externalBuilders []externalbuilder.Config
+	if err := viperutil.EnhancedExactUnmarshalKey("chaincode.externalBuilders", &externalBuilders); err != nil {
+		logger.Panicf("could not load the external builders config: %s", err)
 	}
+	externalBuilderProvider := externalbuilder.NewProvider(
+		ccEndpoint,
+		filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "externalbuilder", "builds"),
+		externalBuilders,
+	)
+	vmProviders[externalbuilder.ContainerType] = externalBuilderProvider
 
 	chaincodeSupport := chaincode.NewChaincodeSupport(
 		chaincode.GlobalConfig(),
@@ -703,20 +735,18 @@ func registerChaincodeSupport(
 		ca.CertBytes(),
 		authenticator,
 		packageProvider,
-		lsccInst,
+		lifecycleImpl,
 		aclProvider,
-		container.NewVMController(
-			map[string]container.VMProvider{
-				dockercontroller.ContainerType: dockerProvider,
-				inproccontroller.ContainerType: ipRegistry,
-			},
-		),
+		container.NewVMController(vmProviders),
+		externalBuilderProvider,
 		sccp,
 		pr,
 		peer.DefaultSupport,
 		ops.Provider,
 	)
+	chaincodeSupport.DeployedCCInfoProvider = deployedCCInfoProvider
 	ipRegistry.ChaincodeSupport = chaincodeSupport
+	externalBuilderProvider.ChaincodeSupport = chaincodeSupport
 	ccp := chaincode.NewProvider(chaincodeSupport)
 
 	ccSrv := pb.ChaincodeSupportServer(chaincodeSupport)
@@ -745,6 +775,7 @@ func startChaincodeServer(
 	peerHost string,
 	aclProvider aclmgmt.ACLProvider,
 	pr *platforms.Registry,
+	deployedCCInfoProvider ledger.DeployedChaincodeInfoProvider,
 	ops *operations.System,
 ) (*chaincode.ChaincodeSupport, ccprovider.ChaincodeProvider, *scc.Provider, *persistence.PackageProvider) {
 	// Setup chaincode path
@@ -762,12 +793,16 @@ func startChaincodeServer(
 		Store:    ccStore,
 	}
 
+	lifecycleImpl := &lifecycle.Lifecycle{
+		PackageParser:  ccPackageParser,
+		ChaincodeStore: ccStore,
+	}
+
 	lifecycleSCC := &lifecycle.SCC{
-		Protobuf: &lifecycle.ProtobufImpl{},
-		Functions: &lifecycle.Lifecycle{
-			PackageParser:  ccPackageParser,
-			ChaincodeStore: ccStore,
-		},
+		OrgMSPID:    viper.GetString("peer.localMspId"),
+		ChannelOrgs: peer.Default,
+		Protobuf:    &lifecycle.ProtobufImpl{},
+		Functions:   lifecycleImpl,
 	}
 
 	// Create a self-signed CA for chaincode service
@@ -787,6 +822,8 @@ func startChaincodeServer(
 		aclProvider,
 		pr,
 		lifecycleSCC,
+		lifecycleImpl,
+		deployedCCInfoProvider,
 		ops,
 	)
 	go ccSrv.Start()
@@ -892,6 +929,13 @@ func initGossipService(policyMgr policies.ChannelPolicyManagerGetter, metricsPro
 		policyMgr,
 		localmsp.NewSigner(),
 		mgmt.NewDeserializersManager(),
+		func(cid string) (channelconfig.Orderer, bool) {
+			cc := peer.GetStableChannelConfig(cid)
+			if cc == nil {
+				return nil, false
+			}
+			return cc.OrdererConfig()
+		},
 	)
 	secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager())
 	bootstrap := viper.GetStringSlice("peer.gossip.bootstrap")
@@ -942,6 +986,7 @@ func registerProverService(peerServer *comm.GRPCServer, aclProvider aclmgmt.ACLP
 			IssueTokens:    resources.Token_Issue,
 			TransferTokens: resources.Token_Transfer,
 			ListTokens:     resources.Token_List,
+			AuditTokens:    resources.Token_Audit,
 		},
 	}
 
@@ -959,6 +1004,7 @@ func registerProverService(peerServer *comm.GRPCServer, aclProvider aclmgmt.ACLP
 		PolicyChecker: policyChecker,
 		TMSManager: &server.Manager{
 			LedgerManager: &server.PeerLedgerManager{},
+			Driver:        viper.GetString("peer.token.driver"),
 		},
 	}
 	token.RegisterProverServer(peerServer.Server(), prover)
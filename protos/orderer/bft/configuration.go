@@ -0,0 +1,130 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/orderer"
+)
+
+// TypeKey is the string with which this consensus implementation is identified across Fabric.
+const TypeKey = "bft"
+
+func init() {
+	orderer.ConsensusTypeMetadataMap[TypeKey] = ConsensusTypeMetadataFactory{}
+}
+
+// ConsensusTypeMetadataFactory allows this implementation's proto messages to register
+// their type with the orderer's proto messages. This is needed for protolator to work.
+type ConsensusTypeMetadataFactory struct{}
+
+// NewMessage implements the Orderer.ConsensusTypeMetadataFactory interface.
+func (dogf ConsensusTypeMetadataFactory) NewMessage() proto.Message {
+	return &ConfigMetadata{}
+}
+
+// Marshal serializes this implementation's proto messages. It is called by the encoder package
+// during the creation of the Orderer ConfigGroup.
+func Marshal(md *ConfigMetadata) ([]byte, error) {
+	copyMd := proto.Clone(md).(*ConfigMetadata)
+	for _, c := range copyMd.Consenters {
+		// Expect the user to set the config value for the identity and the
+		// client/server certs to the path where they are persisted locally,
+		// then load these files to memory.
+		identityCert, err := ioutil.ReadFile(string(c.GetIdentity()))
+		if err != nil {
+			return nil, fmt.Errorf("cannot load identity for consenter %s:%d: %s", c.GetHost(), c.GetPort(), err)
+		}
+		identity, err := proto.Marshal(&msp.SerializedIdentity{Mspid: c.GetMspId(), IdBytes: identityCert})
+		if err != nil {
+			return nil, fmt.Errorf("cannot serialize identity for consenter %s:%d: %s", c.GetHost(), c.GetPort(), err)
+		}
+		c.Identity = identity
+
+		clientCert, err := ioutil.ReadFile(string(c.GetClientTlsCert()))
+		if err != nil {
+			return nil, fmt.Errorf("cannot load client cert for consenter %s:%d: %s", c.GetHost(), c.GetPort(), err)
+		}
+		c.ClientTlsCert = clientCert
+
+		serverCert, err := ioutil.ReadFile(string(c.GetServerTlsCert()))
+		if err != nil {
+			return nil, fmt.Errorf("cannot load server cert for consenter %s:%d: %s", c.GetHost(), c.GetPort(), err)
+		}
+		c.ServerTlsCert = serverCert
+	}
+	return proto.Marshal(copyMd)
+}
+
+// Quorum returns the number of consenters out of n which must agree on a block,
+// tolerating up to (n-1)/3 byzantine consenters.
+func Quorum(n int) int {
+	f := (n - 1) / 3
+	// ceil((n+f+1)/2)
+	return (n + f + 2) / 2
+}
+
+// VerifyBlockSignatures checks that the block carries valid signatures of a quorum
+// of the given consenters. The verify function checks a single signature of the
+// given serialized identity over the given data.
+func VerifyBlockSignatures(block *common.Block, consenters []*Consenter, verify func(identity, data, signature []byte) error) error {
+	if block == nil || block.Header == nil || block.Metadata == nil || len(block.Metadata.Metadata) <= int(common.BlockMetadataIndex_SIGNATURES) {
+		return fmt.Errorf("block is missing its header or signatures")
+	}
+
+	metadata := &common.Metadata{}
+	if err := proto.Unmarshal(block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES], metadata); err != nil {
+		return fmt.Errorf("failed unmarshaling signatures of block [%d]: %s", block.Header.Number, err)
+	}
+
+	headerBytes := block.Header.Bytes()
+	signers := make(map[uint64]struct{})
+	for _, ms := range metadata.Signatures {
+		shdr := &common.SignatureHeader{}
+		if err := proto.Unmarshal(ms.SignatureHeader, shdr); err != nil {
+			continue
+		}
+
+		consenter := consenterByIdentity(consenters, shdr.Creator)
+		if consenter == nil {
+			continue
+		}
+		if _, exists := signers[consenter.ConsenterId]; exists {
+			continue
+		}
+
+		data := make([]byte, 0, len(metadata.Value)+len(ms.SignatureHeader)+len(headerBytes))
+		data = append(data, metadata.Value...)
+		data = append(data, ms.SignatureHeader...)
+		data = append(data, headerBytes...)
+		if err := verify(shdr.Creator, data, ms.Signature); err != nil {
+			continue
+		}
+		signers[consenter.ConsenterId] = struct{}{}
+	}
+
+	if quorum := Quorum(len(consenters)); len(signers) < quorum {
+		return fmt.Errorf("block [%d] has %d valid consenter signatures, but a quorum of %d is required", block.Header.Number, len(signers), quorum)
+	}
+
+	return nil
+}
+
+func consenterByIdentity(consenters []*Consenter, identity []byte) *Consenter {
+	for _, c := range consenters {
+		if bytes.Equal(c.Identity, identity) {
+			return c
+		}
+	}
+	return nil
+}
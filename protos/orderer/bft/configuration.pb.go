@@ -0,0 +1,245 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: orderer/bft/configuration.proto
+
+package bft // import "github.com/hyperledger/fabric/protos/orderer/bft"
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// ConfigMetadata is serialized and set as the value of ConsensusType.Metadata in
+// a channel configuration when the ConsensusType.Type is set "bft".
+type ConfigMetadata struct {
+	Consenters           []*Consenter `protobuf:"bytes,1,rep,name=consenters,proto3" json:"consenters,omitempty"`
+	Options              *Options     `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ConfigMetadata) Reset()         { *m = ConfigMetadata{} }
+func (m *ConfigMetadata) String() string { return proto.CompactTextString(m) }
+func (*ConfigMetadata) ProtoMessage()    {}
+func (*ConfigMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_ae34d8250c5fd40f, []int{0}
+}
+func (m *ConfigMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigMetadata.Unmarshal(m, b)
+}
+func (m *ConfigMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigMetadata.Marshal(b, m, deterministic)
+}
+func (dst *ConfigMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigMetadata.Merge(dst, src)
+}
+func (m *ConfigMetadata) XXX_Size() int {
+	return xxx_messageInfo_ConfigMetadata.Size(m)
+}
+func (m *ConfigMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigMetadata proto.InternalMessageInfo
+
+func (m *ConfigMetadata) GetConsenters() []*Consenter {
+	if m != nil {
+		return m.Consenters
+	}
+	return nil
+}
+
+func (m *ConfigMetadata) GetOptions() *Options {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// Consenter represents a consenting node (i.e. replica).
+type Consenter struct {
+	// consenter_id is unique among the consenters of the channel and cannot be 0.
+	ConsenterId uint64 `protobuf:"varint,1,opt,name=consenter_id,json=consenterId,proto3" json:"consenter_id,omitempty"`
+	Host        string `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Port        uint32 `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	MspId       string `protobuf:"bytes,4,opt,name=msp_id,json=mspId,proto3" json:"msp_id,omitempty"`
+	// identity is the serialized MSP identity the consenter signs blocks with.
+	Identity             []byte   `protobuf:"bytes,5,opt,name=identity,proto3" json:"identity,omitempty"`
+	ClientTlsCert        []byte   `protobuf:"bytes,6,opt,name=client_tls_cert,json=clientTlsCert,proto3" json:"client_tls_cert,omitempty"`
+	ServerTlsCert        []byte   `protobuf:"bytes,7,opt,name=server_tls_cert,json=serverTlsCert,proto3" json:"server_tls_cert,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Consenter) Reset()         { *m = Consenter{} }
+func (m *Consenter) String() string { return proto.CompactTextString(m) }
+func (*Consenter) ProtoMessage()    {}
+func (*Consenter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_ae34d8250c5fd40f, []int{1}
+}
+func (m *Consenter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Consenter.Unmarshal(m, b)
+}
+func (m *Consenter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Consenter.Marshal(b, m, deterministic)
+}
+func (dst *Consenter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Consenter.Merge(dst, src)
+}
+func (m *Consenter) XXX_Size() int {
+	return xxx_messageInfo_Consenter.Size(m)
+}
+func (m *Consenter) XXX_DiscardUnknown() {
+	xxx_messageInfo_Consenter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Consenter proto.InternalMessageInfo
+
+func (m *Consenter) GetConsenterId() uint64 {
+	if m != nil {
+		return m.ConsenterId
+	}
+	return 0
+}
+
+func (m *Consenter) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *Consenter) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *Consenter) GetMspId() string {
+	if m != nil {
+		return m.MspId
+	}
+	return ""
+}
+
+func (m *Consenter) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *Consenter) GetClientTlsCert() []byte {
+	if m != nil {
+		return m.ClientTlsCert
+	}
+	return nil
+}
+
+func (m *Consenter) GetServerTlsCert() []byte {
+	if m != nil {
+		return m.ServerTlsCert
+	}
+	return nil
+}
+
+// Options to be specified for all the BFT nodes. These can be modified on a
+// per-channel basis.
+type Options struct {
+	// request_timeout is the time a node waits for a request it has seen to
+	// be ordered before suspecting the leader, e.g. 10s.
+	RequestTimeout string `protobuf:"bytes,1,opt,name=request_timeout,json=requestTimeout,proto3" json:"request_timeout,omitempty"`
+	// view_change_timeout is the time a node waits for a view change to
+	// complete before moving on to the next view, e.g. 20s.
+	ViewChangeTimeout    string   `protobuf:"bytes,2,opt,name=view_change_timeout,json=viewChangeTimeout,proto3" json:"view_change_timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Options) Reset()         { *m = Options{} }
+func (m *Options) String() string { return proto.CompactTextString(m) }
+func (*Options) ProtoMessage()    {}
+func (*Options) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_ae34d8250c5fd40f, []int{2}
+}
+func (m *Options) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Options.Unmarshal(m, b)
+}
+func (m *Options) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Options.Marshal(b, m, deterministic)
+}
+func (dst *Options) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Options.Merge(dst, src)
+}
+func (m *Options) XXX_Size() int {
+	return xxx_messageInfo_Options.Size(m)
+}
+func (m *Options) XXX_DiscardUnknown() {
+	xxx_messageInfo_Options.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Options proto.InternalMessageInfo
+
+func (m *Options) GetRequestTimeout() string {
+	if m != nil {
+		return m.RequestTimeout
+	}
+	return ""
+}
+
+func (m *Options) GetViewChangeTimeout() string {
+	if m != nil {
+		return m.ViewChangeTimeout
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ConfigMetadata)(nil), "bft.ConfigMetadata")
+	proto.RegisterType((*Consenter)(nil), "bft.Consenter")
+	proto.RegisterType((*Options)(nil), "bft.Options")
+}
+
+func init() {
+	proto.RegisterFile("orderer/bft/configuration.proto", fileDescriptor_configuration_ae34d8250c5fd40f)
+}
+
+var fileDescriptor_configuration_ae34d8250c5fd40f = []byte{
+	// 361 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x92, 0xcd, 0xca, 0x9b, 0x40,
+	0x14, 0x86, 0xb1, 0xf9, 0x6b, 0x26, 0x7f, 0x74, 0x4a, 0x41, 0xba, 0xa9, 0xcd, 0x22, 0xb5, 0x9b,
+	0xb1, 0xa4, 0x77, 0x50, 0x57, 0x59, 0x94, 0x82, 0x64, 0x55, 0x28, 0xe2, 0xcf, 0x51, 0x07, 0xd4,
+	0xb1, 0x67, 0x8e, 0x29, 0xb9, 0xd4, 0xde, 0xcd, 0x87, 0x33, 0xc6, 0x2f, 0xbb, 0xf1, 0x79, 0x9f,
+	0xf3, 0xc2, 0x71, 0x86, 0x7d, 0x52, 0x98, 0x03, 0x02, 0x06, 0x69, 0x41, 0x41, 0xa6, 0xda, 0x42,
+	0x96, 0x3d, 0x26, 0x24, 0x55, 0x2b, 0x3a, 0x54, 0xa4, 0xf8, 0x2c, 0x2d, 0xe8, 0x58, 0xb1, 0x7d,
+	0x68, 0xb2, 0x9f, 0x40, 0x49, 0x9e, 0x50, 0xc2, 0x05, 0x63, 0x99, 0x6a, 0x35, 0xb4, 0x04, 0xa8,
+	0x5d, 0xc7, 0x9b, 0xf9, 0x9b, 0xf3, 0x5e, 0xa4, 0x05, 0x89, 0xf0, 0x81, 0xa3, 0x27, 0x83, 0x9f,
+	0xd8, 0x4a, 0x75, 0x43, 0xad, 0x76, 0xdf, 0x78, 0x8e, 0xbf, 0x39, 0x6f, 0x8d, 0xfc, 0xcb, 0xb2,
+	0xe8, 0x11, 0x1e, 0xff, 0x3b, 0x6c, 0x3d, 0x35, 0xf0, 0xcf, 0x6c, 0x3b, 0x75, 0xc4, 0x32, 0x77,
+	0x1d, 0xcf, 0xf1, 0xe7, 0xd1, 0x66, 0x62, 0x97, 0x9c, 0x73, 0x36, 0xaf, 0x94, 0x26, 0xd3, 0xba,
+	0x8e, 0xcc, 0x79, 0x60, 0x9d, 0x42, 0x72, 0x67, 0x9e, 0xe3, 0xef, 0x22, 0x73, 0xe6, 0x1f, 0xd8,
+	0xb2, 0xd1, 0xdd, 0x50, 0x32, 0x37, 0xe6, 0xa2, 0xd1, 0xdd, 0x25, 0xe7, 0x1f, 0xd9, 0x5b, 0x99,
+	0x43, 0x4b, 0x92, 0xee, 0xee, 0xc2, 0x73, 0xfc, 0x6d, 0x34, 0x7d, 0xf3, 0x13, 0x3b, 0x64, 0xb5,
+	0x84, 0x96, 0x62, 0xaa, 0x75, 0x9c, 0x01, 0x92, 0xbb, 0x34, 0xca, 0xce, 0xe2, 0x6b, 0xad, 0x43,
+	0x40, 0x1a, 0x3c, 0x0d, 0x78, 0x03, 0x7c, 0xf5, 0x56, 0xd6, 0xb3, 0x78, 0xf4, 0x8e, 0x29, 0x5b,
+	0x8d, 0xfb, 0xf2, 0x2f, 0xec, 0x80, 0xf0, 0xb7, 0x07, 0x4d, 0x31, 0xc9, 0x06, 0x54, 0x4f, 0x66,
+	0xb7, 0x75, 0xb4, 0x1f, 0xf1, 0xd5, 0x52, 0x2e, 0xd8, 0xfb, 0x9b, 0x84, 0x7f, 0x71, 0x56, 0x25,
+	0x6d, 0x09, 0x93, 0x6c, 0xb7, 0x7d, 0x37, 0x44, 0xa1, 0x49, 0x46, 0xff, 0xc7, 0x1f, 0xf6, 0x55,
+	0x61, 0x29, 0xaa, 0x7b, 0x07, 0x58, 0x43, 0x5e, 0x02, 0x8a, 0x22, 0x49, 0x51, 0x66, 0xf6, 0x3a,
+	0xb5, 0x18, 0xef, 0x7b, 0xf8, 0xfb, 0xbf, 0xbf, 0x95, 0x92, 0xaa, 0x3e, 0x15, 0x99, 0x6a, 0x82,
+	0xa7, 0x89, 0xc0, 0x4e, 0x04, 0x76, 0x22, 0x78, 0x7a, 0x21, 0xe9, 0xd2, 0xb0, 0xef, 0x2f, 0x03,
+	0x00, 0xf3, 0x4f, 0xf4, 0x58, 0x37, 0x02, 0x00, 0x00,
+}
@@ -0,0 +1,129 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal(t *testing.T) {
+	md := &bft.ConfigMetadata{
+		Consenters: []*bft.Consenter{
+			{
+				ConsenterId:   1,
+				Host:          "node-1.example.com",
+				Port:          7050,
+				MspId:         "OrdererMSP",
+				Identity:      []byte("testdata/sign-cert-1.pem"),
+				ClientTlsCert: []byte("testdata/tls-client-1.pem"),
+				ServerTlsCert: []byte("testdata/tls-server-1.pem"),
+			},
+		},
+	}
+	packed, err := bft.Marshal(md)
+	require.NoError(t, err, "marshalling should succeed")
+	assert.Equal(t, []byte("testdata/sign-cert-1.pem"), md.Consenters[0].Identity, "marshalling should not mutate the input")
+
+	unpacked := &bft.ConfigMetadata{}
+	require.NoError(t, proto.Unmarshal(packed, unpacked), "unmarshalling should succeed")
+
+	signCert, _ := ioutil.ReadFile("testdata/sign-cert-1.pem")
+	clientCert, _ := ioutil.ReadFile("testdata/tls-client-1.pem")
+	serverCert, _ := ioutil.ReadFile("testdata/tls-server-1.pem")
+
+	identity := &msp.SerializedIdentity{}
+	require.NoError(t, proto.Unmarshal(unpacked.Consenters[0].Identity, identity))
+	assert.Equal(t, "OrdererMSP", identity.Mspid)
+	assert.Equal(t, signCert, identity.IdBytes)
+	assert.Equal(t, clientCert, unpacked.Consenters[0].ClientTlsCert)
+	assert.Equal(t, serverCert, unpacked.Consenters[0].ServerTlsCert)
+
+	md.Consenters[0].Identity = []byte("testdata/missing.pem")
+	_, err = bft.Marshal(md)
+	assert.Contains(t, err.Error(), "cannot load identity for consenter node-1.example.com:7050")
+}
+
+func TestQuorum(t *testing.T) {
+	for n, expected := range map[int]int{1: 1, 2: 2, 3: 2, 4: 3, 5: 4, 6: 4, 7: 5, 10: 7} {
+		assert.Equal(t, expected, bft.Quorum(n), "quorum of %d consenters", n)
+	}
+}
+
+func TestVerifyBlockSignatures(t *testing.T) {
+	consenters := []*bft.Consenter{
+		{ConsenterId: 1, Identity: []byte("alice")},
+		{ConsenterId: 2, Identity: []byte("bob")},
+		{ConsenterId: 3, Identity: []byte("carol")},
+		{ConsenterId: 4, Identity: []byte("dave")},
+	}
+
+	// The signature of an identity is its name followed by the signed data
+	verify := func(identity, data, signature []byte) error {
+		if !bytes.Equal(signature, append(append([]byte{}, identity...), data...)) {
+			return errors.New("bad signature")
+		}
+		return nil
+	}
+
+	signedBlock := func(signers ...string) *common.Block {
+		block := common.NewBlock(5, []byte("previous"))
+		value := []byte("value")
+		md := &common.Metadata{Value: value}
+		for _, signer := range signers {
+			shdr, _ := proto.Marshal(&common.SignatureHeader{Creator: []byte(signer), Nonce: []byte(signer)})
+			data := append(append(append([]byte{}, value...), shdr...), block.Header.Bytes()...)
+			md.Signatures = append(md.Signatures, &common.MetadataSignature{
+				SignatureHeader: shdr,
+				Signature:       append([]byte(signer), data...),
+			})
+		}
+		block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES], _ = proto.Marshal(md)
+		return block
+	}
+
+	t.Run("quorum", func(t *testing.T) {
+		assert.NoError(t, bft.VerifyBlockSignatures(signedBlock("alice", "bob", "carol"), consenters, verify))
+		assert.NoError(t, bft.VerifyBlockSignatures(signedBlock("alice", "bob", "carol", "dave"), consenters, verify))
+	})
+
+	t.Run("no quorum", func(t *testing.T) {
+		err := bft.VerifyBlockSignatures(signedBlock("alice", "bob"), consenters, verify)
+		assert.EqualError(t, err, "block [5] has 2 valid consenter signatures, but a quorum of 3 is required")
+	})
+
+	t.Run("duplicate signers", func(t *testing.T) {
+		err := bft.VerifyBlockSignatures(signedBlock("alice", "bob", "bob"), consenters, verify)
+		assert.EqualError(t, err, "block [5] has 2 valid consenter signatures, but a quorum of 3 is required")
+	})
+
+	t.Run("signers that are not consenters", func(t *testing.T) {
+		err := bft.VerifyBlockSignatures(signedBlock("alice", "bob", "eve"), consenters, verify)
+		assert.EqualError(t, err, "block [5] has 2 valid consenter signatures, but a quorum of 3 is required")
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		block := signedBlock("alice", "bob", "carol")
+		block.Header.Number = 6
+		err := bft.VerifyBlockSignatures(block, consenters, verify)
+		assert.EqualError(t, err, "block [6] has 0 valid consenter signatures, but a quorum of 3 is required")
+	})
+
+	t.Run("missing signatures", func(t *testing.T) {
+		err := bft.VerifyBlockSignatures(&common.Block{Header: &common.BlockHeader{}}, consenters, verify)
+		assert.EqualError(t, err, "block is missing its header or signatures")
+	})
+}
@@ -0,0 +1,735 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: orderer/bft/messages.proto
+
+package bft // import "github.com/hyperledger/fabric/protos/orderer/bft"
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import common "github.com/hyperledger/fabric/protos/common"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// Message is the payload of the ConsensusRequests exchanged
+// between BFT nodes.
+type Message struct {
+	// Types that are valid to be assigned to Payload:
+	//	*Message_PrePrepare
+	//	*Message_Prepare
+	//	*Message_Commit
+	//	*Message_ViewChange
+	//	*Message_BlockRequest
+	//	*Message_BlockResponse
+	Payload              isMessage_Payload `protobuf_oneof:"payload"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Message) Reset()         { *m = Message{} }
+func (m *Message) String() string { return proto.CompactTextString(m) }
+func (*Message) ProtoMessage()    {}
+func (*Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_7b4bf01001614af1, []int{0}
+}
+func (m *Message) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Message.Unmarshal(m, b)
+}
+func (m *Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Message.Marshal(b, m, deterministic)
+}
+func (dst *Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Message.Merge(dst, src)
+}
+func (m *Message) XXX_Size() int {
+	return xxx_messageInfo_Message.Size(m)
+}
+func (m *Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_Message.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Message proto.InternalMessageInfo
+
+type isMessage_Payload interface {
+	isMessage_Payload()
+}
+
+type Message_PrePrepare struct {
+	PrePrepare *PrePrepare `protobuf:"bytes,1,opt,name=pre_prepare,json=prePrepare,proto3,oneof"`
+}
+
+type Message_Prepare struct {
+	Prepare *Prepare `protobuf:"bytes,2,opt,name=prepare,proto3,oneof"`
+}
+
+type Message_Commit struct {
+	Commit *Commit `protobuf:"bytes,3,opt,name=commit,proto3,oneof"`
+}
+
+type Message_ViewChange struct {
+	ViewChange *ViewChange `protobuf:"bytes,4,opt,name=view_change,json=viewChange,proto3,oneof"`
+}
+
+type Message_BlockRequest struct {
+	BlockRequest *BlockRequest `protobuf:"bytes,5,opt,name=block_request,json=blockRequest,proto3,oneof"`
+}
+
+type Message_BlockResponse struct {
+	BlockResponse *BlockResponse `protobuf:"bytes,6,opt,name=block_response,json=blockResponse,proto3,oneof"`
+}
+
+func (*Message_PrePrepare) isMessage_Payload() {}
+
+func (*Message_Prepare) isMessage_Payload() {}
+
+func (*Message_Commit) isMessage_Payload() {}
+
+func (*Message_ViewChange) isMessage_Payload() {}
+
+func (*Message_BlockRequest) isMessage_Payload() {}
+
+func (*Message_BlockResponse) isMessage_Payload() {}
+
+func (m *Message) GetPayload() isMessage_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *Message) GetPrePrepare() *PrePrepare {
+	if x, ok := m.GetPayload().(*Message_PrePrepare); ok {
+		return x.PrePrepare
+	}
+	return nil
+}
+
+func (m *Message) GetPrepare() *Prepare {
+	if x, ok := m.GetPayload().(*Message_Prepare); ok {
+		return x.Prepare
+	}
+	return nil
+}
+
+func (m *Message) GetCommit() *Commit {
+	if x, ok := m.GetPayload().(*Message_Commit); ok {
+		return x.Commit
+	}
+	return nil
+}
+
+func (m *Message) GetViewChange() *ViewChange {
+	if x, ok := m.GetPayload().(*Message_ViewChange); ok {
+		return x.ViewChange
+	}
+	return nil
+}
+
+func (m *Message) GetBlockRequest() *BlockRequest {
+	if x, ok := m.GetPayload().(*Message_BlockRequest); ok {
+		return x.BlockRequest
+	}
+	return nil
+}
+
+func (m *Message) GetBlockResponse() *BlockResponse {
+	if x, ok := m.GetPayload().(*Message_BlockResponse); ok {
+		return x.BlockResponse
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*Message) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _Message_OneofMarshaler, _Message_OneofUnmarshaler, _Message_OneofSizer, []interface{}{
+		(*Message_PrePrepare)(nil),
+		(*Message_Prepare)(nil),
+		(*Message_Commit)(nil),
+		(*Message_ViewChange)(nil),
+		(*Message_BlockRequest)(nil),
+		(*Message_BlockResponse)(nil),
+	}
+}
+
+func _Message_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*Message)
+	// payload
+	switch x := m.Payload.(type) {
+	case *Message_PrePrepare:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PrePrepare); err != nil {
+			return err
+		}
+	case *Message_Prepare:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Prepare); err != nil {
+			return err
+		}
+	case *Message_Commit:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Commit); err != nil {
+			return err
+		}
+	case *Message_ViewChange:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ViewChange); err != nil {
+			return err
+		}
+	case *Message_BlockRequest:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.BlockRequest); err != nil {
+			return err
+		}
+	case *Message_BlockResponse:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.BlockResponse); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("Message.Payload has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _Message_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*Message)
+	switch tag {
+	case 1: // payload.pre_prepare
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PrePrepare)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Message_PrePrepare{msg}
+		return true, err
+	case 2: // payload.prepare
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Prepare)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Message_Prepare{msg}
+		return true, err
+	case 3: // payload.commit
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Commit)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Message_Commit{msg}
+		return true, err
+	case 4: // payload.view_change
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ViewChange)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Message_ViewChange{msg}
+		return true, err
+	case 5: // payload.block_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(BlockRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Message_BlockRequest{msg}
+		return true, err
+	case 6: // payload.block_response
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(BlockResponse)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Message_BlockResponse{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _Message_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*Message)
+	// payload
+	switch x := m.Payload.(type) {
+	case *Message_PrePrepare:
+		s := proto.Size(x.PrePrepare)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Message_Prepare:
+		s := proto.Size(x.Prepare)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Message_Commit:
+		s := proto.Size(x.Commit)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Message_ViewChange:
+		s := proto.Size(x.ViewChange)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Message_BlockRequest:
+		s := proto.Size(x.BlockRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Message_BlockResponse:
+		s := proto.Size(x.BlockResponse)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// PrePrepare is sent by the leader of a view to propose the
+// block at the given sequence.
+type PrePrepare struct {
+	View  uint64        `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Seq   uint64        `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Block *common.Block `protobuf:"bytes,3,opt,name=block,proto3" json:"block,omitempty"`
+	// prepared carries the certificate which justifies proposing
+	// a block that some nodes may have locked on in an earlier view.
+	Prepared             *PreparedCertificate `protobuf:"bytes,4,opt,name=prepared,proto3" json:"prepared,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *PrePrepare) Reset()         { *m = PrePrepare{} }
+func (m *PrePrepare) String() string { return proto.CompactTextString(m) }
+func (*PrePrepare) ProtoMessage()    {}
+func (*PrePrepare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_7b4bf01001614af1, []int{1}
+}
+func (m *PrePrepare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrePrepare.Unmarshal(m, b)
+}
+func (m *PrePrepare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrePrepare.Marshal(b, m, deterministic)
+}
+func (dst *PrePrepare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrePrepare.Merge(dst, src)
+}
+func (m *PrePrepare) XXX_Size() int {
+	return xxx_messageInfo_PrePrepare.Size(m)
+}
+func (m *PrePrepare) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrePrepare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrePrepare proto.InternalMessageInfo
+
+func (m *PrePrepare) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *PrePrepare) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *PrePrepare) GetBlock() *common.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *PrePrepare) GetPrepared() *PreparedCertificate {
+	if m != nil {
+		return m.Prepared
+	}
+	return nil
+}
+
+// Prepare is sent by every node which accepted a proposal.
+// The signature is over the view, sequence and digest.
+type Prepare struct {
+	View                 uint64   `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Seq                  uint64   `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Digest               []byte   `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+	Signature            []byte   `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	Sender               uint64   `protobuf:"varint,5,opt,name=sender,proto3" json:"sender,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Prepare) Reset()         { *m = Prepare{} }
+func (m *Prepare) String() string { return proto.CompactTextString(m) }
+func (*Prepare) ProtoMessage()    {}
+func (*Prepare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_7b4bf01001614af1, []int{2}
+}
+func (m *Prepare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Prepare.Unmarshal(m, b)
+}
+func (m *Prepare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Prepare.Marshal(b, m, deterministic)
+}
+func (dst *Prepare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Prepare.Merge(dst, src)
+}
+func (m *Prepare) XXX_Size() int {
+	return xxx_messageInfo_Prepare.Size(m)
+}
+func (m *Prepare) XXX_DiscardUnknown() {
+	xxx_messageInfo_Prepare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Prepare proto.InternalMessageInfo
+
+func (m *Prepare) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *Prepare) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *Prepare) GetDigest() []byte {
+	if m != nil {
+		return m.Digest
+	}
+	return nil
+}
+
+func (m *Prepare) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *Prepare) GetSender() uint64 {
+	if m != nil {
+		return m.Sender
+	}
+	return 0
+}
+
+// Commit is sent by every node which collected a quorum of prepares
+// for a proposal. The signature is the node's signature on the block.
+type Commit struct {
+	View                 uint64                    `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Seq                  uint64                    `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Digest               []byte                    `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+	Signature            *common.MetadataSignature `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *Commit) Reset()         { *m = Commit{} }
+func (m *Commit) String() string { return proto.CompactTextString(m) }
+func (*Commit) ProtoMessage()    {}
+func (*Commit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_7b4bf01001614af1, []int{3}
+}
+func (m *Commit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Commit.Unmarshal(m, b)
+}
+func (m *Commit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Commit.Marshal(b, m, deterministic)
+}
+func (dst *Commit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Commit.Merge(dst, src)
+}
+func (m *Commit) XXX_Size() int {
+	return xxx_messageInfo_Commit.Size(m)
+}
+func (m *Commit) XXX_DiscardUnknown() {
+	xxx_messageInfo_Commit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Commit proto.InternalMessageInfo
+
+func (m *Commit) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *Commit) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *Commit) GetDigest() []byte {
+	if m != nil {
+		return m.Digest
+	}
+	return nil
+}
+
+func (m *Commit) GetSignature() *common.MetadataSignature {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// PreparedCertificate proves that a quorum of nodes
+// prepared the given proposal.
+type PreparedCertificate struct {
+	View                 uint64        `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Block                *common.Block `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
+	Prepares             []*Prepare    `protobuf:"bytes,3,rep,name=prepares,proto3" json:"prepares,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *PreparedCertificate) Reset()         { *m = PreparedCertificate{} }
+func (m *PreparedCertificate) String() string { return proto.CompactTextString(m) }
+func (*PreparedCertificate) ProtoMessage()    {}
+func (*PreparedCertificate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_7b4bf01001614af1, []int{4}
+}
+func (m *PreparedCertificate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PreparedCertificate.Unmarshal(m, b)
+}
+func (m *PreparedCertificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PreparedCertificate.Marshal(b, m, deterministic)
+}
+func (dst *PreparedCertificate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PreparedCertificate.Merge(dst, src)
+}
+func (m *PreparedCertificate) XXX_Size() int {
+	return xxx_messageInfo_PreparedCertificate.Size(m)
+}
+func (m *PreparedCertificate) XXX_DiscardUnknown() {
+	xxx_messageInfo_PreparedCertificate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PreparedCertificate proto.InternalMessageInfo
+
+func (m *PreparedCertificate) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *PreparedCertificate) GetBlock() *common.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *PreparedCertificate) GetPrepares() []*Prepare {
+	if m != nil {
+		return m.Prepares
+	}
+	return nil
+}
+
+// ViewChange is sent by a node which suspects the leader of the current view.
+type ViewChange struct {
+	NextView uint64 `protobuf:"varint,1,opt,name=next_view,json=nextView,proto3" json:"next_view,omitempty"`
+	// prepared is the latest proposal the sender prepared but has not yet committed.
+	Prepared             *PreparedCertificate `protobuf:"bytes,2,opt,name=prepared,proto3" json:"prepared,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ViewChange) Reset()         { *m = ViewChange{} }
+func (m *ViewChange) String() string { return proto.CompactTextString(m) }
+func (*ViewChange) ProtoMessage()    {}
+func (*ViewChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_7b4bf01001614af1, []int{5}
+}
+func (m *ViewChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ViewChange.Unmarshal(m, b)
+}
+func (m *ViewChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ViewChange.Marshal(b, m, deterministic)
+}
+func (dst *ViewChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ViewChange.Merge(dst, src)
+}
+func (m *ViewChange) XXX_Size() int {
+	return xxx_messageInfo_ViewChange.Size(m)
+}
+func (m *ViewChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_ViewChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ViewChange proto.InternalMessageInfo
+
+func (m *ViewChange) GetNextView() uint64 {
+	if m != nil {
+		return m.NextView
+	}
+	return 0
+}
+
+func (m *ViewChange) GetPrepared() *PreparedCertificate {
+	if m != nil {
+		return m.Prepared
+	}
+	return nil
+}
+
+// BlockRequest asks a node for a committed block it has.
+type BlockRequest struct {
+	Seq                  uint64   `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockRequest) Reset()         { *m = BlockRequest{} }
+func (m *BlockRequest) String() string { return proto.CompactTextString(m) }
+func (*BlockRequest) ProtoMessage()    {}
+func (*BlockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_7b4bf01001614af1, []int{6}
+}
+func (m *BlockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockRequest.Unmarshal(m, b)
+}
+func (m *BlockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockRequest.Marshal(b, m, deterministic)
+}
+func (dst *BlockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockRequest.Merge(dst, src)
+}
+func (m *BlockRequest) XXX_Size() int {
+	return xxx_messageInfo_BlockRequest.Size(m)
+}
+func (m *BlockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockRequest proto.InternalMessageInfo
+
+func (m *BlockRequest) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+// BlockResponse carries a committed block along with its quorum of signatures.
+type BlockResponse struct {
+	Block                *common.Block `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *BlockResponse) Reset()         { *m = BlockResponse{} }
+func (m *BlockResponse) String() string { return proto.CompactTextString(m) }
+func (*BlockResponse) ProtoMessage()    {}
+func (*BlockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_7b4bf01001614af1, []int{7}
+}
+func (m *BlockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockResponse.Unmarshal(m, b)
+}
+func (m *BlockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockResponse.Marshal(b, m, deterministic)
+}
+func (dst *BlockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockResponse.Merge(dst, src)
+}
+func (m *BlockResponse) XXX_Size() int {
+	return xxx_messageInfo_BlockResponse.Size(m)
+}
+func (m *BlockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockResponse proto.InternalMessageInfo
+
+func (m *BlockResponse) GetBlock() *common.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Message)(nil), "bft.Message")
+	proto.RegisterType((*PrePrepare)(nil), "bft.PrePrepare")
+	proto.RegisterType((*Prepare)(nil), "bft.Prepare")
+	proto.RegisterType((*Commit)(nil), "bft.Commit")
+	proto.RegisterType((*PreparedCertificate)(nil), "bft.PreparedCertificate")
+	proto.RegisterType((*ViewChange)(nil), "bft.ViewChange")
+	proto.RegisterType((*BlockRequest)(nil), "bft.BlockRequest")
+	proto.RegisterType((*BlockResponse)(nil), "bft.BlockResponse")
+}
+
+func init() {
+	proto.RegisterFile("orderer/bft/messages.proto", fileDescriptor_messages_7b4bf01001614af1)
+}
+
+var fileDescriptor_messages_7b4bf01001614af1 = []byte{
+	// 525 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x5f, 0x8b, 0xd3, 0x4e,
+	0x14, 0xed, 0xbf, 0x6d, 0xb7, 0xb7, 0xe9, 0xef, 0xa7, 0xb3, 0x20, 0x71, 0xf5, 0xa1, 0x44, 0x84,
+	0xfa, 0x92, 0x48, 0x5d, 0x50, 0xf0, 0xad, 0x7d, 0xe9, 0xcb, 0xc2, 0x32, 0x82, 0x0f, 0x82, 0x84,
+	0x49, 0x72, 0x93, 0x06, 0xdb, 0x24, 0x3b, 0x33, 0xdd, 0x3f, 0x0f, 0xe2, 0x27, 0xf0, 0x5b, 0xf9,
+	0xc1, 0x64, 0x66, 0x92, 0x74, 0x76, 0x59, 0x45, 0xf1, 0x29, 0xb9, 0xf7, 0x9e, 0x93, 0x7b, 0x72,
+	0xce, 0x30, 0x70, 0x5a, 0xf2, 0x04, 0x39, 0xf2, 0x20, 0x4a, 0x65, 0xb0, 0x43, 0x21, 0x58, 0x86,
+	0xc2, 0xaf, 0x78, 0x29, 0x4b, 0xd2, 0x8f, 0x52, 0x79, 0x7a, 0x12, 0x97, 0xbb, 0x5d, 0x59, 0x04,
+	0xe6, 0x61, 0x26, 0xde, 0x8f, 0x1e, 0x8c, 0xce, 0x0d, 0x98, 0x2c, 0x60, 0x52, 0x71, 0x0c, 0x2b,
+	0x8e, 0x15, 0xe3, 0xe8, 0x76, 0x67, 0xdd, 0xf9, 0x64, 0xf1, 0xbf, 0x1f, 0xa5, 0xd2, 0xbf, 0xe0,
+	0x78, 0x61, 0xda, 0xeb, 0x0e, 0x85, 0xaa, 0xad, 0xc8, 0x1c, 0x46, 0x0d, 0xbe, 0xa7, 0xf1, 0x4e,
+	0x83, 0xaf, 0xc1, 0xcd, 0x98, 0xbc, 0x84, 0xa1, 0xda, 0x9c, 0x4b, 0xb7, 0xaf, 0x81, 0x13, 0x0d,
+	0x5c, 0xe9, 0xd6, 0xba, 0x43, 0xeb, 0xa1, 0x12, 0x71, 0x95, 0xe3, 0x75, 0x18, 0x6f, 0x58, 0x91,
+	0xa1, 0x3b, 0xb0, 0x44, 0x7c, 0xcc, 0xf1, 0x7a, 0xa5, 0xdb, 0x4a, 0xc4, 0x55, 0x5b, 0x91, 0x77,
+	0x30, 0x8d, 0xb6, 0x65, 0xfc, 0x25, 0xe4, 0x78, 0xb9, 0x47, 0x21, 0xdd, 0x23, 0xcd, 0x7a, 0xac,
+	0x59, 0x4b, 0x35, 0xa1, 0x66, 0xb0, 0xee, 0x50, 0x27, 0xb2, 0x6a, 0xf2, 0x1e, 0xfe, 0x6b, 0x98,
+	0xa2, 0x2a, 0x0b, 0x81, 0xee, 0x50, 0x53, 0x89, 0x4d, 0x35, 0x93, 0x75, 0x87, 0x4e, 0x23, 0xbb,
+	0xb1, 0x1c, 0xc3, 0xa8, 0x62, 0xb7, 0xdb, 0x92, 0x25, 0xde, 0xf7, 0x2e, 0xc0, 0xc1, 0x23, 0x42,
+	0x60, 0xa0, 0xe4, 0x69, 0x0b, 0x07, 0x54, 0xbf, 0x93, 0x47, 0xd0, 0x17, 0x78, 0xa9, 0x5d, 0x1a,
+	0x50, 0xf5, 0x4a, 0x5e, 0xc0, 0x91, 0xfe, 0x60, 0x6d, 0xc8, 0xd4, 0xaf, 0x93, 0x31, 0x6b, 0xcd,
+	0x8c, 0x9c, 0xc1, 0x71, 0xed, 0x60, 0x52, 0x9b, 0xe1, 0xda, 0x0e, 0x27, 0x2b, 0xe4, 0x32, 0x4f,
+	0xf3, 0x98, 0x49, 0xa4, 0x2d, 0xd2, 0xfb, 0x0a, 0xa3, 0xbf, 0xd3, 0xf2, 0x04, 0x86, 0x49, 0x9e,
+	0x29, 0xef, 0x94, 0x18, 0x87, 0xd6, 0x15, 0x79, 0x0e, 0x63, 0x91, 0x67, 0x05, 0x93, 0x7b, 0x6e,
+	0xc2, 0x70, 0xe8, 0xa1, 0xa1, 0x58, 0x02, 0x8b, 0x04, 0xb9, 0x76, 0x7c, 0x40, 0xeb, 0xca, 0xfb,
+	0x06, 0x43, 0x13, 0xec, 0x3f, 0x6e, 0x7f, 0x7b, 0x7f, 0xfb, 0x64, 0xf1, 0xb4, 0x71, 0xe9, 0x1c,
+	0x25, 0x4b, 0x98, 0x64, 0x1f, 0x1a, 0x80, 0x25, 0xcc, 0xbb, 0x81, 0x93, 0x07, 0x0c, 0x7a, 0x50,
+	0x4d, 0x9b, 0x42, 0xef, 0x37, 0x29, 0xcc, 0xdb, 0x14, 0x84, 0xdb, 0x9f, 0xf5, 0xef, 0x9f, 0xf3,
+	0xd6, 0x79, 0xe1, 0x85, 0x00, 0x87, 0x73, 0x4a, 0x9e, 0xc1, 0xb8, 0xc0, 0x1b, 0x19, 0x5a, 0x5b,
+	0x8f, 0x55, 0x43, 0x41, 0xee, 0x44, 0xdb, 0xfb, 0xe3, 0x68, 0x67, 0xe0, 0xd8, 0x47, 0xba, 0x71,
+	0xb3, 0xdb, 0xba, 0xe9, 0x9d, 0xc1, 0xf4, 0xce, 0xc9, 0x3d, 0xfc, 0x62, 0xf7, 0xd7, 0xbf, 0xb8,
+	0xfc, 0x0c, 0xaf, 0x4a, 0x9e, 0xf9, 0x9b, 0xdb, 0x0a, 0xf9, 0x16, 0x93, 0x0c, 0xb9, 0x9f, 0xb2,
+	0x88, 0xe7, 0xb1, 0xb9, 0x29, 0x84, 0x5f, 0xdf, 0x2f, 0x4a, 0xe2, 0xa7, 0xd7, 0x59, 0x2e, 0x37,
+	0xfb, 0x48, 0x7d, 0x28, 0xb0, 0x18, 0x81, 0x61, 0x04, 0x86, 0x11, 0x58, 0x37, 0x52, 0x34, 0xd4,
+	0xbd, 0x37, 0x3f, 0x07, 0x00, 0x68, 0x18, 0xcf, 0xb8, 0xa7, 0x04, 0x00, 0x00,
+}
@@ -32,7 +32,7 @@ func (m *ConfigMetadata) Reset()         { *m = ConfigMetadata{} }
 func (m *ConfigMetadata) String() string { return proto.CompactTextString(m) }
 func (*ConfigMetadata) ProtoMessage()    {}
 func (*ConfigMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_780531726dd41db7, []int{0}
+	return fileDescriptor_configuration_859130a8f79038fe, []int{0}
 }
 func (m *ConfigMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigMetadata.Unmarshal(m, b)
@@ -68,10 +68,14 @@ func (m *ConfigMetadata) GetOptions() *Options {
 
 // Consenter represents a consenting node (i.e. replica).
 type Consenter struct {
-	Host                 string   `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
-	Port                 uint32   `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
-	ClientTlsCert        []byte   `protobuf:"bytes,3,opt,name=client_tls_cert,json=clientTlsCert,proto3" json:"client_tls_cert,omitempty"`
-	ServerTlsCert        []byte   `protobuf:"bytes,4,opt,name=server_tls_cert,json=serverTlsCert,proto3" json:"server_tls_cert,omitempty"`
+	Host          string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Port          uint32 `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	ClientTlsCert []byte `protobuf:"bytes,3,opt,name=client_tls_cert,json=clientTlsCert,proto3" json:"client_tls_cert,omitempty"`
+	ServerTlsCert []byte `protobuf:"bytes,4,opt,name=server_tls_cert,json=serverTlsCert,proto3" json:"server_tls_cert,omitempty"`
+	// A learner replicates the blocks of the channel but does not vote in the
+	// elections nor count towards the quorum. It is promoted to a voting
+	// consenter by unsetting this field.
+	Learner              bool     `protobuf:"varint,5,opt,name=learner,proto3" json:"learner,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -81,7 +85,7 @@ func (m *Consenter) Reset()         { *m = Consenter{} }
 func (m *Consenter) String() string { return proto.CompactTextString(m) }
 func (*Consenter) ProtoMessage()    {}
 func (*Consenter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_780531726dd41db7, []int{1}
+	return fileDescriptor_configuration_859130a8f79038fe, []int{1}
 }
 func (m *Consenter) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Consenter.Unmarshal(m, b)
@@ -129,6 +133,13 @@ func (m *Consenter) GetServerTlsCert() []byte {
 	return nil
 }
 
+func (m *Consenter) GetLearner() bool {
+	if m != nil {
+		return m.Learner
+	}
+	return false
+}
+
 // Options to be specified for all the etcd/raft nodes. These can be modified on a
 // per-channel basis.
 type Options struct {
@@ -147,7 +158,7 @@ func (m *Options) Reset()         { *m = Options{} }
 func (m *Options) String() string { return proto.CompactTextString(m) }
 func (*Options) ProtoMessage()    {}
 func (*Options) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_780531726dd41db7, []int{2}
+	return fileDescriptor_configuration_859130a8f79038fe, []int{2}
 }
 func (m *Options) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Options.Unmarshal(m, b)
@@ -223,7 +234,7 @@ func (m *BlockMetadata) Reset()         { *m = BlockMetadata{} }
 func (m *BlockMetadata) String() string { return proto.CompactTextString(m) }
 func (*BlockMetadata) ProtoMessage()    {}
 func (*BlockMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_780531726dd41db7, []int{3}
+	return fileDescriptor_configuration_859130a8f79038fe, []int{3}
 }
 func (m *BlockMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockMetadata.Unmarshal(m, b)
@@ -272,37 +283,38 @@ func init() {
 }
 
 func init() {
-	proto.RegisterFile("orderer/etcdraft/configuration.proto", fileDescriptor_configuration_780531726dd41db7)
-}
-
-var fileDescriptor_configuration_780531726dd41db7 = []byte{
-	// 448 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x92, 0xc1, 0x6f, 0xd3, 0x30,
-	0x14, 0xc6, 0x15, 0x56, 0x18, 0x7d, 0x6b, 0x36, 0xd5, 0x43, 0x28, 0x17, 0xa4, 0xaa, 0x03, 0x54,
-	0x81, 0x94, 0x48, 0x1b, 0xfc, 0x03, 0xeb, 0xa9, 0x07, 0x84, 0x14, 0x76, 0xe2, 0x62, 0x39, 0xce,
-	0x6b, 0x62, 0x35, 0x8d, 0x23, 0xfb, 0x6d, 0x2a, 0xbb, 0x70, 0xe0, 0x1f, 0xe5, 0x4f, 0x41, 0xb6,
-	0x93, 0xb4, 0xe2, 0x66, 0x7d, 0xdf, 0xef, 0xb3, 0x3f, 0xeb, 0x3d, 0x78, 0xaf, 0x4d, 0x89, 0x06,
-	0x4d, 0x86, 0x24, 0x4b, 0x23, 0xb6, 0x94, 0x49, 0xdd, 0x6e, 0x55, 0xf5, 0x68, 0x04, 0x29, 0xdd,
-	0xa6, 0x9d, 0xd1, 0xa4, 0xd9, 0xeb, 0xc1, 0x5d, 0x1a, 0xb8, 0x5c, 0x7b, 0xe0, 0x1b, 0x92, 0x28,
-	0x05, 0x09, 0x76, 0x07, 0x20, 0x75, 0x6b, 0xb1, 0x25, 0x34, 0x36, 0x89, 0x16, 0x67, 0xab, 0x8b,
-	0xdb, 0xeb, 0x74, 0x08, 0xa4, 0xeb, 0xc1, 0xcb, 0x4f, 0x30, 0xf6, 0x19, 0xce, 0x75, 0xe7, 0x1e,
-	0xb0, 0xc9, 0x8b, 0x45, 0xb4, 0xba, 0xb8, 0x9d, 0x1f, 0x13, 0xdf, 0x83, 0x91, 0x0f, 0xc4, 0xf2,
-	0x4f, 0x04, 0xd3, 0xf1, 0x1a, 0xc6, 0x60, 0x52, 0x6b, 0x4b, 0x49, 0xb4, 0x88, 0x56, 0xd3, 0xdc,
-	0x9f, 0x9d, 0xd6, 0x69, 0x43, 0xfe, 0xae, 0x38, 0xf7, 0x67, 0xf6, 0x11, 0xae, 0x64, 0xa3, 0xb0,
-	0x25, 0x4e, 0x8d, 0xe5, 0x12, 0x0d, 0x25, 0x67, 0x8b, 0x68, 0x35, 0xcb, 0xe3, 0x20, 0x3f, 0x34,
-	0x76, 0x8d, 0x81, 0xb3, 0x68, 0x9e, 0xd0, 0x1c, 0xb9, 0x49, 0xe0, 0x82, 0xdc, 0x73, 0xcb, 0xbf,
-	0x11, 0x9c, 0xf7, 0xd5, 0xd8, 0x0d, 0xc4, 0xa4, 0xe4, 0x8e, 0x2b, 0xd7, 0xe8, 0x49, 0x34, 0x7d,
-	0x99, 0x99, 0x13, 0x37, 0xbd, 0xe6, 0x20, 0x6c, 0x50, 0xba, 0x04, 0x77, 0x46, 0xdf, 0x6e, 0x36,
-	0x88, 0x0f, 0x4a, 0xee, 0xd8, 0x07, 0xb8, 0xac, 0x51, 0x18, 0x2a, 0x50, 0x50, 0xa0, 0xce, 0x3c,
-	0x15, 0x8f, 0xaa, 0xc7, 0x52, 0xb8, 0xde, 0x8b, 0x03, 0x57, 0xed, 0xb6, 0x51, 0x55, 0x4d, 0xbc,
-	0x68, 0xb4, 0xdc, 0x59, 0x5f, 0x34, 0xce, 0xe7, 0x7b, 0x71, 0xd8, 0xf4, 0xce, 0xbd, 0x37, 0xd8,
-	0x17, 0x78, 0x6b, 0x5b, 0xd1, 0xd9, 0x5a, 0xd3, 0x58, 0x92, 0x5b, 0xf5, 0x8c, 0xc9, 0x4b, 0x1f,
-	0x79, 0x33, 0xb8, 0x43, 0xdb, 0x1f, 0xea, 0x19, 0x97, 0xbf, 0x21, 0xf6, 0xf9, 0x71, 0xb6, 0x37,
-	0x10, 0x8f, 0x43, 0xe3, 0xaa, 0x0c, 0xe3, 0x9d, 0xe4, 0xb3, 0x51, 0xdc, 0x94, 0x96, 0x7d, 0x82,
-	0x79, 0x8b, 0x07, 0xe2, 0xa7, 0xa4, 0xff, 0xeb, 0x24, 0xbf, 0x72, 0xc6, 0xfa, 0x08, 0xb3, 0x77,
-	0x00, 0x6e, 0xc6, 0x5c, 0xb5, 0x25, 0x1e, 0xfc, 0x57, 0x27, 0xf9, 0xd4, 0x29, 0x1b, 0x27, 0xdc,
-	0x57, 0x90, 0x6a, 0x53, 0xa5, 0xf5, 0xaf, 0x0e, 0x4d, 0x83, 0x65, 0x85, 0x26, 0xdd, 0x8a, 0xc2,
-	0x28, 0x19, 0xf6, 0xd0, 0xa6, 0xfd, 0xb6, 0x8e, 0xcb, 0xf2, 0xf3, 0x6b, 0xa5, 0xa8, 0x7e, 0x2c,
-	0x52, 0xa9, 0xf7, 0xd9, 0x49, 0x2c, 0x0b, 0xb1, 0x2c, 0xc4, 0xb2, 0xff, 0x97, 0xbc, 0x78, 0xe5,
-	0x8d, 0xbb, 0x7f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x7e, 0x1e, 0x2b, 0x0a, 0xff, 0x02, 0x00, 0x00,
+	proto.RegisterFile("orderer/etcdraft/configuration.proto", fileDescriptor_configuration_859130a8f79038fe)
+}
+
+var fileDescriptor_configuration_859130a8f79038fe = []byte{
+	// 461 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x92, 0xc1, 0x8a, 0xdb, 0x3c,
+	0x14, 0x85, 0xf1, 0x9f, 0xfc, 0xcd, 0xe4, 0x4e, 0x3c, 0x43, 0x34, 0xa5, 0x78, 0x53, 0x08, 0x99,
+	0xb6, 0x84, 0x16, 0x6c, 0x98, 0x69, 0x5f, 0x60, 0xb2, 0xca, 0xa2, 0x14, 0xd4, 0x59, 0x75, 0x23,
+	0x14, 0xf9, 0xc6, 0x16, 0x71, 0x24, 0x23, 0x69, 0x86, 0x74, 0x36, 0x7d, 0x92, 0xbe, 0x5b, 0x1f,
+	0xa5, 0x48, 0xb2, 0x9d, 0xd0, 0x9d, 0x73, 0xce, 0x77, 0x94, 0x73, 0xb9, 0x17, 0xde, 0x69, 0x53,
+	0xa2, 0x41, 0x53, 0xa0, 0x13, 0xa5, 0xe1, 0x3b, 0x57, 0x08, 0xad, 0x76, 0xb2, 0x7a, 0x32, 0xdc,
+	0x49, 0xad, 0xf2, 0xd6, 0x68, 0xa7, 0xc9, 0x45, 0xef, 0x2e, 0x0d, 0x5c, 0xad, 0x03, 0xf0, 0x15,
+	0x1d, 0x2f, 0xb9, 0xe3, 0xe4, 0x1e, 0x40, 0x68, 0x65, 0x51, 0x39, 0x34, 0x36, 0x4b, 0x16, 0xa3,
+	0xd5, 0xe5, 0xdd, 0x4d, 0xde, 0x07, 0xf2, 0x75, 0xef, 0xd1, 0x33, 0x8c, 0x7c, 0x82, 0x89, 0x6e,
+	0xfd, 0x1f, 0xd8, 0xec, 0xbf, 0x45, 0xb2, 0xba, 0xbc, 0x9b, 0x9f, 0x12, 0xdf, 0xa2, 0x41, 0x7b,
+	0x62, 0xf9, 0x3b, 0x81, 0xe9, 0xf0, 0x0c, 0x21, 0x30, 0xae, 0xb5, 0x75, 0x59, 0xb2, 0x48, 0x56,
+	0x53, 0x1a, 0xbe, 0xbd, 0xd6, 0x6a, 0xe3, 0xc2, 0x5b, 0x29, 0x0d, 0xdf, 0xe4, 0x03, 0x5c, 0x8b,
+	0x46, 0xa2, 0x72, 0xcc, 0x35, 0x96, 0x09, 0x34, 0x2e, 0x1b, 0x2d, 0x92, 0xd5, 0x8c, 0xa6, 0x51,
+	0x7e, 0x6c, 0xec, 0x1a, 0x23, 0x67, 0xd1, 0x3c, 0xa3, 0x39, 0x71, 0xe3, 0xc8, 0x45, 0xb9, 0xe7,
+	0x32, 0x98, 0x34, 0xc8, 0x8d, 0x42, 0x93, 0xfd, 0xbf, 0x48, 0x56, 0x17, 0xb4, 0xff, 0xb9, 0xfc,
+	0x93, 0xc0, 0xa4, 0x2b, 0x4d, 0x6e, 0x21, 0x75, 0x52, 0xec, 0x99, 0xf4, 0x5d, 0x9f, 0x79, 0xd3,
+	0xd5, 0x9c, 0x79, 0x71, 0xd3, 0x69, 0x1e, 0xc2, 0x06, 0x85, 0x4f, 0x30, 0x6f, 0x74, 0xbd, 0x67,
+	0xbd, 0xf8, 0x28, 0xc5, 0x9e, 0xbc, 0x87, 0xab, 0x1a, 0xb9, 0x71, 0x5b, 0xe4, 0x2e, 0x52, 0xa3,
+	0x40, 0xa5, 0x83, 0x1a, 0xb0, 0x1c, 0x6e, 0x0e, 0xfc, 0xc8, 0xa4, 0xda, 0x35, 0xb2, 0xaa, 0x1d,
+	0xdb, 0x36, 0x5a, 0xec, 0x6d, 0x18, 0x21, 0xa5, 0xf3, 0x03, 0x3f, 0x6e, 0x3a, 0xe7, 0x21, 0x18,
+	0xe4, 0x33, 0xbc, 0xb1, 0x8a, 0xb7, 0xb6, 0xd6, 0x6e, 0x28, 0xc9, 0xac, 0x7c, 0xc1, 0x30, 0x55,
+	0x4a, 0x5f, 0xf7, 0x6e, 0xdf, 0xf6, 0xbb, 0x7c, 0xc1, 0xe5, 0x2f, 0x48, 0x43, 0x7e, 0xd8, 0xfa,
+	0x2d, 0xa4, 0xc3, 0x3a, 0x99, 0x2c, 0xe3, 0xe2, 0xc7, 0x74, 0x36, 0x88, 0x9b, 0xd2, 0x92, 0x8f,
+	0x30, 0x57, 0x78, 0x74, 0xec, 0x9c, 0x0c, 0xb3, 0x8e, 0xe9, 0xb5, 0x37, 0xd6, 0x27, 0x98, 0xbc,
+	0x05, 0xf0, 0xdb, 0x67, 0x52, 0x95, 0x78, 0x0c, 0xa3, 0x8e, 0xe9, 0xd4, 0x2b, 0x1b, 0x2f, 0x3c,
+	0x54, 0x90, 0x6b, 0x53, 0xe5, 0xf5, 0xcf, 0x16, 0x4d, 0x83, 0x65, 0x85, 0x26, 0xdf, 0xf1, 0xad,
+	0x91, 0x22, 0x5e, 0xa8, 0xcd, 0xbb, 0x3b, 0x1e, 0xce, 0xe8, 0xc7, 0x97, 0x4a, 0xba, 0xfa, 0x69,
+	0x9b, 0x0b, 0x7d, 0x28, 0xce, 0x62, 0x45, 0x8c, 0x15, 0x31, 0x56, 0xfc, 0x7b, 0xfe, 0xdb, 0x57,
+	0xc1, 0xb8, 0xff, 0x3b, 0x00, 0xba, 0xda, 0xce, 0xce, 0x19, 0x03, 0x00, 0x00,
 }
@@ -104,7 +104,7 @@ func (x ChaincodeMessage_Type) String() string {
 	return proto.EnumName(ChaincodeMessage_Type_name, int32(x))
 }
 func (ChaincodeMessage_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{0, 0}
+	return fileDescriptor_chaincode_shim_3c7e73a209e14e7e, []int{0, 0}
 }
 
 type ChaincodeMessage struct {
@@ -128,7 +128,7 @@ func (m *ChaincodeMessage) Reset()         { *m = ChaincodeMessage{} }
 func (m *ChaincodeMessage) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeMessage) ProtoMessage()    {}
 func (*ChaincodeMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{0}
+	return fileDescriptor_chaincode_shim_3c7e73a209e14e7e, []int{0}
 }
 func (m *ChaincodeMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeMessage.Unmarshal(m, b)
@@ -212,7 +212,7 @@ func (m *GetState) Reset()         { *m = GetState{} }
 func (m *GetState) String() string { return proto.CompactTextString(m) }
 func (*GetState) ProtoMessage()    {}
 func (*GetState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{1}
+	return fileDescriptor_chaincode_shim_3c7e73a209e14e7e, []int{1}
 }
 func (m *GetState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetState.Unmarshal(m, b)
@@ -258,7 +258,7 @@ func (m *GetStateMetadata) Reset()         { *m = GetStateMetadata{} }
 func (m *GetStateMetadata) String() string { return proto.CompactTextString(m) }
 func (*GetStateMetadata) ProtoMessage()    {}
 func (*GetStateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{2}
+	return fileDescriptor_chaincode_shim_3c7e73a209e14e7e, []int{2}
 }
 func (m *GetStateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateMetadata.Unmarshal(m, b)
@@ -309,7 +309,7 @@ func (m *PutState) Reset()         { *m = PutState{} }
 func (m *PutState) String() string { return proto.CompactTextString(m) }
 func (*PutState) ProtoMessage()    {}
 func (*PutState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{3}
+	return fileDescriptor_chaincode_shim_3c7e73a209e14e7e, []int{3}
 }
 func (m *PutState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutState.Unmarshal(m, b)
@@ -363,7 +363,7 @@ func (m *PutStateMetadata) Reset()         { *m = PutStateMetadata{} }
 func (m *PutStateMetadata) String() string { return proto.CompactTextString(m) }
 func (*PutStateMetadata) ProtoMessage()    {}
 func (*PutStateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{4}
+	return fileDescriptor_chaincode_shim_3c7e73a209e14e7e, []int{4}
 }
 func (m *PutStateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutStateMetadata.Unmarshal(m, b)
@@ -420,7 +420,7 @@ func (m *DelState) Reset()         { *m = DelState{} }
 func (m *DelState) String() string { return proto.CompactTextString(m) }
 func (*DelState) ProtoMessage()    {}
 func (*DelState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{5}
+	return fileDescriptor_chaincode_shim_3c7e73a209e14e7e, []int{5}
 }
 func (m *DelState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DelState.Unmarshal(m, b)
@@ -472,7 +472,7 @@ func (m *GetStateByRange) Reset()         { *m = GetStateByRange{} }
 func (m *GetStateByRange) String() string { return proto.CompactTextString(m) }
 func (*GetStateByRange) ProtoMessage()    {}
 func (*GetStateByRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{6}
+	return fileDescriptor_chaincode_shim_3c7e73a209e14e7e, []int{6}
 }
 func (m *GetStateByRange) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateByRange.Unmarshal(m, b)
@@ -537,7 +537,7 @@ func (m *GetQueryResult) Reset()         { *m = GetQueryResult{} }
 func (m *GetQueryResult) String() string { return proto.CompactTextString(m) }
 func (*GetQueryResult) ProtoMessage()    {}
 func (*GetQueryResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{7}
+	return fileDescriptor_chaincode_shim_3c7e73a209e14e7e, []int{7}
 }
 func (m *GetQueryResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetQueryResult.Unmarshal(m, b)
@@ -578,7 +578,7 @@ func (m *GetQueryResult) GetMetadata() []byte {
 	return nil
 }
 
-// QueryMetadata is the metadata of a GetStateByRange and GetQueryResult.
+// QueryMetadata is the metadata of a GetStateByRange, GetQueryResult and GetHistoryForKey.
 // It contains a pageSize which denotes the number of records to be fetched
 // and a bookmark.
 type QueryMetadata struct {
@@ -593,7 +593,7 @@ func (m *QueryMetadata) Reset()         { *m = QueryMetadata{} }
 func (m *QueryMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryMetadata) ProtoMessage()    {}
 func (*QueryMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{8}
+	return fileDescriptor_chaincode_shim_3c7e73a209e14e7e, []int{8}
 }
 func (m *QueryMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryMetadata.Unmarshal(m, b)
@@ -628,9 +628,17 @@ func (m *QueryMetadata) GetBookmark() string {
 }
 
 // GetHistoryForKey is the payload of a ChaincodeMessage. It contains a key
-// for which the historical values need to be retrieved.
+// for which the historical values need to be retrieved. The history can be
+// restricted to the modifications committed between startBlock and endBlock
+// (both inclusive, an endBlock of 0 meaning no upper bound) and returned
+// most recent first when reverse is set. The metadata hold the byte
+// representation of QueryMetadata.
 type GetHistoryForKey struct {
 	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	StartBlock           uint64   `protobuf:"varint,2,opt,name=startBlock,proto3" json:"startBlock,omitempty"`
+	EndBlock             uint64   `protobuf:"varint,3,opt,name=endBlock,proto3" json:"endBlock,omitempty"`
+	Reverse              bool     `protobuf:"varint,4,opt,name=reverse,proto3" json:"reverse,omitempty"`
+	Metadata             []byte   `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -640,7 +648,7 @@ func (m *GetHistoryForKey) Reset()         { *m = GetHistoryForKey{} }
 func (m *GetHistoryForKey) String() string { return proto.CompactTextString(m) }
 func (*GetHistoryForKey) ProtoMessage()    {}
 func (*GetHistoryForKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{9}
+	return fileDescriptor_chaincode_shim_3c7e73a209e14e7e, []int{9}
 }
 func (m *GetHistoryForKey) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetHistoryForKey.Unmarshal(m, b)
@@ -667,6 +675,34 @@ func (m *GetHistoryForKey) GetKey() string {
 	return ""
 }
 
+func (m *GetHistoryForKey) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *GetHistoryForKey) GetEndBlock() uint64 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+func (m *GetHistoryForKey) GetReverse() bool {
+	if m != nil {
+		return m.Reverse
+	}
+	return false
+}
+
+func (m *GetHistoryForKey) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 type QueryStateNext struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -678,7 +714,7 @@ func (m *QueryStateNext) Reset()         { *m = QueryStateNext{} }
 func (m *QueryStateNext) String() string { return proto.CompactTextString(m) }
 func (*QueryStateNext) ProtoMessage()    {}
 func (*QueryStateNext) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{10}
+	return fileDescriptor_chaincode_shim_3c7e73a209e14e7e, []int{10}
 }
 func (m *QueryStateNext) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateNext.Unmarshal(m, b)
@@ -716,7 +752,7 @@ func (m *QueryStateClose) Reset()         { *m = QueryStateClose{} }
 func (m *QueryStateClose) String() string { return proto.CompactTextString(m) }
 func (*QueryStateClose) ProtoMessage()    {}
 func (*QueryStateClose) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{11}
+	return fileDescriptor_chaincode_shim_3c7e73a209e14e7e, []int{11}
 }
 func (m *QueryStateClose) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateClose.Unmarshal(m, b)
@@ -755,7 +791,7 @@ func (m *QueryResultBytes) Reset()         { *m = QueryResultBytes{} }
 func (m *QueryResultBytes) String() string { return proto.CompactTextString(m) }
 func (*QueryResultBytes) ProtoMessage()    {}
 func (*QueryResultBytes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{12}
+	return fileDescriptor_chaincode_shim_3c7e73a209e14e7e, []int{12}
 }
 func (m *QueryResultBytes) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResultBytes.Unmarshal(m, b)
@@ -801,7 +837,7 @@ func (m *QueryResponse) Reset()         { *m = QueryResponse{} }
 func (m *QueryResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryResponse) ProtoMessage()    {}
 func (*QueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{13}
+	return fileDescriptor_chaincode_shim_3c7e73a209e14e7e, []int{13}
 }
 func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponse.Unmarshal(m, b)
@@ -863,7 +899,7 @@ func (m *QueryResponseMetadata) Reset()         { *m = QueryResponseMetadata{} }
 func (m *QueryResponseMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryResponseMetadata) ProtoMessage()    {}
 func (*QueryResponseMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{14}
+	return fileDescriptor_chaincode_shim_3c7e73a209e14e7e, []int{14}
 }
 func (m *QueryResponseMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponseMetadata.Unmarshal(m, b)
@@ -909,7 +945,7 @@ func (m *StateMetadata) Reset()         { *m = StateMetadata{} }
 func (m *StateMetadata) String() string { return proto.CompactTextString(m) }
 func (*StateMetadata) ProtoMessage()    {}
 func (*StateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{15}
+	return fileDescriptor_chaincode_shim_3c7e73a209e14e7e, []int{15}
 }
 func (m *StateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadata.Unmarshal(m, b)
@@ -954,7 +990,7 @@ func (m *StateMetadataResult) Reset()         { *m = StateMetadataResult{} }
 func (m *StateMetadataResult) String() string { return proto.CompactTextString(m) }
 func (*StateMetadataResult) ProtoMessage()    {}
 func (*StateMetadataResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{16}
+	return fileDescriptor_chaincode_shim_3c7e73a209e14e7e, []int{16}
 }
 func (m *StateMetadataResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadataResult.Unmarshal(m, b)
@@ -1106,75 +1142,174 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 	Metadata: "peer/chaincode_shim.proto",
 }
 
+// ChaincodeClient is the client API for Chaincode service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ChaincodeClient interface {
+	Connect(ctx context.Context, opts ...grpc.CallOption) (Chaincode_ConnectClient, error)
+}
+
+type chaincodeClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChaincodeClient(cc *grpc.ClientConn) ChaincodeClient {
+	return &chaincodeClient{cc}
+}
+
+func (c *chaincodeClient) Connect(ctx context.Context, opts ...grpc.CallOption) (Chaincode_ConnectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Chaincode_serviceDesc.Streams[0], "/protos.Chaincode/Connect", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &chaincodeConnectClient{stream}
+	return x, nil
+}
+
+type Chaincode_ConnectClient interface {
+	Send(*ChaincodeMessage) error
+	Recv() (*ChaincodeMessage, error)
+	grpc.ClientStream
+}
+
+type chaincodeConnectClient struct {
+	grpc.ClientStream
+}
+
+func (x *chaincodeConnectClient) Send(m *ChaincodeMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *chaincodeConnectClient) Recv() (*ChaincodeMessage, error) {
+	m := new(ChaincodeMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ChaincodeServer is the server API for Chaincode service.
+type ChaincodeServer interface {
+	Connect(Chaincode_ConnectServer) error
+}
+
+func RegisterChaincodeServer(s *grpc.Server, srv ChaincodeServer) {
+	s.RegisterService(&_Chaincode_serviceDesc, srv)
+}
+
+func _Chaincode_Connect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ChaincodeServer).Connect(&chaincodeConnectServer{stream})
+}
+
+type Chaincode_ConnectServer interface {
+	Send(*ChaincodeMessage) error
+	Recv() (*ChaincodeMessage, error)
+	grpc.ServerStream
+}
+
+type chaincodeConnectServer struct {
+	grpc.ServerStream
+}
+
+func (x *chaincodeConnectServer) Send(m *ChaincodeMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *chaincodeConnectServer) Recv() (*ChaincodeMessage, error) {
+	m := new(ChaincodeMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Chaincode_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protos.Chaincode",
+	HandlerType: (*ChaincodeServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Connect",
+			Handler:       _Chaincode_Connect_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "peer/chaincode_shim.proto",
+}
+
 func init() {
-	proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_chaincode_shim_b04d3028f86b65a2)
-}
-
-var fileDescriptor_chaincode_shim_b04d3028f86b65a2 = []byte{
-	// 1027 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcf, 0x73, 0xda, 0x46,
-	0x14, 0x0e, 0x06, 0x8c, 0x78, 0xd8, 0x78, 0xb3, 0x0e, 0x2e, 0x66, 0x26, 0x2d, 0x65, 0x7a, 0xa0,
-	0x17, 0x68, 0x68, 0x0f, 0x3d, 0x74, 0x26, 0x83, 0x61, 0x8d, 0x19, 0xdb, 0x40, 0x56, 0xb2, 0x27,
-	0xee, 0x45, 0x23, 0xa4, 0xb5, 0xd0, 0x58, 0x68, 0x55, 0x69, 0x49, 0x43, 0x6f, 0xbd, 0xf6, 0xd8,
-	0x3f, 0xae, 0x7f, 0x4f, 0x67, 0xf5, 0xcb, 0x80, 0xeb, 0xa4, 0x93, 0x13, 0xfa, 0xde, 0xfb, 0xf6,
-	0x7b, 0xbf, 0xf6, 0x21, 0xc1, 0xa9, 0xcf, 0x58, 0xd0, 0x35, 0x17, 0x86, 0xe3, 0x99, 0xdc, 0x62,
-	0x7a, 0xb8, 0x70, 0x96, 0x1d, 0x3f, 0xe0, 0x82, 0xe3, 0xfd, 0xe8, 0x27, 0x6c, 0x34, 0x76, 0x28,
-	0xec, 0x03, 0xf3, 0x44, 0xcc, 0x69, 0x1c, 0x47, 0x3e, 0x3f, 0xe0, 0x3e, 0x0f, 0x0d, 0x37, 0x31,
-	0x7e, 0x63, 0x73, 0x6e, 0xbb, 0xac, 0x1b, 0xa1, 0xf9, 0xea, 0xbe, 0x2b, 0x9c, 0x25, 0x0b, 0x85,
-	0xb1, 0xf4, 0x63, 0x42, 0xeb, 0x9f, 0x22, 0xa0, 0x41, 0xaa, 0x77, 0xcd, 0xc2, 0xd0, 0xb0, 0x19,
-	0x7e, 0x03, 0x05, 0xb1, 0xf6, 0x59, 0x3d, 0xd7, 0xcc, 0xb5, 0xab, 0xbd, 0xd7, 0x31, 0x35, 0xec,
-	0xec, 0xf2, 0x3a, 0xda, 0xda, 0x67, 0x34, 0xa2, 0xe2, 0x9f, 0xa1, 0x9c, 0x49, 0xd7, 0xf7, 0x9a,
-	0xb9, 0x76, 0xa5, 0xd7, 0xe8, 0xc4, 0xc1, 0x3b, 0x69, 0xf0, 0x8e, 0x96, 0x32, 0xe8, 0x23, 0x19,
-	0xd7, 0xa1, 0xe4, 0x1b, 0x6b, 0x97, 0x1b, 0x56, 0x3d, 0xdf, 0xcc, 0xb5, 0x0f, 0x68, 0x0a, 0x31,
-	0x86, 0x82, 0xf8, 0xe8, 0x58, 0xf5, 0x42, 0x33, 0xd7, 0x2e, 0xd3, 0xe8, 0x19, 0xf7, 0x40, 0x49,
-	0x4b, 0xac, 0x17, 0xa3, 0x30, 0x27, 0x69, 0x7a, 0xaa, 0x63, 0x7b, 0xcc, 0x9a, 0x25, 0x5e, 0x9a,
-	0xf1, 0xf0, 0x5b, 0x38, 0xda, 0x69, 0x59, 0x7d, 0x7f, 0xfb, 0x68, 0x56, 0x19, 0x91, 0x5e, 0x5a,
-	0x35, 0xb7, 0x30, 0x7e, 0x0d, 0x60, 0x2e, 0x0c, 0xcf, 0x63, 0xae, 0xee, 0x58, 0xf5, 0x52, 0x94,
-	0x4e, 0x39, 0xb1, 0x8c, 0xad, 0xd6, 0xdf, 0x79, 0x28, 0xc8, 0x56, 0xe0, 0x43, 0x28, 0xdf, 0x4c,
-	0x86, 0xe4, 0x7c, 0x3c, 0x21, 0x43, 0xf4, 0x02, 0x1f, 0x80, 0x42, 0xc9, 0x68, 0xac, 0x6a, 0x84,
-	0xa2, 0x1c, 0xae, 0x02, 0xa4, 0x88, 0x0c, 0xd1, 0x1e, 0x56, 0xa0, 0x30, 0x9e, 0x8c, 0x35, 0x94,
-	0xc7, 0x65, 0x28, 0x52, 0xd2, 0x1f, 0xde, 0xa1, 0x02, 0x3e, 0x82, 0x8a, 0x46, 0xfb, 0x13, 0xb5,
-	0x3f, 0xd0, 0xc6, 0xd3, 0x09, 0x2a, 0x4a, 0xc9, 0xc1, 0xf4, 0x7a, 0x76, 0x45, 0x34, 0x32, 0x44,
-	0xfb, 0x92, 0x4a, 0x28, 0x9d, 0x52, 0x54, 0x92, 0x9e, 0x11, 0xd1, 0x74, 0x55, 0xeb, 0x6b, 0x04,
-	0x29, 0x12, 0xce, 0x6e, 0x52, 0x58, 0x96, 0x70, 0x48, 0xae, 0x12, 0x08, 0xf8, 0x15, 0xa0, 0xf1,
-	0xe4, 0x76, 0x7a, 0x49, 0xf4, 0xc1, 0x45, 0x7f, 0x3c, 0x19, 0x4c, 0x87, 0x04, 0x55, 0xe2, 0x04,
-	0xd5, 0xd9, 0x74, 0xa2, 0x12, 0x74, 0x88, 0x4f, 0x00, 0x67, 0x82, 0xfa, 0xd9, 0x9d, 0x4e, 0xfb,
-	0x93, 0x11, 0x41, 0x55, 0x79, 0x56, 0xda, 0xdf, 0xdd, 0x10, 0x7a, 0xa7, 0x53, 0xa2, 0xde, 0x5c,
-	0x69, 0xe8, 0x48, 0x5a, 0x63, 0x4b, 0xcc, 0x9f, 0x90, 0xf7, 0x1a, 0x42, 0xb8, 0x06, 0x2f, 0x37,
-	0xad, 0x83, 0xab, 0xa9, 0x4a, 0xd0, 0x4b, 0x99, 0xcd, 0x25, 0x21, 0xb3, 0xfe, 0xd5, 0xf8, 0x96,
-	0x20, 0x8c, 0xbf, 0x82, 0x63, 0xa9, 0x78, 0x31, 0x56, 0xb5, 0x29, 0xbd, 0xd3, 0xcf, 0xa7, 0x54,
-	0xbf, 0x24, 0x77, 0xe8, 0x78, 0x3b, 0x85, 0x6b, 0xa2, 0xf5, 0x87, 0x7d, 0xad, 0x8f, 0x5e, 0x49,
-	0x7b, 0x56, 0xdc, 0xa3, 0xbd, 0x86, 0x4f, 0xa1, 0x26, 0xf9, 0x33, 0x3a, 0xbe, 0x95, 0x1e, 0x69,
-	0xd5, 0x2f, 0xfa, 0xea, 0x05, 0x3a, 0x69, 0xfd, 0x02, 0xca, 0x88, 0x09, 0x55, 0x18, 0x82, 0x61,
-	0x04, 0xf9, 0x07, 0xb6, 0x8e, 0xae, 0x73, 0x99, 0xca, 0x47, 0xfc, 0x35, 0x80, 0xc9, 0x5d, 0x97,
-	0x99, 0xc2, 0xe1, 0x5e, 0x74, 0x5f, 0xcb, 0x74, 0xc3, 0xd2, 0x1a, 0x02, 0x4a, 0x4f, 0x5f, 0x33,
-	0x61, 0x58, 0x86, 0x30, 0xbe, 0x40, 0x85, 0x82, 0x32, 0x5b, 0x3d, 0x9b, 0xc3, 0x2b, 0x28, 0x7e,
-	0x30, 0xdc, 0x15, 0x8b, 0x0e, 0x1e, 0xd0, 0x18, 0xec, 0x68, 0xe6, 0x9f, 0x68, 0xfe, 0x0e, 0x28,
-	0xd5, 0xfc, 0xdf, 0x99, 0x3d, 0x51, 0xc1, 0x6f, 0x40, 0x59, 0x26, 0xa7, 0xa3, 0xf5, 0xaa, 0xf4,
-	0x6a, 0xd9, 0x1a, 0x6d, 0x4a, 0xd3, 0x8c, 0x26, 0x1b, 0x3a, 0x64, 0xee, 0x97, 0x36, 0xf4, 0xcf,
-	0x1c, 0x1c, 0xa5, 0x1d, 0x3d, 0x5b, 0x53, 0xc3, 0xb3, 0x19, 0x6e, 0x80, 0x12, 0x0a, 0x23, 0x10,
-	0x97, 0x99, 0x54, 0x86, 0xf1, 0x09, 0xec, 0x33, 0xcf, 0x92, 0x9e, 0x58, 0x2b, 0x41, 0x9f, 0x2d,
-	0xac, 0xb1, 0x53, 0xd8, 0xc1, 0x46, 0x05, 0x73, 0xa8, 0x8e, 0x98, 0x78, 0xb7, 0x62, 0xc1, 0x9a,
-	0xb2, 0x70, 0xe5, 0x0a, 0x39, 0x82, 0xdf, 0x24, 0x4c, 0xc2, 0xc7, 0xe0, 0x73, 0xb5, 0x6c, 0xc5,
-	0xc8, 0xef, 0xc4, 0x18, 0xc1, 0x61, 0x14, 0x20, 0x9b, 0x4d, 0x03, 0x14, 0xdf, 0xb0, 0x99, 0xea,
-	0xfc, 0x11, 0xff, 0x9f, 0x16, 0x69, 0x86, 0xa5, 0x6f, 0xce, 0xf9, 0xc3, 0xd2, 0x08, 0x1e, 0x92,
-	0x30, 0x19, 0x6e, 0x7d, 0x17, 0xdd, 0xc0, 0x0b, 0x27, 0x14, 0x3c, 0x58, 0x9f, 0xf3, 0x40, 0x16,
-	0xff, 0xa4, 0xed, 0xad, 0x26, 0x54, 0xa3, 0x70, 0x51, 0x5f, 0x27, 0xec, 0xa3, 0xc0, 0x55, 0xd8,
-	0x73, 0xac, 0x84, 0xb2, 0xe7, 0x58, 0xad, 0x6f, 0xe1, 0xe8, 0x91, 0x31, 0x70, 0x79, 0xc8, 0x9e,
-	0x50, 0x7e, 0x02, 0xb4, 0xd1, 0x94, 0xb3, 0xb5, 0x60, 0x21, 0x6e, 0x42, 0x25, 0x78, 0x84, 0x11,
-	0xf9, 0x80, 0x6e, 0x9a, 0x5a, 0x7f, 0xe5, 0x92, 0x52, 0x29, 0x0b, 0x7d, 0xee, 0x85, 0x0c, 0xf7,
-	0xa0, 0x14, 0x13, 0x24, 0x3f, 0xdf, 0xae, 0xf4, 0xea, 0xe9, 0x9d, 0xda, 0x95, 0xa7, 0x29, 0x11,
-	0x9f, 0x82, 0xb2, 0x30, 0x42, 0x7d, 0xc9, 0x83, 0x78, 0x0f, 0x14, 0x5a, 0x5a, 0x18, 0xe1, 0x35,
-	0x0f, 0xd2, 0x34, 0xf3, 0x69, 0x9a, 0x9f, 0x1c, 0xad, 0x0d, 0xb5, 0xad, 0x5c, 0xb2, 0xf6, 0xf7,
-	0xa0, 0x76, 0xcf, 0x84, 0xb9, 0x60, 0x96, 0x1e, 0x30, 0x93, 0x07, 0x56, 0xa8, 0x9b, 0x7c, 0xe5,
-	0x89, 0x64, 0x16, 0xc7, 0x89, 0x93, 0xc6, 0xbe, 0x81, 0x74, 0x7d, 0x72, 0x2c, 0x6f, 0xe1, 0x70,
-	0x7b, 0xf7, 0xea, 0x50, 0x92, 0x59, 0x3c, 0xce, 0x25, 0x85, 0xff, 0xbd, 0xdf, 0xad, 0x73, 0x38,
-	0xde, 0xde, 0xb0, 0xf8, 0x26, 0x76, 0xa1, 0xc4, 0x3c, 0x11, 0x38, 0x2c, 0xed, 0xdd, 0x33, 0xfb,
-	0x98, 0xb2, 0x7a, 0xef, 0x37, 0xde, 0xdb, 0xea, 0xca, 0xf7, 0x79, 0x20, 0xf0, 0x10, 0x14, 0xca,
-	0x6c, 0x27, 0x14, 0x2c, 0xc0, 0xf5, 0xe7, 0xde, 0xda, 0x8d, 0x67, 0x3d, 0xad, 0x17, 0xed, 0xdc,
-	0x0f, 0xb9, 0xb3, 0x29, 0xb4, 0x78, 0x60, 0x77, 0x16, 0x6b, 0x9f, 0x05, 0x2e, 0xb3, 0x6c, 0x16,
-	0x74, 0xee, 0x8d, 0x79, 0xe0, 0x98, 0xe9, 0x39, 0xf9, 0xa1, 0xf1, 0xeb, 0xf7, 0xb6, 0x23, 0x16,
-	0xab, 0x79, 0xc7, 0xe4, 0xcb, 0xee, 0x06, 0xb5, 0x1b, 0x53, 0xe3, 0x0f, 0x8e, 0xb0, 0x2b, 0xa9,
-	0xf3, 0xf8, 0xeb, 0xe5, 0xc7, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x3e, 0x82, 0x3d, 0x52, 0xe1,
-	0x08, 0x00, 0x00,
+	proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_chaincode_shim_3c7e73a209e14e7e)
+}
+
+var fileDescriptor_chaincode_shim_3c7e73a209e14e7e = []byte{
+	// 1080 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcf, 0x73, 0xe2, 0x36,
+	0x14, 0x5e, 0x02, 0x04, 0xf3, 0x48, 0x88, 0x56, 0x09, 0x29, 0x61, 0x66, 0x5b, 0xca, 0x89, 0x5e,
+	0xa0, 0x4b, 0x7b, 0xe8, 0xa1, 0x33, 0x3b, 0x04, 0x14, 0xc2, 0x24, 0x01, 0x56, 0x76, 0x32, 0x9b,
+	0x5e, 0x3c, 0xc6, 0x56, 0xc0, 0x13, 0x63, 0xb9, 0xb6, 0x48, 0x97, 0xde, 0x7a, 0xed, 0xb1, 0x87,
+	0xfe, 0x69, 0xfd, 0x7b, 0x3a, 0xf2, 0xaf, 0x00, 0xd9, 0xec, 0xce, 0xe6, 0x64, 0x7f, 0xef, 0x7d,
+	0xfa, 0xde, 0x0f, 0x3d, 0x69, 0x04, 0x27, 0x1e, 0x63, 0x7e, 0xdb, 0x9c, 0x1b, 0xb6, 0x6b, 0x72,
+	0x8b, 0xe9, 0xc1, 0xdc, 0x5e, 0xb4, 0x3c, 0x9f, 0x0b, 0x8e, 0x77, 0xc3, 0x4f, 0x50, 0xab, 0x6d,
+	0x51, 0xd8, 0x03, 0x73, 0x45, 0xc4, 0xa9, 0x1d, 0x86, 0x3e, 0xcf, 0xe7, 0x1e, 0x0f, 0x0c, 0x27,
+	0x36, 0x7e, 0x37, 0xe3, 0x7c, 0xe6, 0xb0, 0x76, 0x88, 0xa6, 0xcb, 0xbb, 0xb6, 0xb0, 0x17, 0x2c,
+	0x10, 0xc6, 0xc2, 0x8b, 0x08, 0x8d, 0xff, 0xf2, 0x80, 0x7a, 0x89, 0xde, 0x15, 0x0b, 0x02, 0x63,
+	0xc6, 0xf0, 0x5b, 0xc8, 0x89, 0x95, 0xc7, 0xaa, 0x99, 0x7a, 0xa6, 0x59, 0xee, 0xbc, 0x89, 0xa8,
+	0x41, 0x6b, 0x9b, 0xd7, 0xd2, 0x56, 0x1e, 0xa3, 0x21, 0x15, 0xff, 0x02, 0xc5, 0x54, 0xba, 0xba,
+	0x53, 0xcf, 0x34, 0x4b, 0x9d, 0x5a, 0x2b, 0x0a, 0xde, 0x4a, 0x82, 0xb7, 0xb4, 0x84, 0x41, 0x1f,
+	0xc9, 0xb8, 0x0a, 0x05, 0xcf, 0x58, 0x39, 0xdc, 0xb0, 0xaa, 0xd9, 0x7a, 0xa6, 0xb9, 0x47, 0x13,
+	0x88, 0x31, 0xe4, 0xc4, 0x47, 0xdb, 0xaa, 0xe6, 0xea, 0x99, 0x66, 0x91, 0x86, 0xff, 0xb8, 0x03,
+	0x4a, 0x52, 0x62, 0x35, 0x1f, 0x86, 0x39, 0x4e, 0xd2, 0x53, 0xed, 0x99, 0xcb, 0xac, 0x49, 0xec,
+	0xa5, 0x29, 0x0f, 0xbf, 0x83, 0x83, 0xad, 0x96, 0x55, 0x77, 0x37, 0x97, 0xa6, 0x95, 0x11, 0xe9,
+	0xa5, 0x65, 0x73, 0x03, 0xe3, 0x37, 0x00, 0xe6, 0xdc, 0x70, 0x5d, 0xe6, 0xe8, 0xb6, 0x55, 0x2d,
+	0x84, 0xe9, 0x14, 0x63, 0xcb, 0xd0, 0x6a, 0xfc, 0x93, 0x85, 0x9c, 0x6c, 0x05, 0xde, 0x87, 0xe2,
+	0xf5, 0xa8, 0x4f, 0xce, 0x86, 0x23, 0xd2, 0x47, 0xaf, 0xf0, 0x1e, 0x28, 0x94, 0x0c, 0x86, 0xaa,
+	0x46, 0x28, 0xca, 0xe0, 0x32, 0x40, 0x82, 0x48, 0x1f, 0xed, 0x60, 0x05, 0x72, 0xc3, 0xd1, 0x50,
+	0x43, 0x59, 0x5c, 0x84, 0x3c, 0x25, 0xdd, 0xfe, 0x2d, 0xca, 0xe1, 0x03, 0x28, 0x69, 0xb4, 0x3b,
+	0x52, 0xbb, 0x3d, 0x6d, 0x38, 0x1e, 0xa1, 0xbc, 0x94, 0xec, 0x8d, 0xaf, 0x26, 0x97, 0x44, 0x23,
+	0x7d, 0xb4, 0x2b, 0xa9, 0x84, 0xd2, 0x31, 0x45, 0x05, 0xe9, 0x19, 0x10, 0x4d, 0x57, 0xb5, 0xae,
+	0x46, 0x90, 0x22, 0xe1, 0xe4, 0x3a, 0x81, 0x45, 0x09, 0xfb, 0xe4, 0x32, 0x86, 0x80, 0x8f, 0x00,
+	0x0d, 0x47, 0x37, 0xe3, 0x0b, 0xa2, 0xf7, 0xce, 0xbb, 0xc3, 0x51, 0x6f, 0xdc, 0x27, 0xa8, 0x14,
+	0x25, 0xa8, 0x4e, 0xc6, 0x23, 0x95, 0xa0, 0x7d, 0x7c, 0x0c, 0x38, 0x15, 0xd4, 0x4f, 0x6f, 0x75,
+	0xda, 0x1d, 0x0d, 0x08, 0x2a, 0xcb, 0xb5, 0xd2, 0xfe, 0xfe, 0x9a, 0xd0, 0x5b, 0x9d, 0x12, 0xf5,
+	0xfa, 0x52, 0x43, 0x07, 0xd2, 0x1a, 0x59, 0x22, 0xfe, 0x88, 0x7c, 0xd0, 0x10, 0xc2, 0x15, 0x78,
+	0xbd, 0x6e, 0xed, 0x5d, 0x8e, 0x55, 0x82, 0x5e, 0xcb, 0x6c, 0x2e, 0x08, 0x99, 0x74, 0x2f, 0x87,
+	0x37, 0x04, 0x61, 0xfc, 0x0d, 0x1c, 0x4a, 0xc5, 0xf3, 0xa1, 0xaa, 0x8d, 0xe9, 0xad, 0x7e, 0x36,
+	0xa6, 0xfa, 0x05, 0xb9, 0x45, 0x87, 0x9b, 0x29, 0x5c, 0x11, 0xad, 0xdb, 0xef, 0x6a, 0x5d, 0x74,
+	0x24, 0xed, 0x93, 0xeb, 0x27, 0xf6, 0x0a, 0x3e, 0x81, 0x8a, 0xe4, 0x4f, 0xe8, 0xf0, 0x46, 0x7a,
+	0xa4, 0x55, 0x3f, 0xef, 0xaa, 0xe7, 0xe8, 0xb8, 0xf1, 0x2b, 0x28, 0x03, 0x26, 0x54, 0x61, 0x08,
+	0x86, 0x11, 0x64, 0xef, 0xd9, 0x2a, 0x1c, 0xe7, 0x22, 0x95, 0xbf, 0xf8, 0x5b, 0x00, 0x93, 0x3b,
+	0x0e, 0x33, 0x85, 0xcd, 0xdd, 0x70, 0x5e, 0x8b, 0x74, 0xcd, 0xd2, 0xe8, 0x03, 0x4a, 0x56, 0x5f,
+	0x31, 0x61, 0x58, 0x86, 0x30, 0x5e, 0xa0, 0x42, 0x41, 0x99, 0x2c, 0x9f, 0xcd, 0xe1, 0x08, 0xf2,
+	0x0f, 0x86, 0xb3, 0x64, 0xe1, 0xc2, 0x3d, 0x1a, 0x81, 0x2d, 0xcd, 0xec, 0x13, 0xcd, 0x3f, 0x00,
+	0x4d, 0x96, 0x5f, 0x99, 0xd9, 0x13, 0x15, 0xfc, 0x16, 0x94, 0x45, 0xbc, 0x3a, 0x3c, 0x5e, 0xa5,
+	0x4e, 0x25, 0x3d, 0x46, 0xeb, 0xd2, 0x34, 0xa5, 0xc9, 0x86, 0xf6, 0x99, 0xf3, 0xd2, 0x86, 0xfe,
+	0x95, 0x81, 0x83, 0xa4, 0xa3, 0xa7, 0x2b, 0x6a, 0xb8, 0x33, 0x86, 0x6b, 0xa0, 0x04, 0xc2, 0xf0,
+	0xc5, 0x45, 0x2a, 0x95, 0x62, 0x7c, 0x0c, 0xbb, 0xcc, 0xb5, 0xa4, 0x27, 0xd2, 0x8a, 0xd1, 0x17,
+	0x0b, 0xab, 0x6d, 0x15, 0xb6, 0xb7, 0x56, 0xc1, 0x14, 0xca, 0x03, 0x26, 0xde, 0x2f, 0x99, 0xbf,
+	0xa2, 0x2c, 0x58, 0x3a, 0x42, 0x6e, 0xc1, 0xef, 0x12, 0xc6, 0xe1, 0x23, 0xf0, 0xa5, 0x5a, 0x36,
+	0x62, 0x64, 0xb7, 0x62, 0x0c, 0x60, 0x3f, 0x0c, 0x90, 0xee, 0x4d, 0x0d, 0x14, 0xcf, 0x98, 0x31,
+	0xd5, 0xfe, 0x33, 0xba, 0x4f, 0xf3, 0x34, 0xc5, 0xd2, 0x37, 0xe5, 0xfc, 0x7e, 0x61, 0xf8, 0xf7,
+	0x71, 0x98, 0x14, 0x37, 0xfe, 0xcd, 0x84, 0x23, 0x78, 0x6e, 0x07, 0x82, 0xfb, 0xab, 0x33, 0xee,
+	0xcb, 0xea, 0x3f, 0xd9, 0xf7, 0xb0, 0x67, 0xa7, 0x0e, 0x37, 0x23, 0x91, 0x1c, 0x5d, 0xb3, 0xc8,
+	0x10, 0xcc, 0xb5, 0x22, 0x6f, 0x36, 0xf4, 0xa6, 0x58, 0xde, 0xbc, 0x3e, 0x7b, 0x60, 0x7e, 0xc0,
+	0xc2, 0x56, 0x29, 0x34, 0x81, 0x1b, 0x15, 0xe6, 0xb7, 0x2a, 0xac, 0x43, 0x39, 0xac, 0x30, 0xdc,
+	0xca, 0x11, 0xfb, 0x28, 0x70, 0x19, 0x76, 0x6c, 0x2b, 0x4e, 0x6a, 0xc7, 0xb6, 0x1a, 0xdf, 0xc3,
+	0xc1, 0x23, 0xa3, 0xe7, 0xf0, 0x80, 0x3d, 0xa1, 0xfc, 0x0c, 0x68, 0x6d, 0x1f, 0x4e, 0x57, 0x82,
+	0x05, 0xb8, 0x0e, 0x25, 0xff, 0x11, 0x86, 0xe4, 0x3d, 0xba, 0x6e, 0x6a, 0xfc, 0x9d, 0x89, 0xbb,
+	0x4b, 0x59, 0xe0, 0x71, 0x37, 0x60, 0xb8, 0x03, 0x85, 0x88, 0x20, 0xf9, 0xd9, 0x66, 0xa9, 0x53,
+	0x4d, 0xc6, 0x78, 0x5b, 0x9e, 0x26, 0x44, 0x7c, 0x02, 0xca, 0xdc, 0x08, 0xf4, 0x05, 0xf7, 0xa3,
+	0xa3, 0xa7, 0xd0, 0xc2, 0xdc, 0x08, 0xae, 0xb8, 0x9f, 0xa4, 0x99, 0x4d, 0xd2, 0xfc, 0xec, 0x34,
+	0xcd, 0xa0, 0xb2, 0x91, 0x4b, 0xba, 0xe3, 0x1d, 0xa8, 0xdc, 0x31, 0x61, 0xce, 0x99, 0xa5, 0xfb,
+	0xcc, 0xe4, 0xbe, 0x15, 0xe8, 0x26, 0x5f, 0xba, 0x22, 0xde, 0xfe, 0xc3, 0xd8, 0x49, 0x23, 0x5f,
+	0x4f, 0xba, 0x3e, 0x3b, 0x09, 0xef, 0x60, 0x7f, 0xf3, 0xb8, 0x57, 0xa1, 0x20, 0xb3, 0x78, 0x9c,
+	0x84, 0x04, 0x7e, 0xfa, 0x4a, 0x69, 0x9c, 0xc1, 0xe1, 0xe6, 0xa1, 0x8e, 0x86, 0xbf, 0x0d, 0x05,
+	0xe6, 0x0a, 0xdf, 0x66, 0x49, 0xef, 0x9e, 0xb9, 0x02, 0x12, 0x56, 0xe7, 0xc3, 0xda, 0x53, 0x41,
+	0x5d, 0x7a, 0x1e, 0xf7, 0x05, 0xee, 0x83, 0x42, 0xd9, 0xcc, 0x0e, 0x04, 0xf3, 0x71, 0xf5, 0xb9,
+	0x87, 0x42, 0xed, 0x59, 0x4f, 0xe3, 0x55, 0x33, 0xf3, 0x63, 0xa6, 0x33, 0x81, 0x62, 0xea, 0xc1,
+	0x3d, 0x28, 0xf4, 0xb8, 0xeb, 0x32, 0x53, 0xbc, 0x5c, 0xf1, 0x74, 0x0c, 0x0d, 0xee, 0xcf, 0x5a,
+	0xf3, 0x95, 0xc7, 0x7c, 0x87, 0x59, 0x33, 0xe6, 0xb7, 0xee, 0x8c, 0xa9, 0x6f, 0x9b, 0xc9, 0x3a,
+	0xf9, 0x5a, 0xfa, 0xed, 0x87, 0x99, 0x2d, 0xe6, 0xcb, 0x69, 0xcb, 0xe4, 0x8b, 0xf6, 0x1a, 0xb5,
+	0x1d, 0x51, 0xa3, 0x57, 0x53, 0xd0, 0x96, 0xd4, 0x69, 0xf4, 0x04, 0xfb, 0xe9, 0xff, 0x01, 0x00,
+	0x0f, 0xf3, 0x43, 0x96, 0xa6, 0x09, 0x00, 0x00,
 }
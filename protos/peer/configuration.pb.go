@@ -30,7 +30,7 @@ func (m *AnchorPeers) Reset()         { *m = AnchorPeers{} }
 func (m *AnchorPeers) String() string { return proto.CompactTextString(m) }
 func (*AnchorPeers) ProtoMessage()    {}
 func (*AnchorPeers) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{0}
+	return fileDescriptor_configuration_3e67db928599496e, []int{0}
 }
 func (m *AnchorPeers) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnchorPeers.Unmarshal(m, b)
@@ -73,7 +73,7 @@ func (m *AnchorPeer) Reset()         { *m = AnchorPeer{} }
 func (m *AnchorPeer) String() string { return proto.CompactTextString(m) }
 func (*AnchorPeer) ProtoMessage()    {}
 func (*AnchorPeer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{1}
+	return fileDescriptor_configuration_3e67db928599496e, []int{1}
 }
 func (m *AnchorPeer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnchorPeer.Unmarshal(m, b)
@@ -120,7 +120,7 @@ func (m *APIResource) Reset()         { *m = APIResource{} }
 func (m *APIResource) String() string { return proto.CompactTextString(m) }
 func (*APIResource) ProtoMessage()    {}
 func (*APIResource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{2}
+	return fileDescriptor_configuration_3e67db928599496e, []int{2}
 }
 func (m *APIResource) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_APIResource.Unmarshal(m, b)
@@ -160,7 +160,7 @@ func (m *ACLs) Reset()         { *m = ACLs{} }
 func (m *ACLs) String() string { return proto.CompactTextString(m) }
 func (*ACLs) ProtoMessage()    {}
 func (*ACLs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{3}
+	return fileDescriptor_configuration_3e67db928599496e, []int{3}
 }
 func (m *ACLs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ACLs.Unmarshal(m, b)
@@ -187,37 +187,83 @@ func (m *ACLs) GetAcls() map[string]*APIResource {
 	return nil
 }
 
+// TokenSupplyCaps caps the supply of the token types of a channel. A token
+// type that is not listed is uncapped
+type TokenSupplyCaps struct {
+	MaxSupplies          map[string]uint64 `protobuf:"bytes,1,rep,name=max_supplies,json=maxSupplies,proto3" json:"max_supplies,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *TokenSupplyCaps) Reset()         { *m = TokenSupplyCaps{} }
+func (m *TokenSupplyCaps) String() string { return proto.CompactTextString(m) }
+func (*TokenSupplyCaps) ProtoMessage()    {}
+func (*TokenSupplyCaps) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_3e67db928599496e, []int{4}
+}
+func (m *TokenSupplyCaps) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenSupplyCaps.Unmarshal(m, b)
+}
+func (m *TokenSupplyCaps) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenSupplyCaps.Marshal(b, m, deterministic)
+}
+func (dst *TokenSupplyCaps) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenSupplyCaps.Merge(dst, src)
+}
+func (m *TokenSupplyCaps) XXX_Size() int {
+	return xxx_messageInfo_TokenSupplyCaps.Size(m)
+}
+func (m *TokenSupplyCaps) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenSupplyCaps.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenSupplyCaps proto.InternalMessageInfo
+
+func (m *TokenSupplyCaps) GetMaxSupplies() map[string]uint64 {
+	if m != nil {
+		return m.MaxSupplies
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*AnchorPeers)(nil), "protos.AnchorPeers")
 	proto.RegisterType((*AnchorPeer)(nil), "protos.AnchorPeer")
 	proto.RegisterType((*APIResource)(nil), "protos.APIResource")
 	proto.RegisterType((*ACLs)(nil), "protos.ACLs")
 	proto.RegisterMapType((map[string]*APIResource)(nil), "protos.ACLs.AclsEntry")
+	proto.RegisterType((*TokenSupplyCaps)(nil), "protos.TokenSupplyCaps")
+	proto.RegisterMapType((map[string]uint64)(nil), "protos.TokenSupplyCaps.MaxSuppliesEntry")
 }
 
 func init() {
-	proto.RegisterFile("peer/configuration.proto", fileDescriptor_configuration_d9ec63ae33c182ef)
-}
-
-var fileDescriptor_configuration_d9ec63ae33c182ef = []byte{
-	// 295 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x91, 0xdf, 0x4b, 0xfb, 0x30,
-	0x14, 0xc5, 0xe9, 0x7e, 0x7c, 0x61, 0xb7, 0xdf, 0x07, 0x89, 0x20, 0x45, 0x10, 0x46, 0x9f, 0x36,
-	0x91, 0x14, 0xa6, 0x82, 0xf8, 0x56, 0xa7, 0x0f, 0xc2, 0xc0, 0x91, 0x47, 0x5f, 0x46, 0x16, 0x6f,
-	0x7f, 0x60, 0x6d, 0xca, 0x4d, 0x2a, 0xf4, 0xcd, 0x3f, 0x5d, 0x9a, 0x6c, 0xab, 0x4f, 0x39, 0x39,
-	0xf9, 0x9c, 0xcb, 0x21, 0x17, 0xa2, 0x06, 0x91, 0x12, 0xa5, 0xeb, 0xac, 0xcc, 0x5b, 0x92, 0xb6,
-	0xd4, 0x35, 0x6f, 0x48, 0x5b, 0xcd, 0xfe, 0xb9, 0xc3, 0xc4, 0xcf, 0x10, 0xa6, 0xb5, 0x2a, 0x34,
-	0x6d, 0x11, 0xc9, 0xb0, 0x7b, 0xf8, 0x2f, 0xdd, 0x75, 0xd7, 0x27, 0x4d, 0x14, 0xcc, 0xc7, 0x8b,
-	0x70, 0xc5, 0x7c, 0xc8, 0xf0, 0x01, 0x15, 0xa1, 0x1c, 0x62, 0xf1, 0x1d, 0xc0, 0xf0, 0xc4, 0x18,
-	0x4c, 0x0a, 0x6d, 0x6c, 0x14, 0xcc, 0x83, 0xc5, 0x4c, 0x38, 0xdd, 0x7b, 0x8d, 0x26, 0x1b, 0x8d,
-	0xe6, 0xc1, 0x62, 0x2a, 0x9c, 0x8e, 0x6f, 0x20, 0x4c, 0xb7, 0xaf, 0x02, 0x8d, 0x6e, 0x49, 0x21,
-	0xbb, 0x02, 0x68, 0x74, 0x55, 0xaa, 0x6e, 0x47, 0x98, 0x1d, 0xc2, 0x33, 0xef, 0x08, 0xcc, 0xe2,
-	0x9f, 0x00, 0x26, 0xe9, 0x7a, 0x63, 0xd8, 0x35, 0x4c, 0xa4, 0xaa, 0x8e, 0xdd, 0x2e, 0x4e, 0xdd,
-	0xd6, 0x1b, 0xc3, 0x53, 0x55, 0x99, 0x97, 0xda, 0x52, 0x27, 0x1c, 0x73, 0xb9, 0x81, 0xd9, 0xc9,
-	0x62, 0x67, 0x30, 0xfe, 0xc4, 0xee, 0x30, 0xb9, 0x97, 0x6c, 0x09, 0xd3, 0x6f, 0x59, 0xb5, 0xe8,
-	0x6a, 0x85, 0xab, 0xf3, 0xd3, 0xac, 0xa1, 0x96, 0xf0, 0xc4, 0xe3, 0xe8, 0x21, 0x78, 0x7a, 0x83,
-	0x58, 0x53, 0xce, 0x8b, 0xae, 0x41, 0xaa, 0xf0, 0x23, 0x47, 0xe2, 0x99, 0xdc, 0x53, 0xa9, 0x8e,
-	0xb9, 0xfe, 0xd3, 0xde, 0x97, 0x79, 0x69, 0x8b, 0x76, 0xcf, 0x95, 0xfe, 0x4a, 0xfe, 0xa0, 0x89,
-	0x47, 0x13, 0x8f, 0x26, 0x3d, 0xba, 0xf7, 0x5b, 0xb8, 0xfd, 0x0d, 0x00, 0x00, 0xff, 0xff, 0xae,
-	0x0a, 0x1d, 0x41, 0xa8, 0x01, 0x00, 0x00,
+	proto.RegisterFile("peer/configuration.proto", fileDescriptor_configuration_3e67db928599496e)
+}
+
+var fileDescriptor_configuration_3e67db928599496e = []byte{
+	// 357 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x4d, 0x4b, 0xeb, 0x40,
+	0x14, 0x86, 0x49, 0x9b, 0x5e, 0xe8, 0x49, 0xe1, 0x96, 0xb9, 0x97, 0x4b, 0x28, 0x5c, 0x28, 0x59,
+	0xa5, 0x22, 0x09, 0x54, 0x05, 0x71, 0x21, 0xc4, 0xea, 0x42, 0xac, 0x58, 0x52, 0x57, 0x6e, 0xca,
+	0x74, 0x3c, 0xf9, 0xa0, 0x69, 0x66, 0x98, 0x49, 0xa4, 0xd9, 0xf9, 0x4b, 0xfc, 0xad, 0x92, 0xa4,
+	0x69, 0x4a, 0x71, 0x95, 0x93, 0x77, 0x9e, 0xf7, 0xf0, 0xc0, 0x0c, 0x98, 0x02, 0x51, 0xba, 0x8c,
+	0xa7, 0x41, 0x1c, 0xe6, 0x92, 0x66, 0x31, 0x4f, 0x1d, 0x21, 0x79, 0xc6, 0xc9, 0xaf, 0xea, 0xa3,
+	0xac, 0x7b, 0x30, 0xbc, 0x94, 0x45, 0x5c, 0x2e, 0x10, 0xa5, 0x22, 0x57, 0x30, 0xa0, 0xd5, 0xef,
+	0xaa, 0x6c, 0x2a, 0x53, 0x1b, 0x77, 0x6d, 0x63, 0x4a, 0xea, 0x92, 0x72, 0x5a, 0xd4, 0x37, 0x68,
+	0x5b, 0xb3, 0x2e, 0x01, 0xda, 0x23, 0x42, 0x40, 0x8f, 0xb8, 0xca, 0x4c, 0x6d, 0xac, 0xd9, 0x7d,
+	0xbf, 0x9a, 0xcb, 0x4c, 0x70, 0x99, 0x99, 0x9d, 0xb1, 0x66, 0xf7, 0xfc, 0x6a, 0xb6, 0xce, 0xc1,
+	0xf0, 0x16, 0x8f, 0x3e, 0x2a, 0x9e, 0x4b, 0x86, 0xe4, 0x3f, 0x80, 0xe0, 0x49, 0xcc, 0x8a, 0x95,
+	0xc4, 0x60, 0x5f, 0xee, 0xd7, 0x89, 0x8f, 0x81, 0xf5, 0xa9, 0x81, 0xee, 0xcd, 0xe6, 0x8a, 0x9c,
+	0x81, 0x4e, 0x59, 0xd2, 0xb8, 0xfd, 0x3b, 0xb8, 0xcd, 0xe6, 0xca, 0xf1, 0x58, 0xa2, 0x1e, 0xd2,
+	0x4c, 0x16, 0x7e, 0xc5, 0x8c, 0xe6, 0xd0, 0x3f, 0x44, 0x64, 0x08, 0xdd, 0x0d, 0x16, 0xfb, 0xcd,
+	0xe5, 0x48, 0x26, 0xd0, 0xfb, 0xa0, 0x49, 0x8e, 0x95, 0x96, 0x31, 0xfd, 0x73, 0xd8, 0xd5, 0x6a,
+	0xf9, 0x35, 0x71, 0xd3, 0xb9, 0xd6, 0xac, 0x2f, 0x0d, 0x7e, 0xbf, 0xf2, 0x0d, 0xa6, 0xcb, 0x5c,
+	0x88, 0xa4, 0x98, 0x51, 0xa1, 0xc8, 0x13, 0x0c, 0xb6, 0x74, 0xb7, 0x52, 0x65, 0x12, 0x63, 0x63,
+	0x65, 0x37, 0x9b, 0x4e, 0x70, 0xe7, 0x99, 0xee, 0x96, 0x7b, 0xb4, 0xf6, 0x34, 0xb6, 0x6d, 0x32,
+	0xba, 0x85, 0xe1, 0x29, 0xf0, 0x83, 0xf5, 0xdf, 0x63, 0x6b, 0xfd, 0x48, 0xf0, 0xee, 0x05, 0x2c,
+	0x2e, 0x43, 0x27, 0x2a, 0x04, 0xca, 0x04, 0xdf, 0x43, 0x94, 0x4e, 0x40, 0xd7, 0x32, 0x66, 0x8d,
+	0x4e, 0x79, 0xab, 0x6f, 0x93, 0x30, 0xce, 0xa2, 0x7c, 0xed, 0x30, 0xbe, 0x75, 0x8f, 0x50, 0xb7,
+	0x46, 0xdd, 0x1a, 0x75, 0x4b, 0x74, 0x5d, 0x3f, 0x93, 0x8b, 0xef, 0x01, 0x00, 0x0c, 0x05, 0x84,
+	0x4f, 0x49, 0x02, 0x00, 0x00,
 }
@@ -0,0 +1,183 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: peer/lifecycle/db.proto
+
+package lifecycle // import "github.com/hyperledger/fabric/protos/peer/lifecycle"
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import common "github.com/hyperledger/fabric/protos/common"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// ChaincodeDefinition is the definition of a chaincode as it is stored
+// in the public state of '+lifecycle' once committed, and in the implicit
+// collection of an org once approved by the org
+type ChaincodeDefinition struct {
+	Sequence             int64                           `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Version              string                          `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	EndorsementPlugin    string                          `protobuf:"bytes,3,opt,name=endorsement_plugin,json=endorsementPlugin,proto3" json:"endorsement_plugin,omitempty"`
+	ValidationPlugin     string                          `protobuf:"bytes,4,opt,name=validation_plugin,json=validationPlugin,proto3" json:"validation_plugin,omitempty"`
+	ValidationParameter  []byte                          `protobuf:"bytes,5,opt,name=validation_parameter,json=validationParameter,proto3" json:"validation_parameter,omitempty"`
+	Collections          *common.CollectionConfigPackage `protobuf:"bytes,6,opt,name=collections,proto3" json:"collections,omitempty"`
+	InitRequired         bool                            `protobuf:"varint,7,opt,name=init_required,json=initRequired,proto3" json:"init_required,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *ChaincodeDefinition) Reset()         { *m = ChaincodeDefinition{} }
+func (m *ChaincodeDefinition) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeDefinition) ProtoMessage()    {}
+func (*ChaincodeDefinition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db_70b2c148816b41d6, []int{0}
+}
+func (m *ChaincodeDefinition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeDefinition.Unmarshal(m, b)
+}
+func (m *ChaincodeDefinition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeDefinition.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeDefinition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeDefinition.Merge(dst, src)
+}
+func (m *ChaincodeDefinition) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeDefinition.Size(m)
+}
+func (m *ChaincodeDefinition) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeDefinition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeDefinition proto.InternalMessageInfo
+
+func (m *ChaincodeDefinition) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *ChaincodeDefinition) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ChaincodeDefinition) GetEndorsementPlugin() string {
+	if m != nil {
+		return m.EndorsementPlugin
+	}
+	return ""
+}
+
+func (m *ChaincodeDefinition) GetValidationPlugin() string {
+	if m != nil {
+		return m.ValidationPlugin
+	}
+	return ""
+}
+
+func (m *ChaincodeDefinition) GetValidationParameter() []byte {
+	if m != nil {
+		return m.ValidationParameter
+	}
+	return nil
+}
+
+func (m *ChaincodeDefinition) GetCollections() *common.CollectionConfigPackage {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+func (m *ChaincodeDefinition) GetInitRequired() bool {
+	if m != nil {
+		return m.InitRequired
+	}
+	return false
+}
+
+// ChaincodeLocalPackage is the reference to the installed chaincode an
+// org runs for a chaincode definition, as it is stored in the implicit
+// collection of the org
+type ChaincodeLocalPackage struct {
+	Hash                 []byte   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChaincodeLocalPackage) Reset()         { *m = ChaincodeLocalPackage{} }
+func (m *ChaincodeLocalPackage) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeLocalPackage) ProtoMessage()    {}
+func (*ChaincodeLocalPackage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db_70b2c148816b41d6, []int{1}
+}
+func (m *ChaincodeLocalPackage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeLocalPackage.Unmarshal(m, b)
+}
+func (m *ChaincodeLocalPackage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeLocalPackage.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeLocalPackage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeLocalPackage.Merge(dst, src)
+}
+func (m *ChaincodeLocalPackage) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeLocalPackage.Size(m)
+}
+func (m *ChaincodeLocalPackage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeLocalPackage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeLocalPackage proto.InternalMessageInfo
+
+func (m *ChaincodeLocalPackage) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ChaincodeDefinition)(nil), "lifecycle.ChaincodeDefinition")
+	proto.RegisterType((*ChaincodeLocalPackage)(nil), "lifecycle.ChaincodeLocalPackage")
+}
+
+func init() { proto.RegisterFile("peer/lifecycle/db.proto", fileDescriptor_db_70b2c148816b41d6) }
+
+var fileDescriptor_db_70b2c148816b41d6 = []byte{
+	// 339 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x92, 0xcd, 0x6e, 0xa3, 0x30,
+	0x14, 0x85, 0x45, 0x92, 0xc9, 0x8f, 0x93, 0x91, 0x26, 0xce, 0x54, 0x41, 0xd9, 0x14, 0xa5, 0x1b,
+	0xa4, 0xb4, 0xb6, 0xda, 0x3c, 0x41, 0x9b, 0x2e, 0xbb, 0x88, 0xbc, 0xec, 0x26, 0x32, 0xe6, 0x02,
+	0x56, 0x8d, 0x4d, 0x0c, 0x44, 0xca, 0xeb, 0xf5, 0xc9, 0x2a, 0x20, 0x21, 0x74, 0xc7, 0x3d, 0xe7,
+	0x3b, 0x80, 0xcf, 0x35, 0x5a, 0x66, 0x00, 0x96, 0x2a, 0x19, 0x81, 0x38, 0x0b, 0x05, 0x34, 0x0c,
+	0x48, 0x66, 0x4d, 0x61, 0xf0, 0xa4, 0xd5, 0x56, 0x4b, 0x61, 0xd2, 0xd4, 0x68, 0x2a, 0x8c, 0x52,
+	0x20, 0x0a, 0x69, 0x74, 0xc3, 0xac, 0xbf, 0x7b, 0x68, 0xb1, 0x4b, 0xb8, 0xd4, 0xc2, 0x84, 0xf0,
+	0x0e, 0x91, 0xd4, 0xb2, 0x72, 0xf1, 0x0a, 0x8d, 0x73, 0x38, 0x96, 0xa0, 0x05, 0xb8, 0x8e, 0xe7,
+	0xf8, 0x7d, 0xd6, 0xce, 0xd8, 0x45, 0xa3, 0x13, 0xd8, 0x5c, 0x1a, 0xed, 0xf6, 0x3c, 0xc7, 0x9f,
+	0xb0, 0xeb, 0x88, 0x9f, 0x10, 0x06, 0x1d, 0x1a, 0x9b, 0x43, 0x0a, 0xba, 0x38, 0x64, 0xaa, 0x8c,
+	0xa5, 0x76, 0xfb, 0x35, 0x34, 0xef, 0x38, 0xfb, 0xda, 0xc0, 0x1b, 0x34, 0x3f, 0x71, 0x25, 0x43,
+	0x5e, 0x7d, 0xf2, 0x4a, 0x0f, 0x6a, 0xfa, 0xdf, 0xcd, 0xb8, 0xc0, 0xcf, 0xe8, 0x7f, 0x17, 0xe6,
+	0x96, 0xa7, 0x50, 0x80, 0x75, 0xff, 0x78, 0x8e, 0x3f, 0x63, 0x8b, 0x0e, 0x7f, 0xb5, 0xf0, 0x2b,
+	0x9a, 0xde, 0x0e, 0x9c, 0xbb, 0x43, 0xcf, 0xf1, 0xa7, 0x2f, 0xf7, 0xa4, 0xe9, 0x82, 0xec, 0x5a,
+	0x6b, 0x67, 0x74, 0x24, 0xe3, 0x3d, 0x17, 0x5f, 0x3c, 0x06, 0xd6, 0xcd, 0xe0, 0x07, 0xf4, 0xb7,
+	0xaa, 0xe4, 0x60, 0xe1, 0x58, 0x4a, 0x0b, 0xa1, 0x3b, 0xf2, 0x1c, 0x7f, 0xcc, 0x66, 0x95, 0xc8,
+	0x2e, 0xda, 0x7a, 0x83, 0xee, 0xda, 0x0e, 0x3f, 0x8c, 0xe0, 0xea, 0xf2, 0x2a, 0x8c, 0xd1, 0x20,
+	0xe1, 0x79, 0x52, 0x37, 0x38, 0x63, 0xf5, 0xf3, 0x9b, 0x40, 0x8f, 0xc6, 0xc6, 0x24, 0x39, 0x67,
+	0x60, 0x15, 0x84, 0x31, 0x58, 0x12, 0xf1, 0xc0, 0x4a, 0xd1, 0x6c, 0x24, 0x27, 0xd5, 0x3a, 0x49,
+	0xbb, 0xba, 0xcf, 0x6d, 0x2c, 0x8b, 0xa4, 0x0c, 0xaa, 0xbf, 0xa6, 0x9d, 0x10, 0x6d, 0x42, 0xb4,
+	0x09, 0xd1, 0xdf, 0x77, 0x20, 0x18, 0xd6, 0xf2, 0xf6, 0x67, 0x00, 0x99, 0xd0, 0x7e, 0xa8, 0x1c,
+	0x02, 0x00, 0x00,
+}
@@ -6,6 +6,7 @@ package lifecycle // import "github.com/hyperledger/fabric/protos/peer/lifecycle
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import common "github.com/hyperledger/fabric/protos/common"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -33,7 +34,7 @@ func (m *InstallChaincodeArgs) Reset()         { *m = InstallChaincodeArgs{} }
 func (m *InstallChaincodeArgs) String() string { return proto.CompactTextString(m) }
 func (*InstallChaincodeArgs) ProtoMessage()    {}
 func (*InstallChaincodeArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_lifecycle_f98901bea638af10, []int{0}
+	return fileDescriptor_lifecycle_fc74faec12be9f0d, []int{0}
 }
 func (m *InstallChaincodeArgs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InstallChaincodeArgs.Unmarshal(m, b)
@@ -87,7 +88,7 @@ func (m *InstallChaincodeResult) Reset()         { *m = InstallChaincodeResult{}
 func (m *InstallChaincodeResult) String() string { return proto.CompactTextString(m) }
 func (*InstallChaincodeResult) ProtoMessage()    {}
 func (*InstallChaincodeResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_lifecycle_f98901bea638af10, []int{1}
+	return fileDescriptor_lifecycle_fc74faec12be9f0d, []int{1}
 }
 func (m *InstallChaincodeResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InstallChaincodeResult.Unmarshal(m, b)
@@ -128,7 +129,7 @@ func (m *QueryInstalledChaincodeArgs) Reset()         { *m = QueryInstalledChain
 func (m *QueryInstalledChaincodeArgs) String() string { return proto.CompactTextString(m) }
 func (*QueryInstalledChaincodeArgs) ProtoMessage()    {}
 func (*QueryInstalledChaincodeArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_lifecycle_f98901bea638af10, []int{2}
+	return fileDescriptor_lifecycle_fc74faec12be9f0d, []int{2}
 }
 func (m *QueryInstalledChaincodeArgs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryInstalledChaincodeArgs.Unmarshal(m, b)
@@ -175,7 +176,7 @@ func (m *QueryInstalledChaincodeResult) Reset()         { *m = QueryInstalledCha
 func (m *QueryInstalledChaincodeResult) String() string { return proto.CompactTextString(m) }
 func (*QueryInstalledChaincodeResult) ProtoMessage()    {}
 func (*QueryInstalledChaincodeResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_lifecycle_f98901bea638af10, []int{3}
+	return fileDescriptor_lifecycle_fc74faec12be9f0d, []int{3}
 }
 func (m *QueryInstalledChaincodeResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryInstalledChaincodeResult.Unmarshal(m, b)
@@ -202,33 +203,602 @@ func (m *QueryInstalledChaincodeResult) GetHash() []byte {
 	return nil
 }
 
+// ApproveChaincodeDefinitionForMyOrgArgs is the message used as arguments to
+// `+lifecycle.ApproveChaincodeDefinitionForMyOrg`.
+type ApproveChaincodeDefinitionForMyOrgArgs struct {
+	Sequence             int64                           `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Name                 string                          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Version              string                          `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Hash                 []byte                          `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	EndorsementPlugin    string                          `protobuf:"bytes,5,opt,name=endorsement_plugin,json=endorsementPlugin,proto3" json:"endorsement_plugin,omitempty"`
+	ValidationPlugin     string                          `protobuf:"bytes,6,opt,name=validation_plugin,json=validationPlugin,proto3" json:"validation_plugin,omitempty"`
+	ValidationParameter  []byte                          `protobuf:"bytes,7,opt,name=validation_parameter,json=validationParameter,proto3" json:"validation_parameter,omitempty"`
+	Collections          *common.CollectionConfigPackage `protobuf:"bytes,8,opt,name=collections,proto3" json:"collections,omitempty"`
+	InitRequired         bool                            `protobuf:"varint,9,opt,name=init_required,json=initRequired,proto3" json:"init_required,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) Reset() {
+	*m = ApproveChaincodeDefinitionForMyOrgArgs{}
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) String() string { return proto.CompactTextString(m) }
+func (*ApproveChaincodeDefinitionForMyOrgArgs) ProtoMessage()    {}
+func (*ApproveChaincodeDefinitionForMyOrgArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_fc74faec12be9f0d, []int{4}
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.Unmarshal(m, b)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.Marshal(b, m, deterministic)
+}
+func (dst *ApproveChaincodeDefinitionForMyOrgArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.Merge(dst, src)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) XXX_Size() int {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.Size(m)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs proto.InternalMessageInfo
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetEndorsementPlugin() string {
+	if m != nil {
+		return m.EndorsementPlugin
+	}
+	return ""
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetValidationPlugin() string {
+	if m != nil {
+		return m.ValidationPlugin
+	}
+	return ""
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetValidationParameter() []byte {
+	if m != nil {
+		return m.ValidationParameter
+	}
+	return nil
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetCollections() *common.CollectionConfigPackage {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetInitRequired() bool {
+	if m != nil {
+		return m.InitRequired
+	}
+	return false
+}
+
+// ApproveChaincodeDefinitionForMyOrgResult is the message returned by
+// `+lifecycle.ApproveChaincodeDefinitionForMyOrg`. Currently it returns
+// nothing, but may be extended in the future.
+type ApproveChaincodeDefinitionForMyOrgResult struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgResult) Reset() {
+	*m = ApproveChaincodeDefinitionForMyOrgResult{}
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) String() string { return proto.CompactTextString(m) }
+func (*ApproveChaincodeDefinitionForMyOrgResult) ProtoMessage()    {}
+func (*ApproveChaincodeDefinitionForMyOrgResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_fc74faec12be9f0d, []int{5}
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.Unmarshal(m, b)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.Marshal(b, m, deterministic)
+}
+func (dst *ApproveChaincodeDefinitionForMyOrgResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.Merge(dst, src)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) XXX_Size() int {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.Size(m)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult proto.InternalMessageInfo
+
+// CheckCommitReadinessArgs is the message used as arguments to
+// `+lifecycle.CheckCommitReadiness`.
+type CheckCommitReadinessArgs struct {
+	Sequence             int64                           `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Name                 string                          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Version              string                          `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	EndorsementPlugin    string                          `protobuf:"bytes,4,opt,name=endorsement_plugin,json=endorsementPlugin,proto3" json:"endorsement_plugin,omitempty"`
+	ValidationPlugin     string                          `protobuf:"bytes,5,opt,name=validation_plugin,json=validationPlugin,proto3" json:"validation_plugin,omitempty"`
+	ValidationParameter  []byte                          `protobuf:"bytes,6,opt,name=validation_parameter,json=validationParameter,proto3" json:"validation_parameter,omitempty"`
+	Collections          *common.CollectionConfigPackage `protobuf:"bytes,7,opt,name=collections,proto3" json:"collections,omitempty"`
+	InitRequired         bool                            `protobuf:"varint,8,opt,name=init_required,json=initRequired,proto3" json:"init_required,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *CheckCommitReadinessArgs) Reset()         { *m = CheckCommitReadinessArgs{} }
+func (m *CheckCommitReadinessArgs) String() string { return proto.CompactTextString(m) }
+func (*CheckCommitReadinessArgs) ProtoMessage()    {}
+func (*CheckCommitReadinessArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_fc74faec12be9f0d, []int{6}
+}
+func (m *CheckCommitReadinessArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckCommitReadinessArgs.Unmarshal(m, b)
+}
+func (m *CheckCommitReadinessArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckCommitReadinessArgs.Marshal(b, m, deterministic)
+}
+func (dst *CheckCommitReadinessArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckCommitReadinessArgs.Merge(dst, src)
+}
+func (m *CheckCommitReadinessArgs) XXX_Size() int {
+	return xxx_messageInfo_CheckCommitReadinessArgs.Size(m)
+}
+func (m *CheckCommitReadinessArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckCommitReadinessArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckCommitReadinessArgs proto.InternalMessageInfo
+
+func (m *CheckCommitReadinessArgs) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *CheckCommitReadinessArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CheckCommitReadinessArgs) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *CheckCommitReadinessArgs) GetEndorsementPlugin() string {
+	if m != nil {
+		return m.EndorsementPlugin
+	}
+	return ""
+}
+
+func (m *CheckCommitReadinessArgs) GetValidationPlugin() string {
+	if m != nil {
+		return m.ValidationPlugin
+	}
+	return ""
+}
+
+func (m *CheckCommitReadinessArgs) GetValidationParameter() []byte {
+	if m != nil {
+		return m.ValidationParameter
+	}
+	return nil
+}
+
+func (m *CheckCommitReadinessArgs) GetCollections() *common.CollectionConfigPackage {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+func (m *CheckCommitReadinessArgs) GetInitRequired() bool {
+	if m != nil {
+		return m.InitRequired
+	}
+	return false
+}
+
+// CheckCommitReadinessResult is the message returned by
+// `+lifecycle.CheckCommitReadiness`. It returns a map of
+// orgs to their approval (true/false) for the definition
+// supplied as args.
+type CheckCommitReadinessResult struct {
+	Approvals            map[string]bool `protobuf:"bytes,1,rep,name=approvals,proto3" json:"approvals,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CheckCommitReadinessResult) Reset()         { *m = CheckCommitReadinessResult{} }
+func (m *CheckCommitReadinessResult) String() string { return proto.CompactTextString(m) }
+func (*CheckCommitReadinessResult) ProtoMessage()    {}
+func (*CheckCommitReadinessResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_fc74faec12be9f0d, []int{7}
+}
+func (m *CheckCommitReadinessResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckCommitReadinessResult.Unmarshal(m, b)
+}
+func (m *CheckCommitReadinessResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckCommitReadinessResult.Marshal(b, m, deterministic)
+}
+func (dst *CheckCommitReadinessResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckCommitReadinessResult.Merge(dst, src)
+}
+func (m *CheckCommitReadinessResult) XXX_Size() int {
+	return xxx_messageInfo_CheckCommitReadinessResult.Size(m)
+}
+func (m *CheckCommitReadinessResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckCommitReadinessResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckCommitReadinessResult proto.InternalMessageInfo
+
+func (m *CheckCommitReadinessResult) GetApprovals() map[string]bool {
+	if m != nil {
+		return m.Approvals
+	}
+	return nil
+}
+
+// CommitChaincodeDefinitionArgs is the message used as arguments to
+// `+lifecycle.CommitChaincodeDefinition`.
+type CommitChaincodeDefinitionArgs struct {
+	Sequence             int64                           `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Name                 string                          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Version              string                          `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	EndorsementPlugin    string                          `protobuf:"bytes,4,opt,name=endorsement_plugin,json=endorsementPlugin,proto3" json:"endorsement_plugin,omitempty"`
+	ValidationPlugin     string                          `protobuf:"bytes,5,opt,name=validation_plugin,json=validationPlugin,proto3" json:"validation_plugin,omitempty"`
+	ValidationParameter  []byte                          `protobuf:"bytes,6,opt,name=validation_parameter,json=validationParameter,proto3" json:"validation_parameter,omitempty"`
+	Collections          *common.CollectionConfigPackage `protobuf:"bytes,7,opt,name=collections,proto3" json:"collections,omitempty"`
+	InitRequired         bool                            `protobuf:"varint,8,opt,name=init_required,json=initRequired,proto3" json:"init_required,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *CommitChaincodeDefinitionArgs) Reset()         { *m = CommitChaincodeDefinitionArgs{} }
+func (m *CommitChaincodeDefinitionArgs) String() string { return proto.CompactTextString(m) }
+func (*CommitChaincodeDefinitionArgs) ProtoMessage()    {}
+func (*CommitChaincodeDefinitionArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_fc74faec12be9f0d, []int{8}
+}
+func (m *CommitChaincodeDefinitionArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitChaincodeDefinitionArgs.Unmarshal(m, b)
+}
+func (m *CommitChaincodeDefinitionArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitChaincodeDefinitionArgs.Marshal(b, m, deterministic)
+}
+func (dst *CommitChaincodeDefinitionArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitChaincodeDefinitionArgs.Merge(dst, src)
+}
+func (m *CommitChaincodeDefinitionArgs) XXX_Size() int {
+	return xxx_messageInfo_CommitChaincodeDefinitionArgs.Size(m)
+}
+func (m *CommitChaincodeDefinitionArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitChaincodeDefinitionArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitChaincodeDefinitionArgs proto.InternalMessageInfo
+
+func (m *CommitChaincodeDefinitionArgs) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *CommitChaincodeDefinitionArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CommitChaincodeDefinitionArgs) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *CommitChaincodeDefinitionArgs) GetEndorsementPlugin() string {
+	if m != nil {
+		return m.EndorsementPlugin
+	}
+	return ""
+}
+
+func (m *CommitChaincodeDefinitionArgs) GetValidationPlugin() string {
+	if m != nil {
+		return m.ValidationPlugin
+	}
+	return ""
+}
+
+func (m *CommitChaincodeDefinitionArgs) GetValidationParameter() []byte {
+	if m != nil {
+		return m.ValidationParameter
+	}
+	return nil
+}
+
+func (m *CommitChaincodeDefinitionArgs) GetCollections() *common.CollectionConfigPackage {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+func (m *CommitChaincodeDefinitionArgs) GetInitRequired() bool {
+	if m != nil {
+		return m.InitRequired
+	}
+	return false
+}
+
+// CommitChaincodeDefinitionResult is the message returned by
+// `+lifecycle.CommitChaincodeDefinition`. Currently it returns
+// nothing, but may be extended in the future.
+type CommitChaincodeDefinitionResult struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommitChaincodeDefinitionResult) Reset()         { *m = CommitChaincodeDefinitionResult{} }
+func (m *CommitChaincodeDefinitionResult) String() string { return proto.CompactTextString(m) }
+func (*CommitChaincodeDefinitionResult) ProtoMessage()    {}
+func (*CommitChaincodeDefinitionResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_fc74faec12be9f0d, []int{9}
+}
+func (m *CommitChaincodeDefinitionResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitChaincodeDefinitionResult.Unmarshal(m, b)
+}
+func (m *CommitChaincodeDefinitionResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitChaincodeDefinitionResult.Marshal(b, m, deterministic)
+}
+func (dst *CommitChaincodeDefinitionResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitChaincodeDefinitionResult.Merge(dst, src)
+}
+func (m *CommitChaincodeDefinitionResult) XXX_Size() int {
+	return xxx_messageInfo_CommitChaincodeDefinitionResult.Size(m)
+}
+func (m *CommitChaincodeDefinitionResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitChaincodeDefinitionResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitChaincodeDefinitionResult proto.InternalMessageInfo
+
+// QueryChaincodeDefinitionArgs is the message used as arguments to
+// `+lifecycle.QueryChaincodeDefinition`.
+type QueryChaincodeDefinitionArgs struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryChaincodeDefinitionArgs) Reset()         { *m = QueryChaincodeDefinitionArgs{} }
+func (m *QueryChaincodeDefinitionArgs) String() string { return proto.CompactTextString(m) }
+func (*QueryChaincodeDefinitionArgs) ProtoMessage()    {}
+func (*QueryChaincodeDefinitionArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_fc74faec12be9f0d, []int{10}
+}
+func (m *QueryChaincodeDefinitionArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryChaincodeDefinitionArgs.Unmarshal(m, b)
+}
+func (m *QueryChaincodeDefinitionArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryChaincodeDefinitionArgs.Marshal(b, m, deterministic)
+}
+func (dst *QueryChaincodeDefinitionArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryChaincodeDefinitionArgs.Merge(dst, src)
+}
+func (m *QueryChaincodeDefinitionArgs) XXX_Size() int {
+	return xxx_messageInfo_QueryChaincodeDefinitionArgs.Size(m)
+}
+func (m *QueryChaincodeDefinitionArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryChaincodeDefinitionArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryChaincodeDefinitionArgs proto.InternalMessageInfo
+
+func (m *QueryChaincodeDefinitionArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// QueryChaincodeDefinitionResult is the message returned by
+// `+lifecycle.QueryChaincodeDefinition`.
+type QueryChaincodeDefinitionResult struct {
+	Sequence             int64                           `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Version              string                          `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	EndorsementPlugin    string                          `protobuf:"bytes,3,opt,name=endorsement_plugin,json=endorsementPlugin,proto3" json:"endorsement_plugin,omitempty"`
+	ValidationPlugin     string                          `protobuf:"bytes,4,opt,name=validation_plugin,json=validationPlugin,proto3" json:"validation_plugin,omitempty"`
+	ValidationParameter  []byte                          `protobuf:"bytes,5,opt,name=validation_parameter,json=validationParameter,proto3" json:"validation_parameter,omitempty"`
+	Collections          *common.CollectionConfigPackage `protobuf:"bytes,6,opt,name=collections,proto3" json:"collections,omitempty"`
+	InitRequired         bool                            `protobuf:"varint,7,opt,name=init_required,json=initRequired,proto3" json:"init_required,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *QueryChaincodeDefinitionResult) Reset()         { *m = QueryChaincodeDefinitionResult{} }
+func (m *QueryChaincodeDefinitionResult) String() string { return proto.CompactTextString(m) }
+func (*QueryChaincodeDefinitionResult) ProtoMessage()    {}
+func (*QueryChaincodeDefinitionResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_fc74faec12be9f0d, []int{11}
+}
+func (m *QueryChaincodeDefinitionResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryChaincodeDefinitionResult.Unmarshal(m, b)
+}
+func (m *QueryChaincodeDefinitionResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryChaincodeDefinitionResult.Marshal(b, m, deterministic)
+}
+func (dst *QueryChaincodeDefinitionResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryChaincodeDefinitionResult.Merge(dst, src)
+}
+func (m *QueryChaincodeDefinitionResult) XXX_Size() int {
+	return xxx_messageInfo_QueryChaincodeDefinitionResult.Size(m)
+}
+func (m *QueryChaincodeDefinitionResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryChaincodeDefinitionResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryChaincodeDefinitionResult proto.InternalMessageInfo
+
+func (m *QueryChaincodeDefinitionResult) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *QueryChaincodeDefinitionResult) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *QueryChaincodeDefinitionResult) GetEndorsementPlugin() string {
+	if m != nil {
+		return m.EndorsementPlugin
+	}
+	return ""
+}
+
+func (m *QueryChaincodeDefinitionResult) GetValidationPlugin() string {
+	if m != nil {
+		return m.ValidationPlugin
+	}
+	return ""
+}
+
+func (m *QueryChaincodeDefinitionResult) GetValidationParameter() []byte {
+	if m != nil {
+		return m.ValidationParameter
+	}
+	return nil
+}
+
+func (m *QueryChaincodeDefinitionResult) GetCollections() *common.CollectionConfigPackage {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+func (m *QueryChaincodeDefinitionResult) GetInitRequired() bool {
+	if m != nil {
+		return m.InitRequired
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*InstallChaincodeArgs)(nil), "lifecycle.InstallChaincodeArgs")
 	proto.RegisterType((*InstallChaincodeResult)(nil), "lifecycle.InstallChaincodeResult")
 	proto.RegisterType((*QueryInstalledChaincodeArgs)(nil), "lifecycle.QueryInstalledChaincodeArgs")
 	proto.RegisterType((*QueryInstalledChaincodeResult)(nil), "lifecycle.QueryInstalledChaincodeResult")
+	proto.RegisterType((*ApproveChaincodeDefinitionForMyOrgArgs)(nil), "lifecycle.ApproveChaincodeDefinitionForMyOrgArgs")
+	proto.RegisterType((*ApproveChaincodeDefinitionForMyOrgResult)(nil), "lifecycle.ApproveChaincodeDefinitionForMyOrgResult")
+	proto.RegisterType((*CheckCommitReadinessArgs)(nil), "lifecycle.CheckCommitReadinessArgs")
+	proto.RegisterType((*CheckCommitReadinessResult)(nil), "lifecycle.CheckCommitReadinessResult")
+	proto.RegisterMapType((map[string]bool)(nil), "lifecycle.CheckCommitReadinessResult.ApprovalsEntry")
+	proto.RegisterType((*CommitChaincodeDefinitionArgs)(nil), "lifecycle.CommitChaincodeDefinitionArgs")
+	proto.RegisterType((*CommitChaincodeDefinitionResult)(nil), "lifecycle.CommitChaincodeDefinitionResult")
+	proto.RegisterType((*QueryChaincodeDefinitionArgs)(nil), "lifecycle.QueryChaincodeDefinitionArgs")
+	proto.RegisterType((*QueryChaincodeDefinitionResult)(nil), "lifecycle.QueryChaincodeDefinitionResult")
 }
 
 func init() {
-	proto.RegisterFile("peer/lifecycle/lifecycle.proto", fileDescriptor_lifecycle_f98901bea638af10)
-}
-
-var fileDescriptor_lifecycle_f98901bea638af10 = []byte{
-	// 255 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x91, 0x3f, 0x4f, 0xc3, 0x30,
-	0x10, 0xc5, 0x15, 0x40, 0xa0, 0x9e, 0x3a, 0x59, 0x08, 0x82, 0x10, 0xa8, 0xca, 0xd4, 0xa1, 0xb2,
-	0x87, 0x6e, 0x6c, 0xc0, 0x84, 0x58, 0x20, 0x23, 0x4b, 0xe5, 0x38, 0x57, 0xdb, 0xc2, 0x8d, 0xa3,
-	0x73, 0x82, 0x94, 0x8d, 0x8f, 0x8e, 0x6a, 0xb7, 0xe1, 0x8f, 0xd4, 0x89, 0xed, 0xf9, 0xd9, 0xef,
-	0xfc, 0xd3, 0x3b, 0xb8, 0x6d, 0x11, 0x49, 0x38, 0xbb, 0x46, 0x35, 0x28, 0x87, 0xdf, 0x8a, 0xb7,
-	0xe4, 0x3b, 0xcf, 0x26, 0xa3, 0x51, 0x7c, 0x66, 0x70, 0xfe, 0xd4, 0x84, 0x4e, 0x3a, 0xf7, 0x68,
-	0xa4, 0x6d, 0x94, 0xaf, 0xf1, 0x9e, 0x74, 0x60, 0x0c, 0x4e, 0x1a, 0xb9, 0xc1, 0x3c, 0x9b, 0x65,
-	0xf3, 0x49, 0x19, 0x35, 0xcb, 0xe1, 0xec, 0x03, 0x29, 0x58, 0xdf, 0xe4, 0x47, 0xd1, 0xde, 0x1f,
-	0xd9, 0x1d, 0x5c, 0xa9, 0x7d, 0x7c, 0x65, 0xd3, 0xbc, 0x55, 0x2b, 0xd5, 0xbb, 0xd4, 0x98, 0x1f,
-	0xcf, 0xb2, 0xf9, 0xb4, 0xbc, 0x1c, 0x1f, 0xec, 0xfe, 0x7b, 0x49, 0xd7, 0xc5, 0x02, 0x2e, 0xfe,
-	0x12, 0x94, 0x18, 0x7a, 0xd7, 0x6d, 0x19, 0x8c, 0x0c, 0x26, 0x32, 0x4c, 0xcb, 0xa8, 0x8b, 0x67,
-	0xb8, 0x7e, 0xed, 0x91, 0x86, 0x5d, 0x04, 0xeb, 0x7f, 0x60, 0x17, 0x4b, 0xb8, 0x39, 0x30, 0xec,
-	0x30, 0xc1, 0x83, 0x82, 0x85, 0x27, 0xcd, 0xcd, 0xd0, 0x22, 0x39, 0xac, 0x35, 0x12, 0x5f, 0xcb,
-	0x8a, 0xac, 0x4a, 0xed, 0x06, 0xbe, 0x6d, 0x9f, 0x8f, 0x15, 0xbf, 0x2d, 0xb5, 0xed, 0x4c, 0x5f,
-	0x71, 0xe5, 0x37, 0xe2, 0x47, 0x48, 0xa4, 0x90, 0x48, 0x21, 0xf1, 0x7b, 0x65, 0xd5, 0x69, 0xb4,
-	0x97, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xd3, 0x6e, 0xec, 0xb6, 0xcb, 0x01, 0x00, 0x00,
+	proto.RegisterFile("peer/lifecycle/lifecycle.proto", fileDescriptor_lifecycle_fc74faec12be9f0d)
+}
+
+var fileDescriptor_lifecycle_fc74faec12be9f0d = []byte{
+	// 638 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x96, 0xdf, 0x6e, 0xd3, 0x3e,
+	0x14, 0xc7, 0x95, 0xf4, 0xff, 0xd9, 0x7e, 0x3f, 0x6d, 0x61, 0x62, 0xa1, 0xb0, 0xad, 0x04, 0x09,
+	0x55, 0x30, 0x52, 0xb1, 0x71, 0x81, 0x26, 0x6e, 0x4a, 0x01, 0x09, 0x21, 0xc4, 0xc8, 0x25, 0x37,
+	0x95, 0x97, 0x9c, 0xa6, 0xd6, 0x12, 0x3b, 0x73, 0x92, 0x4a, 0xbd, 0xe3, 0x89, 0x78, 0x13, 0x9e,
+	0x80, 0x1b, 0x5e, 0x04, 0x09, 0xc5, 0x4e, 0x9b, 0x16, 0xd2, 0xb1, 0x8a, 0x5e, 0x72, 0xe7, 0xf8,
+	0x9c, 0xaf, 0xfd, 0xd5, 0xf9, 0x1c, 0x3b, 0x86, 0xc3, 0x08, 0x51, 0xf4, 0x02, 0x3a, 0x42, 0x77,
+	0xea, 0x06, 0x58, 0x8c, 0xec, 0x48, 0xf0, 0x84, 0x1b, 0xad, 0xf9, 0x44, 0x7b, 0xdf, 0xe5, 0x61,
+	0xc8, 0x59, 0xcf, 0xe5, 0x41, 0x80, 0x6e, 0x42, 0x39, 0x53, 0x39, 0xd6, 0x67, 0x0d, 0xf6, 0xde,
+	0xb2, 0x38, 0x21, 0x41, 0x30, 0x18, 0x13, 0xca, 0x5c, 0xee, 0x61, 0x5f, 0xf8, 0xb1, 0x61, 0x40,
+	0x95, 0x91, 0x10, 0x4d, 0xad, 0xa3, 0x75, 0x5b, 0x8e, 0x1c, 0x1b, 0x26, 0x34, 0x26, 0x28, 0x62,
+	0xca, 0x99, 0xa9, 0xcb, 0xe9, 0xd9, 0xa7, 0x71, 0x06, 0x77, 0xdc, 0x99, 0x7c, 0x48, 0xd5, 0x7a,
+	0xc3, 0x88, 0xb8, 0x97, 0xc4, 0x47, 0xb3, 0xd2, 0xd1, 0xba, 0xdb, 0xce, 0xfe, 0x3c, 0x21, 0xdf,
+	0xef, 0x5c, 0x85, 0xad, 0x63, 0xb8, 0xfd, 0xab, 0x03, 0x07, 0xe3, 0x34, 0x48, 0x32, 0x0f, 0x63,
+	0x12, 0x8f, 0xa5, 0x87, 0x6d, 0x47, 0x8e, 0xad, 0x77, 0x70, 0xf7, 0x63, 0x8a, 0x62, 0x9a, 0x4b,
+	0xd0, 0xfb, 0x0b, 0xdb, 0xd6, 0x29, 0x1c, 0xac, 0x58, 0xec, 0x1a, 0x07, 0x3f, 0x74, 0x78, 0xd8,
+	0x8f, 0x22, 0xc1, 0x27, 0x38, 0x4f, 0x7f, 0x85, 0x23, 0xca, 0x68, 0x56, 0xd7, 0x37, 0x5c, 0xbc,
+	0x9f, 0x7e, 0x10, 0xbe, 0x74, 0xd3, 0x86, 0x66, 0x8c, 0x57, 0x29, 0x32, 0x57, 0x39, 0xaa, 0x38,
+	0xf3, 0xef, 0xb9, 0x53, 0xbd, 0xdc, 0x69, 0x65, 0xb9, 0xc0, 0x33, 0x23, 0xd5, 0xc2, 0x88, 0xf1,
+	0x04, 0x0c, 0x64, 0x1e, 0x17, 0x31, 0x86, 0xc8, 0x92, 0x61, 0x14, 0xa4, 0x3e, 0x65, 0x66, 0x4d,
+	0x0a, 0x77, 0x17, 0x22, 0xe7, 0x32, 0x60, 0x3c, 0x86, 0xdd, 0x09, 0x09, 0xa8, 0x47, 0x32, 0x9b,
+	0xb3, 0xec, 0xba, 0xcc, 0xde, 0x29, 0x02, 0x79, 0xf2, 0x53, 0xd8, 0x5b, 0x4c, 0x26, 0x82, 0x84,
+	0x98, 0xa0, 0x30, 0x1b, 0x72, 0xff, 0x5b, 0x0b, 0xf9, 0xb3, 0x90, 0xd1, 0x87, 0xad, 0xa2, 0xbd,
+	0x62, 0xb3, 0xd9, 0xd1, 0xba, 0x5b, 0x27, 0x47, 0xb6, 0xea, 0x3c, 0x7b, 0x30, 0x0f, 0x0d, 0x38,
+	0x1b, 0x51, 0x3f, 0xa7, 0xef, 0x2c, 0x6a, 0x8c, 0x07, 0xf0, 0x5f, 0x56, 0xc6, 0xa1, 0xc0, 0xab,
+	0x94, 0x0a, 0xf4, 0xcc, 0x56, 0x47, 0xeb, 0x36, 0x9d, 0xed, 0x6c, 0xd2, 0xc9, 0xe7, 0xac, 0x47,
+	0xd0, 0xfd, 0x73, 0xf9, 0x15, 0x3f, 0xeb, 0x9b, 0x0e, 0xe6, 0x60, 0x8c, 0xee, 0xe5, 0x80, 0x87,
+	0x61, 0xb6, 0x06, 0xf1, 0x28, 0xc3, 0x38, 0xde, 0x30, 0x9d, 0x72, 0x12, 0xd5, 0xb5, 0x48, 0xd4,
+	0xd6, 0x24, 0x51, 0xbf, 0x31, 0x89, 0xc6, 0x26, 0x48, 0x34, 0x4b, 0x48, 0x7c, 0xd1, 0xa0, 0x5d,
+	0x56, 0xdd, 0xfc, 0xf0, 0x38, 0xd0, 0x22, 0x12, 0x14, 0x09, 0x62, 0x53, 0xeb, 0x54, 0xba, 0x5b,
+	0x27, 0xcf, 0xec, 0xe2, 0x92, 0x5a, 0xad, 0xb4, 0xfb, 0x33, 0xd9, 0x6b, 0x96, 0x88, 0xa9, 0x53,
+	0x2c, 0xd3, 0x7e, 0x01, 0xff, 0x2f, 0x07, 0x8d, 0x1d, 0xa8, 0x5c, 0xe2, 0x34, 0x3f, 0xf0, 0xd9,
+	0xd0, 0xd8, 0x83, 0xda, 0x84, 0x04, 0xa9, 0x82, 0xd7, 0x74, 0xd4, 0xc7, 0x99, 0xfe, 0x5c, 0xb3,
+	0xbe, 0xeb, 0x70, 0xa0, 0x76, 0x2c, 0x69, 0x9d, 0x7f, 0x3d, 0xb1, 0x89, 0x9e, 0xb8, 0x0f, 0x47,
+	0x2b, 0x2b, 0x9c, 0x1f, 0xca, 0x13, 0xb8, 0x27, 0x6f, 0xdd, 0x55, 0x0c, 0x4a, 0xee, 0x70, 0xeb,
+	0xab, 0x0e, 0x87, 0xab, 0x44, 0x79, 0xbb, 0x5d, 0x87, 0x6e, 0xf5, 0x9f, 0xab, 0x1c, 0x53, 0x65,
+	0x2d, 0x4c, 0xd5, 0x35, 0x31, 0xd5, 0x6e, 0x8c, 0xa9, 0xbe, 0x09, 0x4c, 0x8d, 0xdf, 0x31, 0xbd,
+	0x74, 0xe1, 0x98, 0x0b, 0xdf, 0x1e, 0x4f, 0x23, 0x14, 0x01, 0x7a, 0x3e, 0x0a, 0x7b, 0x44, 0x2e,
+	0x04, 0x75, 0xd5, 0xbb, 0x20, 0xb6, 0xb3, 0xb7, 0x45, 0x71, 0x58, 0x3f, 0x9d, 0xfa, 0x34, 0x19,
+	0xa7, 0x17, 0x99, 0x91, 0xde, 0x82, 0xa8, 0xa7, 0x44, 0x3d, 0x25, 0xea, 0x2d, 0x3f, 0x48, 0x2e,
+	0xea, 0x72, 0xfa, 0xf4, 0xe7, 0x00, 0x4e, 0xd3, 0xd7, 0xe3, 0xa9, 0x08, 0x00, 0x00,
 }
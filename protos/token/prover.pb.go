@@ -31,7 +31,12 @@ type TokenToIssue struct {
 	// Type refers to the token type
 	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
 	// Quantity refers to the number of token units to be issued
-	Quantity             uint64   `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Quantity uint64 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Nft describes the non-fungible token to be issued, if any
+	Nft *NonFungibleToken `protobuf:"bytes,4,opt,name=nft,proto3" json:"nft,omitempty"`
+	// ZkOutput is the serialized output of a token of the zkat driver,
+	// which only its owner can open
+	ZkOutput             []byte   `protobuf:"bytes,5,opt,name=zk_output,json=zkOutput,proto3" json:"zk_output,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -41,7 +46,7 @@ func (m *TokenToIssue) Reset()         { *m = TokenToIssue{} }
 func (m *TokenToIssue) String() string { return proto.CompactTextString(m) }
 func (*TokenToIssue) ProtoMessage()    {}
 func (*TokenToIssue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{0}
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{0}
 }
 func (m *TokenToIssue) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TokenToIssue.Unmarshal(m, b)
@@ -82,6 +87,13 @@ func (m *TokenToIssue) GetQuantity() uint64 {
 	return 0
 }
 
+func (m *TokenToIssue) GetNft() *NonFungibleToken {
+	if m != nil {
+		return m.Nft
+	}
+	return nil
+}
+
 // RecipientTransferShare describes how much a recipient will receive in a token transfer
 type RecipientTransferShare struct {
 	// Recipient refers to the prospective owner of a transferred token
@@ -97,7 +109,7 @@ func (m *RecipientTransferShare) Reset()         { *m = RecipientTransferShare{}
 func (m *RecipientTransferShare) String() string { return proto.CompactTextString(m) }
 func (*RecipientTransferShare) ProtoMessage()    {}
 func (*RecipientTransferShare) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{1}
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{1}
 }
 func (m *RecipientTransferShare) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RecipientTransferShare.Unmarshal(m, b)
@@ -138,7 +150,12 @@ type TokenOutput struct {
 	// Type is the type of the token
 	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
 	// Quantity represents the number for this type of token
-	Quantity             uint64   `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Quantity uint64 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Nft describes the non-fungible token, if any
+	Nft *NonFungibleToken `protobuf:"bytes,4,opt,name=nft,proto3" json:"nft,omitempty"`
+	// ZkOutput is the serialized output of a token of the zkat driver,
+	// which only its owner can open
+	ZkOutput             []byte   `protobuf:"bytes,5,opt,name=zk_output,json=zkOutput,proto3" json:"zk_output,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -148,7 +165,7 @@ func (m *TokenOutput) Reset()         { *m = TokenOutput{} }
 func (m *TokenOutput) String() string { return proto.CompactTextString(m) }
 func (*TokenOutput) ProtoMessage()    {}
 func (*TokenOutput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{2}
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{2}
 }
 func (m *TokenOutput) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TokenOutput.Unmarshal(m, b)
@@ -189,6 +206,20 @@ func (m *TokenOutput) GetQuantity() uint64 {
 	return 0
 }
 
+func (m *TokenOutput) GetNft() *NonFungibleToken {
+	if m != nil {
+		return m.Nft
+	}
+	return nil
+}
+
+func (m *TokenOutput) GetZkOutput() []byte {
+	if m != nil {
+		return m.ZkOutput
+	}
+	return nil
+}
+
 // UnspentTokens is used to hold the output of listRequest
 type UnspentTokens struct {
 	Tokens               []*TokenOutput `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
@@ -201,7 +232,7 @@ func (m *UnspentTokens) Reset()         { *m = UnspentTokens{} }
 func (m *UnspentTokens) String() string { return proto.CompactTextString(m) }
 func (*UnspentTokens) ProtoMessage()    {}
 func (*UnspentTokens) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{3}
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{3}
 }
 func (m *UnspentTokens) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UnspentTokens.Unmarshal(m, b)
@@ -231,6 +262,7 @@ func (m *UnspentTokens) GetTokens() []*TokenOutput {
 // ListRequest is used to request a list of unspent tokens
 type ListRequest struct {
 	Credential           []byte   `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	Delegated            bool     `protobuf:"varint,2,opt,name=delegated,proto3" json:"delegated,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -240,7 +272,7 @@ func (m *ListRequest) Reset()         { *m = ListRequest{} }
 func (m *ListRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRequest) ProtoMessage()    {}
 func (*ListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{4}
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{4}
 }
 func (m *ListRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
@@ -267,6 +299,13 @@ func (m *ListRequest) GetCredential() []byte {
 	return nil
 }
 
+func (m *ListRequest) GetDelegated() bool {
+	if m != nil {
+		return m.Delegated
+	}
+	return false
+}
+
 // ImportRequest is used to request creation of imports
 type ImportRequest struct {
 	// Credential contains information about the party who is requesting the operation
@@ -283,7 +322,7 @@ func (m *ImportRequest) Reset()         { *m = ImportRequest{} }
 func (m *ImportRequest) String() string { return proto.CompactTextString(m) }
 func (*ImportRequest) ProtoMessage()    {}
 func (*ImportRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{5}
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{5}
 }
 func (m *ImportRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ImportRequest.Unmarshal(m, b)
@@ -331,7 +370,7 @@ func (m *TransferRequest) Reset()         { *m = TransferRequest{} }
 func (m *TransferRequest) String() string { return proto.CompactTextString(m) }
 func (*TransferRequest) ProtoMessage()    {}
 func (*TransferRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{6}
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{6}
 }
 func (m *TransferRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TransferRequest.Unmarshal(m, b)
@@ -390,7 +429,7 @@ func (m *RedeemRequest) Reset()         { *m = RedeemRequest{} }
 func (m *RedeemRequest) String() string { return proto.CompactTextString(m) }
 func (*RedeemRequest) ProtoMessage()    {}
 func (*RedeemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{7}
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{7}
 }
 func (m *RedeemRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RedeemRequest.Unmarshal(m, b)
@@ -446,7 +485,7 @@ func (m *AllowanceRecipientShare) Reset()         { *m = AllowanceRecipientShare
 func (m *AllowanceRecipientShare) String() string { return proto.CompactTextString(m) }
 func (*AllowanceRecipientShare) ProtoMessage()    {}
 func (*AllowanceRecipientShare) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{8}
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{8}
 }
 func (m *AllowanceRecipientShare) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AllowanceRecipientShare.Unmarshal(m, b)
@@ -497,7 +536,7 @@ func (m *ApproveRequest) Reset()         { *m = ApproveRequest{} }
 func (m *ApproveRequest) String() string { return proto.CompactTextString(m) }
 func (*ApproveRequest) ProtoMessage()    {}
 func (*ApproveRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{9}
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{9}
 }
 func (m *ApproveRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ApproveRequest.Unmarshal(m, b)
@@ -556,7 +595,7 @@ func (m *ExpectationRequest) Reset()         { *m = ExpectationRequest{} }
 func (m *ExpectationRequest) String() string { return proto.CompactTextString(m) }
 func (*ExpectationRequest) ProtoMessage()    {}
 func (*ExpectationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{10}
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{10}
 }
 func (m *ExpectationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExpectationRequest.Unmarshal(m, b)
@@ -597,6 +636,448 @@ func (m *ExpectationRequest) GetTokenIds() [][]byte {
 	return nil
 }
 
+// SupplyRequest is used to request the outstanding supply of token types
+type SupplyRequest struct {
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// Types are the token types whose supply is requested; all types if empty
+	Types                []string `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SupplyRequest) Reset()         { *m = SupplyRequest{} }
+func (m *SupplyRequest) String() string { return proto.CompactTextString(m) }
+func (*SupplyRequest) ProtoMessage()    {}
+func (*SupplyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{11}
+}
+func (m *SupplyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SupplyRequest.Unmarshal(m, b)
+}
+func (m *SupplyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SupplyRequest.Marshal(b, m, deterministic)
+}
+func (dst *SupplyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SupplyRequest.Merge(dst, src)
+}
+func (m *SupplyRequest) XXX_Size() int {
+	return xxx_messageInfo_SupplyRequest.Size(m)
+}
+func (m *SupplyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SupplyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SupplyRequest proto.InternalMessageInfo
+
+func (m *SupplyRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *SupplyRequest) GetTypes() []string {
+	if m != nil {
+		return m.Types
+	}
+	return nil
+}
+
+// TokenSupplies is used to hold the output of SupplyRequest
+type TokenSupplies struct {
+	Supplies             []*TokenSupply `protobuf:"bytes,1,rep,name=supplies,proto3" json:"supplies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *TokenSupplies) Reset()         { *m = TokenSupplies{} }
+func (m *TokenSupplies) String() string { return proto.CompactTextString(m) }
+func (*TokenSupplies) ProtoMessage()    {}
+func (*TokenSupplies) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{12}
+}
+func (m *TokenSupplies) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenSupplies.Unmarshal(m, b)
+}
+func (m *TokenSupplies) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenSupplies.Marshal(b, m, deterministic)
+}
+func (dst *TokenSupplies) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenSupplies.Merge(dst, src)
+}
+func (m *TokenSupplies) XXX_Size() int {
+	return xxx_messageInfo_TokenSupplies.Size(m)
+}
+func (m *TokenSupplies) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenSupplies.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenSupplies proto.InternalMessageInfo
+
+func (m *TokenSupplies) GetSupplies() []*TokenSupply {
+	if m != nil {
+		return m.Supplies
+	}
+	return nil
+}
+
+// LineageRequest is used to request the spend history of a token output
+type LineageRequest struct {
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// TokenId is the identifier of the token output whose lineage is requested
+	TokenId              []byte   `protobuf:"bytes,2,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LineageRequest) Reset()         { *m = LineageRequest{} }
+func (m *LineageRequest) String() string { return proto.CompactTextString(m) }
+func (*LineageRequest) ProtoMessage()    {}
+func (*LineageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{13}
+}
+func (m *LineageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LineageRequest.Unmarshal(m, b)
+}
+func (m *LineageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LineageRequest.Marshal(b, m, deterministic)
+}
+func (dst *LineageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LineageRequest.Merge(dst, src)
+}
+func (m *LineageRequest) XXX_Size() int {
+	return xxx_messageInfo_LineageRequest.Size(m)
+}
+func (m *LineageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LineageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LineageRequest proto.InternalMessageInfo
+
+func (m *LineageRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *LineageRequest) GetTokenId() []byte {
+	if m != nil {
+		return m.TokenId
+	}
+	return nil
+}
+
+// OwnerHistoryRequest is used to request the token transactions involving an owner
+// that were committed in a range of blocks
+type OwnerHistoryRequest struct {
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// Owner is the owner whose token transactions are requested
+	Owner []byte `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	// StartBlock is the first block of the range
+	StartBlock uint64 `protobuf:"varint,3,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	// EndBlock is the last block of the range
+	EndBlock             uint64   `protobuf:"varint,4,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OwnerHistoryRequest) Reset()         { *m = OwnerHistoryRequest{} }
+func (m *OwnerHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*OwnerHistoryRequest) ProtoMessage()    {}
+func (*OwnerHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{14}
+}
+func (m *OwnerHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OwnerHistoryRequest.Unmarshal(m, b)
+}
+func (m *OwnerHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OwnerHistoryRequest.Marshal(b, m, deterministic)
+}
+func (dst *OwnerHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OwnerHistoryRequest.Merge(dst, src)
+}
+func (m *OwnerHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_OwnerHistoryRequest.Size(m)
+}
+func (m *OwnerHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OwnerHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OwnerHistoryRequest proto.InternalMessageInfo
+
+func (m *OwnerHistoryRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *OwnerHistoryRequest) GetOwner() []byte {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+func (m *OwnerHistoryRequest) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *OwnerHistoryRequest) GetEndBlock() uint64 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+// BalanceRequest is used to request the unspent quantity of each token type
+type BalanceRequest struct {
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// Owner restricts the balances to the tokens of the given owner; all owners if empty
+	Owner                []byte   `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BalanceRequest) Reset()         { *m = BalanceRequest{} }
+func (m *BalanceRequest) String() string { return proto.CompactTextString(m) }
+func (*BalanceRequest) ProtoMessage()    {}
+func (*BalanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{15}
+}
+func (m *BalanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BalanceRequest.Unmarshal(m, b)
+}
+func (m *BalanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BalanceRequest.Marshal(b, m, deterministic)
+}
+func (dst *BalanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BalanceRequest.Merge(dst, src)
+}
+func (m *BalanceRequest) XXX_Size() int {
+	return xxx_messageInfo_BalanceRequest.Size(m)
+}
+func (m *BalanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BalanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BalanceRequest proto.InternalMessageInfo
+
+func (m *BalanceRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *BalanceRequest) GetOwner() []byte {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+// TokenTransactionRecord is a committed token transaction
+type TokenTransactionRecord struct {
+	// TxId is the identifier of the transaction
+	TxId string `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// BlockNumber is the number of the block that contains the transaction
+	BlockNumber uint64 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	// TokenTransaction is the committed token transaction
+	TokenTransaction     *TokenTransaction `protobuf:"bytes,3,opt,name=token_transaction,json=tokenTransaction,proto3" json:"token_transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *TokenTransactionRecord) Reset()         { *m = TokenTransactionRecord{} }
+func (m *TokenTransactionRecord) String() string { return proto.CompactTextString(m) }
+func (*TokenTransactionRecord) ProtoMessage()    {}
+func (*TokenTransactionRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{16}
+}
+func (m *TokenTransactionRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenTransactionRecord.Unmarshal(m, b)
+}
+func (m *TokenTransactionRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenTransactionRecord.Marshal(b, m, deterministic)
+}
+func (dst *TokenTransactionRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenTransactionRecord.Merge(dst, src)
+}
+func (m *TokenTransactionRecord) XXX_Size() int {
+	return xxx_messageInfo_TokenTransactionRecord.Size(m)
+}
+func (m *TokenTransactionRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenTransactionRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenTransactionRecord proto.InternalMessageInfo
+
+func (m *TokenTransactionRecord) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *TokenTransactionRecord) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *TokenTransactionRecord) GetTokenTransaction() *TokenTransaction {
+	if m != nil {
+		return m.TokenTransaction
+	}
+	return nil
+}
+
+// TokenHistory is used to hold the output of LineageRequest and OwnerHistoryRequest
+type TokenHistory struct {
+	Transactions         []*TokenTransactionRecord `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *TokenHistory) Reset()         { *m = TokenHistory{} }
+func (m *TokenHistory) String() string { return proto.CompactTextString(m) }
+func (*TokenHistory) ProtoMessage()    {}
+func (*TokenHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{17}
+}
+func (m *TokenHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenHistory.Unmarshal(m, b)
+}
+func (m *TokenHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenHistory.Marshal(b, m, deterministic)
+}
+func (dst *TokenHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenHistory.Merge(dst, src)
+}
+func (m *TokenHistory) XXX_Size() int {
+	return xxx_messageInfo_TokenHistory.Size(m)
+}
+func (m *TokenHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenHistory proto.InternalMessageInfo
+
+func (m *TokenHistory) GetTransactions() []*TokenTransactionRecord {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+// TokenBalance is the unspent quantity of a token type
+type TokenBalance struct {
+	Type     string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Quantity uint64 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Outputs is the number of unspent outputs that make up the quantity
+	Outputs              uint64   `protobuf:"varint,3,opt,name=outputs,proto3" json:"outputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenBalance) Reset()         { *m = TokenBalance{} }
+func (m *TokenBalance) String() string { return proto.CompactTextString(m) }
+func (*TokenBalance) ProtoMessage()    {}
+func (*TokenBalance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{18}
+}
+func (m *TokenBalance) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenBalance.Unmarshal(m, b)
+}
+func (m *TokenBalance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenBalance.Marshal(b, m, deterministic)
+}
+func (dst *TokenBalance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenBalance.Merge(dst, src)
+}
+func (m *TokenBalance) XXX_Size() int {
+	return xxx_messageInfo_TokenBalance.Size(m)
+}
+func (m *TokenBalance) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenBalance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenBalance proto.InternalMessageInfo
+
+func (m *TokenBalance) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *TokenBalance) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *TokenBalance) GetOutputs() uint64 {
+	if m != nil {
+		return m.Outputs
+	}
+	return 0
+}
+
+// TokenBalances is used to hold the output of BalanceRequest
+type TokenBalances struct {
+	Balances             []*TokenBalance `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *TokenBalances) Reset()         { *m = TokenBalances{} }
+func (m *TokenBalances) String() string { return proto.CompactTextString(m) }
+func (*TokenBalances) ProtoMessage()    {}
+func (*TokenBalances) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{19}
+}
+func (m *TokenBalances) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenBalances.Unmarshal(m, b)
+}
+func (m *TokenBalances) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenBalances.Marshal(b, m, deterministic)
+}
+func (dst *TokenBalances) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenBalances.Merge(dst, src)
+}
+func (m *TokenBalances) XXX_Size() int {
+	return xxx_messageInfo_TokenBalances.Size(m)
+}
+func (m *TokenBalances) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenBalances.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenBalances proto.InternalMessageInfo
+
+func (m *TokenBalances) GetBalances() []*TokenBalance {
+	if m != nil {
+		return m.Balances
+	}
+	return nil
+}
+
 // Header is a generic replay prevention and identity message to include in a signed command
 type Header struct {
 	// Timestamp is the local time when the message was created
@@ -619,7 +1100,7 @@ func (m *Header) Reset()         { *m = Header{} }
 func (m *Header) String() string { return proto.CompactTextString(m) }
 func (*Header) ProtoMessage()    {}
 func (*Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{11}
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{20}
 }
 func (m *Header) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Header.Unmarshal(m, b)
@@ -681,6 +1162,10 @@ type Command struct {
 	//	*Command_ApproveRequest
 	//	*Command_TransferFromRequest
 	//	*Command_ExpectationRequest
+	//	*Command_SupplyRequest
+	//	*Command_LineageRequest
+	//	*Command_OwnerHistoryRequest
+	//	*Command_BalanceRequest
 	Payload              isCommand_Payload `protobuf_oneof:"payload"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
@@ -691,7 +1176,7 @@ func (m *Command) Reset()         { *m = Command{} }
 func (m *Command) String() string { return proto.CompactTextString(m) }
 func (*Command) ProtoMessage()    {}
 func (*Command) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{12}
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{21}
 }
 func (m *Command) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Command.Unmarshal(m, b)
@@ -750,6 +1235,22 @@ type Command_ExpectationRequest struct {
 	ExpectationRequest *ExpectationRequest `protobuf:"bytes,8,opt,name=expectation_request,json=expectationRequest,proto3,oneof"`
 }
 
+type Command_SupplyRequest struct {
+	SupplyRequest *SupplyRequest `protobuf:"bytes,9,opt,name=supply_request,json=supplyRequest,proto3,oneof"`
+}
+
+type Command_LineageRequest struct {
+	LineageRequest *LineageRequest `protobuf:"bytes,10,opt,name=lineage_request,json=lineageRequest,proto3,oneof"`
+}
+
+type Command_OwnerHistoryRequest struct {
+	OwnerHistoryRequest *OwnerHistoryRequest `protobuf:"bytes,11,opt,name=owner_history_request,json=ownerHistoryRequest,proto3,oneof"`
+}
+
+type Command_BalanceRequest struct {
+	BalanceRequest *BalanceRequest `protobuf:"bytes,12,opt,name=balance_request,json=balanceRequest,proto3,oneof"`
+}
+
 func (*Command_ImportRequest) isCommand_Payload() {}
 
 func (*Command_TransferRequest) isCommand_Payload() {}
@@ -764,6 +1265,14 @@ func (*Command_TransferFromRequest) isCommand_Payload() {}
 
 func (*Command_ExpectationRequest) isCommand_Payload() {}
 
+func (*Command_SupplyRequest) isCommand_Payload() {}
+
+func (*Command_LineageRequest) isCommand_Payload() {}
+
+func (*Command_OwnerHistoryRequest) isCommand_Payload() {}
+
+func (*Command_BalanceRequest) isCommand_Payload() {}
+
 func (m *Command) GetPayload() isCommand_Payload {
 	if m != nil {
 		return m.Payload
@@ -820,6 +1329,34 @@ func (m *Command) GetExpectationRequest() *ExpectationRequest {
 	return nil
 }
 
+func (m *Command) GetSupplyRequest() *SupplyRequest {
+	if x, ok := m.GetPayload().(*Command_SupplyRequest); ok {
+		return x.SupplyRequest
+	}
+	return nil
+}
+
+func (m *Command) GetLineageRequest() *LineageRequest {
+	if x, ok := m.GetPayload().(*Command_LineageRequest); ok {
+		return x.LineageRequest
+	}
+	return nil
+}
+
+func (m *Command) GetOwnerHistoryRequest() *OwnerHistoryRequest {
+	if x, ok := m.GetPayload().(*Command_OwnerHistoryRequest); ok {
+		return x.OwnerHistoryRequest
+	}
+	return nil
+}
+
+func (m *Command) GetBalanceRequest() *BalanceRequest {
+	if x, ok := m.GetPayload().(*Command_BalanceRequest); ok {
+		return x.BalanceRequest
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Command) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Command_OneofMarshaler, _Command_OneofUnmarshaler, _Command_OneofSizer, []interface{}{
@@ -830,6 +1367,10 @@ func (*Command) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error
 		(*Command_ApproveRequest)(nil),
 		(*Command_TransferFromRequest)(nil),
 		(*Command_ExpectationRequest)(nil),
+		(*Command_SupplyRequest)(nil),
+		(*Command_LineageRequest)(nil),
+		(*Command_OwnerHistoryRequest)(nil),
+		(*Command_BalanceRequest)(nil),
 	}
 }
 
@@ -872,6 +1413,26 @@ func _Command_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.ExpectationRequest); err != nil {
 			return err
 		}
+	case *Command_SupplyRequest:
+		b.EncodeVarint(9<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SupplyRequest); err != nil {
+			return err
+		}
+	case *Command_LineageRequest:
+		b.EncodeVarint(10<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.LineageRequest); err != nil {
+			return err
+		}
+	case *Command_OwnerHistoryRequest:
+		b.EncodeVarint(11<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OwnerHistoryRequest); err != nil {
+			return err
+		}
+	case *Command_BalanceRequest:
+		b.EncodeVarint(12<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.BalanceRequest); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Command.Payload has unexpected type %T", x)
@@ -938,6 +1499,38 @@ func _Command_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer
 		err := b.DecodeMessage(msg)
 		m.Payload = &Command_ExpectationRequest{msg}
 		return true, err
+	case 9: // payload.supply_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SupplyRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_SupplyRequest{msg}
+		return true, err
+	case 10: // payload.lineage_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LineageRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_LineageRequest{msg}
+		return true, err
+	case 11: // payload.owner_history_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(OwnerHistoryRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_OwnerHistoryRequest{msg}
+		return true, err
+	case 12: // payload.balance_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(BalanceRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_BalanceRequest{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -982,6 +1575,26 @@ func _Command_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Command_SupplyRequest:
+		s := proto.Size(x.SupplyRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_LineageRequest:
+		s := proto.Size(x.LineageRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_OwnerHistoryRequest:
+		s := proto.Size(x.OwnerHistoryRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_BalanceRequest:
+		s := proto.Size(x.BalanceRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1004,7 +1617,7 @@ func (m *SignedCommand) Reset()         { *m = SignedCommand{} }
 func (m *SignedCommand) String() string { return proto.CompactTextString(m) }
 func (*SignedCommand) ProtoMessage()    {}
 func (*SignedCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{13}
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{22}
 }
 func (m *SignedCommand) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedCommand.Unmarshal(m, b)
@@ -1058,7 +1671,7 @@ func (m *CommandResponseHeader) Reset()         { *m = CommandResponseHeader{} }
 func (m *CommandResponseHeader) String() string { return proto.CompactTextString(m) }
 func (*CommandResponseHeader) ProtoMessage()    {}
 func (*CommandResponseHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{14}
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{23}
 }
 func (m *CommandResponseHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandResponseHeader.Unmarshal(m, b)
@@ -1114,7 +1727,7 @@ func (m *Error) Reset()         { *m = Error{} }
 func (m *Error) String() string { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()    {}
 func (*Error) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{15}
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{24}
 }
 func (m *Error) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Error.Unmarshal(m, b)
@@ -1158,6 +1771,9 @@ type CommandResponse struct {
 	//	*CommandResponse_Err
 	//	*CommandResponse_TokenTransaction
 	//	*CommandResponse_UnspentTokens
+	//	*CommandResponse_TokenSupplies
+	//	*CommandResponse_TokenHistory
+	//	*CommandResponse_TokenBalances
 	Payload              isCommandResponse_Payload `protobuf_oneof:"payload"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
 	XXX_unrecognized     []byte                    `json:"-"`
@@ -1168,7 +1784,7 @@ func (m *CommandResponse) Reset()         { *m = CommandResponse{} }
 func (m *CommandResponse) String() string { return proto.CompactTextString(m) }
 func (*CommandResponse) ProtoMessage()    {}
 func (*CommandResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{16}
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{25}
 }
 func (m *CommandResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandResponse.Unmarshal(m, b)
@@ -1211,12 +1827,30 @@ type CommandResponse_UnspentTokens struct {
 	UnspentTokens *UnspentTokens `protobuf:"bytes,4,opt,name=unspent_tokens,json=unspentTokens,proto3,oneof"`
 }
 
+type CommandResponse_TokenSupplies struct {
+	TokenSupplies *TokenSupplies `protobuf:"bytes,5,opt,name=token_supplies,json=tokenSupplies,proto3,oneof"`
+}
+
+type CommandResponse_TokenHistory struct {
+	TokenHistory *TokenHistory `protobuf:"bytes,6,opt,name=token_history,json=tokenHistory,proto3,oneof"`
+}
+
+type CommandResponse_TokenBalances struct {
+	TokenBalances *TokenBalances `protobuf:"bytes,7,opt,name=token_balances,json=tokenBalances,proto3,oneof"`
+}
+
 func (*CommandResponse_Err) isCommandResponse_Payload() {}
 
 func (*CommandResponse_TokenTransaction) isCommandResponse_Payload() {}
 
 func (*CommandResponse_UnspentTokens) isCommandResponse_Payload() {}
 
+func (*CommandResponse_TokenSupplies) isCommandResponse_Payload() {}
+
+func (*CommandResponse_TokenHistory) isCommandResponse_Payload() {}
+
+func (*CommandResponse_TokenBalances) isCommandResponse_Payload() {}
+
 func (m *CommandResponse) GetPayload() isCommandResponse_Payload {
 	if m != nil {
 		return m.Payload
@@ -1245,12 +1879,36 @@ func (m *CommandResponse) GetUnspentTokens() *UnspentTokens {
 	return nil
 }
 
+func (m *CommandResponse) GetTokenSupplies() *TokenSupplies {
+	if x, ok := m.GetPayload().(*CommandResponse_TokenSupplies); ok {
+		return x.TokenSupplies
+	}
+	return nil
+}
+
+func (m *CommandResponse) GetTokenHistory() *TokenHistory {
+	if x, ok := m.GetPayload().(*CommandResponse_TokenHistory); ok {
+		return x.TokenHistory
+	}
+	return nil
+}
+
+func (m *CommandResponse) GetTokenBalances() *TokenBalances {
+	if x, ok := m.GetPayload().(*CommandResponse_TokenBalances); ok {
+		return x.TokenBalances
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*CommandResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _CommandResponse_OneofMarshaler, _CommandResponse_OneofUnmarshaler, _CommandResponse_OneofSizer, []interface{}{
 		(*CommandResponse_Err)(nil),
 		(*CommandResponse_TokenTransaction)(nil),
 		(*CommandResponse_UnspentTokens)(nil),
+		(*CommandResponse_TokenSupplies)(nil),
+		(*CommandResponse_TokenHistory)(nil),
+		(*CommandResponse_TokenBalances)(nil),
 	}
 }
 
@@ -1273,6 +1931,21 @@ func _CommandResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.UnspentTokens); err != nil {
 			return err
 		}
+	case *CommandResponse_TokenSupplies:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TokenSupplies); err != nil {
+			return err
+		}
+	case *CommandResponse_TokenHistory:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TokenHistory); err != nil {
+			return err
+		}
+	case *CommandResponse_TokenBalances:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TokenBalances); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("CommandResponse.Payload has unexpected type %T", x)
@@ -1307,6 +1980,30 @@ func _CommandResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *prot
 		err := b.DecodeMessage(msg)
 		m.Payload = &CommandResponse_UnspentTokens{msg}
 		return true, err
+	case 5: // payload.token_supplies
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TokenSupplies)
+		err := b.DecodeMessage(msg)
+		m.Payload = &CommandResponse_TokenSupplies{msg}
+		return true, err
+	case 6: // payload.token_history
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TokenHistory)
+		err := b.DecodeMessage(msg)
+		m.Payload = &CommandResponse_TokenHistory{msg}
+		return true, err
+	case 7: // payload.token_balances
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TokenBalances)
+		err := b.DecodeMessage(msg)
+		m.Payload = &CommandResponse_TokenBalances{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1331,6 +2028,21 @@ func _CommandResponse_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *CommandResponse_TokenSupplies:
+		s := proto.Size(x.TokenSupplies)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *CommandResponse_TokenHistory:
+		s := proto.Size(x.TokenHistory)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *CommandResponse_TokenBalances:
+		s := proto.Size(x.TokenBalances)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1353,7 +2065,7 @@ func (m *SignedCommandResponse) Reset()         { *m = SignedCommandResponse{} }
 func (m *SignedCommandResponse) String() string { return proto.CompactTextString(m) }
 func (*SignedCommandResponse) ProtoMessage()    {}
 func (*SignedCommandResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{17}
+	return fileDescriptor_prover_c60333b2c0db8dce, []int{26}
 }
 func (m *SignedCommandResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedCommandResponse.Unmarshal(m, b)
@@ -1399,6 +2111,15 @@ func init() {
 	proto.RegisterType((*AllowanceRecipientShare)(nil), "protos.AllowanceRecipientShare")
 	proto.RegisterType((*ApproveRequest)(nil), "protos.ApproveRequest")
 	proto.RegisterType((*ExpectationRequest)(nil), "protos.ExpectationRequest")
+	proto.RegisterType((*SupplyRequest)(nil), "protos.SupplyRequest")
+	proto.RegisterType((*TokenSupplies)(nil), "protos.TokenSupplies")
+	proto.RegisterType((*LineageRequest)(nil), "protos.LineageRequest")
+	proto.RegisterType((*OwnerHistoryRequest)(nil), "protos.OwnerHistoryRequest")
+	proto.RegisterType((*BalanceRequest)(nil), "protos.BalanceRequest")
+	proto.RegisterType((*TokenTransactionRecord)(nil), "protos.TokenTransactionRecord")
+	proto.RegisterType((*TokenHistory)(nil), "protos.TokenHistory")
+	proto.RegisterType((*TokenBalance)(nil), "protos.TokenBalance")
+	proto.RegisterType((*TokenBalances)(nil), "protos.TokenBalances")
 	proto.RegisterType((*Header)(nil), "protos.Header")
 	proto.RegisterType((*Command)(nil), "protos.Command")
 	proto.RegisterType((*SignedCommand)(nil), "protos.SignedCommand")
@@ -1488,72 +2209,98 @@ var _Prover_serviceDesc = grpc.ServiceDesc{
 	Metadata: "token/prover.proto",
 }
 
-func init() { proto.RegisterFile("token/prover.proto", fileDescriptor_prover_aacdf31643d38144) }
-
-var fileDescriptor_prover_aacdf31643d38144 = []byte{
-	// 1010 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdd, 0x6f, 0x1b, 0x45,
-	0x10, 0xf7, 0xd9, 0xae, 0x13, 0x8f, 0xbf, 0xd2, 0x4d, 0xd3, 0x9c, 0x0c, 0x69, 0xd3, 0x43, 0x42,
-	0x11, 0x1f, 0xb6, 0x14, 0x04, 0xaa, 0xa0, 0xaa, 0x48, 0xa1, 0x70, 0x41, 0x54, 0xb4, 0x1b, 0xf3,
-	0x82, 0x90, 0xac, 0xcd, 0xdd, 0xc6, 0x5e, 0xe1, 0xbb, 0xbd, 0xee, 0xee, 0x01, 0xe1, 0x0f, 0xe0,
-	0x0d, 0x24, 0x1e, 0x79, 0xe0, 0xff, 0xe4, 0x11, 0xdd, 0x7e, 0x9c, 0xef, 0xdc, 0xd0, 0x06, 0x95,
-	0x27, 0x7b, 0x67, 0x66, 0x67, 0x7e, 0x3b, 0xfb, 0xfb, 0xcd, 0x1e, 0x20, 0xc5, 0x7f, 0xa0, 0xe9,
-	0x34, 0x13, 0xfc, 0x47, 0x2a, 0x26, 0x99, 0xe0, 0x8a, 0xa3, 0x8e, 0xfe, 0x91, 0xe3, 0xbb, 0x0b,
-	0xce, 0x17, 0x2b, 0x3a, 0xd5, 0xcb, 0xf3, 0xfc, 0x62, 0xaa, 0x58, 0x42, 0xa5, 0x22, 0x49, 0x66,
-	0x02, 0xc7, 0xbe, 0xd9, 0x4c, 0x7f, 0xce, 0x68, 0xa4, 0x88, 0x62, 0x3c, 0x95, 0xd6, 0xb3, 0x6f,
-	0x3c, 0x4a, 0x90, 0x54, 0x92, 0xa8, 0xf0, 0x18, 0x47, 0xf0, 0x3d, 0xf4, 0x67, 0x85, 0x6b, 0xc6,
-	0x4f, 0xa5, 0xcc, 0x29, 0x7a, 0x13, 0xba, 0x82, 0x46, 0x2c, 0x63, 0x34, 0x55, 0xbe, 0x77, 0xe8,
-	0x1d, 0xf5, 0xf1, 0xda, 0x80, 0x10, 0xb4, 0xd5, 0x65, 0x46, 0xfd, 0xe6, 0xa1, 0x77, 0xd4, 0xc5,
-	0xfa, 0x3f, 0x1a, 0xc3, 0xf6, 0xf3, 0x9c, 0xa4, 0x8a, 0xa9, 0x4b, 0xbf, 0x75, 0xe8, 0x1d, 0xb5,
-	0x71, 0xb9, 0x0e, 0x30, 0xdc, 0xc6, 0x6e, 0xf3, 0xac, 0xa8, 0x7d, 0x41, 0xc5, 0xd9, 0x92, 0x88,
-	0x57, 0xd5, 0xa9, 0xe6, 0x6c, 0x6e, 0xe4, 0x7c, 0x02, 0x3d, 0x8d, 0xf8, 0x9b, 0x5c, 0x65, 0xb9,
-	0x42, 0x43, 0x68, 0xb2, 0xd8, 0x66, 0x68, 0xb2, 0xf8, 0x3f, 0x43, 0x7c, 0x00, 0x83, 0x6f, 0x53,
-	0x99, 0x15, 0x00, 0x8b, 0xac, 0x12, 0xbd, 0x0b, 0x1d, 0xdd, 0x2c, 0xe9, 0x7b, 0x87, 0xad, 0xa3,
-	0xde, 0xf1, 0xae, 0xe9, 0x94, 0x9c, 0x54, 0xaa, 0x62, 0x1b, 0x12, 0xbc, 0x0f, 0xbd, 0xaf, 0x99,
-	0x54, 0x98, 0x3e, 0xcf, 0xa9, 0x54, 0xe8, 0x0e, 0x40, 0x24, 0x68, 0x4c, 0x53, 0xc5, 0xc8, 0xca,
-	0x82, 0xaa, 0x58, 0x82, 0x04, 0x06, 0xa7, 0x49, 0xc6, 0xc5, 0x75, 0x37, 0xa0, 0x07, 0x30, 0x32,
-	0x95, 0xe6, 0x8a, 0xcf, 0x59, 0x71, 0x43, 0x7e, 0x53, 0xa3, 0xba, 0x55, 0x43, 0x65, 0x6f, 0x0f,
-	0x0f, 0x4c, 0xb0, 0x5d, 0x06, 0xbf, 0x7a, 0x30, 0x72, 0x6d, 0xbf, 0x6e, 0xc5, 0x37, 0xa0, 0xab,
-	0x93, 0xcc, 0x59, 0x2c, 0x75, 0xad, 0x3e, 0xde, 0xd6, 0x86, 0xd3, 0x58, 0xa2, 0x8f, 0xa0, 0x23,
-	0x8b, 0xeb, 0x93, 0x7e, 0x4b, 0xa3, 0xb8, 0xe3, 0x50, 0x5c, 0x7d, 0xcb, 0xd8, 0x46, 0x07, 0xbf,
-	0xc0, 0x00, 0xd3, 0x98, 0xd2, 0xe4, 0x7f, 0x41, 0xf1, 0x1e, 0x20, 0x77, 0x7d, 0x45, 0x5b, 0x84,
-	0xce, 0x6c, 0x2f, 0x76, 0xc7, 0x79, 0x66, 0xdc, 0x54, 0x0c, 0xce, 0x60, 0xff, 0x64, 0xb5, 0xe2,
-	0x3f, 0x91, 0x34, 0xa2, 0x25, 0xcc, 0xd7, 0x25, 0xe1, 0x9f, 0x1e, 0x0c, 0x4f, 0x32, 0xad, 0xd2,
-	0xeb, 0x1e, 0xe9, 0x2b, 0xd8, 0x21, 0x0e, 0xc7, 0xdc, 0x76, 0xd1, 0xdc, 0xe5, 0x5d, 0xd7, 0xc5,
-	0x7f, 0xc1, 0x89, 0x47, 0xe5, 0x46, 0xbd, 0x96, 0xf5, 0xf6, 0xb4, 0xea, 0xed, 0x09, 0x7e, 0xf3,
-	0x00, 0x3d, 0x5e, 0x8f, 0x80, 0xeb, 0xe2, 0xfb, 0x18, 0x7a, 0x95, 0xc1, 0xa1, 0x4f, 0xdc, 0x3b,
-	0xf6, 0x6b, 0x34, 0xab, 0x66, 0xad, 0x06, 0xbf, 0x1c, 0xcf, 0x1f, 0x1e, 0x74, 0x42, 0x4a, 0x62,
-	0x2a, 0xd0, 0x7d, 0xe8, 0x96, 0x33, 0x4b, 0x43, 0xe8, 0x1d, 0x8f, 0x27, 0x66, 0xaa, 0x4d, 0xdc,
-	0x54, 0x9b, 0xcc, 0x5c, 0x04, 0x5e, 0x07, 0xa3, 0x03, 0x80, 0x68, 0x49, 0xd2, 0x94, 0xae, 0xe6,
-	0x2c, 0xb6, 0xe2, 0xee, 0x5a, 0xcb, 0x69, 0x8c, 0x6e, 0xc1, 0x8d, 0x94, 0xa7, 0x11, 0xd5, 0x2c,
-	0xe8, 0x63, 0xb3, 0x40, 0x3e, 0x6c, 0x45, 0x82, 0x12, 0xc5, 0x85, 0xdf, 0xd6, 0x76, 0xb7, 0x0c,
-	0xfe, 0x6a, 0xc3, 0xd6, 0x67, 0x3c, 0x49, 0x48, 0x1a, 0xa3, 0xb7, 0xa1, 0xb3, 0xd4, 0xf0, 0x2c,
-	0xa2, 0xa1, 0x3b, 0xb3, 0x01, 0x8d, 0xad, 0x17, 0x3d, 0x84, 0x21, 0xd3, 0xe2, 0x9d, 0x0b, 0xd3,
-	0x52, 0xdb, 0xa3, 0x3d, 0x17, 0x5f, 0x93, 0x76, 0xd8, 0xc0, 0x03, 0x56, 0xd3, 0xfa, 0xe7, 0xb0,
-	0xa3, 0xac, 0x3a, 0xca, 0x0c, 0x2d, 0x9d, 0x61, 0xbf, 0xec, 0x72, 0x5d, 0xac, 0x61, 0x03, 0x8f,
-	0xd4, 0x86, 0x7e, 0xef, 0x43, 0x7f, 0xc5, 0xe4, 0x1a, 0x43, 0x5b, 0x67, 0x28, 0x87, 0x54, 0x65,
-	0x1a, 0x85, 0x0d, 0xdc, 0x5b, 0x55, 0x86, 0xd3, 0x43, 0x18, 0x1a, 0xa9, 0x94, 0x7b, 0x6f, 0xd4,
-	0xf1, 0xd7, 0x24, 0x5a, 0xe0, 0x17, 0x35, 0xcd, 0x9e, 0xc0, 0x88, 0x18, 0xca, 0x97, 0x09, 0x3a,
-	0x3a, 0xc1, 0xed, 0x92, 0xbf, 0x35, 0x45, 0x84, 0x0d, 0x3c, 0x24, 0x75, 0x8d, 0x3c, 0x81, 0xbd,
-	0xb2, 0x05, 0x17, 0x82, 0xaf, 0x91, 0x6c, 0xbd, 0xaa, 0x0f, 0xbb, 0x6e, 0xdf, 0x17, 0x82, 0x27,
-	0xeb, 0x74, 0xbb, 0x15, 0x16, 0x96, 0xc9, 0xb6, 0x2d, 0xb1, 0x6c, 0xb2, 0x17, 0xb5, 0x10, 0x36,
-	0x30, 0xa2, 0x2f, 0x58, 0x1f, 0x75, 0x61, 0x2b, 0x23, 0x97, 0x2b, 0x4e, 0xe2, 0xe0, 0x4b, 0x18,
-	0x9c, 0xb1, 0x45, 0x4a, 0x63, 0x47, 0x92, 0x82, 0x4a, 0xe6, 0xaf, 0x95, 0x8e, 0x5b, 0x16, 0x43,
-	0x44, 0xb2, 0x45, 0x4a, 0x54, 0x2e, 0xcc, 0xab, 0xd3, 0xc7, 0x6b, 0x43, 0xf0, 0xbb, 0x07, 0x7b,
-	0x36, 0x07, 0xa6, 0x32, 0xe3, 0xa9, 0xa4, 0xaf, 0xad, 0x85, 0x7b, 0xd0, 0xb7, 0xc5, 0xe7, 0x4b,
-	0x22, 0x97, 0xb6, 0x68, 0xcf, 0xda, 0x42, 0x22, 0x97, 0x55, 0xe6, 0xb7, 0xea, 0xcc, 0xff, 0x04,
-	0x6e, 0x3c, 0x16, 0x82, 0x8b, 0x22, 0x24, 0xa1, 0x52, 0x92, 0x05, 0xd5, 0xd5, 0xbb, 0xd8, 0x2d,
-	0x0b, 0x8f, 0xed, 0x83, 0x4d, 0x5d, 0xb6, 0xe5, 0x6f, 0x0f, 0x46, 0x1b, 0xa7, 0x41, 0x1f, 0x6e,
-	0xc8, 0xe7, 0xc0, 0xf5, 0xfd, 0xca, 0x63, 0x97, 0x6a, 0xba, 0x07, 0x2d, 0x2a, 0x84, 0x95, 0xd0,
-	0xa0, 0xbc, 0xab, 0x02, 0x5a, 0xd8, 0xc0, 0x85, 0x0f, 0x7d, 0x0a, 0x37, 0xcd, 0x54, 0xa9, 0x7c,
-	0xb6, 0x58, 0xc5, 0xdc, 0xb4, 0xef, 0xde, 0xda, 0x11, 0x36, 0xf0, 0x8e, 0xda, 0xb0, 0x15, 0x94,
-	0xcf, 0xcd, 0xe3, 0x3e, 0xb7, 0x6f, 0x7a, 0xbb, 0x4e, 0xf9, 0xda, 0xd3, 0x5f, 0x50, 0x3e, 0xaf,
-	0x1a, 0xaa, 0x8c, 0x78, 0x06, 0x7b, 0x35, 0x46, 0x94, 0xe7, 0x1f, 0xc3, 0xb6, 0xb0, 0xff, 0x2d,
-	0x35, 0xca, 0xf5, 0xcb, 0xb9, 0x71, 0x8c, 0xa1, 0xf3, 0x54, 0x7f, 0xe7, 0xa1, 0x10, 0x86, 0x4f,
-	0x05, 0x8f, 0xa8, 0x94, 0x8e, 0x6f, 0x25, 0xc2, 0x5a, 0xd1, 0xf1, 0xc1, 0x95, 0x66, 0x87, 0x25,
-	0x68, 0x3c, 0x7a, 0x06, 0x6f, 0x71, 0xb1, 0x98, 0x2c, 0x2f, 0x33, 0x2a, 0x56, 0x34, 0x5e, 0x50,
-	0x31, 0xb9, 0x20, 0xe7, 0x82, 0x45, 0x6e, 0xa3, 0xee, 0xc3, 0x77, 0xef, 0x2c, 0x98, 0x5a, 0xe6,
-	0xe7, 0x93, 0x88, 0x27, 0xd3, 0x4a, 0xec, 0xd4, 0xc4, 0x9a, 0x2f, 0x4c, 0x39, 0xd5, 0xb1, 0xe7,
-	0xe6, 0xf3, 0xf3, 0x83, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x10, 0x78, 0x90, 0x5b, 0x9b, 0x0a,
-	0x00, 0x00,
+func init() { proto.RegisterFile("token/prover.proto", fileDescriptor_prover_c60333b2c0db8dce) }
+
+var fileDescriptor_prover_c60333b2c0db8dce = []byte{
+	// 1435 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5b, 0x6f, 0xdb, 0xc6,
+	0x12, 0x16, 0x2d, 0x5b, 0x96, 0x46, 0x17, 0x3b, 0xeb, 0x38, 0xd1, 0x71, 0x4e, 0x12, 0x87, 0x01,
+	0x0e, 0x8c, 0xd3, 0x42, 0x2e, 0x5c, 0xb4, 0x48, 0x9b, 0x20, 0xa8, 0xdd, 0x26, 0x95, 0x9b, 0x34,
+	0x97, 0xb5, 0x0b, 0x14, 0x7d, 0x11, 0x28, 0x71, 0x2d, 0x11, 0xa6, 0xb8, 0xcc, 0xee, 0xaa, 0x89,
+	0xf2, 0xd6, 0x97, 0x02, 0x7d, 0xe8, 0xed, 0xb1, 0x3f, 0xa4, 0xfd, 0x7d, 0xc5, 0x5e, 0xc9, 0xb5,
+	0x9d, 0xc4, 0x41, 0xda, 0x27, 0x71, 0x66, 0x67, 0x67, 0xbe, 0x99, 0x1d, 0x7e, 0xb3, 0x14, 0x20,
+	0x41, 0x8f, 0x49, 0xb6, 0x9d, 0x33, 0xfa, 0x3d, 0x61, 0xbd, 0x9c, 0x51, 0x41, 0x51, 0x4d, 0xfd,
+	0xf0, 0x8d, 0xeb, 0x63, 0x4a, 0xc7, 0x29, 0xd9, 0x56, 0xe2, 0x70, 0x76, 0xb4, 0x2d, 0x92, 0x29,
+	0xe1, 0x22, 0x9a, 0xe6, 0xda, 0x70, 0xa3, 0xab, 0x37, 0x93, 0x17, 0x39, 0x19, 0x89, 0x48, 0x24,
+	0x34, 0xe3, 0x66, 0xe5, 0xb2, 0x5e, 0x11, 0x2c, 0xca, 0x78, 0x34, 0x92, 0x2b, 0x7a, 0x21, 0xfc,
+	0x21, 0x80, 0xd6, 0xa1, 0x5c, 0x3b, 0xa4, 0xfb, 0x9c, 0xcf, 0x08, 0xfa, 0x2f, 0x34, 0x18, 0x19,
+	0x25, 0x79, 0x42, 0x32, 0xd1, 0x0d, 0x36, 0x83, 0xad, 0x16, 0x2e, 0x14, 0x08, 0xc1, 0xa2, 0x98,
+	0xe7, 0xa4, 0xbb, 0xb0, 0x19, 0x6c, 0x35, 0xb0, 0x7a, 0x46, 0x1b, 0x50, 0x7f, 0x36, 0x8b, 0x32,
+	0x91, 0x88, 0x79, 0xb7, 0xba, 0x19, 0x6c, 0x2d, 0x62, 0x27, 0xa3, 0x9b, 0x50, 0xcd, 0x8e, 0x44,
+	0x77, 0x71, 0x33, 0xd8, 0x6a, 0xee, 0x5c, 0xe8, 0x3d, 0xa2, 0xd9, 0xfd, 0x59, 0x36, 0x4e, 0x86,
+	0x29, 0x51, 0x41, 0xb1, 0x5c, 0x0d, 0x31, 0x5c, 0xc2, 0x36, 0xc2, 0xa1, 0x44, 0x78, 0x44, 0xd8,
+	0xc1, 0x24, 0x62, 0x6f, 0x02, 0x53, 0x0e, 0xbc, 0xe0, 0x07, 0x0e, 0x7f, 0x0d, 0xa0, 0xa9, 0x42,
+	0x3c, 0x9e, 0x89, 0x7c, 0x26, 0x50, 0x07, 0x16, 0x92, 0xd8, 0xb8, 0x58, 0x48, 0xe2, 0x7f, 0x25,
+	0x11, 0x74, 0x05, 0x1a, 0x2f, 0x8f, 0x07, 0x54, 0x45, 0xec, 0x2e, 0xa9, 0x58, 0xf5, 0x97, 0xc7,
+	0x1a, 0x41, 0x78, 0x07, 0xda, 0xdf, 0x64, 0x3c, 0x97, 0x39, 0xca, 0x1d, 0x1c, 0xbd, 0x07, 0x35,
+	0x75, 0x2a, 0xbc, 0x1b, 0x6c, 0x56, 0xb7, 0x9a, 0x3b, 0x6b, 0xfa, 0x48, 0x78, 0xaf, 0x84, 0x1b,
+	0x1b, 0x93, 0xf0, 0x01, 0x34, 0x1f, 0x26, 0x5c, 0x60, 0xf2, 0x6c, 0x46, 0xb8, 0x40, 0xd7, 0x00,
+	0x46, 0x8c, 0xc4, 0x24, 0x13, 0x49, 0x94, 0x9a, 0xb4, 0x4a, 0x1a, 0x59, 0xb8, 0x98, 0xa4, 0x64,
+	0x1c, 0x09, 0x12, 0xab, 0x1c, 0xeb, 0xb8, 0x50, 0x84, 0x53, 0x68, 0xef, 0x4f, 0x73, 0xca, 0xce,
+	0xed, 0xee, 0x0e, 0xac, 0x68, 0x1c, 0x03, 0x41, 0x07, 0x89, 0xec, 0x93, 0xee, 0x82, 0xc2, 0x7c,
+	0xd1, 0xc3, 0x6c, 0x7a, 0x08, 0xb7, 0xb5, 0xb1, 0x11, 0xc3, 0x1f, 0x03, 0x58, 0xb1, 0xe7, 0x7a,
+	0xde, 0x88, 0x57, 0xa0, 0xa1, 0x9c, 0x0c, 0x92, 0x98, 0xab, 0x58, 0x2d, 0x5c, 0x57, 0x8a, 0xfd,
+	0x98, 0xa3, 0x8f, 0xa1, 0xc6, 0x65, 0x7f, 0xf0, 0x6e, 0x55, 0xa1, 0xb8, 0x66, 0x51, 0x9c, 0xdd,
+	0x46, 0xd8, 0x58, 0x87, 0x2f, 0xa1, 0x8d, 0x49, 0x4c, 0xc8, 0xf4, 0x1f, 0x41, 0xf1, 0x3e, 0x20,
+	0xdb, 0x1e, 0xb2, 0x2c, 0x4c, 0x79, 0x36, 0x8d, 0xb3, 0x6a, 0x57, 0x0e, 0xa9, 0x8e, 0x18, 0x1e,
+	0xc0, 0xe5, 0xdd, 0x34, 0xa5, 0xcf, 0xa3, 0x6c, 0x44, 0x1c, 0xcc, 0x77, 0xed, 0xf2, 0x3f, 0x02,
+	0xe8, 0xec, 0xe6, 0x8a, 0x2c, 0xce, 0x9b, 0xd2, 0x57, 0xb0, 0x1a, 0x59, 0x1c, 0x03, 0x53, 0x45,
+	0x7d, 0x96, 0xd7, 0x6d, 0x15, 0x5f, 0x81, 0x13, 0xaf, 0xb8, 0x8d, 0x4a, 0xe6, 0x7e, 0x79, 0xaa,
+	0x7e, 0x79, 0xc2, 0x9f, 0x03, 0x40, 0xf7, 0x0a, 0x26, 0x3a, 0x2f, 0xbe, 0x4f, 0xa1, 0x59, 0xe2,
+	0x2f, 0x95, 0x71, 0x73, 0xa7, 0xeb, 0xb5, 0x59, 0xd9, 0x6b, 0xd9, 0xf8, 0xf5, 0x78, 0xee, 0x41,
+	0xfb, 0x60, 0x96, 0xe7, 0xe9, 0xfc, 0xbc, 0x48, 0x2e, 0xc2, 0x92, 0xa4, 0x05, 0x5d, 0x9e, 0x06,
+	0xd6, 0x42, 0xf8, 0x09, 0xb4, 0x15, 0x08, 0xe5, 0x2b, 0x21, 0x1c, 0x6d, 0x41, 0x9d, 0x9b, 0x67,
+	0xf3, 0x22, 0xb7, 0x7a, 0x85, 0xc5, 0x1c, 0xbb, 0xd5, 0xf0, 0x01, 0x74, 0x1e, 0x26, 0x19, 0x89,
+	0xc6, 0xe7, 0x3e, 0xac, 0xff, 0x40, 0xdd, 0x26, 0xa4, 0x2a, 0xd1, 0xc2, 0xcb, 0x26, 0x9f, 0xf0,
+	0xa7, 0x00, 0xd6, 0x1e, 0x3f, 0xcf, 0x08, 0xeb, 0x27, 0x5c, 0x50, 0xf6, 0x36, 0x59, 0x51, 0xb9,
+	0xcd, 0xf8, 0xd3, 0x02, 0xba, 0x0e, 0x4d, 0x2e, 0x22, 0x26, 0x06, 0xc3, 0x94, 0x8e, 0x8e, 0x4d,
+	0x13, 0x83, 0x52, 0xed, 0x49, 0x8d, 0x2c, 0x2d, 0xc9, 0x62, 0xb3, 0xbc, 0xa8, 0xdb, 0x90, 0x64,
+	0xb1, 0x5a, 0x0c, 0xef, 0x43, 0x67, 0x2f, 0x4a, 0x75, 0xc7, 0xbc, 0x03, 0x8a, 0xf0, 0xb7, 0x00,
+	0x2e, 0x69, 0x22, 0x29, 0xe6, 0x14, 0x26, 0x23, 0xca, 0x62, 0xb4, 0x06, 0x4b, 0xe2, 0xc5, 0xc0,
+	0x50, 0xb8, 0x24, 0xec, 0x17, 0xfb, 0x31, 0xba, 0x01, 0x2d, 0x05, 0x68, 0x90, 0xcd, 0xa6, 0x43,
+	0xe3, 0x6c, 0x11, 0x37, 0x95, 0xee, 0x91, 0x52, 0xa1, 0xbb, 0x70, 0x41, 0x57, 0xb0, 0x34, 0xfa,
+	0x54, 0x7a, 0x92, 0xc5, 0x4f, 0xc5, 0x5a, 0x15, 0x27, 0x34, 0x21, 0x36, 0xe3, 0xd1, 0x54, 0x19,
+	0xed, 0x41, 0xab, 0xe4, 0xc9, 0x9e, 0xf8, 0x35, 0x9f, 0x06, 0x4f, 0xa2, 0xc7, 0xde, 0x9e, 0xf0,
+	0x5b, 0xe3, 0xd3, 0xd4, 0xcc, 0xcd, 0xa2, 0xe0, 0x15, 0xb3, 0xe8, 0xc4, 0x5b, 0x8f, 0xba, 0xb0,
+	0xac, 0x67, 0x0c, 0x37, 0x07, 0x65, 0xc5, 0x70, 0x17, 0xda, 0x65, 0xcf, 0x1c, 0x7d, 0x00, 0xf5,
+	0xa1, 0x79, 0xee, 0x06, 0x67, 0x30, 0xb6, 0x3d, 0x36, 0x67, 0x15, 0xfe, 0x1e, 0x40, 0xad, 0x4f,
+	0xa2, 0x98, 0x30, 0x74, 0x0b, 0x1a, 0xee, 0x86, 0xa1, 0xc0, 0x35, 0x77, 0x36, 0x7a, 0xfa, 0x0e,
+	0xd2, 0xb3, 0x77, 0x90, 0xde, 0xa1, 0xb5, 0xc0, 0x85, 0x31, 0xba, 0x0a, 0x30, 0x9a, 0x44, 0x59,
+	0x46, 0x52, 0xdb, 0xb9, 0x0d, 0xdc, 0x30, 0x9a, 0xfd, 0x58, 0x9e, 0x7e, 0x46, 0xb3, 0x11, 0x51,
+	0xf0, 0x5b, 0x58, 0x0b, 0x32, 0xad, 0x11, 0x23, 0x91, 0xa0, 0x4c, 0x35, 0x58, 0x0b, 0x5b, 0x31,
+	0xfc, 0xab, 0x06, 0xcb, 0x9f, 0xd3, 0xe9, 0x34, 0xca, 0x62, 0xf4, 0x3f, 0xa8, 0x4d, 0x14, 0x3c,
+	0x83, 0xa8, 0x63, 0xf3, 0xd1, 0xa0, 0xb1, 0x59, 0x45, 0x77, 0xa1, 0x93, 0xa8, 0x19, 0x37, 0x60,
+	0xba, 0x27, 0x0d, 0x95, 0xac, 0x5b, 0x7b, 0x6f, 0x02, 0xf6, 0x2b, 0xb8, 0x9d, 0x94, 0x15, 0xe8,
+	0x0b, 0x58, 0x15, 0x66, 0x88, 0x38, 0x0f, 0xba, 0x6f, 0x2e, 0xbb, 0x0a, 0xfa, 0x33, 0xad, 0x5f,
+	0xc1, 0x2b, 0xc2, 0x57, 0xa1, 0x5b, 0xd0, 0x4a, 0x13, 0x5e, 0x60, 0xd0, 0xf7, 0x07, 0x37, 0xe9,
+	0x4b, 0x23, 0xbd, 0x5f, 0xc1, 0xcd, 0xb4, 0x10, 0x25, 0x7e, 0x3d, 0x51, 0xdc, 0xde, 0x25, 0x1f,
+	0xbf, 0x37, 0xc9, 0x24, 0x7e, 0x56, 0x56, 0xa0, 0x5d, 0x58, 0x89, 0xf4, 0x64, 0x70, 0x0e, 0x6a,
+	0xca, 0xc1, 0x25, 0x47, 0xf3, 0xde, 0xe0, 0xe8, 0x57, 0x70, 0x27, 0xf2, 0x34, 0xe8, 0x6b, 0x58,
+	0x77, 0x25, 0x38, 0x62, 0xb4, 0x40, 0xb2, 0xfc, 0xa6, 0x3a, 0xac, 0xd9, 0x7d, 0xf7, 0x19, 0x9d,
+	0x16, 0xee, 0xd6, 0x4a, 0x64, 0xed, 0x9c, 0xd5, 0x4d, 0x63, 0x19, 0x67, 0xa7, 0x47, 0x46, 0xbf,
+	0x82, 0x11, 0x39, 0xa5, 0x95, 0x05, 0x52, 0xcc, 0x3a, 0x77, 0x9e, 0x1a, 0x7e, 0x81, 0x3c, 0xb6,
+	0x97, 0x05, 0xe2, 0x65, 0x85, 0x2c, 0x50, 0xaa, 0xd9, 0xd8, 0x39, 0x00, 0xbf, 0x40, 0x3e, 0x59,
+	0xcb, 0x02, 0xa5, 0x9e, 0x06, 0x3d, 0x85, 0x75, 0x45, 0x5c, 0x83, 0x89, 0x66, 0x07, 0xe7, 0xa8,
+	0xa9, 0x1c, 0x5d, 0xb1, 0x8e, 0xce, 0xe0, 0x69, 0x59, 0x24, 0x7a, 0x5a, 0x2d, 0x51, 0x99, 0x57,
+	0xd1, 0x39, 0x6b, 0xf9, 0xa8, 0x7c, 0xa6, 0x95, 0xa8, 0x86, 0x9e, 0x66, 0xaf, 0x01, 0xcb, 0x79,
+	0x34, 0x4f, 0x69, 0x14, 0x87, 0x5f, 0x42, 0xfb, 0x20, 0x19, 0x67, 0x24, 0xb6, 0x6f, 0x8f, 0x7c,
+	0xc7, 0xf4, 0xa3, 0x21, 0x65, 0x2b, 0xca, 0x4b, 0x08, 0x4f, 0xc6, 0x59, 0x24, 0x66, 0x8c, 0x18,
+	0x56, 0x2e, 0x14, 0xe1, 0x2f, 0x01, 0xac, 0x1b, 0x1f, 0x98, 0xf0, 0x9c, 0x66, 0x9c, 0xbc, 0x33,
+	0x49, 0xdc, 0x80, 0x96, 0x09, 0x3e, 0x98, 0x44, 0x7c, 0x62, 0x82, 0x36, 0x8d, 0xae, 0x1f, 0xf1,
+	0x49, 0x99, 0x12, 0xaa, 0x3e, 0x25, 0xdc, 0x86, 0xa5, 0x7b, 0x8c, 0x51, 0x26, 0x4d, 0xa6, 0x84,
+	0xf3, 0x68, 0x6c, 0xf9, 0xd3, 0x8a, 0xa8, 0xeb, 0xea, 0x60, 0x67, 0xa7, 0x2d, 0xcb, 0x9f, 0x55,
+	0x58, 0x39, 0x91, 0x0d, 0xfa, 0xe8, 0x04, 0xaf, 0x5c, 0xb5, 0xf5, 0x3e, 0x33, 0x6d, 0x47, 0x33,
+	0x37, 0xa0, 0x4a, 0x18, 0x33, 0xdc, 0xd2, 0x76, 0x4d, 0x2c, 0xa1, 0xf5, 0x2b, 0x58, 0xae, 0xa1,
+	0xcf, 0xde, 0x66, 0x04, 0xf5, 0x2b, 0xa7, 0x87, 0x90, 0x6c, 0xf5, 0x99, 0xfe, 0x74, 0x18, 0x98,
+	0x2f, 0x86, 0x45, 0xbf, 0xd5, 0xbd, 0x0f, 0x0b, 0xd9, 0xea, 0xb3, 0xb2, 0x42, 0xee, 0xd7, 0x08,
+	0xdc, 0x45, 0xe5, 0x04, 0x97, 0x78, 0x37, 0x1a, 0xb9, 0x5f, 0x94, 0x15, 0xe8, 0x36, 0x68, 0x85,
+	0xed, 0x73, 0xc3, 0x24, 0xfe, 0x28, 0x31, 0x8d, 0xdc, 0xaf, 0xe0, 0x96, 0x28, 0xc9, 0x45, 0x70,
+	0x37, 0x88, 0x96, 0xcf, 0x08, 0x6e, 0x27, 0x96, 0x0b, 0x6e, 0x15, 0xe5, 0x76, 0x7e, 0x0a, 0xeb,
+	0x5e, 0x3b, 0xbb, 0xc3, 0xdb, 0x80, 0x3a, 0x33, 0xcf, 0xa6, 0xaf, 0x9d, 0xfc, 0xfa, 0xc6, 0xde,
+	0xc1, 0x50, 0x7b, 0xa2, 0xbe, 0xb5, 0x51, 0x1f, 0x3a, 0x4f, 0x18, 0x1d, 0x11, 0xce, 0xed, 0xcb,
+	0x52, 0x30, 0x49, 0x39, 0xe8, 0xc6, 0xd5, 0x33, 0xd5, 0x16, 0x4b, 0x58, 0xd9, 0x7b, 0x0a, 0x37,
+	0x29, 0x1b, 0xf7, 0x26, 0xf3, 0x9c, 0xb0, 0x94, 0xc4, 0x63, 0xc2, 0x7a, 0x47, 0xd1, 0x90, 0x25,
+	0x23, 0xbb, 0x51, 0x25, 0xf8, 0xdd, 0xff, 0xc7, 0x89, 0x98, 0xcc, 0x86, 0xbd, 0x11, 0x9d, 0x6e,
+	0x97, 0x6c, 0xb7, 0xb5, 0xad, 0xfe, 0xca, 0xe7, 0xdb, 0xca, 0x76, 0xa8, 0xff, 0x02, 0xf8, 0xf0,
+	0xef, 0x01, 0x00, 0x73, 0x7c, 0x3b, 0x25, 0x1f, 0x10, 0x00, 0x00,
 }
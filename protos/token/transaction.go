@@ -0,0 +1,15 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import "github.com/golang/protobuf/proto"
+
+// SigningBytes returns the bytes the owners of the inputs of the swap sign:
+// the serialization of a PlainSwap holding only the inputs and the outputs of the swap.
+func (ps *PlainSwap) SigningBytes() ([]byte, error) {
+	return proto.Marshal(&PlainSwap{Inputs: ps.GetInputs(), Outputs: ps.GetOutputs()})
+}
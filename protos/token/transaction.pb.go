@@ -26,6 +26,7 @@ type TokenTransaction struct {
 	//
 	// Types that are valid to be assigned to Action:
 	//	*TokenTransaction_PlainAction
+	//	*TokenTransaction_ZkAction
 	Action               isTokenTransaction_Action `protobuf_oneof:"action"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
 	XXX_unrecognized     []byte                    `json:"-"`
@@ -36,7 +37,7 @@ func (m *TokenTransaction) Reset()         { *m = TokenTransaction{} }
 func (m *TokenTransaction) String() string { return proto.CompactTextString(m) }
 func (*TokenTransaction) ProtoMessage()    {}
 func (*TokenTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4be9961cb2bdf952, []int{0}
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{0}
 }
 func (m *TokenTransaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TokenTransaction.Unmarshal(m, b)
@@ -64,8 +65,14 @@ type TokenTransaction_PlainAction struct {
 	PlainAction *PlainTokenAction `protobuf:"bytes,1,opt,name=plain_action,json=plainAction,proto3,oneof"`
 }
 
+type TokenTransaction_ZkAction struct {
+	ZkAction *ZkTokenAction `protobuf:"bytes,2,opt,name=zk_action,json=zkAction,proto3,oneof"`
+}
+
 func (*TokenTransaction_PlainAction) isTokenTransaction_Action() {}
 
+func (*TokenTransaction_ZkAction) isTokenTransaction_Action() {}
+
 func (m *TokenTransaction) GetAction() isTokenTransaction_Action {
 	if m != nil {
 		return m.Action
@@ -80,10 +87,18 @@ func (m *TokenTransaction) GetPlainAction() *PlainTokenAction {
 	return nil
 }
 
+func (m *TokenTransaction) GetZkAction() *ZkTokenAction {
+	if x, ok := m.GetAction().(*TokenTransaction_ZkAction); ok {
+		return x.ZkAction
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*TokenTransaction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _TokenTransaction_OneofMarshaler, _TokenTransaction_OneofUnmarshaler, _TokenTransaction_OneofSizer, []interface{}{
 		(*TokenTransaction_PlainAction)(nil),
+		(*TokenTransaction_ZkAction)(nil),
 	}
 }
 
@@ -96,6 +111,11 @@ func _TokenTransaction_OneofMarshaler(msg proto.Message, b *proto.Buffer) error
 		if err := b.EncodeMessage(x.PlainAction); err != nil {
 			return err
 		}
+	case *TokenTransaction_ZkAction:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ZkAction); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("TokenTransaction.Action has unexpected type %T", x)
@@ -114,6 +134,14 @@ func _TokenTransaction_OneofUnmarshaler(msg proto.Message, tag, wire int, b *pro
 		err := b.DecodeMessage(msg)
 		m.Action = &TokenTransaction_PlainAction{msg}
 		return true, err
+	case 2: // action.zk_action
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ZkTokenAction)
+		err := b.DecodeMessage(msg)
+		m.Action = &TokenTransaction_ZkAction{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -128,6 +156,11 @@ func _TokenTransaction_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *TokenTransaction_ZkAction:
+		s := proto.Size(x.ZkAction)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -144,6 +177,10 @@ type PlainTokenAction struct {
 	//	*PlainTokenAction_PlainRedeem
 	//	*PlainTokenAction_PlainApprove
 	//	*PlainTokenAction_PlainTransfer_From
+	//	*PlainTokenAction_PlainSwap
+	//	*PlainTokenAction_PlainLock
+	//	*PlainTokenAction_PlainClaim
+	//	*PlainTokenAction_PlainReclaim
 	Data                 isPlainTokenAction_Data `protobuf_oneof:"data"`
 	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
 	XXX_unrecognized     []byte                  `json:"-"`
@@ -154,7 +191,7 @@ func (m *PlainTokenAction) Reset()         { *m = PlainTokenAction{} }
 func (m *PlainTokenAction) String() string { return proto.CompactTextString(m) }
 func (*PlainTokenAction) ProtoMessage()    {}
 func (*PlainTokenAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4be9961cb2bdf952, []int{1}
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{1}
 }
 func (m *PlainTokenAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainTokenAction.Unmarshal(m, b)
@@ -198,6 +235,22 @@ type PlainTokenAction_PlainTransfer_From struct {
 	PlainTransfer_From *PlainTransferFrom `protobuf:"bytes,5,opt,name=plain_transfer_From,json=plainTransferFrom,proto3,oneof"`
 }
 
+type PlainTokenAction_PlainSwap struct {
+	PlainSwap *PlainSwap `protobuf:"bytes,6,opt,name=plain_swap,json=plainSwap,proto3,oneof"`
+}
+
+type PlainTokenAction_PlainLock struct {
+	PlainLock *PlainLock `protobuf:"bytes,7,opt,name=plain_lock,json=plainLock,proto3,oneof"`
+}
+
+type PlainTokenAction_PlainClaim struct {
+	PlainClaim *PlainClaim `protobuf:"bytes,8,opt,name=plain_claim,json=plainClaim,proto3,oneof"`
+}
+
+type PlainTokenAction_PlainReclaim struct {
+	PlainReclaim *PlainReclaim `protobuf:"bytes,9,opt,name=plain_reclaim,json=plainReclaim,proto3,oneof"`
+}
+
 func (*PlainTokenAction_PlainImport) isPlainTokenAction_Data() {}
 
 func (*PlainTokenAction_PlainTransfer) isPlainTokenAction_Data() {}
@@ -208,6 +261,14 @@ func (*PlainTokenAction_PlainApprove) isPlainTokenAction_Data() {}
 
 func (*PlainTokenAction_PlainTransfer_From) isPlainTokenAction_Data() {}
 
+func (*PlainTokenAction_PlainSwap) isPlainTokenAction_Data() {}
+
+func (*PlainTokenAction_PlainLock) isPlainTokenAction_Data() {}
+
+func (*PlainTokenAction_PlainClaim) isPlainTokenAction_Data() {}
+
+func (*PlainTokenAction_PlainReclaim) isPlainTokenAction_Data() {}
+
 func (m *PlainTokenAction) GetData() isPlainTokenAction_Data {
 	if m != nil {
 		return m.Data
@@ -250,6 +311,34 @@ func (m *PlainTokenAction) GetPlainTransfer_From() *PlainTransferFrom {
 	return nil
 }
 
+func (m *PlainTokenAction) GetPlainSwap() *PlainSwap {
+	if x, ok := m.GetData().(*PlainTokenAction_PlainSwap); ok {
+		return x.PlainSwap
+	}
+	return nil
+}
+
+func (m *PlainTokenAction) GetPlainLock() *PlainLock {
+	if x, ok := m.GetData().(*PlainTokenAction_PlainLock); ok {
+		return x.PlainLock
+	}
+	return nil
+}
+
+func (m *PlainTokenAction) GetPlainClaim() *PlainClaim {
+	if x, ok := m.GetData().(*PlainTokenAction_PlainClaim); ok {
+		return x.PlainClaim
+	}
+	return nil
+}
+
+func (m *PlainTokenAction) GetPlainReclaim() *PlainReclaim {
+	if x, ok := m.GetData().(*PlainTokenAction_PlainReclaim); ok {
+		return x.PlainReclaim
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*PlainTokenAction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _PlainTokenAction_OneofMarshaler, _PlainTokenAction_OneofUnmarshaler, _PlainTokenAction_OneofSizer, []interface{}{
@@ -258,6 +347,10 @@ func (*PlainTokenAction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buff
 		(*PlainTokenAction_PlainRedeem)(nil),
 		(*PlainTokenAction_PlainApprove)(nil),
 		(*PlainTokenAction_PlainTransfer_From)(nil),
+		(*PlainTokenAction_PlainSwap)(nil),
+		(*PlainTokenAction_PlainLock)(nil),
+		(*PlainTokenAction_PlainClaim)(nil),
+		(*PlainTokenAction_PlainReclaim)(nil),
 	}
 }
 
@@ -290,6 +383,26 @@ func _PlainTokenAction_OneofMarshaler(msg proto.Message, b *proto.Buffer) error
 		if err := b.EncodeMessage(x.PlainTransfer_From); err != nil {
 			return err
 		}
+	case *PlainTokenAction_PlainSwap:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PlainSwap); err != nil {
+			return err
+		}
+	case *PlainTokenAction_PlainLock:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PlainLock); err != nil {
+			return err
+		}
+	case *PlainTokenAction_PlainClaim:
+		b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PlainClaim); err != nil {
+			return err
+		}
+	case *PlainTokenAction_PlainReclaim:
+		b.EncodeVarint(9<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PlainReclaim); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("PlainTokenAction.Data has unexpected type %T", x)
@@ -340,6 +453,38 @@ func _PlainTokenAction_OneofUnmarshaler(msg proto.Message, tag, wire int, b *pro
 		err := b.DecodeMessage(msg)
 		m.Data = &PlainTokenAction_PlainTransfer_From{msg}
 		return true, err
+	case 6: // data.plain_swap
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PlainSwap)
+		err := b.DecodeMessage(msg)
+		m.Data = &PlainTokenAction_PlainSwap{msg}
+		return true, err
+	case 7: // data.plain_lock
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PlainLock)
+		err := b.DecodeMessage(msg)
+		m.Data = &PlainTokenAction_PlainLock{msg}
+		return true, err
+	case 8: // data.plain_claim
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PlainClaim)
+		err := b.DecodeMessage(msg)
+		m.Data = &PlainTokenAction_PlainClaim{msg}
+		return true, err
+	case 9: // data.plain_reclaim
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PlainReclaim)
+		err := b.DecodeMessage(msg)
+		m.Data = &PlainTokenAction_PlainReclaim{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -374,6 +519,26 @@ func _PlainTokenAction_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *PlainTokenAction_PlainSwap:
+		s := proto.Size(x.PlainSwap)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *PlainTokenAction_PlainLock:
+		s := proto.Size(x.PlainLock)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *PlainTokenAction_PlainClaim:
+		s := proto.Size(x.PlainClaim)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *PlainTokenAction_PlainReclaim:
+		s := proto.Size(x.PlainReclaim)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -394,7 +559,7 @@ func (m *PlainImport) Reset()         { *m = PlainImport{} }
 func (m *PlainImport) String() string { return proto.CompactTextString(m) }
 func (*PlainImport) ProtoMessage()    {}
 func (*PlainImport) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4be9961cb2bdf952, []int{2}
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{2}
 }
 func (m *PlainImport) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainImport.Unmarshal(m, b)
@@ -436,7 +601,7 @@ func (m *PlainTransfer) Reset()         { *m = PlainTransfer{} }
 func (m *PlainTransfer) String() string { return proto.CompactTextString(m) }
 func (*PlainTransfer) ProtoMessage()    {}
 func (*PlainTransfer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4be9961cb2bdf952, []int{3}
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{3}
 }
 func (m *PlainTransfer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainTransfer.Unmarshal(m, b)
@@ -487,7 +652,7 @@ func (m *PlainApprove) Reset()         { *m = PlainApprove{} }
 func (m *PlainApprove) String() string { return proto.CompactTextString(m) }
 func (*PlainApprove) ProtoMessage()    {}
 func (*PlainApprove) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4be9961cb2bdf952, []int{4}
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{4}
 }
 func (m *PlainApprove) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainApprove.Unmarshal(m, b)
@@ -546,7 +711,7 @@ func (m *PlainTransferFrom) Reset()         { *m = PlainTransferFrom{} }
 func (m *PlainTransferFrom) String() string { return proto.CompactTextString(m) }
 func (*PlainTransferFrom) ProtoMessage()    {}
 func (*PlainTransferFrom) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4be9961cb2bdf952, []int{5}
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{5}
 }
 func (m *PlainTransferFrom) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainTransferFrom.Unmarshal(m, b)
@@ -587,181 +752,1260 @@ func (m *PlainTransferFrom) GetDelegatedOutput() *PlainDelegatedOutput {
 	return nil
 }
 
-// A PlainOutput is the result of import and transfer transactions using plaintext tokens
-type PlainOutput struct {
-	// The owner is the serialization of a SerializedIdentity struct
-	Owner []byte `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
-	// The token type
-	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	// The quantity of tokens
-	Quantity             uint64   `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// PlainSwap specifies an atomic exchange of plaintext tokens between several owners
+type PlainSwap struct {
+	// The inputs to the swap transaction are specified by their ID; they may belong to different owners
+	Inputs []*InputId `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// A swap transaction contains one or more outputs, possibly for each of the owners of the inputs
+	Outputs []*PlainOutput `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// The signatures of the owners of the inputs over the serialization of a PlainSwap
+	// holding only the inputs and the outputs of this swap.
+	// The inputs owned by the creator of the transaction do not need a signature
+	Signatures           []*PlainSwapSignature `protobuf:"bytes,3,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
-func (m *PlainOutput) Reset()         { *m = PlainOutput{} }
-func (m *PlainOutput) String() string { return proto.CompactTextString(m) }
-func (*PlainOutput) ProtoMessage()    {}
-func (*PlainOutput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4be9961cb2bdf952, []int{6}
+func (m *PlainSwap) Reset()         { *m = PlainSwap{} }
+func (m *PlainSwap) String() string { return proto.CompactTextString(m) }
+func (*PlainSwap) ProtoMessage()    {}
+func (*PlainSwap) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{6}
 }
-func (m *PlainOutput) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PlainOutput.Unmarshal(m, b)
+func (m *PlainSwap) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlainSwap.Unmarshal(m, b)
 }
-func (m *PlainOutput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PlainOutput.Marshal(b, m, deterministic)
+func (m *PlainSwap) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlainSwap.Marshal(b, m, deterministic)
 }
-func (dst *PlainOutput) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PlainOutput.Merge(dst, src)
+func (dst *PlainSwap) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlainSwap.Merge(dst, src)
 }
-func (m *PlainOutput) XXX_Size() int {
-	return xxx_messageInfo_PlainOutput.Size(m)
+func (m *PlainSwap) XXX_Size() int {
+	return xxx_messageInfo_PlainSwap.Size(m)
 }
-func (m *PlainOutput) XXX_DiscardUnknown() {
-	xxx_messageInfo_PlainOutput.DiscardUnknown(m)
+func (m *PlainSwap) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlainSwap.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PlainOutput proto.InternalMessageInfo
+var xxx_messageInfo_PlainSwap proto.InternalMessageInfo
 
-func (m *PlainOutput) GetOwner() []byte {
+func (m *PlainSwap) GetInputs() []*InputId {
 	if m != nil {
-		return m.Owner
+		return m.Inputs
 	}
 	return nil
 }
 
-func (m *PlainOutput) GetType() string {
+func (m *PlainSwap) GetOutputs() []*PlainOutput {
 	if m != nil {
-		return m.Type
+		return m.Outputs
 	}
-	return ""
+	return nil
 }
 
-func (m *PlainOutput) GetQuantity() uint64 {
+func (m *PlainSwap) GetSignatures() []*PlainSwapSignature {
 	if m != nil {
-		return m.Quantity
+		return m.Signatures
 	}
-	return 0
+	return nil
 }
 
-// An InputId specifies an output using the transaction ID and the index of the output in the transaction
-type InputId struct {
-	// The transaction ID
-	TxId string `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
-	// The index of the output in the transaction
-	Index                uint32   `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+// A PlainSwapSignature is the signature of an input owner over a swap
+type PlainSwapSignature struct {
+	// The signer is the serialization of a SerializedIdentity struct, matching the owner of some inputs
+	Signer []byte `protobuf:"bytes,1,opt,name=signer,proto3" json:"signer,omitempty"`
+	// The signature over the swap
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *InputId) Reset()         { *m = InputId{} }
-func (m *InputId) String() string { return proto.CompactTextString(m) }
-func (*InputId) ProtoMessage()    {}
-func (*InputId) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4be9961cb2bdf952, []int{7}
+func (m *PlainSwapSignature) Reset()         { *m = PlainSwapSignature{} }
+func (m *PlainSwapSignature) String() string { return proto.CompactTextString(m) }
+func (*PlainSwapSignature) ProtoMessage()    {}
+func (*PlainSwapSignature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{7}
 }
-func (m *InputId) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_InputId.Unmarshal(m, b)
+func (m *PlainSwapSignature) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlainSwapSignature.Unmarshal(m, b)
 }
-func (m *InputId) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_InputId.Marshal(b, m, deterministic)
+func (m *PlainSwapSignature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlainSwapSignature.Marshal(b, m, deterministic)
 }
-func (dst *InputId) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_InputId.Merge(dst, src)
+func (dst *PlainSwapSignature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlainSwapSignature.Merge(dst, src)
 }
-func (m *InputId) XXX_Size() int {
-	return xxx_messageInfo_InputId.Size(m)
+func (m *PlainSwapSignature) XXX_Size() int {
+	return xxx_messageInfo_PlainSwapSignature.Size(m)
 }
-func (m *InputId) XXX_DiscardUnknown() {
-	xxx_messageInfo_InputId.DiscardUnknown(m)
+func (m *PlainSwapSignature) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlainSwapSignature.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_InputId proto.InternalMessageInfo
+var xxx_messageInfo_PlainSwapSignature proto.InternalMessageInfo
 
-func (m *InputId) GetTxId() string {
+func (m *PlainSwapSignature) GetSigner() []byte {
 	if m != nil {
-		return m.TxId
+		return m.Signer
 	}
-	return ""
+	return nil
 }
 
-func (m *InputId) GetIndex() uint32 {
+func (m *PlainSwapSignature) GetSignature() []byte {
 	if m != nil {
-		return m.Index
+		return m.Signature
 	}
-	return 0
+	return nil
 }
 
-// A PlainDelegatedOutput is the result of approve transactions using plaintext tokens
-type PlainDelegatedOutput struct {
-	// The owner is the serialization of a SerializedIdentity struct
-	Owner []byte `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
-	// The delegatees is an arrary of the serialized identities that can spend the output on behalf
-	// the owner
-	Delegatees [][]byte `protobuf:"bytes,2,rep,name=delegatees,proto3" json:"delegatees,omitempty"`
+// PlainLock specifies a lock of one or more plaintext tokens into a hash/time-locked output
+type PlainLock struct {
+	// The inputs to the lock transaction are specified by their ID
+	Inputs []*InputId `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// The locked output
+	LockedOutput *PlainLockedOutput `protobuf:"bytes,2,opt,name=locked_output,json=lockedOutput,proto3" json:"locked_output,omitempty"`
+	// A lock transaction may contain one output holding the remaining quantity of the inputs,
+	// which goes back to the sender
+	Output               *PlainOutput `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *PlainLock) Reset()         { *m = PlainLock{} }
+func (m *PlainLock) String() string { return proto.CompactTextString(m) }
+func (*PlainLock) ProtoMessage()    {}
+func (*PlainLock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{8}
+}
+func (m *PlainLock) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlainLock.Unmarshal(m, b)
+}
+func (m *PlainLock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlainLock.Marshal(b, m, deterministic)
+}
+func (dst *PlainLock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlainLock.Merge(dst, src)
+}
+func (m *PlainLock) XXX_Size() int {
+	return xxx_messageInfo_PlainLock.Size(m)
+}
+func (m *PlainLock) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlainLock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlainLock proto.InternalMessageInfo
+
+func (m *PlainLock) GetInputs() []*InputId {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *PlainLock) GetLockedOutput() *PlainLockedOutput {
+	if m != nil {
+		return m.LockedOutput
+	}
+	return nil
+}
+
+func (m *PlainLock) GetOutput() *PlainOutput {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+// A PlainLockedOutput is a hash/time-locked output: before the deadline, it can be claimed by the recipient
+// revealing the preimage of the hash; from the deadline on, it can be reclaimed by the sender
+type PlainLockedOutput struct {
+	// The sender is the serialization of a SerializedIdentity struct
+	Sender []byte `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	// The recipient is the serialization of a SerializedIdentity struct
+	Recipient []byte `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
 	// The token type
 	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
 	// The quantity of tokens
-	Quantity             uint64   `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Quantity uint64 `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// The non-fungible token carried by this output, if any
+	Nft *NonFungibleToken `protobuf:"bytes,5,opt,name=nft,proto3" json:"nft,omitempty"`
+	// The SHA-256 hash of the secret the recipient reveals to claim the output
+	Hash []byte `protobuf:"bytes,6,opt,name=hash,proto3" json:"hash,omitempty"`
+	// The number of the block from which the lock expires, compared with the numbers of the blocks of the
+	// claim and reclaim transactions
+	Deadline             uint64   `protobuf:"varint,7,opt,name=deadline,proto3" json:"deadline,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PlainDelegatedOutput) Reset()         { *m = PlainDelegatedOutput{} }
-func (m *PlainDelegatedOutput) String() string { return proto.CompactTextString(m) }
-func (*PlainDelegatedOutput) ProtoMessage()    {}
-func (*PlainDelegatedOutput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4be9961cb2bdf952, []int{8}
+func (m *PlainLockedOutput) Reset()         { *m = PlainLockedOutput{} }
+func (m *PlainLockedOutput) String() string { return proto.CompactTextString(m) }
+func (*PlainLockedOutput) ProtoMessage()    {}
+func (*PlainLockedOutput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{9}
 }
-func (m *PlainDelegatedOutput) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PlainDelegatedOutput.Unmarshal(m, b)
+func (m *PlainLockedOutput) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlainLockedOutput.Unmarshal(m, b)
 }
-func (m *PlainDelegatedOutput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PlainDelegatedOutput.Marshal(b, m, deterministic)
+func (m *PlainLockedOutput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlainLockedOutput.Marshal(b, m, deterministic)
 }
-func (dst *PlainDelegatedOutput) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PlainDelegatedOutput.Merge(dst, src)
+func (dst *PlainLockedOutput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlainLockedOutput.Merge(dst, src)
 }
-func (m *PlainDelegatedOutput) XXX_Size() int {
-	return xxx_messageInfo_PlainDelegatedOutput.Size(m)
+func (m *PlainLockedOutput) XXX_Size() int {
+	return xxx_messageInfo_PlainLockedOutput.Size(m)
 }
-func (m *PlainDelegatedOutput) XXX_DiscardUnknown() {
-	xxx_messageInfo_PlainDelegatedOutput.DiscardUnknown(m)
+func (m *PlainLockedOutput) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlainLockedOutput.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PlainDelegatedOutput proto.InternalMessageInfo
+var xxx_messageInfo_PlainLockedOutput proto.InternalMessageInfo
 
-func (m *PlainDelegatedOutput) GetOwner() []byte {
+func (m *PlainLockedOutput) GetSender() []byte {
 	if m != nil {
-		return m.Owner
+		return m.Sender
 	}
 	return nil
 }
 
-func (m *PlainDelegatedOutput) GetDelegatees() [][]byte {
+func (m *PlainLockedOutput) GetRecipient() []byte {
 	if m != nil {
-		return m.Delegatees
+		return m.Recipient
 	}
 	return nil
 }
 
-func (m *PlainDelegatedOutput) GetType() string {
+func (m *PlainLockedOutput) GetType() string {
 	if m != nil {
 		return m.Type
 	}
 	return ""
 }
 
-func (m *PlainDelegatedOutput) GetQuantity() uint64 {
+func (m *PlainLockedOutput) GetQuantity() uint64 {
 	if m != nil {
 		return m.Quantity
 	}
 	return 0
 }
 
+func (m *PlainLockedOutput) GetNft() *NonFungibleToken {
+	if m != nil {
+		return m.Nft
+	}
+	return nil
+}
+
+func (m *PlainLockedOutput) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *PlainLockedOutput) GetDeadline() uint64 {
+	if m != nil {
+		return m.Deadline
+	}
+	return 0
+}
+
+// PlainClaim specifies a claim of a locked output by its recipient; the claimed tokens go to the recipient
+type PlainClaim struct {
+	// The locked output to claim is specified by its ID
+	Input *InputId `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
+	// The preimage of the hash of the locked output
+	Preimage             []byte   `protobuf:"bytes,2,opt,name=preimage,proto3" json:"preimage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlainClaim) Reset()         { *m = PlainClaim{} }
+func (m *PlainClaim) String() string { return proto.CompactTextString(m) }
+func (*PlainClaim) ProtoMessage()    {}
+func (*PlainClaim) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{10}
+}
+func (m *PlainClaim) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlainClaim.Unmarshal(m, b)
+}
+func (m *PlainClaim) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlainClaim.Marshal(b, m, deterministic)
+}
+func (dst *PlainClaim) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlainClaim.Merge(dst, src)
+}
+func (m *PlainClaim) XXX_Size() int {
+	return xxx_messageInfo_PlainClaim.Size(m)
+}
+func (m *PlainClaim) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlainClaim.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlainClaim proto.InternalMessageInfo
+
+func (m *PlainClaim) GetInput() *InputId {
+	if m != nil {
+		return m.Input
+	}
+	return nil
+}
+
+func (m *PlainClaim) GetPreimage() []byte {
+	if m != nil {
+		return m.Preimage
+	}
+	return nil
+}
+
+// PlainReclaim specifies a reclaim of a locked output by its sender; the reclaimed tokens go back to the sender
+type PlainReclaim struct {
+	// The locked output to reclaim is specified by its ID
+	Input                *InputId `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlainReclaim) Reset()         { *m = PlainReclaim{} }
+func (m *PlainReclaim) String() string { return proto.CompactTextString(m) }
+func (*PlainReclaim) ProtoMessage()    {}
+func (*PlainReclaim) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{11}
+}
+func (m *PlainReclaim) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlainReclaim.Unmarshal(m, b)
+}
+func (m *PlainReclaim) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlainReclaim.Marshal(b, m, deterministic)
+}
+func (dst *PlainReclaim) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlainReclaim.Merge(dst, src)
+}
+func (m *PlainReclaim) XXX_Size() int {
+	return xxx_messageInfo_PlainReclaim.Size(m)
+}
+func (m *PlainReclaim) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlainReclaim.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlainReclaim proto.InternalMessageInfo
+
+func (m *PlainReclaim) GetInput() *InputId {
+	if m != nil {
+		return m.Input
+	}
+	return nil
+}
+
+// A PlainOutput is the result of import and transfer transactions using plaintext tokens
+type PlainOutput struct {
+	// The owner is the serialization of a SerializedIdentity struct
+	Owner []byte `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The token type
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// The quantity of tokens
+	Quantity uint64 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// The non-fungible token carried by this output, if any.
+	// An output carrying a non-fungible token always has quantity 1
+	Nft                  *NonFungibleToken `protobuf:"bytes,4,opt,name=nft,proto3" json:"nft,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *PlainOutput) Reset()         { *m = PlainOutput{} }
+func (m *PlainOutput) String() string { return proto.CompactTextString(m) }
+func (*PlainOutput) ProtoMessage()    {}
+func (*PlainOutput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{12}
+}
+func (m *PlainOutput) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlainOutput.Unmarshal(m, b)
+}
+func (m *PlainOutput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlainOutput.Marshal(b, m, deterministic)
+}
+func (dst *PlainOutput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlainOutput.Merge(dst, src)
+}
+func (m *PlainOutput) XXX_Size() int {
+	return xxx_messageInfo_PlainOutput.Size(m)
+}
+func (m *PlainOutput) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlainOutput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlainOutput proto.InternalMessageInfo
+
+func (m *PlainOutput) GetOwner() []byte {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+func (m *PlainOutput) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *PlainOutput) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *PlainOutput) GetNft() *NonFungibleToken {
+	if m != nil {
+		return m.Nft
+	}
+	return nil
+}
+
+// A NonFungibleToken is a unique, non-divisible token of a given type
+type NonFungibleToken struct {
+	// The id of the token, unique among the tokens of the same type
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The URI of the metadata describing the token
+	Uri string `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`
+	// The hash of the metadata describing the token
+	Hash                 []byte   `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NonFungibleToken) Reset()         { *m = NonFungibleToken{} }
+func (m *NonFungibleToken) String() string { return proto.CompactTextString(m) }
+func (*NonFungibleToken) ProtoMessage()    {}
+func (*NonFungibleToken) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{13}
+}
+func (m *NonFungibleToken) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NonFungibleToken.Unmarshal(m, b)
+}
+func (m *NonFungibleToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NonFungibleToken.Marshal(b, m, deterministic)
+}
+func (dst *NonFungibleToken) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NonFungibleToken.Merge(dst, src)
+}
+func (m *NonFungibleToken) XXX_Size() int {
+	return xxx_messageInfo_NonFungibleToken.Size(m)
+}
+func (m *NonFungibleToken) XXX_DiscardUnknown() {
+	xxx_messageInfo_NonFungibleToken.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NonFungibleToken proto.InternalMessageInfo
+
+func (m *NonFungibleToken) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *NonFungibleToken) GetUri() string {
+	if m != nil {
+		return m.Uri
+	}
+	return ""
+}
+
+func (m *NonFungibleToken) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+// A TokenSupply is the outstanding supply of a token type, that is the quantity
+// of tokens of that type that have been imported and not redeemed
+type TokenSupply struct {
+	// The token type
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// The outstanding quantity of tokens
+	Quantity             uint64   `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenSupply) Reset()         { *m = TokenSupply{} }
+func (m *TokenSupply) String() string { return proto.CompactTextString(m) }
+func (*TokenSupply) ProtoMessage()    {}
+func (*TokenSupply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{14}
+}
+func (m *TokenSupply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenSupply.Unmarshal(m, b)
+}
+func (m *TokenSupply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenSupply.Marshal(b, m, deterministic)
+}
+func (dst *TokenSupply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenSupply.Merge(dst, src)
+}
+func (m *TokenSupply) XXX_Size() int {
+	return xxx_messageInfo_TokenSupply.Size(m)
+}
+func (m *TokenSupply) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenSupply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenSupply proto.InternalMessageInfo
+
+func (m *TokenSupply) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *TokenSupply) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+// An InputId specifies an output using the transaction ID and the index of the output in the transaction
+type InputId struct {
+	// The transaction ID
+	TxId string `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// The index of the output in the transaction
+	Index                uint32   `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InputId) Reset()         { *m = InputId{} }
+func (m *InputId) String() string { return proto.CompactTextString(m) }
+func (*InputId) ProtoMessage()    {}
+func (*InputId) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{15}
+}
+func (m *InputId) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InputId.Unmarshal(m, b)
+}
+func (m *InputId) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InputId.Marshal(b, m, deterministic)
+}
+func (dst *InputId) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InputId.Merge(dst, src)
+}
+func (m *InputId) XXX_Size() int {
+	return xxx_messageInfo_InputId.Size(m)
+}
+func (m *InputId) XXX_DiscardUnknown() {
+	xxx_messageInfo_InputId.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InputId proto.InternalMessageInfo
+
+func (m *InputId) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *InputId) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+// A PlainDelegatedOutput is the result of approve transactions using plaintext tokens
+type PlainDelegatedOutput struct {
+	// The owner is the serialization of a SerializedIdentity struct
+	Owner []byte `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The delegatees is an arrary of the serialized identities that can spend the output on behalf
+	// the owner
+	Delegatees [][]byte `protobuf:"bytes,2,rep,name=delegatees,proto3" json:"delegatees,omitempty"`
+	// The token type
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// The quantity of tokens
+	Quantity             uint64   `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlainDelegatedOutput) Reset()         { *m = PlainDelegatedOutput{} }
+func (m *PlainDelegatedOutput) String() string { return proto.CompactTextString(m) }
+func (*PlainDelegatedOutput) ProtoMessage()    {}
+func (*PlainDelegatedOutput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{16}
+}
+func (m *PlainDelegatedOutput) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlainDelegatedOutput.Unmarshal(m, b)
+}
+func (m *PlainDelegatedOutput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlainDelegatedOutput.Marshal(b, m, deterministic)
+}
+func (dst *PlainDelegatedOutput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlainDelegatedOutput.Merge(dst, src)
+}
+func (m *PlainDelegatedOutput) XXX_Size() int {
+	return xxx_messageInfo_PlainDelegatedOutput.Size(m)
+}
+func (m *PlainDelegatedOutput) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlainDelegatedOutput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlainDelegatedOutput proto.InternalMessageInfo
+
+func (m *PlainDelegatedOutput) GetOwner() []byte {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+func (m *PlainDelegatedOutput) GetDelegatees() [][]byte {
+	if m != nil {
+		return m.Delegatees
+	}
+	return nil
+}
+
+func (m *PlainDelegatedOutput) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *PlainDelegatedOutput) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+// ZkTokenAction governs the structure of a token action whose quantities are
+// hidden in Pedersen commitments and whose owners are unlinkable pseudonyms
+type ZkTokenAction struct {
+	// Types that are valid to be assigned to Data:
+	//	*ZkTokenAction_ZkImport
+	//	*ZkTokenAction_ZkTransfer
+	//	*ZkTokenAction_ZkRedeem
+	Data                 isZkTokenAction_Data `protobuf_oneof:"data"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ZkTokenAction) Reset()         { *m = ZkTokenAction{} }
+func (m *ZkTokenAction) String() string { return proto.CompactTextString(m) }
+func (*ZkTokenAction) ProtoMessage()    {}
+func (*ZkTokenAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{17}
+}
+func (m *ZkTokenAction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ZkTokenAction.Unmarshal(m, b)
+}
+func (m *ZkTokenAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ZkTokenAction.Marshal(b, m, deterministic)
+}
+func (dst *ZkTokenAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ZkTokenAction.Merge(dst, src)
+}
+func (m *ZkTokenAction) XXX_Size() int {
+	return xxx_messageInfo_ZkTokenAction.Size(m)
+}
+func (m *ZkTokenAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_ZkTokenAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ZkTokenAction proto.InternalMessageInfo
+
+type isZkTokenAction_Data interface {
+	isZkTokenAction_Data()
+}
+
+type ZkTokenAction_ZkImport struct {
+	ZkImport *ZkImport `protobuf:"bytes,1,opt,name=zk_import,json=zkImport,proto3,oneof"`
+}
+
+type ZkTokenAction_ZkTransfer struct {
+	ZkTransfer *ZkTransfer `protobuf:"bytes,2,opt,name=zk_transfer,json=zkTransfer,proto3,oneof"`
+}
+
+type ZkTokenAction_ZkRedeem struct {
+	ZkRedeem *ZkRedeem `protobuf:"bytes,3,opt,name=zk_redeem,json=zkRedeem,proto3,oneof"`
+}
+
+func (*ZkTokenAction_ZkImport) isZkTokenAction_Data() {}
+
+func (*ZkTokenAction_ZkTransfer) isZkTokenAction_Data() {}
+
+func (*ZkTokenAction_ZkRedeem) isZkTokenAction_Data() {}
+
+func (m *ZkTokenAction) GetData() isZkTokenAction_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ZkTokenAction) GetZkImport() *ZkImport {
+	if x, ok := m.GetData().(*ZkTokenAction_ZkImport); ok {
+		return x.ZkImport
+	}
+	return nil
+}
+
+func (m *ZkTokenAction) GetZkTransfer() *ZkTransfer {
+	if x, ok := m.GetData().(*ZkTokenAction_ZkTransfer); ok {
+		return x.ZkTransfer
+	}
+	return nil
+}
+
+func (m *ZkTokenAction) GetZkRedeem() *ZkRedeem {
+	if x, ok := m.GetData().(*ZkTokenAction_ZkRedeem); ok {
+		return x.ZkRedeem
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*ZkTokenAction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _ZkTokenAction_OneofMarshaler, _ZkTokenAction_OneofUnmarshaler, _ZkTokenAction_OneofSizer, []interface{}{
+		(*ZkTokenAction_ZkImport)(nil),
+		(*ZkTokenAction_ZkTransfer)(nil),
+		(*ZkTokenAction_ZkRedeem)(nil),
+	}
+}
+
+func _ZkTokenAction_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*ZkTokenAction)
+	// data
+	switch x := m.Data.(type) {
+	case *ZkTokenAction_ZkImport:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ZkImport); err != nil {
+			return err
+		}
+	case *ZkTokenAction_ZkTransfer:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ZkTransfer); err != nil {
+			return err
+		}
+	case *ZkTokenAction_ZkRedeem:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ZkRedeem); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("ZkTokenAction.Data has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _ZkTokenAction_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*ZkTokenAction)
+	switch tag {
+	case 1: // data.zk_import
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ZkImport)
+		err := b.DecodeMessage(msg)
+		m.Data = &ZkTokenAction_ZkImport{msg}
+		return true, err
+	case 2: // data.zk_transfer
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ZkTransfer)
+		err := b.DecodeMessage(msg)
+		m.Data = &ZkTokenAction_ZkTransfer{msg}
+		return true, err
+	case 3: // data.zk_redeem
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ZkRedeem)
+		err := b.DecodeMessage(msg)
+		m.Data = &ZkTokenAction_ZkRedeem{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _ZkTokenAction_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*ZkTokenAction)
+	// data
+	switch x := m.Data.(type) {
+	case *ZkTokenAction_ZkImport:
+		s := proto.Size(x.ZkImport)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ZkTokenAction_ZkTransfer:
+		s := proto.Size(x.ZkTransfer)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ZkTokenAction_ZkRedeem:
+		s := proto.Size(x.ZkRedeem)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// ZkImport specifies an import of one or more tokens with hidden quantities
+type ZkImport struct {
+	// An import transaction may contain one or more outputs
+	Outputs              []*ZkOutput `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ZkImport) Reset()         { *m = ZkImport{} }
+func (m *ZkImport) String() string { return proto.CompactTextString(m) }
+func (*ZkImport) ProtoMessage()    {}
+func (*ZkImport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{18}
+}
+func (m *ZkImport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ZkImport.Unmarshal(m, b)
+}
+func (m *ZkImport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ZkImport.Marshal(b, m, deterministic)
+}
+func (dst *ZkImport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ZkImport.Merge(dst, src)
+}
+func (m *ZkImport) XXX_Size() int {
+	return xxx_messageInfo_ZkImport.Size(m)
+}
+func (m *ZkImport) XXX_DiscardUnknown() {
+	xxx_messageInfo_ZkImport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ZkImport proto.InternalMessageInfo
+
+func (m *ZkImport) GetOutputs() []*ZkOutput {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+// ZkTransfer specifies a transfer of one or more tokens with hidden quantities to one or more outputs
+type ZkTransfer struct {
+	// The inputs to the transfer transaction are specified by their ID
+	Inputs []*InputId `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// A transfer transaction may contain one or more outputs
+	Outputs []*ZkOutput `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// The proof that the inputs and the outputs commit to the same total quantity
+	BalanceProof *SchnorrProof `protobuf:"bytes,3,opt,name=balance_proof,json=balanceProof,proto3" json:"balance_proof,omitempty"`
+	// The signatures of the owners of the inputs, in the order of the inputs
+	InputSignatures      [][]byte `protobuf:"bytes,4,rep,name=input_signatures,json=inputSignatures,proto3" json:"input_signatures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ZkTransfer) Reset()         { *m = ZkTransfer{} }
+func (m *ZkTransfer) String() string { return proto.CompactTextString(m) }
+func (*ZkTransfer) ProtoMessage()    {}
+func (*ZkTransfer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{19}
+}
+func (m *ZkTransfer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ZkTransfer.Unmarshal(m, b)
+}
+func (m *ZkTransfer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ZkTransfer.Marshal(b, m, deterministic)
+}
+func (dst *ZkTransfer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ZkTransfer.Merge(dst, src)
+}
+func (m *ZkTransfer) XXX_Size() int {
+	return xxx_messageInfo_ZkTransfer.Size(m)
+}
+func (m *ZkTransfer) XXX_DiscardUnknown() {
+	xxx_messageInfo_ZkTransfer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ZkTransfer proto.InternalMessageInfo
+
+func (m *ZkTransfer) GetInputs() []*InputId {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *ZkTransfer) GetOutputs() []*ZkOutput {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+func (m *ZkTransfer) GetBalanceProof() *SchnorrProof {
+	if m != nil {
+		return m.BalanceProof
+	}
+	return nil
+}
+
+func (m *ZkTransfer) GetInputSignatures() [][]byte {
+	if m != nil {
+		return m.InputSignatures
+	}
+	return nil
+}
+
+// ZkRedeem specifies a redemption of a public quantity out of one or more tokens with hidden quantities
+type ZkRedeem struct {
+	// The inputs to the redeem transaction are specified by their ID
+	Inputs []*InputId `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// The quantity of tokens that is redeemed
+	Quantity uint64 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// A redeem transaction may contain an output for the remaining tokens
+	Outputs []*ZkOutput `protobuf:"bytes,3,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// The proof that the inputs commit to the redeemed quantity plus the quantity of the outputs
+	BalanceProof *SchnorrProof `protobuf:"bytes,4,opt,name=balance_proof,json=balanceProof,proto3" json:"balance_proof,omitempty"`
+	// The signatures of the owners of the inputs, in the order of the inputs
+	InputSignatures      [][]byte `protobuf:"bytes,5,rep,name=input_signatures,json=inputSignatures,proto3" json:"input_signatures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ZkRedeem) Reset()         { *m = ZkRedeem{} }
+func (m *ZkRedeem) String() string { return proto.CompactTextString(m) }
+func (*ZkRedeem) ProtoMessage()    {}
+func (*ZkRedeem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{20}
+}
+func (m *ZkRedeem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ZkRedeem.Unmarshal(m, b)
+}
+func (m *ZkRedeem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ZkRedeem.Marshal(b, m, deterministic)
+}
+func (dst *ZkRedeem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ZkRedeem.Merge(dst, src)
+}
+func (m *ZkRedeem) XXX_Size() int {
+	return xxx_messageInfo_ZkRedeem.Size(m)
+}
+func (m *ZkRedeem) XXX_DiscardUnknown() {
+	xxx_messageInfo_ZkRedeem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ZkRedeem proto.InternalMessageInfo
+
+func (m *ZkRedeem) GetInputs() []*InputId {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *ZkRedeem) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *ZkRedeem) GetOutputs() []*ZkOutput {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+func (m *ZkRedeem) GetBalanceProof() *SchnorrProof {
+	if m != nil {
+		return m.BalanceProof
+	}
+	return nil
+}
+
+func (m *ZkRedeem) GetInputSignatures() [][]byte {
+	if m != nil {
+		return m.InputSignatures
+	}
+	return nil
+}
+
+// A ZkOutput is the result of zero-knowledge import, transfer and redeem transactions
+type ZkOutput struct {
+	// The owner is a one-time pseudonym of the recipient
+	Owner []byte `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The ephemeral key the recipient uses to recognize the output and recover its opening
+	EphemeralKey []byte `protobuf:"bytes,2,opt,name=ephemeral_key,json=ephemeralKey,proto3" json:"ephemeral_key,omitempty"`
+	// The token type
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// The Pedersen commitment to the quantity of tokens
+	Commitment []byte `protobuf:"bytes,4,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	// The proof that the committed quantity is in range
+	RangeProof *RangeProof `protobuf:"bytes,5,opt,name=range_proof,json=rangeProof,proto3" json:"range_proof,omitempty"`
+	// The opening of the commitment, encrypted for the recipient
+	EncryptedOpening     []byte   `protobuf:"bytes,6,opt,name=encrypted_opening,json=encryptedOpening,proto3" json:"encrypted_opening,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ZkOutput) Reset()         { *m = ZkOutput{} }
+func (m *ZkOutput) String() string { return proto.CompactTextString(m) }
+func (*ZkOutput) ProtoMessage()    {}
+func (*ZkOutput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{21}
+}
+func (m *ZkOutput) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ZkOutput.Unmarshal(m, b)
+}
+func (m *ZkOutput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ZkOutput.Marshal(b, m, deterministic)
+}
+func (dst *ZkOutput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ZkOutput.Merge(dst, src)
+}
+func (m *ZkOutput) XXX_Size() int {
+	return xxx_messageInfo_ZkOutput.Size(m)
+}
+func (m *ZkOutput) XXX_DiscardUnknown() {
+	xxx_messageInfo_ZkOutput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ZkOutput proto.InternalMessageInfo
+
+func (m *ZkOutput) GetOwner() []byte {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+func (m *ZkOutput) GetEphemeralKey() []byte {
+	if m != nil {
+		return m.EphemeralKey
+	}
+	return nil
+}
+
+func (m *ZkOutput) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ZkOutput) GetCommitment() []byte {
+	if m != nil {
+		return m.Commitment
+	}
+	return nil
+}
+
+func (m *ZkOutput) GetRangeProof() *RangeProof {
+	if m != nil {
+		return m.RangeProof
+	}
+	return nil
+}
+
+func (m *ZkOutput) GetEncryptedOpening() []byte {
+	if m != nil {
+		return m.EncryptedOpening
+	}
+	return nil
+}
+
+// A RangeProof proves that a commitment hides a quantity between 1 and 2^n,
+// where n is the number of bit commitments
+type RangeProof struct {
+	// The commitments to the bits of the quantity minus one
+	BitCommitments [][]byte `protobuf:"bytes,1,rep,name=bit_commitments,json=bitCommitments,proto3" json:"bit_commitments,omitempty"`
+	// The proofs that each bit commitment hides either 0 or 1
+	BitProofs            []*BitProof `protobuf:"bytes,2,rep,name=bit_proofs,json=bitProofs,proto3" json:"bit_proofs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *RangeProof) Reset()         { *m = RangeProof{} }
+func (m *RangeProof) String() string { return proto.CompactTextString(m) }
+func (*RangeProof) ProtoMessage()    {}
+func (*RangeProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{22}
+}
+func (m *RangeProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RangeProof.Unmarshal(m, b)
+}
+func (m *RangeProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RangeProof.Marshal(b, m, deterministic)
+}
+func (dst *RangeProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RangeProof.Merge(dst, src)
+}
+func (m *RangeProof) XXX_Size() int {
+	return xxx_messageInfo_RangeProof.Size(m)
+}
+func (m *RangeProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_RangeProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RangeProof proto.InternalMessageInfo
+
+func (m *RangeProof) GetBitCommitments() [][]byte {
+	if m != nil {
+		return m.BitCommitments
+	}
+	return nil
+}
+
+func (m *RangeProof) GetBitProofs() []*BitProof {
+	if m != nil {
+		return m.BitProofs
+	}
+	return nil
+}
+
+// A BitProof is a proof of knowledge of the opening of a commitment to either 0 or 1
+type BitProof struct {
+	Challenge_0          []byte   `protobuf:"bytes,1,opt,name=challenge_0,json=challenge0,proto3" json:"challenge_0,omitempty"`
+	Challenge_1          []byte   `protobuf:"bytes,2,opt,name=challenge_1,json=challenge1,proto3" json:"challenge_1,omitempty"`
+	Response_0           []byte   `protobuf:"bytes,3,opt,name=response_0,json=response0,proto3" json:"response_0,omitempty"`
+	Response_1           []byte   `protobuf:"bytes,4,opt,name=response_1,json=response1,proto3" json:"response_1,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BitProof) Reset()         { *m = BitProof{} }
+func (m *BitProof) String() string { return proto.CompactTextString(m) }
+func (*BitProof) ProtoMessage()    {}
+func (*BitProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{23}
+}
+func (m *BitProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BitProof.Unmarshal(m, b)
+}
+func (m *BitProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BitProof.Marshal(b, m, deterministic)
+}
+func (dst *BitProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BitProof.Merge(dst, src)
+}
+func (m *BitProof) XXX_Size() int {
+	return xxx_messageInfo_BitProof.Size(m)
+}
+func (m *BitProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_BitProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BitProof proto.InternalMessageInfo
+
+func (m *BitProof) GetChallenge_0() []byte {
+	if m != nil {
+		return m.Challenge_0
+	}
+	return nil
+}
+
+func (m *BitProof) GetChallenge_1() []byte {
+	if m != nil {
+		return m.Challenge_1
+	}
+	return nil
+}
+
+func (m *BitProof) GetResponse_0() []byte {
+	if m != nil {
+		return m.Response_0
+	}
+	return nil
+}
+
+func (m *BitProof) GetResponse_1() []byte {
+	if m != nil {
+		return m.Response_1
+	}
+	return nil
+}
+
+// A SchnorrProof is a proof of knowledge of a discrete logarithm
+type SchnorrProof struct {
+	Challenge            []byte   `protobuf:"bytes,1,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	Response             []byte   `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SchnorrProof) Reset()         { *m = SchnorrProof{} }
+func (m *SchnorrProof) String() string { return proto.CompactTextString(m) }
+func (*SchnorrProof) ProtoMessage()    {}
+func (*SchnorrProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_1e6dfdad04c781ce, []int{24}
+}
+func (m *SchnorrProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchnorrProof.Unmarshal(m, b)
+}
+func (m *SchnorrProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchnorrProof.Marshal(b, m, deterministic)
+}
+func (dst *SchnorrProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchnorrProof.Merge(dst, src)
+}
+func (m *SchnorrProof) XXX_Size() int {
+	return xxx_messageInfo_SchnorrProof.Size(m)
+}
+func (m *SchnorrProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchnorrProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchnorrProof proto.InternalMessageInfo
+
+func (m *SchnorrProof) GetChallenge() []byte {
+	if m != nil {
+		return m.Challenge
+	}
+	return nil
+}
+
+func (m *SchnorrProof) GetResponse() []byte {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*TokenTransaction)(nil), "TokenTransaction")
 	proto.RegisterType((*PlainTokenAction)(nil), "PlainTokenAction")
@@ -769,48 +2013,107 @@ func init() {
 	proto.RegisterType((*PlainTransfer)(nil), "PlainTransfer")
 	proto.RegisterType((*PlainApprove)(nil), "PlainApprove")
 	proto.RegisterType((*PlainTransferFrom)(nil), "PlainTransferFrom")
+	proto.RegisterType((*PlainSwap)(nil), "PlainSwap")
+	proto.RegisterType((*PlainSwapSignature)(nil), "PlainSwapSignature")
+	proto.RegisterType((*PlainLock)(nil), "PlainLock")
+	proto.RegisterType((*PlainLockedOutput)(nil), "PlainLockedOutput")
+	proto.RegisterType((*PlainClaim)(nil), "PlainClaim")
+	proto.RegisterType((*PlainReclaim)(nil), "PlainReclaim")
 	proto.RegisterType((*PlainOutput)(nil), "PlainOutput")
+	proto.RegisterType((*NonFungibleToken)(nil), "NonFungibleToken")
+	proto.RegisterType((*TokenSupply)(nil), "TokenSupply")
 	proto.RegisterType((*InputId)(nil), "InputId")
 	proto.RegisterType((*PlainDelegatedOutput)(nil), "PlainDelegatedOutput")
+	proto.RegisterType((*ZkTokenAction)(nil), "ZkTokenAction")
+	proto.RegisterType((*ZkImport)(nil), "ZkImport")
+	proto.RegisterType((*ZkTransfer)(nil), "ZkTransfer")
+	proto.RegisterType((*ZkRedeem)(nil), "ZkRedeem")
+	proto.RegisterType((*ZkOutput)(nil), "ZkOutput")
+	proto.RegisterType((*RangeProof)(nil), "RangeProof")
+	proto.RegisterType((*BitProof)(nil), "BitProof")
+	proto.RegisterType((*SchnorrProof)(nil), "SchnorrProof")
 }
 
 func init() {
-	proto.RegisterFile("token/transaction.proto", fileDescriptor_transaction_4be9961cb2bdf952)
-}
-
-var fileDescriptor_transaction_4be9961cb2bdf952 = []byte{
-	// 518 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0xcd, 0x87, 0xe3, 0x86, 0x89, 0x5d, 0x92, 0x6d, 0x11, 0x16, 0x07, 0x14, 0x19, 0x84, 0x10,
-	0x07, 0x5b, 0xd0, 0x02, 0x57, 0x1a, 0x55, 0x28, 0x39, 0x01, 0x4b, 0x2e, 0x70, 0x89, 0x9c, 0xec,
-	0x36, 0xb5, 0x48, 0xbc, 0xcb, 0x66, 0x03, 0x89, 0xc4, 0x2f, 0xe1, 0xc2, 0xcf, 0xe0, 0xef, 0x21,
-	0xef, 0xac, 0x53, 0x3b, 0x10, 0xc4, 0x81, 0x9b, 0xdf, 0x9b, 0x79, 0x6f, 0xde, 0x8c, 0x2d, 0xc3,
-	0x5d, 0x2d, 0x3e, 0xf1, 0x2c, 0xd6, 0x2a, 0xc9, 0x56, 0xc9, 0x4c, 0xa7, 0x22, 0x8b, 0xa4, 0x12,
-	0x5a, 0x84, 0x63, 0xe8, 0x8e, 0xf3, 0xd2, 0xf8, 0xa6, 0x42, 0x5e, 0x80, 0x27, 0x17, 0x49, 0x9a,
-	0x4d, 0x10, 0x07, 0xf5, 0x7e, 0xfd, 0x71, 0xe7, 0x59, 0x2f, 0x7a, 0x9b, 0x93, 0xa6, 0xfb, 0xc2,
-	0x14, 0x86, 0x35, 0xda, 0x31, 0x8d, 0x08, 0x07, 0x6d, 0x70, 0x51, 0x11, 0xfe, 0x6c, 0x40, 0x77,
-	0xbf, 0x9b, 0x3c, 0x2d, 0x6c, 0xd3, 0xa5, 0x14, 0x4a, 0x5b, 0x5b, 0x0f, 0x6d, 0x47, 0x86, 0xdb,
-	0x39, 0x22, 0x24, 0x2f, 0xe1, 0x18, 0x25, 0x26, 0xf8, 0x15, 0x57, 0x41, 0xc3, 0x88, 0x8e, 0x6d,
-	0x16, 0xcb, 0x0e, 0x6b, 0xd4, 0x97, 0x65, 0x82, 0x9c, 0x15, 0xb3, 0x14, 0x67, 0x9c, 0x2f, 0x83,
-	0xe6, 0x01, 0x19, 0x4e, 0xa3, 0xa6, 0x89, 0x9c, 0x83, 0x6f, 0xf7, 0x96, 0x52, 0x89, 0x2f, 0x3c,
-	0x70, 0x8c, 0xca, 0x47, 0xd5, 0x05, 0x92, 0xc3, 0x1a, 0x45, 0x6b, 0x8b, 0xc9, 0x25, 0x9c, 0x54,
-	0x33, 0x4e, 0x5e, 0x2b, 0xb1, 0x0c, 0x5a, 0x46, 0x4b, 0xaa, 0x13, 0xf3, 0xca, 0xb0, 0x46, 0x7b,
-	0x72, 0x9f, 0x1c, 0xb8, 0xe0, 0xb0, 0x44, 0x27, 0xe1, 0x73, 0xe8, 0x94, 0xee, 0x41, 0x1e, 0xc1,
-	0x91, 0x58, 0x6b, 0xb9, 0xd6, 0xab, 0xa0, 0xde, 0x6f, 0xde, 0x9c, 0xeb, 0x8d, 0x21, 0x69, 0x51,
-	0x0c, 0x3f, 0x80, 0x5f, 0x19, 0x44, 0xfa, 0xe0, 0xa6, 0x59, 0x49, 0xd7, 0x8e, 0x46, 0x39, 0x1c,
-	0x31, 0x6a, 0xf9, 0xb2, 0x75, 0xe3, 0x6f, 0xd6, 0xdf, 0xeb, 0xe0, 0x95, 0x0f, 0xf0, 0x0f, 0xd6,
-	0x03, 0xe8, 0x31, 0xbe, 0xe0, 0xf3, 0x44, 0x73, 0x36, 0xa9, 0x0e, 0xb9, 0x83, 0x43, 0x2e, 0x8b,
-	0xb2, 0x9d, 0xd6, 0x65, 0x55, 0x62, 0x45, 0x1e, 0x82, 0x8b, 0x4a, 0xfb, 0xee, 0xaa, 0xe9, 0x6c,
-	0x2d, 0xfc, 0x51, 0x87, 0xde, 0x6f, 0x17, 0xfe, 0x7f, 0xcb, 0x93, 0x57, 0xd0, 0xdd, 0xdf, 0xc4,
-	0xe6, 0x39, 0xb0, 0xc8, 0xed, 0xbd, 0x45, 0xc2, 0xf7, 0xf6, 0x85, 0x22, 0x24, 0xa7, 0xd0, 0x12,
-	0x5f, 0x33, 0xae, 0xcc, 0xd7, 0xef, 0x51, 0x04, 0x84, 0x80, 0xa3, 0xb7, 0x92, 0x9b, 0xaf, 0xfb,
-	0x16, 0x35, 0xcf, 0xe4, 0x1e, 0xb4, 0x3f, 0xaf, 0x93, 0x4c, 0xa7, 0x7a, 0x6b, 0x46, 0x3a, 0x74,
-	0x87, 0xc3, 0x73, 0x38, 0xb2, 0x1b, 0x91, 0x13, 0x68, 0xe9, 0xcd, 0x24, 0x65, 0xc6, 0x30, 0xd7,
-	0x6e, 0x46, 0x2c, 0x9f, 0x92, 0x66, 0x8c, 0x6f, 0x8c, 0xa1, 0x4f, 0x11, 0x84, 0xdf, 0xe0, 0xf4,
-	0x4f, 0x99, 0x0f, 0x64, 0xba, 0x0f, 0x50, 0xec, 0xc2, 0xf1, 0x4a, 0x1e, 0x2d, 0x31, 0xbb, 0xcc,
-	0xcd, 0x03, 0x99, 0x9d, 0x6a, 0xe6, 0xc1, 0x3b, 0x78, 0x20, 0xd4, 0x3c, 0xba, 0xde, 0x4a, 0xae,
-	0x16, 0x9c, 0xcd, 0xb9, 0x8a, 0xae, 0x92, 0xa9, 0x4a, 0x67, 0xf8, 0x27, 0x5a, 0x45, 0xe6, 0x17,
-	0xf5, 0xf1, 0xc9, 0x3c, 0xd5, 0xd7, 0xeb, 0x69, 0x34, 0x13, 0xcb, 0xb8, 0xd4, 0x1b, 0x63, 0x6f,
-	0x8c, 0xbd, 0xb1, 0xe9, 0x9d, 0xba, 0x06, 0x9d, 0xfd, 0x0a, 0x00, 0x00, 0xff, 0xff, 0x03, 0x3a,
-	0x3a, 0x47, 0xde, 0x04, 0x00, 0x00,
+	proto.RegisterFile("token/transaction.proto", fileDescriptor_transaction_1e6dfdad04c781ce)
+}
+
+var fileDescriptor_transaction_1e6dfdad04c781ce = []byte{
+	// 1214 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0x4f, 0x6f, 0x1b, 0x45,
+	0x14, 0xf7, 0xff, 0xda, 0xcf, 0xeb, 0xd4, 0x9e, 0x16, 0xb0, 0x10, 0x84, 0x68, 0x83, 0x20, 0x50,
+	0x58, 0x27, 0x4d, 0xa1, 0x27, 0x24, 0x9a, 0x56, 0x95, 0x03, 0x15, 0x2d, 0x93, 0x5e, 0xc8, 0xc5,
+	0x5a, 0xef, 0x4e, 0xec, 0x91, 0xd7, 0xbb, 0xc3, 0x78, 0x4c, 0xe2, 0x88, 0x1b, 0x37, 0xc4, 0x27,
+	0x80, 0x03, 0xdf, 0x81, 0xcf, 0x81, 0x38, 0xf1, 0x5d, 0xb8, 0xa2, 0xf9, 0xb3, 0xb3, 0xbb, 0x6e,
+	0xfe, 0x81, 0x7a, 0x9b, 0xf7, 0x7b, 0xff, 0xdf, 0xbc, 0x7d, 0x6f, 0x16, 0xde, 0x12, 0xc9, 0x8c,
+	0xc4, 0x03, 0xc1, 0xfd, 0x78, 0xe1, 0x07, 0x82, 0x26, 0xb1, 0xc7, 0x78, 0x22, 0x12, 0xf7, 0xa7,
+	0x32, 0x74, 0x5f, 0x4a, 0xde, 0xcb, 0x8c, 0x85, 0x3e, 0x07, 0x87, 0x45, 0x3e, 0x8d, 0x47, 0x9a,
+	0xee, 0x97, 0xb7, 0xca, 0x3b, 0xed, 0xfb, 0x3d, 0xef, 0x85, 0x04, 0x95, 0xf4, 0x23, 0xc5, 0x18,
+	0x96, 0x70, 0x5b, 0x09, 0x6a, 0x12, 0x7d, 0x0a, 0xad, 0xf3, 0x59, 0xaa, 0x54, 0x51, 0x4a, 0x1b,
+	0xde, 0xf1, 0xac, 0xa8, 0xd1, 0x3c, 0x9f, 0xe9, 0xf3, 0x41, 0x13, 0x1a, 0x5a, 0xd6, 0xfd, 0xa7,
+	0x0a, 0xdd, 0x75, 0xe3, 0x68, 0x2f, 0x8d, 0x82, 0xce, 0x59, 0xc2, 0x85, 0x89, 0xc2, 0xd1, 0x51,
+	0x1c, 0x2a, 0xcc, 0x06, 0xa0, 0x49, 0xf4, 0x10, 0x36, 0xb4, 0x8a, 0x4a, 0xf4, 0x84, 0x70, 0x1b,
+	0x85, 0xb6, 0x6e, 0xd0, 0x61, 0x09, 0x77, 0x58, 0x1e, 0x40, 0xfb, 0xa9, 0x2f, 0x4e, 0x42, 0x42,
+	0xe6, 0xfd, 0xea, 0x25, 0x6a, 0xda, 0x1b, 0x56, 0x42, 0xe8, 0x01, 0x74, 0x4c, 0x99, 0x18, 0xe3,
+	0xc9, 0x0f, 0xa4, 0x5f, 0x53, 0x5a, 0x1d, 0xad, 0xf5, 0x48, 0x83, 0xc3, 0x12, 0x76, 0x58, 0x8e,
+	0x46, 0x4f, 0xe0, 0x4e, 0x31, 0xc6, 0xd1, 0x53, 0x9e, 0xcc, 0xfb, 0x75, 0xa5, 0x8b, 0x8a, 0x1e,
+	0x25, 0x67, 0x58, 0xc2, 0x3d, 0xb6, 0x0e, 0xa2, 0x7b, 0x00, 0xda, 0xca, 0xe2, 0xd4, 0x67, 0xfd,
+	0x86, 0x52, 0x06, 0xad, 0x7c, 0x74, 0xea, 0xb3, 0x61, 0x09, 0xb7, 0x58, 0x4a, 0x64, 0xc2, 0x51,
+	0x12, 0xcc, 0xfa, 0xb7, 0xf2, 0xc2, 0xcf, 0x92, 0x60, 0x66, 0x85, 0x25, 0x81, 0x3c, 0xd0, 0x49,
+	0x8e, 0x82, 0xc8, 0xa7, 0xf3, 0x7e, 0x53, 0x49, 0xb7, 0xb5, 0xf4, 0x63, 0x09, 0x0d, 0x4b, 0x18,
+	0x98, 0xa5, 0xb2, 0x2a, 0x70, 0xa2, 0x35, 0x5a, 0xf9, 0x2a, 0x60, 0x0d, 0xda, 0x2a, 0x18, 0xfa,
+	0xa0, 0x01, 0xb5, 0xd0, 0x17, 0xbe, 0xfb, 0x19, 0xb4, 0x73, 0xf7, 0x89, 0x3e, 0x80, 0x5b, 0xc9,
+	0x52, 0xb0, 0xa5, 0x58, 0xf4, 0xcb, 0x5b, 0xd5, 0xec, 0xba, 0x9f, 0x2b, 0x10, 0xa7, 0x4c, 0xf7,
+	0x3b, 0xe8, 0x14, 0x0a, 0x85, 0xb6, 0xa0, 0x41, 0xe3, 0x9c, 0x5e, 0xd3, 0x3b, 0x94, 0xe4, 0x61,
+	0x88, 0x0d, 0x9e, 0x37, 0x5d, 0xb9, 0xca, 0xf4, 0xaf, 0x65, 0x70, 0xf2, 0x17, 0x78, 0x03, 0xd3,
+	0x07, 0xd0, 0x0b, 0x49, 0x44, 0x26, 0xbe, 0x20, 0xe1, 0xa8, 0xe8, 0xe4, 0x0d, 0xed, 0xe4, 0x49,
+	0xca, 0x36, 0xde, 0xba, 0x61, 0x11, 0x58, 0xa0, 0xf7, 0xa1, 0xa1, 0x35, 0x4d, 0xef, 0x15, 0xa3,
+	0x33, 0x3c, 0xf7, 0xf7, 0x32, 0xf4, 0x5e, 0xe9, 0x90, 0xd7, 0x97, 0x3c, 0xfa, 0x12, 0xba, 0xeb,
+	0x99, 0x98, 0x78, 0x2e, 0x49, 0xe4, 0xf6, 0x5a, 0x22, 0xee, 0xcf, 0x65, 0x68, 0xd9, 0x36, 0x7c,
+	0x8d, 0x91, 0xed, 0x03, 0x2c, 0xe8, 0x24, 0xf6, 0xc5, 0x92, 0x93, 0x45, 0xbf, 0xaa, 0x44, 0xef,
+	0x64, 0x0d, 0x7f, 0x94, 0xf2, 0x70, 0x4e, 0xcc, 0xfd, 0x0a, 0xd0, 0xab, 0x12, 0xe8, 0x4d, 0x68,
+	0x48, 0x19, 0xc2, 0xd5, 0x48, 0x71, 0xb0, 0xa1, 0xd0, 0x3b, 0xd0, 0xb2, 0xba, 0x6a, 0x70, 0x38,
+	0x38, 0x03, 0xdc, 0x5f, 0xd2, 0xc4, 0xd4, 0x57, 0x72, 0x7d, 0x62, 0x0f, 0xa1, 0x23, 0x3f, 0xb7,
+	0xac, 0x8e, 0x95, 0xfc, 0x17, 0xfe, 0x4c, 0xb1, 0x4c, 0x92, 0x4e, 0x94, 0xa3, 0x6e, 0xd8, 0x09,
+	0x7f, 0xa5, 0x9d, 0x90, 0xb7, 0xa4, 0x52, 0x23, 0x71, 0x98, 0x4b, 0x4d, 0x51, 0x32, 0x35, 0x4e,
+	0x02, 0xca, 0x28, 0x89, 0x45, 0x9a, 0x9a, 0x05, 0x10, 0x82, 0x9a, 0x58, 0x31, 0xa2, 0xfc, 0xb5,
+	0xb0, 0x3a, 0xa3, 0xb7, 0xa1, 0xf9, 0xfd, 0xd2, 0x8f, 0x05, 0x15, 0x2b, 0x35, 0xd7, 0x6a, 0xd8,
+	0xd2, 0x68, 0x1b, 0xaa, 0xf1, 0x89, 0x30, 0x23, 0xab, 0xe7, 0x7d, 0x93, 0xc4, 0x4f, 0x97, 0xf1,
+	0x84, 0x8e, 0x23, 0xa2, 0xe6, 0x37, 0x96, 0x5c, 0x69, 0x74, 0xea, 0x2f, 0xa6, 0x6a, 0x36, 0x39,
+	0x58, 0x9d, 0xa5, 0xd1, 0x90, 0xf8, 0x61, 0x44, 0x63, 0xa2, 0xc6, 0x50, 0x0d, 0x5b, 0xda, 0x1d,
+	0x02, 0x64, 0x33, 0x06, 0x6d, 0x42, 0x5d, 0xd5, 0xd1, 0x4c, 0xfd, 0xac, 0xbc, 0x1a, 0x96, 0x96,
+	0x18, 0x27, 0x74, 0xee, 0x4f, 0xd2, 0xab, 0xb2, 0xb4, 0xeb, 0x99, 0x0f, 0xd8, 0xcc, 0x9a, 0xeb,
+	0x6c, 0xb9, 0x67, 0x66, 0x06, 0x99, 0x1a, 0xde, 0x85, 0x7a, 0x72, 0x9a, 0x75, 0x87, 0x26, 0x6c,
+	0x8d, 0x2a, 0x97, 0xd4, 0xa8, 0x7a, 0x71, 0x8d, 0x6a, 0x57, 0xd5, 0xc8, 0x1d, 0x42, 0x77, 0x9d,
+	0x81, 0x36, 0xa0, 0x42, 0x43, 0xe5, 0xbb, 0x85, 0x2b, 0x34, 0x44, 0x5d, 0xa8, 0x2e, 0x39, 0x35,
+	0x7e, 0xe5, 0xd1, 0x56, 0xb6, 0x9a, 0x55, 0xd6, 0xfd, 0x02, 0xda, 0x4a, 0xfd, 0x68, 0xc9, 0x58,
+	0xb4, 0xb2, 0xd1, 0x96, 0x2f, 0x89, 0xb6, 0x52, 0x8c, 0xd6, 0x7d, 0x00, 0xb7, 0x4c, 0x51, 0xd0,
+	0x1d, 0xa8, 0x8b, 0xb3, 0x91, 0x0d, 0xa1, 0x26, 0xce, 0x0e, 0x43, 0x59, 0x13, 0x1a, 0x87, 0xe4,
+	0x4c, 0x29, 0x76, 0xb0, 0x26, 0xdc, 0x1f, 0xe1, 0xee, 0x45, 0x43, 0xe1, 0x92, 0x0a, 0x6e, 0x02,
+	0xa4, 0xc3, 0x82, 0xe8, 0x8f, 0xdd, 0xc1, 0x39, 0xe4, 0xbf, 0x76, 0xa1, 0xfb, 0x5b, 0x19, 0x3a,
+	0x85, 0xc7, 0x05, 0xda, 0x51, 0xef, 0x8f, 0xc2, 0x73, 0xa1, 0xe5, 0x1d, 0xcf, 0xec, 0x5b, 0xa1,
+	0x79, 0x6e, 0xce, 0x72, 0xc9, 0x9d, 0xcf, 0xd6, 0x5f, 0x09, 0x6d, 0xf9, 0x56, 0xc9, 0x76, 0x3d,
+	0x9c, 0x5b, 0xca, 0x58, 0x2e, 0x3c, 0x0e, 0xa4, 0x65, 0xfd, 0x10, 0xd0, 0x96, 0xf5, 0xd9, 0x2e,
+	0xb6, 0x01, 0x34, 0x53, 0xcf, 0x68, 0x7b, 0x7d, 0xab, 0x49, 0xdd, 0xf5, 0xbd, 0xf3, 0x47, 0x19,
+	0x20, 0xf3, 0x7f, 0x83, 0x01, 0xb3, 0xbd, 0x3e, 0x39, 0x2f, 0xb0, 0x8a, 0xee, 0x43, 0x67, 0xec,
+	0x47, 0x7e, 0x1c, 0x90, 0x11, 0xe3, 0x49, 0x72, 0x62, 0x82, 0xef, 0x78, 0x47, 0xc1, 0x34, 0x4e,
+	0x38, 0x7f, 0x21, 0x41, 0xec, 0x18, 0x19, 0x45, 0xa1, 0x8f, 0xa0, 0xab, 0x5c, 0x8c, 0x72, 0x03,
+	0xb7, 0xa6, 0xae, 0xeb, 0xb6, 0xc2, 0x8f, 0xb2, 0x01, 0xfb, 0x67, 0x59, 0xa6, 0x69, 0xde, 0x43,
+	0xd7, 0x87, 0x7c, 0x45, 0x0b, 0xe6, 0xd3, 0xa9, 0xde, 0x3c, 0x9d, 0xda, 0xff, 0x4b, 0xa7, 0x7e,
+	0x71, 0x3a, 0x7f, 0xab, 0x74, 0xae, 0xec, 0xe2, 0x6d, 0xe8, 0x10, 0x36, 0x25, 0x73, 0xc2, 0xfd,
+	0x68, 0x34, 0x23, 0x2b, 0x33, 0x7d, 0x1c, 0x0b, 0x7e, 0x4d, 0x56, 0x17, 0xb6, 0xf2, 0x26, 0x40,
+	0x90, 0xcc, 0xe7, 0x54, 0xcc, 0xe5, 0x0c, 0xae, 0x29, 0xad, 0x1c, 0x82, 0x3e, 0x81, 0x36, 0xf7,
+	0xe3, 0x49, 0x9a, 0x58, 0xdd, 0xb4, 0x24, 0x96, 0x98, 0x4e, 0x0b, 0xb8, 0x3d, 0xa3, 0x7b, 0xd0,
+	0x23, 0x71, 0xc0, 0x57, 0x4c, 0x2d, 0x6a, 0x46, 0x62, 0x1a, 0x4f, 0xcc, 0xa8, 0xed, 0x5a, 0xc6,
+	0x73, 0x8d, 0xbb, 0x23, 0x80, 0xcc, 0x0c, 0xfa, 0x10, 0x6e, 0x8f, 0xa9, 0x18, 0x65, 0xae, 0xf5,
+	0x7d, 0x39, 0x78, 0x63, 0x4c, 0xc5, 0xe3, 0x0c, 0x45, 0x3b, 0x00, 0x52, 0x50, 0xc5, 0x93, 0xf5,
+	0xd8, 0x01, 0x15, 0x3a, 0x9c, 0xd6, 0xd8, 0x9c, 0x16, 0x72, 0xe9, 0x37, 0x53, 0x1c, 0xbd, 0x07,
+	0xed, 0x60, 0xea, 0x47, 0x11, 0x91, 0xc9, 0xec, 0x9a, 0xea, 0x81, 0x85, 0x76, 0x8b, 0x02, 0x7b,
+	0xfd, 0xca, 0x9a, 0xc0, 0x1e, 0x7a, 0x17, 0x80, 0x93, 0x05, 0x4b, 0xe2, 0x85, 0x34, 0x50, 0x4d,
+	0xd7, 0x95, 0x46, 0x76, 0x0b, 0xec, 0xbd, 0x7e, 0xad, 0xc8, 0xde, 0x73, 0x87, 0xe0, 0xe4, 0xbb,
+	0x41, 0xee, 0x3e, 0x6b, 0xdb, 0x44, 0x93, 0x01, 0xb2, 0x25, 0x53, 0xd5, 0x74, 0x91, 0xa4, 0xf4,
+	0xc1, 0xb7, 0xb0, 0x9d, 0xf0, 0x89, 0x37, 0x5d, 0x31, 0xc2, 0x23, 0x12, 0x4e, 0x08, 0xf7, 0x4e,
+	0xfc, 0x31, 0xa7, 0x81, 0xfe, 0x79, 0x5a, 0x78, 0xea, 0xaf, 0xea, 0xf8, 0xe3, 0x09, 0x15, 0xd3,
+	0xe5, 0xd8, 0x0b, 0x92, 0xf9, 0x20, 0x27, 0x3b, 0xd0, 0xb2, 0x03, 0x2d, 0x3b, 0x50, 0xb2, 0xe3,
+	0x86, 0xa2, 0xf6, 0xff, 0x1d, 0x00, 0x4d, 0x8a, 0xcb, 0x23, 0x91, 0x0d, 0x00, 0x00,
 }
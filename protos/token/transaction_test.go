@@ -0,0 +1,33 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlainSwapSigningBytes(t *testing.T) {
+	swap := &PlainSwap{
+		Inputs:  []*InputId{{TxId: "tx", Index: 1}},
+		Outputs: []*PlainOutput{{Owner: []byte("alice"), Type: "TOK1", Quantity: 10}},
+	}
+	unsigned, err := swap.SigningBytes()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, unsigned)
+
+	swap.Signatures = []*PlainSwapSignature{{Signer: []byte("bob"), Signature: []byte("signature")}}
+	signed, err := swap.SigningBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, unsigned, signed, "signatures must not be part of the signed bytes")
+
+	swap.Outputs[0].Quantity = 11
+	changed, err := swap.SigningBytes()
+	assert.NoError(t, err)
+	assert.NotEqual(t, unsigned, changed)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/protos/common"
 	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
 )
@@ -347,6 +348,30 @@ func ChannelID(env *cb.Envelope) (string, error) {
 	return chdr.ChannelId, nil
 }
 
+// Creator returns the serialized identity of the creator of a given *cb.Envelope.
+func Creator(env *cb.Envelope) (*mspprotos.SerializedIdentity, error) {
+	envPayload, err := UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if envPayload.Header == nil {
+		return nil, errors.New("header not set")
+	}
+
+	shdr, err := UnmarshalSignatureHeader(envPayload.Header.SignatureHeader)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error unmarshaling signature header")
+	}
+
+	sid := &mspprotos.SerializedIdentity{}
+	if err := proto.Unmarshal(shdr.Creator, sid); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling creator")
+	}
+
+	return sid, nil
+}
+
 // EnvelopeToConfigUpdate is used to extract a ConfigUpdateEnvelope from an envelope of
 // type CONFIG_UPDATE
 func EnvelopeToConfigUpdate(configtx *cb.Envelope) (*cb.ConfigUpdateEnvelope, error) {
@@ -14,6 +14,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/crypto"
 	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/stretchr/testify/assert"
 )
@@ -396,6 +397,38 @@ func TestChannelHeader(t *testing.T) {
 	assert.Error(t, err, "Payload was missing")
 }
 
+func TestCreator(t *testing.T) {
+	makeEnvelope := func(payload *cb.Payload) *cb.Envelope {
+		return &cb.Envelope{
+			Payload: MarshalOrPanic(payload),
+		}
+	}
+
+	sid, err := Creator(makeEnvelope(&cb.Payload{
+		Header: &cb.Header{
+			SignatureHeader: MarshalOrPanic(&cb.SignatureHeader{
+				Creator: MarshalOrPanic(&mspprotos.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("cert")}),
+			}),
+		},
+	}))
+	assert.NoError(t, err, "Creator was present")
+	assert.Equal(t, "Org1MSP", sid.Mspid)
+	assert.Equal(t, []byte("cert"), sid.IdBytes)
+
+	_, err = Creator(makeEnvelope(&cb.Payload{
+		Header: &cb.Header{
+			SignatureHeader: MarshalOrPanic(&cb.SignatureHeader{Creator: []byte("garbage")}),
+		},
+	}))
+	assert.Error(t, err, "Creator was malformed")
+
+	_, err = Creator(makeEnvelope(&cb.Payload{}))
+	assert.Error(t, err, "Header was missing")
+
+	_, err = Creator(&cb.Envelope{Payload: []byte("garbage")})
+	assert.Error(t, err, "Payload was malformed")
+}
+
 func TestIsConfigBlock(t *testing.T) {
 	newBlock := func(env *cb.Envelope) *cb.Block {
 		return &cb.Block{
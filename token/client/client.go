@@ -11,6 +11,7 @@ import (
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/token"
 	tk "github.com/hyperledger/fabric/token"
+	"github.com/pkg/errors"
 )
 
 //go:generate counterfeiter -o mock/prover.go -fake-name Prover . Prover
@@ -29,6 +30,45 @@ type Prover interface {
 	// among recipients; it returns a response in bytes and an error message in the case the
 	// request fails
 	RequestTransfer(tokenIDs [][]byte, shares []*token.RecipientTransferShare, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// RequestRedeem allows the client to submit a redeem request to a prover peer service;
+	// the function takes as parameters the identifiers of the tokens to be redeemed, the quantity
+	// to redeem and the signing identity of the client; it returns a response in bytes and an
+	// error message in the case the request fails
+	RequestRedeem(tokenIDs [][]byte, quantity uint64, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// ListTokens allows the client to submit a list request to a prover peer service;
+	// it returns the unspent tokens owned by the client and an error message in the case
+	// the request fails
+	ListTokens(signingIdentity tk.SigningIdentity) ([]*token.TokenOutput, error)
+
+	// ListDelegatedTokens allows the client to submit a list request to a prover peer service;
+	// it returns the unspent delegated outputs of which the client is a delegatee and an error
+	// message in the case the request fails
+	ListDelegatedTokens(signingIdentity tk.SigningIdentity) ([]*token.TokenOutput, error)
+
+	// RequestApprove allows the client to submit an approve request to a prover peer service;
+	// the function takes as parameters the identifiers of the tokens to be delegated, the shares
+	// describing the allowance of each delegatee and the signing identity of the client;
+	// it returns a response in bytes and an error message in the case the request fails
+	RequestApprove(tokenIDs [][]byte, shares []*token.AllowanceRecipientShare, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// RequestTransferFrom allows the client to submit a transfer from request to a prover peer service;
+	// the function takes as parameters the identifiers of the delegated outputs to be spent, the shares
+	// describing how they are going to be distributed among recipients and the signing identity of
+	// the client; it returns a response in bytes and an error message in the case the request fails
+	RequestTransferFrom(tokenIDs [][]byte, shares []*token.RecipientTransferShare, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// RequestExpectation allows the client to submit an expectation request to a prover peer service;
+	// the function takes as parameters the identifiers of the tokens used to fulfill the expectation,
+	// if any, the expectation and the signing identity of the client; it returns a response in bytes
+	// and an error message in the case the request fails
+	RequestExpectation(tokenIDs [][]byte, expectation *token.TokenExpectation, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// GetSupply allows the client to submit a supply request to a prover peer service;
+	// it returns the outstanding supply of the given token types, or of all token types
+	// if none is given, and an error message in the case the request fails
+	GetSupply(types []string, signingIdentity tk.SigningIdentity) ([]*token.TokenSupply, error)
 }
 
 //go:generate counterfeiter -o mock/fabric_tx_submitter.go -fake-name FabricTxSubmitter . FabricTxSubmitter
@@ -47,6 +87,11 @@ type Client struct {
 	SigningIdentity tk.SigningIdentity
 	Prover          Prover
 	TxSubmitter     FabricTxSubmitter
+	// Selector picks the inputs of TransferByType and RedeemByType, usually a Wallet.
+	Selector Selector
+	// ChangeRecipient receives the change of TransferByType; it defaults to the
+	// serialized public version of SigningIdentity.
+	ChangeRecipient []byte
 }
 
 // Issue is the function that the client calls to introduce tokens into the system.
@@ -83,6 +128,182 @@ func (c *Client) Transfer(tokenIDs [][]byte, shares []*token.RecipientTransferSh
 	return tx, c.TxSubmitter.Submit(tx)
 }
 
+// Redeem is the function that the client calls to take tokens out of the system.
+// Redeem takes as parameter the identifiers of the tokens to redeem and the quantity
+// to redeem; the remaining quantity, if any, is transferred back to the client.
+func (c *Client) Redeem(tokenIDs [][]byte, quantity uint64) ([]byte, error) {
+	serializedTokenTx, err := c.Prover.RequestRedeem(tokenIDs, quantity, c.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := c.createTx(serializedTokenTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, c.TxSubmitter.Submit(tx)
+}
+
+// ListTokens is the function that the client calls to retrieve the unspent tokens it owns.
+func (c *Client) ListTokens() ([]*token.TokenOutput, error) {
+	return c.Prover.ListTokens(c.SigningIdentity)
+}
+
+// ListDelegatedTokens is the function that the client calls to retrieve the unspent
+// delegated outputs it can spend with TransferFrom.
+func (c *Client) ListDelegatedTokens() ([]*token.TokenOutput, error) {
+	return c.Prover.ListDelegatedTokens(c.SigningIdentity)
+}
+
+// ListNonFungibleTokens is the function that the client calls to retrieve the unspent non-fungible tokens it owns.
+func (c *Client) ListNonFungibleTokens() ([]*token.TokenOutput, error) {
+	tokens, err := c.ListTokens()
+	if err != nil {
+		return nil, err
+	}
+	var nfts []*token.TokenOutput
+	for _, tok := range tokens {
+		if tok.Nft != nil {
+			nfts = append(nfts, tok)
+		}
+	}
+	return nfts, nil
+}
+
+// TransferNonFungibleToken is the function that the client calls to transfer
+// the non-fungible token identified by tokenID, as a whole, to recipient.
+func (c *Client) TransferNonFungibleToken(tokenID []byte, recipient []byte) ([]byte, error) {
+	return c.Transfer([][]byte{tokenID}, []*token.RecipientTransferShare{{Recipient: recipient, Quantity: 1}})
+}
+
+// GetSupply is the function that auditors call to retrieve the outstanding supply
+// of the given token types, or of all token types if none is given.
+func (c *Client) GetSupply(types ...string) ([]*token.TokenSupply, error) {
+	return c.Prover.GetSupply(types, c.SigningIdentity)
+}
+
+// Approve is the function that the client calls to delegate the spending of its tokens.
+// Approve takes as parameter the identifiers of the tokens to delegate and the shares
+// describing the allowance of each delegatee; the remaining quantity, if any, stays with the client.
+func (c *Client) Approve(tokenIDs [][]byte, shares []*token.AllowanceRecipientShare) ([]byte, error) {
+	serializedTokenTx, err := c.Prover.RequestApprove(tokenIDs, shares, c.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := c.createTx(serializedTokenTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, c.TxSubmitter.Submit(tx)
+}
+
+// TransferFrom is the function that the client calls to spend tokens delegated to it.
+// TransferFrom takes as parameter the identifiers of the delegated outputs and the shares
+// describing how the tokens are distributed; the remaining quantity, if any, stays delegated to the client.
+func (c *Client) TransferFrom(tokenIDs [][]byte, shares []*token.RecipientTransferShare) ([]byte, error) {
+	serializedTokenTx, err := c.Prover.RequestTransferFrom(tokenIDs, shares, c.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := c.createTx(serializedTokenTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, c.TxSubmitter.Submit(tx)
+}
+
+// FulfillExpectation is the function that the client calls to import or transfer tokens
+// as described by an expectation set by another party; for a transfer expectation, tokenIDs
+// identifies the inputs and the remaining quantity, if any, is transferred back to the client.
+func (c *Client) FulfillExpectation(tokenIDs [][]byte, expectation *token.TokenExpectation) ([]byte, error) {
+	serializedTokenTx, err := c.Prover.RequestExpectation(tokenIDs, expectation, c.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := c.createTx(serializedTokenTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, c.TxSubmitter.Submit(tx)
+}
+
+// TransferByType transfers tokens of the passed type to the recipients of the shares,
+// picking the inputs with the Selector; the change, if any, goes to ChangeRecipient.
+// The inputs are unlocked if the transaction cannot be submitted.
+func (c *Client) TransferByType(tokenType string, shares []*token.RecipientTransferShare) ([]byte, error) {
+	if c.Selector == nil {
+		return nil, errors.New("no selector configured")
+	}
+	quantity := uint64(0)
+	for _, share := range shares {
+		var err error
+		quantity, err = addQuantity(quantity, share.Quantity)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid shares")
+		}
+	}
+	tokenIDs, total, err := c.Selector.Select(tokenType, quantity)
+	if err != nil {
+		return nil, err
+	}
+	if total > quantity {
+		changeRecipient, err := c.changeRecipient()
+		if err != nil {
+			c.unlock(tokenIDs)
+			return nil, err
+		}
+		shares = append(shares, &token.RecipientTransferShare{Recipient: changeRecipient, Quantity: total - quantity})
+	}
+
+	tx, err := c.Transfer(tokenIDs, shares)
+	if err != nil {
+		c.unlock(tokenIDs)
+	}
+	return tx, err
+}
+
+// RedeemByType redeems quantity tokens of the passed type, picking the inputs with the Selector.
+// The inputs are unlocked if the transaction cannot be submitted.
+func (c *Client) RedeemByType(tokenType string, quantity uint64) ([]byte, error) {
+	if c.Selector == nil {
+		return nil, errors.New("no selector configured")
+	}
+	tokenIDs, _, err := c.Selector.Select(tokenType, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := c.Redeem(tokenIDs, quantity)
+	if err != nil {
+		c.unlock(tokenIDs)
+	}
+	return tx, err
+}
+
+func (c *Client) changeRecipient() ([]byte, error) {
+	if len(c.ChangeRecipient) != 0 {
+		return c.ChangeRecipient, nil
+	}
+	return c.SigningIdentity.GetPublicVersion().Serialize()
+}
+
+func (c *Client) unlock(tokenIDs [][]byte) {
+	if err := c.Selector.Unlock(tokenIDs); err != nil {
+		logger.Errorf("failed unlocking tokens: %s", err)
+	}
+}
+
+// addQuantity returns the sum of two token quantities, or an error if the sum overflows.
+func addQuantity(sum, quantity uint64) (uint64, error) {
+	if sum+quantity < sum {
+		return 0, errors.Errorf("token sum overflows (%d + %d)", sum, quantity)
+	}
+	return sum + quantity, nil
+}
+
 // TODO to be updated later to have a proper fabric header
 // createTx is a function that creates a fabric tx form an array of bytes.
 func (c *Client) createTx(tokenTx []byte) ([]byte, error) {
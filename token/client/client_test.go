@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package client_test
 
 import (
+	"fmt"
+	"math"
+
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/token/client"
@@ -39,6 +42,10 @@ var _ = Describe("TokenClient", func() {
 		fakeProver = &mock.Prover{}
 		fakeProver.RequestImportReturns([]byte("tx-payload"), nil) // same data as payload
 		fakeProver.RequestTransferReturns([]byte("tx-payload"), nil)
+		fakeProver.RequestRedeemReturns([]byte("tx-payload"), nil)
+		fakeProver.RequestApproveReturns([]byte("tx-payload"), nil)
+		fakeProver.RequestTransferFromReturns([]byte("tx-payload"), nil)
+		fakeProver.RequestExpectationReturns([]byte("tx-payload"), nil)
 
 		fakeSigningIdentity = &mock.SigningIdentity{}
 		fakeSigningIdentity.SignReturns([]byte("tx-signature"), nil) // same signature as envelope
@@ -214,4 +221,392 @@ var _ = Describe("TokenClient", func() {
 			})
 		})
 	})
+
+	Describe("Redeem", func() {
+		var tokenIDs [][]byte
+
+		BeforeEach(func() {
+			tokenIDs = [][]byte{[]byte("id1"), []byte("id2")}
+		})
+
+		It("returns tx envelope without error", func() {
+			serializedTx, err := tokenClient.Redeem(tokenIDs, 50)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serializedTx).To(Equal(envelopeBytes))
+
+			Expect(fakeProver.RequestRedeemCallCount()).To(Equal(1))
+			ids, quantity, signingIdentity := fakeProver.RequestRedeemArgsForCall(0)
+			Expect(ids).To(Equal(tokenIDs))
+			Expect(quantity).To(Equal(uint64(50)))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+			raw := fakeTxSubmitter.SubmitArgsForCall(0)
+			Expect(raw).To(Equal(envelopeBytes))
+		})
+
+		Context("when prover.RequestRedeem fails", func() {
+			BeforeEach(func() {
+				fakeProver.RequestRedeemReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.Redeem(tokenIDs, 50)
+				Expect(err).To(MatchError("wild-banana"))
+
+				Expect(fakeSigningIdentity.SignCallCount()).To(Equal(0))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Approve", func() {
+		var (
+			tokenIDs [][]byte
+			shares   []*token.AllowanceRecipientShare
+		)
+
+		BeforeEach(func() {
+			tokenIDs = [][]byte{[]byte("id1"), []byte("id2")}
+			shares = []*token.AllowanceRecipientShare{{Recipient: []byte("bob"), Quantity: 50}}
+		})
+
+		It("returns tx envelope without error", func() {
+			serializedTx, err := tokenClient.Approve(tokenIDs, shares)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serializedTx).To(Equal(envelopeBytes))
+
+			Expect(fakeProver.RequestApproveCallCount()).To(Equal(1))
+			ids, arg, signingIdentity := fakeProver.RequestApproveArgsForCall(0)
+			Expect(ids).To(Equal(tokenIDs))
+			Expect(arg).To(Equal(shares))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+			raw := fakeTxSubmitter.SubmitArgsForCall(0)
+			Expect(raw).To(Equal(envelopeBytes))
+		})
+
+		Context("when prover.RequestApprove fails", func() {
+			BeforeEach(func() {
+				fakeProver.RequestApproveReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.Approve(tokenIDs, shares)
+				Expect(err).To(MatchError("wild-banana"))
+
+				Expect(fakeSigningIdentity.SignCallCount()).To(Equal(0))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("TransferFrom", func() {
+		var (
+			tokenIDs [][]byte
+			shares   []*token.RecipientTransferShare
+		)
+
+		BeforeEach(func() {
+			tokenIDs = [][]byte{[]byte("id1"), []byte("id2")}
+			shares = []*token.RecipientTransferShare{{Recipient: []byte("bob"), Quantity: 50}}
+		})
+
+		It("returns tx envelope without error", func() {
+			serializedTx, err := tokenClient.TransferFrom(tokenIDs, shares)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serializedTx).To(Equal(envelopeBytes))
+
+			Expect(fakeProver.RequestTransferFromCallCount()).To(Equal(1))
+			ids, arg, signingIdentity := fakeProver.RequestTransferFromArgsForCall(0)
+			Expect(ids).To(Equal(tokenIDs))
+			Expect(arg).To(Equal(shares))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+			raw := fakeTxSubmitter.SubmitArgsForCall(0)
+			Expect(raw).To(Equal(envelopeBytes))
+		})
+
+		Context("when prover.RequestTransferFrom fails", func() {
+			BeforeEach(func() {
+				fakeProver.RequestTransferFromReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.TransferFrom(tokenIDs, shares)
+				Expect(err).To(MatchError("wild-banana"))
+
+				Expect(fakeSigningIdentity.SignCallCount()).To(Equal(0))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("FulfillExpectation", func() {
+		var (
+			tokenIDs    [][]byte
+			expectation *token.TokenExpectation
+		)
+
+		BeforeEach(func() {
+			tokenIDs = [][]byte{[]byte("id1"), []byte("id2")}
+			expectation = &token.TokenExpectation{
+				Expectation: &token.TokenExpectation_PlainExpectation{
+					PlainExpectation: &token.PlainExpectation{
+						Payload: &token.PlainExpectation_TransferExpectation{
+							TransferExpectation: &token.PlainTokenExpectation{
+								Outputs: []*token.PlainOutput{{Owner: []byte("bob"), Type: "type", Quantity: 50}},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("returns tx envelope without error", func() {
+			serializedTx, err := tokenClient.FulfillExpectation(tokenIDs, expectation)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serializedTx).To(Equal(envelopeBytes))
+
+			Expect(fakeProver.RequestExpectationCallCount()).To(Equal(1))
+			ids, arg, signingIdentity := fakeProver.RequestExpectationArgsForCall(0)
+			Expect(ids).To(Equal(tokenIDs))
+			Expect(arg).To(Equal(expectation))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+			raw := fakeTxSubmitter.SubmitArgsForCall(0)
+			Expect(raw).To(Equal(envelopeBytes))
+		})
+
+		Context("when prover.RequestExpectation fails", func() {
+			BeforeEach(func() {
+				fakeProver.RequestExpectationReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.FulfillExpectation(tokenIDs, expectation)
+				Expect(err).To(MatchError("wild-banana"))
+
+				Expect(fakeSigningIdentity.SignCallCount()).To(Equal(0))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("TransferByType", func() {
+		var (
+			fakeSelector   *mock.Selector
+			fakeIdentity   *mock.Identity
+			transferShares []*token.RecipientTransferShare
+		)
+
+		BeforeEach(func() {
+			fakeSelector = &mock.Selector{}
+			fakeSelector.SelectReturns([][]byte{[]byte("id1"), []byte("id2")}, 200, nil)
+			fakeIdentity = &mock.Identity{}
+			fakeIdentity.SerializeReturns([]byte("creator"), nil)
+			fakeSigningIdentity.GetPublicVersionReturns(fakeIdentity)
+			tokenClient.Selector = fakeSelector
+
+			transferShares = []*token.RecipientTransferShare{
+				{Recipient: []byte("alice"), Quantity: 100},
+				{Recipient: []byte("Bob"), Quantity: 50},
+			}
+		})
+
+		It("transfers the selected tokens and sends the change to the client", func() {
+			serializedTx, err := tokenClient.TransferByType("USD", transferShares)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serializedTx).To(Equal(envelopeBytes))
+
+			Expect(fakeSelector.SelectCallCount()).To(Equal(1))
+			tokenType, quantity := fakeSelector.SelectArgsForCall(0)
+			Expect(tokenType).To(Equal("USD"))
+			Expect(quantity).To(Equal(uint64(150)))
+
+			Expect(fakeProver.RequestTransferCallCount()).To(Equal(1))
+			ids, shares, _ := fakeProver.RequestTransferArgsForCall(0)
+			Expect(ids).To(Equal([][]byte{[]byte("id1"), []byte("id2")}))
+			Expect(shares).To(Equal([]*token.RecipientTransferShare{
+				{Recipient: []byte("alice"), Quantity: 100},
+				{Recipient: []byte("Bob"), Quantity: 50},
+				{Recipient: []byte("creator"), Quantity: 50},
+			}))
+			Expect(fakeSelector.UnlockCallCount()).To(Equal(0))
+		})
+
+		It("sends the change to the change recipient when configured", func() {
+			tokenClient.ChangeRecipient = []byte("change")
+			_, err := tokenClient.TransferByType("USD", transferShares)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, shares, _ := fakeProver.RequestTransferArgsForCall(0)
+			Expect(shares[2]).To(Equal(&token.RecipientTransferShare{Recipient: []byte("change"), Quantity: 50}))
+		})
+
+		It("rejects shares whose quantities overflow", func() {
+			transferShares[1].Quantity = math.MaxUint64
+			_, err := tokenClient.TransferByType("USD", transferShares)
+			Expect(err).To(MatchError(fmt.Sprintf("invalid shares: token sum overflows (100 + %d)", uint64(math.MaxUint64))))
+			Expect(fakeSelector.SelectCallCount()).To(Equal(0))
+		})
+
+		It("does not add change when the selected tokens match the shares", func() {
+			fakeSelector.SelectReturns([][]byte{[]byte("id1")}, 150, nil)
+			_, err := tokenClient.TransferByType("USD", transferShares)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, shares, _ := fakeProver.RequestTransferArgsForCall(0)
+			Expect(shares).To(HaveLen(2))
+		})
+
+		Context("when the selector fails", func() {
+			BeforeEach(func() {
+				fakeSelector.SelectReturns(nil, 0, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.TransferByType("USD", transferShares)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeProver.RequestTransferCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the transaction cannot be submitted", func() {
+			BeforeEach(func() {
+				fakeTxSubmitter.SubmitReturns(errors.New("wild-banana"))
+			})
+
+			It("unlocks the selected tokens", func() {
+				_, err := tokenClient.TransferByType("USD", transferShares)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeSelector.UnlockCallCount()).To(Equal(1))
+				Expect(fakeSelector.UnlockArgsForCall(0)).To(Equal([][]byte{[]byte("id1"), []byte("id2")}))
+			})
+		})
+
+		Context("when no selector is configured", func() {
+			It("returns an error", func() {
+				tokenClient.Selector = nil
+				_, err := tokenClient.TransferByType("USD", transferShares)
+				Expect(err).To(MatchError("no selector configured"))
+			})
+		})
+	})
+
+	Describe("RedeemByType", func() {
+		var fakeSelector *mock.Selector
+
+		BeforeEach(func() {
+			fakeSelector = &mock.Selector{}
+			fakeSelector.SelectReturns([][]byte{[]byte("id1")}, 200, nil)
+			tokenClient.Selector = fakeSelector
+		})
+
+		It("redeems the selected tokens", func() {
+			serializedTx, err := tokenClient.RedeemByType("USD", 50)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serializedTx).To(Equal(envelopeBytes))
+
+			tokenType, quantity := fakeSelector.SelectArgsForCall(0)
+			Expect(tokenType).To(Equal("USD"))
+			Expect(quantity).To(Equal(uint64(50)))
+			ids, quantity, _ := fakeProver.RequestRedeemArgsForCall(0)
+			Expect(ids).To(Equal([][]byte{[]byte("id1")}))
+			Expect(quantity).To(Equal(uint64(50)))
+		})
+
+		Context("when the prover fails", func() {
+			BeforeEach(func() {
+				fakeProver.RequestRedeemReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("unlocks the selected tokens", func() {
+				_, err := tokenClient.RedeemByType("USD", 50)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeSelector.UnlockCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("ListTokens", func() {
+		It("returns the unspent tokens of the client", func() {
+			unspentTokens := []*token.TokenOutput{{Id: []byte("id1"), Type: "type", Quantity: 1}}
+			fakeProver.ListTokensReturns(unspentTokens, nil)
+
+			tokens, err := tokenClient.ListTokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(Equal(unspentTokens))
+			Expect(fakeProver.ListTokensCallCount()).To(Equal(1))
+			Expect(fakeProver.ListTokensArgsForCall(0)).To(Equal(fakeSigningIdentity))
+		})
+	})
+
+	Describe("ListDelegatedTokens", func() {
+		It("returns the unspent delegated outputs of the client", func() {
+			delegatedTokens := []*token.TokenOutput{{Id: []byte("id1"), Type: "type", Quantity: 1}}
+			fakeProver.ListDelegatedTokensReturns(delegatedTokens, nil)
+
+			tokens, err := tokenClient.ListDelegatedTokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(Equal(delegatedTokens))
+			Expect(fakeProver.ListDelegatedTokensCallCount()).To(Equal(1))
+			Expect(fakeProver.ListDelegatedTokensArgsForCall(0)).To(Equal(fakeSigningIdentity))
+		})
+	})
+
+	Describe("ListNonFungibleTokens", func() {
+		It("returns the unspent non-fungible tokens of the client", func() {
+			nft := &token.TokenOutput{Id: []byte("id2"), Type: "art", Quantity: 1, Nft: &token.NonFungibleToken{Id: "nft1", Uri: "uri"}}
+			fakeProver.ListTokensReturns([]*token.TokenOutput{{Id: []byte("id1"), Type: "type", Quantity: 1}, nft}, nil)
+
+			tokens, err := tokenClient.ListNonFungibleTokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(Equal([]*token.TokenOutput{nft}))
+		})
+
+		Context("when prover.ListTokens fails", func() {
+			BeforeEach(func() {
+				fakeProver.ListTokensReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.ListNonFungibleTokens()
+				Expect(err).To(MatchError("wild-banana"))
+			})
+		})
+	})
+
+	Describe("GetSupply", func() {
+		It("returns the supplies from the prover", func() {
+			supplies := []*token.TokenSupply{{Type: "USD", Quantity: 100}}
+			fakeProver.GetSupplyReturns(supplies, nil)
+
+			result, err := tokenClient.GetSupply("USD")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(supplies))
+
+			Expect(fakeProver.GetSupplyCallCount()).To(Equal(1))
+			types, signingIdentity := fakeProver.GetSupplyArgsForCall(0)
+			Expect(types).To(Equal([]string{"USD"}))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+		})
+	})
+
+	Describe("TransferNonFungibleToken", func() {
+		It("transfers the whole token to the recipient", func() {
+			serializedTx, err := tokenClient.TransferNonFungibleToken([]byte("id1"), []byte("alice"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serializedTx).To(Equal(envelopeBytes))
+
+			Expect(fakeProver.RequestTransferCallCount()).To(Equal(1))
+			ids, shares, _ := fakeProver.RequestTransferArgsForCall(0)
+			Expect(ids).To(Equal([][]byte{[]byte("id1")}))
+			Expect(shares).To(Equal([]*token.RecipientTransferShare{{Recipient: []byte("alice"), Quantity: 1}}))
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+		})
+	})
 })
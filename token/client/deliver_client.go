@@ -151,6 +151,23 @@ func CreateDeliverEnvelope(channelId string, creator []byte, signer SignerIdenti
 	return envelope, nil
 }
 
+// DeliverFilteredBlocks opens a DeliverFiltered stream to the commit peer at address, which delivers
+// the filtered blocks of the channel from the newest one on; the stream is meant to be passed to Wallet.Listen.
+func DeliverFilteredBlocks(ctx context.Context, dc DeliverClient, channelId string, creator []byte, signer SignerIdentity, address string) (DeliverFiltered, error) {
+	df, err := dc.NewDeliverFiltered(ctx)
+	if err != nil {
+		return nil, err
+	}
+	envelope, err := CreateDeliverEnvelope(channelId, creator, signer, dc.Certificate())
+	if err != nil {
+		return nil, err
+	}
+	if err := DeliverSend(df, address, envelope); err != nil {
+		return nil, err
+	}
+	return df, nil
+}
+
 func DeliverSend(df DeliverFiltered, address string, envelope *common.Envelope) error {
 	err := df.Send(envelope)
 	df.CloseSend()
@@ -149,6 +149,49 @@ var _ = Describe("OrdererClient", func() {
 		})
 	})
 
+	Describe("DeliverFilteredBlocks", func() {
+		var fakeDeliverClient *mock.DeliverClient
+
+		BeforeEach(func() {
+			fakeDeliverClient = &mock.DeliverClient{}
+			fakeDeliverClient.NewDeliverFilteredReturns(fakeDeliverFiltered, nil)
+		})
+
+		It("returns a stream on which the seek envelope is sent", func() {
+			df, err := client.DeliverFilteredBlocks(context.Background(), fakeDeliverClient, channelId, creator, fakeSigner, "dummyAddress")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(df).To(Equal(fakeDeliverFiltered))
+
+			Expect(fakeDeliverFiltered.SendCallCount()).To(Equal(1))
+			envelope := fakeDeliverFiltered.SendArgsForCall(0)
+			payload := &common.Payload{}
+			Expect(proto.Unmarshal(envelope.Payload, payload)).To(Succeed())
+			Expect(payload.Data).To(Equal(expectedPayloadData))
+		})
+
+		Context("when the stream cannot be created", func() {
+			BeforeEach(func() {
+				fakeDeliverClient.NewDeliverFilteredReturns(nil, errors.New("flying-pineapple"))
+			})
+
+			It("returns an error", func() {
+				_, err := client.DeliverFilteredBlocks(context.Background(), fakeDeliverClient, channelId, creator, fakeSigner, "dummyAddress")
+				Expect(err).To(MatchError("flying-pineapple"))
+			})
+		})
+
+		Context("when the seek envelope cannot be sent", func() {
+			BeforeEach(func() {
+				fakeDeliverFiltered.SendReturns(errors.New("flying-pineapple"))
+			})
+
+			It("returns an error", func() {
+				_, err := client.DeliverFilteredBlocks(context.Background(), fakeDeliverClient, channelId, creator, fakeSigner, "dummyAddress")
+				Expect(err).To(MatchError("failed to send deliver envelope to peer dummyAddress: flying-pineapple"))
+			})
+		})
+	})
+
 	Describe("DeliverReceive", func() {
 		var (
 			eventCh chan client.TxEvent
@@ -0,0 +1,177 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// NewLockSecret returns a random preimage and its SHA-256 hash, to be used to lock tokens.
+func NewLockSecret() (preimage []byte, hash []byte, err error) {
+	preimage = make([]byte, 32)
+	if _, err := rand.Read(preimage); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate preimage")
+	}
+	h := sha256.Sum256(preimage)
+	return preimage, h[:], nil
+}
+
+// LockedOutputID returns the ID of the locked output created by the lock transaction with ID txID.
+func LockedOutputID(txID string) []byte {
+	return []byte("\x00tokenLockedOutput\x00" + txID + "\x000\x00")
+}
+
+// Lock is the function that the client calls to lock quantity units of the tokens identified by tokenIDs
+// for recipient. The recipient can claim the tokens by revealing the preimage of hash in a block whose
+// number is below deadline; from the block of number deadline on, the client can reclaim them.
+// The remaining quantity, if any, stays with the client.
+// The ID of the locked output is given by LockedOutputID with the ID of the returned transaction.
+func (c *Client) Lock(tokenIDs [][]byte, recipient []byte, quantity uint64, hash []byte, deadline uint64) ([]byte, error) {
+	if len(tokenIDs) == 0 {
+		return nil, errors.New("no token ids in lock")
+	}
+	if quantity == 0 {
+		return nil, errors.New("quantity to lock must be greater than 0")
+	}
+	if len(hash) != sha256.Size {
+		return nil, errors.New("hash must be a SHA-256 hash")
+	}
+	var inputs []*token.InputId
+	for _, tokenID := range tokenIDs {
+		input, err := parseTokenID(tokenID)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, input)
+	}
+	tokenType, sum, nft, err := c.lockInputs(tokenIDs)
+	if err != nil {
+		return nil, err
+	}
+	if sum < quantity {
+		return nil, errors.Errorf("insufficient funds: %d of type '%s' requested, %d available", quantity, tokenType, sum)
+	}
+
+	sender, err := c.SigningIdentity.Serialize()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize signing identity")
+	}
+	lock := &token.PlainLock{
+		Inputs: inputs,
+		LockedOutput: &token.PlainLockedOutput{
+			Sender:    sender,
+			Recipient: recipient,
+			Type:      tokenType,
+			Quantity:  quantity,
+			Nft:       nft,
+			Hash:      hash,
+			Deadline:  deadline,
+		},
+	}
+	if sum > quantity {
+		lock.Output = &token.PlainOutput{Owner: sender, Type: tokenType, Quantity: sum - quantity}
+	}
+
+	return c.submitPlainAction(&token.PlainTokenAction{Data: &token.PlainTokenAction_PlainLock{PlainLock: lock}})
+}
+
+// Claim is the function that the recipient of a locked output calls to take ownership of its tokens,
+// by revealing the preimage of the hash of the lock.
+func (c *Client) Claim(lockedOutputID []byte, preimage []byte) ([]byte, error) {
+	input, err := parseLockedOutputID(lockedOutputID)
+	if err != nil {
+		return nil, err
+	}
+	claim := &token.PlainClaim{Input: input, Preimage: preimage}
+	return c.submitPlainAction(&token.PlainTokenAction{Data: &token.PlainTokenAction_PlainClaim{PlainClaim: claim}})
+}
+
+// Reclaim is the function that the sender of a locked output calls to take back its tokens
+// once the deadline of the lock has passed.
+func (c *Client) Reclaim(lockedOutputID []byte) ([]byte, error) {
+	input, err := parseLockedOutputID(lockedOutputID)
+	if err != nil {
+		return nil, err
+	}
+	reclaim := &token.PlainReclaim{Input: input}
+	return c.submitPlainAction(&token.PlainTokenAction{Data: &token.PlainTokenAction_PlainReclaim{PlainReclaim: reclaim}})
+}
+
+// lockInputs looks up the tokens identified by tokenIDs among the unspent tokens of the client
+// and returns their type, the sum of their quantities and the non-fungible token they carry, if any.
+func (c *Client) lockInputs(tokenIDs [][]byte) (string, uint64, *token.NonFungibleToken, error) {
+	tokens, err := c.ListTokens()
+	if err != nil {
+		return "", 0, nil, err
+	}
+	tokenType := ""
+	sum := uint64(0)
+	var nft *token.NonFungibleToken
+	for _, tokenID := range tokenIDs {
+		var found *token.TokenOutput
+		for _, tok := range tokens {
+			if bytes.Equal(tok.Id, tokenID) {
+				found = tok
+				break
+			}
+		}
+		if found == nil {
+			return "", 0, nil, errors.Errorf("token '%x' is not an unspent token of the client", tokenID)
+		}
+		if tokenType != "" && tokenType != found.Type {
+			return "", 0, nil, errors.Errorf("multiple token types ('%s', '%s') in lock", tokenType, found.Type)
+		}
+		tokenType = found.Type
+		sum, err = addQuantity(sum, found.Quantity)
+		if err != nil {
+			return "", 0, nil, errors.WithMessage(err, "invalid tokens")
+		}
+		if found.Nft != nil {
+			if len(tokenIDs) != 1 {
+				return "", 0, nil, errors.New("a non-fungible token must be locked on its own")
+			}
+			nft = found.Nft
+		}
+	}
+	return tokenType, sum, nft, nil
+}
+
+func (c *Client) submitPlainAction(action *token.PlainTokenAction) ([]byte, error) {
+	serializedTokenTx, err := proto.Marshal(&token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{PlainAction: action},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal token transaction")
+	}
+	tx, err := c.createTx(serializedTokenTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, c.TxSubmitter.Submit(tx)
+}
+
+// parseLockedOutputID returns the input identified by the ID of a locked output.
+func parseLockedOutputID(lockedOutputID []byte) (*token.InputId, error) {
+	components := strings.Split(string(lockedOutputID), "\x00")
+	if len(components) != 5 || components[0] != "" || components[1] != "tokenLockedOutput" || components[4] != "" {
+		return nil, errors.Errorf("invalid locked output id '%x'", lockedOutputID)
+	}
+	index, err := strconv.ParseUint(components[3], 10, 32)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid index in locked output id '%x'", lockedOutputID)
+	}
+	return &token.InputId{TxId: components[2], Index: uint32(index)}, nil
+}
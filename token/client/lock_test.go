@@ -0,0 +1,193 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Lock", func() {
+	var (
+		fakeSigningIdentity *mock.SigningIdentity
+		fakeProver          *mock.Prover
+		fakeTxSubmitter     *mock.FabricTxSubmitter
+		tokenClient         *client.Client
+
+		hash     []byte
+		deadline uint64
+	)
+
+	BeforeEach(func() {
+		fakeSigningIdentity = &mock.SigningIdentity{}
+		fakeSigningIdentity.SignReturns([]byte("tx-signature"), nil)
+		fakeSigningIdentity.SerializeReturns([]byte("alice"), nil)
+		fakeProver = &mock.Prover{}
+		fakeProver.ListTokensReturns([]*token.TokenOutput{
+			{Id: []byte("\x00tokenOutput\x00tx1\x000\x00"), Type: "USD", Quantity: 50},
+			{Id: []byte("\x00tokenOutput\x00tx2\x001\x00"), Type: "USD", Quantity: 30},
+			{Id: []byte("\x00tokenOutput\x00tx3\x000\x00"), Type: "EUR", Quantity: 10},
+			{Id: []byte("\x00tokenOutput\x00tx4\x000\x00"), Type: "ART", Quantity: 1, Nft: &token.NonFungibleToken{Id: "mona-lisa"}},
+		}, nil)
+		fakeTxSubmitter = &mock.FabricTxSubmitter{}
+		tokenClient = &client.Client{
+			SigningIdentity: fakeSigningIdentity,
+			Prover:          fakeProver,
+			TxSubmitter:     fakeTxSubmitter,
+		}
+
+		h := sha256.Sum256([]byte("secret"))
+		hash = h[:]
+		deadline = 20
+	})
+
+	plainAction := func(tx []byte) *token.PlainTokenAction {
+		envelope := &common.Envelope{}
+		Expect(proto.Unmarshal(tx, envelope)).To(Succeed())
+		payload := &common.Payload{}
+		Expect(proto.Unmarshal(envelope.Payload, payload)).To(Succeed())
+		tokenTx := &token.TokenTransaction{}
+		Expect(proto.Unmarshal(payload.Data, tokenTx)).To(Succeed())
+		return tokenTx.GetPlainAction()
+	}
+
+	Describe("NewLockSecret", func() {
+		It("returns a preimage and its hash", func() {
+			preimage, hash, err := client.NewLockSecret()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(preimage).To(HaveLen(32))
+			h := sha256.Sum256(preimage)
+			Expect(hash).To(Equal(h[:]))
+		})
+	})
+
+	Describe("Client.Lock", func() {
+		It("locks the requested quantity and returns the change to the client", func() {
+			tx, err := tokenClient.Lock([][]byte{
+				[]byte("\x00tokenOutput\x00tx1\x000\x00"),
+				[]byte("\x00tokenOutput\x00tx2\x001\x00"),
+			}, []byte("bob"), 60, hash, deadline)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+			Expect(fakeTxSubmitter.SubmitArgsForCall(0)).To(Equal(tx))
+
+			Expect(proto.Equal(plainAction(tx).GetPlainLock(), &token.PlainLock{
+				Inputs: []*token.InputId{{TxId: "tx1", Index: 0}, {TxId: "tx2", Index: 1}},
+				LockedOutput: &token.PlainLockedOutput{
+					Sender:    []byte("alice"),
+					Recipient: []byte("bob"),
+					Type:      "USD",
+					Quantity:  60,
+					Hash:      hash,
+					Deadline:  deadline,
+				},
+				Output: &token.PlainOutput{Owner: []byte("alice"), Type: "USD", Quantity: 20},
+			})).To(BeTrue())
+		})
+
+		It("locks a non-fungible token", func() {
+			tx, err := tokenClient.Lock([][]byte{[]byte("\x00tokenOutput\x00tx4\x000\x00")}, []byte("bob"), 1, hash, deadline)
+			Expect(err).NotTo(HaveOccurred())
+
+			lock := plainAction(tx).GetPlainLock()
+			Expect(lock.Output).To(BeNil())
+			Expect(proto.Equal(lock.LockedOutput.Nft, &token.NonFungibleToken{Id: "mona-lisa"})).To(BeTrue())
+		})
+
+		It("returns an error when the quantity exceeds the inputs", func() {
+			_, err := tokenClient.Lock([][]byte{[]byte("\x00tokenOutput\x00tx1\x000\x00")}, []byte("bob"), 60, hash, deadline)
+			Expect(err).To(MatchError("insufficient funds: 60 of type 'USD' requested, 50 available"))
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+		})
+
+		It("returns an error when the sum of the inputs overflows", func() {
+			fakeProver.ListTokensReturns([]*token.TokenOutput{
+				{Id: []byte("\x00tokenOutput\x00tx1\x000\x00"), Type: "USD", Quantity: 50},
+				{Id: []byte("\x00tokenOutput\x00tx2\x001\x00"), Type: "USD", Quantity: math.MaxUint64},
+			}, nil)
+			_, err := tokenClient.Lock([][]byte{
+				[]byte("\x00tokenOutput\x00tx1\x000\x00"),
+				[]byte("\x00tokenOutput\x00tx2\x001\x00"),
+			}, []byte("bob"), 10, hash, deadline)
+			Expect(err).To(MatchError(fmt.Sprintf("invalid tokens: token sum overflows (50 + %d)", uint64(math.MaxUint64))))
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+		})
+
+		It("returns an error when the inputs have different types", func() {
+			_, err := tokenClient.Lock([][]byte{
+				[]byte("\x00tokenOutput\x00tx1\x000\x00"),
+				[]byte("\x00tokenOutput\x00tx3\x000\x00"),
+			}, []byte("bob"), 10, hash, deadline)
+			Expect(err).To(MatchError("multiple token types ('USD', 'EUR') in lock"))
+		})
+
+		It("returns an error when an input is not an unspent token of the client", func() {
+			_, err := tokenClient.Lock([][]byte{[]byte("\x00tokenOutput\x00tx9\x000\x00")}, []byte("bob"), 10, hash, deadline)
+			Expect(err).To(MatchError("token '00746f6b656e4f757470757400747839003000' is not an unspent token of the client"))
+		})
+
+		It("returns an error when the hash is not a SHA-256 hash", func() {
+			_, err := tokenClient.Lock([][]byte{[]byte("\x00tokenOutput\x00tx1\x000\x00")}, []byte("bob"), 10, []byte("short"), deadline)
+			Expect(err).To(MatchError("hash must be a SHA-256 hash"))
+		})
+
+		It("returns an error when the quantity is 0", func() {
+			_, err := tokenClient.Lock([][]byte{[]byte("\x00tokenOutput\x00tx1\x000\x00")}, []byte("bob"), 0, hash, deadline)
+			Expect(err).To(MatchError("quantity to lock must be greater than 0"))
+		})
+
+		Context("when listing the tokens fails", func() {
+			BeforeEach(func() {
+				fakeProver.ListTokensReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.Lock([][]byte{[]byte("\x00tokenOutput\x00tx1\x000\x00")}, []byte("bob"), 10, hash, deadline)
+				Expect(err).To(MatchError("wild-banana"))
+			})
+		})
+	})
+
+	Describe("Client.Claim", func() {
+		It("submits a claim with the preimage", func() {
+			tx, err := tokenClient.Claim(client.LockedOutputID("lock-tx"), []byte("secret"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+			Expect(proto.Equal(plainAction(tx).GetPlainClaim(), &token.PlainClaim{
+				Input:    &token.InputId{TxId: "lock-tx", Index: 0},
+				Preimage: []byte("secret"),
+			})).To(BeTrue())
+		})
+
+		It("returns an error when the id is not the id of a locked output", func() {
+			_, err := tokenClient.Claim([]byte("\x00tokenOutput\x00tx1\x000\x00"), []byte("secret"))
+			Expect(err).To(MatchError("invalid locked output id '00746f6b656e4f757470757400747831003000'"))
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("Client.Reclaim", func() {
+		It("submits a reclaim", func() {
+			tx, err := tokenClient.Reclaim(client.LockedOutputID("lock-tx"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+			Expect(proto.Equal(plainAction(tx).GetPlainReclaim(), &token.PlainReclaim{
+				Input: &token.InputId{TxId: "lock-tx", Index: 0},
+			})).To(BeTrue())
+		})
+	})
+})
@@ -10,6 +10,76 @@ import (
 )
 
 type Prover struct {
+	GetSupplyStub        func([]string, tokena.SigningIdentity) ([]*token.TokenSupply, error)
+	getSupplyMutex       sync.RWMutex
+	getSupplyArgsForCall []struct {
+		arg1 []string
+		arg2 tokena.SigningIdentity
+	}
+	getSupplyReturns struct {
+		result1 []*token.TokenSupply
+		result2 error
+	}
+	getSupplyReturnsOnCall map[int]struct {
+		result1 []*token.TokenSupply
+		result2 error
+	}
+	ListDelegatedTokensStub        func(tokena.SigningIdentity) ([]*token.TokenOutput, error)
+	listDelegatedTokensMutex       sync.RWMutex
+	listDelegatedTokensArgsForCall []struct {
+		arg1 tokena.SigningIdentity
+	}
+	listDelegatedTokensReturns struct {
+		result1 []*token.TokenOutput
+		result2 error
+	}
+	listDelegatedTokensReturnsOnCall map[int]struct {
+		result1 []*token.TokenOutput
+		result2 error
+	}
+	ListTokensStub        func(tokena.SigningIdentity) ([]*token.TokenOutput, error)
+	listTokensMutex       sync.RWMutex
+	listTokensArgsForCall []struct {
+		arg1 tokena.SigningIdentity
+	}
+	listTokensReturns struct {
+		result1 []*token.TokenOutput
+		result2 error
+	}
+	listTokensReturnsOnCall map[int]struct {
+		result1 []*token.TokenOutput
+		result2 error
+	}
+	RequestApproveStub        func([][]byte, []*token.AllowanceRecipientShare, tokena.SigningIdentity) ([]byte, error)
+	requestApproveMutex       sync.RWMutex
+	requestApproveArgsForCall []struct {
+		arg1 [][]byte
+		arg2 []*token.AllowanceRecipientShare
+		arg3 tokena.SigningIdentity
+	}
+	requestApproveReturns struct {
+		result1 []byte
+		result2 error
+	}
+	requestApproveReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	RequestExpectationStub        func([][]byte, *token.TokenExpectation, tokena.SigningIdentity) ([]byte, error)
+	requestExpectationMutex       sync.RWMutex
+	requestExpectationArgsForCall []struct {
+		arg1 [][]byte
+		arg2 *token.TokenExpectation
+		arg3 tokena.SigningIdentity
+	}
+	requestExpectationReturns struct {
+		result1 []byte
+		result2 error
+	}
+	requestExpectationReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	RequestImportStub        func([]*token.TokenToIssue, tokena.SigningIdentity) ([]byte, error)
 	requestImportMutex       sync.RWMutex
 	requestImportArgsForCall []struct {
@@ -24,6 +94,21 @@ type Prover struct {
 		result1 []byte
 		result2 error
 	}
+	RequestRedeemStub        func([][]byte, uint64, tokena.SigningIdentity) ([]byte, error)
+	requestRedeemMutex       sync.RWMutex
+	requestRedeemArgsForCall []struct {
+		arg1 [][]byte
+		arg2 uint64
+		arg3 tokena.SigningIdentity
+	}
+	requestRedeemReturns struct {
+		result1 []byte
+		result2 error
+	}
+	requestRedeemReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	RequestTransferStub        func([][]byte, []*token.RecipientTransferShare, tokena.SigningIdentity) ([]byte, error)
 	requestTransferMutex       sync.RWMutex
 	requestTransferArgsForCall []struct {
@@ -39,10 +124,365 @@ type Prover struct {
 		result1 []byte
 		result2 error
 	}
+	RequestTransferFromStub        func([][]byte, []*token.RecipientTransferShare, tokena.SigningIdentity) ([]byte, error)
+	requestTransferFromMutex       sync.RWMutex
+	requestTransferFromArgsForCall []struct {
+		arg1 [][]byte
+		arg2 []*token.RecipientTransferShare
+		arg3 tokena.SigningIdentity
+	}
+	requestTransferFromReturns struct {
+		result1 []byte
+		result2 error
+	}
+	requestTransferFromReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *Prover) GetSupply(arg1 []string, arg2 tokena.SigningIdentity) ([]*token.TokenSupply, error) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.getSupplyMutex.Lock()
+	ret, specificReturn := fake.getSupplyReturnsOnCall[len(fake.getSupplyArgsForCall)]
+	fake.getSupplyArgsForCall = append(fake.getSupplyArgsForCall, struct {
+		arg1 []string
+		arg2 tokena.SigningIdentity
+	}{arg1Copy, arg2})
+	fake.recordInvocation("GetSupply", []interface{}{arg1Copy, arg2})
+	fake.getSupplyMutex.Unlock()
+	if fake.GetSupplyStub != nil {
+		return fake.GetSupplyStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getSupplyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) GetSupplyCallCount() int {
+	fake.getSupplyMutex.RLock()
+	defer fake.getSupplyMutex.RUnlock()
+	return len(fake.getSupplyArgsForCall)
+}
+
+func (fake *Prover) GetSupplyCalls(stub func([]string, tokena.SigningIdentity) ([]*token.TokenSupply, error)) {
+	fake.getSupplyMutex.Lock()
+	defer fake.getSupplyMutex.Unlock()
+	fake.GetSupplyStub = stub
+}
+
+func (fake *Prover) GetSupplyArgsForCall(i int) ([]string, tokena.SigningIdentity) {
+	fake.getSupplyMutex.RLock()
+	defer fake.getSupplyMutex.RUnlock()
+	argsForCall := fake.getSupplyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Prover) GetSupplyReturns(result1 []*token.TokenSupply, result2 error) {
+	fake.getSupplyMutex.Lock()
+	defer fake.getSupplyMutex.Unlock()
+	fake.GetSupplyStub = nil
+	fake.getSupplyReturns = struct {
+		result1 []*token.TokenSupply
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) GetSupplyReturnsOnCall(i int, result1 []*token.TokenSupply, result2 error) {
+	fake.getSupplyMutex.Lock()
+	defer fake.getSupplyMutex.Unlock()
+	fake.GetSupplyStub = nil
+	if fake.getSupplyReturnsOnCall == nil {
+		fake.getSupplyReturnsOnCall = make(map[int]struct {
+			result1 []*token.TokenSupply
+			result2 error
+		})
+	}
+	fake.getSupplyReturnsOnCall[i] = struct {
+		result1 []*token.TokenSupply
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) ListDelegatedTokens(arg1 tokena.SigningIdentity) ([]*token.TokenOutput, error) {
+	fake.listDelegatedTokensMutex.Lock()
+	ret, specificReturn := fake.listDelegatedTokensReturnsOnCall[len(fake.listDelegatedTokensArgsForCall)]
+	fake.listDelegatedTokensArgsForCall = append(fake.listDelegatedTokensArgsForCall, struct {
+		arg1 tokena.SigningIdentity
+	}{arg1})
+	fake.recordInvocation("ListDelegatedTokens", []interface{}{arg1})
+	fake.listDelegatedTokensMutex.Unlock()
+	if fake.ListDelegatedTokensStub != nil {
+		return fake.ListDelegatedTokensStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listDelegatedTokensReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) ListDelegatedTokensCallCount() int {
+	fake.listDelegatedTokensMutex.RLock()
+	defer fake.listDelegatedTokensMutex.RUnlock()
+	return len(fake.listDelegatedTokensArgsForCall)
+}
+
+func (fake *Prover) ListDelegatedTokensCalls(stub func(tokena.SigningIdentity) ([]*token.TokenOutput, error)) {
+	fake.listDelegatedTokensMutex.Lock()
+	defer fake.listDelegatedTokensMutex.Unlock()
+	fake.ListDelegatedTokensStub = stub
+}
+
+func (fake *Prover) ListDelegatedTokensArgsForCall(i int) tokena.SigningIdentity {
+	fake.listDelegatedTokensMutex.RLock()
+	defer fake.listDelegatedTokensMutex.RUnlock()
+	argsForCall := fake.listDelegatedTokensArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Prover) ListDelegatedTokensReturns(result1 []*token.TokenOutput, result2 error) {
+	fake.listDelegatedTokensMutex.Lock()
+	defer fake.listDelegatedTokensMutex.Unlock()
+	fake.ListDelegatedTokensStub = nil
+	fake.listDelegatedTokensReturns = struct {
+		result1 []*token.TokenOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) ListDelegatedTokensReturnsOnCall(i int, result1 []*token.TokenOutput, result2 error) {
+	fake.listDelegatedTokensMutex.Lock()
+	defer fake.listDelegatedTokensMutex.Unlock()
+	fake.ListDelegatedTokensStub = nil
+	if fake.listDelegatedTokensReturnsOnCall == nil {
+		fake.listDelegatedTokensReturnsOnCall = make(map[int]struct {
+			result1 []*token.TokenOutput
+			result2 error
+		})
+	}
+	fake.listDelegatedTokensReturnsOnCall[i] = struct {
+		result1 []*token.TokenOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) ListTokens(arg1 tokena.SigningIdentity) ([]*token.TokenOutput, error) {
+	fake.listTokensMutex.Lock()
+	ret, specificReturn := fake.listTokensReturnsOnCall[len(fake.listTokensArgsForCall)]
+	fake.listTokensArgsForCall = append(fake.listTokensArgsForCall, struct {
+		arg1 tokena.SigningIdentity
+	}{arg1})
+	fake.recordInvocation("ListTokens", []interface{}{arg1})
+	fake.listTokensMutex.Unlock()
+	if fake.ListTokensStub != nil {
+		return fake.ListTokensStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listTokensReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) ListTokensCallCount() int {
+	fake.listTokensMutex.RLock()
+	defer fake.listTokensMutex.RUnlock()
+	return len(fake.listTokensArgsForCall)
+}
+
+func (fake *Prover) ListTokensCalls(stub func(tokena.SigningIdentity) ([]*token.TokenOutput, error)) {
+	fake.listTokensMutex.Lock()
+	defer fake.listTokensMutex.Unlock()
+	fake.ListTokensStub = stub
+}
+
+func (fake *Prover) ListTokensArgsForCall(i int) tokena.SigningIdentity {
+	fake.listTokensMutex.RLock()
+	defer fake.listTokensMutex.RUnlock()
+	argsForCall := fake.listTokensArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Prover) ListTokensReturns(result1 []*token.TokenOutput, result2 error) {
+	fake.listTokensMutex.Lock()
+	defer fake.listTokensMutex.Unlock()
+	fake.ListTokensStub = nil
+	fake.listTokensReturns = struct {
+		result1 []*token.TokenOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) ListTokensReturnsOnCall(i int, result1 []*token.TokenOutput, result2 error) {
+	fake.listTokensMutex.Lock()
+	defer fake.listTokensMutex.Unlock()
+	fake.ListTokensStub = nil
+	if fake.listTokensReturnsOnCall == nil {
+		fake.listTokensReturnsOnCall = make(map[int]struct {
+			result1 []*token.TokenOutput
+			result2 error
+		})
+	}
+	fake.listTokensReturnsOnCall[i] = struct {
+		result1 []*token.TokenOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestApprove(arg1 [][]byte, arg2 []*token.AllowanceRecipientShare, arg3 tokena.SigningIdentity) ([]byte, error) {
+	var arg1Copy [][]byte
+	if arg1 != nil {
+		arg1Copy = make([][]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	var arg2Copy []*token.AllowanceRecipientShare
+	if arg2 != nil {
+		arg2Copy = make([]*token.AllowanceRecipientShare, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.requestApproveMutex.Lock()
+	ret, specificReturn := fake.requestApproveReturnsOnCall[len(fake.requestApproveArgsForCall)]
+	fake.requestApproveArgsForCall = append(fake.requestApproveArgsForCall, struct {
+		arg1 [][]byte
+		arg2 []*token.AllowanceRecipientShare
+		arg3 tokena.SigningIdentity
+	}{arg1Copy, arg2Copy, arg3})
+	fake.recordInvocation("RequestApprove", []interface{}{arg1Copy, arg2Copy, arg3})
+	fake.requestApproveMutex.Unlock()
+	if fake.RequestApproveStub != nil {
+		return fake.RequestApproveStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestApproveReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestApproveCallCount() int {
+	fake.requestApproveMutex.RLock()
+	defer fake.requestApproveMutex.RUnlock()
+	return len(fake.requestApproveArgsForCall)
+}
+
+func (fake *Prover) RequestApproveCalls(stub func([][]byte, []*token.AllowanceRecipientShare, tokena.SigningIdentity) ([]byte, error)) {
+	fake.requestApproveMutex.Lock()
+	defer fake.requestApproveMutex.Unlock()
+	fake.RequestApproveStub = stub
+}
+
+func (fake *Prover) RequestApproveArgsForCall(i int) ([][]byte, []*token.AllowanceRecipientShare, tokena.SigningIdentity) {
+	fake.requestApproveMutex.RLock()
+	defer fake.requestApproveMutex.RUnlock()
+	argsForCall := fake.requestApproveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Prover) RequestApproveReturns(result1 []byte, result2 error) {
+	fake.requestApproveMutex.Lock()
+	defer fake.requestApproveMutex.Unlock()
+	fake.RequestApproveStub = nil
+	fake.requestApproveReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestApproveReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.requestApproveMutex.Lock()
+	defer fake.requestApproveMutex.Unlock()
+	fake.RequestApproveStub = nil
+	if fake.requestApproveReturnsOnCall == nil {
+		fake.requestApproveReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.requestApproveReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestExpectation(arg1 [][]byte, arg2 *token.TokenExpectation, arg3 tokena.SigningIdentity) ([]byte, error) {
+	var arg1Copy [][]byte
+	if arg1 != nil {
+		arg1Copy = make([][]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.requestExpectationMutex.Lock()
+	ret, specificReturn := fake.requestExpectationReturnsOnCall[len(fake.requestExpectationArgsForCall)]
+	fake.requestExpectationArgsForCall = append(fake.requestExpectationArgsForCall, struct {
+		arg1 [][]byte
+		arg2 *token.TokenExpectation
+		arg3 tokena.SigningIdentity
+	}{arg1Copy, arg2, arg3})
+	fake.recordInvocation("RequestExpectation", []interface{}{arg1Copy, arg2, arg3})
+	fake.requestExpectationMutex.Unlock()
+	if fake.RequestExpectationStub != nil {
+		return fake.RequestExpectationStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestExpectationReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestExpectationCallCount() int {
+	fake.requestExpectationMutex.RLock()
+	defer fake.requestExpectationMutex.RUnlock()
+	return len(fake.requestExpectationArgsForCall)
+}
+
+func (fake *Prover) RequestExpectationCalls(stub func([][]byte, *token.TokenExpectation, tokena.SigningIdentity) ([]byte, error)) {
+	fake.requestExpectationMutex.Lock()
+	defer fake.requestExpectationMutex.Unlock()
+	fake.RequestExpectationStub = stub
+}
+
+func (fake *Prover) RequestExpectationArgsForCall(i int) ([][]byte, *token.TokenExpectation, tokena.SigningIdentity) {
+	fake.requestExpectationMutex.RLock()
+	defer fake.requestExpectationMutex.RUnlock()
+	argsForCall := fake.requestExpectationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Prover) RequestExpectationReturns(result1 []byte, result2 error) {
+	fake.requestExpectationMutex.Lock()
+	defer fake.requestExpectationMutex.Unlock()
+	fake.RequestExpectationStub = nil
+	fake.requestExpectationReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestExpectationReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.requestExpectationMutex.Lock()
+	defer fake.requestExpectationMutex.Unlock()
+	fake.RequestExpectationStub = nil
+	if fake.requestExpectationReturnsOnCall == nil {
+		fake.requestExpectationReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.requestExpectationReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Prover) RequestImport(arg1 []*token.TokenToIssue, arg2 tokena.SigningIdentity) ([]byte, error) {
 	var arg1Copy []*token.TokenToIssue
 	if arg1 != nil {
@@ -112,6 +552,76 @@ func (fake *Prover) RequestImportReturnsOnCall(i int, result1 []byte, result2 er
 	}{result1, result2}
 }
 
+func (fake *Prover) RequestRedeem(arg1 [][]byte, arg2 uint64, arg3 tokena.SigningIdentity) ([]byte, error) {
+	var arg1Copy [][]byte
+	if arg1 != nil {
+		arg1Copy = make([][]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.requestRedeemMutex.Lock()
+	ret, specificReturn := fake.requestRedeemReturnsOnCall[len(fake.requestRedeemArgsForCall)]
+	fake.requestRedeemArgsForCall = append(fake.requestRedeemArgsForCall, struct {
+		arg1 [][]byte
+		arg2 uint64
+		arg3 tokena.SigningIdentity
+	}{arg1Copy, arg2, arg3})
+	fake.recordInvocation("RequestRedeem", []interface{}{arg1Copy, arg2, arg3})
+	fake.requestRedeemMutex.Unlock()
+	if fake.RequestRedeemStub != nil {
+		return fake.RequestRedeemStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestRedeemReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestRedeemCallCount() int {
+	fake.requestRedeemMutex.RLock()
+	defer fake.requestRedeemMutex.RUnlock()
+	return len(fake.requestRedeemArgsForCall)
+}
+
+func (fake *Prover) RequestRedeemCalls(stub func([][]byte, uint64, tokena.SigningIdentity) ([]byte, error)) {
+	fake.requestRedeemMutex.Lock()
+	defer fake.requestRedeemMutex.Unlock()
+	fake.RequestRedeemStub = stub
+}
+
+func (fake *Prover) RequestRedeemArgsForCall(i int) ([][]byte, uint64, tokena.SigningIdentity) {
+	fake.requestRedeemMutex.RLock()
+	defer fake.requestRedeemMutex.RUnlock()
+	argsForCall := fake.requestRedeemArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Prover) RequestRedeemReturns(result1 []byte, result2 error) {
+	fake.requestRedeemMutex.Lock()
+	defer fake.requestRedeemMutex.Unlock()
+	fake.RequestRedeemStub = nil
+	fake.requestRedeemReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestRedeemReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.requestRedeemMutex.Lock()
+	defer fake.requestRedeemMutex.Unlock()
+	fake.RequestRedeemStub = nil
+	if fake.requestRedeemReturnsOnCall == nil {
+		fake.requestRedeemReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.requestRedeemReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Prover) RequestTransfer(arg1 [][]byte, arg2 []*token.RecipientTransferShare, arg3 tokena.SigningIdentity) ([]byte, error) {
 	var arg1Copy [][]byte
 	if arg1 != nil {
@@ -187,13 +697,102 @@ func (fake *Prover) RequestTransferReturnsOnCall(i int, result1 []byte, result2
 	}{result1, result2}
 }
 
+func (fake *Prover) RequestTransferFrom(arg1 [][]byte, arg2 []*token.RecipientTransferShare, arg3 tokena.SigningIdentity) ([]byte, error) {
+	var arg1Copy [][]byte
+	if arg1 != nil {
+		arg1Copy = make([][]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	var arg2Copy []*token.RecipientTransferShare
+	if arg2 != nil {
+		arg2Copy = make([]*token.RecipientTransferShare, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.requestTransferFromMutex.Lock()
+	ret, specificReturn := fake.requestTransferFromReturnsOnCall[len(fake.requestTransferFromArgsForCall)]
+	fake.requestTransferFromArgsForCall = append(fake.requestTransferFromArgsForCall, struct {
+		arg1 [][]byte
+		arg2 []*token.RecipientTransferShare
+		arg3 tokena.SigningIdentity
+	}{arg1Copy, arg2Copy, arg3})
+	fake.recordInvocation("RequestTransferFrom", []interface{}{arg1Copy, arg2Copy, arg3})
+	fake.requestTransferFromMutex.Unlock()
+	if fake.RequestTransferFromStub != nil {
+		return fake.RequestTransferFromStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestTransferFromReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestTransferFromCallCount() int {
+	fake.requestTransferFromMutex.RLock()
+	defer fake.requestTransferFromMutex.RUnlock()
+	return len(fake.requestTransferFromArgsForCall)
+}
+
+func (fake *Prover) RequestTransferFromCalls(stub func([][]byte, []*token.RecipientTransferShare, tokena.SigningIdentity) ([]byte, error)) {
+	fake.requestTransferFromMutex.Lock()
+	defer fake.requestTransferFromMutex.Unlock()
+	fake.RequestTransferFromStub = stub
+}
+
+func (fake *Prover) RequestTransferFromArgsForCall(i int) ([][]byte, []*token.RecipientTransferShare, tokena.SigningIdentity) {
+	fake.requestTransferFromMutex.RLock()
+	defer fake.requestTransferFromMutex.RUnlock()
+	argsForCall := fake.requestTransferFromArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Prover) RequestTransferFromReturns(result1 []byte, result2 error) {
+	fake.requestTransferFromMutex.Lock()
+	defer fake.requestTransferFromMutex.Unlock()
+	fake.RequestTransferFromStub = nil
+	fake.requestTransferFromReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestTransferFromReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.requestTransferFromMutex.Lock()
+	defer fake.requestTransferFromMutex.Unlock()
+	fake.RequestTransferFromStub = nil
+	if fake.requestTransferFromReturnsOnCall == nil {
+		fake.requestTransferFromReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.requestTransferFromReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Prover) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.getSupplyMutex.RLock()
+	defer fake.getSupplyMutex.RUnlock()
+	fake.listDelegatedTokensMutex.RLock()
+	defer fake.listDelegatedTokensMutex.RUnlock()
+	fake.listTokensMutex.RLock()
+	defer fake.listTokensMutex.RUnlock()
+	fake.requestApproveMutex.RLock()
+	defer fake.requestApproveMutex.RUnlock()
+	fake.requestExpectationMutex.RLock()
+	defer fake.requestExpectationMutex.RUnlock()
 	fake.requestImportMutex.RLock()
 	defer fake.requestImportMutex.RUnlock()
+	fake.requestRedeemMutex.RLock()
+	defer fake.requestRedeemMutex.RUnlock()
 	fake.requestTransferMutex.RLock()
 	defer fake.requestTransferMutex.RUnlock()
+	fake.requestTransferFromMutex.RLock()
+	defer fake.requestTransferFromMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
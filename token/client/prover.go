@@ -69,6 +69,147 @@ func (prover *ProverPeer) RequestTransfer(
 	return scr.Response, nil
 }
 
+func (prover *ProverPeer) RequestRedeem(tokenIDs [][]byte, quantity uint64, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	rr := &token.RedeemRequest{
+		TokenIds:         tokenIDs,
+		QuantityToRedeem: quantity,
+	}
+	payload := &token.Command_RedeemRequest{RedeemRequest: rr}
+
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr.Response, nil
+}
+
+func (prover *ProverPeer) RequestApprove(tokenIDs [][]byte, shares []*token.AllowanceRecipientShare, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	ar := &token.ApproveRequest{
+		TokenIds:        tokenIDs,
+		AllowanceShares: shares,
+	}
+	payload := &token.Command_ApproveRequest{ApproveRequest: ar}
+
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr.Response, nil
+}
+
+func (prover *ProverPeer) RequestTransferFrom(tokenIDs [][]byte, shares []*token.RecipientTransferShare, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	tr := &token.TransferRequest{
+		Shares:   shares,
+		TokenIds: tokenIDs,
+	}
+	payload := &token.Command_TransferFromRequest{TransferFromRequest: tr}
+
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr.Response, nil
+}
+
+func (prover *ProverPeer) RequestExpectation(tokenIDs [][]byte, expectation *token.TokenExpectation, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	er := &token.ExpectationRequest{
+		Expectation: expectation,
+		TokenIds:    tokenIDs,
+	}
+	payload := &token.Command_ExpectationRequest{ExpectationRequest: er}
+
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr.Response, nil
+}
+
+func (prover *ProverPeer) ListTokens(signingIdentity tk.SigningIdentity) ([]*token.TokenOutput, error) {
+	return prover.listTokens(&token.ListRequest{}, signingIdentity)
+}
+
+func (prover *ProverPeer) ListDelegatedTokens(signingIdentity tk.SigningIdentity) ([]*token.TokenOutput, error) {
+	return prover.listTokens(&token.ListRequest{Delegated: true}, signingIdentity)
+}
+
+func (prover *ProverPeer) listTokens(lr *token.ListRequest, signingIdentity tk.SigningIdentity) ([]*token.TokenOutput, error) {
+	payload := &token.Command_ListRequest{ListRequest: lr}
+
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	commandResp := &token.CommandResponse{}
+	err = proto.Unmarshal(scr.Response, commandResp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal command response")
+	}
+	if commandResp.GetErr() != nil {
+		return nil, errors.Errorf("error from prover: %s", commandResp.GetErr().GetMessage())
+	}
+	if commandResp.GetUnspentTokens() == nil {
+		return nil, errors.New("no unspent tokens in command response")
+	}
+
+	return commandResp.GetUnspentTokens().GetTokens(), nil
+}
+
+func (prover *ProverPeer) GetSupply(types []string, signingIdentity tk.SigningIdentity) ([]*token.TokenSupply, error) {
+	sr := &token.SupplyRequest{
+		Types: types,
+	}
+	payload := &token.Command_SupplyRequest{SupplyRequest: sr}
+
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	commandResp := &token.CommandResponse{}
+	err = proto.Unmarshal(scr.Response, commandResp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal command response")
+	}
+	if commandResp.GetErr() != nil {
+		return nil, errors.Errorf("error from prover: %s", commandResp.GetErr().GetMessage())
+	}
+	if commandResp.GetTokenSupplies() == nil {
+		return nil, errors.New("no token supplies in command response")
+	}
+
+	return commandResp.GetTokenSupplies().GetSupplies(), nil
+}
+
 func (prover *ProverPeer) CreateSignedCommand(payload interface{}, signingIdentity tk.SigningIdentity) (*token.SignedCommand, error) {
 
 	command, err := commandFromPayload(payload)
@@ -122,6 +263,18 @@ func commandFromPayload(payload interface{}) (*token.Command, error) {
 		return &token.Command{Payload: t}, nil
 	case *token.Command_TransferRequest:
 		return &token.Command{Payload: t}, nil
+	case *token.Command_RedeemRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_ListRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_ApproveRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_TransferFromRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_ExpectationRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_SupplyRequest:
+		return &token.Command{Payload: t}, nil
 	default:
 		return nil, errors.Errorf("command type not recognized: %T", t)
 	}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/token/client"
@@ -247,6 +248,393 @@ var _ = Describe("TokenClient", func() {
 			})
 		})
 	})
+
+	Describe("RequestRedeem", func() {
+		var (
+			tokenIDs          [][]byte
+			marshalledCommand []byte
+			signedCommand     *token.SignedCommand
+		)
+
+		BeforeEach(func() {
+			tokenIDs = [][]byte{[]byte("id1"), []byte("id2")}
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_RedeemRequest{
+					RedeemRequest: &token.RedeemRequest{
+						TokenIds:         tokenIDs,
+						QuantityToRedeem: 50,
+					},
+				},
+			}
+			marshalledCommand = ProtoMarshal(command)
+			signedCommand = &token.SignedCommand{
+				Command:   marshalledCommand,
+				Signature: []byte("pineapple"),
+			}
+		})
+
+		It("returns serialized token transaction", func() {
+			response, err := prover.RequestRedeem(tokenIDs, 50, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(marshalledCommand))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(signedCommand))
+		})
+
+		Context("when processcommand fails", func() {
+			BeforeEach(func() {
+				fakeProverClient.ProcessCommandReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := prover.RequestRedeem(tokenIDs, 50, fakeSigningIdentity)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+				Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("RequestApprove", func() {
+		var (
+			tokenIDs          [][]byte
+			shares            []*token.AllowanceRecipientShare
+			marshalledCommand []byte
+			signedCommand     *token.SignedCommand
+		)
+
+		BeforeEach(func() {
+			tokenIDs = [][]byte{[]byte("id1"), []byte("id2")}
+			shares = []*token.AllowanceRecipientShare{{Recipient: []byte("bob"), Quantity: 50}}
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_ApproveRequest{
+					ApproveRequest: &token.ApproveRequest{
+						TokenIds:        tokenIDs,
+						AllowanceShares: shares,
+					},
+				},
+			}
+			marshalledCommand = ProtoMarshal(command)
+			signedCommand = &token.SignedCommand{
+				Command:   marshalledCommand,
+				Signature: []byte("pineapple"),
+			}
+		})
+
+		It("returns serialized token transaction", func() {
+			response, err := prover.RequestApprove(tokenIDs, shares, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(marshalledCommand))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(signedCommand))
+		})
+
+		Context("when processcommand fails", func() {
+			BeforeEach(func() {
+				fakeProverClient.ProcessCommandReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := prover.RequestApprove(tokenIDs, shares, fakeSigningIdentity)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+				Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("RequestTransferFrom", func() {
+		var (
+			tokenIDs          [][]byte
+			shares            []*token.RecipientTransferShare
+			marshalledCommand []byte
+			signedCommand     *token.SignedCommand
+		)
+
+		BeforeEach(func() {
+			tokenIDs = [][]byte{[]byte("id1"), []byte("id2")}
+			shares = []*token.RecipientTransferShare{{Recipient: []byte("bob"), Quantity: 50}}
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_TransferFromRequest{
+					TransferFromRequest: &token.TransferRequest{
+						TokenIds: tokenIDs,
+						Shares:   shares,
+					},
+				},
+			}
+			marshalledCommand = ProtoMarshal(command)
+			signedCommand = &token.SignedCommand{
+				Command:   marshalledCommand,
+				Signature: []byte("pineapple"),
+			}
+		})
+
+		It("returns serialized token transaction", func() {
+			response, err := prover.RequestTransferFrom(tokenIDs, shares, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(marshalledCommand))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(signedCommand))
+		})
+
+		Context("when processcommand fails", func() {
+			BeforeEach(func() {
+				fakeProverClient.ProcessCommandReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := prover.RequestTransferFrom(tokenIDs, shares, fakeSigningIdentity)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+				Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("RequestExpectation", func() {
+		var (
+			tokenIDs          [][]byte
+			expectation       *token.TokenExpectation
+			marshalledCommand []byte
+			signedCommand     *token.SignedCommand
+		)
+
+		BeforeEach(func() {
+			tokenIDs = [][]byte{[]byte("id1"), []byte("id2")}
+			expectation = &token.TokenExpectation{
+				Expectation: &token.TokenExpectation_PlainExpectation{
+					PlainExpectation: &token.PlainExpectation{
+						Payload: &token.PlainExpectation_TransferExpectation{
+							TransferExpectation: &token.PlainTokenExpectation{
+								Outputs: []*token.PlainOutput{{Owner: []byte("bob"), Type: "type", Quantity: 50}},
+							},
+						},
+					},
+				},
+			}
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_ExpectationRequest{
+					ExpectationRequest: &token.ExpectationRequest{
+						TokenIds:    tokenIDs,
+						Expectation: expectation,
+					},
+				},
+			}
+			marshalledCommand = ProtoMarshal(command)
+			signedCommand = &token.SignedCommand{
+				Command:   marshalledCommand,
+				Signature: []byte("pineapple"),
+			}
+		})
+
+		It("returns serialized token transaction", func() {
+			response, err := prover.RequestExpectation(tokenIDs, expectation, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(marshalledCommand))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(signedCommand))
+		})
+
+		Context("when processcommand fails", func() {
+			BeforeEach(func() {
+				fakeProverClient.ProcessCommandReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := prover.RequestExpectation(tokenIDs, expectation, fakeSigningIdentity)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+				Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("ListTokens", func() {
+		var (
+			unspentTokens     []*token.TokenOutput
+			marshalledCommand []byte
+			signedCommand     *token.SignedCommand
+		)
+
+		BeforeEach(func() {
+			unspentTokens = []*token.TokenOutput{
+				{Id: []byte("id1"), Type: "type", Quantity: 100},
+			}
+			commandResp := &token.CommandResponse{
+				Payload: &token.CommandResponse_UnspentTokens{
+					UnspentTokens: &token.UnspentTokens{Tokens: unspentTokens},
+				},
+			}
+			signedCommandResp.Response = ProtoMarshal(commandResp)
+
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_ListRequest{
+					ListRequest: &token.ListRequest{},
+				},
+			}
+			marshalledCommand = ProtoMarshal(command)
+			signedCommand = &token.SignedCommand{
+				Command:   marshalledCommand,
+				Signature: []byte("pineapple"),
+			}
+		})
+
+		It("returns the unspent tokens", func() {
+			tokens, err := prover.ListTokens(fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(&token.UnspentTokens{Tokens: tokens}, &token.UnspentTokens{Tokens: unspentTokens})).To(BeTrue())
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(signedCommand))
+		})
+
+		It("requests the delegated outputs", func() {
+			tokens, err := prover.ListDelegatedTokens(fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(&token.UnspentTokens{Tokens: tokens}, &token.UnspentTokens{Tokens: unspentTokens})).To(BeTrue())
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			command := &token.Command{}
+			err = proto.Unmarshal(sc.Command, command)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(command.GetListRequest().GetDelegated()).To(BeTrue())
+		})
+
+		Context("when the prover returns an error", func() {
+			BeforeEach(func() {
+				commandResp := &token.CommandResponse{
+					Payload: &token.CommandResponse_Err{
+						Err: &token.Error{Message: "wild-banana"},
+					},
+				}
+				signedCommandResp.Response = ProtoMarshal(commandResp)
+			})
+
+			It("returns an error", func() {
+				_, err := prover.ListTokens(fakeSigningIdentity)
+				Expect(err).To(MatchError("error from prover: wild-banana"))
+			})
+		})
+
+		Context("when the response cannot be unmarshaled", func() {
+			BeforeEach(func() {
+				signedCommandResp.Response = []byte("command-response")
+			})
+
+			It("returns an error", func() {
+				_, err := prover.ListTokens(fakeSigningIdentity)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to unmarshal command response"))
+			})
+		})
+
+		Context("when processcommand fails", func() {
+			BeforeEach(func() {
+				fakeProverClient.ProcessCommandReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := prover.ListTokens(fakeSigningIdentity)
+				Expect(err).To(MatchError("wild-banana"))
+			})
+		})
+	})
+
+	Describe("GetSupply", func() {
+		var (
+			supplies      []*token.TokenSupply
+			signedCommand *token.SignedCommand
+		)
+
+		BeforeEach(func() {
+			supplies = []*token.TokenSupply{{Type: "type", Quantity: 100}}
+			commandResp := &token.CommandResponse{
+				Payload: &token.CommandResponse_TokenSupplies{
+					TokenSupplies: &token.TokenSupplies{Supplies: supplies},
+				},
+			}
+			signedCommandResp.Response = ProtoMarshal(commandResp)
+
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_SupplyRequest{
+					SupplyRequest: &token.SupplyRequest{Types: []string{"type"}},
+				},
+			}
+			signedCommand = &token.SignedCommand{
+				Command:   ProtoMarshal(command),
+				Signature: []byte("pineapple"),
+			}
+		})
+
+		It("returns the token supplies", func() {
+			result, err := prover.GetSupply([]string{"type"}, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(&token.TokenSupplies{Supplies: result}, &token.TokenSupplies{Supplies: supplies})).To(BeTrue())
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(signedCommand))
+		})
+
+		Context("when the prover returns an error", func() {
+			BeforeEach(func() {
+				commandResp := &token.CommandResponse{
+					Payload: &token.CommandResponse_Err{
+						Err: &token.Error{Message: "wild-banana"},
+					},
+				}
+				signedCommandResp.Response = ProtoMarshal(commandResp)
+			})
+
+			It("returns an error", func() {
+				_, err := prover.GetSupply(nil, fakeSigningIdentity)
+				Expect(err).To(MatchError("error from prover: wild-banana"))
+			})
+		})
+
+		Context("when the response has no supplies", func() {
+			BeforeEach(func() {
+				signedCommandResp.Response = ProtoMarshal(&token.CommandResponse{})
+			})
+
+			It("returns an error", func() {
+				_, err := prover.GetSupply(nil, fakeSigningIdentity)
+				Expect(err).To(MatchError("no token supplies in command response"))
+			})
+		})
+	})
 })
 
 func clock() time.Time {
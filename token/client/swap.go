@@ -0,0 +1,112 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/pkg/errors"
+)
+
+//go:generate counterfeiter -o mock/swap_signer.go -fake-name SwapSigner . SwapSigner
+
+// A SwapSigner signs swaps on behalf of the owner of some of their inputs,
+// usually a counterparty of the client.
+type SwapSigner interface {
+	// SignSwap returns the signature of the owner over the passed swap.
+	SignSwap(swap *token.PlainSwap) (*token.PlainSwapSignature, error)
+}
+
+// SigningIdentitySwapSigner is a SwapSigner that signs swaps with a SigningIdentity.
+type SigningIdentitySwapSigner struct {
+	SigningIdentity tk.SigningIdentity
+}
+
+// SignSwap signs the swap with the SigningIdentity.
+func (s *SigningIdentitySwapSigner) SignSwap(swap *token.PlainSwap) (*token.PlainSwapSignature, error) {
+	message, err := swap.SigningBytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal swap")
+	}
+	signer, err := s.SigningIdentity.Serialize()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize signing identity")
+	}
+	signature, err := s.SigningIdentity.Sign(message)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign swap")
+	}
+	return &token.PlainSwapSignature{Signer: signer, Signature: signature}, nil
+}
+
+// NewSwap assembles a swap that spends the tokens identified by tokenIDs,
+// possibly owned by several parties, into outputs.
+func NewSwap(tokenIDs [][]byte, outputs []*token.PlainOutput) (*token.PlainSwap, error) {
+	if len(tokenIDs) == 0 {
+		return nil, errors.New("no token ids in swap")
+	}
+	if len(outputs) == 0 {
+		return nil, errors.New("no outputs in swap")
+	}
+	var inputs []*token.InputId
+	for _, tokenID := range tokenIDs {
+		input, err := parseTokenID(tokenID)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, input)
+	}
+	return &token.PlainSwap{Inputs: inputs, Outputs: outputs}, nil
+}
+
+// Swap collects the signatures of signers over swap and submits it in a single token transaction.
+// The signers are the owners of the inputs other than the client, whose inputs are authorized
+// by the signature of the transaction itself.
+func (c *Client) Swap(swap *token.PlainSwap, signers ...SwapSigner) ([]byte, error) {
+	for _, signer := range signers {
+		signature, err := signer.SignSwap(swap)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to collect swap signature")
+		}
+		swap.Signatures = append(swap.Signatures, signature)
+	}
+
+	serializedTokenTx, err := proto.Marshal(&token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainSwap{PlainSwap: swap},
+			},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal swap transaction")
+	}
+	tx, err := c.createTx(serializedTokenTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, c.TxSubmitter.Submit(tx)
+}
+
+// parseTokenID returns the input identified by the ID of a plain token output,
+// which is the composite key of the output.
+func parseTokenID(tokenID []byte) (*token.InputId, error) {
+	components := strings.Split(string(tokenID), "\x00")
+	if len(components) != 5 || components[0] != "" || components[1] != "tokenOutput" || components[4] != "" {
+		return nil, errors.Errorf("invalid token id '%x'", tokenID)
+	}
+	index, err := strconv.ParseUint(components[3], 10, 32)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid index in token id '%x'", tokenID)
+	}
+	return &token.InputId{TxId: components[2], Index: uint32(index)}, nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Swap", func() {
+	var (
+		tokenIDs [][]byte
+		outputs  []*token.PlainOutput
+	)
+
+	BeforeEach(func() {
+		tokenIDs = [][]byte{
+			[]byte("\x00tokenOutput\x00alice-tx\x000\x00"),
+			[]byte("\x00tokenOutput\x00bob-tx\x002\x00"),
+		}
+		outputs = []*token.PlainOutput{
+			{Owner: []byte("bob"), Type: "TOK1", Quantity: 10},
+			{Owner: []byte("alice"), Type: "TOK2", Quantity: 20},
+		}
+	})
+
+	Describe("NewSwap", func() {
+		It("assembles a swap from the token ids and the outputs", func() {
+			swap, err := client.NewSwap(tokenIDs, outputs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(swap).To(Equal(&token.PlainSwap{
+				Inputs:  []*token.InputId{{TxId: "alice-tx", Index: 0}, {TxId: "bob-tx", Index: 2}},
+				Outputs: outputs,
+			}))
+		})
+
+		It("returns an error when a token id is not the id of an output", func() {
+			_, err := client.NewSwap([][]byte{[]byte("\x00tokenDelegatedOutput\x00tx\x000\x00")}, outputs)
+			Expect(err).To(MatchError("invalid token id '00746f6b656e44656c6567617465644f7574707574007478003000'"))
+		})
+
+		It("returns an error when there are no token ids", func() {
+			_, err := client.NewSwap(nil, outputs)
+			Expect(err).To(MatchError("no token ids in swap"))
+		})
+
+		It("returns an error when there are no outputs", func() {
+			_, err := client.NewSwap(tokenIDs, nil)
+			Expect(err).To(MatchError("no outputs in swap"))
+		})
+	})
+
+	Describe("SigningIdentitySwapSigner", func() {
+		var (
+			fakeSigningIdentity *mock.SigningIdentity
+			swapSigner          *client.SigningIdentitySwapSigner
+			swap                *token.PlainSwap
+		)
+
+		BeforeEach(func() {
+			fakeSigningIdentity = &mock.SigningIdentity{}
+			fakeSigningIdentity.SerializeReturns([]byte("bob"), nil)
+			fakeSigningIdentity.SignReturns([]byte("bob-signature"), nil)
+			swapSigner = &client.SigningIdentitySwapSigner{SigningIdentity: fakeSigningIdentity}
+
+			var err error
+			swap, err = client.NewSwap(tokenIDs, outputs)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("signs the signing bytes of the swap", func() {
+			signature, err := swapSigner.SignSwap(swap)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(signature).To(Equal(&token.PlainSwapSignature{Signer: []byte("bob"), Signature: []byte("bob-signature")}))
+
+			expected, err := swap.SigningBytes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSigningIdentity.SignArgsForCall(0)).To(Equal(expected))
+		})
+
+		Context("when signing fails", func() {
+			BeforeEach(func() {
+				fakeSigningIdentity.SignReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := swapSigner.SignSwap(swap)
+				Expect(err).To(MatchError("failed to sign swap: wild-banana"))
+			})
+		})
+	})
+
+	Describe("Client.Swap", func() {
+		var (
+			fakeSigningIdentity *mock.SigningIdentity
+			fakeTxSubmitter     *mock.FabricTxSubmitter
+			fakeSwapSigner      *mock.SwapSigner
+			tokenClient         *client.Client
+			swap                *token.PlainSwap
+		)
+
+		BeforeEach(func() {
+			fakeSigningIdentity = &mock.SigningIdentity{}
+			fakeSigningIdentity.SignReturns([]byte("tx-signature"), nil)
+			fakeTxSubmitter = &mock.FabricTxSubmitter{}
+			fakeSwapSigner = &mock.SwapSigner{}
+			fakeSwapSigner.SignSwapReturns(&token.PlainSwapSignature{Signer: []byte("bob"), Signature: []byte("bob-signature")}, nil)
+			tokenClient = &client.Client{
+				SigningIdentity: fakeSigningIdentity,
+				TxSubmitter:     fakeTxSubmitter,
+			}
+
+			var err error
+			swap, err = client.NewSwap(tokenIDs, outputs)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("collects the signatures and submits the swap", func() {
+			tx, err := tokenClient.Swap(swap, fakeSwapSigner)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeSwapSigner.SignSwapCallCount()).To(Equal(1))
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+			Expect(fakeTxSubmitter.SubmitArgsForCall(0)).To(Equal(tx))
+
+			envelope := &common.Envelope{}
+			Expect(proto.Unmarshal(tx, envelope)).To(Succeed())
+			Expect(envelope.Signature).To(Equal([]byte("tx-signature")))
+			payload := &common.Payload{}
+			Expect(proto.Unmarshal(envelope.Payload, payload)).To(Succeed())
+			tokenTx := &token.TokenTransaction{}
+			Expect(proto.Unmarshal(payload.Data, tokenTx)).To(Succeed())
+			Expect(proto.Equal(tokenTx.GetPlainAction().GetPlainSwap(), &token.PlainSwap{
+				Inputs:     swap.Inputs,
+				Outputs:    outputs,
+				Signatures: []*token.PlainSwapSignature{{Signer: []byte("bob"), Signature: []byte("bob-signature")}},
+			})).To(BeTrue())
+		})
+
+		Context("when a signer fails", func() {
+			BeforeEach(func() {
+				fakeSwapSigner.SignSwapReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error without submitting", func() {
+				_, err := tokenClient.Swap(swap, fakeSwapSigner)
+				Expect(err).To(MatchError("failed to collect swap signature: wild-banana"))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
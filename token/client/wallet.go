@@ -0,0 +1,276 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+//go:generate counterfeiter -o mock/token_lister.go -fake-name TokenLister . TokenLister
+
+// TokenLister lists the unspent tokens owned by the client.
+// It is implemented by Client.
+type TokenLister interface {
+	ListTokens() ([]*token.TokenOutput, error)
+}
+
+//go:generate counterfeiter -o mock/selector.go -fake-name Selector . Selector
+
+// Selector picks the inputs of transfers and redemptions.
+type Selector interface {
+	// Select picks unspent tokens of the passed type whose quantities add up to at least quantity,
+	// and locks them so that they are not picked again until they are spent or the lock expires;
+	// it returns the identifiers of the tokens and the sum of their quantities.
+	Select(tokenType string, quantity uint64) ([][]byte, uint64, error)
+
+	// Unlock releases the locks on the passed tokens, to be used when the transaction
+	// spending them could not be submitted.
+	Unlock(tokenIDs [][]byte) error
+}
+
+const (
+	unspentKeyPrefix = "u"
+	lockKeyPrefix    = "l"
+	keySep           = 0x00
+)
+
+// DefaultLockTimeout is how long a selected token stays locked when no timeout is configured.
+const DefaultLockTimeout = 5 * time.Minute
+
+// Wallet keeps track of the unspent tokens owned by the client in a local LevelDB.
+// The tokens are refreshed through a TokenLister whenever a valid token transaction is
+// received from the deliver service, and the tokens selected as inputs stay locked until
+// a refresh finds them spent or their lock expires.
+type Wallet struct {
+	Lister      TokenLister
+	LockTimeout time.Duration
+	Time        TimeFunc
+
+	mutex    sync.Mutex
+	provider *leveldbhelper.Provider
+	db       *leveldbhelper.DBHandle
+}
+
+// NewWallet opens, or creates, the wallet stored at dbPath.
+func NewWallet(dbPath string, lister TokenLister, lockTimeout time.Duration) *Wallet {
+	if lockTimeout <= 0 {
+		lockTimeout = DefaultLockTimeout
+	}
+	provider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
+	return &Wallet{
+		Lister:      lister,
+		LockTimeout: lockTimeout,
+		Time:        time.Now,
+		provider:    provider,
+		db:          provider.GetDBHandle("wallet"),
+	}
+}
+
+// Close closes the underlying db.
+func (w *Wallet) Close() {
+	w.provider.Close()
+}
+
+// UnspentTokens returns the unspent tokens in the wallet, locked or not.
+func (w *Wallet) UnspentTokens() ([]*token.TokenOutput, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.unspentTokens()
+}
+
+// Refresh replaces the tokens in the wallet with the ones returned by the TokenLister.
+// The locks of the tokens that are no longer unspent are removed.
+func (w *Wallet) Refresh() error {
+	tokens, err := w.Lister.ListTokens()
+	if err != nil {
+		return errors.WithMessage(err, "failed listing unspent tokens")
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	current, err := w.unspentTokens()
+	if err != nil {
+		return err
+	}
+	listed := map[string]bool{}
+	batch := leveldbhelper.NewUpdateBatch()
+	for _, tok := range tokens {
+		value, err := proto.Marshal(tok)
+		if err != nil {
+			return errors.Wrap(err, "failed marshaling token")
+		}
+		listed[string(tok.Id)] = true
+		batch.Put(unspentKey(tok.Id), value)
+	}
+	for _, tok := range current {
+		if !listed[string(tok.Id)] {
+			batch.Delete(unspentKey(tok.Id))
+			batch.Delete(lockKey(tok.Id))
+		}
+	}
+	return w.db.WriteBatch(batch, true)
+}
+
+// Update refreshes the wallet if the filtered block contains a valid token transaction.
+func (w *Wallet) Update(block *pb.FilteredBlock) error {
+	for _, tx := range block.FilteredTransactions {
+		if tx.Type == common.HeaderType_TOKEN_TRANSACTION && tx.TxValidationCode == pb.TxValidationCode_VALID {
+			logger.Debugf("refreshing wallet on token transaction [%s] in block [%d]", tx.Txid, block.Number)
+			return w.Refresh()
+		}
+	}
+	return nil
+}
+
+// Listen updates the wallet with the filtered blocks received from df,
+// until the deliver stream fails or completes.
+func (w *Wallet) Listen(df DeliverFiltered, address string) error {
+	for {
+		resp, err := df.Recv()
+		if err != nil {
+			return errors.WithMessage(err, "error receiving deliver response from peer "+address)
+		}
+		switch r := resp.Type.(type) {
+		case *pb.DeliverResponse_FilteredBlock:
+			if err := w.Update(r.FilteredBlock); err != nil {
+				logger.Errorf("failed updating wallet with block [%d]: %s", r.FilteredBlock.Number, err)
+			}
+		case *pb.DeliverResponse_Status:
+			return errors.Errorf("deliver completed with status (%s) from peer %s", r.Status, address)
+		default:
+			return errors.Errorf("received unexpected response type (%T) from peer %s", r, address)
+		}
+	}
+}
+
+// Select picks unlocked unspent tokens of the passed type, largest first, until their
+// quantities add up to at least quantity, and locks them for LockTimeout.
+func (w *Wallet) Select(tokenType string, quantity uint64) ([][]byte, uint64, error) {
+	if quantity == 0 {
+		return nil, 0, errors.New("quantity must be greater than 0")
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	tokens, err := w.unspentTokens()
+	if err != nil {
+		return nil, 0, err
+	}
+	now := w.Time()
+	var candidates []*token.TokenOutput
+	available := uint64(0)
+	for _, tok := range tokens {
+		// non-fungible tokens are spent individually, never selected by quantity
+		if tok.Type != tokenType || tok.Nft != nil {
+			continue
+		}
+		locked, err := w.isLocked(tok.Id, now)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !locked {
+			candidates = append(candidates, tok)
+			// the sum is capped, as it only has to reach quantity
+			if available, err = addQuantity(available, tok.Quantity); err != nil {
+				available = math.MaxUint64
+			}
+		}
+	}
+	if available < quantity {
+		return nil, 0, errors.Errorf("insufficient funds: %d of type '%s' requested, %d available", quantity, tokenType, available)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Quantity > candidates[j].Quantity
+	})
+
+	var tokenIDs [][]byte
+	total := uint64(0)
+	expiry := make([]byte, 8)
+	binary.BigEndian.PutUint64(expiry, uint64(now.Add(w.LockTimeout).UnixNano()))
+	batch := leveldbhelper.NewUpdateBatch()
+	for _, tok := range candidates {
+		if total >= quantity {
+			break
+		}
+		tokenIDs = append(tokenIDs, tok.Id)
+		total, err = addQuantity(total, tok.Quantity)
+		if err != nil {
+			return nil, 0, errors.WithMessage(err, "failed selecting tokens")
+		}
+		batch.Put(lockKey(tok.Id), expiry)
+	}
+	if err := w.db.WriteBatch(batch, true); err != nil {
+		return nil, 0, err
+	}
+	return tokenIDs, total, nil
+}
+
+// Unlock releases the locks on the passed tokens.
+func (w *Wallet) Unlock(tokenIDs [][]byte) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	batch := leveldbhelper.NewUpdateBatch()
+	for _, id := range tokenIDs {
+		batch.Delete(lockKey(id))
+	}
+	return w.db.WriteBatch(batch, true)
+}
+
+func (w *Wallet) unspentTokens() ([]*token.TokenOutput, error) {
+	start := []byte{unspentKeyPrefix[0], keySep}
+	end := []byte{unspentKeyPrefix[0], keySep + 1}
+	itr := w.db.GetIterator(start, end)
+	defer itr.Release()
+
+	var tokens []*token.TokenOutput
+	for itr.Next() {
+		tok := &token.TokenOutput{}
+		if err := proto.Unmarshal(itr.Value(), tok); err != nil {
+			return nil, errors.Wrap(err, "failed unmarshaling token")
+		}
+		tokens = append(tokens, tok)
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "failed iterating over unspent tokens")
+	}
+	return tokens, nil
+}
+
+func (w *Wallet) isLocked(tokenID []byte, now time.Time) (bool, error) {
+	expiry, err := w.db.Get(lockKey(tokenID))
+	if err != nil {
+		return false, err
+	}
+	if len(expiry) != 8 {
+		return false, nil
+	}
+	return now.UnixNano() < int64(binary.BigEndian.Uint64(expiry)), nil
+}
+
+func unspentKey(tokenID []byte) []byte {
+	return bytes.Join([][]byte{[]byte(unspentKeyPrefix), tokenID}, []byte{keySep})
+}
+
+func lockKey(tokenID []byte) []byte {
+	return bytes.Join([][]byte{[]byte(lockKeyPrefix), tokenID}, []byte{keySep})
+}
@@ -0,0 +1,243 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"time"
+
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Wallet", func() {
+	var (
+		dbPath          string
+		now             time.Time
+		fakeTokenLister *mock.TokenLister
+		unspentTokens   []*token.TokenOutput
+
+		wallet *client.Wallet
+	)
+
+	BeforeEach(func() {
+		var err error
+		dbPath, err = ioutil.TempDir("", "wallet")
+		Expect(err).NotTo(HaveOccurred())
+
+		now = time.Unix(1000, 0)
+		unspentTokens = []*token.TokenOutput{
+			{Id: []byte("id1"), Type: "USD", Quantity: 10},
+			{Id: []byte("id2"), Type: "USD", Quantity: 50},
+			{Id: []byte("id3"), Type: "EUR", Quantity: 100},
+			{Id: []byte("id4"), Type: "USD", Quantity: 20},
+		}
+		fakeTokenLister = &mock.TokenLister{}
+		fakeTokenLister.ListTokensReturns(unspentTokens, nil)
+
+		wallet = client.NewWallet(dbPath, fakeTokenLister, time.Minute)
+		wallet.Time = func() time.Time { return now }
+		Expect(wallet.Refresh()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		wallet.Close()
+		os.RemoveAll(dbPath)
+	})
+
+	Describe("Refresh", func() {
+		It("stores the unspent tokens", func() {
+			tokens, err := wallet.UnspentTokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(HaveLen(4))
+			Expect(tokens[0].Id).To(Equal([]byte("id1")))
+			Expect(tokens[3].Quantity).To(Equal(uint64(20)))
+		})
+
+		It("persists the unspent tokens", func() {
+			wallet.Close()
+			wallet = client.NewWallet(dbPath, fakeTokenLister, time.Minute)
+
+			tokens, err := wallet.UnspentTokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(HaveLen(4))
+		})
+
+		It("removes the tokens that are no longer unspent and their locks", func() {
+			ids, _, err := wallet.Select("USD", 50)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(Equal([][]byte{[]byte("id2")}))
+
+			fakeTokenLister.ListTokensReturns([]*token.TokenOutput{unspentTokens[0], unspentTokens[2], unspentTokens[3]}, nil)
+			Expect(wallet.Refresh()).To(Succeed())
+			tokens, err := wallet.UnspentTokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(HaveLen(3))
+
+			// id2 is listed again, which does not happen with a real lister; it is not locked anymore
+			fakeTokenLister.ListTokensReturns(unspentTokens, nil)
+			Expect(wallet.Refresh()).To(Succeed())
+			ids, _, err = wallet.Select("USD", 80)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(Equal([][]byte{[]byte("id2"), []byte("id4"), []byte("id1")}))
+		})
+
+		Context("when the lister fails", func() {
+			BeforeEach(func() {
+				fakeTokenLister.ListTokensReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				err := wallet.Refresh()
+				Expect(err).To(MatchError("failed listing unspent tokens: wild-banana"))
+			})
+		})
+	})
+
+	Describe("Select", func() {
+		It("picks the largest tokens of the type first", func() {
+			ids, total, err := wallet.Select("USD", 55)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(Equal([][]byte{[]byte("id2"), []byte("id4")}))
+			Expect(total).To(Equal(uint64(70)))
+		})
+
+		It("does not pick locked tokens", func() {
+			_, _, err := wallet.Select("USD", 55)
+			Expect(err).NotTo(HaveOccurred())
+
+			ids, total, err := wallet.Select("USD", 5)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(Equal([][]byte{[]byte("id1")}))
+			Expect(total).To(Equal(uint64(10)))
+
+			_, _, err = wallet.Select("USD", 1)
+			Expect(err).To(MatchError("insufficient funds: 1 of type 'USD' requested, 0 available"))
+		})
+
+		It("picks tokens again once their lock expires", func() {
+			_, _, err := wallet.Select("USD", 80)
+			Expect(err).NotTo(HaveOccurred())
+
+			now = now.Add(time.Minute)
+			ids, _, err := wallet.Select("USD", 80)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(HaveLen(3))
+		})
+
+		It("does not overflow the sum of the selected quantities", func() {
+			fakeTokenLister.ListTokensReturns([]*token.TokenOutput{
+				{Id: []byte("big1"), Type: "BIG", Quantity: math.MaxUint64 - 1},
+				{Id: []byte("big2"), Type: "BIG", Quantity: 5},
+			}, nil)
+			Expect(wallet.Refresh()).To(Succeed())
+
+			_, _, err := wallet.Select("BIG", math.MaxUint64)
+			Expect(err).To(MatchError(fmt.Sprintf("failed selecting tokens: token sum overflows (%d + 5)", uint64(math.MaxUint64-1))))
+		})
+
+		It("picks unlocked tokens again", func() {
+			ids, _, err := wallet.Select("EUR", 100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(wallet.Unlock(ids)).To(Succeed())
+
+			ids, _, err = wallet.Select("EUR", 100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(Equal([][]byte{[]byte("id3")}))
+		})
+
+		It("returns an error when funds are insufficient", func() {
+			_, _, err := wallet.Select("USD", 81)
+			Expect(err).To(MatchError("insufficient funds: 81 of type 'USD' requested, 80 available"))
+			_, _, err = wallet.Select("GBP", 1)
+			Expect(err).To(MatchError("insufficient funds: 1 of type 'GBP' requested, 0 available"))
+		})
+
+		It("does not pick non-fungible tokens", func() {
+			nft := &token.TokenOutput{Id: []byte("id5"), Type: "USD", Quantity: 1, Nft: &token.NonFungibleToken{Id: "nft1"}}
+			fakeTokenLister.ListTokensReturns(append(unspentTokens, nft), nil)
+			Expect(wallet.Refresh()).To(Succeed())
+
+			_, _, err := wallet.Select("USD", 81)
+			Expect(err).To(MatchError("insufficient funds: 81 of type 'USD' requested, 80 available"))
+		})
+
+		It("returns an error when quantity is 0", func() {
+			_, _, err := wallet.Select("USD", 0)
+			Expect(err).To(MatchError("quantity must be greater than 0"))
+		})
+	})
+
+	Describe("Listen", func() {
+		var fakeDeliverFiltered *mock.DeliverFiltered
+
+		BeforeEach(func() {
+			fakeDeliverFiltered = &mock.DeliverFiltered{}
+			fakeDeliverFiltered.RecvReturnsOnCall(0, &pb.DeliverResponse{
+				Type: &pb.DeliverResponse_FilteredBlock{
+					FilteredBlock: &pb.FilteredBlock{
+						Number: 1,
+						FilteredTransactions: []*pb.FilteredTransaction{
+							{Txid: "tx1", Type: common.HeaderType_ENDORSER_TRANSACTION, TxValidationCode: pb.TxValidationCode_VALID},
+							{Txid: "tx2", Type: common.HeaderType_TOKEN_TRANSACTION, TxValidationCode: pb.TxValidationCode_MVCC_READ_CONFLICT},
+						},
+					},
+				},
+			}, nil)
+			fakeDeliverFiltered.RecvReturnsOnCall(1, &pb.DeliverResponse{
+				Type: &pb.DeliverResponse_FilteredBlock{
+					FilteredBlock: &pb.FilteredBlock{
+						Number: 2,
+						FilteredTransactions: []*pb.FilteredTransaction{
+							{Txid: "tx3", Type: common.HeaderType_TOKEN_TRANSACTION, TxValidationCode: pb.TxValidationCode_VALID},
+						},
+					},
+				},
+			}, nil)
+			fakeDeliverFiltered.RecvReturnsOnCall(2, &pb.DeliverResponse{
+				Type: &pb.DeliverResponse_Status{Status: common.Status_SUCCESS},
+			}, nil)
+		})
+
+		It("refreshes the wallet on valid token transactions only", func() {
+			fakeTokenLister.ListTokensReturns(unspentTokens[1:], nil)
+
+			err := wallet.Listen(fakeDeliverFiltered, "peer0")
+			Expect(err).To(MatchError("deliver completed with status (SUCCESS) from peer peer0"))
+			Expect(fakeDeliverFiltered.RecvCallCount()).To(Equal(3))
+			Expect(fakeTokenLister.ListTokensCallCount()).To(Equal(2))
+
+			tokens, err := wallet.UnspentTokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(HaveLen(3))
+		})
+
+		It("keeps listening when a refresh fails", func() {
+			fakeTokenLister.ListTokensReturns(nil, errors.New("wild-banana"))
+
+			err := wallet.Listen(fakeDeliverFiltered, "peer0")
+			Expect(err).To(MatchError("deliver completed with status (SUCCESS) from peer peer0"))
+			Expect(fakeDeliverFiltered.RecvCallCount()).To(Equal(3))
+		})
+
+		It("returns an error when the stream fails", func() {
+			fakeDeliverFiltered.RecvReturnsOnCall(0, nil, errors.New("flying-pineapple"))
+
+			err := wallet.Listen(fakeDeliverFiltered, "peer0")
+			Expect(err).To(MatchError("error receiving deliver response from peer peer0: flying-pineapple"))
+		})
+	})
+})
@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/tms/zkat"
+	"github.com/pkg/errors"
+)
+
+// ZkProver is the Prover of the clients of a prover peer that runs the zkat driver.
+// Imports and the other requests are forwarded to the prover peer, while transfers and
+// redemptions are created by the client itself: the prover peer lists the unspent outputs,
+// and the owner opens them, proves and signs the transaction with its own key pair, so
+// that only public material leaves the client.
+type ZkProver struct {
+	*ProverPeer
+	Owner *zkat.OwnerTransactor
+}
+
+// RequestTransfer creates the transfer of the tokens with the given identifiers among the
+// recipients of the shares; it returns a command response that carries the token transaction.
+func (prover *ZkProver) RequestTransfer(tokenIDs [][]byte, shares []*token.RecipientTransferShare, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	inputs, err := prover.inputs(tokenIDs, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	tokenTx, err := prover.Owner.RequestTransfer(inputs, shares)
+	if err != nil {
+		return nil, err
+	}
+	return commandResponse(tokenTx)
+}
+
+// RequestRedeem creates the redemption of the given quantity out of the tokens with the given
+// identifiers; it returns a command response that carries the token transaction.
+func (prover *ZkProver) RequestRedeem(tokenIDs [][]byte, quantity uint64, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	inputs, err := prover.inputs(tokenIDs, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	tokenTx, err := prover.Owner.RequestRedeem(inputs, quantity)
+	if err != nil {
+		return nil, err
+	}
+	return commandResponse(tokenTx)
+}
+
+// ListTokens returns the unspent tokens, listed by the prover peer, that are owned by the client.
+func (prover *ZkProver) ListTokens(signingIdentity tk.SigningIdentity) ([]*token.TokenOutput, error) {
+	tokens, err := prover.ProverPeer.ListTokens(signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	return prover.Owner.Open(tokens), nil
+}
+
+// inputs returns the unspent tokens of the client with the given identifiers.
+func (prover *ZkProver) inputs(tokenIDs [][]byte, signingIdentity tk.SigningIdentity) ([]*token.TokenOutput, error) {
+	tokens, err := prover.ListTokens(signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	unspent := map[string]*token.TokenOutput{}
+	for _, tok := range tokens {
+		unspent[string(tok.Id)] = tok
+	}
+
+	var inputs []*token.TokenOutput
+	for _, id := range tokenIDs {
+		tok, ok := unspent[string(id)]
+		if !ok {
+			return nil, errors.Errorf("input '%s' is not an unspent token of the client", id)
+		}
+		inputs = append(inputs, tok)
+	}
+	return inputs, nil
+}
+
+// commandResponse wraps a token transaction created by the client in a serialized command response,
+// as the ones of the prover peer.
+func commandResponse(tokenTx *token.TokenTransaction) ([]byte, error) {
+	return proto.Marshal(&token.CommandResponse{
+		Payload: &token.CommandResponse_TokenTransaction{TokenTransaction: tokenTx},
+	})
+}
@@ -0,0 +1,146 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/mock"
+	"github.com/hyperledger/fabric/token/tms/zkat"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("ZkProver", func() {
+	var (
+		pp                  *zkat.PublicParams
+		alice, bob          *zkat.KeyPair
+		fakeIdentity        *mock.Identity
+		fakeSigningIdentity *mock.SigningIdentity
+		fakeProverClient    *mock.ProverClient
+		unspent             []*token.TokenOutput
+
+		prover *client.ZkProver
+	)
+
+	zkOutputKey := func(txID string, index string) []byte {
+		return []byte("\x00" + "zkOutput" + "\x00" + txID + "\x00" + index + "\x00")
+	}
+
+	BeforeEach(func() {
+		var err error
+		pp, err = zkat.NewPublicParams(16)
+		Expect(err).NotTo(HaveOccurred())
+		alice, err = zkat.NewKeyPair(pp)
+		Expect(err).NotTo(HaveOccurred())
+		bob, err = zkat.NewKeyPair(pp)
+		Expect(err).NotTo(HaveOccurred())
+
+		importTx, err := (&zkat.Issuer{PublicParams: pp}).RequestImport([]*token.TokenToIssue{
+			{Recipient: alice.PublicKey(), Type: "USD", Quantity: 100},
+			{Recipient: bob.PublicKey(), Type: "USD", Quantity: 5},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		unspent = nil
+		for i, output := range importTx.GetZkAction().GetZkImport().Outputs {
+			unspent = append(unspent, &token.TokenOutput{
+				Id:       zkOutputKey("0", string('0'+rune(i))),
+				Type:     output.Type,
+				ZkOutput: ProtoMarshal(output),
+			})
+		}
+
+		fakeIdentity = &mock.Identity{}
+		fakeIdentity.SerializeReturns([]byte("Alice"), nil)
+		fakeSigningIdentity = &mock.SigningIdentity{}
+		fakeSigningIdentity.GetPublicVersionReturns(fakeIdentity)
+		fakeSigningIdentity.SignReturns([]byte("pineapple"), nil)
+		fakeProverClient = &mock.ProverClient{}
+		fakeProverClient.ProcessCommandReturns(&token.SignedCommandResponse{
+			Response: ProtoMarshal(&token.CommandResponse{
+				Payload: &token.CommandResponse_UnspentTokens{UnspentTokens: &token.UnspentTokens{Tokens: unspent}},
+			}),
+		}, nil)
+
+		prover = &client.ZkProver{
+			ProverPeer: &client.ProverPeer{
+				ChannelID:        "mychannel",
+				ProverClient:     fakeProverClient,
+				RandomnessReader: strings.NewReader(strings.Repeat("0", 1024)),
+				Time:             clock,
+			},
+			Owner: &zkat.OwnerTransactor{PublicParams: pp, KeyPair: alice},
+		}
+	})
+
+	Describe("ListTokens", func() {
+		It("returns the listed tokens owned by the client", func() {
+			tokens, err := prover.ListTokens(fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(HaveLen(1))
+			Expect(tokens[0].Id).To(Equal(zkOutputKey("0", "0")))
+			Expect(tokens[0].Quantity).To(Equal(uint64(100)))
+		})
+	})
+
+	Describe("RequestTransfer", func() {
+		It("creates the transaction on the client and only sends a list request", func() {
+			response, err := prover.RequestTransfer([][]byte{zkOutputKey("0", "0")}, []*token.RecipientTransferShare{
+				{Recipient: bob.PublicKey(), Quantity: 100},
+			}, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+
+			commandResp := &token.CommandResponse{}
+			Expect(proto.Unmarshal(response, commandResp)).To(Succeed())
+			transfer := commandResp.GetTokenTransaction().GetZkAction().GetZkTransfer()
+			Expect(transfer.Inputs).To(Equal([]*token.InputId{{TxId: "0", Index: 0}}))
+			Expect(transfer.Outputs).To(HaveLen(1))
+			Expect(transfer.InputSignatures).To(HaveLen(1))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			command := &token.Command{}
+			Expect(proto.Unmarshal(sc.Command, command)).To(Succeed())
+			Expect(command.GetListRequest()).To(Equal(&token.ListRequest{}))
+		})
+
+		It("rejects tokens that are not unspent tokens of the client", func() {
+			_, err := prover.RequestTransfer([][]byte{zkOutputKey("0", "1")}, []*token.RecipientTransferShare{
+				{Recipient: bob.PublicKey(), Quantity: 5},
+			}, fakeSigningIdentity)
+			Expect(err).To(MatchError("input '" + string(zkOutputKey("0", "1")) + "' is not an unspent token of the client"))
+		})
+	})
+
+	Describe("RequestRedeem", func() {
+		It("creates the transaction on the client", func() {
+			response, err := prover.RequestRedeem([][]byte{zkOutputKey("0", "0")}, 40, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+
+			commandResp := &token.CommandResponse{}
+			Expect(proto.Unmarshal(response, commandResp)).To(Succeed())
+			redeem := commandResp.GetTokenTransaction().GetZkAction().GetZkRedeem()
+			Expect(redeem.Quantity).To(Equal(uint64(40)))
+			Expect(redeem.Outputs).To(HaveLen(1))
+		})
+
+		Context("when the prover peer cannot list the tokens", func() {
+			BeforeEach(func() {
+				fakeProverClient.ProcessCommandReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := prover.RequestRedeem([][]byte{zkOutputKey("0", "0")}, 40, fakeSigningIdentity)
+				Expect(err).To(MatchError("wild-banana"))
+			})
+		})
+	})
+})
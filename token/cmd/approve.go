@@ -0,0 +1,62 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"io"
+
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/hyperledger/fabric/protos/token"
+)
+
+// NewApproveCmd creates a new ApproveCmd with the given Stub and writer
+func NewApproveCmd(stub Stub, writer io.Writer) *ApproveCmd {
+	return &ApproveCmd{
+		spendCmd: spendCmd{baseCmd: baseCmd{stub: stub, writer: writer}},
+	}
+}
+
+// ApproveCmd executes a command that delegates the spending of tokens
+type ApproveCmd struct {
+	spendCmd
+	shares *string
+}
+
+// SetShares sets the delegatees and their allowances, as a JSON array
+func (ac *ApproveCmd) SetShares(shares *string) {
+	ac.shares = shares
+}
+
+// Execute executes the command
+func (ac *ApproveCmd) Execute(conf common.Config) error {
+	tokenIDs, err := ac.parseTokenIDs()
+	if err != nil {
+		return err
+	}
+	parsed, err := parseShares(ac.shares)
+	if err != nil {
+		return err
+	}
+	var shares []*token.AllowanceRecipientShare
+	for _, s := range parsed {
+		recipient, err := parseRecipient(s.Recipient)
+		if err != nil {
+			return err
+		}
+		shares = append(shares, &token.AllowanceRecipientShare{Recipient: recipient, Quantity: s.Quantity})
+	}
+
+	if err := ac.setup(conf); err != nil {
+		return err
+	}
+	defer ac.stub.Close()
+	resp, err := ac.stub.Approve(tokenIDs, shares)
+	if err != nil {
+		return err
+	}
+	return ac.print(resp)
+}
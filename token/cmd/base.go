@@ -0,0 +1,114 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// baseCmd holds what all the token commands that talk to the token services have in common
+type baseCmd struct {
+	stub        Stub
+	writer      io.Writer
+	tokenConfig *string
+}
+
+// SetTokenConfig sets the file the token config is loaded from
+func (bc *baseCmd) SetTokenConfig(tokenConfig *string) {
+	bc.tokenConfig = tokenConfig
+}
+
+// setup loads the token config and prepares the stub
+func (bc *baseCmd) setup(conf common.Config) error {
+	if bc.tokenConfig == nil || *bc.tokenConfig == "" {
+		return errors.New("no token config specified")
+	}
+	tokenConf, err := TokenConfigFromFile(*bc.tokenConfig)
+	if err != nil {
+		return err
+	}
+	return bc.stub.Setup(conf, tokenConf)
+}
+
+// print writes the given value as JSON
+func (bc *baseCmd) print(v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "\t")
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling response")
+	}
+	fmt.Fprintln(bc.writer, string(b))
+	return nil
+}
+
+// spendCmd is a command that spends tokens
+type spendCmd struct {
+	baseCmd
+	tokenIDs *[]string
+}
+
+// SetTokenIDs sets the base64 encoded identifiers of the tokens to spend
+func (sc *spendCmd) SetTokenIDs(tokenIDs *[]string) {
+	sc.tokenIDs = tokenIDs
+}
+
+func (sc *spendCmd) parseTokenIDs() ([][]byte, error) {
+	if sc.tokenIDs == nil || len(*sc.tokenIDs) == 0 {
+		return nil, errors.New("no token IDs specified")
+	}
+	var tokenIDs [][]byte
+	for _, id := range *sc.tokenIDs {
+		tokenID, err := base64.StdEncoding.DecodeString(id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid token ID %s", id)
+		}
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+	return tokenIDs, nil
+}
+
+// share is a recipient of tokens and its quantity, as passed on the command line
+type share struct {
+	Recipient string `json:"recipient"`
+	Quantity  uint64 `json:"quantity"`
+}
+
+func parseShares(shares *string) ([]share, error) {
+	if shares == nil || *shares == "" {
+		return nil, errors.New("no shares specified")
+	}
+	var parsed []share
+	if err := json.Unmarshal([]byte(*shares), &parsed); err != nil {
+		return nil, errors.Wrap(err, "failed parsing shares")
+	}
+	if len(parsed) == 0 {
+		return nil, errors.New("no shares specified")
+	}
+	return parsed, nil
+}
+
+// parseRecipient converts a recipient of the form MSPID:CERT_PATH into a serialized identity
+func parseRecipient(recipient string) ([]byte, error) {
+	parts := strings.SplitN(recipient, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, errors.Errorf("recipient %s is not of the form MSPID:CERT_PATH", recipient)
+	}
+	cert, err := ioutil.ReadFile(parts[1])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading certificate of recipient %s", recipient)
+	}
+	return proto.Marshal(&msp.SerializedIdentity{Mspid: parts[0], IdBytes: cert})
+}
@@ -0,0 +1,137 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"os"
+
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/hyperledger/fabric/protos/token"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	SaveTokenConfigCommand = "saveTokenConfig"
+	IssueCommand           = "issue"
+	ListCommand            = "list"
+	TransferCommand        = "transfer"
+	RedeemCommand          = "redeem"
+	ApproveCommand         = "approve"
+	TransferFromCommand    = "transferFrom"
+	SupplyCommand          = "supply"
+)
+
+var (
+	// responseWriter defines the stdout
+	responseWriter = os.Stdout
+)
+
+//go:generate counterfeiter -o mock/stub.go -fake-name Stub . Stub
+
+// Stub represents the remote token services, that is the prover peer and the orderer
+type Stub interface {
+	// Setup prepares the stub to send requests to the services of the given token config
+	Setup(conf common.Config, tokenConf TokenConfig) error
+
+	// Close releases the connection to the prover peer opened by Setup
+	Close() error
+
+	// Issue requests the import of the given tokens and submits the resulting transaction
+	Issue(tokensToIssue []*token.TokenToIssue) (*TxResponse, error)
+
+	// Transfer requests the transfer of the given tokens and submits the resulting transaction
+	Transfer(tokenIDs [][]byte, shares []*token.RecipientTransferShare) (*TxResponse, error)
+
+	// Redeem requests the redemption of the given tokens and submits the resulting transaction
+	Redeem(tokenIDs [][]byte, quantity uint64) (*TxResponse, error)
+
+	// Approve requests the delegation of the given tokens and submits the resulting transaction
+	Approve(tokenIDs [][]byte, shares []*token.AllowanceRecipientShare) (*TxResponse, error)
+
+	// TransferFrom requests the transfer of the given delegated tokens and submits the resulting transaction
+	TransferFrom(tokenIDs [][]byte, shares []*token.RecipientTransferShare) (*TxResponse, error)
+
+	// ListTokens returns the unspent tokens owned by the client
+	ListTokens() ([]*token.TokenOutput, error)
+
+	// GetSupply returns the outstanding supply of the given token types, or of all token types if none is given
+	GetSupply(types []string) ([]*token.TokenSupply, error)
+}
+
+//go:generate counterfeiter -o mock/command_registrar.go -fake-name CommandRegistrar . CommandRegistrar
+
+// CommandRegistrar registers commands
+type CommandRegistrar interface {
+	// Command adds a new top-level command to the CLI
+	Command(name, help string, onCommand common.CLICommand) *kingpin.CmdClause
+}
+
+// AddCommands registers the token commands to the given CommandRegistrar
+func AddCommands(cli CommandRegistrar) {
+	saveCmd := &SaveTokenConfigCmd{}
+	save := cli.Command(SaveTokenConfigCommand, "Save the token config passed by flags into the file specified by --tokenConfig", saveCmd.Execute)
+	saveCmd.SetTokenConfig(tokenConfigFlag(save))
+	saveCmd.SetChannel(save.Flag("channel", "Sets the channel the token transactions are submitted to").String())
+	saveCmd.SetProverPeer(save.Flag("prover", "Sets the endpoint of the prover peer").String())
+	saveCmd.SetOrderer(save.Flag("orderer", "Sets the endpoint of the orderer").String())
+
+	issueCmd := NewIssueCmd(&ClientStub{}, responseWriter)
+	issue := cli.Command(IssueCommand, "Issue tokens", issueCmd.Execute)
+	issueCmd.SetTokenConfig(tokenConfigFlag(issue))
+	issueCmd.SetType(issue.Flag("type", "Sets the type of the tokens to issue").String())
+	issueCmd.SetQuantity(issue.Flag("quantity", "Sets the quantity of the tokens to issue").Uint64())
+	issueCmd.SetRecipient(recipientFlag(issue))
+
+	listCmd := NewListCmd(&ClientStub{}, responseWriter)
+	list := cli.Command(ListCommand, "List the unspent tokens of the user", listCmd.Execute)
+	listCmd.SetTokenConfig(tokenConfigFlag(list))
+
+	transferCmd := NewTransferCmd(&ClientStub{}, responseWriter)
+	transfer := cli.Command(TransferCommand, "Transfer tokens", transferCmd.Execute)
+	transferCmd.SetTokenConfig(tokenConfigFlag(transfer))
+	transferCmd.SetTokenIDs(tokenIDsFlag(transfer))
+	transferCmd.SetShares(sharesFlag(transfer))
+
+	redeemCmd := NewRedeemCmd(&ClientStub{}, responseWriter)
+	redeem := cli.Command(RedeemCommand, "Redeem tokens", redeemCmd.Execute)
+	redeemCmd.SetTokenConfig(tokenConfigFlag(redeem))
+	redeemCmd.SetTokenIDs(tokenIDsFlag(redeem))
+	redeemCmd.SetQuantity(redeem.Flag("quantity", "Sets the quantity of the tokens to redeem").Uint64())
+
+	approveCmd := NewApproveCmd(&ClientStub{}, responseWriter)
+	approve := cli.Command(ApproveCommand, "Delegate the spending of tokens", approveCmd.Execute)
+	approveCmd.SetTokenConfig(tokenConfigFlag(approve))
+	approveCmd.SetTokenIDs(tokenIDsFlag(approve))
+	approveCmd.SetShares(sharesFlag(approve))
+
+	transferFromCmd := NewTransferFromCmd(&ClientStub{}, responseWriter)
+	transferFrom := cli.Command(TransferFromCommand, "Transfer tokens delegated to the user", transferFromCmd.Execute)
+	transferFromCmd.SetTokenConfig(tokenConfigFlag(transferFrom))
+	transferFromCmd.SetTokenIDs(tokenIDsFlag(transferFrom))
+	transferFromCmd.SetShares(sharesFlag(transferFrom))
+
+	supplyCmd := NewSupplyCmd(&ClientStub{}, responseWriter)
+	supply := cli.Command(SupplyCommand, "Show the outstanding supply of token types", supplyCmd.Execute)
+	supplyCmd.SetTokenConfig(tokenConfigFlag(supply))
+	supplyCmd.SetTypes(supply.Flag("type", "(Optional) Specifies the token type(s) to show the supply of; all types if omitted").Strings())
+}
+
+func tokenConfigFlag(cmd *kingpin.CmdClause) *string {
+	return cmd.Flag("tokenConfig", "Specifies the file the token config is saved to and loaded from").String()
+}
+
+func tokenIDsFlag(cmd *kingpin.CmdClause) *[]string {
+	return cmd.Flag("tokenID", "Specifies the base64 encoded identifier(s) of the tokens to spend, as printed by list").Strings()
+}
+
+func recipientFlag(cmd *kingpin.CmdClause) *string {
+	return cmd.Flag("recipient", "Sets the recipient of the tokens").PlaceHolder("MSPID:CERT_PATH").String()
+}
+
+func sharesFlag(cmd *kingpin.CmdClause) *string {
+	return cmd.Flag("shares", "Sets the recipients of the tokens as a JSON array").PlaceHolder(`'[{"recipient":"MSPID:CERT_PATH","quantity":10}]'`).String()
+}
@@ -0,0 +1,397 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/token"
+	token "github.com/hyperledger/fabric/token/cmd"
+	"github.com/hyperledger/fabric/token/cmd/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var _ = Describe("Token commands", func() {
+	var (
+		tempDir     string
+		tokenConfig string
+		recipient   string
+		bob         []byte
+		tokenIDs    []string
+		shares      string
+		txResponse  *token.TxResponse
+
+		fakeStub *mock.Stub
+		buffer   *gbytes.Buffer
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "token-cmd")
+		Expect(err).NotTo(HaveOccurred())
+
+		tokenConfig = filepath.Join(tempDir, "token.yaml")
+		err = token.TokenConfig{ChannelID: "mychannel", ProverPeer: "peer0:7051", Orderer: "orderer:7050"}.ToFile(tokenConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		certPath := filepath.Join(tempDir, "bob.pem")
+		err = ioutil.WriteFile(certPath, []byte("bob-cert"), 0600)
+		Expect(err).NotTo(HaveOccurred())
+		recipient = "Org1MSP:" + certPath
+		bob, err = proto.Marshal(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("bob-cert")})
+		Expect(err).NotTo(HaveOccurred())
+
+		tokenIDs = []string{base64.StdEncoding.EncodeToString([]byte("id1")), base64.StdEncoding.EncodeToString([]byte("id2"))}
+		shares = `[{"recipient":"` + recipient + `","quantity":10}]`
+
+		txResponse = &token.TxResponse{TxID: "txid", Status: "SUCCESS"}
+		fakeStub = &mock.Stub{}
+		buffer = gbytes.NewBuffer()
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	Describe("IssueCmd", func() {
+		var (
+			issueCmd  *token.IssueCmd
+			tokenType string
+			quantity  uint64
+		)
+
+		BeforeEach(func() {
+			tokenType, quantity = "USD", 100
+			fakeStub.IssueReturns(txResponse, nil)
+			issueCmd = token.NewIssueCmd(fakeStub, buffer)
+			issueCmd.SetTokenConfig(&tokenConfig)
+			issueCmd.SetType(&tokenType)
+			issueCmd.SetQuantity(&quantity)
+			issueCmd.SetRecipient(&recipient)
+		})
+
+		It("issues the tokens and prints the response", func() {
+			err := issueCmd.Execute(common.Config{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeStub.SetupCallCount()).To(Equal(1))
+			_, tokenConf := fakeStub.SetupArgsForCall(0)
+			Expect(tokenConf).To(Equal(token.TokenConfig{ChannelID: "mychannel", ProverPeer: "peer0:7051", Orderer: "orderer:7050"}))
+
+			Expect(fakeStub.IssueCallCount()).To(Equal(1))
+			Expect(fakeStub.IssueArgsForCall(0)).To(Equal([]*pb.TokenToIssue{{Recipient: bob, Type: "USD", Quantity: 100}}))
+			Expect(buffer).To(gbytes.Say(`"TxID": "txid",\s+"Status": "SUCCESS"`))
+			Expect(fakeStub.CloseCallCount()).To(Equal(1))
+		})
+
+		Context("when the recipient is malformed", func() {
+			It("returns an error", func() {
+				recipient = "Org1MSP"
+				err := issueCmd.Execute(common.Config{})
+				Expect(err).To(MatchError("recipient Org1MSP is not of the form MSPID:CERT_PATH"))
+				Expect(fakeStub.SetupCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when no quantity is specified", func() {
+			It("returns an error", func() {
+				quantity = 0
+				err := issueCmd.Execute(common.Config{})
+				Expect(err).To(MatchError("no quantity specified"))
+			})
+		})
+
+		Context("when no token config is specified", func() {
+			It("returns an error", func() {
+				issueCmd.SetTokenConfig(nil)
+				err := issueCmd.Execute(common.Config{})
+				Expect(err).To(MatchError("no token config specified"))
+			})
+		})
+
+		Context("when the stub cannot be set up", func() {
+			It("returns an error", func() {
+				fakeStub.SetupReturns(errors.New("no-connection"))
+				err := issueCmd.Execute(common.Config{})
+				Expect(err).To(MatchError("no-connection"))
+				Expect(fakeStub.IssueCallCount()).To(Equal(0))
+				Expect(fakeStub.CloseCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the stub fails", func() {
+			It("returns an error", func() {
+				fakeStub.IssueReturns(nil, errors.New("wild-banana"))
+				err := issueCmd.Execute(common.Config{})
+				Expect(err).To(MatchError("wild-banana"))
+			})
+		})
+	})
+
+	Describe("ListCmd", func() {
+		var listCmd *token.ListCmd
+
+		BeforeEach(func() {
+			fakeStub.ListTokensReturns([]*pb.TokenOutput{{Id: []byte("id1"), Type: "USD", Quantity: 100}}, nil)
+			listCmd = token.NewListCmd(fakeStub, buffer)
+			listCmd.SetTokenConfig(&tokenConfig)
+		})
+
+		It("prints the unspent tokens with base64 encoded IDs", func() {
+			err := listCmd.Execute(common.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buffer).To(gbytes.Say(`"ID": "` + base64.StdEncoding.EncodeToString([]byte("id1")) + `",\s+"Type": "USD",\s+"Quantity": 100`))
+		})
+
+		It("prints the non-fungible token of unspent tokens", func() {
+			fakeStub.ListTokensReturns([]*pb.TokenOutput{{Id: []byte("id2"), Type: "ART", Quantity: 1, Nft: &pb.NonFungibleToken{Id: "painting-1", Uri: "uri"}}}, nil)
+			err := listCmd.Execute(common.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buffer).To(gbytes.Say(`"Quantity": 1,\s+"NFT": {\s+"id": "painting-1",\s+"uri": "uri"\s+}`))
+		})
+
+		It("prints an empty list when there are no unspent tokens", func() {
+			fakeStub.ListTokensReturns(nil, nil)
+			err := listCmd.Execute(common.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buffer).To(gbytes.Say(`\[\]`))
+		})
+
+		Context("when the stub fails", func() {
+			It("returns an error", func() {
+				fakeStub.ListTokensReturns(nil, errors.New("wild-banana"))
+				err := listCmd.Execute(common.Config{})
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeStub.CloseCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("SupplyCmd", func() {
+		var supplyCmd *token.SupplyCmd
+
+		BeforeEach(func() {
+			fakeStub.GetSupplyReturns([]*pb.TokenSupply{{Type: "USD", Quantity: 100}}, nil)
+			supplyCmd = token.NewSupplyCmd(fakeStub, buffer)
+			supplyCmd.SetTokenConfig(&tokenConfig)
+		})
+
+		It("prints the supply of all token types", func() {
+			err := supplyCmd.Execute(common.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buffer).To(gbytes.Say(`"Type": "USD",\s+"Quantity": 100`))
+			Expect(fakeStub.GetSupplyArgsForCall(0)).To(BeEmpty())
+		})
+
+		It("requests the supply of the given token types", func() {
+			types := []string{"USD", "EUR"}
+			supplyCmd.SetTypes(&types)
+			err := supplyCmd.Execute(common.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeStub.GetSupplyArgsForCall(0)).To(Equal([]string{"USD", "EUR"}))
+		})
+
+		Context("when the stub fails", func() {
+			It("returns an error", func() {
+				fakeStub.GetSupplyReturns(nil, errors.New("wild-banana"))
+				err := supplyCmd.Execute(common.Config{})
+				Expect(err).To(MatchError("wild-banana"))
+			})
+		})
+	})
+
+	Describe("TransferCmd", func() {
+		var transferCmd *token.TransferCmd
+
+		BeforeEach(func() {
+			fakeStub.TransferReturns(txResponse, nil)
+			transferCmd = token.NewTransferCmd(fakeStub, buffer)
+			transferCmd.SetTokenConfig(&tokenConfig)
+			transferCmd.SetTokenIDs(&tokenIDs)
+			transferCmd.SetShares(&shares)
+		})
+
+		It("transfers the tokens and prints the response", func() {
+			err := transferCmd.Execute(common.Config{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeStub.TransferCallCount()).To(Equal(1))
+			ids, transferShares := fakeStub.TransferArgsForCall(0)
+			Expect(ids).To(Equal([][]byte{[]byte("id1"), []byte("id2")}))
+			Expect(transferShares).To(Equal([]*pb.RecipientTransferShare{{Recipient: bob, Quantity: 10}}))
+			Expect(buffer).To(gbytes.Say(`"TxID": "txid"`))
+		})
+
+		Context("when no token IDs are specified", func() {
+			It("returns an error", func() {
+				tokenIDs = nil
+				err := transferCmd.Execute(common.Config{})
+				Expect(err).To(MatchError("no token IDs specified"))
+			})
+		})
+
+		Context("when a token ID is not base64 encoded", func() {
+			It("returns an error", func() {
+				tokenIDs = []string{"%%%"}
+				err := transferCmd.Execute(common.Config{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid token ID %%%"))
+			})
+		})
+
+		Context("when the shares are not valid JSON", func() {
+			It("returns an error", func() {
+				shares = "[{"
+				err := transferCmd.Execute(common.Config{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed parsing shares"))
+			})
+		})
+
+		Context("when no shares are specified", func() {
+			It("returns an error", func() {
+				shares = "[]"
+				err := transferCmd.Execute(common.Config{})
+				Expect(err).To(MatchError("no shares specified"))
+			})
+		})
+	})
+
+	Describe("RedeemCmd", func() {
+		var (
+			redeemCmd *token.RedeemCmd
+			quantity  uint64
+		)
+
+		BeforeEach(func() {
+			quantity = 50
+			fakeStub.RedeemReturns(txResponse, nil)
+			redeemCmd = token.NewRedeemCmd(fakeStub, buffer)
+			redeemCmd.SetTokenConfig(&tokenConfig)
+			redeemCmd.SetTokenIDs(&tokenIDs)
+			redeemCmd.SetQuantity(&quantity)
+		})
+
+		It("redeems the tokens and prints the response", func() {
+			err := redeemCmd.Execute(common.Config{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeStub.RedeemCallCount()).To(Equal(1))
+			ids, q := fakeStub.RedeemArgsForCall(0)
+			Expect(ids).To(Equal([][]byte{[]byte("id1"), []byte("id2")}))
+			Expect(q).To(Equal(uint64(50)))
+			Expect(buffer).To(gbytes.Say(`"TxID": "txid"`))
+		})
+
+		Context("when no quantity is specified", func() {
+			It("returns an error", func() {
+				quantity = 0
+				err := redeemCmd.Execute(common.Config{})
+				Expect(err).To(MatchError("no quantity specified"))
+			})
+		})
+	})
+
+	Describe("ApproveCmd", func() {
+		var approveCmd *token.ApproveCmd
+
+		BeforeEach(func() {
+			fakeStub.ApproveReturns(txResponse, nil)
+			approveCmd = token.NewApproveCmd(fakeStub, buffer)
+			approveCmd.SetTokenConfig(&tokenConfig)
+			approveCmd.SetTokenIDs(&tokenIDs)
+			approveCmd.SetShares(&shares)
+		})
+
+		It("delegates the tokens and prints the response", func() {
+			err := approveCmd.Execute(common.Config{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeStub.ApproveCallCount()).To(Equal(1))
+			ids, allowanceShares := fakeStub.ApproveArgsForCall(0)
+			Expect(ids).To(Equal([][]byte{[]byte("id1"), []byte("id2")}))
+			Expect(allowanceShares).To(Equal([]*pb.AllowanceRecipientShare{{Recipient: bob, Quantity: 10}}))
+			Expect(buffer).To(gbytes.Say(`"TxID": "txid"`))
+		})
+
+		Context("when the stub fails", func() {
+			It("returns an error", func() {
+				fakeStub.ApproveReturns(nil, errors.New("wild-banana"))
+				err := approveCmd.Execute(common.Config{})
+				Expect(err).To(MatchError("wild-banana"))
+			})
+		})
+	})
+
+	Describe("TransferFromCmd", func() {
+		var transferFromCmd *token.TransferFromCmd
+
+		BeforeEach(func() {
+			fakeStub.TransferFromReturns(txResponse, nil)
+			transferFromCmd = token.NewTransferFromCmd(fakeStub, buffer)
+			transferFromCmd.SetTokenConfig(&tokenConfig)
+			transferFromCmd.SetTokenIDs(&tokenIDs)
+			transferFromCmd.SetShares(&shares)
+		})
+
+		It("transfers the delegated tokens and prints the response", func() {
+			err := transferFromCmd.Execute(common.Config{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeStub.TransferFromCallCount()).To(Equal(1))
+			ids, transferShares := fakeStub.TransferFromArgsForCall(0)
+			Expect(ids).To(Equal([][]byte{[]byte("id1"), []byte("id2")}))
+			Expect(transferShares).To(Equal([]*pb.RecipientTransferShare{{Recipient: bob, Quantity: 10}}))
+			Expect(buffer).To(gbytes.Say(`"TxID": "txid"`))
+		})
+
+		Context("when the stub fails", func() {
+			It("returns an error", func() {
+				fakeStub.TransferFromReturns(nil, errors.New("wild-banana"))
+				err := transferFromCmd.Execute(common.Config{})
+				Expect(err).To(MatchError("wild-banana"))
+			})
+		})
+	})
+
+	Describe("AddCommands", func() {
+		It("registers the token commands", func() {
+			fakeRegistrar := &mock.CommandRegistrar{}
+			app := kingpin.New("token", "token CLI")
+			fakeRegistrar.CommandStub = func(name, help string, _ common.CLICommand) *kingpin.CmdClause {
+				return app.Command(name, help)
+			}
+			token.AddCommands(fakeRegistrar)
+
+			var names []string
+			for i := 0; i < fakeRegistrar.CommandCallCount(); i++ {
+				name, _, _ := fakeRegistrar.CommandArgsForCall(i)
+				names = append(names, name)
+			}
+			Expect(names).To(Equal([]string{
+				token.SaveTokenConfigCommand,
+				token.IssueCommand,
+				token.ListCommand,
+				token.TransferCommand,
+				token.RedeemCommand,
+				token.ApproveCommand,
+				token.TransferFromCommand,
+				token.SupplyCommand,
+			}))
+		})
+	})
+})
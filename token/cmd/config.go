@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"io/ioutil"
+
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// TokenConfig defines the services the token commands connect to
+type TokenConfig struct {
+	ChannelID  string
+	ProverPeer string
+	Orderer    string
+}
+
+// TokenConfigFromFile loads the given file and converts it to a TokenConfig
+func TokenConfigFromFile(file string) (TokenConfig, error) {
+	configData, err := ioutil.ReadFile(file)
+	if err != nil {
+		return TokenConfig{}, errors.WithStack(err)
+	}
+	config := TokenConfig{}
+
+	if err := yaml.Unmarshal(configData, &config); err != nil {
+		return TokenConfig{}, errors.Errorf("error unmarshaling YAML file %s: %s", file, err)
+	}
+
+	return config, validateTokenConfig(config)
+}
+
+// ToFile writes the token config into a file
+func (c TokenConfig) ToFile(file string) error {
+	if err := validateTokenConfig(c); err != nil {
+		return errors.Wrap(err, "token config isn't valid")
+	}
+	b, _ := yaml.Marshal(c)
+	if err := ioutil.WriteFile(file, b, 0600); err != nil {
+		return errors.Errorf("failed writing file %s: %v", file, err)
+	}
+	return nil
+}
+
+func validateTokenConfig(conf TokenConfig) error {
+	if conf.ChannelID == "" {
+		return errors.New("no channel specified")
+	}
+	if conf.ProverPeer == "" {
+		return errors.New("no prover peer specified")
+	}
+	if conf.Orderer == "" {
+		return errors.New("no orderer specified")
+	}
+	return nil
+}
+
+// SaveTokenConfigCmd executes a command that saves the token config
+type SaveTokenConfigCmd struct {
+	tokenConfig *string
+	channel     *string
+	proverPeer  *string
+	orderer     *string
+}
+
+// SetTokenConfig sets the file the token config is saved to
+func (sc *SaveTokenConfigCmd) SetTokenConfig(tokenConfig *string) {
+	sc.tokenConfig = tokenConfig
+}
+
+// SetChannel sets the channel of the SaveTokenConfigCmd
+func (sc *SaveTokenConfigCmd) SetChannel(channel *string) {
+	sc.channel = channel
+}
+
+// SetProverPeer sets the prover peer of the SaveTokenConfigCmd
+func (sc *SaveTokenConfigCmd) SetProverPeer(proverPeer *string) {
+	sc.proverPeer = proverPeer
+}
+
+// SetOrderer sets the orderer of the SaveTokenConfigCmd
+func (sc *SaveTokenConfigCmd) SetOrderer(orderer *string) {
+	sc.orderer = orderer
+}
+
+// Execute executes the command
+func (sc *SaveTokenConfigCmd) Execute(_ common.Config) error {
+	if sc.tokenConfig == nil || *sc.tokenConfig == "" {
+		return errors.New("--tokenConfig must be used to specify the token config file")
+	}
+	conf := TokenConfig{
+		ChannelID:  stringValue(sc.channel),
+		ProverPeer: stringValue(sc.proverPeer),
+		Orderer:    stringValue(sc.orderer),
+	}
+	return conf.ToFile(*sc.tokenConfig)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/cmd/common"
+	token "github.com/hyperledger/fabric/token/cmd"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TokenConfig", func() {
+	var (
+		tempDir    string
+		configFile string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "token-config")
+		Expect(err).NotTo(HaveOccurred())
+		configFile = filepath.Join(tempDir, "token.yaml")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	It("saves and loads a token config", func() {
+		conf := token.TokenConfig{
+			ChannelID:  "mychannel",
+			ProverPeer: "peer0:7051",
+			Orderer:    "orderer:7050",
+		}
+		err := conf.ToFile(configFile)
+		Expect(err).NotTo(HaveOccurred())
+
+		loaded, err := token.TokenConfigFromFile(configFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded).To(Equal(conf))
+	})
+
+	It("does not save an invalid token config", func() {
+		err := token.TokenConfig{ChannelID: "mychannel", ProverPeer: "peer0:7051"}.ToFile(configFile)
+		Expect(err).To(MatchError("token config isn't valid: no orderer specified"))
+	})
+
+	It("does not load a file that doesn't exist", func() {
+		_, err := token.TokenConfigFromFile(configFile)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("no such file or directory"))
+	})
+
+	It("does not load a file that is not YAML", func() {
+		err := ioutil.WriteFile(configFile, []byte("not: a: yaml"), 0600)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = token.TokenConfigFromFile(configFile)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("error unmarshaling YAML file"))
+	})
+
+	Describe("SaveTokenConfigCmd", func() {
+		var (
+			saveCmd                  *token.SaveTokenConfigCmd
+			channel, prover, orderer string
+		)
+
+		BeforeEach(func() {
+			channel, prover, orderer = "mychannel", "peer0:7051", "orderer:7050"
+			saveCmd = &token.SaveTokenConfigCmd{}
+			saveCmd.SetTokenConfig(&configFile)
+			saveCmd.SetChannel(&channel)
+			saveCmd.SetProverPeer(&prover)
+			saveCmd.SetOrderer(&orderer)
+		})
+
+		It("saves the token config passed by flags", func() {
+			err := saveCmd.Execute(common.Config{})
+			Expect(err).NotTo(HaveOccurred())
+
+			loaded, err := token.TokenConfigFromFile(configFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loaded).To(Equal(token.TokenConfig{ChannelID: "mychannel", ProverPeer: "peer0:7051", Orderer: "orderer:7050"}))
+		})
+
+		Context("when no token config file is specified", func() {
+			It("returns an error", func() {
+				saveCmd.SetTokenConfig(nil)
+				err := saveCmd.Execute(common.Config{})
+				Expect(err).To(MatchError("--tokenConfig must be used to specify the token config file"))
+			})
+		})
+
+		Context("when the prover peer is missing", func() {
+			It("returns an error", func() {
+				saveCmd.SetProverPeer(nil)
+				err := saveCmd.Execute(common.Config{})
+				Expect(err).To(MatchError("token config isn't valid: no prover peer specified"))
+			})
+		})
+	})
+})
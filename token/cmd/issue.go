@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"io"
+
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// NewIssueCmd creates a new IssueCmd with the given Stub and writer
+func NewIssueCmd(stub Stub, writer io.Writer) *IssueCmd {
+	return &IssueCmd{
+		baseCmd: baseCmd{stub: stub, writer: writer},
+	}
+}
+
+// IssueCmd executes a command that issues tokens
+type IssueCmd struct {
+	baseCmd
+	tokenType *string
+	quantity  *uint64
+	recipient *string
+}
+
+// SetType sets the type of the tokens to issue
+func (ic *IssueCmd) SetType(tokenType *string) {
+	ic.tokenType = tokenType
+}
+
+// SetQuantity sets the quantity of the tokens to issue
+func (ic *IssueCmd) SetQuantity(quantity *uint64) {
+	ic.quantity = quantity
+}
+
+// SetRecipient sets the recipient of the tokens to issue
+func (ic *IssueCmd) SetRecipient(recipient *string) {
+	ic.recipient = recipient
+}
+
+// Execute executes the command
+func (ic *IssueCmd) Execute(conf common.Config) error {
+	if ic.tokenType == nil || *ic.tokenType == "" {
+		return errors.New("no type specified")
+	}
+	if ic.quantity == nil || *ic.quantity == 0 {
+		return errors.New("no quantity specified")
+	}
+	if ic.recipient == nil || *ic.recipient == "" {
+		return errors.New("no recipient specified")
+	}
+	recipient, err := parseRecipient(*ic.recipient)
+	if err != nil {
+		return err
+	}
+
+	if err := ic.setup(conf); err != nil {
+		return err
+	}
+	defer ic.stub.Close()
+	resp, err := ic.stub.Issue([]*token.TokenToIssue{{
+		Recipient: recipient,
+		Type:      *ic.tokenType,
+		Quantity:  *ic.quantity,
+	}})
+	if err != nil {
+		return err
+	}
+	return ic.print(resp)
+}
@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"io"
+
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/hyperledger/fabric/protos/token"
+)
+
+// NewListCmd creates a new ListCmd with the given Stub and writer
+func NewListCmd(stub Stub, writer io.Writer) *ListCmd {
+	return &ListCmd{
+		baseCmd: baseCmd{stub: stub, writer: writer},
+	}
+}
+
+// ListCmd executes a command that lists the unspent tokens of the user
+type ListCmd struct {
+	baseCmd
+}
+
+// unspentToken is the JSON representation of an unspent token;
+// the ID is base64 encoded, which is how token IDs are passed to the other commands.
+// NFT is only present for non-fungible tokens.
+type unspentToken struct {
+	ID       []byte
+	Type     string
+	Quantity uint64
+	NFT      *token.NonFungibleToken `json:",omitempty"`
+}
+
+// Execute executes the command
+func (lc *ListCmd) Execute(conf common.Config) error {
+	if err := lc.setup(conf); err != nil {
+		return err
+	}
+	defer lc.stub.Close()
+	tokens, err := lc.stub.ListTokens()
+	if err != nil {
+		return err
+	}
+
+	unspentTokens := []unspentToken{}
+	for _, tok := range tokens {
+		unspentTokens = append(unspentTokens, unspentToken{ID: tok.Id, Type: tok.Type, Quantity: tok.Quantity, NFT: tok.Nft})
+	}
+	return lc.print(unspentTokens)
+}
@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"io"
+
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/pkg/errors"
+)
+
+// NewRedeemCmd creates a new RedeemCmd with the given Stub and writer
+func NewRedeemCmd(stub Stub, writer io.Writer) *RedeemCmd {
+	return &RedeemCmd{
+		spendCmd: spendCmd{baseCmd: baseCmd{stub: stub, writer: writer}},
+	}
+}
+
+// RedeemCmd executes a command that redeems tokens
+type RedeemCmd struct {
+	spendCmd
+	quantity *uint64
+}
+
+// SetQuantity sets the quantity of the tokens to redeem
+func (rc *RedeemCmd) SetQuantity(quantity *uint64) {
+	rc.quantity = quantity
+}
+
+// Execute executes the command
+func (rc *RedeemCmd) Execute(conf common.Config) error {
+	tokenIDs, err := rc.parseTokenIDs()
+	if err != nil {
+		return err
+	}
+	if rc.quantity == nil || *rc.quantity == 0 {
+		return errors.New("no quantity specified")
+	}
+
+	if err := rc.setup(conf); err != nil {
+		return err
+	}
+	defer rc.stub.Close()
+	resp, err := rc.stub.Redeem(tokenIDs, *rc.quantity)
+	if err != nil {
+		return err
+	}
+	return rc.print(resp)
+}
@@ -0,0 +1,206 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"context"
+	"crypto/rand"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/hyperledger/fabric/cmd/common/comm"
+	"github.com/hyperledger/fabric/cmd/common/signer"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+const (
+	defaultTimeout = time.Second * 10
+)
+
+// TxResponse describes the outcome of the submission of a token transaction
+type TxResponse struct {
+	TxID   string
+	Status string
+}
+
+// ClientStub is a stub that communicates with the prover peer and the orderer
+// using the token client implementation
+type ClientStub struct {
+	tokenConf TokenConfig
+	comm      *comm.Client
+	signer    *signingIdentity
+	conn      *grpc.ClientConn
+	prover    *client.ProverPeer
+}
+
+// Setup connects the stub to the prover peer of the given token config;
+// the connection is released by Close
+func (stub *ClientStub) Setup(conf common.Config, tokenConf TokenConfig) error {
+	comm, err := comm.NewClient(conf.TLSConfig)
+	if err != nil {
+		return err
+	}
+	signer, err := signer.NewSigner(conf.SignerConfig)
+	if err != nil {
+		return err
+	}
+	conn, err := comm.NewDialer(tokenConf.ProverPeer)()
+	if err != nil {
+		return errors.Errorf("failed connecting to %s: %v", tokenConf.ProverPeer, err)
+	}
+
+	stub.tokenConf = tokenConf
+	stub.comm = comm
+	stub.signer = &signingIdentity{Signer: signer}
+	stub.conn = conn
+	stub.prover = &client.ProverPeer{
+		ChannelID:        tokenConf.ChannelID,
+		ProverClient:     token.NewProverClient(conn),
+		RandomnessReader: rand.Reader,
+		Time:             time.Now,
+	}
+	return nil
+}
+
+// Close closes the connection to the prover peer
+func (stub *ClientStub) Close() error {
+	if stub.conn == nil {
+		return nil
+	}
+	return stub.conn.Close()
+}
+
+// Issue requests the import of the given tokens and submits the resulting transaction
+func (stub *ClientStub) Issue(tokensToIssue []*token.TokenToIssue) (*TxResponse, error) {
+	resp, err := stub.prover.RequestImport(tokensToIssue, stub.signer)
+	if err != nil {
+		return nil, err
+	}
+	return stub.submit(resp)
+}
+
+// Transfer requests the transfer of the given tokens and submits the resulting transaction
+func (stub *ClientStub) Transfer(tokenIDs [][]byte, shares []*token.RecipientTransferShare) (*TxResponse, error) {
+	resp, err := stub.prover.RequestTransfer(tokenIDs, shares, stub.signer)
+	if err != nil {
+		return nil, err
+	}
+	return stub.submit(resp)
+}
+
+// Redeem requests the redemption of the given tokens and submits the resulting transaction
+func (stub *ClientStub) Redeem(tokenIDs [][]byte, quantity uint64) (*TxResponse, error) {
+	resp, err := stub.prover.RequestRedeem(tokenIDs, quantity, stub.signer)
+	if err != nil {
+		return nil, err
+	}
+	return stub.submit(resp)
+}
+
+// Approve requests the delegation of the given tokens and submits the resulting transaction
+func (stub *ClientStub) Approve(tokenIDs [][]byte, shares []*token.AllowanceRecipientShare) (*TxResponse, error) {
+	resp, err := stub.prover.RequestApprove(tokenIDs, shares, stub.signer)
+	if err != nil {
+		return nil, err
+	}
+	return stub.submit(resp)
+}
+
+// TransferFrom requests the transfer of the given delegated tokens and submits the resulting transaction
+func (stub *ClientStub) TransferFrom(tokenIDs [][]byte, shares []*token.RecipientTransferShare) (*TxResponse, error) {
+	resp, err := stub.prover.RequestTransferFrom(tokenIDs, shares, stub.signer)
+	if err != nil {
+		return nil, err
+	}
+	return stub.submit(resp)
+}
+
+// ListTokens returns the unspent tokens owned by the client
+func (stub *ClientStub) ListTokens() ([]*token.TokenOutput, error) {
+	return stub.prover.ListTokens(stub.signer)
+}
+
+// GetSupply returns the outstanding supply of the given token types, or of all token types if none is given
+func (stub *ClientStub) GetSupply(types []string) ([]*token.TokenSupply, error) {
+	return stub.prover.GetSupply(types, stub.signer)
+}
+
+// submit wraps the token transaction of the prover response in a transaction envelope
+// and broadcasts it to the orderer.
+func (stub *ClientStub) submit(proverResponse []byte) (*TxResponse, error) {
+	tokenTx, err := tokenTransaction(proverResponse)
+	if err != nil {
+		return nil, err
+	}
+	txID, header, err := client.CreateHeader(cb.HeaderType_TOKEN_TRANSACTION, stub.tokenConf.ChannelID, stub.signer.Creator, stub.comm.TLSCertHash)
+	if err != nil {
+		return nil, err
+	}
+	envelope, err := client.CreateEnvelope(tokenTx, header, stub.signer)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := stub.comm.NewDialer(stub.tokenConf.Orderer)()
+	if err != nil {
+		return nil, errors.Errorf("failed connecting to %s: %v", stub.tokenConf.Orderer, err)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	broadcast, err := ab.NewAtomicBroadcastClient(conn).Broadcast(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed creating broadcast stream to %s", stub.tokenConf.Orderer)
+	}
+	if err := client.BroadcastSend(broadcast, stub.tokenConf.Orderer, envelope); err != nil {
+		return nil, err
+	}
+	responses := make(chan cb.Status)
+	errs := make(chan error, 1)
+	go client.BroadcastReceive(broadcast, stub.tokenConf.Orderer, responses, errs)
+	status, err := client.BroadcastWaitForResponse(responses, errs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TxResponse{TxID: txID, Status: status.String()}, nil
+}
+
+// tokenTransaction extracts the serialized token transaction from the response of the prover peer
+func tokenTransaction(proverResponse []byte) ([]byte, error) {
+	commandResp := &token.CommandResponse{}
+	if err := proto.Unmarshal(proverResponse, commandResp); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal command response")
+	}
+	if commandResp.GetErr() != nil {
+		return nil, errors.Errorf("error from prover: %s", commandResp.GetErr().GetMessage())
+	}
+	if commandResp.GetTokenTransaction() == nil {
+		return nil, errors.New("no token transaction in command response")
+	}
+	return proto.Marshal(commandResp.GetTokenTransaction())
+}
+
+// signingIdentity adapts a Signer to the SigningIdentity used by the token client
+type signingIdentity struct {
+	*signer.Signer
+}
+
+func (si *signingIdentity) Serialize() ([]byte, error) {
+	return si.Creator, nil
+}
+
+func (si *signingIdentity) GetPublicVersion() tk.Identity {
+	return si
+}
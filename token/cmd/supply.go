@@ -0,0 +1,60 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"io"
+
+	"github.com/hyperledger/fabric/cmd/common"
+)
+
+// NewSupplyCmd creates a new SupplyCmd with the given Stub and writer
+func NewSupplyCmd(stub Stub, writer io.Writer) *SupplyCmd {
+	return &SupplyCmd{
+		baseCmd: baseCmd{stub: stub, writer: writer},
+	}
+}
+
+// SupplyCmd executes a command that shows the outstanding supply of token types
+type SupplyCmd struct {
+	baseCmd
+	types *[]string
+}
+
+// tokenSupply is the JSON representation of the outstanding supply of a token type
+type tokenSupply struct {
+	Type     string
+	Quantity uint64
+}
+
+// SetTypes sets the token types to show the supply of
+func (sc *SupplyCmd) SetTypes(types *[]string) {
+	sc.types = types
+}
+
+// Execute executes the command
+func (sc *SupplyCmd) Execute(conf common.Config) error {
+	var types []string
+	if sc.types != nil {
+		types = *sc.types
+	}
+
+	if err := sc.setup(conf); err != nil {
+		return err
+	}
+	defer sc.stub.Close()
+	supplies, err := sc.stub.GetSupply(types)
+	if err != nil {
+		return err
+	}
+
+	tokenSupplies := []tokenSupply{}
+	for _, supply := range supplies {
+		tokenSupplies = append(tokenSupplies, tokenSupply{Type: supply.Type, Quantity: supply.Quantity})
+	}
+	return sc.print(tokenSupplies)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"io"
+
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/hyperledger/fabric/protos/token"
+)
+
+// NewTransferCmd creates a new TransferCmd with the given Stub and writer
+func NewTransferCmd(stub Stub, writer io.Writer) *TransferCmd {
+	return &TransferCmd{
+		spendCmd: spendCmd{baseCmd: baseCmd{stub: stub, writer: writer}},
+	}
+}
+
+// TransferCmd executes a command that transfers tokens
+type TransferCmd struct {
+	spendCmd
+	shares *string
+}
+
+// SetShares sets the recipients of the tokens, as a JSON array
+func (tc *TransferCmd) SetShares(shares *string) {
+	tc.shares = shares
+}
+
+// Execute executes the command
+func (tc *TransferCmd) Execute(conf common.Config) error {
+	tokenIDs, err := tc.parseTokenIDs()
+	if err != nil {
+		return err
+	}
+	shares, err := transferShares(tc.shares)
+	if err != nil {
+		return err
+	}
+
+	if err := tc.setup(conf); err != nil {
+		return err
+	}
+	defer tc.stub.Close()
+	resp, err := tc.stub.Transfer(tokenIDs, shares)
+	if err != nil {
+		return err
+	}
+	return tc.print(resp)
+}
+
+func transferShares(shares *string) ([]*token.RecipientTransferShare, error) {
+	parsed, err := parseShares(shares)
+	if err != nil {
+		return nil, err
+	}
+	var transferShares []*token.RecipientTransferShare
+	for _, s := range parsed {
+		recipient, err := parseRecipient(s.Recipient)
+		if err != nil {
+			return nil, err
+		}
+		transferShares = append(transferShares, &token.RecipientTransferShare{Recipient: recipient, Quantity: s.Quantity})
+	}
+	return transferShares, nil
+}
@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"io"
+
+	"github.com/hyperledger/fabric/cmd/common"
+)
+
+// NewTransferFromCmd creates a new TransferFromCmd with the given Stub and writer
+func NewTransferFromCmd(stub Stub, writer io.Writer) *TransferFromCmd {
+	return &TransferFromCmd{
+		spendCmd: spendCmd{baseCmd: baseCmd{stub: stub, writer: writer}},
+	}
+}
+
+// TransferFromCmd executes a command that transfers tokens delegated to the user
+type TransferFromCmd struct {
+	spendCmd
+	shares *string
+}
+
+// SetShares sets the recipients of the tokens, as a JSON array
+func (tc *TransferFromCmd) SetShares(shares *string) {
+	tc.shares = shares
+}
+
+// Execute executes the command
+func (tc *TransferFromCmd) Execute(conf common.Config) error {
+	tokenIDs, err := tc.parseTokenIDs()
+	if err != nil {
+		return err
+	}
+	shares, err := transferShares(tc.shares)
+	if err != nil {
+		return err
+	}
+
+	if err := tc.setup(conf); err != nil {
+		return err
+	}
+	defer tc.stub.Close()
+	resp, err := tc.stub.TransferFrom(tokenIDs, shares)
+	if err != nil {
+		return err
+	}
+	return tc.print(resp)
+}
@@ -16,6 +16,19 @@ type IssuingValidator interface {
 	Validate(creator PublicInfo, tokenType string) error
 }
 
+// SupplyPolicy is used to establish the maximum outstanding supply of a token type.
+type SupplyPolicy interface {
+	// MaxSupply returns the maximum outstanding supply of tokens of the passed type, or 0 if the supply is not capped.
+	MaxSupply(tokenType string) uint64
+}
+
+// SupplyPolicyManager returns instances of SupplyPolicy
+type SupplyPolicyManager interface {
+	// SupplyPolicy returns the SupplyPolicy of the passed channel
+	// if the channel exists
+	SupplyPolicy(channel string) (SupplyPolicy, error)
+}
+
 // PublicInfo is used to identify token owners.
 type PublicInfo interface {
 	Public() []byte
@@ -9,6 +9,8 @@ package identity_test
 import "github.com/hyperledger/fabric/msp"
 
 //go:generate counterfeiter -o mock/issuing_validator.go -fake-name IssuingValidator . IssuingValidator
+//go:generate counterfeiter -o mock/supply_policy.go -fake-name SupplyPolicy . SupplyPolicy
+//go:generate counterfeiter -o mock/supply_policy_manager.go -fake-name SupplyPolicyManager . SupplyPolicyManager
 //go:generate counterfeiter -o mock/public_info.go -fake-name PublicInfo . PublicInfo
 //go:generate counterfeiter -o mock/deserializer_manager.go -fake-name DeserializerManager . DeserializerManager
 //go:generate counterfeiter -o mock/deserializer.go -fake-name Deserializer . Deserializer
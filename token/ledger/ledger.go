@@ -6,7 +6,10 @@ SPDX-License-Identifier: Apache-2.0
 
 package ledger
 
-import "github.com/hyperledger/fabric/common/ledger"
+import (
+	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+)
 
 //go:generate counterfeiter -o mock/ledger_reader.go -fake-name LedgerReader . LedgerReader
 //go:generate counterfeiter -o mock/ledger_manager.go -fake-name LedgerManager . LedgerManager
@@ -15,6 +18,9 @@ import "github.com/hyperledger/fabric/common/ledger"
 type LedgerManager interface {
 	// Returns a LedgerReader for the passed channel, an error otherwise
 	GetLedgerReader(channel string) (LedgerReader, error)
+
+	// Returns a BlockReader for the passed channel, an error otherwise
+	GetBlockReader(channel string) (BlockReader, error)
 }
 
 // LedgerReader interface, used to read from a ledger.
@@ -33,6 +39,20 @@ type LedgerReader interface {
 	Done()
 }
 
+//go:generate counterfeiter -o mock/block_reader.go -fake-name BlockReader . BlockReader
+
+// BlockReader interface, used to read the blocks committed to a ledger.
+type BlockReader interface {
+	// GetBlockchainInfo returns basic info about the blockchain, such as its height
+	GetBlockchainInfo() (*common.BlockchainInfo, error)
+
+	// GetBlockByNumber returns the block with the given number
+	GetBlockByNumber(blockNumber uint64) (*common.Block, error)
+
+	// GetBlockByTxID returns the block that contains the transaction with the given ID
+	GetBlockByTxID(txID string) (*common.Block, error)
+}
+
 //go:generate counterfeiter -o mock/ledger_writer.go -fake-name LedgerWriter . LedgerWriter
 
 // LedgerWriter interface, used to read from, and write to, a ledger.
@@ -2,16 +2,29 @@
 package mock
 
 import (
-	"sync"
+	sync "sync"
 
-	"github.com/hyperledger/fabric/token/ledger"
+	ledger "github.com/hyperledger/fabric/token/ledger"
 )
 
 type LedgerManager struct {
-	GetLedgerReaderStub        func(channel string) (ledger.LedgerReader, error)
+	GetBlockReaderStub        func(string) (ledger.BlockReader, error)
+	getBlockReaderMutex       sync.RWMutex
+	getBlockReaderArgsForCall []struct {
+		arg1 string
+	}
+	getBlockReaderReturns struct {
+		result1 ledger.BlockReader
+		result2 error
+	}
+	getBlockReaderReturnsOnCall map[int]struct {
+		result1 ledger.BlockReader
+		result2 error
+	}
+	GetLedgerReaderStub        func(string) (ledger.LedgerReader, error)
 	getLedgerReaderMutex       sync.RWMutex
 	getLedgerReaderArgsForCall []struct {
-		channel string
+		arg1 string
 	}
 	getLedgerReaderReturns struct {
 		result1 ledger.LedgerReader
@@ -25,21 +38,85 @@ type LedgerManager struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *LedgerManager) GetLedgerReader(channel string) (ledger.LedgerReader, error) {
+func (fake *LedgerManager) GetBlockReader(arg1 string) (ledger.BlockReader, error) {
+	fake.getBlockReaderMutex.Lock()
+	ret, specificReturn := fake.getBlockReaderReturnsOnCall[len(fake.getBlockReaderArgsForCall)]
+	fake.getBlockReaderArgsForCall = append(fake.getBlockReaderArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetBlockReader", []interface{}{arg1})
+	fake.getBlockReaderMutex.Unlock()
+	if fake.GetBlockReaderStub != nil {
+		return fake.GetBlockReaderStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getBlockReaderReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *LedgerManager) GetBlockReaderCallCount() int {
+	fake.getBlockReaderMutex.RLock()
+	defer fake.getBlockReaderMutex.RUnlock()
+	return len(fake.getBlockReaderArgsForCall)
+}
+
+func (fake *LedgerManager) GetBlockReaderCalls(stub func(string) (ledger.BlockReader, error)) {
+	fake.getBlockReaderMutex.Lock()
+	defer fake.getBlockReaderMutex.Unlock()
+	fake.GetBlockReaderStub = stub
+}
+
+func (fake *LedgerManager) GetBlockReaderArgsForCall(i int) string {
+	fake.getBlockReaderMutex.RLock()
+	defer fake.getBlockReaderMutex.RUnlock()
+	argsForCall := fake.getBlockReaderArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *LedgerManager) GetBlockReaderReturns(result1 ledger.BlockReader, result2 error) {
+	fake.getBlockReaderMutex.Lock()
+	defer fake.getBlockReaderMutex.Unlock()
+	fake.GetBlockReaderStub = nil
+	fake.getBlockReaderReturns = struct {
+		result1 ledger.BlockReader
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *LedgerManager) GetBlockReaderReturnsOnCall(i int, result1 ledger.BlockReader, result2 error) {
+	fake.getBlockReaderMutex.Lock()
+	defer fake.getBlockReaderMutex.Unlock()
+	fake.GetBlockReaderStub = nil
+	if fake.getBlockReaderReturnsOnCall == nil {
+		fake.getBlockReaderReturnsOnCall = make(map[int]struct {
+			result1 ledger.BlockReader
+			result2 error
+		})
+	}
+	fake.getBlockReaderReturnsOnCall[i] = struct {
+		result1 ledger.BlockReader
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *LedgerManager) GetLedgerReader(arg1 string) (ledger.LedgerReader, error) {
 	fake.getLedgerReaderMutex.Lock()
 	ret, specificReturn := fake.getLedgerReaderReturnsOnCall[len(fake.getLedgerReaderArgsForCall)]
 	fake.getLedgerReaderArgsForCall = append(fake.getLedgerReaderArgsForCall, struct {
-		channel string
-	}{channel})
-	fake.recordInvocation("GetLedgerReader", []interface{}{channel})
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetLedgerReader", []interface{}{arg1})
 	fake.getLedgerReaderMutex.Unlock()
 	if fake.GetLedgerReaderStub != nil {
-		return fake.GetLedgerReaderStub(channel)
+		return fake.GetLedgerReaderStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.getLedgerReaderReturns.result1, fake.getLedgerReaderReturns.result2
+	fakeReturns := fake.getLedgerReaderReturns
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *LedgerManager) GetLedgerReaderCallCount() int {
@@ -48,13 +125,22 @@ func (fake *LedgerManager) GetLedgerReaderCallCount() int {
 	return len(fake.getLedgerReaderArgsForCall)
 }
 
+func (fake *LedgerManager) GetLedgerReaderCalls(stub func(string) (ledger.LedgerReader, error)) {
+	fake.getLedgerReaderMutex.Lock()
+	defer fake.getLedgerReaderMutex.Unlock()
+	fake.GetLedgerReaderStub = stub
+}
+
 func (fake *LedgerManager) GetLedgerReaderArgsForCall(i int) string {
 	fake.getLedgerReaderMutex.RLock()
 	defer fake.getLedgerReaderMutex.RUnlock()
-	return fake.getLedgerReaderArgsForCall[i].channel
+	argsForCall := fake.getLedgerReaderArgsForCall[i]
+	return argsForCall.arg1
 }
 
 func (fake *LedgerManager) GetLedgerReaderReturns(result1 ledger.LedgerReader, result2 error) {
+	fake.getLedgerReaderMutex.Lock()
+	defer fake.getLedgerReaderMutex.Unlock()
 	fake.GetLedgerReaderStub = nil
 	fake.getLedgerReaderReturns = struct {
 		result1 ledger.LedgerReader
@@ -63,6 +149,8 @@ func (fake *LedgerManager) GetLedgerReaderReturns(result1 ledger.LedgerReader, r
 }
 
 func (fake *LedgerManager) GetLedgerReaderReturnsOnCall(i int, result1 ledger.LedgerReader, result2 error) {
+	fake.getLedgerReaderMutex.Lock()
+	defer fake.getLedgerReaderMutex.Unlock()
 	fake.GetLedgerReaderStub = nil
 	if fake.getLedgerReaderReturnsOnCall == nil {
 		fake.getLedgerReaderReturnsOnCall = make(map[int]struct {
@@ -79,6 +167,8 @@ func (fake *LedgerManager) GetLedgerReaderReturnsOnCall(i int, result1 ledger.Le
 func (fake *LedgerManager) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.getBlockReaderMutex.RLock()
+	defer fake.getBlockReaderMutex.RUnlock()
 	fake.getLedgerReaderMutex.RLock()
 	defer fake.getLedgerReaderMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -25,6 +25,7 @@ type ACLResources struct {
 	IssueTokens    string
 	TransferTokens string
 	ListTokens     string
+	AuditTokens    string
 }
 
 // PolicyBasedAccessControl implements token command access control functions.
@@ -54,6 +55,19 @@ func (ac *PolicyBasedAccessControl) Check(sc *token.SignedCommand, c *token.Comm
 			c.Header.ChannelId,
 			signedData,
 		)
+	case *token.Command_SupplyRequest:
+		// Querying the supply has the same policy as listing tokens
+		return ac.ACLProvider.CheckACL(
+			ac.ACLResources.ListTokens,
+			c.Header.ChannelId,
+			signedData,
+		)
+	case *token.Command_LineageRequest, *token.Command_OwnerHistoryRequest, *token.Command_BalanceRequest:
+		return ac.ACLProvider.CheckACL(
+			ac.ACLResources.AuditTokens,
+			c.Header.ChannelId,
+			signedData,
+		)
 	case *token.Command_TransferRequest:
 		return ac.ACLProvider.CheckACL(
 			ac.ACLResources.TransferTokens,
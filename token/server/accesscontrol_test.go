@@ -12,6 +12,7 @@ import (
 	"github.com/hyperledger/fabric/token/server"
 	"github.com/hyperledger/fabric/token/server/mock"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 )
@@ -117,6 +118,58 @@ var _ = Describe("AccessControl", func() {
 		}))
 	})
 
+	It("validates the list policy for supply command", func() {
+		aclResources.ListTokens = "banana"
+		supplyCommand := &token.Command{
+			Header: header,
+			Payload: &token.Command_SupplyRequest{
+				SupplyRequest: &token.SupplyRequest{},
+			},
+		}
+		signedSupplyCommand := &token.SignedCommand{
+			Command:   ProtoMarshal(supplyCommand),
+			Signature: []byte("signature"),
+		}
+		err := pbac.Check(signedSupplyCommand, supplyCommand)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeACLProvider.CheckACLCallCount()).To(Equal(1))
+		resourceName, channelID, signedData := fakeACLProvider.CheckACLArgsForCall(0)
+		Expect(resourceName).To(Equal("banana"))
+		Expect(channelID).To(Equal("channel-id"))
+		Expect(signedData).To(ConsistOf(&common.SignedData{
+			Data:      signedSupplyCommand.Command,
+			Identity:  []byte("creator"),
+			Signature: []byte("signature"),
+		}))
+	})
+
+	DescribeTable("validates the audit policy for audit commands",
+		func(auditCommand *token.Command) {
+			aclResources.AuditTokens = "durian"
+			auditCommand.Header = header
+			signedAuditCommand := &token.SignedCommand{
+				Command:   ProtoMarshal(auditCommand),
+				Signature: []byte("signature"),
+			}
+			err := pbac.Check(signedAuditCommand, auditCommand)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeACLProvider.CheckACLCallCount()).To(Equal(1))
+			resourceName, channelID, signedData := fakeACLProvider.CheckACLArgsForCall(0)
+			Expect(resourceName).To(Equal("durian"))
+			Expect(channelID).To(Equal("channel-id"))
+			Expect(signedData).To(ConsistOf(&common.SignedData{
+				Data:      signedAuditCommand.Command,
+				Identity:  []byte("creator"),
+				Signature: []byte("signature"),
+			}))
+		},
+		Entry("lineage", &token.Command{Payload: &token.Command_LineageRequest{LineageRequest: &token.LineageRequest{}}}),
+		Entry("owner history", &token.Command{Payload: &token.Command_OwnerHistoryRequest{OwnerHistoryRequest: &token.OwnerHistoryRequest{}}}),
+		Entry("balances", &token.Command{Payload: &token.Command_BalanceRequest{BalanceRequest: &token.BalanceRequest{}}}),
+	)
+
 	Context("when the policy checker returns an error", func() {
 		BeforeEach(func() {
 			fakeACLProvider.CheckACLReturns(errors.New("wild-banana"))
@@ -25,3 +25,12 @@ func (*PeerLedgerManager) GetLedgerReader(channel string) (ledger.LedgerReader,
 
 	return l.NewQueryExecutor()
 }
+
+func (*PeerLedgerManager) GetBlockReader(channel string) (ledger.BlockReader, error) {
+	l := peer.Default.GetLedger(channel)
+	if l == nil {
+		return nil, errors.Errorf("ledger not found for channel %s", channel)
+	}
+
+	return l, nil
+}
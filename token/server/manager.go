@@ -9,28 +9,83 @@ package server
 import (
 	"github.com/hyperledger/fabric/token/ledger"
 	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/hyperledger/fabric/token/tms/zkat"
 	"github.com/pkg/errors"
 )
 
+const (
+	// PlainDriver is the name of the driver whose outputs are in the clear.
+	PlainDriver = "plain"
+	// ZkatDriver is the name of the driver whose outputs hide quantities and owners.
+	ZkatDriver = "zkat"
+)
+
 // Manager implements  token/server/TMSManager interface
 // TODO: it will be updated after lscc-baased tms configuration is available
 type Manager struct {
 	LedgerManager ledger.LedgerManager
+	// Driver is the name of the driver issuers and transactors are created for.
+	// It defaults to PlainDriver.
+	Driver string
 }
 
-// For now it returns a plain issuer.
+// For now it returns an issuer of the configured driver.
 // After lscc-based tms configuration is available, it will be updated
 // to return an issuer configured for the specific channel
 func (manager *Manager) GetIssuer(channel string, privateCredential, publicCredential []byte) (Issuer, error) {
-	return &plain.Issuer{}, nil
+	switch manager.driver() {
+	case PlainDriver:
+		return &plain.Issuer{}, nil
+	case ZkatDriver:
+		return &zkat.Issuer{PublicParams: zkat.DefaultPublicParams()}, nil
+	default:
+		return nil, errors.Errorf("unknown token driver: %s", manager.Driver)
+	}
 }
 
 // GetTransactor returns a Transactor bound to the passed channel and whose credential
 // is the tuple (privateCredential, publicCredential).
+// The zkat transactor takes no credential, as the owners spend their tokens on the client.
 func (manager *Manager) GetTransactor(channel string, privateCredential, publicCredential []byte) (Transactor, error) {
+	driver := manager.driver()
+	if driver != PlainDriver && driver != ZkatDriver {
+		return nil, errors.Errorf("unknown token driver: %s", driver)
+	}
+
 	ledger, err := manager.LedgerManager.GetLedgerReader(channel)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed getting ledger for channel: %s", channel)
 	}
+
+	if driver == ZkatDriver {
+		return &zkat.Transactor{Ledger: ledger}, nil
+	}
 	return &plain.Transactor{Ledger: ledger, PublicCredential: publicCredential}, nil
 }
+
+// GetAuditor returns an Auditor bound to the passed channel.
+// Only the plain driver supports auditing, since zkat outputs hide quantities and owners.
+func (manager *Manager) GetAuditor(channel string, privateCredential, publicCredential []byte) (Auditor, error) {
+	driver := manager.driver()
+	if driver != PlainDriver {
+		return nil, errors.Errorf("auditing is not supported by token driver: %s", driver)
+	}
+
+	blocks, err := manager.LedgerManager.GetBlockReader(channel)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting ledger for channel: %s", channel)
+	}
+	ledger, err := manager.LedgerManager.GetLedgerReader(channel)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting ledger for channel: %s", channel)
+	}
+
+	return &plain.Auditor{Ledger: ledger, Blocks: blocks}, nil
+}
+
+func (manager *Manager) driver() string {
+	if manager == nil || manager.Driver == "" {
+		return PlainDriver
+	}
+	return manager.Driver
+}
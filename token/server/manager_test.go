@@ -12,6 +12,7 @@ import (
 	"github.com/hyperledger/fabric/token/ledger/mock"
 	"github.com/hyperledger/fabric/token/server"
 	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/hyperledger/fabric/token/tms/zkat"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -24,6 +25,20 @@ var _ = Describe("Manager", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(issuer).To(Equal(&plain.Issuer{}))
 		})
+
+		It("returns a zkat issuer", func() {
+			Manager := &server.Manager{Driver: server.ZkatDriver}
+			issuer, err := Manager.GetIssuer("test-channel", []byte("private-credential"), []byte("public-credential"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issuer).To(Equal(&zkat.Issuer{PublicParams: zkat.DefaultPublicParams()}))
+		})
+
+		It("returns an error for an unknown driver", func() {
+			Manager := &server.Manager{Driver: "banana"}
+			issuer, err := Manager.GetIssuer("test-channel", []byte("private-credential"), []byte("public-credential"))
+			Expect(err).To(MatchError("unknown token driver: banana"))
+			Expect(issuer).To(BeNil())
+		})
 	})
 
 	Describe("GetTransactor", func() {
@@ -52,5 +67,71 @@ var _ = Describe("Manager", func() {
 			Expect(err.Error()).To(Equal("failed getting ledger for channel: test-channel: banana ledger"))
 			Expect(transactor).To(BeNil())
 		})
+
+		It("returns a zkat transactor that takes no credential", func() {
+			manager := &server.Manager{LedgerManager: fakeLedgerManager, Driver: server.ZkatDriver}
+			fakeLedgerManager.GetLedgerReaderReturns(fakeLedgerReader, nil)
+			transactor, err := manager.GetTransactor("test-channel", []byte("private-credential"), []byte("public-credential"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactor).To(Equal(&zkat.Transactor{Ledger: fakeLedgerReader}))
+		})
+
+		It("returns an error for an unknown driver", func() {
+			manager := &server.Manager{LedgerManager: fakeLedgerManager, Driver: "banana"}
+			transactor, err := manager.GetTransactor("test-channel", []byte("private-credential"), []byte("public-credential"))
+			Expect(err).To(MatchError("unknown token driver: banana"))
+			Expect(transactor).To(BeNil())
+			Expect(fakeLedgerManager.GetLedgerReaderCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("GetAuditor", func() {
+		var (
+			fakeLedgerReader  *mock.LedgerReader
+			fakeBlockReader   *mock.BlockReader
+			fakeLedgerManager *mock.LedgerManager
+		)
+
+		BeforeEach(func() {
+			fakeLedgerReader = &mock.LedgerReader{}
+			fakeBlockReader = &mock.BlockReader{}
+			fakeLedgerManager = &mock.LedgerManager{}
+			fakeLedgerManager.GetLedgerReaderReturns(fakeLedgerReader, nil)
+			fakeLedgerManager.GetBlockReaderReturns(fakeBlockReader, nil)
+		})
+
+		It("returns a plain auditor", func() {
+			manager := &server.Manager{LedgerManager: fakeLedgerManager}
+			auditor, err := manager.GetAuditor("test-channel", []byte("private-credential"), []byte("public-credential"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(auditor).To(Equal(&plain.Auditor{Ledger: fakeLedgerReader, Blocks: fakeBlockReader}))
+			Expect(fakeLedgerManager.GetBlockReaderArgsForCall(0)).To(Equal("test-channel"))
+			Expect(fakeLedgerManager.GetLedgerReaderArgsForCall(0)).To(Equal("test-channel"))
+		})
+
+		It("returns an error when the block reader cannot be retrieved", func() {
+			manager := &server.Manager{LedgerManager: fakeLedgerManager}
+			fakeLedgerManager.GetBlockReaderReturns(nil, errors.New("banana ledger"))
+			auditor, err := manager.GetAuditor("test-channel", []byte("private-credential"), []byte("public-credential"))
+			Expect(err).To(MatchError("failed getting ledger for channel: test-channel: banana ledger"))
+			Expect(auditor).To(BeNil())
+			Expect(fakeLedgerManager.GetLedgerReaderCallCount()).To(Equal(0))
+		})
+
+		It("returns an error when the ledger reader cannot be retrieved", func() {
+			manager := &server.Manager{LedgerManager: fakeLedgerManager}
+			fakeLedgerManager.GetLedgerReaderReturns(nil, errors.New("banana ledger"))
+			auditor, err := manager.GetAuditor("test-channel", []byte("private-credential"), []byte("public-credential"))
+			Expect(err).To(MatchError("failed getting ledger for channel: test-channel: banana ledger"))
+			Expect(auditor).To(BeNil())
+		})
+
+		It("returns an error for the zkat driver", func() {
+			manager := &server.Manager{LedgerManager: fakeLedgerManager, Driver: server.ZkatDriver}
+			auditor, err := manager.GetAuditor("test-channel", []byte("private-credential"), []byte("public-credential"))
+			Expect(err).To(MatchError("auditing is not supported by token driver: zkat"))
+			Expect(auditor).To(BeNil())
+			Expect(fakeLedgerManager.GetLedgerReaderCallCount()).To(Equal(0))
+		})
 	})
 })
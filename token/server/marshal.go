@@ -94,6 +94,12 @@ func commandResponseFromPayload(payload interface{}) (*token.CommandResponse, er
 		return &token.CommandResponse{Payload: t}, nil
 	case *token.CommandResponse_UnspentTokens:
 		return &token.CommandResponse{Payload: t}, nil
+	case *token.CommandResponse_TokenSupplies:
+		return &token.CommandResponse{Payload: t}, nil
+	case *token.CommandResponse_TokenHistory:
+		return &token.CommandResponse{Payload: t}, nil
+	case *token.CommandResponse_TokenBalances:
+		return &token.CommandResponse{Payload: t}, nil
 	default:
 		return nil, errors.Errorf("command type not recognized: %T", t)
 	}
@@ -13,6 +13,31 @@ type Transactor struct {
 	doneMutex       sync.RWMutex
 	doneArgsForCall []struct {
 	}
+	GetSupplyStub        func(*token.SupplyRequest) (*token.TokenSupplies, error)
+	getSupplyMutex       sync.RWMutex
+	getSupplyArgsForCall []struct {
+		arg1 *token.SupplyRequest
+	}
+	getSupplyReturns struct {
+		result1 *token.TokenSupplies
+		result2 error
+	}
+	getSupplyReturnsOnCall map[int]struct {
+		result1 *token.TokenSupplies
+		result2 error
+	}
+	ListDelegatedTokensStub        func() (*token.UnspentTokens, error)
+	listDelegatedTokensMutex       sync.RWMutex
+	listDelegatedTokensArgsForCall []struct {
+	}
+	listDelegatedTokensReturns struct {
+		result1 *token.UnspentTokens
+		result2 error
+	}
+	listDelegatedTokensReturnsOnCall map[int]struct {
+		result1 *token.UnspentTokens
+		result2 error
+	}
 	ListTokensStub        func() (*token.UnspentTokens, error)
 	listTokensMutex       sync.RWMutex
 	listTokensArgsForCall []struct {
@@ -117,6 +142,124 @@ func (fake *Transactor) DoneCalls(stub func()) {
 	fake.DoneStub = stub
 }
 
+func (fake *Transactor) GetSupply(arg1 *token.SupplyRequest) (*token.TokenSupplies, error) {
+	fake.getSupplyMutex.Lock()
+	ret, specificReturn := fake.getSupplyReturnsOnCall[len(fake.getSupplyArgsForCall)]
+	fake.getSupplyArgsForCall = append(fake.getSupplyArgsForCall, struct {
+		arg1 *token.SupplyRequest
+	}{arg1})
+	fake.recordInvocation("GetSupply", []interface{}{arg1})
+	fake.getSupplyMutex.Unlock()
+	if fake.GetSupplyStub != nil {
+		return fake.GetSupplyStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getSupplyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Transactor) GetSupplyCallCount() int {
+	fake.getSupplyMutex.RLock()
+	defer fake.getSupplyMutex.RUnlock()
+	return len(fake.getSupplyArgsForCall)
+}
+
+func (fake *Transactor) GetSupplyCalls(stub func(*token.SupplyRequest) (*token.TokenSupplies, error)) {
+	fake.getSupplyMutex.Lock()
+	defer fake.getSupplyMutex.Unlock()
+	fake.GetSupplyStub = stub
+}
+
+func (fake *Transactor) GetSupplyArgsForCall(i int) *token.SupplyRequest {
+	fake.getSupplyMutex.RLock()
+	defer fake.getSupplyMutex.RUnlock()
+	argsForCall := fake.getSupplyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Transactor) GetSupplyReturns(result1 *token.TokenSupplies, result2 error) {
+	fake.getSupplyMutex.Lock()
+	defer fake.getSupplyMutex.Unlock()
+	fake.GetSupplyStub = nil
+	fake.getSupplyReturns = struct {
+		result1 *token.TokenSupplies
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Transactor) GetSupplyReturnsOnCall(i int, result1 *token.TokenSupplies, result2 error) {
+	fake.getSupplyMutex.Lock()
+	defer fake.getSupplyMutex.Unlock()
+	fake.GetSupplyStub = nil
+	if fake.getSupplyReturnsOnCall == nil {
+		fake.getSupplyReturnsOnCall = make(map[int]struct {
+			result1 *token.TokenSupplies
+			result2 error
+		})
+	}
+	fake.getSupplyReturnsOnCall[i] = struct {
+		result1 *token.TokenSupplies
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Transactor) ListDelegatedTokens() (*token.UnspentTokens, error) {
+	fake.listDelegatedTokensMutex.Lock()
+	ret, specificReturn := fake.listDelegatedTokensReturnsOnCall[len(fake.listDelegatedTokensArgsForCall)]
+	fake.listDelegatedTokensArgsForCall = append(fake.listDelegatedTokensArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListDelegatedTokens", []interface{}{})
+	fake.listDelegatedTokensMutex.Unlock()
+	if fake.ListDelegatedTokensStub != nil {
+		return fake.ListDelegatedTokensStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listDelegatedTokensReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Transactor) ListDelegatedTokensCallCount() int {
+	fake.listDelegatedTokensMutex.RLock()
+	defer fake.listDelegatedTokensMutex.RUnlock()
+	return len(fake.listDelegatedTokensArgsForCall)
+}
+
+func (fake *Transactor) ListDelegatedTokensCalls(stub func() (*token.UnspentTokens, error)) {
+	fake.listDelegatedTokensMutex.Lock()
+	defer fake.listDelegatedTokensMutex.Unlock()
+	fake.ListDelegatedTokensStub = stub
+}
+
+func (fake *Transactor) ListDelegatedTokensReturns(result1 *token.UnspentTokens, result2 error) {
+	fake.listDelegatedTokensMutex.Lock()
+	defer fake.listDelegatedTokensMutex.Unlock()
+	fake.ListDelegatedTokensStub = nil
+	fake.listDelegatedTokensReturns = struct {
+		result1 *token.UnspentTokens
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Transactor) ListDelegatedTokensReturnsOnCall(i int, result1 *token.UnspentTokens, result2 error) {
+	fake.listDelegatedTokensMutex.Lock()
+	defer fake.listDelegatedTokensMutex.Unlock()
+	fake.ListDelegatedTokensStub = nil
+	if fake.listDelegatedTokensReturnsOnCall == nil {
+		fake.listDelegatedTokensReturnsOnCall = make(map[int]struct {
+			result1 *token.UnspentTokens
+			result2 error
+		})
+	}
+	fake.listDelegatedTokensReturnsOnCall[i] = struct {
+		result1 *token.UnspentTokens
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Transactor) ListTokens() (*token.UnspentTokens, error) {
 	fake.listTokensMutex.Lock()
 	ret, specificReturn := fake.listTokensReturnsOnCall[len(fake.listTokensArgsForCall)]
@@ -492,6 +635,10 @@ func (fake *Transactor) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.doneMutex.RLock()
 	defer fake.doneMutex.RUnlock()
+	fake.getSupplyMutex.RLock()
+	defer fake.getSupplyMutex.RUnlock()
+	fake.listDelegatedTokensMutex.RLock()
+	defer fake.listDelegatedTokensMutex.RUnlock()
 	fake.listTokensMutex.RLock()
 	defer fake.listTokensMutex.RUnlock()
 	fake.requestApproveMutex.RLock()
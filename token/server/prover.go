@@ -94,6 +94,14 @@ func (s *Prover) ProcessCommand(ctx context.Context, sc *token.SignedCommand) (*
 		payload, err = s.RequestTransferFrom(ctx, command.Header, t.TransferFromRequest)
 	case *token.Command_ExpectationRequest:
 		payload, err = s.RequestExpectation(ctx, command.Header, t.ExpectationRequest)
+	case *token.Command_SupplyRequest:
+		payload, err = s.GetSupply(ctx, command.Header, t.SupplyRequest)
+	case *token.Command_LineageRequest:
+		payload, err = s.GetLineage(ctx, command.Header, t.LineageRequest)
+	case *token.Command_OwnerHistoryRequest:
+		payload, err = s.GetOwnerHistory(ctx, command.Header, t.OwnerHistoryRequest)
+	case *token.Command_BalanceRequest:
+		payload, err = s.GetBalances(ctx, command.Header, t.BalanceRequest)
 	default:
 		err = errors.Errorf("command type not recognized: %T", t)
 	}
@@ -158,7 +166,12 @@ func (s *Prover) ListUnspentTokens(ctxt context.Context, header *token.Header, l
 	}
 	defer transactor.Done()
 
-	tokens, err := transactor.ListTokens()
+	var tokens *token.UnspentTokens
+	if listRequest.Delegated {
+		tokens, err = transactor.ListDelegatedTokens()
+	} else {
+		tokens, err = transactor.ListTokens()
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -166,6 +179,71 @@ func (s *Prover) ListUnspentTokens(ctxt context.Context, header *token.Header, l
 	return &token.CommandResponse_UnspentTokens{UnspentTokens: tokens}, nil
 }
 
+// GetSupply returns the outstanding supply of the token types in the request.
+func (s *Prover) GetSupply(ctx context.Context, header *token.Header, request *token.SupplyRequest) (*token.CommandResponse_TokenSupplies, error) {
+	transactor, err := s.TMSManager.GetTransactor(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+	defer transactor.Done()
+
+	supplies, err := transactor.GetSupply(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_TokenSupplies{TokenSupplies: supplies}, nil
+}
+
+// GetLineage returns the spend history of the token output in the request.
+func (s *Prover) GetLineage(ctx context.Context, header *token.Header, request *token.LineageRequest) (*token.CommandResponse_TokenHistory, error) {
+	auditor, err := s.TMSManager.GetAuditor(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+	defer auditor.Done()
+
+	history, err := auditor.Lineage(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_TokenHistory{TokenHistory: history}, nil
+}
+
+// GetOwnerHistory returns the token transactions involving the owner in the request
+// over its block range.
+func (s *Prover) GetOwnerHistory(ctx context.Context, header *token.Header, request *token.OwnerHistoryRequest) (*token.CommandResponse_TokenHistory, error) {
+	auditor, err := s.TMSManager.GetAuditor(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+	defer auditor.Done()
+
+	history, err := auditor.OwnerHistory(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_TokenHistory{TokenHistory: history}, nil
+}
+
+// GetBalances returns the unspent quantity of each token type.
+func (s *Prover) GetBalances(ctx context.Context, header *token.Header, request *token.BalanceRequest) (*token.CommandResponse_TokenBalances, error) {
+	auditor, err := s.TMSManager.GetAuditor(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+	defer auditor.Done()
+
+	balances, err := auditor.Balances(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_TokenBalances{TokenBalances: balances}, nil
+}
+
 func (s *Prover) RequestApprove(ctx context.Context, header *token.Header, request *token.ApproveRequest) (*token.CommandResponse_TokenTransaction, error) {
 	transactor, err := s.TMSManager.GetTransactor(header.ChannelId, request.Credential, header.Creator)
 	if err != nil {
@@ -38,6 +38,7 @@ var _ = Describe("Prover", func() {
 		fakeIssuer            *mock.Issuer
 		fakeTransactor        *mock.Transactor
 		fakeTMSManager        *mock.TMSManager
+		fakeAuditor           *mock.Auditor
 
 		prover *server.Prover
 
@@ -108,6 +109,7 @@ var _ = Describe("Prover", func() {
 			},
 		}
 		fakeTransactor = &mock.Transactor{}
+		fakeAuditor = &mock.Auditor{}
 		fakeTransactor.RequestTransferReturns(trTokenTransaction, nil)
 
 		redeemTokenTransaction = &token.TokenTransaction{
@@ -141,6 +143,7 @@ var _ = Describe("Prover", func() {
 		fakeTMSManager = &mock.TMSManager{}
 		fakeTMSManager.GetIssuerReturns(fakeIssuer, nil)
 		fakeTMSManager.GetTransactorReturns(fakeTransactor, nil)
+		fakeTMSManager.GetAuditorReturns(fakeAuditor, nil)
 
 		marshaledResponse = &token.SignedCommandResponse{Response: []byte("signed-command-response")}
 		fakeMarshaler = &mock.Marshaler{}
@@ -998,6 +1001,25 @@ var _ = Describe("Prover", func() {
 			}))
 
 			Expect(fakeTransactor.ListTokensCallCount()).To(Equal(1))
+			Expect(fakeTransactor.ListDelegatedTokensCallCount()).To(Equal(0))
+		})
+
+		Context("when the delegated outputs are requested", func() {
+			BeforeEach(func() {
+				listRequest.Delegated = true
+				fakeTransactor.ListDelegatedTokensReturns(unspentTokens, nil)
+			})
+
+			It("uses the transactor to list the delegated outputs", func() {
+				resp, err := prover.ListUnspentTokens(context.Background(), command.Header, listRequest)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(&token.CommandResponse_UnspentTokens{
+					UnspentTokens: unspentTokens,
+				}))
+
+				Expect(fakeTransactor.ListDelegatedTokensCallCount()).To(Equal(1))
+				Expect(fakeTransactor.ListTokensCallCount()).To(Equal(0))
+			})
 		})
 
 		Context("when the TMS manager fails to get a transactor", func() {
@@ -1023,6 +1045,225 @@ var _ = Describe("Prover", func() {
 		})
 	})
 
+	Describe("GetSupply", func() {
+		var (
+			supplyRequest *token.SupplyRequest
+			supplies      *token.TokenSupplies
+		)
+
+		BeforeEach(func() {
+			supplyRequest = &token.SupplyRequest{Credential: []byte("credential"), Types: []string{"typeaz"}}
+			supplies = &token.TokenSupplies{Supplies: []*token.TokenSupply{{Type: "typeaz", Quantity: 135}}}
+			fakeTransactor.GetSupplyReturns(supplies, nil)
+		})
+
+		It("uses a transactor to get the supply", func() {
+			resp, err := prover.GetSupply(context.Background(), command.Header, supplyRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_TokenSupplies{TokenSupplies: supplies}))
+
+			Expect(fakeTMSManager.GetTransactorCallCount()).To(Equal(1))
+			channel, cred, creator := fakeTMSManager.GetTransactorArgsForCall(0)
+			Expect(channel).To(Equal("channel-id"))
+			Expect(cred).To(Equal([]byte("credential")))
+			Expect(creator).To(Equal([]byte("creator")))
+			Expect(fakeTransactor.GetSupplyCallCount()).To(Equal(1))
+			Expect(fakeTransactor.GetSupplyArgsForCall(0)).To(Equal(supplyRequest))
+			Expect(fakeTransactor.DoneCallCount()).To(Equal(1))
+		})
+
+		It("is dispatched by ProcessCommand", func() {
+			command.Payload = &token.Command_SupplyRequest{SupplyRequest: supplyRequest}
+			signedCommand.Command = ProtoMarshal(command)
+
+			_, err := prover.ProcessCommand(context.Background(), signedCommand)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeMarshaler.MarshalCommandResponseCallCount()).To(Equal(1))
+			_, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+			Expect(payload).To(Equal(&token.CommandResponse_TokenSupplies{TokenSupplies: supplies}))
+		})
+
+		Context("when the TMS manager fails to get a transactor", func() {
+			BeforeEach(func() {
+				fakeTMSManager.GetTransactorReturns(nil, errors.New("pineapple"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.GetSupply(context.Background(), command.Header, supplyRequest)
+				Expect(err).To(MatchError("pineapple"))
+			})
+		})
+
+		Context("when the transactor fails to get the supply", func() {
+			BeforeEach(func() {
+				fakeTransactor.GetSupplyReturns(nil, errors.New("pineapple"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.GetSupply(context.Background(), command.Header, supplyRequest)
+				Expect(err).To(MatchError("pineapple"))
+			})
+		})
+	})
+
+	Describe("GetLineage", func() {
+		var (
+			lineageRequest *token.LineageRequest
+			history        *token.TokenHistory
+		)
+
+		BeforeEach(func() {
+			lineageRequest = &token.LineageRequest{Credential: []byte("credential"), TokenId: []byte("token-id")}
+			history = &token.TokenHistory{Transactions: []*token.TokenTransactionRecord{{TxId: "tx1", BlockNumber: 3}}}
+			fakeAuditor.LineageReturns(history, nil)
+		})
+
+		It("uses an auditor to get the lineage", func() {
+			resp, err := prover.GetLineage(context.Background(), command.Header, lineageRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_TokenHistory{TokenHistory: history}))
+
+			Expect(fakeTMSManager.GetAuditorCallCount()).To(Equal(1))
+			channel, cred, creator := fakeTMSManager.GetAuditorArgsForCall(0)
+			Expect(channel).To(Equal("channel-id"))
+			Expect(cred).To(Equal([]byte("credential")))
+			Expect(creator).To(Equal([]byte("creator")))
+			Expect(fakeAuditor.LineageCallCount()).To(Equal(1))
+			Expect(fakeAuditor.LineageArgsForCall(0)).To(Equal(lineageRequest))
+			Expect(fakeAuditor.DoneCallCount()).To(Equal(1))
+		})
+
+		It("is dispatched by ProcessCommand", func() {
+			command.Payload = &token.Command_LineageRequest{LineageRequest: lineageRequest}
+			signedCommand.Command = ProtoMarshal(command)
+
+			_, err := prover.ProcessCommand(context.Background(), signedCommand)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeMarshaler.MarshalCommandResponseCallCount()).To(Equal(1))
+			_, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+			Expect(payload).To(Equal(&token.CommandResponse_TokenHistory{TokenHistory: history}))
+		})
+
+		Context("when the TMS manager fails to get an auditor", func() {
+			BeforeEach(func() {
+				fakeTMSManager.GetAuditorReturns(nil, errors.New("pineapple"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.GetLineage(context.Background(), command.Header, lineageRequest)
+				Expect(err).To(MatchError("pineapple"))
+			})
+		})
+
+		Context("when the auditor fails to get the lineage", func() {
+			BeforeEach(func() {
+				fakeAuditor.LineageReturns(nil, errors.New("pineapple"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.GetLineage(context.Background(), command.Header, lineageRequest)
+				Expect(err).To(MatchError("pineapple"))
+				Expect(fakeAuditor.DoneCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("GetOwnerHistory", func() {
+		var (
+			historyRequest *token.OwnerHistoryRequest
+			history        *token.TokenHistory
+		)
+
+		BeforeEach(func() {
+			historyRequest = &token.OwnerHistoryRequest{Credential: []byte("credential"), Owner: []byte("alice"), StartBlock: 2, EndBlock: 5}
+			history = &token.TokenHistory{Transactions: []*token.TokenTransactionRecord{{TxId: "tx1", BlockNumber: 3}}}
+			fakeAuditor.OwnerHistoryReturns(history, nil)
+		})
+
+		It("uses an auditor to get the history of the owner", func() {
+			resp, err := prover.GetOwnerHistory(context.Background(), command.Header, historyRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_TokenHistory{TokenHistory: history}))
+
+			Expect(fakeTMSManager.GetAuditorCallCount()).To(Equal(1))
+			Expect(fakeAuditor.OwnerHistoryCallCount()).To(Equal(1))
+			Expect(fakeAuditor.OwnerHistoryArgsForCall(0)).To(Equal(historyRequest))
+			Expect(fakeAuditor.DoneCallCount()).To(Equal(1))
+		})
+
+		It("is dispatched by ProcessCommand", func() {
+			command.Payload = &token.Command_OwnerHistoryRequest{OwnerHistoryRequest: historyRequest}
+			signedCommand.Command = ProtoMarshal(command)
+
+			_, err := prover.ProcessCommand(context.Background(), signedCommand)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeMarshaler.MarshalCommandResponseCallCount()).To(Equal(1))
+			_, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+			Expect(payload).To(Equal(&token.CommandResponse_TokenHistory{TokenHistory: history}))
+		})
+
+		Context("when the auditor fails to get the history", func() {
+			BeforeEach(func() {
+				fakeAuditor.OwnerHistoryReturns(nil, errors.New("pineapple"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.GetOwnerHistory(context.Background(), command.Header, historyRequest)
+				Expect(err).To(MatchError("pineapple"))
+			})
+		})
+	})
+
+	Describe("GetBalances", func() {
+		var (
+			balanceRequest *token.BalanceRequest
+			balances       *token.TokenBalances
+		)
+
+		BeforeEach(func() {
+			balanceRequest = &token.BalanceRequest{Credential: []byte("credential")}
+			balances = &token.TokenBalances{Balances: []*token.TokenBalance{{Type: "typeaz", Quantity: 135, Outputs: 2}}}
+			fakeAuditor.BalancesReturns(balances, nil)
+		})
+
+		It("uses an auditor to get the balances", func() {
+			resp, err := prover.GetBalances(context.Background(), command.Header, balanceRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_TokenBalances{TokenBalances: balances}))
+
+			Expect(fakeTMSManager.GetAuditorCallCount()).To(Equal(1))
+			Expect(fakeAuditor.BalancesCallCount()).To(Equal(1))
+			Expect(fakeAuditor.BalancesArgsForCall(0)).To(Equal(balanceRequest))
+			Expect(fakeAuditor.DoneCallCount()).To(Equal(1))
+		})
+
+		It("is dispatched by ProcessCommand", func() {
+			command.Payload = &token.Command_BalanceRequest{BalanceRequest: balanceRequest}
+			signedCommand.Command = ProtoMarshal(command)
+
+			_, err := prover.ProcessCommand(context.Background(), signedCommand)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeMarshaler.MarshalCommandResponseCallCount()).To(Equal(1))
+			_, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+			Expect(payload).To(Equal(&token.CommandResponse_TokenBalances{TokenBalances: balances}))
+		})
+
+		Context("when the auditor fails to get the balances", func() {
+			BeforeEach(func() {
+				fakeAuditor.BalancesReturns(nil, errors.New("pineapple"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.GetBalances(context.Background(), command.Header, balanceRequest)
+				Expect(err).To(MatchError("pineapple"))
+			})
+		})
+	})
+
 	Describe("ProcessCommand_RequestExpection for import", func() {
 		BeforeEach(func() {
 			command = &token.Command{
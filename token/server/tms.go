@@ -39,6 +39,10 @@ type Transactor interface {
 	// ListTokens returns a slice of unspent tokens owned by this transactor
 	ListTokens() (*token.UnspentTokens, error)
 
+	// ListDelegatedTokens returns a slice of the unspent delegated outputs
+	// that this transactor is a delegatee of
+	ListDelegatedTokens() (*token.UnspentTokens, error)
+
 	// RequestApprove creates a token transaction that includes the data necessary
 	// for approve
 	RequestApprove(request *token.ApproveRequest) (*token.TokenTransaction, error)
@@ -52,10 +56,33 @@ type Transactor interface {
 	// It creates a token transaction with the outputs as specified in the expectation.
 	RequestExpectation(request *token.ExpectationRequest) (*token.TokenTransaction, error)
 
+	// GetSupply returns the outstanding supply of the token types in the request,
+	// or of all token types if the request has none.
+	GetSupply(request *token.SupplyRequest) (*token.TokenSupplies, error)
+
 	// Done releases any resources held by this transactor
 	Done()
 }
 
+//go:generate counterfeiter -o mock/auditor.go -fake-name Auditor . Auditor
+
+// An Auditor answers queries on the history and the balances of the tokens of a channel
+type Auditor interface {
+	// Lineage returns the spend history of the token output identified by the request
+	Lineage(request *token.LineageRequest) (*token.TokenHistory, error)
+
+	// OwnerHistory returns the token transactions involving the owner of the request
+	// that were committed in the block range of the request
+	OwnerHistory(request *token.OwnerHistoryRequest) (*token.TokenHistory, error)
+
+	// Balances returns the unspent quantity of each token type, optionally restricted
+	// to the tokens of the owner of the request
+	Balances(request *token.BalanceRequest) (*token.TokenBalances, error)
+
+	// Done releases any resources held by this auditor
+	Done()
+}
+
 //go:generate counterfeiter -o mock/tms_manager.go -fake-name TMSManager . TMSManager
 
 type TMSManager interface {
@@ -66,4 +93,8 @@ type TMSManager interface {
 	// GetTransactor returns a Transactor bound to the passed channel and whose credential
 	// is the tuple (privateCredential, publicCredential).
 	GetTransactor(channel string, privateCredential, publicCredential []byte) (Transactor, error)
+
+	// GetAuditor returns an Auditor bound to the passed channel and whose credential
+	// is the tuple (privateCredential, publicCredential).
+	GetAuditor(channel string, privateCredential, publicCredential []byte) (Auditor, error)
 }
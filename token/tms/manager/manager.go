@@ -7,9 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package manager
 
 import (
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/token/identity"
 	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/hyperledger/fabric/token/tms/zkat"
 	"github.com/hyperledger/fabric/token/transaction"
 	"github.com/pkg/errors"
 )
@@ -29,17 +31,56 @@ func (*FabricIdentityDeserializerManager) Deserializer(channel string) (identity
 	return id, nil
 }
 
+// ChannelConfigSupplyPolicyManager implements a SupplyPolicyManager
+// by reading the token supply caps from the application config of the channels
+type ChannelConfigSupplyPolicyManager struct {
+	// ChannelConfig returns the config resources of the passed channel, nil if the channel does not exist
+	ChannelConfig func(channel string) channelconfig.Resources
+}
+
+func (m *ChannelConfigSupplyPolicyManager) SupplyPolicy(channel string) (identity.SupplyPolicy, error) {
+	resources := m.ChannelConfig(channel)
+	if resources == nil {
+		return nil, errors.New("channel not found")
+	}
+	ac, ok := resources.ApplicationConfig()
+	if !ok {
+		return &CappedSupplyPolicy{}, nil
+	}
+	return &CappedSupplyPolicy{MaxSupplies: ac.TokenSupplyCaps()}, nil
+}
+
 // Manager is used to access TMS components.
 type Manager struct {
 	IdentityDeserializerManager identity.DeserializerManager
+	// SupplyPolicyManager returns the policy capping the supply of the token types of a channel.
+	// If nil, the supply of token types is not capped.
+	SupplyPolicyManager identity.SupplyPolicyManager
 }
 
-// GetTxProcessor returns a TMSTxProcessor that is used to process token transactions.
+// GetTxProcessor returns a TMSTxProcessor that is used to process token transactions
+// of both the plain and the zkat drivers.
 func (m *Manager) GetTxProcessor(channel string) (transaction.TMSTxProcessor, error) {
 	identityDeserializerManager, err := m.IdentityDeserializerManager.Deserializer(channel)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed getting identity deserialiser manager for channel '%s'", channel)
 	}
 
-	return &plain.Verifier{IssuingValidator: &AllIssuingValidator{Deserializer: identityDeserializerManager}}, nil
+	var supplyPolicy identity.SupplyPolicy
+	if m.SupplyPolicyManager != nil {
+		supplyPolicy, err = m.SupplyPolicyManager.SupplyPolicy(channel)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed getting supply policy for channel '%s'", channel)
+		}
+	}
+
+	issuingValidator := &AllIssuingValidator{Deserializer: identityDeserializerManager}
+	return &TxProcessor{
+		Plain: &plain.Verifier{
+			IssuingValidator: issuingValidator,
+			Deserializer:     identityDeserializerManager,
+			SupplyPolicy:     supplyPolicy,
+		},
+		Zkat: &zkat.Verifier{IssuingValidator: issuingValidator, PublicParams: zkat.DefaultPublicParams()},
+	}, nil
 }
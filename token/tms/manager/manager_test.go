@@ -7,9 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package manager_test
 
 import (
+	"github.com/hyperledger/fabric/common/channelconfig"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/token/identity/mock"
 	"github.com/hyperledger/fabric/token/tms/manager"
 	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/hyperledger/fabric/token/tms/zkat"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
@@ -48,11 +51,36 @@ var _ = Describe("Manager", func() {
 		})
 
 		Describe("Get a TxProcessor for an existing channel", func() {
-			It("returns a Verifier that implements the TxProcessor interface", func() {
+			It("returns a TxProcessor that dispatches to the verifiers of the drivers", func() {
 				txProcessor, err := mgm.GetTxProcessor(channel)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(txProcessor).NotTo(BeNil())
-				Expect(txProcessor).To(Equal(&plain.Verifier{IssuingValidator: &manager.AllIssuingValidator{Deserializer: fakeIdentityDeserializer}}))
+				issuingValidator := &manager.AllIssuingValidator{Deserializer: fakeIdentityDeserializer}
+				Expect(txProcessor).To(Equal(&manager.TxProcessor{
+					Plain: &plain.Verifier{IssuingValidator: issuingValidator, Deserializer: fakeIdentityDeserializer},
+					Zkat:  &zkat.Verifier{IssuingValidator: issuingValidator, PublicParams: zkat.DefaultPublicParams()},
+				}))
+			})
+
+			It("caps the supply with the policy of the channel", func() {
+				supplyPolicy := &mock.SupplyPolicy{}
+				fakeSupplyPolicyManager := &mock.SupplyPolicyManager{}
+				fakeSupplyPolicyManager.SupplyPolicyReturns(supplyPolicy, nil)
+				mgm.SupplyPolicyManager = fakeSupplyPolicyManager
+
+				txProcessor, err := mgm.GetTxProcessor(channel)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(txProcessor.(*manager.TxProcessor).Plain.(*plain.Verifier).SupplyPolicy).To(BeIdenticalTo(supplyPolicy))
+				Expect(fakeSupplyPolicyManager.SupplyPolicyArgsForCall(0)).To(Equal(channel))
+			})
+
+			It("returns an error when the supply policy cannot be retrieved", func() {
+				fakeSupplyPolicyManager := &mock.SupplyPolicyManager{}
+				fakeSupplyPolicyManager.SupplyPolicyReturns(nil, errors.New("channel not found"))
+				mgm.SupplyPolicyManager = fakeSupplyPolicyManager
+
+				_, err := mgm.GetTxProcessor(channel)
+				Expect(err).To(MatchError("failed getting supply policy for channel 'ch0': channel not found"))
 			})
 		})
 	})
@@ -72,3 +100,42 @@ var _ = Describe("FabricIdentityDeserializerManager", func() {
 		})
 	})
 })
+
+var _ = Describe("ChannelConfigSupplyPolicyManager", func() {
+	var (
+		resources           *mockconfig.Resources
+		supplyPolicyManager *manager.ChannelConfigSupplyPolicyManager
+	)
+
+	BeforeEach(func() {
+		resources = &mockconfig.Resources{
+			ApplicationConfigVal: &mockconfig.MockApplication{SupplyCaps: map[string]uint64{"TOK1": 100}},
+		}
+		supplyPolicyManager = &manager.ChannelConfigSupplyPolicyManager{
+			ChannelConfig: func(channel string) channelconfig.Resources {
+				if channel != "ch0" {
+					return nil
+				}
+				return resources
+			},
+		}
+	})
+
+	It("returns a policy with the token supply caps of the channel", func() {
+		supplyPolicy, err := supplyPolicyManager.SupplyPolicy("ch0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(supplyPolicy).To(Equal(&manager.CappedSupplyPolicy{MaxSupplies: map[string]uint64{"TOK1": 100}}))
+	})
+
+	It("returns an uncapped policy when the channel has no application config", func() {
+		resources.ApplicationConfigVal = nil
+		supplyPolicy, err := supplyPolicyManager.SupplyPolicy("ch0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(supplyPolicy.MaxSupply("TOK1")).To(Equal(uint64(0)))
+	})
+
+	It("returns an error for a non-existent channel", func() {
+		_, err := supplyPolicyManager.SupplyPolicy("boguschannel")
+		Expect(err).To(MatchError("channel not found"))
+	})
+})
@@ -31,3 +31,15 @@ func (p *AllIssuingValidator) Validate(creator identity.PublicInfo, tokenType st
 
 	return nil
 }
+
+// CappedSupplyPolicy caps the outstanding supply of token types.
+type CappedSupplyPolicy struct {
+	// MaxSupplies maps token types to their maximum outstanding supply.
+	// The supply of types that are not in the map is not capped.
+	MaxSupplies map[string]uint64
+}
+
+// MaxSupply returns the maximum outstanding supply of tokens of the passed type, or 0 if the supply is not capped.
+func (p *CappedSupplyPolicy) MaxSupply(tokenType string) uint64 {
+	return p.MaxSupplies[tokenType]
+}
@@ -77,3 +77,11 @@ var _ = Describe("AllIssuingValidator", func() {
 
 	})
 })
+
+var _ = Describe("CappedSupplyPolicy", func() {
+	It("returns the maximum supply of capped types and 0 otherwise", func() {
+		supplyPolicy := &manager.CappedSupplyPolicy{MaxSupplies: map[string]uint64{"TOK1": 100}}
+		Expect(supplyPolicy.MaxSupply("TOK1")).To(Equal(uint64(100)))
+		Expect(supplyPolicy.MaxSupply("TOK2")).To(Equal(uint64(0)))
+	})
+})
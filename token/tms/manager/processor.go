@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package manager
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/hyperledger/fabric/token/ledger"
+	"github.com/hyperledger/fabric/token/transaction"
+)
+
+// TxProcessor routes token transactions to the TMSTxProcessor of the driver
+// that produced them, based on the type of their action.
+type TxProcessor struct {
+	Plain transaction.TMSTxProcessor
+	Zkat  transaction.TMSTxProcessor
+}
+
+// ProcessTx processes ttx with the driver of its action.
+func (p *TxProcessor) ProcessTx(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, simulator ledger.LedgerWriter) error {
+	driver, err := p.driver(txID, ttx)
+	if err != nil {
+		return err
+	}
+	return driver.ProcessTx(txID, creator, ttx, simulator)
+}
+
+// ProcessTxAt processes ttx, committed in the block of number blockNum, with the driver of its action;
+// drivers that do not take into account the block number of transactions ignore it.
+func (p *TxProcessor) ProcessTxAt(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, blockNum uint64, simulator ledger.LedgerWriter) error {
+	driver, err := p.driver(txID, ttx)
+	if err != nil {
+		return err
+	}
+	if timedDriver, ok := driver.(transaction.TimedTMSTxProcessor); ok {
+		return timedDriver.ProcessTxAt(txID, creator, ttx, blockNum, simulator)
+	}
+	return driver.ProcessTx(txID, creator, ttx, simulator)
+}
+
+func (p *TxProcessor) driver(txID string, ttx *token.TokenTransaction) (transaction.TMSTxProcessor, error) {
+	switch ttx.GetAction().(type) {
+	case *token.TokenTransaction_PlainAction:
+		return p.Plain, nil
+	case *token.TokenTransaction_ZkAction:
+		return p.Zkat, nil
+	default:
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("unknown token action in transaction '%s'", txID)}
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package manager_test
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	idmock "github.com/hyperledger/fabric/token/identity/mock"
+	"github.com/hyperledger/fabric/token/ledger/mock"
+	"github.com/hyperledger/fabric/token/tms/manager"
+	txmock "github.com/hyperledger/fabric/token/transaction/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("TxProcessor", func() {
+	var (
+		fakePlain      *txmock.TMSTxProcessor
+		fakeZkat       *txmock.TMSTxProcessor
+		fakePublicInfo *idmock.PublicInfo
+		fakeLedger     *mock.LedgerWriter
+		txProcessor    *manager.TxProcessor
+	)
+
+	BeforeEach(func() {
+		fakePlain = &txmock.TMSTxProcessor{}
+		fakeZkat = &txmock.TMSTxProcessor{}
+		fakePublicInfo = &idmock.PublicInfo{}
+		fakeLedger = &mock.LedgerWriter{}
+		txProcessor = &manager.TxProcessor{Plain: fakePlain, Zkat: fakeZkat}
+	})
+
+	It("routes plain transactions to the plain verifier", func() {
+		fakePlain.ProcessTxReturns(errors.New("plain"))
+		ttx := &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: &token.PlainTokenAction{}}}
+
+		err := txProcessor.ProcessTx("tx0", fakePublicInfo, ttx, fakeLedger)
+		Expect(err).To(MatchError("plain"))
+		Expect(fakePlain.ProcessTxCallCount()).To(Equal(1))
+		txID, creator, tx, simulator := fakePlain.ProcessTxArgsForCall(0)
+		Expect(txID).To(Equal("tx0"))
+		Expect(creator).To(Equal(fakePublicInfo))
+		Expect(tx).To(Equal(ttx))
+		Expect(simulator).To(Equal(fakeLedger))
+		Expect(fakeZkat.ProcessTxCallCount()).To(Equal(0))
+	})
+
+	It("routes zkat transactions to the zkat verifier", func() {
+		ttx := &token.TokenTransaction{Action: &token.TokenTransaction_ZkAction{ZkAction: &token.ZkTokenAction{}}}
+
+		err := txProcessor.ProcessTx("tx0", fakePublicInfo, ttx, fakeLedger)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeZkat.ProcessTxCallCount()).To(Equal(1))
+		Expect(fakePlain.ProcessTxCallCount()).To(Equal(0))
+	})
+
+	It("rejects transactions without an action", func() {
+		err := txProcessor.ProcessTx("tx0", fakePublicInfo, &token.TokenTransaction{}, fakeLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "unknown token action in transaction 'tx0'"}))
+	})
+
+	Describe("ProcessTxAt", func() {
+		const blockNum = uint64(10)
+
+		It("passes the block number to drivers that take it into account", func() {
+			fakeTimedPlain := &txmock.TimedTMSTxProcessor{}
+			txProcessor.Plain = fakeTimedPlain
+			ttx := &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: &token.PlainTokenAction{}}}
+
+			err := txProcessor.ProcessTxAt("tx0", fakePublicInfo, ttx, blockNum, fakeLedger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeTimedPlain.ProcessTxAtCallCount()).To(Equal(1))
+			txID, creator, tx, num, simulator := fakeTimedPlain.ProcessTxAtArgsForCall(0)
+			Expect(txID).To(Equal("tx0"))
+			Expect(creator).To(Equal(fakePublicInfo))
+			Expect(tx).To(Equal(ttx))
+			Expect(num).To(Equal(blockNum))
+			Expect(simulator).To(Equal(fakeLedger))
+			Expect(fakeTimedPlain.ProcessTxCallCount()).To(Equal(0))
+		})
+
+		It("ignores the block number for the other drivers", func() {
+			ttx := &token.TokenTransaction{Action: &token.TokenTransaction_ZkAction{ZkAction: &token.ZkTokenAction{}}}
+
+			err := txProcessor.ProcessTxAt("tx0", fakePublicInfo, ttx, blockNum, fakeLedger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeZkat.ProcessTxCallCount()).To(Equal(1))
+		})
+
+		It("rejects transactions without an action", func() {
+			err := txProcessor.ProcessTxAt("tx0", fakePublicInfo, &token.TokenTransaction{}, blockNum, fakeLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "unknown token action in transaction 'tx0'"}))
+		})
+	})
+})
@@ -0,0 +1,518 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/ledger"
+	"github.com/pkg/errors"
+)
+
+// spentNamespaces maps the namespace of each kind of spendable output
+// to the namespace of the marker recording that it has been spent.
+var spentNamespaces = map[string]string{
+	tokenOutput:          tokenInput,
+	tokenDelegatedOutput: tokenDelegatedInput,
+	tokenLockedOutput:    tokenLockedInput,
+}
+
+// An Auditor answers queries on the history and the balances of the tokens of a channel,
+// using the token namespace state and the blocks of the ledger.
+type Auditor struct {
+	Ledger ledger.LedgerReader
+	Blocks ledger.BlockReader
+}
+
+// Lineage returns the spend history of the output identified by the request: the transaction
+// that created it, the transactions that created its inputs, recursively back to the imports,
+// and the transaction that spent it, if any. The transactions are ordered by block number.
+func (a *Auditor) Lineage(request *token.LineageRequest) (*token.TokenHistory, error) {
+	outputKey := parseCompositeKeyBytes(request.GetTokenId())
+	namespace, components, err := splitCompositeKey(outputKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid token id '%x'", request.GetTokenId())
+	}
+	if _, ok := spentNamespaces[namespace]; !ok && namespace != tokenRedeem {
+		return nil, errors.Errorf("invalid token id '%x': '%s' is not an output namespace", request.GetTokenId(), namespace)
+	}
+	if len(components) != 2 {
+		return nil, errors.Errorf("invalid token id '%x': expected 2 components, received %d", request.GetTokenId(), len(components))
+	}
+	txID := components[0]
+	index, err := strconv.Atoi(components[1])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid output index in token id '%x'", request.GetTokenId())
+	}
+	outputBytes, err := a.Ledger.GetState(tokenNameSpace, outputKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(outputBytes) == 0 {
+		return nil, errors.Errorf("token '%x' does not exist", request.GetTokenId())
+	}
+
+	records := make([]*token.TokenTransactionRecord, 0)
+	spender, err := a.spender(namespace, txID, index)
+	if err != nil {
+		return nil, err
+	}
+	if spender != nil {
+		records = append(records, spender)
+	}
+
+	// walk back from the transaction that created the output to the imports
+	visited := map[string]bool{}
+	queue := []string{txID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		ttx, err := a.getTransaction(current)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, &token.TokenTransactionRecord{TxId: current, TokenTransaction: ttx})
+		inputs, _ := actionInputs(ttx.GetPlainAction())
+		for _, input := range inputs {
+			queue = append(queue, input.TxId)
+		}
+	}
+
+	// records were collected from the most recent transaction to the oldest
+	for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+		records[i], records[j] = records[j], records[i]
+	}
+	for _, record := range records {
+		block, err := a.Blocks.GetBlockByTxID(record.TxId)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to retrieve the block of transaction %s", record.TxId)
+		}
+		record.BlockNumber = block.GetHeader().GetNumber()
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].BlockNumber < records[j].BlockNumber
+	})
+
+	return &token.TokenHistory{Transactions: records}, nil
+}
+
+// OwnerHistory returns the valid token transactions committed between the start block and the end block
+// of the request, both included, that have an input or an output of the owner of the request.
+// The end block is capped to the last block of the ledger.
+func (a *Auditor) OwnerHistory(request *token.OwnerHistoryRequest) (*token.TokenHistory, error) {
+	if len(request.GetOwner()) == 0 {
+		return nil, errors.New("owner is required in OwnerHistoryRequest")
+	}
+	start, end := request.GetStartBlock(), request.GetEndBlock()
+	if start > end {
+		return nil, errors.Errorf("invalid block range [%d, %d]", start, end)
+	}
+	info, err := a.Blocks.GetBlockchainInfo()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve blockchain info")
+	}
+	if start >= info.GetHeight() {
+		return nil, errors.Errorf("start block %d is beyond the last block of the ledger (height %d)", start, info.GetHeight())
+	}
+	if end >= info.GetHeight() {
+		end = info.GetHeight() - 1
+	}
+
+	records := make([]*token.TokenTransactionRecord, 0)
+	for number := start; number <= end; number++ {
+		block, err := a.Blocks.GetBlockByNumber(number)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to retrieve block %d", number)
+		}
+		blockRecords, err := a.ownerRecords(block, request.GetOwner())
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, blockRecords...)
+	}
+
+	return &token.TokenHistory{Transactions: records}, nil
+}
+
+// Balances returns, for each token type, the quantity and the number of the unspent outputs,
+// restricted to the outputs of the owner of the request if one is given.
+// Delegated outputs count towards the balance of their owner and locked outputs towards
+// the balance of their sender, until they are spent.
+func (a *Auditor) Balances(request *token.BalanceRequest) (*token.TokenBalances, error) {
+	balances := map[string]*token.TokenBalance{}
+	for _, namespace := range []string{tokenOutput, tokenDelegatedOutput, tokenLockedOutput} {
+		err := a.addUnspentOutputs(namespace, request.GetOwner(), balances)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]*token.TokenBalance, 0, len(balances))
+	for _, balance := range balances {
+		result = append(result, balance)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Type < result[j].Type
+	})
+	return &token.TokenBalances{Balances: result}, nil
+}
+
+// Done releases any resources held by this auditor
+func (a *Auditor) Done() {
+	if a.Ledger != nil {
+		a.Ledger.Done()
+	}
+}
+
+// spender returns the transaction that spent the output with the given namespace, transaction ID and index,
+// or nil if the output has not been spent.
+func (a *Auditor) spender(namespace string, txID string, index int) (*token.TokenTransactionRecord, error) {
+	spentNamespace, ok := spentNamespaces[namespace]
+	if !ok {
+		// redeemed outputs cannot be spent
+		return nil, nil
+	}
+	spentKey, err := createCompositeKey(spentNamespace, []string{txID, strconv.Itoa(index)})
+	if err != nil {
+		return nil, err
+	}
+	marker, err := a.Ledger.GetState(tokenNameSpace, spentKey)
+	if err != nil {
+		return nil, err
+	}
+	if marker == nil {
+		return nil, nil
+	}
+
+	// the spent marker does not record the spending transaction,
+	// so it is looked up among the inputs of all the token transactions
+	prefix, err := createPrefix(tokenTx)
+	if err != nil {
+		return nil, err
+	}
+	iterator, err := a.Ledger.GetStateRangeScanIterator(tokenNameSpace, prefix, prefix+string(maxUnicodeRuneValue))
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	for {
+		next, err := iterator.Next()
+		switch {
+		case err != nil:
+			return nil, err
+
+		case next == nil:
+			// nil response from iterator indicates end of query results
+			return nil, errors.Errorf("no transaction spending output %s:%d found", txID, index)
+
+		default:
+			result, ok := next.(*queryresult.KV)
+			if !ok {
+				return nil, errors.New("failed to retrieve token transactions: casting error")
+			}
+			if !strings.HasPrefix(result.Key, prefix) {
+				continue
+			}
+			ttx := &token.TokenTransaction{}
+			err = proto.Unmarshal(result.Value, ttx)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to retrieve token transactions")
+			}
+			inputs, inputNamespace := actionInputs(ttx.GetPlainAction())
+			if inputNamespace != namespace {
+				continue
+			}
+			for _, input := range inputs {
+				if input.TxId == txID && int(input.Index) == index {
+					_, components, err := splitCompositeKey(result.Key)
+					if err != nil {
+						return nil, err
+					}
+					return &token.TokenTransactionRecord{TxId: components[0], TokenTransaction: ttx}, nil
+				}
+			}
+		}
+	}
+}
+
+// getTransaction returns the committed token transaction with the given ID.
+func (a *Auditor) getTransaction(txID string) (*token.TokenTransaction, error) {
+	txKey, err := createTxKey(txID)
+	if err != nil {
+		return nil, err
+	}
+	ttxBytes, err := a.Ledger.GetState(tokenNameSpace, txKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(ttxBytes) == 0 {
+		return nil, errors.Errorf("token transaction %s not found", txID)
+	}
+	ttx := &token.TokenTransaction{}
+	err = proto.Unmarshal(ttxBytes, ttx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal token transaction %s", txID)
+	}
+	return ttx, nil
+}
+
+// ownerRecords returns the valid token transactions of the block that involve the owner.
+func (a *Auditor) ownerRecords(block *common.Block, owner []byte) ([]*token.TokenTransactionRecord, error) {
+	var txFilter ledgerutil.TxValidationFlags
+	if metadata := block.GetMetadata().GetMetadata(); len(metadata) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		txFilter = ledgerutil.TxValidationFlags(metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	var records []*token.TokenTransactionRecord
+	for i, data := range block.GetData().GetData() {
+		if i >= len(txFilter) || !txFilter.IsValid(i) {
+			continue
+		}
+		envelope, err := utils.GetEnvelopeFromBlock(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal transaction %d of block %d", i, block.GetHeader().GetNumber())
+		}
+		payload, err := utils.GetPayload(envelope)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal transaction %d of block %d", i, block.GetHeader().GetNumber())
+		}
+		if payload.GetHeader() == nil {
+			continue
+		}
+		channelHeader, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal transaction %d of block %d", i, block.GetHeader().GetNumber())
+		}
+		if common.HeaderType(channelHeader.Type) != common.HeaderType_TOKEN_TRANSACTION {
+			continue
+		}
+		ttx := &token.TokenTransaction{}
+		err = proto.Unmarshal(payload.Data, ttx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal token transaction %s", channelHeader.TxId)
+		}
+		involved, err := a.involves(ttx.GetPlainAction(), owner)
+		if err != nil {
+			return nil, err
+		}
+		if involved {
+			records = append(records, &token.TokenTransactionRecord{
+				TxId:             channelHeader.TxId,
+				BlockNumber:      block.GetHeader().GetNumber(),
+				TokenTransaction: ttx,
+			})
+		}
+	}
+	return records, nil
+}
+
+// involves returns true if the owner owns an output or an input of the action.
+func (a *Auditor) involves(action *token.PlainTokenAction, owner []byte) (bool, error) {
+	if action == nil {
+		return false, nil
+	}
+	if containsOwner(actionOwners(action), owner) {
+		return true, nil
+	}
+	inputs, namespace := actionInputs(action)
+	for _, input := range inputs {
+		inputKey, err := createCompositeKey(namespace, []string{input.TxId, strconv.Itoa(int(input.Index))})
+		if err != nil {
+			return false, err
+		}
+		inputBytes, err := a.Ledger.GetState(tokenNameSpace, inputKey)
+		if err != nil {
+			return false, err
+		}
+		if len(inputBytes) == 0 {
+			return false, errors.Errorf("input %s:%d does not exist", input.TxId, input.Index)
+		}
+		inputOwners, _, _, err := outputOwners(namespace, inputBytes)
+		if err != nil {
+			return false, err
+		}
+		if containsOwner(inputOwners, owner) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// addUnspentOutputs adds the unspent outputs of the namespace owned by owner, or by anyone if owner is empty,
+// to the balances.
+func (a *Auditor) addUnspentOutputs(namespace string, owner []byte, balances map[string]*token.TokenBalance) error {
+	prefix, err := createPrefix(namespace)
+	if err != nil {
+		return err
+	}
+	iterator, err := a.Ledger.GetStateRangeScanIterator(tokenNameSpace, prefix, prefix+string(maxUnicodeRuneValue))
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	for {
+		next, err := iterator.Next()
+		switch {
+		case err != nil:
+			return err
+
+		case next == nil:
+			// nil response from iterator indicates end of query results
+			return nil
+
+		default:
+			result, ok := next.(*queryresult.KV)
+			if !ok {
+				return errors.New("failed to retrieve unspent outputs: casting error")
+			}
+			if !strings.HasPrefix(result.Key, prefix) {
+				continue
+			}
+			owners, tokenType, quantity, err := outputOwners(namespace, result.Value)
+			if err != nil {
+				return err
+			}
+			// the owner of a locked output is its sender until it is claimed
+			if len(owner) != 0 && !bytes.Equal(owners[0], owner) {
+				continue
+			}
+			_, components, err := splitCompositeKey(result.Key)
+			if err != nil {
+				return err
+			}
+			spentKey, err := createCompositeKey(spentNamespaces[namespace], components)
+			if err != nil {
+				return err
+			}
+			marker, err := a.Ledger.GetState(tokenNameSpace, spentKey)
+			if err != nil {
+				return err
+			}
+			if marker != nil {
+				continue
+			}
+
+			balance, ok := balances[tokenType]
+			if !ok {
+				balance = &token.TokenBalance{Type: tokenType}
+				balances[tokenType] = balance
+			}
+			balance.Quantity, err = AddQuantity(balance.Quantity, quantity)
+			if err != nil {
+				return errors.WithMessage(err, fmt.Sprintf("invalid balance of token type '%s'", tokenType))
+			}
+			balance.Outputs++
+		}
+	}
+}
+
+// actionInputs returns the inputs of the action, along with the namespace of the outputs they spend.
+func actionInputs(action *token.PlainTokenAction) ([]*token.InputId, string) {
+	switch t := action.GetData().(type) {
+	case *token.PlainTokenAction_PlainTransfer:
+		return t.PlainTransfer.GetInputs(), tokenOutput
+	case *token.PlainTokenAction_PlainRedeem:
+		return t.PlainRedeem.GetInputs(), tokenOutput
+	case *token.PlainTokenAction_PlainApprove:
+		return t.PlainApprove.GetInputs(), tokenOutput
+	case *token.PlainTokenAction_PlainTransfer_From:
+		return t.PlainTransfer_From.GetInputs(), tokenDelegatedOutput
+	case *token.PlainTokenAction_PlainSwap:
+		return t.PlainSwap.GetInputs(), tokenOutput
+	case *token.PlainTokenAction_PlainLock:
+		return t.PlainLock.GetInputs(), tokenOutput
+	case *token.PlainTokenAction_PlainClaim:
+		return []*token.InputId{t.PlainClaim.GetInput()}, tokenLockedOutput
+	case *token.PlainTokenAction_PlainReclaim:
+		return []*token.InputId{t.PlainReclaim.GetInput()}, tokenLockedOutput
+	default:
+		return nil, ""
+	}
+}
+
+// actionOwners returns the owners of the outputs created by the action. The outputs created
+// by claims and reclaims are owned by the recipient and the sender of their input.
+func actionOwners(action *token.PlainTokenAction) [][]byte {
+	var owners [][]byte
+	addOutputs := func(outputs ...*token.PlainOutput) {
+		for _, output := range outputs {
+			if output != nil {
+				owners = append(owners, output.Owner)
+			}
+		}
+	}
+	switch t := action.GetData().(type) {
+	case *token.PlainTokenAction_PlainImport:
+		addOutputs(t.PlainImport.GetOutputs()...)
+	case *token.PlainTokenAction_PlainTransfer:
+		addOutputs(t.PlainTransfer.GetOutputs()...)
+	case *token.PlainTokenAction_PlainRedeem:
+		addOutputs(t.PlainRedeem.GetOutputs()...)
+	case *token.PlainTokenAction_PlainApprove:
+		addOutputs(t.PlainApprove.GetOutput())
+		for _, delegatedOutput := range t.PlainApprove.GetDelegatedOutputs() {
+			owners = append(owners, delegatedOutput.Owner)
+		}
+	case *token.PlainTokenAction_PlainTransfer_From:
+		addOutputs(t.PlainTransfer_From.GetOutputs()...)
+		if t.PlainTransfer_From.GetDelegatedOutput() != nil {
+			owners = append(owners, t.PlainTransfer_From.GetDelegatedOutput().Owner)
+		}
+	case *token.PlainTokenAction_PlainSwap:
+		addOutputs(t.PlainSwap.GetOutputs()...)
+	case *token.PlainTokenAction_PlainLock:
+		addOutputs(t.PlainLock.GetOutput())
+		if lockedOutput := t.PlainLock.GetLockedOutput(); lockedOutput != nil {
+			owners = append(owners, lockedOutput.Sender, lockedOutput.Recipient)
+		}
+	}
+	return owners
+}
+
+// outputOwners unmarshals an output of the namespace and returns its owners, type and quantity.
+// The owners of a locked output are its sender and its recipient, in this order.
+func outputOwners(namespace string, outputBytes []byte) ([][]byte, string, uint64, error) {
+	switch namespace {
+	case tokenOutput:
+		output := &token.PlainOutput{}
+		if err := proto.Unmarshal(outputBytes, output); err != nil {
+			return nil, "", 0, errors.Wrap(err, "failed to unmarshal output")
+		}
+		return [][]byte{output.Owner}, output.Type, output.Quantity, nil
+	case tokenDelegatedOutput:
+		output := &token.PlainDelegatedOutput{}
+		if err := proto.Unmarshal(outputBytes, output); err != nil {
+			return nil, "", 0, errors.Wrap(err, "failed to unmarshal delegated output")
+		}
+		return [][]byte{output.Owner}, output.Type, output.Quantity, nil
+	case tokenLockedOutput:
+		output := &token.PlainLockedOutput{}
+		if err := proto.Unmarshal(outputBytes, output); err != nil {
+			return nil, "", 0, errors.Wrap(err, "failed to unmarshal locked output")
+		}
+		return [][]byte{output.Sender, output.Recipient}, output.Type, output.Quantity, nil
+	default:
+		return nil, "", 0, errors.Errorf("unknown output namespace '%s'", namespace)
+	}
+}
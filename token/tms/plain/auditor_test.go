@@ -0,0 +1,361 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain_test
+
+import (
+	"math"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	mockid "github.com/hyperledger/fabric/token/identity/mock"
+	mockledger "github.com/hyperledger/fabric/token/ledger/mock"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Auditor", func() {
+	var (
+		state           map[string][]byte
+		fakeLedger      *mockledger.LedgerWriter
+		fakeBlockReader *mockledger.BlockReader
+		blocks          []*common.Block
+
+		importTx, transferTx, redeemTx, invalidTx *token.TokenTransaction
+
+		auditor *plain.Auditor
+	)
+
+	plainTx := func(action *token.PlainTokenAction) *token.TokenTransaction {
+		return &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: action}}
+	}
+
+	envelope := func(headerType common.HeaderType, txID string, data []byte) []byte {
+		return utils.MarshalOrPanic(&common.Envelope{
+			Payload: utils.MarshalOrPanic(&common.Payload{
+				Header: &common.Header{
+					ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{Type: int32(headerType), TxId: txID}),
+				},
+				Data: data,
+			}),
+		})
+	}
+
+	newBlock := func(number uint64, envelopes ...[]byte) *common.Block {
+		block := common.NewBlock(number, nil)
+		block.Data.Data = envelopes
+		block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = ledgerutil.NewTxValidationFlagsSetValue(len(envelopes), peer.TxValidationCode_VALID)
+		return block
+	}
+
+	BeforeEach(func() {
+		state = map[string][]byte{}
+		fakeLedger = &mockledger.LedgerWriter{}
+		fakeLedger.GetStateStub = func(namespace string, key string) ([]byte, error) {
+			return state[key], nil
+		}
+		fakeLedger.SetStateStub = func(namespace string, key string, value []byte) error {
+			state[key] = value
+			return nil
+		}
+		fakeLedger.GetStateRangeScanIteratorStub = func(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+			var keys []string
+			for key := range state {
+				if key >= startKey && key < endKey {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			iterator := &mockledger.ResultsIterator{}
+			for i, key := range keys {
+				iterator.NextReturnsOnCall(i, &queryresult.KV{Key: key, Value: state[key]}, nil)
+			}
+			iterator.NextReturnsOnCall(len(keys), nil, nil)
+			return iterator, nil
+		}
+
+		importTx = plainTx(&token.PlainTokenAction{
+			Data: &token.PlainTokenAction_PlainImport{
+				PlainImport: &token.PlainImport{
+					Outputs: []*token.PlainOutput{
+						{Owner: []byte("alice"), Type: "USD", Quantity: 100},
+						{Owner: []byte("bob"), Type: "EUR", Quantity: 50},
+					},
+				},
+			},
+		})
+		transferTx = plainTx(&token.PlainTokenAction{
+			Data: &token.PlainTokenAction_PlainTransfer{
+				PlainTransfer: &token.PlainTransfer{
+					Inputs: []*token.InputId{{TxId: "import", Index: 0}},
+					Outputs: []*token.PlainOutput{
+						{Owner: []byte("bob"), Type: "USD", Quantity: 30},
+						{Owner: []byte("alice"), Type: "USD", Quantity: 70},
+					},
+				},
+			},
+		})
+		redeemTx = plainTx(&token.PlainTokenAction{
+			Data: &token.PlainTokenAction_PlainRedeem{
+				PlainRedeem: &token.PlainTransfer{
+					Inputs: []*token.InputId{{TxId: "transfer", Index: 0}},
+					Outputs: []*token.PlainOutput{
+						{Type: "USD", Quantity: 10},
+						{Owner: []byte("bob"), Type: "USD", Quantity: 20},
+					},
+				},
+			},
+		})
+		invalidTx = plainTx(&token.PlainTokenAction{
+			Data: &token.PlainTokenAction_PlainImport{
+				PlainImport: &token.PlainImport{
+					Outputs: []*token.PlainOutput{{Owner: []byte("alice"), Type: "USD", Quantity: 1000}},
+				},
+			},
+		})
+
+		verifier := &plain.Verifier{IssuingValidator: &mockid.IssuingValidator{}}
+		for _, tx := range []struct {
+			txID    string
+			creator string
+			ttx     *token.TokenTransaction
+		}{
+			{"import", "issuer", importTx},
+			{"transfer", "alice", transferTx},
+			{"redeem", "bob", redeemTx},
+		} {
+			creator := &mockid.PublicInfo{}
+			creator.PublicReturns([]byte(tx.creator))
+			err := verifier.ProcessTx(tx.txID, creator, tx.ttx, fakeLedger)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		invalidBlock := newBlock(3,
+			envelope(common.HeaderType_TOKEN_TRANSACTION, "redeem", utils.MarshalOrPanic(redeemTx)),
+			envelope(common.HeaderType_TOKEN_TRANSACTION, "invalid", utils.MarshalOrPanic(invalidTx)),
+		)
+		ledgerutil.TxValidationFlags(invalidBlock.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER]).SetFlag(1, peer.TxValidationCode_INVALID_OTHER_REASON)
+		blocks = []*common.Block{
+			newBlock(0, envelope(common.HeaderType_CONFIG, "config", []byte("config"))),
+			newBlock(1, envelope(common.HeaderType_TOKEN_TRANSACTION, "import", utils.MarshalOrPanic(importTx))),
+			newBlock(2,
+				envelope(common.HeaderType_ENDORSER_TRANSACTION, "endorser", []byte("endorser")),
+				envelope(common.HeaderType_TOKEN_TRANSACTION, "transfer", utils.MarshalOrPanic(transferTx)),
+			),
+			invalidBlock,
+		}
+
+		fakeBlockReader = &mockledger.BlockReader{}
+		fakeBlockReader.GetBlockchainInfoReturns(&common.BlockchainInfo{Height: uint64(len(blocks))}, nil)
+		fakeBlockReader.GetBlockByNumberStub = func(number uint64) (*common.Block, error) {
+			return blocks[number], nil
+		}
+		fakeBlockReader.GetBlockByTxIDStub = func(txID string) (*common.Block, error) {
+			switch txID {
+			case "import":
+				return blocks[1], nil
+			case "transfer":
+				return blocks[2], nil
+			case "redeem":
+				return blocks[3], nil
+			default:
+				return nil, errors.Errorf("transaction %s not found", txID)
+			}
+		}
+
+		auditor = &plain.Auditor{Ledger: fakeLedger, Blocks: fakeBlockReader}
+	})
+
+	expectRecords := func(history *token.TokenHistory, expected ...*token.TokenTransactionRecord) {
+		Expect(history.Transactions).To(HaveLen(len(expected)))
+		for i, record := range history.Transactions {
+			Expect(proto.Equal(record, expected[i])).To(BeTrue(), "record %d: %v", i, record)
+		}
+	}
+
+	Describe("Lineage", func() {
+		It("returns the transactions that created a spent output and the transaction that spent it", func() {
+			history, err := auditor.Lineage(&token.LineageRequest{TokenId: []byte("\x00tokenOutput\x00transfer\x000\x00")})
+			Expect(err).NotTo(HaveOccurred())
+			expectRecords(history,
+				&token.TokenTransactionRecord{TxId: "import", BlockNumber: 1, TokenTransaction: importTx},
+				&token.TokenTransactionRecord{TxId: "transfer", BlockNumber: 2, TokenTransaction: transferTx},
+				&token.TokenTransactionRecord{TxId: "redeem", BlockNumber: 3, TokenTransaction: redeemTx},
+			)
+		})
+
+		It("returns the ancestry of an unspent output", func() {
+			history, err := auditor.Lineage(&token.LineageRequest{TokenId: []byte("\x00tokenOutput\x00redeem\x001\x00")})
+			Expect(err).NotTo(HaveOccurred())
+			expectRecords(history,
+				&token.TokenTransactionRecord{TxId: "import", BlockNumber: 1, TokenTransaction: importTx},
+				&token.TokenTransactionRecord{TxId: "transfer", BlockNumber: 2, TokenTransaction: transferTx},
+				&token.TokenTransactionRecord{TxId: "redeem", BlockNumber: 3, TokenTransaction: redeemTx},
+			)
+		})
+
+		It("returns the import of an imported unspent output", func() {
+			history, err := auditor.Lineage(&token.LineageRequest{TokenId: []byte("\x00tokenOutput\x00import\x001\x00")})
+			Expect(err).NotTo(HaveOccurred())
+			expectRecords(history,
+				&token.TokenTransactionRecord{TxId: "import", BlockNumber: 1, TokenTransaction: importTx},
+			)
+		})
+
+		It("returns the lineage of a redeemed output", func() {
+			history, err := auditor.Lineage(&token.LineageRequest{TokenId: []byte("\x00tokenRedeem\x00redeem\x000\x00")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history.Transactions).To(HaveLen(3))
+		})
+
+		It("returns an error when the output does not exist", func() {
+			_, err := auditor.Lineage(&token.LineageRequest{TokenId: []byte("\x00tokenOutput\x00import\x005\x00")})
+			Expect(err).To(MatchError("token '00746f6b656e4f757470757400696d706f7274003500' does not exist"))
+		})
+
+		It("returns an error when the token id is not the id of an output", func() {
+			_, err := auditor.Lineage(&token.LineageRequest{TokenId: []byte("\x00tokenTx\x00import\x00")})
+			Expect(err).To(MatchError("invalid token id '00746f6b656e547800696d706f727400': 'tokenTx' is not an output namespace"))
+		})
+
+		It("returns an error when the token id is not a composite key", func() {
+			_, err := auditor.Lineage(&token.LineageRequest{TokenId: []byte("banana")})
+			Expect(err).To(MatchError("invalid token id '62616e616e61': invalid composite key - no components found"))
+		})
+
+		Context("when the block of a transaction cannot be retrieved", func() {
+			BeforeEach(func() {
+				fakeBlockReader.GetBlockByTxIDReturns(nil, errors.New("wild-banana"))
+				fakeBlockReader.GetBlockByTxIDStub = nil
+			})
+
+			It("returns an error", func() {
+				_, err := auditor.Lineage(&token.LineageRequest{TokenId: []byte("\x00tokenOutput\x00import\x001\x00")})
+				Expect(err).To(MatchError("failed to retrieve the block of transaction import: wild-banana"))
+			})
+		})
+	})
+
+	Describe("OwnerHistory", func() {
+		It("returns the valid token transactions involving the owner", func() {
+			history, err := auditor.OwnerHistory(&token.OwnerHistoryRequest{Owner: []byte("alice"), StartBlock: 0, EndBlock: 10})
+			Expect(err).NotTo(HaveOccurred())
+			expectRecords(history,
+				&token.TokenTransactionRecord{TxId: "import", BlockNumber: 1, TokenTransaction: importTx},
+				&token.TokenTransactionRecord{TxId: "transfer", BlockNumber: 2, TokenTransaction: transferTx},
+			)
+			Expect(fakeBlockReader.GetBlockByNumberCallCount()).To(Equal(4))
+		})
+
+		It("returns the transactions in which the owner spends an input", func() {
+			history, err := auditor.OwnerHistory(&token.OwnerHistoryRequest{Owner: []byte("bob"), StartBlock: 3, EndBlock: 3})
+			Expect(err).NotTo(HaveOccurred())
+			expectRecords(history,
+				&token.TokenTransactionRecord{TxId: "redeem", BlockNumber: 3, TokenTransaction: redeemTx},
+			)
+		})
+
+		It("returns no transactions when the owner has none in the range", func() {
+			history, err := auditor.OwnerHistory(&token.OwnerHistoryRequest{Owner: []byte("charlie"), StartBlock: 1, EndBlock: 3})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history.Transactions).To(BeEmpty())
+		})
+
+		It("returns an error when the owner is missing", func() {
+			_, err := auditor.OwnerHistory(&token.OwnerHistoryRequest{EndBlock: 3})
+			Expect(err).To(MatchError("owner is required in OwnerHistoryRequest"))
+		})
+
+		It("returns an error when the range is invalid", func() {
+			_, err := auditor.OwnerHistory(&token.OwnerHistoryRequest{Owner: []byte("alice"), StartBlock: 3, EndBlock: 2})
+			Expect(err).To(MatchError("invalid block range [3, 2]"))
+		})
+
+		It("returns an error when the range starts after the last block", func() {
+			_, err := auditor.OwnerHistory(&token.OwnerHistoryRequest{Owner: []byte("alice"), StartBlock: 4, EndBlock: 8})
+			Expect(err).To(MatchError("start block 4 is beyond the last block of the ledger (height 4)"))
+		})
+
+		Context("when a block cannot be retrieved", func() {
+			BeforeEach(func() {
+				fakeBlockReader.GetBlockByNumberStub = nil
+				fakeBlockReader.GetBlockByNumberReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := auditor.OwnerHistory(&token.OwnerHistoryRequest{Owner: []byte("alice"), StartBlock: 1, EndBlock: 1})
+				Expect(err).To(MatchError("failed to retrieve block 1: wild-banana"))
+			})
+		})
+	})
+
+	Describe("Balances", func() {
+		It("returns the unspent quantity of each token type", func() {
+			balances, err := auditor.Balances(&token.BalanceRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(balances.Balances).To(Equal([]*token.TokenBalance{
+				{Type: "EUR", Quantity: 50, Outputs: 1},
+				{Type: "USD", Quantity: 90, Outputs: 2},
+			}))
+		})
+
+		It("returns the unspent quantity of each token type of an owner", func() {
+			balances, err := auditor.Balances(&token.BalanceRequest{Owner: []byte("bob")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(balances.Balances).To(Equal([]*token.TokenBalance{
+				{Type: "EUR", Quantity: 50, Outputs: 1},
+				{Type: "USD", Quantity: 20, Outputs: 1},
+			}))
+		})
+
+		It("counts locked outputs towards the balance of their sender", func() {
+			state["\x00tokenLockedOutput\x00lock\x000\x00"] = utils.MarshalOrPanic(&token.PlainLockedOutput{
+				Sender: []byte("alice"), Recipient: []byte("bob"), Type: "USD", Quantity: 5,
+			})
+			balances, err := auditor.Balances(&token.BalanceRequest{Owner: []byte("alice")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(balances.Balances).To(Equal([]*token.TokenBalance{
+				{Type: "USD", Quantity: 75, Outputs: 2},
+			}))
+		})
+
+		It("returns an error when a balance overflows", func() {
+			state["\x00tokenLockedOutput\x00lock\x000\x00"] = utils.MarshalOrPanic(&token.PlainLockedOutput{
+				Sender: []byte("alice"), Recipient: []byte("bob"), Type: "USD", Quantity: math.MaxUint64,
+			})
+			_, err := auditor.Balances(&token.BalanceRequest{Owner: []byte("alice")})
+			Expect(err).To(MatchError("invalid balance of token type 'USD': token sum overflows (70 + 18446744073709551615)"))
+		})
+
+		Context("when the range scan fails", func() {
+			BeforeEach(func() {
+				fakeLedger.GetStateRangeScanIteratorStub = nil
+				fakeLedger.GetStateRangeScanIteratorReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := auditor.Balances(&token.BalanceRequest{})
+				Expect(err).To(MatchError("wild-banana"))
+			})
+		})
+	})
+
+	Describe("Done", func() {
+		It("releases the ledger", func() {
+			auditor.Done()
+			Expect(fakeLedger.DoneCallCount()).To(Equal(1))
+		})
+	})
+})
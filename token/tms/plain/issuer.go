@@ -8,6 +8,7 @@ package plain
 
 import (
 	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
 )
 
 // An Issuer that can import new tokens
@@ -17,10 +18,19 @@ type Issuer struct{}
 func (i *Issuer) RequestImport(tokensToIssue []*token.TokenToIssue) (*token.TokenTransaction, error) {
 	var outputs []*token.PlainOutput
 	for _, tti := range tokensToIssue {
+		if tti.Nft != nil {
+			if tti.Nft.Id == "" {
+				return nil, errors.Errorf("no id for non-fungible token of type '%s'", tti.Type)
+			}
+			if tti.Quantity != 1 {
+				return nil, errors.Errorf("the quantity of non-fungible token '%s' must be 1, got [%d]", tti.Nft.Id, tti.Quantity)
+			}
+		}
 		outputs = append(outputs, &token.PlainOutput{
 			Owner:    tti.Recipient,
 			Type:     tti.Type,
 			Quantity: tti.Quantity,
+			Nft:      tti.Nft,
 		})
 	}
 
@@ -40,5 +50,34 @@ func (i *Issuer) RequestImport(tokensToIssue []*token.TokenToIssue) (*token.Toke
 // RequestExpectation allows indirect import based on the expectation.
 // It creates a token transaction with the outputs as specified in the expectation.
 func (i *Issuer) RequestExpectation(request *token.ExpectationRequest) (*token.TokenTransaction, error) {
-	panic("not implemented yet")
+	if request.GetExpectation() == nil {
+		return nil, errors.New("no token expectation in ExpectationRequest")
+	}
+	if request.GetExpectation().GetPlainExpectation() == nil {
+		return nil, errors.New("no plain expectation in ExpectationRequest")
+	}
+	importExpectation := request.GetExpectation().GetPlainExpectation().GetImportExpectation()
+	if importExpectation == nil {
+		return nil, errors.New("no import expectation in ExpectationRequest")
+	}
+	if len(importExpectation.GetOutputs()) == 0 {
+		return nil, errors.New("no outputs in expectation")
+	}
+	for _, output := range importExpectation.GetOutputs() {
+		if err := checkExpectedOutput(output); err != nil {
+			return nil, err
+		}
+	}
+
+	return &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainImport{
+					PlainImport: &token.PlainImport{
+						Outputs: importExpectation.GetOutputs(),
+					},
+				},
+			},
+		},
+	}, nil
 }
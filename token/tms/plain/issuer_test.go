@@ -77,4 +77,111 @@ var _ = Describe("Issuer", func() {
 			}))
 		})
 	})
+
+	Context("when a non-fungible token is issued", func() {
+		var nft *token.NonFungibleToken
+
+		BeforeEach(func() {
+			nft = &token.NonFungibleToken{Id: "painting-1", Uri: "https://example.com/painting-1", Hash: []byte("hash")}
+		})
+
+		It("carries the token in the output", func() {
+			tt, err := issuer.RequestImport([]*token.TokenToIssue{{Recipient: []byte("R1"), Type: "ART", Quantity: 1, Nft: nft}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tt.GetPlainAction().GetPlainImport().GetOutputs()).To(Equal([]*token.PlainOutput{
+				{Owner: []byte("R1"), Type: "ART", Quantity: 1, Nft: nft},
+			}))
+		})
+
+		It("returns an error when the token has no id", func() {
+			nft.Id = ""
+			_, err := issuer.RequestImport([]*token.TokenToIssue{{Recipient: []byte("R1"), Type: "ART", Quantity: 1, Nft: nft}})
+			Expect(err).To(MatchError("no id for non-fungible token of type 'ART'"))
+		})
+
+		It("returns an error when the quantity is not 1", func() {
+			_, err := issuer.RequestImport([]*token.TokenToIssue{{Recipient: []byte("R1"), Type: "ART", Quantity: 2, Nft: nft}})
+			Expect(err).To(MatchError("the quantity of non-fungible token 'painting-1' must be 1, got [2]"))
+		})
+	})
+
+	Describe("RequestExpectation", func() {
+		var expectationRequest *token.ExpectationRequest
+
+		BeforeEach(func() {
+			expectationRequest = &token.ExpectationRequest{
+				Expectation: &token.TokenExpectation{
+					Expectation: &token.TokenExpectation_PlainExpectation{
+						PlainExpectation: &token.PlainExpectation{
+							Payload: &token.PlainExpectation_ImportExpectation{
+								ImportExpectation: &token.PlainTokenExpectation{
+									Outputs: []*token.PlainOutput{
+										{Owner: []byte("R1"), Type: "TOK1", Quantity: 1001},
+										{Owner: []byte("R2"), Type: "TOK2", Quantity: 1002},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("converts an import expectation to a token transaction", func() {
+			tt, err := issuer.RequestExpectation(expectationRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tt).To(Equal(&token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainImport{
+							PlainImport: &token.PlainImport{
+								Outputs: []*token.PlainOutput{
+									{Owner: []byte("R1"), Type: "TOK1", Quantity: 1001},
+									{Owner: []byte("R2"), Type: "TOK2", Quantity: 1002},
+								},
+							},
+						},
+					},
+				},
+			}))
+		})
+
+		Context("when the expectation is missing", func() {
+			It("returns an error", func() {
+				expectationRequest.Expectation = nil
+				tt, err := issuer.RequestExpectation(expectationRequest)
+				Expect(err).To(MatchError("no token expectation in ExpectationRequest"))
+				Expect(tt).To(BeNil())
+			})
+		})
+
+		Context("when the expectation is not an import expectation", func() {
+			It("returns an error", func() {
+				expectationRequest.Expectation.GetPlainExpectation().Payload = &token.PlainExpectation_TransferExpectation{
+					TransferExpectation: &token.PlainTokenExpectation{},
+				}
+				tt, err := issuer.RequestExpectation(expectationRequest)
+				Expect(err).To(MatchError("no import expectation in ExpectationRequest"))
+				Expect(tt).To(BeNil())
+			})
+		})
+
+		Context("when the expectation has no outputs", func() {
+			It("returns an error", func() {
+				expectationRequest.Expectation.GetPlainExpectation().GetImportExpectation().Outputs = nil
+				tt, err := issuer.RequestExpectation(expectationRequest)
+				Expect(err).To(MatchError("no outputs in expectation"))
+				Expect(tt).To(BeNil())
+			})
+		})
+
+		Context("when an expected output has no owner", func() {
+			It("returns an error", func() {
+				expectationRequest.Expectation.GetPlainExpectation().GetImportExpectation().Outputs[1].Owner = nil
+				tt, err := issuer.RequestExpectation(expectationRequest)
+				Expect(err).To(MatchError("the owner of an expected output must be specified"))
+				Expect(tt).To(BeNil())
+			})
+		})
+	})
 })
@@ -30,17 +30,29 @@ type Transactor struct {
 func (t *Transactor) RequestTransfer(request *token.TransferRequest) (*token.TokenTransaction, error) {
 	var outputs []*token.PlainOutput
 
-	inputs, tokenType, _, err := t.getInputsFromTokenIds(request.GetTokenIds())
+	inputs, tokenType, _, nfts, err := t.getInputsFromTokenIds(request.GetTokenIds())
 	if err != nil {
 		return nil, err
 	}
 
-	for _, ttt := range request.GetShares() {
-		outputs = append(outputs, &token.PlainOutput{
+	// each non-fungible token goes, as a whole, to the recipient of the share with the same position
+	if len(nfts) != 0 && len(nfts) != len(request.GetShares()) {
+		return nil, errors.Errorf("the number of shares [%d] must match the number of non-fungible tokens [%d]", len(request.GetShares()), len(nfts))
+	}
+
+	for i, ttt := range request.GetShares() {
+		output := &token.PlainOutput{
 			Owner:    ttt.Recipient,
 			Type:     tokenType,
 			Quantity: ttt.Quantity,
-		})
+		}
+		if len(nfts) != 0 {
+			if ttt.Quantity != 1 {
+				return nil, errors.Errorf("the quantity of a non-fungible token share must be 1, got [%d]", ttt.Quantity)
+			}
+			output.Nft = nfts[i]
+		}
+		outputs = append(outputs, output)
 	}
 
 	// prepare transfer request
@@ -69,7 +81,7 @@ func (t *Transactor) RequestRedeem(request *token.RedeemRequest) (*token.TokenTr
 		return nil, errors.Errorf("quantity to redeem [%d] must be greater than 0", request.GetQuantityToRedeem())
 	}
 
-	inputs, tokenType, quantitySum, err := t.getInputsFromTokenIds(request.GetTokenIds())
+	inputs, tokenType, quantitySum, nfts, err := t.getInputsFromTokenIds(request.GetTokenIds())
 	if err != nil {
 		return nil, err
 	}
@@ -78,11 +90,21 @@ func (t *Transactor) RequestRedeem(request *token.RedeemRequest) (*token.TokenTr
 		return nil, errors.Errorf("total quantity [%d] from TokenIds is less than quantity [%d] to be redeemed", quantitySum, request.QuantityToRedeem)
 	}
 
+	// a non-fungible token is redeemed as a whole, on its own
+	var nft *token.NonFungibleToken
+	if len(nfts) != 0 {
+		if len(nfts) != 1 || request.QuantityToRedeem != 1 {
+			return nil, errors.New("a non-fungible token must be redeemed on its own with quantity 1")
+		}
+		nft = nfts[0]
+	}
+
 	// add the output for redeem itself
 	var outputs []*token.PlainOutput
 	outputs = append(outputs, &token.PlainOutput{
 		Type:     tokenType,
 		Quantity: request.QuantityToRedeem,
+		Nft:      nft,
 	})
 
 	// add another output if there is remaining quantity after redemption
@@ -112,65 +134,63 @@ func (t *Transactor) RequestRedeem(request *token.RedeemRequest) (*token.TokenTr
 }
 
 // read token data from ledger for each token ids and calculate the sum of quantities for all token ids
-// Returns InputIds, token type, sum of token quantities, and error in the case of failure
-func (t *Transactor) getInputsFromTokenIds(tokenIds [][]byte) ([]*token.InputId, string, uint64, error) {
+// Returns InputIds, token type, sum of token quantities, the non-fungible tokens of the inputs, and error in the case of failure
+func (t *Transactor) getInputsFromTokenIds(tokenIds [][]byte) ([]*token.InputId, string, uint64, []*token.NonFungibleToken, error) {
 	var inputs []*token.InputId
 	var tokenType string = ""
 	var quantitySum uint64 = 0
+	var nfts []*token.NonFungibleToken
 	for _, inKeyBytes := range tokenIds {
-		// parse the composite key bytes into a string
-		inKey := parseCompositeKeyBytes(inKeyBytes)
-
-		// check whether the composite key conforms to the composite key of an output
-		namespace, components, err := splitCompositeKey(inKey)
-		if err != nil {
-			return nil, "", 0, errors.New(fmt.Sprintf("error splitting input composite key: '%s'", err))
-		}
-		if namespace != tokenOutput {
-			return nil, "", 0, errors.New(fmt.Sprintf("namespace not '%s': '%s'", tokenOutput, namespace))
-		}
-		if len(components) != 2 {
-			return nil, "", 0, errors.New(fmt.Sprintf("not enough components in output ID composite key; expected 2, received '%s'", components))
-		}
-		txID := components[0]
-		index, err := strconv.Atoi(components[1])
+		inKey, txID, index, err := parseInputKey(inKeyBytes, tokenOutput)
 		if err != nil {
-			return nil, "", 0, errors.New(fmt.Sprintf("error parsing output index '%s': '%s'", components[1], err))
+			return nil, "", 0, nil, err
 		}
 
 		// make sure the output exists in the ledger
 		inBytes, err := t.Ledger.GetState(tokenNameSpace, inKey)
 		if err != nil {
-			return nil, "", 0, err
+			return nil, "", 0, nil, err
 		}
 		if inBytes == nil {
-			return nil, "", 0, errors.New(fmt.Sprintf("input '%s' does not exist", inKey))
+			return nil, "", 0, nil, errors.New(fmt.Sprintf("input '%s' does not exist", inKey))
 		}
 		input := &token.PlainOutput{}
 		err = proto.Unmarshal(inBytes, input)
 		if err != nil {
-			return nil, "", 0, errors.New(fmt.Sprintf("error unmarshaling input bytes: '%s'", err))
+			return nil, "", 0, nil, errors.New(fmt.Sprintf("error unmarshaling input bytes: '%s'", err))
 		}
 
 		// check the owner of the token
 		if !bytes.Equal(t.PublicCredential, input.Owner) {
-			return nil, "", 0, errors.New(fmt.Sprintf("the requestor does not own inputs"))
+			return nil, "", 0, nil, errors.New(fmt.Sprintf("the requestor does not own inputs"))
 		}
 
 		// check the token type - only one type allowed per transfer
 		if tokenType == "" {
 			tokenType = input.Type
 		} else if tokenType != input.Type {
-			return nil, "", 0, errors.New(fmt.Sprintf("two or more token types specified in input: '%s', '%s'", tokenType, input.Type))
+			return nil, "", 0, nil, errors.New(fmt.Sprintf("two or more token types specified in input: '%s', '%s'", tokenType, input.Type))
+		}
+
+		// fungible and non-fungible tokens cannot be spent together
+		if len(inputs) != 0 && (input.Nft != nil) != (len(nfts) != 0) {
+			return nil, "", 0, nil, errors.New("fungible and non-fungible tokens specified in input")
+		}
+		if input.Nft != nil {
+			nfts = append(nfts, input.Nft)
 		}
+
 		// add input to list of inputs
 		inputs = append(inputs, &token.InputId{TxId: txID, Index: uint32(index)})
 
 		// sum up the quantity
-		quantitySum += input.Quantity
+		quantitySum, err = AddQuantity(quantitySum, input.Quantity)
+		if err != nil {
+			return nil, "", 0, nil, errors.WithMessage(err, "invalid inputs")
+		}
 	}
 
-	return inputs, tokenType, quantitySum, nil
+	return inputs, tokenType, quantitySum, nfts, nil
 }
 
 // ListTokens creates a TokenTransaction that lists the unspent tokens owned by owner.
@@ -218,6 +238,7 @@ func (t *Transactor) ListTokens() (*token.UnspentTokens, error) {
 								Type:     output.Type,
 								Quantity: output.Quantity,
 								Id:       getCompositeKeyBytes(result.Key),
+								Nft:      output.Nft,
 							})
 					}
 				}
@@ -227,6 +248,140 @@ func (t *Transactor) ListTokens() (*token.UnspentTokens, error) {
 
 }
 
+// ListDelegatedTokens returns the unspent delegated outputs whose delegatees include this transactor.
+func (t *Transactor) ListDelegatedTokens() (*token.UnspentTokens, error) {
+	prefix, err := createPrefix(tokenDelegatedOutput)
+	if err != nil {
+		return nil, err
+	}
+	iterator, err := t.Ledger.GetStateRangeScanIterator(tokenNameSpace, prefix, prefix+string(maxUnicodeRuneValue))
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	tokens := make([]*token.TokenOutput, 0)
+	for {
+		next, err := iterator.Next()
+		switch {
+		case err != nil:
+			return nil, err
+
+		case next == nil:
+			// nil response from iterator indicates end of query results
+			return &token.UnspentTokens{Tokens: tokens}, nil
+
+		default:
+			result, ok := next.(*queryresult.KV)
+			if !ok {
+				return nil, errors.New("failed to retrieve delegated tokens: casting error")
+			}
+			if !strings.HasPrefix(result.Key, prefix) {
+				continue
+			}
+			output := &token.PlainDelegatedOutput{}
+			err = proto.Unmarshal(result.Value, output)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to retrieve delegated tokens")
+			}
+			if !isDelegatee(t.PublicCredential, output) {
+				continue
+			}
+			_, txID, index, err := parseInputKey(getCompositeKeyBytes(result.Key), tokenDelegatedOutput)
+			if err != nil {
+				return nil, err
+			}
+			spentKey, err := createSpentDelegatedOutputKey(txID, index)
+			if err != nil {
+				return nil, err
+			}
+			spent, err := t.Ledger.GetState(tokenNameSpace, spentKey)
+			if err != nil {
+				return nil, err
+			}
+			if spent != nil {
+				continue
+			}
+			tokens = append(tokens,
+				&token.TokenOutput{
+					Type:     output.Type,
+					Quantity: output.Quantity,
+					Id:       getCompositeKeyBytes(result.Key),
+				})
+		}
+	}
+}
+
+// GetSupply returns the outstanding supply of the token types in the request,
+// or of all the token types whose supply is recorded if the request has no types.
+func (t *Transactor) GetSupply(request *token.SupplyRequest) (*token.TokenSupplies, error) {
+	if len(request.GetTypes()) == 0 {
+		return t.listSupplies()
+	}
+
+	supplies := make([]*token.TokenSupply, 0, len(request.GetTypes()))
+	for _, tokenType := range request.GetTypes() {
+		supplyKey, err := createSupplyKey(tokenType)
+		if err != nil {
+			return nil, err
+		}
+		supplyBytes, err := t.Ledger.GetState(tokenNameSpace, supplyKey)
+		if err != nil {
+			return nil, err
+		}
+		supply := &token.TokenSupply{Type: tokenType}
+		if len(supplyBytes) != 0 {
+			err = proto.Unmarshal(supplyBytes, supply)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to retrieve supply of token type '%s'", tokenType)
+			}
+		}
+		supplies = append(supplies, supply)
+	}
+	return &token.TokenSupplies{Supplies: supplies}, nil
+}
+
+// listSupplies returns the outstanding supply of all the token types whose supply is recorded.
+func (t *Transactor) listSupplies() (*token.TokenSupplies, error) {
+	prefix, err := createPrefix(tokenSupply)
+	if err != nil {
+		return nil, err
+	}
+	iterator, err := t.Ledger.GetStateRangeScanIterator(tokenNameSpace, prefix, prefix+string(maxUnicodeRuneValue))
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	supplies := make([]*token.TokenSupply, 0)
+	for {
+		next, err := iterator.Next()
+		switch {
+		case err != nil:
+			return nil, err
+
+		case next == nil:
+			// nil response from iterator indicates end of query results
+			return &token.TokenSupplies{Supplies: supplies}, nil
+
+		default:
+			result, ok := next.(*queryresult.KV)
+			if !ok {
+				return nil, errors.New("failed to retrieve token supplies: casting error")
+			}
+			if !strings.HasPrefix(result.Key, prefix) {
+				continue
+			}
+			supply := &token.TokenSupply{}
+			err = proto.Unmarshal(result.Value, supply)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to retrieve token supplies")
+			}
+			supplies = append(supplies, supply)
+		}
+	}
+}
+
 func (t *Transactor) RequestApprove(request *token.ApproveRequest) (*token.TokenTransaction, error) {
 	if len(request.GetTokenIds()) == 0 {
 		return nil, errors.New("no token ids in ApproveAllowanceRequest")
@@ -238,10 +393,13 @@ func (t *Transactor) RequestApprove(request *token.ApproveRequest) (*token.Token
 
 	var delegatedOutputs []*token.PlainDelegatedOutput
 
-	inputs, tokenType, sumQuantity, err := t.getInputsFromTokenIds(request.GetTokenIds())
+	inputs, tokenType, sumQuantity, nfts, err := t.getInputsFromTokenIds(request.GetTokenIds())
 	if err != nil {
 		return nil, err
 	}
+	if len(nfts) != 0 {
+		return nil, errors.New("non-fungible tokens cannot be approved")
+	}
 
 	// prepare approve tx
 
@@ -254,7 +412,7 @@ func (t *Transactor) RequestApprove(request *token.ApproveRequest) (*token.Token
 			return nil, errors.Errorf("the quantity to approve [%d] must be greater than 0", share.GetQuantity())
 		}
 		delegatedOutputs = append(delegatedOutputs, &token.PlainDelegatedOutput{
-			Owner:      []byte(request.Credential),
+			Owner:      t.PublicCredential,
 			Delegatees: [][]byte{share.Recipient},
 			Type:       tokenType,
 			Quantity:   share.Quantity,
@@ -268,7 +426,7 @@ func (t *Transactor) RequestApprove(request *token.ApproveRequest) (*token.Token
 	var output *token.PlainOutput
 	if sumQuantity != delegatedQuantity {
 		output = &token.PlainOutput{
-			Owner:    request.Credential,
+			Owner:    t.PublicCredential,
 			Type:     tokenType,
 			Quantity: sumQuantity - delegatedQuantity,
 		}
@@ -291,14 +449,270 @@ func (t *Transactor) RequestApprove(request *token.ApproveRequest) (*token.Token
 	return transaction, nil
 }
 
+// RequestTransferFrom creates a TokenTransaction that transfers delegated outputs
+// the requestor is a delegatee of to the recipients of the shares.
+// The remaining quantity, if any, stays delegated to the requestor.
 func (t *Transactor) RequestTransferFrom(request *token.TransferRequest) (*token.TokenTransaction, error) {
-	panic("implement me!")
+	if len(request.GetTokenIds()) == 0 {
+		return nil, errors.New("no token ids in TransferFromRequest")
+	}
+	if len(request.GetShares()) == 0 {
+		return nil, errors.New("no shares in TransferFromRequest")
+	}
+
+	inputs, owner, tokenType, inputSum, err := t.getDelegatedInputsFromTokenIds(request.GetTokenIds())
+	if err != nil {
+		return nil, err
+	}
+
+	var outputs []*token.PlainOutput
+	outputSum := uint64(0)
+	for _, share := range request.GetShares() {
+		if len(share.Recipient) == 0 {
+			return nil, errors.New("the recipient in transfer from must be specified")
+		}
+		if share.Quantity == 0 {
+			return nil, errors.New("the quantity to transfer from must be greater than 0")
+		}
+		outputs = append(outputs, &token.PlainOutput{
+			Owner:    share.Recipient,
+			Type:     tokenType,
+			Quantity: share.Quantity,
+		})
+		outputSum, err = AddQuantity(outputSum, share.Quantity)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid shares")
+		}
+	}
+	if inputSum < outputSum {
+		return nil, errors.Errorf("insufficient funds: %v < %v", inputSum, outputSum)
+	}
+
+	// the remaining quantity stays delegated to the requestor
+	var delegatedOutput *token.PlainDelegatedOutput
+	if inputSum > outputSum {
+		delegatedOutput = &token.PlainDelegatedOutput{
+			Owner:      owner,
+			Delegatees: [][]byte{t.PublicCredential},
+			Type:       tokenType,
+			Quantity:   inputSum - outputSum,
+		}
+	}
+
+	transaction := &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainTransfer_From{
+					PlainTransfer_From: &token.PlainTransferFrom{
+						Inputs:          inputs,
+						Outputs:         outputs,
+						DelegatedOutput: delegatedOutput,
+					},
+				},
+			},
+		},
+	}
+
+	return transaction, nil
 }
 
 // RequestExpectation allows indirect transfer based on the expectation.
 // It creates a token transaction based on the outputs as specified in the expectation.
+// The remaining quantity of the inputs, if any, is transferred back to the requestor.
 func (t *Transactor) RequestExpectation(request *token.ExpectationRequest) (*token.TokenTransaction, error) {
-	panic("not implemented yet")
+	if len(request.GetTokenIds()) == 0 {
+		return nil, errors.New("no token ids in ExpectationRequest")
+	}
+	if request.GetExpectation() == nil {
+		return nil, errors.New("no token expectation in ExpectationRequest")
+	}
+	if request.GetExpectation().GetPlainExpectation() == nil {
+		return nil, errors.New("no plain expectation in ExpectationRequest")
+	}
+	transferExpectation := request.GetExpectation().GetPlainExpectation().GetTransferExpectation()
+	if transferExpectation == nil {
+		return nil, errors.New("no transfer expectation in ExpectationRequest")
+	}
+
+	inputs, inputType, inputSum, nfts, err := t.getInputsFromTokenIds(request.GetTokenIds())
+	if err != nil {
+		return nil, err
+	}
+	if len(nfts) != 0 {
+		return nil, errors.New("non-fungible tokens cannot be transferred based on an expectation")
+	}
+
+	outputType, outputSum, err := parseExpectedOutputs(transferExpectation.GetOutputs())
+	if err != nil {
+		return nil, err
+	}
+	if outputType != inputType {
+		return nil, errors.Errorf("token type mismatch in inputs and outputs for expectation (%s vs %s)", inputType, outputType)
+	}
+	if outputSum > inputSum {
+		return nil, errors.Errorf("total quantity [%d] from TokenIds is less than total quantity [%d] in expectation", inputSum, outputSum)
+	}
+
+	outputs := append([]*token.PlainOutput{}, transferExpectation.GetOutputs()...)
+	if inputSum > outputSum {
+		outputs = append(outputs, &token.PlainOutput{
+			Owner:    t.PublicCredential,
+			Type:     outputType,
+			Quantity: inputSum - outputSum,
+		})
+	}
+
+	transaction := &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainTransfer{
+					PlainTransfer: &token.PlainTransfer{
+						Inputs:  inputs,
+						Outputs: outputs,
+					},
+				},
+			},
+		},
+	}
+
+	return transaction, nil
+}
+
+// read the delegated outputs for each token id from the ledger and check that the requestor is their delegatee
+// Returns InputIds, the owner of the delegated outputs, token type, sum of token quantities, and error in the case of failure
+func (t *Transactor) getDelegatedInputsFromTokenIds(tokenIds [][]byte) ([]*token.InputId, []byte, string, uint64, error) {
+	var inputs []*token.InputId
+	var owner []byte
+	tokenType := ""
+	quantitySum := uint64(0)
+	for _, inKeyBytes := range tokenIds {
+		inKey, txID, index, err := parseInputKey(inKeyBytes, tokenDelegatedOutput)
+		if err != nil {
+			return nil, nil, "", 0, err
+		}
+
+		inBytes, err := t.Ledger.GetState(tokenNameSpace, inKey)
+		if err != nil {
+			return nil, nil, "", 0, err
+		}
+		if inBytes == nil {
+			return nil, nil, "", 0, errors.Errorf("input '%s' does not exist", inKey)
+		}
+		input := &token.PlainDelegatedOutput{}
+		err = proto.Unmarshal(inBytes, input)
+		if err != nil {
+			return nil, nil, "", 0, errors.Errorf("error unmarshaling input bytes: '%s'", err)
+		}
+
+		if !isDelegatee(t.PublicCredential, input) {
+			return nil, nil, "", 0, errors.New("the requestor is not a delegatee of inputs")
+		}
+		if owner == nil {
+			owner = input.Owner
+		} else if !bytes.Equal(owner, input.Owner) {
+			return nil, nil, "", 0, errors.New("two or more owners specified in input")
+		}
+		if tokenType == "" {
+			tokenType = input.Type
+		} else if tokenType != input.Type {
+			return nil, nil, "", 0, errors.Errorf("two or more token types specified in input: '%s', '%s'", tokenType, input.Type)
+		}
+
+		spentKey, err := createSpentDelegatedOutputKey(txID, index)
+		if err != nil {
+			return nil, nil, "", 0, err
+		}
+		spent, err := t.Ledger.GetState(tokenNameSpace, spentKey)
+		if err != nil {
+			return nil, nil, "", 0, err
+		}
+		if spent != nil {
+			return nil, nil, "", 0, errors.Errorf("input '%s' has already been spent", inKey)
+		}
+
+		inputs = append(inputs, &token.InputId{TxId: txID, Index: uint32(index)})
+		quantitySum, err = AddQuantity(quantitySum, input.Quantity)
+		if err != nil {
+			return nil, nil, "", 0, errors.WithMessage(err, "invalid inputs")
+		}
+	}
+
+	return inputs, owner, tokenType, quantitySum, nil
+}
+
+// parseInputKey checks that the passed token id is the composite key of an output in the passed namespace,
+// and returns the key, along with the transaction ID and index of the output.
+func parseInputKey(inKeyBytes []byte, outputNamespace string) (string, string, int, error) {
+	// parse the composite key bytes into a string
+	inKey := parseCompositeKeyBytes(inKeyBytes)
+
+	// check whether the composite key conforms to the composite key of an output
+	namespace, components, err := splitCompositeKey(inKey)
+	if err != nil {
+		return "", "", 0, errors.New(fmt.Sprintf("error splitting input composite key: '%s'", err))
+	}
+	if namespace != outputNamespace {
+		return "", "", 0, errors.New(fmt.Sprintf("namespace not '%s': '%s'", outputNamespace, namespace))
+	}
+	if len(components) != 2 {
+		return "", "", 0, errors.New(fmt.Sprintf("not enough components in output ID composite key; expected 2, received '%s'", components))
+	}
+	txID := components[0]
+	index, err := strconv.Atoi(components[1])
+	if err != nil {
+		return "", "", 0, errors.New(fmt.Sprintf("error parsing output index '%s': '%s'", components[1], err))
+	}
+	return inKey, txID, index, nil
+}
+
+// parseExpectedOutputs checks the outputs of an expectation
+// and returns their token type and the sum of their quantities.
+func parseExpectedOutputs(outputs []*token.PlainOutput) (string, uint64, error) {
+	if len(outputs) == 0 {
+		return "", 0, errors.New("no outputs in expectation")
+	}
+	tokenType := ""
+	quantitySum := uint64(0)
+	for _, output := range outputs {
+		if err := checkExpectedOutput(output); err != nil {
+			return "", 0, err
+		}
+		if tokenType == "" {
+			tokenType = output.Type
+		} else if tokenType != output.Type {
+			return "", 0, errors.Errorf("multiple token types ('%s', '%s') in expectation outputs", tokenType, output.Type)
+		}
+		var err error
+		quantitySum, err = AddQuantity(quantitySum, output.Quantity)
+		if err != nil {
+			return "", 0, errors.WithMessage(err, "invalid expectation outputs")
+		}
+	}
+	return tokenType, quantitySum, nil
+}
+
+// checkExpectedOutput checks that an output of an expectation has an owner and a quantity.
+func checkExpectedOutput(output *token.PlainOutput) error {
+	if len(output.Owner) == 0 {
+		return errors.New("the owner of an expected output must be specified")
+	}
+	if output.Quantity == 0 {
+		return errors.New("the quantity of an expected output must be greater than 0")
+	}
+	if output.Nft != nil && output.Quantity != 1 {
+		return errors.New("the quantity of an expected non-fungible token must be 1")
+	}
+	return nil
+}
+
+// isDelegatee returns true if the passed credential is one of the delegatees of the delegated output.
+func isDelegatee(credential []byte, delegatedOutput *token.PlainDelegatedOutput) bool {
+	for _, delegatee := range delegatedOutput.Delegatees {
+		if bytes.Equal(credential, delegatee) {
+			return true
+		}
+	}
+	return false
 }
 
 // Done releases any resources held by this transactor
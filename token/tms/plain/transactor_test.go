@@ -9,6 +9,7 @@ package plain_test
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"testing"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/hyperledger/fabric/token/ledger/mock"
 	"github.com/hyperledger/fabric/token/tms/plain"
 	. "github.com/onsi/ginkgo"
@@ -790,3 +792,522 @@ var _ = Describe("Transactor Approve", func() {
 	})
 
 })
+
+var _ = Describe("Transactor TransferFrom", func() {
+	var (
+		transactor          *plain.Transactor
+		fakeLedger          *mock.LedgerReader
+		transferFromRequest *token.TransferRequest
+		delegatedID         []byte
+	)
+
+	BeforeEach(func() {
+		input := &token.PlainDelegatedOutput{
+			Owner:      []byte("owner"),
+			Delegatees: [][]byte{[]byte("spender")},
+			Type:       "XYZ",
+			Quantity:   100,
+		}
+		inputBytes, err := proto.Marshal(input)
+		Expect(err).NotTo(HaveOccurred())
+		fakeLedger = &mock.LedgerReader{}
+		fakeLedger.GetStateReturnsOnCall(0, inputBytes, nil)
+		transactor = &plain.Transactor{PublicCredential: []byte("spender"), Ledger: fakeLedger}
+
+		delegatedID = []byte(string("\x00") + "tokenDelegatedOutput" + string("\x00") + "lalaland" + string("\x00") + "0" + string("\x00"))
+		transferFromRequest = &token.TransferRequest{
+			TokenIds: [][]byte{delegatedID},
+			Shares:   []*token.RecipientTransferShare{{Recipient: []byte("Alice"), Quantity: 70}},
+		}
+	})
+
+	It("creates a valid transfer from request", func() {
+		tt, err := transactor.RequestTransferFrom(transferFromRequest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tt).To(Equal(&token.TokenTransaction{
+			Action: &token.TokenTransaction_PlainAction{
+				PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainTransfer_From{
+						PlainTransfer_From: &token.PlainTransferFrom{
+							Inputs: []*token.InputId{
+								{TxId: "lalaland", Index: uint32(0)},
+							},
+							Outputs: []*token.PlainOutput{
+								{Owner: []byte("Alice"), Type: "XYZ", Quantity: 70},
+							},
+							DelegatedOutput: &token.PlainDelegatedOutput{
+								Owner:      []byte("owner"),
+								Delegatees: [][]byte{[]byte("spender")},
+								Type:       "XYZ",
+								Quantity:   30,
+							},
+						},
+					},
+				},
+			},
+		}))
+
+		Expect(fakeLedger.GetStateCallCount()).To(Equal(2))
+		_, spentKey := fakeLedger.GetStateArgsForCall(1)
+		Expect(spentKey).To(Equal("\x00tokenDelegateInput\x00lalaland\x000\x00"))
+	})
+
+	It("creates a transfer from request without a delegated output when the whole allowance is spent", func() {
+		transferFromRequest.Shares = []*token.RecipientTransferShare{
+			{Recipient: []byte("Alice"), Quantity: 70},
+			{Recipient: []byte("Bob"), Quantity: 30},
+		}
+		tt, err := transactor.RequestTransferFrom(transferFromRequest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tt.GetPlainAction().GetPlainTransfer_From().GetOutputs()).To(HaveLen(2))
+		Expect(tt.GetPlainAction().GetPlainTransfer_From().GetDelegatedOutput()).To(BeNil())
+	})
+
+	When("no token ids are provided", func() {
+		It("returns an error", func() {
+			transferFromRequest.TokenIds = nil
+			tt, err := transactor.RequestTransferFrom(transferFromRequest)
+			Expect(err).To(MatchError("no token ids in TransferFromRequest"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("no shares are provided", func() {
+		It("returns an error", func() {
+			transferFromRequest.Shares = nil
+			tt, err := transactor.RequestTransferFrom(transferFromRequest)
+			Expect(err).To(MatchError("no shares in TransferFromRequest"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the input is not a delegated output", func() {
+		It("returns an error", func() {
+			transferFromRequest.TokenIds = [][]byte{[]byte(string("\x00") + "tokenOutput" + string("\x00") + "lalaland" + string("\x00") + "0" + string("\x00"))}
+			tt, err := transactor.RequestTransferFrom(transferFromRequest)
+			Expect(err).To(MatchError("namespace not 'tokenDelegatedOutput': 'tokenOutput'"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the input does not exist", func() {
+		It("returns an error", func() {
+			fakeLedger.GetStateReturnsOnCall(0, nil, nil)
+			tt, err := transactor.RequestTransferFrom(transferFromRequest)
+			Expect(err).To(MatchError(fmt.Sprintf("input '%s' does not exist", delegatedID)))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the requestor is not a delegatee of the input", func() {
+		It("returns an error", func() {
+			transactor.PublicCredential = []byte("owner")
+			tt, err := transactor.RequestTransferFrom(transferFromRequest)
+			Expect(err).To(MatchError("the requestor is not a delegatee of inputs"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the input has already been spent", func() {
+		It("returns an error", func() {
+			fakeLedger.GetStateReturnsOnCall(1, plain.TokenInputSpentMarker, nil)
+			tt, err := transactor.RequestTransferFrom(transferFromRequest)
+			Expect(err).To(MatchError(fmt.Sprintf("input '%s' has already been spent", delegatedID)))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("a recipient is not specified", func() {
+		It("returns an error", func() {
+			transferFromRequest.Shares = []*token.RecipientTransferShare{{Quantity: 70}}
+			tt, err := transactor.RequestTransferFrom(transferFromRequest)
+			Expect(err).To(MatchError("the recipient in transfer from must be specified"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("a quantity in a share is 0", func() {
+		It("returns an error", func() {
+			transferFromRequest.Shares = []*token.RecipientTransferShare{{Recipient: []byte("Alice")}}
+			tt, err := transactor.RequestTransferFrom(transferFromRequest)
+			Expect(err).To(MatchError("the quantity to transfer from must be greater than 0"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the allowance is not sufficient", func() {
+		It("returns an error", func() {
+			transferFromRequest.Shares = []*token.RecipientTransferShare{{Recipient: []byte("Alice"), Quantity: 170}}
+			tt, err := transactor.RequestTransferFrom(transferFromRequest)
+			Expect(err).To(MatchError("insufficient funds: 100 < 170"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the sum of the shares overflows", func() {
+		It("returns an error", func() {
+			transferFromRequest.Shares = []*token.RecipientTransferShare{
+				{Recipient: []byte("Alice"), Quantity: 70},
+				{Recipient: []byte("Bob"), Quantity: math.MaxUint64},
+			}
+			tt, err := transactor.RequestTransferFrom(transferFromRequest)
+			Expect(err).To(MatchError(fmt.Sprintf("invalid shares: token sum overflows (70 + %d)", uint64(math.MaxUint64))))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the transactor fails to get inputs from the ledger", func() {
+		It("returns an error", func() {
+			fakeLedger.GetStateReturnsOnCall(0, nil, errors.New("banana"))
+			tt, err := transactor.RequestTransferFrom(transferFromRequest)
+			Expect(err).To(MatchError("banana"))
+			Expect(tt).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("Transactor ListDelegatedTokens", func() {
+	var (
+		fakeLedger   *mock.LedgerReader
+		fakeIterator *mock.ResultsIterator
+		transactor   *plain.Transactor
+	)
+
+	BeforeEach(func() {
+		fakeLedger = &mock.LedgerReader{}
+		fakeIterator = &mock.ResultsIterator{}
+		fakeLedger.GetStateRangeScanIteratorReturns(fakeIterator, nil)
+		transactor = &plain.Transactor{PublicCredential: []byte("spender"), Ledger: fakeLedger}
+
+		delegated := func(delegatee string, quantity uint64) []byte {
+			return utils.MarshalOrPanic(&token.PlainDelegatedOutput{
+				Owner:      []byte("owner"),
+				Delegatees: [][]byte{[]byte(delegatee)},
+				Type:       "XYZ",
+				Quantity:   quantity,
+			})
+		}
+		fakeIterator.NextReturnsOnCall(0, &queryresult.KV{Key: "\x00tokenDelegatedOutput\x00tx1\x000\x00", Value: delegated("spender", 100)}, nil)
+		fakeIterator.NextReturnsOnCall(1, &queryresult.KV{Key: "\x00tokenDelegatedOutput\x00tx2\x000\x00", Value: delegated("someone-else", 50)}, nil)
+		fakeIterator.NextReturnsOnCall(2, &queryresult.KV{Key: "\x00tokenDelegatedOutput\x00tx3\x000\x00", Value: delegated("spender", 30)}, nil)
+		fakeIterator.NextReturnsOnCall(3, nil, nil)
+		fakeLedger.GetStateReturnsOnCall(0, nil, nil)
+		fakeLedger.GetStateReturnsOnCall(1, []byte("spent"), nil)
+	})
+
+	It("returns the unspent delegated outputs of which the transactor is a delegatee", func() {
+		tokens, err := transactor.ListDelegatedTokens()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tokens).To(Equal(&token.UnspentTokens{Tokens: []*token.TokenOutput{
+			{Id: []byte("\x00tokenDelegatedOutput\x00tx1\x000\x00"), Type: "XYZ", Quantity: 100},
+		}}))
+
+		ns, start, end := fakeLedger.GetStateRangeScanIteratorArgsForCall(0)
+		Expect(ns).To(Equal("tms"))
+		Expect(start).To(Equal("\x00tokenDelegatedOutput\x00"))
+		Expect(end).To(Equal("\x00tokenDelegatedOutput\x00\U0010FFFF"))
+		Expect(fakeLedger.GetStateCallCount()).To(Equal(2))
+		_, spentKey := fakeLedger.GetStateArgsForCall(1)
+		Expect(spentKey).To(Equal("\x00tokenDelegateInput\x00tx3\x000\x00"))
+		Expect(fakeIterator.CloseCallCount()).To(Equal(1))
+	})
+
+	Context("when the iterator fails", func() {
+		BeforeEach(func() {
+			fakeIterator.NextReturnsOnCall(0, nil, errors.New("wild banana"))
+		})
+
+		It("returns an error", func() {
+			_, err := transactor.ListDelegatedTokens()
+			Expect(err).To(MatchError("wild banana"))
+		})
+	})
+})
+
+var _ = Describe("Transactor Expectation", func() {
+	var (
+		transactor         *plain.Transactor
+		fakeLedger         *mock.LedgerReader
+		expectationRequest *token.ExpectationRequest
+	)
+
+	BeforeEach(func() {
+		input := &token.PlainOutput{
+			Owner:    []byte("credential"),
+			Type:     "XYZ",
+			Quantity: 100,
+		}
+		inputBytes, err := proto.Marshal(input)
+		Expect(err).NotTo(HaveOccurred())
+		fakeLedger = &mock.LedgerReader{}
+		fakeLedger.GetStateReturnsOnCall(0, inputBytes, nil)
+		transactor = &plain.Transactor{PublicCredential: []byte("credential"), Ledger: fakeLedger}
+
+		expectationRequest = &token.ExpectationRequest{
+			TokenIds: [][]byte{[]byte(string("\x00") + "tokenOutput" + string("\x00") + "lalaland" + string("\x00") + "0" + string("\x00"))},
+			Expectation: &token.TokenExpectation{
+				Expectation: &token.TokenExpectation_PlainExpectation{
+					PlainExpectation: &token.PlainExpectation{
+						Payload: &token.PlainExpectation_TransferExpectation{
+							TransferExpectation: &token.PlainTokenExpectation{
+								Outputs: []*token.PlainOutput{{Owner: []byte("Alice"), Type: "XYZ", Quantity: 60}},
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("creates a transfer that fulfills the expectation", func() {
+		tt, err := transactor.RequestExpectation(expectationRequest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tt).To(Equal(&token.TokenTransaction{
+			Action: &token.TokenTransaction_PlainAction{
+				PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainTransfer{
+						PlainTransfer: &token.PlainTransfer{
+							Inputs: []*token.InputId{
+								{TxId: "lalaland", Index: uint32(0)},
+							},
+							Outputs: []*token.PlainOutput{
+								{Owner: []byte("Alice"), Type: "XYZ", Quantity: 60},
+								{Owner: []byte("credential"), Type: "XYZ", Quantity: 40},
+							},
+						},
+					},
+				},
+			},
+		}))
+	})
+
+	When("no token ids are provided", func() {
+		It("returns an error", func() {
+			expectationRequest.TokenIds = nil
+			tt, err := transactor.RequestExpectation(expectationRequest)
+			Expect(err).To(MatchError("no token ids in ExpectationRequest"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("no expectation is provided", func() {
+		It("returns an error", func() {
+			expectationRequest.Expectation = nil
+			tt, err := transactor.RequestExpectation(expectationRequest)
+			Expect(err).To(MatchError("no token expectation in ExpectationRequest"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the expectation is not a transfer expectation", func() {
+		It("returns an error", func() {
+			expectationRequest.Expectation.GetPlainExpectation().Payload = &token.PlainExpectation_ImportExpectation{
+				ImportExpectation: &token.PlainTokenExpectation{},
+			}
+			tt, err := transactor.RequestExpectation(expectationRequest)
+			Expect(err).To(MatchError("no transfer expectation in ExpectationRequest"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the expectation has outputs of several types", func() {
+		It("returns an error", func() {
+			outputs := expectationRequest.Expectation.GetPlainExpectation().GetTransferExpectation().Outputs
+			outputs = append(outputs, &token.PlainOutput{Owner: []byte("Bob"), Type: "ABC", Quantity: 10})
+			expectationRequest.Expectation.GetPlainExpectation().GetTransferExpectation().Outputs = outputs
+			tt, err := transactor.RequestExpectation(expectationRequest)
+			Expect(err).To(MatchError("multiple token types ('XYZ', 'ABC') in expectation outputs"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the inputs are of another type", func() {
+		It("returns an error", func() {
+			expectationRequest.Expectation.GetPlainExpectation().GetTransferExpectation().Outputs[0].Type = "ABC"
+			tt, err := transactor.RequestExpectation(expectationRequest)
+			Expect(err).To(MatchError("token type mismatch in inputs and outputs for expectation (XYZ vs ABC)"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the inputs are not sufficient", func() {
+		It("returns an error", func() {
+			expectationRequest.Expectation.GetPlainExpectation().GetTransferExpectation().Outputs[0].Quantity = 160
+			tt, err := transactor.RequestExpectation(expectationRequest)
+			Expect(err).To(MatchError("total quantity [100] from TokenIds is less than total quantity [160] in expectation"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the sum of the expected outputs overflows", func() {
+		It("returns an error", func() {
+			outputs := expectationRequest.Expectation.GetPlainExpectation().GetTransferExpectation().Outputs
+			outputs = append(outputs, &token.PlainOutput{Owner: []byte("Bob"), Type: "XYZ", Quantity: math.MaxUint64})
+			expectationRequest.Expectation.GetPlainExpectation().GetTransferExpectation().Outputs = outputs
+			tt, err := transactor.RequestExpectation(expectationRequest)
+			Expect(err).To(MatchError(fmt.Sprintf("invalid expectation outputs: token sum overflows (60 + %d)", uint64(math.MaxUint64))))
+			Expect(tt).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("Transactor NonFungible", func() {
+	var (
+		transactor *plain.Transactor
+		fakeLedger *mock.LedgerReader
+		nft        *token.NonFungibleToken
+		nftBytes   []byte
+		tokenIDs   [][]byte
+	)
+
+	BeforeEach(func() {
+		nft = &token.NonFungibleToken{Id: "painting-1", Uri: "https://example.com/painting-1", Hash: []byte("hash")}
+		var err error
+		nftBytes, err = proto.Marshal(&token.PlainOutput{Owner: []byte("Alice"), Type: "ART", Quantity: 1, Nft: nft})
+		Expect(err).NotTo(HaveOccurred())
+		fakeLedger = &mock.LedgerReader{}
+		fakeLedger.GetStateReturns(nftBytes, nil)
+		transactor = &plain.Transactor{PublicCredential: []byte("Alice"), Ledger: fakeLedger}
+		tokenIDs = [][]byte{[]byte("\x00tokenOutput\x00robert\x000\x00")}
+	})
+
+	It("transfers the token as a whole", func() {
+		tt, err := transactor.RequestTransfer(&token.TransferRequest{
+			TokenIds: tokenIDs,
+			Shares:   []*token.RecipientTransferShare{{Recipient: []byte("Bob"), Quantity: 1}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		transfer := tt.GetPlainAction().GetPlainTransfer()
+		Expect(transfer.GetInputs()).To(Equal([]*token.InputId{{TxId: "robert", Index: 0}}))
+		Expect(transfer.GetOutputs()).To(HaveLen(1))
+		Expect(proto.Equal(transfer.GetOutputs()[0], &token.PlainOutput{Owner: []byte("Bob"), Type: "ART", Quantity: 1, Nft: nft})).To(BeTrue())
+	})
+
+	It("returns an error when the shares do not match the tokens", func() {
+		_, err := transactor.RequestTransfer(&token.TransferRequest{
+			TokenIds: tokenIDs,
+			Shares: []*token.RecipientTransferShare{
+				{Recipient: []byte("Bob"), Quantity: 1},
+				{Recipient: []byte("Charlie"), Quantity: 1},
+			},
+		})
+		Expect(err).To(MatchError("the number of shares [2] must match the number of non-fungible tokens [1]"))
+	})
+
+	It("returns an error when a share splits the token", func() {
+		_, err := transactor.RequestTransfer(&token.TransferRequest{
+			TokenIds: tokenIDs,
+			Shares:   []*token.RecipientTransferShare{{Recipient: []byte("Bob"), Quantity: 2}},
+		})
+		Expect(err).To(MatchError("the quantity of a non-fungible token share must be 1, got [2]"))
+	})
+
+	It("returns an error when fungible and non-fungible tokens are spent together", func() {
+		fungibleBytes, err := proto.Marshal(&token.PlainOutput{Owner: []byte("Alice"), Type: "ART", Quantity: 10})
+		Expect(err).NotTo(HaveOccurred())
+		fakeLedger.GetStateReturnsOnCall(1, fungibleBytes, nil)
+
+		_, err = transactor.RequestTransfer(&token.TransferRequest{
+			TokenIds: append(tokenIDs, []byte("\x00tokenOutput\x00robert\x001\x00")),
+			Shares:   []*token.RecipientTransferShare{{Recipient: []byte("Bob"), Quantity: 11}},
+		})
+		Expect(err).To(MatchError("fungible and non-fungible tokens specified in input"))
+	})
+
+	It("redeems the token as a whole", func() {
+		tt, err := transactor.RequestRedeem(&token.RedeemRequest{TokenIds: tokenIDs, QuantityToRedeem: 1})
+		Expect(err).NotTo(HaveOccurred())
+		outputs := tt.GetPlainAction().GetPlainRedeem().GetOutputs()
+		Expect(outputs).To(HaveLen(1))
+		Expect(proto.Equal(outputs[0], &token.PlainOutput{Type: "ART", Quantity: 1, Nft: nft})).To(BeTrue())
+	})
+
+	It("returns an error when redeeming several tokens together", func() {
+		_, err := transactor.RequestRedeem(&token.RedeemRequest{
+			TokenIds:         append(tokenIDs, []byte("\x00tokenOutput\x00robert\x001\x00")),
+			QuantityToRedeem: 2,
+		})
+		Expect(err).To(MatchError("a non-fungible token must be redeemed on its own with quantity 1"))
+	})
+
+	It("returns an error when approving the token", func() {
+		_, err := transactor.RequestApprove(&token.ApproveRequest{
+			TokenIds:        tokenIDs,
+			AllowanceShares: []*token.AllowanceRecipientShare{{Recipient: []byte("Bob"), Quantity: 1}},
+		})
+		Expect(err).To(MatchError("non-fungible tokens cannot be approved"))
+	})
+})
+
+var _ = Describe("Transactor GetSupply", func() {
+	var (
+		fakeLedger   *mock.LedgerReader
+		fakeIterator *mock.ResultsIterator
+		transactor   *plain.Transactor
+		tok1Key      string
+		tok1Supply   *token.TokenSupply
+	)
+
+	BeforeEach(func() {
+		fakeLedger = &mock.LedgerReader{}
+		fakeIterator = &mock.ResultsIterator{}
+		transactor = &plain.Transactor{PublicCredential: []byte("auditor"), Ledger: fakeLedger}
+		tok1Key = strings.Join([]string{"", "tokenSupply", "TOK1", ""}, "\x00")
+		tok1Supply = &token.TokenSupply{Type: "TOK1", Quantity: 100}
+	})
+
+	It("returns the supply of the requested types", func() {
+		fakeLedger.GetStateReturnsOnCall(0, utils.MarshalOrPanic(tok1Supply), nil)
+		fakeLedger.GetStateReturnsOnCall(1, nil, nil)
+
+		supplies, err := transactor.GetSupply(&token.SupplyRequest{Types: []string{"TOK1", "TOK2"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(supplies.Supplies).To(HaveLen(2))
+		Expect(proto.Equal(supplies.Supplies[0], tok1Supply)).To(BeTrue())
+		Expect(proto.Equal(supplies.Supplies[1], &token.TokenSupply{Type: "TOK2"})).To(BeTrue())
+
+		ns, key := fakeLedger.GetStateArgsForCall(0)
+		Expect(ns).To(Equal("tms"))
+		Expect(key).To(Equal(tok1Key))
+	})
+
+	It("returns the supply of all types when no type is requested", func() {
+		fakeLedger.GetStateRangeScanIteratorReturns(fakeIterator, nil)
+		fakeIterator.NextReturnsOnCall(0, &queryresult.KV{Key: tok1Key, Value: utils.MarshalOrPanic(tok1Supply)}, nil)
+		fakeIterator.NextReturnsOnCall(1, nil, nil)
+
+		supplies, err := transactor.GetSupply(&token.SupplyRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(supplies.Supplies).To(HaveLen(1))
+		Expect(proto.Equal(supplies.Supplies[0], tok1Supply)).To(BeTrue())
+
+		ns, start, end := fakeLedger.GetStateRangeScanIteratorArgsForCall(0)
+		Expect(ns).To(Equal("tms"))
+		Expect(start).To(Equal("\x00tokenSupply\x00"))
+		Expect(end).To(Equal("\x00tokenSupply\x00\U0010FFFF"))
+		Expect(fakeIterator.CloseCallCount()).To(Equal(1))
+	})
+
+	Context("when the ledger read fails", func() {
+		BeforeEach(func() {
+			fakeLedger.GetStateReturns(nil, errors.New("wild potato"))
+		})
+
+		It("returns an error", func() {
+			_, err := transactor.GetSupply(&token.SupplyRequest{Types: []string{"TOK1"}})
+			Expect(err).To(MatchError("wild potato"))
+		})
+	})
+
+	Context("when the iterator fails", func() {
+		BeforeEach(func() {
+			fakeLedger.GetStateRangeScanIteratorReturns(fakeIterator, nil)
+			fakeIterator.NextReturns(nil, errors.New("wild banana"))
+		})
+
+		It("returns an error", func() {
+			_, err := transactor.GetSupply(&token.SupplyRequest{})
+			Expect(err).To(MatchError("wild banana"))
+		})
+	})
+})
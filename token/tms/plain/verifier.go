@@ -8,6 +8,7 @@ package plain
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"strconv"
@@ -33,6 +34,10 @@ const (
 	tokenDelegatedOutput  = "tokenDelegatedOutput"
 	tokenInput            = "tokenInput"
 	tokenDelegatedInput   = "tokenDelegateInput"
+	tokenNonFungible      = "tokenNonFungible"
+	tokenLockedOutput     = "tokenLockedOutput"
+	tokenLockedInput      = "tokenLockedInput"
+	tokenSupply           = "tokenSupply"
 	tokenNameSpace        = "tms"
 )
 
@@ -41,13 +46,25 @@ var verifierLogger = flogging.MustGetLogger("token.tms.plain.verifier")
 // A Verifier validates and commits token transactions.
 type Verifier struct {
 	IssuingValidator identity.IssuingValidator
+	// Deserializer is used to verify the signatures of the owners of the inputs of swaps
+	Deserializer identity.Deserializer
+	// SupplyPolicy caps the outstanding supply of token types; if nil, the supply is not capped
+	SupplyPolicy identity.SupplyPolicy
 }
 
 // ProcessTx checks that transactions are correct wrt. the most recent ledger state.
 // ProcessTx checks are ones that shall be done sequentially, since transactions within a block may introduce dependencies.
+// Transactions processed with ProcessTx have no block number, so locked outputs cannot be claimed or reclaimed.
 func (v *Verifier) ProcessTx(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, simulator ledger.LedgerWriter) error {
+	return v.ProcessTxAt(txID, creator, ttx, 0, simulator)
+}
+
+// ProcessTxAt is like ProcessTx, for a transaction committed in the block of number blockNum.
+// The block number is used to enforce the deadlines of locked outputs, so that the creator of a claim
+// or reclaim cannot pick the time its transaction is checked at.
+func (v *Verifier) ProcessTxAt(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, blockNum uint64, simulator ledger.LedgerWriter) error {
 	verifierLogger.Debugf("checking transaction with txID '%s'", txID)
-	err := v.checkProcess(txID, creator, ttx, simulator)
+	err := v.checkProcess(txID, creator, ttx, blockNum, simulator)
 	if err != nil {
 		return err
 	}
@@ -62,13 +79,13 @@ func (v *Verifier) ProcessTx(txID string, creator identity.PublicInfo, ttx *toke
 	return nil
 }
 
-func (v *Verifier) checkProcess(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, simulator ledger.LedgerReader) error {
+func (v *Verifier) checkProcess(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, blockNum uint64, simulator ledger.LedgerReader) error {
 	action := ttx.GetPlainAction()
 	if action == nil {
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("check process failed for transaction '%s': missing token action", txID)}
 	}
 
-	err := v.checkAction(creator, action, txID, simulator)
+	err := v.checkAction(creator, action, txID, blockNum, simulator)
 	if err != nil {
 		return err
 	}
@@ -80,7 +97,7 @@ func (v *Verifier) checkProcess(txID string, creator identity.PublicInfo, ttx *t
 	return nil
 }
 
-func (v *Verifier) checkAction(creator identity.PublicInfo, plainAction *token.PlainTokenAction, txID string, simulator ledger.LedgerReader) error {
+func (v *Verifier) checkAction(creator identity.PublicInfo, plainAction *token.PlainTokenAction, txID string, blockNum uint64, simulator ledger.LedgerReader) error {
 	switch action := plainAction.Data.(type) {
 	case *token.PlainTokenAction_PlainImport:
 		return v.checkImportAction(creator, action.PlainImport, txID, simulator)
@@ -90,6 +107,16 @@ func (v *Verifier) checkAction(creator identity.PublicInfo, plainAction *token.P
 		return v.checkRedeemAction(creator, action.PlainRedeem, txID, simulator)
 	case *token.PlainTokenAction_PlainApprove:
 		return v.checkApproveAction(creator, action.PlainApprove, txID, simulator)
+	case *token.PlainTokenAction_PlainTransfer_From:
+		return v.checkTransferFromAction(creator, action.PlainTransfer_From, txID, simulator)
+	case *token.PlainTokenAction_PlainSwap:
+		return v.checkSwapAction(creator, action.PlainSwap, txID, simulator)
+	case *token.PlainTokenAction_PlainLock:
+		return v.checkLockAction(creator, action.PlainLock, txID, blockNum, simulator)
+	case *token.PlainTokenAction_PlainClaim:
+		return v.checkClaimAction(creator, action.PlainClaim, txID, blockNum, simulator)
+	case *token.PlainTokenAction_PlainReclaim:
+		return v.checkReclaimAction(creator, action.PlainReclaim, txID, blockNum, simulator)
 	default:
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("unknown plain token action: %T", action)}
 	}
@@ -100,13 +127,71 @@ func (v *Verifier) checkImportAction(creator identity.PublicInfo, importAction *
 	if err != nil {
 		return err
 	}
-	return v.checkImportPolicy(creator, txID, importAction)
+	err = v.checkImportPolicy(creator, txID, importAction)
+	if err != nil {
+		return err
+	}
+	return v.checkImportSupply(importAction.GetOutputs(), txID, simulator)
+}
+
+// checkImportSupply checks that an import does not raise the outstanding supply
+// of any token type beyond the maximum set by the supply policy.
+func (v *Verifier) checkImportSupply(outputs []*token.PlainOutput, txID string, simulator ledger.LedgerReader) error {
+	types, imported, err := importedQuantities(outputs, txID)
+	if err != nil {
+		return err
+	}
+	for _, tokenType := range types {
+		supply, err := v.getSupply(tokenType, simulator)
+		if err != nil {
+			return err
+		}
+		quantity := imported[tokenType]
+		if _, err := AddQuantity(supply, quantity); err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("%s in the supply of token type '%s' for import with ID %s", err, tokenType, txID)}
+		}
+		if v.SupplyPolicy == nil {
+			continue
+		}
+		if max := v.SupplyPolicy.MaxSupply(tokenType); max != 0 && supply+quantity > max {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("import with ID %s exceeds the maximum supply of token type '%s' (%d + %d > %d)", txID, tokenType, supply, quantity, max)}
+		}
+	}
+	return nil
+}
+
+// AddQuantity returns the sum of two token quantities, or an error if the sum overflows.
+func AddQuantity(sum, quantity uint64) (uint64, error) {
+	if sum+quantity < sum {
+		return 0, errors.Errorf("token sum overflows (%d + %d)", sum, quantity)
+	}
+	return sum + quantity, nil
+}
+
+// importedQuantities returns the token types of the outputs of an import, in order of appearance,
+// and the quantity imported for each type.
+func importedQuantities(outputs []*token.PlainOutput, txID string) ([]string, map[string]uint64, error) {
+	var types []string
+	imported := make(map[string]uint64)
+	for _, output := range outputs {
+		quantity, ok := imported[output.Type]
+		if !ok {
+			types = append(types, output.Type)
+		}
+		quantity, err := AddQuantity(quantity, output.Quantity)
+		if err != nil {
+			return nil, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("%s in the supply of token type '%s' for import with ID %s", err, output.Type, txID)}
+		}
+		imported[output.Type] = quantity
+	}
+	return types, imported, nil
 }
 
 func (v *Verifier) checkImportOutputs(outputs []*token.PlainOutput, txID string, simulator ledger.LedgerReader) error {
 	if len(outputs) == 0 {
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no outputs in transaction: %s", txID)}
 	}
+	issuedNfts := make(map[string]bool)
 	for i, output := range outputs {
 		err := v.checkOutputDoesNotExist(i, txID, simulator)
 		if err != nil {
@@ -116,16 +201,60 @@ func (v *Verifier) checkImportOutputs(outputs []*token.PlainOutput, txID string,
 		if output.Quantity == 0 {
 			return &customtx.InvalidTxError{Msg: fmt.Sprintf("output %d quantity is 0 in transaction: %s", i, txID)}
 		}
+
+		if output.Nft != nil {
+			nftKey, err := v.checkNonFungibleOutput(i, output, txID)
+			if err != nil {
+				return err
+			}
+			if issuedNfts[nftKey] {
+				return &customtx.InvalidTxError{Msg: fmt.Sprintf("non-fungible token '%s' of type '%s' issued more than once in transaction: %s", output.Nft.Id, output.Type, txID)}
+			}
+			issuedNfts[nftKey] = true
+			err = v.checkNonFungibleNotIssued(nftKey, output, simulator)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkNonFungibleOutput checks that an output carrying a non-fungible token
+// has quantity 1 and a token id, and returns the registry key of the token.
+func (v *Verifier) checkNonFungibleOutput(index int, output *token.PlainOutput, txID string) (string, error) {
+	if output.Quantity != 1 {
+		return "", &customtx.InvalidTxError{Msg: fmt.Sprintf("output %d carries a non-fungible token but its quantity is %d in transaction: %s", index, output.Quantity, txID)}
+	}
+	if output.Nft.Id == "" {
+		return "", &customtx.InvalidTxError{Msg: fmt.Sprintf("output %d carries a non-fungible token without id in transaction: %s", index, txID)}
+	}
+	nftKey, err := createNonFungibleKey(output.Type, output.Nft.Id)
+	if err != nil {
+		return "", &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating non-fungible token key: %s", err)}
+	}
+	return nftKey, nil
+}
+
+// checkNonFungibleNotIssued checks that a non-fungible token has never been issued before.
+// The registry key of a non-fungible token is never deleted, so a redeemed token cannot be issued again.
+func (v *Verifier) checkNonFungibleNotIssued(nftKey string, output *token.PlainOutput, simulator ledger.LedgerReader) error {
+	existing, err := simulator.GetState(tokenNameSpace, nftKey)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("non-fungible token '%s' of type '%s' already issued", output.Nft.Id, output.Type)}
 	}
 	return nil
 }
 
 func (v *Verifier) checkTransferAction(creator identity.PublicInfo, transferAction *token.PlainTransfer, txID string, simulator ledger.LedgerReader) error {
-	outputType, outputSum, err := v.checkTransferOutputs(transferAction.GetOutputs(), txID, simulator)
+	outputType, outputSum, outputNfts, err := v.checkTransferOutputs(transferAction.GetOutputs(), txID, simulator)
 	if err != nil {
 		return err
 	}
-	inputType, inputSum, err := v.checkTransferInputs(creator, transferAction.GetInputs(), txID, simulator)
+	inputType, inputSum, inputNfts, err := v.checkTransferInputs(creator, transferAction.GetInputs(), txID, simulator)
 	if err != nil {
 		return err
 	}
@@ -135,6 +264,25 @@ func (v *Verifier) checkTransferAction(creator identity.PublicInfo, transferActi
 	if outputSum != inputSum {
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token sum mismatch in inputs and outputs for transfer with ID %s (%d vs %d)", txID, outputSum, inputSum)}
 	}
+	return v.checkSameNonFungibles(inputNfts, outputNfts, txID)
+}
+
+// checkSameNonFungibles checks that the outputs of a transfer carry exactly the non-fungible tokens of its inputs.
+func (v *Verifier) checkSameNonFungibles(inputNfts, outputNfts []*token.NonFungibleToken, txID string) error {
+	if len(inputNfts) != len(outputNfts) {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("non-fungible token mismatch in inputs and outputs for transfer with ID %s (%d vs %d)", txID, len(inputNfts), len(outputNfts))}
+	}
+	remaining := make(map[string]*token.NonFungibleToken)
+	for _, nft := range inputNfts {
+		remaining[nft.Id] = nft
+	}
+	for _, nft := range outputNfts {
+		input, ok := remaining[nft.Id]
+		if !ok || !proto.Equal(input, nft) {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("non-fungible token '%s' in outputs for transfer with ID %s is not spent by its inputs", nft.Id, txID)}
+		}
+		delete(remaining, nft.Id)
+	}
 	return nil
 }
 
@@ -157,6 +305,11 @@ func (v *Verifier) checkRedeemAction(creator identity.PublicInfo, redeemAction *
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("owner should be nil in a redeem output")}
 	}
 
+	// a non-fungible token is redeemed as a whole, on its own
+	if outputs[0].Nft != nil && len(outputs) != 1 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("a redeem of a non-fungible token must have exactly one output")}
+	}
+
 	// if output[1] presents, its owner must be same as the creator
 	if len(outputs) == 2 && !bytes.Equal(creator.Public(), outputs[1].Owner) {
 		println(hex.EncodeToString(creator.Public()))
@@ -184,64 +337,83 @@ func (v *Verifier) checkOutputDoesNotExist(index int, txID string, simulator led
 	return nil
 }
 
-func (v *Verifier) checkTransferOutputs(outputs []*token.PlainOutput, txID string, simulator ledger.LedgerReader) (string, uint64, error) {
+// checkTransferOutputs returns the type of the outputs, the sum of their quantities and the non-fungible tokens they carry.
+func (v *Verifier) checkTransferOutputs(outputs []*token.PlainOutput, txID string, simulator ledger.LedgerReader) (string, uint64, []*token.NonFungibleToken, error) {
 	tokenType := ""
 	tokenSum := uint64(0)
+	var nfts []*token.NonFungibleToken
 	for i, output := range outputs {
 		err := v.checkOutputDoesNotExist(i, txID, simulator)
 		if err != nil {
-			return "", 0, err
+			return "", 0, nil, err
 		}
 		if tokenType == "" {
 			tokenType = output.GetType()
 		} else if tokenType != output.GetType() {
-			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("multiple token types ('%s', '%s') in transfer output for txID '%s'", tokenType, output.GetType(), txID)}
+			return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("multiple token types ('%s', '%s') in transfer output for txID '%s'", tokenType, output.GetType(), txID)}
+		}
+		if output.Nft != nil {
+			if _, err := v.checkNonFungibleOutput(i, output, txID); err != nil {
+				return "", 0, nil, err
+			}
+			nfts = append(nfts, output.Nft)
+		}
+		tokenSum, err = AddQuantity(tokenSum, output.GetQuantity())
+		if err != nil {
+			return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("%s in transfer output for txID '%s'", err, txID)}
 		}
-		tokenSum += output.GetQuantity()
 	}
-	return tokenType, tokenSum, nil
+	return tokenType, tokenSum, nfts, nil
 }
 
-func (v *Verifier) checkTransferInputs(creator identity.PublicInfo, inputIDs []*token.InputId, txID string, simulator ledger.LedgerReader) (string, uint64, error) {
+// checkTransferInputs returns the type of the inputs, the sum of their quantities and the non-fungible tokens they carry.
+func (v *Verifier) checkTransferInputs(creator identity.PublicInfo, inputIDs []*token.InputId, txID string, simulator ledger.LedgerReader) (string, uint64, []*token.NonFungibleToken, error) {
 	tokenType := ""
 	inputSum := uint64(0)
+	var nfts []*token.NonFungibleToken
 	processedIDs := make(map[string]bool)
 	for _, id := range inputIDs {
 		inputKey, err := createOutputKey(id.TxId, int(id.Index))
 		if err != nil {
-			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID for transfer input: %s", err)}
+			return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID for transfer input: %s", err)}
 		}
 		input, err := v.getOutput(inputKey, simulator)
 		if err != nil {
-			return "", 0, err
+			return "", 0, nil, err
 		}
 		err = v.checkInputOwner(creator, input, inputKey)
 		if err != nil {
-			return "", 0, err
+			return "", 0, nil, err
 		}
 		if tokenType == "" {
 			tokenType = input.GetType()
 		} else if tokenType != input.GetType() {
-			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("multiple token types in transfer input for txID: %s (%s, %s)", txID, tokenType, input.GetType())}
+			return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("multiple token types in transfer input for txID: %s (%s, %s)", txID, tokenType, input.GetType())}
 		}
 		if processedIDs[inputKey] {
-			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("token input '%s' spent more than once in single transfer with txID '%s'", inputKey, txID)}
+			return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("token input '%s' spent more than once in single transfer with txID '%s'", inputKey, txID)}
 		}
 		processedIDs[inputKey] = true
-		inputSum += input.GetQuantity()
+		inputSum, err = AddQuantity(inputSum, input.GetQuantity())
+		if err != nil {
+			return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("%s in transfer input for txID: %s", err, txID)}
+		}
+		if input.Nft != nil {
+			nfts = append(nfts, input.Nft)
+		}
 		spentKey, err := createSpentKey(id.TxId, int(id.Index))
 		if err != nil {
-			return "", 0, err
+			return "", 0, nil, err
 		}
 		spent, err := v.isSpent(spentKey, simulator)
 		if err != nil {
-			return "", 0, err
+			return "", 0, nil, err
 		}
 		if spent {
-			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s for transfer has already been spent", inputKey)}
+			return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s for transfer has already been spent", inputKey)}
 		}
 	}
-	return tokenType, inputSum, nil
+	return tokenType, inputSum, nfts, nil
 }
 
 func (v *Verifier) checkInputOwner(creator identity.PublicInfo, input *token.PlainOutput, inputID string) error {
@@ -306,8 +478,21 @@ func (v *Verifier) commitAction(plainAction *token.PlainTokenAction, txID string
 	case *token.PlainTokenAction_PlainRedeem:
 		// call the same commit method as transfer because PlainRedeem points to the same type of outputs as transfer
 		err = v.commitTransferAction(action.PlainRedeem, txID, simulator)
+		if err == nil {
+			err = v.commitRedeemSupply(action.PlainRedeem, simulator)
+		}
 	case *token.PlainTokenAction_PlainApprove:
 		err = v.commitApproveAction(action.PlainApprove, txID, simulator)
+	case *token.PlainTokenAction_PlainTransfer_From:
+		err = v.commitTransferFromAction(action.PlainTransfer_From, txID, simulator)
+	case *token.PlainTokenAction_PlainSwap:
+		err = v.commitSwapAction(action.PlainSwap, txID, simulator)
+	case *token.PlainTokenAction_PlainLock:
+		err = v.commitLockAction(action.PlainLock, txID, simulator)
+	case *token.PlainTokenAction_PlainClaim:
+		err = v.commitUnlockAction(action.PlainClaim.GetInput(), true, txID, simulator)
+	case *token.PlainTokenAction_PlainReclaim:
+		err = v.commitUnlockAction(action.PlainReclaim.GetInput(), false, txID, simulator)
 	}
 	return
 }
@@ -323,10 +508,55 @@ func (v *Verifier) commitImportAction(importAction *token.PlainImport, txID stri
 		if err != nil {
 			return err
 		}
+
+		if output.Nft != nil {
+			err = v.registerNonFungible(output, simulator)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// importedQuantities() error already checked in checkImportSupply
+	types, imported, _ := importedQuantities(importAction.GetOutputs(), txID)
+	for _, tokenType := range types {
+		supply, err := v.getSupply(tokenType, simulator)
+		if err != nil {
+			return err
+		}
+		err = v.setSupply(tokenType, supply+imported[tokenType], simulator)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// commitRedeemSupply decreases the outstanding supply of the redeemed token type.
+// The supply of tokens imported before supply tracking was introduced is not recorded,
+// so the supply never goes below 0.
+func (v *Verifier) commitRedeemSupply(redeemAction *token.PlainTransfer, simulator ledger.LedgerWriter) error {
+	redeemed := redeemAction.GetOutputs()[0]
+	supply, err := v.getSupply(redeemed.Type, simulator)
+	if err != nil {
+		return err
+	}
+	if supply < redeemed.Quantity {
+		verifierLogger.Debugf("supply of token type '%s' is %d, lower than the redeemed quantity %d", redeemed.Type, supply, redeemed.Quantity)
+		supply = redeemed.Quantity
+	}
+	return v.setSupply(redeemed.Type, supply-redeemed.Quantity, simulator)
+}
+
+// registerNonFungible records the issuance of a non-fungible token, so that it is never issued again.
+func (v *Verifier) registerNonFungible(output *token.PlainOutput, simulator ledger.LedgerWriter) error {
+	nftKey, err := createNonFungibleKey(output.Type, output.Nft.Id)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating non-fungible token key: %s", err)}
+	}
+	return simulator.SetState(tokenNameSpace, nftKey, utils.MarshalOrPanic(output.Nft))
+}
+
 // commitTransferAction is called for both transfer and redeem transactions
 // Check the owner of each output to determine how to generate the key
 func (v *Verifier) commitTransferAction(transferAction *token.PlainTransfer, txID string, simulator ledger.LedgerWriter) error {
@@ -355,10 +585,13 @@ func (v *Verifier) checkApproveAction(creator identity.PublicInfo, approveAction
 	if err != nil {
 		return err
 	}
-	inputType, inputSum, err := v.checkTransferInputs(creator, approveAction.GetInputs(), txID, simulator)
+	inputType, inputSum, inputNfts, err := v.checkTransferInputs(creator, approveAction.GetInputs(), txID, simulator)
 	if err != nil {
 		return err
 	}
+	if len(inputNfts) != 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("non-fungible tokens cannot be approved in approve with ID %s", txID)}
+	}
 	if outputType != inputType {
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type mismatch in inputs and outputs for approve with ID %s (%s vs %s)", txID, outputType, inputType)}
 	}
@@ -401,6 +634,9 @@ func (v *Verifier) checkApproveOutputs(creator identity.PublicInfo, output *toke
 		if !bytes.Equal(output.Owner, creator.Public()) {
 			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("the owner of the output is not valid")}
 		}
+		if output.Nft != nil {
+			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("the output for approve txID '%s' carries a non-fungible token", txID)}
+		}
 		tokenType = output.GetType()
 		err := v.checkOutputDoesNotExist(0, txID, simulator)
 		if err != nil {
@@ -458,12 +694,532 @@ func (v *Verifier) checkApproveOutputs(creator identity.PublicInfo, output *toke
 		if spent {
 			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("the delegated output for approve txID '%s' is invalid: cannot create a spent key", txID)}
 		}
-		tokenSum += delegatedOutput.GetQuantity()
+		tokenSum, err = AddQuantity(tokenSum, delegatedOutput.GetQuantity())
+		if err != nil {
+			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("%s in delegated outputs for approve txID '%s'", err, txID)}
+		}
 	}
 
 	return tokenType, tokenSum, nil
 }
 
+func (v *Verifier) checkTransferFromAction(creator identity.PublicInfo, transferFromAction *token.PlainTransferFrom, txID string, simulator ledger.LedgerReader) error {
+	inputType, inputSum, owner, err := v.checkDelegatedInputs(creator, transferFromAction.GetInputs(), txID, simulator)
+	if err != nil {
+		return err
+	}
+	outputType, outputSum, outputNfts, err := v.checkTransferOutputs(transferFromAction.GetOutputs(), txID, simulator)
+	if err != nil {
+		return err
+	}
+	if len(outputNfts) != 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("non-fungible token in outputs for transfer from with ID %s", txID)}
+	}
+	if len(transferFromAction.GetOutputs()) == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no outputs in transfer from with ID %s", txID)}
+	}
+	if outputType != inputType {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type mismatch in inputs and outputs for transfer from with ID %s (%s vs %s)", txID, outputType, inputType)}
+	}
+
+	// the remaining quantity, if any, must stay delegated to the creator by the same owner
+	delegatedOutput := transferFromAction.GetDelegatedOutput()
+	if delegatedOutput != nil {
+		if !bytes.Equal(delegatedOutput.Owner, owner) {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("the owner of the delegated output in transfer from with ID %s is invalid", txID)}
+		}
+		if len(delegatedOutput.Delegatees) != 1 || !bytes.Equal(delegatedOutput.Delegatees[0], creator.Public()) {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("the delegatee of the delegated output in transfer from with ID %s is not the creator", txID)}
+		}
+		if delegatedOutput.Type != inputType {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type mismatch in inputs and delegated output for transfer from with ID %s (%s vs %s)", txID, delegatedOutput.Type, inputType)}
+		}
+		if delegatedOutput.Quantity == 0 {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("delegated output quantity is 0 in transfer from with ID %s", txID)}
+		}
+		err = v.checkDelegatedOutputDoesNotExist(0, txID, simulator)
+		if err != nil {
+			return err
+		}
+		outputSum, err = AddQuantity(outputSum, delegatedOutput.Quantity)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("%s in transfer from with ID %s", err, txID)}
+		}
+	}
+
+	if outputSum != inputSum {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token sum mismatch in inputs and outputs for transfer from with ID %s (%d vs %d)", txID, outputSum, inputSum)}
+	}
+	return nil
+}
+
+// checkDelegatedInputs checks that the inputs of a transfer from are unspent delegated outputs of a single owner
+// and type, whose delegatees include the creator. It returns their type, the sum of their quantities and their owner.
+func (v *Verifier) checkDelegatedInputs(creator identity.PublicInfo, inputIDs []*token.InputId, txID string, simulator ledger.LedgerReader) (string, uint64, []byte, error) {
+	if len(inputIDs) == 0 {
+		return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("no inputs in transfer from with ID %s", txID)}
+	}
+	tokenType := ""
+	inputSum := uint64(0)
+	var owner []byte
+	processedIDs := make(map[string]bool)
+	for _, id := range inputIDs {
+		inputKey, err := createDelegatedOutputKey(id.TxId, int(id.Index))
+		if err != nil {
+			return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating delegated output ID for transfer from input: %s", err)}
+		}
+		input, err := v.getDelegatedOutput(inputKey, simulator)
+		if err != nil {
+			return "", 0, nil, err
+		}
+		if !isDelegatee(creator.Public(), input) {
+			return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("transfer from input with ID %s not delegated to creator", inputKey)}
+		}
+		if owner == nil {
+			owner = input.Owner
+		} else if !bytes.Equal(owner, input.Owner) {
+			return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("multiple owners in transfer from input for txID: %s", txID)}
+		}
+		if tokenType == "" {
+			tokenType = input.GetType()
+		} else if tokenType != input.GetType() {
+			return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("multiple token types in transfer from input for txID: %s (%s, %s)", txID, tokenType, input.GetType())}
+		}
+		if processedIDs[inputKey] {
+			return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("token input '%s' spent more than once in single transfer from with txID '%s'", inputKey, txID)}
+		}
+		processedIDs[inputKey] = true
+		inputSum, err = AddQuantity(inputSum, input.GetQuantity())
+		if err != nil {
+			return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("%s in transfer from input for txID: %s", err, txID)}
+		}
+		spentKey, err := createSpentDelegatedOutputKey(id.TxId, int(id.Index))
+		if err != nil {
+			return "", 0, nil, err
+		}
+		spent, err := v.isSpent(spentKey, simulator)
+		if err != nil {
+			return "", 0, nil, err
+		}
+		if spent {
+			return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s for transfer from has already been spent", inputKey)}
+		}
+	}
+	return tokenType, inputSum, owner, nil
+}
+
+func (v *Verifier) commitTransferFromAction(transferFromAction *token.PlainTransferFrom, txID string, simulator ledger.LedgerWriter) error {
+	for i, output := range transferFromAction.GetOutputs() {
+		outputID, err := createOutputKey(txID, i)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID: %s", err)}
+		}
+		err = v.addOutput(outputID, output, simulator)
+		if err != nil {
+			return err
+		}
+	}
+	if transferFromAction.GetDelegatedOutput() != nil {
+		// createDelegatedOutputKey() error already checked in checkDelegatedOutputDoesNotExist
+		outputID, _ := createDelegatedOutputKey(txID, 0)
+		err := v.addDelegatedOutput(outputID, transferFromAction.GetDelegatedOutput(), simulator)
+		if err != nil {
+			return err
+		}
+	}
+	for _, id := range transferFromAction.GetInputs() {
+		inputID, err := createSpentDelegatedOutputKey(id.TxId, int(id.Index))
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating spent key: %s", err)}
+		}
+		verifierLogger.Debugf("marking delegated input '%s' as spent", inputID)
+		err = simulator.SetState(tokenNameSpace, inputID, TokenInputSpentMarker)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *Verifier) checkSwapAction(creator identity.PublicInfo, swapAction *token.PlainSwap, txID string, simulator ledger.LedgerReader) error {
+	if len(swapAction.GetInputs()) == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no inputs in swap with ID %s", txID)}
+	}
+	if len(swapAction.GetOutputs()) == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no outputs in swap with ID %s", txID)}
+	}
+	inputTypes, inputSums, owners, inputNfts, err := v.checkSwapInputs(swapAction.GetInputs(), txID, simulator)
+	if err != nil {
+		return err
+	}
+	outputTypes, outputSums, outputNfts, err := v.checkSwapOutputs(swapAction.GetOutputs(), txID, simulator)
+	if err != nil {
+		return err
+	}
+
+	// every token type is balanced on its own
+	for _, tokenType := range outputTypes {
+		if _, ok := inputSums[tokenType]; !ok {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type %s in outputs is not in inputs for swap with ID %s", tokenType, txID)}
+		}
+	}
+	for _, tokenType := range inputTypes {
+		if outputSums[tokenType] != inputSums[tokenType] {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("token sum mismatch in inputs and outputs of type %s for swap with ID %s (%d vs %d)", tokenType, txID, outputSums[tokenType], inputSums[tokenType])}
+		}
+	}
+	err = v.checkSameNonFungibles(inputNfts, outputNfts, txID)
+	if err != nil {
+		return err
+	}
+
+	return v.checkSwapSignatures(creator, swapAction, owners, txID)
+}
+
+// checkSwapInputs checks that the inputs of a swap are unspent outputs, and returns their token types in order of appearance,
+// the sum of their quantities by type, their owners in order of appearance and the non-fungible tokens they carry.
+func (v *Verifier) checkSwapInputs(inputIDs []*token.InputId, txID string, simulator ledger.LedgerReader) ([]string, map[string]uint64, [][]byte, []*token.NonFungibleToken, error) {
+	var tokenTypes []string
+	inputSums := make(map[string]uint64)
+	var owners [][]byte
+	var nfts []*token.NonFungibleToken
+	processedIDs := make(map[string]bool)
+	for _, id := range inputIDs {
+		inputKey, err := createOutputKey(id.TxId, int(id.Index))
+		if err != nil {
+			return nil, nil, nil, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID for swap input: %s", err)}
+		}
+		if processedIDs[inputKey] {
+			return nil, nil, nil, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("token input '%s' spent more than once in single swap with txID '%s'", inputKey, txID)}
+		}
+		processedIDs[inputKey] = true
+		input, err := v.getOutput(inputKey, simulator)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		spentKey, err := createSpentKey(id.TxId, int(id.Index))
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		spent, err := v.isSpent(spentKey, simulator)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		if spent {
+			return nil, nil, nil, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s for swap has already been spent", inputKey)}
+		}
+
+		if _, ok := inputSums[input.Type]; !ok {
+			tokenTypes = append(tokenTypes, input.Type)
+		}
+		inputSums[input.Type], err = AddQuantity(inputSums[input.Type], input.Quantity)
+		if err != nil {
+			return nil, nil, nil, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("%s in swap inputs of type %s for txID '%s'", err, input.Type, txID)}
+		}
+		if !containsOwner(owners, input.Owner) {
+			owners = append(owners, input.Owner)
+		}
+		if input.Nft != nil {
+			nfts = append(nfts, input.Nft)
+		}
+	}
+	return tokenTypes, inputSums, owners, nfts, nil
+}
+
+// checkSwapOutputs returns the token types of the outputs of a swap in order of appearance,
+// the sum of their quantities by type and the non-fungible tokens they carry.
+func (v *Verifier) checkSwapOutputs(outputs []*token.PlainOutput, txID string, simulator ledger.LedgerReader) ([]string, map[string]uint64, []*token.NonFungibleToken, error) {
+	var tokenTypes []string
+	outputSums := make(map[string]uint64)
+	var nfts []*token.NonFungibleToken
+	for i, output := range outputs {
+		err := v.checkOutputDoesNotExist(i, txID, simulator)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if len(output.Owner) == 0 {
+			return nil, nil, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("output %d has no owner in swap with ID %s", i, txID)}
+		}
+		if output.Quantity == 0 {
+			return nil, nil, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("output %d quantity is 0 in swap with ID %s", i, txID)}
+		}
+		if output.Nft != nil {
+			if _, err := v.checkNonFungibleOutput(i, output, txID); err != nil {
+				return nil, nil, nil, err
+			}
+			nfts = append(nfts, output.Nft)
+		}
+		if _, ok := outputSums[output.Type]; !ok {
+			tokenTypes = append(tokenTypes, output.Type)
+		}
+		outputSums[output.Type], err = AddQuantity(outputSums[output.Type], output.Quantity)
+		if err != nil {
+			return nil, nil, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("%s in swap outputs of type %s for txID '%s'", err, output.Type, txID)}
+		}
+	}
+	return tokenTypes, outputSums, nfts, nil
+}
+
+// checkSwapSignatures checks that every owner of the inputs of a swap, other than the creator, has signed the swap.
+// The inputs of the creator are authorized by the signature of the transaction itself.
+func (v *Verifier) checkSwapSignatures(creator identity.PublicInfo, swapAction *token.PlainSwap, owners [][]byte, txID string) error {
+	var message []byte
+	for _, owner := range owners {
+		if bytes.Equal(owner, creator.Public()) {
+			continue
+		}
+		signature := findSwapSignature(swapAction.GetSignatures(), owner)
+		if signature == nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("missing signature of an input owner in swap with ID %s", txID)}
+		}
+		if v.Deserializer == nil {
+			return errors.Errorf("no deserializer to verify the signatures of swap with ID %s", txID)
+		}
+		if message == nil {
+			var err error
+			message, err = swapAction.SigningBytes()
+			if err != nil {
+				return &customtx.InvalidTxError{Msg: fmt.Sprintf("error marshaling swap with ID %s: %s", txID, err)}
+			}
+		}
+		signer, err := v.Deserializer.DeserializeIdentity(owner)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error deserializing input owner in swap with ID %s: %s", txID, err)}
+		}
+		err = signer.Verify(message, signature.Signature)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid signature of an input owner in swap with ID %s: %s", txID, err)}
+		}
+	}
+	return nil
+}
+
+func (v *Verifier) commitSwapAction(swapAction *token.PlainSwap, txID string, simulator ledger.LedgerWriter) error {
+	for i, output := range swapAction.GetOutputs() {
+		outputID, err := createOutputKey(txID, i)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID: %s", err)}
+		}
+		err = v.addOutput(outputID, output, simulator)
+		if err != nil {
+			return err
+		}
+	}
+	return v.markInputsSpent(txID, swapAction.GetInputs(), simulator)
+}
+
+func (v *Verifier) checkLockAction(creator identity.PublicInfo, lockAction *token.PlainLock, txID string, blockNum uint64, simulator ledger.LedgerReader) error {
+	inputType, inputSum, inputNfts, err := v.checkTransferInputs(creator, lockAction.GetInputs(), txID, simulator)
+	if err != nil {
+		return err
+	}
+	if len(lockAction.GetInputs()) == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no inputs in lock with ID %s", txID)}
+	}
+
+	lockedOutput := lockAction.GetLockedOutput()
+	if lockedOutput == nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no locked output in lock with ID %s", txID)}
+	}
+	if !bytes.Equal(lockedOutput.Sender, creator.Public()) {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("the sender of the locked output in lock with ID %s is not the creator", txID)}
+	}
+	if len(lockedOutput.Recipient) == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("the locked output in lock with ID %s has no recipient", txID)}
+	}
+	if lockedOutput.Type != inputType {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type mismatch in inputs and locked output for lock with ID %s (%s vs %s)", txID, lockedOutput.Type, inputType)}
+	}
+	if lockedOutput.Quantity == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("locked output quantity is 0 in lock with ID %s", txID)}
+	}
+	if len(lockedOutput.Hash) != sha256.Size {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("the hash of the locked output in lock with ID %s is not a SHA-256 hash", txID)}
+	}
+	if blockNum != 0 && blockNum >= lockedOutput.Deadline {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("the deadline of the locked output in lock with ID %s has already passed", txID)}
+	}
+	var lockedNfts []*token.NonFungibleToken
+	if lockedOutput.Nft != nil {
+		if lockedOutput.Quantity != 1 {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("the locked output in lock with ID %s carries a non-fungible token but its quantity is %d", txID, lockedOutput.Quantity)}
+		}
+		lockedNfts = append(lockedNfts, lockedOutput.Nft)
+	}
+	err = v.checkLockedOutputDoesNotExist(0, txID, simulator)
+	if err != nil {
+		return err
+	}
+
+	// the remaining quantity, if any, goes back to the sender
+	outputSum := lockedOutput.Quantity
+	if output := lockAction.GetOutput(); output != nil {
+		if !bytes.Equal(output.Owner, creator.Public()) {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("the owner of the output in lock with ID %s is not the creator", txID)}
+		}
+		if output.Type != inputType {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type mismatch in inputs and output for lock with ID %s (%s vs %s)", txID, output.Type, inputType)}
+		}
+		if output.Quantity == 0 {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("output quantity is 0 in lock with ID %s", txID)}
+		}
+		if output.Nft != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("the output in lock with ID %s carries a non-fungible token", txID)}
+		}
+		err = v.checkOutputDoesNotExist(0, txID, simulator)
+		if err != nil {
+			return err
+		}
+		outputSum, err = AddQuantity(outputSum, output.Quantity)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("%s in lock with ID %s", err, txID)}
+		}
+	}
+	if outputSum != inputSum {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token sum mismatch in inputs and outputs for lock with ID %s (%d vs %d)", txID, outputSum, inputSum)}
+	}
+	return v.checkSameNonFungibles(inputNfts, lockedNfts, txID)
+}
+
+func (v *Verifier) checkClaimAction(creator identity.PublicInfo, claimAction *token.PlainClaim, txID string, blockNum uint64, simulator ledger.LedgerReader) error {
+	lockedOutput, err := v.checkLockedInput(claimAction.GetInput(), txID, simulator)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(lockedOutput.Recipient, creator.Public()) {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("the creator of claim with ID %s is not the recipient of the locked output", txID)}
+	}
+	if blockNum == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no block number for claim with ID %s", txID)}
+	}
+	if blockNum >= lockedOutput.Deadline {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("the locked output of claim with ID %s expired at block %d", txID, lockedOutput.Deadline)}
+	}
+	hash := sha256.Sum256(claimAction.GetPreimage())
+	if !bytes.Equal(hash[:], lockedOutput.Hash) {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("the preimage in claim with ID %s does not match the hash of the locked output", txID)}
+	}
+	return v.checkOutputDoesNotExist(0, txID, simulator)
+}
+
+func (v *Verifier) checkReclaimAction(creator identity.PublicInfo, reclaimAction *token.PlainReclaim, txID string, blockNum uint64, simulator ledger.LedgerReader) error {
+	lockedOutput, err := v.checkLockedInput(reclaimAction.GetInput(), txID, simulator)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(lockedOutput.Sender, creator.Public()) {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("the creator of reclaim with ID %s is not the sender of the locked output", txID)}
+	}
+	if blockNum == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no block number for reclaim with ID %s", txID)}
+	}
+	if blockNum < lockedOutput.Deadline {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("the locked output of reclaim with ID %s is locked until block %d", txID, lockedOutput.Deadline)}
+	}
+	return v.checkOutputDoesNotExist(0, txID, simulator)
+}
+
+// checkLockedInput checks that the input of a claim or reclaim is an unspent locked output, and returns it.
+func (v *Verifier) checkLockedInput(input *token.InputId, txID string, simulator ledger.LedgerReader) (*token.PlainLockedOutput, error) {
+	if input == nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("no input in transaction with ID %s", txID)}
+	}
+	inputKey, err := createLockedOutputKey(input.TxId, int(input.Index))
+	if err != nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating locked output ID: %s", err)}
+	}
+	lockedOutput, err := v.getLockedOutput(inputKey, simulator)
+	if err != nil {
+		return nil, err
+	}
+	spentKey, err := createSpentLockedOutputKey(input.TxId, int(input.Index))
+	if err != nil {
+		return nil, err
+	}
+	spent, err := v.isSpent(spentKey, simulator)
+	if err != nil {
+		return nil, err
+	}
+	if spent {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("locked output with ID %s has already been spent", inputKey)}
+	}
+	return lockedOutput, nil
+}
+
+func (v *Verifier) commitLockAction(lockAction *token.PlainLock, txID string, simulator ledger.LedgerWriter) error {
+	if lockAction.GetOutput() != nil {
+		outputID, err := createOutputKey(txID, 0)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID: %s", err)}
+		}
+		err = v.addOutput(outputID, lockAction.GetOutput(), simulator)
+		if err != nil {
+			return err
+		}
+	}
+	// createLockedOutputKey() error already checked in checkLockedOutputDoesNotExist
+	lockedOutputID, _ := createLockedOutputKey(txID, 0)
+	err := simulator.SetState(tokenNameSpace, lockedOutputID, utils.MarshalOrPanic(lockAction.GetLockedOutput()))
+	if err != nil {
+		return err
+	}
+	return v.markInputsSpent(txID, lockAction.GetInputs(), simulator)
+}
+
+// commitUnlockAction is called for both claim and reclaim transactions: it spends the locked output
+// and creates an output with its tokens, owned by the recipient on claim and by the sender on reclaim.
+func (v *Verifier) commitUnlockAction(input *token.InputId, claim bool, txID string, simulator ledger.LedgerWriter) error {
+	inputKey, err := createLockedOutputKey(input.TxId, int(input.Index))
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating locked output ID: %s", err)}
+	}
+	lockedOutput, err := v.getLockedOutput(inputKey, simulator)
+	if err != nil {
+		return err
+	}
+	owner := lockedOutput.Sender
+	if claim {
+		owner = lockedOutput.Recipient
+	}
+	outputID, err := createOutputKey(txID, 0)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID: %s", err)}
+	}
+	err = v.addOutput(outputID, &token.PlainOutput{
+		Owner:    owner,
+		Type:     lockedOutput.Type,
+		Quantity: lockedOutput.Quantity,
+		Nft:      lockedOutput.Nft,
+	}, simulator)
+	if err != nil {
+		return err
+	}
+	spentKey, err := createSpentLockedOutputKey(input.TxId, int(input.Index))
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating spent key: %s", err)}
+	}
+	verifierLogger.Debugf("marking locked input '%s' as spent", spentKey)
+	return simulator.SetState(tokenNameSpace, spentKey, TokenInputSpentMarker)
+}
+
+func findSwapSignature(signatures []*token.PlainSwapSignature, signer []byte) *token.PlainSwapSignature {
+	for _, signature := range signatures {
+		if bytes.Equal(signature.Signer, signer) {
+			return signature
+		}
+	}
+	return nil
+}
+
+func containsOwner(owners [][]byte, owner []byte) bool {
+	for _, o := range owners {
+		if bytes.Equal(o, owner) {
+			return true
+		}
+	}
+	return false
+}
+
 func (v *Verifier) addOutput(outputID string, output *token.PlainOutput, simulator ledger.LedgerWriter) error {
 	outputBytes := utils.MarshalOrPanic(output)
 
@@ -523,6 +1279,68 @@ func (v *Verifier) getOutput(outputID string, simulator ledger.LedgerReader) (*t
 	return output, nil
 }
 
+func (v *Verifier) getDelegatedOutput(outputID string, simulator ledger.LedgerReader) (*token.PlainDelegatedOutput, error) {
+	outputBytes, err := simulator.GetState(tokenNameSpace, outputID)
+	if err != nil {
+		return nil, err
+	}
+	if len(outputBytes) == 0 {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s for transfer from does not exist", outputID)}
+	}
+	output := &token.PlainDelegatedOutput{}
+	err = proto.Unmarshal(outputBytes, output)
+	if err != nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("unmarshaling error: %s", err)}
+	}
+	return output, nil
+}
+
+func (v *Verifier) getLockedOutput(outputID string, simulator ledger.LedgerReader) (*token.PlainLockedOutput, error) {
+	outputBytes, err := simulator.GetState(tokenNameSpace, outputID)
+	if err != nil {
+		return nil, err
+	}
+	if len(outputBytes) == 0 {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("locked output with ID %s does not exist", outputID)}
+	}
+	output := &token.PlainLockedOutput{}
+	err = proto.Unmarshal(outputBytes, output)
+	if err != nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("unmarshaling error: %s", err)}
+	}
+	return output, nil
+}
+
+// getSupply returns the outstanding supply of a token type, 0 if none was recorded.
+func (v *Verifier) getSupply(tokenType string, simulator ledger.LedgerReader) (uint64, error) {
+	supplyKey, err := createSupplyKey(tokenType)
+	if err != nil {
+		return 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating supply key: %s", err)}
+	}
+	supplyBytes, err := simulator.GetState(tokenNameSpace, supplyKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(supplyBytes) == 0 {
+		return 0, nil
+	}
+	supply := &token.TokenSupply{}
+	err = proto.Unmarshal(supplyBytes, supply)
+	if err != nil {
+		return 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("unmarshaling error: %s", err)}
+	}
+	return supply.Quantity, nil
+}
+
+func (v *Verifier) setSupply(tokenType string, quantity uint64, simulator ledger.LedgerWriter) error {
+	supplyKey, err := createSupplyKey(tokenType)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating supply key: %s", err)}
+	}
+	supplyBytes := utils.MarshalOrPanic(&token.TokenSupply{Type: tokenType, Quantity: quantity})
+	return simulator.SetState(tokenNameSpace, supplyKey, supplyBytes)
+}
+
 // isSpent checks whether an output token with identifier outputID has been spent.
 func (v *Verifier) isSpent(spentKey string, simulator ledger.LedgerReader) (bool, error) {
 	verifierLogger.Debugf("checking if input with ID '%s' has been spent", spentKey)
@@ -553,6 +1371,17 @@ func createSpentKey(txID string, index int) (string, error) {
 	return createCompositeKey("tokenInput", []string{txID, strconv.Itoa(index)})
 }
 
+// Create a ledger key recording the issuance of a non-fungible token, as a function of
+// the token type, and the id of the non-fungible token
+func createNonFungibleKey(tokenType string, id string) (string, error) {
+	return createCompositeKey(tokenNonFungible, []string{tokenType, id})
+}
+
+// Create a ledger key for the outstanding supply of a token type
+func createSupplyKey(tokenType string) (string, error) {
+	return createCompositeKey(tokenSupply, []string{tokenType})
+}
+
 // createCompositeKey and its related functions and consts copied from core/chaincode/shim/chaincode.go
 func createCompositeKey(objectType string, attributes []string) (string, error) {
 	if err := validateCompositeKeyAttribute(objectType); err != nil {
@@ -617,3 +1446,32 @@ func (v *Verifier) checkDelegatedOutputDoesNotExist(index int, txID string, simu
 	}
 	return nil
 }
+
+// Create a ledger key for a locked output in a token transaction, as a function of
+// the transaction ID, and the index of the output
+func createLockedOutputKey(txID string, index int) (string, error) {
+	return createCompositeKey(tokenLockedOutput, []string{txID, strconv.Itoa(index)})
+}
+
+// Create a ledger key for a spent locked output in a token transaction, as a function of
+// the transaction ID, and the index of the locked output
+func createSpentLockedOutputKey(txID string, index int) (string, error) {
+	return createCompositeKey(tokenLockedInput, []string{txID, strconv.Itoa(index)})
+}
+
+func (v *Verifier) checkLockedOutputDoesNotExist(index int, txID string, simulator ledger.LedgerReader) error {
+	outputID, err := createLockedOutputKey(txID, index)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID: %s", err)}
+	}
+
+	existingOutputBytes, err := simulator.GetState(tokenNameSpace, outputID)
+	if err != nil {
+		return err
+	}
+
+	if existingOutputBytes != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("locked output already exists: %s", outputID)}
+	}
+	return nil
+}
@@ -8,8 +8,10 @@ package plain_test
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/golang/protobuf/proto"
@@ -80,7 +82,7 @@ var _ = Describe("Verifier", func() {
 			err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, fakeLedger)
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(fakeLedger.SetStateCallCount()).To(Equal(3))
+			Expect(fakeLedger.SetStateCallCount()).To(Equal(5))
 
 			outputBytes, err := proto.Marshal(&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 111})
 			Expect(err).NotTo(HaveOccurred())
@@ -98,10 +100,24 @@ var _ = Describe("Verifier", func() {
 			Expect(k).To(Equal(expectedOutput))
 			Expect(td).To(Equal(outputBytes))
 
-			ttxBytes, err := proto.Marshal(importTransaction)
+			supplyBytes, err := proto.Marshal(&token.TokenSupply{Type: "TOK1", Quantity: 111})
 			Expect(err).NotTo(HaveOccurred())
 			ns, k, td = fakeLedger.SetStateArgsForCall(2)
 			Expect(ns).To(Equal("tms"))
+			Expect(k).To(Equal(strings.Join([]string{"", "tokenSupply", "TOK1", ""}, "\x00")))
+			Expect(td).To(Equal(supplyBytes))
+
+			supplyBytes, err = proto.Marshal(&token.TokenSupply{Type: "TOK2", Quantity: 222})
+			Expect(err).NotTo(HaveOccurred())
+			ns, k, td = fakeLedger.SetStateArgsForCall(3)
+			Expect(ns).To(Equal("tms"))
+			Expect(k).To(Equal(strings.Join([]string{"", "tokenSupply", "TOK2", ""}, "\x00")))
+			Expect(td).To(Equal(supplyBytes))
+
+			ttxBytes, err := proto.Marshal(importTransaction)
+			Expect(err).NotTo(HaveOccurred())
+			ns, k, td = fakeLedger.SetStateArgsForCall(4)
+			Expect(ns).To(Equal("tms"))
 			expectedOutput = strings.Join([]string{"", "tokenTx", "0", ""}, "\x00")
 			Expect(k).To(Equal(expectedOutput))
 			Expect(td).To(Equal(ttxBytes))
@@ -325,7 +341,9 @@ var _ = Describe("Verifier", func() {
 			BeforeEach(func() {
 				fakeLedger.GetStateReturnsOnCall(0, nil, nil)
 				fakeLedger.GetStateReturnsOnCall(1, nil, nil)
-				fakeLedger.GetStateReturnsOnCall(2, nil, errors.New("error reading transaction"))
+				fakeLedger.GetStateReturnsOnCall(2, nil, nil)
+				fakeLedger.GetStateReturnsOnCall(3, nil, nil)
+				fakeLedger.GetStateReturnsOnCall(4, nil, errors.New("error reading transaction"))
 			})
 
 			It("returns an error", func() {
@@ -333,9 +351,9 @@ var _ = Describe("Verifier", func() {
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError("error reading transaction"))
 
-				Expect(fakeLedger.GetStateCallCount()).To(Equal(3))
+				Expect(fakeLedger.GetStateCallCount()).To(Equal(5))
 				Expect(fakeLedger.SetStateCallCount()).To(Equal(0))
-				ns, k := fakeLedger.GetStateArgsForCall(2)
+				ns, k := fakeLedger.GetStateArgsForCall(4)
 				expectedTx := strings.Join([]string{"", "tokenTx", "0", ""}, "\x00")
 				Expect(k).To(Equal(expectedTx))
 				Expect(ns).To(Equal("tms"))
@@ -344,7 +362,7 @@ var _ = Describe("Verifier", func() {
 
 		Context("when a tx with the same txID already exists", func() {
 			BeforeEach(func() {
-				fakeLedger.GetStateReturnsOnCall(2, []byte("fake-tx"), nil)
+				fakeLedger.GetStateReturnsOnCall(4, []byte("fake-tx"), nil)
 			})
 
 			It("returns an error", func() {
@@ -543,6 +561,33 @@ var _ = Describe("Verifier", func() {
 			})
 		})
 
+		Context("when the output sum overflows", func() {
+			BeforeEach(func() {
+				transferTransaction = &token.TokenTransaction{
+					Action: &token.TokenTransaction_PlainAction{
+						PlainAction: &token.PlainTokenAction{
+							Data: &token.PlainTokenAction_PlainTransfer{
+								PlainTransfer: &token.PlainTransfer{
+									Inputs: []*token.InputId{
+										{TxId: "0", Index: 0},
+									},
+									Outputs: []*token.PlainOutput{
+										{Owner: []byte("owner-1"), Type: "TOK1", Quantity: math.MaxUint64},
+										{Owner: []byte("owner-2"), Type: "TOK1", Quantity: 112},
+									},
+								},
+							},
+						},
+					},
+				}
+			})
+
+			It("returns an InvalidTxError", func() {
+				err := verifier.ProcessTx(transferTxID, fakePublicInfo, transferTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: fmt.Sprintf("token sum overflows (%d + 112) in transfer output for txID '1'", uint64(math.MaxUint64))}))
+			})
+		})
+
 		Context("when the input contains multiple token types", func() {
 			var (
 				anotherImportTransaction *token.TokenTransaction
@@ -1150,4 +1195,857 @@ var _ = Describe("Verifier", func() {
 			})
 		})
 	})
+
+	Describe("Test ProcessTx PlainTransferFrom", func() {
+		var (
+			ownerInfo    *mockid.PublicInfo
+			spenderInfo  *mockid.PublicInfo
+			owner        *plain.Transactor
+			spender      *plain.Transactor
+			delegatedIDs [][]byte
+		)
+
+		BeforeEach(func() {
+			memoryLedger = plain.NewMemoryLedger()
+			ownerInfo = &mockid.PublicInfo{}
+			ownerInfo.PublicReturns([]byte("owner-1"))
+			spenderInfo = &mockid.PublicInfo{}
+			spenderInfo.PublicReturns([]byte("spender"))
+			owner = &plain.Transactor{PublicCredential: []byte("owner-1"), Ledger: memoryLedger}
+			spender = &plain.Transactor{PublicCredential: []byte("spender"), Ledger: memoryLedger}
+
+			err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			inputID, err := plain.GenerateKeyForTest("0", 0)
+			Expect(err).NotTo(HaveOccurred())
+			approveTransaction, err := owner.RequestApprove(&token.ApproveRequest{
+				Credential:      []byte("owner-1"),
+				TokenIds:        [][]byte{[]byte(inputID)},
+				AllowanceShares: []*token.AllowanceRecipientShare{{Recipient: []byte("spender"), Quantity: 100}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("1", ownerInfo, approveTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			delegatedIDs = [][]byte{[]byte(strings.Join([]string{"", "tokenDelegatedOutput", "1", "0", ""}, "\x00"))}
+		})
+
+		It("transfers delegated outputs and keeps the rest delegated", func() {
+			transferFromTransaction, err := spender.RequestTransferFrom(&token.TransferRequest{
+				TokenIds: delegatedIDs,
+				Shares:   []*token.RecipientTransferShare{{Recipient: []byte("owner-3"), Quantity: 60}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transferFromTransaction.GetPlainAction().GetPlainTransfer_From().GetDelegatedOutput()).To(Equal(
+				&token.PlainDelegatedOutput{Owner: []byte("owner-1"), Delegatees: [][]byte{[]byte("spender")}, Type: "TOK1", Quantity: 40},
+			))
+
+			err = verifier.ProcessTx("2", spenderInfo, transferFromTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			outputBytes, err := memoryLedger.GetState("tms", strings.Join([]string{"", "tokenOutput", "2", "0", ""}, "\x00"))
+			Expect(err).NotTo(HaveOccurred())
+			output := &token.PlainOutput{}
+			err = proto.Unmarshal(outputBytes, output)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(output, &token.PlainOutput{Owner: []byte("owner-3"), Type: "TOK1", Quantity: 60})).To(BeTrue())
+
+			spentMarker, err := memoryLedger.GetState("tms", strings.Join([]string{"", "tokenDelegateInput", "1", "0", ""}, "\x00"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spentMarker).To(Equal(plain.TokenInputSpentMarker))
+
+			// the remaining delegated quantity can be spent in turn
+			transferFromTransaction, err = spender.RequestTransferFrom(&token.TransferRequest{
+				TokenIds: [][]byte{[]byte(strings.Join([]string{"", "tokenDelegatedOutput", "2", "0", ""}, "\x00"))},
+				Shares:   []*token.RecipientTransferShare{{Recipient: []byte("owner-3"), Quantity: 40}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transferFromTransaction.GetPlainAction().GetPlainTransfer_From().GetDelegatedOutput()).To(BeNil())
+			err = verifier.ProcessTx("3", spenderInfo, transferFromTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects spending delegated outputs twice", func() {
+			transferFromTransaction, err := spender.RequestTransferFrom(&token.TransferRequest{
+				TokenIds: delegatedIDs,
+				Shares:   []*token.RecipientTransferShare{{Recipient: []byte("owner-3"), Quantity: 100}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("2", spenderInfo, transferFromTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = verifier.ProcessTx("3", spenderInfo, transferFromTransaction, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "input with ID \x00tokenDelegatedOutput\x001\x000\x00 for transfer from has already been spent"}))
+			_, err = spender.RequestTransferFrom(&token.TransferRequest{
+				TokenIds: delegatedIDs,
+				Shares:   []*token.RecipientTransferShare{{Recipient: []byte("owner-3"), Quantity: 100}},
+			})
+			Expect(err).To(MatchError("input '\x00tokenDelegatedOutput\x001\x000\x00' has already been spent"))
+		})
+
+		It("rejects creators that are not delegatees", func() {
+			transferFromTransaction, err := spender.RequestTransferFrom(&token.TransferRequest{
+				TokenIds: delegatedIDs,
+				Shares:   []*token.RecipientTransferShare{{Recipient: []byte("owner-3"), Quantity: 100}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = verifier.ProcessTx("2", ownerInfo, transferFromTransaction, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "transfer from input with ID \x00tokenDelegatedOutput\x001\x000\x00 not delegated to creator"}))
+		})
+
+		It("rejects outputs that do not match the inputs", func() {
+			transferFromTransaction, err := spender.RequestTransferFrom(&token.TransferRequest{
+				TokenIds: delegatedIDs,
+				Shares:   []*token.RecipientTransferShare{{Recipient: []byte("owner-3"), Quantity: 60}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			transferFromTransaction.GetPlainAction().GetPlainTransfer_From().DelegatedOutput.Quantity = 50
+
+			err = verifier.ProcessTx("2", spenderInfo, transferFromTransaction, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token sum mismatch in inputs and outputs for transfer from with ID 2 (110 vs 100)"}))
+		})
+
+		It("rejects outputs whose sum overflows", func() {
+			transferFromTransaction, err := spender.RequestTransferFrom(&token.TransferRequest{
+				TokenIds: delegatedIDs,
+				Shares:   []*token.RecipientTransferShare{{Recipient: []byte("owner-3"), Quantity: 60}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			transferFromTransaction.GetPlainAction().GetPlainTransfer_From().DelegatedOutput.Quantity = math.MaxUint64
+
+			err = verifier.ProcessTx("2", spenderInfo, transferFromTransaction, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: fmt.Sprintf("token sum overflows (60 + %d) in transfer from with ID 2", uint64(math.MaxUint64))}))
+		})
+
+		It("rejects a remaining delegated output that is not delegated to the creator", func() {
+			transferFromTransaction, err := spender.RequestTransferFrom(&token.TransferRequest{
+				TokenIds: delegatedIDs,
+				Shares:   []*token.RecipientTransferShare{{Recipient: []byte("owner-3"), Quantity: 60}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			transferFromTransaction.GetPlainAction().GetPlainTransfer_From().DelegatedOutput.Delegatees = [][]byte{[]byte("owner-3")}
+
+			err = verifier.ProcessTx("2", spenderInfo, transferFromTransaction, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "the delegatee of the delegated output in transfer from with ID 2 is not the creator"}))
+		})
+
+		It("rejects a remaining delegated output of another owner", func() {
+			transferFromTransaction, err := spender.RequestTransferFrom(&token.TransferRequest{
+				TokenIds: delegatedIDs,
+				Shares:   []*token.RecipientTransferShare{{Recipient: []byte("owner-3"), Quantity: 60}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			transferFromTransaction.GetPlainAction().GetPlainTransfer_From().DelegatedOutput.Owner = []byte("spender")
+
+			err = verifier.ProcessTx("2", spenderInfo, transferFromTransaction, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "the owner of the delegated output in transfer from with ID 2 is invalid"}))
+		})
+
+		It("rejects inputs that are not delegated outputs", func() {
+			transferFromTransaction := &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainTransfer_From{
+							PlainTransfer_From: &token.PlainTransferFrom{
+								Inputs:  []*token.InputId{{TxId: "0", Index: 1}},
+								Outputs: []*token.PlainOutput{{Owner: []byte("owner-3"), Type: "TOK2", Quantity: 222}},
+							},
+						},
+					},
+				},
+			}
+			err := verifier.ProcessTx("2", spenderInfo, transferFromTransaction, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "input with ID \x00tokenDelegatedOutput\x000\x001\x00 for transfer from does not exist"}))
+		})
+	})
+
+	Describe("Test ProcessTx expectation based transfers", func() {
+		var (
+			payer     *plain.Transactor
+			payerInfo *mockid.PublicInfo
+			inputID   string
+		)
+
+		BeforeEach(func() {
+			memoryLedger = plain.NewMemoryLedger()
+			payerInfo = &mockid.PublicInfo{}
+			payerInfo.PublicReturns([]byte("owner-1"))
+			payer = &plain.Transactor{PublicCredential: []byte("owner-1"), Ledger: memoryLedger}
+
+			err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			inputID, err = plain.GenerateKeyForTest("0", 0)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("processes a transfer that fulfills the expectation", func() {
+			transferTransaction, err := payer.RequestExpectation(&token.ExpectationRequest{
+				TokenIds: [][]byte{[]byte(inputID)},
+				Expectation: &token.TokenExpectation{
+					Expectation: &token.TokenExpectation_PlainExpectation{
+						PlainExpectation: &token.PlainExpectation{
+							Payload: &token.PlainExpectation_TransferExpectation{
+								TransferExpectation: &token.PlainTokenExpectation{
+									Outputs: []*token.PlainOutput{{Owner: []byte("payee"), Type: "TOK1", Quantity: 100}},
+								},
+							},
+						},
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = verifier.ProcessTx("1", payerInfo, transferTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			outputBytes, err := memoryLedger.GetState("tms", strings.Join([]string{"", "tokenOutput", "1", "1", ""}, "\x00"))
+			Expect(err).NotTo(HaveOccurred())
+			output := &token.PlainOutput{}
+			err = proto.Unmarshal(outputBytes, output)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(output, &token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 11})).To(BeTrue())
+		})
+	})
+
+	Describe("Test ProcessTx non-fungible tokens", func() {
+		var (
+			nft         *token.NonFungibleToken
+			holder      *plain.Transactor
+			holderInfo  *mockid.PublicInfo
+			nftImport   *token.TokenTransaction
+			nftOutputID string
+		)
+
+		BeforeEach(func() {
+			memoryLedger = plain.NewMemoryLedger()
+			holderInfo = &mockid.PublicInfo{}
+			holderInfo.PublicReturns([]byte("owner-1"))
+			holder = &plain.Transactor{PublicCredential: []byte("owner-1"), Ledger: memoryLedger}
+
+			nft = &token.NonFungibleToken{Id: "painting-1", Uri: "https://example.com/painting-1", Hash: []byte("hash")}
+			var err error
+			nftImport, err = (&plain.Issuer{}).RequestImport([]*token.TokenToIssue{
+				{Recipient: []byte("owner-1"), Type: "ART", Quantity: 1, Nft: nft},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("0", fakePublicInfo, nftImport, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			nftOutputID, err = plain.GenerateKeyForTest("0", 0)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("records the issuance of the token", func() {
+			nftBytes, err := memoryLedger.GetState("tms", strings.Join([]string{"", "tokenNonFungible", "ART", "painting-1", ""}, "\x00"))
+			Expect(err).NotTo(HaveOccurred())
+			registered := &token.NonFungibleToken{}
+			err = proto.Unmarshal(nftBytes, registered)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(registered, nft)).To(BeTrue())
+		})
+
+		It("rejects issuing the same token again", func() {
+			err := verifier.ProcessTx("1", fakePublicInfo, nftImport, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "non-fungible token 'painting-1' of type 'ART' already issued"}))
+		})
+
+		It("rejects issuing the same token twice in a transaction", func() {
+			importTx := &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainImport{
+							PlainImport: &token.PlainImport{
+								Outputs: []*token.PlainOutput{
+									{Owner: []byte("owner-1"), Type: "ART", Quantity: 1, Nft: &token.NonFungibleToken{Id: "painting-2"}},
+									{Owner: []byte("owner-2"), Type: "ART", Quantity: 1, Nft: &token.NonFungibleToken{Id: "painting-2"}},
+								},
+							},
+						},
+					},
+				},
+			}
+			err := verifier.ProcessTx("1", fakePublicInfo, importTx, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "non-fungible token 'painting-2' of type 'ART' issued more than once in transaction: 1"}))
+		})
+
+		It("rejects issuing a token with a quantity other than 1", func() {
+			importTx := &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainImport{
+							PlainImport: &token.PlainImport{
+								Outputs: []*token.PlainOutput{
+									{Owner: []byte("owner-1"), Type: "ART", Quantity: 2, Nft: &token.NonFungibleToken{Id: "painting-2"}},
+								},
+							},
+						},
+					},
+				},
+			}
+			err := verifier.ProcessTx("1", fakePublicInfo, importTx, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "output 0 carries a non-fungible token but its quantity is 2 in transaction: 1"}))
+		})
+
+		It("transfers the token as a whole", func() {
+			transferTx, err := holder.RequestTransfer(&token.TransferRequest{
+				TokenIds: [][]byte{[]byte(nftOutputID)},
+				Shares:   []*token.RecipientTransferShare{{Recipient: []byte("owner-2"), Quantity: 1}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("1", holderInfo, transferTx, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			outputBytes, err := memoryLedger.GetState("tms", strings.Join([]string{"", "tokenOutput", "1", "0", ""}, "\x00"))
+			Expect(err).NotTo(HaveOccurred())
+			output := &token.PlainOutput{}
+			err = proto.Unmarshal(outputBytes, output)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(output, &token.PlainOutput{Owner: []byte("owner-2"), Type: "ART", Quantity: 1, Nft: nft})).To(BeTrue())
+		})
+
+		It("rejects transfers that drop the token", func() {
+			transferTx := &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainTransfer{
+							PlainTransfer: &token.PlainTransfer{
+								Inputs:  []*token.InputId{{TxId: "0", Index: 0}},
+								Outputs: []*token.PlainOutput{{Owner: []byte("owner-2"), Type: "ART", Quantity: 1}},
+							},
+						},
+					},
+				},
+			}
+			err := verifier.ProcessTx("1", holderInfo, transferTx, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "non-fungible token mismatch in inputs and outputs for transfer with ID 1 (1 vs 0)"}))
+		})
+
+		It("rejects transfers that alter the token", func() {
+			transferTx := &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainTransfer{
+							PlainTransfer: &token.PlainTransfer{
+								Inputs: []*token.InputId{{TxId: "0", Index: 0}},
+								Outputs: []*token.PlainOutput{
+									{Owner: []byte("owner-2"), Type: "ART", Quantity: 1, Nft: &token.NonFungibleToken{Id: "painting-1", Uri: "forged"}},
+								},
+							},
+						},
+					},
+				},
+			}
+			err := verifier.ProcessTx("1", holderInfo, transferTx, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "non-fungible token 'painting-1' in outputs for transfer with ID 1 is not spent by its inputs"}))
+		})
+
+		It("rejects fungible transfers that mint a token", func() {
+			importTx, err := (&plain.Issuer{}).RequestImport([]*token.TokenToIssue{{Recipient: []byte("owner-1"), Type: "ART", Quantity: 1}})
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("1", fakePublicInfo, importTx, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			transferTx := &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainTransfer{
+							PlainTransfer: &token.PlainTransfer{
+								Inputs:  []*token.InputId{{TxId: "1", Index: 0}},
+								Outputs: []*token.PlainOutput{{Owner: []byte("owner-2"), Type: "ART", Quantity: 1, Nft: &token.NonFungibleToken{Id: "painting-2"}}},
+							},
+						},
+					},
+				},
+			}
+			err = verifier.ProcessTx("2", holderInfo, transferTx, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "non-fungible token mismatch in inputs and outputs for transfer with ID 2 (0 vs 1)"}))
+		})
+
+		It("redeems the token and never issues it again", func() {
+			redeemTx, err := holder.RequestRedeem(&token.RedeemRequest{
+				TokenIds:         [][]byte{[]byte(nftOutputID)},
+				QuantityToRedeem: 1,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("1", holderInfo, redeemTx, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			nftImport.GetPlainAction().GetPlainImport().Outputs[0].Owner = []byte("owner-2")
+			err = verifier.ProcessTx("2", fakePublicInfo, nftImport, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "non-fungible token 'painting-1' of type 'ART' already issued"}))
+		})
+
+		It("rejects approving the token", func() {
+			approveTx := &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainApprove{
+							PlainApprove: &token.PlainApprove{
+								Inputs: []*token.InputId{{TxId: "0", Index: 0}},
+								DelegatedOutputs: []*token.PlainDelegatedOutput{
+									{Owner: []byte("owner-1"), Delegatees: [][]byte{[]byte("spender")}, Type: "ART", Quantity: 1},
+								},
+							},
+						},
+					},
+				},
+			}
+			err := verifier.ProcessTx("1", holderInfo, approveTx, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "non-fungible tokens cannot be approved in approve with ID 1"}))
+		})
+	})
+
+	Describe("Test ProcessTx PlainSwap", func() {
+		var (
+			aliceInfo        *mockid.PublicInfo
+			fakeDeserializer *mockid.Deserializer
+			fakeIdentity     *mockid.Identity
+			swap             *token.PlainSwap
+			swapTransaction  *token.TokenTransaction
+		)
+
+		BeforeEach(func() {
+			memoryLedger = plain.NewMemoryLedger()
+			err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			aliceInfo = &mockid.PublicInfo{}
+			aliceInfo.PublicReturns([]byte("owner-1"))
+			fakeIdentity = &mockid.Identity{}
+			fakeDeserializer = &mockid.Deserializer{}
+			fakeDeserializer.DeserializeIdentityReturns(fakeIdentity, nil)
+			verifier.Deserializer = fakeDeserializer
+
+			swap = &token.PlainSwap{
+				Inputs: []*token.InputId{{TxId: "0", Index: 0}, {TxId: "0", Index: 1}},
+				Outputs: []*token.PlainOutput{
+					{Owner: []byte("owner-2"), Type: "TOK1", Quantity: 111},
+					{Owner: []byte("owner-1"), Type: "TOK2", Quantity: 200},
+					{Owner: []byte("owner-2"), Type: "TOK2", Quantity: 22},
+				},
+				Signatures: []*token.PlainSwapSignature{{Signer: []byte("owner-2"), Signature: []byte("owner-2-signature")}},
+			}
+			swapTransaction = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainSwap{PlainSwap: swap},
+					},
+				},
+			}
+		})
+
+		It("exchanges the tokens of the owners", func() {
+			err := verifier.ProcessTx("1", aliceInfo, swapTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeDeserializer.DeserializeIdentityCallCount()).To(Equal(1))
+			Expect(fakeDeserializer.DeserializeIdentityArgsForCall(0)).To(Equal([]byte("owner-2")))
+			Expect(fakeIdentity.VerifyCallCount()).To(Equal(1))
+			message, signature := fakeIdentity.VerifyArgsForCall(0)
+			expected, err := swap.SigningBytes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(message).To(Equal(expected))
+			Expect(signature).To(Equal([]byte("owner-2-signature")))
+
+			for i, expectedOutput := range swap.Outputs {
+				outputBytes, err := memoryLedger.GetState("tms", strings.Join([]string{"", "tokenOutput", "1", fmt.Sprintf("%d", i), ""}, "\x00"))
+				Expect(err).NotTo(HaveOccurred())
+				output := &token.PlainOutput{}
+				err = proto.Unmarshal(outputBytes, output)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(output, expectedOutput)).To(BeTrue())
+			}
+			for _, index := range []string{"0", "1"} {
+				spentMarker, err := memoryLedger.GetState("tms", strings.Join([]string{"", "tokenInput", "0", index, ""}, "\x00"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(spentMarker).To(Equal(plain.TokenInputSpentMarker))
+			}
+		})
+
+		It("requires the signatures of every owner when the creator owns no input", func() {
+			creatorInfo := &mockid.PublicInfo{}
+			creatorInfo.PublicReturns([]byte("broker"))
+			err := verifier.ProcessTx("1", creatorInfo, swapTransaction, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "missing signature of an input owner in swap with ID 1"}))
+
+			swap.Signatures = append(swap.Signatures, &token.PlainSwapSignature{Signer: []byte("owner-1"), Signature: []byte("owner-1-signature")})
+			err = verifier.ProcessTx("1", creatorInfo, swapTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeIdentity.VerifyCallCount()).To(Equal(2))
+		})
+
+		It("rejects swaps without the signature of an input owner", func() {
+			swap.Signatures = nil
+			err := verifier.ProcessTx("1", aliceInfo, swapTransaction, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "missing signature of an input owner in swap with ID 1"}))
+		})
+
+		It("rejects swaps with an invalid signature", func() {
+			fakeIdentity.VerifyReturns(errors.New("bad signature"))
+			err := verifier.ProcessTx("1", aliceInfo, swapTransaction, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid signature of an input owner in swap with ID 1: bad signature"}))
+		})
+
+		It("rejects swaps whose signer cannot be deserialized", func() {
+			fakeDeserializer.DeserializeIdentityReturns(nil, errors.New("unknown msp"))
+			err := verifier.ProcessTx("1", aliceInfo, swapTransaction, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "error deserializing input owner in swap with ID 1: unknown msp"}))
+		})
+
+		It("rejects swaps that do not balance every token type", func() {
+			swap.Outputs[2].Quantity = 23
+			err := verifier.ProcessTx("1", aliceInfo, swapTransaction, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token sum mismatch in inputs and outputs of type TOK2 for swap with ID 1 (223 vs 222)"}))
+		})
+
+		It("rejects swaps creating a token type missing from the inputs", func() {
+			swap.Outputs = append(swap.Outputs, &token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK3", Quantity: 1})
+			err := verifier.ProcessTx("1", aliceInfo, swapTransaction, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token type TOK3 in outputs is not in inputs for swap with ID 1"}))
+		})
+
+		It("rejects swaps whose output sum overflows", func() {
+			swap.Outputs[2].Quantity = math.MaxUint64
+			err := verifier.ProcessTx("1", aliceInfo, swapTransaction, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: fmt.Sprintf("token sum overflows (200 + %d) in swap outputs of type TOK2 for txID '1'", uint64(math.MaxUint64))}))
+		})
+
+		It("rejects swaps spending an input twice", func() {
+			swap.Inputs = append(swap.Inputs, &token.InputId{TxId: "0", Index: 0})
+			err := verifier.ProcessTx("1", aliceInfo, swapTransaction, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token input '\x00tokenOutput\x000\x000\x00' spent more than once in single swap with txID '1'"}))
+		})
+
+		It("rejects swaps spending spent inputs", func() {
+			err := verifier.ProcessTx("1", aliceInfo, swapTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("2", aliceInfo, swapTransaction, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "input with ID \x00tokenOutput\x000\x000\x00 for swap has already been spent"}))
+		})
+
+		It("rejects swaps with outputs without owner", func() {
+			swap.Outputs[0].Owner = nil
+			err := verifier.ProcessTx("1", aliceInfo, swapTransaction, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "output 0 has no owner in swap with ID 1"}))
+		})
+
+		Context("when the verifier has no deserializer", func() {
+			BeforeEach(func() {
+				verifier.Deserializer = nil
+			})
+
+			It("returns an error", func() {
+				err := verifier.ProcessTx("1", aliceInfo, swapTransaction, memoryLedger)
+				Expect(err).To(MatchError("no deserializer to verify the signatures of swap with ID 1"))
+			})
+		})
+	})
+
+	Describe("Test ProcessTx PlainLock, PlainClaim and PlainReclaim", func() {
+		var (
+			senderInfo    *mockid.PublicInfo
+			recipientInfo *mockid.PublicInfo
+			hash          []byte
+			deadline      uint64
+			lock          *token.PlainLock
+			lockTx        *token.TokenTransaction
+			claim         *token.PlainClaim
+			claimTx       *token.TokenTransaction
+			reclaimTx     *token.TokenTransaction
+		)
+
+		BeforeEach(func() {
+			memoryLedger = plain.NewMemoryLedger()
+			err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			senderInfo = &mockid.PublicInfo{}
+			senderInfo.PublicReturns([]byte("owner-1"))
+			recipientInfo = &mockid.PublicInfo{}
+			recipientInfo.PublicReturns([]byte("owner-2"))
+
+			h := sha256.Sum256([]byte("secret"))
+			hash = h[:]
+			deadline = 20
+
+			lock = &token.PlainLock{
+				Inputs: []*token.InputId{{TxId: "0", Index: 0}},
+				LockedOutput: &token.PlainLockedOutput{
+					Sender:    []byte("owner-1"),
+					Recipient: []byte("owner-2"),
+					Type:      "TOK1",
+					Quantity:  100,
+					Hash:      hash,
+					Deadline:  deadline,
+				},
+				Output: &token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 11},
+			}
+			lockTx = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainLock{PlainLock: lock},
+					},
+				},
+			}
+			claim = &token.PlainClaim{Input: &token.InputId{TxId: "1", Index: 0}, Preimage: []byte("secret")}
+			claimTx = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainClaim{PlainClaim: claim},
+					},
+				},
+			}
+			reclaimTx = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainReclaim{PlainReclaim: &token.PlainReclaim{Input: &token.InputId{TxId: "1", Index: 0}}},
+					},
+				},
+			}
+		})
+
+		getOutput := func(txID string) *token.PlainOutput {
+			outputBytes, err := memoryLedger.GetState("tms", strings.Join([]string{"", "tokenOutput", txID, "0", ""}, "\x00"))
+			Expect(err).NotTo(HaveOccurred())
+			output := &token.PlainOutput{}
+			Expect(proto.Unmarshal(outputBytes, output)).To(Succeed())
+			return output
+		}
+
+		It("locks the tokens and returns the change to the sender", func() {
+			err := verifier.ProcessTxAt("1", senderInfo, lockTx, 10, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			lockedBytes, err := memoryLedger.GetState("tms", strings.Join([]string{"", "tokenLockedOutput", "1", "0", ""}, "\x00"))
+			Expect(err).NotTo(HaveOccurred())
+			locked := &token.PlainLockedOutput{}
+			Expect(proto.Unmarshal(lockedBytes, locked)).To(Succeed())
+			Expect(proto.Equal(locked, lock.LockedOutput)).To(BeTrue())
+			Expect(proto.Equal(getOutput("1"), lock.Output)).To(BeTrue())
+			spentMarker, err := memoryLedger.GetState("tms", strings.Join([]string{"", "tokenInput", "0", "0", ""}, "\x00"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spentMarker).To(Equal(plain.TokenInputSpentMarker))
+		})
+
+		It("lets the recipient claim the tokens with the preimage before the deadline", func() {
+			err := verifier.ProcessTxAt("1", senderInfo, lockTx, 10, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTxAt("2", recipientInfo, claimTx, 15, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(proto.Equal(getOutput("2"), &token.PlainOutput{Owner: []byte("owner-2"), Type: "TOK1", Quantity: 100})).To(BeTrue())
+			spentMarker, err := memoryLedger.GetState("tms", strings.Join([]string{"", "tokenLockedInput", "1", "0", ""}, "\x00"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spentMarker).To(Equal(plain.TokenInputSpentMarker))
+
+			err = verifier.ProcessTxAt("3", senderInfo, reclaimTx, 25, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "locked output with ID \x00tokenLockedOutput\x001\x000\x00 has already been spent"}))
+		})
+
+		It("lets the sender reclaim the tokens after the deadline", func() {
+			err := verifier.ProcessTxAt("1", senderInfo, lockTx, 10, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTxAt("2", senderInfo, reclaimTx, 15, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "the locked output of reclaim with ID 2 is locked until block 20"}))
+			err = verifier.ProcessTxAt("2", senderInfo, reclaimTx, 20, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(proto.Equal(getOutput("2"), &token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 100})).To(BeTrue())
+			err = verifier.ProcessTxAt("3", recipientInfo, claimTx, 15, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "locked output with ID \x00tokenLockedOutput\x001\x000\x00 has already been spent"}))
+		})
+
+		It("rejects claims after the deadline", func() {
+			err := verifier.ProcessTxAt("1", senderInfo, lockTx, 10, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTxAt("2", recipientInfo, claimTx, 20, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "the locked output of claim with ID 2 expired at block 20"}))
+		})
+
+		It("rejects claims with the wrong preimage", func() {
+			err := verifier.ProcessTxAt("1", senderInfo, lockTx, 10, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			claim.Preimage = []byte("guess")
+			err = verifier.ProcessTxAt("2", recipientInfo, claimTx, 15, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "the preimage in claim with ID 2 does not match the hash of the locked output"}))
+		})
+
+		It("rejects claims by anyone but the recipient", func() {
+			err := verifier.ProcessTxAt("1", senderInfo, lockTx, 10, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTxAt("2", senderInfo, claimTx, 15, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "the creator of claim with ID 2 is not the recipient of the locked output"}))
+		})
+
+		It("rejects reclaims by anyone but the sender", func() {
+			err := verifier.ProcessTxAt("1", senderInfo, lockTx, 10, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTxAt("2", recipientInfo, reclaimTx, 25, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "the creator of reclaim with ID 2 is not the sender of the locked output"}))
+		})
+
+		It("rejects claims and reclaims without a block number", func() {
+			err := verifier.ProcessTx("1", senderInfo, lockTx, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("2", recipientInfo, claimTx, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "no block number for claim with ID 2"}))
+			err = verifier.ProcessTx("2", senderInfo, reclaimTx, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "no block number for reclaim with ID 2"}))
+		})
+
+		It("rejects claims of missing locked outputs", func() {
+			err := verifier.ProcessTxAt("2", recipientInfo, claimTx, 15, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "locked output with ID \x00tokenLockedOutput\x001\x000\x00 does not exist"}))
+		})
+
+		It("rejects locks whose deadline has passed", func() {
+			err := verifier.ProcessTxAt("1", senderInfo, lockTx, 20, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "the deadline of the locked output in lock with ID 1 has already passed"}))
+		})
+
+		It("rejects locks with a sender other than the creator", func() {
+			lock.LockedOutput.Sender = []byte("owner-2")
+			err := verifier.ProcessTxAt("1", senderInfo, lockTx, 10, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "the sender of the locked output in lock with ID 1 is not the creator"}))
+		})
+
+		It("rejects locks with a hash that is not a SHA-256 hash", func() {
+			lock.LockedOutput.Hash = []byte("short")
+			err := verifier.ProcessTxAt("1", senderInfo, lockTx, 10, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "the hash of the locked output in lock with ID 1 is not a SHA-256 hash"}))
+		})
+
+		It("rejects locks that do not balance", func() {
+			lock.Output.Quantity = 12
+			err := verifier.ProcessTxAt("1", senderInfo, lockTx, 10, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token sum mismatch in inputs and outputs for lock with ID 1 (112 vs 111)"}))
+		})
+
+		It("rejects locks whose output sum overflows", func() {
+			lock.Output.Quantity = math.MaxUint64
+			err := verifier.ProcessTxAt("1", senderInfo, lockTx, 10, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: fmt.Sprintf("token sum overflows (100 + %d) in lock with ID 1", uint64(math.MaxUint64))}))
+		})
+
+		It("rejects locks with change for someone else", func() {
+			lock.Output.Owner = []byte("owner-2")
+			err := verifier.ProcessTxAt("1", senderInfo, lockTx, 10, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "the owner of the output in lock with ID 1 is not the creator"}))
+		})
+	})
+
+	Describe("Test ProcessTx supply tracking", func() {
+		var (
+			fakeSupplyPolicy *mockid.SupplyPolicy
+		)
+
+		BeforeEach(func() {
+			fakeSupplyPolicy = &mockid.SupplyPolicy{}
+			verifier.SupplyPolicy = fakeSupplyPolicy
+			memoryLedger = plain.NewMemoryLedger()
+			fakePublicInfo.PublicReturns([]byte("owner-1"))
+		})
+
+		getSupply := func(tokenType string) *token.TokenSupply {
+			supplyBytes, err := memoryLedger.GetState("tms", strings.Join([]string{"", "tokenSupply", tokenType, ""}, "\x00"))
+			Expect(err).NotTo(HaveOccurred())
+			supply := &token.TokenSupply{}
+			Expect(proto.Unmarshal(supplyBytes, supply)).To(Succeed())
+			return supply
+		}
+
+		importOf := func(outputs ...*token.PlainOutput) *token.TokenTransaction {
+			return &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainImport{PlainImport: &token.PlainImport{Outputs: outputs}},
+					},
+				},
+			}
+		}
+
+		It("increases the supply on import and decreases it on redeem", func() {
+			err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("1", fakePublicInfo, importOf(&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 9}), memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(getSupply("TOK1"), &token.TokenSupply{Type: "TOK1", Quantity: 120})).To(BeTrue())
+			Expect(proto.Equal(getSupply("TOK2"), &token.TokenSupply{Type: "TOK2", Quantity: 222})).To(BeTrue())
+
+			redeemTransaction := &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainRedeem{
+							PlainRedeem: &token.PlainTransfer{
+								Inputs: []*token.InputId{{TxId: "0", Index: 0}},
+								Outputs: []*token.PlainOutput{
+									{Type: "TOK1", Quantity: 100},
+									{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 11},
+								},
+							},
+						},
+					},
+				},
+			}
+			err = verifier.ProcessTx("2", fakePublicInfo, redeemTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(getSupply("TOK1"), &token.TokenSupply{Type: "TOK1", Quantity: 20})).To(BeTrue())
+		})
+
+		It("does not change the supply on transfer", func() {
+			err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			transferTransaction := &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainTransfer{
+							PlainTransfer: &token.PlainTransfer{
+								Inputs:  []*token.InputId{{TxId: "0", Index: 0}},
+								Outputs: []*token.PlainOutput{{Owner: []byte("owner-2"), Type: "TOK1", Quantity: 111}},
+							},
+						},
+					},
+				},
+			}
+			err = verifier.ProcessTx("1", fakePublicInfo, transferTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(getSupply("TOK1"), &token.TokenSupply{Type: "TOK1", Quantity: 111})).To(BeTrue())
+		})
+
+		It("rejects imports beyond the maximum supply", func() {
+			fakeSupplyPolicy.MaxSupplyStub = func(tokenType string) uint64 {
+				if tokenType == "TOK1" {
+					return 120
+				}
+				return 0
+			}
+			err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("1", fakePublicInfo, importOf(&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 9}), memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = verifier.ProcessTx("2", fakePublicInfo, importOf(
+				&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK2", Quantity: 1000},
+				&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 1},
+			), memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "import with ID 2 exceeds the maximum supply of token type 'TOK1' (120 + 1 > 120)"}))
+			Expect(proto.Equal(getSupply("TOK2"), &token.TokenSupply{Type: "TOK2", Quantity: 222})).To(BeTrue())
+		})
+
+		It("rejects imports overflowing the supply", func() {
+			err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("1", fakePublicInfo, importOf(&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: ^uint64(0) - 110}), memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token sum overflows (111 + 18446744073709551505) in the supply of token type 'TOK1' for import with ID 1"}))
+		})
+
+		It("rejects imports whose outputs overflow the supply", func() {
+			err := verifier.ProcessTx("1", fakePublicInfo, importOf(
+				&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: ^uint64(0)},
+				&token.PlainOutput{Owner: []byte("owner-2"), Type: "TOK1", Quantity: 1},
+			), memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token sum overflows (18446744073709551615 + 1) in the supply of token type 'TOK1' for import with ID 1"}))
+		})
+	})
 })
@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package zkat
+
+import (
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// An Issuer that can import new tokens with hidden quantities
+type Issuer struct {
+	PublicParams *PublicParams
+}
+
+// RequestImport creates an import request with the token owners, types, and quantities specified in tokensToIssue.
+// The recipients are owner public keys, and each output is owned by a fresh pseudonym of its recipient.
+func (i *Issuer) RequestImport(tokensToIssue []*token.TokenToIssue) (*token.TokenTransaction, error) {
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, err
+	}
+
+	var outputs []*token.ZkOutput
+	for _, tti := range tokensToIssue {
+		output, _, err := i.PublicParams.newOutput(tti.Recipient, tti.Type, tti.Quantity, rng)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed creating output")
+		}
+		outputs = append(outputs, output)
+	}
+
+	return &token.TokenTransaction{
+		Action: &token.TokenTransaction_ZkAction{
+			ZkAction: &token.ZkTokenAction{
+				Data: &token.ZkTokenAction_ZkImport{
+					ZkImport: &token.ZkImport{
+						Outputs: outputs,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// RequestExpectation is not supported by the zkat driver.
+func (i *Issuer) RequestExpectation(request *token.ExpectationRequest) (*token.TokenTransaction, error) {
+	return nil, errors.New("expectations are not supported by the zkat driver")
+}
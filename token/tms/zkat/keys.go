@@ -0,0 +1,103 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package zkat
+
+import (
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	minUnicodeRuneValue   = 0            //U+0000
+	maxUnicodeRuneValue   = utf8.MaxRune //U+10FFFF - maximum (and unallocated) code point
+	compositeKeyNamespace = "\x00"
+	zkOutput              = "zkOutput"
+	zkInput               = "zkInput"
+	zkTx                  = "zkTx"
+	tokenNameSpace        = "tms"
+)
+
+// Create a ledger key for an individual output in a token transaction, as a function of
+// the transaction ID, and the index of the output
+func createOutputKey(txID string, index int) (string, error) {
+	return createCompositeKey(zkOutput, []string{txID, strconv.Itoa(index)})
+}
+
+// Create a ledger key for a spent individual output in a token transaction, as a function of
+// the transaction ID, and the index of the output
+func createSpentKey(txID string, index int) (string, error) {
+	return createCompositeKey(zkInput, []string{txID, strconv.Itoa(index)})
+}
+
+// Create a ledger key for a token transaction, as a function of the transaction ID
+func createTxKey(txID string) (string, error) {
+	return createCompositeKey(zkTx, []string{txID})
+}
+
+// parseOutputKey returns the transaction ID and the index of the output the key refers to.
+func parseOutputKey(key string) (string, int, error) {
+	objectType, components, err := splitCompositeKey(key)
+	if err != nil {
+		return "", 0, err
+	}
+	if objectType != zkOutput {
+		return "", 0, errors.Errorf("namespace not '%s': '%s'", zkOutput, objectType)
+	}
+	if len(components) != 2 {
+		return "", 0, errors.Errorf("not enough components in output ID composite key; expected 2, received '%s'", components)
+	}
+	index, err := strconv.Atoi(components[1])
+	if err != nil {
+		return "", 0, errors.Errorf("error parsing output index '%s': '%s'", components[1], err)
+	}
+	return components[0], index, nil
+}
+
+// createCompositeKey and its related functions and consts copied from core/chaincode/shim/chaincode.go
+func createCompositeKey(objectType string, attributes []string) (string, error) {
+	if err := validateCompositeKeyAttribute(objectType); err != nil {
+		return "", err
+	}
+	ck := compositeKeyNamespace + objectType + string(rune(minUnicodeRuneValue))
+	for _, att := range attributes {
+		if err := validateCompositeKeyAttribute(att); err != nil {
+			return "", err
+		}
+		ck += att + string(rune(minUnicodeRuneValue))
+	}
+	return ck, nil
+}
+
+func validateCompositeKeyAttribute(str string) error {
+	if !utf8.ValidString(str) {
+		return errors.Errorf("not a valid utf8 string: [%x]", str)
+	}
+	for index, runeValue := range str {
+		if runeValue == minUnicodeRuneValue || runeValue == maxUnicodeRuneValue {
+			return errors.Errorf(`input contain unicode %#U starting at position [%d]. %#U and %#U are not allowed in the input attribute of a composite key`,
+				runeValue, index, minUnicodeRuneValue, maxUnicodeRuneValue)
+		}
+	}
+	return nil
+}
+
+func splitCompositeKey(compositeKey string) (string, []string, error) {
+	componentIndex := 1
+	components := []string{}
+	for i := 1; i < len(compositeKey); i++ {
+		if compositeKey[i] == minUnicodeRuneValue {
+			components = append(components, compositeKey[componentIndex:i])
+			componentIndex = i + 1
+		}
+	}
+	if len(components) < 2 {
+		return "", nil, errors.New("invalid composite key - no components found")
+	}
+	return components[0], components[1:], nil
+}
@@ -0,0 +1,231 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package zkat
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-amcl/amcl"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// A KeyPair is the long-term key of a token owner. Its public key is what senders use
+// as recipient, but outputs are never owned by it directly: each output is owned by a fresh
+// pseudonym derived from it, which only the holder of the secret key can link back to it.
+type KeyPair struct {
+	secret *FP256BN.BIG
+	public *FP256BN.ECP
+}
+
+// NewKeyPair generates a new owner key pair.
+func NewKeyPair(pp *PublicParams) (*KeyPair, error) {
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, err
+	}
+	secret := idemix.RandModOrder(rng)
+	return &KeyPair{secret: secret, public: pp.G.Mul(secret)}, nil
+}
+
+// KeyPairFromBytes deserializes an owner key pair from its secret key.
+func KeyPairFromBytes(pp *PublicParams, raw []byte) (*KeyPair, error) {
+	secret, err := scalarFromBytes(raw)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid owner secret key")
+	}
+	return &KeyPair{secret: secret, public: pp.G.Mul(secret)}, nil
+}
+
+// Bytes returns the serialized secret key of the key pair.
+func (kp *KeyPair) Bytes() []byte {
+	return idemix.BigToBytes(kp.secret)
+}
+
+// PublicKey returns the serialized public key of the key pair, which is used as recipient.
+func (kp *KeyPair) PublicKey() []byte {
+	return idemix.EcpToBytes(kp.public)
+}
+
+// An opening carries the quantity and blinding factor of a commitment,
+// together with the secrets that allow to sign with the pseudonym of the output.
+type opening struct {
+	quantity uint64
+	blinding *FP256BN.BIG
+	sk       *FP256BN.BIG
+	randNym  *FP256BN.BIG
+}
+
+// newOutput creates an output of the given quantity of tokens for the owner of the recipient public key.
+// The sender picks an ephemeral secret e and publishes E = G^e; both the sender, through recipient^e,
+// and the owner, through E^secret, compute the same shared point, from which the pseudonym owning the
+// output and the key encrypting the opening of the commitment are derived.
+func (pp *PublicParams) newOutput(recipient []byte, tokenType string, quantity uint64, rng *amcl.RAND) (*token.ZkOutput, *FP256BN.BIG, error) {
+	recipientKey, err := pointFromBytes(recipient)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "invalid recipient")
+	}
+	if tokenType == "" {
+		return nil, nil, errors.New("token type must be specified")
+	}
+
+	ephemeralSecret := idemix.RandModOrder(rng)
+	ephemeralKey := pp.G.Mul(ephemeralSecret)
+	owner, encryptionKey := pp.deriveOwner(recipientKey, recipientKey.Mul(ephemeralSecret))
+
+	blinding := idemix.RandModOrder(rng)
+	commitment := pp.commit(quantity, blinding)
+	rangeProof, err := pp.proveRange(quantity, blinding, rng)
+	if err != nil {
+		return nil, nil, err
+	}
+	encryptedOpening, err := encryptOpening(encryptionKey, quantity, blinding, idemix.EcpToBytes(commitment))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &token.ZkOutput{
+		Owner:            idemix.EcpToBytes(owner),
+		EphemeralKey:     idemix.EcpToBytes(ephemeralKey),
+		Type:             tokenType,
+		Commitment:       idemix.EcpToBytes(commitment),
+		RangeProof:       rangeProof,
+		EncryptedOpening: encryptedOpening,
+	}, blinding, nil
+}
+
+// deriveOwner derives the pseudonym G^(secret+s) * H^r of the owner of the recipient key, where s and r
+// are derived from the shared point, along with the key that encrypts the opening of the output.
+func (pp *PublicParams) deriveOwner(recipientKey, shared *FP256BN.ECP) (*FP256BN.ECP, []byte) {
+	s, randNym, encryptionKey := deriveSecrets(shared)
+	return add(recipientKey, pp.G.Mul2(s, pp.H, randNym)), encryptionKey
+}
+
+// open recognizes whether the output is owned by the key pair and, if so,
+// decrypts and checks the opening of its commitment.
+func (kp *KeyPair) open(pp *PublicParams, output *token.ZkOutput) (*opening, bool, error) {
+	owner, err := pointFromBytes(output.Owner)
+	if err != nil {
+		return nil, false, errors.WithMessage(err, "invalid owner")
+	}
+	ephemeralKey, err := pointFromBytes(output.EphemeralKey)
+	if err != nil {
+		return nil, false, errors.WithMessage(err, "invalid ephemeral key")
+	}
+
+	shared := ephemeralKey.Mul(kp.secret)
+	s, randNym, encryptionKey := deriveSecrets(shared)
+	if !add(kp.public, pp.G.Mul2(s, pp.H, randNym)).Equals(owner) {
+		return nil, false, nil
+	}
+
+	quantity, blinding, err := decryptOpening(encryptionKey, output.EncryptedOpening, output.Commitment)
+	if err != nil {
+		return nil, false, err
+	}
+	commitment, err := pointFromBytes(output.Commitment)
+	if err != nil {
+		return nil, false, errors.WithMessage(err, "invalid commitment")
+	}
+	if !pp.commit(quantity, blinding).Equals(commitment) {
+		return nil, false, errors.New("opening does not match the commitment")
+	}
+
+	return &opening{
+		quantity: quantity,
+		blinding: blinding,
+		sk:       idemix.Modadd(kp.secret, s, idemix.GroupOrder),
+		randNym:  randNym,
+	}, true, nil
+}
+
+// sign signs msg with the pseudonym of an owned output.
+func (pp *PublicParams) sign(o *opening, owner []byte, msg []byte, rng *amcl.RAND) ([]byte, error) {
+	nym, err := pointFromBytes(owner)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid owner")
+	}
+	signature, err := idemix.NewNymSignature(o.sk, nym, o.randNym, pp.nymPublicKey(), msg, rng)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(signature)
+}
+
+// verifySignature verifies a signature on msg by the pseudonym that owns an output.
+func (pp *PublicParams) verifySignature(owner []byte, signature []byte, msg []byte) error {
+	nym, err := pointFromBytes(owner)
+	if err != nil {
+		return errors.WithMessage(err, "invalid owner")
+	}
+	nymSignature := &idemix.NymSignature{}
+	if err := proto.Unmarshal(signature, nymSignature); err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	return nymSignature.Ver(nym, pp.nymPublicKey(), msg)
+}
+
+func deriveSecrets(shared *FP256BN.ECP) (s, randNym *FP256BN.BIG, encryptionKey []byte) {
+	sharedBytes := idemix.EcpToBytes(shared)
+	s = idemix.HashModOrder(append([]byte("zkat.owner.sk"), sharedBytes...))
+	randNym = idemix.HashModOrder(append([]byte("zkat.owner.rand"), sharedBytes...))
+	key := sha256.Sum256(append([]byte("zkat.owner.key"), sharedBytes...))
+	return s, randNym, key[:]
+}
+
+func encryptOpening(key []byte, quantity uint64, blinding *FP256BN.BIG, commitment []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Wrap(err, "failed generating nonce")
+	}
+	plaintext := make([]byte, 8, 8+idemix.FieldBytes)
+	binary.BigEndian.PutUint64(plaintext, quantity)
+	plaintext = append(plaintext, idemix.BigToBytes(blinding)...)
+	return aead.Seal(nonce, nonce, plaintext, commitment), nil
+}
+
+func decryptOpening(key []byte, ciphertext []byte, commitment []byte) (uint64, *FP256BN.BIG, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return 0, nil, errors.New("encrypted opening is too short")
+	}
+	plaintext, err := aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], commitment)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "failed decrypting opening")
+	}
+	if len(plaintext) != 8+idemix.FieldBytes {
+		return 0, nil, errors.Errorf("invalid opening length %d", len(plaintext))
+	}
+	blinding, err := scalarFromBytes(plaintext[8:])
+	if err != nil {
+		return 0, nil, errors.WithMessage(err, "invalid blinding factor")
+	}
+	return binary.BigEndian.Uint64(plaintext[:8]), blinding, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating cipher")
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,277 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package zkat
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-amcl/amcl"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// An OwnerTransactor transfers and redeems the tokens owned by a key pair.
+// It runs where the secret key of the owner is, that is, on the client: it opens the
+// unspent tokens listed by the prover peer, and creates the proofs and the signatures
+// of the transactions itself, so that the secret key never leaves the owner.
+type OwnerTransactor struct {
+	PublicParams *PublicParams
+	KeyPair      *KeyPair
+}
+
+// An ownedInput is an unspent output owned by the transactor, along with its opening.
+type ownedInput struct {
+	id      *token.InputId
+	output  *token.ZkOutput
+	opening *opening
+}
+
+// Open returns the tokens, among the listed ones, that are owned by the key pair,
+// along with the quantities their outputs hide.
+func (t *OwnerTransactor) Open(tokens []*token.TokenOutput) []*token.TokenOutput {
+	owned := make([]*token.TokenOutput, 0)
+	for _, tok := range tokens {
+		input, err := t.openInput(tok)
+		if err != nil {
+			continue
+		}
+		owned = append(owned, &token.TokenOutput{
+			Id:       tok.Id,
+			Type:     input.output.Type,
+			Quantity: input.opening.quantity,
+			ZkOutput: tok.ZkOutput,
+		})
+	}
+	return owned
+}
+
+// RequestTransfer creates a TokenTransaction of type transfer request that spends the
+// given unspent tokens. The quantities of the shares must add up to the quantity of the inputs.
+func (t *OwnerTransactor) RequestTransfer(tokens []*token.TokenOutput, shares []*token.RecipientTransferShare) (*token.TokenTransaction, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("no shares in TransferRequest")
+	}
+	inputs, tokenType, quantitySum, err := t.getInputs(tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	outputSum := uint64(0)
+	for _, share := range shares {
+		outputSum, err = addQuantity(outputSum, share.Quantity)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid shares")
+		}
+	}
+	if outputSum != quantitySum {
+		return nil, errors.Errorf("total quantity [%d] from TokenIds does not match the quantity [%d] of the shares", quantitySum, outputSum)
+	}
+
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, err
+	}
+	outputs, outputBlinding, err := t.newOutputs(shares, tokenType, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer := &token.ZkTransfer{
+		Inputs:  inputIDs(inputs),
+		Outputs: outputs,
+	}
+	msg := transferSpend(transfer).msg
+	transfer.BalanceProof, transfer.InputSignatures, err = t.prove(inputs, outputs, outputBlinding, 0, msg, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.TokenTransaction{
+		Action: &token.TokenTransaction_ZkAction{
+			ZkAction: &token.ZkTokenAction{
+				Data: &token.ZkTokenAction_ZkTransfer{ZkTransfer: transfer},
+			},
+		},
+	}, nil
+}
+
+// RequestRedeem creates a TokenTransaction of type redeem request that spends the given
+// unspent tokens. The remaining quantity, if any, is transferred back to the owner.
+func (t *OwnerTransactor) RequestRedeem(tokens []*token.TokenOutput, quantity uint64) (*token.TokenTransaction, error) {
+	if len(tokens) == 0 {
+		return nil, errors.New("no token ids in RedeemRequest")
+	}
+	if quantity <= 0 {
+		return nil, errors.Errorf("quantity to redeem [%d] must be greater than 0", quantity)
+	}
+
+	inputs, tokenType, quantitySum, err := t.getInputs(tokens)
+	if err != nil {
+		return nil, err
+	}
+	if quantitySum < quantity {
+		return nil, errors.Errorf("total quantity [%d] from TokenIds is less than quantity [%d] to be redeemed", quantitySum, quantity)
+	}
+
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, err
+	}
+	var shares []*token.RecipientTransferShare
+	if quantitySum > quantity {
+		shares = append(shares, &token.RecipientTransferShare{
+			Recipient: t.KeyPair.PublicKey(),
+			Quantity:  quantitySum - quantity,
+		})
+	}
+	outputs, outputBlinding, err := t.newOutputs(shares, tokenType, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	redeem := &token.ZkRedeem{
+		Inputs:   inputIDs(inputs),
+		Quantity: quantity,
+		Outputs:  outputs,
+	}
+	msg := redeemSpend(redeem).msg
+	redeem.BalanceProof, redeem.InputSignatures, err = t.prove(inputs, outputs, outputBlinding, quantity, msg, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.TokenTransaction{
+		Action: &token.TokenTransaction_ZkAction{
+			ZkAction: &token.ZkTokenAction{
+				Data: &token.ZkTokenAction_ZkRedeem{ZkRedeem: redeem},
+			},
+		},
+	}, nil
+}
+
+// getInputs opens the outputs of the given tokens.
+// It returns the owned inputs, their token type and the sum of their quantities.
+func (t *OwnerTransactor) getInputs(tokens []*token.TokenOutput) ([]*ownedInput, string, uint64, error) {
+	if len(tokens) == 0 {
+		return nil, "", 0, errors.New("no token ids in request")
+	}
+
+	var inputs []*ownedInput
+	tokenType := ""
+	quantitySum := uint64(0)
+	for _, tok := range tokens {
+		input, err := t.openInput(tok)
+		if err != nil {
+			return nil, "", 0, err
+		}
+		if tokenType == "" {
+			tokenType = input.output.Type
+		} else if tokenType != input.output.Type {
+			return nil, "", 0, errors.Errorf("two or more token types specified in input: '%s', '%s'", tokenType, input.output.Type)
+		}
+		inputs = append(inputs, input)
+		quantitySum, err = addQuantity(quantitySum, input.opening.quantity)
+		if err != nil {
+			return nil, "", 0, errors.WithMessage(err, "invalid inputs")
+		}
+	}
+
+	return inputs, tokenType, quantitySum, nil
+}
+
+// openInput opens the output of a listed token, and checks that it is owned by the key pair.
+func (t *OwnerTransactor) openInput(tok *token.TokenOutput) (*ownedInput, error) {
+	txID, index, err := parseOutputKey(string(tok.Id))
+	if err != nil {
+		return nil, errors.WithMessage(err, "error parsing input composite key")
+	}
+	output := &token.ZkOutput{}
+	if err := proto.Unmarshal(tok.ZkOutput, output); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling input bytes")
+	}
+
+	opening, owned, err := t.KeyPair.open(t.PublicParams, output)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed opening input")
+	}
+	if !owned {
+		return nil, errors.New("the requestor does not own inputs")
+	}
+	return &ownedInput{
+		id:      &token.InputId{TxId: txID, Index: uint32(index)},
+		output:  output,
+		opening: opening,
+	}, nil
+}
+
+// newOutputs creates the outputs of the shares, and returns them along with the sum of their blinding factors.
+func (t *OwnerTransactor) newOutputs(shares []*token.RecipientTransferShare, tokenType string, rng *amcl.RAND) ([]*token.ZkOutput, *FP256BN.BIG, error) {
+	var outputs []*token.ZkOutput
+	blindingSum := FP256BN.NewBIGint(0)
+	for _, share := range shares {
+		output, blinding, err := t.PublicParams.newOutput(share.Recipient, tokenType, share.Quantity, rng)
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "failed creating output")
+		}
+		outputs = append(outputs, output)
+		blindingSum = idemix.Modadd(blindingSum, blinding, idemix.GroupOrder)
+	}
+	return outputs, blindingSum, nil
+}
+
+// prove creates the balance proof of a spend, and signs the spend with the pseudonym of each input.
+func (t *OwnerTransactor) prove(inputs []*ownedInput, outputs []*token.ZkOutput, outputBlinding *FP256BN.BIG, redeemed uint64, msg []byte, rng *amcl.RAND) (*token.SchnorrProof, [][]byte, error) {
+	difference := FP256BN.NewECP()
+	blinding := FP256BN.NewBIGint(0)
+	for _, input := range inputs {
+		commitment, err := pointFromBytes(input.output.Commitment)
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "invalid input commitment")
+		}
+		difference.Add(commitment)
+		blinding = idemix.Modadd(blinding, input.opening.blinding, idemix.GroupOrder)
+	}
+	for _, output := range outputs {
+		commitment, err := pointFromBytes(output.Commitment)
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "invalid output commitment")
+		}
+		difference.Sub(commitment)
+	}
+	if redeemed > 0 {
+		difference.Sub(t.PublicParams.G.Mul(bigFromUint64(redeemed)))
+	}
+	blinding = idemix.Modsub(blinding, outputBlinding, idemix.GroupOrder)
+	balanceProof := t.PublicParams.proveBalance(blinding, difference, msg, rng)
+
+	var signatures [][]byte
+	for _, input := range inputs {
+		signature, err := t.PublicParams.sign(input.opening, input.output.Owner, msg, rng)
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "failed signing input")
+		}
+		signatures = append(signatures, signature)
+	}
+	return balanceProof, signatures, nil
+}
+
+func inputIDs(inputs []*ownedInput) []*token.InputId {
+	var ids []*token.InputId
+	for _, input := range inputs {
+		ids = append(ids, input.id)
+	}
+	return ids
+}
+
+// addQuantity returns the sum of two token quantities, or an error if the sum overflows.
+func addQuantity(sum, quantity uint64) (uint64, error) {
+	if sum+quantity < sum {
+		return 0, errors.Errorf("token sum overflows (%d + %d)", sum, quantity)
+	}
+	return sum + quantity, nil
+}
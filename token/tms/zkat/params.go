@@ -0,0 +1,140 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package zkat
+
+import (
+	"crypto/sha256"
+
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/pkg/errors"
+)
+
+// DefaultBitLength is the number of bits of the range proofs of the default
+// public parameters, which allows any quantity that fits in a uint64.
+const DefaultBitLength = 64
+
+// PublicParams are the public parameters shared by the provers and the
+// verifiers of the zero-knowledge token driver.
+type PublicParams struct {
+	// G is the generator the quantities are committed to
+	G *FP256BN.ECP
+	// H is the generator the blinding factors are committed to
+	H *FP256BN.ECP
+	// BitLength is the number of bits of the range proofs,
+	// that is, quantities must be between 1 and 2^BitLength
+	BitLength int
+}
+
+// NewPublicParams creates public parameters whose range proofs have the given number of bits.
+// The generators are derived by hashing fixed labels to the curve, hence nobody knows their
+// discrete logarithms with respect to each other.
+func NewPublicParams(bitLength int) (*PublicParams, error) {
+	if bitLength <= 0 || bitLength > 64 {
+		return nil, errors.Errorf("invalid bit length %d, it must be between 1 and 64", bitLength)
+	}
+	return &PublicParams{
+		G:         hashToG1("zkat.generator.G"),
+		H:         hashToG1("zkat.generator.H"),
+		BitLength: bitLength,
+	}, nil
+}
+
+// DefaultPublicParams returns the public parameters with the default bit length.
+func DefaultPublicParams() *PublicParams {
+	pp, err := NewPublicParams(DefaultBitLength)
+	if err != nil {
+		panic(err)
+	}
+	return pp
+}
+
+// nymPublicKey returns an idemix issuer public key carrying the generators the owner
+// pseudonyms are based on, so that owners can sign with idemix pseudonym signatures.
+func (pp *PublicParams) nymPublicKey() *idemix.IssuerPublicKey {
+	return &idemix.IssuerPublicKey{
+		HSk:   idemix.EcpToProto(pp.G),
+		HRand: idemix.EcpToProto(pp.H),
+		Hash:  idemix.BigToBytes(idemix.HashModOrder(append(idemix.EcpToBytes(pp.G), idemix.EcpToBytes(pp.H)...))),
+	}
+}
+
+// commit returns the Pedersen commitment G^quantity * H^blinding.
+func (pp *PublicParams) commit(quantity uint64, blinding *FP256BN.BIG) *FP256BN.ECP {
+	return pp.G.Mul2(bigFromUint64(quantity), pp.H, blinding)
+}
+
+func hashToG1(label string) *FP256BN.ECP {
+	digest := sha256.Sum256([]byte(label))
+	return FP256BN.ECP_mapit(digest[:])
+}
+
+func bigFromUint64(n uint64) *FP256BN.BIG {
+	raw := make([]byte, idemix.FieldBytes)
+	for i := 0; i < 8; i++ {
+		raw[idemix.FieldBytes-1-i] = byte(n >> (8 * uint(i)))
+	}
+	return FP256BN.FromBytes(raw)
+}
+
+// pointFromBytes deserializes a point of G1, and rejects the point at infinity
+// as well as byte strings that do not encode a point of the curve.
+func pointFromBytes(raw []byte) (*FP256BN.ECP, error) {
+	if len(raw) != 2*idemix.FieldBytes+1 {
+		return nil, errors.Errorf("invalid point length %d", len(raw))
+	}
+	point := FP256BN.ECP_fromBytes(raw)
+	if point.Is_infinity() {
+		return nil, errors.New("invalid point")
+	}
+	return point, nil
+}
+
+// scalarFromBytes deserializes an element of Zq, and rejects non canonical encodings.
+func scalarFromBytes(raw []byte) (*FP256BN.BIG, error) {
+	if len(raw) != idemix.FieldBytes {
+		return nil, errors.Errorf("invalid scalar length %d", len(raw))
+	}
+	scalar := FP256BN.FromBytes(raw)
+	reduced := FP256BN.NewBIGcopy(scalar)
+	reduced.Mod(idemix.GroupOrder)
+	if string(idemix.BigToBytes(reduced)) != string(raw) {
+		return nil, errors.New("invalid scalar")
+	}
+	return scalar, nil
+}
+
+func add(a, b *FP256BN.ECP) *FP256BN.ECP {
+	res := FP256BN.NewECP()
+	res.Copy(a)
+	res.Add(b)
+	return res
+}
+
+func sub(a, b *FP256BN.ECP) *FP256BN.ECP {
+	res := FP256BN.NewECP()
+	res.Copy(a)
+	res.Sub(b)
+	return res
+}
+
+func neg(a *FP256BN.BIG) *FP256BN.BIG {
+	return FP256BN.Modneg(a, idemix.GroupOrder)
+}
+
+func equalScalars(a, b *FP256BN.BIG) bool {
+	return string(idemix.BigToBytes(a)) == string(idemix.BigToBytes(b))
+}
+
+// challenge hashes the label and the given byte strings into a Fiat-Shamir challenge.
+func challenge(label string, elements ...[]byte) *FP256BN.BIG {
+	data := []byte(label)
+	for _, e := range elements {
+		data = append(data, e...)
+	}
+	return idemix.HashModOrder(data)
+}
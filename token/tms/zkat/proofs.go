@@ -0,0 +1,198 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package zkat
+
+import (
+	"strconv"
+
+	"github.com/hyperledger/fabric-amcl/amcl"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+const (
+	bitProofLabel     = "zkat.bit"
+	balanceProofLabel = "zkat.balance"
+)
+
+// proveRange creates a proof that the commitment G^quantity * H^blinding hides a quantity
+// between 1 and 2^BitLength. The quantity minus one is decomposed into bits, each bit is
+// committed to, and each bit commitment comes with an OR-proof that it hides either 0 or 1.
+// The blinding factors of the bits are chosen such that the bit commitments, weighted by
+// their powers of two, add up to the commitment divided by G.
+func (pp *PublicParams) proveRange(quantity uint64, blinding *FP256BN.BIG, rng *amcl.RAND) (*token.RangeProof, error) {
+	if quantity == 0 {
+		return nil, errors.New("quantity must be greater than 0")
+	}
+	value := quantity - 1
+	if pp.BitLength < 64 && value>>uint(pp.BitLength) != 0 {
+		return nil, errors.Errorf("quantity %d exceeds the maximum of 2^%d", quantity, pp.BitLength)
+	}
+	commitment := idemix.EcpToBytes(pp.commit(quantity, blinding))
+
+	n := pp.BitLength
+	blindings := make([]*FP256BN.BIG, n)
+	// The blinding factor of the most significant bit is the one that makes the weighted sum of the
+	// bit blinding factors equal to the blinding factor of the commitment.
+	remainder := FP256BN.NewBIGcopy(blinding)
+	for i := 0; i < n-1; i++ {
+		blindings[i] = idemix.RandModOrder(rng)
+		remainder = idemix.Modsub(remainder, FP256BN.Modmul(blindings[i], powerOfTwo(i), idemix.GroupOrder), idemix.GroupOrder)
+	}
+	inverse := powerOfTwo(n - 1)
+	inverse.Invmodp(idemix.GroupOrder)
+	blindings[n-1] = FP256BN.Modmul(remainder, inverse, idemix.GroupOrder)
+
+	proof := &token.RangeProof{}
+	for i := 0; i < n; i++ {
+		bit := int((value >> uint(i)) & 1)
+		bitCommitment := pp.H.Mul(blindings[i])
+		if bit == 1 {
+			bitCommitment.Add(pp.G)
+		}
+		bitProof := pp.proveBit(bit, blindings[i], bitCommitment, commitment, i, rng)
+		proof.BitCommitments = append(proof.BitCommitments, idemix.EcpToBytes(bitCommitment))
+		proof.BitProofs = append(proof.BitProofs, bitProof)
+	}
+	return proof, nil
+}
+
+// verifyRange verifies that the given commitment hides a quantity between 1 and 2^BitLength.
+func (pp *PublicParams) verifyRange(commitment *FP256BN.ECP, proof *token.RangeProof) error {
+	if proof == nil {
+		return errors.New("missing range proof")
+	}
+	n := pp.BitLength
+	if len(proof.BitCommitments) != n || len(proof.BitProofs) != n {
+		return errors.Errorf("range proof has %d bit commitments and %d bit proofs, expected %d", len(proof.BitCommitments), len(proof.BitProofs), n)
+	}
+	commitmentBytes := idemix.EcpToBytes(commitment)
+
+	// accumulate the sum of the bit commitments weighted by their powers of two
+	sum := FP256BN.NewECP()
+	two := FP256BN.NewBIGint(2)
+	for i := n - 1; i >= 0; i-- {
+		bitCommitment, err := pointFromBytes(proof.BitCommitments[i])
+		if err != nil {
+			return errors.WithMessage(err, "invalid bit commitment "+strconv.Itoa(i))
+		}
+		if err := pp.verifyBit(bitCommitment, proof.BitProofs[i], commitmentBytes, i); err != nil {
+			return err
+		}
+		sum = sum.Mul(two)
+		sum.Add(bitCommitment)
+	}
+	if !sum.Equals(sub(commitment, pp.G)) {
+		return errors.New("bit commitments do not add up to the commitment")
+	}
+	return nil
+}
+
+// proveBit creates a proof that bitCommitment = G^bit * H^blinding with bit either 0 or 1,
+// without revealing which one. The branch of the other bit value is simulated.
+func (pp *PublicParams) proveBit(bit int, blinding *FP256BN.BIG, bitCommitment *FP256BN.ECP, commitment []byte, index int, rng *amcl.RAND) *token.BitProof {
+	// Y0 = B and Y1 = B / G are both of the form H^x for the actual bit
+	y := [2]*FP256BN.ECP{bitCommitment, sub(bitCommitment, pp.G)}
+
+	var t [2]*FP256BN.ECP
+	var c, s [2]*FP256BN.BIG
+
+	other := 1 - bit
+	c[other] = idemix.RandModOrder(rng)
+	s[other] = idemix.RandModOrder(rng)
+	t[other] = pp.H.Mul2(s[other], y[other], neg(c[other]))
+
+	k := idemix.RandModOrder(rng)
+	t[bit] = pp.H.Mul(k)
+
+	ch := pp.bitChallenge(bitCommitment, t[0], t[1], commitment, index)
+	c[bit] = idemix.Modsub(ch, c[other], idemix.GroupOrder)
+	s[bit] = idemix.Modadd(k, FP256BN.Modmul(c[bit], blinding, idemix.GroupOrder), idemix.GroupOrder)
+
+	return &token.BitProof{
+		Challenge_0: idemix.BigToBytes(c[0]),
+		Challenge_1: idemix.BigToBytes(c[1]),
+		Response_0:  idemix.BigToBytes(s[0]),
+		Response_1:  idemix.BigToBytes(s[1]),
+	}
+}
+
+func (pp *PublicParams) verifyBit(bitCommitment *FP256BN.ECP, proof *token.BitProof, commitment []byte, index int) error {
+	if proof == nil {
+		return errors.Errorf("missing proof of bit %d", index)
+	}
+	var c, s [2]*FP256BN.BIG
+	for i, raw := range [][]byte{proof.Challenge_0, proof.Challenge_1, proof.Response_0, proof.Response_1} {
+		scalar, err := scalarFromBytes(raw)
+		if err != nil {
+			return errors.WithMessage(err, "invalid proof of bit "+strconv.Itoa(index))
+		}
+		if i < 2 {
+			c[i] = scalar
+		} else {
+			s[i-2] = scalar
+		}
+	}
+
+	y := [2]*FP256BN.ECP{bitCommitment, sub(bitCommitment, pp.G)}
+	t0 := pp.H.Mul2(s[0], y[0], neg(c[0]))
+	t1 := pp.H.Mul2(s[1], y[1], neg(c[1]))
+
+	ch := pp.bitChallenge(bitCommitment, t0, t1, commitment, index)
+	if !equalScalars(ch, idemix.Modadd(c[0], c[1], idemix.GroupOrder)) {
+		return errors.Errorf("invalid proof of bit %d", index)
+	}
+	return nil
+}
+
+func (pp *PublicParams) bitChallenge(bitCommitment, t0, t1 *FP256BN.ECP, commitment []byte, index int) *FP256BN.BIG {
+	return challenge(bitProofLabel,
+		commitment,
+		[]byte(strconv.Itoa(index)),
+		idemix.EcpToBytes(bitCommitment),
+		idemix.EcpToBytes(t0),
+		idemix.EcpToBytes(t1))
+}
+
+// proveBalance creates a proof of knowledge of the discrete logarithm of difference with respect to H,
+// bound to msg. Since nobody knows the discrete logarithm of H with respect to G, such a proof shows
+// that the commitments the difference is made of hide quantities that add up to zero.
+func (pp *PublicParams) proveBalance(blinding *FP256BN.BIG, difference *FP256BN.ECP, msg []byte, rng *amcl.RAND) *token.SchnorrProof {
+	k := idemix.RandModOrder(rng)
+	t := pp.H.Mul(k)
+	c := challenge(balanceProofLabel, idemix.EcpToBytes(difference), idemix.EcpToBytes(t), msg)
+	s := idemix.Modadd(k, FP256BN.Modmul(c, blinding, idemix.GroupOrder), idemix.GroupOrder)
+	return &token.SchnorrProof{
+		Challenge: idemix.BigToBytes(c),
+		Response:  idemix.BigToBytes(s),
+	}
+}
+
+func (pp *PublicParams) verifyBalance(difference *FP256BN.ECP, proof *token.SchnorrProof, msg []byte) error {
+	if proof == nil {
+		return errors.New("missing balance proof")
+	}
+	c, err := scalarFromBytes(proof.Challenge)
+	if err != nil {
+		return errors.WithMessage(err, "invalid balance proof")
+	}
+	s, err := scalarFromBytes(proof.Response)
+	if err != nil {
+		return errors.WithMessage(err, "invalid balance proof")
+	}
+	t := pp.H.Mul2(s, difference, neg(c))
+	if !equalScalars(c, challenge(balanceProofLabel, idemix.EcpToBytes(difference), idemix.EcpToBytes(t), msg)) {
+		return errors.New("invalid balance proof")
+	}
+	return nil
+}
+
+func powerOfTwo(exponent int) *FP256BN.BIG {
+	return bigFromUint64(1 << uint(exponent))
+}
@@ -0,0 +1,130 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package zkat
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/ledger"
+	"github.com/pkg/errors"
+)
+
+// A Transactor lists the unspent tokens with hidden quantities.
+// It holds no secret of the owners, hence it can neither recognize the outputs of an
+// owner nor spend them: the outputs are opened, and the transfers and redemptions are
+// created, by the OwnerTransactor of the owner on the client.
+type Transactor struct {
+	Ledger ledger.LedgerReader
+}
+
+// RequestTransfer is not supported by the transactor of the prover peer.
+func (t *Transactor) RequestTransfer(request *token.TransferRequest) (*token.TokenTransaction, error) {
+	return nil, errors.New("transfers of the zkat driver are created by the owner of the inputs")
+}
+
+// RequestRedeem is not supported by the transactor of the prover peer.
+func (t *Transactor) RequestRedeem(request *token.RedeemRequest) (*token.TokenTransaction, error) {
+	return nil, errors.New("redemptions of the zkat driver are created by the owner of the inputs")
+}
+
+// ListTokens returns all the unspent tokens, along with their serialized outputs.
+// Their quantities are hidden, and only their owners can tell which ones they own.
+func (t *Transactor) ListTokens() (*token.UnspentTokens, error) {
+	prefix, err := createCompositeKey(zkOutput, nil)
+	if err != nil {
+		return nil, err
+	}
+	iterator, err := t.Ledger.GetStateRangeScanIterator(tokenNameSpace, prefix, prefix+string(maxUnicodeRuneValue))
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	tokens := make([]*token.TokenOutput, 0)
+	for {
+		next, err := iterator.Next()
+		switch {
+		case err != nil:
+			return nil, err
+
+		case next == nil:
+			// nil response from iterator indicates end of query results
+			return &token.UnspentTokens{Tokens: tokens}, nil
+
+		default:
+			result, ok := next.(*queryresult.KV)
+			if !ok {
+				return nil, errors.New("failed to retrieve unspent tokens: casting error")
+			}
+			output := &token.ZkOutput{}
+			if err := proto.Unmarshal(result.Value, output); err != nil {
+				return nil, errors.Wrap(err, "failed to retrieve unspent tokens")
+			}
+			txID, index, err := parseOutputKey(result.Key)
+			if err != nil {
+				return nil, err
+			}
+			spent, err := t.isSpent(txID, index)
+			if err != nil {
+				return nil, err
+			}
+			if !spent {
+				tokens = append(tokens, &token.TokenOutput{
+					Id:       []byte(result.Key),
+					Type:     output.Type,
+					ZkOutput: result.Value,
+				})
+			}
+		}
+	}
+}
+
+// ListDelegatedTokens is not supported by the zkat driver, which has no delegated outputs.
+func (t *Transactor) ListDelegatedTokens() (*token.UnspentTokens, error) {
+	return nil, errors.New("delegated outputs are not supported by the zkat driver")
+}
+
+// RequestApprove is not supported by the zkat driver.
+func (t *Transactor) RequestApprove(request *token.ApproveRequest) (*token.TokenTransaction, error) {
+	return nil, errors.New("approve is not supported by the zkat driver")
+}
+
+// RequestTransferFrom is not supported by the zkat driver.
+func (t *Transactor) RequestTransferFrom(request *token.TransferRequest) (*token.TokenTransaction, error) {
+	return nil, errors.New("transfer from is not supported by the zkat driver")
+}
+
+// RequestExpectation is not supported by the zkat driver.
+func (t *Transactor) RequestExpectation(request *token.ExpectationRequest) (*token.TokenTransaction, error) {
+	return nil, errors.New("expectations are not supported by the zkat driver")
+}
+
+// GetSupply is not supported by the zkat driver, whose outputs hide their quantities.
+func (t *Transactor) GetSupply(request *token.SupplyRequest) (*token.TokenSupplies, error) {
+	return nil, errors.New("supply tracking is not supported by the zkat driver")
+}
+
+// Done releases any resources held by this transactor
+func (t *Transactor) Done() {
+	if t.Ledger != nil {
+		t.Ledger.Done()
+	}
+}
+
+// isSpent checks whether the output with the given transaction ID and index has been spent.
+func (t *Transactor) isSpent(txID string, index int) (bool, error) {
+	spentKey, err := createSpentKey(txID, index)
+	if err != nil {
+		return false, err
+	}
+	result, err := t.Ledger.GetState(tokenNameSpace, spentKey)
+	if err != nil {
+		return false, err
+	}
+	return result != nil, nil
+}
@@ -0,0 +1,354 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package zkat
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/hyperledger/fabric/token/ledger"
+)
+
+var verifierLogger = flogging.MustGetLogger("token.tms.zkat.verifier")
+
+// TokenInputSpentMarker is the value of the ledger key that marks an output as spent
+var TokenInputSpentMarker = []byte{1}
+
+// A Verifier validates and commits zero-knowledge token transactions.
+type Verifier struct {
+	IssuingValidator identity.IssuingValidator
+	PublicParams     *PublicParams
+}
+
+// A spend is either a transfer or a redeem, the latter taking a public quantity out of circulation.
+type spend struct {
+	inputs       []*token.InputId
+	outputs      []*token.ZkOutput
+	redeemed     uint64
+	balanceProof *token.SchnorrProof
+	signatures   [][]byte
+	// msg is what the balance proof and the signatures are bound to
+	msg []byte
+}
+
+func transferSpend(transfer *token.ZkTransfer) *spend {
+	unsigned := proto.Clone(transfer).(*token.ZkTransfer)
+	unsigned.BalanceProof = nil
+	unsigned.InputSignatures = nil
+	return &spend{
+		inputs:       transfer.Inputs,
+		outputs:      transfer.Outputs,
+		balanceProof: transfer.BalanceProof,
+		signatures:   transfer.InputSignatures,
+		msg:          utils.MarshalOrPanic(unsigned),
+	}
+}
+
+func redeemSpend(redeem *token.ZkRedeem) *spend {
+	unsigned := proto.Clone(redeem).(*token.ZkRedeem)
+	unsigned.BalanceProof = nil
+	unsigned.InputSignatures = nil
+	return &spend{
+		inputs:       redeem.Inputs,
+		outputs:      redeem.Outputs,
+		redeemed:     redeem.Quantity,
+		balanceProof: redeem.BalanceProof,
+		signatures:   redeem.InputSignatures,
+		msg:          utils.MarshalOrPanic(unsigned),
+	}
+}
+
+// ProcessTx checks that transactions are correct wrt. the most recent ledger state.
+// ProcessTx checks are ones that shall be done sequentially, since transactions within a block may introduce dependencies.
+func (v *Verifier) ProcessTx(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, simulator ledger.LedgerWriter) error {
+	verifierLogger.Debugf("checking transaction with txID '%s'", txID)
+	action := ttx.GetZkAction()
+	if action == nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("check process failed for transaction '%s': missing token action", txID)}
+	}
+
+	err := v.checkAction(creator, action, txID, simulator)
+	if err != nil {
+		return err
+	}
+	err = v.checkTxDoesNotExist(txID, simulator)
+	if err != nil {
+		return err
+	}
+
+	verifierLogger.Debugf("committing transaction with txID '%s'", txID)
+	err = v.commitAction(action, txID, simulator)
+	if err != nil {
+		verifierLogger.Errorf("error committing transaction with txID '%s': %s", txID, err)
+		return err
+	}
+	err = v.addTransaction(txID, ttx, simulator)
+	if err != nil {
+		return err
+	}
+	verifierLogger.Debugf("successfully processed transaction with txID '%s'", txID)
+	return nil
+}
+
+func (v *Verifier) checkAction(creator identity.PublicInfo, zkAction *token.ZkTokenAction, txID string, simulator ledger.LedgerReader) error {
+	switch action := zkAction.Data.(type) {
+	case *token.ZkTokenAction_ZkImport:
+		return v.checkImportAction(creator, action.ZkImport, txID, simulator)
+	case *token.ZkTokenAction_ZkTransfer:
+		return v.checkSpend(transferSpend(action.ZkTransfer), txID, simulator)
+	case *token.ZkTokenAction_ZkRedeem:
+		return v.checkRedeemAction(action.ZkRedeem, txID, simulator)
+	default:
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("unknown zk token action: %T", action)}
+	}
+}
+
+func (v *Verifier) checkImportAction(creator identity.PublicInfo, importAction *token.ZkImport, txID string, simulator ledger.LedgerReader) error {
+	outputs := importAction.GetOutputs()
+	if len(outputs) == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no outputs in transaction: %s", txID)}
+	}
+	_, _, err := v.checkOutputs(outputs, txID, simulator)
+	if err != nil {
+		return err
+	}
+	for _, output := range outputs {
+		err := v.IssuingValidator.Validate(creator, output.Type)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("import policy check failed: %s", err)}
+		}
+	}
+	return nil
+}
+
+func (v *Verifier) checkRedeemAction(redeemAction *token.ZkRedeem, txID string, simulator ledger.LedgerReader) error {
+	if redeemAction.Quantity == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("quantity to redeem is 0 in transaction: %s", txID)}
+	}
+	if len(redeemAction.Outputs) > 1 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("too many outputs (%d) in a redeem transaction", len(redeemAction.Outputs))}
+	}
+	return v.checkSpend(redeemSpend(redeemAction), txID, simulator)
+}
+
+// checkSpend checks that the inputs are unspent outputs of a single type, that each of them is
+// signed by its owner, and that the commitments of the inputs hide the same total quantity
+// as the commitments of the outputs plus the redeemed quantity.
+func (v *Verifier) checkSpend(s *spend, txID string, simulator ledger.LedgerReader) error {
+	if len(s.inputs) == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no inputs in transaction: %s", txID)}
+	}
+	if len(s.outputs) == 0 && s.redeemed == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no outputs in transaction: %s", txID)}
+	}
+	if len(s.signatures) != len(s.inputs) {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("transaction %s has %d inputs but %d input signatures", txID, len(s.inputs), len(s.signatures))}
+	}
+
+	outputType, outputSum, err := v.checkOutputs(s.outputs, txID, simulator)
+	if err != nil {
+		return err
+	}
+
+	inputType := ""
+	inputSum := FP256BN.NewECP()
+	processedIDs := make(map[string]bool)
+	for i, id := range s.inputs {
+		inputKey, err := createOutputKey(id.TxId, int(id.Index))
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID for input: %s", err)}
+		}
+		if processedIDs[inputKey] {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("token input '%s' spent more than once in single transaction with txID '%s'", inputKey, txID)}
+		}
+		processedIDs[inputKey] = true
+
+		input, err := v.getOutput(inputKey, simulator)
+		if err != nil {
+			return err
+		}
+		spent, err := v.isSpent(id, simulator)
+		if err != nil {
+			return err
+		}
+		if spent {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s has already been spent", inputKey)}
+		}
+		if inputType == "" {
+			inputType = input.Type
+		} else if inputType != input.Type {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("multiple token types in input for txID: %s (%s, %s)", txID, inputType, input.Type)}
+		}
+		err = v.PublicParams.verifySignature(input.Owner, s.signatures[i], s.msg)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s is not signed by its owner: %s", inputKey, err)}
+		}
+		commitment, err := pointFromBytes(input.Commitment)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s has an invalid commitment: %s", inputKey, err)}
+		}
+		inputSum.Add(commitment)
+	}
+
+	if outputType != "" && outputType != inputType {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type mismatch in inputs and outputs for transaction with ID %s (%s vs %s)", txID, outputType, inputType)}
+	}
+
+	// the inputs minus the outputs minus the redeemed quantity must be a commitment to zero, that is, a power of H
+	difference := sub(inputSum, outputSum)
+	if s.redeemed > 0 {
+		difference.Sub(v.PublicParams.G.Mul(bigFromUint64(s.redeemed)))
+	}
+	err = v.PublicParams.verifyBalance(difference, s.balanceProof, s.msg)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token sum mismatch in inputs and outputs for transaction with ID %s: %s", txID, err)}
+	}
+	return nil
+}
+
+// checkOutputs checks that the outputs do not exist yet, are of a single type and carry valid range proofs.
+// It returns the type of the outputs and the sum of their commitments.
+func (v *Verifier) checkOutputs(outputs []*token.ZkOutput, txID string, simulator ledger.LedgerReader) (string, *FP256BN.ECP, error) {
+	tokenType := ""
+	sum := FP256BN.NewECP()
+	for i, output := range outputs {
+		err := v.checkOutputDoesNotExist(i, txID, simulator)
+		if err != nil {
+			return "", nil, err
+		}
+		if output.Type == "" {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("output %d has no token type in transaction: %s", i, txID)}
+		}
+		if tokenType == "" {
+			tokenType = output.Type
+		} else if tokenType != output.Type {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("multiple token types ('%s', '%s') in output for txID '%s'", tokenType, output.Type, txID)}
+		}
+		if _, err := pointFromBytes(output.Owner); err != nil {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("output %d has an invalid owner in transaction %s: %s", i, txID, err)}
+		}
+		if _, err := pointFromBytes(output.EphemeralKey); err != nil {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("output %d has an invalid ephemeral key in transaction %s: %s", i, txID, err)}
+		}
+		commitment, err := pointFromBytes(output.Commitment)
+		if err != nil {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("output %d has an invalid commitment in transaction %s: %s", i, txID, err)}
+		}
+		err = v.PublicParams.verifyRange(commitment, output.RangeProof)
+		if err != nil {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("output %d has an invalid range proof in transaction %s: %s", i, txID, err)}
+		}
+		sum.Add(commitment)
+	}
+	return tokenType, sum, nil
+}
+
+func (v *Verifier) checkOutputDoesNotExist(index int, txID string, simulator ledger.LedgerReader) error {
+	outputID, err := createOutputKey(txID, index)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID: %s", err)}
+	}
+	existingOutputBytes, err := simulator.GetState(tokenNameSpace, outputID)
+	if err != nil {
+		return err
+	}
+	if existingOutputBytes != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("output already exists: %s", outputID)}
+	}
+	return nil
+}
+
+func (v *Verifier) checkTxDoesNotExist(txID string, simulator ledger.LedgerReader) error {
+	txKey, err := createTxKey(txID)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating txID: %s", err)}
+	}
+	existingTx, err := simulator.GetState(tokenNameSpace, txKey)
+	if err != nil {
+		return err
+	}
+	if existingTx != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("transaction already exists: %s", txID)}
+	}
+	return nil
+}
+
+func (v *Verifier) commitAction(zkAction *token.ZkTokenAction, txID string, simulator ledger.LedgerWriter) error {
+	var outputs []*token.ZkOutput
+	var inputs []*token.InputId
+	switch action := zkAction.Data.(type) {
+	case *token.ZkTokenAction_ZkImport:
+		outputs = action.ZkImport.Outputs
+	case *token.ZkTokenAction_ZkTransfer:
+		outputs, inputs = action.ZkTransfer.Outputs, action.ZkTransfer.Inputs
+	case *token.ZkTokenAction_ZkRedeem:
+		outputs, inputs = action.ZkRedeem.Outputs, action.ZkRedeem.Inputs
+	}
+
+	for i, output := range outputs {
+		outputID, err := createOutputKey(txID, i)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID: %s", err)}
+		}
+		err = simulator.SetState(tokenNameSpace, outputID, utils.MarshalOrPanic(output))
+		if err != nil {
+			return err
+		}
+	}
+	for _, id := range inputs {
+		spentKey, err := createSpentKey(id.TxId, int(id.Index))
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating spent key: %s", err)}
+		}
+		verifierLogger.Debugf("marking input '%s' as spent", spentKey)
+		err = simulator.SetState(tokenNameSpace, spentKey, TokenInputSpentMarker)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *Verifier) addTransaction(txID string, ttx *token.TokenTransaction, simulator ledger.LedgerWriter) error {
+	ttxID, err := createTxKey(txID)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating txID: %s", err)}
+	}
+	return simulator.SetState(tokenNameSpace, ttxID, utils.MarshalOrPanic(ttx))
+}
+
+func (v *Verifier) getOutput(outputID string, simulator ledger.LedgerReader) (*token.ZkOutput, error) {
+	outputBytes, err := simulator.GetState(tokenNameSpace, outputID)
+	if err != nil {
+		return nil, err
+	}
+	if len(outputBytes) == 0 {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s does not exist", outputID)}
+	}
+	output := &token.ZkOutput{}
+	err = proto.Unmarshal(outputBytes, output)
+	if err != nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("unmarshaling error: %s", err)}
+	}
+	return output, nil
+}
+
+// isSpent checks whether the output with the given ID has been spent.
+func (v *Verifier) isSpent(id *token.InputId, simulator ledger.LedgerReader) (bool, error) {
+	spentKey, err := createSpentKey(id.TxId, int(id.Index))
+	if err != nil {
+		return false, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating spent key: %s", err)}
+	}
+	result, err := simulator.GetState(tokenNameSpace, spentKey)
+	return result != nil, err
+}
@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package zkat_test
+
+import (
+	"sort"
+	"testing"
+
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestZkat(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Zkat Suite")
+}
+
+// memoryLedger is an in-memory ledger that supports range scans.
+type memoryLedger struct {
+	entries map[string][]byte
+}
+
+func newMemoryLedger() *memoryLedger {
+	return &memoryLedger{entries: map[string][]byte{}}
+}
+
+func (m *memoryLedger) GetState(namespace string, key string) ([]byte, error) {
+	return m.entries[key], nil
+}
+
+func (m *memoryLedger) SetState(namespace string, key string, value []byte) error {
+	m.entries[key] = value
+	return nil
+}
+
+func (m *memoryLedger) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	var keys []string
+	for key := range m.entries {
+		if key >= startKey && (endKey == "" || key < endKey) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	it := &iterator{}
+	for _, key := range keys {
+		it.results = append(it.results, &queryresult.KV{Namespace: namespace, Key: key, Value: m.entries[key]})
+	}
+	return it, nil
+}
+
+func (m *memoryLedger) Done() {}
+
+type iterator struct {
+	results []*queryresult.KV
+}
+
+func (it *iterator) Next() (commonledger.QueryResult, error) {
+	if len(it.results) == 0 {
+		return nil, nil
+	}
+	next := it.results[0]
+	it.results = it.results[1:]
+	return next, nil
+}
+
+func (it *iterator) Close() {}
@@ -0,0 +1,360 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package zkat_test
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	mockid "github.com/hyperledger/fabric/token/identity/mock"
+	"github.com/hyperledger/fabric/token/tms/zkat"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+func outputKey(txID string, index string) string {
+	return "\x00" + "zkOutput" + "\x00" + txID + "\x00" + index + "\x00"
+}
+
+func spentKey(txID string, index string) string {
+	return "\x00" + "zkInput" + "\x00" + txID + "\x00" + index + "\x00"
+}
+
+var _ = Describe("Zkat", func() {
+	var (
+		pp                   *zkat.PublicParams
+		alice, bob           *zkat.KeyPair
+		fakePublicInfo       *mockid.PublicInfo
+		fakeIssuingValidator *mockid.IssuingValidator
+		ledger               *memoryLedger
+
+		issuer          *zkat.Issuer
+		verifier        *zkat.Verifier
+		transactor      *zkat.Transactor
+		aliceTransactor *zkat.OwnerTransactor
+		bobTransactor   *zkat.OwnerTransactor
+	)
+
+	// unspent returns the unspent tokens listed by the transactor of the prover peer
+	unspent := func() []*token.TokenOutput {
+		tokens, err := transactor.ListTokens()
+		Expect(err).NotTo(HaveOccurred())
+		return tokens.Tokens
+	}
+
+	// inputs returns the listed unspent tokens of the given ids
+	inputs := func(ids ...string) []*token.TokenOutput {
+		var tokens []*token.TokenOutput
+		for _, id := range ids {
+			for _, tok := range unspent() {
+				if string(tok.Id) == id {
+					tokens = append(tokens, tok)
+				}
+			}
+		}
+		return tokens
+	}
+
+	// quantities strips the serialized outputs of the tokens opened by an owner
+	quantities := func(tokens []*token.TokenOutput) []*token.TokenOutput {
+		var stripped []*token.TokenOutput
+		for _, tok := range tokens {
+			stripped = append(stripped, &token.TokenOutput{Id: tok.Id, Type: tok.Type, Quantity: tok.Quantity})
+		}
+		return stripped
+	}
+
+	BeforeEach(func() {
+		var err error
+		pp, err = zkat.NewPublicParams(16)
+		Expect(err).NotTo(HaveOccurred())
+		alice, err = zkat.NewKeyPair(pp)
+		Expect(err).NotTo(HaveOccurred())
+		bob, err = zkat.NewKeyPair(pp)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakePublicInfo = &mockid.PublicInfo{}
+		fakeIssuingValidator = &mockid.IssuingValidator{}
+		ledger = newMemoryLedger()
+
+		issuer = &zkat.Issuer{PublicParams: pp}
+		verifier = &zkat.Verifier{PublicParams: pp, IssuingValidator: fakeIssuingValidator}
+		transactor = &zkat.Transactor{Ledger: ledger}
+		aliceTransactor = &zkat.OwnerTransactor{PublicParams: pp, KeyPair: alice}
+		bobTransactor = &zkat.OwnerTransactor{PublicParams: pp, KeyPair: bob}
+
+		importTx, err := issuer.RequestImport([]*token.TokenToIssue{
+			{Recipient: alice.PublicKey(), Type: "USD", Quantity: 100},
+			{Recipient: alice.PublicKey(), Type: "USD", Quantity: 5},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		err = verifier.ProcessTx("0", fakePublicInfo, importTx, ledger)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("KeyPair", func() {
+		It("round trips through its secret key", func() {
+			kp, err := zkat.KeyPairFromBytes(pp, alice.Bytes())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(kp.PublicKey()).To(Equal(alice.PublicKey()))
+
+			_, err = zkat.KeyPairFromBytes(pp, []byte("short"))
+			Expect(err).To(MatchError("invalid owner secret key: invalid scalar length 5"))
+		})
+	})
+
+	Describe("NewPublicParams", func() {
+		It("rejects invalid bit lengths", func() {
+			_, err := zkat.NewPublicParams(65)
+			Expect(err).To(MatchError("invalid bit length 65, it must be between 1 and 64"))
+			Expect(zkat.DefaultPublicParams().BitLength).To(Equal(64))
+		})
+	})
+
+	Describe("Import", func() {
+		It("hides the quantities and the recipients", func() {
+			raw, _ := ledger.GetState("tms", outputKey("0", "0"))
+			output := &token.ZkOutput{}
+			Expect(proto.Unmarshal(raw, output)).To(Succeed())
+			Expect(output.Type).To(Equal("USD"))
+			Expect(output.Owner).NotTo(Equal(alice.PublicKey()))
+			Expect(output.Commitment).NotTo(BeEmpty())
+			Expect(output.RangeProof.BitCommitments).To(HaveLen(16))
+		})
+
+		It("evaluates the issuing policy for each output", func() {
+			Expect(fakeIssuingValidator.ValidateCallCount()).To(Equal(2))
+			_, tokenType := fakeIssuingValidator.ValidateArgsForCall(0)
+			Expect(tokenType).To(Equal("USD"))
+		})
+
+		It("rejects quantities out of range", func() {
+			_, err := issuer.RequestImport([]*token.TokenToIssue{{Recipient: alice.PublicKey(), Type: "USD", Quantity: 0}})
+			Expect(err).To(MatchError("failed creating output: quantity must be greater than 0"))
+			_, err = issuer.RequestImport([]*token.TokenToIssue{{Recipient: alice.PublicKey(), Type: "USD", Quantity: 1<<16 + 1}})
+			Expect(err).To(MatchError("failed creating output: quantity 65537 exceeds the maximum of 2^16"))
+		})
+
+		It("rejects invalid recipients", func() {
+			_, err := issuer.RequestImport([]*token.TokenToIssue{{Recipient: []byte("alice"), Type: "USD", Quantity: 1}})
+			Expect(err).To(MatchError("failed creating output: invalid recipient: invalid point length 5"))
+		})
+
+		Context("when the issuing policy is not satisfied", func() {
+			BeforeEach(func() {
+				fakeIssuingValidator.ValidateReturns(errors.New("no way"))
+			})
+
+			It("returns an InvalidTxError", func() {
+				importTx, err := issuer.RequestImport([]*token.TokenToIssue{{Recipient: bob.PublicKey(), Type: "USD", Quantity: 1}})
+				Expect(err).NotTo(HaveOccurred())
+				err = verifier.ProcessTx("1", fakePublicInfo, importTx, ledger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "import policy check failed: no way"}))
+			})
+		})
+
+		Context("when a commitment is tampered with", func() {
+			It("returns an InvalidTxError", func() {
+				importTx, err := issuer.RequestImport([]*token.TokenToIssue{{Recipient: bob.PublicKey(), Type: "USD", Quantity: 1}})
+				Expect(err).NotTo(HaveOccurred())
+				other, err := issuer.RequestImport([]*token.TokenToIssue{{Recipient: bob.PublicKey(), Type: "USD", Quantity: 2}})
+				Expect(err).NotTo(HaveOccurred())
+				importTx.GetZkAction().GetZkImport().Outputs[0].Commitment = other.GetZkAction().GetZkImport().Outputs[0].Commitment
+
+				err = verifier.ProcessTx("1", fakePublicInfo, importTx, ledger)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("output 0 has an invalid range proof in transaction 1"))
+			})
+		})
+
+		Context("when the transaction already exists", func() {
+			It("returns an InvalidTxError", func() {
+				importTx, err := issuer.RequestImport([]*token.TokenToIssue{{Recipient: bob.PublicKey(), Type: "USD", Quantity: 1}})
+				Expect(err).NotTo(HaveOccurred())
+				err = verifier.ProcessTx("0", fakePublicInfo, importTx, ledger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "output already exists: " + outputKey("0", "0")}))
+			})
+		})
+	})
+
+	Describe("ListTokens", func() {
+		It("lists the unspent outputs without their quantities", func() {
+			raw, _ := ledger.GetState("tms", outputKey("0", "0"))
+			tokens := unspent()
+			Expect(tokens).To(HaveLen(2))
+			Expect(tokens[0]).To(Equal(&token.TokenOutput{Id: []byte(outputKey("0", "0")), Type: "USD", ZkOutput: raw}))
+		})
+
+		It("lets the owner open its tokens only", func() {
+			Expect(quantities(aliceTransactor.Open(unspent()))).To(Equal([]*token.TokenOutput{
+				{Id: []byte(outputKey("0", "0")), Type: "USD", Quantity: 100},
+				{Id: []byte(outputKey("0", "1")), Type: "USD", Quantity: 5},
+			}))
+			Expect(bobTransactor.Open(unspent())).To(BeEmpty())
+		})
+	})
+
+	Describe("Transfer", func() {
+		var transferTx *token.TokenTransaction
+
+		BeforeEach(func() {
+			var err error
+			transferTx, err = aliceTransactor.RequestTransfer(inputs(outputKey("0", "0")), []*token.RecipientTransferShare{
+				{Recipient: bob.PublicKey(), Quantity: 70},
+				{Recipient: alice.PublicKey(), Quantity: 30},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("is processed successfully", func() {
+			err := verifier.ProcessTx("1", fakePublicInfo, transferTx, ledger)
+			Expect(err).NotTo(HaveOccurred())
+
+			spentMarker, _ := ledger.GetState("tms", spentKey("0", "0"))
+			Expect(spentMarker).To(Equal(zkat.TokenInputSpentMarker))
+
+			Expect(quantities(bobTransactor.Open(unspent()))).To(Equal([]*token.TokenOutput{
+				{Id: []byte(outputKey("1", "0")), Type: "USD", Quantity: 70},
+			}))
+			Expect(quantities(aliceTransactor.Open(unspent()))).To(Equal([]*token.TokenOutput{
+				{Id: []byte(outputKey("0", "1")), Type: "USD", Quantity: 5},
+				{Id: []byte(outputKey("1", "1")), Type: "USD", Quantity: 30},
+			}))
+		})
+
+		It("rejects double spending", func() {
+			err := verifier.ProcessTx("1", fakePublicInfo, transferTx, ledger)
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("2", fakePublicInfo, transferTx, ledger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "input with ID " + outputKey("0", "0") + " has already been spent"}))
+
+			Expect(inputs(outputKey("0", "0"))).To(BeEmpty())
+		})
+
+		It("rejects inputs not owned by the requestor", func() {
+			_, err := bobTransactor.RequestTransfer(inputs(outputKey("0", "0")), []*token.RecipientTransferShare{{Recipient: bob.PublicKey(), Quantity: 100}})
+			Expect(err).To(MatchError("the requestor does not own inputs"))
+		})
+
+		It("rejects shares that do not add up to the inputs", func() {
+			_, err := aliceTransactor.RequestTransfer(inputs(outputKey("0", "0")), []*token.RecipientTransferShare{{Recipient: bob.PublicKey(), Quantity: 101}})
+			Expect(err).To(MatchError("total quantity [100] from TokenIds does not match the quantity [101] of the shares"))
+		})
+
+		It("rejects shares whose sum overflows", func() {
+			_, err := aliceTransactor.RequestTransfer(inputs(outputKey("0", "0")), []*token.RecipientTransferShare{
+				{Recipient: bob.PublicKey(), Quantity: math.MaxUint64},
+				{Recipient: bob.PublicKey(), Quantity: 101},
+			})
+			Expect(err).To(MatchError(fmt.Sprintf("invalid shares: token sum overflows (%d + 101)", uint64(math.MaxUint64))))
+		})
+
+		It("rejects inputs of different types", func() {
+			importTx, err := issuer.RequestImport([]*token.TokenToIssue{{Recipient: alice.PublicKey(), Type: "EUR", Quantity: 5}})
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("2", fakePublicInfo, importTx, ledger)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = aliceTransactor.RequestTransfer(inputs(outputKey("0", "0"), outputKey("2", "0")), []*token.RecipientTransferShare{{Recipient: bob.PublicKey(), Quantity: 105}})
+			Expect(err).To(MatchError("two or more token types specified in input: 'USD', 'EUR'"))
+		})
+
+		Context("when an output is replaced", func() {
+			It("returns an InvalidTxError", func() {
+				other, err := issuer.RequestImport([]*token.TokenToIssue{{Recipient: bob.PublicKey(), Type: "USD", Quantity: 100}})
+				Expect(err).NotTo(HaveOccurred())
+				transferTx.GetZkAction().GetZkTransfer().Outputs[0] = other.GetZkAction().GetZkImport().Outputs[0]
+
+				err = verifier.ProcessTx("1", fakePublicInfo, transferTx, ledger)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("is not signed by its owner"))
+			})
+		})
+
+		Context("when the balance proof is tampered with", func() {
+			It("returns an InvalidTxError", func() {
+				proof := transferTx.GetZkAction().GetZkTransfer().BalanceProof
+				proof.Challenge, proof.Response = proof.Response, proof.Challenge
+
+				err := verifier.ProcessTx("1", fakePublicInfo, transferTx, ledger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token sum mismatch in inputs and outputs for transaction with ID 1: invalid balance proof"}))
+			})
+		})
+
+		Context("when an input signature is missing", func() {
+			It("returns an InvalidTxError", func() {
+				transferTx.GetZkAction().GetZkTransfer().InputSignatures = nil
+
+				err := verifier.ProcessTx("1", fakePublicInfo, transferTx, ledger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "transaction 1 has 1 inputs but 0 input signatures"}))
+			})
+		})
+	})
+
+	Describe("Redeem", func() {
+		It("redeems part of the tokens and returns the rest to the owner", func() {
+			redeemTx, err := aliceTransactor.RequestRedeem(inputs(outputKey("0", "0")), 40)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(redeemTx.GetZkAction().GetZkRedeem().Quantity).To(Equal(uint64(40)))
+
+			err = verifier.ProcessTx("1", fakePublicInfo, redeemTx, ledger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(quantities(aliceTransactor.Open(unspent()))).To(ContainElement(&token.TokenOutput{Id: []byte(outputKey("1", "0")), Type: "USD", Quantity: 60}))
+		})
+
+		It("redeems all of the tokens", func() {
+			redeemTx, err := aliceTransactor.RequestRedeem(inputs(outputKey("0", "1")), 5)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(redeemTx.GetZkAction().GetZkRedeem().Outputs).To(BeEmpty())
+
+			err = verifier.ProcessTx("1", fakePublicInfo, redeemTx, ledger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects a redeemed quantity that does not match the proof", func() {
+			redeemTx, err := aliceTransactor.RequestRedeem(inputs(outputKey("0", "0")), 40)
+			Expect(err).NotTo(HaveOccurred())
+			redeemTx.GetZkAction().GetZkRedeem().Quantity = 10
+
+			err = verifier.ProcessTx("1", fakePublicInfo, redeemTx, ledger)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is not signed by its owner"))
+		})
+
+		It("rejects redeeming more than the inputs", func() {
+			_, err := aliceTransactor.RequestRedeem(inputs(outputKey("0", "1")), 6)
+			Expect(err).To(MatchError("total quantity [5] from TokenIds is less than quantity [6] to be redeemed"))
+		})
+	})
+
+	Describe("Unsupported requests", func() {
+		It("returns an error", func() {
+			_, err := transactor.RequestTransfer(&token.TransferRequest{})
+			Expect(err).To(MatchError("transfers of the zkat driver are created by the owner of the inputs"))
+			_, err = transactor.RequestRedeem(&token.RedeemRequest{})
+			Expect(err).To(MatchError("redemptions of the zkat driver are created by the owner of the inputs"))
+			_, err = transactor.RequestApprove(&token.ApproveRequest{})
+			Expect(err).To(MatchError("approve is not supported by the zkat driver"))
+			_, err = transactor.RequestTransferFrom(&token.TransferRequest{})
+			Expect(err).To(MatchError("transfer from is not supported by the zkat driver"))
+			_, err = issuer.RequestExpectation(&token.ExpectationRequest{})
+			Expect(err).To(MatchError("expectations are not supported by the zkat driver"))
+		})
+	})
+
+	Describe("ProcessTx with a plain transaction", func() {
+		It("returns an InvalidTxError", func() {
+			err := verifier.ProcessTx("1", fakePublicInfo, &token.TokenTransaction{}, ledger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "check process failed for transaction '1': missing token action"}))
+		})
+	})
+})
@@ -17,13 +17,19 @@ import (
 
 var logger = flogging.MustGetLogger("fabtoken-processor")
 
-// Processor implements the interface 'github.com/hyperledger/fabric/core/ledger/customtx/Processor'
+// Processor implements the interface 'github.com/hyperledger/fabric/core/ledger/customtx/TimedProcessor'
 // for FabToken transactions
 type Processor struct {
 	TMSManager TMSManager
 }
 
 func (p *Processor) GenerateSimulationResults(txEnv *common.Envelope, simulator ledger.TxSimulator, initializingLedger bool) error {
+	return p.GenerateSimulationResultsAt(txEnv, 0, simulator, initializingLedger)
+}
+
+// GenerateSimulationResultsAt is like GenerateSimulationResults, for a transaction committed in the block
+// of number blockNum; the block number is used to enforce the deadlines of locks, and is 0 if unknown.
+func (p *Processor) GenerateSimulationResultsAt(txEnv *common.Envelope, blockNum uint64, simulator ledger.TxSimulator, initializingLedger bool) error {
 	// Extract channel header and token transaction
 	ch, ttx, ci, err := UnmarshalTokenTransaction(txEnv.Payload)
 	if err != nil {
@@ -37,7 +43,11 @@ func (p *Processor) GenerateSimulationResults(txEnv *common.Envelope, simulator
 	}
 
 	// Extract the read dependencies and ledger updates associated to the transaction using simulator
-	err = txProcessor.ProcessTx(ch.TxId, ci, ttx, simulator)
+	if timedTxProcessor, ok := txProcessor.(TimedTMSTxProcessor); ok {
+		err = timedTxProcessor.ProcessTxAt(ch.TxId, ci, ttx, blockNum, simulator)
+	} else {
+		err = txProcessor.ProcessTx(ch.TxId, ci, ttx, simulator)
+	}
 	if err != nil {
 		return errors.WithMessage(err, fmt.Sprintf("failed committing transaction for channel %s", ch.ChannelId))
 	}
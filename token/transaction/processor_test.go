@@ -127,6 +127,65 @@ var _ = Describe("Processor", func() {
 				Expect(simulator).To(BeNil())
 			})
 		})
+
+		Context("when the channel TxProcessor takes into account the block number of transactions", func() {
+			var (
+				verifier *mock.TimedTMSTxProcessor
+			)
+			BeforeEach(func() {
+				verifier = &mock.TimedTMSTxProcessor{}
+				verifier.ProcessTxAtReturns(nil)
+				fakeManager.GetTxProcessorReturns(verifier, nil)
+			})
+			It("passes no block number", func() {
+				err := txProcessor.GenerateSimulationResults(validEnvelope, nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(verifier.ProcessTxCallCount()).To(Equal(0))
+				Expect(verifier.ProcessTxAtCallCount()).To(Equal(1))
+				_, _, _, blockNum, _ := verifier.ProcessTxAtArgsForCall(0)
+				Expect(blockNum).To(BeZero())
+			})
+		})
+	})
+
+	Describe("GenerateSimulationResultsAt", func() {
+		Context("when the channel TxProcessor takes into account the block number of transactions", func() {
+			var (
+				verifier *mock.TimedTMSTxProcessor
+			)
+			BeforeEach(func() {
+				verifier = &mock.TimedTMSTxProcessor{}
+				verifier.ProcessTxAtReturns(nil)
+				fakeManager.GetTxProcessorReturns(verifier, nil)
+			})
+			It("passes the block number", func() {
+				err := txProcessor.GenerateSimulationResultsAt(validEnvelope, 20, nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(verifier.ProcessTxCallCount()).To(Equal(0))
+				Expect(verifier.ProcessTxAtCallCount()).To(Equal(1))
+				txID, _, ttx, blockNum, simulator := verifier.ProcessTxAtArgsForCall(0)
+				Expect(txID).To(Equal("tx0"))
+				Expect(proto.Equal(ttx, validTtx)).To(BeTrue())
+				Expect(blockNum).To(Equal(uint64(20)))
+				Expect(simulator).To(BeNil())
+			})
+		})
+
+		Context("when the channel TxProcessor does not take into account the block number of transactions", func() {
+			var (
+				verifier *mock.TMSTxProcessor
+			)
+			BeforeEach(func() {
+				verifier = &mock.TMSTxProcessor{}
+				verifier.ProcessTxReturns(nil)
+				fakeManager.GetTxProcessorReturns(verifier, nil)
+			})
+			It("processes the transaction without the block number", func() {
+				err := txProcessor.GenerateSimulationResultsAt(validEnvelope, 20, nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(verifier.ProcessTxCallCount()).To(Equal(1))
+			})
+		})
 	})
 
 })
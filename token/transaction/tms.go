@@ -13,6 +13,7 @@ import (
 )
 
 //go:generate counterfeiter -o mock/tms_tx_processor.go -fake-name TMSTxProcessor . TMSTxProcessor
+//go:generate counterfeiter -o mock/timed_tms_tx_processor.go -fake-name TimedTMSTxProcessor . TimedTMSTxProcessor
 //go:generate counterfeiter -o mock/tms_manager.go -fake-name TMSManager . TMSManager
 
 // TMSTxProcessor is used to generate the read-dependencies of a token transaction
@@ -24,6 +25,16 @@ type TMSTxProcessor interface {
 	ProcessTx(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, simulator ledger.LedgerWriter) error
 }
 
+// TimedTMSTxProcessor is a TMSTxProcessor that also takes into account the number
+// of the block a token transaction is committed in, for instance to enforce time locks
+type TimedTMSTxProcessor interface {
+	TMSTxProcessor
+
+	// ProcessTxAt parses ttx, committed in the block of number blockNum, to generate a RW set;
+	// blockNum is 0 if the block number is unknown, as the genesis block carries no token transaction
+	ProcessTxAt(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, blockNum uint64, simulator ledger.LedgerWriter) error
+}
+
 type TMSManager interface {
 	// GetTxProcessor returns a TxProcessor for TMS transactions for the provided channel
 	GetTxProcessor(channel string) (TMSTxProcessor, error)